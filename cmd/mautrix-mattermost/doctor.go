@@ -0,0 +1,25 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector"
+)
+
+// runDoctor runs the preflight checks on the initialized but not started
+// bridge, prints the report and returns the process exit code.
+func runDoctor() int {
+	ctx := m.Log.WithContext(context.Background())
+	report := m.Connector.(*connector.MattermostConnector).Doctor(ctx)
+	report.Print(os.Stdout)
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
@@ -10,7 +10,10 @@
 package main
 
 import (
+	"os"
+
 	"github.com/aiku/mautrix-mattermost/pkg/connector"
+	flag "maunium.net/go/mauflag"
 	"maunium.net/go/mautrix/bridgev2/matrix/mxmain"
 )
 
@@ -27,10 +30,21 @@ var m = mxmain.BridgeMain{
 	Description: "A Matrix-Mattermost puppeting bridge",
 	Version:     "0.1.0",
 
+	AdditionalLongFlags: " [doctor]",
+
 	Connector: &connector.MattermostConnector{},
 }
 
 func main() {
 	m.InitVersion(Tag, Commit, BuildTime)
-	m.Run()
+	m.PreInit()
+	m.Connector.(*connector.MattermostConnector).ConfigPath = m.ConfigPath
+	m.Init()
+	if args := flag.Args(); len(args) > 0 && args[0] == "doctor" {
+		os.Exit(runDoctor())
+	}
+	m.Start()
+	exitCode := m.WaitForInterrupt()
+	m.Stop()
+	os.Exit(exitCode)
 }
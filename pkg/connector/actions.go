@@ -0,0 +1,216 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/commands"
+)
+
+// postActions returns the interactive actions (buttons and menus) of a
+// post's message attachments, in the order they're numbered in Matrix.
+func postActions(attachments []*model.SlackAttachment) []*model.PostAction {
+	var actions []*model.PostAction
+	for _, attachment := range attachments {
+		if attachment == nil {
+			continue
+		}
+		for _, action := range attachment.Actions {
+			if action != nil && action.Id != "" {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+// isActionMenu reports whether an action is a select menu rather than a
+// button.
+func isActionMenu(action *model.PostAction) bool {
+	return action.Type == model.PostActionTypeSelect
+}
+
+// actionChoices describes what can be picked from a menu.
+func actionChoices(action *model.PostAction) string {
+	switch action.DataSource {
+	case model.PostActionDataSourceUsers:
+		return "a username"
+	case model.PostActionDataSourceChannels:
+		return "a channel name"
+	}
+	texts := make([]string, 0, len(action.Options))
+	for _, option := range action.Options {
+		if option != nil {
+			texts = append(texts, option.Text)
+		}
+	}
+	return strings.Join(texts, ", ")
+}
+
+// renderActions renders the numbered list of a post's buttons and menus and
+// the command that uses them, returning the plain text and the HTML. The
+// command is prefixed with commandPrefix, the bridge's command prefix.
+func renderActions(actions []*model.PostAction, commandPrefix string) (string, string) {
+	if len(actions) == 0 {
+		return "", ""
+	}
+	command := strings.TrimSpace(commandPrefix + " action")
+	hasMenu := false
+	var plain, rich strings.Builder
+	rich.WriteString("<ol>")
+	for i, action := range actions {
+		line := action.Name
+		if isActionMenu(action) {
+			hasMenu = true
+			if choices := actionChoices(action); choices != "" {
+				line += " (" + choices + ")"
+			}
+		}
+		if action.Disabled {
+			line += " (disabled)"
+		}
+		fmt.Fprintf(&plain, "\n%d. %s", i+1, line)
+		rich.WriteString("<li>" + html.EscapeString(line) + "</li>")
+	}
+	rich.WriteString("</ol>")
+
+	hint := "Reply with `" + command + " <number>` to use an action"
+	if hasMenu {
+		hint += ", or `" + command + " <number> <choice>` for a menu"
+	}
+	richHint := "Reply with <code>" + html.EscapeString(command+" <number>") + "</code> to use an action"
+	if hasMenu {
+		richHint += ", or <code>" + html.EscapeString(command+" <number> <choice>") + "</code> for a menu"
+	}
+	return hint + ":" + plain.String(), "<p>" + richHint + ":</p>" + rich.String()
+}
+
+// commandPrefix returns the bridge's command prefix, or "" without a bridge
+// config.
+func (mc *MattermostConnector) commandPrefix() string {
+	if mc.Bridge == nil || mc.Bridge.Config == nil {
+		return ""
+	}
+	return mc.Bridge.Config.CommandPrefix
+}
+
+var errUnknownChoice = errors.New("unknown choice")
+
+// actionSelection returns the value to send for choice in a menu: an option
+// value for static menus, a user ID for user menus and a channel ID for
+// channel menus. Options match by number, text or value.
+func (m *MattermostClient) actionSelection(ctx context.Context, action *model.PostAction, teamID, choice string) (string, error) {
+	switch action.DataSource {
+	case model.PostActionDataSourceUsers:
+		user, _, err := m.client.GetUserByUsername(ctx, strings.TrimPrefix(choice, "@"), "")
+		if err != nil {
+			return "", fmt.Errorf("%w: failed to get user: %w", errUnknownChoice, err)
+		}
+		return user.Id, nil
+	case model.PostActionDataSourceChannels:
+		channelID := m.channelIDByName(ctx, teamID, strings.TrimPrefix(choice, "~"))
+		if channelID == "" {
+			return "", errUnknownChoice
+		}
+		return channelID, nil
+	}
+	if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(action.Options) && action.Options[n-1] != nil {
+		return action.Options[n-1].Value, nil
+	}
+	for _, option := range action.Options {
+		if option != nil && (strings.EqualFold(option.Text, choice) || option.Value == choice) {
+			return option.Value, nil
+		}
+	}
+	return "", errUnknownChoice
+}
+
+// fnAction triggers a button or menu of a bridged Mattermost post, replied to
+// by the command, with the portal's relay login.
+func (mc *MattermostConnector) fnAction(ce *commands.Event) {
+	if ce.ReplyTo == "" || len(ce.Args) == 0 {
+		ce.Reply("Usage: reply to a message with actions with `$cmdprefix action <number> [choice]`")
+		return
+	}
+	number, err := strconv.Atoi(ce.Args[0])
+	if err != nil || number < 1 {
+		ce.Reply("`%s` isn't an action number.", ce.Args[0])
+		return
+	}
+	if ce.Portal.Relay == nil {
+		ce.Reply("This room has no relay to use actions with.")
+		return
+	}
+	client, ok := ce.Portal.Relay.Client.(*MattermostClient)
+	if !ok || !client.IsLoggedIn() {
+		ce.Reply("The relay isn't logged in to Mattermost.")
+		return
+	}
+	part, err := mc.Bridge.DB.Message.GetPartByMXID(ce.Ctx, ce.ReplyTo)
+	if err != nil {
+		ce.Log.Err(err).Msg("Failed to get replied-to message")
+		ce.Reply("Failed to get the message you replied to.")
+		return
+	}
+	if part == nil || part.Room != ce.Portal.PortalKey {
+		ce.Reply("The message you replied to isn't bridged from Mattermost.")
+		return
+	}
+
+	postID := ParseMessageID(part.ID)
+	post, _, err := client.client.GetPost(ce.Ctx, postID, "")
+	if err != nil {
+		ce.Log.Err(err).Str("post_id", postID).Msg("Failed to get post for action")
+		ce.Reply("Failed to get the post from Mattermost.")
+		return
+	}
+	actions := postActions(post.Attachments())
+	if number > len(actions) {
+		ce.Reply("The message has no action %d.", number)
+		return
+	}
+	action := actions[number-1]
+	if action.Disabled {
+		ce.Reply("**%s** is disabled.", action.Name)
+		return
+	}
+
+	var selected string
+	if isActionMenu(action) {
+		choice := strings.Join(ce.Args[1:], " ")
+		if choice == "" {
+			ce.Reply("**%s** is a menu: add your choice, one of %s.", action.Name, actionChoices(action))
+			return
+		}
+		selected, err = client.actionSelection(ce.Ctx, action, client.portalTeam(ce.Portal), choice)
+		if err != nil {
+			ce.Log.Debug().Err(err).Str("post_id", postID).Msg("Unknown action menu choice")
+			ce.Reply("`%s` isn't a choice of **%s**.", choice, action.Name)
+			return
+		}
+	}
+
+	if _, err = client.client.DoPostActionWithCookie(ce.Ctx, postID, action.Id, selected, action.Cookie); err != nil {
+		ce.Log.Err(err).
+			Str("post_id", postID).
+			Str("action_id", action.Id).
+			Msg("Failed to trigger post action")
+		ce.Reply("Mattermost rejected **%s**.", action.Name)
+		return
+	}
+	ce.Log.Info().
+		Str("post_id", postID).
+		Str("action_id", action.Id).
+		Msg("Post action triggered")
+	ce.Reply("Triggered **%s**.", action.Name)
+}
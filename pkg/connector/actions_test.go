@@ -0,0 +1,250 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/commands"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// fakeReplyBot is a bridge bot that records the bodies of sent messages.
+// Other methods are unimplemented and panic if called.
+type fakeReplyBot struct {
+	bridgev2.MatrixAPI
+	mu      sync.Mutex
+	replies []string
+}
+
+func (b *fakeReplyBot) SendMessage(_ context.Context, _ id.RoomID, _ event.Type, content *event.Content, _ *bridgev2.MatrixSendExtra) (*mautrix.RespSendEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.replies = append(b.replies, content.AsMessage().Body)
+	return &mautrix.RespSendEvent{}, nil
+}
+
+func (b *fakeReplyBot) GetMXID() id.UserID { return "@bot:example.com" }
+
+func (b *fakeReplyBot) lastReply() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.replies) == 0 {
+		return ""
+	}
+	return b.replies[len(b.replies)-1]
+}
+
+// testActionAttachments has a button, a disabled button, a static menu and
+// a user menu, numbered 1 to 4.
+func testActionAttachments() []*model.SlackAttachment {
+	return []*model.SlackAttachment{
+		{Text: "Deploy?", Actions: []*model.PostAction{
+			{Id: "approve", Type: model.PostActionTypeButton, Name: "Approve", Cookie: "cookie-1"},
+			{Id: "reject", Type: model.PostActionTypeButton, Name: "Reject", Disabled: true},
+		}},
+		nil,
+		{Actions: []*model.PostAction{
+			nil,
+			{Id: "", Name: "no id"},
+			{Id: "env", Type: model.PostActionTypeSelect, Name: "Environment", Options: []*model.PostActionOptions{
+				{Text: "Staging", Value: "stg"},
+				{Text: "Production", Value: "prod"},
+			}},
+			{Id: "owner", Type: model.PostActionTypeSelect, Name: "Owner", DataSource: model.PostActionDataSourceUsers},
+		}},
+	}
+}
+
+func TestPostActions(t *testing.T) {
+	t.Parallel()
+	var ids []string
+	for _, action := range postActions(testActionAttachments()) {
+		ids = append(ids, action.Id)
+	}
+	if got := strings.Join(ids, ","); got != "approve,reject,env,owner" {
+		t.Errorf("actions: got %s", got)
+	}
+}
+
+func TestRenderActions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		actions  []*model.PostAction
+		prefix   string
+		wantBody string
+		wantHTML string
+	}{
+		{
+			name:    "none",
+			actions: nil,
+		},
+		{
+			name:     "buttons",
+			actions:  []*model.PostAction{{Id: "a", Name: "Approve"}, {Id: "b", Name: "<Reject>", Disabled: true}},
+			prefix:   "!mm",
+			wantBody: "Reply with `!mm action <number>` to use an action:\n1. Approve\n2. <Reject> (disabled)",
+			wantHTML: "<p>Reply with <code>!mm action &lt;number&gt;</code> to use an action:</p>" +
+				"<ol><li>Approve</li><li>&lt;Reject&gt; (disabled)</li></ol>",
+		},
+		{
+			name:    "menus",
+			actions: postActions(testActionAttachments())[2:],
+			wantBody: "Reply with `action <number>` to use an action, or `action <number> <choice>` for a menu:\n" +
+				"1. Environment (Staging, Production)\n2. Owner (a username)",
+			wantHTML: "<p>Reply with <code>action &lt;number&gt;</code> to use an action, or " +
+				"<code>action &lt;number&gt; &lt;choice&gt;</code> for a menu:</p>" +
+				"<ol><li>Environment (Staging, Production)</li><li>Owner (a username)</li></ol>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body, formatted := renderActions(tt.actions, tt.prefix)
+			if body != tt.wantBody {
+				t.Errorf("body:\ngot  %q\nwant %q", body, tt.wantBody)
+			}
+			if formatted != tt.wantHTML {
+				t.Errorf("html:\ngot  %q\nwant %q", formatted, tt.wantHTML)
+			}
+		})
+	}
+}
+
+func TestConvertPostToMatrix_ActionsOnly(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	post := &model.Post{Id: "p1", ChannelId: "ch1"}
+	post.AddProp(model.PostPropsAttachments, []*model.SlackAttachment{
+		{Actions: []*model.PostAction{{Id: "a", Type: model.PostActionTypeButton, Name: "Approve"}}},
+	})
+
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
+	if len(msg.Parts) != 1 {
+		t.Fatalf("expected 1 part, got %d", len(msg.Parts))
+	}
+	if body := msg.Parts[0].Content.Body; body != "Reply with `action <number>` to use an action:\n1. Approve" {
+		t.Errorf("body: got %q", body)
+	}
+}
+
+// newActionTestConnector returns a connector whose relayTestChannel portal
+// has the relay login as relay and a bridged post p1 with
+// testActionAttachments, sent as $p1:example.com.
+func newActionTestConnector(t *testing.T) (*MattermostConnector, *fakeMM, *bridgev2.Portal) {
+	t.Helper()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {TeamID: "team1"}})
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	post := &model.Post{Id: "p1", ChannelId: relayTestChannel}
+	post.AddProp(model.PostPropsAttachments, testActionAttachments())
+	list := model.NewPostList()
+	list.AddPost(post)
+	list.AddOrder(post.Id)
+	fake.Posts[relayTestChannel] = list
+	fake.Users["alice-id"] = &model.User{Id: "alice-id", Username: "alice"}
+
+	login, err := mc.Bridge.GetExistingUserLoginByID(ctx, MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	client := login.Client.(*MattermostClient)
+	client.client = model.NewAPIv4Client(fake.Server.URL)
+	client.client.SetToken("relay-token")
+
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	if err := portal.SetRelay(ctx, login); err != nil {
+		t.Fatalf("set relay: %v", err)
+	}
+	if err := mc.Bridge.DB.Message.Insert(ctx, &database.Message{
+		ID:        MakeMessageID("p1"),
+		MXID:      "$p1:example.com",
+		Room:      portal.PortalKey,
+		SenderID:  MakeUserID("bot-id"),
+		Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	return mc, fake, portal
+}
+
+func TestFnAction(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		replyTo   id.EventID
+		args      []string
+		noRelay   bool
+		wantPath  string
+		wantBody  string
+		wantReply string
+	}{
+		{"button", "$p1:example.com", []string{"1"}, false, "/api/v4/posts/p1/actions/approve", `"cookie":"cookie-1"`, "Triggered **Approve**."},
+		{"menu by number", "$p1:example.com", []string{"3", "2"}, false, "/api/v4/posts/p1/actions/env", `"selected_option":"prod"`, "Triggered **Environment**."},
+		{"menu by text", "$p1:example.com", []string{"3", "staging"}, false, "/api/v4/posts/p1/actions/env", `"selected_option":"stg"`, "Triggered **Environment**."},
+		{"user menu", "$p1:example.com", []string{"4", "@alice"}, false, "/api/v4/posts/p1/actions/owner", `"selected_option":"alice-id"`, "Triggered **Owner**."},
+		{"unknown user", "$p1:example.com", []string{"4", "bob"}, false, "", "", "`bob` isn't a choice of **Owner**."},
+		{"missing choice", "$p1:example.com", []string{"3"}, false, "", "", "**Environment** is a menu: add your choice, one of Staging, Production."},
+		{"disabled", "$p1:example.com", []string{"2"}, false, "", "", "**Reject** is disabled."},
+		{"unknown number", "$p1:example.com", []string{"5"}, false, "", "", "The message has no action 5."},
+		{"not a number", "$p1:example.com", []string{"one"}, false, "", "", "`one` isn't an action number."},
+		{"not a reply", "", []string{"1"}, false, "", "", "Usage: reply to a message with actions with `!bridge action <number> [choice]`"},
+		{"unbridged message", "$other:example.com", []string{"1"}, false, "", "", "The message you replied to isn't bridged from Mattermost."},
+		{"no relay", "$p1:example.com", []string{"1"}, true, "", "", "This room has no relay to use actions with."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, fake, portal := newActionTestConnector(t)
+			if tt.noRelay {
+				portal.Relay = nil
+			}
+			bot := &fakeReplyBot{}
+			log := zerolog.Nop()
+			mc.fnAction(&commands.Event{
+				Bot:     bot,
+				Bridge:  mc.Bridge,
+				Portal:  portal,
+				ReplyTo: tt.replyTo,
+				Args:    tt.args,
+				Ctx:     context.Background(),
+				Log:     &log,
+			})
+
+			if got := bot.lastReply(); got != tt.wantReply {
+				t.Errorf("reply: got %q, want %q", got, tt.wantReply)
+			}
+			var actionCalls []endpointCall
+			for _, call := range fake.Calls() {
+				if strings.Contains(call.Path, "/actions/") {
+					actionCalls = append(actionCalls, call)
+				}
+			}
+			if tt.wantPath == "" {
+				if len(actionCalls) != 0 {
+					t.Errorf("no action should be triggered, got %+v", actionCalls)
+				}
+				return
+			}
+			if len(actionCalls) != 1 || actionCalls[0].Path != tt.wantPath || !strings.Contains(actionCalls[0].Body, tt.wantBody) {
+				t.Errorf("action calls: got %+v, want %s with %s", actionCalls, tt.wantPath, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	rpprof "runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// defaultAdminAPIAddr is the admin API listen address used when neither the
+// config file nor BRIDGE_API_ADDR set one.
+const defaultAdminAPIAddr = ":29320"
+
+// debugWriteTimeout bounds how long a debug response may take to write.
+// It is longer than the admin server's WriteTimeout so CPU profiles and
+// traces (30s by default) can complete.
+const debugWriteTimeout = 2 * time.Minute
+
+// requestIDHeader carries the correlation ID of an admin API request. Callers
+// may set it to tie the bridge's logs to their own; it is always echoed in
+// the response.
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds caller-provided request IDs.
+const maxRequestIDLength = 128
+
+// adminAPIAddr resolves the admin API listen address: config first, then the
+// BRIDGE_API_ADDR environment variable, then the default.
+func (mc *MattermostConnector) adminAPIAddr() string {
+	if addr := mc.Config.AdminAPIAddr; addr != "" {
+		return addr
+	}
+	if addr := os.Getenv("BRIDGE_API_ADDR"); addr != "" {
+		return addr
+	}
+	return defaultAdminAPIAddr
+}
+
+// adminAPIToken resolves the bearer token guarding the admin API: config
+// first, then the BRIDGE_API_TOKEN environment variable.
+func (mc *MattermostConnector) adminAPIToken() string {
+	if token := mc.Config.AdminAPIToken; token != "" {
+		return token
+	}
+	return os.Getenv("BRIDGE_API_TOKEN")
+}
+
+// adminAPIHandler builds the admin API mux. When a token is configured, every
+// endpoint requires it. The /debug/ endpoints expose process internals and
+// are only registered when a token is configured.
+func (mc *MattermostConnector) adminAPIHandler() http.Handler {
+	token := mc.adminAPIToken()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/reload-puppets", mc.HandleReloadPuppets)
+	mux.HandleFunc("/api/reload-config", mc.HandleReloadConfig)
+	mux.HandleFunc("/api/double-puppet", mc.HandleDoublePuppet)
+	mux.HandleFunc("/api/puppets", mc.HandleListPuppets)
+	mux.HandleFunc("/api/puppets/{mxid}", mc.HandleGetPuppet)
+	mux.HandleFunc("/api/provision-puppet", mc.HandleProvisionPuppet)
+	mux.HandleFunc("/api/relay", mc.HandleRelay)
+	mux.HandleFunc("/api/channel-filter", mc.HandleChannelFilter)
+	mux.HandleFunc("/api/portals/provision", mc.HandleProvisionPortals)
+	mux.HandleFunc("/api/portals/watch", mc.HandlePortalWatch)
+	mux.HandleFunc("/api/portals/{channel_id}", mc.HandleDeletePortal)
+	mux.HandleFunc("/api/resync", mc.HandleResync)
+	mux.HandleFunc("/api/map", mc.HandleMessageMap)
+	mux.HandleFunc("/api/health", mc.HandleHealth)
+	mux.HandleFunc("/api/debug/echo-drops", mc.HandleEchoDrops)
+	mux.HandleFunc("/metrics", mc.HandleMetrics)
+
+	// The OAuth 2.0 callback is opened by users' browsers, which don't have
+	// the admin token. The login's one-time state authenticates it instead,
+	// so it's served next to the token check rather than behind it.
+	root := http.NewServeMux()
+	root.HandleFunc(oauth2CallbackPath, mc.HandleOAuth2Callback)
+	if token == "" {
+		root.Handle("/", mux)
+		return mc.withRequestID(root)
+	}
+
+	mux.Handle("/debug/pprof/", mc.debugHandler(http.HandlerFunc(pprof.Index)))
+	mux.Handle("/debug/pprof/cmdline", mc.debugHandler(http.HandlerFunc(pprof.Cmdline)))
+	mux.Handle("/debug/pprof/profile", mc.debugHandler(http.HandlerFunc(pprof.Profile)))
+	mux.Handle("/debug/pprof/symbol", mc.debugHandler(http.HandlerFunc(pprof.Symbol)))
+	mux.Handle("/debug/pprof/trace", mc.debugHandler(http.HandlerFunc(pprof.Trace)))
+	mux.Handle("/debug/dump", mc.debugHandler(http.HandlerFunc(mc.HandleDebugDump)))
+	if mc.Config.AdminAPIFixtures {
+		mc.registerFixtureRoutes(mux)
+	}
+
+	root.Handle("/", mc.requireAdminToken(token, mux))
+	return mc.withRequestID(root)
+}
+
+// withRequestID tags each request with a correlation ID, taken from the
+// X-Request-ID header if the caller sent a valid one and generated
+// otherwise. The ID is returned in the response header, and the request
+// context carries a logger that includes it, so everything logged while
+// handling the request can be traced back to it.
+func (mc *MattermostConnector) withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		log := mc.Bridge.Log.With().Str("request_id", requestID).Logger()
+		next.ServeHTTP(w, r.WithContext(log.WithContext(r.Context())))
+	})
+}
+
+// validRequestID reports whether a caller-provided request ID can be used
+// as-is. Only short IDs made of letters, digits and ._:- are accepted, so
+// callers can't inject arbitrary text into logs.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '.', c == '_', c == ':', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit request ID in hex.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ctxLog returns the logger carried by ctx, such as an admin API request's
+// logger with its request ID, or the bridge logger if ctx has none.
+func (mc *MattermostConnector) ctxLog(ctx context.Context) *zerolog.Logger {
+	if log := zerolog.Ctx(ctx); log.GetLevel() != zerolog.Disabled {
+		return log
+	}
+	return &mc.Bridge.Log
+}
+
+// requireAdminToken rejects requests that don't carry the admin token as a
+// bearer token. Comparison is constant-time.
+func (mc *MattermostConnector) requireAdminToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			mc.ctxLog(r.Context()).Warn().
+				Str("remote_addr", r.RemoteAddr).
+				Str("path", r.URL.Path).
+				Msg("Rejected unauthenticated admin API request")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// debugHandler logs access to a debug endpoint and lifts the server write
+// deadline so long-running profiles aren't cut off.
+func (mc *MattermostConnector) debugHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mc.ctxLog(r.Context()).Info().
+			Str("remote_addr", r.RemoteAddr).
+			Str("path", r.URL.Path).
+			Str("query", r.URL.RawQuery).
+			Msg("Debug endpoint requested")
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(debugWriteTimeout))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleDebugDump is an HTTP handler for POST /debug/dump. It writes a
+// full goroutine dump (kind=goroutine, the default) or a heap profile
+// (kind=heap) in text form, for diagnosing hangs such as WebSocket deadlocks
+// without attaching a profiler.
+func (mc *MattermostConnector) HandleDebugDump(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	kind := r.URL.Query().Get("kind")
+	if kind == "" {
+		kind = "goroutine"
+	}
+	var debug int
+	switch kind {
+	case "goroutine":
+		// debug=2 prints every goroutine's full stack, like an unrecovered panic.
+		debug = 2
+	case "heap":
+		debug = 1
+		if r.URL.Query().Get("gc") == "1" {
+			runtime.GC()
+		}
+	default:
+		http.Error(w, "kind must be goroutine or heap", http.StatusBadRequest)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("kind", kind).
+		Int("goroutines", runtime.NumGoroutine()).
+		Msg("Writing debug dump")
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := rpprof.Lookup(kind).WriteTo(w, debug); err != nil {
+		log.Warn().Err(err).Str("kind", kind).Msg("Failed to write debug dump")
+	}
+}
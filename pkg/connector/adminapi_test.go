@@ -0,0 +1,265 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestAdminAPIAddr_Resolution(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  string
+		env  string
+		want string
+	}{
+		{"default", "", "", defaultAdminAPIAddr},
+		{"env", "", ":1111", ":1111"},
+		{"config wins over env", ":2222", ":1111", ":2222"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BRIDGE_API_ADDR", tt.env)
+			mc := &MattermostConnector{Config: Config{AdminAPIAddr: tt.cfg}}
+			if got := mc.adminAPIAddr(); got != tt.want {
+				t.Errorf("adminAPIAddr: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminAPIToken_EnvFallback(t *testing.T) {
+	t.Setenv("BRIDGE_API_TOKEN", "env-token")
+	mc := &MattermostConnector{}
+	if got := mc.adminAPIToken(); got != "env-token" {
+		t.Errorf("adminAPIToken: got %q, want env-token", got)
+	}
+	mc.Config.AdminAPIToken = "cfg-token"
+	if got := mc.adminAPIToken(); got != "cfg-token" {
+		t.Errorf("adminAPIToken: got %q, want cfg-token", got)
+	}
+}
+
+func TestAdminAPIHandler_DebugDisabledWithoutToken(t *testing.T) {
+	t.Setenv("BRIDGE_API_TOKEN", "")
+	mc := newTestBridgeConnector()
+	h := mc.adminAPIHandler()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/dump"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s without token: got %d, want 404", path, w.Code)
+		}
+	}
+
+	// Existing endpoints remain reachable without auth.
+	req := httptest.NewRequest(http.MethodGet, "/api/reload-puppets", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("/api/reload-puppets without token: got %d, want 405", w.Code)
+	}
+}
+
+func TestAdminAPIHandler_RequiresToken(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Config.AdminAPIToken = "s3cret"
+	h := mc.adminAPIHandler()
+
+	tests := []struct {
+		name   string
+		path   string
+		auth   string
+		status int
+	}{
+		{"pprof no auth", "/debug/pprof/", "", http.StatusUnauthorized},
+		{"pprof wrong token", "/debug/pprof/", "Bearer nope", http.StatusUnauthorized},
+		{"pprof wrong scheme", "/debug/pprof/", "Basic s3cret", http.StatusUnauthorized},
+		{"pprof ok", "/debug/pprof/", "Bearer s3cret", http.StatusOK},
+		{"api no auth", "/api/reload-puppets", "", http.StatusUnauthorized},
+		{"api ok", "/api/reload-puppets", "Bearer s3cret", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("got %d, want %d", w.Code, tt.status)
+			}
+			if w.Code == http.StatusUnauthorized && strings.Contains(w.Body.String(), "s3cret") {
+				t.Error("401 response must not echo the token")
+			}
+		})
+	}
+}
+
+func TestHandleDebugDump(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+
+	tests := []struct {
+		name     string
+		method   string
+		query    string
+		status   int
+		contains string
+	}{
+		{"default goroutine", http.MethodPost, "", http.StatusOK, "goroutine "},
+		{"explicit goroutine", http.MethodPost, "?kind=goroutine", http.StatusOK, "TestHandleDebugDump"},
+		{"heap", http.MethodPost, "?kind=heap&gc=1", http.StatusOK, "heap profile"},
+		{"unknown kind", http.MethodPost, "?kind=threads", http.StatusBadRequest, ""},
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(tt.method, "/debug/dump"+tt.query, nil)
+			w := httptest.NewRecorder()
+			mc.HandleDebugDump(w, req)
+			if w.Code != tt.status {
+				t.Fatalf("got %d, want %d", w.Code, tt.status)
+			}
+			if tt.contains != "" && !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("dump does not contain %q", tt.contains)
+			}
+		})
+	}
+}
+
+func TestAdminAPIHandler_DebugDumpThroughMux(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Config.AdminAPIToken = "s3cret"
+
+	req := httptest.NewRequest(http.MethodPost, "/debug/dump", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	w := httptest.NewRecorder()
+	mc.adminAPIHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type: got %q, want text/plain", ct)
+	}
+}
+
+func TestValidRequestID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"", false},
+		{"abc-123", true},
+		{"svc:provision.42_a", true},
+		{strings.Repeat("a", maxRequestIDLength), true},
+		{strings.Repeat("a", maxRequestIDLength+1), false},
+		{"has space", false},
+		{"line\nbreak", false},
+		{"quote\"", false},
+	}
+	for _, tt := range tests {
+		if got := validRequestID(tt.id); got != tt.want {
+			t.Errorf("validRequestID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	t.Parallel()
+	a, b := newRequestID(), newRequestID()
+	if len(a) != 32 || !validRequestID(a) {
+		t.Errorf("newRequestID: got %q, want 32 hex characters", a)
+	}
+	if a == b {
+		t.Error("request IDs should be unique")
+	}
+}
+
+func TestAdminAPIHandler_RequestID(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Config.AdminAPIToken = "s3cret"
+	h := mc.adminAPIHandler()
+
+	tests := []struct {
+		name     string
+		provided string
+		auth     string
+		wantSame bool
+	}{
+		{name: "generated", auth: "Bearer s3cret"},
+		{name: "caller provided", provided: "provision-42", auth: "Bearer s3cret", wantSame: true},
+		{name: "invalid replaced", provided: "bad id\n", auth: "Bearer s3cret"},
+		{name: "unauthorized", provided: "provision-43", wantSame: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/api/puppets", nil)
+			if tt.provided != "" {
+				req.Header.Set(requestIDHeader, tt.provided)
+			}
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			got := w.Header().Get(requestIDHeader)
+			if tt.wantSame && got != tt.provided {
+				t.Errorf("request ID: got %q, want %q", got, tt.provided)
+			}
+			if !tt.wantSame && (got == tt.provided || !validRequestID(got)) {
+				t.Errorf("request ID: got %q, want a generated ID", got)
+			}
+		})
+	}
+}
+
+func TestAdminAPIHandler_RequestIDInLogs(t *testing.T) {
+	t.Setenv("BRIDGE_API_TOKEN", "")
+	mc := newTestBridgeConnector()
+	var buf bytes.Buffer
+	mc.Bridge.Log = zerolog.New(&buf)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/reload-puppets", nil)
+	req.Header.Set(requestIDHeader, "trace-me")
+	w := httptest.NewRecorder()
+	mc.adminAPIHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", w.Code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected request and reload log lines, got %q", buf.String())
+	}
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry["request_id"] != "trace-me" {
+			t.Errorf("log line without request ID: %s", line)
+		}
+	}
+}
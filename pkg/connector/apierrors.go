@@ -0,0 +1,132 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+)
+
+// Causes of failed Mattermost API requests. Errors returned for Matrix
+// events wrap one of these when Mattermost reports the cause, so callers can
+// branch with errors.Is.
+var (
+	ErrChannelArchived  = errors.New("mattermost channel is archived")
+	ErrPermissionDenied = errors.New("mattermost permission denied")
+	ErrRateLimited      = errors.New("mattermost rate limit exceeded")
+	ErrNotFound         = errors.New("mattermost resource not found")
+	// ErrTokenRejected means Mattermost rejected the login's token.
+	ErrTokenRejected = errors.New("mattermost rejected the token")
+	// ErrPuppetTokenRejected means Mattermost rejected the token of the
+	// puppet posting for the sender. See puppetTokenError.
+	ErrPuppetTokenRejected = errors.New("mattermost rejected the puppet token")
+)
+
+// archivedChannelErrorIDs are the Mattermost error IDs for changes to posts
+// in archived channels, which fail with 400 or 403 rather than a status of
+// their own.
+var archivedChannelErrorIDs = map[string]bool{
+	"api.post.create_post.can_not_post_to_deleted.error":        true,
+	"api.post.update_post.can_not_update_post_in_deleted.error": true,
+	"api.post.delete_post.can_not_delete_post_in_deleted.error": true,
+}
+
+// classifyAPIError returns the cause of a failed Mattermost request, or nil
+// if it's not one of the typed causes. The status is taken from resp, or from
+// the AppError in err when there is no response. Errors that already wrap
+// errMattermostUnreachable or ErrChannelArchived keep it as their cause.
+func classifyAPIError(resp *model.Response, err error) error {
+	if errors.Is(err, errMattermostUnreachable) {
+		return errMattermostUnreachable
+	}
+	if errors.Is(err, ErrChannelArchived) {
+		return ErrChannelArchived
+	}
+	var appErr *model.AppError
+	hasAppErr := errors.As(err, &appErr)
+	if hasAppErr && (archivedChannelErrorIDs[appErr.Id] || strings.Contains(appErr.Id, "archived_channel")) {
+		return ErrChannelArchived
+	}
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	if statusCode == 0 && hasAppErr {
+		statusCode = appErr.StatusCode
+	}
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return ErrTokenRejected
+	case http.StatusForbidden:
+		return ErrPermissionDenied
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusNotFound:
+		return ErrNotFound
+	}
+	return nil
+}
+
+// apiError wraps the error of a failed Mattermost request made for a Matrix
+// event, prefixed with what failed. Typed causes are wrapped too, in a
+// message status that tells the Matrix user why the event wasn't bridged.
+func apiError(what string, resp *model.Response, err error) error {
+	cause := classifyAPIError(resp, err)
+	if cause == nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	wrapped := fmt.Errorf("%s: %w", what, err)
+	if !errors.Is(err, cause) {
+		wrapped = fmt.Errorf("%s: %w: %w", what, cause, err)
+	}
+	status := bridgev2.WrapErrorInStatus(wrapped).WithIsCertain(true).WithSendNotice(true)
+	switch cause {
+	case ErrChannelArchived:
+		return status.WithStatus(event.MessageStatusFail).
+			WithErrorReason(event.MessageStatusNoPermission).
+			WithMessage("The Mattermost channel is archived")
+	case ErrPermissionDenied:
+		return status.WithStatus(event.MessageStatusFail).
+			WithErrorReason(event.MessageStatusNoPermission).
+			WithMessage("Mattermost denied permission for this action")
+	case ErrRateLimited:
+		return status.WithStatus(event.MessageStatusRetriable).
+			WithErrorReason(event.MessageStatusNetworkError).
+			WithMessage("Mattermost is rate limiting the bridge, try again later")
+	case ErrTokenRejected:
+		return status.WithStatus(event.MessageStatusFail).
+			WithErrorReason(event.MessageStatusNoPermission).
+			WithMessage("Mattermost rejected the bridge's token for this account, log in again")
+	case errMattermostUnreachable:
+		return status.WithStatus(event.MessageStatusRetriable).
+			WithErrorReason(event.MessageStatusNetworkError).
+			WithMessage("Mattermost is unreachable, try again later")
+	default:
+		return status.WithStatus(event.MessageStatusFail).
+			WithErrorReason(event.MessageStatusGenericError).
+			WithMessage("The target no longer exists in Mattermost").
+			WithSendNotice(false)
+	}
+}
+
+// puppetTokenError wraps the error of a message whose puppet token Mattermost
+// rejected. The puppet is marked unhealthy by then, so sending the message
+// again goes through the relay.
+func puppetTokenError(what string, err error) error {
+	wrapped := fmt.Errorf("%s: %w: %w", what, ErrPuppetTokenRejected, err)
+	return bridgev2.WrapErrorInStatus(wrapped).
+		WithStatus(event.MessageStatusRetriable).
+		WithErrorReason(event.MessageStatusNoPermission).
+		WithMessage("Mattermost rejected the sender's puppet token, try again to send without it").
+		WithIsCertain(true).
+		WithSendNotice(true)
+}
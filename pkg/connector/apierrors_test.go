@@ -0,0 +1,139 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	t.Parallel()
+	appErr := func(id string, status int) error {
+		return model.NewAppError("test", id, nil, "", status)
+	}
+	tests := []struct {
+		name string
+		resp *model.Response
+		err  error
+		want error
+	}{
+		{"archived post", &model.Response{StatusCode: http.StatusBadRequest}, appErr("api.post.create_post.can_not_post_to_deleted.error", http.StatusBadRequest), ErrChannelArchived},
+		{"archived reaction", &model.Response{StatusCode: http.StatusForbidden}, appErr("api.reaction.save.archived_channel.app_error", http.StatusForbidden), ErrChannelArchived},
+		{"forbidden", &model.Response{StatusCode: http.StatusForbidden}, appErr("api.context.permissions.app_error", http.StatusForbidden), ErrPermissionDenied},
+		{"rate limited", &model.Response{StatusCode: http.StatusTooManyRequests}, errors.New("too many requests"), ErrRateLimited},
+		{"not found", &model.Response{StatusCode: http.StatusNotFound}, appErr("app.post.get.app_error", http.StatusNotFound), ErrNotFound},
+		{"token rejected", &model.Response{StatusCode: http.StatusUnauthorized}, appErr("api.context.session_expired.app_error", http.StatusUnauthorized), ErrTokenRejected},
+		{"unreachable", nil, fmt.Errorf("upload: %w: %w", errMattermostUnreachable, errors.New("connection refused")), errMattermostUnreachable},
+		{"status from app error", nil, fmt.Errorf("upload: %w", appErr("api.context.permissions.app_error", http.StatusForbidden)), ErrPermissionDenied},
+		{"server error", &model.Response{StatusCode: http.StatusInternalServerError}, errors.New("boom"), nil},
+		{"network error", nil, errors.New("connection refused"), nil},
+	}
+	for _, tt := range tests {
+		if got := classifyAPIError(tt.resp, tt.err); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+	cause := model.NewAppError("test", "api.context.permissions.app_error", nil, "", http.StatusForbidden)
+	err := apiError("failed to create post", &model.Response{StatusCode: http.StatusForbidden}, cause)
+
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("errors.Is(ErrPermissionDenied) = false for %v", err)
+	}
+	var appErr *model.AppError
+	if !errors.As(err, &appErr) || appErr != cause {
+		t.Error("the Mattermost error should stay reachable")
+	}
+	if !strings.HasPrefix(err.Error(), "failed to create post: ") {
+		t.Errorf("message: got %q", err.Error())
+	}
+	var status bridgev2.MessageStatus
+	if !errors.As(err, &status) {
+		t.Fatal("typed errors should carry a message status")
+	}
+	if status.Status != event.MessageStatusFail || status.ErrorReason != event.MessageStatusNoPermission || status.Message == "" || !status.IsCertain {
+		t.Errorf("status: %+v", status)
+	}
+
+	unreachable := apiError("failed to upload media", nil, fmt.Errorf("failed to upload to Mattermost: %w: %w", errMattermostUnreachable, errors.New("connection refused")))
+	if !errors.As(unreachable, &status) || status.Status != event.MessageStatusRetriable || status.ErrorReason != event.MessageStatusNetworkError {
+		t.Errorf("unreachable status: %+v", status)
+	}
+	if unreachable.Error() != "failed to upload media: failed to upload to Mattermost: mattermost is unreachable: connection refused" {
+		t.Errorf("unreachable message: got %q", unreachable.Error())
+	}
+
+	plain := apiError("failed to create post", nil, errors.New("connection refused"))
+	if errors.As(plain, &status) {
+		t.Error("untyped errors should be left to the bridge's default status")
+	}
+	if plain.Error() != "failed to create post: connection refused" {
+		t.Errorf("message: got %q", plain.Error())
+	}
+}
+
+func TestHandleMatrixEvents_TypedErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		appErr *model.AppError
+		want   error
+	}{
+		{"archived", model.NewAppError("UpdatePost", "api.post.update_post.can_not_update_post_in_deleted.error", nil, "", http.StatusBadRequest), ErrChannelArchived},
+		{"forbidden", model.NewAppError("UpdatePost", "api.context.permissions.app_error", nil, "", http.StatusForbidden), ErrPermissionDenied},
+		{"rate limited", model.NewAppError("UpdatePost", "api.context.rate_limit.app_error", nil, "", http.StatusTooManyRequests), ErrRateLimited},
+		{"not found", model.NewAppError("UpdatePost", "app.post.get.app_error", nil, "", http.StatusNotFound), ErrNotFound},
+		{"token rejected", model.NewAppError("UpdatePost", "api.context.session_expired.app_error", nil, "", http.StatusUnauthorized), ErrTokenRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := newFakeMM()
+			t.Cleanup(fm.Close)
+			fm.EndpointErrors["/posts/"] = tt.appErr
+			fm.EndpointErrors["/reactions"] = tt.appErr
+			mc := newFullTestClient(fm.Server.URL)
+			ctx := context.Background()
+			portal := makeTestPortal("test-channel")
+			target := &database.Message{ID: MakeMessageID("post-1")}
+
+			editErr := mc.HandleMatrixEdit(ctx, &bridgev2.MatrixEdit{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+					Portal:  portal,
+					Content: &event.MessageEventContent{MsgType: event.MsgText, Body: "edited"},
+				},
+				EditTarget: target,
+			})
+			removeErr := mc.HandleMatrixMessageRemove(ctx, &bridgev2.MatrixMessageRemove{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.RedactionEventContent]{Portal: portal},
+				TargetMessage:   target,
+			})
+			_, reactErr := mc.HandleMatrixReaction(ctx, &bridgev2.MatrixReaction{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.ReactionEventContent]{Portal: portal},
+				TargetMessage:   target,
+				PreHandleResp:   &bridgev2.MatrixReactionPreResponse{EmojiID: MakeEmojiID("+1")},
+			})
+			for name, err := range map[string]error{"edit": editErr, "remove": removeErr, "reaction": reactErr} {
+				if !errors.Is(err, tt.want) {
+					t.Errorf("%s: got %v, want %v", name, err, tt.want)
+				}
+			}
+		})
+	}
+}
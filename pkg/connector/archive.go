@@ -0,0 +1,156 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+)
+
+// archivedEventsDefault is the events_default power level of the portal
+// rooms of archived channels. Users and ghosts are below it, so only the
+// bridge bot can send events.
+const archivedEventsDefault = 100
+
+// Notices posted in a portal room when its channel is archived or restored.
+const (
+	archivedNotice = "This channel was archived in Mattermost. The room is read-only until the channel is restored."
+	restoredNotice = "This channel was restored in Mattermost. Messages are bridged again."
+)
+
+// ArchivedChannelsConfig controls the portal rooms of archived channels.
+type ArchivedChannelsConfig struct {
+	// DeleteRoom deletes the portal room when its channel is archived,
+	// instead of keeping it read-only. A restored channel gets a new room on
+	// its next message or channel sync, subject to portal_creation.
+	DeleteRoom bool `yaml:"delete_room"`
+}
+
+// archiveUpdater returns a ChatInfo.ExtraUpdates hook that makes the portal
+// room read-only when its channel is archived and writable again when it's
+// restored, with a notice from the bridge bot. Portals already in that state
+// are left alone, so every login seeing the event doesn't repeat the notice.
+func (m *MattermostClient) archiveUpdater(channelID string, archived bool) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		meta := portalMetadata(portal)
+		if portal.MXID == "" || meta.Archived == archived {
+			return false
+		}
+		if err := setRoomReadOnly(ctx, portal, archived); err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Bool("archived", archived).Msg("Failed to change power levels of archived channel")
+			return false
+		}
+		meta.Archived = archived
+		notice := restoredNotice
+		if archived {
+			notice = archivedNotice
+		}
+		content := &event.MessageEventContent{MsgType: event.MsgNotice, Body: notice}
+		_, err := portal.Bridge.Bot.SendMessage(ctx, portal.MXID, event.EventMessage, &event.Content{Parsed: content}, nil)
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to send archive notice")
+		}
+		m.log.Info().
+			Str("channel_id", channelID).
+			Stringer("room_id", portal.MXID).
+			Bool("archived", archived).
+			Msg("Changed portal room for archived channel")
+		return true
+	}
+}
+
+// setRoomReadOnly raises the events_default power level of a portal room to
+// archivedEventsDefault, or lowers it back to 0.
+func setRoomReadOnly(ctx context.Context, portal *bridgev2.Portal, readOnly bool) error {
+	levels, err := portal.Bridge.Matrix.GetPowerLevels(ctx, portal.MXID)
+	if err != nil {
+		return fmt.Errorf("failed to get power levels: %w", err)
+	}
+	eventsDefault := 0
+	if readOnly {
+		eventsDefault = archivedEventsDefault
+	}
+	if levels.EventsDefault == eventsDefault {
+		return nil
+	}
+	levels.EventsDefault = eventsDefault
+	_, err = portal.Bridge.Bot.SendState(ctx, portal.MXID, event.StatePowerLevels, "", &event.Content{Parsed: levels}, time.Time{})
+	return err
+}
+
+// parseChannelArchiveEvent extracts the channel ID from a channel_deleted or
+// channel_restored event. Both are broadcast to the team, so the ID is only
+// in the event data.
+func parseChannelArchiveEvent(evt *model.WebSocketEvent) string {
+	channelID, _ := evt.GetData()["channel_id"].(string)
+	return channelID
+}
+
+// handleChannelDeleted makes the portal room of an archived channel
+// read-only, or deletes it when archived_channels.delete_room is set.
+func (m *MattermostClient) handleChannelDeleted(evt *model.WebSocketEvent) {
+	channelID := parseChannelArchiveEvent(evt)
+	if channelID == "" {
+		m.log.Warn().Msg("Channel deleted event missing channel ID")
+		return
+	}
+	if !m.connector.OwnsChannel(channelID) {
+		return
+	}
+	m.log.Debug().Str("channel_id", channelID).Msg("Channel archived")
+	if m.connector.Config.ArchivedChannels.DeleteRoom {
+		m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatDelete{
+			EventMeta: archiveEventMeta(bridgev2.RemoteEventChatDelete, channelID),
+		})
+		return
+	}
+	m.queueArchiveChange(channelID, true)
+}
+
+// handleChannelRestored makes the portal room of a restored channel writable
+// again.
+func (m *MattermostClient) handleChannelRestored(evt *model.WebSocketEvent) {
+	channelID := parseChannelArchiveEvent(evt)
+	if channelID == "" {
+		m.log.Warn().Msg("Channel restored event missing channel ID")
+		return
+	}
+	if !m.connector.OwnsChannel(channelID) {
+		return
+	}
+	m.log.Debug().Str("channel_id", channelID).Msg("Channel restored")
+	m.queueArchiveChange(channelID, false)
+}
+
+// archiveEventMeta returns the metadata of a remote event for the portal of
+// an archived or restored channel. It never creates the portal.
+func archiveEventMeta(evtType bridgev2.RemoteEventType, channelID string) simplevent.EventMeta {
+	return simplevent.EventMeta{
+		Type:      evtType,
+		PortalKey: makePortalKey(channelID),
+		LogContext: func(c zerolog.Context) zerolog.Context {
+			return c.Str("channel_id", channelID)
+		},
+	}
+}
+
+// queueArchiveChange queues a chat info change that applies archiveUpdater
+// to an existing portal.
+func (m *MattermostClient) queueArchiveChange(channelID string, archived bool) {
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatInfoChange{
+		EventMeta: archiveEventMeta(bridgev2.RemoteEventChatInfoChange, channelID),
+		ChatInfoChange: &bridgev2.ChatInfoChange{ChatInfo: &bridgev2.ChatInfo{
+			ExtraUpdates: m.archiveUpdater(channelID, archived),
+		}},
+	})
+}
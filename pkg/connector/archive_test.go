@@ -0,0 +1,181 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// powerLevelsMatrixConnector returns power levels with eventsDefault from
+// GetPowerLevels.
+type powerLevelsMatrixConnector struct {
+	nopMatrixConnector
+	eventsDefault int
+}
+
+func (c *powerLevelsMatrixConnector) GetPowerLevels(context.Context, id.RoomID) (*event.PowerLevelsEventContent, error) {
+	return &event.PowerLevelsEventContent{EventsDefault: c.eventsDefault}, nil
+}
+
+func TestArchiveUpdater(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := newTestClient()
+	portal := makeTestPortalWithBot("ch1", nil)
+	matrix := &powerLevelsMatrixConnector{}
+	portal.Bridge.Matrix = matrix
+	bot := portal.Bridge.Bot.(*fakeMatrixBot)
+
+	if client.archiveUpdater("ch1", true)(ctx, portal) {
+		t.Error("update before room creation should not report a change")
+	}
+
+	portal.MXID = "!room:example.com"
+	if !client.archiveUpdater("ch1", true)(ctx, portal) {
+		t.Fatal("archiving should report a change")
+	}
+	if !portalMetadata(portal).Archived {
+		t.Error("portal not marked archived")
+	}
+	states := bot.States()
+	if len(states) != 1 || states[0].Type != event.StatePowerLevels {
+		t.Fatalf("states = %+v, want one power levels event", states)
+	}
+	if got := states[0].Content.Parsed.(*event.PowerLevelsEventContent).EventsDefault; got != archivedEventsDefault {
+		t.Errorf("events_default = %d, want %d", got, archivedEventsDefault)
+	}
+	if sent := bot.Sent(); len(sent) != 1 || sent[0].AsMessage().Body != archivedNotice {
+		t.Errorf("notices = %+v, want the archived notice", sent)
+	}
+
+	if client.archiveUpdater("ch1", true)(ctx, portal) {
+		t.Error("archiving an archived portal should not report a change")
+	}
+	if len(bot.Sent()) != 1 {
+		t.Error("archived notice repeated")
+	}
+
+	matrix.eventsDefault = archivedEventsDefault
+	if !client.archiveUpdater("ch1", false)(ctx, portal) {
+		t.Fatal("restoring should report a change")
+	}
+	if portalMetadata(portal).Archived {
+		t.Error("portal still marked archived")
+	}
+	states = bot.States()
+	if len(states) != 2 || states[1].Content.Parsed.(*event.PowerLevelsEventContent).EventsDefault != 0 {
+		t.Errorf("states = %+v, want events_default reset to 0", states)
+	}
+	if sent := bot.Sent(); len(sent) != 2 || sent[1].AsMessage().Body != restoredNotice {
+		t.Errorf("notices = %+v, want the restored notice", sent)
+	}
+}
+
+func TestChannelToChatInfo_Archived(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := newTestClient()
+	portal := makeTestPortalWithBot("ch1", nil)
+	portal.Bridge.Matrix = &powerLevelsMatrixConnector{}
+	portal.MXID = "!room:example.com"
+
+	// A channel archived while the bridge was down is caught up by the next
+	// resync.
+	info := client.channelToChatInfo(&model.Channel{Id: "ch1", Type: model.ChannelTypeOpen, DeleteAt: 1}, nil)
+	info.ExtraUpdates(ctx, portal)
+	if !portalMetadata(portal).Archived {
+		t.Error("archived channel not marked on resync")
+	}
+}
+
+func TestHandleChannelArchiveEvents(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		evtType    model.WebsocketEventType
+		channelID  string
+		deleteRoom bool
+		want       bridgev2.RemoteEventType
+	}{
+		{"archived", model.WebsocketEventChannelDeleted, "ch1", false, bridgev2.RemoteEventChatInfoChange},
+		{"archived, delete room", model.WebsocketEventChannelDeleted, "ch1", true, bridgev2.RemoteEventChatDelete},
+		{"restored", model.WebsocketEventChannelRestored, "ch1", false, bridgev2.RemoteEventChatInfoChange},
+		{"restored, delete room", model.WebsocketEventChannelRestored, "ch1", true, bridgev2.RemoteEventChatInfoChange},
+		{"missing channel", model.WebsocketEventChannelDeleted, "", false, bridgev2.RemoteEventUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mc.connector.Config.ArchivedChannels.DeleteRoom = tt.deleteRoom
+			mock := testMock(mc)
+			evt := model.NewWebSocketEvent(tt.evtType, "team1", "", "", nil, "")
+			if tt.channelID != "" {
+				evt.Add("channel_id", tt.channelID)
+			}
+
+			mc.handleEvent(evt)
+
+			events := mock.Events()
+			if tt.want == bridgev2.RemoteEventUnknown {
+				if len(events) != 0 {
+					t.Errorf("expected no events, got %d", len(events))
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			if events[0].GetType() != tt.want {
+				t.Errorf("event type = %v, want %v", events[0].GetType(), tt.want)
+			}
+			if key := events[0].GetPortalKey(); ParsePortalID(key.ID) != "ch1" {
+				t.Errorf("portal = %v, want ch1", key)
+			}
+			if resync, ok := events[0].(*simplevent.ChatInfoChange); ok && resync.CreatePortal {
+				t.Error("archive change must not create portals")
+			}
+		})
+	}
+}
+
+func TestHandleMatrixMessage_ArchivedChannel(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+	portal := makeTestPortal("ch1")
+	portal.Metadata = &PortalMetadata{Archived: true}
+	content := &event.MessageEventContent{MsgType: event.MsgText, Body: "hello"}
+
+	_, err := mc.HandleMatrixMessage(context.Background(), &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Event:   &event.Event{ID: "$evt:example.com", Type: event.EventMessage, Content: event.Content{Parsed: content}},
+			Content: content,
+			Portal:  portal,
+		},
+	})
+	if !errors.Is(err, ErrChannelArchived) {
+		t.Fatalf("err = %v, want ErrChannelArchived", err)
+	}
+	var status bridgev2.MessageStatus
+	if !errors.As(err, &status) || status.Status != event.MessageStatusFail {
+		t.Errorf("status = %+v, want fail", status)
+	}
+	for _, call := range fake.Calls() {
+		if call.Path == "/api/v4/posts" {
+			t.Error("message to archived channel was posted")
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/event"
+)
+
+// attachmentColors maps the named attachment colors to the hex colors the
+// Mattermost webapp draws them with.
+var attachmentColors = map[string]string{
+	"good":    "#00c100",
+	"warning": "#dede01",
+	"danger":  "#e40303",
+}
+
+var attachmentHexColorRe = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}){1,2}$`)
+
+// attachmentColor returns the #rrggbb color of an attachment's side bar, or
+// "" if it has none or it's invalid.
+func attachmentColor(color string) string {
+	if named, ok := attachmentColors[color]; ok {
+		return named
+	}
+	if !attachmentHexColorRe.MatchString(color) {
+		return ""
+	}
+	color = strings.ToLower(color)
+	if len(color) == 4 {
+		color = string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]})
+	}
+	return color
+}
+
+// attachmentTime parses an attachment's ts, which integrations send as a
+// number or a string of Unix seconds.
+func attachmentTime(ts any) (time.Time, bool) {
+	var secs float64
+	switch v := ts.(type) {
+	case float64:
+		secs = v
+	case int64:
+		secs = float64(v)
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		secs = parsed
+	default:
+		return time.Time{}, false
+	}
+	if secs <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(secs), 0), true
+}
+
+// attachmentFieldValue returns a field value as markdown. Integrations send
+// strings, but numbers and booleans occur too.
+func attachmentFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// attachmentMarkdown renders the markdown of an attachment's pretext, text or
+// field value, returning the plain text and the HTML.
+func attachmentMarkdown(text string, opts mattermostfmt.Options) (string, string) {
+	parsed := mattermostfmtParseWithOptions(text, opts)
+	if parsed.Format == event.FormatHTML {
+		return parsed.Body, parsed.FormattedBody
+	}
+	return parsed.Body, strings.ReplaceAll(html.EscapeString(parsed.Body), "\n", "<br/>")
+}
+
+// attachmentLink returns text as HTML, linked to link if it's an http(s)
+// URL.
+func attachmentLink(text, link string) string {
+	if link == "" || !model.IsValidHTTPURL(link) {
+		return html.EscapeString(text)
+	}
+	return `<a href="` + html.EscapeString(link) + `">` + html.EscapeString(text) + `</a>`
+}
+
+// renderAttachment renders one message attachment, as sent by webhooks and
+// bots in the post's attachments prop. The HTML is a block quote led by a
+// bar in the attachment's color; the plain text quotes the same lines with
+// "> ". Images are linked rather than embedded, since Matrix HTML can only
+// show images from the media repo. Buttons and menus are listed separately by
+// renderActions.
+func renderAttachment(attachment *model.SlackAttachment, opts mattermostfmt.Options) (string, string) {
+	var body, formatted []string
+	add := func(plain, rich string) {
+		body = append(body, plain)
+		formatted = append(formatted, rich)
+	}
+
+	if attachment.AuthorName != "" {
+		add(attachment.AuthorName, attachmentLink(attachment.AuthorName, attachment.AuthorLink))
+	}
+	if attachment.Title != "" {
+		plain := attachment.Title
+		if attachment.TitleLink != "" && model.IsValidHTTPURL(attachment.TitleLink) {
+			plain += " (" + attachment.TitleLink + ")"
+		}
+		add(plain, "<strong>"+attachmentLink(attachment.Title, attachment.TitleLink)+"</strong>")
+	}
+	if attachment.Text != "" {
+		add(attachmentMarkdown(attachment.Text, opts))
+	}
+	for _, field := range attachment.Fields {
+		if field == nil {
+			continue
+		}
+		value := attachmentFieldValue(field.Value)
+		if field.Title == "" && value == "" {
+			continue
+		}
+		plainValue, htmlValue := attachmentMarkdown(value, opts)
+		switch {
+		case field.Title == "":
+			add(plainValue, htmlValue)
+		case value == "":
+			add(field.Title, "<strong>"+html.EscapeString(field.Title)+"</strong>")
+		default:
+			add(field.Title+": "+plainValue, "<strong>"+html.EscapeString(field.Title)+"</strong><br/>"+htmlValue)
+		}
+	}
+	if attachment.ImageURL != "" && model.IsValidHTTPURL(attachment.ImageURL) {
+		add("Image: "+attachment.ImageURL, attachmentLink("Image", attachment.ImageURL))
+	}
+	footer := attachment.Footer
+	if ts, ok := attachmentTime(attachment.Timestamp); ok {
+		if footer != "" {
+			footer += " | "
+		}
+		footer += opts.FormatTime(ts)
+	}
+	if footer != "" {
+		add(footer, "<sub>"+html.EscapeString(footer)+"</sub>")
+	}
+	// Attachments with nothing else to show fall back to their fallback
+	// text.
+	if len(body) == 0 && attachment.Fallback != "" {
+		add(attachment.Fallback, html.EscapeString(attachment.Fallback))
+	}
+
+	var plain, rich strings.Builder
+	if attachment.Pretext != "" {
+		plainPretext, htmlPretext := attachmentMarkdown(attachment.Pretext, opts)
+		plain.WriteString(plainPretext)
+		rich.WriteString("<p>" + htmlPretext + "</p>")
+		if len(body) > 0 {
+			plain.WriteString("\n")
+		}
+	}
+	if len(body) == 0 {
+		return plain.String(), rich.String()
+	}
+	for i, line := range body {
+		if i > 0 {
+			plain.WriteString("\n")
+		}
+		plain.WriteString("> " + strings.ReplaceAll(line, "\n", "\n> "))
+	}
+	rich.WriteString("<blockquote>")
+	for i, line := range formatted {
+		rich.WriteString("<p>")
+		if color := attachmentColor(attachment.Color); i == 0 && color != "" {
+			rich.WriteString(`<font data-mx-color="` + color + `">▌</font> `)
+		}
+		rich.WriteString(line + "</p>")
+	}
+	rich.WriteString("</blockquote>")
+	return plain.String(), rich.String()
+}
+
+// appendAttachments renders a post's message attachments after the message
+// text in content, switching content to HTML. Their buttons and menus follow
+// as a numbered list for the action command, prefixed with commandPrefix.
+// Mentions in attachments aren't resolved: Mattermost doesn't notify for them
+// either.
+func appendAttachments(content *event.MessageEventContent, attachments []*model.SlackAttachment, opts mattermostfmt.Options, commandPrefix string) {
+	opts.Mentions = nil
+	var body, formatted []string
+	for _, attachment := range attachments {
+		if attachment == nil {
+			continue
+		}
+		plain, rich := renderAttachment(attachment, opts)
+		if plain == "" {
+			continue
+		}
+		body = append(body, plain)
+		formatted = append(formatted, rich)
+	}
+	if plain, rich := renderActions(postActions(attachments), commandPrefix); plain != "" {
+		body = append(body, plain)
+		formatted = append(formatted, rich)
+	}
+	if len(body) == 0 {
+		return
+	}
+
+	if content.Body != "" {
+		if content.Format != event.FormatHTML {
+			content.FormattedBody = strings.ReplaceAll(html.EscapeString(content.Body), "\n", "<br/>")
+		}
+		body = append([]string{content.Body}, body...)
+		formatted = append([]string{content.FormattedBody}, formatted...)
+	}
+	content.Body = strings.Join(body, "\n\n")
+	content.Format = event.FormatHTML
+	content.FormattedBody = strings.Join(formatted, "")
+}
@@ -0,0 +1,217 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+)
+
+func TestAttachmentColor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		color string
+		want  string
+	}{
+		{"", ""},
+		{"good", "#00c100"},
+		{"danger", "#e40303"},
+		{"#FF8800", "#ff8800"},
+		{"#f80", "#ff8800"},
+		{"red", ""},
+		{`#ff0000" onclick="x`, ""},
+	}
+	for _, tt := range tests {
+		if got := attachmentColor(tt.color); got != tt.want {
+			t.Errorf("attachmentColor(%q) = %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestAttachmentTime(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		ts     any
+		want   int64
+		wantOK bool
+	}{
+		{"number", float64(1700000000), 1700000000, true},
+		{"int", int64(1700000000), 1700000000, true},
+		{"string", "1700000000.123", 1700000000, true},
+		{"invalid string", "yesterday", 0, false},
+		{"zero", float64(0), 0, false},
+		{"missing", nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := attachmentTime(tt.ts)
+		if ok != tt.wantOK || (ok && got.Unix() != tt.want) {
+			t.Errorf("%s: got %v, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRenderAttachment(t *testing.T) {
+	t.Parallel()
+	opts := mattermostfmt.Options{Location: time.UTC}
+	tests := []struct {
+		name       string
+		attachment *model.SlackAttachment
+		wantBody   string
+		wantHTML   string
+	}{
+		{
+			name: "full",
+			attachment: &model.SlackAttachment{
+				Color:      "danger",
+				Pretext:    "Build **failed**",
+				AuthorName: "CI",
+				AuthorLink: "https://ci.example.com",
+				Title:      "Pipeline #42",
+				TitleLink:  "https://ci.example.com/42",
+				Text:       "Step `test` failed",
+				Fields: []*model.SlackAttachmentField{
+					{Title: "Branch", Value: "main", Short: true},
+					{Title: "Attempt", Value: float64(2), Short: true},
+				},
+				ImageURL:  "https://ci.example.com/graph.png",
+				Footer:    "CI bot",
+				Timestamp: float64(1700000000),
+			},
+			wantBody: "Build **failed**\n" +
+				"> CI\n" +
+				"> Pipeline #42 (https://ci.example.com/42)\n" +
+				"> Step `test` failed\n" +
+				"> Branch: main\n" +
+				"> Attempt: 2\n" +
+				"> Image: https://ci.example.com/graph.png\n" +
+				"> CI bot | 2023-11-14 22:13 UTC",
+			wantHTML: "<p>Build <strong>failed</strong></p><blockquote>" +
+				`<p><font data-mx-color="#e40303">▌</font> <a href="https://ci.example.com">CI</a></p>` +
+				`<p><strong><a href="https://ci.example.com/42">Pipeline #42</a></strong></p>` +
+				"<p>Step <code>test</code> failed</p>" +
+				"<p><strong>Branch</strong><br/>main</p>" +
+				"<p><strong>Attempt</strong><br/>2</p>" +
+				`<p><a href="https://ci.example.com/graph.png">Image</a></p>` +
+				"<p><sub>CI bot | 2023-11-14 22:13 UTC</sub></p>" +
+				"</blockquote>",
+		},
+		{
+			name:       "fallback only",
+			attachment: &model.SlackAttachment{Fallback: "New <ticket>"},
+			wantBody:   "> New <ticket>",
+			wantHTML:   "<blockquote><p>New &lt;ticket&gt;</p></blockquote>",
+		},
+		{
+			name:       "pretext only",
+			attachment: &model.SlackAttachment{Pretext: "heads up"},
+			wantBody:   "heads up",
+			wantHTML:   "<p>heads up</p>",
+		},
+		{
+			name: "unsafe links",
+			attachment: &model.SlackAttachment{
+				Title:     "Click",
+				TitleLink: "javascript:alert(1)",
+				ImageURL:  "file:///etc/passwd",
+				Text:      "line 1\nline 2",
+			},
+			wantBody: "> Click\n> line 1\n> line 2",
+			wantHTML: "<blockquote><p><strong>Click</strong></p><p>line 1<br/>line 2</p></blockquote>",
+		},
+		{
+			name:       "empty",
+			attachment: &model.SlackAttachment{Fields: []*model.SlackAttachmentField{nil, {}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body, formatted := renderAttachment(tt.attachment, opts)
+			if body != tt.wantBody {
+				t.Errorf("body:\ngot  %q\nwant %q", body, tt.wantBody)
+			}
+			if formatted != tt.wantHTML {
+				t.Errorf("html:\ngot  %q\nwant %q", formatted, tt.wantHTML)
+			}
+		})
+	}
+}
+
+// attachmentPost returns a post with message and one attachment, with the
+// attachments prop decoded from JSON like posts from the API.
+func attachmentPost(t *testing.T, message string) *model.Post {
+	t.Helper()
+	var post model.Post
+	raw := `{"id":"p1","channel_id":"ch1","message":` + mustJSON(t, message) +
+		`,"props":{"attachments":[{"color":"good","title":"Deployed","text":"v1.2 is live"}]}}`
+	if err := json.Unmarshal([]byte(raw), &post); err != nil {
+		t.Fatalf("unmarshal post: %v", err)
+	}
+	return &post
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(data)
+}
+
+func TestConvertPostToMatrix_Attachments(t *testing.T) {
+	t.Parallel()
+	const attachmentHTML = `<blockquote><p><font data-mx-color="#00c100">▌</font> <strong>Deployed</strong></p><p>v1.2 is live</p></blockquote>`
+	tests := []struct {
+		name     string
+		message  string
+		wantBody string
+		wantHTML string
+	}{
+		{"attachment only", "", "> Deployed\n> v1.2 is live", attachmentHTML},
+		{"plain message", "deploy\ndone", "deploy\ndone\n\n> Deployed\n> v1.2 is live", "deploy<br/>done" + attachmentHTML},
+		{"formatted message", "**deploy**", "**deploy**\n\n> Deployed\n> v1.2 is live", "<strong>deploy</strong>" + attachmentHTML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient()
+			msg := client.convertPostToMatrix(context.Background(), nil, nil, attachmentPost(t, tt.message))
+			if len(msg.Parts) != 1 {
+				t.Fatalf("expected 1 part, got %d", len(msg.Parts))
+			}
+			content := msg.Parts[0].Content
+			if content.Body != tt.wantBody {
+				t.Errorf("body: got %q, want %q", content.Body, tt.wantBody)
+			}
+			if content.Format != event.FormatHTML || content.FormattedBody != tt.wantHTML {
+				t.Errorf("formatted body: got %q, want %q", content.FormattedBody, tt.wantHTML)
+			}
+		})
+	}
+}
+
+func TestConvertEditToMatrix_Attachments(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	existing := []*database.Message{{ID: "p1"}}
+
+	edit := client.convertEditToMatrix(context.Background(), nil, nil, attachmentPost(t, ""), existing)
+	if len(edit.ModifiedParts) != 1 {
+		t.Fatalf("expected 1 modified part, got %d", len(edit.ModifiedParts))
+	}
+	if body := edit.ModifiedParts[0].Content.Body; body != "> Deployed\n> v1.2 is live" {
+		t.Errorf("body: got %q", body)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+)
+
+// autoInvite invites the auto_invite users of a portal's channel to its room,
+// skipping users invited before, and records them in the portal metadata.
+// It returns the number of users invited; the metadata changed if it's
+// non-zero. Portals without a room and team spaces are skipped.
+func (mc *MattermostConnector) autoInvite(ctx context.Context, portal *bridgev2.Portal, teamID string) int {
+	if portal.MXID == "" || portal.RoomType == database.RoomTypeSpace {
+		return 0
+	}
+	log := mc.ctxLog(ctx)
+	meta := portalMetadata(portal)
+	channelID := ParsePortalID(portal.ID)
+	invited := 0
+	for _, mxid := range mc.Config.AutoInvite.usersFor(channelID, teamID) {
+		if slices.Contains(meta.AutoInvited, mxid) {
+			continue
+		}
+		if err := mc.Bridge.Bot.EnsureInvited(ctx, portal.MXID, mxid); err != nil {
+			log.Warn().Err(err).
+				Str("channel_id", channelID).
+				Stringer("mxid", mxid).
+				Msg("Failed to auto-invite user to portal")
+			continue
+		}
+		meta.AutoInvited = append(meta.AutoInvited, mxid)
+		invited++
+	}
+	if invited > 0 {
+		log.Info().
+			Str("channel_id", channelID).
+			Stringer("room_id", portal.MXID).
+			Int("invited", invited).
+			Msg("Auto-invited users to portal")
+	}
+	return invited
+}
+
+// autoInviteUpdater returns a ChatInfo.ExtraUpdates hook that invites the
+// auto_invite users once the portal room exists, or nil if none are
+// configured.
+func (m *MattermostClient) autoInviteUpdater(channel *model.Channel) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	if !m.connector.Config.AutoInvite.enabled() {
+		return nil
+	}
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		return m.connector.autoInvite(ctx, portal, channel.TeamId) > 0
+	}
+}
+
+// checkAutoInvites invites the auto_invite users to every portal room they
+// haven't been invited to, which catches rooms whose creation didn't go
+// through a channel info update and users added to the config since. With
+// sharding, each process handles its own channels. It returns the number of
+// invites sent.
+func (mc *MattermostConnector) checkAutoInvites(ctx context.Context) (int, error) {
+	if !mc.Config.AutoInvite.enabled() || mc.Bridge == nil || mc.Bridge.DB == nil {
+		return 0, nil
+	}
+	portals, err := mc.Bridge.GetAllPortalsWithMXID(ctx)
+	if err != nil {
+		mc.Bridge.Log.Error().Err(err).Msg("WatchNewPortals: failed to get portals for auto-invites")
+		return 0, fmt.Errorf("failed to get portals: %w", err)
+	}
+	total := 0
+	for _, portal := range portals {
+		if !mc.OwnsChannel(ParsePortalID(portal.ID)) {
+			continue
+		}
+		invited := mc.autoInvite(ctx, portal, portalMetadata(portal).TeamID)
+		if invited == 0 {
+			continue
+		}
+		total += invited
+		if err := portal.Save(ctx); err != nil {
+			mc.Bridge.Log.Warn().Err(err).
+				Str("portal_mxid", string(portal.MXID)).
+				Msg("WatchNewPortals: failed to save auto-invites")
+		}
+	}
+	return total, nil
+}
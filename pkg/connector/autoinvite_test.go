@@ -0,0 +1,106 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/id"
+)
+
+// failingInviteBot is a fakeInviteBot that can't invite @broken:example.com.
+type failingInviteBot struct {
+	fakeInviteBot
+}
+
+func (b *failingInviteBot) EnsureInvited(ctx context.Context, roomID id.RoomID, userID id.UserID) error {
+	if userID == "@broken:example.com" {
+		return errors.New("invite failed")
+	}
+	return b.fakeInviteBot.EnsureInvited(ctx, roomID, userID)
+}
+
+func TestCheckAutoInvites(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{
+		relayTestChannel:  {TeamID: "t1"},
+		relayTestChannel2: {TeamID: "t2", AutoInvited: []id.UserID{"@ops:example.com"}},
+	})
+	bot := &failingInviteBot{}
+	mc.Bridge.Bot = bot
+	mc.Config.AutoInvite = AutoInviteConfig{
+		Users: []id.UserID{"@ops:example.com", "@broken:example.com"},
+		Teams: map[string][]id.UserID{"t1": {"@agent:example.com"}},
+	}
+
+	mc.checkAutoInvites(context.Background())
+
+	room1 := id.RoomID("!" + relayTestChannel + ":example.com")
+	room2 := id.RoomID("!" + relayTestChannel2 + ":example.com")
+	if got := bot.invited[room1]; !slices.Equal(got, []id.UserID{"@ops:example.com", "@agent:example.com"}) {
+		t.Errorf("invites to %s: got %v", room1, got)
+	}
+	if got := bot.invited[room2]; len(got) != 0 {
+		t.Errorf("users invited before should not be invited again, got %v", got)
+	}
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	if got := portalMetadata(portal).AutoInvited; !slices.Equal(got, []id.UserID{"@ops:example.com", "@agent:example.com"}) {
+		t.Errorf("saved auto-invites: got %v", got)
+	}
+
+	// Failed invites are retried, successful ones aren't.
+	bot.invited = nil
+	mc.checkAutoInvites(context.Background())
+	if len(bot.invited) != 0 {
+		t.Errorf("expected no new invites, got %v", bot.invited)
+	}
+}
+
+func TestCheckAutoInvites_Disabled(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	bot := &fakeInviteBot{}
+	mc.Bridge.Bot = bot
+
+	mc.checkAutoInvites(context.Background())
+	if len(bot.invited) != 0 {
+		t.Errorf("expected no invites without auto_invite users, got %v", bot.invited)
+	}
+}
+
+func TestAutoInviteUpdater(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	bot := &fakeInviteBot{}
+	mc.Bridge.Bot = bot
+	client := newTestClient()
+	client.connector = mc
+	if client.autoInviteUpdater(&model.Channel{Id: relayTestChannel}) != nil {
+		t.Error("updater should be nil without auto_invite users")
+	}
+
+	mc.Config.AutoInvite = AutoInviteConfig{Channels: map[string][]id.UserID{relayTestChannel: {"@lead:example.com"}}}
+	update := client.autoInviteUpdater(&model.Channel{Id: relayTestChannel, TeamId: "t1"})
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	portal.MXID = ""
+	if update(context.Background(), portal) {
+		t.Error("nothing should change before the room exists")
+	}
+	portal.MXID = "!" + relayTestChannel + ":example.com"
+	if !update(context.Background(), portal) {
+		t.Error("metadata should change once the room exists")
+	}
+	if update(context.Background(), portal) {
+		t.Error("a second update should not invite again")
+	}
+	if got := bot.invited[portal.MXID]; !slices.Equal(got, []id.UserID{"@lead:example.com"}) {
+		t.Errorf("invites: got %v", got)
+	}
+}
@@ -8,97 +8,520 @@ package connector
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
 	"maunium.net/go/mautrix/bridgev2/networkid"
 )
 
 // Compile-time assertion that MattermostClient implements BackfillingNetworkAPI.
 var _ bridgev2.BackfillingNetworkAPI = (*MattermostClient)(nil)
 
+// maxBackfillPageSize is the largest page the Mattermost posts API returns.
+const maxBackfillPageSize = 200
+
 // FetchMessages implements bridgev2.BackfillingNetworkAPI.
 func (m *MattermostClient) FetchMessages(ctx context.Context, params bridgev2.FetchMessagesParams) (*bridgev2.FetchMessagesResponse, error) {
+	start := time.Now()
+	defer func() { m.connector.metrics().backfillDuration.observe(time.Since(start)) }()
+	// A thread room only has the replies posted since it was split off:
+	// earlier ones stay in the channel's room, and missed ones are caught
+	// up on with the channel.
+	if !m.channelAllowed(ctx, ParsePortalID(params.Portal.ID), "", portalMetadata(params.Portal).TeamID) || isThreadPortal(params.Portal) {
+		return &bridgev2.FetchMessagesResponse{Forward: params.Forward}, nil
+	}
+	if params.ThreadRoot != "" {
+		return m.fetchThreadMessages(ctx, params)
+	}
 	channelID := ParsePortalID(params.Portal.ID)
 
-	maxCount := m.connector.Config.BackfillMaxCount
-	if maxCount <= 0 {
-		maxCount = 100
+	// Forward backfill with an anchor is catch-up after a reconnect; anything
+	// else fills history for a new portal or paginates backwards.
+	catchUp := params.Forward && params.AnchorMessage != nil
+	maxCount := m.backfillCount(params, catchUp)
+
+	posts, cursor, hasMore, err := m.fetchPostPages(ctx, channelID, params, maxCount)
+	if err != nil {
+		return nil, err
 	}
-	if params.Count > 0 {
-		maxCount = params.Count
+
+	// Sort chronologically (oldest first).
+	posts = orderThreads(posts)
+
+	if len(posts) > maxCount {
+		posts = posts[:maxCount]
 	}
 
-	perPage := maxCount
-	if perPage > 200 {
-		perPage = 200
+	// Replies need their root bridged first for the thread relation to
+	// resolve on Matrix. Pull in roots that fell outside this page.
+	posts, injectedRoots := m.resolveMissingRoots(ctx, params.Portal, channelID, posts)
+	if injectedRoots {
+		posts = orderThreads(posts)
 	}
 
-	var postList *model.PostList
-	var err error
+	resp := &bridgev2.FetchMessagesResponse{
+		Messages: m.backfillMessages(ctx, params.Portal, posts, false),
+		HasMore:  hasMore,
+		Forward:  params.Forward,
+		// A root pulled into an earlier batch shows up again on the page that
+		// actually contains it, so deduplicate against bridged messages.
+		AggressiveDeduplication: injectedRoots || hasThreadRoot(posts),
+		CompleteCallback:        m.backfillPinsCallback(ctx, params.Portal, posts),
+	}
 
-	if params.Forward && params.AnchorMessage != nil {
-		anchorPostID := ParseMessageID(params.AnchorMessage.ID)
-		postList, _, err = m.client.GetPostsAfter(ctx, channelID, anchorPostID, 0, perPage, "", false, false)
+	// The oldest fetched post is where the next backward page starts.
+	if !params.Forward && hasMore && cursor != "" {
+		resp.Cursor = networkid.PaginationCursor(cursor)
+	}
+
+	return resp, nil
+}
+
+// backfillCount returns the number of posts to fetch for a backfill request.
+func (m *MattermostClient) backfillCount(params bridgev2.FetchMessagesParams, catchUp bool) int {
+	maxCount := m.connector.liveConfig().backfillLimit(catchUp)
+	if params.Count > 0 {
+		// The bridge-level count is capped by the network-level limit for
+		// forward backfills, which happen at portal creation and reconnect.
+		if params.Forward {
+			return min(params.Count, maxCount)
+		}
+		return params.Count
+	}
+	return maxCount
+}
+
+// fetchPostPages fetches up to maxCount posts of a channel, one page at a
+// time. Catch-up walks forwards from the anchor; everything else walks
+// backwards from the cursor or anchor, or from the newest post. It returns
+// the post the next page would start from and whether the last page was
+// full, meaning more posts may be available.
+func (m *MattermostClient) fetchPostPages(ctx context.Context, channelID string, params bridgev2.FetchMessagesParams, maxCount int) ([]*model.Post, string, bool, error) {
+	forward := params.Forward && params.AnchorMessage != nil
+	var cursor string
+	if params.Cursor != "" && !params.Forward {
+		cursor = string(params.Cursor)
 	} else if params.AnchorMessage != nil {
-		anchorPostID := ParseMessageID(params.AnchorMessage.ID)
-		postList, _, err = m.client.GetPostsBefore(ctx, channelID, anchorPostID, 0, perPage, "", false, false)
-	} else {
-		postList, _, err = m.client.GetPostsForChannel(ctx, channelID, 0, perPage, "", false, false)
+		cursor = ParseMessageID(params.AnchorMessage.ID)
+	}
+
+	var posts []*model.Post
+	seen := make(map[string]bool)
+	hasMore := false
+	for len(posts) < maxCount {
+		if len(posts) > 0 {
+			if err := m.waitBackfillPage(ctx); err != nil {
+				return nil, "", false, err
+			}
+		}
+		perPage := min(maxCount-len(posts), maxBackfillPageSize)
+
+		var page *model.PostList
+		var err error
+		switch {
+		case forward:
+			page, _, err = m.client.GetPostsAfter(ctx, channelID, cursor, 0, perPage, "", false, false)
+		case cursor != "":
+			page, _, err = m.client.GetPostsBefore(ctx, channelID, cursor, 0, perPage, "", false, false)
+		default:
+			page, _, err = m.client.GetPostsForChannel(ctx, channelID, 0, perPage, "", false, false)
+		}
+		if err != nil {
+			return nil, "", false, fmt.Errorf("failed to fetch posts for backfill: %w", err)
+		}
+
+		// The next page starts at the oldest post of this one, or the newest
+		// when walking forwards.
+		var edge *model.Post
+		for _, post := range page.ToSlice() {
+			if seen[post.Id] {
+				continue
+			}
+			seen[post.Id] = true
+			posts = append(posts, post)
+			if edge == nil || (forward && post.CreateAt > edge.CreateAt) || (!forward && post.CreateAt < edge.CreateAt) {
+				edge = post
+			}
+		}
+		hasMore = len(page.Order) >= perPage
+		if edge == nil {
+			// A full page of posts we already have; stop rather than loop.
+			hasMore = false
+			break
+		}
+		cursor = edge.Id
+		if !hasMore {
+			break
+		}
 	}
+	return posts, cursor, hasMore, nil
+}
+
+// waitBackfillPage waits backfill.page_delay_ms between two page requests so
+// a large backfill doesn't hit the Mattermost rate limit.
+func (m *MattermostClient) waitBackfillPage(ctx context.Context) error {
+	delay := m.connector.Config.backfillPageDelay()
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// fetchThreadMessages backfills the replies of a thread. Mattermost returns a
+// whole thread in one request, so there are no pages: the replies after the
+// anchor are returned oldest first, up to the requested count.
+func (m *MattermostClient) fetchThreadMessages(ctx context.Context, params bridgev2.FetchMessagesParams) (*bridgev2.FetchMessagesResponse, error) {
+	channelID := ParsePortalID(params.Portal.ID)
+	rootID := ParseMessageID(params.ThreadRoot)
+	thread, _, err := m.client.GetPostThread(ctx, rootID, "", false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch posts for backfill: %w", err)
+		return nil, fmt.Errorf("failed to fetch thread for backfill: %w", err)
 	}
 
-	// Sort chronologically (oldest first).
-	posts := postList.ToSlice()
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].CreateAt < posts[j].CreateAt
-	})
+	var after int64
+	if params.AnchorMessage != nil && params.AnchorMessage.ID != params.ThreadRoot {
+		after = params.AnchorMessage.Timestamp.UnixMilli()
+	}
+	var replies []*model.Post
+	for _, post := range thread.Posts {
+		if post.RootId != rootID || post.ChannelId != channelID || post.DeleteAt != 0 || post.CreateAt < after {
+			continue
+		}
+		replies = append(replies, post)
+	}
+	replies = orderThreads(replies)
 
-	if len(posts) > maxCount {
-		posts = posts[:maxCount]
+	maxCount := m.backfillCount(params, false)
+	hasMore := len(replies) > maxCount
+	if hasMore {
+		replies = replies[:maxCount]
+	}
+	m.log.Debug().
+		Str("root_id", rootID).
+		Int("reply_count", len(replies)).
+		Msg("Fetched thread for backfill")
+
+	return &bridgev2.FetchMessagesResponse{
+		Messages: m.backfillMessages(ctx, params.Portal, replies, true),
+		HasMore:  hasMore,
+		Forward:  params.Forward,
+		// Replies in the channel's timeline may have been bridged already.
+		AggressiveDeduplication: true,
+		CompleteCallback:        m.backfillPinsCallback(ctx, params.Portal, replies),
+	}, nil
+}
+
+// backfillMessages converts posts for a backfill response, skipping system
+// posts and posts that are already bridged. Outside a thread backfill, roots
+// with replies beyond the batch are marked for a thread backfill.
+func (m *MattermostClient) backfillMessages(ctx context.Context, portal *bridgev2.Portal, posts []*model.Post, inThread bool) []*bridgev2.BackfillMessage {
+	repliesInBatch := make(map[string]int64)
+	for _, post := range posts {
+		if post.RootId != "" {
+			repliesInBatch[post.RootId]++
+		}
 	}
 
 	var messages []*bridgev2.BackfillMessage
 	for _, post := range posts {
 		// Skip system messages.
-		if post.Type != "" && post.Type != model.PostTypeDefault {
+		if post.Type != "" && post.Type != model.PostTypeDefault && post.Type != matterpollPostType {
+			continue
+		}
+		if m.isExcludedGuest(post.UserId) {
+			continue
+		}
+		if _, ok := m.systemUsername(post.UserId, ""); ok {
+			continue
+		}
+
+		// Re-running backfill or catching up can return posts that are
+		// already bridged. Only their new edits and reactions need bridging.
+		if m.isMessageBridged(ctx, portal, post.Id) {
+			m.catchUpEdit(ctx, portal, post)
+			m.catchUpReactions(ctx, portal, post)
 			continue
 		}
 
-		converted := m.convertPostToMatrix(post)
+		converted := m.convertPostToMatrix(ctx, portal, portalBot(portal), post)
 
-		msg := &bridgev2.BackfillMessage{
+		messages = append(messages, &bridgev2.BackfillMessage{
 			ConvertedMessage: converted,
 			Sender: bridgev2.EventSender{
 				Sender: MakeUserID(post.UserId),
 			},
-			ID:        MakeMessageID(post.Id),
-			Timestamp: time.UnixMilli(post.CreateAt),
+			ID:                   MakeMessageID(post.Id),
+			Timestamp:            time.UnixMilli(post.CreateAt),
+			Reactions:            m.backfillReactions(post),
+			ShouldBackfillThread: !inThread && post.RootId == "" && post.ReplyCount > repliesInBatch[post.Id],
+		})
+	}
+	return messages
+}
+
+// orderThreads sorts posts chronologically and guarantees every thread root
+// comes before its replies. CreateAt alone isn't enough: imported posts and
+// clock skew between HA nodes can give a reply the same or an earlier
+// timestamp than its root.
+func orderThreads(posts []*model.Post) []*model.Post {
+	sort.SliceStable(posts, func(i, j int) bool {
+		if posts[i].CreateAt != posts[j].CreateAt {
+			return posts[i].CreateAt < posts[j].CreateAt
 		}
+		// On a tie, roots go first.
+		return posts[i].RootId == "" && posts[j].RootId != ""
+	})
 
-		if post.RootId != "" {
-			msg.ShouldBackfillThread = true
+	inBatch := make(map[string]bool, len(posts))
+	for _, post := range posts {
+		inBatch[post.Id] = true
+	}
+	emitted := make(map[string]bool, len(posts))
+	waiting := make(map[string][]*model.Post)
+	ordered := make([]*model.Post, 0, len(posts))
+	var emit func(post *model.Post)
+	emit = func(post *model.Post) {
+		ordered = append(ordered, post)
+		emitted[post.Id] = true
+		for _, reply := range waiting[post.Id] {
+			emit(reply)
 		}
+		delete(waiting, post.Id)
+	}
+	for _, post := range posts {
+		if post.RootId != "" && inBatch[post.RootId] && !emitted[post.RootId] {
+			waiting[post.RootId] = append(waiting[post.RootId], post)
+			continue
+		}
+		emit(post)
+	}
+	return ordered
+}
 
-		messages = append(messages, msg)
+// resolveMissingRoots fetches the thread roots of replies whose root is
+// neither in posts nor already bridged, and appends them to posts. Roots
+// from other channels (cross-posted threads) are ignored. The second return
+// value reports whether any root was added.
+func (m *MattermostClient) resolveMissingRoots(ctx context.Context, portal *bridgev2.Portal, channelID string, posts []*model.Post) ([]*model.Post, bool) {
+	inBatch := make(map[string]bool, len(posts))
+	for _, post := range posts {
+		inBatch[post.Id] = true
 	}
 
-	hasMore := len(postList.Order) >= perPage
+	added := false
+	checked := make(map[string]bool)
+	for _, post := range posts {
+		rootID := post.RootId
+		if rootID == "" || inBatch[rootID] || checked[rootID] {
+			continue
+		}
+		checked[rootID] = true
+		if m.isMessageBridged(ctx, portal, rootID) {
+			continue
+		}
+		thread, _, err := m.client.GetPostThread(ctx, rootID, "", false)
+		if err != nil {
+			m.log.Warn().Err(err).Str("root_id", rootID).Msg("Failed to fetch thread root for backfill")
+			continue
+		}
+		root, ok := thread.Posts[rootID]
+		if !ok || root.ChannelId != channelID || root.DeleteAt != 0 {
+			continue
+		}
+		m.log.Debug().Str("root_id", rootID).Str("reply_id", post.Id).Msg("Adding missing thread root to backfill batch")
+		posts = append(posts, root)
+		inBatch[rootID] = true
+		added = true
+	}
+	return posts, added
+}
 
-	resp := &bridgev2.FetchMessagesResponse{
-		Messages: messages,
-		HasMore:  hasMore,
-		Forward:  params.Forward,
+// isMessageBridged reports whether the post already has a Matrix event.
+func (m *MattermostClient) isMessageBridged(ctx context.Context, portal *bridgev2.Portal, postID string) bool {
+	if m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return false
 	}
+	msg, err := m.connector.Bridge.DB.Message.GetFirstPartByID(ctx, portal.Receiver, MakeMessageID(postID))
+	if err != nil {
+		m.log.Warn().Err(err).Str("post_id", postID).Msg("Failed to check for bridged message")
+		return false
+	}
+	return msg != nil
+}
 
-	// Use PrevPostId as cursor for backward pagination.
-	if !params.Forward && postList.PrevPostId != "" {
-		resp.Cursor = networkid.PaginationCursor(postList.PrevPostId)
+// hasThreadRoot reports whether any post in the batch has replies.
+func hasThreadRoot(posts []*model.Post) bool {
+	for _, post := range posts {
+		if post.ReplyCount > 0 {
+			return true
+		}
 	}
+	return false
+}
 
-	return resp, nil
+// reactionKey identifies a reaction on a post: one per user and emoji.
+type reactionKey struct {
+	userID networkid.UserID
+	emoji  networkid.EmojiID
+}
+
+// postReactions returns the reactions in a post's metadata with duplicates
+// and echoes of bridged Matrix reactions removed.
+func (m *MattermostClient) postReactions(post *model.Post) []*model.Reaction {
+	if post.Metadata == nil || len(post.Metadata.Reactions) == 0 {
+		return nil
+	}
+	seen := make(map[reactionKey]bool, len(post.Metadata.Reactions))
+	reactions := make([]*model.Reaction, 0, len(post.Metadata.Reactions))
+	for _, reaction := range post.Metadata.Reactions {
+		// Echo prevention, as for live reactions.
+		if reaction.UserId == m.userID || m.connector.IsPuppetUserID(reaction.UserId) {
+			continue
+		}
+		if reaction.PostId == "" {
+			reaction.PostId = post.Id
+		}
+		key := reactionKey{MakeUserID(reaction.UserId), MakeEmojiID(reaction.EmojiName)}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		reactions = append(reactions, reaction)
+	}
+	return reactions
+}
+
+// backfillReactions converts a post's reactions for a backfilled message.
+func (m *MattermostClient) backfillReactions(post *model.Post) []*bridgev2.BackfillReaction {
+	reactions := m.postReactions(post)
+	if len(reactions) == 0 {
+		return nil
+	}
+	out := make([]*bridgev2.BackfillReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		out = append(out, &bridgev2.BackfillReaction{
+			Timestamp: time.UnixMilli(reaction.CreateAt),
+			Sender:    m.senderFor(reaction.UserId),
+			EmojiID:   MakeEmojiID(reaction.EmojiName),
+			Emoji:     m.connector.emojiOverrides().reactionToEmoji(reaction.EmojiName),
+		})
+	}
+	return out
+}
+
+// catchUpReactions queues the reactions on an already-bridged post that
+// aren't in the reaction table yet, e.g. ones added while disconnected.
+func (m *MattermostClient) catchUpReactions(ctx context.Context, portal *bridgev2.Portal, post *model.Post) {
+	reactions := m.postReactions(post)
+	if len(reactions) == 0 {
+		return
+	}
+	existing, err := m.connector.Bridge.DB.Reaction.GetAllToMessage(ctx, portal.Receiver, MakeMessageID(post.Id))
+	if err != nil {
+		m.log.Warn().Err(err).Str("post_id", post.Id).Msg("Failed to get bridged reactions")
+		return
+	}
+	bridged := make(map[reactionKey]bool, len(existing))
+	for _, reaction := range existing {
+		bridged[reactionKey{reaction.SenderID, reaction.EmojiID}] = true
+	}
+	for _, reaction := range reactions {
+		if bridged[reactionKey{MakeUserID(reaction.UserId), MakeEmojiID(reaction.EmojiName)}] {
+			continue
+		}
+		m.log.Debug().
+			Str("post_id", post.Id).
+			Str("user_id", reaction.UserId).
+			Str("emoji", reaction.EmojiName).
+			Msg("Bridging missed reaction")
+		m.queueReaction(ParsePortalID(portal.ID), reaction)
+	}
+}
+
+// markEditAt records the edit time of an edited post on its text part, so a
+// later backfill can tell whether an edit was missed.
+func markEditAt(parts []*bridgev2.ConvertedMessagePart, editAt int64) {
+	if editAt == 0 {
+		return
+	}
+	for _, part := range parts {
+		meta, ok := part.DBMetadata.(*MessageMetadata)
+		if !ok || meta == nil {
+			meta = &MessageMetadata{}
+			part.DBMetadata = meta
+		}
+		if meta.FileID == "" {
+			meta.EditAt = editAt
+		}
+	}
+}
+
+// setEditAt records the edit time of a post on its bridged text part, which
+// the framework saves after sending the edit.
+func setEditAt(part *database.Message, editAt int64) {
+	meta, ok := part.Metadata.(*MessageMetadata)
+	if !ok || meta == nil {
+		meta = &MessageMetadata{}
+		part.Metadata = meta
+	}
+	meta.EditAt = editAt
+}
+
+// catchUpEdit queues the edit of an already-bridged post that was edited
+// after its text was last bridged, e.g. while disconnected. Text parts
+// edited live before edit times were recorded are assumed up to date.
+func (m *MattermostClient) catchUpEdit(ctx context.Context, portal *bridgev2.Portal, post *model.Post) {
+	if post.EditAt == 0 || post.Type == matterpollPostType {
+		return
+	}
+	parts, err := m.connector.Bridge.DB.Message.GetAllPartsByID(ctx, portal.Receiver, MakeMessageID(post.Id))
+	if err != nil {
+		m.log.Warn().Err(err).Str("post_id", post.Id).Msg("Failed to get bridged message parts")
+		return
+	}
+	textPart, _, _, _ := matchEditParts(post, parts)
+	if textPart == nil {
+		return
+	}
+	lastEditAt := messageMetadata(textPart).EditAt
+	if post.EditAt <= lastEditAt || (lastEditAt == 0 && textPart.EditCount > 0) {
+		return
+	}
+	m.log.Debug().Str("post_id", post.Id).Msg("Bridging missed edit")
+	m.queuePostEdit(post)
+}
+
+// backfillPinsCallback returns a backfill CompleteCallback that sets the
+// room's pinned events once a batch holding pinned posts is bridged, since
+// pins can only point to bridged events. It returns nil when no post is
+// pinned.
+func (m *MattermostClient) backfillPinsCallback(ctx context.Context, portal *bridgev2.Portal, posts []*model.Post) func() {
+	if !slices.ContainsFunc(posts, func(post *model.Post) bool { return post.IsPinned }) {
+		return nil
+	}
+	channelID := ParsePortalID(portal.ID)
+	return func() {
+		pinned, _, err := m.client.GetPinnedPosts(ctx, channelID, "")
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to get pinned posts after backfill")
+			return
+		}
+		if !m.pinnedUpdater(channelID, pinned.Order)(ctx, portal) {
+			return
+		}
+		if err := portal.Save(ctx); err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to save pinned events")
+		}
+	}
 }
@@ -7,7 +7,9 @@ package connector
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +18,8 @@ import (
 	"maunium.net/go/mautrix/bridgev2"
 	"maunium.net/go/mautrix/bridgev2/database"
 	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
 )
 
 // makePostList creates a model.PostList from a slice of posts, ordered newest first.
@@ -424,8 +428,9 @@ func TestFetchMessages_MessageContent(t *testing.T) {
 	}
 }
 
-// TestFetchMessages_ThreadPost verifies that reply posts have ShouldBackfillThread
-// set and include ReplyTo references.
+// TestFetchMessages_ThreadPost verifies that replies include ReplyTo
+// references and that only roots with replies outside the batch are marked
+// for thread backfill.
 func TestFetchMessages_ThreadPost(t *testing.T) {
 	t.Parallel()
 	fake := newFakeMM()
@@ -433,8 +438,9 @@ func TestFetchMessages_ThreadPost(t *testing.T) {
 
 	now := time.Now().UnixMilli()
 	posts := []*model.Post{
-		{Id: "parent", ChannelId: "ch1", UserId: "user1", Message: "parent msg", CreateAt: now - 2000},
-		{Id: "reply", ChannelId: "ch1", UserId: "user2", Message: "reply msg", CreateAt: now - 1000, RootId: "parent"},
+		{Id: "parent", ChannelId: "ch1", UserId: "user1", Message: "parent msg", CreateAt: now - 3000, ReplyCount: 1},
+		{Id: "reply", ChannelId: "ch1", UserId: "user2", Message: "reply msg", CreateAt: now - 2000, RootId: "parent"},
+		{Id: "long", ChannelId: "ch1", UserId: "user1", Message: "long thread", CreateAt: now - 1000, ReplyCount: 3},
 	}
 	fake.Posts["ch1"] = makePostList(posts)
 
@@ -448,18 +454,19 @@ func TestFetchMessages_ThreadPost(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if len(resp.Messages) != 2 {
-		t.Fatalf("expected 2 messages, got %d", len(resp.Messages))
+	if len(resp.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(resp.Messages))
 	}
 
-	// Parent should not have ShouldBackfillThread.
+	// All of parent's replies are in the batch.
 	if resp.Messages[0].ShouldBackfillThread {
 		t.Error("parent message should not have ShouldBackfillThread")
 	}
-
-	// Reply should have ShouldBackfillThread set.
-	if !resp.Messages[1].ShouldBackfillThread {
-		t.Error("reply message should have ShouldBackfillThread set")
+	if resp.Messages[1].ShouldBackfillThread {
+		t.Error("reply message should not have ShouldBackfillThread")
+	}
+	if !resp.Messages[2].ShouldBackfillThread {
+		t.Error("root with replies outside the batch should have ShouldBackfillThread")
 	}
 
 	// Reply should have ReplyTo set in the converted message.
@@ -468,7 +475,250 @@ func TestFetchMessages_ThreadPost(t *testing.T) {
 	}
 }
 
-// TestFetchMessages_CancelledContext verifies that a cancelled context is
+// makeChannelPosts returns n posts in ch1, one second apart, oldest first.
+func makeChannelPosts(n int) []*model.Post {
+	posts := make([]*model.Post, 0, n)
+	for i := range n {
+		posts = append(posts, &model.Post{
+			Id:        fmt.Sprintf("post%03d", i),
+			ChannelId: "ch1",
+			UserId:    "user1",
+			Message:   "msg",
+			CreateAt:  int64(i+1) * 1000,
+		})
+	}
+	return posts
+}
+
+// countPostPages returns the number of channel posts requests made.
+func countPostPages(fake *fakeMM) int {
+	n := 0
+	for _, c := range fake.Calls() {
+		if c.Method == http.MethodGet && strings.HasSuffix(c.Path, "/posts") {
+			n++
+		}
+	}
+	return n
+}
+
+// TestFetchMessages_Pagination verifies that backfills larger than one page
+// are fetched page by page, backwards and forwards.
+func TestFetchMessages_Pagination(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		total     int
+		limit     int
+		params    bridgev2.FetchMessagesParams
+		wantFirst string
+		wantLast  string
+		wantPages int
+		wantMore  bool
+	}{
+		{
+			name:      "backward from newest",
+			total:     500,
+			limit:     450,
+			wantFirst: "post050",
+			wantLast:  "post499",
+			wantPages: 3,
+			wantMore:  true,
+		},
+		{
+			name:      "backward to start of channel",
+			total:     250,
+			limit:     400,
+			wantFirst: "post000",
+			wantLast:  "post249",
+			wantPages: 2,
+		},
+		{
+			name:      "backward from anchor",
+			total:     500,
+			limit:     300,
+			params:    bridgev2.FetchMessagesParams{AnchorMessage: &database.Message{ID: MakeMessageID("post400")}},
+			wantFirst: "post100",
+			wantLast:  "post399",
+			wantPages: 2,
+			wantMore:  true,
+		},
+		{
+			name:      "backward from cursor",
+			total:     500,
+			limit:     250,
+			params:    bridgev2.FetchMessagesParams{AnchorMessage: &database.Message{ID: MakeMessageID("post400")}, Cursor: "post300"},
+			wantFirst: "post050",
+			wantLast:  "post299",
+			wantPages: 2,
+			wantMore:  true,
+		},
+		{
+			name:      "forward catch-up",
+			total:     500,
+			limit:     300,
+			params:    bridgev2.FetchMessagesParams{Forward: true, AnchorMessage: &database.Message{ID: MakeMessageID("post099")}},
+			wantFirst: "post100",
+			wantLast:  "post399",
+			wantPages: 2,
+			wantMore:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.PaginatePosts = true
+			fake.Posts["ch1"] = makePostList(makeChannelPosts(tt.total))
+
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.Backfill = BackfillConfig{InitialLimit: tt.limit, MissedLimit: tt.limit}
+			params := tt.params
+			params.Portal = makeTestPortal("ch1")
+
+			resp, err := mc.FetchMessages(context.Background(), params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(resp.Messages) == 0 {
+				t.Fatal("expected messages")
+			}
+			first := ParseMessageID(resp.Messages[0].ID)
+			last := ParseMessageID(resp.Messages[len(resp.Messages)-1].ID)
+			if first != tt.wantFirst || last != tt.wantLast {
+				t.Errorf("range: got %s..%s, want %s..%s", first, last, tt.wantFirst, tt.wantLast)
+			}
+			for i := 1; i < len(resp.Messages); i++ {
+				if !resp.Messages[i].Timestamp.After(resp.Messages[i-1].Timestamp) {
+					t.Fatalf("messages not in chronological order at %d", i)
+				}
+			}
+			if got := countPostPages(fake); got != tt.wantPages {
+				t.Errorf("pages: got %d, want %d", got, tt.wantPages)
+			}
+			if resp.HasMore != tt.wantMore {
+				t.Errorf("HasMore: got %v, want %v", resp.HasMore, tt.wantMore)
+			}
+			if !params.Forward && tt.wantMore && string(resp.Cursor) != tt.wantFirst {
+				t.Errorf("cursor: got %q, want %q", resp.Cursor, tt.wantFirst)
+			}
+		})
+	}
+}
+
+// TestFetchMessages_PageDelay verifies that the pause between pages is
+// applied and interrupted by context cancellation.
+func TestFetchMessages_PageDelay(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.PaginatePosts = true
+	fake.Posts["ch1"] = makePostList(makeChannelPosts(300))
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.Backfill = BackfillConfig{InitialLimit: 300, PageDelayMS: 50}
+
+	start := time.Now()
+	resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{Portal: makeTestPortal("ch1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) != 300 {
+		t.Errorf("expected 300 messages, got %d", len(resp.Messages))
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected a pause between pages, took %v", elapsed)
+	}
+
+	mc.connector.Config.Backfill.PageDelayMS = 60000
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = mc.FetchMessages(ctx, bridgev2.FetchMessagesParams{Portal: makeTestPortal("ch1")})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded during page delay, got %v", err)
+	}
+}
+
+// TestFetchMessages_Thread verifies that thread backfill returns the replies
+// after the anchor from the post thread API.
+func TestFetchMessages_Thread(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	fake.Posts["ch1"] = makePostList([]*model.Post{
+		{Id: "root", ChannelId: "ch1", UserId: "user1", Message: "root", CreateAt: 1000, ReplyCount: 4},
+		{Id: "r1", ChannelId: "ch1", UserId: "user2", Message: "one", RootId: "root", CreateAt: 2000},
+		{Id: "r2", ChannelId: "ch1", UserId: "user1", Message: "two", RootId: "root", CreateAt: 3000},
+		{Id: "r3", ChannelId: "ch1", UserId: "user2", Message: "three", RootId: "root", CreateAt: 4000},
+		{Id: "r4", ChannelId: "ch1", UserId: "user2", Message: "four", RootId: "root", CreateAt: 5000, DeleteAt: 6000},
+		{Id: "other", ChannelId: "ch1", UserId: "user2", Message: "other", CreateAt: 4500},
+	})
+
+	tests := []struct {
+		name     string
+		anchor   *database.Message
+		count    int
+		want     []string
+		wantMore bool
+	}{
+		{name: "from root", anchor: &database.Message{ID: MakeMessageID("root"), Timestamp: time.UnixMilli(1000)}, want: []string{"r1", "r2", "r3"}},
+		{name: "from last reply", anchor: &database.Message{ID: MakeMessageID("r1"), Timestamp: time.UnixMilli(2000)}, want: []string{"r1", "r2", "r3"}},
+		{name: "count limit", anchor: &database.Message{ID: MakeMessageID("root"), Timestamp: time.UnixMilli(1000)}, count: 2, want: []string{"r1", "r2"}, wantMore: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient(fake.Server.URL)
+			resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{
+				Portal:        makeTestPortal("ch1"),
+				ThreadRoot:    MakeMessageID("root"),
+				Forward:       true,
+				AnchorMessage: tt.anchor,
+				Count:         tt.count,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []string
+			for _, msg := range resp.Messages {
+				ids = append(ids, ParseMessageID(msg.ID))
+				if msg.ShouldBackfillThread {
+					t.Errorf("%s: thread replies shouldn't request thread backfill", msg.ID)
+				}
+				if msg.ReplyTo == nil || msg.ReplyTo.MessageID != MakeMessageID("root") {
+					t.Errorf("%s: expected reply to root", msg.ID)
+				}
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("messages: got %v, want %v", ids, tt.want)
+			}
+			if resp.HasMore != tt.wantMore {
+				t.Errorf("HasMore: got %v, want %v", resp.HasMore, tt.wantMore)
+			}
+			if !resp.AggressiveDeduplication {
+				t.Error("thread backfill should deduplicate")
+			}
+		})
+	}
+}
+
+// TestFetchMessages_ThreadError verifies that thread fetch errors are wrapped.
+func TestFetchMessages_ThreadError(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	mc := newFullTestClient(fake.Server.URL)
+	_, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{
+		Portal:     makeTestPortal("ch1"),
+		ThreadRoot: MakeMessageID("missing"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch thread for backfill") {
+		t.Errorf("expected wrapped thread fetch error, got %v", err)
+	}
+}
+
 // propagated to the API call and results in an error.
 func TestFetchMessages_CancelledContext(t *testing.T) {
 	t.Parallel()
@@ -540,3 +790,429 @@ func TestFetchMessages_LargeMaxCount(t *testing.T) {
 		t.Fatal("expected non-nil response for large max count")
 	}
 }
+
+// TestFetchMessages_ForwardCappedByConfig verifies that forward backfills are
+// capped by initial_limit (new portal) and missed_limit (catch-up).
+func TestFetchMessages_ForwardCappedByConfig(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	now := time.Now().UnixMilli()
+	var posts []*model.Post
+	for i := range 10 {
+		posts = append(posts, &model.Post{
+			Id:        fmt.Sprintf("post%d", i),
+			ChannelId: "ch1",
+			UserId:    "user1",
+			Message:   "msg",
+			CreateAt:  now - int64((10-i)*1000),
+		})
+	}
+	fake.Posts["ch1"] = makePostList(posts)
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.Backfill = BackfillConfig{InitialLimit: 4, MissedLimit: 2}
+	portal := makeTestPortal("ch1")
+
+	resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{
+		Portal:  portal,
+		Forward: true,
+		Count:   50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) != 4 {
+		t.Errorf("initial: expected 4 messages (initial_limit), got %d", len(resp.Messages))
+	}
+
+	resp, err = mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{
+		Portal:        portal,
+		Forward:       true,
+		Count:         50,
+		AnchorMessage: &database.Message{ID: MakeMessageID("post0")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) > 2 {
+		t.Errorf("catch-up: expected at most 2 messages (missed_limit), got %d", len(resp.Messages))
+	}
+}
+
+func TestOrderThreads(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		posts []*model.Post
+		want  []string
+	}{
+		{
+			name: "chronological",
+			posts: []*model.Post{
+				{Id: "b", CreateAt: 2},
+				{Id: "a", CreateAt: 1},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "reply timestamp before root",
+			posts: []*model.Post{
+				{Id: "reply", RootId: "root", CreateAt: 1},
+				{Id: "root", CreateAt: 2},
+				{Id: "other", CreateAt: 3},
+			},
+			want: []string{"root", "reply", "other"},
+		},
+		{
+			name: "same timestamp",
+			posts: []*model.Post{
+				{Id: "reply", RootId: "root", CreateAt: 5},
+				{Id: "root", CreateAt: 5},
+			},
+			want: []string{"root", "reply"},
+		},
+		{
+			name: "root outside batch",
+			posts: []*model.Post{
+				{Id: "reply", RootId: "missing", CreateAt: 2},
+				{Id: "a", CreateAt: 1},
+			},
+			want: []string{"a", "reply"},
+		},
+		{
+			name: "several replies keep order",
+			posts: []*model.Post{
+				{Id: "r1", RootId: "root", CreateAt: 1},
+				{Id: "r2", RootId: "root", CreateAt: 2},
+				{Id: "root", CreateAt: 3},
+			},
+			want: []string{"root", "r1", "r2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, post := range orderThreads(tt.posts) {
+				got = append(got, post.Id)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFetchMessages_ResolvesMissingRoot verifies that a reply whose root is
+// not in the fetched page gets its root fetched and emitted first.
+func TestFetchMessages_ResolvesMissingRoot(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	now := time.Now().UnixMilli()
+	// The root lives on an older page, reachable only via GetPostThread.
+	fake.Posts["older"] = makePostList([]*model.Post{
+		{Id: "root", ChannelId: "ch1", UserId: "user1", Message: "root", CreateAt: now - 10000, ReplyCount: 1},
+		{Id: "foreign-root", ChannelId: "other-ch", UserId: "user1", Message: "x", CreateAt: now - 9000},
+	})
+	fake.Posts["ch1"] = makePostList([]*model.Post{
+		{Id: "reply", ChannelId: "ch1", UserId: "user2", Message: "reply", RootId: "root", CreateAt: now - 1000},
+		{Id: "cross", ChannelId: "ch1", UserId: "user2", Message: "cross", RootId: "foreign-root", CreateAt: now - 900},
+		{Id: "orphan", ChannelId: "ch1", UserId: "user2", Message: "orphan", RootId: "gone", CreateAt: now - 800},
+	})
+
+	mc := newFullTestClient(fake.Server.URL)
+	resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{
+		Portal: makeTestPortal("ch1"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, msg := range resp.Messages {
+		ids = append(ids, ParseMessageID(msg.ID))
+	}
+	want := []string{"root", "reply", "cross", "orphan"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("messages: got %v, want %v", ids, want)
+	}
+	if !resp.AggressiveDeduplication {
+		t.Error("AggressiveDeduplication should be set when roots were added")
+	}
+	if !fake.CalledPath("/api/v4/posts/root/thread") {
+		t.Error("expected GetPostThread for the missing root")
+	}
+}
+
+// TestFetchMessages_NoThreadsNoDedup verifies that plain batches don't
+// request deduplication.
+func TestFetchMessages_NoThreadsNoDedup(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	fake.Posts["ch1"] = makePostList([]*model.Post{
+		{Id: "p1", ChannelId: "ch1", UserId: "user1", Message: "hi", CreateAt: 1},
+	})
+
+	mc := newFullTestClient(fake.Server.URL)
+	resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{
+		Portal: makeTestPortal("ch1"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.AggressiveDeduplication {
+		t.Error("AggressiveDeduplication should not be set without threads")
+	}
+}
+
+// TestFetchMessages_Reactions verifies that reactions in post metadata are
+// attached to backfilled messages, without duplicates or echoes.
+func TestFetchMessages_Reactions(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	fake.Posts["ch1"] = makePostList([]*model.Post{{
+		Id: "p1", ChannelId: "ch1", UserId: "user1", Message: "hi", CreateAt: 1000,
+		Metadata: &model.PostMetadata{Reactions: []*model.Reaction{
+			{UserId: "user2", PostId: "p1", EmojiName: "+1", CreateAt: 2000},
+			{UserId: "user2", PostId: "p1", EmojiName: "+1", CreateAt: 2000},
+			{UserId: "user3", PostId: "p1", EmojiName: "+1", CreateAt: 3000},
+			{UserId: "my-user-id", PostId: "p1", EmojiName: "heart", CreateAt: 4000},
+		}},
+	}})
+
+	mc := newFullTestClient(fake.Server.URL)
+	resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{
+		Portal: makeTestPortal("ch1"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(resp.Messages))
+	}
+	reactions := resp.Messages[0].Reactions
+	if len(reactions) != 2 {
+		t.Fatalf("expected 2 reactions (deduplicated, own reaction skipped), got %d", len(reactions))
+	}
+	if reactions[0].Sender.Sender != MakeUserID("user2") || reactions[0].EmojiID != MakeEmojiID("+1") {
+		t.Errorf("first reaction: got %+v", reactions[0])
+	}
+	if !reactions[0].Timestamp.Equal(time.UnixMilli(2000)) {
+		t.Errorf("reaction timestamp: got %v", reactions[0].Timestamp)
+	}
+}
+
+// TestFetchMessages_CatchUpReactionsSkipsBridged verifies that re-fetching an
+// already-bridged post only queues its reactions missing from the database.
+func TestFetchMessages_CatchUpReactionsSkipsBridged(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	fake.Posts["ch1"] = makePostList([]*model.Post{
+		{
+			Id: "p1", ChannelId: "ch1", UserId: "user1", Message: "old", CreateAt: 1000,
+			Metadata: &model.PostMetadata{Reactions: []*model.Reaction{
+				{UserId: "user2", PostId: "p1", EmojiName: "+1", CreateAt: 2000},
+				{UserId: "user3", PostId: "p1", EmojiName: "heart", CreateAt: 3000},
+			}},
+		},
+		{Id: "p2", ChannelId: "ch1", UserId: "user1", Message: "new", CreateAt: 5000},
+	})
+
+	ctx := context.Background()
+	db := newTestBridgeDB(t)
+	portal := makeTestPortal("ch1")
+	portal.Portal = db.Portal.New()
+	portal.PortalKey = makePortalKey("ch1")
+	if err := db.Portal.Insert(ctx, portal.Portal); err != nil {
+		t.Fatalf("insert portal: %v", err)
+	}
+	if err := db.Message.Insert(ctx, &database.Message{
+		ID: MakeMessageID("p1"), MXID: "$p1", Room: portal.PortalKey,
+		SenderID: MakeUserID("user1"), Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	if err := db.Reaction.Upsert(ctx, &database.Reaction{
+		Room: portal.PortalKey, MessageID: MakeMessageID("p1"), SenderID: MakeUserID("user2"),
+		EmojiID: MakeEmojiID("+1"), Emoji: "👍", MXID: "$r1", Timestamp: time.UnixMilli(2000),
+	}); err != nil {
+		t.Fatalf("insert reaction: %v", err)
+	}
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Bridge.DB = db
+	mock := testMock(mc)
+
+	resp, err := mc.FetchMessages(ctx, bridgev2.FetchMessagesParams{Portal: portal})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) != 1 || resp.Messages[0].ID != MakeMessageID("p2") {
+		t.Fatalf("expected only the unbridged post p2, got %d messages", len(resp.Messages))
+	}
+
+	events := mock.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 queued reaction, got %d", len(events))
+	}
+	reaction, ok := events[0].(*simplevent.Reaction)
+	if !ok {
+		t.Fatalf("expected *simplevent.Reaction, got %T", events[0])
+	}
+	if reaction.Sender.Sender != MakeUserID("user3") || reaction.EmojiID != MakeEmojiID("heart") {
+		t.Errorf("queued reaction: sender %q emoji %q", reaction.Sender.Sender, reaction.EmojiID)
+	}
+	if reaction.TargetMessage != MakeMessageID("p1") || reaction.PortalKey != makePortalKey("ch1") {
+		t.Errorf("queued reaction target: %q in %v", reaction.TargetMessage, reaction.PortalKey)
+	}
+}
+
+// TestFetchMessages_EditedPost verifies that a backfilled edited post records
+// its edit time on the text part only.
+func TestFetchMessages_EditedPost(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Files["f1"] = &model.FileInfo{Id: "f1", Name: "cat.png", MimeType: "image/png", Width: 640, Height: 480}
+	fake.Posts["ch1"] = makePostList([]*model.Post{{
+		Id: "p1", ChannelId: "ch1", UserId: "user1", Message: "fixed", CreateAt: 1000, EditAt: 3000, FileIds: []string{"f1"},
+	}})
+
+	mc := newFullTestClient(fake.Server.URL)
+	resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{Portal: makeTestPortal("ch1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) != 1 || len(resp.Messages[0].Parts) != 2 {
+		t.Fatalf("expected 1 message with 2 parts, got %+v", resp.Messages)
+	}
+	text, file := resp.Messages[0].Parts[0], resp.Messages[0].Parts[1]
+	if meta, _ := text.DBMetadata.(*MessageMetadata); meta == nil || meta.EditAt != 3000 || text.Content.Body != "fixed" {
+		t.Errorf("text part: metadata %+v, body %q", text.DBMetadata, text.Content.Body)
+	}
+	if meta, _ := file.DBMetadata.(*MessageMetadata); meta == nil || meta.EditAt != 0 || meta.FileID != "f1" {
+		t.Errorf("file part metadata: %+v", file.DBMetadata)
+	}
+	if info := file.Content.Info; info == nil || info.Width != 640 || info.Height != 480 {
+		t.Errorf("file info: %+v", info)
+	}
+}
+
+// TestFetchMessages_CatchUpEdit verifies that re-fetching an already-bridged
+// post queues its edit only when the edit wasn't bridged yet.
+func TestFetchMessages_CatchUpEdit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		editAt    int64
+		meta      *MessageMetadata
+		editCount int
+		want      bool
+	}{
+		{name: "missed edit", editAt: 3000, want: true},
+		{name: "newer edit", editAt: 3000, meta: &MessageMetadata{EditAt: 2000}, want: true},
+		{name: "bridged edit", editAt: 3000, meta: &MessageMetadata{EditAt: 3000}},
+		{name: "edited before edit times", editAt: 3000, editCount: 1},
+		{name: "never edited"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.Posts["ch1"] = makePostList([]*model.Post{{
+				Id: "p1", ChannelId: "ch1", UserId: "user1", Message: "fixed", CreateAt: 1000, EditAt: tt.editAt,
+			}})
+
+			ctx := context.Background()
+			db := newTestBridgeDB(t)
+			portal := makeTestPortal("ch1")
+			portal.Portal = db.Portal.New()
+			portal.PortalKey = makePortalKey("ch1")
+			if err := db.Portal.Insert(ctx, portal.Portal); err != nil {
+				t.Fatalf("insert portal: %v", err)
+			}
+			if err := db.Message.Insert(ctx, &database.Message{
+				ID: MakeMessageID("p1"), PartID: MakeMessagePartID(0), MXID: "$p1", Room: portal.PortalKey,
+				SenderID: MakeUserID("user1"), Timestamp: time.UnixMilli(1000), EditCount: tt.editCount, Metadata: tt.meta,
+			}); err != nil {
+				t.Fatalf("insert message: %v", err)
+			}
+
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Bridge.DB = db
+			if _, err := mc.FetchMessages(ctx, bridgev2.FetchMessagesParams{Portal: portal}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			events := testMock(mc).Events()
+			if !tt.want {
+				if len(events) != 0 {
+					t.Errorf("expected no queued edit, got %+v", events)
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 queued edit, got %d", len(events))
+			}
+			edit, ok := events[0].(*simplevent.Message[*model.Post])
+			if !ok || edit.Type != bridgev2.RemoteEventEdit || edit.TargetMessage != MakeMessageID("p1") {
+				t.Errorf("expected an edit of p1, got %+v", events[0])
+			}
+		})
+	}
+}
+
+// TestFetchMessages_Pins verifies that the room's pinned events are set once
+// a batch holding pinned posts is bridged.
+func TestFetchMessages_Pins(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	bot := &fakeMatrixBot{}
+	conn.Bridge.Bot = bot
+	portal := getRelayTestPortal(t, conn, relayTestChannel)
+	if err := conn.Bridge.DB.Message.Insert(ctx, &database.Message{
+		ID: MakeMessageID("p1"), MXID: "$p1:example.com", Room: portal.PortalKey,
+		SenderID: MakeUserID("user1"), Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	pinnedPost := &model.Post{Id: "p1", ChannelId: relayTestChannel, UserId: "user1", Message: "rules", CreateAt: 1000, IsPinned: true}
+	fake.Posts[relayTestChannel] = makePostList([]*model.Post{pinnedPost})
+	fake.Pinned[relayTestChannel] = makePostList([]*model.Post{pinnedPost})
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector = conn
+
+	resp, err := mc.FetchMessages(ctx, bridgev2.FetchMessagesParams{Portal: portal})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.CompleteCallback == nil {
+		t.Fatal("expected a callback setting the pins")
+	}
+	resp.CompleteCallback()
+	if got := portalMetadata(portal).PinnedEvents; len(got) != 1 || got[0] != "$p1:example.com" {
+		t.Errorf("pinned events = %v", got)
+	}
+	if states := bot.States(); len(states) != 1 || states[0].Type != event.StatePinnedEvents {
+		t.Errorf("states = %+v", states)
+	}
+
+	if mc.backfillPinsCallback(ctx, portal, []*model.Post{{Id: "p2"}}) != nil {
+		t.Error("batch without pins got a callback")
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/commands"
+)
+
+// maxListedChannels bounds the channels list-channels shows at once.
+const maxListedChannels = 100
+
+// Errors of channel lookups by bot commands.
+var (
+	errChannelNotFound  = errors.New("no channel with that ID or name")
+	errChannelAmbiguous = errors.New("several channels have that name, use the channel ID")
+)
+
+// commandClient returns the Mattermost client of the command sender's default
+// login, or replies and returns nil if it isn't connected.
+func commandClient(ce *commands.Event) *MattermostClient {
+	login := ce.User.GetDefaultLogin()
+	if login == nil {
+		ce.Reply("That command requires you to be logged in.")
+		return nil
+	}
+	client, ok := login.Client.(*MattermostClient)
+	if !ok || !client.IsLoggedIn() {
+		ce.Reply("Your Mattermost login isn't connected.")
+		return nil
+	}
+	return client
+}
+
+// userChannels returns the channels the login is a member of, including DMs
+// and group DMs, sorted by name. Channels of other shards are left out.
+func (m *MattermostClient) userChannels(ctx context.Context) ([]*model.Channel, error) {
+	channels, _, err := m.client.GetChannelsForUserWithLastDeleteAt(ctx, m.userID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels: %w", err)
+	}
+	channels = slices.DeleteFunc(channels, func(ch *model.Channel) bool {
+		return !m.connector.OwnsChannel(ch.Id)
+	})
+	slices.SortFunc(channels, func(a, b *model.Channel) int {
+		return cmp.Or(cmp.Compare(channelLabel(a), channelLabel(b)), cmp.Compare(a.Id, b.Id))
+	})
+	return channels, nil
+}
+
+// findChannel returns the login's channel with the given ID, or with the given
+// name, with or without a leading ~.
+func (m *MattermostClient) findChannel(ctx context.Context, arg string) (*model.Channel, error) {
+	channels, err := m.userChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimPrefix(arg, "~")
+	var found *model.Channel
+	for _, ch := range channels {
+		if ch.Id == arg {
+			return ch, nil
+		}
+		if ch.Name == name {
+			if found != nil {
+				return nil, errChannelAmbiguous
+			}
+			found = ch
+		}
+	}
+	if found == nil {
+		return nil, errChannelNotFound
+	}
+	return found, nil
+}
+
+// channelLabel returns the name a channel is listed under.
+func channelLabel(ch *model.Channel) string {
+	if ch.DisplayName != "" {
+		return ch.DisplayName
+	}
+	return ch.Name
+}
+
+func (mc *MattermostConnector) fnListChannels(ce *commands.Event) {
+	client := commandClient(ce)
+	if client == nil {
+		return
+	}
+	channels, err := client.userChannels(ce.Ctx)
+	if err != nil {
+		ce.Log.Err(err).Msg("Failed to list channels")
+		ce.Reply("Failed to get your Mattermost channels.")
+		return
+	}
+	if len(ce.Args) > 0 {
+		filter := strings.ToLower(strings.Join(ce.Args, " "))
+		channels = slices.DeleteFunc(channels, func(ch *model.Channel) bool {
+			return !strings.Contains(strings.ToLower(ch.Name), filter) &&
+				!strings.Contains(strings.ToLower(ch.DisplayName), filter)
+		})
+	}
+	if len(channels) == 0 {
+		ce.Reply("No channels found.")
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Your Mattermost channels:\n\n")
+	for i, ch := range channels {
+		if i == maxListedChannels {
+			fmt.Fprintf(&sb, "\n…and %d more. Add a filter to narrow the list.", len(channels)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "* **%s** `%s`: ", channelLabel(ch), ch.Id)
+		portal, err := mc.Bridge.GetExistingPortalByKey(ce.Ctx, makePortalKey(ch.Id))
+		switch {
+		case err != nil:
+			ce.Log.Warn().Err(err).Str("channel_id", ch.Id).Msg("Failed to get portal of listed channel")
+			sb.WriteString("unknown\n")
+		case portal == nil || portal.MXID == "":
+			sb.WriteString("not bridged\n")
+		default:
+			fmt.Fprintf(&sb, "bridged to `%s`\n", portal.MXID)
+		}
+	}
+	ce.Reply("%s", sb.String())
+}
+
+func (mc *MattermostConnector) fnBridgeChannel(ce *commands.Event) {
+	if len(ce.Args) != 1 {
+		ce.Reply("Usage: `bridge <channel ID or name>`")
+		return
+	}
+	client := commandClient(ce)
+	if client == nil {
+		return
+	}
+	ch, err := client.findChannel(ce.Ctx, ce.Args[0])
+	if err != nil {
+		ce.Reply("Can't bridge `%s`: %v", ce.Args[0], err)
+		return
+	}
+	relay, err := mc.relayLogin(ce.Ctx)
+	if err != nil {
+		ce.Log.Warn().Err(err).Msg("Failed to find relay login, bridging without relay")
+	}
+	result := mc.provisionPortal(ce.Ctx, client.userLogin, client, relay, ch.Id)
+	switch {
+	case result.Error != "":
+		ce.Reply("Failed to bridge **%s**: %s", channelLabel(ch), result.Error)
+	case result.Created:
+		ce.Reply("Bridged **%s** to `%s`.", channelLabel(ch), result.RoomID)
+	default:
+		ce.Reply("**%s** is already bridged to `%s`.", channelLabel(ch), result.RoomID)
+	}
+}
+
+func (mc *MattermostConnector) fnUnbridgeChannel(ce *commands.Event) {
+	portal := ce.Portal
+	if len(ce.Args) > 0 {
+		client := commandClient(ce)
+		if client == nil {
+			return
+		}
+		ch, err := client.findChannel(ce.Ctx, ce.Args[0])
+		if err != nil {
+			ce.Reply("Can't unbridge `%s`: %v", ce.Args[0], err)
+			return
+		}
+		if portal, err = mc.Bridge.GetExistingPortalByKey(ce.Ctx, makePortalKey(ch.Id)); err != nil {
+			ce.Log.Err(err).Str("channel_id", ch.Id).Msg("Failed to get portal to unbridge")
+			ce.Reply("Failed to get the portal of **%s**.", channelLabel(ch))
+			return
+		}
+	}
+	if portal == nil || portal.MXID == "" {
+		if len(ce.Args) == 0 {
+			ce.Reply("Usage: `unbridge [channel ID or name]`, or run it in a portal room.")
+		} else {
+			ce.Reply("`%s` isn't bridged.", ce.Args[0])
+		}
+		return
+	}
+
+	roomID := portal.MXID
+	if err := portal.Delete(ce.Ctx); err != nil {
+		ce.Log.Err(err).Stringer("room_id", roomID).Msg("Failed to delete unbridged portal")
+		ce.Reply("Failed to delete the portal.")
+		return
+	}
+	ce.Log.Info().
+		Str("channel_id", ParsePortalID(portal.ID)).
+		Stringer("room_id", roomID).
+		Msg("Channel unbridged by command")
+	if roomID != ce.RoomID {
+		ce.Reply("Unbridged `%s`. New messages or the next channel sync bridge the channel again unless portal_creation prevents it.", roomID)
+	}
+	if err := ce.Bot.DeleteRoom(ce.Ctx, roomID, false); err != nil {
+		ce.Log.Warn().Err(err).Stringer("room_id", roomID).Msg("Failed to clean up unbridged room")
+	}
+	if roomID == ce.RoomID {
+		ce.MessageStatus.DisableMSS = true
+	}
+}
+
+func (mc *MattermostConnector) fnReloadPuppets(ce *commands.Event) {
+	added, removed := mc.ReloadPuppets(ce.Ctx)
+	ce.Reply("Reloaded puppets from the environment: %d added, %d removed, %d total.", added, removed, mc.PuppetCount())
+}
+
+func (mc *MattermostConnector) fnBackfill(ce *commands.Event) {
+	limit := mc.liveConfig().backfillLimit(true)
+	if len(ce.Args) > 0 {
+		n, err := strconv.Atoi(ce.Args[0])
+		if err != nil || n < 1 {
+			ce.Reply("Usage: `backfill [count]`")
+			return
+		}
+		limit = n
+	}
+	client := commandClient(ce)
+	if client == nil {
+		return
+	}
+	if !mc.OwnsChannel(ParsePortalID(ce.Portal.ID)) {
+		ce.Reply("This channel is bridged by another shard.")
+		return
+	}
+	queued, err := client.recoverChannelPosts(ce.Ctx, ce.Portal.Portal, limit)
+	if err != nil {
+		ce.Log.Err(err).Msg("Failed to backfill missed posts")
+		ce.Reply("Failed to fetch missed posts from Mattermost.")
+		return
+	}
+	if queued == 0 {
+		ce.Reply("No missed posts to bridge.")
+		return
+	}
+	ce.Reply("Bridging %d missed posts.", queued)
+}
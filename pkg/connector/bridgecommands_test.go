@@ -0,0 +1,227 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/commands"
+	"maunium.net/go/mautrix/id"
+)
+
+// deleteRoomBot is a fakeReplyBot that records deleted rooms.
+type deleteRoomBot struct {
+	fakeReplyBot
+	deleteMu sync.Mutex
+	deleted  []id.RoomID
+}
+
+func (b *deleteRoomBot) DeleteRoom(_ context.Context, roomID id.RoomID, _ bool) error {
+	b.deleteMu.Lock()
+	defer b.deleteMu.Unlock()
+	b.deleted = append(b.deleted, roomID)
+	return nil
+}
+
+// newBridgeCommandTest returns a relay test connector whose relayuser login
+// talks to fake and is a member of the bridged "town-square" channel and the
+// unbridged "off-topic" one.
+func newBridgeCommandTest(t *testing.T) (*MattermostConnector, *fakeMM) {
+	t.Helper()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	mc.Puppets = make(map[id.UserID]*PuppetClient)
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.ChannelsForUser["relay-id"] = []*model.Channel{
+		{Id: relayTestChannel, Name: "town-square", DisplayName: "Town Square", Type: model.ChannelTypeOpen},
+		{Id: relayTestChannel2, Name: "off-topic", DisplayName: "Off-Topic", Type: model.ChannelTypeOpen},
+	}
+
+	login, err := mc.Bridge.GetExistingUserLoginByID(context.Background(), MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	client := login.Client.(*MattermostClient)
+	client.client = model.NewAPIv4Client(fake.Server.URL)
+	client.client.SetToken("relay-token")
+	client.userID = "relay-id"
+	client.eventSender = &mockEventSender{}
+	return mc, fake
+}
+
+// runBridgeCommand runs fn as @relay:example.com, in portal if set, and
+// returns the last reply.
+func runBridgeCommand(t *testing.T, mc *MattermostConnector, bot bridgev2.MatrixAPI, fn func(*commands.Event), portal *bridgev2.Portal, args ...string) string {
+	t.Helper()
+	user, err := mc.Bridge.GetUserByMXID(context.Background(), "@relay:example.com")
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	log := zerolog.Nop()
+	ce := &commands.Event{Bot: bot, Bridge: mc.Bridge, User: user, Portal: portal, RoomID: "!mgmt:example.com", Args: args, Ctx: context.Background(), Log: &log}
+	fn(ce)
+	switch b := bot.(type) {
+	case *fakeReplyBot:
+		return b.lastReply()
+	case *deleteRoomBot:
+		return b.lastReply()
+	}
+	return ""
+}
+
+func TestFnListChannels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		notWant []string
+	}{
+		{"all", nil, []string{"**Off-Topic** `" + relayTestChannel2 + "`: not bridged", "**Town Square** `" + relayTestChannel + "`: bridged to `!" + relayTestChannel + ":example.com`"}, nil},
+		{"filter", []string{"town"}, []string{"Town Square"}, []string{"Off-Topic"}},
+		{"no match", []string{"random"}, []string{"No channels found."}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, _ := newBridgeCommandTest(t)
+			reply := runBridgeCommand(t, mc, &fakeReplyBot{}, mc.fnListChannels, nil, tt.args...)
+			for _, want := range tt.want {
+				if !strings.Contains(reply, want) {
+					t.Errorf("reply %q doesn't contain %q", reply, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(reply, notWant) {
+					t.Errorf("reply %q contains %q", reply, notWant)
+				}
+			}
+			if tt.name == "all" && strings.Index(reply, "Off-Topic") > strings.Index(reply, "Town Square") {
+				t.Errorf("channels not sorted by name: %q", reply)
+			}
+		})
+	}
+}
+
+func TestFindChannel(t *testing.T) {
+	t.Parallel()
+	mc, fake := newBridgeCommandTest(t)
+	fake.ChannelsForUser["relay-id"] = append(fake.ChannelsForUser["relay-id"],
+		&model.Channel{Id: "cccccccccccccccccccccccccc", Name: "off-topic", DisplayName: "Off-Topic (other team)", Type: model.ChannelTypeOpen})
+	login, _ := mc.Bridge.GetExistingUserLoginByID(context.Background(), MakeUserLoginID("relayuser"))
+	client := login.Client.(*MattermostClient)
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr error
+	}{
+		{"id", relayTestChannel, relayTestChannel, nil},
+		{"name", "town-square", relayTestChannel, nil},
+		{"tilde name", "~town-square", relayTestChannel, nil},
+		{"ambiguous name", "off-topic", "", errChannelAmbiguous},
+		{"id of ambiguous name", relayTestChannel2, relayTestChannel2, nil},
+		{"unknown", "random", "", errChannelNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ch, err := client.findChannel(context.Background(), tt.arg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if ch != nil && ch.Id != tt.want {
+				t.Errorf("channel = %s, want %s", ch.Id, tt.want)
+			}
+		})
+	}
+}
+
+func TestFnBridgeChannel_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no args", nil, "Usage"},
+		{"unknown channel", []string{"random"}, "no channel with that ID or name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, _ := newBridgeCommandTest(t)
+			if reply := runBridgeCommand(t, mc, &fakeReplyBot{}, mc.fnBridgeChannel, nil, tt.args...); !strings.Contains(reply, tt.want) {
+				t.Errorf("reply %q doesn't contain %q", reply, tt.want)
+			}
+		})
+	}
+}
+
+func TestFnUnbridgeChannel(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc, _ := newBridgeCommandTest(t)
+	bot := &deleteRoomBot{}
+
+	reply := runBridgeCommand(t, mc, bot, mc.fnUnbridgeChannel, nil, "town-square")
+	if !strings.Contains(reply, "Unbridged `!"+relayTestChannel+":example.com`") {
+		t.Errorf("reply = %q", reply)
+	}
+	if len(bot.deleted) != 1 || bot.deleted[0] != id.RoomID("!"+relayTestChannel+":example.com") {
+		t.Errorf("deleted rooms = %v", bot.deleted)
+	}
+	if portal, err := mc.Bridge.GetExistingPortalByKey(ctx, makePortalKey(relayTestChannel)); err != nil || portal != nil {
+		t.Errorf("portal still exists: %v, %v", portal, err)
+	}
+
+	if reply := runBridgeCommand(t, mc, bot, mc.fnUnbridgeChannel, nil, "off-topic"); !strings.Contains(reply, "isn't bridged") {
+		t.Errorf("unbridged channel: reply = %q", reply)
+	}
+	if reply := runBridgeCommand(t, mc, bot, mc.fnUnbridgeChannel, nil); !strings.Contains(reply, "Usage") {
+		t.Errorf("no args outside a portal: reply = %q", reply)
+	}
+}
+
+func TestFnReloadPuppets(t *testing.T) {
+	t.Parallel()
+	mc, _ := newBridgeCommandTest(t)
+	mc.Puppets["@gone:example.com"] = &PuppetClient{MXID: "@gone:example.com", UserID: "gone-id"}
+	reply := runBridgeCommand(t, mc, &fakeReplyBot{}, mc.fnReloadPuppets, nil)
+	if !strings.Contains(reply, "1 removed") {
+		t.Errorf("reply = %q, want the puppet missing from the environment removed", reply)
+	}
+}
+
+func TestFnBackfill(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"bad count", []string{"x"}, "Usage"},
+		{"zero count", []string{"0"}, "Usage"},
+		{"nothing bridged yet", []string{"5"}, "No missed posts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, _ := newBridgeCommandTest(t)
+			portal := getRelayTestPortal(t, mc, relayTestChannel)
+			if reply := runBridgeCommand(t, mc, &fakeReplyBot{}, mc.fnBackfill, portal, tt.args...); !strings.Contains(reply, tt.want) {
+				t.Errorf("reply %q doesn't contain %q", reply, tt.want)
+			}
+		})
+	}
+}
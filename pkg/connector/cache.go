@@ -0,0 +1,263 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCacheMaxEntries is the default size limit of each cache of a login.
+const defaultCacheMaxEntries = 10000
+
+// CacheConfig bounds the lookup caches each login keeps: users looked up for
+// mentions and guest checks, channels and teams looked up for links, room
+// names and avatars, the users whose presence is polled, and the keys of
+// recently queued events.
+type CacheConfig struct {
+	// MaxEntries is the most entries each cache holds. When a cache is full,
+	// the least recently used entry is evicted. Defaults to 10000.
+	MaxEntries int `yaml:"max_entries"`
+	// TTLMinutes drops lookup cache entries this long after they were
+	// stored, so changes in Mattermost are picked up without waiting for a
+	// channel sync. 0 keeps them until they're evicted or the next channel
+	// sync. Doesn't apply to the presence and recent event caches, whose
+	// entries aren't lookups.
+	TTLMinutes int `yaml:"ttl_minutes"`
+}
+
+func (c *CacheConfig) maxEntries() int {
+	if c.MaxEntries <= 0 {
+		return defaultCacheMaxEntries
+	}
+	return c.MaxEntries
+}
+
+func (c *CacheConfig) ttl() time.Duration {
+	if c.TTLMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(c.TTLMinutes) * time.Minute
+}
+
+// cacheStats counts the entries and evictions of all caches of one kind,
+// across logins, for GET /metrics.
+type cacheStats struct {
+	entries   atomic.Int64
+	evictions atomic.Uint64
+}
+
+// cacheEntry is an entry of a boundedCache.
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// boundedCache is a map with a size limit and an optional time to live.
+// When it's full, the least recently used entry is evicted. It isn't safe
+// for concurrent use; callers guard it with their own mutex, like the maps
+// it replaces.
+type boundedCache[K comparable, V any] struct {
+	maxEntries int
+	ttl        time.Duration
+	stats      *cacheStats
+
+	entries map[K]*list.Element
+	// order holds the entries, most recently used first.
+	order *list.List
+	now   func() time.Time
+	// counted is how many entries this cache added to stats.entries.
+	counted int64
+}
+
+func newBoundedCache[K comparable, V any](maxEntries int, ttl time.Duration, stats *cacheStats) *boundedCache[K, V] {
+	if stats == nil {
+		stats = &cacheStats{}
+	}
+	return &boundedCache[K, V]{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		stats:      stats,
+		entries:    make(map[K]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// newClientCache returns a cache bounded by caches.max_entries, counted
+// under name in the cache metrics. With ttl, its entries also expire after
+// caches.ttl_minutes.
+func newClientCache[K comparable, V any](m *MattermostClient, name string, ttl bool) *boundedCache[K, V] {
+	cfg := m.connector.Config.Caches
+	var expiry time.Duration
+	if ttl {
+		expiry = cfg.ttl()
+	}
+	return newBoundedCache[K, V](cfg.maxEntries(), expiry, m.connector.metrics().cacheStats(name))
+}
+
+// get returns the value of a key and marks it as recently used. Expired
+// entries are removed and reported missing. A nil cache is empty.
+func (c *boundedCache[K, V]) get(key K) (V, bool) {
+	var zero V
+	if c == nil {
+		return zero, false
+	}
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*cacheEntry[K, V])
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.remove(elem)
+		c.stats.evictions.Add(1)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// set stores a value, evicting the least recently used entry if the cache
+// is full.
+func (c *boundedCache[K, V]) set(key K, value V) {
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry[K, V])
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+	for c.order.Len() >= c.maxEntries {
+		c.remove(c.order.Back())
+		c.stats.evictions.Add(1)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry[K, V]{key: key, value: value, expires: expires})
+	c.countEntries()
+}
+
+// delete removes a key.
+func (c *boundedCache[K, V]) delete(key K) {
+	if c == nil {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// clear removes every entry.
+func (c *boundedCache[K, V]) clear() {
+	if c == nil {
+		return
+	}
+	clear(c.entries)
+	c.order.Init()
+	c.countEntries()
+}
+
+// keys returns the keys of the entries that haven't expired.
+func (c *boundedCache[K, V]) keys() []K {
+	if c == nil {
+		return nil
+	}
+	now := c.now()
+	keys := make([]K, 0, len(c.entries))
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*cacheEntry[K, V])
+		if entry.expires.IsZero() || now.Before(entry.expires) {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
+// len returns the number of entries, including expired ones not removed
+// yet.
+func (c *boundedCache[K, V]) len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.entries)
+}
+
+func (c *boundedCache[K, V]) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*cacheEntry[K, V])
+	delete(c.entries, entry.key)
+	c.countEntries()
+}
+
+// countEntries sets this cache's share of stats.entries to its length, so
+// the gauge follows the map rather than a running tally of changes.
+func (c *boundedCache[K, V]) countEntries() {
+	n := int64(len(c.entries))
+	c.stats.entries.Add(n - c.counted)
+	c.counted = n
+}
+
+// cacheMetrics holds the stats of each kind of cache.
+type cacheMetrics struct {
+	mu    sync.Mutex
+	stats map[string]*cacheStats
+}
+
+// cacheStats returns the stats of the caches named name, created on first
+// use.
+func (b *bridgeMetrics) cacheStats(name string) *cacheStats {
+	b.caches.mu.Lock()
+	defer b.caches.mu.Unlock()
+	if b.caches.stats == nil {
+		b.caches.stats = make(map[string]*cacheStats)
+	}
+	stats, ok := b.caches.stats[name]
+	if !ok {
+		stats = &cacheStats{}
+		b.caches.stats[name] = stats
+	}
+	return stats
+}
+
+// writeCacheMetrics writes the cache metrics in the Prometheus text
+// exposition format. The puppet and double puppet maps are reported as
+// caches too; they're bounded by the configured puppets and logins rather
+// than by caches.max_entries.
+func (mc *MattermostConnector) writeCacheMetrics(w io.Writer) {
+	entries := make(map[string]int64)
+	evictions := make(map[string]uint64)
+	caches := &mc.metrics().caches
+	caches.mu.Lock()
+	for name, stats := range caches.stats {
+		entries[name] = stats.entries.Load()
+		evictions[name] = stats.evictions.Load()
+	}
+	caches.mu.Unlock()
+	mc.puppetMu.RLock()
+	entries["puppets"] = int64(len(mc.Puppets))
+	mc.puppetMu.RUnlock()
+	mc.dpLoginsMu.RLock()
+	entries["double_puppet_logins"] = int64(len(mc.dpLogins))
+	mc.dpLoginsMu.RUnlock()
+
+	names := slices.Sorted(maps.Keys(entries))
+	_, _ = fmt.Fprint(w, "# HELP mautrix_mattermost_cache_entries Entries in the bridge's caches, by cache.\n# TYPE mautrix_mattermost_cache_entries gauge\n")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "mautrix_mattermost_cache_entries{cache=%q} %d\n", name, entries[name])
+	}
+	_, _ = fmt.Fprint(w, "# HELP mautrix_mattermost_cache_evictions_total Cache entries evicted because the cache was full or the entry expired, by cache.\n# TYPE mautrix_mattermost_cache_evictions_total counter\n")
+	for _, name := range slices.Sorted(maps.Keys(evictions)) {
+		_, _ = fmt.Fprintf(w, "mautrix_mattermost_cache_evictions_total{cache=%q} %d\n", name, evictions[name])
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestBoundedCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	stats := &cacheStats{}
+	c := newBoundedCache[string, int](2, 0, stats)
+	c.set("a", 1)
+	c.set("b", 2)
+	c.get("a")
+	c.set("c", 3)
+
+	if _, ok := c.get("b"); ok {
+		t.Error("b should have been evicted as least recently used")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if got, ok := c.get(key); !ok || got != want {
+			t.Errorf("get(%q) = %d, %v; want %d", key, got, ok, want)
+		}
+	}
+	if got := stats.entries.Load(); got != 2 {
+		t.Errorf("entries = %d, want 2", got)
+	}
+	if got := stats.evictions.Load(); got != 1 {
+		t.Errorf("evictions = %d, want 1", got)
+	}
+}
+
+func TestBoundedCache_TTL(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1000, 0)
+	stats := &cacheStats{}
+	c := newBoundedCache[string, string](10, time.Minute, stats)
+	c.now = func() time.Time { return now }
+	c.set("old", "x")
+	now = now.Add(30 * time.Second)
+	c.set("new", "y")
+	now = now.Add(45 * time.Second)
+
+	if got := c.keys(); !slices.Equal(got, []string{"new"}) {
+		t.Errorf("keys = %v, want [new]", got)
+	}
+	if _, ok := c.get("old"); ok {
+		t.Error("old should have expired")
+	}
+	if got, ok := c.get("new"); !ok || got != "y" {
+		t.Errorf("get(new) = %q, %v; want y", got, ok)
+	}
+	if c.len() != 1 || stats.entries.Load() != 1 || stats.evictions.Load() != 1 {
+		t.Errorf("len = %d, entries = %d, evictions = %d; want 1, 1, 1", c.len(), stats.entries.Load(), stats.evictions.Load())
+	}
+}
+
+func TestBoundedCache_EntriesFollowLength(t *testing.T) {
+	t.Parallel()
+	stats := &cacheStats{}
+	a := newBoundedCache[string, int](2, 0, stats)
+	b := newBoundedCache[string, int](3, 0, stats)
+	for i, key := range []string{"x", "y", "x", "z", "w", "y"} {
+		a.set(key, i)
+		b.set(key, i)
+	}
+	b.delete("missing")
+	if want := int64(a.len() + b.len()); stats.entries.Load() != want {
+		t.Errorf("entries = %d, want %d", stats.entries.Load(), want)
+	}
+	a.clear()
+	if want := int64(b.len()); stats.entries.Load() != want {
+		t.Errorf("entries after clear = %d, want %d", stats.entries.Load(), want)
+	}
+}
+
+func TestBoundedCache_ClearAndDelete(t *testing.T) {
+	t.Parallel()
+	stats := &cacheStats{}
+	c := newBoundedCache[string, bool](10, 0, stats)
+	c.set("a", true)
+	c.set("b", true)
+	c.set("a", false)
+	c.delete("b")
+	if got := stats.entries.Load(); got != 1 {
+		t.Errorf("entries after delete = %d, want 1", got)
+	}
+	c.clear()
+	if c.len() != 0 || stats.entries.Load() != 0 {
+		t.Errorf("len = %d, entries = %d after clear; want 0", c.len(), stats.entries.Load())
+	}
+
+	var nilCache *boundedCache[string, bool]
+	nilCache.clear()
+	nilCache.delete("a")
+	if _, ok := nilCache.get("a"); ok || nilCache.len() != 0 || nilCache.keys() != nil {
+		t.Error("nil cache should be empty")
+	}
+}
+
+func TestClientCaches_BoundedByConfig(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.Caches = CacheConfig{MaxEntries: 2}
+	mc.connector.Config.BridgePresence = true
+	for _, userID := range []string{"u1", "u2", "u3"} {
+		mc.trackPresence(userID)
+		mc.rememberGuest(userID, false)
+	}
+	if got := mc.trackedPresenceUsers(); len(got) != 2 || slices.Contains(got, "u1") {
+		t.Errorf("tracked presence users = %v, want the 2 most recent", got)
+	}
+	if _, ok := mc.guests.get("u1"); ok {
+		t.Error("oldest guest entry should have been evicted")
+	}
+}
+
+func TestWriteCacheMetrics(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.Caches = CacheConfig{MaxEntries: 1}
+	mc.connector.Config.BridgePresence = true
+	mc.connector.Puppets["@bot:test"] = &PuppetClient{MXID: "@bot:test"}
+	mc.trackPresence("u1")
+	mc.trackPresence("u2")
+
+	var buf strings.Builder
+	mc.connector.writeCacheMetrics(&buf)
+	for _, want := range []string{
+		"# TYPE mautrix_mattermost_cache_entries gauge\n",
+		"mautrix_mattermost_cache_entries{cache=\"double_puppet_logins\"} 0\n",
+		"mautrix_mattermost_cache_entries{cache=\"presence\"} 1\n",
+		"mautrix_mattermost_cache_entries{cache=\"puppets\"} 1\n",
+		"mautrix_mattermost_cache_evictions_total{cache=\"presence\"} 1\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("metrics missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestForgetDoublePuppetLogin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		mapped  string
+		wantGot bool
+	}{
+		{"own login", "login1", false},
+		{"other login", "login2", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://unused")
+			mc.userLogin = &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1", UserMXID: id.UserID("@me:test")}}
+			mc.connector.dpLogins[mc.userID] = networkid.UserLoginID(tt.mapped)
+			mc.forgetDoublePuppetLogin()
+			if _, ok := mc.connector.dpLogins[mc.userID]; ok != tt.wantGot {
+				t.Errorf("mapping kept = %v, want %v", ok, tt.wantGot)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+)
+
+// WebSocket events of the Mattermost Calls plugin, broadcast to the channel
+// of the call.
+const (
+	callsEventCallStart model.WebsocketEventType = "custom_com.mattermost.calls_call_start"
+	callsEventCallEnd   model.WebsocketEventType = "custom_com.mattermost.calls_call_end"
+)
+
+// callWidgetEventType is the state event of room widgets, as used by
+// Element.
+var callWidgetEventType = event.Type{Type: "im.vector.modular.widgets", Class: event.StateEventType}
+
+// callWidgetStateKeyPrefix prefixes the state key of the widget of a call,
+// followed by the call ID.
+const callWidgetStateKeyPrefix = "fi.mau.mattermost.call."
+
+// CallsConfig controls the bridging of calls of the Mattermost Calls
+// plugin. Calls themselves aren't bridged: Matrix users join them in
+// Mattermost.
+type CallsConfig struct {
+	// Notices posts a notice with the join link in the portal room when a
+	// call starts in the channel, and another when it ends.
+	Notices bool `yaml:"notices"`
+	// Widget adds a room widget linking to the channel while a call is
+	// active, so Matrix clients show that a call is going on.
+	Widget bool `yaml:"widget"`
+}
+
+// callEvent is a call_start or call_end event of the Calls plugin.
+type callEvent struct {
+	ChannelID string
+	CallID    string
+	// OwnerID is the user who started the call, empty for call_end.
+	OwnerID string
+	// ThreadID is the post of the call, empty for call_end.
+	ThreadID string
+	StartAt  time.Time
+}
+
+// parseCallEvent extracts a call event from a Calls plugin WebSocket event.
+// Plugin versions differ in whether the channel is in the event data, so
+// the broadcast channel is the fallback.
+func parseCallEvent(evt *model.WebSocketEvent) callEvent {
+	data := evt.GetData()
+	str := func(key string) string {
+		s, _ := data[key].(string)
+		return s
+	}
+	call := callEvent{
+		ChannelID: cmp.Or(str("channelID"), str("channel_id"), evt.GetBroadcast().ChannelId),
+		CallID:    cmp.Or(str("id"), str("callID"), str("call_id")),
+		OwnerID:   cmp.Or(str("owner_id"), str("host_id")),
+		ThreadID:  cmp.Or(str("thread_id"), str("post_id")),
+	}
+	if startAt, ok := data["start_at"].(float64); ok && startAt > 0 {
+		call.StartAt = time.UnixMilli(int64(startAt))
+	}
+	return call
+}
+
+// handleCallStart posts the notice and adds the widget of a call started in
+// a bridged channel.
+func (m *MattermostClient) handleCallStart(evt *model.WebSocketEvent) {
+	m.handleCallEvent(parseCallEvent(evt), true)
+}
+
+// handleCallEnd posts the notice and removes the widget of a call that
+// ended in a bridged channel.
+func (m *MattermostClient) handleCallEnd(evt *model.WebSocketEvent) {
+	m.handleCallEvent(parseCallEvent(evt), false)
+}
+
+// handleCallEvent queues a chat info change that applies callUpdater to an
+// existing portal. Calls never create portals.
+func (m *MattermostClient) handleCallEvent(call callEvent, started bool) {
+	cfg := m.connector.Config.Calls
+	if !cfg.Notices && !cfg.Widget {
+		return
+	}
+	if call.ChannelID == "" {
+		m.log.Warn().Bool("started", started).Msg("Call event missing channel ID")
+		return
+	}
+	if !m.connector.OwnsChannel(call.ChannelID) {
+		return
+	}
+	m.log.Debug().
+		Str("channel_id", call.ChannelID).
+		Str("call_id", call.CallID).
+		Bool("started", started).
+		Msg("Call event")
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatInfoChange{
+		EventMeta: archiveEventMeta(bridgev2.RemoteEventChatInfoChange, call.ChannelID),
+		ChatInfoChange: &bridgev2.ChatInfoChange{ChatInfo: &bridgev2.ChatInfo{
+			ExtraUpdates: m.callUpdater(call, started),
+		}},
+	})
+}
+
+// callUpdater returns a ChatInfo.ExtraUpdates hook that records a call
+// starting or ending in the portal metadata, posting the notices and
+// changing the widget configured in CallsConfig. Like archiveUpdater, calls
+// already recorded are left alone, so every login seeing the event doesn't
+// repeat the notice.
+func (m *MattermostClient) callUpdater(call callEvent, started bool) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		meta := portalMetadata(portal)
+		if portal.MXID == "" {
+			return false
+		}
+		callID := cmp.Or(call.CallID, call.ThreadID, call.ChannelID)
+		if started && meta.ActiveCall == callID || !started && meta.ActiveCall == "" {
+			return false
+		}
+		cfg := m.connector.Config.Calls
+
+		prevCall, prevStart := meta.ActiveCall, meta.ActiveCallStart
+		if started {
+			meta.ActiveCall = callID
+			meta.ActiveCallStart = cmp.Or(call.StartAt, time.Now()).UnixMilli()
+		} else {
+			meta.ActiveCall, meta.ActiveCallStart = "", 0
+		}
+
+		joinURL := m.callJoinURL(ctx, portal, call)
+		if cfg.Widget {
+			if prevCall != "" {
+				m.setCallWidget(ctx, portal, prevCall, "")
+			}
+			if started {
+				m.setCallWidget(ctx, portal, callID, joinURL)
+			}
+		}
+		if cfg.Notices {
+			var content *event.MessageEventContent
+			if started {
+				content = m.callStartedNotice(ctx, call.OwnerID, joinURL)
+			} else {
+				content = callEndedNotice(prevStart)
+			}
+			_, err := portal.Bridge.Bot.SendMessage(ctx, portal.MXID, event.EventMessage, &event.Content{Parsed: content}, nil)
+			if err != nil {
+				m.log.Warn().Err(err).Str("channel_id", call.ChannelID).Msg("Failed to send call notice")
+			}
+		}
+		m.log.Info().
+			Str("channel_id", call.ChannelID).
+			Stringer("room_id", portal.MXID).
+			Str("call_id", callID).
+			Bool("started", started).
+			Msg("Bridged call event")
+		return true
+	}
+}
+
+// callJoinURL returns the link that opens a call in Mattermost: the call's
+// post, or the channel when the post is unknown.
+func (m *MattermostClient) callJoinURL(ctx context.Context, portal *bridgev2.Portal, call callEvent) string {
+	serverURL := strings.TrimRight(m.serverURL, "/")
+	if serverURL == "" || m.client == nil {
+		return ""
+	}
+	teamName, ok := m.teamName(ctx, m.portalTeam(portal))
+	if !ok {
+		return ""
+	}
+	if call.ThreadID != "" {
+		return serverURL + "/" + teamName + "/pl/" + call.ThreadID
+	}
+	return serverURL + "/" + teamName + "/channels/" + call.ChannelID
+}
+
+// callStartedNotice returns the notice of a call started by ownerID, with
+// the caller as a pill when known.
+func (m *MattermostClient) callStartedNotice(ctx context.Context, ownerID, joinURL string) *event.MessageEventContent {
+	body, formatted := "A call started in Mattermost.", "A call started in Mattermost."
+	if ownerID != "" {
+		if username, ok := m.mentionUsername(ctx, ownerID); ok {
+			body = "@" + username + " started a call in Mattermost."
+			formatted = html.EscapeString(body)
+			if mxid, ok := m.matrixUserFor(ownerID); ok {
+				formatted = fmt.Sprintf(`<a href="%s">@%s</a> started a call in Mattermost.`, mxid.URI().MatrixToURL(), html.EscapeString(username))
+			}
+		}
+	}
+	if joinURL != "" {
+		body += " Join: " + joinURL
+		formatted += fmt.Sprintf(` <a href="%s">Join the call</a>`, html.EscapeString(joinURL))
+	}
+	return &event.MessageEventContent{
+		MsgType:       event.MsgNotice,
+		Body:          "📞 " + body,
+		Format:        event.FormatHTML,
+		FormattedBody: "📞 " + formatted,
+	}
+}
+
+// callEndedNotice returns the notice of a call that started at startMilli,
+// with its duration when known.
+func callEndedNotice(startMilli int64) *event.MessageEventContent {
+	body := "📞 The call in Mattermost ended."
+	if startMilli > 0 {
+		if d := time.Since(time.UnixMilli(startMilli)).Round(time.Second); d > 0 {
+			body = fmt.Sprintf("📞 The call in Mattermost ended after %s.", d)
+		}
+	}
+	return &event.MessageEventContent{MsgType: event.MsgNotice, Body: body}
+}
+
+// setCallWidget adds the widget of a call linking to joinURL, or removes it
+// when joinURL is empty.
+func (m *MattermostClient) setCallWidget(ctx context.Context, portal *bridgev2.Portal, callID, joinURL string) {
+	stateKey := callWidgetStateKeyPrefix + callID
+	content := map[string]any{}
+	if joinURL != "" {
+		content = map[string]any{
+			"id":   stateKey,
+			"type": "m.custom",
+			"name": "Mattermost call",
+			"url":  joinURL,
+			"data": map[string]any{},
+		}
+	}
+	_, err := portal.Bridge.Bot.SendState(ctx, portal.MXID, callWidgetEventType, stateKey, &event.Content{Raw: content}, time.Time{})
+	if err != nil {
+		m.log.Warn().Err(err).Stringer("room_id", portal.MXID).Str("call_id", callID).Msg("Failed to set call widget")
+	}
+}
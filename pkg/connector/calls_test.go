@@ -0,0 +1,151 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+func callStartEvent(channelID string) *model.WebSocketEvent {
+	evt := model.NewWebSocketEvent(callsEventCallStart, "", channelID, "", nil, "")
+	evt.Add("id", "call1")
+	evt.Add("channelID", channelID)
+	evt.Add("owner_id", "u1")
+	evt.Add("thread_id", "callpost1")
+	evt.Add("start_at", float64(time.Now().Add(-90*time.Second).UnixMilli()))
+	return evt
+}
+
+func TestParseCallEvent(t *testing.T) {
+	t.Parallel()
+	call := parseCallEvent(callStartEvent("ch1"))
+	if call.ChannelID != "ch1" || call.CallID != "call1" || call.OwnerID != "u1" || call.ThreadID != "callpost1" || call.StartAt.IsZero() {
+		t.Errorf("call = %+v", call)
+	}
+
+	end := model.NewWebSocketEvent(callsEventCallEnd, "", "ch2", "", nil, "")
+	end.Add("callID", "call2")
+	call = parseCallEvent(end)
+	if call.ChannelID != "ch2" || call.CallID != "call2" {
+		t.Errorf("call_end = %+v, want the broadcast channel and callID", call)
+	}
+}
+
+func TestHandleCallEvents(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		evt     *model.WebSocketEvent
+		config  CallsConfig
+		wantOne bool
+	}{
+		{"call start", callStartEvent("ch1"), CallsConfig{Notices: true}, true},
+		{"call end", model.NewWebSocketEvent(callsEventCallEnd, "", "ch1", "", nil, ""), CallsConfig{Notices: true}, true},
+		{"widget only", callStartEvent("ch1"), CallsConfig{Widget: true}, true},
+		{"disabled", callStartEvent("ch1"), CallsConfig{}, false},
+		{"missing channel", model.NewWebSocketEvent(callsEventCallStart, "", "", "", nil, ""), CallsConfig{Notices: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mc.connector.Config.Calls = tt.config
+			mock := testMock(mc)
+
+			mc.handleEvent(tt.evt)
+
+			events := mock.Events()
+			if !tt.wantOne {
+				if len(events) != 0 {
+					t.Errorf("expected no events, got %d", len(events))
+				}
+				return
+			}
+			if len(events) != 1 || events[0].GetType() != bridgev2.RemoteEventChatInfoChange {
+				t.Fatalf("events = %+v, want one chat info change", events)
+			}
+			if change := events[0].(*simplevent.ChatInfoChange); change.CreatePortal {
+				t.Error("call events must not create portals")
+			}
+			if key := events[0].GetPortalKey(); ParsePortalID(key.ID) != "ch1" {
+				t.Errorf("portal = %v, want ch1", key)
+			}
+		})
+	}
+}
+
+func TestCallUpdater(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Users["u1"] = &model.User{Id: "u1", Username: "alice"}
+	fake.Teams["my-user-id"] = []*model.Team{{Id: "my-team-id", Name: "acme"}}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.Calls = CallsConfig{Notices: true, Widget: true}
+	mc.connector.Puppets["@alice:example.com"] = &PuppetClient{UserID: "u1"}
+	portal := makeTestPortalWithBot("ch1", nil)
+	bot := portal.Bridge.Bot.(*fakeMatrixBot)
+	call := parseCallEvent(callStartEvent("ch1"))
+
+	if mc.callUpdater(call, true)(ctx, portal) {
+		t.Error("update before room creation should not report a change")
+	}
+
+	portal.MXID = "!room:example.com"
+	if !mc.callUpdater(call, true)(ctx, portal) {
+		t.Fatal("call start should report a change")
+	}
+	if portalMetadata(portal).ActiveCall != "call1" {
+		t.Errorf("active call = %q, want call1", portalMetadata(portal).ActiveCall)
+	}
+	joinURL := fake.Server.URL + "/acme/pl/callpost1"
+	sent := bot.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("notices = %d, want 1", len(sent))
+	}
+	notice := sent[0].AsMessage()
+	if notice.Body != "📞 @alice started a call in Mattermost. Join: "+joinURL {
+		t.Errorf("start notice = %q", notice.Body)
+	}
+	if !strings.Contains(notice.FormattedBody, "https://matrix.to/#/@alice:example.com") {
+		t.Errorf("start notice should mention the caller: %q", notice.FormattedBody)
+	}
+	states := bot.States()
+	if len(states) != 1 || states[0].Type != callWidgetEventType || states[0].StateKey != callWidgetStateKeyPrefix+"call1" || states[0].Content.Raw["url"] != joinURL {
+		t.Errorf("states = %+v, want the call widget", states)
+	}
+
+	if mc.callUpdater(call, true)(ctx, portal) {
+		t.Error("repeated call start should not report a change")
+	}
+
+	if !mc.callUpdater(callEvent{ChannelID: "ch1", CallID: "call1"}, false)(ctx, portal) {
+		t.Fatal("call end should report a change")
+	}
+	if portalMetadata(portal).ActiveCall != "" {
+		t.Error("call still active")
+	}
+	sent = bot.Sent()
+	if len(sent) != 2 || !strings.HasPrefix(sent[1].AsMessage().Body, "📞 The call in Mattermost ended after 1m3") {
+		t.Errorf("notices = %+v, want the end notice with the duration", sent)
+	}
+	states = bot.States()
+	if len(states) != 2 || states[1].StateKey != callWidgetStateKeyPrefix+"call1" || len(states[1].Content.Raw) != 0 {
+		t.Errorf("states = %+v, want the widget removed", states)
+	}
+
+	if mc.callUpdater(callEvent{ChannelID: "ch1"}, false)(ctx, portal) {
+		t.Error("ending without an active call should not report a change")
+	}
+}
@@ -7,8 +7,10 @@ package connector
 
 import (
 	"context"
+	"maps"
 	"testing"
 
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
 	"maunium.net/go/mautrix/event"
 )
 
@@ -81,6 +83,24 @@ func TestGetCapabilities_ImageCaption(t *testing.T) {
 	if videoFC.Caption != event.CapLevelFullySupported {
 		t.Errorf("Video caption: got %v, want FullySupported", videoFC.Caption)
 	}
+	if imageFC.MaxCaptionLength != 16383 || videoFC.MaxCaptionLength != 16383 {
+		t.Errorf("MaxCaptionLength: got %d and %d, want 16383", imageFC.MaxCaptionLength, videoFC.MaxCaptionLength)
+	}
+}
+
+func TestGetCapabilities_FormattingMatchesConverter(t *testing.T) {
+	t.Parallel()
+	client := &MattermostClient{}
+	caps := client.GetCapabilities(context.Background(), nil)
+
+	if !maps.Equal(caps.Formatting, matrixfmt.Features()) {
+		t.Errorf("Formatting: got %v, want matrixfmt.Features()", caps.Formatting)
+	}
+	for _, feature := range []event.FormattingFeature{event.FmtCustomEmoji, event.FmtUnderline} {
+		if level := caps.Formatting[feature]; level != event.CapLevelDropped {
+			t.Errorf("Formatting %v: got %v, want Dropped", feature, level)
+		}
+	}
 }
 
 func TestGetCapabilities_Features(t *testing.T) {
@@ -0,0 +1,217 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+// maxChannelFilterBodySize is the maximum size of a PUT /api/channel-filter
+// request body.
+const maxChannelFilterBodySize = 64 << 10
+
+// ChannelFilterConfig selects the Mattermost channels that get a portal.
+// Entries are either a channel ("town-square", or a channel ID) or a team and
+// a channel ("engineering/town-square"); each part matches a name or an ID
+// and may use path.Match globs ("engineering/*", "*/alerts-*"). With both
+// lists empty, every channel does.
+type ChannelFilterConfig struct {
+	// Allowlist lists the channels that get a portal. When it's non-empty,
+	// other team channels don't. DMs and group DMs belong to no team and
+	// aren't affected by it.
+	Allowlist []string `yaml:"allowlist" json:"allowlist"`
+	// Denylist lists the channels that never get a portal. It takes
+	// precedence over the allowlist and applies to DMs too.
+	Denylist []string `yaml:"denylist" json:"denylist"`
+}
+
+// empty reports whether the filter lets every channel through.
+func (c *ChannelFilterConfig) empty() bool {
+	return c == nil || (len(c.Allowlist) == 0 && len(c.Denylist) == 0)
+}
+
+// allows reports whether a channel may get a portal. teamID and teamName are
+// empty for DMs and group DMs.
+func (c *ChannelFilterConfig) allows(channelID, channelName, teamID, teamName string) bool {
+	if c.empty() {
+		return true
+	}
+	matches := func(entry string) bool {
+		return channelEntryMatches(entry, channelID, channelName, teamID, teamName)
+	}
+	if slices.ContainsFunc(c.Denylist, matches) {
+		return false
+	}
+	if len(c.Allowlist) == 0 || teamID == "" {
+		return true
+	}
+	return slices.ContainsFunc(c.Allowlist, matches)
+}
+
+// validate checks that every entry is a valid pattern.
+func (c *ChannelFilterConfig) validate() error {
+	for _, list := range []struct {
+		name    string
+		entries []string
+	}{{"allowlist", c.Allowlist}, {"denylist", c.Denylist}} {
+		for _, entry := range list.entries {
+			if err := validateChannelEntry(entry); err != nil {
+				return fmt.Errorf("invalid channels.%s entry %q: %w", list.name, entry, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateChannelEntry checks that entry is a channel or team/channel
+// pattern.
+func validateChannelEntry(entry string) error {
+	parts := strings.Split(entry, "/")
+	if len(parts) > 2 {
+		return errors.New("expected channel or team/channel")
+	}
+	for _, part := range parts {
+		if part == "" {
+			return errors.New("empty team or channel")
+		}
+		if _, err := path.Match(part, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// channelEntryMatches reports whether a filter entry matches a channel. A
+// team/channel entry never matches DMs and group DMs.
+func channelEntryMatches(entry, channelID, channelName, teamID, teamName string) bool {
+	team, channel, hasTeam := strings.Cut(entry, "/")
+	if !hasTeam {
+		return globMatchesAny(entry, channelID, channelName)
+	}
+	return teamID != "" && globMatchesAny(team, teamID, teamName) && globMatchesAny(channel, channelID, channelName)
+}
+
+// globMatchesAny reports whether pattern matches one of the non-empty values.
+func globMatchesAny(pattern string, values ...string) bool {
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// channelFilter returns the channel filter in effect: the one set through
+// PUT /api/channel-filter, or the configured one, as last reloaded.
+func (mc *MattermostConnector) channelFilter() *ChannelFilterConfig {
+	if filter := mc.channelFilterOverride.Load(); filter != nil {
+		return filter
+	}
+	return &mc.liveConfig().Channels
+}
+
+// channelAllowed reports whether a channel may get a portal: it mustn't
+// have been unbridged, and the channel filter must let it through.
+// channelName may be empty, in which case it's looked up when the filter is
+// set. teamID is empty for DMs and group DMs. If the channel or team can't
+// be looked up, a set filter keeps the channel out, as it can't tell
+// whether the denylist names it.
+func (m *MattermostClient) channelAllowed(ctx context.Context, channelID, channelName, teamID string) bool {
+	if m.connector.isUnbridged(ctx, channelID) {
+		m.log.Debug().Str("channel_id", channelID).Msg("Channel excluded as unbridged")
+		return false
+	}
+	filter := m.connector.channelFilter()
+	if filter.empty() {
+		return true
+	}
+	if channelName == "" {
+		var ok bool
+		if channelName, ok = m.channelNameByID(ctx, channelID); !ok {
+			m.log.Warn().Str("channel_id", channelID).Msg("Channel excluded as its name can't be checked against the channel filter")
+			return false
+		}
+	}
+	var teamName string
+	if teamID != "" {
+		team, ok := m.lookupTeam(ctx, teamID)
+		if !ok {
+			m.log.Warn().Str("channel_id", channelID).Str("team_id", teamID).Msg("Channel excluded as its team can't be checked against the channel filter")
+			return false
+		}
+		teamName = team.Name
+	}
+	if filter.allows(channelID, channelName, teamID, teamName) {
+		return true
+	}
+	m.log.Debug().
+		Str("channel_id", channelID).
+		Str("channel_name", channelName).
+		Str("team_id", teamID).
+		Msg("Channel excluded by the channel filter")
+	return false
+}
+
+// allowCreatePortal reports whether an event may create the portal of a
+// channel: portal_creation must allow it and the channel filter must let the
+// channel through. synced is true for the channel sync, false for live
+// events.
+func (m *MattermostClient) allowCreatePortal(ctx context.Context, channelID, channelName, teamID string, synced bool) bool {
+	return m.connector.Config.PortalCreation.allowCreate(teamID, synced) &&
+		m.channelAllowed(ctx, channelID, channelName, teamID)
+}
+
+// HandleChannelFilter is an HTTP handler for /api/channel-filter. GET returns
+// the channel filter in effect; PUT replaces it until the bridge restarts.
+func (mc *MattermostConnector) HandleChannelFilter(w http.ResponseWriter, r *http.Request) {
+	log := mc.ctxLog(r.Context())
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		r.Body = http.MaxBytesReader(w, r.Body, maxChannelFilterBodySize)
+		defer func() { _ = r.Body.Close() }()
+
+		var filter ChannelFilterConfig
+		if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if err := filter.validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mc.channelFilterOverride.Store(&filter)
+		log.Info().
+			Str("remote_addr", r.RemoteAddr).
+			Strs("allowlist", filter.Allowlist).
+			Strs("denylist", filter.Denylist).
+			Msg("Channel filter changed")
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Appending to empty slices encodes missing lists as [] rather than null.
+	filter := mc.channelFilter()
+	resp := ChannelFilterConfig{
+		Allowlist: append([]string{}, filter.Allowlist...),
+		Denylist:  append([]string{}, filter.Denylist...),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Warn().Err(err).Msg("Failed to write channel filter response")
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+func TestChannelFilterConfig_Allows(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		cfg      ChannelFilterConfig
+		channel  string
+		teamName string
+		want     bool
+	}{
+		{"empty", ChannelFilterConfig{}, "town-square", "eng", true},
+		{"allowed by name", ChannelFilterConfig{Allowlist: []string{"town-square"}}, "town-square", "eng", true},
+		{"allowed by id", ChannelFilterConfig{Allowlist: []string{"ch1"}}, "town-square", "eng", true},
+		{"not allowed", ChannelFilterConfig{Allowlist: []string{"off-topic"}}, "town-square", "eng", false},
+		{"allowed by glob", ChannelFilterConfig{Allowlist: []string{"town-*"}}, "town-square", "eng", true},
+		{"allowed by team", ChannelFilterConfig{Allowlist: []string{"eng/*"}}, "town-square", "eng", true},
+		{"allowed by team id", ChannelFilterConfig{Allowlist: []string{"t1/town-square"}}, "town-square", "eng", true},
+		{"other team", ChannelFilterConfig{Allowlist: []string{"sales/*"}}, "town-square", "eng", false},
+		{"denied by name", ChannelFilterConfig{Denylist: []string{"town-square"}}, "town-square", "eng", false},
+		{"denied by glob", ChannelFilterConfig{Denylist: []string{"*/town-*"}}, "town-square", "eng", false},
+		{"deny wins", ChannelFilterConfig{Allowlist: []string{"eng/*"}, Denylist: []string{"eng/town-square"}}, "town-square", "eng", false},
+		{"not denied", ChannelFilterConfig{Denylist: []string{"off-topic"}}, "town-square", "eng", true},
+		{"dm ignores allowlist", ChannelFilterConfig{Allowlist: []string{"off-topic"}}, "u1__u2", "", true},
+		{"dm denied by name", ChannelFilterConfig{Denylist: []string{"u1__*"}}, "u1__u2", "", false},
+		{"dm not denied by team entry", ChannelFilterConfig{Denylist: []string{"*/*"}}, "u1__u2", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			teamID := ""
+			if tt.teamName != "" {
+				teamID = "t1"
+			}
+			if got := tt.cfg.allows("ch1", tt.channel, teamID, tt.teamName); got != tt.want {
+				t.Errorf("allows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelFilterConfig_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     ChannelFilterConfig
+		wantErr bool
+	}{
+		{"empty", ChannelFilterConfig{}, false},
+		{"valid", ChannelFilterConfig{Allowlist: []string{"town-square", "eng/*"}, Denylist: []string{"*/alerts-?"}}, false},
+		{"bad glob", ChannelFilterConfig{Allowlist: []string{"town-[square"}}, true},
+		{"empty entry", ChannelFilterConfig{Denylist: []string{""}}, true},
+		{"empty team", ChannelFilterConfig{Denylist: []string{"/town-square"}}, true},
+		{"too many parts", ChannelFilterConfig{Allowlist: []string{"a/b/c"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newChannelFilterTestClient returns a test client whose fake knows team t1
+// ("eng") and channel ch1 ("town-square").
+func newChannelFilterTestClient(t *testing.T, filter ChannelFilterConfig) (*MattermostClient, *fakeMM) {
+	t.Helper()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Teams["my-user-id"] = []*model.Team{{Id: "t1", Name: "eng"}}
+	fake.Channels["ch1"] = &model.Channel{Id: "ch1", Name: "town-square", TeamId: "t1", Type: model.ChannelTypeOpen}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.Channels = filter
+	return mc, fake
+}
+
+func TestChannelAllowed_FailedLookup(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		filter    ChannelFilterConfig
+		channelID string
+		teamID    string
+		want      bool
+	}{
+		{"looked up", ChannelFilterConfig{Denylist: []string{"off-*"}}, "ch1", "t1", true},
+		{"unknown channel", ChannelFilterConfig{Denylist: []string{"off-*"}}, "missing", "t1", false},
+		{"unknown team", ChannelFilterConfig{Denylist: []string{"off-*"}}, "ch1", "missing", false},
+		{"no filter", ChannelFilterConfig{}, "missing", "missing", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, _ := newChannelFilterTestClient(t, tt.filter)
+			if got := mc.channelAllowed(context.Background(), tt.channelID, "", tt.teamID); got != tt.want {
+				t.Errorf("channelAllowed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncChannels_ChannelFilter(t *testing.T) {
+	t.Parallel()
+	mc, fake := newChannelFilterTestClient(t, ChannelFilterConfig{Allowlist: []string{"eng/town-*"}})
+	fake.ChannelsForUser["my-user-id"] = []*model.Channel{
+		{Id: "ch1", Name: "town-square", Type: model.ChannelTypeOpen, TeamId: "t1"},
+		{Id: "ch2", Name: "off-topic", Type: model.ChannelTypeOpen, TeamId: "t1"},
+		{Id: "dm1", Name: "my-user-id__other", Type: model.ChannelTypeDirect},
+	}
+	mock := testMock(mc)
+
+	mc.syncChannels(context.Background())
+
+	synced := make(map[string]bool)
+	for _, evt := range mock.Events() {
+		synced[ParsePortalID(evt.(*simplevent.ChatResync).PortalKey.ID)] = true
+	}
+	if !synced["ch1"] || synced["ch2"] || !synced["dm1"] {
+		t.Errorf("synced channels = %v, want ch1 and dm1", synced)
+	}
+}
+
+func TestHandlePosted_ChannelFilter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		filter ChannelFilterConfig
+		want   bool
+	}{
+		{"no filter", ChannelFilterConfig{}, true},
+		{"allowed", ChannelFilterConfig{Allowlist: []string{"eng/town-square"}}, true},
+		{"denied", ChannelFilterConfig{Denylist: []string{"town-*"}}, false},
+		{"not allowed", ChannelFilterConfig{Allowlist: []string{"off-topic"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, _ := newChannelFilterTestClient(t, tt.filter)
+			mock := testMock(mc)
+			postJSON, _ := json.Marshal(&model.Post{Id: "p1", UserId: "other-user", ChannelId: "ch1", Message: "hello"})
+
+			mc.handlePosted(newWebSocketEvent(model.WebsocketEventPosted, "ch1", map[string]any{
+				"post":         string(postJSON),
+				"sender_name":  "@other",
+				"team_id":      "t1",
+				"channel_name": "town-square",
+			}))
+
+			events := mock.Events()
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			if got := events[0].(*simplevent.Message[*model.Post]).CreatePortal; got != tt.want {
+				t.Errorf("CreatePortal: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchMessages_ChannelFilter(t *testing.T) {
+	t.Parallel()
+	mc, fake := newChannelFilterTestClient(t, ChannelFilterConfig{Denylist: []string{"eng/town-square"}})
+	fake.Posts["ch1"] = makePostList([]*model.Post{
+		{Id: "post1", ChannelId: "ch1", UserId: "user1", Message: "first", CreateAt: time.Now().UnixMilli()},
+	})
+	portal := makeTestPortal("ch1")
+	portal.Metadata = &PortalMetadata{TeamID: "t1"}
+
+	resp, err := mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{Portal: portal, Forward: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) != 0 || resp.HasMore || !resp.Forward {
+		t.Errorf("response for denied channel: %d messages, has more %v, forward %v", len(resp.Messages), resp.HasMore, resp.Forward)
+	}
+
+	mc.connector.channelFilterOverride.Store(&ChannelFilterConfig{})
+	resp, err = mc.FetchMessages(context.Background(), bridgev2.FetchMessagesParams{Portal: portal})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Messages) != 1 {
+		t.Errorf("expected 1 message once the filter is cleared, got %d", len(resp.Messages))
+	}
+}
+
+func TestHandleChannelFilter(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, nil)
+	mc.Config.Channels = ChannelFilterConfig{Denylist: []string{"off-topic"}}
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/channel-filter", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		mc.HandleChannelFilter(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) ChannelFilterConfig {
+		t.Helper()
+		var resp ChannelFilterConfig
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response %q: %v", w.Body.String(), err)
+		}
+		return resp
+	}
+
+	w := do(http.MethodGet, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("get status: got %d", w.Code)
+	}
+	if resp := decode(w); len(resp.Allowlist) != 0 || strings.Join(resp.Denylist, ",") != "off-topic" {
+		t.Errorf("configured filter: %+v", resp)
+	}
+	if !strings.Contains(w.Body.String(), `"allowlist":[]`) {
+		t.Errorf("empty allowlist not encoded as []: %s", w.Body.String())
+	}
+
+	w = do(http.MethodPut, `{"allowlist":["eng/*"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("put status: got %d, body %q", w.Code, w.Body.String())
+	}
+	if resp := decode(w); strings.Join(resp.Allowlist, ",") != "eng/*" || len(resp.Denylist) != 0 {
+		t.Errorf("replaced filter: %+v", resp)
+	}
+	if filter := mc.channelFilter(); filter.allows("ch1", "town-square", "t1", "sales") {
+		t.Error("filter in effect wasn't replaced")
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"method", http.MethodPost, "", http.StatusMethodNotAllowed},
+		{"invalid json", http.MethodPut, "not json", http.StatusBadRequest},
+		{"bad pattern", http.MethodPut, `{"denylist":["[x"]}`, http.StatusBadRequest},
+		{"too large", http.MethodPut, `{"denylist":["` + strings.Repeat("a", maxChannelFilterBodySize) + `"]}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if w := do(tt.method, tt.body); w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+	if resp := decode(do(http.MethodGet, "")); strings.Join(resp.Allowlist, ",") != "eng/*" {
+		t.Errorf("rejected requests changed the filter: %+v", resp)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/id"
+)
+
+// errNoAliasAPI is returned when the Matrix connector can't resolve room
+// aliases.
+var errNoAliasAPI = errors.New("matrix connector can't resolve room aliases")
+
+// aliasAPI resolves Matrix room aliases. *appservice.IntentAPI implements it.
+type aliasAPI interface {
+	ResolveAlias(ctx context.Context, alias id.RoomAlias) (*mautrix.RespAliasResolve, error)
+}
+
+// matrixAliasAPI returns the bridge bot's alias API, or nil.
+func (mc *MattermostConnector) matrixAliasAPI() aliasAPI {
+	if conn, ok := mc.Bridge.Matrix.(*matrix.Connector); ok && conn.Bot != nil {
+		return conn.Bot
+	}
+	if api, ok := mc.Bridge.Bot.(aliasAPI); ok {
+		return api
+	}
+	return nil
+}
+
+// resetChannelLinks forgets the channels looked up for ~channel references
+// and the teams looked up for permalinks, so renamed channels and teams are
+// looked up again.
+func (m *MattermostClient) resetChannelLinks() {
+	m.channelLinksMu.Lock()
+	m.channelIDs.clear()
+	m.channelNames.clear()
+	m.teams.clear()
+	m.channelLinksMu.Unlock()
+}
+
+// rememberChannelLink caches a channel looked up for a ~channel reference. An
+// empty channelID marks a name that doesn't exist in the team.
+func (m *MattermostClient) rememberChannelLink(teamID, name, channelID string) {
+	m.channelLinksMu.Lock()
+	defer m.channelLinksMu.Unlock()
+	if m.channelIDs == nil {
+		m.channelIDs = newClientCache[string, string](m, "channel_ids", true)
+		m.channelNames = newClientCache[string, string](m, "channel_names", true)
+	}
+	m.channelIDs.set(teamID+"/"+name, channelID)
+	if channelID != "" {
+		m.channelNames.set(channelID, name)
+	}
+}
+
+// channelIDByName returns the ID of a team's channel, or "" if there is none
+// or the lookup fails.
+func (m *MattermostClient) channelIDByName(ctx context.Context, teamID, name string) string {
+	m.channelLinksMu.Lock()
+	channelID, ok := m.channelIDs.get(teamID + "/" + name)
+	m.channelLinksMu.Unlock()
+	if ok {
+		return channelID
+	}
+	channel, resp, err := m.client.GetChannelByName(ctx, name, teamID, "")
+	if err != nil {
+		// Unknown names are cached so "~" in ordinary text isn't looked up
+		// again; other failures are retried on the next reference.
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			m.rememberChannelLink(teamID, name, "")
+		} else {
+			m.log.Warn().Err(err).Str("channel_name", name).Msg("Failed to look up referenced channel")
+		}
+		return ""
+	}
+	m.rememberChannelLink(teamID, name, channel.Id)
+	return channel.Id
+}
+
+// channelNameByID returns the name of a channel.
+func (m *MattermostClient) channelNameByID(ctx context.Context, channelID string) (string, bool) {
+	m.channelLinksMu.Lock()
+	name, ok := m.channelNames.get(channelID)
+	m.channelLinksMu.Unlock()
+	if ok {
+		return name, true
+	}
+	channel, _, err := m.client.GetChannel(ctx, channelID, "")
+	if err != nil {
+		m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to look up referenced channel")
+		return "", false
+	}
+	m.rememberChannelLink(channel.TeamId, channel.Name, channel.Id)
+	return channel.Name, true
+}
+
+// portalTeam returns the team of a portal's channel, defaulting to the
+// login's team for DMs and unknown portals, where Mattermost resolves
+// ~channel references in the current team.
+func (m *MattermostClient) portalTeam(portal *bridgev2.Portal) string {
+	if portal != nil {
+		if meta, ok := portal.Metadata.(*PortalMetadata); ok && meta != nil && meta.TeamID != "" {
+			return meta.TeamID
+		}
+	}
+	return m.teamID
+}
+
+// channelLinkResolver returns the resolver that turns ~channel references in
+// a portal's posts into links to the portal rooms of the channels. Channels
+// are looked up in the portal's team; channels without a room are left as
+// text. Without a client or a bridge database, references are left as text.
+func (m *MattermostClient) channelLinkResolver(ctx context.Context, portal *bridgev2.Portal) mattermostfmt.ChannelResolver {
+	if m.client == nil || m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return nil
+	}
+	teamID := m.portalTeam(portal)
+	if teamID == "" {
+		return nil
+	}
+	var via []string
+	if m.connector.Bridge.Bot != nil {
+		via = []string{m.connector.Bridge.Bot.GetMXID().Homeserver()}
+	}
+	return func(name string) (*id.MatrixURI, bool) {
+		channelID := m.channelIDByName(ctx, teamID, name)
+		if channelID == "" {
+			return nil, false
+		}
+		linked, err := m.connector.Bridge.GetExistingPortalByKey(ctx, makePortalKey(channelID))
+		if err != nil || linked == nil || linked.MXID == "" {
+			return nil, false
+		}
+		return linked.MXID.URI(via...), true
+	}
+}
+
+// matrixChannelResolver returns the resolver that turns links to portal
+// rooms, by room ID or alias, into ~channel references when sent to a
+// portal. Only channels of the portal's team can be referenced; links to
+// other rooms are left as links.
+func (m *MattermostClient) matrixChannelResolver(ctx context.Context, portal *bridgev2.Portal) matrixfmt.ChannelResolver {
+	if m.client == nil || m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return nil
+	}
+	teamID := m.portalTeam(portal)
+	if teamID == "" {
+		return nil
+	}
+	return func(uri *id.MatrixURI) (string, bool) {
+		roomID := uri.RoomID()
+		if uri.Sigil1 == '#' {
+			var err error
+			if roomID, err = m.connector.resolveRoomAlias(ctx, uri.RoomAlias()); err != nil {
+				m.log.Debug().Err(err).Str("alias", string(uri.RoomAlias())).Msg("Failed to resolve linked room alias")
+				return "", false
+			}
+		}
+		linked, err := m.connector.Bridge.GetPortalByMXID(ctx, roomID)
+		if err != nil || linked == nil || linked.RoomType == database.RoomTypeSpace {
+			return "", false
+		}
+		if meta, ok := linked.Metadata.(*PortalMetadata); !ok || meta == nil || meta.TeamID != teamID {
+			return "", false
+		}
+		return m.channelNameByID(ctx, ParsePortalID(linked.ID))
+	}
+}
+
+// resolveRoomAlias returns the room a Matrix alias points to.
+func (mc *MattermostConnector) resolveRoomAlias(ctx context.Context, alias id.RoomAlias) (id.RoomID, error) {
+	api := mc.matrixAliasAPI()
+	if api == nil {
+		return "", errNoAliasAPI
+	}
+	resp, err := api.ResolveAlias(ctx, alias)
+	if err != nil {
+		return "", err
+	}
+	return resp.RoomID, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// fakeAliasBot is a bridge bot that resolves #town:example.com to the room
+// of relayTestChannel. Other methods are unimplemented and panic if called.
+type fakeAliasBot struct {
+	bridgev2.MatrixAPI
+}
+
+func (fakeAliasBot) GetMXID() id.UserID { return "@bot:example.com" }
+
+func (fakeAliasBot) ResolveAlias(_ context.Context, alias id.RoomAlias) (*mautrix.RespAliasResolve, error) {
+	if alias == "#town:example.com" {
+		return &mautrix.RespAliasResolve{RoomID: id.RoomID("!" + relayTestChannel + ":example.com")}, nil
+	}
+	return nil, errors.New("alias not found")
+}
+
+// newChannelLinkTestClient returns a client whose bridge has portal rooms for
+// relayTestChannel (town-square in team t1) and relayTestChannel2
+// (town-square in team t2), and whose fake server also knows off-topic in
+// t1, which has no room.
+func newChannelLinkTestClient(t *testing.T) (*MattermostClient, *fakeMM) {
+	t.Helper()
+	connector := newRelayTestConnector(t, map[string]*PortalMetadata{
+		relayTestChannel:  {TeamID: "t1"},
+		relayTestChannel2: {TeamID: "t2"},
+	})
+	connector.Bridge.Bot = fakeAliasBot{}
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	for _, ch := range []*model.Channel{
+		{Id: relayTestChannel, TeamId: "t1", Name: "town-square"},
+		{Id: relayTestChannel2, TeamId: "t2", Name: "town-square"},
+		{Id: "cccccccccccccccccccccccccc", TeamId: "t1", Name: "off-topic"},
+	} {
+		ch.Type = model.ChannelTypeOpen
+		fake.Channels[ch.Id] = ch
+	}
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector = connector
+	mc.teamID = "t1"
+	return mc, fake
+}
+
+func TestChannelLinkResolver(t *testing.T) {
+	t.Parallel()
+	mc, fake := newChannelLinkTestClient(t)
+	resolve := mc.channelLinkResolver(context.Background(), nil)
+
+	uri, ok := resolve("town-square")
+	if !ok {
+		t.Fatal("town-square should resolve to its portal room")
+	}
+	if want := "https://matrix.to/#/%21" + relayTestChannel + ":example.com?via=example.com"; uri.MatrixToURL() != want {
+		t.Errorf("link: got %q, want %q", uri.MatrixToURL(), want)
+	}
+	for _, name := range []string{"off-topic", "random"} {
+		if _, ok := resolve(name); ok {
+			t.Errorf("%s has no portal room and should not resolve", name)
+		}
+	}
+
+	// Names, including unknown ones, are looked up once.
+	before := len(fake.Calls())
+	for _, name := range []string{"town-square", "random"} {
+		resolve(name)
+	}
+	if calls := len(fake.Calls()) - before; calls != 0 {
+		t.Errorf("expected cached lookups, got %d requests", calls)
+	}
+}
+
+func TestChannelLinkResolver_PortalTeam(t *testing.T) {
+	t.Parallel()
+	mc, _ := newChannelLinkTestClient(t)
+	portal := getRelayTestPortal(t, mc.connector, relayTestChannel2)
+
+	uri, ok := mc.channelLinkResolver(context.Background(), portal)("town-square")
+	if !ok || uri.RoomID() != id.RoomID("!"+relayTestChannel2+":example.com") {
+		t.Errorf("references should resolve in the portal's team, got %v, %v", uri, ok)
+	}
+}
+
+func TestConvertPostToMatrix_ChannelLinks(t *testing.T) {
+	t.Parallel()
+	mc, _ := newChannelLinkTestClient(t)
+	portal := getRelayTestPortal(t, mc.connector, relayTestChannel)
+	post := &model.Post{Id: "p1", ChannelId: relayTestChannel, Message: "moved to ~town-square"}
+
+	content := mc.convertPostToMatrix(context.Background(), portal, nil, post).Parts[0].Content
+	want := `moved to <a href="https://matrix.to/#/%21` + relayTestChannel + `:example.com?via=example.com">~town-square</a>`
+	if content.Format != event.FormatHTML || content.FormattedBody != want {
+		t.Errorf("FormattedBody: got %q, want %q", content.FormattedBody, want)
+	}
+}
+
+func TestMatrixChannelResolver(t *testing.T) {
+	t.Parallel()
+	mc, _ := newChannelLinkTestClient(t)
+	portal := getRelayTestPortal(t, mc.connector, relayTestChannel)
+	resolve := mc.matrixChannelResolver(context.Background(), portal)
+	tests := []struct {
+		name   string
+		uri    *id.MatrixURI
+		want   string
+		wantOK bool
+	}{
+		{"room id", id.RoomID("!" + relayTestChannel + ":example.com").URI(), "town-square", true},
+		{"alias", id.RoomAlias("#town:example.com").URI(), "town-square", true},
+		{"other team", id.RoomID("!" + relayTestChannel2 + ":example.com").URI(), "", false},
+		{"not a portal", id.RoomID("!other:example.com").URI(), "", false},
+		{"unknown alias", id.RoomAlias("#nowhere:example.com").URI(), "", false},
+	}
+	for _, tt := range tests {
+		got, ok := resolve(tt.uri)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: got %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestHandleChannelUpdated_ForgetsChannelLinks(t *testing.T) {
+	t.Parallel()
+	mc, fake := newChannelLinkTestClient(t)
+	resolve := mc.channelLinkResolver(context.Background(), nil)
+	if _, ok := resolve("town-square"); !ok {
+		t.Fatal("town-square should resolve")
+	}
+
+	fake.Channels[relayTestChannel].Name = "lobby"
+	mc.handleEvent(channelUpdatedEvent(t, fake.Channels[relayTestChannel]))
+
+	if _, ok := resolve("town-square"); ok {
+		t.Error("the old name should not resolve after a rename")
+	}
+	if _, ok := resolve("lobby"); !ok {
+		t.Error("the new name should resolve after a rename")
+	}
+}
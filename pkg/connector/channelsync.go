@@ -0,0 +1,363 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+var (
+	errRelayedChannelEdit     = errors.New("room changes from relayed users without a puppet are not bridged")
+	errChannelEditUnsupported = errors.New("direct and group message channels can't be changed in Mattermost")
+	errTeamSpaceUnsupported   = errors.New("team spaces can't be changed from Matrix")
+)
+
+// channelAvatar returns the avatar for a team channel, which is its team's
+// icon. It returns nil for DMs, teams without an icon, or if the team can't be
+// fetched, leaving the room avatar unchanged. Teams are cached until the next
+// channel sync.
+func (m *MattermostClient) channelAvatar(ctx context.Context, channel *model.Channel) *bridgev2.Avatar {
+	teamID := channel.TeamId
+	if teamID == "" || m.client == nil {
+		return nil
+	}
+
+	m.teamIconsMu.Lock()
+	lastUpdate, ok := m.teamIcons.get(teamID)
+	m.teamIconsMu.Unlock()
+	if !ok {
+		team, _, err := m.client.GetTeam(ctx, teamID, "")
+		if err != nil {
+			m.log.Debug().Err(err).Str("team_id", teamID).Msg("Failed to get team for channel avatar")
+			return nil
+		}
+		lastUpdate = team.LastTeamIconUpdate
+		m.teamIconsMu.Lock()
+		if m.teamIcons == nil {
+			m.teamIcons = newClientCache[string, int64](m, "team_icons", true)
+		}
+		m.teamIcons.set(teamID, lastUpdate)
+		m.teamIconsMu.Unlock()
+	}
+	return m.teamIconAvatar(teamID, lastUpdate)
+}
+
+// teamIconAvatar returns the avatar for a team icon last updated at
+// lastUpdate, or nil if the team has no icon.
+func (m *MattermostClient) teamIconAvatar(teamID string, lastUpdate int64) *bridgev2.Avatar {
+	if lastUpdate == 0 {
+		return nil
+	}
+	client := m.client
+	return &bridgev2.Avatar{
+		ID: networkid.AvatarID("team_" + teamID + "_" + strconv.FormatInt(lastUpdate, 10)),
+		Get: func(ctx context.Context) ([]byte, error) {
+			data, _, err := client.GetTeamIcon(ctx, teamID, "")
+			return data, err
+		},
+	}
+}
+
+// resetTeamIcons forgets cached team icons, so the next channelAvatar call
+// picks up icon changes.
+func (m *MattermostClient) resetTeamIcons() {
+	m.teamIconsMu.Lock()
+	m.teamIcons.clear()
+	m.teamIconsMu.Unlock()
+}
+
+// forgetTeamIcon drops one team from the icon cache, so the next
+// channelAvatar call fetches it again.
+func (m *MattermostClient) forgetTeamIcon(teamID string) {
+	m.teamIconsMu.Lock()
+	m.teamIcons.delete(teamID)
+	m.teamIconsMu.Unlock()
+}
+
+// parseChannelUpdatedEvent extracts the channel from a channel_updated event.
+func (m *MattermostClient) parseChannelUpdatedEvent(evt *model.WebSocketEvent) (*model.Channel, error) {
+	channelJSON, ok := evt.GetData()["channel"].(string)
+	if !ok {
+		return nil, fmt.Errorf("channel updated event missing channel data")
+	}
+	var channel model.Channel
+	if err := json.Unmarshal([]byte(channelJSON), &channel); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal channel: %w", err)
+	}
+	if channel.Id == "" {
+		return nil, fmt.Errorf("channel updated event has no channel ID")
+	}
+	return &channel, nil
+}
+
+// handleChannelUpdated updates the portal's name, topic, avatar and channel
+// info state when a channel is edited in Mattermost, and moves the portal to
+// its new team's space when the channel is moved to another team. Header and
+// purpose changes become a topic with the markdown converted to plain text.
+// Mattermost doesn't say who made the change, so the bridge bot applies it.
+func (m *MattermostClient) handleChannelUpdated(evt *model.WebSocketEvent) {
+	channel, err := m.parseChannelUpdatedEvent(evt)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to parse channel updated event")
+		return
+	}
+	m.log.Debug().
+		Str("channel_id", channel.Id).
+		Str("channel_name", channel.Name).
+		Msg("Channel updated")
+	// The channel may have been renamed.
+	m.resetChannelLinks()
+
+	ctx := m.log.WithContext(context.Background())
+	info := &bridgev2.ChatInfo{
+		Avatar:       m.channelAvatar(ctx, channel),
+		ParentID:     m.teamParentID(channel),
+		ExtraUpdates: bridgev2.MergeExtraUpdaters(m.channelInfoUpdater(channel), m.teamUpdater(channel), m.roomAliasUpdater(channel), m.roomNameClaimer(channel)),
+	}
+	info.Name, info.Topic = m.channelNameAndTopic(ctx, channel)
+
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatInfoChange{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatInfoChange,
+			PortalKey: makePortalKey(channel.Id),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("channel_id", channel.Id)
+			},
+		},
+		ChatInfoChange: &bridgev2.ChatInfoChange{ChatInfo: info},
+	})
+}
+
+// resyncChannels periodically resyncs all channels, refreshing portal names,
+// topics, avatars and members. It runs until the client disconnects.
+func (m *MattermostClient) resyncChannels(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+			m.syncChannels(ctx)
+		}
+	}
+}
+
+// channelSyncProgressEvery is how many channels are synced between two
+// progress logs of a login's channel sync.
+const channelSyncProgressEvery = 50
+
+// channelSyncStats counts the outcomes of a login's channel sync.
+type channelSyncStats struct {
+	// Synced channels had a ChatResync queued.
+	Synced int
+	// Skipped channels need no portal, like DMs with an excluded guest.
+	Skipped int
+	// Failed channels couldn't be synced.
+	Failed int
+}
+
+// done returns how many channels have been handled.
+func (s channelSyncStats) done() int {
+	return s.Synced + s.Skipped + s.Failed
+}
+
+// syncChannelList queues a ChatResync for each channel with a pool of
+// concurrency workers, logging the progress every channelSyncProgressEvery
+// channels. A channel that fails, even by panicking, is logged and counted
+// without holding up the others. No more channels are started once ctx is
+// done or the client disconnects.
+func (m *MattermostClient) syncChannelList(ctx context.Context, channels []*model.Channel, concurrency int) channelSyncStats {
+	start := time.Now()
+	m.log.Info().Int("count", len(channels)).Int("concurrency", concurrency).Msg("Syncing channels")
+
+	var (
+		mu    sync.Mutex
+		stats channelSyncStats
+		wg    sync.WaitGroup
+	)
+	jobs := make(chan *model.Channel)
+	for range min(max(concurrency, 1), len(channels)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ch := range jobs {
+				queued, err := m.syncChannel(ctx, ch)
+				if err != nil {
+					m.log.Warn().Err(err).
+						Str("channel_id", ch.Id).
+						Str("channel_name", ch.Name).
+						Msg("Failed to sync channel")
+				}
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					stats.Failed++
+				case queued:
+					stats.Synced++
+				default:
+					stats.Skipped++
+				}
+				if done := stats.done(); done%channelSyncProgressEvery == 0 && done < len(channels) {
+					m.log.Info().
+						Int("done", done).
+						Int("count", len(channels)).
+						Int("failed", stats.Failed).
+						Msg("Channel sync progress")
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	interrupted := false
+feed:
+	for _, ch := range channels {
+		select {
+		case jobs <- ch:
+		case <-ctx.Done():
+			interrupted = true
+			break feed
+		case <-m.stopChan:
+			interrupted = true
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	m.log.Info().
+		Int("count", len(channels)).
+		Int("synced", stats.Synced).
+		Int("skipped", stats.Skipped).
+		Int("failed", stats.Failed).
+		Bool("interrupted", interrupted).
+		Dur("duration", time.Since(start)).
+		Msg("Channel sync complete")
+	return stats
+}
+
+// syncChannel queues the ChatResync of one channel of the login's channel
+// sync. It reports whether one was queued; a panic is returned as an error
+// so it only fails this channel.
+func (m *MattermostClient) syncChannel(ctx context.Context, ch *model.Channel) (queued bool, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			m.log.Error().
+				Str("channel_id", ch.Id).
+				Str("goroutine_stack", string(debug.Stack())).
+				Msg("Panic while syncing channel")
+			queued, err = false, fmt.Errorf("panic while syncing channel: %v", p)
+		}
+	}()
+	evt, err := m.channelSyncEvent(ctx, ch, m.connector.Config.PortalCreation.allowCreate(ch.TeamId, true))
+	if err != nil || evt == nil {
+		return false, err
+	}
+	m.eventSender.QueueRemoteEvent(m.userLogin, evt)
+	return true, nil
+}
+
+// channelEditClient returns the Mattermost client to change channel metadata
+// or membership with. Users with their own login use it. Relayed users must
+// be mapped to a puppet, since the relay account would otherwise make changes
+// on behalf of anyone in the room, unless relay allows it, for membership
+// changes, which the relay account makes like it posts relayed messages.
+// Mattermost then checks that the account may manage the channel.
+func (m *MattermostClient) channelEditClient(origSender *bridgev2.OrigSender, portal *bridgev2.Portal, relay bool) (*model.Client4, string, error) {
+	if !m.IsLoggedIn() {
+		return nil, "", bridgev2.ErrNotLoggedIn
+	}
+	switch portal.RoomType {
+	case database.RoomTypeDM, database.RoomTypeGroupDM:
+		return nil, "", errChannelEditUnsupported
+	case database.RoomTypeSpace:
+		return nil, "", errTeamSpaceUnsupported
+	}
+	if isThreadPortal(portal) {
+		return nil, "", errThreadRoomEdit
+	}
+	client, senderID := m.resolvePostClient(origSender, nil)
+	if origSender != nil && senderID == m.userID && !relay {
+		return nil, "", errRelayedChannelEdit
+	}
+	return client, senderID, nil
+}
+
+// patchChannel applies a channel patch from a Matrix room metadata change.
+func (m *MattermostClient) patchChannel(ctx context.Context, origSender *bridgev2.OrigSender, portal *bridgev2.Portal, patch *model.ChannelPatch) error {
+	client, senderID, err := m.channelEditClient(origSender, portal, false)
+	if err != nil {
+		return err
+	}
+	channelID := ParsePortalID(portal.ID)
+	_, resp, err := client.PatchChannel(ctx, channelID, patch)
+	if err != nil {
+		m.checkPuppetFailure(ctx, senderID, resp, err)
+		return fmt.Errorf("failed to patch channel: %w", err)
+	}
+	m.recordPuppetSuccess(senderID)
+	zerolog.Ctx(ctx).Info().
+		Str("channel_id", channelID).
+		Str("mm_user_id", senderID).
+		Msg("Updated Mattermost channel from Matrix")
+	return nil
+}
+
+// HandleMatrixRoomName sets the channel display name when the room is renamed.
+func (m *MattermostClient) HandleMatrixRoomName(ctx context.Context, msg *bridgev2.MatrixRoomName) (bool, error) {
+	name := strings.TrimSpace(msg.Content.Name)
+	if name == "" {
+		return false, fmt.Errorf("room name can't be empty in Mattermost")
+	}
+	if utf8.RuneCountInString(name) > model.ChannelDisplayNameMaxRunes {
+		return false, fmt.Errorf("room name is longer than %d characters", model.ChannelDisplayNameMaxRunes)
+	}
+	if err := m.patchChannel(ctx, msg.OrigSender, msg.Portal, &model.ChannelPatch{DisplayName: &name}); err != nil {
+		return false, err
+	}
+	msg.Portal.Name = name
+	msg.Portal.NameSet = true
+	return true, nil
+}
+
+// HandleMatrixRoomTopic sets the channel header when the room topic changes,
+// as the Matrix user's puppet or login. The header is what Mattermost shows
+// at the top of the channel, so it is the closest match for a topic. Topics
+// with an HTML representation are converted to markdown.
+func (m *MattermostClient) HandleMatrixRoomTopic(ctx context.Context, msg *bridgev2.MatrixRoomTopic) (bool, error) {
+	header := topicMarkdown(msg.Content)
+	if utf8.RuneCountInString(header) > model.ChannelHeaderMaxRunes {
+		return false, fmt.Errorf("topic is longer than %d characters", model.ChannelHeaderMaxRunes)
+	}
+	if err := m.patchChannel(ctx, msg.OrigSender, msg.Portal, &model.ChannelPatch{Header: &header}); err != nil {
+		return false, err
+	}
+	// Record the header as the channel_updated event of this change converts
+	// it, so the conversion doesn't replace the room topic the user set.
+	msg.Portal.Topic = m.connector.Config.topicText(header)
+	msg.Portal.TopicSet = true
+	return true, nil
+}
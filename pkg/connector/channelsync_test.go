@@ -0,0 +1,479 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func channelUpdatedEvent(t *testing.T, channel *model.Channel) *model.WebSocketEvent {
+	t.Helper()
+	data, err := json.Marshal(channel)
+	if err != nil {
+		t.Fatalf("marshal channel: %v", err)
+	}
+	return newWebSocketEvent(model.WebsocketEventChannelUpdated, channel.Id, map[string]any{"channel": string(data)})
+}
+
+func TestParseChannelUpdatedEvent(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr bool
+	}{
+		{"valid", map[string]any{"channel": `{"id":"ch1","display_name":"General"}`}, false},
+		{"missing channel", map[string]any{}, true},
+		{"invalid json", map[string]any{"channel": "{nope"}, true},
+		{"no channel id", map[string]any{"channel": `{"display_name":"General"}`}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			channel, err := client.parseChannelUpdatedEvent(newWebSocketEvent(model.WebsocketEventChannelUpdated, "ch1", tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && channel.DisplayName != "General" {
+				t.Errorf("display name: got %q", channel.DisplayName)
+			}
+		})
+	}
+}
+
+func TestHandleChannelUpdated(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Teams["my-user-id"] = []*model.Team{{Id: "team1", LastTeamIconUpdate: 1700000000000}}
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.handleEvent(channelUpdatedEvent(t, &model.Channel{
+		Id:          "ch1",
+		TeamId:      "team1",
+		Type:        model.ChannelTypeOpen,
+		Name:        "town-square",
+		DisplayName: "Town Square",
+		Header:      "New header",
+		Purpose:     "Chat",
+	}))
+
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	change, ok := events[0].(*simplevent.ChatInfoChange)
+	if !ok {
+		t.Fatalf("expected ChatInfoChange, got %T", events[0])
+	}
+	if change.PortalKey != makePortalKey("ch1") {
+		t.Errorf("portal key: got %v", change.PortalKey)
+	}
+	if change.GetSender().Sender != "" {
+		t.Errorf("change should be applied by the bridge bot, got sender %q", change.GetSender().Sender)
+	}
+	info := change.ChatInfoChange.ChatInfo
+	if info.Name == nil || *info.Name != "Town Square" {
+		t.Errorf("name: got %v", info.Name)
+	}
+	if info.Topic == nil || *info.Topic != "New header" {
+		t.Errorf("topic: got %v", info.Topic)
+	}
+	if info.Members != nil {
+		t.Error("members should not be touched by a channel update")
+	}
+	if info.Avatar == nil || info.Avatar.ID != "team_team1_1700000000000" {
+		t.Fatalf("avatar: got %+v", info.Avatar)
+	}
+	data, err := info.Avatar.Get(context.Background())
+	if err != nil || string(data) != "icon:team1" {
+		t.Errorf("avatar data: got %q, %v", data, err)
+	}
+}
+
+func TestHandleChannelUpdated_MarkdownHeader(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.handleEvent(channelUpdatedEvent(t, &model.Channel{
+		Id:     "ch1",
+		Type:   model.ChannelTypeOpen,
+		Name:   "town-square",
+		Header: "**On call:** see [the rota](https://example.com/rota)",
+	}))
+
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	info := events[0].(*simplevent.ChatInfoChange).ChatInfoChange.ChatInfo
+	if want := "On call: see the rota (https://example.com/rota)"; info.Topic == nil || *info.Topic != want {
+		t.Errorf("topic: got %v, want %q", info.Topic, want)
+	}
+}
+
+func TestHandleChannelUpdated_DirectMessage(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.handleEvent(channelUpdatedEvent(t, &model.Channel{
+		Id:   "dm1",
+		Type: model.ChannelTypeDirect,
+		Name: "user1__user2",
+	}))
+
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	info := events[0].(*simplevent.ChatInfoChange).ChatInfoChange.ChatInfo
+	if info.Name != nil || info.Topic != nil || info.Avatar != nil {
+		t.Errorf("DM update should not set name, topic or avatar: %+v", info)
+	}
+}
+
+func TestHandleChannelUpdated_InvalidPayload(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventChannelUpdated, "ch1", map[string]any{"channel": 42}))
+	if len(testMock(mc).Events()) != 0 {
+		t.Error("invalid payload should not queue events")
+	}
+}
+
+func TestChannelAvatar(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Teams["my-user-id"] = []*model.Team{
+		{Id: "with-icon", LastTeamIconUpdate: 42},
+		{Id: "no-icon"},
+	}
+	mc := newFullTestClient(fake.Server.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		channel *model.Channel
+		wantID  string
+	}{
+		{"team icon", &model.Channel{Id: "c1", TeamId: "with-icon"}, "team_with-icon_42"},
+		{"team without icon", &model.Channel{Id: "c2", TeamId: "no-icon"}, ""},
+		{"unknown team", &model.Channel{Id: "c3", TeamId: "missing"}, ""},
+		{"no team", &model.Channel{Id: "c4"}, ""},
+	}
+	for _, tt := range tests {
+		avatar := mc.channelAvatar(ctx, tt.channel)
+		if tt.wantID == "" {
+			if avatar != nil {
+				t.Errorf("%s: expected no avatar, got %q", tt.name, avatar.ID)
+			}
+			continue
+		}
+		if avatar == nil || string(avatar.ID) != tt.wantID {
+			t.Errorf("%s: got %+v, want ID %q", tt.name, avatar, tt.wantID)
+		}
+	}
+}
+
+func TestChannelAvatar_CachedUntilReset(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	team := &model.Team{Id: "team1", LastTeamIconUpdate: 1}
+	fake.Teams["my-user-id"] = []*model.Team{team}
+	mc := newFullTestClient(fake.Server.URL)
+	ctx := context.Background()
+	channel := &model.Channel{Id: "c1", TeamId: "team1"}
+
+	mc.channelAvatar(ctx, channel)
+	team.LastTeamIconUpdate = 2
+	if avatar := mc.channelAvatar(ctx, channel); avatar.ID != "team_team1_1" {
+		t.Errorf("cached avatar: got %q", avatar.ID)
+	}
+	teamCalls := 0
+	for _, call := range fake.Calls() {
+		if call.Path == "/api/v4/teams/team1" {
+			teamCalls++
+		}
+	}
+	if teamCalls != 1 {
+		t.Errorf("expected 1 team fetch, got %d", teamCalls)
+	}
+
+	mc.resetTeamIcons()
+	if avatar := mc.channelAvatar(ctx, channel); avatar.ID != "team_team1_2" {
+		t.Errorf("avatar after reset: got %q", avatar.ID)
+	}
+}
+
+func roomNameMessage(portal *bridgev2.Portal, sender string, origSender *bridgev2.OrigSender, name string) *bridgev2.MatrixRoomName {
+	return &bridgev2.MatrixRoomName{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.RoomNameEventContent]{
+			Event:      &event.Event{Sender: id.UserID("@" + sender + ":localhost")},
+			Content:    &event.RoomNameEventContent{Name: name},
+			Portal:     portal,
+			OrigSender: origSender,
+		},
+	}
+}
+
+func TestHandleMatrixRoomName(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Channels["ch1"] = &model.Channel{Id: "ch1", Type: model.ChannelTypeOpen, DisplayName: "Old"}
+	mc := newFullTestClient(fake.Server.URL)
+	portal := makeTestPortal("ch1")
+
+	changed, err := mc.HandleMatrixRoomName(context.Background(), roomNameMessage(portal, "me", nil, "  New name  "))
+	if err != nil {
+		t.Fatalf("HandleMatrixRoomName: %v", err)
+	}
+	if !changed {
+		t.Error("expected changed=true")
+	}
+	if got := fake.Channels["ch1"].DisplayName; got != "New name" {
+		t.Errorf("channel display name: got %q", got)
+	}
+	if portal.Name != "New name" || !portal.NameSet {
+		t.Errorf("portal name: got %q (set=%v)", portal.Name, portal.NameSet)
+	}
+}
+
+func TestHandleMatrixRoomName_Rejected(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		roomType   database.RoomType
+		origSender *bridgev2.OrigSender
+		roomName   string
+		wantErr    error
+	}{
+		{name: "empty name", roomName: "   "},
+		{name: "too long", roomName: strings.Repeat("n", model.ChannelDisplayNameMaxRunes+1)},
+		{name: "direct message", roomType: database.RoomTypeDM, roomName: "x", wantErr: errChannelEditUnsupported},
+		{name: "group message", roomType: database.RoomTypeGroupDM, roomName: "x", wantErr: errChannelEditUnsupported},
+		{name: "team space", roomType: database.RoomTypeSpace, roomName: "x", wantErr: errTeamSpaceUnsupported},
+		{name: "relayed user", origSender: &bridgev2.OrigSender{UserID: "@stranger:localhost"}, roomName: "x", wantErr: errRelayedChannelEdit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			mc := newFullTestClient(fake.Server.URL)
+			portal := makeTestPortal("ch1")
+			portal.RoomType = tt.roomType
+
+			changed, err := mc.HandleMatrixRoomName(context.Background(), roomNameMessage(portal, "someone", tt.origSender, tt.roomName))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if changed || portal.NameSet {
+				t.Error("portal should not be changed")
+			}
+			if fake.CalledPath("/patch") {
+				t.Error("channel should not be patched")
+			}
+		})
+	}
+}
+
+func TestHandleMatrixRoomName_NotLoggedIn(t *testing.T) {
+	t.Parallel()
+	mc := newNotLoggedInClient()
+	_, err := mc.HandleMatrixRoomName(context.Background(), roomNameMessage(makeTestPortal("ch1"), "me", nil, "x"))
+	if !errors.Is(err, bridgev2.ErrNotLoggedIn) {
+		t.Errorf("err = %v, want ErrNotLoggedIn", err)
+	}
+}
+
+func TestHandleMatrixRoomName_RelayedPuppet(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, _ := newHealthTestClient(fake)
+	portal := makeTestPortal("ch1")
+
+	origSender := &bridgev2.OrigSender{UserID: "@alice:localhost"}
+	if _, err := mc.HandleMatrixRoomName(context.Background(), roomNameMessage(portal, "alice", origSender, "Renamed")); err != nil {
+		t.Fatalf("HandleMatrixRoomName: %v", err)
+	}
+	if got := fake.Channels["ch1"].DisplayName; got != "Renamed" {
+		t.Errorf("channel display name: got %q", got)
+	}
+}
+
+func TestHandleMatrixRoomName_PuppetRejected(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.RejectTokens["puppet-token"] = &model.AppError{Id: "api.context.session_expired.app_error", StatusCode: 401}
+	mc, puppet := newHealthTestClient(fake)
+
+	origSender := &bridgev2.OrigSender{UserID: "@alice:localhost"}
+	if _, err := mc.HandleMatrixRoomName(context.Background(), roomNameMessage(makeTestPortal("ch1"), "alice", origSender, "Renamed")); err == nil {
+		t.Fatal("expected an error")
+	}
+	if puppet.Healthy() {
+		t.Error("puppet should be marked unhealthy")
+	}
+}
+
+func TestHandleMatrixRoomTopic(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Channels["ch1"] = &model.Channel{Id: "ch1", Type: model.ChannelTypeOpen, Header: "old", Purpose: "kept"}
+	mc := newFullTestClient(fake.Server.URL)
+	portal := makeTestPortal("ch1")
+
+	msg := &bridgev2.MatrixRoomTopic{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.TopicEventContent]{
+			Event:   &event.Event{Sender: "@me:localhost"},
+			Content: &event.TopicEventContent{Topic: "New topic"},
+			Portal:  portal,
+		},
+	}
+	changed, err := mc.HandleMatrixRoomTopic(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("HandleMatrixRoomTopic: %v", err)
+	}
+	if !changed || portal.Topic != "New topic" || !portal.TopicSet {
+		t.Errorf("portal topic: got %q (changed=%v set=%v)", portal.Topic, changed, portal.TopicSet)
+	}
+	ch := fake.Channels["ch1"]
+	if ch.Header != "New topic" || ch.Purpose != "kept" {
+		t.Errorf("channel: header %q, purpose %q", ch.Header, ch.Purpose)
+	}
+
+	// HTML topics become markdown, and the portal records the topic the
+	// header converts back to.
+	msg.Content = &event.TopicEventContent{
+		Topic: "Read the docs",
+		ExtensibleTopic: &event.ExtensibleTopic{Text: []event.ExtensibleText{
+			{MimeType: "text/html", Body: "Read <strong>the docs</strong>"},
+		}},
+	}
+	if _, err := mc.HandleMatrixRoomTopic(context.Background(), msg); err != nil {
+		t.Fatalf("HandleMatrixRoomTopic with HTML: %v", err)
+	}
+	if got := fake.Channels["ch1"].Header; got != "Read **the docs**" {
+		t.Errorf("channel header: got %q", got)
+	}
+	if portal.Topic != "Read the docs" {
+		t.Errorf("portal topic: got %q", portal.Topic)
+	}
+
+	msg.Content = &event.TopicEventContent{Topic: strings.Repeat("t", model.ChannelHeaderMaxRunes+1)}
+	if _, err := mc.HandleMatrixRoomTopic(context.Background(), msg); err == nil {
+		t.Error("expected an error for an overlong topic")
+	}
+}
+
+func TestConfigResyncInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		minutes int
+		want    time.Duration
+	}{
+		{0, 0},
+		{-5, 0},
+		{15, 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		cfg := &Config{ResyncIntervalMinutes: tt.minutes}
+		if got := cfg.resyncInterval(); got != tt.want {
+			t.Errorf("resyncInterval(%d) = %v, want %v", tt.minutes, got, tt.want)
+		}
+	}
+}
+
+// flakySyncSender is a remoteEventSender that panics on the ChatResync of
+// one channel and records how many events were queued at once.
+type flakySyncSender struct {
+	*mockEventSender
+	panicOn string
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (s *flakySyncSender) QueueRemoteEvent(login *bridgev2.UserLogin, evt bridgev2.RemoteEvent) {
+	if ParsePortalID(evt.GetPortalKey().ID) == s.panicOn {
+		panic("bad channel")
+	}
+	s.mu.Lock()
+	s.inFlight++
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+	s.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+	s.mockEventSender.QueueRemoteEvent(login, evt)
+}
+
+func TestSyncChannelList(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	var channels []*model.Channel
+	for i := range 8 {
+		channels = append(channels, &model.Channel{Id: fmt.Sprintf("ch%d", i), Name: fmt.Sprintf("channel-%d", i), Type: model.ChannelTypeOpen})
+	}
+	mc := newFullTestClient(fake.Server.URL)
+	sender := &flakySyncSender{mockEventSender: testMock(mc), panicOn: "ch3"}
+	mc.eventSender = sender
+
+	stats := mc.syncChannelList(context.Background(), channels, 2)
+
+	if stats.Synced != 7 || stats.Failed != 1 || stats.Skipped != 0 {
+		t.Errorf("stats: got %+v", stats)
+	}
+	if got := len(sender.Events()); got != 7 {
+		t.Errorf("a failing channel must not block the others: got %d events", got)
+	}
+	if sender.maxInFlight > 2 {
+		t.Errorf("at most 2 channels should sync at once, got %d", sender.maxInFlight)
+	}
+}
+
+func TestSyncChannelList_MemberFetchError(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.FailEndpoints["/members"] = true
+
+	mc := newFullTestClient(fake.Server.URL)
+	stats := mc.syncChannelList(context.Background(), []*model.Channel{
+		{Id: "ch1", Type: model.ChannelTypeOpen},
+		{Id: "ch2", Type: model.ChannelTypeOpen},
+	}, 4)
+
+	if stats.Failed != 2 || stats.done() != 2 {
+		t.Errorf("stats: got %+v", stats)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"maunium.net/go/mautrix/bridgev2"
@@ -22,7 +24,17 @@ func (m *MattermostClient) channelToChatInfo(channel *model.Channel, members mod
 	memberList := m.channelMembersToChatMembers(members)
 
 	chatInfo := &bridgev2.ChatInfo{
-		Members: memberList,
+		Members:  memberList,
+		ParentID: m.teamParentID(channel),
+		ExtraUpdates: bridgev2.MergeExtraUpdaters(
+			m.welcomeUpdater(channel),
+			m.channelInfoUpdater(channel),
+			m.teamUpdater(channel),
+			m.autoInviteUpdater(channel),
+			m.roomAliasUpdater(channel),
+			m.roomNameClaimer(channel),
+			m.archiveUpdater(channel.Id, channel.DeleteAt != 0),
+		),
 	}
 
 	switch channel.Type {
@@ -39,24 +51,126 @@ func (m *MattermostClient) channelToChatInfo(channel *model.Channel, members mod
 	case model.ChannelTypeGroup:
 		groupType := database.RoomTypeGroupDM
 		chatInfo.Type = &groupType
-		if channel.DisplayName != "" {
-			name := channel.DisplayName
-			chatInfo.Name = &name
-		}
 	default:
 		roomType := database.RoomTypeDefault
 		chatInfo.Type = &roomType
-		name := channel.DisplayName
-		if name == "" {
-			name = channel.Name
+	}
+	if channel.Type == model.ChannelTypeDirect || channel.Type == model.ChannelTypeGroup {
+		chatInfo.ExtraUpdates = bridgev2.MergeExtraUpdaters(chatInfo.ExtraUpdates, m.dmPuppetUpdater(chatInfo))
+	}
+	chatInfo.Name, chatInfo.Topic = m.channelNameAndTopic(m.log.WithContext(context.Background()), channel)
+
+	return chatInfo
+}
+
+// channelNameAndTopic returns the room name and topic for a channel. Either
+// is nil if the channel type has none: DMs are named after the other user,
+// and group DMs only have a name if Mattermost provides a display name.
+// Other channels are named by roomName.
+func (m *MattermostClient) channelNameAndTopic(ctx context.Context, channel *model.Channel) (name, topic *string) {
+	switch channel.Type {
+	case model.ChannelTypeDirect:
+		return nil, nil
+	case model.ChannelTypeGroup:
+		if channel.DisplayName != "" {
+			displayName := channel.DisplayName
+			name = &displayName
 		}
-		chatInfo.Name = &name
-		if channel.Header != "" {
-			chatInfo.Topic = &channel.Header
+		return name, nil
+	}
+	displayName := m.roomName(ctx, channel)
+	formatted := m.channelTopic(channel)
+	if formatted != "" {
+		topic = &formatted
+	}
+	return &displayName, topic
+}
+
+// channelTopic returns the Matrix room topic of a team channel: the topic
+// template applied to its header and purpose, with the markdown converted to
+// plain text.
+func (m *MattermostClient) channelTopic(channel *model.Channel) string {
+	cfg := &m.connector.Config
+	return cfg.topicText(cfg.FormatTopic(TopicParams{
+		Header:  channel.Header,
+		Purpose: channel.Purpose,
+	}))
+}
+
+// TopicParams holds the parameters for rendering the topic template.
+type TopicParams struct {
+	Header  string
+	Purpose string
+}
+
+// FormatTopic builds the Matrix room topic from a channel's header and
+// purpose. Without a template, or if rendering fails, it falls back to the
+// header, then the purpose.
+func (c *Config) FormatTopic(params TopicParams) string {
+	fallback := params.Header
+	if fallback == "" {
+		fallback = params.Purpose
+	}
+	if c.topicTemplate == nil {
+		return fallback
+	}
+	var buf []byte
+	if err := c.topicTemplate.Execute((*templateBuffer)(&buf), params); err != nil {
+		return fallback
+	}
+	return strings.TrimSpace(string(buf))
+}
+
+// StateChannelInfo is a custom state event holding the Mattermost channel
+// header and purpose, which don't both fit in the room topic.
+var StateChannelInfo = event.Type{Type: "fi.mau.mattermost.channel_info", Class: event.StateEventType}
+
+// ChannelInfoEventContent is the content of a StateChannelInfo event.
+type ChannelInfoEventContent struct {
+	Header  string `json:"header"`
+	Purpose string `json:"purpose"`
+}
+
+// channelInfoUpdater returns a ChatInfo.ExtraUpdates hook that keeps the
+// StateChannelInfo event in sync with the channel, or nil if disabled. The
+// values last sent are tracked in the portal metadata, so the event is only
+// sent when the header or purpose changes. Nothing is sent until the room
+// exists.
+func (m *MattermostClient) channelInfoUpdater(channel *model.Channel) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	if !m.connector.Config.ChannelInfoState {
+		return nil
+	}
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		meta := portalMetadata(portal)
+		if portal.MXID == "" || (meta.Header == channel.Header && meta.Purpose == channel.Purpose) {
+			return false
 		}
+		content := &ChannelInfoEventContent{Header: channel.Header, Purpose: channel.Purpose}
+		_, err := portal.Bridge.Bot.SendState(ctx, portal.MXID, StateChannelInfo, "", &event.Content{Parsed: content}, time.Time{})
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channel.Id).Msg("Failed to send channel info state event")
+			return false
+		}
+		meta.Header = channel.Header
+		meta.Purpose = channel.Purpose
+		return true
 	}
+}
 
-	return chatInfo
+// teamUpdater returns a ChatInfo.ExtraUpdates hook that records the
+// channel's team in the portal metadata. The team filters of relay,
+// auto_invite, relay_webhooks and the channel filter read it, so it is
+// recorded whatever the config says: a filter added by a reload must not
+// find portals without a team.
+func (m *MattermostClient) teamUpdater(channel *model.Channel) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	return func(_ context.Context, portal *bridgev2.Portal) bool {
+		meta := portalMetadata(portal)
+		if meta.TeamID == channel.TeamId {
+			return false
+		}
+		meta.TeamID = channel.TeamId
+		return true
+	}
 }
 
 // channelMembersToChatMembers converts Mattermost channel members to bridgev2 member list.
@@ -64,11 +178,14 @@ func (m *MattermostClient) channelMembersToChatMembers(members model.ChannelMemb
 	memberMap := make(map[networkid.UserID]bridgev2.ChatMember, len(members))
 
 	for _, member := range members {
+		if m.isExcludedGuestMember(member) {
+			continue
+		}
+		// The login's user and double-puppeted users join as themselves,
+		// like their messages do.
 		chatMember := bridgev2.ChatMember{
-			EventSender: bridgev2.EventSender{
-				Sender: MakeUserID(member.UserId),
-			},
-			Membership: event.MembershipJoin,
+			EventSender: m.memberSender(member.UserId),
+			Membership:  event.MembershipJoin,
 		}
 		// Mark channel admins as moderators.
 		if member.SchemeAdmin {
@@ -82,19 +199,15 @@ func (m *MattermostClient) channelMembersToChatMembers(members model.ChannelMemb
 
 	return &bridgev2.ChatMemberList{
 		IsFull:           true,
-		TotalMemberCount: len(members),
+		TotalMemberCount: len(memberMap),
 		MemberMap:        memberMap,
 	}
 }
 
 // mmUserToUserInfo converts a Mattermost user to a bridgev2.UserInfo.
-func (m *MattermostClient) mmUserToUserInfo(user *model.User) *bridgev2.UserInfo {
-	name := m.connector.Config.FormatDisplayname(DisplaynameParams{
-		Username:  user.Username,
-		Nickname:  user.Nickname,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-	})
+func (m *MattermostClient) mmUserToUserInfo(ctx context.Context, user *model.User) *bridgev2.UserInfo {
+	name := m.connector.ghostDisplayname(user, m.ghostTeams(ctx, []string{user.Id})[user.Id])
+	m.rememberGuest(user.Id, user.IsGuest())
 
 	info := &bridgev2.UserInfo{
 		Identifiers: []string{
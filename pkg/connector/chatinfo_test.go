@@ -6,9 +6,11 @@
 package connector
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
 	"maunium.net/go/mautrix/bridgev2/database"
 	"maunium.net/go/mautrix/bridgev2/networkid"
 	"maunium.net/go/mautrix/event"
@@ -291,7 +293,7 @@ func TestMmUserToUserInfo(t *testing.T) {
 		LastName:  "Doe",
 	}
 
-	info := client.mmUserToUserInfo(user)
+	info := client.mmUserToUserInfo(context.Background(), user)
 
 	if info.Name == nil {
 		t.Fatal("Name should not be nil")
@@ -316,7 +318,7 @@ func TestMmUserToUserInfo_Avatar(t *testing.T) {
 		LastPictureUpdate: 1700000000000,
 	}
 
-	info := client.mmUserToUserInfo(user)
+	info := client.mmUserToUserInfo(context.Background(), user)
 
 	if info.Avatar == nil {
 		t.Fatal("Avatar should not be nil")
@@ -338,7 +340,7 @@ func TestMmUserToUserInfo_AvatarID_Format(t *testing.T) {
 		LastPictureUpdate: 1700000000000,
 	}
 
-	info := client.mmUserToUserInfo(user)
+	info := client.mmUserToUserInfo(context.Background(), user)
 
 	expectedID := networkid.AvatarID("user456_1700000000000")
 	if info.Avatar.ID != expectedID {
@@ -353,8 +355,8 @@ func TestMmUserToUserInfo_AvatarCacheInvalidation(t *testing.T) {
 	user1 := &model.User{Id: "user789", Username: "bob", LastPictureUpdate: 1000}
 	user2 := &model.User{Id: "user789", Username: "bob", LastPictureUpdate: 2000}
 
-	info1 := client.mmUserToUserInfo(user1)
-	info2 := client.mmUserToUserInfo(user2)
+	info1 := client.mmUserToUserInfo(context.Background(), user1)
+	info2 := client.mmUserToUserInfo(context.Background(), user2)
 
 	if info1.Avatar.ID == info2.Avatar.ID {
 		t.Error("Avatar IDs should differ when LastPictureUpdate changes")
@@ -390,7 +392,7 @@ func TestMmUserToUserInfo_ZeroLastPictureUpdate(t *testing.T) {
 		LastPictureUpdate: 0,
 	}
 
-	info := client.mmUserToUserInfo(user)
+	info := client.mmUserToUserInfo(context.Background(), user)
 
 	if info.Avatar == nil {
 		t.Fatal("Avatar should not be nil even with zero LastPictureUpdate")
@@ -431,3 +433,132 @@ func TestChannelToChatInfo_PrivateChannel(t *testing.T) {
 		t.Errorf("Topic: got %v, want %q", info.Topic, "Private matters")
 	}
 }
+
+func TestFormatTopic(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		params   TopicParams
+		want     string
+	}{
+		{"header", "", TopicParams{Header: "H", Purpose: "P"}, "H"},
+		{"purpose fallback", "", TopicParams{Purpose: "P"}, "P"},
+		{"empty", "", TopicParams{}, ""},
+		{"combined", "{{.Header}}{{if and .Header .Purpose}} | {{end}}{{.Purpose}}", TopicParams{Header: "H", Purpose: "P"}, "H | P"},
+		{"combined header only", "{{.Header}}{{if and .Header .Purpose}} | {{end}}{{.Purpose}}", TopicParams{Header: "H"}, "H"},
+		{"purpose only template", "{{.Purpose}}", TopicParams{Header: "H", Purpose: "P"}, "P"},
+		{"trimmed", "  {{.Header}}\n", TopicParams{Header: "H"}, "H"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := Config{TopicTemplate: tt.template}
+			if err := cfg.PostProcess(); err != nil {
+				t.Fatalf("PostProcess: %v", err)
+			}
+			if got := cfg.FormatTopic(tt.params); got != tt.want {
+				t.Errorf("FormatTopic() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigPostProcessTopicTemplate(t *testing.T) {
+	t.Parallel()
+	cfg := Config{TopicTemplate: "{{.Header"}
+	if err := cfg.PostProcess(); err == nil {
+		t.Fatal("expected error for invalid topic_template")
+	}
+}
+
+func TestChannelToChatInfo_TopicFromPurpose(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	channel := &model.Channel{Id: "ch1", Type: model.ChannelTypeOpen, Name: "dev", Purpose: "Development chat"}
+
+	info := client.channelToChatInfo(channel, nil)
+	if info.Topic == nil || *info.Topic != "Development chat" {
+		t.Errorf("Topic: got %v, want %q", info.Topic, "Development chat")
+	}
+}
+
+func TestChannelInfoUpdater(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.Config.ChannelInfoState = true
+	client.log = zerolog.Nop()
+
+	portal := makeTestPortalWithBot("ch1", nil)
+	bot := portal.Bridge.Bot.(*fakeMatrixBot)
+	ctx := context.Background()
+	channel := &model.Channel{Id: "ch1", Type: model.ChannelTypeOpen, Header: "Header", Purpose: "Purpose"}
+
+	update := client.channelToChatInfo(channel, nil).ExtraUpdates
+	if update == nil {
+		t.Fatal("expected ExtraUpdates hook")
+	}
+
+	// Before the room exists nothing is sent.
+	if update(ctx, portal) {
+		t.Error("update before room creation should not report a change")
+	}
+	if len(bot.States()) != 0 {
+		t.Fatal("no state should be sent before the room exists")
+	}
+
+	portal.MXID = "!room:localhost"
+	if !update(ctx, portal) {
+		t.Error("first update with a room should report a change")
+	}
+	if update(ctx, portal) {
+		t.Error("unchanged channel should not resend the state event")
+	}
+
+	channel.Purpose = "New purpose"
+	if !client.channelInfoUpdater(channel)(ctx, portal) {
+		t.Error("changed purpose should report a change")
+	}
+
+	states := bot.States()
+	if len(states) != 2 {
+		t.Fatalf("expected 2 state events, got %d", len(states))
+	}
+	last := states[1]
+	if last.Type != StateChannelInfo || last.StateKey != "" {
+		t.Errorf("state type/key: got %v %q", last.Type, last.StateKey)
+	}
+	content := last.Content.Parsed.(*ChannelInfoEventContent)
+	if content.Header != "Header" || content.Purpose != "New purpose" {
+		t.Errorf("content: got %+v", content)
+	}
+	if meta := portalMetadata(portal); meta.Header != "Header" || meta.Purpose != "New purpose" {
+		t.Errorf("metadata: got %q %q", meta.Header, meta.Purpose)
+	}
+}
+
+func TestTeamUpdater(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	portal := makeTestPortal("ch1")
+	ctx := context.Background()
+
+	update := client.teamUpdater(&model.Channel{Id: "ch1", TeamId: "team1"})
+	if !update(ctx, portal) {
+		t.Error("first update should report a change")
+	}
+	if got := portalMetadata(portal).TeamID; got != "team1" {
+		t.Errorf("TeamID: got %q, want team1", got)
+	}
+	if update(ctx, portal) {
+		t.Error("unchanged team should not report a change")
+	}
+}
+
+func TestChannelInfoUpdater_Disabled(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	if client.channelInfoUpdater(&model.Channel{Id: "ch1"}) != nil {
+		t.Error("channelInfoUpdater should be nil when channel_info_state is off")
+	}
+}
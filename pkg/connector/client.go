@@ -7,12 +7,15 @@ package connector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"time"
 
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/rs/zerolog"
 	"maunium.net/go/mautrix/bridgev2"
@@ -50,6 +53,100 @@ type MattermostClient struct {
 	teamID    string
 	serverURL string
 
+	// presenceStatus maps the Mattermost users whose status is polled to
+	// their last bridged status; the least recently active users are
+	// dropped once caches.max_entries is reached. Guarded by presenceMu.
+	presenceStatus *boundedCache[string, string]
+	presenceMu     sync.Mutex
+
+	// memberRoles maps channel members to the channel roles last seen for
+	// them, so power levels are only updated when the roles change.
+	// Guarded by memberRolesMu.
+	memberRoles   *boundedCache[channelMemberKey, channelRoles]
+	memberRolesMu sync.Mutex
+
+	// reconcilingPortals holds the portals whose reactions are being
+	// reconciled after a redaction, and whether another redaction came in
+	// meanwhile. Guarded by reconcilingMu.
+	reconcilingPortals map[networkid.PortalKey]bool
+	reconcilingMu      sync.Mutex
+
+	// receiptChannels holds the channels whose read positions are polled
+	// for read_receipts, by channel ID. Guarded by receiptsMu.
+	receiptChannels map[string]*receiptChannel
+	receiptsMu      sync.Mutex
+
+	// teamIcons caches each team's LastTeamIconUpdate for channel avatars
+	// until the next channel sync. Guarded by teamIconsMu.
+	teamIcons   *boundedCache[string, int64]
+	teamIconsMu sync.Mutex
+
+	// guests caches whether Mattermost users are guests, for guests.exclude,
+	// until the next channel sync. Guarded by guestsMu.
+	guests   *boundedCache[string, bool]
+	guestsMu sync.Mutex
+
+	// recentEvents holds the keys of the Mattermost events most recently
+	// queued, so events delivered twice are dropped. Guarded by
+	// recentEventsMu.
+	recentEvents   *boundedCache[string, struct{}]
+	recentEventsMu sync.Mutex
+
+	// mentionUsers caches the Mattermost users looked up to convert mentions,
+	// by username, until the next channel sync; nil marks usernames that
+	// don't exist. mentionUsernames caches usernames by user ID. Guarded by
+	// mentionMu.
+	mentionUsers     *boundedCache[string, *model.User]
+	mentionUsernames *boundedCache[string, string]
+	// mentionMembers caches whether mentioned users are members of the
+	// channel they were mentioned in, until the next channel sync or
+	// membership change. Guarded by mentionMu.
+	mentionMembers *boundedCache[channelMemberKey, bool]
+	mentionMu      sync.Mutex
+
+	// channelIDs caches the channel IDs looked up to convert ~channel
+	// references, by "team ID/channel name", until the next channel sync or
+	// channel update; "" marks names that don't exist. channelNames caches
+	// channel names by ID, and teams the teams of permalinks and room names
+	// by ID. Guarded by channelLinksMu.
+	channelIDs     *boundedCache[string, string]
+	channelNames   *boundedCache[string, string]
+	teams          *boundedCache[string, *model.Team]
+	channelLinksMu sync.Mutex
+
+	// profile is the Matrix profile of a double-puppeted user last pushed to
+	// their Mattermost account. Guarded by profileMu.
+	profile   matrixProfile
+	profileMu sync.Mutex
+
+	// holdFlushMu is held while the login's held Matrix messages are sent.
+	holdFlushMu sync.Mutex
+
+	// connState is the login's connState* connection state, and
+	// lastEventAt the time of its last WebSocket event in Unix
+	// milliseconds, for /api/health.
+	connState   atomic.Value
+	lastEventAt atomic.Int64
+
+	// maxPostSize is the server's post size limit in runes once fetched,
+	// for splitting long Matrix messages.
+	maxPostSize atomic.Int64
+
+	// matterpollEnabled is whether the Matterpoll plugin was enabled when
+	// it was checked at matterpollCheckedAt. Guarded by matterpollMu.
+	matterpollEnabled   bool
+	matterpollCheckedAt time.Time
+	matterpollMu        sync.Mutex
+
+	// bursts holds the bot posts waiting to be bridged as one message for
+	// coalesce_bot_posts, by channel ID. Guarded by burstsMu.
+	bursts   map[string]*postBurst
+	burstsMu sync.Mutex
+
+	// tokenCheck is set while a rejection of the login's token is checked,
+	// and after the token turned out to be revoked or expired.
+	tokenCheck atomic.Bool
+
 	stopOnce sync.Once
 	stopChan chan struct{}
 	log      zerolog.Logger
@@ -62,6 +159,10 @@ var (
 	_ bridgev2.RedactionHandlingNetworkAPI   = (*MattermostClient)(nil)
 	_ bridgev2.ReadReceiptHandlingNetworkAPI = (*MattermostClient)(nil)
 	_ bridgev2.TypingHandlingNetworkAPI      = (*MattermostClient)(nil)
+	_ bridgev2.RoomNameHandlingNetworkAPI    = (*MattermostClient)(nil)
+	_ bridgev2.RoomTopicHandlingNetworkAPI   = (*MattermostClient)(nil)
+	_ bridgev2.MembershipHandlingNetworkAPI  = (*MattermostClient)(nil)
+	_ bridgev2.PollHandlingNetworkAPI        = (*MattermostClient)(nil)
 )
 
 // NewMattermostClient creates a new client from an existing user login.
@@ -70,7 +171,7 @@ func NewMattermostClient(login *bridgev2.UserLogin, connector *MattermostConnect
 	mc := &MattermostClient{
 		connector:   connector,
 		userLogin:   login,
-		eventSender: &bridgeEventSender{bridge: connector.Bridge},
+		eventSender: connector.remoteEventSender(),
 		stopChan:    make(chan struct{}),
 		log:         log,
 	}
@@ -84,7 +185,7 @@ func NewMattermostClient(login *bridgev2.UserLogin, connector *MattermostConnect
 	mc.teamID = meta.TeamID
 	mc.serverURL = meta.ServerURL
 	if meta.Token != "" && !meta.DoublePuppetOnly {
-		mc.client = model.NewAPIv4Client(meta.ServerURL)
+		mc.client = connector.newAPIClient(meta.ServerURL)
 		mc.client.SetToken(meta.Token)
 	}
 	return mc
@@ -100,6 +201,7 @@ func (m *MattermostClient) Connect(ctx context.Context) {
 		m.connector.dpLoginsMu.Lock()
 		m.connector.dpLogins[meta.UserID] = m.userLogin.ID
 		m.connector.dpLoginsMu.Unlock()
+		m.setConnState(connStateDoublePuppetOnly)
 		m.log.Info().
 			Str("mm_user_id", meta.UserID).
 			Str("matrix_mxid", string(m.userLogin.UserMXID)).
@@ -109,6 +211,7 @@ func (m *MattermostClient) Connect(ctx context.Context) {
 
 	if m.client == nil {
 		m.log.Warn().Msg("Client not initialized, login first")
+		m.setConnState(connStateBadCredentials)
 		m.userLogin.BridgeState.Send(status.BridgeState{
 			StateEvent: status.StateBadCredentials,
 			Error:      "mm-not-logged-in",
@@ -119,9 +222,20 @@ func (m *MattermostClient) Connect(ctx context.Context) {
 
 	m.log.Info().Str("server_url", m.serverURL).Msg("Connecting to Mattermost")
 
+	refreshOAuth2 := meta != nil && meta.RefreshToken != "" && m.connector.Config.OAuth2.enabled()
+	if refreshOAuth2 && m.needsOAuth2Refresh(meta) {
+		if err := m.refreshOAuth2Token(ctx); errors.Is(err, errOAuth2GrantRejected) {
+			m.oauth2RefreshRejected(err)
+			return
+		} else if err != nil {
+			m.log.Warn().Err(err).Msg("Failed to refresh OAuth 2.0 access token before connecting")
+		}
+	}
+
 	me, _, err := m.client.GetMe(ctx, "")
 	if err != nil {
 		m.log.Error().Err(err).Msg("Failed to verify Mattermost session")
+		m.setConnState(connStateBadCredentials)
 		m.userLogin.BridgeState.Send(status.BridgeState{
 			StateEvent: status.StateBadCredentials,
 			Error:      "mm-token-invalid",
@@ -131,11 +245,14 @@ func (m *MattermostClient) Connect(ctx context.Context) {
 	}
 	m.userID = me.Id
 	m.log.Info().Str("user_id", me.Id).Str("username", me.Username).Msg("Authenticated")
+	m.tokenCheck.Store(false)
+	m.watchToken()
 
 	if m.teamID == "" {
 		teams, _, err := m.client.GetTeamsForUser(ctx, m.userID, "")
 		if err != nil {
 			m.log.Error().Err(err).Msg("Failed to get teams")
+			m.setConnState(connStateDisconnected)
 			m.userLogin.BridgeState.Send(status.BridgeState{
 				StateEvent: status.StateUnknownError,
 				Error:      "mm-teams-failed",
@@ -150,6 +267,7 @@ func (m *MattermostClient) Connect(ctx context.Context) {
 
 	if err := m.connectWebSocket(); err != nil {
 		m.log.Error().Err(err).Msg("WebSocket connection failed")
+		m.setConnState(connStateDisconnected)
 		m.userLogin.BridgeState.Send(status.BridgeState{
 			StateEvent: status.StateTransientDisconnect,
 			Error:      "mm-ws-failed",
@@ -158,12 +276,38 @@ func (m *MattermostClient) Connect(ctx context.Context) {
 		return
 	}
 
+	m.setConnState(connStateConnected)
 	m.userLogin.BridgeState.Send(status.BridgeState{
 		StateEvent: status.StateConnected,
 	})
 
+	m.connector.primaryClient.CompareAndSwap(nil, m.client)
+	if refreshOAuth2 && meta.TokenExpiresAt > 0 {
+		go m.refreshOAuth2Periodically(m.log.WithContext(context.Background()))
+	}
+	if m.connector.Config.BridgePresence {
+		go m.pollPresence(m.log.WithContext(context.Background()), presencePollInterval)
+	}
+	if m.connector.Config.ReadReceipts.Enabled {
+		if interval := m.connector.Config.ReadReceipts.pollInterval(); interval > 0 {
+			go m.pollReadReceipts(m.log.WithContext(context.Background()), interval)
+		}
+	}
+	if interval := m.connector.Config.ReactionSync.interval(); interval > 0 {
+		go m.syncReactionsPeriodically(m.log.WithContext(context.Background()), interval)
+	}
+	if interval := m.connector.Config.resyncInterval(); interval > 0 {
+		go m.resyncChannels(m.log.WithContext(context.Background()), interval)
+	}
+	if m.connector.outboundHold != nil {
+		go m.flushHeldMessages(m.log.WithContext(context.Background()))
+		go m.retryHeldMessages(m.log.WithContext(context.Background()), m.connector.Config.OutboundHold.retryInterval())
+	}
+
 	// Sync existing channels to create portal rooms in Matrix.
 	go m.syncChannels(ctx)
+	go m.recoverMissedPosts(m.log.WithContext(context.Background()))
+	go m.resyncMissedChannels(m.log.WithContext(context.Background()))
 }
 
 func (m *MattermostClient) connectWebSocket() error {
@@ -206,12 +350,20 @@ func (m *MattermostClient) listenWebSocket() {
 			if event == nil {
 				continue
 			}
+			m.lastEventAt.Store(time.Now().UnixMilli())
 			m.handleEvent(event)
 		}
 	}
 }
 
 func (m *MattermostClient) handleWebSocketDisconnect() {
+	// A rejected token stops the login; reconnecting would fail anyway.
+	select {
+	case <-m.stopChan:
+		return
+	default:
+	}
+	m.setConnState(connStateReconnecting)
 	m.userLogin.BridgeState.Send(status.BridgeState{
 		StateEvent: status.StateTransientDisconnect,
 		Error:      "mm-ws-disconnected",
@@ -219,16 +371,28 @@ func (m *MattermostClient) handleWebSocketDisconnect() {
 	})
 
 	if err := m.connectWebSocket(); err != nil {
+		m.connector.metrics().wsReconnects.inc("failure")
 		m.log.Error().Err(err).Msg("Failed to reconnect WebSocket")
+		m.setConnState(connStateDisconnected)
 		m.userLogin.BridgeState.Send(status.BridgeState{
 			StateEvent: status.StateUnknownError,
 			Error:      "mm-ws-reconnect-failed",
 			Message:    "Failed to reconnect WebSocket",
 		})
 	} else {
+		m.connector.metrics().wsReconnects.inc("success")
+		m.setConnState(connStateConnected)
 		m.userLogin.BridgeState.Send(status.BridgeState{
 			StateEvent: status.StateConnected,
 		})
+		// Posts sent while the WebSocket was down were never received;
+		// resync so the bridge backfills them.
+		if m.connector.Config.backfillMissed() {
+			go m.syncChannels(m.log.WithContext(context.Background()))
+		}
+		if m.connector.outboundHold != nil {
+			go m.flushHeldMessages(m.log.WithContext(context.Background()))
+		}
 	}
 }
 
@@ -237,6 +401,10 @@ func (m *MattermostClient) handleWebSocketDisconnect() {
 // creates portal rooms in Matrix.
 func (m *MattermostClient) syncChannels(ctx context.Context) {
 	channelMap := make(map[string]*model.Channel)
+	m.resetTeamIcons()
+	m.resetGuests()
+	m.resetMentionUsers()
+	m.resetChannelLinks()
 
 	// Fetch team channels if we have a team ID.
 	if m.teamID != "" {
@@ -263,52 +431,97 @@ func (m *MattermostClient) syncChannels(ctx context.Context) {
 		}
 	}
 
-	m.log.Info().Int("count", len(channelMap)).Msg("Syncing channels")
+	if m.connector.Config.Sharding.Enabled() {
+		for chID := range channelMap {
+			if !m.connector.OwnsChannel(chID) {
+				delete(channelMap, chID)
+			}
+		}
+	}
+
+	excluded := 0
+	for chID, ch := range channelMap {
+		if !m.channelAllowed(ctx, ch.Id, ch.Name, ch.TeamId) {
+			delete(channelMap, chID)
+			excluded++
+		}
+	}
+	if excluded > 0 {
+		m.log.Info().Int("excluded", excluded).Msg("Applied channel filter to channel sync")
+	}
 
+	channels := make([]*model.Channel, 0, len(channelMap))
 	for _, ch := range channelMap {
-		m.log.Debug().
-			Str("channel_id", ch.Id).
-			Str("channel_name", ch.Name).
-			Str("channel_type", string(ch.Type)).
-			Msg("Syncing channel")
+		channels = append(channels, ch)
+	}
+	m.syncChannelList(ctx, channels, m.connector.Config.MattermostAPI.syncConcurrency())
+}
 
-		members, _, err := m.client.GetChannelMembers(ctx, ch.Id, 0, 200, "")
-		if err != nil {
-			m.log.Warn().Err(err).Str("channel_id", ch.Id).Msg("Failed to get channel members")
-			continue
-		}
+// queueChannelSync queues a ChatResync for a channel with its current info
+// and members. When backfill is enabled, the resync also backfills the
+// channel if it's new, or catches up if it has posts newer than the latest
+// bridged message.
+func (m *MattermostClient) queueChannelSync(ctx context.Context, ch *model.Channel, createPortal bool) {
+	evt, err := m.channelSyncEvent(ctx, ch, createPortal)
+	if err != nil {
+		m.log.Warn().Err(err).Str("channel_id", ch.Id).Msg("Failed to get channel members")
+		return
+	}
+	if evt != nil {
+		m.eventSender.QueueRemoteEvent(m.userLogin, evt)
+	}
+}
+
+// channelSyncEvent returns the ChatResync queued by queueChannelSync, or nil
+// for DMs with an excluded guest.
+func (m *MattermostClient) channelSyncEvent(ctx context.Context, ch *model.Channel, createPortal bool) (*simplevent.ChatResync, error) {
+	m.log.Debug().
+		Str("channel_id", ch.Id).
+		Str("channel_name", ch.Name).
+		Str("channel_type", string(ch.Type)).
+		Msg("Syncing channel")
+
+	members, _, err := m.client.GetChannelMembers(ctx, ch.Id, 0, 200, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel members: %w", err)
+	}
+	if ch.Type == model.ChannelTypeDirect && m.hasExcludedGuest(members) {
+		m.log.Debug().Str("channel_id", ch.Id).Msg("Skipping DM with excluded guest")
+		return nil, nil
+	}
 
-		chatInfo := m.channelToChatInfo(ch, members)
-
-		var checkBackfill func(ctx context.Context, latestMessage *database.Message) (bool, error)
-		var latestMessageTS time.Time
-		if m.connector.Config.BackfillEnabled && ch.LastPostAt > 0 {
-			lastPostAt := ch.LastPostAt
-			latestMessageTS = time.UnixMilli(lastPostAt)
-			checkBackfill = func(_ context.Context, latestMessage *database.Message) (bool, error) {
-				if latestMessage == nil {
-					return true, nil
-				}
-				return latestMessage.Timestamp.Before(time.UnixMilli(lastPostAt)), nil
+	chatInfo := m.channelToChatInfo(ch, members)
+	m.applyRolePowerLevels(ctx, ch, members, chatInfo.Members)
+	chatInfo.Avatar = m.channelAvatar(ctx, ch)
+
+	var checkBackfill func(ctx context.Context, latestMessage *database.Message) (bool, error)
+	var latestMessageTS time.Time
+	cfg := &m.connector.Config
+	if (cfg.backfillOnCreate() || cfg.backfillMissed()) && ch.LastPostAt > 0 {
+		lastPostAt := ch.LastPostAt
+		latestMessageTS = time.UnixMilli(lastPostAt)
+		checkBackfill = func(_ context.Context, latestMessage *database.Message) (bool, error) {
+			if latestMessage == nil {
+				return cfg.backfillOnCreate(), nil
 			}
+			return cfg.backfillMissed() && latestMessage.Timestamp.Before(time.UnixMilli(lastPostAt)), nil
 		}
-
-		m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatResync{
-			EventMeta: simplevent.EventMeta{
-				Type:      bridgev2.RemoteEventChatResync,
-				PortalKey: makePortalKey(ch.Id),
-				LogContext: func(c zerolog.Context) zerolog.Context {
-					return c.Str("channel_id", ch.Id).Str("channel_name", ch.Name)
-				},
-				CreatePortal: true,
-			},
-			ChatInfo:               chatInfo,
-			LatestMessageTS:        latestMessageTS,
-			CheckNeedsBackfillFunc: checkBackfill,
-		})
 	}
 
-	m.log.Info().Msg("Channel sync complete")
+	return &simplevent.ChatResync{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatResync,
+			PortalKey: makePortalKey(ch.Id),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("channel_id", ch.Id).Str("channel_name", ch.Name)
+			},
+			CreatePortal:   createPortal,
+			PostHandleFunc: m.connector.watchIfRelayMissing,
+		},
+		ChatInfo:               chatInfo,
+		LatestMessageTS:        latestMessageTS,
+		CheckNeedsBackfillFunc: checkBackfill,
+	}, nil
 }
 
 // Disconnect closes the WebSocket connection and stops the client's event loop.
@@ -316,6 +529,13 @@ func (m *MattermostClient) Disconnect() {
 	m.stopOnce.Do(func() {
 		close(m.stopChan)
 	})
+	m.flushBursts()
+	if m.connectionState() != connStateDoublePuppetOnly {
+		m.setConnState(connStateDisconnected)
+	}
+	if m.client != nil && m.connector != nil {
+		m.connector.primaryClient.CompareAndSwap(m.client, nil)
+	}
 	if m.wsClient != nil {
 		m.wsClient.Close()
 		m.wsClient = nil
@@ -332,6 +552,21 @@ func (m *MattermostClient) LogoutRemote(ctx context.Context) {
 		_, _ = m.client.Logout(ctx)
 	}
 	m.Disconnect()
+	m.forgetDoublePuppetLogin()
+	m.connector.untrackClient(m)
+}
+
+// forgetDoublePuppetLogin removes this login's double puppet mapping, so
+// logged out logins don't stay in dpLogins until the bridge restarts.
+func (m *MattermostClient) forgetDoublePuppetLogin() {
+	if m.connector == nil || m.userLogin == nil {
+		return
+	}
+	m.connector.dpLoginsMu.Lock()
+	defer m.connector.dpLoginsMu.Unlock()
+	if m.connector.dpLogins[m.userID] == m.userLogin.ID {
+		delete(m.connector.dpLogins, m.userID)
+	}
 }
 
 // IsThisUser reports whether the given network user ID matches this client's Mattermost user.
@@ -340,6 +575,12 @@ func (m *MattermostClient) IsThisUser(_ context.Context, userID networkid.UserID
 }
 
 func (m *MattermostClient) GetChatInfo(ctx context.Context, portal *bridgev2.Portal) (*bridgev2.ChatInfo, error) {
+	if teamID, ok := ParseTeamPortalID(portal.ID); ok {
+		return m.getTeamChatInfo(ctx, teamID)
+	}
+	if channelID, rootID, ok := ParseThreadPortalID(portal.ID); ok {
+		return m.getThreadChatInfo(ctx, channelID, rootID)
+	}
 	channelID := ParsePortalID(portal.ID)
 	channel, _, err := m.client.GetChannel(ctx, channelID, "")
 	if err != nil {
@@ -351,7 +592,10 @@ func (m *MattermostClient) GetChatInfo(ctx context.Context, portal *bridgev2.Por
 		return nil, fmt.Errorf("failed to get channel members: %w", err)
 	}
 
-	return m.channelToChatInfo(channel, members), nil
+	chatInfo := m.channelToChatInfo(channel, members)
+	m.applyRolePowerLevels(ctx, channel, members, chatInfo.Members)
+	chatInfo.Avatar = m.channelAvatar(ctx, channel)
+	return chatInfo, nil
 }
 
 func (m *MattermostClient) GetUserInfo(ctx context.Context, ghost *bridgev2.Ghost) (*bridgev2.UserInfo, error) {
@@ -360,38 +604,31 @@ func (m *MattermostClient) GetUserInfo(ctx context.Context, ghost *bridgev2.Ghos
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
-	return m.mmUserToUserInfo(user), nil
+	return m.mmUserToUserInfo(ctx, user), nil
 }
 
+// GetCapabilities declares the message features portal rooms support, so
+// Matrix clients can adapt their composer. Formatting levels come from the
+// matrixfmt feature table.
 func (m *MattermostClient) GetCapabilities(_ context.Context, _ *bridgev2.Portal) *event.RoomFeatures {
 	return &event.RoomFeatures{
-		Formatting: event.FormattingFeatureMap{
-			event.FmtBold:          event.CapLevelFullySupported,
-			event.FmtItalic:        event.CapLevelFullySupported,
-			event.FmtStrikethrough: event.CapLevelFullySupported,
-			event.FmtInlineCode:    event.CapLevelFullySupported,
-			event.FmtCodeBlock:     event.CapLevelFullySupported,
-			event.FmtBlockquote:    event.CapLevelFullySupported,
-			event.FmtInlineLink:    event.CapLevelFullySupported,
-			event.FmtUserLink:      event.CapLevelFullySupported,
-			event.FmtUnorderedList: event.CapLevelFullySupported,
-			event.FmtOrderedList:   event.CapLevelFullySupported,
-			event.FmtHeaders:       event.CapLevelFullySupported,
-		},
+		Formatting: matrixfmt.Features(),
 		File: event.FileFeatureMap{
 			event.MsgImage: {
 				MimeTypes: map[string]event.CapabilitySupportLevel{
 					"image/*": event.CapLevelFullySupported,
 				},
-				MaxSize: 100 * 1024 * 1024,
-				Caption: event.CapLevelFullySupported,
+				MaxSize:          100 * 1024 * 1024,
+				Caption:          event.CapLevelFullySupported,
+				MaxCaptionLength: model.PostMessageMaxRunesV2,
 			},
 			event.MsgVideo: {
 				MimeTypes: map[string]event.CapabilitySupportLevel{
 					"video/*": event.CapLevelFullySupported,
 				},
-				MaxSize: 100 * 1024 * 1024,
-				Caption: event.CapLevelFullySupported,
+				MaxSize:          100 * 1024 * 1024,
+				Caption:          event.CapLevelFullySupported,
+				MaxCaptionLength: model.PostMessageMaxRunesV2,
 			},
 			event.MsgAudio: {
 				MimeTypes: map[string]event.CapabilitySupportLevel{
@@ -406,11 +643,12 @@ func (m *MattermostClient) GetCapabilities(_ context.Context, _ *bridgev2.Portal
 				MaxSize: 100 * 1024 * 1024,
 			},
 		},
-		MaxTextLength:       16383,
+		MaxTextLength:       model.PostMessageMaxRunesV2,
 		Reply:               event.CapLevelFullySupported,
 		Edit:                event.CapLevelFullySupported,
 		Delete:              event.CapLevelFullySupported,
 		Reaction:            event.CapLevelFullySupported,
+		Poll:                event.CapLevelPartialSupport,
 		ReadReceipts:        true,
 		TypingNotifications: true,
 	}
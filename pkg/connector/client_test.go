@@ -10,11 +10,13 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/rs/zerolog"
 	"maunium.net/go/mautrix/bridgev2"
 	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
 	"maunium.net/go/mautrix/id"
 )
 
@@ -289,6 +291,50 @@ func TestSyncChannels_NonDMChannelReachesQueue(t *testing.T) {
 	}
 }
 
+// TestSyncChannels_PortalCreationPolicy verifies that the channel sync creates
+// portals under only-synced, but not under never.
+func TestSyncChannels_PortalCreationPolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		cfg  PortalCreationConfig
+		want map[string]bool
+	}{
+		{"only-synced", PortalCreationConfig{Policy: PortalCreationOnlySynced}, map[string]bool{"pub1": true, "dm1": true}},
+		{"never", PortalCreationConfig{Policy: PortalCreationNever}, map[string]bool{"pub1": false, "dm1": false}},
+		{"never except team", PortalCreationConfig{Policy: PortalCreationNever, Teams: map[string]PortalCreationPolicy{"my-team-id": PortalCreationOnlySynced}}, map[string]bool{"pub1": true, "dm1": false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.ChannelsForUser["my-user-id"] = []*model.Channel{
+				{Id: "pub1", Name: "public-channel", Type: model.ChannelTypeOpen, TeamId: "my-team-id"},
+				{Id: "dm1", Name: "my-user-id__other", Type: model.ChannelTypeDirect},
+			}
+
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.PortalCreation = tt.cfg
+			mock := testMock(mc)
+
+			mc.syncChannels(context.Background())
+
+			events := mock.Events()
+			if len(events) != len(tt.want) {
+				t.Fatalf("expected %d events, got %d", len(tt.want), len(events))
+			}
+			for _, evt := range events {
+				resync := evt.(*simplevent.ChatResync)
+				chID := ParsePortalID(resync.PortalKey.ID)
+				if resync.CreatePortal != tt.want[chID] {
+					t.Errorf("%s: CreatePortal = %v, want %v", chID, resync.CreatePortal, tt.want[chID])
+				}
+			}
+		})
+	}
+}
+
 // TestSyncChannels_NoTeamStillFetchesDMs verifies that DMs are synced even
 // when teamID is empty.
 func TestSyncChannels_NoTeamStillFetchesDMs(t *testing.T) {
@@ -492,3 +538,58 @@ func TestDisconnect_ConcurrentSafe(t *testing.T) {
 		t.Fatal("stopChan was not closed after concurrent Disconnect calls")
 	}
 }
+
+// TestSyncChannels_BackfillCheck verifies that the backfill block decides
+// whether new and existing portals are backfilled.
+func TestSyncChannels_BackfillCheck(t *testing.T) {
+	t.Parallel()
+	lastPostAt := time.Now().UnixMilli()
+	stale := &database.Message{Timestamp: time.UnixMilli(lastPostAt - 1000)}
+	tests := []struct {
+		name        string
+		backfill    BackfillConfig
+		wantCheck   bool
+		wantCreate  bool
+		wantCatchUp bool
+	}{
+		{"disabled", BackfillConfig{}, false, false, false},
+		{"on create", BackfillConfig{EnableOnCreate: true}, true, true, false},
+		{"missed", BackfillConfig{MissedLimit: 10}, true, false, true},
+		{"both", BackfillConfig{EnableOnCreate: true, MissedLimit: 10}, true, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.ChannelsForUser["my-user-id"] = []*model.Channel{
+				{Id: "ch1", Name: "town-square", Type: model.ChannelTypeOpen, LastPostAt: lastPostAt},
+			}
+
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.Backfill = tt.backfill
+			_ = mc.connector.Config.PostProcess()
+			mock := testMock(mc)
+
+			mc.syncChannels(context.Background())
+
+			events := mock.Events()
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			resync := events[0].(*simplevent.ChatResync)
+			if (resync.CheckNeedsBackfillFunc != nil) != tt.wantCheck {
+				t.Fatalf("CheckNeedsBackfillFunc set: got %v, want %v", resync.CheckNeedsBackfillFunc != nil, tt.wantCheck)
+			}
+			if !tt.wantCheck {
+				return
+			}
+			if got, _ := resync.CheckNeedsBackfillFunc(context.Background(), nil); got != tt.wantCreate {
+				t.Errorf("new portal: got %v, want %v", got, tt.wantCreate)
+			}
+			if got, _ := resync.CheckNeedsBackfillFunc(context.Background(), stale); got != tt.wantCatchUp {
+				t.Errorf("stale portal: got %v, want %v", got, tt.wantCatchUp)
+			}
+		})
+	}
+}
@@ -0,0 +1,490 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"go.mau.fi/util/dbutil"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+)
+
+// maxCoalescedPosts is the most posts merged into one Matrix message; a
+// burst reaching it is bridged without waiting for the end of its window.
+const maxCoalescedPosts = 20
+
+const (
+	coalescedPostCreateTable = `
+		CREATE TABLE IF NOT EXISTS mattermost_coalesced_post (
+			bridge_id  TEXT NOT NULL,
+			post_id    TEXT NOT NULL,
+			message_id TEXT NOT NULL,
+			PRIMARY KEY (bridge_id, post_id)
+		)
+	`
+	coalescedPostUpsert = `
+		INSERT INTO mattermost_coalesced_post (bridge_id, post_id, message_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (bridge_id, post_id) DO UPDATE SET message_id=excluded.message_id
+	`
+	coalescedPostGet = `
+		SELECT message_id FROM mattermost_coalesced_post WHERE bridge_id=$1 AND post_id=$2
+	`
+	coalescedPostDelete = `
+		DELETE FROM mattermost_coalesced_post WHERE bridge_id=$1 AND post_id=$2
+	`
+)
+
+// CoalesceConfig selects the channels where bursts of posts from a bot or
+// an incoming webhook are bridged as one Matrix message, and how long a
+// burst may last. Windows are in seconds; 0 bridges each post on its own.
+type CoalesceConfig struct {
+	// WindowSeconds applies to all channels.
+	WindowSeconds int `yaml:"window_seconds"`
+	// Teams overrides WindowSeconds for the channels of a team, keyed by
+	// team ID.
+	Teams map[string]int `yaml:"teams"`
+	// Channels overrides WindowSeconds and Teams for a channel, keyed by
+	// channel ID.
+	Channels map[string]int `yaml:"channels"`
+}
+
+// validate checks that no window is negative.
+func (c *CoalesceConfig) validate() error {
+	if c.WindowSeconds < 0 {
+		return fmt.Errorf("coalesce_bot_posts.window_seconds must not be negative, got %d", c.WindowSeconds)
+	}
+	for teamID, seconds := range c.Teams {
+		if seconds < 0 {
+			return fmt.Errorf("coalesce_bot_posts.teams.%s must not be negative, got %d", teamID, seconds)
+		}
+	}
+	for channelID, seconds := range c.Channels {
+		if seconds < 0 {
+			return fmt.Errorf("coalesce_bot_posts.channels.%s must not be negative, got %d", channelID, seconds)
+		}
+	}
+	return nil
+}
+
+// windowFor returns how long a burst of bot posts in a channel of the given
+// team is collected, 0 when posts aren't coalesced. teamID is empty for DMs
+// and group DMs.
+func (c *CoalesceConfig) windowFor(channelID, teamID string) time.Duration {
+	seconds := c.WindowSeconds
+	if s, ok := c.Channels[channelID]; ok {
+		seconds = s
+	} else if s, ok := c.Teams[teamID]; ok && teamID != "" {
+		seconds = s
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// postBurst is a run of posts from one bot in a channel, waiting to be
+// bridged as one message.
+type postBurst struct {
+	posts        []*model.Post
+	createPortal bool
+	timer        *time.Timer
+}
+
+// isCoalescablePost reports whether a post may be merged with others: a
+// plain text post from a bot or an incoming webhook, without files or a
+// priority, which need parts and labels of their own.
+func isCoalescablePost(post *model.Post) bool {
+	if post.Type != model.PostTypeDefault || len(post.FileIds) > 0 || !isBotOrWebhookPost(post) {
+		return false
+	}
+	if _, ok := postPriority(post); ok {
+		return false
+	}
+	return post.Message != "" || len(post.Attachments()) > 0
+}
+
+// isBotOrWebhookPost reports whether a post was made by a bot or through an
+// incoming webhook, the posts coalesce_bot_posts merges.
+func isBotOrWebhookPost(post *model.Post) bool {
+	return post.GetProp(model.PostPropsFromBot) == "true" || post.GetProp(model.PostPropsFromWebhook) == "true"
+}
+
+// sameBurst reports whether post continues the burst started by first: the
+// same account, under the same webhook username, in the same thread.
+func sameBurst(first, post *model.Post) bool {
+	return first.UserId == post.UserId &&
+		first.RootId == post.RootId &&
+		first.GetProp(model.PostPropsOverrideUsername) == post.GetProp(model.PostPropsOverrideUsername)
+}
+
+// coalescePost holds a live post for coalesce_bot_posts and reports whether
+// it did. A post that doesn't continue its channel's burst bridges the
+// burst first, so posts keep their order in the room.
+func (m *MattermostClient) coalescePost(ctx context.Context, post *model.Post, teamID string, createPortal bool) bool {
+	window := m.connector.Config.CoalesceBotPosts.windowFor(post.ChannelId, teamID)
+	coalescable := window > 0 && isCoalescablePost(post)
+
+	m.burstsMu.Lock()
+	defer m.burstsMu.Unlock()
+	burst := m.bursts[post.ChannelId]
+	if burst != nil && (!coalescable || !sameBurst(burst.posts[0], post)) {
+		m.flushBurstLocked(ctx, post.ChannelId)
+		burst = nil
+	}
+	if !coalescable {
+		return false
+	}
+	if burst == nil {
+		if m.bursts == nil {
+			m.bursts = make(map[string]*postBurst)
+		}
+		burst = &postBurst{createPortal: createPortal}
+		channelID := post.ChannelId
+		burst.timer = time.AfterFunc(window, func() {
+			m.burstsMu.Lock()
+			defer m.burstsMu.Unlock()
+			if m.bursts[channelID] == burst {
+				m.flushBurstLocked(ctx, channelID)
+			}
+		})
+		m.bursts[post.ChannelId] = burst
+	}
+	burst.posts = append(burst.posts, post)
+	if len(burst.posts) >= maxCoalescedPosts {
+		m.flushBurstLocked(ctx, post.ChannelId)
+	}
+	return true
+}
+
+// flushBurstLocked queues a channel's burst as one message. burstsMu must
+// be held.
+func (m *MattermostClient) flushBurstLocked(ctx context.Context, channelID string) {
+	burst := m.bursts[channelID]
+	if burst == nil {
+		return
+	}
+	delete(m.bursts, channelID)
+	burst.timer.Stop()
+	if len(burst.posts) == 0 {
+		return
+	}
+	if len(burst.posts) > 1 {
+		m.log.Debug().
+			Str("channel_id", channelID).
+			Str("post_id", burst.posts[0].Id).
+			Int("post_count", len(burst.posts)).
+			Msg("Bridging burst of bot posts as one message")
+	}
+	m.queuePost(ctx, burst.posts[0], burst.createPortal, burst.posts[1:]...)
+}
+
+// flushBurst queues a channel's held burst, if any, before a post of the
+// channel is queued without going through coalescePost.
+func (m *MattermostClient) flushBurst(ctx context.Context, channelID string) {
+	m.burstsMu.Lock()
+	defer m.burstsMu.Unlock()
+	m.flushBurstLocked(ctx, channelID)
+}
+
+// flushBursts queues every held burst, when the login disconnects.
+func (m *MattermostClient) flushBursts() {
+	ctx := m.log.WithContext(context.Background())
+	m.burstsMu.Lock()
+	defer m.burstsMu.Unlock()
+	for channelID := range m.bursts {
+		m.flushBurstLocked(ctx, channelID)
+	}
+}
+
+// updateBurstPost applies the edit or deletion of a post still held in a
+// burst, and reports whether the post was there.
+func (m *MattermostClient) updateBurstPost(post *model.Post, deleted bool) bool {
+	m.burstsMu.Lock()
+	defer m.burstsMu.Unlock()
+	burst := m.bursts[post.ChannelId]
+	if burst == nil {
+		return false
+	}
+	for i, held := range burst.posts {
+		if held.Id != post.Id {
+			continue
+		}
+		if !deleted {
+			burst.posts[i] = post
+			return true
+		}
+		burst.posts = append(burst.posts[:i], burst.posts[i+1:]...)
+		if len(burst.posts) == 0 {
+			burst.timer.Stop()
+			delete(m.bursts, post.ChannelId)
+		}
+		return true
+	}
+	return false
+}
+
+// mergeCoalescedPosts appends the text of the posts merged into a message
+// after the first one to its text part, as paragraphs, and records their
+// IDs on it.
+func (m *MattermostClient) mergeCoalescedPosts(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, msg *bridgev2.ConvertedMessage, firstID string, posts []*model.Post) {
+	var text *bridgev2.ConvertedMessagePart
+	if len(msg.Parts) > 0 {
+		text = msg.Parts[0]
+	}
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id)
+		converted := m.convertPostToMatrix(ctx, portal, intent, post)
+		if len(converted.Parts) == 0 {
+			continue
+		}
+		if text == nil {
+			text = converted.Parts[0]
+			msg.Parts = append(msg.Parts, text)
+			continue
+		}
+		appendParagraph(text.Content, converted.Parts[0].Content)
+	}
+	if text == nil {
+		return
+	}
+	addPostIDToParts(msg.Parts, firstID)
+	meta, ok := text.DBMetadata.(*MessageMetadata)
+	if !ok || meta == nil {
+		meta = &MessageMetadata{}
+		text.DBMetadata = meta
+	}
+	meta.CoalescedPosts = ids
+	m.connector.recordCoalescedPosts(ctx, firstID, ids)
+}
+
+// coalescedPostDB returns the database recording the message each merged
+// post is part of, creating its table if needed, or nil without a bridge
+// database.
+func (mc *MattermostConnector) coalescedPostDB(ctx context.Context) (*dbutil.Database, error) {
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return nil, nil
+	}
+	db := mc.Bridge.DB.Database
+	if !mc.coalescedPostTable.Load() {
+		if _, err := db.Exec(ctx, coalescedPostCreateTable); err != nil {
+			return nil, fmt.Errorf("failed to create coalesced post table: %w", err)
+		}
+		mc.coalescedPostTable.Store(true)
+	}
+	return db, nil
+}
+
+// recordCoalescedPosts records that the posts ids were merged into the
+// message of the post firstID, so their edits and deletions find it. The
+// first post is a message of its own again, if it was merged before.
+func (mc *MattermostConnector) recordCoalescedPosts(ctx context.Context, firstID string, ids []string) {
+	db, err := mc.coalescedPostDB(ctx)
+	if db == nil {
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("Failed to record coalesced posts")
+		}
+		return
+	}
+	err = db.DoTxn(ctx, nil, func(ctx context.Context) error {
+		if _, err := db.Exec(ctx, coalescedPostDelete, string(mc.Bridge.ID), firstID); err != nil {
+			return err
+		}
+		for _, postID := range ids {
+			if _, err := db.Exec(ctx, coalescedPostUpsert, string(mc.Bridge.ID), postID, firstID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("post_id", firstID).Msg("Failed to record coalesced posts")
+	}
+}
+
+// forgetCoalescedPost removes the record of the message a post was merged
+// into.
+func (mc *MattermostConnector) forgetCoalescedPost(ctx context.Context, postID string) {
+	db, err := mc.coalescedPostDB(ctx)
+	if db != nil {
+		_, err = db.Exec(ctx, coalescedPostDelete, string(mc.Bridge.ID), postID)
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("post_id", postID).Msg("Failed to forget coalesced post")
+	}
+}
+
+// coalescedMessageID returns the ID of the post whose message a post was
+// merged into by coalesce_bot_posts, or the post's own ID when it wasn't.
+func (mc *MattermostConnector) coalescedMessageID(ctx context.Context, post *model.Post) string {
+	if !isBotOrWebhookPost(post) {
+		return post.Id
+	}
+	db, err := mc.coalescedPostDB(ctx)
+	if db == nil {
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("post_id", post.Id).Msg("Failed to find coalesced message of post")
+		}
+		return post.Id
+	}
+	var messageID string
+	err = db.QueryRow(ctx, coalescedPostGet, string(mc.Bridge.ID), post.Id).Scan(&messageID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("post_id", post.Id).Msg("Failed to find coalesced message of post")
+		}
+		return post.Id
+	}
+	return messageID
+}
+
+// getCoalescedPosts fetches the posts of a coalesced message that still
+// exist, in order. updated replaces its fetched version when set, as the
+// post of an edit may be newer.
+func (m *MattermostClient) getCoalescedPosts(ctx context.Context, ids []string, updated *model.Post) ([]*model.Post, error) {
+	fetched, resp, err := m.client.GetPostsByIds(ctx, ids)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return nil, apiError("failed to get coalesced posts", resp, err)
+	}
+	byID := make(map[string]*model.Post, len(fetched))
+	for _, post := range fetched {
+		byID[post.Id] = post
+	}
+	if updated != nil {
+		byID[updated.Id] = updated
+	}
+	posts := make([]*model.Post, 0, len(ids))
+	for _, postID := range ids {
+		if post := byID[postID]; post != nil && post.DeleteAt == 0 {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+// convertCoalescedEdit converts the edit of a post merged with others into
+// one message, by rendering the message again with the current text of its
+// posts.
+func (m *MattermostClient) convertCoalescedEdit(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, existing *database.Message, edited *model.Post) (*bridgev2.ConvertedEdit, error) {
+	meta := messageMetadata(existing)
+	firstID := ParseMessageID(existing.ID)
+	posts, err := m.getCoalescedPosts(ctx, append([]string{firstID}, meta.CoalescedPosts...), edited)
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 || posts[0].Id != firstID {
+		// The deletion of the first post bridges the message again.
+		return nil, bridgev2.ErrIgnoringRemoteEvent
+	}
+	msg := m.convertPostToMatrix(ctx, portal, intent, posts[0])
+	m.mergeCoalescedPosts(ctx, portal, intent, msg, firstID, posts[1:])
+	if len(msg.Parts) == 0 {
+		return nil, bridgev2.ErrIgnoringRemoteEvent
+	}
+	part := msg.Parts[0]
+	if merged, ok := part.DBMetadata.(*MessageMetadata); ok && merged != nil {
+		meta.CoalescedPosts = merged.CoalescedPosts
+	}
+	existing.Metadata = meta
+	return &bridgev2.ConvertedEdit{
+		ModifiedParts: []*bridgev2.ConvertedEditPart{{
+			Part:    existing,
+			Type:    part.Type,
+			Content: part.Content,
+			Extra:   part.Extra,
+		}},
+	}, nil
+}
+
+// removeCoalescedPost handles the deletion of a post merged with others
+// into the message of messageID, and reports whether it was. The message is
+// redacted rather than edited, so the deleted text doesn't stay in its edit
+// history, and the remaining posts are bridged again as a new message.
+func (m *MattermostClient) removeCoalescedPost(ctx context.Context, post *model.Post, messageID string, portalKey networkid.PortalKey) bool {
+	br := m.connector.Bridge
+	if br == nil || br.DB == nil {
+		return false
+	}
+	msg, err := br.DB.Message.GetFirstPartByID(ctx, "", MakeMessageID(messageID))
+	if err != nil {
+		m.log.Warn().Err(err).Str("post_id", post.Id).Msg("Failed to get message of deleted post")
+		return false
+	}
+	if msg == nil || len(messageMetadata(msg).CoalescedPosts) == 0 {
+		return false
+	}
+	ids := slices.DeleteFunc(append([]string{messageID}, messageMetadata(msg).CoalescedPosts...), func(postID string) bool {
+		return postID == post.Id
+	})
+	remaining, err := m.getCoalescedPosts(ctx, ids, nil)
+	if err != nil {
+		// The deleted text must go regardless.
+		m.log.Warn().Err(err).Str("post_id", post.Id).Msg("Failed to get the posts merged with a deleted post")
+	}
+	m.connector.forgetCoalescedPost(ctx, post.Id)
+
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.MessageRemove{
+		EventMeta: simplevent.EventMeta{
+			Type: bridgev2.RemoteEventMessageRemove,
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("post_id", post.Id).Str("message_id", messageID).Str("channel_id", post.ChannelId)
+			},
+			PortalKey: portalKey,
+			Sender:    m.senderFor(post.UserId),
+			Timestamp: time.UnixMilli(post.DeleteAt),
+		},
+		TargetMessage: MakeMessageID(messageID),
+	})
+	if len(remaining) > 0 {
+		m.log.Debug().
+			Str("post_id", post.Id).
+			Str("message_id", messageID).
+			Int("post_count", len(remaining)).
+			Msg("Bridging the rest of a coalesced message again after a deletion")
+		m.connector.forgetCoalescedPost(ctx, remaining[0].Id)
+		m.queuePost(ctx, remaining[0], false, remaining[1:]...)
+	}
+	return true
+}
+
+// appendParagraph appends the text and mentions of src to dst, separated by
+// a blank line.
+func appendParagraph(dst, src *event.MessageEventContent) {
+	if dst.Format != event.FormatHTML && src.Format == event.FormatHTML {
+		dst.Format = event.FormatHTML
+		dst.FormattedBody = strings.ReplaceAll(html.EscapeString(dst.Body), "\n", "<br/>")
+	}
+	if dst.Format == event.FormatHTML {
+		rich := src.FormattedBody
+		if src.Format != event.FormatHTML {
+			rich = strings.ReplaceAll(html.EscapeString(src.Body), "\n", "<br/>")
+		}
+		dst.FormattedBody += "<br/><br/>" + rich
+	}
+	dst.Body += "\n\n" + src.Body
+	if src.Mentions == nil {
+		return
+	}
+	if dst.Mentions == nil {
+		dst.Mentions = &event.Mentions{}
+	}
+	for _, userID := range src.Mentions.UserIDs {
+		dst.Mentions.Add(userID)
+	}
+	dst.Mentions.Room = dst.Mentions.Room || src.Mentions.Room
+}
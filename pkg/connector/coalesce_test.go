@@ -0,0 +1,369 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// newCoalesceTestClient returns a client coalescing bot posts in a minute
+// long window, with its queued events recorded.
+func newCoalesceTestClient(t *testing.T) (*MattermostClient, *mockEventSender) {
+	t.Helper()
+	mc := newTestClient()
+	mc.connector.Config.CoalesceBotPosts.WindowSeconds = 60
+	sender := &mockEventSender{}
+	mc.eventSender = sender
+	t.Cleanup(mc.flushBursts)
+	return mc, sender
+}
+
+func botPost(postID, message string) *model.Post {
+	post := &model.Post{Id: postID, ChannelId: "ch1", UserId: "bot1", Message: message}
+	post.AddProp(model.PostPropsFromBot, "true")
+	return post
+}
+
+func TestCoalesceConfigWindowFor(t *testing.T) {
+	t.Parallel()
+	cfg := CoalesceConfig{
+		WindowSeconds: 5,
+		Teams:         map[string]int{"team1": 10},
+		Channels:      map[string]int{"ch1": 0, "ch2": 30},
+	}
+	tests := []struct {
+		channelID, teamID string
+		want              time.Duration
+	}{
+		{"other", "", 5 * time.Second},
+		{"other", "team1", 10 * time.Second},
+		{"ch1", "team1", 0},
+		{"ch2", "team2", 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := cfg.windowFor(tt.channelID, tt.teamID); got != tt.want {
+			t.Errorf("windowFor(%q, %q) = %v, want %v", tt.channelID, tt.teamID, got, tt.want)
+		}
+	}
+	if err := (&CoalesceConfig{Channels: map[string]int{"ch1": -1}}).validate(); err == nil {
+		t.Error("a negative window should be rejected")
+	}
+}
+
+func TestIsCoalescablePost(t *testing.T) {
+	t.Parallel()
+	webhook := &model.Post{Message: "hook"}
+	webhook.AddProp(model.PostPropsFromWebhook, "true")
+	withFile := botPost("p1", "file")
+	withFile.FileIds = []string{"f1"}
+	system := botPost("p1", "joined")
+	system.Type = model.PostTypeJoinChannel
+	urgent := botPost("p1", "alert")
+	urgent.Metadata = &model.PostMetadata{Priority: &model.PostPriority{Priority: model.NewPointer(model.PostPriorityUrgent)}}
+	tests := []struct {
+		name string
+		post *model.Post
+		want bool
+	}{
+		{"bot", botPost("p1", "hello"), true},
+		{"webhook", webhook, true},
+		{"user", &model.Post{Message: "hello"}, false},
+		{"file", withFile, false},
+		{"system", system, false},
+		{"priority", urgent, false},
+		{"empty", botPost("p1", ""), false},
+	}
+	for _, tt := range tests {
+		if got := isCoalescablePost(tt.post); got != tt.want {
+			t.Errorf("%s: isCoalescablePost = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCoalescePost_Burst(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc, sender := newCoalesceTestClient(t)
+
+	for _, post := range []*model.Post{botPost("p1", "one"), botPost("p2", "**two**"), botPost("p3", "three")} {
+		if !mc.coalescePost(ctx, post, "", false) {
+			t.Fatalf("post %s wasn't held", post.Id)
+		}
+	}
+	if events := sender.Events(); len(events) != 0 {
+		t.Fatalf("got %d events before the burst ended, want 0", len(events))
+	}
+	if mc.coalescePost(ctx, &model.Post{Id: "p4", ChannelId: "ch1", UserId: "user1", Message: "hi"}, "", false) {
+		t.Fatal("a user's post shouldn't be held")
+	}
+
+	events := sender.Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want the burst", len(events))
+	}
+	msg := events[0].(*simplevent.Message[*model.Post])
+	if msg.ID != MakeMessageID("p1") {
+		t.Errorf("message ID = %q, want the first post's", msg.ID)
+	}
+	converted, err := msg.ConvertMessageFunc(ctx, makeTestPortal("ch1"), nil, msg.Data)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	if len(converted.Parts) != 1 {
+		t.Fatalf("got %d parts, want 1", len(converted.Parts))
+	}
+	content := converted.Parts[0].Content
+	if content.Body != "one\n\n**two**\n\nthree" {
+		t.Errorf("body = %q", content.Body)
+	}
+	if content.FormattedBody != "one<br/><br/><strong>two</strong><br/><br/>three" {
+		t.Errorf("formatted body = %q", content.FormattedBody)
+	}
+	if converted.Parts[0].Extra[postIDContentKey] != "p1" {
+		t.Errorf("post ID = %v, want p1", converted.Parts[0].Extra[postIDContentKey])
+	}
+	meta := converted.Parts[0].DBMetadata.(*MessageMetadata)
+	if !reflect.DeepEqual(meta.CoalescedPosts, []string{"p2", "p3"}) {
+		t.Errorf("coalesced posts = %v, want p2 and p3", meta.CoalescedPosts)
+	}
+}
+
+func TestCoalescePost_NewBurst(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		next *model.Post
+	}{
+		{"other bot", &model.Post{Id: "p2", ChannelId: "ch1", UserId: "bot2", Message: "x", Props: model.StringInterface{model.PostPropsFromBot: "true"}}},
+		{"thread reply", &model.Post{Id: "p2", ChannelId: "ch1", UserId: "bot1", RootId: "r1", Message: "x", Props: model.StringInterface{model.PostPropsFromBot: "true"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, sender := newCoalesceTestClient(t)
+			mc.coalescePost(ctx, botPost("p1", "one"), "", false)
+			if !mc.coalescePost(ctx, tt.next, "", false) {
+				t.Fatal("the post should start a burst of its own")
+			}
+			events := sender.Events()
+			if len(events) != 1 || events[0].(*simplevent.Message[*model.Post]).ID != MakeMessageID("p1") {
+				t.Fatalf("events = %+v, want the first burst alone", events)
+			}
+		})
+	}
+}
+
+func TestCoalescePost_Disabled(t *testing.T) {
+	t.Parallel()
+	mc, sender := newCoalesceTestClient(t)
+	mc.connector.Config.CoalesceBotPosts.Channels = map[string]int{"ch1": 0}
+	if mc.coalescePost(context.Background(), botPost("p1", "one"), "", false) {
+		t.Error("post held in a channel without coalescing")
+	}
+	if len(sender.Events()) != 0 {
+		t.Error("coalescePost shouldn't queue unheld posts")
+	}
+}
+
+func TestCoalescePost_MaxPosts(t *testing.T) {
+	t.Parallel()
+	mc, sender := newCoalesceTestClient(t)
+	for i := range maxCoalescedPosts {
+		mc.coalescePost(context.Background(), botPost(string(rune('a'+i)), "x"), "", false)
+	}
+	if len(sender.Events()) != 1 {
+		t.Errorf("got %d events, want the full burst bridged", len(sender.Events()))
+	}
+}
+
+func TestUpdateBurstPost(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc, sender := newCoalesceTestClient(t)
+	mc.coalescePost(ctx, botPost("p1", "one"), "", false)
+	mc.coalescePost(ctx, botPost("p2", "two"), "", false)
+
+	if !mc.updateBurstPost(botPost("p2", "two, edited"), false) {
+		t.Error("edit of a held post wasn't applied")
+	}
+	if !mc.updateBurstPost(botPost("p1", ""), true) {
+		t.Error("deletion of a held post wasn't applied")
+	}
+	if mc.updateBurstPost(botPost("p9", ""), true) {
+		t.Error("unknown post reported as held")
+	}
+	mc.flushBursts()
+
+	events := sender.Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	msg := events[0].(*simplevent.Message[*model.Post])
+	if msg.ID != MakeMessageID("p2") || msg.Data.Message != "two, edited" {
+		t.Errorf("got %s %q, want the edited second post", msg.ID, msg.Data.Message)
+	}
+}
+
+func TestAppendParagraph(t *testing.T) {
+	t.Parallel()
+	dst := &event.MessageEventContent{Body: "a < b", Mentions: &event.Mentions{UserIDs: []id.UserID{"@u1:example.com"}}}
+	appendParagraph(dst, &event.MessageEventContent{
+		Body: "c", Format: event.FormatHTML, FormattedBody: "<em>c</em>",
+		Mentions: &event.Mentions{UserIDs: []id.UserID{"@u1:example.com", "@u2:example.com"}, Room: true},
+	})
+	if dst.Body != "a < b\n\nc" || dst.FormattedBody != "a &lt; b<br/><br/><em>c</em>" || dst.Format != event.FormatHTML {
+		t.Errorf("got %q / %q", dst.Body, dst.FormattedBody)
+	}
+	if len(dst.Mentions.UserIDs) != 2 || !dst.Mentions.Room {
+		t.Errorf("mentions = %+v, want both users and the room", dst.Mentions)
+	}
+}
+
+// newCoalescedMessageTest returns a client whose bridge database holds a
+// message of relayTestChannel with the posts p1, p2 and p3 merged into it,
+// and whose fake server has the posts.
+func newCoalescedMessageTest(t *testing.T) (*MattermostClient, *mockEventSender, *bridgev2.Portal) {
+	t.Helper()
+	ctx := context.Background()
+	conn := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	posts := make([]*model.Post, 0, 3)
+	for i, message := range []string{"one", "two", "three"} {
+		post := botPost(fmt.Sprintf("p%d", i+1), message)
+		post.ChannelId = relayTestChannel
+		posts = append(posts, post)
+	}
+	fake.Posts[relayTestChannel] = makePostList(posts)
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector = conn
+	sender := &mockEventSender{}
+	mc.eventSender = sender
+
+	portal := getRelayTestPortal(t, conn, relayTestChannel)
+	if err := conn.Bridge.DB.Message.Insert(ctx, &database.Message{
+		ID:       MakeMessageID("p1"),
+		MXID:     "$merged:example.com",
+		Room:     portal.PortalKey,
+		SenderID: MakeUserID("bot1"),
+		Metadata: &MessageMetadata{CoalescedPosts: []string{"p2", "p3"}},
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	conn.recordCoalescedPosts(ctx, "p1", []string{"p2", "p3"})
+	return mc, sender, portal
+}
+
+func TestQueuePostEdit_CoalescedMessage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		edited *model.Post
+		want   string
+	}{
+		{"first post", botPost("p1", "one, edited"), "one, edited\n\ntwo\n\nthree"},
+		{"merged post", botPost("p3", "three, edited"), "one\n\ntwo\n\nthree, edited"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, sender, portal := newCoalescedMessageTest(t)
+			edited := tt.edited
+			edited.ChannelId = relayTestChannel
+			mc.queuePostEdit(edited)
+
+			edit := sender.Events()[0].(*simplevent.Message[*model.Post])
+			if edit.TargetMessage != MakeMessageID("p1") {
+				t.Fatalf("edit targets %s, want the merged message", edit.TargetMessage)
+			}
+			existing, err := mc.connector.Bridge.DB.Message.GetAllPartsByID(ctx, "", MakeMessageID("p1"))
+			if err != nil {
+				t.Fatalf("get message: %v", err)
+			}
+			converted, err := edit.ConvertEditFunc(ctx, portal, nil, existing, edit.Data)
+			if err != nil {
+				t.Fatalf("convert edit: %v", err)
+			}
+			if len(converted.ModifiedParts) != 1 {
+				t.Fatalf("got %d modified parts, want 1", len(converted.ModifiedParts))
+			}
+			if body := converted.ModifiedParts[0].Content.Body; body != tt.want {
+				t.Errorf("body = %q, want %q", body, tt.want)
+			}
+			if got := messageMetadata(existing[0]).CoalescedPosts; !reflect.DeepEqual(got, []string{"p2", "p3"}) {
+				t.Errorf("coalesced posts = %v", got)
+			}
+		})
+	}
+}
+
+func TestHandlePostDeleted_CoalescedMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		postID      string
+		deletedText string
+		wantFirst   string
+	}{
+		{"first post", "p1", "one", "p2"},
+		{"merged post", "p2", "two", "p1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			mc, sender, portal := newCoalescedMessageTest(t)
+			post := botPost(tt.postID, "")
+			post.ChannelId = relayTestChannel
+			post.DeleteAt = 1
+			postJSON, _ := json.Marshal(post)
+			mc.handlePostDeleted(newWebSocketEvent(model.WebsocketEventPostDeleted, relayTestChannel, map[string]any{
+				"post": string(postJSON),
+			}))
+
+			events := sender.Events()
+			if len(events) != 2 {
+				t.Fatalf("got %d events, want a removal and a new message", len(events))
+			}
+			remove, ok := events[0].(*simplevent.MessageRemove)
+			if !ok || remove.TargetMessage != MakeMessageID("p1") {
+				t.Fatalf("first event = %+v, want the merged message removed", events[0])
+			}
+			msg := events[1].(*simplevent.Message[*model.Post])
+			if msg.ID != MakeMessageID(tt.wantFirst) {
+				t.Errorf("new message ID = %s, want %s", msg.ID, tt.wantFirst)
+			}
+			converted, err := msg.ConvertMessageFunc(ctx, portal, nil, msg.Data)
+			if err != nil {
+				t.Fatalf("convert: %v", err)
+			}
+			if got := converted.Parts[0].DBMetadata.(*MessageMetadata).CoalescedPosts; !reflect.DeepEqual(got, []string{"p3"}) {
+				t.Errorf("coalesced posts = %v, want p3", got)
+			}
+			if body := converted.Parts[0].Content.Body; strings.Contains(body, tt.deletedText) || !strings.Contains(body, "three") {
+				t.Errorf("body = %q, want the remaining posts", body)
+			}
+			if got := mc.connector.coalescedMessageID(ctx, botPost(tt.wantFirst, "")); got != tt.wantFirst {
+				t.Errorf("new first post still maps to %s", got)
+			}
+		})
+	}
+}
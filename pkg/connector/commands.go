@@ -0,0 +1,229 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/commands"
+	"maunium.net/go/mautrix/event"
+)
+
+// HelpSectionPortalSettings groups the per-portal settings commands.
+var HelpSectionPortalSettings = commands.HelpSection{Name: "Portal settings", Order: 25}
+
+// registerCommands adds the connector's bot commands to the bridge's
+// command processor.
+func (mc *MattermostConnector) registerCommands() {
+	if mc.Bridge == nil {
+		return
+	}
+	proc, ok := mc.Bridge.Commands.(*commands.Processor)
+	if !ok {
+		return
+	}
+	proc.AddHandlers(mc.commandHandlers()...)
+}
+
+// commandHandlers returns the connector's bot commands.
+func (mc *MattermostConnector) commandHandlers() []commands.CommandHandler {
+	return []commands.CommandHandler{
+		&commands.FullHandler{
+			Func: mc.fnTimezone,
+			Name: "timezone",
+			Help: commands.HelpMeta{
+				Section:     HelpSectionPortalSettings,
+				Description: "View or set the timezone used for timestamps in this room",
+				Args:        "[_IANA timezone_ | reset]",
+			},
+			RequiresPortal:     true,
+			RequiresEventLevel: event.StatePowerLevels,
+		},
+		&commands.FullHandler{
+			Func: mc.fnLocale,
+			Name: "locale",
+			Help: commands.HelpMeta{
+				Section:     HelpSectionPortalSettings,
+				Description: "View or set the locale used to format timestamps in this room",
+				Args:        "[_locale_ | reset]",
+			},
+			RequiresPortal:     true,
+			RequiresEventLevel: event.StatePowerLevels,
+		},
+		&commands.FullHandler{
+			Func: mc.fnAction,
+			Name: "action",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionChats,
+				Description: "Use a button or menu of the Mattermost message you reply to",
+				Args:        "<_number_> [_choice_]",
+			},
+			RequiresPortal: true,
+		},
+		&commands.FullHandler{
+			Func: mc.fnEchoDrops,
+			Name: "echo-drops",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionAdmin,
+				Description: "List the most recent Mattermost events dropped by echo prevention",
+				Args:        "[_count_]",
+			},
+			RequiresAdmin: true,
+		},
+		&commands.FullHandler{
+			Func: mc.fnListChannels,
+			Name: "list-channels",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionChats,
+				Description: "List your Mattermost channels and whether they're bridged",
+				Args:        "[_filter_]",
+			},
+			RequiresLogin: true,
+		},
+		&commands.FullHandler{
+			Func: mc.fnBridgeChannel,
+			Name: "bridge",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionAdmin,
+				Description: "Create the Matrix room of a Mattermost channel, with its relay and invites",
+				Args:        "<_channel ID or name_>",
+			},
+			RequiresAdmin: true,
+			RequiresLogin: true,
+		},
+		&commands.FullHandler{
+			Func: mc.fnUnbridgeChannel,
+			Name: "unbridge",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionAdmin,
+				Description: "Delete the portal room of this room's or the given Mattermost channel",
+				Args:        "[_channel ID or name_]",
+			},
+			RequiresAdmin: true,
+		},
+		&commands.FullHandler{
+			Func: mc.fnReloadPuppets,
+			Name: "reload-puppets",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionAdmin,
+				Description: "Reload the puppets from the MATTERMOST_PUPPET_* environment variables",
+			},
+			RequiresAdmin: true,
+		},
+		&commands.FullHandler{
+			Func: mc.fnBackfill,
+			Name: "backfill",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionAdmin,
+				Description: "Bridge the Mattermost posts this room missed since its latest bridged message",
+				Args:        "[_count_]",
+			},
+			RequiresAdmin:  true,
+			RequiresPortal: true,
+			RequiresLogin:  true,
+		},
+		&commands.FullHandler{
+			Func: mc.fnConfirmLink,
+			Name: "confirm-link",
+			Help: commands.HelpMeta{
+				Section:     commands.HelpSectionAuth,
+				Description: "Approve linking your Matrix account to a Mattermost account for double puppeting",
+				Args:        "<_code_>",
+			},
+		},
+	}
+}
+
+// portalMetadata returns the portal's metadata, initializing it if unset.
+func portalMetadata(portal *bridgev2.Portal) *PortalMetadata {
+	meta, ok := portal.Metadata.(*PortalMetadata)
+	if !ok || meta == nil {
+		meta = &PortalMetadata{}
+		portal.Metadata = meta
+	}
+	return meta
+}
+
+func (mc *MattermostConnector) fnTimezone(ce *commands.Event) {
+	meta := portalMetadata(ce.Portal)
+	if len(ce.Args) == 0 {
+		opts := mc.Config.formatOptionsFor(ce.Portal)
+		source := "bridge default"
+		if meta.Timezone != "" {
+			source = "room setting"
+		}
+		ce.Reply("Timestamps in this room use `%s` (%s). Current time: %s",
+			opts.Location.String(), source, opts.FormatTime(time.Now()))
+		return
+	}
+
+	arg := ce.Args[0]
+	if strings.EqualFold(arg, "reset") {
+		meta.Timezone = ""
+	} else {
+		loc, err := time.LoadLocation(arg)
+		if err != nil || arg == "" || strings.EqualFold(arg, "local") {
+			ce.Reply("Unknown timezone `%s`. Use an IANA name such as `Europe/Paris` or `America/New_York`.", arg)
+			return
+		}
+		meta.Timezone = loc.String()
+	}
+	if err := ce.Portal.Save(ce.Ctx); err != nil {
+		ce.Log.Err(err).Msg("Failed to save portal timezone")
+		ce.Reply("Failed to save the timezone setting.")
+		return
+	}
+	ce.Log.Info().
+		Str("portal_id", string(ce.Portal.ID)).
+		Str("timezone", meta.Timezone).
+		Msg("Portal timezone updated")
+	if meta.Timezone == "" {
+		ce.Reply("Timezone reset to the bridge default.")
+	} else {
+		ce.Reply("Timezone set to `%s`.", meta.Timezone)
+	}
+}
+
+func (mc *MattermostConnector) fnLocale(ce *commands.Event) {
+	meta := portalMetadata(ce.Portal)
+	if len(ce.Args) == 0 {
+		opts := mc.Config.formatOptionsFor(ce.Portal)
+		locale := meta.Locale
+		if locale == "" {
+			locale = "bridge default"
+		}
+		ce.Reply("Timestamps in this room use locale %s, e.g. %s", locale, opts.FormatTime(time.Now()))
+		return
+	}
+
+	arg := ce.Args[0]
+	if strings.EqualFold(arg, "reset") {
+		meta.Locale = ""
+	} else {
+		if _, ok := mattermostfmt.LocaleTimeFormat(arg); !ok {
+			ce.Reply("Unsupported locale `%s`.", arg)
+			return
+		}
+		meta.Locale = arg
+	}
+	if err := ce.Portal.Save(ce.Ctx); err != nil {
+		ce.Log.Err(err).Msg("Failed to save portal locale")
+		ce.Reply("Failed to save the locale setting.")
+		return
+	}
+	ce.Log.Info().
+		Str("portal_id", string(ce.Portal.ID)).
+		Str("locale", meta.Locale).
+		Msg("Portal locale updated")
+	if meta.Locale == "" {
+		ce.Reply("Locale reset to the bridge default.")
+	} else {
+		ce.Reply("Locale set to `%s`.", meta.Locale)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/commands"
+)
+
+func TestCommandHandlers_Names(t *testing.T) {
+	t.Parallel()
+	mc := &MattermostConnector{}
+	// Settings commands need room admin rights; action is for everyone.
+	// echo-drops and the management commands are bridge admin commands that
+	// work outside portals, except backfill, and confirm-link and
+	// list-channels are sent from the management room by anyone.
+	adminOnly := map[string]bool{"timezone": true, "locale": true}
+	bridgeAdmin := map[string]bool{"echo-drops": true, "bridge": true, "unbridge": true, "reload-puppets": true, "backfill": true}
+	noPortal := map[string]bool{"echo-drops": true, "confirm-link": true, "list-channels": true, "bridge": true, "unbridge": true, "reload-puppets": true}
+	want := map[string]bool{"timezone": false, "locale": false, "action": false, "echo-drops": false, "confirm-link": false,
+		"list-channels": false, "bridge": false, "unbridge": false, "reload-puppets": false, "backfill": false}
+	for _, h := range mc.commandHandlers() {
+		fh, ok := h.(*commands.FullHandler)
+		if !ok {
+			t.Fatalf("handler %q is not a FullHandler", h.GetName())
+		}
+		if fh.RequiresPortal == noPortal[fh.Name] {
+			t.Errorf("%s requires a portal: got %v, want %v", fh.Name, fh.RequiresPortal, !noPortal[fh.Name])
+		}
+		if fh.RequiresAdmin != bridgeAdmin[fh.Name] {
+			t.Errorf("%s requires bridge admin: got %v, want %v", fh.Name, fh.RequiresAdmin, bridgeAdmin[fh.Name])
+		}
+		if admin := fh.RequiresEventLevel.Type != ""; admin != adminOnly[fh.Name] {
+			t.Errorf("%s requires room admin rights: got %v, want %v", fh.Name, admin, adminOnly[fh.Name])
+		}
+		want[fh.Name] = true
+	}
+	for name, seen := range want {
+		if !seen {
+			t.Errorf("missing command %q", name)
+		}
+	}
+}
+
+func TestRegisterCommands_NoProcessor(t *testing.T) {
+	t.Parallel()
+	// Must not panic without a bridge or with a non-default processor.
+	(&MattermostConnector{}).registerCommands()
+	(&MattermostConnector{Bridge: &bridgev2.Bridge{}}).registerCommands()
+}
+
+func TestPortalMetadata_InitializesNil(t *testing.T) {
+	t.Parallel()
+	portal := makeTestPortal("ch1")
+	meta := portalMetadata(portal)
+	if meta == nil {
+		t.Fatal("portalMetadata returned nil")
+	}
+	meta.Timezone = "Europe/Paris"
+	if got := portal.Metadata.(*PortalMetadata).Timezone; got != "Europe/Paris" {
+		t.Errorf("metadata not stored on portal: got %q", got)
+	}
+}
+
+func TestFormatOptionsFor(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{Timezone: "UTC", TimeFormat: "2006-01-02 15:04 MST"}
+	if err := cfg.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		meta       any
+		wantLoc    string
+		wantFormat string
+	}{
+		{"nil metadata", nil, "UTC", "2006-01-02 15:04 MST"},
+		{"empty metadata", &PortalMetadata{}, "UTC", "2006-01-02 15:04 MST"},
+		{"timezone override", &PortalMetadata{Timezone: "Asia/Tokyo"}, "Asia/Tokyo", "2006-01-02 15:04 MST"},
+		{"invalid timezone ignored", &PortalMetadata{Timezone: "Nope/Nowhere"}, "UTC", "2006-01-02 15:04 MST"},
+		{"locale override", &PortalMetadata{Locale: "de"}, "UTC", "02.01.2006 15:04 MST"},
+		{"unknown locale ignored", &PortalMetadata{Locale: "xx"}, "UTC", "2006-01-02 15:04 MST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			portal := makeTestPortal("ch1")
+			portal.Metadata = tt.meta
+			opts := cfg.formatOptionsFor(portal)
+			if got := opts.Location.String(); got != tt.wantLoc {
+				t.Errorf("location: got %q, want %q", got, tt.wantLoc)
+			}
+			if opts.TimeFormat != tt.wantFormat {
+				t.Errorf("time format: got %q, want %q", opts.TimeFormat, tt.wantFormat)
+			}
+		})
+	}
+
+	if opts := cfg.formatOptionsFor(nil); opts.Location.String() != "UTC" {
+		t.Errorf("nil portal should use config defaults, got %q", opts.Location)
+	}
+}
+
+func TestConvertPostToMatrix_PortalTimezone(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	portal := makeTestPortal("ch1")
+	portal.Metadata = &PortalMetadata{Timezone: "America/New_York", Locale: "en-US"}
+
+	msg := client.convertPostToMatrix(context.Background(), portal, nil, &model.Post{Id: "p1", Message: "at <t:1700000000>"})
+	if got := msg.Parts[0].Content.Body; got != "at Nov 14, 2023 5:13 PM EST" {
+		t.Errorf("body: got %q", got)
+	}
+}
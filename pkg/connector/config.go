@@ -7,10 +7,15 @@ package connector
 
 import (
 	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
 	"text/template"
+	"time"
 
 	up "go.mau.fi/util/configupgrade"
 	"gopkg.in/yaml.v3"
+	"maunium.net/go/mautrix/id"
 )
 
 //go:embed example-config.yaml
@@ -20,6 +25,9 @@ var ExampleConfig string
 type Config struct {
 	ServerURL           string `yaml:"server_url"`
 	DisplaynameTemplate string `yaml:"displayname_template"`
+	// TeamDisplaynameTemplates overrides DisplaynameTemplate for the ghosts
+	// of a team's members, keyed by team ID.
+	TeamDisplaynameTemplates map[string]string `yaml:"team_displayname_templates"`
 	// BotPrefix is a username prefix for echo prevention. Any Mattermost
 	// username starting with this prefix is treated as a bridge-managed bot
 	// and its posts are not relayed back to Matrix. Leave empty to disable
@@ -28,12 +36,486 @@ type Config struct {
 	// AdminAPIAddr is the listen address for the admin HTTP API that serves
 	// the /api/reload-puppets endpoint. Defaults to ":29320".
 	AdminAPIAddr string `yaml:"admin_api_addr"`
+	// AdminAPIToken, when set, is required as a bearer token on every admin
+	// API request and enables the /debug/ endpoints (pprof, dumps).
+	AdminAPIToken string `yaml:"admin_api_token"`
+	// AdminAPIFixtures enables the /debug/fixtures/ endpoints that create
+	// ghosts, portals and messages directly, for integration tests. Requires
+	// AdminAPIToken. Never enable in production.
+	AdminAPIFixtures bool `yaml:"admin_api_fixtures"`
 
 	BackfillEnabled  bool `yaml:"backfill_enabled"`
 	BackfillMaxCount int  `yaml:"backfill_max_count"`
 	TypingTimeout    int  `yaml:"typing_timeout"`
 
-	displaynameTemplate *template.Template `yaml:"-"`
+	// RelayTyping makes the relay user appear typing in Mattermost while a
+	// Matrix user without a puppet bot types. Off by default, since the
+	// relay user would seem to type for everyone.
+	RelayTyping bool `yaml:"relay_typing"`
+
+	// Backfill controls when history is fetched for portals and how much.
+	// The legacy backfill_enabled and backfill_max_count keys are used as
+	// fallbacks when these are unset.
+	Backfill BackfillConfig `yaml:"backfill"`
+
+	// MaxMessageAgeSeconds drops posts, edits and reactions older than this
+	// when they arrive live or are recovered after a restart, so delayed or
+	// misdelivered events don't surface in Matrix long after the fact.
+	// Backfill isn't affected. 0 disables the limit.
+	MaxMessageAgeSeconds int `yaml:"max_message_age_seconds"`
+
+	// Timezone is the IANA timezone name used when rendering Mattermost
+	// timestamps and reminders as absolute times. Defaults to UTC.
+	Timezone string `yaml:"timezone"`
+	// TimeFormat is the Go time layout for rendered timestamps. Defaults to
+	// mattermostfmt.DefaultTimeFormat.
+	TimeFormat string `yaml:"time_format"`
+
+	// SpoilerStyle is how Matrix spoilers are sent to Mattermost, which has
+	// no spoilers: "link" (default) hides the text behind a link to the
+	// Matrix event, "pipes" wraps it in ||, for servers with a plugin that
+	// renders that syntax.
+	SpoilerStyle string `yaml:"spoiler_style"`
+
+	// RoomNameTemplate is a Go text/template that builds the Matrix room
+	// name of a team channel. See RoomNameParams for the available fields.
+	// Empty uses the channel's display name. Names already used by another
+	// room get a suffix.
+	RoomNameTemplate string `yaml:"room_name_template"`
+	// RoomAliasTemplate is a Go text/template that builds the localpart of
+	// the alias given to the portal room of a team channel. See
+	// RoomNameParams for the available fields. Empty disables aliases.
+	RoomAliasTemplate string `yaml:"room_alias_template"`
+
+	// TopicTemplate is a Go text/template that builds the Matrix room topic
+	// from the channel's header and purpose. See TopicParams for the
+	// available fields. Empty uses the header, or the purpose if there is no
+	// header.
+	TopicTemplate string `yaml:"topic_template"`
+	// ChannelInfoState mirrors the channel's header and purpose into a
+	// fi.mau.mattermost.channel_info state event, so both are kept even when
+	// the topic only shows one.
+	ChannelInfoState bool `yaml:"channel_info_state"`
+
+	// TeamSpaces groups the portals of each Mattermost team into a Matrix
+	// space.
+	TeamSpaces bool `yaml:"team_spaces"`
+
+	// ResyncIntervalMinutes is how often all channels are resynced, which
+	// refreshes portal names, topics, avatars and members. 0 only syncs on
+	// connect and reconnect.
+	ResyncIntervalMinutes int `yaml:"resync_interval_minutes"`
+
+	// BridgePresence bridges Mattermost user statuses to ghost presence, and
+	// the Matrix presence of double-puppeted users back to Mattermost.
+	BridgePresence bool `yaml:"bridge_presence"`
+
+	// ReadReceipts bridges other Mattermost users' read positions as read
+	// receipts of their ghosts.
+	ReadReceipts ReadReceiptsConfig `yaml:"read_receipts"`
+
+	// ReactionSync repairs reactions that drifted apart between Matrix and
+	// Mattermost.
+	ReactionSync ReactionSyncConfig `yaml:"reaction_sync"`
+
+	// EmojiOverridesFile is a YAML file mapping Mattermost emoji names to
+	// Unicode emoji, used in both directions before the Mattermost system
+	// emoji. Empty uses the system emoji only.
+	EmojiOverridesFile string `yaml:"emoji_overrides_file"`
+
+	// WelcomeNotice is a Go text/template, rendered as Markdown, that is
+	// posted as a notice when a portal room is created. See WelcomeParams
+	// for the available fields. Empty disables the notice.
+	WelcomeNotice string `yaml:"welcome_notice"`
+
+	// Media controls how Mattermost attachments are reuploaded to Matrix.
+	Media MediaConfig `yaml:"media"`
+
+	// PuppetProfileSync pushes the Matrix profiles of puppet-mapped users to
+	// their Mattermost bot accounts.
+	PuppetProfileSync PuppetProfileSyncConfig `yaml:"puppet_profile_sync"`
+
+	// PortalCreation controls which Mattermost channels get a Matrix room
+	// automatically.
+	PortalCreation PortalCreationConfig `yaml:"portal_creation"`
+
+	// RelayWebhooks posts the messages of relayed Matrix users through
+	// incoming webhooks with their Matrix name and avatar.
+	RelayWebhooks RelayWebhookConfig `yaml:"relay_webhooks"`
+
+	// Channels selects the Mattermost channels that get a portal.
+	Channels ChannelFilterConfig `yaml:"channels"`
+
+	// ArchivedChannels controls the portal rooms of archived channels.
+	ArchivedChannels ArchivedChannelsConfig `yaml:"archived_channels"`
+
+	// Calls controls the notices and widgets of Calls plugin calls.
+	Calls CallsConfig `yaml:"calls"`
+
+	// PostPriority controls the bridging of post priority labels and
+	// requested acknowledgements.
+	PostPriority PostPriorityConfig `yaml:"post_priority"`
+
+	// SavedPosts bridges the posts double-puppeted users save in
+	// Mattermost, and saves posts from a Matrix reaction.
+	SavedPosts SavedPostsConfig `yaml:"saved_posts"`
+
+	// Relay selects the portals the auto-login user is set as relay on.
+	Relay RelayConfig `yaml:"relay"`
+
+	// AutoInvite lists Matrix users invited to portal rooms automatically.
+	AutoInvite AutoInviteConfig `yaml:"auto_invite"`
+
+	// PortalWatcher controls the background pass that sets relays and sends
+	// auto-invites on portal rooms.
+	PortalWatcher PortalWatcherConfig `yaml:"portal_watcher"`
+
+	// Guests controls how Mattermost guest accounts are bridged.
+	Guests GuestConfig `yaml:"guests"`
+
+	// PowerLevels maps Mattermost roles to Matrix power levels.
+	PowerLevels PowerLevelConfig `yaml:"power_levels"`
+
+	// GroupMentions bridges @channel, @all and @here as @room mentions.
+	GroupMentions GroupMentionConfig `yaml:"group_mentions"`
+
+	// ThreadRooms splits long Mattermost threads into rooms of their own.
+	ThreadRooms ThreadRoomConfig `yaml:"thread_rooms"`
+
+	// CoalesceBotPosts merges bursts of bot and webhook posts into one
+	// Matrix message.
+	CoalesceBotPosts CoalesceConfig `yaml:"coalesce_bot_posts"`
+
+	// SystemUsers lists the Mattermost system accounts whose posts and
+	// reactions aren't bridged.
+	SystemUsers SystemUsersConfig `yaml:"system_users"`
+
+	// MattermostAPI tunes the Mattermost API clients for self-hosted servers
+	// or Mattermost Cloud.
+	MattermostAPI MattermostAPIConfig `yaml:"mattermost_api"`
+
+	// EventQueue bounds the Mattermost events waiting for the bridge.
+	EventQueue EventQueueConfig `yaml:"event_queue"`
+
+	// OutboundHold holds Matrix messages while Mattermost is unreachable
+	// and sends them once it's back.
+	OutboundHold OutboundHoldConfig `yaml:"outbound_hold"`
+
+	// EchoDropLog keeps the most recent events dropped by echo prevention
+	// for debugging.
+	EchoDropLog EchoDropLogConfig `yaml:"echo_drop_log"`
+
+	// Caches bounds the lookup caches of each login.
+	Caches CacheConfig `yaml:"caches"`
+
+	// Push configures push notification registration for wrapper apps.
+	Push PushConfig `yaml:"push"`
+
+	// PuppetProvisioning lets the admin API create bot accounts for new
+	// puppets.
+	PuppetProvisioning PuppetProvisioningConfig `yaml:"puppet_provisioning"`
+
+	// DoublePuppetConfirmation makes POST /api/double-puppet wait for the
+	// Matrix user to confirm the link.
+	DoublePuppetConfirmation DoublePuppetConfirmationConfig `yaml:"double_puppet_confirmation"`
+
+	// OAuth2 enables the oauth2 login flow through a Mattermost OAuth 2.0
+	// application.
+	OAuth2 OAuth2Config `yaml:"oauth2"`
+
+	// Sharding splits channels across several bridge processes that share
+	// one database. Disabled unless count is greater than 1.
+	Sharding ShardingConfig `yaml:"sharding"`
+
+	displaynameTemplate      *template.Template            `yaml:"-"`
+	teamDisplaynameTemplates map[string]*template.Template `yaml:"-"`
+	topicTemplate            *template.Template            `yaml:"-"`
+	roomNameTemplate         *template.Template            `yaml:"-"`
+	roomAliasTemplate        *template.Template            `yaml:"-"`
+	welcomeTemplate          *template.Template            `yaml:"-"`
+	location                 *time.Location                `yaml:"-"`
+	emojiOverrides           *emojiOverrides               `yaml:"-"`
+}
+
+// GuestConfig controls how Mattermost guest accounts are bridged.
+type GuestConfig struct {
+	// DisplaynameSuffix is appended to the display name of guests' ghosts,
+	// so Matrix users can tell them apart.
+	DisplaynameSuffix string `yaml:"displayname_suffix"`
+	// Exclude leaves guests out of the bridge: their ghosts don't join portal
+	// rooms, and their posts, reactions and typing aren't bridged.
+	Exclude bool `yaml:"exclude"`
+}
+
+// BackfillConfig controls initial and catch-up backfill.
+type BackfillConfig struct {
+	// EnableOnCreate backfills history when a portal is first created.
+	EnableOnCreate bool `yaml:"enable_on_create"`
+	// InitialLimit caps the number of posts fetched for a new portal.
+	InitialLimit int `yaml:"initial_limit"`
+	// MissedLimit caps the number of posts fetched to catch up on posts
+	// missed while disconnected. 0 disables catch-up.
+	MissedLimit int `yaml:"missed_limit"`
+	// PageDelayMS is the pause between two page requests when a backfill
+	// needs more than one page of posts. 0 disables the pause.
+	PageDelayMS int `yaml:"page_delay_ms"`
+}
+
+// defaultBackfillLimit is used when no backfill limit is configured.
+const defaultBackfillLimit = 100
+
+// backfillOnCreate reports whether new portals should be backfilled.
+func (c *Config) backfillOnCreate() bool {
+	return c.Backfill.EnableOnCreate || c.BackfillEnabled
+}
+
+// backfillMissed reports whether existing portals should catch up on posts
+// missed while the bridge was disconnected.
+func (c *Config) backfillMissed() bool {
+	return c.Backfill.MissedLimit > 0 || c.BackfillEnabled
+}
+
+// backfillLimit returns the maximum number of posts to fetch in one
+// backfill request. catchUp selects missed_limit over initial_limit.
+func (c *Config) backfillLimit(catchUp bool) int {
+	if catchUp && c.Backfill.MissedLimit > 0 {
+		return c.Backfill.MissedLimit
+	}
+	if !catchUp && c.Backfill.InitialLimit > 0 {
+		return c.Backfill.InitialLimit
+	}
+	if c.BackfillMaxCount > 0 {
+		return c.BackfillMaxCount
+	}
+	return defaultBackfillLimit
+}
+
+// backfillPageDelay returns the pause between backfill page requests.
+func (c *Config) backfillPageDelay() time.Duration {
+	return time.Duration(c.Backfill.PageDelayMS) * time.Millisecond
+}
+
+// maxMessageAge returns the age beyond which live events are dropped, or 0
+// for no limit.
+func (c *Config) maxMessageAge() time.Duration {
+	if c.MaxMessageAgeSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.MaxMessageAgeSeconds) * time.Second
+}
+
+// PortalCreationPolicy decides when a Matrix room is created for a
+// Mattermost channel that doesn't have one yet.
+type PortalCreationPolicy string
+
+const (
+	// PortalCreationAlways creates rooms for channels found by the channel
+	// sync and on the first live event (message, join, new DM) in a channel.
+	PortalCreationAlways PortalCreationPolicy = "always"
+	// PortalCreationOnlySynced only creates rooms for channels found by the
+	// channel sync at startup, reconnect and periodic resync.
+	PortalCreationOnlySynced PortalCreationPolicy = "only-synced"
+	// PortalCreationNever never creates rooms automatically. Existing rooms
+	// are still bridged.
+	PortalCreationNever PortalCreationPolicy = "never"
+)
+
+// valid reports whether p is a known policy. Empty means always.
+func (p PortalCreationPolicy) valid() bool {
+	switch p {
+	case "", PortalCreationAlways, PortalCreationOnlySynced, PortalCreationNever:
+		return true
+	}
+	return false
+}
+
+// PortalCreationConfig controls automatic room creation for channels.
+type PortalCreationConfig struct {
+	// Policy applies to all channels, including DMs and group DMs. Empty
+	// means always.
+	Policy PortalCreationPolicy `yaml:"policy"`
+	// Teams overrides Policy for the channels of a team, keyed by team ID.
+	Teams map[string]PortalCreationPolicy `yaml:"teams"`
+}
+
+// policyFor returns the policy for a channel in the given team. teamID is
+// empty for DMs and group DMs.
+func (c *PortalCreationConfig) policyFor(teamID string) PortalCreationPolicy {
+	if policy, ok := c.Teams[teamID]; ok && teamID != "" && policy != "" {
+		return policy
+	}
+	if c.Policy == "" {
+		return PortalCreationAlways
+	}
+	return c.Policy
+}
+
+// allowCreate reports whether an event may create the room for a channel in
+// the given team. synced is true for the channel sync, false for live events.
+func (c *PortalCreationConfig) allowCreate(teamID string, synced bool) bool {
+	switch c.policyFor(teamID) {
+	case PortalCreationNever:
+		return false
+	case PortalCreationOnlySynced:
+		return synced
+	default:
+		return true
+	}
+}
+
+// validate checks that all policies are known.
+func (c *PortalCreationConfig) validate() error {
+	if !c.Policy.valid() {
+		return fmt.Errorf("invalid portal_creation.policy %q", c.Policy)
+	}
+	for teamID, policy := range c.Teams {
+		if !policy.valid() {
+			return fmt.Errorf("invalid portal_creation policy %q for team %s", policy, teamID)
+		}
+	}
+	return nil
+}
+
+// RelayConfig selects the portals that get the auto-login user as relay.
+// With every list empty, all portals do.
+type RelayConfig struct {
+	// ChannelAllowlist lists channel IDs that always get a relay. When it's
+	// non-empty, other channels don't get one.
+	ChannelAllowlist []string `yaml:"channel_allowlist"`
+	// ChannelDenylist lists channel IDs that never get a relay. It takes
+	// precedence over the allowlist.
+	ChannelDenylist []string `yaml:"channel_denylist"`
+	// Teams restricts relays to channels of these team IDs. DMs and group
+	// DMs belong to no team and are excluded when it's set.
+	Teams []string `yaml:"teams"`
+}
+
+// allows reports whether a channel of the given team may get a relay.
+func (c *RelayConfig) allows(channelID, teamID string) bool {
+	if slices.Contains(c.ChannelDenylist, channelID) {
+		return false
+	}
+	if slices.Contains(c.ChannelAllowlist, channelID) {
+		return true
+	}
+	if len(c.ChannelAllowlist) > 0 {
+		return false
+	}
+	if len(c.Teams) > 0 {
+		return teamID != "" && slices.Contains(c.Teams, teamID)
+	}
+	return true
+}
+
+// AutoInviteConfig lists the Matrix users the bridge bot invites to portal
+// rooms once they exist. A user's rules add up: a user listed for a team and
+// for one of its channels is invited once.
+type AutoInviteConfig struct {
+	// Users are invited to every portal room, including DMs and group DMs.
+	Users []id.UserID `yaml:"users"`
+	// Teams lists users invited to the channels of a team, keyed by team ID.
+	Teams map[string][]id.UserID `yaml:"teams"`
+	// Channels lists users invited to one channel, keyed by channel ID.
+	Channels map[string][]id.UserID `yaml:"channels"`
+}
+
+// enabled reports whether any user is configured.
+func (c *AutoInviteConfig) enabled() bool {
+	return len(c.Users) > 0 || len(c.Teams) > 0 || len(c.Channels) > 0
+}
+
+// usersFor returns the users to invite to a channel of the given team,
+// without duplicates. teamID is empty for DMs and group DMs.
+func (c *AutoInviteConfig) usersFor(channelID, teamID string) []id.UserID {
+	var users []id.UserID
+	lists := [][]id.UserID{c.Users, c.Channels[channelID]}
+	if teamID != "" {
+		lists = append(lists, c.Teams[teamID])
+	}
+	for _, list := range lists {
+		for _, userID := range list {
+			if !slices.Contains(users, userID) {
+				users = append(users, userID)
+			}
+		}
+	}
+	return users
+}
+
+// validate checks that all users are valid Matrix user IDs.
+func (c *AutoInviteConfig) validate() error {
+	lists := [][]id.UserID{c.Users}
+	for _, list := range c.Teams {
+		lists = append(lists, list)
+	}
+	for _, list := range c.Channels {
+		lists = append(lists, list)
+	}
+	for _, list := range lists {
+		for _, userID := range list {
+			if _, _, err := userID.Parse(); err != nil {
+				return fmt.Errorf("invalid auto_invite user %q: %w", userID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// EventQueueConfig controls the queue between the Mattermost WebSocket and
+// the bridge.
+type EventQueueConfig struct {
+	// Size is the maximum number of queued events. When the queue is full,
+	// events are dropped and their channels resynced once it drains. 0
+	// disables the queue and hands events straight to the bridge.
+	Size int `yaml:"size"`
+}
+
+// MediaConfig controls the Mattermost to Matrix attachment pipeline.
+type MediaConfig struct {
+	// MaxSizeMB caps the size of attachments reuploaded to Matrix, in
+	// megabytes. Larger files are bridged as a download link. 0 uses only
+	// the homeserver's upload limit.
+	MaxSizeMB int `yaml:"max_size_mb"`
+	// TimeoutSeconds bounds how long one attachment may take to download
+	// and reupload. Defaults to 120.
+	TimeoutSeconds int `yaml:"timeout_seconds"`
+}
+
+// PuppetProfileSyncConfig controls pushing puppet users' Matrix display
+// names and avatars to their Mattermost bots, and double-puppeted users'
+// to their own accounts.
+type PuppetProfileSyncConfig struct {
+	// Enabled pushes puppet users' profiles when their puppet is loaded,
+	// and profile changes as they make them in Matrix.
+	Enabled bool `yaml:"enabled"`
+	// DoublePuppets pushes double-puppeted users' profiles to their
+	// Mattermost nickname and profile image. Only users with their own
+	// Mattermost login are updated.
+	DoublePuppets bool `yaml:"double_puppets"`
+	// IntervalMinutes also resyncs every puppet's profile on a schedule,
+	// which catches changes made while the bridge was down. 0 disables the
+	// schedule.
+	IntervalMinutes int `yaml:"interval_minutes"`
+}
+
+// defaultMediaTimeout is used when media.timeout_seconds is unset.
+const defaultMediaTimeout = 2 * time.Minute
+
+// mediaTimeout returns the per-attachment reupload timeout.
+func (c *Config) mediaTimeout() time.Duration {
+	if c.Media.TimeoutSeconds > 0 {
+		return time.Duration(c.Media.TimeoutSeconds) * time.Second
+	}
+	return defaultMediaTimeout
+}
+
+// resyncInterval returns the periodic channel resync interval, or 0 if
+// periodic resync is disabled.
+func (c *Config) resyncInterval() time.Duration {
+	if c.ResyncIntervalMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(c.ResyncIntervalMinutes) * time.Minute
 }
 
 // DisplaynameParams holds the parameters for rendering the displayname template.
@@ -42,6 +524,24 @@ type DisplaynameParams struct {
 	Nickname  string
 	FirstName string
 	LastName  string
+	// FullName is the first and last name, set by FormatDisplayname.
+	FullName string
+	// DisplayName is the first of the nickname, the full name and the
+	// username that isn't empty, set by FormatDisplayname.
+	DisplayName string
+}
+
+// fill sets the names derived from the others.
+func (p *DisplaynameParams) fill() {
+	p.FullName = strings.TrimSpace(p.FirstName + " " + p.LastName)
+	switch {
+	case strings.TrimSpace(p.Nickname) != "":
+		p.DisplayName = p.Nickname
+	case p.FullName != "":
+		p.DisplayName = p.FullName
+	default:
+		p.DisplayName = p.Username
+	}
 }
 
 func (c *Config) UnmarshalYAML(node *yaml.Node) error {
@@ -52,17 +552,189 @@ func (c *Config) UnmarshalYAML(node *yaml.Node) error {
 func (c *Config) PostProcess() error {
 	var err error
 	c.displaynameTemplate, err = template.New("displayname").Parse(c.DisplaynameTemplate)
-	return err
+	if err != nil {
+		return err
+	}
+	c.teamDisplaynameTemplates = make(map[string]*template.Template, len(c.TeamDisplaynameTemplates))
+	for teamID, tpl := range c.TeamDisplaynameTemplates {
+		c.teamDisplaynameTemplates[teamID], err = template.New("displayname").Parse(tpl)
+		if err != nil {
+			return fmt.Errorf("invalid team_displayname_templates entry for team %s: %w", teamID, err)
+		}
+	}
+	c.topicTemplate = nil
+	if strings.TrimSpace(c.TopicTemplate) != "" {
+		c.topicTemplate, err = template.New("topic").Parse(c.TopicTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid topic_template: %w", err)
+		}
+	}
+	c.roomNameTemplate = nil
+	if strings.TrimSpace(c.RoomNameTemplate) != "" {
+		c.roomNameTemplate, err = template.New("room_name").Parse(c.RoomNameTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid room_name_template: %w", err)
+		}
+	}
+	c.roomAliasTemplate = nil
+	if strings.TrimSpace(c.RoomAliasTemplate) != "" {
+		c.roomAliasTemplate, err = template.New("room_alias").Parse(c.RoomAliasTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid room_alias_template: %w", err)
+		}
+	}
+	c.welcomeTemplate = nil
+	if strings.TrimSpace(c.WelcomeNotice) != "" {
+		c.welcomeTemplate, err = template.New("welcome").Parse(c.WelcomeNotice)
+		if err != nil {
+			return fmt.Errorf("invalid welcome_notice template: %w", err)
+		}
+	}
+	c.emojiOverrides = nil
+	if c.EmojiOverridesFile != "" {
+		c.emojiOverrides, err = loadEmojiOverrides(c.EmojiOverridesFile)
+		if err != nil {
+			return fmt.Errorf("invalid emoji_overrides_file: %w", err)
+		}
+	}
+	c.location, err = time.LoadLocation(c.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	switch c.SpoilerStyle {
+	case "", SpoilerStyleLink, SpoilerStylePipes:
+	default:
+		return fmt.Errorf("invalid spoiler_style %q", c.SpoilerStyle)
+	}
+	if err := c.PortalCreation.validate(); err != nil {
+		return err
+	}
+	if err := c.Channels.validate(); err != nil {
+		return err
+	}
+	if err := c.PowerLevels.validate(); err != nil {
+		return err
+	}
+	if err := c.AutoInvite.validate(); err != nil {
+		return err
+	}
+	if err := c.ThreadRooms.validate(); err != nil {
+		return err
+	}
+	if err := c.CoalesceBotPosts.validate(); err != nil {
+		return err
+	}
+	if err := c.DoublePuppetConfirmation.validate(); err != nil {
+		return err
+	}
+	if err := c.OAuth2.validate(); err != nil {
+		return err
+	}
+	return c.MattermostAPI.validate()
 }
 
 func upgradeConfig(helper up.Helper) {
 	helper.Copy(up.Str, "server_url")
 	helper.Copy(up.Str, "displayname_template")
+	helper.Copy(up.Map, "team_displayname_templates")
 	helper.Copy(up.Str, "bot_prefix")
 	helper.Copy(up.Str, "admin_api_addr")
+	helper.Copy(up.Str, "admin_api_token")
+	helper.Copy(up.Bool, "admin_api_fixtures")
 	helper.Copy(up.Bool, "backfill_enabled")
 	helper.Copy(up.Int, "backfill_max_count")
 	helper.Copy(up.Int, "typing_timeout")
+	helper.Copy(up.Bool, "relay_typing")
+	helper.Copy(up.Bool, "backfill", "enable_on_create")
+	helper.Copy(up.Int, "backfill", "initial_limit")
+	helper.Copy(up.Int, "backfill", "missed_limit")
+	helper.Copy(up.Int, "backfill", "page_delay_ms")
+	helper.Copy(up.Int, "max_message_age_seconds")
+	helper.Copy(up.Str, "timezone")
+	helper.Copy(up.Str, "time_format")
+	helper.Copy(up.Str, "spoiler_style")
+	helper.Copy(up.Str, "room_name_template")
+	helper.Copy(up.Str, "room_alias_template")
+	helper.Copy(up.Str, "topic_template")
+	helper.Copy(up.Bool, "channel_info_state")
+	helper.Copy(up.Bool, "team_spaces")
+	helper.Copy(up.Int, "resync_interval_minutes")
+	helper.Copy(up.Bool, "bridge_presence")
+	helper.Copy(up.Bool, "read_receipts", "enabled")
+	helper.Copy(up.Int, "read_receipts", "poll_interval_seconds")
+	helper.Copy(up.Bool, "reaction_sync", "redactions")
+	helper.Copy(up.Int, "reaction_sync", "interval_minutes")
+	helper.Copy(up.Int, "reaction_sync", "recent_posts")
+	helper.Copy(up.Str, "emoji_overrides_file")
+	helper.Copy(up.Str, "welcome_notice")
+	helper.Copy(up.Int, "media", "max_size_mb")
+	helper.Copy(up.Int, "media", "timeout_seconds")
+	helper.Copy(up.Bool, "puppet_profile_sync", "enabled")
+	helper.Copy(up.Bool, "puppet_profile_sync", "double_puppets")
+	helper.Copy(up.Int, "puppet_profile_sync", "interval_minutes")
+	helper.Copy(up.Int, "event_queue", "size")
+	helper.Copy(up.Bool, "outbound_hold", "enabled")
+	helper.Copy(up.Int, "outbound_hold", "max_per_portal")
+	helper.Copy(up.Int, "outbound_hold", "max_age_minutes")
+	helper.Copy(up.Int, "outbound_hold", "retry_interval_seconds")
+	helper.Copy(up.Int, "echo_drop_log", "size")
+	helper.Copy(up.Int, "caches", "max_entries")
+	helper.Copy(up.Int, "caches", "ttl_minutes")
+	helper.Copy(up.Str, "push", "apns_bundle_id")
+	helper.Copy(up.Str, "push", "fcm_sender_id")
+	helper.Copy(up.Str, "puppet_provisioning", "admin_token")
+	helper.Copy(up.List, "puppet_provisioning", "team_ids")
+	helper.Copy(up.Bool, "double_puppet_confirmation", "enabled")
+	helper.Copy(up.Str, "double_puppet_confirmation", "deliver")
+	helper.Copy(up.Int, "double_puppet_confirmation", "expiry_minutes")
+	helper.Copy(up.Str, "oauth2", "client_id")
+	helper.Copy(up.Str, "oauth2", "client_secret")
+	helper.Copy(up.Str, "oauth2", "redirect_url")
+	helper.Copy(up.Int, "oauth2", "refresh_margin_minutes")
+	helper.Copy(up.List, "channels", "allowlist")
+	helper.Copy(up.List, "channels", "denylist")
+	helper.Copy(up.Bool, "archived_channels", "delete_room")
+	helper.Copy(up.Bool, "calls", "notices")
+	helper.Copy(up.Bool, "calls", "widget")
+	helper.Copy(up.Bool, "post_priority", "labels")
+	helper.Copy(up.Bool, "post_priority", "acknowledge_with_reactions")
+	helper.Copy(up.Bool, "saved_posts", "enabled")
+	helper.Copy(up.Str, "saved_posts", "reaction")
+	helper.Copy(up.List, "relay", "channel_allowlist")
+	helper.Copy(up.List, "relay", "channel_denylist")
+	helper.Copy(up.List, "relay", "teams")
+	helper.Copy(up.List, "auto_invite", "users")
+	helper.Copy(up.Map, "auto_invite", "teams")
+	helper.Copy(up.Map, "auto_invite", "channels")
+	helper.Copy(up.Int, "portal_watcher", "interval_seconds")
+	helper.Copy(up.Str, "guests", "displayname_suffix")
+	helper.Copy(up.Bool, "guests", "exclude")
+	helper.Copy(up.Bool, "power_levels", "enabled")
+	helper.Copy(up.Int, "power_levels", "channel_admin")
+	helper.Copy(up.Int, "power_levels", "team_admin")
+	helper.Copy(up.Int, "power_levels", "system_admin")
+	helper.Copy(up.Bool, "power_levels", "sync_to_mattermost")
+	helper.Copy(up.Bool, "group_mentions", "to_matrix")
+	helper.Copy(up.Bool, "group_mentions", "to_mattermost")
+	helper.Copy(up.Bool, "thread_rooms", "enabled")
+	helper.Copy(up.Int, "thread_rooms", "reply_threshold")
+	helper.Copy(up.Int, "coalesce_bot_posts", "window_seconds")
+	helper.Copy(up.Map, "coalesce_bot_posts", "teams")
+	helper.Copy(up.Map, "coalesce_bot_posts", "channels")
+	helper.Copy(up.List, "system_users", "usernames")
+	helper.Copy(up.Str, "mattermost_api", "profile")
+	helper.Copy(up.Int, "mattermost_api", "requests_per_second")
+	helper.Copy(up.Int, "mattermost_api", "burst")
+	helper.Copy(up.Int, "mattermost_api", "sync_concurrency")
+	helper.Copy(up.Bool, "relay_webhooks", "enabled")
+	helper.Copy(up.Map, "relay_webhooks", "teams")
+	helper.Copy(up.Map, "relay_webhooks", "channels")
+	helper.Copy(up.Str, "portal_creation", "policy")
+	helper.Copy(up.Map, "portal_creation", "teams")
+	helper.Copy(up.Int, "sharding", "count")
+	helper.Copy(up.Int, "sharding", "id")
+	helper.Copy(up.Str, "sharding", "worker_id")
+	helper.Copy(up.Int, "sharding", "lease_seconds")
 }
 
 func (mc *MattermostConnector) GetConfig() (example string, data any, upgrader up.Upgrader) {
@@ -74,17 +746,33 @@ func (mc *MattermostConnector) GetConfig() (example string, data any, upgrader u
 }
 
 // FormatDisplayname generates a display name from the template and params.
+// A template that fails or renders only whitespace falls back to the
+// nickname, the full name and the username, in that order.
 func (c *Config) FormatDisplayname(params DisplaynameParams) string {
-	if c.displaynameTemplate == nil {
-		return params.Username
+	return c.formatDisplaynameWith(c.displaynameTemplate, params)
+}
+
+// FormatTeamDisplayname is FormatDisplayname with the team's template from
+// team_displayname_templates, if it has one.
+func (c *Config) FormatTeamDisplayname(teamID string, params DisplaynameParams) string {
+	if tpl, ok := c.teamDisplaynameTemplates[teamID]; ok {
+		return c.formatDisplaynameWith(tpl, params)
+	}
+	return c.FormatDisplayname(params)
+}
+
+func (c *Config) formatDisplaynameWith(tpl *template.Template, params DisplaynameParams) string {
+	params.fill()
+	if tpl == nil {
+		return params.DisplayName
 	}
 	var buf []byte
-	err := c.displaynameTemplate.Execute(
+	err := tpl.Execute(
 		(*templateBuffer)(&buf),
 		params,
 	)
-	if err != nil {
-		return params.Username
+	if err != nil || strings.TrimSpace(string(buf)) == "" {
+		return params.DisplayName
 	}
 	return string(buf)
 }
@@ -6,10 +6,13 @@
 package connector
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	up "go.mau.fi/util/configupgrade"
 	"gopkg.in/yaml.v3"
+	"maunium.net/go/mautrix/id"
 )
 
 func TestConfigUnmarshalYAML(t *testing.T) {
@@ -53,6 +56,181 @@ func TestConfigPostProcessInvalidTemplate(t *testing.T) {
 	}
 }
 
+func TestPortalCreationConfig_AllowCreate(t *testing.T) {
+	t.Parallel()
+	cfg := PortalCreationConfig{
+		Policy: PortalCreationOnlySynced,
+		Teams: map[string]PortalCreationPolicy{
+			"open-team":   PortalCreationAlways,
+			"closed-team": PortalCreationNever,
+			"blank-team":  "",
+		},
+	}
+	tests := []struct {
+		name   string
+		cfg    PortalCreationConfig
+		teamID string
+		synced bool
+		want   bool
+	}{
+		{"default is always, live", PortalCreationConfig{}, "t1", false, true},
+		{"default is always, synced", PortalCreationConfig{}, "t1", true, true},
+		{"never", PortalCreationConfig{Policy: PortalCreationNever}, "t1", true, false},
+		{"only-synced, live", cfg, "t1", false, false},
+		{"only-synced, synced", cfg, "t1", true, true},
+		{"dm uses policy", cfg, "", false, false},
+		{"team override always", cfg, "open-team", false, true},
+		{"team override never", cfg, "closed-team", true, false},
+		{"empty override falls back", cfg, "blank-team", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.allowCreate(tt.teamID, tt.synced); got != tt.want {
+				t.Errorf("allowCreate(%q, %v) = %v, want %v", tt.teamID, tt.synced, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelayConfig_Allows(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		cfg       RelayConfig
+		channelID string
+		teamID    string
+		want      bool
+	}{
+		{"empty allows all", RelayConfig{}, "c1", "t1", true},
+		{"empty allows dms", RelayConfig{}, "c1", "", true},
+		{"denied", RelayConfig{ChannelDenylist: []string{"c1"}}, "c1", "t1", false},
+		{"not denied", RelayConfig{ChannelDenylist: []string{"c1"}}, "c2", "t1", true},
+		{"deny beats allow", RelayConfig{ChannelAllowlist: []string{"c1"}, ChannelDenylist: []string{"c1"}}, "c1", "t1", false},
+		{"allowed", RelayConfig{ChannelAllowlist: []string{"c1"}}, "c1", "t1", true},
+		{"not allowlisted", RelayConfig{ChannelAllowlist: []string{"c1"}}, "c2", "t1", false},
+		{"allowlist beats teams", RelayConfig{ChannelAllowlist: []string{"c1"}, Teams: []string{"t2"}}, "c1", "t1", true},
+		{"team listed", RelayConfig{Teams: []string{"t1"}}, "c1", "t1", true},
+		{"team not listed", RelayConfig{Teams: []string{"t1"}}, "c1", "t2", false},
+		{"teams exclude dms", RelayConfig{Teams: []string{"t1"}}, "c1", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.allows(tt.channelID, tt.teamID); got != tt.want {
+				t.Errorf("allows(%q, %q) = %v, want %v", tt.channelID, tt.teamID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoInviteConfig_UsersFor(t *testing.T) {
+	t.Parallel()
+	cfg := AutoInviteConfig{
+		Users:    []id.UserID{"@ops:example.com"},
+		Teams:    map[string][]id.UserID{"t1": {"@agent:example.com", "@ops:example.com"}},
+		Channels: map[string][]id.UserID{"c1": {"@lead:example.com"}},
+	}
+	tests := []struct {
+		name      string
+		channelID string
+		teamID    string
+		want      []id.UserID
+	}{
+		{"team and channel", "c1", "t1", []id.UserID{"@ops:example.com", "@lead:example.com", "@agent:example.com"}},
+		{"team", "c2", "t1", []id.UserID{"@ops:example.com", "@agent:example.com"}},
+		{"other team", "c2", "t2", []id.UserID{"@ops:example.com"}},
+		{"dm", "c3", "", []id.UserID{"@ops:example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfg.usersFor(tt.channelID, tt.teamID); !slices.Equal(got, tt.want) {
+				t.Errorf("usersFor(%q, %q) = %v, want %v", tt.channelID, tt.teamID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigPostProcessAutoInvite(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     AutoInviteConfig
+		wantErr bool
+	}{
+		{"empty", AutoInviteConfig{}, false},
+		{"valid", AutoInviteConfig{Users: []id.UserID{"@ops:example.com"}, Channels: map[string][]id.UserID{"c1": {"@lead:example.com"}}}, false},
+		{"invalid user", AutoInviteConfig{Users: []id.UserID{"ops"}}, true},
+		{"invalid team user", AutoInviteConfig{Teams: map[string][]id.UserID{"t1": {"#room:example.com"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{AutoInvite: tt.cfg}
+			if err := cfg.PostProcess(); (err != nil) != tt.wantErr {
+				t.Errorf("PostProcess: err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigPostProcessPortalCreation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     PortalCreationConfig
+		wantErr bool
+	}{
+		{"empty", PortalCreationConfig{}, false},
+		{"valid", PortalCreationConfig{Policy: PortalCreationNever, Teams: map[string]PortalCreationPolicy{"t1": PortalCreationOnlySynced}}, false},
+		{"unknown policy", PortalCreationConfig{Policy: "sometimes"}, true},
+		{"unknown team policy", PortalCreationConfig{Teams: map[string]PortalCreationPolicy{"t1": "synced"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{PortalCreation: tt.cfg}
+			if err := cfg.PostProcess(); (err != nil) != tt.wantErr {
+				t.Errorf("PostProcess: err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigPostProcessTimezone(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		timezone string
+		wantErr  bool
+		wantLoc  string
+	}{
+		{"empty defaults to UTC", "", false, "UTC"},
+		{"named zone", "Europe/Paris", false, "Europe/Paris"},
+		{"invalid", "Mars/Olympus_Mons", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Timezone: tt.timezone}
+			err := cfg.PostProcess()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error for invalid timezone")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PostProcess: %v", err)
+			}
+			if got := cfg.formatOptions().Location.String(); got != tt.wantLoc {
+				t.Errorf("location: got %q, want %q", got, tt.wantLoc)
+			}
+		})
+	}
+}
+
 func TestFormatDisplayname(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -85,6 +263,36 @@ func TestFormatDisplayname(t *testing.T) {
 			params: DisplaynameParams{},
 			want:   "[]",
 		},
+		{
+			name:   "display name prefers the nickname",
+			tmpl:   "{{.DisplayName}} (MM)",
+			params: DisplaynameParams{Username: "johnd", Nickname: "JD", FirstName: "John", LastName: "Doe"},
+			want:   "JD (MM)",
+		},
+		{
+			name:   "display name falls back to the full name",
+			tmpl:   "{{.DisplayName}}",
+			params: DisplaynameParams{Username: "johnd", FirstName: "John"},
+			want:   "John",
+		},
+		{
+			name:   "full name",
+			tmpl:   "{{.FullName}}",
+			params: DisplaynameParams{FirstName: "John", LastName: "Doe"},
+			want:   "John Doe",
+		},
+		{
+			name:   "blank render falls back to the full name",
+			tmpl:   "{{.Nickname}}",
+			params: DisplaynameParams{Username: "johnd", FirstName: "John", LastName: "Doe"},
+			want:   "John Doe",
+		},
+		{
+			name:   "blank render falls back to the username",
+			tmpl:   "{{.FirstName}} {{.LastName}}",
+			params: DisplaynameParams{Username: "johnd"},
+			want:   "johnd",
+		},
 	}
 
 	for _, tt := range tests {
@@ -111,6 +319,29 @@ func TestFormatDisplayname_NilTemplate(t *testing.T) {
 	}
 }
 
+func TestFormatTeamDisplayname(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		DisplaynameTemplate:      "{{.Username}} (MM)",
+		TeamDisplaynameTemplates: map[string]string{"team1": "{{.DisplayName}} [team1]"},
+	}
+	if err := cfg.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	params := DisplaynameParams{Username: "johnd", FirstName: "John", LastName: "Doe"}
+	if got := cfg.FormatTeamDisplayname("team1", params); got != "John Doe [team1]" {
+		t.Errorf("team template: got %q", got)
+	}
+	if got := cfg.FormatTeamDisplayname("team2", params); got != "johnd (MM)" {
+		t.Errorf("other teams should use displayname_template: got %q", got)
+	}
+
+	bad := &Config{TeamDisplaynameTemplates: map[string]string{"team1": "{{.Bad"}}
+	if err := bad.PostProcess(); err == nil || !strings.Contains(err.Error(), "team1") {
+		t.Errorf("invalid team template: got %v", err)
+	}
+}
+
 func TestUpgradeConfig(t *testing.T) {
 	t.Parallel()
 	// Parse the example config as the base.
@@ -151,6 +382,11 @@ displayname_template: "{{.Nickname}} (MM)"
 backfill_enabled: true
 backfill_max_count: 250
 typing_timeout: 10
+bridge_presence: true
+puppet_profile_sync:
+    enabled: true
+    double_puppets: true
+    interval_minutes: 30
 `
 	var cfg Config
 	if err := yaml.Unmarshal([]byte(input), &cfg); err != nil {
@@ -165,6 +401,12 @@ typing_timeout: 10
 	if cfg.TypingTimeout != 10 {
 		t.Errorf("TypingTimeout: got %d, want 10", cfg.TypingTimeout)
 	}
+	if !cfg.BridgePresence {
+		t.Error("BridgePresence: got false, want true")
+	}
+	if !cfg.PuppetProfileSync.Enabled || !cfg.PuppetProfileSync.DoublePuppets || cfg.PuppetProfileSync.IntervalMinutes != 30 {
+		t.Errorf("PuppetProfileSync: got %+v, want enabled every 30 minutes", cfg.PuppetProfileSync)
+	}
 }
 
 func TestConfigDefaults(t *testing.T) {
@@ -227,3 +469,74 @@ func TestFormatDisplayname_SpecialCharacters(t *testing.T) {
 
 // Note: FuzzFormatDisplayname is defined in fuzz_test.go with a more
 // comprehensive corpus including arbitrary template strings.
+
+func TestConfigUnmarshalYAML_BackfillBlock(t *testing.T) {
+	t.Parallel()
+	input := `
+backfill:
+  enable_on_create: true
+  initial_limit: 50
+  missed_limit: 20
+  page_delay_ms: 100
+`
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatalf("UnmarshalYAML: %v", err)
+	}
+	want := BackfillConfig{EnableOnCreate: true, InitialLimit: 50, MissedLimit: 20, PageDelayMS: 100}
+	if cfg.Backfill != want {
+		t.Errorf("Backfill: got %+v, want %+v", cfg.Backfill, want)
+	}
+}
+
+func TestConfigUnmarshalYAML_MediaBlock(t *testing.T) {
+	t.Parallel()
+	input := `
+media:
+  max_size_mb: 25
+  timeout_seconds: 30
+`
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatalf("UnmarshalYAML: %v", err)
+	}
+	want := MediaConfig{MaxSizeMB: 25, TimeoutSeconds: 30}
+	if cfg.Media != want {
+		t.Errorf("Media: got %+v, want %+v", cfg.Media, want)
+	}
+}
+
+func TestConfigBackfillSettings(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		cfg         Config
+		onCreate    bool
+		missed      bool
+		initial     int
+		missedLimit int
+	}{
+		{"defaults", Config{}, false, false, defaultBackfillLimit, defaultBackfillLimit},
+		{"legacy", Config{BackfillEnabled: true, BackfillMaxCount: 30}, true, true, 30, 30},
+		{"on create only", Config{Backfill: BackfillConfig{EnableOnCreate: true, InitialLimit: 10}}, true, false, 10, defaultBackfillLimit},
+		{"missed only", Config{Backfill: BackfillConfig{MissedLimit: 5}}, false, true, defaultBackfillLimit, 5},
+		{"block overrides legacy limit", Config{BackfillMaxCount: 30, Backfill: BackfillConfig{InitialLimit: 10, MissedLimit: 5}}, false, true, 10, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.backfillOnCreate(); got != tt.onCreate {
+				t.Errorf("backfillOnCreate: got %v, want %v", got, tt.onCreate)
+			}
+			if got := tt.cfg.backfillMissed(); got != tt.missed {
+				t.Errorf("backfillMissed: got %v, want %v", got, tt.missed)
+			}
+			if got := tt.cfg.backfillLimit(false); got != tt.initial {
+				t.Errorf("backfillLimit(false): got %d, want %d", got, tt.initial)
+			}
+			if got := tt.cfg.backfillLimit(true); got != tt.missedLimit {
+				t.Errorf("backfillLimit(true): got %d, want %d", got, tt.missedLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errNoConfigPath is returned by ReloadConfig when the bridge doesn't know
+// its config file.
+var errNoConfigPath = errors.New("config file path is unknown")
+
+// liveConfig returns the config in effect for the settings POST
+// /api/reload-config changes: the last reloaded one, or the startup config.
+// Other settings are only read from Config.
+func (mc *MattermostConnector) liveConfig() *Config {
+	if cfg := mc.reloadedConfig.Load(); cfg != nil {
+		return cfg
+	}
+	return &mc.Config
+}
+
+// readNetworkConfig reads the network section of a bridge config file and
+// validates it like the startup config.
+func readNetworkConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var file struct {
+		Network Config `yaml:"network"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := file.Network.PostProcess(); err != nil {
+		return nil, fmt.Errorf("invalid network config: %w", err)
+	}
+	return &file.Network, nil
+}
+
+// ReloadConfig re-reads the reloadable settings from the config file:
+// bot_prefix, typing_timeout, backfill_max_count, the displayname templates
+// and the channels lists. They're swapped in at once, so readers never see a
+// mix of old and new values; other settings keep their startup values. When
+// a displayname template changes, existing ghosts are renamed in the
+// background. It returns the keys whose value changed. Thread-safe.
+func (mc *MattermostConnector) ReloadConfig() ([]string, error) {
+	if mc.ConfigPath == "" {
+		return nil, errNoConfigPath
+	}
+	loaded, err := readNetworkConfig(mc.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mc.configReloadMu.Lock()
+	defer mc.configReloadMu.Unlock()
+	current := mc.liveConfig()
+	var changed []string
+	if loaded.BotPrefix != current.BotPrefix {
+		changed = append(changed, "bot_prefix")
+	}
+	if loaded.TypingTimeout != current.TypingTimeout {
+		changed = append(changed, "typing_timeout")
+	}
+	if loaded.BackfillMaxCount != current.BackfillMaxCount {
+		changed = append(changed, "backfill_max_count")
+	}
+	if loaded.DisplaynameTemplate != current.DisplaynameTemplate {
+		changed = append(changed, "displayname_template")
+	}
+	if !maps.Equal(loaded.TeamDisplaynameTemplates, current.TeamDisplaynameTemplates) {
+		changed = append(changed, "team_displayname_templates")
+	}
+	if !slices.Equal(loaded.Channels.Allowlist, current.Channels.Allowlist) {
+		changed = append(changed, "channels.allowlist")
+	}
+	if !slices.Equal(loaded.Channels.Denylist, current.Channels.Denylist) {
+		changed = append(changed, "channels.denylist")
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	next := *current
+	next.BotPrefix = loaded.BotPrefix
+	next.TypingTimeout = loaded.TypingTimeout
+	next.BackfillMaxCount = loaded.BackfillMaxCount
+	next.DisplaynameTemplate = loaded.DisplaynameTemplate
+	next.displaynameTemplate = loaded.displaynameTemplate
+	next.TeamDisplaynameTemplates = loaded.TeamDisplaynameTemplates
+	next.teamDisplaynameTemplates = loaded.teamDisplaynameTemplates
+	next.Channels = loaded.Channels
+	mc.reloadedConfig.Store(&next)
+	if slices.Contains(changed, "displayname_template") || slices.Contains(changed, "team_displayname_templates") {
+		go mc.renameGhosts(mc.Bridge.Log.With().Str("component", "ghost_rename").Logger().WithContext(context.Background()))
+	}
+	return changed, nil
+}
+
+// HandleReloadConfig is an HTTP handler for POST /api/reload-config. It
+// applies the reloadable settings of the config file and responds with the
+// keys that changed.
+func (mc *MattermostConnector) HandleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	changed, err := mc.ReloadConfig()
+	switch {
+	case errors.Is(err, errNoConfigPath):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		log.Error().Err(err).Str("remote_addr", r.RemoteAddr).Msg("Config reload failed")
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Strs("changed", changed).
+		Msg("Config reloaded")
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string][]string{"changed": changed}); err != nil {
+		log.Warn().Err(err).Msg("Failed to write config reload response")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const reloadTestConfig = `
+homeserver:
+  address: http://synapse:8008
+network:
+  server_url: http://mm.local:8065
+  displayname_template: "{{.Username}}"
+  bot_prefix: relay_
+  typing_timeout: 5
+  backfill_max_count: 100
+  channels:
+    allowlist: [town-square]
+    denylist: []
+`
+
+// newReloadTestConnector returns a connector whose startup config and
+// config file both hold reloadTestConfig. The file's path is returned so
+// tests can change it.
+func newReloadTestConnector(t *testing.T) (*MattermostConnector, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(reloadTestConfig), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	cfg, err := readNetworkConfig(path)
+	if err != nil {
+		t.Fatalf("readNetworkConfig: %v", err)
+	}
+	mc := newTestBridgeConnector()
+	mc.Config = *cfg
+	mc.ConfigPath = path
+	return mc, path
+}
+
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+	mc, path := newReloadTestConnector(t)
+
+	changed, err := mc.ReloadConfig()
+	if err != nil || len(changed) != 0 {
+		t.Fatalf("unchanged file: got %v, %v", changed, err)
+	}
+
+	updated := `
+network:
+  server_url: http://other.local:8065
+  displayname_template: "{{.Username}} (MM)"
+  bot_prefix: bridge_
+  typing_timeout: 10
+  backfill_max_count: 100
+  channels:
+    allowlist: [town-square]
+    denylist: [off-topic]
+`
+	if err := os.WriteFile(path, []byte(updated), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	changed, err = mc.ReloadConfig()
+	if err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	want := []string{"bot_prefix", "typing_timeout", "displayname_template", "channels.denylist"}
+	if !slices.Equal(changed, want) {
+		t.Errorf("changed: got %v, want %v", changed, want)
+	}
+
+	live := mc.liveConfig()
+	if live.BotPrefix != "bridge_" || live.TypingTimeout != 10 {
+		t.Errorf("live config not updated: %+v", live)
+	}
+	if got := live.FormatDisplayname(DisplaynameParams{Username: "alice"}); got != "alice (MM)" {
+		t.Errorf("displayname: got %q", got)
+	}
+	if !mc.channelFilter().allows("ch1", "town-square", "team1", "team") || mc.channelFilter().allows("ch2", "off-topic", "", "") {
+		t.Errorf("channel filter not updated: %+v", mc.channelFilter())
+	}
+	if live.ServerURL != "http://mm.local:8065" {
+		t.Errorf("non-reloadable server_url changed to %q", live.ServerURL)
+	}
+	if mc.Config.BotPrefix != "relay_" {
+		t.Error("the startup config must not be modified")
+	}
+}
+
+func TestReloadConfig_TeamDisplaynameTemplates(t *testing.T) {
+	t.Parallel()
+	mc, path := newReloadTestConnector(t)
+
+	updated := reloadTestConfig + "  team_displayname_templates:\n    team1: \"{{.Username}} [team1]\"\n"
+	if err := os.WriteFile(path, []byte(updated), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	changed, err := mc.ReloadConfig()
+	if err != nil || !slices.Equal(changed, []string{"team_displayname_templates"}) {
+		t.Fatalf("ReloadConfig: got %v, %v", changed, err)
+	}
+	if got := mc.liveConfig().FormatTeamDisplayname("team1", DisplaynameParams{Username: "alice"}); got != "alice [team1]" {
+		t.Errorf("team displayname: got %q", got)
+	}
+}
+
+func TestReloadConfig_Invalid(t *testing.T) {
+	t.Parallel()
+	mc, path := newReloadTestConnector(t)
+
+	for name, content := range map[string]string{
+		"template": "network:\n  displayname_template: \"{{.Bad\"\n  bot_prefix: bridge_\n",
+		"channels": "network:\n  bot_prefix: bridge_\n  channels:\n    allowlist: [a/b/c]\n",
+		"yaml":     "network: [",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if _, err := mc.ReloadConfig(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if mc.liveConfig().BotPrefix != "relay_" {
+			t.Errorf("%s: an invalid config must not be applied", name)
+		}
+	}
+}
+
+func TestReloadConfig_NoPath(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	if _, err := mc.ReloadConfig(); !errors.Is(err, errNoConfigPath) {
+		t.Errorf("got %v, want errNoConfigPath", err)
+	}
+}
+
+func TestHandleReloadConfig(t *testing.T) {
+	t.Parallel()
+	mc, path := newReloadTestConnector(t)
+	if err := os.WriteFile(path, []byte("network:\n  displayname_template: \"{{.Username}}\"\n  bot_prefix: bridge_\n  typing_timeout: 5\n  backfill_max_count: 100\n  channels:\n    allowlist: [town-square]\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	mc.HandleReloadConfig(rec, httptest.NewRequest(http.MethodPost, "/api/reload-config", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Changed []string `json:"changed"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !slices.Equal(resp.Changed, []string{"bot_prefix"}) {
+		t.Errorf("changed: got %v", resp.Changed)
+	}
+}
+
+func TestHandleReloadConfig_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		method string
+		config string
+		want   int
+	}{
+		{"method", http.MethodGet, reloadTestConfig, http.StatusMethodNotAllowed},
+		{"no path", http.MethodPost, "", http.StatusServiceUnavailable},
+		{"invalid", http.MethodPost, "network:\n  spoiler_style: blink\n", http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, path := newReloadTestConnector(t)
+			if tt.config == "" {
+				mc.ConfigPath = ""
+			} else if err := os.WriteFile(path, []byte(tt.config), 0o600); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			mc.HandleReloadConfig(rec, httptest.NewRequest(tt.method, "/api/reload-config", nil))
+			if rec.Code != tt.want {
+				t.Errorf("status: got %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
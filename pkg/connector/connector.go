@@ -7,14 +7,18 @@ package connector
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -30,6 +34,38 @@ type PuppetEntry struct {
 	Slug  string `json:"slug"`
 	MXID  string `json:"mxid"`
 	Token string `json:"token"`
+	// URL is the Mattermost server of the puppet's token. Empty uses
+	// MATTERMOST_PUPPET_<SLUG>_URL, or network.server_url.
+	URL string `json:"url,omitempty"`
+}
+
+// serverURL returns the Mattermost server the puppet's token belongs to.
+func (e PuppetEntry) serverURL(defaultURL string) string {
+	return strings.TrimRight(cmp.Or(e.URL, os.Getenv("MATTERMOST_PUPPET_"+e.Slug+"_URL"), defaultURL), "/")
+}
+
+// parseServerURL validates a Mattermost server URL: an http or https URL
+// with a host. It returns the URL without trailing slashes.
+func parseServerURL(raw string) (string, error) {
+	serverURL := strings.TrimRight(raw, "/")
+	parsed, err := url.Parse(serverURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid server URL %q", raw)
+	}
+	return serverURL, nil
+}
+
+// validatePuppetEntries checks the puppet entries of a reload request.
+func validatePuppetEntries(entries []PuppetEntry) error {
+	for _, entry := range entries {
+		if entry.URL == "" {
+			continue
+		}
+		if _, err := parseServerURL(entry.URL); err != nil {
+			return fmt.Errorf("puppet %q: %w", entry.Slug, err)
+		}
+	}
+	return nil
 }
 
 // PuppetClient holds a Mattermost API client for a specific Matrix user,
@@ -39,6 +75,22 @@ type PuppetClient struct {
 	Client   *model.Client4
 	UserID   string // Mattermost user/bot ID
 	Username string
+
+	// health is nil while the puppet's token works, and records the failure
+	// once Mattermost rejects it.
+	health atomic.Pointer[puppetHealth]
+	// lastSuccess is the time of the last Mattermost API call that
+	// succeeded with the puppet's token, in Unix milliseconds.
+	lastSuccess atomic.Int64
+
+	// profile is the Matrix profile last pushed to the bot. Guarded by
+	// profileMu.
+	profile   matrixProfile
+	profileMu sync.Mutex
+
+	// provisioned is true for puppets created by POST /api/provision-puppet.
+	// Puppet reloads keep them unless their entries replace them.
+	provisioned bool
 }
 
 // MattermostConnector implements bridgev2.NetworkConnector for Mattermost.
@@ -47,6 +99,9 @@ type MattermostConnector struct {
 	Config   Config
 	Puppets  map[id.UserID]*PuppetClient
 	puppetMu sync.RWMutex
+	// puppetIssues lists the puppet entries skipped by the last puppet load
+	// or reload. Guarded by puppetMu.
+	puppetIssues []PuppetIssue
 
 	// dpLogins maps Mattermost user IDs to UserLoginIDs for double puppet
 	// resolution. When an incoming MM event's sender matches a key in this
@@ -54,48 +109,163 @@ type MattermostConnector struct {
 	// so the bridgev2 framework uses that user's double puppet intent.
 	dpLogins   map[string]networkid.UserLoginID
 	dpLoginsMu sync.RWMutex
+
+	// pendingLinks holds the double puppet links waiting for their Matrix
+	// user to confirm, keyed by Matrix user. Used when
+	// double_puppet_confirmation is enabled.
+	pendingLinks   map[id.UserID]*pendingLink
+	pendingLinksMu sync.Mutex
+
+	// oauth2Logins holds the OAuth 2.0 logins waiting for their callback.
+	oauth2Logins   map[*OAuth2LoginProcess]struct{}
+	oauth2LoginsMu sync.Mutex
+
+	// shardLease is the lease on this process's channel shard. Nil when
+	// sharding is disabled.
+	shardLease *shardLease
+
+	// maxFileSize is the homeserver's media upload limit in bytes, as
+	// reported by the Matrix connector. 0 when unknown.
+	maxFileSize atomic.Int64
+
+	// primaryClient is the client of the first connected full login. It
+	// acts on other accounts: it pushes the Matrix presence of
+	// double-puppeted users that have no Mattermost login of their own, and
+	// updates puppet bot profiles.
+	primaryClient atomic.Pointer[model.Client4]
+
+	// eventQueue buffers remote events on their way to the bridge. Nil when
+	// event_queue.size is 0.
+	eventQueue *eventQueue
+
+	// outboundHold stores Matrix messages held while Mattermost is
+	// unreachable. Nil when outbound_hold is disabled.
+	outboundHold *outboundHold
+
+	// echoDrops keeps the most recent events dropped by echo prevention.
+	// Nil when echo_drop_log.size is 0.
+	echoDrops *echoDropLog
+
+	// ConfigPath is the path of the bridge config file, read again by
+	// POST /api/reload-config. Set by the main package; empty disables
+	// config reloads.
+	ConfigPath string
+	// reloadedConfig is the config with the settings applied by the last
+	// config reload, nil until one changes something. configReloadMu
+	// serializes reloads.
+	reloadedConfig atomic.Pointer[Config]
+	configReloadMu sync.Mutex
+
+	// channelFilterOverride is the channel filter set through the admin
+	// API, nil until one is. It replaces Config.Channels until the bridge
+	// restarts.
+	channelFilterOverride atomic.Pointer[ChannelFilterConfig]
+
+	// unbridgedChannels holds the channels unbridged through
+	// DELETE /api/portals/{channel_id}, which get no portal until they're
+	// provisioned again. Loaded from the database on first use and guarded
+	// by unbridgedMu.
+	unbridgedChannels map[string]struct{}
+	unbridgedMu       sync.Mutex
+
+	// coalescedPostTable is set once the table of the posts merged by
+	// coalesce_bot_posts exists.
+	coalescedPostTable atomic.Bool
+	// missedChannelTable is set once the table of the channels whose
+	// events were still queued at shutdown exists.
+	missedChannelTable atomic.Bool
+
+	// watchTrigger requests an immediate WatchNewPortals pass. Created on
+	// first use by portalWatchTrigger.
+	watchTrigger     chan struct{}
+	watchTriggerOnce sync.Once
+	// lastPortalWatch is the result of the last WatchNewPortals pass, nil
+	// until the first one.
+	lastPortalWatch atomic.Pointer[PortalWatchResult]
+	// relayWatched holds the portals missing their relay that already
+	// requested a pass, so their later events don't request more. Guarded by
+	// relayWatchedMu.
+	relayWatched   map[networkid.PortalKey]struct{}
+	relayWatchedMu sync.Mutex
+
+	// roomNames maps the names of channel portal rooms to the portal holding
+	// each, so duplicate names get a suffix. Loaded from the database on
+	// first use and guarded by roomNamesMu.
+	roomNames   map[string]roomNameClaim
+	roomNamesMu sync.Mutex
+
+	// savedPostsMu serializes the read-modify-write updates of the saved
+	// posts account data.
+	savedPostsMu sync.Mutex
+
+	// bridgeMetrics holds the counters reported on GET /metrics. Created on
+	// first use by metrics.
+	bridgeMetrics *bridgeMetrics
+	metricsOnce   sync.Once
+
+	// adminServer serves the admin API. Nil when it's disabled.
+	adminServer *http.Server
+	// cancelBackground stops the loops started by Start. background tracks
+	// the ones Stop waits for: the shard lease, which is released on the
+	// way out, and the event queue.
+	cancelBackground context.CancelFunc
+	background       sync.WaitGroup
+	// clients maps login IDs to the clients of loaded logins, so Stop can
+	// disconnect them. Guarded by clientsMu.
+	clients   map[networkid.UserLoginID]*MattermostClient
+	clientsMu sync.Mutex
+	stopOnce  sync.Once
 }
 
-var _ bridgev2.NetworkConnector = (*MattermostConnector)(nil)
+var (
+	_ bridgev2.NetworkConnector      = (*MattermostConnector)(nil)
+	_ bridgev2.MaxFileSizeingNetwork = (*MattermostConnector)(nil)
+)
 
 func (mc *MattermostConnector) Init(bridge *bridgev2.Bridge) {
 	mc.Bridge = bridge
+	mc.registerCommands()
 }
 
 func (mc *MattermostConnector) Start(ctx context.Context) error {
+	ctx, mc.cancelBackground = context.WithCancel(ctx)
 	if err := mc.Config.PostProcess(); err != nil {
 		return fmt.Errorf("failed to post-process config: %w", err)
 	}
 	mc.Puppets = make(map[id.UserID]*PuppetClient)
 	mc.dpLogins = make(map[string]networkid.UserLoginID)
+	if err := mc.startSharding(ctx); err != nil {
+		return err
+	}
+	mc.startEventQueue(ctx)
+	if err := mc.startOutboundHold(ctx); err != nil {
+		return err
+	}
+	mc.startEchoDropLog()
 	mc.loadPuppets(ctx)
+	mc.loadProvisionedPuppets(ctx)
+	mc.registerPresenceHandler()
+	mc.registerTypingHandler()
+	mc.registerRedactionHandler()
+	mc.startPuppetProfileSync(ctx)
 	go mc.autoLogin(ctx)
 
 	// Start continuous portal watcher for relay setup on new rooms.
-	go mc.WatchNewPortals(ctx, 0)
+	go mc.WatchNewPortals(ctx, mc.Config.PortalWatcher.interval())
 
 	// Start admin HTTP API for puppet hot-reload.
-	apiAddr := mc.Config.AdminAPIAddr
-	if apiAddr == "" {
-		apiAddr = os.Getenv("BRIDGE_API_ADDR")
-	}
-	if apiAddr == "" {
-		apiAddr = ":29320"
-	}
+	apiAddr := mc.adminAPIAddr()
 	if apiAddr != "" {
-		mux := http.NewServeMux()
-		mux.HandleFunc("/api/reload-puppets", mc.HandleReloadPuppets)
-		mux.HandleFunc("/api/double-puppet", mc.HandleDoublePuppet)
-		server := &http.Server{
+		mc.adminServer = &http.Server{
 			Addr:         apiAddr,
-			Handler:      mux,
+			Handler:      mc.adminAPIHandler(),
 			ReadTimeout:  10 * time.Second,
 			WriteTimeout: 10 * time.Second,
 			IdleTimeout:  60 * time.Second,
 		}
 		go func() {
 			mc.Bridge.Log.Info().Str("addr", apiAddr).Msg("Starting bridge admin API")
-			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			if err := mc.adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				mc.Bridge.Log.Error().Err(err).Msg("Bridge admin API error")
 			}
 		}()
@@ -138,20 +308,30 @@ func (mc *MattermostConnector) loadPuppets(ctx context.Context) {
 		}
 	}
 
+	var entries []PuppetEntry
 	for _, name := range puppetNames {
 		mxid := os.Getenv("MATTERMOST_PUPPET_" + name + "_MXID")
 		token := os.Getenv("MATTERMOST_PUPPET_" + name + "_TOKEN")
 		if mxid == "" || token == "" {
 			continue
 		}
+		entries = append(entries, PuppetEntry{Slug: name, MXID: mxid, Token: token, URL: os.Getenv("MATTERMOST_PUPPET_" + name + "_URL")})
+	}
+	entries, issues := mc.checkPuppetEntries(entries)
+	for _, issue := range issues {
+		mc.Bridge.Log.Error().
+			Str("puppet", issue.Slug).
+			Str("mxid", issue.MXID).
+			Str("reason", issue.Reason).
+			Str("detail", issue.Detail).
+			Msg("Skipping misconfigured puppet")
+	}
 
-		serverURL := os.Getenv("MATTERMOST_PUPPET_" + name + "_URL")
-		if serverURL == "" {
-			serverURL = mc.Config.ServerURL
-		}
-
-		client := model.NewAPIv4Client(serverURL)
-		client.SetToken(token)
+	var loaded []*PuppetClient
+	for _, entry := range entries {
+		name, mxid := entry.Slug, entry.MXID
+		client := mc.newAPIClient(entry.serverURL(mc.Config.ServerURL))
+		client.SetToken(entry.Token)
 
 		me, _, err := client.GetMe(ctx, "")
 		if err != nil {
@@ -159,6 +339,7 @@ func (mc *MattermostConnector) loadPuppets(ctx context.Context) {
 				Str("puppet", name).
 				Str("mxid", mxid).
 				Msg("Failed to verify puppet token")
+			issues = append(issues, PuppetIssue{Slug: name, MXID: mxid, Reason: PuppetReasonAuthFailed, Detail: "Mattermost rejected the token"})
 			continue
 		}
 
@@ -168,7 +349,10 @@ func (mc *MattermostConnector) loadPuppets(ctx context.Context) {
 			UserID:   me.Id,
 			Username: me.Username,
 		}
+		puppet.markSuccess()
+		mc.watchPuppetToken(puppet)
 		mc.Puppets[puppet.MXID] = puppet
+		loaded = append(loaded, puppet)
 		mc.Bridge.Log.Info().
 			Str("puppet", name).
 			Str("mxid", mxid).
@@ -178,13 +362,12 @@ func (mc *MattermostConnector) loadPuppets(ctx context.Context) {
 
 		// Also set up double puppeting so MM→Matrix events from this user
 		// appear under their real Matrix MXID instead of a ghost.
-		if err := mc.setupUserDoublePuppet(ctx, me.Id, mxid); err != nil {
-			mc.Bridge.Log.Warn().Err(err).
-				Str("puppet", name).
-				Str("mxid", mxid).
-				Msg("Failed to setup double puppet for puppet user")
-		}
+		mc.setupPuppetDoublePuppet(ctx, puppet, name, false)
 	}
+	mc.puppetMu.Lock()
+	mc.puppetIssues = issues
+	mc.puppetMu.Unlock()
+	mc.syncLoadedPuppetProfiles(ctx, loaded...)
 }
 
 // findSuffix returns the index where suffix starts in s, or -1 if not found.
@@ -225,7 +408,7 @@ func (mc *MattermostConnector) autoLogin(ctx context.Context) {
 
 	mc.Bridge.Log.Info().Str("server_url", serverURL).Msg("Performing auto-login")
 
-	client := model.NewAPIv4Client(serverURL)
+	client := mc.newAPIClient(serverURL)
 	client.SetToken(token)
 
 	me, _, err := client.GetMe(ctx, "")
@@ -304,7 +487,8 @@ func (mc *MattermostConnector) autoLogin(ctx context.Context) {
 	go mc.autoSetRelay(ctx, ul)
 }
 
-// autoSetRelay sets the auto-login user as the relay for all bridged rooms.
+// autoSetRelay sets the auto-login user as the relay for the bridged rooms
+// the relay config allows.
 // Runs with retries because portals are created asynchronously during
 // Mattermost channel sync after the WebSocket connects.
 func (mc *MattermostConnector) autoSetRelay(ctx context.Context, login *bridgev2.UserLogin) {
@@ -320,7 +504,7 @@ func (mc *MattermostConnector) autoSetRelay(ctx context.Context, login *bridgev2
 
 		setCount := 0
 		for _, portal := range portals {
-			if portal.Relay == nil {
+			if portal.Relay == nil && mc.relayAllowed(portal) {
 				if err := portal.SetRelay(ctx, login); err != nil {
 					mc.Bridge.Log.Warn().Err(err).
 						Str("portal_mxid", string(portal.MXID)).
@@ -363,6 +547,10 @@ func (mc *MattermostConnector) setupDoublePuppet(ctx context.Context, user *brid
 		mc.Bridge.Log.Error().Err(err).Str("mxid", string(mxid)).Msg("Double puppet: failed to parse MXID")
 		return
 	}
+	if mc.isRemoteMXID(mxid) {
+		mc.Bridge.Log.Warn().Str("mxid", string(mxid)).Msg("Double puppet: user is on a remote homeserver, skipping password login")
+		return
+	}
 
 	// Get Synapse URL from the double_puppet.servers config or fall back to env var.
 	synapseURL := os.Getenv("SYNAPSE_URL")
@@ -457,13 +645,19 @@ const useConfigASToken = "appservice-config"
 // route incoming MM events through the real Matrix user's double puppet intent.
 //
 // If a UserLogin already exists for the MM user (e.g. the auto-login user),
-// it only registers the dpLogins mapping without creating a duplicate.
+// it only registers the dpLogins mapping without creating a duplicate. Users
+// on a remote homeserver without an as_token secret in double_puppet.secrets
+// get errNoRemoteDoublePuppet.
 func (mc *MattermostConnector) setupUserDoublePuppet(ctx context.Context, mmUserID, matrixMXID string) error {
+	mxid := id.UserID(matrixMXID)
+	if err := mc.checkRemoteDoublePuppet(mxid); err != nil {
+		return err
+	}
 	if mc.Bridge == nil || mc.Bridge.DB == nil {
 		return fmt.Errorf("bridge not fully initialized")
 	}
 
-	mxid := id.UserID(matrixMXID)
+	log := mc.ctxLog(ctx)
 	loginID := MakeUserLoginID(mmUserID)
 
 	// Check if a full login already exists for this MM user (e.g. auto-login).
@@ -479,11 +673,11 @@ func (mc *MattermostConnector) setupUserDoublePuppet(ctx context.Context, mmUser
 			return fmt.Errorf("get user for existing login: %w", err)
 		}
 		if err := user.LoginDoublePuppet(ctx, useConfigASToken); err != nil {
-			mc.Bridge.Log.Warn().Err(err).
+			log.Warn().Err(err).
 				Str("mxid", matrixMXID).
 				Msg("Double puppet: as_token setup failed for existing login, skipping")
 		} else {
-			mc.Bridge.Log.Info().
+			log.Info().
 				Str("mm_user_id", mmUserID).
 				Str("matrix_mxid", matrixMXID).
 				Msg("Double puppet: enabled for existing login")
@@ -529,7 +723,7 @@ func (mc *MattermostConnector) setupUserDoublePuppet(ctx context.Context, mmUser
 	mc.dpLogins[mmUserID] = loginID
 	mc.dpLoginsMu.Unlock()
 
-	mc.Bridge.Log.Info().
+	log.Info().
 		Str("mm_user_id", mmUserID).
 		Str("matrix_mxid", matrixMXID).
 		Msg("Double puppet: enabled for user")
@@ -546,18 +740,34 @@ func (mc *MattermostConnector) DoublePuppetLoginID(mmUserID string) (networkid.U
 	return id, ok
 }
 
+// doublePuppetLogin returns the Mattermost user double-puppeted by a Matrix
+// user and the login doing it, or an empty ID if there is none.
+func (mc *MattermostConnector) doublePuppetLogin(mxid id.UserID) (string, *bridgev2.UserLogin) {
+	mc.dpLoginsMu.RLock()
+	defer mc.dpLoginsMu.RUnlock()
+	for mmUserID, loginID := range mc.dpLogins {
+		login := mc.Bridge.GetCachedUserLoginByID(loginID)
+		if login != nil && login.UserMXID == mxid {
+			return mmUserID, login
+		}
+	}
+	return "", nil
+}
+
 // maxDoublePuppetBodySize is the maximum allowed request body for double puppet registration (64 KB).
 const maxDoublePuppetBodySize = 64 << 10
 
 // HandleDoublePuppet is an HTTP handler for POST /api/double-puppet.
 // It registers a MM user → Matrix MXID mapping for double puppeting without
-// requiring a Mattermost API token.
+// requiring a Mattermost API token. With double_puppet_confirmation, the
+// mapping is only registered once the Matrix user confirms it.
 func (mc *MattermostConnector) HandleDoublePuppet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	log := mc.ctxLog(r.Context())
 	r.Body = http.MaxBytesReader(w, r.Body, maxDoublePuppetBodySize)
 	defer func() { _ = r.Body.Close() }()
 
@@ -574,19 +784,27 @@ func (mc *MattermostConnector) HandleDoublePuppet(w http.ResponseWriter, r *http
 		return
 	}
 
-	mc.Bridge.Log.Info().
+	log.Info().
 		Str("remote_addr", r.RemoteAddr).
 		Str("mm_user_id", req.MMUserID).
 		Str("matrix_mxid", req.MatrixMXID).
 		Msg("Double puppet registration requested")
 
 	ctx := r.Context()
+	if mc.Config.DoublePuppetConfirmation.Enabled {
+		mc.requestDoublePuppetConfirmation(w, r, req.MMUserID, req.MatrixMXID)
+		return
+	}
 	if err := mc.setupUserDoublePuppet(ctx, req.MMUserID, req.MatrixMXID); err != nil {
-		mc.Bridge.Log.Error().Err(err).
+		log.Error().Err(err).
 			Str("mm_user_id", req.MMUserID).
 			Str("matrix_mxid", req.MatrixMXID).
 			Msg("Double puppet registration failed")
-		http.Error(w, fmt.Sprintf("failed to setup double puppet: %v", err), http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		if errors.Is(err, errNoRemoteDoublePuppet) {
+			status = http.StatusUnprocessableEntity
+		}
+		http.Error(w, fmt.Sprintf("failed to setup double puppet: %v", err), status)
 		return
 	}
 
@@ -598,9 +816,49 @@ func (mc *MattermostConnector) HandleDoublePuppet(w http.ResponseWriter, r *http
 	})
 }
 
+// requestDoublePuppetConfirmation answers POST /api/double-puppet when
+// confirmation is required: it sends the Matrix user a code and responds
+// 202 Accepted with the code's expiry.
+func (mc *MattermostConnector) requestDoublePuppetConfirmation(w http.ResponseWriter, r *http.Request, mmUserID, matrixMXID string) {
+	log := mc.ctxLog(r.Context())
+	mxid := id.UserID(matrixMXID)
+	if _, _, err := mxid.Parse(); err != nil {
+		http.Error(w, "matrix_mxid must be a valid Matrix user ID", http.StatusBadRequest)
+		return
+	}
+	if !model.IsValidId(mmUserID) {
+		http.Error(w, "mm_user_id must be a valid Mattermost user ID", http.StatusBadRequest)
+		return
+	}
+	expires, err := mc.requestLinkConfirmation(r.Context(), mmUserID, mxid)
+	if err != nil {
+		log.Error().Err(err).
+			Str("mm_user_id", mmUserID).
+			Str("matrix_mxid", matrixMXID).
+			Msg("Failed to send double puppet confirmation")
+		http.Error(w, fmt.Sprintf("failed to send confirmation: %v", err), http.StatusBadGateway)
+		return
+	}
+	log.Info().
+		Str("mm_user_id", mmUserID).
+		Str("matrix_mxid", matrixMXID).
+		Time("expires_at", expires).
+		Msg("Double puppet confirmation sent")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"status":      "pending",
+		"mm_user_id":  mmUserID,
+		"matrix_mxid": matrixMXID,
+		"expires_at":  expires.UTC().Format(time.RFC3339),
+	})
+}
+
 func (mc *MattermostConnector) LoadUserLogin(_ context.Context, login *bridgev2.UserLogin) error {
 	mmClient := NewMattermostClient(login, mc)
 	login.Client = mmClient
+	mc.trackClient(mmClient)
 	return nil
 }
 
@@ -617,9 +875,15 @@ func (mc *MattermostConnector) GetName() bridgev2.BridgeName {
 
 func (mc *MattermostConnector) GetDBMetaTypes() database.MetaTypes {
 	return database.MetaTypes{
+		Portal: func() any {
+			return &PortalMetadata{}
+		},
 		UserLogin: func() any {
 			return &UserLoginMetadata{}
 		},
+		Message: func() any {
+			return &MessageMetadata{}
+		},
 	}
 }
 
@@ -646,6 +910,87 @@ type UserLoginMetadata struct {
 	// bridgev2 framework can match incoming MM events to a real Matrix user
 	// and send them via that user's double puppet intent.
 	DoublePuppetOnly bool `json:"double_puppet_only,omitempty"`
+
+	// RefreshToken and TokenExpiresAt (Unix milliseconds) are set for OAuth
+	// 2.0 logins, whose access token is refreshed before it expires.
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	TokenExpiresAt int64  `json:"token_expires_at,omitempty"`
+}
+
+// PortalMetadata stores per-portal settings adjustable via bot commands.
+type PortalMetadata struct {
+	// Timezone overrides the configured timezone for rendered timestamps.
+	Timezone string `json:"timezone,omitempty"`
+	// Locale selects the time layout for rendered timestamps, overriding
+	// the configured time_format.
+	Locale string `json:"locale,omitempty"`
+	// WelcomePending is set while the portal room is being created and
+	// cleared once the welcome notice has been sent.
+	WelcomePending bool `json:"welcome_pending,omitempty"`
+	// Header and Purpose are the channel header and purpose last sent in
+	// the channel info state event.
+	Header  string `json:"header,omitempty"`
+	Purpose string `json:"purpose,omitempty"`
+	// TeamID is the Mattermost team the channel belongs to, empty for DMs.
+	TeamID string `json:"team_id,omitempty"`
+	// RelayDisabled keeps the auto-login user from being set as relay,
+	// after an operator cleared the relay through the admin API.
+	RelayDisabled bool `json:"relay_disabled,omitempty"`
+	// AutoInvited lists the auto_invite users, and the puppet users of DMs,
+	// invited to the portal room, so users who leave aren't invited again.
+	AutoInvited []id.UserID `json:"auto_invited,omitempty"`
+	// Alias is the room alias last set from room_alias_template.
+	Alias id.RoomAlias `json:"alias,omitempty"`
+	// Archived is set while the channel is archived in Mattermost and the
+	// portal room is read-only.
+	Archived bool `json:"archived,omitempty"`
+	// ActiveCall is the ID of the Calls plugin call going on in the
+	// channel, and ActiveCallStart its start in Unix milliseconds.
+	ActiveCall      string `json:"active_call,omitempty"`
+	ActiveCallStart int64  `json:"active_call_start,omitempty"`
+	// PinnedEvents are the pinned events last set from the channel's
+	// pinned posts by /api/resync.
+	PinnedEvents []id.EventID `json:"pinned_events,omitempty"`
+	// WebhookID is the incoming webhook the bridge created in the channel
+	// for relay_webhooks.
+	WebhookID string `json:"webhook_id,omitempty"`
+	// ThreadAnnounced is set on a thread room's portal once the room was
+	// linked from the thread in its channel's room.
+	ThreadAnnounced bool `json:"thread_announced,omitempty"`
+}
+
+// MessageMetadata stores Mattermost-specific data of a message part.
+type MessageMetadata struct {
+	// FileID is the Mattermost file bridged as this part, empty for the
+	// post's text.
+	FileID string `json:"file_id,omitempty"`
+	// PollAnswers maps the answer IDs of a poll sent from Matrix, which is
+	// posted as text, to the answers.
+	PollAnswers map[string]string `json:"poll_answers,omitempty"`
+	// RequestedAck is set on the parts of posts requesting an
+	// acknowledgement, which Matrix reactions give.
+	RequestedAck bool `json:"requested_ack,omitempty"`
+	// Webhook is set on posts made through a channel's incoming webhook
+	// for a relayed Matrix user.
+	Webhook bool `json:"webhook,omitempty"`
+	// EditAt is the Mattermost edit time of the post's text as last
+	// bridged, set on the text part of edited posts.
+	EditAt int64 `json:"edit_at,omitempty"`
+	// ContinuationPosts are the posts a Matrix message too long for one
+	// post continued in, after this one.
+	ContinuationPosts []string `json:"continuation_posts,omitempty"`
+	// CoalescedPosts are the bot posts merged into this message after the
+	// first one, set on its text part.
+	CoalescedPosts []string `json:"coalesced_posts,omitempty"`
+}
+
+// messageMetadata returns the metadata of a message part, or empty metadata
+// for parts bridged without it.
+func messageMetadata(msg *database.Message) *MessageMetadata {
+	if meta, ok := msg.Metadata.(*MessageMetadata); ok && meta != nil {
+		return meta
+	}
+	return &MessageMetadata{}
 }
 
 // MakeUserLoginID creates a UserLoginID from a Mattermost user ID.
@@ -709,7 +1054,7 @@ func (mc *MattermostConnector) envToPuppetEntries() []PuppetEntry {
 		mxidVal := os.Getenv(prefix + slug + mxidSuffix)
 		tokenVal := os.Getenv(prefix + slug + tokenSuffix)
 		if mxidVal != "" && tokenVal != "" {
-			entries = append(entries, PuppetEntry{Slug: slug, MXID: mxidVal, Token: tokenVal})
+			entries = append(entries, PuppetEntry{Slug: slug, MXID: mxidVal, Token: tokenVal, URL: os.Getenv(prefix + slug + "_URL")})
 		}
 	}
 	return entries
@@ -717,8 +1062,27 @@ func (mc *MattermostConnector) envToPuppetEntries() []PuppetEntry {
 
 // ReloadPuppetsFromEntries updates the puppet map from an explicit list of
 // entries. This is the core reload logic used by both env-based reload and
-// the HTTP API endpoint. Thread-safe.
+// the HTTP API endpoint. Entries with invalid, ghost or repeated MXIDs and
+// entries whose token is rejected are skipped and reported by PuppetIssues.
+// Thread-safe.
 func (mc *MattermostConnector) ReloadPuppetsFromEntries(ctx context.Context, entries []PuppetEntry) (added, removed int) {
+	return mc.reloadPuppetsFromEntries(ctx, entries, false)
+}
+
+// reloadPuppetsFromEntries is ReloadPuppetsFromEntries. With confirm, the
+// entries come from an API request rather than the bridge's environment,
+// and their double puppets wait for double_puppet_confirmation.
+func (mc *MattermostConnector) reloadPuppetsFromEntries(ctx context.Context, entries []PuppetEntry, confirm bool) (added, removed int) {
+	log := mc.ctxLog(ctx)
+	entries, issues := mc.checkPuppetEntries(entries)
+	for _, issue := range issues {
+		log.Error().
+			Str("slug", issue.Slug).
+			Str("mxid", issue.MXID).
+			Str("reason", issue.Reason).
+			Str("detail", issue.Detail).
+			Msg("Skipping misconfigured puppet")
+	}
 	// Build desired set from entries.
 	desired := make(map[id.UserID]PuppetEntry, len(entries))
 	for _, e := range entries {
@@ -728,10 +1092,11 @@ func (mc *MattermostConnector) ReloadPuppetsFromEntries(ctx context.Context, ent
 	mc.puppetMu.Lock()
 	defer mc.puppetMu.Unlock()
 
-	// Remove puppets that are no longer in the desired set.
+	// Remove puppets that are no longer in the desired set. Provisioned
+	// puppets aren't part of it.
 	for uid, puppet := range mc.Puppets {
-		if _, ok := desired[uid]; !ok {
-			mc.Bridge.Log.Info().Str("mxid", string(uid)).Msg("Removing puppet")
+		if _, ok := desired[uid]; !ok && !puppet.provisioned {
+			log.Info().Str("mxid", string(uid)).Msg("Removing puppet")
 			// Remove double puppet mapping for this puppet's MM user.
 			mc.dpLoginsMu.Lock()
 			delete(mc.dpLogins, puppet.UserID)
@@ -742,27 +1107,44 @@ func (mc *MattermostConnector) ReloadPuppetsFromEntries(ctx context.Context, ent
 	}
 
 	// Add or update puppets.
+	var loaded []*PuppetClient
 	for uid, entry := range desired {
+		serverURL := entry.serverURL(mc.Config.ServerURL)
 		existing, ok := mc.Puppets[uid]
-		if ok && existing.Client != nil && existing.Client.AuthToken == entry.Token {
-			// Unchanged -- keep as-is.
+		if ok && existing.Client != nil && existing.Client.AuthToken == entry.Token && strings.TrimRight(existing.Client.URL, "/") == serverURL {
+			if existing.Healthy() {
+				// Unchanged -- keep as-is.
+				continue
+			}
+			// Unhealthy puppets are re-verified, so a re-enabled bot
+			// recovers without changing its token.
+			if _, _, err := existing.Client.GetMe(ctx, ""); err != nil {
+				log.Warn().Err(err).
+					Str("slug", entry.Slug).
+					Str("mxid", entry.MXID).
+					Msg("Puppet is still unhealthy")
+				continue
+			}
+			existing.markHealthy()
+			existing.markSuccess()
+			log.Info().
+				Str("slug", entry.Slug).
+				Str("mxid", entry.MXID).
+				Msg("Puppet recovered")
 			continue
 		}
 
-		serverURL := os.Getenv("MATTERMOST_PUPPET_" + entry.Slug + "_URL")
-		if serverURL == "" {
-			serverURL = mc.Config.ServerURL
-		}
-
-		client := model.NewAPIv4Client(serverURL)
+		client := mc.newAPIClient(serverURL)
 		client.SetToken(entry.Token)
 
 		me, _, err := client.GetMe(ctx, "")
 		if err != nil {
-			mc.Bridge.Log.Error().Err(err).
+			log.Error().Err(err).
 				Str("slug", entry.Slug).
 				Str("mxid", entry.MXID).
+				Str("server_url", serverURL).
 				Msg("Failed to authenticate puppet during reload, skipping")
+			issues = append(issues, PuppetIssue{Slug: entry.Slug, MXID: entry.MXID, Reason: PuppetReasonAuthFailed, Detail: "Mattermost rejected the token"})
 			continue
 		}
 
@@ -772,30 +1154,33 @@ func (mc *MattermostConnector) ReloadPuppetsFromEntries(ctx context.Context, ent
 			UserID:   me.Id,
 			Username: me.Username,
 		}
+		puppet.markSuccess()
+		mc.watchPuppetToken(puppet)
 		mc.Puppets[uid] = puppet
+		loaded = append(loaded, puppet)
 		added++
 
-		mc.Bridge.Log.Info().
+		log.Info().
 			Str("slug", entry.Slug).
 			Str("mxid", entry.MXID).
 			Str("mm_user_id", me.Id).
 			Str("mm_username", me.Username).
+			Str("server_url", serverURL).
 			Msg("Hot-loaded puppet")
 
 		// Set up double puppeting for the new/updated puppet.
-		if err := mc.setupUserDoublePuppet(ctx, me.Id, entry.MXID); err != nil {
-			mc.Bridge.Log.Warn().Err(err).
-				Str("slug", entry.Slug).
-				Str("mxid", entry.MXID).
-				Msg("Failed to setup double puppet during reload")
-		}
+		mc.setupPuppetDoublePuppet(ctx, puppet, entry.Slug, confirm)
 	}
 
-	mc.Bridge.Log.Info().
+	mc.puppetIssues = issues
+
+	log.Info().
 		Int("added", added).
 		Int("removed", removed).
 		Int("total", len(mc.Puppets)).
+		Int("misconfigured", len(issues)).
 		Msg("Puppet reload complete")
+	mc.syncLoadedPuppetProfiles(ctx, loaded...)
 
 	return added, removed
 }
@@ -819,7 +1204,8 @@ func (mc *MattermostConnector) HandleReloadPuppets(w http.ResponseWriter, r *htt
 		return
 	}
 
-	mc.Bridge.Log.Info().
+	log := mc.ctxLog(r.Context())
+	log.Info().
 		Str("remote_addr", r.RemoteAddr).
 		Str("content_length", r.Header.Get("Content-Length")).
 		Msg("Puppet reload requested")
@@ -842,10 +1228,18 @@ func (mc *MattermostConnector) HandleReloadPuppets(w http.ResponseWriter, r *htt
 				http.Error(w, "invalid JSON", http.StatusBadRequest)
 				return
 			}
+			if err := validatePuppetEntries(entries); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if _, issues := mc.checkPuppetEntries(entries); len(issues) > 0 {
+				http.Error(w, fmt.Sprintf("puppet %q: %s", issues[0].Slug, issues[0].Detail), http.StatusBadRequest)
+				return
+			}
 		}
 	}
 
-	mc.Bridge.Log.Info().
+	log.Info().
 		Str("remote_addr", r.RemoteAddr).
 		Int("entries", len(entries)).
 		Str("source", func() string {
@@ -857,90 +1251,95 @@ func (mc *MattermostConnector) HandleReloadPuppets(w http.ResponseWriter, r *htt
 		Msg("Processing puppet reload")
 
 	if len(entries) > 0 {
-		added, removed = mc.ReloadPuppetsFromEntries(ctx, entries)
+		added, removed = mc.reloadPuppetsFromEntries(ctx, entries, true)
 	} else {
 		added, removed = mc.ReloadPuppets(ctx)
 	}
 
 	resp := map[string]int{
-		"added":   added,
-		"removed": removed,
-		"total":   mc.PuppetCount(),
+		"added":         added,
+		"removed":       removed,
+		"total":         mc.PuppetCount(),
+		"misconfigured": len(mc.PuppetIssues()),
 	}
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		mc.Bridge.Log.Warn().Err(err).Msg("Failed to write reload response")
+		log.Warn().Err(err).Msg("Failed to write reload response")
 	}
 }
 
 // WatchNewPortals periodically checks for new portal rooms that don't have
 // relay set, and sets the relay user on them. This replaces the fixed 3-attempt
 // boot cycle with continuous monitoring for rooms created after startup (e.g.,
-// when a new PL agent is provisioned).
+// when a new PL agent is provisioned). Other subsystems can request an
+// immediate pass with TriggerPortalWatch.
 //
 // The interval parameter controls how often the check runs. Pass 0 to use
-// the default of 60 seconds.
+// the default of 60 seconds, or a negative interval to only run triggered
+// passes.
 func (mc *MattermostConnector) WatchNewPortals(ctx context.Context, interval time.Duration) {
-	if interval <= 0 {
-		interval = 60 * time.Second
+	if interval == 0 {
+		interval = defaultPortalWatchInterval
 	}
 
 	mc.Bridge.Log.Info().
 		Dur("interval", interval).
 		Msg("Starting WatchNewPortals loop")
 
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	trigger := mc.portalWatchTrigger()
 
 	for {
 		select {
 		case <-ctx.Done():
 			mc.Bridge.Log.Info().Msg("WatchNewPortals stopped")
 			return
-		case <-ticker.C:
-			mc.checkAndSetRelay(ctx)
+		case <-tick:
+			mc.runPortalWatch(ctx, portalWatchInterval)
+		case <-trigger:
+			mc.runPortalWatch(ctx, portalWatchTriggered)
 		}
 	}
 }
 
-// checkAndSetRelay scans portal rooms and sets relay on any that lack it.
-func (mc *MattermostConnector) checkAndSetRelay(ctx context.Context) {
+// checkAndSetRelay scans portal rooms and sets relay on any that lack it and
+// are allowed one by the relay config. It returns the number of portal rooms
+// and the number of relays set.
+func (mc *MattermostConnector) checkAndSetRelay(ctx context.Context) (int, int, error) {
 	if mc.Bridge == nil || mc.Bridge.DB == nil {
-		return
+		return 0, 0, nil
 	}
 	portals, err := mc.Bridge.GetAllPortalsWithMXID(ctx)
 	if err != nil {
 		mc.Bridge.Log.Error().Err(err).Msg("WatchNewPortals: failed to get portals")
-		return
+		return 0, 0, fmt.Errorf("failed to get portals: %w", err)
 	}
 
 	// Find the auto-login user to use as relay.
-	loginUsers, err := mc.Bridge.DB.UserLogin.GetAllUserIDsWithLogins(ctx)
-	if err != nil || len(loginUsers) == 0 {
-		return
+	login, err := mc.relayLogin(ctx)
+	if err != nil {
+		return len(portals), 0, err
+	}
+	if login == nil {
+		return len(portals), 0, nil
 	}
 
 	setCount := 0
 	for _, portal := range portals {
-		if portal.Relay == nil {
-			// Get any available login to use as relay.
-			for _, userID := range loginUsers {
-				user, err := mc.Bridge.GetUserByMXID(ctx, userID)
-				if err != nil {
-					continue
-				}
-				logins := user.GetUserLogins()
-				if len(logins) > 0 {
-					if err := portal.SetRelay(ctx, logins[0]); err != nil {
-						mc.Bridge.Log.Warn().Err(err).
-							Str("portal_mxid", string(portal.MXID)).
-							Msg("WatchNewPortals: failed to set relay")
-					} else {
-						setCount++
-					}
-					break
-				}
-			}
+		if portal.Relay != nil || !mc.relayAllowed(portal) {
+			continue
+		}
+		if err := portal.SetRelay(ctx, login); err != nil {
+			mc.Bridge.Log.Warn().Err(err).
+				Str("portal_mxid", string(portal.MXID)).
+				Msg("WatchNewPortals: failed to set relay")
+		} else {
+			setCount++
 		}
 	}
 
@@ -950,4 +1349,5 @@ func (mc *MattermostConnector) checkAndSetRelay(ctx context.Context) {
 			Int("total_portals", len(portals)).
 			Msg("WatchNewPortals: set relay on new portals")
 	}
+	return len(portals), setCount, nil
 }
@@ -79,6 +79,13 @@ func TestGetDBMetaTypes(t *testing.T) {
 	if _, ok := instance.(*UserLoginMetadata); !ok {
 		t.Errorf("UserLogin factory returned %T, want *UserLoginMetadata", instance)
 	}
+
+	if meta.Portal == nil {
+		t.Fatal("Portal meta factory should not be nil")
+	}
+	if _, ok := meta.Portal().(*PortalMetadata); !ok {
+		t.Errorf("Portal factory returned %T, want *PortalMetadata", meta.Portal())
+	}
 }
 
 // TestGetConfigBeforeInit ensures GetConfig returns an addressable config
@@ -0,0 +1,143 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/id"
+)
+
+// postIDContentKey is the content key of Matrix events bridged from a
+// Mattermost post that holds the post ID. Posts bridged from Matrix carry
+// the event ID in postPropMatrixEventID in return.
+const postIDContentKey = "fi.mau.mattermost.post_id"
+
+// addPostIDToParts records the ID of the post parts were converted from in
+// their content.
+func addPostIDToParts(parts []*bridgev2.ConvertedMessagePart, postID string) {
+	if postID == "" {
+		return
+	}
+	for _, part := range parts {
+		if part.Extra == nil {
+			part.Extra = make(map[string]any)
+		}
+		part.Extra[postIDContentKey] = postID
+	}
+}
+
+// MessageMapping links a Mattermost post to the Matrix events it was bridged
+// as, or bridged from.
+type MessageMapping struct {
+	PostID    string        `json:"post_id"`
+	ChannelID string        `json:"channel_id"`
+	RoomID    id.RoomID     `json:"room_id,omitempty"`
+	Parts     []MappingPart `json:"parts"`
+}
+
+// MappingPart is one Matrix event of a MessageMapping. A post with files is
+// bridged as one event per file besides its text.
+type MappingPart struct {
+	PartID  string     `json:"part_id"`
+	EventID id.EventID `json:"event_id"`
+}
+
+// lookupMessageMapping finds the bridged message of a post, or of the post
+// a Matrix event belongs to. Exactly one of postID and eventID must be set.
+// It returns nil if the message isn't bridged.
+func (mc *MattermostConnector) lookupMessageMapping(ctx context.Context, postID string, eventID id.EventID) (*MessageMapping, error) {
+	if eventID != "" {
+		part, err := mc.Bridge.DB.Message.GetPartByMXID(ctx, eventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get message by event ID: %w", err)
+		} else if part == nil {
+			return nil, nil
+		}
+		postID = ParseMessageID(part.ID)
+	}
+	parts, err := mc.Bridge.DB.Message.GetAllPartsByID(ctx, "", MakeMessageID(postID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message parts: %w", err)
+	} else if len(parts) == 0 {
+		return nil, nil
+	}
+
+	mapping := &MessageMapping{
+		PostID:    postID,
+		ChannelID: ParsePortalID(parts[0].Room.ID),
+		Parts:     make([]MappingPart, 0, len(parts)),
+	}
+	portal, err := mc.Bridge.DB.Portal.GetByKey(ctx, parts[0].Room)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portal: %w", err)
+	} else if portal != nil {
+		mapping.RoomID = portal.MXID
+	}
+	for _, part := range parts {
+		mapping.Parts = append(mapping.Parts, MappingPart{PartID: string(part.PartID), EventID: part.MXID})
+	}
+	return mapping, nil
+}
+
+// HandleMessageMap is an HTTP handler for GET /api/map. It takes either a
+// post_id or an event_id query parameter and responds with the post and the
+// Matrix events it is bridged as, so operators can trace a message from one
+// side to the other.
+func (mc *MattermostConnector) HandleMessageMap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	query := r.URL.Query()
+	postID := query.Get("post_id")
+	eventID := id.EventID(query.Get("event_id"))
+	switch {
+	case (postID == "") == (eventID == ""):
+		http.Error(w, "exactly one of post_id and event_id is required", http.StatusBadRequest)
+		return
+	case postID != "" && !model.IsValidId(postID):
+		http.Error(w, "invalid post ID", http.StatusBadRequest)
+		return
+	case eventID != "" && !strings.HasPrefix(string(eventID), "$"):
+		http.Error(w, "invalid event ID", http.StatusBadRequest)
+		return
+	}
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		http.Error(w, "bridge not fully initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	mapping, err := mc.lookupMessageMapping(r.Context(), postID, eventID)
+	if err != nil {
+		log.Error().Err(err).Msg("Message mapping lookup failed")
+		http.Error(w, "lookup failed", http.StatusInternalServerError)
+		return
+	}
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("post_id", postID).
+		Stringer("event_id", eventID).
+		Bool("found", mapping != nil).
+		Msg("Message mapping requested")
+	if mapping == nil {
+		http.Error(w, "message not bridged", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(mapping); err != nil {
+		log.Warn().Err(err).Msg("Failed to write message mapping response")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/id"
+)
+
+// mapTestPostID is a post ID in the format Mattermost validates.
+const mapTestPostID = "abcdefghijklmnopqrstuvwxyz"
+
+func TestConvertPostToMatrix_PostID(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+
+	for name, post := range map[string]*model.Post{
+		"text":     {Id: "p1", Message: "hello"},
+		"reminder": {Id: "p1", Type: model.PostTypeReminder},
+	} {
+		msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
+		if len(msg.Parts) == 0 {
+			t.Fatalf("%s: expected parts", name)
+		}
+		for _, part := range msg.Parts {
+			if part.Extra[postIDContentKey] != "p1" {
+				t.Errorf("%s: %s = %v", name, postIDContentKey, part.Extra[postIDContentKey])
+			}
+		}
+	}
+
+	edit := client.convertEditToMatrix(context.Background(), nil, nil, &model.Post{Id: "p1", Message: "edited", FileIds: []string{"f1"}}, []*database.Message{
+		{ID: MakeMessageID("p1"), PartID: MakeMessagePartID(1), Metadata: &MessageMetadata{FileID: "f1"}},
+	})
+	if edit.AddedParts == nil || len(edit.AddedParts.Parts) != 1 || edit.AddedParts.Parts[0].Extra[postIDContentKey] != "p1" {
+		t.Errorf("added edit parts should carry the post ID: %+v", edit.AddedParts)
+	}
+}
+
+// newMessageMapTestConnector returns a connector whose bridge database holds
+// the portal of ch1 and the two parts of a post with a file.
+func newMessageMapTestConnector(t *testing.T) *MattermostConnector {
+	t.Helper()
+	ctx := context.Background()
+	db := newTestBridgeDB(t)
+	portal := db.Portal.New()
+	portal.PortalKey = makePortalKey("ch1")
+	portal.MXID = "!room:example.com"
+	if err := db.Portal.Insert(ctx, portal); err != nil {
+		t.Fatalf("insert portal: %v", err)
+	}
+	for i, mxid := range []id.EventID{"$text:example.com", "$file:example.com"} {
+		if err := db.Message.Insert(ctx, &database.Message{
+			ID: MakeMessageID(mapTestPostID), PartID: MakeMessagePartID(i), MXID: mxid, Room: portal.PortalKey,
+			SenderID: MakeUserID("user1"), Timestamp: time.UnixMilli(1000),
+		}); err != nil {
+			t.Fatalf("insert message: %v", err)
+		}
+	}
+	mc := newTestBridgeConnector()
+	mc.Bridge.DB = db
+	return mc
+}
+
+func TestHandleMessageMap(t *testing.T) {
+	t.Parallel()
+	mc := newMessageMapTestConnector(t)
+
+	for _, query := range []string{"post_id=" + mapTestPostID, "event_id=$file:example.com"} {
+		rec := httptest.NewRecorder()
+		mc.HandleMessageMap(rec, httptest.NewRequest(http.MethodGet, "/api/map?"+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", query, rec.Code, rec.Body.String())
+		}
+		var mapping MessageMapping
+		if err := json.NewDecoder(rec.Body).Decode(&mapping); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if mapping.PostID != mapTestPostID || mapping.ChannelID != "ch1" || mapping.RoomID != "!room:example.com" {
+			t.Errorf("%s: mapping = %+v", query, mapping)
+		}
+		if len(mapping.Parts) != 2 || mapping.Parts[0].EventID != "$text:example.com" || mapping.Parts[1].EventID != "$file:example.com" {
+			t.Errorf("%s: parts = %+v", query, mapping.Parts)
+		}
+	}
+}
+
+func TestHandleMessageMap_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		noDB   bool
+		want   int
+	}{
+		{"method", http.MethodPost, "post_id=" + mapTestPostID, false, http.StatusMethodNotAllowed},
+		{"no parameter", http.MethodGet, "", false, http.StatusBadRequest},
+		{"both parameters", http.MethodGet, "post_id=" + mapTestPostID + "&event_id=$text:example.com", false, http.StatusBadRequest},
+		{"invalid post ID", http.MethodGet, "post_id=p1", false, http.StatusBadRequest},
+		{"invalid event ID", http.MethodGet, "event_id=text", false, http.StatusBadRequest},
+		{"unknown post", http.MethodGet, "post_id=zyxwvutsrqponmlkjihgfedcba", false, http.StatusNotFound},
+		{"unknown event", http.MethodGet, "event_id=$other:example.com", false, http.StatusNotFound},
+		{"no database", http.MethodGet, "post_id=" + mapTestPostID, true, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newMessageMapTestConnector(t)
+			if tt.noDB {
+				mc.Bridge.DB = nil
+			}
+			rec := httptest.NewRecorder()
+			mc.HandleMessageMap(rec, httptest.NewRequest(tt.method, "/api/map?"+tt.query, nil))
+			if rec.Code != tt.want {
+				t.Errorf("status: got %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// postEventKeys returns the keys identifying a post event. Posts are keyed
+// by ID, plus the pending post ID the sending client attached, so a post the
+// client retried under a new ID is recognized too. Edits are keyed by their
+// edit time, so later edits of the same post aren't dropped.
+func postEventKeys(event string, post *model.Post) []string {
+	switch event {
+	case string(model.WebsocketEventPosted):
+		keys := []string{"posted/" + post.Id}
+		if post.PendingPostId != "" {
+			keys = append(keys, "pending/"+post.UserId+"/"+post.PendingPostId)
+		}
+		return keys
+	case string(model.WebsocketEventPostEdited):
+		return []string{"edited/" + post.Id + "/" + strconv.FormatInt(post.EditAt, 10)}
+	default:
+		return []string{event + "/" + post.Id}
+	}
+}
+
+// reactionEventKey returns the key identifying a reaction event. The
+// reaction's creation time tells a reaction removed and added again apart
+// from a duplicate.
+func reactionEventKey(event string, reaction *model.Reaction) string {
+	return event + "/" + reaction.PostId + "/" + reaction.UserId + "/" + reaction.EmojiName + "/" + strconv.FormatInt(reaction.CreateAt, 10)
+}
+
+// isDuplicateEvent reports whether an event with any of keys was already
+// queued, and remembers the keys otherwise. WebSocket reconnects can
+// deliver the same event twice; only the most recent caches.max_entries
+// keys are remembered.
+func (m *MattermostClient) isDuplicateEvent(event string, keys ...string) bool {
+	m.recentEventsMu.Lock()
+	defer m.recentEventsMu.Unlock()
+	if m.recentEvents == nil {
+		m.recentEvents = newClientCache[string, struct{}](m, "recent_events", false)
+	}
+	for _, key := range keys {
+		if _, ok := m.recentEvents.get(key); ok {
+			m.log.Debug().
+				Str("event_type", event).
+				Str("key", key).
+				Msg("Dropping duplicate event")
+			m.connector.metrics().duplicateDrops.inc(event)
+			return true
+		}
+	}
+	for _, key := range keys {
+		m.recentEvents.set(key, struct{}{})
+	}
+	return false
+}
+
+// isDuplicatePost reports whether a post event was already queued.
+func (m *MattermostClient) isDuplicatePost(event string, post *model.Post) bool {
+	return m.isDuplicateEvent(event, postEventKeys(event, post)...)
+}
+
+// isDuplicateReaction reports whether a reaction event was already queued.
+func (m *MattermostClient) isDuplicateReaction(event string, reaction *model.Reaction) bool {
+	return m.isDuplicateEvent(event, reactionEventKey(event, reaction))
+}
@@ -0,0 +1,101 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestHandleEvent_DropsDuplicates(t *testing.T) {
+	t.Parallel()
+	postEvent := func(eventType model.WebsocketEventType, post *model.Post) *model.WebSocketEvent {
+		postJSON, _ := json.Marshal(post)
+		return newWebSocketEvent(eventType, "ch1", map[string]any{"post": string(postJSON)})
+	}
+	reactionEvent := func(eventType model.WebsocketEventType, createAt int64) *model.WebSocketEvent {
+		reactionJSON, _ := json.Marshal(&model.Reaction{PostId: "p1", ChannelId: "ch1", UserId: "alice-id", EmojiName: "+1", CreateAt: createAt})
+		return newWebSocketEvent(eventType, "ch1", map[string]any{"reaction": string(reactionJSON), "sender_name": "@alice"})
+	}
+	tests := []struct {
+		name   string
+		events []*model.WebSocketEvent
+		want   int
+	}{
+		{"posted twice", []*model.WebSocketEvent{
+			postEvent(model.WebsocketEventPosted, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id"}),
+			postEvent(model.WebsocketEventPosted, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id"}),
+		}, 1},
+		{"retried with same pending ID", []*model.WebSocketEvent{
+			postEvent(model.WebsocketEventPosted, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id", PendingPostId: "alice-id:1"}),
+			postEvent(model.WebsocketEventPosted, &model.Post{Id: "p2", ChannelId: "ch1", UserId: "alice-id", PendingPostId: "alice-id:1"}),
+		}, 1},
+		{"different posts", []*model.WebSocketEvent{
+			postEvent(model.WebsocketEventPosted, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id", PendingPostId: "alice-id:1"}),
+			postEvent(model.WebsocketEventPosted, &model.Post{Id: "p2", ChannelId: "ch1", UserId: "alice-id", PendingPostId: "alice-id:2"}),
+		}, 2},
+		{"same edit twice", []*model.WebSocketEvent{
+			postEvent(model.WebsocketEventPostEdited, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id", EditAt: 10}),
+			postEvent(model.WebsocketEventPostEdited, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id", EditAt: 10}),
+		}, 1},
+		{"two edits", []*model.WebSocketEvent{
+			postEvent(model.WebsocketEventPostEdited, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id", EditAt: 10}),
+			postEvent(model.WebsocketEventPostEdited, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id", EditAt: 20}),
+		}, 2},
+		{"deleted twice", []*model.WebSocketEvent{
+			postEvent(model.WebsocketEventPostDeleted, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id"}),
+			postEvent(model.WebsocketEventPostDeleted, &model.Post{Id: "p1", ChannelId: "ch1", UserId: "alice-id"}),
+		}, 1},
+		{"reaction twice", []*model.WebSocketEvent{
+			reactionEvent(model.WebsocketEventReactionAdded, 10),
+			reactionEvent(model.WebsocketEventReactionAdded, 10),
+		}, 1},
+		{"reaction removed and added again", []*model.WebSocketEvent{
+			reactionEvent(model.WebsocketEventReactionAdded, 10),
+			reactionEvent(model.WebsocketEventReactionRemoved, 10),
+			reactionEvent(model.WebsocketEventReactionRemoved, 10),
+			reactionEvent(model.WebsocketEventReactionAdded, 20),
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://unused")
+			for _, evt := range tt.events {
+				mc.handleEvent(evt)
+			}
+			if got := len(testMock(mc).Events()); got != tt.want {
+				t.Errorf("queued %d events, want %d", got, tt.want)
+			}
+			var drops uint64
+			for _, evt := range tt.events {
+				drops = max(drops, mc.connector.metrics().duplicateDrops.get(string(evt.EventType())))
+			}
+			if want := uint64(len(tt.events) - tt.want); drops != want {
+				t.Errorf("duplicate drops = %d, want %d", drops, want)
+			}
+		})
+	}
+}
+
+func TestIsDuplicateEvent_Bounded(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.Caches = CacheConfig{MaxEntries: 2}
+	for _, key := range []string{"a", "b", "c"} {
+		if mc.isDuplicateEvent("posted", key) {
+			t.Fatalf("%s reported as duplicate on first delivery", key)
+		}
+	}
+	if mc.isDuplicateEvent("posted", "a") {
+		t.Error("a should have been forgotten once the cache was full")
+	}
+	if !mc.isDuplicateEvent("posted", "c") {
+		t.Error("c should still be remembered")
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+var (
+	_ bridgev2.IdentifierResolvingNetworkAPI = (*MattermostClient)(nil)
+	_ bridgev2.GhostDMCreatingNetworkAPI     = (*MattermostClient)(nil)
+	_ bridgev2.UserSearchingNetworkAPI       = (*MattermostClient)(nil)
+)
+
+// identifierPrefix is the prefix of the user identifiers returned in
+// UserInfo.Identifiers (see mmUserToUserInfo).
+const identifierPrefix = "mattermost:"
+
+// usernameSuffix marks a username in identifiers like "alice@mattermost",
+// which would otherwise be taken for an email address.
+const usernameSuffix = "@mattermost"
+
+// maxUserSearchResults bounds the users returned by SearchUsers.
+const maxUserSearchResults = 50
+
+// lookupUser resolves a user identifier to a Mattermost user. Accepted
+// forms are a user ID (optionally prefixed with "mattermost:"), a username
+// (optionally prefixed with "@" or suffixed with "@mattermost") and an
+// email address. Returns (nil, nil) if no such user exists.
+func (m *MattermostClient) lookupUser(ctx context.Context, identifier string) (*model.User, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return nil, nil
+	}
+	if username, ok := cutUsernameSuffix(identifier); ok {
+		identifier = "@" + username
+	}
+
+	var user *model.User
+	var resp *model.Response
+	var err error
+	switch {
+	case strings.HasPrefix(identifier, identifierPrefix):
+		user, resp, err = m.client.GetUser(ctx, strings.TrimPrefix(identifier, identifierPrefix), "")
+	case strings.HasPrefix(identifier, "@"):
+		user, resp, err = m.client.GetUserByUsername(ctx, strings.TrimPrefix(identifier, "@"), "")
+	case strings.Contains(identifier, "@"):
+		user, resp, err = m.client.GetUserByEmail(ctx, identifier, "")
+	case model.IsValidId(identifier):
+		user, resp, err = m.client.GetUser(ctx, identifier, "")
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// 26-character usernames look like IDs, try that too.
+			user, resp, err = m.client.GetUserByUsername(ctx, identifier, "")
+		}
+	default:
+		user, resp, err = m.client.GetUserByUsername(ctx, identifier, "")
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	return user, nil
+}
+
+// ResolveIdentifier implements bridgev2.IdentifierResolvingNetworkAPI. It
+// returns (nil, nil) if the identifier doesn't match any Mattermost user.
+func (m *MattermostClient) ResolveIdentifier(ctx context.Context, identifier string, createChat bool) (*bridgev2.ResolveIdentifierResponse, error) {
+	if m.client == nil {
+		return nil, bridgev2.ErrNotLoggedIn
+	}
+	user, err := m.lookupUser(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	resp, err := m.userToResolveResponse(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if createChat {
+		resp.Chat, err = m.createDM(ctx, user.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// SearchUsers implements bridgev2.UserSearchingNetworkAPI with the
+// Mattermost user search, limited to active users of the login's team so
+// results are users the login can open a DM with.
+func (m *MattermostClient) SearchUsers(ctx context.Context, query string) ([]*bridgev2.ResolveIdentifierResponse, error) {
+	if m.client == nil {
+		return nil, bridgev2.ErrNotLoggedIn
+	}
+	term := strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if username, ok := cutUsernameSuffix(term); ok {
+		term = username
+	}
+	if term == "" {
+		return nil, nil
+	}
+	users, _, err := m.client.SearchUsers(ctx, &model.UserSearch{
+		Term:   term,
+		TeamId: m.teamID,
+		Limit:  maxUserSearchResults,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	results := make([]*bridgev2.ResolveIdentifierResponse, 0, len(users))
+	for _, user := range users {
+		resp, err := m.userToResolveResponse(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, resp)
+	}
+	return results, nil
+}
+
+// userToResolveResponse returns the ghost and user info of a Mattermost
+// user.
+func (m *MattermostClient) userToResolveResponse(ctx context.Context, user *model.User) (*bridgev2.ResolveIdentifierResponse, error) {
+	resp := &bridgev2.ResolveIdentifierResponse{
+		UserID:   MakeUserID(user.Id),
+		UserInfo: m.mmUserToUserInfo(ctx, user),
+	}
+	if m.connector.Bridge != nil && m.connector.Bridge.DB != nil {
+		ghost, err := m.connector.Bridge.GetGhostByID(ctx, resp.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ghost: %w", err)
+		}
+		resp.Ghost = ghost
+	}
+	return resp, nil
+}
+
+// cutUsernameSuffix returns the username of an identifier like
+// "alice@mattermost".
+func cutUsernameSuffix(identifier string) (string, bool) {
+	if len(identifier) <= len(usernameSuffix) || !strings.EqualFold(identifier[len(identifier)-len(usernameSuffix):], usernameSuffix) {
+		return "", false
+	}
+	return identifier[:len(identifier)-len(usernameSuffix)], true
+}
+
+// CreateChatWithGhost implements bridgev2.GhostDMCreatingNetworkAPI.
+func (m *MattermostClient) CreateChatWithGhost(ctx context.Context, ghost *bridgev2.Ghost) (*bridgev2.CreateChatResponse, error) {
+	if m.client == nil {
+		return nil, bridgev2.ErrNotLoggedIn
+	}
+	return m.createDM(ctx, ParseUserID(ghost.ID))
+}
+
+// createDM opens (or reuses) the Mattermost direct channel between the
+// logged-in user and otherUserID. Mattermost derives DM channel IDs from the
+// participant pair, so the portal is keyed by the participant set.
+func (m *MattermostClient) createDM(ctx context.Context, otherUserID string) (*bridgev2.CreateChatResponse, error) {
+	channel, resp, err := m.client.CreateDirectChannel(ctx, m.userID, otherUserID)
+	if resp != nil && resp.StatusCode == http.StatusForbidden {
+		// Mattermost refuses DMs with users outside the login's teams when
+		// RestrictDirectMessage is set to "team".
+		return nil, bridgev2.WrapRespErr(fmt.Errorf("direct messages with that user are not allowed: %w", err), mautrix.MForbidden)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create direct channel: %w", err)
+	}
+	m.log.Info().
+		Str("channel_id", channel.Id).
+		Str("other_user_id", otherUserID).
+		Msg("Opened direct channel")
+
+	members := model.ChannelMembers{{ChannelId: channel.Id, UserId: m.userID}}
+	if otherUserID != m.userID {
+		members = append(members, model.ChannelMember{ChannelId: channel.Id, UserId: otherUserID})
+	}
+	return &bridgev2.CreateChatResponse{
+		PortalKey:  makePortalKey(channel.Id),
+		PortalInfo: m.channelToChatInfo(channel, members),
+	}, nil
+}
+
+// handleDirectAdded handles direct_added and group_added events, sent when
+// someone opens a new DM or group DM with the user, by creating the portal
+// right away instead of waiting for the first message.
+func (m *MattermostClient) handleDirectAdded(evt *model.WebSocketEvent) {
+	chID := evt.GetBroadcast().ChannelId
+	if chID == "" {
+		m.log.Warn().Str("event_type", string(evt.EventType())).Msg("DM added event missing channel ID")
+		return
+	}
+
+	m.log.Debug().
+		Str("event_type", string(evt.EventType())).
+		Str("channel_id", chID).
+		Msg("New direct channel")
+
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatResync{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatResync,
+			PortalKey: makePortalKey(chID),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("channel_id", chID)
+			},
+			CreatePortal:   m.allowCreatePortal(m.log.WithContext(context.Background()), chID, "", "", false),
+			PostHandleFunc: m.connector.watchIfRelayMissing,
+		},
+		GetChatInfoFunc: m.GetChatInfo,
+	})
+}
+
+// dmPuppetUpdater returns a ChatInfo.ExtraUpdates hook that invites the
+// Matrix users of the puppets whose bots are in a DM or group DM once its
+// room exists, so a DM opened from Mattermost reaches them. Users invited
+// before are recorded in AutoInvited and skipped. Returns nil if no puppet
+// is a member.
+func (m *MattermostClient) dmPuppetUpdater(info *bridgev2.ChatInfo) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	puppets := m.connector.channelPuppets(info)
+	if len(puppets) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		if portal.MXID == "" {
+			return false
+		}
+		meta := portalMetadata(portal)
+		channelID := ParsePortalID(portal.ID)
+		changed := false
+		for _, mxid := range puppets {
+			if slices.Contains(meta.AutoInvited, mxid) {
+				continue
+			}
+			if err := portal.Bridge.Bot.EnsureInvited(ctx, portal.MXID, mxid); err != nil {
+				m.log.Warn().Err(err).
+					Str("channel_id", channelID).
+					Stringer("mxid", mxid).
+					Msg("Failed to invite puppet user to DM")
+				continue
+			}
+			meta.AutoInvited = append(meta.AutoInvited, mxid)
+			changed = true
+			m.log.Info().
+				Str("channel_id", channelID).
+				Stringer("room_id", portal.MXID).
+				Stringer("mxid", mxid).
+				Msg("Invited puppet user to DM")
+		}
+		return changed
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/id"
+)
+
+// dmTestUserID is a valid 26-character Mattermost ID.
+const dmTestUserID = "abcdefghijklmnopqrstuvwxyz"
+
+func newDMTestFake() *fakeMM {
+	fake := newFakeMM()
+	fake.Users[dmTestUserID] = &model.User{Id: dmTestUserID, Username: "alice", Email: "alice@example.com"}
+	return fake
+}
+
+func TestLookupUser(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+
+	tests := []struct {
+		name       string
+		identifier string
+		wantID     string
+	}{
+		{"user id", dmTestUserID, dmTestUserID},
+		{"prefixed user id", "mattermost:" + dmTestUserID, dmTestUserID},
+		{"username", "alice", dmTestUserID},
+		{"at username", "@alice", dmTestUserID},
+		{"email", "alice@example.com", dmTestUserID},
+		{"username with suffix", "alice@mattermost", dmTestUserID},
+		{"unknown username with suffix", "bob@Mattermost", ""},
+		{"padded", "  @alice ", dmTestUserID},
+		{"unknown username", "bob", ""},
+		{"unknown email", "bob@example.com", ""},
+		{"unknown id", "zyxwvutsrqponmlkjihgfedcba", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			user, err := mc.lookupUser(context.Background(), tt.identifier)
+			if err != nil {
+				t.Fatalf("lookupUser: %v", err)
+			}
+			if tt.wantID == "" {
+				if user != nil {
+					t.Errorf("expected no user, got %q", user.Id)
+				}
+				return
+			}
+			if user == nil || user.Id != tt.wantID {
+				t.Errorf("expected user %q, got %+v", tt.wantID, user)
+			}
+		})
+	}
+}
+
+func TestLookupUser_ServerError(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	fake.FailEndpoints["/users/username/"] = true
+	mc := newFullTestClient(fake.Server.URL)
+
+	if _, err := mc.lookupUser(context.Background(), "alice"); err == nil {
+		t.Error("expected error when the server fails")
+	}
+}
+
+func TestResolveIdentifier(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	mc := newFullTestClient(fake.Server.URL)
+
+	resp, err := mc.ResolveIdentifier(context.Background(), "@alice", false)
+	if err != nil {
+		t.Fatalf("ResolveIdentifier: %v", err)
+	}
+	if resp == nil {
+		t.Fatal("expected a response")
+	}
+	if resp.UserID != MakeUserID(dmTestUserID) {
+		t.Errorf("UserID: got %q", resp.UserID)
+	}
+	if resp.UserInfo == nil || len(resp.UserInfo.Identifiers) == 0 || resp.UserInfo.Identifiers[0] != "mattermost:"+dmTestUserID {
+		t.Errorf("UserInfo not populated: %+v", resp.UserInfo)
+	}
+	if resp.Chat != nil {
+		t.Error("Chat should be nil when createChat is false")
+	}
+	if fake.CalledPath("/api/v4/channels/direct") {
+		t.Error("no DM should be created when createChat is false")
+	}
+}
+
+func TestResolveIdentifier_CreateChat(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	mc := newFullTestClient(fake.Server.URL)
+
+	resp, err := mc.ResolveIdentifier(context.Background(), "alice", true)
+	if err != nil {
+		t.Fatalf("ResolveIdentifier: %v", err)
+	}
+	if resp.Chat == nil {
+		t.Fatal("expected Chat to be set")
+	}
+	wantKey := makePortalKey("dm-my-user-id-" + dmTestUserID)
+	if resp.Chat.PortalKey != wantKey {
+		t.Errorf("PortalKey: got %+v, want %+v", resp.Chat.PortalKey, wantKey)
+	}
+	info := resp.Chat.PortalInfo
+	if info == nil || info.Type == nil || *info.Type != database.RoomTypeDM {
+		t.Fatalf("expected DM room type, got %+v", info)
+	}
+	if info.Members.OtherUserID != MakeUserID(dmTestUserID) {
+		t.Errorf("OtherUserID: got %q", info.Members.OtherUserID)
+	}
+	if len(info.Members.MemberMap) != 2 {
+		t.Errorf("expected 2 members, got %d", len(info.Members.MemberMap))
+	}
+}
+
+func TestResolveIdentifier_DMForbidden(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	fake.EndpointErrors["/channels/direct"] = model.NewAppError("createDirectChannel", "api.context.permissions.app_error", nil, "", http.StatusForbidden)
+	mc := newFullTestClient(fake.Server.URL)
+
+	_, err := mc.ResolveIdentifier(context.Background(), "alice", true)
+	var respErr bridgev2.RespError
+	if !errors.As(err, &respErr) || respErr.ErrCode != mautrix.MForbidden.ErrCode {
+		t.Errorf("expected M_FORBIDDEN, got %v", err)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	t.Cleanup(fake.Close)
+	fake.Users["other-team-user"] = &model.User{Id: "other-team-user", Username: "alicia"}
+	fake.Users["team-user"] = &model.User{Id: "team-user", Username: "alina"}
+	myTeam := []*model.Team{{Id: "my-team-id"}}
+	fake.Teams[dmTestUserID] = myTeam
+	fake.Teams["team-user"] = myTeam
+	fake.Teams["other-team-user"] = []*model.Team{{Id: "other-team"}}
+	mc := newFullTestClient(fake.Server.URL)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []networkid.UserID
+	}{
+		{"prefix", "ali", []networkid.UserID{MakeUserID(dmTestUserID), MakeUserID("team-user")}},
+		{"at username", "@alice", []networkid.UserID{MakeUserID(dmTestUserID)}},
+		{"username with suffix", "alice@mattermost", []networkid.UserID{MakeUserID(dmTestUserID)}},
+		{"other team", "alicia", nil},
+		{"empty", " @ ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			results, err := mc.SearchUsers(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("SearchUsers: %v", err)
+			}
+			var got []networkid.UserID
+			for _, resp := range results {
+				if resp.UserInfo == nil {
+					t.Errorf("result %q has no user info", resp.UserID)
+				}
+				got = append(got, resp.UserID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("results: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchUsers_Errors(t *testing.T) {
+	t.Parallel()
+	if _, err := newNotLoggedInClient().SearchUsers(context.Background(), "alice"); !errors.Is(err, bridgev2.ErrNotLoggedIn) {
+		t.Errorf("expected ErrNotLoggedIn, got %v", err)
+	}
+	fake := newDMTestFake()
+	defer fake.Close()
+	fake.FailEndpoints["/users/search"] = true
+	if _, err := newFullTestClient(fake.Server.URL).SearchUsers(context.Background(), "alice"); err == nil {
+		t.Error("expected an error when the search fails")
+	}
+}
+
+func TestResolveIdentifier_NotFound(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	mc := newFullTestClient(fake.Server.URL)
+
+	resp, err := mc.ResolveIdentifier(context.Background(), "nobody", true)
+	if err != nil {
+		t.Fatalf("not found should not be an error, got %v", err)
+	}
+	if resp != nil {
+		t.Errorf("expected nil response, got %+v", resp)
+	}
+}
+
+func TestResolveIdentifier_NotLoggedIn(t *testing.T) {
+	t.Parallel()
+	mc := newNotLoggedInClient()
+	if _, err := mc.ResolveIdentifier(context.Background(), "alice", false); !errors.Is(err, bridgev2.ErrNotLoggedIn) {
+		t.Errorf("expected ErrNotLoggedIn, got %v", err)
+	}
+	ghost := &bridgev2.Ghost{Ghost: &database.Ghost{ID: networkid.UserID(dmTestUserID)}}
+	if _, err := mc.CreateChatWithGhost(context.Background(), ghost); !errors.Is(err, bridgev2.ErrNotLoggedIn) {
+		t.Errorf("expected ErrNotLoggedIn, got %v", err)
+	}
+}
+
+func TestCreateChatWithGhost(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	mc := newFullTestClient(fake.Server.URL)
+
+	ghost := &bridgev2.Ghost{Ghost: &database.Ghost{ID: MakeUserID(dmTestUserID)}}
+	resp, err := mc.CreateChatWithGhost(context.Background(), ghost)
+	if err != nil {
+		t.Fatalf("CreateChatWithGhost: %v", err)
+	}
+	if resp.PortalKey.ID != MakePortalID("dm-my-user-id-"+dmTestUserID) {
+		t.Errorf("PortalKey: got %+v", resp.PortalKey)
+	}
+
+	var ids []string
+	for _, c := range fake.Calls() {
+		if c.Path == "/api/v4/channels/direct" {
+			_ = json.Unmarshal([]byte(c.Body), &ids)
+		}
+	}
+	if len(ids) != 2 || ids[0] != "my-user-id" || ids[1] != dmTestUserID {
+		t.Errorf("direct channel request body: got %v", ids)
+	}
+}
+
+func TestCreateChatWithGhost_SelfDM(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	mc := newFullTestClient(fake.Server.URL)
+
+	ghost := &bridgev2.Ghost{Ghost: &database.Ghost{ID: MakeUserID("my-user-id")}}
+	resp, err := mc.CreateChatWithGhost(context.Background(), ghost)
+	if err != nil {
+		t.Fatalf("CreateChatWithGhost: %v", err)
+	}
+	if n := len(resp.PortalInfo.Members.MemberMap); n != 1 {
+		t.Errorf("self DM should have 1 member, got %d", n)
+	}
+}
+
+func TestCreateChatWithGhost_ServerError(t *testing.T) {
+	t.Parallel()
+	fake := newDMTestFake()
+	defer fake.Close()
+	fake.FailEndpoints["/channels/direct"] = true
+	mc := newFullTestClient(fake.Server.URL)
+
+	ghost := &bridgev2.Ghost{Ghost: &database.Ghost{ID: MakeUserID(dmTestUserID)}}
+	if _, err := mc.CreateChatWithGhost(context.Background(), ghost); err == nil {
+		t.Error("expected error when direct channel creation fails")
+	}
+}
+
+func TestHandleEvent_DirectAndGroupAdded(t *testing.T) {
+	t.Parallel()
+	for _, evtType := range []model.WebsocketEventType{model.WebsocketEventDirectAdded, model.WebsocketEventGroupAdded} {
+		t.Run(string(evtType), func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mock := testMock(mc)
+
+			mc.handleEvent(newWebSocketEvent(evtType, "dm-channel", map[string]any{"teammate_id": "other"}))
+
+			events := mock.Events()
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			resync, ok := events[0].(*simplevent.ChatResync)
+			if !ok {
+				t.Fatalf("expected ChatResync, got %T", events[0])
+			}
+			if resync.PortalKey != makePortalKey("dm-channel") {
+				t.Errorf("PortalKey: got %+v", resync.PortalKey)
+			}
+			if !resync.CreatePortal {
+				t.Error("CreatePortal should be true")
+			}
+			if resync.GetChatInfoFunc == nil {
+				t.Error("GetChatInfoFunc should be set")
+			}
+		})
+	}
+}
+
+func TestHandleEvent_DirectAddedMissingChannel(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	mock := testMock(mc)
+
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventDirectAdded, "", nil))
+
+	if len(mock.Events()) != 0 {
+		t.Errorf("expected no events without a channel ID, got %d", len(mock.Events()))
+	}
+}
+
+func TestHandlePosted_DirectChannel(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	mock := testMock(mc)
+
+	postJSON, _ := json.Marshal(&model.Post{Id: "p1", UserId: dmTestUserID, ChannelId: "dm-channel", Message: "hi"})
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventPosted, "dm-channel", map[string]any{
+		"post":         string(postJSON),
+		"channel_type": string(model.ChannelTypeDirect),
+		"sender_name":  "@alice",
+	}))
+
+	events := mock.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].GetPortalKey() != makePortalKey("dm-channel") {
+		t.Errorf("DM post routed to %+v", events[0].GetPortalKey())
+	}
+	if !events[0].(*simplevent.Message[*model.Post]).CreatePortal {
+		t.Error("DM post should create the portal if missing")
+	}
+}
+
+func TestChannelToChatInfo_DirectMembers(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.dpLogins[dmTestUserID] = "alice-login"
+	channel := &model.Channel{Id: "dm-channel", Type: model.ChannelTypeDirect}
+	members := model.ChannelMembers{
+		{ChannelId: "dm-channel", UserId: "myuserid"},
+		{ChannelId: "dm-channel", UserId: dmTestUserID},
+	}
+
+	info := client.channelToChatInfo(channel, members)
+
+	self := info.Members.MemberMap[MakeUserID("myuserid")]
+	if !self.IsFromMe {
+		t.Error("login user should be marked IsFromMe")
+	}
+	other := info.Members.MemberMap[MakeUserID(dmTestUserID)]
+	if other.IsFromMe || other.SenderLogin != "alice-login" {
+		t.Errorf("double-puppeted member: IsFromMe %v, SenderLogin %q", other.IsFromMe, other.SenderLogin)
+	}
+}
+
+func TestDMPuppetUpdater(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.Puppets = map[id.UserID]*PuppetClient{
+		"@agent:example.com": {MXID: "@agent:example.com", UserID: "agent-bot-id"},
+		"@other:example.com": {MXID: "@other:example.com", UserID: "other-bot-id"},
+	}
+	channel := &model.Channel{Id: "dm-channel", Type: model.ChannelTypeDirect}
+
+	if update := client.dmPuppetUpdater(client.channelToChatInfo(channel, model.ChannelMembers{
+		{ChannelId: "dm-channel", UserId: dmTestUserID},
+	})); update != nil {
+		t.Error("updater should be nil without puppets in the DM")
+	}
+
+	update := client.dmPuppetUpdater(client.channelToChatInfo(channel, model.ChannelMembers{
+		{ChannelId: "dm-channel", UserId: dmTestUserID},
+		{ChannelId: "dm-channel", UserId: "agent-bot-id"},
+	}))
+	bot := &fakeInviteBot{}
+	portal := makeTestPortal("dm-channel")
+	portal.Bridge = &bridgev2.Bridge{Bot: bot}
+	portal.Metadata = &PortalMetadata{}
+	if update(context.Background(), portal) {
+		t.Error("nothing should change before the room exists")
+	}
+	portal.MXID = "!dm:example.com"
+	if !update(context.Background(), portal) {
+		t.Error("metadata should change once the room exists")
+	}
+	if update(context.Background(), portal) {
+		t.Error("a second update should not invite again")
+	}
+	if got := bot.invited[portal.MXID]; !slices.Equal(got, []id.UserID{"@agent:example.com"}) {
+		t.Errorf("invites: got %v", got)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/id"
+)
+
+// DoctorStatus is the outcome of one doctor check.
+type DoctorStatus string
+
+// Doctor check outcomes. Only DoctorFail makes the doctor exit non-zero.
+const (
+	DoctorOK   DoctorStatus = "ok"
+	DoctorWarn DoctorStatus = "warn"
+	DoctorFail DoctorStatus = "fail"
+	DoctorSkip DoctorStatus = "skip"
+)
+
+// DoctorCheck is one line of the doctor report. Details never contain
+// tokens.
+type DoctorCheck struct {
+	Name   string
+	Status DoctorStatus
+	Detail string
+}
+
+// DoctorReport is the result of the `doctor` preflight checks.
+type DoctorReport struct {
+	Checks []DoctorCheck
+}
+
+func (r *DoctorReport) add(status DoctorStatus, name, format string, args ...any) {
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Failed reports whether any check failed.
+func (r *DoctorReport) Failed() bool {
+	return slices.ContainsFunc(r.Checks, func(c DoctorCheck) bool { return c.Status == DoctorFail })
+}
+
+// Print writes the report in a human-readable form, with a summary line.
+func (r *DoctorReport) Print(w io.Writer) {
+	counts := make(map[DoctorStatus]int)
+	for _, check := range r.Checks {
+		counts[check.Status]++
+		_, _ = fmt.Fprintf(w, "[%-4s] %s: %s\n", strings.ToUpper(string(check.Status)), check.Name, check.Detail)
+	}
+	_, _ = fmt.Fprintf(w, "\n%d ok, %d warnings, %d failed, %d skipped\n",
+		counts[DoctorOK], counts[DoctorWarn], counts[DoctorFail], counts[DoctorSkip])
+}
+
+// Doctor runs preflight checks against the configured Mattermost server,
+// the puppet and auto-login tokens, the homeserver and the database, and
+// looks for config pitfalls. It's meant to be run before the first
+// deployment, with the bridge initialized but not started.
+func (mc *MattermostConnector) Doctor(ctx context.Context) *DoctorReport {
+	report := &DoctorReport{}
+	mc.doctorConfig(report)
+	mc.doctorDatabase(ctx, report)
+	mc.doctorServer(ctx, report)
+
+	var sampleMXIDs []id.UserID
+	if token := os.Getenv("MATTERMOST_AUTO_TOKEN"); token != "" {
+		serverURL := os.Getenv("MATTERMOST_AUTO_SERVER_URL")
+		if serverURL == "" {
+			serverURL = mc.Config.ServerURL
+		}
+		mc.doctorToken(ctx, report, "Auto-login token", serverURL, token)
+		if owner := os.Getenv("MATTERMOST_AUTO_OWNER_MXID"); owner != "" {
+			sampleMXIDs = append(sampleMXIDs, id.UserID(owner))
+		}
+	} else {
+		report.add(DoctorSkip, "Auto-login token", "MATTERMOST_AUTO_TOKEN is not set, so the relay login must be created through the bot")
+	}
+	for _, entry := range mc.envToPuppetEntries() {
+		mc.doctorPuppet(ctx, report, fmt.Sprintf("Puppet %s (%s)", entry.Slug, entry.MXID), entry.serverURL(mc.Config.ServerURL), entry)
+		sampleMXIDs = append(sampleMXIDs, id.UserID(entry.MXID))
+	}
+	if adminToken := mc.puppetAdminToken(); adminToken != "" {
+		mc.doctorAdminToken(ctx, report, adminToken)
+	}
+
+	mc.doctorAppservice(ctx, report)
+	for _, mxid := range sampleMXIDs {
+		mc.doctorDoublePuppet(ctx, report, mxid)
+	}
+	return report
+}
+
+// doctorGhostPrefixes returns the username prefixes of the bridge's ghosts:
+// the one echo prevention filters, and the one of the appservice username
+// template where available.
+func (mc *MattermostConnector) doctorGhostPrefixes() []string {
+	prefixes := []string{"mattermost_"}
+	if conn, ok := mc.Bridge.Matrix.(*matrix.Connector); ok && conn.Config != nil {
+		prefix, _, ok := strings.Cut(conn.Config.AppService.FormatUsername("\x00"), "\x00")
+		if ok && prefix != "" && !slices.Contains(prefixes, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// doctorConfig checks the network config for values the bridge rejects at
+// startup and for a bot_prefix that overlaps a ghost prefix.
+func (mc *MattermostConnector) doctorConfig(report *DoctorReport) {
+	if err := mc.Config.PostProcess(); err != nil {
+		report.add(DoctorFail, "Config", "invalid template or time zone: %v", err)
+	} else {
+		report.add(DoctorOK, "Config", "templates and time zone parse")
+	}
+
+	prefix := mc.liveConfig().BotPrefix
+	if prefix == "" {
+		report.add(DoctorOK, "bot_prefix", "not set; puppet bots are recognised by user ID")
+		return
+	}
+	for _, ghostPrefix := range mc.doctorGhostPrefixes() {
+		switch {
+		case strings.HasPrefix(ghostPrefix, prefix):
+			report.add(DoctorWarn, "bot_prefix", "%q is also a prefix of ghost usernames (%q): every Mattermost user whose username starts with %q is treated as a bridge bot and their posts are dropped", prefix, ghostPrefix, prefix)
+			return
+		case strings.HasPrefix(prefix, ghostPrefix):
+			report.add(DoctorWarn, "bot_prefix", "%q starts with the ghost prefix %q, so puppet bots can't be told apart from ghosts; pick a distinct prefix", prefix, ghostPrefix)
+			return
+		}
+	}
+	report.add(DoctorOK, "bot_prefix", "%q doesn't overlap the ghost prefixes", prefix)
+}
+
+// doctorDatabase checks that the bridge database is reachable.
+func (mc *MattermostConnector) doctorDatabase(ctx context.Context, report *DoctorReport) {
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		report.add(DoctorSkip, "Database", "no database configured")
+		return
+	}
+	if err := mc.Bridge.DB.RawDB.PingContext(ctx); err != nil {
+		report.add(DoctorFail, "Database", "can't connect: %v", err)
+		return
+	}
+	report.add(DoctorOK, "Database", "connected (%s)", mc.Bridge.DB.Dialect)
+}
+
+// doctorServer checks that the Mattermost server answers and reports its
+// version.
+func (mc *MattermostConnector) doctorServer(ctx context.Context, report *DoctorReport) {
+	if mc.Config.ServerURL == "" {
+		report.add(DoctorFail, "Mattermost server", "server_url is not set")
+		return
+	}
+	client := mc.newAPIClient(mc.Config.ServerURL)
+	status, resp, err := client.GetPing(ctx)
+	if err != nil {
+		report.add(DoctorFail, "Mattermost server", "%s is unreachable: %v", mc.Config.ServerURL, err)
+		return
+	}
+	version := "unknown"
+	if resp != nil && resp.ServerVersion != "" {
+		parts := strings.SplitN(resp.ServerVersion, ".", 4)
+		version = strings.Join(parts[:min(3, len(parts))], ".")
+	}
+	report.add(DoctorOK, "Mattermost server", "%s answers (status %s, version %s)", mc.Config.ServerURL, status, version)
+}
+
+// doctorToken checks that a Mattermost token is accepted, and returns the
+// user it belongs to.
+func (mc *MattermostConnector) doctorToken(ctx context.Context, report *DoctorReport, name, serverURL, token string) *model.User {
+	client := mc.newAPIClient(serverURL)
+	client.SetToken(token)
+	me, _, err := client.GetMe(ctx, "")
+	if err != nil {
+		report.add(DoctorFail, name, "token rejected by %s: %v", serverURL, err)
+		return nil
+	}
+	report.add(DoctorOK, name, "valid, belongs to @%s", me.Username)
+	return me
+}
+
+// doctorPuppet checks a puppet's token and that its Matrix user isn't one of
+// the bridge's ghosts.
+func (mc *MattermostConnector) doctorPuppet(ctx context.Context, report *DoctorReport, name, serverURL string, entry PuppetEntry) {
+	mxid, err := validatePuppetMXID(entry.MXID)
+	if err != nil {
+		report.add(DoctorFail, name, "MXID is not a valid Matrix user ID with a valid server name")
+		return
+	}
+	if mc.Bridge != nil && mc.Bridge.Matrix != nil {
+		if _, isGhost := mc.Bridge.Matrix.ParseGhostMXID(mxid); isGhost {
+			report.add(DoctorFail, name, "MXID is in the bridge's ghost namespace; puppets must map real Matrix users")
+			return
+		}
+	}
+	me := mc.doctorToken(ctx, report, name, serverURL, entry.Token)
+	if me != nil && !me.IsBot {
+		report.add(DoctorWarn, name, "@%s is a regular user, not a bot; its posts aren't marked as bot posts", me.Username)
+	}
+}
+
+// doctorAdminToken checks the puppet provisioning admin token.
+func (mc *MattermostConnector) doctorAdminToken(ctx context.Context, report *DoctorReport, token string) {
+	const name = "Puppet provisioning token"
+	me := mc.doctorToken(ctx, report, name, mc.Config.ServerURL, token)
+	if me != nil && !me.IsSystemAdmin() {
+		report.add(DoctorWarn, name, "@%s isn't a system admin, so creating bots and their tokens may be refused", me.Username)
+	}
+}
+
+// doctorAppservice checks that the homeserver accepts the appservice's
+// as_token.
+func (mc *MattermostConnector) doctorAppservice(ctx context.Context, report *DoctorReport) {
+	const name = "Appservice token"
+	conn, ok := mc.Bridge.Matrix.(*matrix.Connector)
+	if !ok || conn.Bot == nil {
+		report.add(DoctorSkip, name, "Matrix connector not initialized")
+		return
+	}
+	resp, err := conn.Bot.Whoami(ctx)
+	if err != nil {
+		report.add(DoctorFail, name, "homeserver rejected the as_token: %v", err)
+		return
+	}
+	report.add(DoctorOK, name, "accepted, bridge bot is %s", resp.UserID)
+}
+
+// doctorDoublePuppet checks that double puppeting works for a Matrix user by
+// asserting their identity with the double_puppet secret of their server.
+func (mc *MattermostConnector) doctorDoublePuppet(ctx context.Context, report *DoctorReport, mxid id.UserID) {
+	name := "Double puppet " + string(mxid)
+	if mc.Bridge == nil || mc.Bridge.Matrix == nil {
+		report.add(DoctorSkip, name, "Matrix connector not initialized")
+		return
+	}
+	intent, _, err := mc.Bridge.Matrix.NewUserIntent(ctx, mxid, "")
+	switch {
+	case err != nil:
+		report.add(DoctorFail, name, "whoami as the user failed: %v", err)
+	case intent == nil:
+		_, server, _ := mxid.Parse()
+		report.add(DoctorWarn, name, "no as_token secret for %s in double_puppet.secrets, so the user's messages appear from a ghost", server)
+	default:
+		report.add(DoctorOK, name, "the homeserver accepts the identity assertion")
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/id"
+)
+
+// doctorMatrixConnector is a nopMatrixConnector with a ghost namespace and
+// a canned double puppeting result.
+type doctorMatrixConnector struct {
+	nopMatrixConnector
+	intent bridgev2.MatrixAPI
+	err    error
+}
+
+func (doctorMatrixConnector) ParseGhostMXID(userID id.UserID) (networkid.UserID, bool) {
+	localpart, _, _ := userID.Parse()
+	if rest, ok := strings.CutPrefix(localpart, "mattermost_"); ok {
+		return networkid.UserID(rest), true
+	}
+	return "", false
+}
+
+func (c doctorMatrixConnector) NewUserIntent(context.Context, id.UserID, string) (bridgev2.MatrixAPI, string, error) {
+	return c.intent, "", c.err
+}
+
+// lastCheck returns the last check of a report.
+func lastCheck(t *testing.T, report *DoctorReport) DoctorCheck {
+	t.Helper()
+	if len(report.Checks) == 0 {
+		t.Fatal("no checks reported")
+	}
+	return report.Checks[len(report.Checks)-1]
+}
+
+func TestDoctorConfig_BotPrefix(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		prefix string
+		want   DoctorStatus
+	}{
+		{"unset", "", DoctorOK},
+		{"distinct", "agent-", DoctorOK},
+		{"broader than ghost prefix", "matter", DoctorWarn},
+		{"inside ghost namespace", "mattermost_bot", DoctorWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newTestBridgeConnector()
+			mc.Config.BotPrefix = tt.prefix
+			report := &DoctorReport{}
+			mc.doctorConfig(report)
+			if got := lastCheck(t, report); got.Status != tt.want {
+				t.Errorf("bot_prefix check = %+v, want %s", got, tt.want)
+			}
+			if report.Checks[0].Status != DoctorOK {
+				t.Errorf("config check = %+v, want ok", report.Checks[0])
+			}
+		})
+	}
+}
+
+func TestDoctorConfig_ReloadedBotPrefix(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Config.BotPrefix = "agent-"
+	reloaded := mc.Config
+	reloaded.BotPrefix = "matter"
+	mc.reloadedConfig.Store(&reloaded)
+
+	report := &DoctorReport{}
+	mc.doctorConfig(report)
+	if got := lastCheck(t, report); got.Status != DoctorWarn {
+		t.Errorf("bot_prefix check = %+v, want the reloaded prefix to warn", got)
+	}
+}
+
+func TestDoctorServer(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	tests := []struct {
+		name      string
+		serverURL string
+		want      DoctorStatus
+		detail    string
+	}{
+		{"reachable", fake.Server.URL, DoctorOK, "version 10.5.0"},
+		{"unreachable", "http://127.0.0.1:1", DoctorFail, "unreachable"},
+		{"unset", "", DoctorFail, "server_url is not set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newTestBridgeConnector()
+			mc.Config.ServerURL = tt.serverURL
+			report := &DoctorReport{}
+			mc.doctorServer(context.Background(), report)
+			if got := lastCheck(t, report); got.Status != tt.want || !strings.Contains(got.Detail, tt.detail) {
+				t.Errorf("check = %+v, want %s containing %q", got, tt.want, tt.detail)
+			}
+		})
+	}
+}
+
+func TestDoctorPuppet(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Users["bot-id"] = &model.User{Id: "bot-id", Username: "alice-bot", IsBot: true}
+	fake.Users["user-id"] = &model.User{Id: "user-id", Username: "alice"}
+	fake.TokenToUser["bot-token"] = "bot-id"
+	fake.TokenToUser["user-token"] = "user-id"
+	tests := []struct {
+		name  string
+		entry PuppetEntry
+		want  []DoctorStatus
+	}{
+		{"bot token", PuppetEntry{Slug: "ALICE", MXID: "@alice:example.com", Token: "bot-token"}, []DoctorStatus{DoctorOK}},
+		{"user token", PuppetEntry{Slug: "ALICE", MXID: "@alice:example.com", Token: "user-token"}, []DoctorStatus{DoctorOK, DoctorWarn}},
+		{"rejected token", PuppetEntry{Slug: "ALICE", MXID: "@alice:example.com", Token: "secret-bad-token"}, []DoctorStatus{DoctorFail}},
+		{"invalid MXID", PuppetEntry{Slug: "ALICE", MXID: "alice", Token: "bot-token"}, []DoctorStatus{DoctorFail}},
+		{"ghost MXID", PuppetEntry{Slug: "ALICE", MXID: "@mattermost_alice:example.com", Token: "bot-token"}, []DoctorStatus{DoctorFail}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newTestBridgeConnector()
+			mc.Bridge.Matrix = doctorMatrixConnector{}
+			report := &DoctorReport{}
+			mc.doctorPuppet(context.Background(), report, "Puppet ALICE", fake.Server.URL, tt.entry)
+			var got []DoctorStatus
+			for _, check := range report.Checks {
+				got = append(got, check.Status)
+				if strings.Contains(check.Detail, tt.entry.Token) {
+					t.Errorf("check %+v contains the token", check)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("statuses = %v, want %v (%+v)", got, tt.want, report.Checks)
+			}
+		})
+	}
+}
+
+func TestDoctorDoublePuppet(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		conn doctorMatrixConnector
+		want DoctorStatus
+	}{
+		{"asserted", doctorMatrixConnector{intent: ghostIntent{}}, DoctorOK},
+		{"no secret", doctorMatrixConnector{}, DoctorWarn},
+		{"rejected", doctorMatrixConnector{err: errors.New("M_FORBIDDEN")}, DoctorFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newTestBridgeConnector()
+			mc.Bridge.Matrix = tt.conn
+			report := &DoctorReport{}
+			mc.doctorDoublePuppet(context.Background(), report, "@alice:example.com")
+			if got := lastCheck(t, report); got.Status != tt.want {
+				t.Errorf("check = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoctorDatabase(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, nil)
+	report := &DoctorReport{}
+	mc.doctorDatabase(context.Background(), report)
+	if got := lastCheck(t, report); got.Status != DoctorOK {
+		t.Errorf("check = %+v, want ok", got)
+	}
+}
+
+func TestDoctorReport_Print(t *testing.T) {
+	t.Parallel()
+	report := &DoctorReport{}
+	report.add(DoctorOK, "Database", "connected")
+	report.add(DoctorWarn, "bot_prefix", "overlaps")
+	if report.Failed() {
+		t.Error("report without failures reported as failed")
+	}
+	report.add(DoctorFail, "Mattermost server", "unreachable")
+	if !report.Failed() {
+		t.Error("report with a failure not reported as failed")
+	}
+	var buf strings.Builder
+	report.Print(&buf)
+	want := "[OK  ] Database: connected\n[WARN] bot_prefix: overlaps\n[FAIL] Mattermost server: unreachable\n\n1 ok, 1 warnings, 1 failed, 0 skipped\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/commands"
+)
+
+// maxEchoDropsShown bounds the drops listed by the echo-drops command.
+const maxEchoDropsShown = 20
+
+// EchoDropLogConfig controls the log of events dropped by echo prevention.
+type EchoDropLogConfig struct {
+	// Size is the number of most recent drops kept in memory for
+	// GET /api/debug/echo-drops and the echo-drops command. 0 disables the
+	// log.
+	Size int `yaml:"size"`
+}
+
+// EchoDrop is a Mattermost event dropped by echo prevention. Message text
+// is never recorded.
+type EchoDrop struct {
+	Time time.Time `json:"time"`
+	// Layer is the echo prevention layer that dropped the event:
+	// own_user, system_message, puppet, bridge_username or system_user.
+	Layer string `json:"layer"`
+	// Event is the Mattermost event type, e.g. posted or reaction_added.
+	Event     string `json:"event"`
+	LoginID   string `json:"login_id,omitempty"`
+	ChannelID string `json:"channel_id,omitempty"`
+	PostID    string `json:"post_id,omitempty"`
+	PostType  string `json:"post_type,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Emoji     string `json:"emoji,omitempty"`
+}
+
+// echoDropLog keeps the most recent echo drops in a ring buffer.
+type echoDropLog struct {
+	mu    sync.Mutex
+	drops []EchoDrop
+	// next is the index the next drop is written to.
+	next int
+	full bool
+}
+
+func newEchoDropLog(size int) *echoDropLog {
+	return &echoDropLog{drops: make([]EchoDrop, size)}
+}
+
+// add records a drop, overwriting the oldest one once the log is full.
+func (l *echoDropLog) add(drop EchoDrop) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.drops[l.next] = drop
+	l.next++
+	if l.next == len(l.drops) {
+		l.next = 0
+		l.full = true
+	}
+}
+
+// recent returns up to limit drops, newest first. A limit of 0 or less
+// returns all of them.
+func (l *echoDropLog) recent(limit int) []EchoDrop {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	count := l.next
+	if l.full {
+		count = len(l.drops)
+	}
+	if limit > 0 && limit < count {
+		count = limit
+	}
+	drops := make([]EchoDrop, 0, count)
+	for i := 1; i <= count; i++ {
+		drops = append(drops, l.drops[(l.next-i+len(l.drops))%len(l.drops)])
+	}
+	return drops
+}
+
+// startEchoDropLog creates the echo drop log, if it's enabled.
+func (mc *MattermostConnector) startEchoDropLog() {
+	if size := mc.Config.EchoDropLog.Size; size > 0 {
+		mc.echoDrops = newEchoDropLog(size)
+	}
+}
+
+// echoDropped counts an event dropped by echo prevention and records it in
+// the echo drop log, if enabled.
+func (m *MattermostClient) echoDropped(drop EchoDrop) {
+	m.connector.metrics().echoDrops.inc(drop.Layer)
+	if m.connector.echoDrops == nil {
+		return
+	}
+	drop.Time = time.Now()
+	if m.userLogin != nil {
+		drop.LoginID = string(m.userLogin.ID)
+	}
+	m.connector.echoDrops.add(drop)
+}
+
+// postEchoDropped records a post event dropped by echo prevention.
+func (m *MattermostClient) postEchoDropped(layer, event string, post *model.Post, username string) {
+	m.echoDropped(EchoDrop{
+		Layer:     layer,
+		Event:     event,
+		ChannelID: post.ChannelId,
+		PostID:    post.Id,
+		PostType:  post.Type,
+		UserID:    post.UserId,
+		Username:  username,
+	})
+}
+
+// reactionEchoDropped records a reaction event dropped by echo prevention.
+func (m *MattermostClient) reactionEchoDropped(layer, event string, reaction *model.Reaction, username string) {
+	m.echoDropped(EchoDrop{
+		Layer:     layer,
+		Event:     event,
+		ChannelID: reaction.ChannelId,
+		PostID:    reaction.PostId,
+		UserID:    reaction.UserId,
+		Username:  username,
+		Emoji:     reaction.EmojiName,
+	})
+}
+
+// HandleEchoDrops is an HTTP handler for GET /api/debug/echo-drops. It lists
+// the most recent events dropped by echo prevention, newest first. The
+// optional limit query parameter caps the number returned.
+func (mc *MattermostConnector) HandleEchoDrops(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log := mc.ctxLog(r.Context())
+	if mc.echoDrops == nil {
+		http.Error(w, "echo drop log is disabled", http.StatusNotFound)
+		return
+	}
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+	drops := mc.echoDrops.recent(limit)
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Int("drops", len(drops)).
+		Msg("Echo drops requested")
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"drops": drops}); err != nil {
+		log.Warn().Err(err).Msg("Failed to write echo drops response")
+	}
+}
+
+func (mc *MattermostConnector) fnEchoDrops(ce *commands.Event) {
+	if mc.echoDrops == nil {
+		ce.Reply("The echo drop log is disabled. Set `echo_drop_log.size` in the config to enable it.")
+		return
+	}
+	limit := 10
+	if len(ce.Args) > 0 {
+		n, err := strconv.Atoi(ce.Args[0])
+		if err != nil || n < 1 {
+			ce.Reply("Usage: `echo-drops [count]`")
+			return
+		}
+		limit = min(n, maxEchoDropsShown)
+	}
+	drops := mc.echoDrops.recent(limit)
+	if len(drops) == 0 {
+		ce.Reply("No events have been dropped by echo prevention.")
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString("Most recent events dropped by echo prevention, newest first:\n\n")
+	for _, drop := range drops {
+		sb.WriteString("* " + drop.Time.UTC().Format(time.RFC3339) + " `" + drop.Layer + "` " + drop.Event)
+		if drop.PostID != "" {
+			sb.WriteString(" post `" + drop.PostID + "`")
+		}
+		if drop.ChannelID != "" {
+			sb.WriteString(" in `" + drop.ChannelID + "`")
+		}
+		if drop.Username != "" {
+			sb.WriteString(" by @" + drop.Username)
+		} else if drop.UserID != "" {
+			sb.WriteString(" by `" + drop.UserID + "`")
+		}
+		if drop.Emoji != "" {
+			sb.WriteString(" :" + drop.Emoji + ":")
+		}
+		sb.WriteString("\n")
+	}
+	ce.Reply("%s", sb.String())
+}
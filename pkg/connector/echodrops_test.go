@@ -0,0 +1,160 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/bridgeconfig"
+	"maunium.net/go/mautrix/bridgev2/commands"
+)
+
+func TestEchoDropLog_Recent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		size  int
+		adds  int
+		limit int
+		want  string
+	}{
+		{"empty", 3, 0, 0, ""},
+		{"partial", 3, 2, 0, "p2,p1"},
+		{"wrapped", 3, 5, 0, "p5,p4,p3"},
+		{"limit", 3, 5, 2, "p5,p4"},
+		{"limit above count", 3, 1, 5, "p1"},
+	}
+	for _, tt := range tests {
+		log := newEchoDropLog(tt.size)
+		for i := 1; i <= tt.adds; i++ {
+			log.add(EchoDrop{PostID: "p" + string(rune('0'+i))})
+		}
+		var ids []string
+		for _, drop := range log.recent(tt.limit) {
+			ids = append(ids, drop.PostID)
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEchoDropped_RecordsLayer(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	mc.connector.echoDrops = newEchoDropLog(10)
+	mc.connector.Puppets["@bot:test"] = &PuppetClient{MXID: "@bot:test", UserID: "puppet-id"}
+
+	postJSON, _ := json.Marshal(&model.Post{Id: "p1", ChannelId: "ch1", UserId: "puppet-id", Message: "secret"})
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventPosted, "ch1", map[string]any{"post": string(postJSON)}))
+	reactionJSON, _ := json.Marshal(&model.Reaction{PostId: "p1", ChannelId: "ch1", UserId: "bob-id", EmojiName: "+1"})
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventReactionAdded, "ch1", map[string]any{"reaction": string(reactionJSON), "sender_name": "@mattermost_bob"}))
+
+	drops := mc.connector.echoDrops.recent(0)
+	if len(drops) != 2 {
+		t.Fatalf("got %d drops, want 2: %+v", len(drops), drops)
+	}
+	reaction, post := drops[0], drops[1]
+	if post.Layer != echoLayerPuppet || post.Event != string(model.WebsocketEventPosted) || post.PostID != "p1" || post.ChannelID != "ch1" || post.UserID != "puppet-id" || post.Time.IsZero() {
+		t.Errorf("post drop = %+v", post)
+	}
+	if reaction.Layer != echoLayerBridgeUsername || reaction.Event != string(model.WebsocketEventReactionAdded) || reaction.Username != "mattermost_bob" || reaction.Emoji != "+1" {
+		t.Errorf("reaction drop = %+v", reaction)
+	}
+}
+
+func TestHandleEchoDrops(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		method   string
+		query    string
+		disabled bool
+		status   int
+		want     int
+	}{
+		{"all", http.MethodGet, "", false, http.StatusOK, 3},
+		{"limit", http.MethodGet, "?limit=2", false, http.StatusOK, 2},
+		{"bad limit", http.MethodGet, "?limit=0", false, http.StatusBadRequest, 0},
+		{"disabled", http.MethodGet, "", true, http.StatusNotFound, 0},
+		{"wrong method", http.MethodPost, "", false, http.StatusMethodNotAllowed, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := &MattermostConnector{Bridge: &bridgev2.Bridge{}}
+			if !tt.disabled {
+				mc.echoDrops = newEchoDropLog(5)
+				for _, id := range []string{"p1", "p2", "p3"} {
+					mc.echoDrops.add(EchoDrop{Layer: echoLayerOwnUser, PostID: id})
+				}
+			}
+			rec := httptest.NewRecorder()
+			mc.HandleEchoDrops(rec, httptest.NewRequest(tt.method, "/api/debug/echo-drops"+tt.query, nil))
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Drops []EchoDrop `json:"drops"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(resp.Drops) != tt.want || resp.Drops[0].PostID != "p3" {
+				t.Errorf("got drops %+v, want %d newest first", resp.Drops, tt.want)
+			}
+		})
+	}
+}
+
+func TestFnEchoDrops(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		disabled bool
+		args     []string
+		want     []string
+	}{
+		{"disabled", true, nil, []string{"disabled"}},
+		{"list", false, nil, []string{"`puppet` posted post `p1` in `ch1` by `puppet-id`", "`bridge_username` reaction_added post `p1` in `ch1` by @mattermost_bob :+1:"}},
+		{"count", false, []string{"1"}, []string{"reaction_added"}},
+		{"bad count", false, []string{"x"}, []string{"Usage"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := &MattermostConnector{Bridge: &bridgev2.Bridge{Config: &bridgeconfig.BridgeConfig{}}}
+			if !tt.disabled {
+				mc.echoDrops = newEchoDropLog(5)
+				mc.echoDrops.add(EchoDrop{Layer: echoLayerPuppet, Event: "posted", ChannelID: "ch1", PostID: "p1", UserID: "puppet-id"})
+				mc.echoDrops.add(EchoDrop{Layer: echoLayerBridgeUsername, Event: "reaction_added", ChannelID: "ch1", PostID: "p1", Username: "mattermost_bob", Emoji: "+1"})
+			}
+			bot := &fakeReplyBot{}
+			log := zerolog.Nop()
+			mc.fnEchoDrops(&commands.Event{Bot: bot, Bridge: mc.Bridge, Args: tt.args, Ctx: context.Background(), Log: &log})
+			reply := bot.lastReply()
+			for _, want := range tt.want {
+				if !strings.Contains(reply, want) {
+					t.Errorf("reply %q doesn't contain %q", reply, want)
+				}
+			}
+			if tt.name == "count" && strings.Contains(reply, "posted") {
+				t.Errorf("count 1: reply %q lists more than one drop", reply)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/id"
+)
+
+// Post props the bridge stamps on the posts it creates from Matrix events.
+const (
+	// postPropFromBridge names the bridge that created the post. Posts
+	// carrying this bridge's marker are echoes, whoever posted them.
+	postPropFromBridge = "from_bridge"
+	// postPropMatrixEventID is the ID of the Matrix event the post was
+	// created from.
+	postPropMatrixEventID = "matrix_event_id"
+	// bridgePostMarker prefixes the value of postPropFromBridge, which
+	// postMarker scopes to the bridge instance.
+	bridgePostMarker = "mautrix-mattermost"
+)
+
+// postMarker returns the value of postPropFromBridge for this bridge:
+// bridgePostMarker and the appservice bot's MXID, so that two bridges on
+// the same Mattermost server don't drop each other's posts as echoes.
+// Without a running bridge it's bridgePostMarker alone.
+func (mc *MattermostConnector) postMarker() string {
+	if mc.Bridge == nil || mc.Bridge.Bot == nil {
+		return bridgePostMarker
+	}
+	return bridgePostMarker + ":" + mc.Bridge.Bot.GetMXID().String()
+}
+
+// markBridgePost stamps a post created from a Matrix event with the bridge
+// marker, so its echo is recognized by the post itself rather than by who
+// posted it. eventID may be empty.
+func (mc *MattermostConnector) markBridgePost(post *model.Post, eventID id.EventID) {
+	post.AddProp(postPropFromBridge, mc.postMarker())
+	if eventID != "" {
+		post.AddProp(postPropMatrixEventID, eventID.String())
+	}
+}
+
+// isBridgeMarkedPost reports whether a post carries this bridge's marker.
+func (mc *MattermostConnector) isBridgeMarkedPost(post *model.Post) bool {
+	marker, _ := post.GetProp(postPropFromBridge).(string)
+	return marker == mc.postMarker()
+}
+
+// isMarkerEcho reports whether a post is an echo by the bridge marker, the
+// first echo prevention layer, and records the drop under event, the
+// Mattermost event type.
+func (m *MattermostClient) isMarkerEcho(post *model.Post, event string) bool {
+	if !m.connector.isBridgeMarkedPost(post) {
+		return false
+	}
+	m.log.Debug().
+		Str("post_id", post.Id).
+		Str("user_id", post.UserId).
+		Msg("Skipping bridge-marked post (echo prevention)")
+	m.postEchoDropped(echoLayerBridgeMarker, event, post, "")
+	return true
+}
@@ -0,0 +1,119 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+)
+
+func TestMarkBridgePost(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Bridge.Bot = fakeAliasBot{}
+	if got, want := mc.postMarker(), "mautrix-mattermost:@bot:example.com"; got != want {
+		t.Errorf("postMarker() = %q, want %q", got, want)
+	}
+
+	post := &model.Post{}
+	mc.markBridgePost(post, "$evt1:example.com")
+	if !mc.isBridgeMarkedPost(post) {
+		t.Error("marked post should be recognized")
+	}
+	if got := post.GetProp(postPropMatrixEventID); got != "$evt1:example.com" {
+		t.Errorf("matrix_event_id: got %v", got)
+	}
+
+	unknown := &model.Post{}
+	mc.markBridgePost(unknown, "")
+	if unknown.GetProp(postPropMatrixEventID) != nil {
+		t.Error("matrix_event_id should be left out without an event")
+	}
+
+	for _, marker := range []string{"another-bridge", bridgePostMarker, bridgePostMarker + ":@otherbot:example.com"} {
+		other := &model.Post{}
+		other.AddProp(postPropFromBridge, marker)
+		if mc.isBridgeMarkedPost(other) {
+			t.Errorf("marker %q of another bridge counts as ours", marker)
+		}
+	}
+	if mc.isBridgeMarkedPost(&model.Post{}) {
+		t.Error("unmarked post counts as marked")
+	}
+}
+
+func TestParsePostEvents_BridgeMarker(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		event model.WebsocketEventType
+		parse func(*MattermostClient, *model.WebSocketEvent) (*model.Post, error)
+	}{
+		{model.WebsocketEventPosted, (*MattermostClient).parsePostedEvent},
+		{model.WebsocketEventPostEdited, (*MattermostClient).parsePostEditedEvent},
+		{model.WebsocketEventPostDeleted, (*MattermostClient).parsePostDeletedEvent},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.event), func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mc.connector.echoDrops = newEchoDropLog(10)
+
+			// Neither a puppet, the logged-in user nor a bridge username: only
+			// the marker tells the post is an echo.
+			post := &model.Post{Id: "p1", UserId: "other-user", ChannelId: "ch1", Message: "hello"}
+			mc.connector.markBridgePost(post, "$evt1:example.com")
+			postJSON, _ := json.Marshal(post)
+			evt := newWebSocketEvent(tt.event, "ch1", map[string]any{
+				"post":        string(postJSON),
+				"sender_name": "@alice",
+			})
+
+			got, err := tt.parse(mc, evt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != nil {
+				t.Error("bridge-marked posts should be dropped")
+			}
+			drops := mc.connector.echoDrops.recent(0)
+			if len(drops) != 1 || drops[0].Layer != echoLayerBridgeMarker || drops[0].Event != string(tt.event) {
+				t.Errorf("echo drops: got %+v", drops)
+			}
+		})
+	}
+}
+
+func TestHandleMatrixMessage_MarksPost(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	fm.TokenToUser["test-token"] = "my-user-id"
+	mc := newFullTestClient(fm.Server.URL)
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Event:   &event.Event{ID: "$evt1:example.com", Sender: "@alice:example.com"},
+			Portal:  makeTestPortal("test-channel"),
+			Content: &event.MessageEventContent{MsgType: event.MsgText, Body: "hello"},
+		},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	post := lastCreatedPost(t, fm)
+	if post.GetProp(postPropFromBridge) != mc.connector.postMarker() {
+		t.Errorf("from_bridge: got %v", post.GetProp(postPropFromBridge))
+	}
+	if post.GetProp(postPropMatrixEventID) != "$evt1:example.com" {
+		t.Errorf("matrix_event_id: got %v", post.GetProp(postPropMatrixEventID))
+	}
+}
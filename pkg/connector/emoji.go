@@ -0,0 +1,134 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:generate go run emoji_gen.go
+
+// variationSelector16 requests the emoji presentation of the character
+// before it. Clients don't agree on sending it, so lookups ignore it.
+const variationSelector16 = "\ufe0f"
+
+// emojiOverrides are mappings between Mattermost emoji names and Unicode
+// emoji loaded from emoji_overrides_file, e.g. for custom Mattermost emoji
+// that stand for a Unicode one. They take precedence over the Mattermost
+// system emoji in both directions. A nil *emojiOverrides has none.
+type emojiOverrides struct {
+	byName map[string]string
+	names  map[string]string
+}
+
+// loadEmojiOverrides reads an emoji override file: a YAML mapping of
+// Mattermost emoji names to Unicode emoji. When several names map to the
+// same emoji, reactions from Matrix use the first.
+func loadEmojiOverrides(path string) (*emojiOverrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read emoji overrides: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse emoji overrides: %w", err)
+	}
+	overrides := &emojiOverrides{byName: make(map[string]string), names: make(map[string]string)}
+	if len(doc.Content) == 0 {
+		return overrides, nil
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("emoji overrides must be a mapping of emoji names to emoji")
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		name, emoji := mapping.Content[i].Value, strings.TrimSpace(mapping.Content[i+1].Value)
+		if name == "" || strings.ContainsAny(name, ": \t\n") {
+			return nil, fmt.Errorf("invalid emoji name %q on line %d of emoji overrides", name, mapping.Content[i].Line)
+		}
+		if emoji == "" || mapping.Content[i+1].Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("emoji %q on line %d of emoji overrides has no Unicode emoji", name, mapping.Content[i].Line)
+		}
+		overrides.byName[name] = emoji
+		key := strings.ReplaceAll(emoji, variationSelector16, "")
+		if _, ok := overrides.names[key]; !ok {
+			overrides.names[key] = name
+		}
+	}
+	return overrides, nil
+}
+
+// emojiOverrides returns the configured emoji overrides, nil if there are
+// none.
+func (mc *MattermostConnector) emojiOverrides() *emojiOverrides {
+	return mc.Config.emojiOverrides
+}
+
+// reactionToEmoji converts a Mattermost emoji name to a Unicode emoji.
+// Names without one, like custom emoji, become ":name:".
+func (o *emojiOverrides) reactionToEmoji(name string) string {
+	if o != nil {
+		if emoji, ok := o.byName[name]; ok {
+			return emoji
+		}
+	}
+	if emoji, ok := emojiByName[name]; ok {
+		return emoji
+	}
+	return fmt.Sprintf(":%s:", name)
+}
+
+// emojiToReaction converts a Matrix reaction key to a Mattermost emoji name:
+// Unicode emoji to their name, ":name:" to name. Other keys are returned
+// as they are.
+func (o *emojiOverrides) emojiToReaction(emoji string) string {
+	key := strings.ReplaceAll(emoji, variationSelector16, "")
+	if o != nil {
+		if name, ok := o.names[key]; ok {
+			return name
+		}
+	}
+	if name, ok := emojiNames[key]; ok {
+		return name
+	}
+
+	// Strip colons for custom emoji names.
+	if len(emoji) > 2 && emoji[0] == ':' && emoji[len(emoji)-1] == ':' {
+		return emoji[1 : len(emoji)-1]
+	}
+
+	return emoji
+}
+
+// reactionMatches reports whether a Matrix reaction key stands for a
+// Mattermost emoji name. The conversions between the two aren't inverses,
+// e.g. for emoji with several Mattermost names, so both directions are
+// tried.
+func (o *emojiOverrides) reactionMatches(key, emojiName string) bool {
+	return o.emojiToReaction(key) == emojiName || o.reactionToEmoji(emojiName) == key
+}
+
+// reactionToEmoji converts a Mattermost emoji name to a Unicode emoji,
+// without overrides.
+func reactionToEmoji(name string) string {
+	return (*emojiOverrides)(nil).reactionToEmoji(name)
+}
+
+// emojiToReaction converts a Unicode emoji to a Mattermost emoji name,
+// without overrides.
+func emojiToReaction(emoji string) string {
+	return (*emojiOverrides)(nil).emojiToReaction(emoji)
+}
+
+// reactionMatches reports whether a Matrix reaction key stands for a
+// Mattermost emoji name, without overrides.
+func reactionMatches(key, emojiName string) bool {
+	return (*emojiOverrides)(nil).reactionMatches(key, emojiName)
+}
@@ -0,0 +1,7775 @@
+// Code generated by emoji_gen.go from the Mattermost system emoji; DO NOT EDIT.
+
+package connector
+
+// emojiByName maps Mattermost system emoji names to Unicode emoji.
+var emojiByName = map[string]string{
+	"grinning":                      "\U0001f600",
+	"smiley":                        "\U0001f603",
+	"smile":                         "\U0001f604",
+	"grin":                          "\U0001f601",
+	"laughing":                      "\U0001f606",
+	"satisfied":                     "\U0001f606",
+	"sweat_smile":                   "\U0001f605",
+	"rolling_on_the_floor_laughing": "\U0001f923",
+	"rofl":                          "\U0001f923",
+	"joy":                           "\U0001f602",
+	"slightly_smiling_face":         "\U0001f642",
+	"upside_down_face":              "\U0001f643",
+	"wink":                          "\U0001f609",
+	"blush":                         "\U0001f60a",
+	"innocent":                      "\U0001f607",
+	"smiling_face_with_3_hearts":    "\U0001f970",
+	"heart_eyes":                    "\U0001f60d",
+	"star-struck":                   "\U0001f929",
+	"grinning_face_with_star_eyes":  "\U0001f929",
+	"kissing_heart":                 "\U0001f618",
+	"kissing":                       "\U0001f617",
+	"relaxed":                       "\u263a\ufe0f",
+	"kissing_closed_eyes":           "\U0001f61a",
+	"kissing_smiling_eyes":          "\U0001f619",
+	"smiling_face_with_tear":        "\U0001f972",
+	"yum":                           "\U0001f60b",
+	"stuck_out_tongue":              "\U0001f61b",
+	"stuck_out_tongue_winking_eye":  "\U0001f61c",
+	"zany_face":                     "\U0001f92a",
+	"grinning_face_with_one_large_and_one_small_eye": "\U0001f92a",
+	"stuck_out_tongue_closed_eyes":                   "\U0001f61d",
+	"money_mouth_face":                               "\U0001f911",
+	"hugging_face":                                   "\U0001f917",
+	"hugs":                                           "\U0001f917",
+	"face_with_hand_over_mouth":                      "\U0001f92d",
+	"smiling_face_with_smiling_eyes_and_hand_covering_mouth": "\U0001f92d",
+	"shushing_face":                         "\U0001f92b",
+	"face_with_finger_covering_closed_lips": "\U0001f92b",
+	"thinking_face":                         "\U0001f914",
+	"thinking":                              "\U0001f914",
+	"zipper_mouth_face":                     "\U0001f910",
+	"face_with_raised_eyebrow":              "\U0001f928",
+	"face_with_one_eyebrow_raised":          "\U0001f928",
+	"neutral_face":                          "\U0001f610",
+	"expressionless":                        "\U0001f611",
+	"no_mouth":                              "\U0001f636",
+	"smirk":                                 "\U0001f60f",
+	"unamused":                              "\U0001f612",
+	"face_with_rolling_eyes":                "\U0001f644",
+	"roll_eyes":                             "\U0001f644",
+	"grimacing":                             "\U0001f62c",
+	"lying_face":                            "\U0001f925",
+	"relieved":                              "\U0001f60c",
+	"pensive":                               "\U0001f614",
+	"sleepy":                                "\U0001f62a",
+	"drooling_face":                         "\U0001f924",
+	"sleeping":                              "\U0001f634",
+	"mask":                                  "\U0001f637",
+	"face_with_thermometer":                 "\U0001f912",
+	"face_with_head_bandage":                "\U0001f915",
+	"nauseated_face":                        "\U0001f922",
+	"face_vomiting":                         "\U0001f92e",
+	"face_with_open_mouth_vomiting":         "\U0001f92e",
+	"sneezing_face":                         "\U0001f927",
+	"hot_face":                              "\U0001f975",
+	"cold_face":                             "\U0001f976",
+	"woozy_face":                            "\U0001f974",
+	"dizzy_face":                            "\U0001f635",
+	"exploding_head":                        "\U0001f92f",
+	"shocked_face_with_exploding_head":      "\U0001f92f",
+	"face_with_cowboy_hat":                  "\U0001f920",
+	"cowboy_hat_face":                       "\U0001f920",
+	"partying_face":                         "\U0001f973",
+	"disguised_face":                        "\U0001f978",
+	"sunglasses":                            "\U0001f60e",
+	"nerd_face":                             "\U0001f913",
+	"face_with_monocle":                     "\U0001f9d0",
+	"confused":                              "\U0001f615",
+	"worried":                               "\U0001f61f",
+	"slightly_frowning_face":                "\U0001f641",
+	"white_frowning_face":                   "\u2639\ufe0f",
+	"frowning_face":                         "\u2639\ufe0f",
+	"open_mouth":                            "\U0001f62e",
+	"hushed":                                "\U0001f62f",
+	"astonished":                            "\U0001f632",
+	"flushed":                               "\U0001f633",
+	"pleading_face":                         "\U0001f97a",
+	"frowning":                              "\U0001f626",
+	"anguished":                             "\U0001f627",
+	"fearful":                               "\U0001f628",
+	"cold_sweat":                            "\U0001f630",
+	"disappointed_relieved":                 "\U0001f625",
+	"cry":                                   "\U0001f622",
+	"sob":                                   "\U0001f62d",
+	"scream":                                "\U0001f631",
+	"confounded":                            "\U0001f616",
+	"persevere":                             "\U0001f623",
+	"disappointed":                          "\U0001f61e",
+	"sweat":                                 "\U0001f613",
+	"weary":                                 "\U0001f629",
+	"tired_face":                            "\U0001f62b",
+	"yawning_face":                          "\U0001f971",
+	"triumph":                               "\U0001f624",
+	"rage":                                  "\U0001f621",
+	"pout":                                  "\U0001f621",
+	"angry":                                 "\U0001f620",
+	"face_with_symbols_on_mouth":            "\U0001f92c",
+	"serious_face_with_symbols_covering_mouth": "\U0001f92c",
+	"smiling_imp":                           "\U0001f608",
+	"imp":                                   "\U0001f47f",
+	"skull":                                 "\U0001f480",
+	"skull_and_crossbones":                  "\u2620\ufe0f",
+	"hankey":                                "\U0001f4a9",
+	"poop":                                  "\U0001f4a9",
+	"shit":                                  "\U0001f4a9",
+	"clown_face":                            "\U0001f921",
+	"japanese_ogre":                         "\U0001f479",
+	"japanese_goblin":                       "\U0001f47a",
+	"ghost":                                 "\U0001f47b",
+	"alien":                                 "\U0001f47d",
+	"space_invader":                         "\U0001f47e",
+	"robot_face":                            "\U0001f916",
+	"robot":                                 "\U0001f916",
+	"smiley_cat":                            "\U0001f63a",
+	"smile_cat":                             "\U0001f638",
+	"joy_cat":                               "\U0001f639",
+	"heart_eyes_cat":                        "\U0001f63b",
+	"smirk_cat":                             "\U0001f63c",
+	"kissing_cat":                           "\U0001f63d",
+	"scream_cat":                            "\U0001f640",
+	"crying_cat_face":                       "\U0001f63f",
+	"pouting_cat":                           "\U0001f63e",
+	"see_no_evil":                           "\U0001f648",
+	"hear_no_evil":                          "\U0001f649",
+	"speak_no_evil":                         "\U0001f64a",
+	"kiss":                                  "\U0001f48b",
+	"love_letter":                           "\U0001f48c",
+	"cupid":                                 "\U0001f498",
+	"gift_heart":                            "\U0001f49d",
+	"sparkling_heart":                       "\U0001f496",
+	"heartpulse":                            "\U0001f497",
+	"heartbeat":                             "\U0001f493",
+	"revolving_hearts":                      "\U0001f49e",
+	"two_hearts":                            "\U0001f495",
+	"heart_decoration":                      "\U0001f49f",
+	"heavy_heart_exclamation_mark_ornament": "\u2763\ufe0f",
+	"heavy_heart_exclamation":               "\u2763\ufe0f",
+	"broken_heart":                          "\U0001f494",
+	"heart":                                 "\u2764\ufe0f",
+	"orange_heart":                          "\U0001f9e1",
+	"yellow_heart":                          "\U0001f49b",
+	"green_heart":                           "\U0001f49a",
+	"blue_heart":                            "\U0001f499",
+	"purple_heart":                          "\U0001f49c",
+	"brown_heart":                           "\U0001f90e",
+	"black_heart":                           "\U0001f5a4",
+	"white_heart":                           "\U0001f90d",
+	"100":                                   "\U0001f4af",
+	"anger":                                 "\U0001f4a2",
+	"boom":                                  "\U0001f4a5",
+	"collision":                             "\U0001f4a5",
+	"dizzy":                                 "\U0001f4ab",
+	"sweat_drops":                           "\U0001f4a6",
+	"dash":                                  "\U0001f4a8",
+	"hole":                                  "\U0001f573\ufe0f",
+	"bomb":                                  "\U0001f4a3",
+	"speech_balloon":                        "\U0001f4ac",
+	"eye-in-speech-bubble":                  "\U0001f441\ufe0f\u200d\U0001f5e8\ufe0f",
+	"left_speech_bubble":                    "\U0001f5e8\ufe0f",
+	"right_anger_bubble":                    "\U0001f5ef\ufe0f",
+	"thought_balloon":                       "\U0001f4ad",
+	"zzz":                                   "\U0001f4a4",
+	"wave":                                  "\U0001f44b",
+	"raised_back_of_hand":                   "\U0001f91a",
+	"raised_hand_with_fingers_splayed":      "\U0001f590\ufe0f",
+	"hand":                                  "\u270b",
+	"raised_hand":                           "\u270b",
+	"spock-hand":                            "\U0001f596",
+	"vulcan_salute":                         "\U0001f596",
+	"ok_hand":                               "\U0001f44c",
+	"pinched_fingers":                       "\U0001f90c",
+	"pinching_hand":                         "\U0001f90f",
+	"v":                                     "\u270c\ufe0f",
+	"crossed_fingers":                       "\U0001f91e",
+	"hand_with_index_and_middle_fingers_crossed": "\U0001f91e",
+	"i_love_you_hand_sign":                       "\U0001f91f",
+	"the_horns":                                  "\U0001f918",
+	"sign_of_the_horns":                          "\U0001f918",
+	"metal":                                      "\U0001f918",
+	"call_me_hand":                               "\U0001f919",
+	"point_left":                                 "\U0001f448",
+	"point_right":                                "\U0001f449",
+	"point_up_2":                                 "\U0001f446",
+	"middle_finger":                              "\U0001f595",
+	"reversed_hand_with_middle_finger_extended": "\U0001f595",
+	"fu":                                "\U0001f595",
+	"point_down":                        "\U0001f447",
+	"point_up":                          "\u261d\ufe0f",
+	"+1":                                "\U0001f44d",
+	"thumbsup":                          "\U0001f44d",
+	"-1":                                "\U0001f44e",
+	"thumbsdown":                        "\U0001f44e",
+	"fist":                              "\u270a",
+	"fist_raised":                       "\u270a",
+	"facepunch":                         "\U0001f44a",
+	"punch":                             "\U0001f44a",
+	"fist_oncoming":                     "\U0001f44a",
+	"left-facing_fist":                  "\U0001f91b",
+	"fist_left":                         "\U0001f91b",
+	"right-facing_fist":                 "\U0001f91c",
+	"fist_right":                        "\U0001f91c",
+	"clap":                              "\U0001f44f",
+	"raised_hands":                      "\U0001f64c",
+	"open_hands":                        "\U0001f450",
+	"palms_up_together":                 "\U0001f932",
+	"handshake":                         "\U0001f91d",
+	"pray":                              "\U0001f64f",
+	"writing_hand":                      "\u270d\ufe0f",
+	"nail_care":                         "\U0001f485",
+	"selfie":                            "\U0001f933",
+	"muscle":                            "\U0001f4aa",
+	"mechanical_arm":                    "\U0001f9be",
+	"mechanical_leg":                    "\U0001f9bf",
+	"leg":                               "\U0001f9b5",
+	"foot":                              "\U0001f9b6",
+	"ear":                               "\U0001f442",
+	"ear_with_hearing_aid":              "\U0001f9bb",
+	"nose":                              "\U0001f443",
+	"brain":                             "\U0001f9e0",
+	"anatomical_heart":                  "\U0001fac0",
+	"lungs":                             "\U0001fac1",
+	"tooth":                             "\U0001f9b7",
+	"bone":                              "\U0001f9b4",
+	"eyes":                              "\U0001f440",
+	"eye":                               "\U0001f441\ufe0f",
+	"tongue":                            "\U0001f445",
+	"lips":                              "\U0001f444",
+	"baby":                              "\U0001f476",
+	"child":                             "\U0001f9d2",
+	"boy":                               "\U0001f466",
+	"girl":                              "\U0001f467",
+	"adult":                             "\U0001f9d1",
+	"person_with_blond_hair":            "\U0001f471",
+	"man":                               "\U0001f468",
+	"bearded_person":                    "\U0001f9d4",
+	"red_haired_man":                    "\U0001f468\u200d\U0001f9b0",
+	"curly_haired_man":                  "\U0001f468\u200d\U0001f9b1",
+	"white_haired_man":                  "\U0001f468\u200d\U0001f9b3",
+	"bald_man":                          "\U0001f468\u200d\U0001f9b2",
+	"woman":                             "\U0001f469",
+	"red_haired_woman":                  "\U0001f469\u200d\U0001f9b0",
+	"red_haired_person":                 "\U0001f9d1\u200d\U0001f9b0",
+	"curly_haired_woman":                "\U0001f469\u200d\U0001f9b1",
+	"curly_haired_person":               "\U0001f9d1\u200d\U0001f9b1",
+	"white_haired_woman":                "\U0001f469\u200d\U0001f9b3",
+	"white_haired_person":               "\U0001f9d1\u200d\U0001f9b3",
+	"bald_woman":                        "\U0001f469\u200d\U0001f9b2",
+	"bald_person":                       "\U0001f9d1\u200d\U0001f9b2",
+	"blond-haired-woman":                "\U0001f471\u200d\u2640\ufe0f",
+	"blonde_woman":                      "\U0001f471\u200d\u2640\ufe0f",
+	"blond-haired-man":                  "\U0001f471\u200d\u2642\ufe0f",
+	"blonde_man":                        "\U0001f471\u200d\u2642\ufe0f",
+	"older_adult":                       "\U0001f9d3",
+	"older_man":                         "\U0001f474",
+	"older_woman":                       "\U0001f475",
+	"person_frowning":                   "\U0001f64d",
+	"man-frowning":                      "\U0001f64d\u200d\u2642\ufe0f",
+	"frowning_man":                      "\U0001f64d\u200d\u2642\ufe0f",
+	"woman-frowning":                    "\U0001f64d\u200d\u2640\ufe0f",
+	"frowning_woman":                    "\U0001f64d\u200d\u2640\ufe0f",
+	"person_with_pouting_face":          "\U0001f64e",
+	"man-pouting":                       "\U0001f64e\u200d\u2642\ufe0f",
+	"pouting_man":                       "\U0001f64e\u200d\u2642\ufe0f",
+	"woman-pouting":                     "\U0001f64e\u200d\u2640\ufe0f",
+	"pouting_woman":                     "\U0001f64e\u200d\u2640\ufe0f",
+	"no_good":                           "\U0001f645",
+	"man-gesturing-no":                  "\U0001f645\u200d\u2642\ufe0f",
+	"ng_man":                            "\U0001f645\u200d\u2642\ufe0f",
+	"no_good_man":                       "\U0001f645\u200d\u2642\ufe0f",
+	"woman-gesturing-no":                "\U0001f645\u200d\u2640\ufe0f",
+	"no_good_woman":                     "\U0001f645\u200d\u2640\ufe0f",
+	"ng_woman":                          "\U0001f645\u200d\u2640\ufe0f",
+	"ok_woman":                          "\U0001f646",
+	"man-gesturing-ok":                  "\U0001f646\u200d\u2642\ufe0f",
+	"ok_man":                            "\U0001f646\u200d\u2642\ufe0f",
+	"woman-gesturing-ok":                "\U0001f646\u200d\u2640\ufe0f",
+	"information_desk_person":           "\U0001f481",
+	"man-tipping-hand":                  "\U0001f481\u200d\u2642\ufe0f",
+	"tipping_hand_man":                  "\U0001f481\u200d\u2642\ufe0f",
+	"woman-tipping-hand":                "\U0001f481\u200d\u2640\ufe0f",
+	"tipping_hand_woman":                "\U0001f481\u200d\u2640\ufe0f",
+	"raising_hand":                      "\U0001f64b",
+	"man-raising-hand":                  "\U0001f64b\u200d\u2642\ufe0f",
+	"raising_hand_man":                  "\U0001f64b\u200d\u2642\ufe0f",
+	"woman-raising-hand":                "\U0001f64b\u200d\u2640\ufe0f",
+	"raising_hand_woman":                "\U0001f64b\u200d\u2640\ufe0f",
+	"deaf_person":                       "\U0001f9cf",
+	"deaf_man":                          "\U0001f9cf\u200d\u2642\ufe0f",
+	"deaf_woman":                        "\U0001f9cf\u200d\u2640\ufe0f",
+	"bow":                               "\U0001f647",
+	"man-bowing":                        "\U0001f647\u200d\u2642\ufe0f",
+	"bowing_man":                        "\U0001f647\u200d\u2642\ufe0f",
+	"woman-bowing":                      "\U0001f647\u200d\u2640\ufe0f",
+	"bowing_woman":                      "\U0001f647\u200d\u2640\ufe0f",
+	"face_palm":                         "\U0001f926",
+	"man-facepalming":                   "\U0001f926\u200d\u2642\ufe0f",
+	"man_facepalming":                   "\U0001f926\u200d\u2642\ufe0f",
+	"woman-facepalming":                 "\U0001f926\u200d\u2640\ufe0f",
+	"woman_facepalming":                 "\U0001f926\u200d\u2640\ufe0f",
+	"shrug":                             "\U0001f937",
+	"man-shrugging":                     "\U0001f937\u200d\u2642\ufe0f",
+	"man_shrugging":                     "\U0001f937\u200d\u2642\ufe0f",
+	"woman-shrugging":                   "\U0001f937\u200d\u2640\ufe0f",
+	"woman_shrugging":                   "\U0001f937\u200d\u2640\ufe0f",
+	"health_worker":                     "\U0001f9d1\u200d\u2695\ufe0f",
+	"doctor":                            "\U0001f9d1\u200d\u2695\ufe0f",
+	"male-doctor":                       "\U0001f468\u200d\u2695\ufe0f",
+	"man_health_worker":                 "\U0001f468\u200d\u2695\ufe0f",
+	"female-doctor":                     "\U0001f469\u200d\u2695\ufe0f",
+	"woman_health_worker":               "\U0001f469\u200d\u2695\ufe0f",
+	"student":                           "\U0001f9d1\u200d\U0001f393",
+	"male-student":                      "\U0001f468\u200d\U0001f393",
+	"man_student":                       "\U0001f468\u200d\U0001f393",
+	"female-student":                    "\U0001f469\u200d\U0001f393",
+	"woman_student":                     "\U0001f469\u200d\U0001f393",
+	"teacher":                           "\U0001f9d1\u200d\U0001f3eb",
+	"male-teacher":                      "\U0001f468\u200d\U0001f3eb",
+	"man_teacher":                       "\U0001f468\u200d\U0001f3eb",
+	"female-teacher":                    "\U0001f469\u200d\U0001f3eb",
+	"woman_teacher":                     "\U0001f469\u200d\U0001f3eb",
+	"judge":                             "\U0001f9d1\u200d\u2696\ufe0f",
+	"male-judge":                        "\U0001f468\u200d\u2696\ufe0f",
+	"man_judge":                         "\U0001f468\u200d\u2696\ufe0f",
+	"female-judge":                      "\U0001f469\u200d\u2696\ufe0f",
+	"woman_judge":                       "\U0001f469\u200d\u2696\ufe0f",
+	"farmer":                            "\U0001f9d1\u200d\U0001f33e",
+	"male-farmer":                       "\U0001f468\u200d\U0001f33e",
+	"man_farmer":                        "\U0001f468\u200d\U0001f33e",
+	"female-farmer":                     "\U0001f469\u200d\U0001f33e",
+	"woman_farmer":                      "\U0001f469\u200d\U0001f33e",
+	"cook":                              "\U0001f9d1\u200d\U0001f373",
+	"male-cook":                         "\U0001f468\u200d\U0001f373",
+	"man_cook":                          "\U0001f468\u200d\U0001f373",
+	"female-cook":                       "\U0001f469\u200d\U0001f373",
+	"woman_cook":                        "\U0001f469\u200d\U0001f373",
+	"mechanic":                          "\U0001f9d1\u200d\U0001f527",
+	"male-mechanic":                     "\U0001f468\u200d\U0001f527",
+	"man_mechanic":                      "\U0001f468\u200d\U0001f527",
+	"female-mechanic":                   "\U0001f469\u200d\U0001f527",
+	"woman_mechanic":                    "\U0001f469\u200d\U0001f527",
+	"factory_worker":                    "\U0001f9d1\u200d\U0001f3ed",
+	"male-factory-worker":               "\U0001f468\u200d\U0001f3ed",
+	"man_factory_worker":                "\U0001f468\u200d\U0001f3ed",
+	"female-factory-worker":             "\U0001f469\u200d\U0001f3ed",
+	"woman_factory_worker":              "\U0001f469\u200d\U0001f3ed",
+	"office_worker":                     "\U0001f9d1\u200d\U0001f4bc",
+	"male-office-worker":                "\U0001f468\u200d\U0001f4bc",
+	"man_office_worker":                 "\U0001f468\u200d\U0001f4bc",
+	"female-office-worker":              "\U0001f469\u200d\U0001f4bc",
+	"woman_office_worker":               "\U0001f469\u200d\U0001f4bc",
+	"scientist":                         "\U0001f9d1\u200d\U0001f52c",
+	"male-scientist":                    "\U0001f468\u200d\U0001f52c",
+	"man_scientist":                     "\U0001f468\u200d\U0001f52c",
+	"female-scientist":                  "\U0001f469\u200d\U0001f52c",
+	"woman_scientist":                   "\U0001f469\u200d\U0001f52c",
+	"technologist":                      "\U0001f9d1\u200d\U0001f4bb",
+	"male-technologist":                 "\U0001f468\u200d\U0001f4bb",
+	"man_technologist":                  "\U0001f468\u200d\U0001f4bb",
+	"female-technologist":               "\U0001f469\u200d\U0001f4bb",
+	"woman_technologist":                "\U0001f469\u200d\U0001f4bb",
+	"singer":                            "\U0001f9d1\u200d\U0001f3a4",
+	"male-singer":                       "\U0001f468\u200d\U0001f3a4",
+	"man_singer":                        "\U0001f468\u200d\U0001f3a4",
+	"female-singer":                     "\U0001f469\u200d\U0001f3a4",
+	"woman_singer":                      "\U0001f469\u200d\U0001f3a4",
+	"artist":                            "\U0001f9d1\u200d\U0001f3a8",
+	"male-artist":                       "\U0001f468\u200d\U0001f3a8",
+	"man_artist":                        "\U0001f468\u200d\U0001f3a8",
+	"female-artist":                     "\U0001f469\u200d\U0001f3a8",
+	"woman_artist":                      "\U0001f469\u200d\U0001f3a8",
+	"pilot":                             "\U0001f9d1\u200d\u2708\ufe0f",
+	"male-pilot":                        "\U0001f468\u200d\u2708\ufe0f",
+	"man_pilot":                         "\U0001f468\u200d\u2708\ufe0f",
+	"female-pilot":                      "\U0001f469\u200d\u2708\ufe0f",
+	"woman_pilot":                       "\U0001f469\u200d\u2708\ufe0f",
+	"astronaut":                         "\U0001f9d1\u200d\U0001f680",
+	"male-astronaut":                    "\U0001f468\u200d\U0001f680",
+	"man_astronaut":                     "\U0001f468\u200d\U0001f680",
+	"female-astronaut":                  "\U0001f469\u200d\U0001f680",
+	"woman_astronaut":                   "\U0001f469\u200d\U0001f680",
+	"firefighter":                       "\U0001f9d1\u200d\U0001f692",
+	"male-firefighter":                  "\U0001f468\u200d\U0001f692",
+	"man_firefighter":                   "\U0001f468\u200d\U0001f692",
+	"female-firefighter":                "\U0001f469\u200d\U0001f692",
+	"woman_firefighter":                 "\U0001f469\u200d\U0001f692",
+	"cop":                               "\U0001f46e",
+	"male-police-officer":               "\U0001f46e\u200d\u2642\ufe0f",
+	"policeman":                         "\U0001f46e\u200d\u2642\ufe0f",
+	"female-police-officer":             "\U0001f46e\u200d\u2640\ufe0f",
+	"policewoman":                       "\U0001f46e\u200d\u2640\ufe0f",
+	"sleuth_or_spy":                     "\U0001f575\ufe0f",
+	"detective":                         "\U0001f575\ufe0f",
+	"male-detective":                    "\U0001f575\ufe0f\u200d\u2642\ufe0f",
+	"male_detective":                    "\U0001f575\ufe0f\u200d\u2642\ufe0f",
+	"female-detective":                  "\U0001f575\ufe0f\u200d\u2640\ufe0f",
+	"female_detective":                  "\U0001f575\ufe0f\u200d\u2640\ufe0f",
+	"guardsman":                         "\U0001f482",
+	"male-guard":                        "\U0001f482\u200d\u2642\ufe0f",
+	"female-guard":                      "\U0001f482\u200d\u2640\ufe0f",
+	"guardswoman":                       "\U0001f482\u200d\u2640\ufe0f",
+	"ninja":                             "\U0001f977",
+	"construction_worker":               "\U0001f477",
+	"male-construction-worker":          "\U0001f477\u200d\u2642\ufe0f",
+	"construction_worker_man":           "\U0001f477\u200d\u2642\ufe0f",
+	"female-construction-worker":        "\U0001f477\u200d\u2640\ufe0f",
+	"construction_worker_woman":         "\U0001f477\u200d\u2640\ufe0f",
+	"prince":                            "\U0001f934",
+	"princess":                          "\U0001f478",
+	"man_with_turban":                   "\U0001f473",
+	"man-wearing-turban":                "\U0001f473\u200d\u2642\ufe0f",
+	"woman-wearing-turban":              "\U0001f473\u200d\u2640\ufe0f",
+	"woman_with_turban":                 "\U0001f473\u200d\u2640\ufe0f",
+	"man_with_gua_pi_mao":               "\U0001f472",
+	"person_with_headscarf":             "\U0001f9d5",
+	"person_in_tuxedo":                  "\U0001f935",
+	"man_in_tuxedo":                     "\U0001f935\u200d\u2642\ufe0f",
+	"woman_in_tuxedo":                   "\U0001f935\u200d\u2640\ufe0f",
+	"bride_with_veil":                   "\U0001f470",
+	"man_with_veil":                     "\U0001f470\u200d\u2642\ufe0f",
+	"woman_with_veil":                   "\U0001f470\u200d\u2640\ufe0f",
+	"pregnant_woman":                    "\U0001f930",
+	"breast-feeding":                    "\U0001f931",
+	"woman_feeding_baby":                "\U0001f469\u200d\U0001f37c",
+	"man_feeding_baby":                  "\U0001f468\u200d\U0001f37c",
+	"person_feeding_baby":               "\U0001f9d1\u200d\U0001f37c",
+	"angel":                             "\U0001f47c",
+	"santa":                             "\U0001f385",
+	"mrs_claus":                         "\U0001f936",
+	"mother_christmas":                  "\U0001f936",
+	"mx_claus":                          "\U0001f9d1\u200d\U0001f384",
+	"superhero":                         "\U0001f9b8",
+	"male_superhero":                    "\U0001f9b8\u200d\u2642\ufe0f",
+	"female_superhero":                  "\U0001f9b8\u200d\u2640\ufe0f",
+	"supervillain":                      "\U0001f9b9",
+	"male_supervillain":                 "\U0001f9b9\u200d\u2642\ufe0f",
+	"female_supervillain":               "\U0001f9b9\u200d\u2640\ufe0f",
+	"mage":                              "\U0001f9d9",
+	"male_mage":                         "\U0001f9d9\u200d\u2642\ufe0f",
+	"female_mage":                       "\U0001f9d9\u200d\u2640\ufe0f",
+	"fairy":                             "\U0001f9da",
+	"male_fairy":                        "\U0001f9da\u200d\u2642\ufe0f",
+	"female_fairy":                      "\U0001f9da\u200d\u2640\ufe0f",
+	"vampire":                           "\U0001f9db",
+	"male_vampire":                      "\U0001f9db\u200d\u2642\ufe0f",
+	"female_vampire":                    "\U0001f9db\u200d\u2640\ufe0f",
+	"merperson":                         "\U0001f9dc",
+	"merman":                            "\U0001f9dc\u200d\u2642\ufe0f",
+	"mermaid":                           "\U0001f9dc\u200d\u2640\ufe0f",
+	"elf":                               "\U0001f9dd",
+	"male_elf":                          "\U0001f9dd\u200d\u2642\ufe0f",
+	"female_elf":                        "\U0001f9dd\u200d\u2640\ufe0f",
+	"genie":                             "\U0001f9de",
+	"male_genie":                        "\U0001f9de\u200d\u2642\ufe0f",
+	"female_genie":                      "\U0001f9de\u200d\u2640\ufe0f",
+	"zombie":                            "\U0001f9df",
+	"male_zombie":                       "\U0001f9df\u200d\u2642\ufe0f",
+	"female_zombie":                     "\U0001f9df\u200d\u2640\ufe0f",
+	"massage":                           "\U0001f486",
+	"man-getting-massage":               "\U0001f486\u200d\u2642\ufe0f",
+	"massage_man":                       "\U0001f486\u200d\u2642\ufe0f",
+	"woman-getting-massage":             "\U0001f486\u200d\u2640\ufe0f",
+	"massage_woman":                     "\U0001f486\u200d\u2640\ufe0f",
+	"haircut":                           "\U0001f487",
+	"man-getting-haircut":               "\U0001f487\u200d\u2642\ufe0f",
+	"haircut_man":                       "\U0001f487\u200d\u2642\ufe0f",
+	"woman-getting-haircut":             "\U0001f487\u200d\u2640\ufe0f",
+	"haircut_woman":                     "\U0001f487\u200d\u2640\ufe0f",
+	"walking":                           "\U0001f6b6",
+	"man-walking":                       "\U0001f6b6\u200d\u2642\ufe0f",
+	"walking_man":                       "\U0001f6b6\u200d\u2642\ufe0f",
+	"woman-walking":                     "\U0001f6b6\u200d\u2640\ufe0f",
+	"walking_woman":                     "\U0001f6b6\u200d\u2640\ufe0f",
+	"standing_person":                   "\U0001f9cd",
+	"man_standing":                      "\U0001f9cd\u200d\u2642\ufe0f",
+	"woman_standing":                    "\U0001f9cd\u200d\u2640\ufe0f",
+	"kneeling_person":                   "\U0001f9ce",
+	"man_kneeling":                      "\U0001f9ce\u200d\u2642\ufe0f",
+	"woman_kneeling":                    "\U0001f9ce\u200d\u2640\ufe0f",
+	"person_with_probing_cane":          "\U0001f9d1\u200d\U0001f9af",
+	"man_with_probing_cane":             "\U0001f468\u200d\U0001f9af",
+	"woman_with_probing_cane":           "\U0001f469\u200d\U0001f9af",
+	"person_in_motorized_wheelchair":    "\U0001f9d1\u200d\U0001f9bc",
+	"man_in_motorized_wheelchair":       "\U0001f468\u200d\U0001f9bc",
+	"woman_in_motorized_wheelchair":     "\U0001f469\u200d\U0001f9bc",
+	"person_in_manual_wheelchair":       "\U0001f9d1\u200d\U0001f9bd",
+	"man_in_manual_wheelchair":          "\U0001f468\u200d\U0001f9bd",
+	"woman_in_manual_wheelchair":        "\U0001f469\u200d\U0001f9bd",
+	"runner":                            "\U0001f3c3",
+	"running":                           "\U0001f3c3",
+	"man-running":                       "\U0001f3c3\u200d\u2642\ufe0f",
+	"running_man":                       "\U0001f3c3\u200d\u2642\ufe0f",
+	"woman-running":                     "\U0001f3c3\u200d\u2640\ufe0f",
+	"running_woman":                     "\U0001f3c3\u200d\u2640\ufe0f",
+	"dancer":                            "\U0001f483",
+	"man_dancing":                       "\U0001f57a",
+	"man_in_business_suit_levitating":   "\U0001f574\ufe0f",
+	"business_suit_levitating":          "\U0001f574\ufe0f",
+	"dancers":                           "\U0001f46f",
+	"man-with-bunny-ears-partying":      "\U0001f46f\u200d\u2642\ufe0f",
+	"dancing_men":                       "\U0001f46f\u200d\u2642\ufe0f",
+	"woman-with-bunny-ears-partying":    "\U0001f46f\u200d\u2640\ufe0f",
+	"dancing_women":                     "\U0001f46f\u200d\u2640\ufe0f",
+	"person_in_steamy_room":             "\U0001f9d6",
+	"man_in_steamy_room":                "\U0001f9d6\u200d\u2642\ufe0f",
+	"woman_in_steamy_room":              "\U0001f9d6\u200d\u2640\ufe0f",
+	"person_climbing":                   "\U0001f9d7",
+	"man_climbing":                      "\U0001f9d7\u200d\u2642\ufe0f",
+	"woman_climbing":                    "\U0001f9d7\u200d\u2640\ufe0f",
+	"fencer":                            "\U0001f93a",
+	"person_fencing":                    "\U0001f93a",
+	"horse_racing":                      "\U0001f3c7",
+	"skier":                             "\u26f7\ufe0f",
+	"snowboarder":                       "\U0001f3c2",
+	"golfer":                            "\U0001f3cc\ufe0f",
+	"man-golfing":                       "\U0001f3cc\ufe0f\u200d\u2642\ufe0f",
+	"golfing_man":                       "\U0001f3cc\ufe0f\u200d\u2642\ufe0f",
+	"woman-golfing":                     "\U0001f3cc\ufe0f\u200d\u2640\ufe0f",
+	"golfing_woman":                     "\U0001f3cc\ufe0f\u200d\u2640\ufe0f",
+	"surfer":                            "\U0001f3c4",
+	"man-surfing":                       "\U0001f3c4\u200d\u2642\ufe0f",
+	"surfing_man":                       "\U0001f3c4\u200d\u2642\ufe0f",
+	"woman-surfing":                     "\U0001f3c4\u200d\u2640\ufe0f",
+	"surfing_woman":                     "\U0001f3c4\u200d\u2640\ufe0f",
+	"rowboat":                           "\U0001f6a3",
+	"man-rowing-boat":                   "\U0001f6a3\u200d\u2642\ufe0f",
+	"rowing_man":                        "\U0001f6a3\u200d\u2642\ufe0f",
+	"woman-rowing-boat":                 "\U0001f6a3\u200d\u2640\ufe0f",
+	"rowing_woman":                      "\U0001f6a3\u200d\u2640\ufe0f",
+	"swimmer":                           "\U0001f3ca",
+	"man-swimming":                      "\U0001f3ca\u200d\u2642\ufe0f",
+	"swimming_man":                      "\U0001f3ca\u200d\u2642\ufe0f",
+	"woman-swimming":                    "\U0001f3ca\u200d\u2640\ufe0f",
+	"swimming_woman":                    "\U0001f3ca\u200d\u2640\ufe0f",
+	"person_with_ball":                  "\u26f9\ufe0f",
+	"man-bouncing-ball":                 "\u26f9\ufe0f\u200d\u2642\ufe0f",
+	"basketball_man":                    "\u26f9\ufe0f\u200d\u2642\ufe0f",
+	"woman-bouncing-ball":               "\u26f9\ufe0f\u200d\u2640\ufe0f",
+	"basketball_woman":                  "\u26f9\ufe0f\u200d\u2640\ufe0f",
+	"weight_lifter":                     "\U0001f3cb\ufe0f",
+	"man-lifting-weights":               "\U0001f3cb\ufe0f\u200d\u2642\ufe0f",
+	"weight_lifting_man":                "\U0001f3cb\ufe0f\u200d\u2642\ufe0f",
+	"woman-lifting-weights":             "\U0001f3cb\ufe0f\u200d\u2640\ufe0f",
+	"weight_lifting_woman":              "\U0001f3cb\ufe0f\u200d\u2640\ufe0f",
+	"bicyclist":                         "\U0001f6b4",
+	"man-biking":                        "\U0001f6b4\u200d\u2642\ufe0f",
+	"biking_man":                        "\U0001f6b4\u200d\u2642\ufe0f",
+	"woman-biking":                      "\U0001f6b4\u200d\u2640\ufe0f",
+	"biking_woman":                      "\U0001f6b4\u200d\u2640\ufe0f",
+	"mountain_bicyclist":                "\U0001f6b5",
+	"man-mountain-biking":               "\U0001f6b5\u200d\u2642\ufe0f",
+	"mountain_biking_man":               "\U0001f6b5\u200d\u2642\ufe0f",
+	"woman-mountain-biking":             "\U0001f6b5\u200d\u2640\ufe0f",
+	"mountain_biking_woman":             "\U0001f6b5\u200d\u2640\ufe0f",
+	"person_doing_cartwheel":            "\U0001f938",
+	"man-cartwheeling":                  "\U0001f938\u200d\u2642\ufe0f",
+	"man_cartwheeling":                  "\U0001f938\u200d\u2642\ufe0f",
+	"woman-cartwheeling":                "\U0001f938\u200d\u2640\ufe0f",
+	"woman_cartwheeling":                "\U0001f938\u200d\u2640\ufe0f",
+	"wrestlers":                         "\U0001f93c",
+	"man-wrestling":                     "\U0001f93c\u200d\u2642\ufe0f",
+	"men_wrestling":                     "\U0001f93c\u200d\u2642\ufe0f",
+	"woman-wrestling":                   "\U0001f93c\u200d\u2640\ufe0f",
+	"women_wrestling":                   "\U0001f93c\u200d\u2640\ufe0f",
+	"water_polo":                        "\U0001f93d",
+	"man-playing-water-polo":            "\U0001f93d\u200d\u2642\ufe0f",
+	"man_playing_water_polo":            "\U0001f93d\u200d\u2642\ufe0f",
+	"woman-playing-water-polo":          "\U0001f93d\u200d\u2640\ufe0f",
+	"woman_playing_water_polo":          "\U0001f93d\u200d\u2640\ufe0f",
+	"handball":                          "\U0001f93e",
+	"man-playing-handball":              "\U0001f93e\u200d\u2642\ufe0f",
+	"man_playing_handball":              "\U0001f93e\u200d\u2642\ufe0f",
+	"woman-playing-handball":            "\U0001f93e\u200d\u2640\ufe0f",
+	"woman_playing_handball":            "\U0001f93e\u200d\u2640\ufe0f",
+	"juggling":                          "\U0001f939",
+	"man-juggling":                      "\U0001f939\u200d\u2642\ufe0f",
+	"man_juggling":                      "\U0001f939\u200d\u2642\ufe0f",
+	"woman-juggling":                    "\U0001f939\u200d\u2640\ufe0f",
+	"woman_juggling":                    "\U0001f939\u200d\u2640\ufe0f",
+	"person_in_lotus_position":          "\U0001f9d8",
+	"man_in_lotus_position":             "\U0001f9d8\u200d\u2642\ufe0f",
+	"woman_in_lotus_position":           "\U0001f9d8\u200d\u2640\ufe0f",
+	"bath":                              "\U0001f6c0",
+	"sleeping_accommodation":            "\U0001f6cc",
+	"sleeping_bed":                      "\U0001f6cc",
+	"people_holding_hands":              "\U0001f9d1\u200d\U0001f91d\u200d\U0001f9d1",
+	"two_women_holding_hands":           "\U0001f46d",
+	"women_holding_hands":               "\U0001f46d",
+	"man_and_woman_holding_hands":       "\U0001f46b",
+	"woman_and_man_holding_hands":       "\U0001f46b",
+	"couple":                            "\U0001f46b",
+	"two_men_holding_hands":             "\U0001f46c",
+	"men_holding_hands":                 "\U0001f46c",
+	"couplekiss":                        "\U0001f48f",
+	"woman-kiss-man":                    "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f48b\u200d\U0001f468",
+	"couplekiss_man_woman":              "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f48b\u200d\U0001f468",
+	"man-kiss-man":                      "\U0001f468\u200d\u2764\ufe0f\u200d\U0001f48b\u200d\U0001f468",
+	"couplekiss_man_man":                "\U0001f468\u200d\u2764\ufe0f\u200d\U0001f48b\u200d\U0001f468",
+	"woman-kiss-woman":                  "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f48b\u200d\U0001f469",
+	"couplekiss_woman_woman":            "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f48b\u200d\U0001f469",
+	"couple_with_heart":                 "\U0001f491",
+	"woman-heart-man":                   "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f468",
+	"couple_with_heart_woman_man":       "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f468",
+	"man-heart-man":                     "\U0001f468\u200d\u2764\ufe0f\u200d\U0001f468",
+	"couple_with_heart_man_man":         "\U0001f468\u200d\u2764\ufe0f\u200d\U0001f468",
+	"woman-heart-woman":                 "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f469",
+	"couple_with_heart_woman_woman":     "\U0001f469\u200d\u2764\ufe0f\u200d\U0001f469",
+	"family":                            "\U0001f46a",
+	"man-woman-boy":                     "\U0001f468\u200d\U0001f469\u200d\U0001f466",
+	"family_man_woman_boy":              "\U0001f468\u200d\U0001f469\u200d\U0001f466",
+	"man-woman-girl":                    "\U0001f468\u200d\U0001f469\u200d\U0001f467",
+	"family_man_woman_girl":             "\U0001f468\u200d\U0001f469\u200d\U0001f467",
+	"man-woman-girl-boy":                "\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466",
+	"family_man_woman_girl_boy":         "\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466",
+	"man-woman-boy-boy":                 "\U0001f468\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466",
+	"family_man_woman_boy_boy":          "\U0001f468\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466",
+	"man-woman-girl-girl":               "\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467",
+	"family_man_woman_girl_girl":        "\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467",
+	"man-man-boy":                       "\U0001f468\u200d\U0001f468\u200d\U0001f466",
+	"family_man_man_boy":                "\U0001f468\u200d\U0001f468\u200d\U0001f466",
+	"man-man-girl":                      "\U0001f468\u200d\U0001f468\u200d\U0001f467",
+	"family_man_man_girl":               "\U0001f468\u200d\U0001f468\u200d\U0001f467",
+	"man-man-girl-boy":                  "\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f466",
+	"family_man_man_girl_boy":           "\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f466",
+	"man-man-boy-boy":                   "\U0001f468\u200d\U0001f468\u200d\U0001f466\u200d\U0001f466",
+	"family_man_man_boy_boy":            "\U0001f468\u200d\U0001f468\u200d\U0001f466\u200d\U0001f466",
+	"man-man-girl-girl":                 "\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f467",
+	"family_man_man_girl_girl":          "\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f467",
+	"woman-woman-boy":                   "\U0001f469\u200d\U0001f469\u200d\U0001f466",
+	"family_woman_woman_boy":            "\U0001f469\u200d\U0001f469\u200d\U0001f466",
+	"woman-woman-girl":                  "\U0001f469\u200d\U0001f469\u200d\U0001f467",
+	"family_woman_woman_girl":           "\U0001f469\u200d\U0001f469\u200d\U0001f467",
+	"woman-woman-girl-boy":              "\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466",
+	"family_woman_woman_girl_boy":       "\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466",
+	"woman-woman-boy-boy":               "\U0001f469\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466",
+	"family_woman_woman_boy_boy":        "\U0001f469\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466",
+	"woman-woman-girl-girl":             "\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467",
+	"family_woman_woman_girl_girl":      "\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467",
+	"man-boy":                           "\U0001f468\u200d\U0001f466",
+	"family_man_boy":                    "\U0001f468\u200d\U0001f466",
+	"man-boy-boy":                       "\U0001f468\u200d\U0001f466\u200d\U0001f466",
+	"family_man_boy_boy":                "\U0001f468\u200d\U0001f466\u200d\U0001f466",
+	"man-girl":                          "\U0001f468\u200d\U0001f467",
+	"family_man_girl":                   "\U0001f468\u200d\U0001f467",
+	"man-girl-boy":                      "\U0001f468\u200d\U0001f467\u200d\U0001f466",
+	"family_man_girl_boy":               "\U0001f468\u200d\U0001f467\u200d\U0001f466",
+	"man-girl-girl":                     "\U0001f468\u200d\U0001f467\u200d\U0001f467",
+	"family_man_girl_girl":              "\U0001f468\u200d\U0001f467\u200d\U0001f467",
+	"woman-boy":                         "\U0001f469\u200d\U0001f466",
+	"family_woman_boy":                  "\U0001f469\u200d\U0001f466",
+	"woman-boy-boy":                     "\U0001f469\u200d\U0001f466\u200d\U0001f466",
+	"family_woman_boy_boy":              "\U0001f469\u200d\U0001f466\u200d\U0001f466",
+	"woman-girl":                        "\U0001f469\u200d\U0001f467",
+	"family_woman_girl":                 "\U0001f469\u200d\U0001f467",
+	"woman-girl-boy":                    "\U0001f469\u200d\U0001f467\u200d\U0001f466",
+	"family_woman_girl_boy":             "\U0001f469\u200d\U0001f467\u200d\U0001f466",
+	"woman-girl-girl":                   "\U0001f469\u200d\U0001f467\u200d\U0001f467",
+	"family_woman_girl_girl":            "\U0001f469\u200d\U0001f467\u200d\U0001f467",
+	"speaking_head_in_silhouette":       "\U0001f5e3\ufe0f",
+	"speaking_head":                     "\U0001f5e3\ufe0f",
+	"bust_in_silhouette":                "\U0001f464",
+	"busts_in_silhouette":               "\U0001f465",
+	"people_hugging":                    "\U0001fac2",
+	"footprints":                        "\U0001f463",
+	"skin-tone-2":                       "\U0001f3fb",
+	"skin-tone-3":                       "\U0001f3fc",
+	"skin-tone-4":                       "\U0001f3fd",
+	"skin-tone-5":                       "\U0001f3fe",
+	"skin-tone-6":                       "\U0001f3ff",
+	"monkey_face":                       "\U0001f435",
+	"monkey":                            "\U0001f412",
+	"gorilla":                           "\U0001f98d",
+	"orangutan":                         "\U0001f9a7",
+	"dog":                               "\U0001f436",
+	"dog2":                              "\U0001f415",
+	"guide_dog":                         "\U0001f9ae",
+	"service_dog":                       "\U0001f415\u200d\U0001f9ba",
+	"poodle":                            "\U0001f429",
+	"wolf":                              "\U0001f43a",
+	"fox_face":                          "\U0001f98a",
+	"raccoon":                           "\U0001f99d",
+	"cat":                               "\U0001f431",
+	"cat2":                              "\U0001f408",
+	"black_cat":                         "\U0001f408\u200d\u2b1b",
+	"lion_face":                         "\U0001f981",
+	"lion":                              "\U0001f981",
+	"tiger":                             "\U0001f42f",
+	"tiger2":                            "\U0001f405",
+	"leopard":                           "\U0001f406",
+	"horse":                             "\U0001f434",
+	"racehorse":                         "\U0001f40e",
+	"unicorn_face":                      "\U0001f984",
+	"unicorn":                           "\U0001f984",
+	"zebra_face":                        "\U0001f993",
+	"deer":                              "\U0001f98c",
+	"bison":                             "\U0001f9ac",
+	"cow":                               "\U0001f42e",
+	"ox":                                "\U0001f402",
+	"water_buffalo":                     "\U0001f403",
+	"cow2":                              "\U0001f404",
+	"pig":                               "\U0001f437",
+	"pig2":                              "\U0001f416",
+	"boar":                              "\U0001f417",
+	"pig_nose":                          "\U0001f43d",
+	"ram":                               "\U0001f40f",
+	"sheep":                             "\U0001f411",
+	"goat":                              "\U0001f410",
+	"dromedary_camel":                   "\U0001f42a",
+	"camel":                             "\U0001f42b",
+	"llama":                             "\U0001f999",
+	"giraffe_face":                      "\U0001f992",
+	"elephant":                          "\U0001f418",
+	"mammoth":                           "\U0001f9a3",
+	"rhinoceros":                        "\U0001f98f",
+	"hippopotamus":                      "\U0001f99b",
+	"mouse":                             "\U0001f42d",
+	"mouse2":                            "\U0001f401",
+	"rat":                               "\U0001f400",
+	"hamster":                           "\U0001f439",
+	"rabbit":                            "\U0001f430",
+	"rabbit2":                           "\U0001f407",
+	"chipmunk":                          "\U0001f43f\ufe0f",
+	"beaver":                            "\U0001f9ab",
+	"hedgehog":                          "\U0001f994",
+	"bat":                               "\U0001f987",
+	"bear":                              "\U0001f43b",
+	"polar_bear":                        "\U0001f43b\u200d\u2744\ufe0f",
+	"koala":                             "\U0001f428",
+	"panda_face":                        "\U0001f43c",
+	"sloth":                             "\U0001f9a5",
+	"otter":                             "\U0001f9a6",
+	"skunk":                             "\U0001f9a8",
+	"kangaroo":                          "\U0001f998",
+	"badger":                            "\U0001f9a1",
+	"feet":                              "\U0001f43e",
+	"paw_prints":                        "\U0001f43e",
+	"turkey":                            "\U0001f983",
+	"chicken":                           "\U0001f414",
+	"rooster":                           "\U0001f413",
+	"hatching_chick":                    "\U0001f423",
+	"baby_chick":                        "\U0001f424",
+	"hatched_chick":                     "\U0001f425",
+	"bird":                              "\U0001f426",
+	"penguin":                           "\U0001f427",
+	"dove_of_peace":                     "\U0001f54a\ufe0f",
+	"dove":                              "\U0001f54a\ufe0f",
+	"eagle":                             "\U0001f985",
+	"duck":                              "\U0001f986",
+	"swan":                              "\U0001f9a2",
+	"owl":                               "\U0001f989",
+	"dodo":                              "\U0001f9a4",
+	"feather":                           "\U0001fab6",
+	"flamingo":                          "\U0001f9a9",
+	"peacock":                           "\U0001f99a",
+	"parrot":                            "\U0001f99c",
+	"frog":                              "\U0001f438",
+	"crocodile":                         "\U0001f40a",
+	"turtle":                            "\U0001f422",
+	"lizard":                            "\U0001f98e",
+	"snake":                             "\U0001f40d",
+	"dragon_face":                       "\U0001f432",
+	"dragon":                            "\U0001f409",
+	"sauropod":                          "\U0001f995",
+	"t-rex":                             "\U0001f996",
+	"whale":                             "\U0001f433",
+	"whale2":                            "\U0001f40b",
+	"dolphin":                           "\U0001f42c",
+	"flipper":                           "\U0001f42c",
+	"seal":                              "\U0001f9ad",
+	"fish":                              "\U0001f41f",
+	"tropical_fish":                     "\U0001f420",
+	"blowfish":                          "\U0001f421",
+	"shark":                             "\U0001f988",
+	"octopus":                           "\U0001f419",
+	"shell":                             "\U0001f41a",
+	"snail":                             "\U0001f40c",
+	"butterfly":                         "\U0001f98b",
+	"bug":                               "\U0001f41b",
+	"ant":                               "\U0001f41c",
+	"bee":                               "\U0001f41d",
+	"honeybee":                          "\U0001f41d",
+	"beetle":                            "\U0001fab2",
+	"ladybug":                           "\U0001f41e",
+	"lady_beetle":                       "\U0001f41e",
+	"cricket":                           "\U0001f997",
+	"cockroach":                         "\U0001fab3",
+	"spider":                            "\U0001f577\ufe0f",
+	"spider_web":                        "\U0001f578\ufe0f",
+	"scorpion":                          "\U0001f982",
+	"mosquito":                          "\U0001f99f",
+	"fly":                               "\U0001fab0",
+	"worm":                              "\U0001fab1",
+	"microbe":                           "\U0001f9a0",
+	"bouquet":                           "\U0001f490",
+	"cherry_blossom":                    "\U0001f338",
+	"white_flower":                      "\U0001f4ae",
+	"rosette":                           "\U0001f3f5\ufe0f",
+	"rose":                              "\U0001f339",
+	"wilted_flower":                     "\U0001f940",
+	"hibiscus":                          "\U0001f33a",
+	"sunflower":                         "\U0001f33b",
+	"blossom":                           "\U0001f33c",
+	"tulip":                             "\U0001f337",
+	"seedling":                          "\U0001f331",
+	"potted_plant":                      "\U0001fab4",
+	"evergreen_tree":                    "\U0001f332",
+	"deciduous_tree":                    "\U0001f333",
+	"palm_tree":                         "\U0001f334",
+	"cactus":                            "\U0001f335",
+	"ear_of_rice":                       "\U0001f33e",
+	"herb":                              "\U0001f33f",
+	"shamrock":                          "\u2618\ufe0f",
+	"four_leaf_clover":                  "\U0001f340",
+	"maple_leaf":                        "\U0001f341",
+	"fallen_leaf":                       "\U0001f342",
+	"leaves":                            "\U0001f343",
+	"grapes":                            "\U0001f347",
+	"melon":                             "\U0001f348",
+	"watermelon":                        "\U0001f349",
+	"tangerine":                         "\U0001f34a",
+	"mandarin":                          "\U0001f34a",
+	"orange":                            "\U0001f34a",
+	"lemon":                             "\U0001f34b",
+	"banana":                            "\U0001f34c",
+	"pineapple":                         "\U0001f34d",
+	"mango":                             "\U0001f96d",
+	"apple":                             "\U0001f34e",
+	"green_apple":                       "\U0001f34f",
+	"pear":                              "\U0001f350",
+	"peach":                             "\U0001f351",
+	"cherries":                          "\U0001f352",
+	"strawberry":                        "\U0001f353",
+	"blueberries":                       "\U0001fad0",
+	"kiwifruit":                         "\U0001f95d",
+	"kiwi_fruit":                        "\U0001f95d",
+	"tomato":                            "\U0001f345",
+	"olive":                             "\U0001fad2",
+	"coconut":                           "\U0001f965",
+	"avocado":                           "\U0001f951",
+	"eggplant":                          "\U0001f346",
+	"potato":                            "\U0001f954",
+	"carrot":                            "\U0001f955",
+	"corn":                              "\U0001f33d",
+	"hot_pepper":                        "\U0001f336\ufe0f",
+	"bell_pepper":                       "\U0001fad1",
+	"cucumber":                          "\U0001f952",
+	"leafy_green":                       "\U0001f96c",
+	"broccoli":                          "\U0001f966",
+	"garlic":                            "\U0001f9c4",
+	"onion":                             "\U0001f9c5",
+	"mushroom":                          "\U0001f344",
+	"peanuts":                           "\U0001f95c",
+	"chestnut":                          "\U0001f330",
+	"bread":                             "\U0001f35e",
+	"croissant":                         "\U0001f950",
+	"baguette_bread":                    "\U0001f956",
+	"flatbread":                         "\U0001fad3",
+	"pretzel":                           "\U0001f968",
+	"bagel":                             "\U0001f96f",
+	"pancakes":                          "\U0001f95e",
+	"waffle":                            "\U0001f9c7",
+	"cheese_wedge":                      "\U0001f9c0",
+	"cheese":                            "\U0001f9c0",
+	"meat_on_bone":                      "\U0001f356",
+	"poultry_leg":                       "\U0001f357",
+	"cut_of_meat":                       "\U0001f969",
+	"bacon":                             "\U0001f953",
+	"hamburger":                         "\U0001f354",
+	"fries":                             "\U0001f35f",
+	"pizza":                             "\U0001f355",
+	"hotdog":                            "\U0001f32d",
+	"sandwich":                          "\U0001f96a",
+	"taco":                              "\U0001f32e",
+	"burrito":                           "\U0001f32f",
+	"tamale":                            "\U0001fad4",
+	"stuffed_flatbread":                 "\U0001f959",
+	"falafel":                           "\U0001f9c6",
+	"egg":                               "\U0001f95a",
+	"fried_egg":                         "\U0001f373",
+	"cooking":                           "\U0001f373",
+	"shallow_pan_of_food":               "\U0001f958",
+	"stew":                              "\U0001f372",
+	"fondue":                            "\U0001fad5",
+	"bowl_with_spoon":                   "\U0001f963",
+	"green_salad":                       "\U0001f957",
+	"popcorn":                           "\U0001f37f",
+	"butter":                            "\U0001f9c8",
+	"salt":                              "\U0001f9c2",
+	"canned_food":                       "\U0001f96b",
+	"bento":                             "\U0001f371",
+	"rice_cracker":                      "\U0001f358",
+	"rice_ball":                         "\U0001f359",
+	"rice":                              "\U0001f35a",
+	"curry":                             "\U0001f35b",
+	"ramen":                             "\U0001f35c",
+	"spaghetti":                         "\U0001f35d",
+	"sweet_potato":                      "\U0001f360",
+	"oden":                              "\U0001f362",
+	"sushi":                             "\U0001f363",
+	"fried_shrimp":                      "\U0001f364",
+	"fish_cake":                         "\U0001f365",
+	"moon_cake":                         "\U0001f96e",
+	"dango":                             "\U0001f361",
+	"dumpling":                          "\U0001f95f",
+	"fortune_cookie":                    "\U0001f960",
+	"takeout_box":                       "\U0001f961",
+	"crab":                              "\U0001f980",
+	"lobster":                           "\U0001f99e",
+	"shrimp":                            "\U0001f990",
+	"squid":                             "\U0001f991",
+	"oyster":                            "\U0001f9aa",
+	"icecream":                          "\U0001f366",
+	"shaved_ice":                        "\U0001f367",
+	"ice_cream":                         "\U0001f368",
+	"doughnut":                          "\U0001f369",
+	"cookie":                            "\U0001f36a",
+	"birthday":                          "\U0001f382",
+	"cake":                              "\U0001f370",
+	"cupcake":                           "\U0001f9c1",
+	"pie":                               "\U0001f967",
+	"chocolate_bar":                     "\U0001f36b",
+	"candy":                             "\U0001f36c",
+	"lollipop":                          "\U0001f36d",
+	"custard":                           "\U0001f36e",
+	"honey_pot":                         "\U0001f36f",
+	"baby_bottle":                       "\U0001f37c",
+	"glass_of_milk":                     "\U0001f95b",
+	"milk_glass":                        "\U0001f95b",
+	"coffee":                            "\u2615",
+	"teapot":                            "\U0001fad6",
+	"tea":                               "\U0001f375",
+	"sake":                              "\U0001f376",
+	"champagne":                         "\U0001f37e",
+	"wine_glass":                        "\U0001f377",
+	"cocktail":                          "\U0001f378",
+	"tropical_drink":                    "\U0001f379",
+	"beer":                              "\U0001f37a",
+	"beers":                             "\U0001f37b",
+	"clinking_glasses":                  "\U0001f942",
+	"tumbler_glass":                     "\U0001f943",
+	"cup_with_straw":                    "\U0001f964",
+	"bubble_tea":                        "\U0001f9cb",
+	"beverage_box":                      "\U0001f9c3",
+	"mate_drink":                        "\U0001f9c9",
+	"ice_cube":                          "\U0001f9ca",
+	"chopsticks":                        "\U0001f962",
+	"knife_fork_plate":                  "\U0001f37d\ufe0f",
+	"plate_with_cutlery":                "\U0001f37d\ufe0f",
+	"fork_and_knife":                    "\U0001f374",
+	"spoon":                             "\U0001f944",
+	"hocho":                             "\U0001f52a",
+	"knife":                             "\U0001f52a",
+	"amphora":                           "\U0001f3fa",
+	"earth_africa":                      "\U0001f30d",
+	"earth_americas":                    "\U0001f30e",
+	"earth_asia":                        "\U0001f30f",
+	"globe_with_meridians":              "\U0001f310",
+	"world_map":                         "\U0001f5fa\ufe0f",
+	"japan":                             "\U0001f5fe",
+	"compass":                           "\U0001f9ed",
+	"snow_capped_mountain":              "\U0001f3d4\ufe0f",
+	"mountain_snow":                     "\U0001f3d4\ufe0f",
+	"mountain":                          "\u26f0\ufe0f",
+	"volcano":                           "\U0001f30b",
+	"mount_fuji":                        "\U0001f5fb",
+	"camping":                           "\U0001f3d5\ufe0f",
+	"beach_with_umbrella":               "\U0001f3d6\ufe0f",
+	"beach_umbrella":                    "\U0001f3d6\ufe0f",
+	"desert":                            "\U0001f3dc\ufe0f",
+	"desert_island":                     "\U0001f3dd\ufe0f",
+	"national_park":                     "\U0001f3de\ufe0f",
+	"stadium":                           "\U0001f3df\ufe0f",
+	"classical_building":                "\U0001f3db\ufe0f",
+	"building_construction":             "\U0001f3d7\ufe0f",
+	"bricks":                            "\U0001f9f1",
+	"rock":                              "\U0001faa8",
+	"wood":                              "\U0001fab5",
+	"hut":                               "\U0001f6d6",
+	"house_buildings":                   "\U0001f3d8\ufe0f",
+	"houses":                            "\U0001f3d8\ufe0f",
+	"derelict_house_building":           "\U0001f3da\ufe0f",
+	"derelict_house":                    "\U0001f3da\ufe0f",
+	"house":                             "\U0001f3e0",
+	"house_with_garden":                 "\U0001f3e1",
+	"office":                            "\U0001f3e2",
+	"post_office":                       "\U0001f3e3",
+	"european_post_office":              "\U0001f3e4",
+	"hospital":                          "\U0001f3e5",
+	"bank":                              "\U0001f3e6",
+	"hotel":                             "\U0001f3e8",
+	"love_hotel":                        "\U0001f3e9",
+	"convenience_store":                 "\U0001f3ea",
+	"school":                            "\U0001f3eb",
+	"department_store":                  "\U0001f3ec",
+	"factory":                           "\U0001f3ed",
+	"japanese_castle":                   "\U0001f3ef",
+	"european_castle":                   "\U0001f3f0",
+	"wedding":                           "\U0001f492",
+	"tokyo_tower":                       "\U0001f5fc",
+	"statue_of_liberty":                 "\U0001f5fd",
+	"church":                            "\u26ea",
+	"mosque":                            "\U0001f54c",
+	"hindu_temple":                      "\U0001f6d5",
+	"synagogue":                         "\U0001f54d",
+	"shinto_shrine":                     "\u26e9\ufe0f",
+	"kaaba":                             "\U0001f54b",
+	"fountain":                          "\u26f2",
+	"tent":                              "\u26fa",
+	"foggy":                             "\U0001f301",
+	"night_with_stars":                  "\U0001f303",
+	"cityscape":                         "\U0001f3d9\ufe0f",
+	"sunrise_over_mountains":            "\U0001f304",
+	"sunrise":                           "\U0001f305",
+	"city_sunset":                       "\U0001f306",
+	"city_sunrise":                      "\U0001f307",
+	"bridge_at_night":                   "\U0001f309",
+	"hotsprings":                        "\u2668\ufe0f",
+	"carousel_horse":                    "\U0001f3a0",
+	"ferris_wheel":                      "\U0001f3a1",
+	"roller_coaster":                    "\U0001f3a2",
+	"barber":                            "\U0001f488",
+	"circus_tent":                       "\U0001f3aa",
+	"steam_locomotive":                  "\U0001f682",
+	"railway_car":                       "\U0001f683",
+	"bullettrain_side":                  "\U0001f684",
+	"bullettrain_front":                 "\U0001f685",
+	"train2":                            "\U0001f686",
+	"metro":                             "\U0001f687",
+	"light_rail":                        "\U0001f688",
+	"station":                           "\U0001f689",
+	"tram":                              "\U0001f68a",
+	"monorail":                          "\U0001f69d",
+	"mountain_railway":                  "\U0001f69e",
+	"train":                             "\U0001f68b",
+	"bus":                               "\U0001f68c",
+	"oncoming_bus":                      "\U0001f68d",
+	"trolleybus":                        "\U0001f68e",
+	"minibus":                           "\U0001f690",
+	"ambulance":                         "\U0001f691",
+	"fire_engine":                       "\U0001f692",
+	"police_car":                        "\U0001f693",
+	"oncoming_police_car":               "\U0001f694",
+	"taxi":                              "\U0001f695",
+	"oncoming_taxi":                     "\U0001f696",
+	"car":                               "\U0001f697",
+	"red_car":                           "\U0001f697",
+	"oncoming_automobile":               "\U0001f698",
+	"blue_car":                          "\U0001f699",
+	"pickup_truck":                      "\U0001f6fb",
+	"truck":                             "\U0001f69a",
+	"articulated_lorry":                 "\U0001f69b",
+	"tractor":                           "\U0001f69c",
+	"racing_car":                        "\U0001f3ce\ufe0f",
+	"racing_motorcycle":                 "\U0001f3cd\ufe0f",
+	"motorcycle":                        "\U0001f3cd\ufe0f",
+	"motor_scooter":                     "\U0001f6f5",
+	"manual_wheelchair":                 "\U0001f9bd",
+	"motorized_wheelchair":              "\U0001f9bc",
+	"auto_rickshaw":                     "\U0001f6fa",
+	"bike":                              "\U0001f6b2",
+	"scooter":                           "\U0001f6f4",
+	"kick_scooter":                      "\U0001f6f4",
+	"skateboard":                        "\U0001f6f9",
+	"roller_skate":                      "\U0001f6fc",
+	"busstop":                           "\U0001f68f",
+	"motorway":                          "\U0001f6e3\ufe0f",
+	"railway_track":                     "\U0001f6e4\ufe0f",
+	"oil_drum":                          "\U0001f6e2\ufe0f",
+	"fuelpump":                          "\u26fd",
+	"rotating_light":                    "\U0001f6a8",
+	"traffic_light":                     "\U0001f6a5",
+	"vertical_traffic_light":            "\U0001f6a6",
+	"octagonal_sign":                    "\U0001f6d1",
+	"stop_sign":                         "\U0001f6d1",
+	"construction":                      "\U0001f6a7",
+	"anchor":                            "\u2693",
+	"boat":                              "\u26f5",
+	"sailboat":                          "\u26f5",
+	"canoe":                             "\U0001f6f6",
+	"speedboat":                         "\U0001f6a4",
+	"passenger_ship":                    "\U0001f6f3\ufe0f",
+	"ferry":                             "\u26f4\ufe0f",
+	"motor_boat":                        "\U0001f6e5\ufe0f",
+	"ship":                              "\U0001f6a2",
+	"airplane":                          "\u2708\ufe0f",
+	"small_airplane":                    "\U0001f6e9\ufe0f",
+	"airplane_departure":                "\U0001f6eb",
+	"flight_departure":                  "\U0001f6eb",
+	"airplane_arriving":                 "\U0001f6ec",
+	"flight_arrival":                    "\U0001f6ec",
+	"parachute":                         "\U0001fa82",
+	"seat":                              "\U0001f4ba",
+	"helicopter":                        "\U0001f681",
+	"suspension_railway":                "\U0001f69f",
+	"mountain_cableway":                 "\U0001f6a0",
+	"aerial_tramway":                    "\U0001f6a1",
+	"satellite":                         "\U0001f6f0\ufe0f",
+	"artificial_satellite":              "\U0001f6f0\ufe0f",
+	"rocket":                            "\U0001f680",
+	"flying_saucer":                     "\U0001f6f8",
+	"bellhop_bell":                      "\U0001f6ce\ufe0f",
+	"luggage":                           "\U0001f9f3",
+	"hourglass":                         "\u231b",
+	"hourglass_flowing_sand":            "\u23f3",
+	"watch":                             "\u231a",
+	"alarm_clock":                       "\u23f0",
+	"stopwatch":                         "\u23f1\ufe0f",
+	"timer_clock":                       "\u23f2\ufe0f",
+	"mantelpiece_clock":                 "\U0001f570\ufe0f",
+	"clock12":                           "\U0001f55b",
+	"clock1230":                         "\U0001f567",
+	"clock1":                            "\U0001f550",
+	"clock130":                          "\U0001f55c",
+	"clock2":                            "\U0001f551",
+	"clock230":                          "\U0001f55d",
+	"clock3":                            "\U0001f552",
+	"clock330":                          "\U0001f55e",
+	"clock4":                            "\U0001f553",
+	"clock430":                          "\U0001f55f",
+	"clock5":                            "\U0001f554",
+	"clock530":                          "\U0001f560",
+	"clock6":                            "\U0001f555",
+	"clock630":                          "\U0001f561",
+	"clock7":                            "\U0001f556",
+	"clock730":                          "\U0001f562",
+	"clock8":                            "\U0001f557",
+	"clock830":                          "\U0001f563",
+	"clock9":                            "\U0001f558",
+	"clock930":                          "\U0001f564",
+	"clock10":                           "\U0001f559",
+	"clock1030":                         "\U0001f565",
+	"clock11":                           "\U0001f55a",
+	"clock1130":                         "\U0001f566",
+	"new_moon":                          "\U0001f311",
+	"waxing_crescent_moon":              "\U0001f312",
+	"first_quarter_moon":                "\U0001f313",
+	"moon":                              "\U0001f314",
+	"waxing_gibbous_moon":               "\U0001f314",
+	"full_moon":                         "\U0001f315",
+	"waning_gibbous_moon":               "\U0001f316",
+	"last_quarter_moon":                 "\U0001f317",
+	"waning_crescent_moon":              "\U0001f318",
+	"crescent_moon":                     "\U0001f319",
+	"new_moon_with_face":                "\U0001f31a",
+	"first_quarter_moon_with_face":      "\U0001f31b",
+	"last_quarter_moon_with_face":       "\U0001f31c",
+	"thermometer":                       "\U0001f321\ufe0f",
+	"sunny":                             "\u2600\ufe0f",
+	"full_moon_with_face":               "\U0001f31d",
+	"sun_with_face":                     "\U0001f31e",
+	"ringed_planet":                     "\U0001fa90",
+	"star":                              "\u2b50",
+	"star2":                             "\U0001f31f",
+	"stars":                             "\U0001f320",
+	"milky_way":                         "\U0001f30c",
+	"cloud":                             "\u2601\ufe0f",
+	"partly_sunny":                      "\u26c5",
+	"thunder_cloud_and_rain":            "\u26c8\ufe0f",
+	"cloud_with_lightning_and_rain":     "\u26c8\ufe0f",
+	"mostly_sunny":                      "\U0001f324\ufe0f",
+	"sun_small_cloud":                   "\U0001f324\ufe0f",
+	"sun_behind_small_cloud":            "\U0001f324\ufe0f",
+	"barely_sunny":                      "\U0001f325\ufe0f",
+	"sun_behind_cloud":                  "\U0001f325\ufe0f",
+	"sun_behind_large_cloud":            "\U0001f325\ufe0f",
+	"partly_sunny_rain":                 "\U0001f326\ufe0f",
+	"sun_behind_rain_cloud":             "\U0001f326\ufe0f",
+	"rain_cloud":                        "\U0001f327\ufe0f",
+	"cloud_with_rain":                   "\U0001f327\ufe0f",
+	"snow_cloud":                        "\U0001f328\ufe0f",
+	"cloud_with_snow":                   "\U0001f328\ufe0f",
+	"lightning":                         "\U0001f329\ufe0f",
+	"lightning_cloud":                   "\U0001f329\ufe0f",
+	"cloud_with_lightning":              "\U0001f329\ufe0f",
+	"tornado":                           "\U0001f32a\ufe0f",
+	"tornado_cloud":                     "\U0001f32a\ufe0f",
+	"fog":                               "\U0001f32b\ufe0f",
+	"wind_blowing_face":                 "\U0001f32c\ufe0f",
+	"wind_face":                         "\U0001f32c\ufe0f",
+	"cyclone":                           "\U0001f300",
+	"rainbow":                           "\U0001f308",
+	"closed_umbrella":                   "\U0001f302",
+	"umbrella":                          "\u2602\ufe0f",
+	"open_umbrella":                     "\u2602\ufe0f",
+	"umbrella_with_rain_drops":          "\u2614",
+	"umbrella_on_ground":                "\u26f1\ufe0f",
+	"parasol_on_ground":                 "\u26f1\ufe0f",
+	"zap":                               "\u26a1",
+	"snowflake":                         "\u2744\ufe0f",
+	"snowman":                           "\u2603\ufe0f",
+	"snowman_with_snow":                 "\u2603\ufe0f",
+	"snowman_without_snow":              "\u26c4",
+	"comet":                             "\u2604\ufe0f",
+	"fire":                              "\U0001f525",
+	"droplet":                           "\U0001f4a7",
+	"ocean":                             "\U0001f30a",
+	"jack_o_lantern":                    "\U0001f383",
+	"christmas_tree":                    "\U0001f384",
+	"fireworks":                         "\U0001f386",
+	"sparkler":                          "\U0001f387",
+	"firecracker":                       "\U0001f9e8",
+	"sparkles":                          "\u2728",
+	"balloon":                           "\U0001f388",
+	"tada":                              "\U0001f389",
+	"confetti_ball":                     "\U0001f38a",
+	"tanabata_tree":                     "\U0001f38b",
+	"bamboo":                            "\U0001f38d",
+	"dolls":                             "\U0001f38e",
+	"flags":                             "\U0001f38f",
+	"wind_chime":                        "\U0001f390",
+	"rice_scene":                        "\U0001f391",
+	"red_envelope":                      "\U0001f9e7",
+	"ribbon":                            "\U0001f380",
+	"gift":                              "\U0001f381",
+	"reminder_ribbon":                   "\U0001f397\ufe0f",
+	"admission_tickets":                 "\U0001f39f\ufe0f",
+	"tickets":                           "\U0001f39f\ufe0f",
+	"ticket":                            "\U0001f3ab",
+	"medal":                             "\U0001f396\ufe0f",
+	"medal_military":                    "\U0001f396\ufe0f",
+	"trophy":                            "\U0001f3c6",
+	"sports_medal":                      "\U0001f3c5",
+	"medal_sports":                      "\U0001f3c5",
+	"first_place_medal":                 "\U0001f947",
+	"1st_place_medal":                   "\U0001f947",
+	"second_place_medal":                "\U0001f948",
+	"2nd_place_medal":                   "\U0001f948",
+	"third_place_medal":                 "\U0001f949",
+	"3rd_place_medal":                   "\U0001f949",
+	"soccer":                            "\u26bd",
+	"baseball":                          "\u26be",
+	"softball":                          "\U0001f94e",
+	"basketball":                        "\U0001f3c0",
+	"volleyball":                        "\U0001f3d0",
+	"football":                          "\U0001f3c8",
+	"rugby_football":                    "\U0001f3c9",
+	"tennis":                            "\U0001f3be",
+	"flying_disc":                       "\U0001f94f",
+	"bowling":                           "\U0001f3b3",
+	"cricket_bat_and_ball":              "\U0001f3cf",
+	"field_hockey_stick_and_ball":       "\U0001f3d1",
+	"field_hockey":                      "\U0001f3d1",
+	"ice_hockey_stick_and_puck":         "\U0001f3d2",
+	"ice_hockey":                        "\U0001f3d2",
+	"lacrosse":                          "\U0001f94d",
+	"table_tennis_paddle_and_ball":      "\U0001f3d3",
+	"ping_pong":                         "\U0001f3d3",
+	"badminton_racquet_and_shuttlecock": "\U0001f3f8",
+	"badminton":                         "\U0001f3f8",
+	"boxing_glove":                      "\U0001f94a",
+	"martial_arts_uniform":              "\U0001f94b",
+	"goal_net":                          "\U0001f945",
+	"golf":                              "\u26f3",
+	"ice_skate":                         "\u26f8\ufe0f",
+	"fishing_pole_and_fish":             "\U0001f3a3",
+	"diving_mask":                       "\U0001f93f",
+	"running_shirt_with_sash":           "\U0001f3bd",
+	"ski":                               "\U0001f3bf",
+	"sled":                              "\U0001f6f7",
+	"curling_stone":                     "\U0001f94c",
+	"dart":                              "\U0001f3af",
+	"yo-yo":                             "\U0001fa80",
+	"kite":                              "\U0001fa81",
+	"8ball":                             "\U0001f3b1",
+	"crystal_ball":                      "\U0001f52e",
+	"magic_wand":                        "\U0001fa84",
+	"nazar_amulet":                      "\U0001f9ff",
+	"video_game":                        "\U0001f3ae",
+	"joystick":                          "\U0001f579\ufe0f",
+	"slot_machine":                      "\U0001f3b0",
+	"game_die":                          "\U0001f3b2",
+	"jigsaw":                            "\U0001f9e9",
+	"teddy_bear":                        "\U0001f9f8",
+	"pinata":                            "\U0001fa85",
+	"nesting_dolls":                     "\U0001fa86",
+	"spades":                            "\u2660\ufe0f",
+	"hearts":                            "\u2665\ufe0f",
+	"diamonds":                          "\u2666\ufe0f",
+	"clubs":                             "\u2663\ufe0f",
+	"chess_pawn":                        "\u265f\ufe0f",
+	"black_joker":                       "\U0001f0cf",
+	"mahjong":                           "\U0001f004",
+	"flower_playing_cards":              "\U0001f3b4",
+	"performing_arts":                   "\U0001f3ad",
+	"frame_with_picture":                "\U0001f5bc\ufe0f",
+	"framed_picture":                    "\U0001f5bc\ufe0f",
+	"art":                               "\U0001f3a8",
+	"thread":                            "\U0001f9f5",
+	"sewing_needle":                     "\U0001faa1",
+	"yarn":                              "\U0001f9f6",
+	"knot":                              "\U0001faa2",
+	"eyeglasses":                        "\U0001f453",
+	"dark_sunglasses":                   "\U0001f576\ufe0f",
+	"goggles":                           "\U0001f97d",
+	"lab_coat":                          "\U0001f97c",
+	"safety_vest":                       "\U0001f9ba",
+	"necktie":                           "\U0001f454",
+	"shirt":                             "\U0001f455",
+	"tshirt":                            "\U0001f455",
+	"jeans":                             "\U0001f456",
+	"scarf":                             "\U0001f9e3",
+	"gloves":                            "\U0001f9e4",
+	"coat":                              "\U0001f9e5",
+	"socks":                             "\U0001f9e6",
+	"dress":                             "\U0001f457",
+	"kimono":                            "\U0001f458",
+	"sari":                              "\U0001f97b",
+	"one-piece_swimsuit":                "\U0001fa71",
+	"briefs":                            "\U0001fa72",
+	"shorts":                            "\U0001fa73",
+	"bikini":                            "\U0001f459",
+	"womans_clothes":                    "\U0001f45a",
+	"purse":                             "\U0001f45b",
+	"handbag":                           "\U0001f45c",
+	"pouch":                             "\U0001f45d",
+	"shopping_bags":                     "\U0001f6cd\ufe0f",
+	"shopping":                          "\U0001f6cd\ufe0f",
+	"school_satchel":                    "\U0001f392",
+	"thong_sandal":                      "\U0001fa74",
+	"mans_shoe":                         "\U0001f45e",
+	"shoe":                              "\U0001f45e",
+	"athletic_shoe":                     "\U0001f45f",
+	"hiking_boot":                       "\U0001f97e",
+	"womans_flat_shoe":                  "\U0001f97f",
+	"high_heel":                         "\U0001f460",
+	"sandal":                            "\U0001f461",
+	"ballet_shoes":                      "\U0001fa70",
+	"boot":                              "\U0001f462",
+	"crown":                             "\U0001f451",
+	"womans_hat":                        "\U0001f452",
+	"tophat":                            "\U0001f3a9",
+	"mortar_board":                      "\U0001f393",
+	"billed_cap":                        "\U0001f9e2",
+	"military_helmet":                   "\U0001fa96",
+	"helmet_with_white_cross":           "\u26d1\ufe0f",
+	"rescue_worker_helmet":              "\u26d1\ufe0f",
+	"prayer_beads":                      "\U0001f4ff",
+	"lipstick":                          "\U0001f484",
+	"ring":                              "\U0001f48d",
+	"gem":                               "\U0001f48e",
+	"mute":                              "\U0001f507",
+	"speaker":                           "\U0001f508",
+	"sound":                             "\U0001f509",
+	"loud_sound":                        "\U0001f50a",
+	"loudspeaker":                       "\U0001f4e2",
+	"mega":                              "\U0001f4e3",
+	"postal_horn":                       "\U0001f4ef",
+	"bell":                              "\U0001f514",
+	"no_bell":                           "\U0001f515",
+	"musical_score":                     "\U0001f3bc",
+	"musical_note":                      "\U0001f3b5",
+	"notes":                             "\U0001f3b6",
+	"studio_microphone":                 "\U0001f399\ufe0f",
+	"level_slider":                      "\U0001f39a\ufe0f",
+	"control_knobs":                     "\U0001f39b\ufe0f",
+	"microphone":                        "\U0001f3a4",
+	"headphones":                        "\U0001f3a7",
+	"radio":                             "\U0001f4fb",
+	"saxophone":                         "\U0001f3b7",
+	"accordion":                         "\U0001fa97",
+	"guitar":                            "\U0001f3b8",
+	"musical_keyboard":                  "\U0001f3b9",
+	"trumpet":                           "\U0001f3ba",
+	"violin":                            "\U0001f3bb",
+	"banjo":                             "\U0001fa95",
+	"drum_with_drumsticks":              "\U0001f941",
+	"drum":                              "\U0001f941",
+	"long_drum":                         "\U0001fa98",
+	"iphone":                            "\U0001f4f1",
+	"calling":                           "\U0001f4f2",
+	"phone":                             "\u260e\ufe0f",
+	"telephone":                         "\u260e\ufe0f",
+	"telephone_receiver":                "\U0001f4de",
+	"pager":                             "\U0001f4df",
+	"fax":                               "\U0001f4e0",
+	"battery":                           "\U0001f50b",
+	"electric_plug":                     "\U0001f50c",
+	"computer":                          "\U0001f4bb",
+	"desktop_computer":                  "\U0001f5a5\ufe0f",
+	"printer":                           "\U0001f5a8\ufe0f",
+	"keyboard":                          "\u2328\ufe0f",
+	"three_button_mouse":                "\U0001f5b1\ufe0f",
+	"computer_mouse":                    "\U0001f5b1\ufe0f",
+	"trackball":                         "\U0001f5b2\ufe0f",
+	"minidisc":                          "\U0001f4bd",
+	"floppy_disk":                       "\U0001f4be",
+	"cd":                                "\U0001f4bf",
+	"dvd":                               "\U0001f4c0",
+	"abacus":                            "\U0001f9ee",
+	"movie_camera":                      "\U0001f3a5",
+	"film_frames":                       "\U0001f39e\ufe0f",
+	"film_strip":                        "\U0001f39e\ufe0f",
+	"film_projector":                    "\U0001f4fd\ufe0f",
+	"clapper":                           "\U0001f3ac",
+	"tv":                                "\U0001f4fa",
+	"camera":                            "\U0001f4f7",
+	"camera_with_flash":                 "\U0001f4f8",
+	"camera_flash":                      "\U0001f4f8",
+	"video_camera":                      "\U0001f4f9",
+	"vhs":                               "\U0001f4fc",
+	"mag":                               "\U0001f50d",
+	"mag_right":                         "\U0001f50e",
+	"candle":                            "\U0001f56f\ufe0f",
+	"bulb":                              "\U0001f4a1",
+	"flashlight":                        "\U0001f526",
+	"izakaya_lantern":                   "\U0001f3ee",
+	"lantern":                           "\U0001f3ee",
+	"diya_lamp":                         "\U0001fa94",
+	"notebook_with_decorative_cover":    "\U0001f4d4",
+	"closed_book":                       "\U0001f4d5",
+	"book":                              "\U0001f4d6",
+	"open_book":                         "\U0001f4d6",
+	"green_book":                        "\U0001f4d7",
+	"blue_book":                         "\U0001f4d8",
+	"orange_book":                       "\U0001f4d9",
+	"books":                             "\U0001f4da",
+	"notebook":                          "\U0001f4d3",
+	"ledger":                            "\U0001f4d2",
+	"page_with_curl":                    "\U0001f4c3",
+	"scroll":                            "\U0001f4dc",
+	"page_facing_up":                    "\U0001f4c4",
+	"newspaper":                         "\U0001f4f0",
+	"rolled_up_newspaper":               "\U0001f5de\ufe0f",
+	"newspaper_roll":                    "\U0001f5de\ufe0f",
+	"bookmark_tabs":                     "\U0001f4d1",
+	"bookmark":                          "\U0001f516",
+	"label":                             "\U0001f3f7\ufe0f",
+	"moneybag":                          "\U0001f4b0",
+	"coin":                              "\U0001fa99",
+	"yen":                               "\U0001f4b4",
+	"dollar":                            "\U0001f4b5",
+	"euro":                              "\U0001f4b6",
+	"pound":                             "\U0001f4b7",
+	"money_with_wings":                  "\U0001f4b8",
+	"credit_card":                       "\U0001f4b3",
+	"receipt":                           "\U0001f9fe",
+	"chart":                             "\U0001f4b9",
+	"email":                             "\u2709\ufe0f",
+	"envelope":                          "\u2709\ufe0f",
+	"e-mail":                            "\U0001f4e7",
+	"incoming_envelope":                 "\U0001f4e8",
+	"envelope_with_arrow":               "\U0001f4e9",
+	"outbox_tray":                       "\U0001f4e4",
+	"inbox_tray":                        "\U0001f4e5",
+	"package":                           "\U0001f4e6",
+	"mailbox":                           "\U0001f4eb",
+	"mailbox_closed":                    "\U0001f4ea",
+	"mailbox_with_mail":                 "\U0001f4ec",
+	"mailbox_with_no_mail":              "\U0001f4ed",
+	"postbox":                           "\U0001f4ee",
+	"ballot_box_with_ballot":            "\U0001f5f3\ufe0f",
+	"ballot_box":                        "\U0001f5f3\ufe0f",
+	"pencil2":                           "\u270f\ufe0f",
+	"black_nib":                         "\u2712\ufe0f",
+	"lower_left_fountain_pen":           "\U0001f58b\ufe0f",
+	"fountain_pen":                      "\U0001f58b\ufe0f",
+	"lower_left_ballpoint_pen":          "\U0001f58a\ufe0f",
+	"pen":                               "\U0001f58a\ufe0f",
+	"lower_left_paintbrush":             "\U0001f58c\ufe0f",
+	"paintbrush":                        "\U0001f58c\ufe0f",
+	"lower_left_crayon":                 "\U0001f58d\ufe0f",
+	"crayon":                            "\U0001f58d\ufe0f",
+	"memo":                              "\U0001f4dd",
+	"pencil":                            "\U0001f4dd",
+	"briefcase":                         "\U0001f4bc",
+	"file_folder":                       "\U0001f4c1",
+	"open_file_folder":                  "\U0001f4c2",
+	"card_index_dividers":               "\U0001f5c2\ufe0f",
+	"date":                              "\U0001f4c5",
+	"calendar":                          "\U0001f4c6",
+	"spiral_note_pad":                   "\U0001f5d2\ufe0f",
+	"spiral_notepad":                    "\U0001f5d2\ufe0f",
+	"spiral_calendar_pad":               "\U0001f5d3\ufe0f",
+	"spiral_calendar":                   "\U0001f5d3\ufe0f",
+	"card_index":                        "\U0001f4c7",
+	"chart_with_upwards_trend":          "\U0001f4c8",
+	"chart_with_downwards_trend":        "\U0001f4c9",
+	"bar_chart":                         "\U0001f4ca",
+	"clipboard":                         "\U0001f4cb",
+	"pushpin":                           "\U0001f4cc",
+	"round_pushpin":                     "\U0001f4cd",
+	"paperclip":                         "\U0001f4ce",
+	"linked_paperclips":                 "\U0001f587\ufe0f",
+	"paperclips":                        "\U0001f587\ufe0f",
+	"straight_ruler":                    "\U0001f4cf",
+	"triangular_ruler":                  "\U0001f4d0",
+	"scissors":                          "\u2702\ufe0f",
+	"card_file_box":                     "\U0001f5c3\ufe0f",
+	"file_cabinet":                      "\U0001f5c4\ufe0f",
+	"wastebasket":                       "\U0001f5d1\ufe0f",
+	"lock":                              "\U0001f512",
+	"unlock":                            "\U0001f513",
+	"lock_with_ink_pen":                 "\U0001f50f",
+	"closed_lock_with_key":              "\U0001f510",
+	"key":                               "\U0001f511",
+	"old_key":                           "\U0001f5dd\ufe0f",
+	"hammer":                            "\U0001f528",
+	"axe":                               "\U0001fa93",
+	"pick":                              "\u26cf\ufe0f",
+	"hammer_and_pick":                   "\u2692\ufe0f",
+	"hammer_and_wrench":                 "\U0001f6e0\ufe0f",
+	"dagger_knife":                      "\U0001f5e1\ufe0f",
+	"dagger":                            "\U0001f5e1\ufe0f",
+	"crossed_swords":                    "\u2694\ufe0f",
+	"gun":                               "\U0001f52b",
+	"boomerang":                         "\U0001fa83",
+	"bow_and_arrow":                     "\U0001f3f9",
+	"shield":                            "\U0001f6e1\ufe0f",
+	"carpentry_saw":                     "\U0001fa9a",
+	"wrench":                            "\U0001f527",
+	"screwdriver":                       "\U0001fa9b",
+	"nut_and_bolt":                      "\U0001f529",
+	"gear":                              "\u2699\ufe0f",
+	"compression":                       "\U0001f5dc\ufe0f",
+	"clamp":                             "\U0001f5dc\ufe0f",
+	"scales":                            "\u2696\ufe0f",
+	"balance_scale":                     "\u2696\ufe0f",
+	"probing_cane":                      "\U0001f9af",
+	"link":                              "\U0001f517",
+	"chains":                            "\u26d3\ufe0f",
+	"hook":                              "\U0001fa9d",
+	"toolbox":                           "\U0001f9f0",
+	"magnet":                            "\U0001f9f2",
+	"ladder":                            "\U0001fa9c",
+	"alembic":                           "\u2697\ufe0f",
+	"test_tube":                         "\U0001f9ea",
+	"petri_dish":                        "\U0001f9eb",
+	"dna":                               "\U0001f9ec",
+	"microscope":                        "\U0001f52c",
+	"telescope":                         "\U0001f52d",
+	"satellite_antenna":                 "\U0001f4e1",
+	"syringe":                           "\U0001f489",
+	"drop_of_blood":                     "\U0001fa78",
+	"pill":                              "\U0001f48a",
+	"adhesive_bandage":                  "\U0001fa79",
+	"stethoscope":                       "\U0001fa7a",
+	"door":                              "\U0001f6aa",
+	"elevator":                          "\U0001f6d7",
+	"mirror":                            "\U0001fa9e",
+	"window":                            "\U0001fa9f",
+	"bed":                               "\U0001f6cf\ufe0f",
+	"couch_and_lamp":                    "\U0001f6cb\ufe0f",
+	"chair":                             "\U0001fa91",
+	"toilet":                            "\U0001f6bd",
+	"plunger":                           "\U0001faa0",
+	"shower":                            "\U0001f6bf",
+	"bathtub":                           "\U0001f6c1",
+	"mouse_trap":                        "\U0001faa4",
+	"razor":                             "\U0001fa92",
+	"lotion_bottle":                     "\U0001f9f4",
+	"safety_pin":                        "\U0001f9f7",
+	"broom":                             "\U0001f9f9",
+	"basket":                            "\U0001f9fa",
+	"roll_of_paper":                     "\U0001f9fb",
+	"bucket":                            "\U0001faa3",
+	"soap":                              "\U0001f9fc",
+	"toothbrush":                        "\U0001faa5",
+	"sponge":                            "\U0001f9fd",
+	"fire_extinguisher":                 "\U0001f9ef",
+	"shopping_trolley":                  "\U0001f6d2",
+	"shopping_cart":                     "\U0001f6d2",
+	"smoking":                           "\U0001f6ac",
+	"coffin":                            "\u26b0\ufe0f",
+	"headstone":                         "\U0001faa6",
+	"funeral_urn":                       "\u26b1\ufe0f",
+	"moyai":                             "\U0001f5ff",
+	"placard":                           "\U0001faa7",
+	"atm":                               "\U0001f3e7",
+	"put_litter_in_its_place":           "\U0001f6ae",
+	"potable_water":                     "\U0001f6b0",
+	"wheelchair":                        "\u267f",
+	"mens":                              "\U0001f6b9",
+	"womens":                            "\U0001f6ba",
+	"restroom":                          "\U0001f6bb",
+	"baby_symbol":                       "\U0001f6bc",
+	"wc":                                "\U0001f6be",
+	"passport_control":                  "\U0001f6c2",
+	"customs":                           "\U0001f6c3",
+	"baggage_claim":                     "\U0001f6c4",
+	"left_luggage":                      "\U0001f6c5",
+	"warning":                           "\u26a0\ufe0f",
+	"children_crossing":                 "\U0001f6b8",
+	"no_entry":                          "\u26d4",
+	"no_entry_sign":                     "\U0001f6ab",
+	"no_bicycles":                       "\U0001f6b3",
+	"no_smoking":                        "\U0001f6ad",
+	"do_not_litter":                     "\U0001f6af",
+	"non-potable_water":                 "\U0001f6b1",
+	"no_pedestrians":                    "\U0001f6b7",
+	"no_mobile_phones":                  "\U0001f4f5",
+	"underage":                          "\U0001f51e",
+	"radioactive_sign":                  "\u2622\ufe0f",
+	"radioactive":                       "\u2622\ufe0f",
+	"biohazard_sign":                    "\u2623\ufe0f",
+	"biohazard":                         "\u2623\ufe0f",
+	"arrow_up":                          "\u2b06\ufe0f",
+	"arrow_upper_right":                 "\u2197\ufe0f",
+	"arrow_right":                       "\u27a1\ufe0f",
+	"arrow_lower_right":                 "\u2198\ufe0f",
+	"arrow_down":                        "\u2b07\ufe0f",
+	"arrow_lower_left":                  "\u2199\ufe0f",
+	"arrow_left":                        "\u2b05\ufe0f",
+	"arrow_upper_left":                  "\u2196\ufe0f",
+	"arrow_up_down":                     "\u2195\ufe0f",
+	"left_right_arrow":                  "\u2194\ufe0f",
+	"leftwards_arrow_with_hook":         "\u21a9\ufe0f",
+	"arrow_right_hook":                  "\u21aa\ufe0f",
+	"arrow_heading_up":                  "\u2934\ufe0f",
+	"arrow_heading_down":                "\u2935\ufe0f",
+	"arrows_clockwise":                  "\U0001f503",
+	"arrows_counterclockwise":           "\U0001f504",
+	"back":                              "\U0001f519",
+	"end":                               "\U0001f51a",
+	"on":                                "\U0001f51b",
+	"soon":                              "\U0001f51c",
+	"top":                               "\U0001f51d",
+	"place_of_worship":                  "\U0001f6d0",
+	"atom_symbol":                       "\u269b\ufe0f",
+	"om_symbol":                         "\U0001f549\ufe0f",
+	"om":                                "\U0001f549\ufe0f",
+	"star_of_david":                     "\u2721\ufe0f",
+	"wheel_of_dharma":                   "\u2638\ufe0f",
+	"yin_yang":                          "\u262f\ufe0f",
+	"latin_cross":                       "\u271d\ufe0f",
+	"orthodox_cross":                    "\u2626\ufe0f",
+	"star_and_crescent":                 "\u262a\ufe0f",
+	"peace_symbol":                      "\u262e\ufe0f",
+	"menorah_with_nine_branches":        "\U0001f54e",
+	"menorah":                           "\U0001f54e",
+	"six_pointed_star":                  "\U0001f52f",
+	"aries":                             "\u2648",
+	"taurus":                            "\u2649",
+	"gemini":                            "\u264a",
+	"cancer":                            "\u264b",
+	"leo":                               "\u264c",
+	"virgo":                             "\u264d",
+	"libra":                             "\u264e",
+	"scorpius":                          "\u264f",
+	"sagittarius":                       "\u2650",
+	"capricorn":                         "\u2651",
+	"aquarius":                          "\u2652",
+	"pisces":                            "\u2653",
+	"ophiuchus":                         "\u26ce",
+	"twisted_rightwards_arrows":         "\U0001f500",
+	"repeat":                            "\U0001f501",
+	"repeat_one":                        "\U0001f502",
+	"arrow_forward":                     "\u25b6\ufe0f",
+	"fast_forward":                      "\u23e9",
+	"black_right_pointing_double_triangle_with_vertical_bar": "\u23ed\ufe0f",
+	"next_track_button": "\u23ed\ufe0f",
+	"black_right_pointing_triangle_with_double_vertical_bar": "\u23ef\ufe0f",
+	"play_or_pause_button": "\u23ef\ufe0f",
+	"arrow_backward":       "\u25c0\ufe0f",
+	"rewind":               "\u23ea",
+	"black_left_pointing_double_triangle_with_vertical_bar": "\u23ee\ufe0f",
+	"previous_track_button":                                 "\u23ee\ufe0f",
+	"arrow_up_small":                                        "\U0001f53c",
+	"arrow_double_up":                                       "\u23eb",
+	"arrow_down_small":                                      "\U0001f53d",
+	"arrow_double_down":                                     "\u23ec",
+	"double_vertical_bar":                                   "\u23f8\ufe0f",
+	"pause_button":                                          "\u23f8\ufe0f",
+	"black_square_for_stop":                                 "\u23f9\ufe0f",
+	"stop_button":                                           "\u23f9\ufe0f",
+	"black_circle_for_record":                               "\u23fa\ufe0f",
+	"record_button":                                         "\u23fa\ufe0f",
+	"eject":                                                 "\u23cf\ufe0f",
+	"cinema":                                                "\U0001f3a6",
+	"low_brightness":                                        "\U0001f505",
+	"high_brightness":                                       "\U0001f506",
+	"signal_strength":                                       "\U0001f4f6",
+	"vibration_mode":                                        "\U0001f4f3",
+	"mobile_phone_off":                                      "\U0001f4f4",
+	"female_sign":                                           "\u2640\ufe0f",
+	"male_sign":                                             "\u2642\ufe0f",
+	"transgender_symbol":                                    "\u26a7\ufe0f",
+	"heavy_multiplication_x":                                "\u2716\ufe0f",
+	"heavy_plus_sign":                                       "\u2795",
+	"heavy_minus_sign":                                      "\u2796",
+	"heavy_division_sign":                                   "\u2797",
+	"infinity":                                              "\u267e\ufe0f",
+	"bangbang":                                              "\u203c\ufe0f",
+	"interrobang":                                           "\u2049\ufe0f",
+	"question":                                              "\u2753",
+	"grey_question":                                         "\u2754",
+	"grey_exclamation":                                      "\u2755",
+	"exclamation":                                           "\u2757",
+	"heavy_exclamation_mark":                                "\u2757",
+	"wavy_dash":                                             "\u3030\ufe0f",
+	"currency_exchange":                                     "\U0001f4b1",
+	"heavy_dollar_sign":                                     "\U0001f4b2",
+	"medical_symbol":                                        "\u2695\ufe0f",
+	"staff_of_aesculapius":                                  "\u2695\ufe0f",
+	"recycle":                                               "\u267b\ufe0f",
+	"fleur_de_lis":                                          "\u269c\ufe0f",
+	"trident":                                               "\U0001f531",
+	"name_badge":                                            "\U0001f4db",
+	"beginner":                                              "\U0001f530",
+	"o":                                                     "\u2b55",
+	"white_check_mark":                                      "\u2705",
+	"ballot_box_with_check":                                 "\u2611\ufe0f",
+	"heavy_check_mark":                                      "\u2714\ufe0f",
+	"x":                                                     "\u274c",
+	"negative_squared_cross_mark":                           "\u274e",
+	"curly_loop":                                            "\u27b0",
+	"loop":                                                  "\u27bf",
+	"part_alternation_mark":                                 "\u303d\ufe0f",
+	"eight_spoked_asterisk":                                 "\u2733\ufe0f",
+	"eight_pointed_black_star":                              "\u2734\ufe0f",
+	"sparkle":                                               "\u2747\ufe0f",
+	"copyright":                                             "\u00a9\ufe0f",
+	"registered":                                            "\u00ae\ufe0f",
+	"tm":                                                    "\u2122\ufe0f",
+	"hash":                                                  "#\ufe0f\u20e3",
+	"keycap_star":                                           "*\ufe0f\u20e3",
+	"asterisk":                                              "*\ufe0f\u20e3",
+	"zero":                                                  "0\ufe0f\u20e3",
+	"one":                                                   "1\ufe0f\u20e3",
+	"two":                                                   "2\ufe0f\u20e3",
+	"three":                                                 "3\ufe0f\u20e3",
+	"four":                                                  "4\ufe0f\u20e3",
+	"five":                                                  "5\ufe0f\u20e3",
+	"six":                                                   "6\ufe0f\u20e3",
+	"seven":                                                 "7\ufe0f\u20e3",
+	"eight":                                                 "8\ufe0f\u20e3",
+	"nine":                                                  "9\ufe0f\u20e3",
+	"keycap_ten":                                            "\U0001f51f",
+	"capital_abcd":                                          "\U0001f520",
+	"abcd":                                                  "\U0001f521",
+	"1234":                                                  "\U0001f522",
+	"symbols":                                               "\U0001f523",
+	"abc":                                                   "\U0001f524",
+	"a":                                                     "\U0001f170\ufe0f",
+	"ab":                                                    "\U0001f18e",
+	"b":                                                     "\U0001f171\ufe0f",
+	"cl":                                                    "\U0001f191",
+	"cool":                                                  "\U0001f192",
+	"free":                                                  "\U0001f193",
+	"information_source":                                    "\u2139\ufe0f",
+	"id":                                                    "\U0001f194",
+	"m":                                                     "\u24c2\ufe0f",
+	"new":                                                   "\U0001f195",
+	"ng":                                                    "\U0001f196",
+	"o2":                                                    "\U0001f17e\ufe0f",
+	"ok":                                                    "\U0001f197",
+	"parking":                                               "\U0001f17f\ufe0f",
+	"sos":                                                   "\U0001f198",
+	"up":                                                    "\U0001f199",
+	"vs":                                                    "\U0001f19a",
+	"koko":                                                  "\U0001f201",
+	"sa":                                                    "\U0001f202\ufe0f",
+	"u6708":                                                 "\U0001f237\ufe0f",
+	"u6709":                                                 "\U0001f236",
+	"u6307":                                                 "\U0001f22f",
+	"ideograph_advantage":                                   "\U0001f250",
+	"u5272":                                                 "\U0001f239",
+	"u7121":                                                 "\U0001f21a",
+	"u7981":                                                 "\U0001f232",
+	"accept":                                                "\U0001f251",
+	"u7533":                                                 "\U0001f238",
+	"u5408":                                                 "\U0001f234",
+	"u7a7a":                                                 "\U0001f233",
+	"congratulations":                                       "\u3297\ufe0f",
+	"secret":                                                "\u3299\ufe0f",
+	"u55b6":                                                 "\U0001f23a",
+	"u6e80":                                                 "\U0001f235",
+	"red_circle":                                            "\U0001f534",
+	"large_orange_circle":                                   "\U0001f7e0",
+	"large_yellow_circle":                                   "\U0001f7e1",
+	"large_green_circle":                                    "\U0001f7e2",
+	"large_blue_circle":                                     "\U0001f535",
+	"large_purple_circle":                                   "\U0001f7e3",
+	"large_brown_circle":                                    "\U0001f7e4",
+	"black_circle":                                          "\u26ab",
+	"white_circle":                                          "\u26aa",
+	"large_red_square":                                      "\U0001f7e5",
+	"large_orange_square":                                   "\U0001f7e7",
+	"large_yellow_square":                                   "\U0001f7e8",
+	"large_green_square":                                    "\U0001f7e9",
+	"large_blue_square":                                     "\U0001f7e6",
+	"large_purple_square":                                   "\U0001f7ea",
+	"large_brown_square":                                    "\U0001f7eb",
+	"black_large_square":                                    "\u2b1b",
+	"white_large_square":                                    "\u2b1c",
+	"black_medium_square":                                   "\u25fc\ufe0f",
+	"white_medium_square":                                   "\u25fb\ufe0f",
+	"black_medium_small_square":                             "\u25fe",
+	"white_medium_small_square":                             "\u25fd",
+	"black_small_square":                                    "\u25aa\ufe0f",
+	"white_small_square":                                    "\u25ab\ufe0f",
+	"large_orange_diamond":                                  "\U0001f536",
+	"large_blue_diamond":                                    "\U0001f537",
+	"small_orange_diamond":                                  "\U0001f538",
+	"small_blue_diamond":                                    "\U0001f539",
+	"small_red_triangle":                                    "\U0001f53a",
+	"small_red_triangle_down":                               "\U0001f53b",
+	"diamond_shape_with_a_dot_inside":                       "\U0001f4a0",
+	"radio_button":                                          "\U0001f518",
+	"white_square_button":                                   "\U0001f533",
+	"black_square_button":                                   "\U0001f532",
+	"checkered_flag":                                        "\U0001f3c1",
+	"triangular_flag_on_post":                               "\U0001f6a9",
+	"crossed_flags":                                         "\U0001f38c",
+	"waving_black_flag":                                     "\U0001f3f4",
+	"black_flag":                                            "\U0001f3f4",
+	"waving_white_flag":                                     "\U0001f3f3\ufe0f",
+	"white_flag":                                            "\U0001f3f3\ufe0f",
+	"rainbow-flag":                                          "\U0001f3f3\ufe0f\u200d\U0001f308",
+	"rainbow_flag":                                          "\U0001f3f3\ufe0f\u200d\U0001f308",
+	"transgender_flag":                                      "\U0001f3f3\ufe0f\u200d\u26a7\ufe0f",
+	"pirate_flag":                                           "\U0001f3f4\u200d\u2620\ufe0f",
+	"flag-ac":                                               "\U0001f1e6\U0001f1e8",
+	"flag-ad":                                               "\U0001f1e6\U0001f1e9",
+	"andorra":                                               "\U0001f1e6\U0001f1e9",
+	"flag-ae":                                               "\U0001f1e6\U0001f1ea",
+	"united_arab_emirates":                                  "\U0001f1e6\U0001f1ea",
+	"flag-af":                                               "\U0001f1e6\U0001f1eb",
+	"afghanistan":                                           "\U0001f1e6\U0001f1eb",
+	"flag-ag":                                               "\U0001f1e6\U0001f1ec",
+	"antigua_barbuda":                                       "\U0001f1e6\U0001f1ec",
+	"flag-ai":                                               "\U0001f1e6\U0001f1ee",
+	"anguilla":                                              "\U0001f1e6\U0001f1ee",
+	"flag-al":                                               "\U0001f1e6\U0001f1f1",
+	"albania":                                               "\U0001f1e6\U0001f1f1",
+	"flag-am":                                               "\U0001f1e6\U0001f1f2",
+	"armenia":                                               "\U0001f1e6\U0001f1f2",
+	"flag-ao":                                               "\U0001f1e6\U0001f1f4",
+	"angola":                                                "\U0001f1e6\U0001f1f4",
+	"flag-aq":                                               "\U0001f1e6\U0001f1f6",
+	"antarctica":                                            "\U0001f1e6\U0001f1f6",
+	"flag-ar":                                               "\U0001f1e6\U0001f1f7",
+	"argentina":                                             "\U0001f1e6\U0001f1f7",
+	"flag-as":                                               "\U0001f1e6\U0001f1f8",
+	"american_samoa":                                        "\U0001f1e6\U0001f1f8",
+	"flag-at":                                               "\U0001f1e6\U0001f1f9",
+	"austria":                                               "\U0001f1e6\U0001f1f9",
+	"flag-au":                                               "\U0001f1e6\U0001f1fa",
+	"australia":                                             "\U0001f1e6\U0001f1fa",
+	"flag-aw":                                               "\U0001f1e6\U0001f1fc",
+	"aruba":                                                 "\U0001f1e6\U0001f1fc",
+	"flag-ax":                                               "\U0001f1e6\U0001f1fd",
+	"aland_islands":                                         "\U0001f1e6\U0001f1fd",
+	"flag-az":                                               "\U0001f1e6\U0001f1ff",
+	"azerbaijan":                                            "\U0001f1e6\U0001f1ff",
+	"flag-ba":                                               "\U0001f1e7\U0001f1e6",
+	"bosnia_herzegovina":                                    "\U0001f1e7\U0001f1e6",
+	"flag-bb":                                               "\U0001f1e7\U0001f1e7",
+	"barbados":                                              "\U0001f1e7\U0001f1e7",
+	"flag-bd":                                               "\U0001f1e7\U0001f1e9",
+	"bangladesh":                                            "\U0001f1e7\U0001f1e9",
+	"flag-be":                                               "\U0001f1e7\U0001f1ea",
+	"belgium":                                               "\U0001f1e7\U0001f1ea",
+	"flag-bf":                                               "\U0001f1e7\U0001f1eb",
+	"burkina_faso":                                          "\U0001f1e7\U0001f1eb",
+	"flag-bg":                                               "\U0001f1e7\U0001f1ec",
+	"bulgaria":                                              "\U0001f1e7\U0001f1ec",
+	"flag-bh":                                               "\U0001f1e7\U0001f1ed",
+	"bahrain":                                               "\U0001f1e7\U0001f1ed",
+	"flag-bi":                                               "\U0001f1e7\U0001f1ee",
+	"burundi":                                               "\U0001f1e7\U0001f1ee",
+	"flag-bj":                                               "\U0001f1e7\U0001f1ef",
+	"benin":                                                 "\U0001f1e7\U0001f1ef",
+	"flag-bl":                                               "\U0001f1e7\U0001f1f1",
+	"st_barthelemy":                                         "\U0001f1e7\U0001f1f1",
+	"flag-bm":                                               "\U0001f1e7\U0001f1f2",
+	"bermuda":                                               "\U0001f1e7\U0001f1f2",
+	"flag-bn":                                               "\U0001f1e7\U0001f1f3",
+	"brunei":                                                "\U0001f1e7\U0001f1f3",
+	"flag-bo":                                               "\U0001f1e7\U0001f1f4",
+	"bolivia":                                               "\U0001f1e7\U0001f1f4",
+	"flag-bq":                                               "\U0001f1e7\U0001f1f6",
+	"caribbean_netherlands":                                 "\U0001f1e7\U0001f1f6",
+	"flag-br":                                               "\U0001f1e7\U0001f1f7",
+	"brazil":                                                "\U0001f1e7\U0001f1f7",
+	"flag-bs":                                               "\U0001f1e7\U0001f1f8",
+	"bahamas":                                               "\U0001f1e7\U0001f1f8",
+	"flag-bt":                                               "\U0001f1e7\U0001f1f9",
+	"bhutan":                                                "\U0001f1e7\U0001f1f9",
+	"flag-bv":                                               "\U0001f1e7\U0001f1fb",
+	"flag-bw":                                               "\U0001f1e7\U0001f1fc",
+	"botswana":                                              "\U0001f1e7\U0001f1fc",
+	"flag-by":                                               "\U0001f1e7\U0001f1fe",
+	"belarus":                                               "\U0001f1e7\U0001f1fe",
+	"flag-bz":                                               "\U0001f1e7\U0001f1ff",
+	"belize":                                                "\U0001f1e7\U0001f1ff",
+	"flag-ca":                                               "\U0001f1e8\U0001f1e6",
+	"ca":                                                    "\U0001f1e8\U0001f1e6",
+	"canada":                                                "\U0001f1e8\U0001f1e6",
+	"flag-cc":                                               "\U0001f1e8\U0001f1e8",
+	"cocos_islands":                                         "\U0001f1e8\U0001f1e8",
+	"flag-cd":                                               "\U0001f1e8\U0001f1e9",
+	"congo_kinshasa":                                        "\U0001f1e8\U0001f1e9",
+	"flag-cf":                                               "\U0001f1e8\U0001f1eb",
+	"central_african_republic":                              "\U0001f1e8\U0001f1eb",
+	"flag-cg":                                               "\U0001f1e8\U0001f1ec",
+	"congo_brazzaville":                                     "\U0001f1e8\U0001f1ec",
+	"flag-ch":                                               "\U0001f1e8\U0001f1ed",
+	"switzerland":                                           "\U0001f1e8\U0001f1ed",
+	"flag-ci":                                               "\U0001f1e8\U0001f1ee",
+	"cote_divoire":                                          "\U0001f1e8\U0001f1ee",
+	"flag-ck":                                               "\U0001f1e8\U0001f1f0",
+	"cook_islands":                                          "\U0001f1e8\U0001f1f0",
+	"flag-cl":                                               "\U0001f1e8\U0001f1f1",
+	"chile":                                                 "\U0001f1e8\U0001f1f1",
+	"flag-cm":                                               "\U0001f1e8\U0001f1f2",
+	"cameroon":                                              "\U0001f1e8\U0001f1f2",
+	"cn":                                                    "\U0001f1e8\U0001f1f3",
+	"flag-cn":                                               "\U0001f1e8\U0001f1f3",
+	"flag-co":                                               "\U0001f1e8\U0001f1f4",
+	"colombia":                                              "\U0001f1e8\U0001f1f4",
+	"flag-cp":                                               "\U0001f1e8\U0001f1f5",
+	"flag-cr":                                               "\U0001f1e8\U0001f1f7",
+	"costa_rica":                                            "\U0001f1e8\U0001f1f7",
+	"flag-cu":                                               "\U0001f1e8\U0001f1fa",
+	"cuba":                                                  "\U0001f1e8\U0001f1fa",
+	"flag-cv":                                               "\U0001f1e8\U0001f1fb",
+	"cape_verde":                                            "\U0001f1e8\U0001f1fb",
+	"flag-cw":                                               "\U0001f1e8\U0001f1fc",
+	"curacao":                                               "\U0001f1e8\U0001f1fc",
+	"flag-cx":                                               "\U0001f1e8\U0001f1fd",
+	"christmas_island":                                      "\U0001f1e8\U0001f1fd",
+	"flag-cy":                                               "\U0001f1e8\U0001f1fe",
+	"cyprus":                                                "\U0001f1e8\U0001f1fe",
+	"flag-cz":                                               "\U0001f1e8\U0001f1ff",
+	"czech_republic":                                        "\U0001f1e8\U0001f1ff",
+	"de":                                                    "\U0001f1e9\U0001f1ea",
+	"flag-de":                                               "\U0001f1e9\U0001f1ea",
+	"flag-dg":                                               "\U0001f1e9\U0001f1ec",
+	"flag-dj":                                               "\U0001f1e9\U0001f1ef",
+	"djibouti":                                              "\U0001f1e9\U0001f1ef",
+	"flag-dk":                                               "\U0001f1e9\U0001f1f0",
+	"denmark":                                               "\U0001f1e9\U0001f1f0",
+	"flag-dm":                                               "\U0001f1e9\U0001f1f2",
+	"dominica":                                              "\U0001f1e9\U0001f1f2",
+	"flag-do":                                               "\U0001f1e9\U0001f1f4",
+	"dominican_republic":                                    "\U0001f1e9\U0001f1f4",
+	"flag-dz":                                               "\U0001f1e9\U0001f1ff",
+	"algeria":                                               "\U0001f1e9\U0001f1ff",
+	"flag-ea":                                               "\U0001f1ea\U0001f1e6",
+	"flag-ec":                                               "\U0001f1ea\U0001f1e8",
+	"ecuador":                                               "\U0001f1ea\U0001f1e8",
+	"flag-ee":                                               "\U0001f1ea\U0001f1ea",
+	"estonia":                                               "\U0001f1ea\U0001f1ea",
+	"flag-eg":                                               "\U0001f1ea\U0001f1ec",
+	"egypt":                                                 "\U0001f1ea\U0001f1ec",
+	"flag-eh":                                               "\U0001f1ea\U0001f1ed",
+	"western_sahara":                                        "\U0001f1ea\U0001f1ed",
+	"flag-er":                                               "\U0001f1ea\U0001f1f7",
+	"eritrea":                                               "\U0001f1ea\U0001f1f7",
+	"es":                                                    "\U0001f1ea\U0001f1f8",
+	"flag-es":                                               "\U0001f1ea\U0001f1f8",
+	"flag-et":                                               "\U0001f1ea\U0001f1f9",
+	"ethiopia":                                              "\U0001f1ea\U0001f1f9",
+	"flag-eu":                                               "\U0001f1ea\U0001f1fa",
+	"eu":                                                    "\U0001f1ea\U0001f1fa",
+	"european_union":                                        "\U0001f1ea\U0001f1fa",
+	"flag-fi":                                               "\U0001f1eb\U0001f1ee",
+	"finland":                                               "\U0001f1eb\U0001f1ee",
+	"flag-fj":                                               "\U0001f1eb\U0001f1ef",
+	"fiji":                                                  "\U0001f1eb\U0001f1ef",
+	"flag-fk":                                               "\U0001f1eb\U0001f1f0",
+	"falkland_islands":                                      "\U0001f1eb\U0001f1f0",
+	"flag-fm":                                               "\U0001f1eb\U0001f1f2",
+	"micronesia":                                            "\U0001f1eb\U0001f1f2",
+	"flag-fo":                                               "\U0001f1eb\U0001f1f4",
+	"faroe_islands":                                         "\U0001f1eb\U0001f1f4",
+	"fr":                                                    "\U0001f1eb\U0001f1f7",
+	"flag-fr":                                               "\U0001f1eb\U0001f1f7",
+	"flag-ga":                                               "\U0001f1ec\U0001f1e6",
+	"gabon":                                                 "\U0001f1ec\U0001f1e6",
+	"gb":                                                    "\U0001f1ec\U0001f1e7",
+	"uk":                                                    "\U0001f1ec\U0001f1e7",
+	"flag-gb":                                               "\U0001f1ec\U0001f1e7",
+	"flag-gd":                                               "\U0001f1ec\U0001f1e9",
+	"grenada":                                               "\U0001f1ec\U0001f1e9",
+	"flag-ge":                                               "\U0001f1ec\U0001f1ea",
+	"georgia":                                               "\U0001f1ec\U0001f1ea",
+	"flag-gf":                                               "\U0001f1ec\U0001f1eb",
+	"french_guiana":                                         "\U0001f1ec\U0001f1eb",
+	"flag-gg":                                               "\U0001f1ec\U0001f1ec",
+	"guernsey":                                              "\U0001f1ec\U0001f1ec",
+	"flag-gh":                                               "\U0001f1ec\U0001f1ed",
+	"ghana":                                                 "\U0001f1ec\U0001f1ed",
+	"flag-gi":                                               "\U0001f1ec\U0001f1ee",
+	"gibraltar":                                             "\U0001f1ec\U0001f1ee",
+	"flag-gl":                                               "\U0001f1ec\U0001f1f1",
+	"greenland":                                             "\U0001f1ec\U0001f1f1",
+	"flag-gm":                                               "\U0001f1ec\U0001f1f2",
+	"gambia":                                                "\U0001f1ec\U0001f1f2",
+	"flag-gn":                                               "\U0001f1ec\U0001f1f3",
+	"guinea":                                                "\U0001f1ec\U0001f1f3",
+	"flag-gp":                                               "\U0001f1ec\U0001f1f5",
+	"guadeloupe":                                            "\U0001f1ec\U0001f1f5",
+	"flag-gq":                                               "\U0001f1ec\U0001f1f6",
+	"equatorial_guinea":                                     "\U0001f1ec\U0001f1f6",
+	"flag-gr":                                               "\U0001f1ec\U0001f1f7",
+	"greece":                                                "\U0001f1ec\U0001f1f7",
+	"flag-gs":                                               "\U0001f1ec\U0001f1f8",
+	"south_georgia_south_sandwich_islands":                  "\U0001f1ec\U0001f1f8",
+	"flag-gt":                                               "\U0001f1ec\U0001f1f9",
+	"guatemala":                                             "\U0001f1ec\U0001f1f9",
+	"flag-gu":                                               "\U0001f1ec\U0001f1fa",
+	"guam":                                                  "\U0001f1ec\U0001f1fa",
+	"flag-gw":                                               "\U0001f1ec\U0001f1fc",
+	"guinea_bissau":                                         "\U0001f1ec\U0001f1fc",
+	"flag-gy":                                               "\U0001f1ec\U0001f1fe",
+	"guyana":                                                "\U0001f1ec\U0001f1fe",
+	"flag-hk":                                               "\U0001f1ed\U0001f1f0",
+	"hong_kong":                                             "\U0001f1ed\U0001f1f0",
+	"flag-hm":                                               "\U0001f1ed\U0001f1f2",
+	"flag-hn":                                               "\U0001f1ed\U0001f1f3",
+	"honduras":                                              "\U0001f1ed\U0001f1f3",
+	"flag-hr":                                               "\U0001f1ed\U0001f1f7",
+	"croatia":                                               "\U0001f1ed\U0001f1f7",
+	"flag-ht":                                               "\U0001f1ed\U0001f1f9",
+	"haiti":                                                 "\U0001f1ed\U0001f1f9",
+	"flag-hu":                                               "\U0001f1ed\U0001f1fa",
+	"hungary":                                               "\U0001f1ed\U0001f1fa",
+	"flag-ic":                                               "\U0001f1ee\U0001f1e8",
+	"canary_islands":                                        "\U0001f1ee\U0001f1e8",
+	"flag-id":                                               "\U0001f1ee\U0001f1e9",
+	"indonesia":                                             "\U0001f1ee\U0001f1e9",
+	"flag-ie":                                               "\U0001f1ee\U0001f1ea",
+	"ireland":                                               "\U0001f1ee\U0001f1ea",
+	"flag-il":                                               "\U0001f1ee\U0001f1f1",
+	"israel":                                                "\U0001f1ee\U0001f1f1",
+	"flag-im":                                               "\U0001f1ee\U0001f1f2",
+	"isle_of_man":                                           "\U0001f1ee\U0001f1f2",
+	"flag-in":                                               "\U0001f1ee\U0001f1f3",
+	"india":                                                 "\U0001f1ee\U0001f1f3",
+	"flag-io":                                               "\U0001f1ee\U0001f1f4",
+	"british_indian_ocean_territory":                        "\U0001f1ee\U0001f1f4",
+	"flag-iq":                                               "\U0001f1ee\U0001f1f6",
+	"iraq":                                                  "\U0001f1ee\U0001f1f6",
+	"flag-ir":                                               "\U0001f1ee\U0001f1f7",
+	"iran":                                                  "\U0001f1ee\U0001f1f7",
+	"flag-is":                                               "\U0001f1ee\U0001f1f8",
+	"iceland":                                               "\U0001f1ee\U0001f1f8",
+	"it":                                                    "\U0001f1ee\U0001f1f9",
+	"flag-it":                                               "\U0001f1ee\U0001f1f9",
+	"flag-je":                                               "\U0001f1ef\U0001f1ea",
+	"jersey":                                                "\U0001f1ef\U0001f1ea",
+	"flag-jm":                                               "\U0001f1ef\U0001f1f2",
+	"jamaica":                                               "\U0001f1ef\U0001f1f2",
+	"flag-jo":                                               "\U0001f1ef\U0001f1f4",
+	"jordan":                                                "\U0001f1ef\U0001f1f4",
+	"jp":                                                    "\U0001f1ef\U0001f1f5",
+	"flag-jp":                                               "\U0001f1ef\U0001f1f5",
+	"flag-ke":                                               "\U0001f1f0\U0001f1ea",
+	"kenya":                                                 "\U0001f1f0\U0001f1ea",
+	"flag-kg":                                               "\U0001f1f0\U0001f1ec",
+	"kyrgyzstan":                                            "\U0001f1f0\U0001f1ec",
+	"flag-kh":                                               "\U0001f1f0\U0001f1ed",
+	"cambodia":                                              "\U0001f1f0\U0001f1ed",
+	"flag-ki":                                               "\U0001f1f0\U0001f1ee",
+	"kiribati":                                              "\U0001f1f0\U0001f1ee",
+	"flag-km":                                               "\U0001f1f0\U0001f1f2",
+	"comoros":                                               "\U0001f1f0\U0001f1f2",
+	"flag-kn":                                               "\U0001f1f0\U0001f1f3",
+	"st_kitts_nevis":                                        "\U0001f1f0\U0001f1f3",
+	"flag-kp":                                               "\U0001f1f0\U0001f1f5",
+	"north_korea":                                           "\U0001f1f0\U0001f1f5",
+	"kr":                                                    "\U0001f1f0\U0001f1f7",
+	"flag-kr":                                               "\U0001f1f0\U0001f1f7",
+	"flag-kw":                                               "\U0001f1f0\U0001f1fc",
+	"kuwait":                                                "\U0001f1f0\U0001f1fc",
+	"flag-ky":                                               "\U0001f1f0\U0001f1fe",
+	"cayman_islands":                                        "\U0001f1f0\U0001f1fe",
+	"flag-kz":                                               "\U0001f1f0\U0001f1ff",
+	"kazakhstan":                                            "\U0001f1f0\U0001f1ff",
+	"flag-la":                                               "\U0001f1f1\U0001f1e6",
+	"laos":                                                  "\U0001f1f1\U0001f1e6",
+	"flag-lb":                                               "\U0001f1f1\U0001f1e7",
+	"lebanon":                                               "\U0001f1f1\U0001f1e7",
+	"flag-lc":                                               "\U0001f1f1\U0001f1e8",
+	"st_lucia":                                              "\U0001f1f1\U0001f1e8",
+	"flag-li":                                               "\U0001f1f1\U0001f1ee",
+	"liechtenstein":                                         "\U0001f1f1\U0001f1ee",
+	"flag-lk":                                               "\U0001f1f1\U0001f1f0",
+	"sri_lanka":                                             "\U0001f1f1\U0001f1f0",
+	"flag-lr":                                               "\U0001f1f1\U0001f1f7",
+	"liberia":                                               "\U0001f1f1\U0001f1f7",
+	"flag-ls":                                               "\U0001f1f1\U0001f1f8",
+	"lesotho":                                               "\U0001f1f1\U0001f1f8",
+	"flag-lt":                                               "\U0001f1f1\U0001f1f9",
+	"lithuania":                                             "\U0001f1f1\U0001f1f9",
+	"flag-lu":                                               "\U0001f1f1\U0001f1fa",
+	"luxembourg":                                            "\U0001f1f1\U0001f1fa",
+	"flag-lv":                                               "\U0001f1f1\U0001f1fb",
+	"latvia":                                                "\U0001f1f1\U0001f1fb",
+	"flag-ly":                                               "\U0001f1f1\U0001f1fe",
+	"libya":                                                 "\U0001f1f1\U0001f1fe",
+	"flag-ma":                                               "\U0001f1f2\U0001f1e6",
+	"morocco":                                               "\U0001f1f2\U0001f1e6",
+	"flag-mc":                                               "\U0001f1f2\U0001f1e8",
+	"monaco":                                                "\U0001f1f2\U0001f1e8",
+	"flag-md":                                               "\U0001f1f2\U0001f1e9",
+	"moldova":                                               "\U0001f1f2\U0001f1e9",
+	"flag-me":                                               "\U0001f1f2\U0001f1ea",
+	"montenegro":                                            "\U0001f1f2\U0001f1ea",
+	"flag-mf":                                               "\U0001f1f2\U0001f1eb",
+	"flag-mg":                                               "\U0001f1f2\U0001f1ec",
+	"madagascar":                                            "\U0001f1f2\U0001f1ec",
+	"flag-mh":                                               "\U0001f1f2\U0001f1ed",
+	"marshall_islands":                                      "\U0001f1f2\U0001f1ed",
+	"flag-mk":                                               "\U0001f1f2\U0001f1f0",
+	"macedonia":                                             "\U0001f1f2\U0001f1f0",
+	"flag-ml":                                               "\U0001f1f2\U0001f1f1",
+	"mali":                                                  "\U0001f1f2\U0001f1f1",
+	"flag-mm":                                               "\U0001f1f2\U0001f1f2",
+	"myanmar":                                               "\U0001f1f2\U0001f1f2",
+	"flag-mn":                                               "\U0001f1f2\U0001f1f3",
+	"mongolia":                                              "\U0001f1f2\U0001f1f3",
+	"flag-mo":                                               "\U0001f1f2\U0001f1f4",
+	"macau":                                                 "\U0001f1f2\U0001f1f4",
+	"flag-mp":                                               "\U0001f1f2\U0001f1f5",
+	"northern_mariana_islands":                              "\U0001f1f2\U0001f1f5",
+	"flag-mq":                                               "\U0001f1f2\U0001f1f6",
+	"martinique":                                            "\U0001f1f2\U0001f1f6",
+	"flag-mr":                                               "\U0001f1f2\U0001f1f7",
+	"mauritania":                                            "\U0001f1f2\U0001f1f7",
+	"flag-ms":                                               "\U0001f1f2\U0001f1f8",
+	"montserrat":                                            "\U0001f1f2\U0001f1f8",
+	"flag-mt":                                               "\U0001f1f2\U0001f1f9",
+	"malta":                                                 "\U0001f1f2\U0001f1f9",
+	"flag-mu":                                               "\U0001f1f2\U0001f1fa",
+	"mauritius":                                             "\U0001f1f2\U0001f1fa",
+	"flag-mv":                                               "\U0001f1f2\U0001f1fb",
+	"maldives":                                              "\U0001f1f2\U0001f1fb",
+	"flag-mw":                                               "\U0001f1f2\U0001f1fc",
+	"malawi":                                                "\U0001f1f2\U0001f1fc",
+	"flag-mx":                                               "\U0001f1f2\U0001f1fd",
+	"mexico":                                                "\U0001f1f2\U0001f1fd",
+	"flag-my":                                               "\U0001f1f2\U0001f1fe",
+	"malaysia":                                              "\U0001f1f2\U0001f1fe",
+	"flag-mz":                                               "\U0001f1f2\U0001f1ff",
+	"mozambique":                                            "\U0001f1f2\U0001f1ff",
+	"flag-na":                                               "\U0001f1f3\U0001f1e6",
+	"namibia":                                               "\U0001f1f3\U0001f1e6",
+	"flag-nc":                                               "\U0001f1f3\U0001f1e8",
+	"new_caledonia":                                         "\U0001f1f3\U0001f1e8",
+	"flag-ne":                                               "\U0001f1f3\U0001f1ea",
+	"niger":                                                 "\U0001f1f3\U0001f1ea",
+	"flag-nf":                                               "\U0001f1f3\U0001f1eb",
+	"norfolk_island":                                        "\U0001f1f3\U0001f1eb",
+	"flag-ng":                                               "\U0001f1f3\U0001f1ec",
+	"nigeria":                                               "\U0001f1f3\U0001f1ec",
+	"flag-ni":                                               "\U0001f1f3\U0001f1ee",
+	"nicaragua":                                             "\U0001f1f3\U0001f1ee",
+	"flag-nl":                                               "\U0001f1f3\U0001f1f1",
+	"netherlands":                                           "\U0001f1f3\U0001f1f1",
+	"flag-no":                                               "\U0001f1f3\U0001f1f4",
+	"norway":                                                "\U0001f1f3\U0001f1f4",
+	"flag-np":                                               "\U0001f1f3\U0001f1f5",
+	"nepal":                                                 "\U0001f1f3\U0001f1f5",
+	"flag-nr":                                               "\U0001f1f3\U0001f1f7",
+	"nauru":                                                 "\U0001f1f3\U0001f1f7",
+	"flag-nu":                                               "\U0001f1f3\U0001f1fa",
+	"niue":                                                  "\U0001f1f3\U0001f1fa",
+	"flag-nz":                                               "\U0001f1f3\U0001f1ff",
+	"new_zealand":                                           "\U0001f1f3\U0001f1ff",
+	"flag-om":                                               "\U0001f1f4\U0001f1f2",
+	"oman":                                                  "\U0001f1f4\U0001f1f2",
+	"flag-pa":                                               "\U0001f1f5\U0001f1e6",
+	"panama":                                                "\U0001f1f5\U0001f1e6",
+	"flag-pe":                                               "\U0001f1f5\U0001f1ea",
+	"peru":                                                  "\U0001f1f5\U0001f1ea",
+	"flag-pf":                                               "\U0001f1f5\U0001f1eb",
+	"french_polynesia":                                      "\U0001f1f5\U0001f1eb",
+	"flag-pg":                                               "\U0001f1f5\U0001f1ec",
+	"papua_new_guinea":                                      "\U0001f1f5\U0001f1ec",
+	"flag-ph":                                               "\U0001f1f5\U0001f1ed",
+	"philippines":                                           "\U0001f1f5\U0001f1ed",
+	"flag-pk":                                               "\U0001f1f5\U0001f1f0",
+	"pakistan":                                              "\U0001f1f5\U0001f1f0",
+	"pk":                                                    "\U0001f1f5\U0001f1f0",
+	"flag-pl":                                               "\U0001f1f5\U0001f1f1",
+	"poland":                                                "\U0001f1f5\U0001f1f1",
+	"flag-pm":                                               "\U0001f1f5\U0001f1f2",
+	"st_pierre_miquelon":                                    "\U0001f1f5\U0001f1f2",
+	"flag-pn":                                               "\U0001f1f5\U0001f1f3",
+	"pitcairn_islands":                                      "\U0001f1f5\U0001f1f3",
+	"flag-pr":                                               "\U0001f1f5\U0001f1f7",
+	"puerto_rico":                                           "\U0001f1f5\U0001f1f7",
+	"flag-ps":                                               "\U0001f1f5\U0001f1f8",
+	"palestinian_territories":                               "\U0001f1f5\U0001f1f8",
+	"flag-pt":                                               "\U0001f1f5\U0001f1f9",
+	"portugal":                                              "\U0001f1f5\U0001f1f9",
+	"flag-pw":                                               "\U0001f1f5\U0001f1fc",
+	"palau":                                                 "\U0001f1f5\U0001f1fc",
+	"flag-py":                                               "\U0001f1f5\U0001f1fe",
+	"paraguay":                                              "\U0001f1f5\U0001f1fe",
+	"flag-qa":                                               "\U0001f1f6\U0001f1e6",
+	"qatar":                                                 "\U0001f1f6\U0001f1e6",
+	"flag-re":                                               "\U0001f1f7\U0001f1ea",
+	"reunion":                                               "\U0001f1f7\U0001f1ea",
+	"flag-ro":                                               "\U0001f1f7\U0001f1f4",
+	"romania":                                               "\U0001f1f7\U0001f1f4",
+	"flag-rs":                                               "\U0001f1f7\U0001f1f8",
+	"serbia":                                                "\U0001f1f7\U0001f1f8",
+	"ru":                                                    "\U0001f1f7\U0001f1fa",
+	"flag-ru":                                               "\U0001f1f7\U0001f1fa",
+	"flag-rw":                                               "\U0001f1f7\U0001f1fc",
+	"rwanda":                                                "\U0001f1f7\U0001f1fc",
+	"flag-sa":                                               "\U0001f1f8\U0001f1e6",
+	"saudi_arabia":                                          "\U0001f1f8\U0001f1e6",
+	"flag-sb":                                               "\U0001f1f8\U0001f1e7",
+	"solomon_islands":                                       "\U0001f1f8\U0001f1e7",
+	"flag-sc":                                               "\U0001f1f8\U0001f1e8",
+	"seychelles":                                            "\U0001f1f8\U0001f1e8",
+	"flag-sd":                                               "\U0001f1f8\U0001f1e9",
+	"sudan":                                                 "\U0001f1f8\U0001f1e9",
+	"flag-se":                                               "\U0001f1f8\U0001f1ea",
+	"sweden":                                                "\U0001f1f8\U0001f1ea",
+	"flag-sg":                                               "\U0001f1f8\U0001f1ec",
+	"singapore":                                             "\U0001f1f8\U0001f1ec",
+	"flag-sh":                                               "\U0001f1f8\U0001f1ed",
+	"st_helena":                                             "\U0001f1f8\U0001f1ed",
+	"flag-si":                                               "\U0001f1f8\U0001f1ee",
+	"slovenia":                                              "\U0001f1f8\U0001f1ee",
+	"flag-sj":                                               "\U0001f1f8\U0001f1ef",
+	"flag-sk":                                               "\U0001f1f8\U0001f1f0",
+	"slovakia":                                              "\U0001f1f8\U0001f1f0",
+	"flag-sl":                                               "\U0001f1f8\U0001f1f1",
+	"sierra_leone":                                          "\U0001f1f8\U0001f1f1",
+	"flag-sm":                                               "\U0001f1f8\U0001f1f2",
+	"san_marino":                                            "\U0001f1f8\U0001f1f2",
+	"flag-sn":                                               "\U0001f1f8\U0001f1f3",
+	"senegal":                                               "\U0001f1f8\U0001f1f3",
+	"flag-so":                                               "\U0001f1f8\U0001f1f4",
+	"somalia":                                               "\U0001f1f8\U0001f1f4",
+	"flag-sr":                                               "\U0001f1f8\U0001f1f7",
+	"suriname":                                              "\U0001f1f8\U0001f1f7",
+	"flag-ss":                                               "\U0001f1f8\U0001f1f8",
+	"south_sudan":                                           "\U0001f1f8\U0001f1f8",
+	"flag-st":                                               "\U0001f1f8\U0001f1f9",
+	"sao_tome_principe":                                     "\U0001f1f8\U0001f1f9",
+	"flag-sv":                                               "\U0001f1f8\U0001f1fb",
+	"el_salvador":                                           "\U0001f1f8\U0001f1fb",
+	"flag-sx":                                               "\U0001f1f8\U0001f1fd",
+	"sint_maarten":                                          "\U0001f1f8\U0001f1fd",
+	"flag-sy":                                               "\U0001f1f8\U0001f1fe",
+	"syria":                                                 "\U0001f1f8\U0001f1fe",
+	"flag-sz":                                               "\U0001f1f8\U0001f1ff",
+	"swaziland":                                             "\U0001f1f8\U0001f1ff",
+	"flag-ta":                                               "\U0001f1f9\U0001f1e6",
+	"flag-tc":                                               "\U0001f1f9\U0001f1e8",
+	"turks_caicos_islands":                                  "\U0001f1f9\U0001f1e8",
+	"flag-td":                                               "\U0001f1f9\U0001f1e9",
+	"chad":                                                  "\U0001f1f9\U0001f1e9",
+	"flag-tf":                                               "\U0001f1f9\U0001f1eb",
+	"french_southern_territories":                           "\U0001f1f9\U0001f1eb",
+	"flag-tg":                                               "\U0001f1f9\U0001f1ec",
+	"togo":                                                  "\U0001f1f9\U0001f1ec",
+	"flag-th":                                               "\U0001f1f9\U0001f1ed",
+	"thailand":                                              "\U0001f1f9\U0001f1ed",
+	"flag-tj":                                               "\U0001f1f9\U0001f1ef",
+	"tajikistan":                                            "\U0001f1f9\U0001f1ef",
+	"flag-tk":                                               "\U0001f1f9\U0001f1f0",
+	"tokelau":                                               "\U0001f1f9\U0001f1f0",
+	"flag-tl":                                               "\U0001f1f9\U0001f1f1",
+	"timor_leste":                                           "\U0001f1f9\U0001f1f1",
+	"flag-tm":                                               "\U0001f1f9\U0001f1f2",
+	"turkmenistan":                                          "\U0001f1f9\U0001f1f2",
+	"flag-tn":                                               "\U0001f1f9\U0001f1f3",
+	"tunisia":                                               "\U0001f1f9\U0001f1f3",
+	"flag-to":                                               "\U0001f1f9\U0001f1f4",
+	"tonga":                                                 "\U0001f1f9\U0001f1f4",
+	"flag-tr":                                               "\U0001f1f9\U0001f1f7",
+	"tr":                                                    "\U0001f1f9\U0001f1f7",
+	"flag-tt":                                               "\U0001f1f9\U0001f1f9",
+	"trinidad_tobago":                                       "\U0001f1f9\U0001f1f9",
+	"flag-tv":                                               "\U0001f1f9\U0001f1fb",
+	"tuvalu":                                                "\U0001f1f9\U0001f1fb",
+	"flag-tw":                                               "\U0001f1f9\U0001f1fc",
+	"taiwan":                                                "\U0001f1f9\U0001f1fc",
+	"flag-tz":                                               "\U0001f1f9\U0001f1ff",
+	"tanzania":                                              "\U0001f1f9\U0001f1ff",
+	"flag-ua":                                               "\U0001f1fa\U0001f1e6",
+	"ukraine":                                               "\U0001f1fa\U0001f1e6",
+	"flag-ug":                                               "\U0001f1fa\U0001f1ec",
+	"uganda":                                                "\U0001f1fa\U0001f1ec",
+	"flag-um":                                               "\U0001f1fa\U0001f1f2",
+	"flag-un":                                               "\U0001f1fa\U0001f1f3",
+	"us":                                                    "\U0001f1fa\U0001f1f8",
+	"flag-us":                                               "\U0001f1fa\U0001f1f8",
+	"flag-uy":                                               "\U0001f1fa\U0001f1fe",
+	"uruguay":                                               "\U0001f1fa\U0001f1fe",
+	"flag-uz":                                               "\U0001f1fa\U0001f1ff",
+	"uzbekistan":                                            "\U0001f1fa\U0001f1ff",
+	"flag-va":                                               "\U0001f1fb\U0001f1e6",
+	"vatican_city":                                          "\U0001f1fb\U0001f1e6",
+	"flag-vc":                                               "\U0001f1fb\U0001f1e8",
+	"st_vincent_grenadines":                                 "\U0001f1fb\U0001f1e8",
+	"flag-ve":                                               "\U0001f1fb\U0001f1ea",
+	"venezuela":                                             "\U0001f1fb\U0001f1ea",
+	"flag-vg":                                               "\U0001f1fb\U0001f1ec",
+	"british_virgin_islands":                                "\U0001f1fb\U0001f1ec",
+	"flag-vi":                                               "\U0001f1fb\U0001f1ee",
+	"us_virgin_islands":                                     "\U0001f1fb\U0001f1ee",
+	"flag-vn":                                               "\U0001f1fb\U0001f1f3",
+	"vietnam":                                               "\U0001f1fb\U0001f1f3",
+	"flag-vu":                                               "\U0001f1fb\U0001f1fa",
+	"vanuatu":                                               "\U0001f1fb\U0001f1fa",
+	"flag-wf":                                               "\U0001f1fc\U0001f1eb",
+	"wallis_futuna":                                         "\U0001f1fc\U0001f1eb",
+	"flag-ws":                                               "\U0001f1fc\U0001f1f8",
+	"samoa":                                                 "\U0001f1fc\U0001f1f8",
+	"flag-xk":                                               "\U0001f1fd\U0001f1f0",
+	"kosovo":                                                "\U0001f1fd\U0001f1f0",
+	"flag-ye":                                               "\U0001f1fe\U0001f1ea",
+	"yemen":                                                 "\U0001f1fe\U0001f1ea",
+	"flag-yt":                                               "\U0001f1fe\U0001f1f9",
+	"mayotte":                                               "\U0001f1fe\U0001f1f9",
+	"flag-za":                                               "\U0001f1ff\U0001f1e6",
+	"south_africa":                                          "\U0001f1ff\U0001f1e6",
+	"za":                                                    "\U0001f1ff\U0001f1e6",
+	"flag-zm":                                               "\U0001f1ff\U0001f1f2",
+	"zambia":                                                "\U0001f1ff\U0001f1f2",
+	"flag-zw":                                               "\U0001f1ff\U0001f1fc",
+	"zimbabwe":                                              "\U0001f1ff\U0001f1fc",
+	"flag-england":                                          "\U0001f3f4\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f",
+	"flag-scotland":                                         "\U0001f3f4\U000e0067\U000e0062\U000e0073\U000e0063\U000e0074\U000e007f",
+	"flag-wales":                                            "\U0001f3f4\U000e0067\U000e0062\U000e0077\U000e006c\U000e0073\U000e007f",
+	"santa_light_skin_tone":                                 "\U0001f385\U0001f3fb",
+	"santa_medium_light_skin_tone":                          "\U0001f385\U0001f3fc",
+	"santa_medium_skin_tone":                                "\U0001f385\U0001f3fd",
+	"santa_medium_dark_skin_tone":                           "\U0001f385\U0001f3fe",
+	"santa_dark_skin_tone":                                  "\U0001f385\U0001f3ff",
+	"snowboarder_light_skin_tone":                           "\U0001f3c2\U0001f3fb",
+	"snowboarder_medium_light_skin_tone":                    "\U0001f3c2\U0001f3fc",
+	"snowboarder_medium_skin_tone":                          "\U0001f3c2\U0001f3fd",
+	"snowboarder_medium_dark_skin_tone":                     "\U0001f3c2\U0001f3fe",
+	"snowboarder_dark_skin_tone":                            "\U0001f3c2\U0001f3ff",
+	"woman-running_light_skin_tone":                         "\U0001f3c3\U0001f3fb\u200d\u2640\ufe0f",
+	"running_woman_light_skin_tone":                         "\U0001f3c3\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-running_medium_light_skin_tone":                  "\U0001f3c3\U0001f3fc\u200d\u2640\ufe0f",
+	"running_woman_medium_light_skin_tone":                  "\U0001f3c3\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-running_medium_skin_tone":                        "\U0001f3c3\U0001f3fd\u200d\u2640\ufe0f",
+	"running_woman_medium_skin_tone":                        "\U0001f3c3\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-running_medium_dark_skin_tone":                   "\U0001f3c3\U0001f3fe\u200d\u2640\ufe0f",
+	"running_woman_medium_dark_skin_tone":                   "\U0001f3c3\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-running_dark_skin_tone":                          "\U0001f3c3\U0001f3ff\u200d\u2640\ufe0f",
+	"running_woman_dark_skin_tone":                          "\U0001f3c3\U0001f3ff\u200d\u2640\ufe0f",
+	"man-running_light_skin_tone":                           "\U0001f3c3\U0001f3fb\u200d\u2642\ufe0f",
+	"running_man_light_skin_tone":                           "\U0001f3c3\U0001f3fb\u200d\u2642\ufe0f",
+	"man-running_medium_light_skin_tone":                    "\U0001f3c3\U0001f3fc\u200d\u2642\ufe0f",
+	"running_man_medium_light_skin_tone":                    "\U0001f3c3\U0001f3fc\u200d\u2642\ufe0f",
+	"man-running_medium_skin_tone":                          "\U0001f3c3\U0001f3fd\u200d\u2642\ufe0f",
+	"running_man_medium_skin_tone":                          "\U0001f3c3\U0001f3fd\u200d\u2642\ufe0f",
+	"man-running_medium_dark_skin_tone":                     "\U0001f3c3\U0001f3fe\u200d\u2642\ufe0f",
+	"running_man_medium_dark_skin_tone":                     "\U0001f3c3\U0001f3fe\u200d\u2642\ufe0f",
+	"man-running_dark_skin_tone":                            "\U0001f3c3\U0001f3ff\u200d\u2642\ufe0f",
+	"running_man_dark_skin_tone":                            "\U0001f3c3\U0001f3ff\u200d\u2642\ufe0f",
+	"runner_light_skin_tone":                                "\U0001f3c3\U0001f3fb",
+	"running_light_skin_tone":                               "\U0001f3c3\U0001f3fb",
+	"runner_medium_light_skin_tone":                         "\U0001f3c3\U0001f3fc",
+	"running_medium_light_skin_tone":                        "\U0001f3c3\U0001f3fc",
+	"runner_medium_skin_tone":                               "\U0001f3c3\U0001f3fd",
+	"running_medium_skin_tone":                              "\U0001f3c3\U0001f3fd",
+	"runner_medium_dark_skin_tone":                          "\U0001f3c3\U0001f3fe",
+	"running_medium_dark_skin_tone":                         "\U0001f3c3\U0001f3fe",
+	"runner_dark_skin_tone":                                 "\U0001f3c3\U0001f3ff",
+	"running_dark_skin_tone":                                "\U0001f3c3\U0001f3ff",
+	"woman-surfing_light_skin_tone":                         "\U0001f3c4\U0001f3fb\u200d\u2640\ufe0f",
+	"surfing_woman_light_skin_tone":                         "\U0001f3c4\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-surfing_medium_light_skin_tone":                  "\U0001f3c4\U0001f3fc\u200d\u2640\ufe0f",
+	"surfing_woman_medium_light_skin_tone":                  "\U0001f3c4\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-surfing_medium_skin_tone":                        "\U0001f3c4\U0001f3fd\u200d\u2640\ufe0f",
+	"surfing_woman_medium_skin_tone":                        "\U0001f3c4\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-surfing_medium_dark_skin_tone":                   "\U0001f3c4\U0001f3fe\u200d\u2640\ufe0f",
+	"surfing_woman_medium_dark_skin_tone":                   "\U0001f3c4\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-surfing_dark_skin_tone":                          "\U0001f3c4\U0001f3ff\u200d\u2640\ufe0f",
+	"surfing_woman_dark_skin_tone":                          "\U0001f3c4\U0001f3ff\u200d\u2640\ufe0f",
+	"man-surfing_light_skin_tone":                           "\U0001f3c4\U0001f3fb\u200d\u2642\ufe0f",
+	"surfing_man_light_skin_tone":                           "\U0001f3c4\U0001f3fb\u200d\u2642\ufe0f",
+	"man-surfing_medium_light_skin_tone":                    "\U0001f3c4\U0001f3fc\u200d\u2642\ufe0f",
+	"surfing_man_medium_light_skin_tone":                    "\U0001f3c4\U0001f3fc\u200d\u2642\ufe0f",
+	"man-surfing_medium_skin_tone":                          "\U0001f3c4\U0001f3fd\u200d\u2642\ufe0f",
+	"surfing_man_medium_skin_tone":                          "\U0001f3c4\U0001f3fd\u200d\u2642\ufe0f",
+	"man-surfing_medium_dark_skin_tone":                     "\U0001f3c4\U0001f3fe\u200d\u2642\ufe0f",
+	"surfing_man_medium_dark_skin_tone":                     "\U0001f3c4\U0001f3fe\u200d\u2642\ufe0f",
+	"man-surfing_dark_skin_tone":                            "\U0001f3c4\U0001f3ff\u200d\u2642\ufe0f",
+	"surfing_man_dark_skin_tone":                            "\U0001f3c4\U0001f3ff\u200d\u2642\ufe0f",
+	"surfer_light_skin_tone":                                "\U0001f3c4\U0001f3fb",
+	"surfer_medium_light_skin_tone":                         "\U0001f3c4\U0001f3fc",
+	"surfer_medium_skin_tone":                               "\U0001f3c4\U0001f3fd",
+	"surfer_medium_dark_skin_tone":                          "\U0001f3c4\U0001f3fe",
+	"surfer_dark_skin_tone":                                 "\U0001f3c4\U0001f3ff",
+	"horse_racing_light_skin_tone":                          "\U0001f3c7\U0001f3fb",
+	"horse_racing_medium_light_skin_tone":                   "\U0001f3c7\U0001f3fc",
+	"horse_racing_medium_skin_tone":                         "\U0001f3c7\U0001f3fd",
+	"horse_racing_medium_dark_skin_tone":                    "\U0001f3c7\U0001f3fe",
+	"horse_racing_dark_skin_tone":                           "\U0001f3c7\U0001f3ff",
+	"woman-swimming_light_skin_tone":                        "\U0001f3ca\U0001f3fb\u200d\u2640\ufe0f",
+	"swimming_woman_light_skin_tone":                        "\U0001f3ca\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-swimming_medium_light_skin_tone":                 "\U0001f3ca\U0001f3fc\u200d\u2640\ufe0f",
+	"swimming_woman_medium_light_skin_tone":                 "\U0001f3ca\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-swimming_medium_skin_tone":                       "\U0001f3ca\U0001f3fd\u200d\u2640\ufe0f",
+	"swimming_woman_medium_skin_tone":                       "\U0001f3ca\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-swimming_medium_dark_skin_tone":                  "\U0001f3ca\U0001f3fe\u200d\u2640\ufe0f",
+	"swimming_woman_medium_dark_skin_tone":                  "\U0001f3ca\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-swimming_dark_skin_tone":                         "\U0001f3ca\U0001f3ff\u200d\u2640\ufe0f",
+	"swimming_woman_dark_skin_tone":                         "\U0001f3ca\U0001f3ff\u200d\u2640\ufe0f",
+	"man-swimming_light_skin_tone":                          "\U0001f3ca\U0001f3fb\u200d\u2642\ufe0f",
+	"swimming_man_light_skin_tone":                          "\U0001f3ca\U0001f3fb\u200d\u2642\ufe0f",
+	"man-swimming_medium_light_skin_tone":                   "\U0001f3ca\U0001f3fc\u200d\u2642\ufe0f",
+	"swimming_man_medium_light_skin_tone":                   "\U0001f3ca\U0001f3fc\u200d\u2642\ufe0f",
+	"man-swimming_medium_skin_tone":                         "\U0001f3ca\U0001f3fd\u200d\u2642\ufe0f",
+	"swimming_man_medium_skin_tone":                         "\U0001f3ca\U0001f3fd\u200d\u2642\ufe0f",
+	"man-swimming_medium_dark_skin_tone":                    "\U0001f3ca\U0001f3fe\u200d\u2642\ufe0f",
+	"swimming_man_medium_dark_skin_tone":                    "\U0001f3ca\U0001f3fe\u200d\u2642\ufe0f",
+	"man-swimming_dark_skin_tone":                           "\U0001f3ca\U0001f3ff\u200d\u2642\ufe0f",
+	"swimming_man_dark_skin_tone":                           "\U0001f3ca\U0001f3ff\u200d\u2642\ufe0f",
+	"swimmer_light_skin_tone":                               "\U0001f3ca\U0001f3fb",
+	"swimmer_medium_light_skin_tone":                        "\U0001f3ca\U0001f3fc",
+	"swimmer_medium_skin_tone":                              "\U0001f3ca\U0001f3fd",
+	"swimmer_medium_dark_skin_tone":                         "\U0001f3ca\U0001f3fe",
+	"swimmer_dark_skin_tone":                                "\U0001f3ca\U0001f3ff",
+	"woman-lifting-weights_light_skin_tone":                 "\U0001f3cb\U0001f3fb\u200d\u2640\ufe0f",
+	"weight_lifting_woman_light_skin_tone":                  "\U0001f3cb\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-lifting-weights_medium_light_skin_tone":                             "\U0001f3cb\U0001f3fc\u200d\u2640\ufe0f",
+	"weight_lifting_woman_medium_light_skin_tone":                              "\U0001f3cb\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-lifting-weights_medium_skin_tone":                                   "\U0001f3cb\U0001f3fd\u200d\u2640\ufe0f",
+	"weight_lifting_woman_medium_skin_tone":                                    "\U0001f3cb\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-lifting-weights_medium_dark_skin_tone":                              "\U0001f3cb\U0001f3fe\u200d\u2640\ufe0f",
+	"weight_lifting_woman_medium_dark_skin_tone":                               "\U0001f3cb\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-lifting-weights_dark_skin_tone":                                     "\U0001f3cb\U0001f3ff\u200d\u2640\ufe0f",
+	"weight_lifting_woman_dark_skin_tone":                                      "\U0001f3cb\U0001f3ff\u200d\u2640\ufe0f",
+	"man-lifting-weights_light_skin_tone":                                      "\U0001f3cb\U0001f3fb\u200d\u2642\ufe0f",
+	"weight_lifting_man_light_skin_tone":                                       "\U0001f3cb\U0001f3fb\u200d\u2642\ufe0f",
+	"man-lifting-weights_medium_light_skin_tone":                               "\U0001f3cb\U0001f3fc\u200d\u2642\ufe0f",
+	"weight_lifting_man_medium_light_skin_tone":                                "\U0001f3cb\U0001f3fc\u200d\u2642\ufe0f",
+	"man-lifting-weights_medium_skin_tone":                                     "\U0001f3cb\U0001f3fd\u200d\u2642\ufe0f",
+	"weight_lifting_man_medium_skin_tone":                                      "\U0001f3cb\U0001f3fd\u200d\u2642\ufe0f",
+	"man-lifting-weights_medium_dark_skin_tone":                                "\U0001f3cb\U0001f3fe\u200d\u2642\ufe0f",
+	"weight_lifting_man_medium_dark_skin_tone":                                 "\U0001f3cb\U0001f3fe\u200d\u2642\ufe0f",
+	"man-lifting-weights_dark_skin_tone":                                       "\U0001f3cb\U0001f3ff\u200d\u2642\ufe0f",
+	"weight_lifting_man_dark_skin_tone":                                        "\U0001f3cb\U0001f3ff\u200d\u2642\ufe0f",
+	"weight_lifter_light_skin_tone":                                            "\U0001f3cb\U0001f3fb",
+	"weight_lifter_medium_light_skin_tone":                                     "\U0001f3cb\U0001f3fc",
+	"weight_lifter_medium_skin_tone":                                           "\U0001f3cb\U0001f3fd",
+	"weight_lifter_medium_dark_skin_tone":                                      "\U0001f3cb\U0001f3fe",
+	"weight_lifter_dark_skin_tone":                                             "\U0001f3cb\U0001f3ff",
+	"woman-golfing_light_skin_tone":                                            "\U0001f3cc\U0001f3fb\u200d\u2640\ufe0f",
+	"golfing_woman_light_skin_tone":                                            "\U0001f3cc\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-golfing_medium_light_skin_tone":                                     "\U0001f3cc\U0001f3fc\u200d\u2640\ufe0f",
+	"golfing_woman_medium_light_skin_tone":                                     "\U0001f3cc\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-golfing_medium_skin_tone":                                           "\U0001f3cc\U0001f3fd\u200d\u2640\ufe0f",
+	"golfing_woman_medium_skin_tone":                                           "\U0001f3cc\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-golfing_medium_dark_skin_tone":                                      "\U0001f3cc\U0001f3fe\u200d\u2640\ufe0f",
+	"golfing_woman_medium_dark_skin_tone":                                      "\U0001f3cc\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-golfing_dark_skin_tone":                                             "\U0001f3cc\U0001f3ff\u200d\u2640\ufe0f",
+	"golfing_woman_dark_skin_tone":                                             "\U0001f3cc\U0001f3ff\u200d\u2640\ufe0f",
+	"man-golfing_light_skin_tone":                                              "\U0001f3cc\U0001f3fb\u200d\u2642\ufe0f",
+	"golfing_man_light_skin_tone":                                              "\U0001f3cc\U0001f3fb\u200d\u2642\ufe0f",
+	"man-golfing_medium_light_skin_tone":                                       "\U0001f3cc\U0001f3fc\u200d\u2642\ufe0f",
+	"golfing_man_medium_light_skin_tone":                                       "\U0001f3cc\U0001f3fc\u200d\u2642\ufe0f",
+	"man-golfing_medium_skin_tone":                                             "\U0001f3cc\U0001f3fd\u200d\u2642\ufe0f",
+	"golfing_man_medium_skin_tone":                                             "\U0001f3cc\U0001f3fd\u200d\u2642\ufe0f",
+	"man-golfing_medium_dark_skin_tone":                                        "\U0001f3cc\U0001f3fe\u200d\u2642\ufe0f",
+	"golfing_man_medium_dark_skin_tone":                                        "\U0001f3cc\U0001f3fe\u200d\u2642\ufe0f",
+	"man-golfing_dark_skin_tone":                                               "\U0001f3cc\U0001f3ff\u200d\u2642\ufe0f",
+	"golfing_man_dark_skin_tone":                                               "\U0001f3cc\U0001f3ff\u200d\u2642\ufe0f",
+	"golfer_light_skin_tone":                                                   "\U0001f3cc\U0001f3fb",
+	"golfer_medium_light_skin_tone":                                            "\U0001f3cc\U0001f3fc",
+	"golfer_medium_skin_tone":                                                  "\U0001f3cc\U0001f3fd",
+	"golfer_medium_dark_skin_tone":                                             "\U0001f3cc\U0001f3fe",
+	"golfer_dark_skin_tone":                                                    "\U0001f3cc\U0001f3ff",
+	"ear_light_skin_tone":                                                      "\U0001f442\U0001f3fb",
+	"ear_medium_light_skin_tone":                                               "\U0001f442\U0001f3fc",
+	"ear_medium_skin_tone":                                                     "\U0001f442\U0001f3fd",
+	"ear_medium_dark_skin_tone":                                                "\U0001f442\U0001f3fe",
+	"ear_dark_skin_tone":                                                       "\U0001f442\U0001f3ff",
+	"nose_light_skin_tone":                                                     "\U0001f443\U0001f3fb",
+	"nose_medium_light_skin_tone":                                              "\U0001f443\U0001f3fc",
+	"nose_medium_skin_tone":                                                    "\U0001f443\U0001f3fd",
+	"nose_medium_dark_skin_tone":                                               "\U0001f443\U0001f3fe",
+	"nose_dark_skin_tone":                                                      "\U0001f443\U0001f3ff",
+	"point_up_2_light_skin_tone":                                               "\U0001f446\U0001f3fb",
+	"point_up_2_medium_light_skin_tone":                                        "\U0001f446\U0001f3fc",
+	"point_up_2_medium_skin_tone":                                              "\U0001f446\U0001f3fd",
+	"point_up_2_medium_dark_skin_tone":                                         "\U0001f446\U0001f3fe",
+	"point_up_2_dark_skin_tone":                                                "\U0001f446\U0001f3ff",
+	"point_down_light_skin_tone":                                               "\U0001f447\U0001f3fb",
+	"point_down_medium_light_skin_tone":                                        "\U0001f447\U0001f3fc",
+	"point_down_medium_skin_tone":                                              "\U0001f447\U0001f3fd",
+	"point_down_medium_dark_skin_tone":                                         "\U0001f447\U0001f3fe",
+	"point_down_dark_skin_tone":                                                "\U0001f447\U0001f3ff",
+	"point_left_light_skin_tone":                                               "\U0001f448\U0001f3fb",
+	"point_left_medium_light_skin_tone":                                        "\U0001f448\U0001f3fc",
+	"point_left_medium_skin_tone":                                              "\U0001f448\U0001f3fd",
+	"point_left_medium_dark_skin_tone":                                         "\U0001f448\U0001f3fe",
+	"point_left_dark_skin_tone":                                                "\U0001f448\U0001f3ff",
+	"point_right_light_skin_tone":                                              "\U0001f449\U0001f3fb",
+	"point_right_medium_light_skin_tone":                                       "\U0001f449\U0001f3fc",
+	"point_right_medium_skin_tone":                                             "\U0001f449\U0001f3fd",
+	"point_right_medium_dark_skin_tone":                                        "\U0001f449\U0001f3fe",
+	"point_right_dark_skin_tone":                                               "\U0001f449\U0001f3ff",
+	"facepunch_light_skin_tone":                                                "\U0001f44a\U0001f3fb",
+	"punch_light_skin_tone":                                                    "\U0001f44a\U0001f3fb",
+	"fist_oncoming_light_skin_tone":                                            "\U0001f44a\U0001f3fb",
+	"facepunch_medium_light_skin_tone":                                         "\U0001f44a\U0001f3fc",
+	"punch_medium_light_skin_tone":                                             "\U0001f44a\U0001f3fc",
+	"fist_oncoming_medium_light_skin_tone":                                     "\U0001f44a\U0001f3fc",
+	"facepunch_medium_skin_tone":                                               "\U0001f44a\U0001f3fd",
+	"punch_medium_skin_tone":                                                   "\U0001f44a\U0001f3fd",
+	"fist_oncoming_medium_skin_tone":                                           "\U0001f44a\U0001f3fd",
+	"facepunch_medium_dark_skin_tone":                                          "\U0001f44a\U0001f3fe",
+	"punch_medium_dark_skin_tone":                                              "\U0001f44a\U0001f3fe",
+	"fist_oncoming_medium_dark_skin_tone":                                      "\U0001f44a\U0001f3fe",
+	"facepunch_dark_skin_tone":                                                 "\U0001f44a\U0001f3ff",
+	"punch_dark_skin_tone":                                                     "\U0001f44a\U0001f3ff",
+	"fist_oncoming_dark_skin_tone":                                             "\U0001f44a\U0001f3ff",
+	"wave_light_skin_tone":                                                     "\U0001f44b\U0001f3fb",
+	"wave_medium_light_skin_tone":                                              "\U0001f44b\U0001f3fc",
+	"wave_medium_skin_tone":                                                    "\U0001f44b\U0001f3fd",
+	"wave_medium_dark_skin_tone":                                               "\U0001f44b\U0001f3fe",
+	"wave_dark_skin_tone":                                                      "\U0001f44b\U0001f3ff",
+	"ok_hand_light_skin_tone":                                                  "\U0001f44c\U0001f3fb",
+	"ok_hand_medium_light_skin_tone":                                           "\U0001f44c\U0001f3fc",
+	"ok_hand_medium_skin_tone":                                                 "\U0001f44c\U0001f3fd",
+	"ok_hand_medium_dark_skin_tone":                                            "\U0001f44c\U0001f3fe",
+	"ok_hand_dark_skin_tone":                                                   "\U0001f44c\U0001f3ff",
+	"+1_light_skin_tone":                                                       "\U0001f44d\U0001f3fb",
+	"thumbsup_light_skin_tone":                                                 "\U0001f44d\U0001f3fb",
+	"+1_medium_light_skin_tone":                                                "\U0001f44d\U0001f3fc",
+	"thumbsup_medium_light_skin_tone":                                          "\U0001f44d\U0001f3fc",
+	"+1_medium_skin_tone":                                                      "\U0001f44d\U0001f3fd",
+	"thumbsup_medium_skin_tone":                                                "\U0001f44d\U0001f3fd",
+	"+1_medium_dark_skin_tone":                                                 "\U0001f44d\U0001f3fe",
+	"thumbsup_medium_dark_skin_tone":                                           "\U0001f44d\U0001f3fe",
+	"+1_dark_skin_tone":                                                        "\U0001f44d\U0001f3ff",
+	"thumbsup_dark_skin_tone":                                                  "\U0001f44d\U0001f3ff",
+	"-1_light_skin_tone":                                                       "\U0001f44e\U0001f3fb",
+	"thumbsdown_light_skin_tone":                                               "\U0001f44e\U0001f3fb",
+	"-1_medium_light_skin_tone":                                                "\U0001f44e\U0001f3fc",
+	"thumbsdown_medium_light_skin_tone":                                        "\U0001f44e\U0001f3fc",
+	"-1_medium_skin_tone":                                                      "\U0001f44e\U0001f3fd",
+	"thumbsdown_medium_skin_tone":                                              "\U0001f44e\U0001f3fd",
+	"-1_medium_dark_skin_tone":                                                 "\U0001f44e\U0001f3fe",
+	"thumbsdown_medium_dark_skin_tone":                                         "\U0001f44e\U0001f3fe",
+	"-1_dark_skin_tone":                                                        "\U0001f44e\U0001f3ff",
+	"thumbsdown_dark_skin_tone":                                                "\U0001f44e\U0001f3ff",
+	"clap_light_skin_tone":                                                     "\U0001f44f\U0001f3fb",
+	"clap_medium_light_skin_tone":                                              "\U0001f44f\U0001f3fc",
+	"clap_medium_skin_tone":                                                    "\U0001f44f\U0001f3fd",
+	"clap_medium_dark_skin_tone":                                               "\U0001f44f\U0001f3fe",
+	"clap_dark_skin_tone":                                                      "\U0001f44f\U0001f3ff",
+	"open_hands_light_skin_tone":                                               "\U0001f450\U0001f3fb",
+	"open_hands_medium_light_skin_tone":                                        "\U0001f450\U0001f3fc",
+	"open_hands_medium_skin_tone":                                              "\U0001f450\U0001f3fd",
+	"open_hands_medium_dark_skin_tone":                                         "\U0001f450\U0001f3fe",
+	"open_hands_dark_skin_tone":                                                "\U0001f450\U0001f3ff",
+	"boy_light_skin_tone":                                                      "\U0001f466\U0001f3fb",
+	"boy_medium_light_skin_tone":                                               "\U0001f466\U0001f3fc",
+	"boy_medium_skin_tone":                                                     "\U0001f466\U0001f3fd",
+	"boy_medium_dark_skin_tone":                                                "\U0001f466\U0001f3fe",
+	"boy_dark_skin_tone":                                                       "\U0001f466\U0001f3ff",
+	"girl_light_skin_tone":                                                     "\U0001f467\U0001f3fb",
+	"girl_medium_light_skin_tone":                                              "\U0001f467\U0001f3fc",
+	"girl_medium_skin_tone":                                                    "\U0001f467\U0001f3fd",
+	"girl_medium_dark_skin_tone":                                               "\U0001f467\U0001f3fe",
+	"girl_dark_skin_tone":                                                      "\U0001f467\U0001f3ff",
+	"male-farmer_light_skin_tone":                                              "\U0001f468\U0001f3fb\u200d\U0001f33e",
+	"man_farmer_light_skin_tone":                                               "\U0001f468\U0001f3fb\u200d\U0001f33e",
+	"male-farmer_medium_light_skin_tone":                                       "\U0001f468\U0001f3fc\u200d\U0001f33e",
+	"man_farmer_medium_light_skin_tone":                                        "\U0001f468\U0001f3fc\u200d\U0001f33e",
+	"male-farmer_medium_skin_tone":                                             "\U0001f468\U0001f3fd\u200d\U0001f33e",
+	"man_farmer_medium_skin_tone":                                              "\U0001f468\U0001f3fd\u200d\U0001f33e",
+	"male-farmer_medium_dark_skin_tone":                                        "\U0001f468\U0001f3fe\u200d\U0001f33e",
+	"man_farmer_medium_dark_skin_tone":                                         "\U0001f468\U0001f3fe\u200d\U0001f33e",
+	"male-farmer_dark_skin_tone":                                               "\U0001f468\U0001f3ff\u200d\U0001f33e",
+	"man_farmer_dark_skin_tone":                                                "\U0001f468\U0001f3ff\u200d\U0001f33e",
+	"male-cook_light_skin_tone":                                                "\U0001f468\U0001f3fb\u200d\U0001f373",
+	"man_cook_light_skin_tone":                                                 "\U0001f468\U0001f3fb\u200d\U0001f373",
+	"male-cook_medium_light_skin_tone":                                         "\U0001f468\U0001f3fc\u200d\U0001f373",
+	"man_cook_medium_light_skin_tone":                                          "\U0001f468\U0001f3fc\u200d\U0001f373",
+	"male-cook_medium_skin_tone":                                               "\U0001f468\U0001f3fd\u200d\U0001f373",
+	"man_cook_medium_skin_tone":                                                "\U0001f468\U0001f3fd\u200d\U0001f373",
+	"male-cook_medium_dark_skin_tone":                                          "\U0001f468\U0001f3fe\u200d\U0001f373",
+	"man_cook_medium_dark_skin_tone":                                           "\U0001f468\U0001f3fe\u200d\U0001f373",
+	"male-cook_dark_skin_tone":                                                 "\U0001f468\U0001f3ff\u200d\U0001f373",
+	"man_cook_dark_skin_tone":                                                  "\U0001f468\U0001f3ff\u200d\U0001f373",
+	"man_feeding_baby_light_skin_tone":                                         "\U0001f468\U0001f3fb\u200d\U0001f37c",
+	"man_feeding_baby_medium_light_skin_tone":                                  "\U0001f468\U0001f3fc\u200d\U0001f37c",
+	"man_feeding_baby_medium_skin_tone":                                        "\U0001f468\U0001f3fd\u200d\U0001f37c",
+	"man_feeding_baby_medium_dark_skin_tone":                                   "\U0001f468\U0001f3fe\u200d\U0001f37c",
+	"man_feeding_baby_dark_skin_tone":                                          "\U0001f468\U0001f3ff\u200d\U0001f37c",
+	"male-student_light_skin_tone":                                             "\U0001f468\U0001f3fb\u200d\U0001f393",
+	"man_student_light_skin_tone":                                              "\U0001f468\U0001f3fb\u200d\U0001f393",
+	"male-student_medium_light_skin_tone":                                      "\U0001f468\U0001f3fc\u200d\U0001f393",
+	"man_student_medium_light_skin_tone":                                       "\U0001f468\U0001f3fc\u200d\U0001f393",
+	"male-student_medium_skin_tone":                                            "\U0001f468\U0001f3fd\u200d\U0001f393",
+	"man_student_medium_skin_tone":                                             "\U0001f468\U0001f3fd\u200d\U0001f393",
+	"male-student_medium_dark_skin_tone":                                       "\U0001f468\U0001f3fe\u200d\U0001f393",
+	"man_student_medium_dark_skin_tone":                                        "\U0001f468\U0001f3fe\u200d\U0001f393",
+	"male-student_dark_skin_tone":                                              "\U0001f468\U0001f3ff\u200d\U0001f393",
+	"man_student_dark_skin_tone":                                               "\U0001f468\U0001f3ff\u200d\U0001f393",
+	"male-singer_light_skin_tone":                                              "\U0001f468\U0001f3fb\u200d\U0001f3a4",
+	"man_singer_light_skin_tone":                                               "\U0001f468\U0001f3fb\u200d\U0001f3a4",
+	"male-singer_medium_light_skin_tone":                                       "\U0001f468\U0001f3fc\u200d\U0001f3a4",
+	"man_singer_medium_light_skin_tone":                                        "\U0001f468\U0001f3fc\u200d\U0001f3a4",
+	"male-singer_medium_skin_tone":                                             "\U0001f468\U0001f3fd\u200d\U0001f3a4",
+	"man_singer_medium_skin_tone":                                              "\U0001f468\U0001f3fd\u200d\U0001f3a4",
+	"male-singer_medium_dark_skin_tone":                                        "\U0001f468\U0001f3fe\u200d\U0001f3a4",
+	"man_singer_medium_dark_skin_tone":                                         "\U0001f468\U0001f3fe\u200d\U0001f3a4",
+	"male-singer_dark_skin_tone":                                               "\U0001f468\U0001f3ff\u200d\U0001f3a4",
+	"man_singer_dark_skin_tone":                                                "\U0001f468\U0001f3ff\u200d\U0001f3a4",
+	"male-artist_light_skin_tone":                                              "\U0001f468\U0001f3fb\u200d\U0001f3a8",
+	"man_artist_light_skin_tone":                                               "\U0001f468\U0001f3fb\u200d\U0001f3a8",
+	"male-artist_medium_light_skin_tone":                                       "\U0001f468\U0001f3fc\u200d\U0001f3a8",
+	"man_artist_medium_light_skin_tone":                                        "\U0001f468\U0001f3fc\u200d\U0001f3a8",
+	"male-artist_medium_skin_tone":                                             "\U0001f468\U0001f3fd\u200d\U0001f3a8",
+	"man_artist_medium_skin_tone":                                              "\U0001f468\U0001f3fd\u200d\U0001f3a8",
+	"male-artist_medium_dark_skin_tone":                                        "\U0001f468\U0001f3fe\u200d\U0001f3a8",
+	"man_artist_medium_dark_skin_tone":                                         "\U0001f468\U0001f3fe\u200d\U0001f3a8",
+	"male-artist_dark_skin_tone":                                               "\U0001f468\U0001f3ff\u200d\U0001f3a8",
+	"man_artist_dark_skin_tone":                                                "\U0001f468\U0001f3ff\u200d\U0001f3a8",
+	"male-teacher_light_skin_tone":                                             "\U0001f468\U0001f3fb\u200d\U0001f3eb",
+	"man_teacher_light_skin_tone":                                              "\U0001f468\U0001f3fb\u200d\U0001f3eb",
+	"male-teacher_medium_light_skin_tone":                                      "\U0001f468\U0001f3fc\u200d\U0001f3eb",
+	"man_teacher_medium_light_skin_tone":                                       "\U0001f468\U0001f3fc\u200d\U0001f3eb",
+	"male-teacher_medium_skin_tone":                                            "\U0001f468\U0001f3fd\u200d\U0001f3eb",
+	"man_teacher_medium_skin_tone":                                             "\U0001f468\U0001f3fd\u200d\U0001f3eb",
+	"male-teacher_medium_dark_skin_tone":                                       "\U0001f468\U0001f3fe\u200d\U0001f3eb",
+	"man_teacher_medium_dark_skin_tone":                                        "\U0001f468\U0001f3fe\u200d\U0001f3eb",
+	"male-teacher_dark_skin_tone":                                              "\U0001f468\U0001f3ff\u200d\U0001f3eb",
+	"man_teacher_dark_skin_tone":                                               "\U0001f468\U0001f3ff\u200d\U0001f3eb",
+	"male-factory-worker_light_skin_tone":                                      "\U0001f468\U0001f3fb\u200d\U0001f3ed",
+	"man_factory_worker_light_skin_tone":                                       "\U0001f468\U0001f3fb\u200d\U0001f3ed",
+	"male-factory-worker_medium_light_skin_tone":                               "\U0001f468\U0001f3fc\u200d\U0001f3ed",
+	"man_factory_worker_medium_light_skin_tone":                                "\U0001f468\U0001f3fc\u200d\U0001f3ed",
+	"male-factory-worker_medium_skin_tone":                                     "\U0001f468\U0001f3fd\u200d\U0001f3ed",
+	"man_factory_worker_medium_skin_tone":                                      "\U0001f468\U0001f3fd\u200d\U0001f3ed",
+	"male-factory-worker_medium_dark_skin_tone":                                "\U0001f468\U0001f3fe\u200d\U0001f3ed",
+	"man_factory_worker_medium_dark_skin_tone":                                 "\U0001f468\U0001f3fe\u200d\U0001f3ed",
+	"male-factory-worker_dark_skin_tone":                                       "\U0001f468\U0001f3ff\u200d\U0001f3ed",
+	"man_factory_worker_dark_skin_tone":                                        "\U0001f468\U0001f3ff\u200d\U0001f3ed",
+	"male-technologist_light_skin_tone":                                        "\U0001f468\U0001f3fb\u200d\U0001f4bb",
+	"man_technologist_light_skin_tone":                                         "\U0001f468\U0001f3fb\u200d\U0001f4bb",
+	"male-technologist_medium_light_skin_tone":                                 "\U0001f468\U0001f3fc\u200d\U0001f4bb",
+	"man_technologist_medium_light_skin_tone":                                  "\U0001f468\U0001f3fc\u200d\U0001f4bb",
+	"male-technologist_medium_skin_tone":                                       "\U0001f468\U0001f3fd\u200d\U0001f4bb",
+	"man_technologist_medium_skin_tone":                                        "\U0001f468\U0001f3fd\u200d\U0001f4bb",
+	"male-technologist_medium_dark_skin_tone":                                  "\U0001f468\U0001f3fe\u200d\U0001f4bb",
+	"man_technologist_medium_dark_skin_tone":                                   "\U0001f468\U0001f3fe\u200d\U0001f4bb",
+	"male-technologist_dark_skin_tone":                                         "\U0001f468\U0001f3ff\u200d\U0001f4bb",
+	"man_technologist_dark_skin_tone":                                          "\U0001f468\U0001f3ff\u200d\U0001f4bb",
+	"male-office-worker_light_skin_tone":                                       "\U0001f468\U0001f3fb\u200d\U0001f4bc",
+	"man_office_worker_light_skin_tone":                                        "\U0001f468\U0001f3fb\u200d\U0001f4bc",
+	"male-office-worker_medium_light_skin_tone":                                "\U0001f468\U0001f3fc\u200d\U0001f4bc",
+	"man_office_worker_medium_light_skin_tone":                                 "\U0001f468\U0001f3fc\u200d\U0001f4bc",
+	"male-office-worker_medium_skin_tone":                                      "\U0001f468\U0001f3fd\u200d\U0001f4bc",
+	"man_office_worker_medium_skin_tone":                                       "\U0001f468\U0001f3fd\u200d\U0001f4bc",
+	"male-office-worker_medium_dark_skin_tone":                                 "\U0001f468\U0001f3fe\u200d\U0001f4bc",
+	"man_office_worker_medium_dark_skin_tone":                                  "\U0001f468\U0001f3fe\u200d\U0001f4bc",
+	"male-office-worker_dark_skin_tone":                                        "\U0001f468\U0001f3ff\u200d\U0001f4bc",
+	"man_office_worker_dark_skin_tone":                                         "\U0001f468\U0001f3ff\u200d\U0001f4bc",
+	"male-mechanic_light_skin_tone":                                            "\U0001f468\U0001f3fb\u200d\U0001f527",
+	"man_mechanic_light_skin_tone":                                             "\U0001f468\U0001f3fb\u200d\U0001f527",
+	"male-mechanic_medium_light_skin_tone":                                     "\U0001f468\U0001f3fc\u200d\U0001f527",
+	"man_mechanic_medium_light_skin_tone":                                      "\U0001f468\U0001f3fc\u200d\U0001f527",
+	"male-mechanic_medium_skin_tone":                                           "\U0001f468\U0001f3fd\u200d\U0001f527",
+	"man_mechanic_medium_skin_tone":                                            "\U0001f468\U0001f3fd\u200d\U0001f527",
+	"male-mechanic_medium_dark_skin_tone":                                      "\U0001f468\U0001f3fe\u200d\U0001f527",
+	"man_mechanic_medium_dark_skin_tone":                                       "\U0001f468\U0001f3fe\u200d\U0001f527",
+	"male-mechanic_dark_skin_tone":                                             "\U0001f468\U0001f3ff\u200d\U0001f527",
+	"man_mechanic_dark_skin_tone":                                              "\U0001f468\U0001f3ff\u200d\U0001f527",
+	"male-scientist_light_skin_tone":                                           "\U0001f468\U0001f3fb\u200d\U0001f52c",
+	"man_scientist_light_skin_tone":                                            "\U0001f468\U0001f3fb\u200d\U0001f52c",
+	"male-scientist_medium_light_skin_tone":                                    "\U0001f468\U0001f3fc\u200d\U0001f52c",
+	"man_scientist_medium_light_skin_tone":                                     "\U0001f468\U0001f3fc\u200d\U0001f52c",
+	"male-scientist_medium_skin_tone":                                          "\U0001f468\U0001f3fd\u200d\U0001f52c",
+	"man_scientist_medium_skin_tone":                                           "\U0001f468\U0001f3fd\u200d\U0001f52c",
+	"male-scientist_medium_dark_skin_tone":                                     "\U0001f468\U0001f3fe\u200d\U0001f52c",
+	"man_scientist_medium_dark_skin_tone":                                      "\U0001f468\U0001f3fe\u200d\U0001f52c",
+	"male-scientist_dark_skin_tone":                                            "\U0001f468\U0001f3ff\u200d\U0001f52c",
+	"man_scientist_dark_skin_tone":                                             "\U0001f468\U0001f3ff\u200d\U0001f52c",
+	"male-astronaut_light_skin_tone":                                           "\U0001f468\U0001f3fb\u200d\U0001f680",
+	"man_astronaut_light_skin_tone":                                            "\U0001f468\U0001f3fb\u200d\U0001f680",
+	"male-astronaut_medium_light_skin_tone":                                    "\U0001f468\U0001f3fc\u200d\U0001f680",
+	"man_astronaut_medium_light_skin_tone":                                     "\U0001f468\U0001f3fc\u200d\U0001f680",
+	"male-astronaut_medium_skin_tone":                                          "\U0001f468\U0001f3fd\u200d\U0001f680",
+	"man_astronaut_medium_skin_tone":                                           "\U0001f468\U0001f3fd\u200d\U0001f680",
+	"male-astronaut_medium_dark_skin_tone":                                     "\U0001f468\U0001f3fe\u200d\U0001f680",
+	"man_astronaut_medium_dark_skin_tone":                                      "\U0001f468\U0001f3fe\u200d\U0001f680",
+	"male-astronaut_dark_skin_tone":                                            "\U0001f468\U0001f3ff\u200d\U0001f680",
+	"man_astronaut_dark_skin_tone":                                             "\U0001f468\U0001f3ff\u200d\U0001f680",
+	"male-firefighter_light_skin_tone":                                         "\U0001f468\U0001f3fb\u200d\U0001f692",
+	"man_firefighter_light_skin_tone":                                          "\U0001f468\U0001f3fb\u200d\U0001f692",
+	"male-firefighter_medium_light_skin_tone":                                  "\U0001f468\U0001f3fc\u200d\U0001f692",
+	"man_firefighter_medium_light_skin_tone":                                   "\U0001f468\U0001f3fc\u200d\U0001f692",
+	"male-firefighter_medium_skin_tone":                                        "\U0001f468\U0001f3fd\u200d\U0001f692",
+	"man_firefighter_medium_skin_tone":                                         "\U0001f468\U0001f3fd\u200d\U0001f692",
+	"male-firefighter_medium_dark_skin_tone":                                   "\U0001f468\U0001f3fe\u200d\U0001f692",
+	"man_firefighter_medium_dark_skin_tone":                                    "\U0001f468\U0001f3fe\u200d\U0001f692",
+	"male-firefighter_dark_skin_tone":                                          "\U0001f468\U0001f3ff\u200d\U0001f692",
+	"man_firefighter_dark_skin_tone":                                           "\U0001f468\U0001f3ff\u200d\U0001f692",
+	"man_with_probing_cane_light_skin_tone":                                    "\U0001f468\U0001f3fb\u200d\U0001f9af",
+	"man_with_probing_cane_medium_light_skin_tone":                             "\U0001f468\U0001f3fc\u200d\U0001f9af",
+	"man_with_probing_cane_medium_skin_tone":                                   "\U0001f468\U0001f3fd\u200d\U0001f9af",
+	"man_with_probing_cane_medium_dark_skin_tone":                              "\U0001f468\U0001f3fe\u200d\U0001f9af",
+	"man_with_probing_cane_dark_skin_tone":                                     "\U0001f468\U0001f3ff\u200d\U0001f9af",
+	"red_haired_man_light_skin_tone":                                           "\U0001f468\U0001f3fb\u200d\U0001f9b0",
+	"red_haired_man_medium_light_skin_tone":                                    "\U0001f468\U0001f3fc\u200d\U0001f9b0",
+	"red_haired_man_medium_skin_tone":                                          "\U0001f468\U0001f3fd\u200d\U0001f9b0",
+	"red_haired_man_medium_dark_skin_tone":                                     "\U0001f468\U0001f3fe\u200d\U0001f9b0",
+	"red_haired_man_dark_skin_tone":                                            "\U0001f468\U0001f3ff\u200d\U0001f9b0",
+	"curly_haired_man_light_skin_tone":                                         "\U0001f468\U0001f3fb\u200d\U0001f9b1",
+	"curly_haired_man_medium_light_skin_tone":                                  "\U0001f468\U0001f3fc\u200d\U0001f9b1",
+	"curly_haired_man_medium_skin_tone":                                        "\U0001f468\U0001f3fd\u200d\U0001f9b1",
+	"curly_haired_man_medium_dark_skin_tone":                                   "\U0001f468\U0001f3fe\u200d\U0001f9b1",
+	"curly_haired_man_dark_skin_tone":                                          "\U0001f468\U0001f3ff\u200d\U0001f9b1",
+	"bald_man_light_skin_tone":                                                 "\U0001f468\U0001f3fb\u200d\U0001f9b2",
+	"bald_man_medium_light_skin_tone":                                          "\U0001f468\U0001f3fc\u200d\U0001f9b2",
+	"bald_man_medium_skin_tone":                                                "\U0001f468\U0001f3fd\u200d\U0001f9b2",
+	"bald_man_medium_dark_skin_tone":                                           "\U0001f468\U0001f3fe\u200d\U0001f9b2",
+	"bald_man_dark_skin_tone":                                                  "\U0001f468\U0001f3ff\u200d\U0001f9b2",
+	"white_haired_man_light_skin_tone":                                         "\U0001f468\U0001f3fb\u200d\U0001f9b3",
+	"white_haired_man_medium_light_skin_tone":                                  "\U0001f468\U0001f3fc\u200d\U0001f9b3",
+	"white_haired_man_medium_skin_tone":                                        "\U0001f468\U0001f3fd\u200d\U0001f9b3",
+	"white_haired_man_medium_dark_skin_tone":                                   "\U0001f468\U0001f3fe\u200d\U0001f9b3",
+	"white_haired_man_dark_skin_tone":                                          "\U0001f468\U0001f3ff\u200d\U0001f9b3",
+	"man_in_motorized_wheelchair_light_skin_tone":                              "\U0001f468\U0001f3fb\u200d\U0001f9bc",
+	"man_in_motorized_wheelchair_medium_light_skin_tone":                       "\U0001f468\U0001f3fc\u200d\U0001f9bc",
+	"man_in_motorized_wheelchair_medium_skin_tone":                             "\U0001f468\U0001f3fd\u200d\U0001f9bc",
+	"man_in_motorized_wheelchair_medium_dark_skin_tone":                        "\U0001f468\U0001f3fe\u200d\U0001f9bc",
+	"man_in_motorized_wheelchair_dark_skin_tone":                               "\U0001f468\U0001f3ff\u200d\U0001f9bc",
+	"man_in_manual_wheelchair_light_skin_tone":                                 "\U0001f468\U0001f3fb\u200d\U0001f9bd",
+	"man_in_manual_wheelchair_medium_light_skin_tone":                          "\U0001f468\U0001f3fc\u200d\U0001f9bd",
+	"man_in_manual_wheelchair_medium_skin_tone":                                "\U0001f468\U0001f3fd\u200d\U0001f9bd",
+	"man_in_manual_wheelchair_medium_dark_skin_tone":                           "\U0001f468\U0001f3fe\u200d\U0001f9bd",
+	"man_in_manual_wheelchair_dark_skin_tone":                                  "\U0001f468\U0001f3ff\u200d\U0001f9bd",
+	"male-doctor_light_skin_tone":                                              "\U0001f468\U0001f3fb\u200d\u2695\ufe0f",
+	"man_health_worker_light_skin_tone":                                        "\U0001f468\U0001f3fb\u200d\u2695\ufe0f",
+	"male-doctor_medium_light_skin_tone":                                       "\U0001f468\U0001f3fc\u200d\u2695\ufe0f",
+	"man_health_worker_medium_light_skin_tone":                                 "\U0001f468\U0001f3fc\u200d\u2695\ufe0f",
+	"male-doctor_medium_skin_tone":                                             "\U0001f468\U0001f3fd\u200d\u2695\ufe0f",
+	"man_health_worker_medium_skin_tone":                                       "\U0001f468\U0001f3fd\u200d\u2695\ufe0f",
+	"male-doctor_medium_dark_skin_tone":                                        "\U0001f468\U0001f3fe\u200d\u2695\ufe0f",
+	"man_health_worker_medium_dark_skin_tone":                                  "\U0001f468\U0001f3fe\u200d\u2695\ufe0f",
+	"male-doctor_dark_skin_tone":                                               "\U0001f468\U0001f3ff\u200d\u2695\ufe0f",
+	"man_health_worker_dark_skin_tone":                                         "\U0001f468\U0001f3ff\u200d\u2695\ufe0f",
+	"male-judge_light_skin_tone":                                               "\U0001f468\U0001f3fb\u200d\u2696\ufe0f",
+	"man_judge_light_skin_tone":                                                "\U0001f468\U0001f3fb\u200d\u2696\ufe0f",
+	"male-judge_medium_light_skin_tone":                                        "\U0001f468\U0001f3fc\u200d\u2696\ufe0f",
+	"man_judge_medium_light_skin_tone":                                         "\U0001f468\U0001f3fc\u200d\u2696\ufe0f",
+	"male-judge_medium_skin_tone":                                              "\U0001f468\U0001f3fd\u200d\u2696\ufe0f",
+	"man_judge_medium_skin_tone":                                               "\U0001f468\U0001f3fd\u200d\u2696\ufe0f",
+	"male-judge_medium_dark_skin_tone":                                         "\U0001f468\U0001f3fe\u200d\u2696\ufe0f",
+	"man_judge_medium_dark_skin_tone":                                          "\U0001f468\U0001f3fe\u200d\u2696\ufe0f",
+	"male-judge_dark_skin_tone":                                                "\U0001f468\U0001f3ff\u200d\u2696\ufe0f",
+	"man_judge_dark_skin_tone":                                                 "\U0001f468\U0001f3ff\u200d\u2696\ufe0f",
+	"male-pilot_light_skin_tone":                                               "\U0001f468\U0001f3fb\u200d\u2708\ufe0f",
+	"man_pilot_light_skin_tone":                                                "\U0001f468\U0001f3fb\u200d\u2708\ufe0f",
+	"male-pilot_medium_light_skin_tone":                                        "\U0001f468\U0001f3fc\u200d\u2708\ufe0f",
+	"man_pilot_medium_light_skin_tone":                                         "\U0001f468\U0001f3fc\u200d\u2708\ufe0f",
+	"male-pilot_medium_skin_tone":                                              "\U0001f468\U0001f3fd\u200d\u2708\ufe0f",
+	"man_pilot_medium_skin_tone":                                               "\U0001f468\U0001f3fd\u200d\u2708\ufe0f",
+	"male-pilot_medium_dark_skin_tone":                                         "\U0001f468\U0001f3fe\u200d\u2708\ufe0f",
+	"man_pilot_medium_dark_skin_tone":                                          "\U0001f468\U0001f3fe\u200d\u2708\ufe0f",
+	"male-pilot_dark_skin_tone":                                                "\U0001f468\U0001f3ff\u200d\u2708\ufe0f",
+	"man_pilot_dark_skin_tone":                                                 "\U0001f468\U0001f3ff\u200d\u2708\ufe0f",
+	"man_light_skin_tone":                                                      "\U0001f468\U0001f3fb",
+	"man_medium_light_skin_tone":                                               "\U0001f468\U0001f3fc",
+	"man_medium_skin_tone":                                                     "\U0001f468\U0001f3fd",
+	"man_medium_dark_skin_tone":                                                "\U0001f468\U0001f3fe",
+	"man_dark_skin_tone":                                                       "\U0001f468\U0001f3ff",
+	"female-farmer_light_skin_tone":                                            "\U0001f469\U0001f3fb\u200d\U0001f33e",
+	"woman_farmer_light_skin_tone":                                             "\U0001f469\U0001f3fb\u200d\U0001f33e",
+	"female-farmer_medium_light_skin_tone":                                     "\U0001f469\U0001f3fc\u200d\U0001f33e",
+	"woman_farmer_medium_light_skin_tone":                                      "\U0001f469\U0001f3fc\u200d\U0001f33e",
+	"female-farmer_medium_skin_tone":                                           "\U0001f469\U0001f3fd\u200d\U0001f33e",
+	"woman_farmer_medium_skin_tone":                                            "\U0001f469\U0001f3fd\u200d\U0001f33e",
+	"female-farmer_medium_dark_skin_tone":                                      "\U0001f469\U0001f3fe\u200d\U0001f33e",
+	"woman_farmer_medium_dark_skin_tone":                                       "\U0001f469\U0001f3fe\u200d\U0001f33e",
+	"female-farmer_dark_skin_tone":                                             "\U0001f469\U0001f3ff\u200d\U0001f33e",
+	"woman_farmer_dark_skin_tone":                                              "\U0001f469\U0001f3ff\u200d\U0001f33e",
+	"female-cook_light_skin_tone":                                              "\U0001f469\U0001f3fb\u200d\U0001f373",
+	"woman_cook_light_skin_tone":                                               "\U0001f469\U0001f3fb\u200d\U0001f373",
+	"female-cook_medium_light_skin_tone":                                       "\U0001f469\U0001f3fc\u200d\U0001f373",
+	"woman_cook_medium_light_skin_tone":                                        "\U0001f469\U0001f3fc\u200d\U0001f373",
+	"female-cook_medium_skin_tone":                                             "\U0001f469\U0001f3fd\u200d\U0001f373",
+	"woman_cook_medium_skin_tone":                                              "\U0001f469\U0001f3fd\u200d\U0001f373",
+	"female-cook_medium_dark_skin_tone":                                        "\U0001f469\U0001f3fe\u200d\U0001f373",
+	"woman_cook_medium_dark_skin_tone":                                         "\U0001f469\U0001f3fe\u200d\U0001f373",
+	"female-cook_dark_skin_tone":                                               "\U0001f469\U0001f3ff\u200d\U0001f373",
+	"woman_cook_dark_skin_tone":                                                "\U0001f469\U0001f3ff\u200d\U0001f373",
+	"woman_feeding_baby_light_skin_tone":                                       "\U0001f469\U0001f3fb\u200d\U0001f37c",
+	"woman_feeding_baby_medium_light_skin_tone":                                "\U0001f469\U0001f3fc\u200d\U0001f37c",
+	"woman_feeding_baby_medium_skin_tone":                                      "\U0001f469\U0001f3fd\u200d\U0001f37c",
+	"woman_feeding_baby_medium_dark_skin_tone":                                 "\U0001f469\U0001f3fe\u200d\U0001f37c",
+	"woman_feeding_baby_dark_skin_tone":                                        "\U0001f469\U0001f3ff\u200d\U0001f37c",
+	"female-student_light_skin_tone":                                           "\U0001f469\U0001f3fb\u200d\U0001f393",
+	"woman_student_light_skin_tone":                                            "\U0001f469\U0001f3fb\u200d\U0001f393",
+	"female-student_medium_light_skin_tone":                                    "\U0001f469\U0001f3fc\u200d\U0001f393",
+	"woman_student_medium_light_skin_tone":                                     "\U0001f469\U0001f3fc\u200d\U0001f393",
+	"female-student_medium_skin_tone":                                          "\U0001f469\U0001f3fd\u200d\U0001f393",
+	"woman_student_medium_skin_tone":                                           "\U0001f469\U0001f3fd\u200d\U0001f393",
+	"female-student_medium_dark_skin_tone":                                     "\U0001f469\U0001f3fe\u200d\U0001f393",
+	"woman_student_medium_dark_skin_tone":                                      "\U0001f469\U0001f3fe\u200d\U0001f393",
+	"female-student_dark_skin_tone":                                            "\U0001f469\U0001f3ff\u200d\U0001f393",
+	"woman_student_dark_skin_tone":                                             "\U0001f469\U0001f3ff\u200d\U0001f393",
+	"female-singer_light_skin_tone":                                            "\U0001f469\U0001f3fb\u200d\U0001f3a4",
+	"woman_singer_light_skin_tone":                                             "\U0001f469\U0001f3fb\u200d\U0001f3a4",
+	"female-singer_medium_light_skin_tone":                                     "\U0001f469\U0001f3fc\u200d\U0001f3a4",
+	"woman_singer_medium_light_skin_tone":                                      "\U0001f469\U0001f3fc\u200d\U0001f3a4",
+	"female-singer_medium_skin_tone":                                           "\U0001f469\U0001f3fd\u200d\U0001f3a4",
+	"woman_singer_medium_skin_tone":                                            "\U0001f469\U0001f3fd\u200d\U0001f3a4",
+	"female-singer_medium_dark_skin_tone":                                      "\U0001f469\U0001f3fe\u200d\U0001f3a4",
+	"woman_singer_medium_dark_skin_tone":                                       "\U0001f469\U0001f3fe\u200d\U0001f3a4",
+	"female-singer_dark_skin_tone":                                             "\U0001f469\U0001f3ff\u200d\U0001f3a4",
+	"woman_singer_dark_skin_tone":                                              "\U0001f469\U0001f3ff\u200d\U0001f3a4",
+	"female-artist_light_skin_tone":                                            "\U0001f469\U0001f3fb\u200d\U0001f3a8",
+	"woman_artist_light_skin_tone":                                             "\U0001f469\U0001f3fb\u200d\U0001f3a8",
+	"female-artist_medium_light_skin_tone":                                     "\U0001f469\U0001f3fc\u200d\U0001f3a8",
+	"woman_artist_medium_light_skin_tone":                                      "\U0001f469\U0001f3fc\u200d\U0001f3a8",
+	"female-artist_medium_skin_tone":                                           "\U0001f469\U0001f3fd\u200d\U0001f3a8",
+	"woman_artist_medium_skin_tone":                                            "\U0001f469\U0001f3fd\u200d\U0001f3a8",
+	"female-artist_medium_dark_skin_tone":                                      "\U0001f469\U0001f3fe\u200d\U0001f3a8",
+	"woman_artist_medium_dark_skin_tone":                                       "\U0001f469\U0001f3fe\u200d\U0001f3a8",
+	"female-artist_dark_skin_tone":                                             "\U0001f469\U0001f3ff\u200d\U0001f3a8",
+	"woman_artist_dark_skin_tone":                                              "\U0001f469\U0001f3ff\u200d\U0001f3a8",
+	"female-teacher_light_skin_tone":                                           "\U0001f469\U0001f3fb\u200d\U0001f3eb",
+	"woman_teacher_light_skin_tone":                                            "\U0001f469\U0001f3fb\u200d\U0001f3eb",
+	"female-teacher_medium_light_skin_tone":                                    "\U0001f469\U0001f3fc\u200d\U0001f3eb",
+	"woman_teacher_medium_light_skin_tone":                                     "\U0001f469\U0001f3fc\u200d\U0001f3eb",
+	"female-teacher_medium_skin_tone":                                          "\U0001f469\U0001f3fd\u200d\U0001f3eb",
+	"woman_teacher_medium_skin_tone":                                           "\U0001f469\U0001f3fd\u200d\U0001f3eb",
+	"female-teacher_medium_dark_skin_tone":                                     "\U0001f469\U0001f3fe\u200d\U0001f3eb",
+	"woman_teacher_medium_dark_skin_tone":                                      "\U0001f469\U0001f3fe\u200d\U0001f3eb",
+	"female-teacher_dark_skin_tone":                                            "\U0001f469\U0001f3ff\u200d\U0001f3eb",
+	"woman_teacher_dark_skin_tone":                                             "\U0001f469\U0001f3ff\u200d\U0001f3eb",
+	"female-factory-worker_light_skin_tone":                                    "\U0001f469\U0001f3fb\u200d\U0001f3ed",
+	"woman_factory_worker_light_skin_tone":                                     "\U0001f469\U0001f3fb\u200d\U0001f3ed",
+	"female-factory-worker_medium_light_skin_tone":                             "\U0001f469\U0001f3fc\u200d\U0001f3ed",
+	"woman_factory_worker_medium_light_skin_tone":                              "\U0001f469\U0001f3fc\u200d\U0001f3ed",
+	"female-factory-worker_medium_skin_tone":                                   "\U0001f469\U0001f3fd\u200d\U0001f3ed",
+	"woman_factory_worker_medium_skin_tone":                                    "\U0001f469\U0001f3fd\u200d\U0001f3ed",
+	"female-factory-worker_medium_dark_skin_tone":                              "\U0001f469\U0001f3fe\u200d\U0001f3ed",
+	"woman_factory_worker_medium_dark_skin_tone":                               "\U0001f469\U0001f3fe\u200d\U0001f3ed",
+	"female-factory-worker_dark_skin_tone":                                     "\U0001f469\U0001f3ff\u200d\U0001f3ed",
+	"woman_factory_worker_dark_skin_tone":                                      "\U0001f469\U0001f3ff\u200d\U0001f3ed",
+	"female-technologist_light_skin_tone":                                      "\U0001f469\U0001f3fb\u200d\U0001f4bb",
+	"woman_technologist_light_skin_tone":                                       "\U0001f469\U0001f3fb\u200d\U0001f4bb",
+	"female-technologist_medium_light_skin_tone":                               "\U0001f469\U0001f3fc\u200d\U0001f4bb",
+	"woman_technologist_medium_light_skin_tone":                                "\U0001f469\U0001f3fc\u200d\U0001f4bb",
+	"female-technologist_medium_skin_tone":                                     "\U0001f469\U0001f3fd\u200d\U0001f4bb",
+	"woman_technologist_medium_skin_tone":                                      "\U0001f469\U0001f3fd\u200d\U0001f4bb",
+	"female-technologist_medium_dark_skin_tone":                                "\U0001f469\U0001f3fe\u200d\U0001f4bb",
+	"woman_technologist_medium_dark_skin_tone":                                 "\U0001f469\U0001f3fe\u200d\U0001f4bb",
+	"female-technologist_dark_skin_tone":                                       "\U0001f469\U0001f3ff\u200d\U0001f4bb",
+	"woman_technologist_dark_skin_tone":                                        "\U0001f469\U0001f3ff\u200d\U0001f4bb",
+	"female-office-worker_light_skin_tone":                                     "\U0001f469\U0001f3fb\u200d\U0001f4bc",
+	"woman_office_worker_light_skin_tone":                                      "\U0001f469\U0001f3fb\u200d\U0001f4bc",
+	"female-office-worker_medium_light_skin_tone":                              "\U0001f469\U0001f3fc\u200d\U0001f4bc",
+	"woman_office_worker_medium_light_skin_tone":                               "\U0001f469\U0001f3fc\u200d\U0001f4bc",
+	"female-office-worker_medium_skin_tone":                                    "\U0001f469\U0001f3fd\u200d\U0001f4bc",
+	"woman_office_worker_medium_skin_tone":                                     "\U0001f469\U0001f3fd\u200d\U0001f4bc",
+	"female-office-worker_medium_dark_skin_tone":                               "\U0001f469\U0001f3fe\u200d\U0001f4bc",
+	"woman_office_worker_medium_dark_skin_tone":                                "\U0001f469\U0001f3fe\u200d\U0001f4bc",
+	"female-office-worker_dark_skin_tone":                                      "\U0001f469\U0001f3ff\u200d\U0001f4bc",
+	"woman_office_worker_dark_skin_tone":                                       "\U0001f469\U0001f3ff\u200d\U0001f4bc",
+	"female-mechanic_light_skin_tone":                                          "\U0001f469\U0001f3fb\u200d\U0001f527",
+	"woman_mechanic_light_skin_tone":                                           "\U0001f469\U0001f3fb\u200d\U0001f527",
+	"female-mechanic_medium_light_skin_tone":                                   "\U0001f469\U0001f3fc\u200d\U0001f527",
+	"woman_mechanic_medium_light_skin_tone":                                    "\U0001f469\U0001f3fc\u200d\U0001f527",
+	"female-mechanic_medium_skin_tone":                                         "\U0001f469\U0001f3fd\u200d\U0001f527",
+	"woman_mechanic_medium_skin_tone":                                          "\U0001f469\U0001f3fd\u200d\U0001f527",
+	"female-mechanic_medium_dark_skin_tone":                                    "\U0001f469\U0001f3fe\u200d\U0001f527",
+	"woman_mechanic_medium_dark_skin_tone":                                     "\U0001f469\U0001f3fe\u200d\U0001f527",
+	"female-mechanic_dark_skin_tone":                                           "\U0001f469\U0001f3ff\u200d\U0001f527",
+	"woman_mechanic_dark_skin_tone":                                            "\U0001f469\U0001f3ff\u200d\U0001f527",
+	"female-scientist_light_skin_tone":                                         "\U0001f469\U0001f3fb\u200d\U0001f52c",
+	"woman_scientist_light_skin_tone":                                          "\U0001f469\U0001f3fb\u200d\U0001f52c",
+	"female-scientist_medium_light_skin_tone":                                  "\U0001f469\U0001f3fc\u200d\U0001f52c",
+	"woman_scientist_medium_light_skin_tone":                                   "\U0001f469\U0001f3fc\u200d\U0001f52c",
+	"female-scientist_medium_skin_tone":                                        "\U0001f469\U0001f3fd\u200d\U0001f52c",
+	"woman_scientist_medium_skin_tone":                                         "\U0001f469\U0001f3fd\u200d\U0001f52c",
+	"female-scientist_medium_dark_skin_tone":                                   "\U0001f469\U0001f3fe\u200d\U0001f52c",
+	"woman_scientist_medium_dark_skin_tone":                                    "\U0001f469\U0001f3fe\u200d\U0001f52c",
+	"female-scientist_dark_skin_tone":                                          "\U0001f469\U0001f3ff\u200d\U0001f52c",
+	"woman_scientist_dark_skin_tone":                                           "\U0001f469\U0001f3ff\u200d\U0001f52c",
+	"female-astronaut_light_skin_tone":                                         "\U0001f469\U0001f3fb\u200d\U0001f680",
+	"woman_astronaut_light_skin_tone":                                          "\U0001f469\U0001f3fb\u200d\U0001f680",
+	"female-astronaut_medium_light_skin_tone":                                  "\U0001f469\U0001f3fc\u200d\U0001f680",
+	"woman_astronaut_medium_light_skin_tone":                                   "\U0001f469\U0001f3fc\u200d\U0001f680",
+	"female-astronaut_medium_skin_tone":                                        "\U0001f469\U0001f3fd\u200d\U0001f680",
+	"woman_astronaut_medium_skin_tone":                                         "\U0001f469\U0001f3fd\u200d\U0001f680",
+	"female-astronaut_medium_dark_skin_tone":                                   "\U0001f469\U0001f3fe\u200d\U0001f680",
+	"woman_astronaut_medium_dark_skin_tone":                                    "\U0001f469\U0001f3fe\u200d\U0001f680",
+	"female-astronaut_dark_skin_tone":                                          "\U0001f469\U0001f3ff\u200d\U0001f680",
+	"woman_astronaut_dark_skin_tone":                                           "\U0001f469\U0001f3ff\u200d\U0001f680",
+	"female-firefighter_light_skin_tone":                                       "\U0001f469\U0001f3fb\u200d\U0001f692",
+	"woman_firefighter_light_skin_tone":                                        "\U0001f469\U0001f3fb\u200d\U0001f692",
+	"female-firefighter_medium_light_skin_tone":                                "\U0001f469\U0001f3fc\u200d\U0001f692",
+	"woman_firefighter_medium_light_skin_tone":                                 "\U0001f469\U0001f3fc\u200d\U0001f692",
+	"female-firefighter_medium_skin_tone":                                      "\U0001f469\U0001f3fd\u200d\U0001f692",
+	"woman_firefighter_medium_skin_tone":                                       "\U0001f469\U0001f3fd\u200d\U0001f692",
+	"female-firefighter_medium_dark_skin_tone":                                 "\U0001f469\U0001f3fe\u200d\U0001f692",
+	"woman_firefighter_medium_dark_skin_tone":                                  "\U0001f469\U0001f3fe\u200d\U0001f692",
+	"female-firefighter_dark_skin_tone":                                        "\U0001f469\U0001f3ff\u200d\U0001f692",
+	"woman_firefighter_dark_skin_tone":                                         "\U0001f469\U0001f3ff\u200d\U0001f692",
+	"woman_with_probing_cane_light_skin_tone":                                  "\U0001f469\U0001f3fb\u200d\U0001f9af",
+	"woman_with_probing_cane_medium_light_skin_tone":                           "\U0001f469\U0001f3fc\u200d\U0001f9af",
+	"woman_with_probing_cane_medium_skin_tone":                                 "\U0001f469\U0001f3fd\u200d\U0001f9af",
+	"woman_with_probing_cane_medium_dark_skin_tone":                            "\U0001f469\U0001f3fe\u200d\U0001f9af",
+	"woman_with_probing_cane_dark_skin_tone":                                   "\U0001f469\U0001f3ff\u200d\U0001f9af",
+	"red_haired_woman_light_skin_tone":                                         "\U0001f469\U0001f3fb\u200d\U0001f9b0",
+	"red_haired_woman_medium_light_skin_tone":                                  "\U0001f469\U0001f3fc\u200d\U0001f9b0",
+	"red_haired_woman_medium_skin_tone":                                        "\U0001f469\U0001f3fd\u200d\U0001f9b0",
+	"red_haired_woman_medium_dark_skin_tone":                                   "\U0001f469\U0001f3fe\u200d\U0001f9b0",
+	"red_haired_woman_dark_skin_tone":                                          "\U0001f469\U0001f3ff\u200d\U0001f9b0",
+	"curly_haired_woman_light_skin_tone":                                       "\U0001f469\U0001f3fb\u200d\U0001f9b1",
+	"curly_haired_woman_medium_light_skin_tone":                                "\U0001f469\U0001f3fc\u200d\U0001f9b1",
+	"curly_haired_woman_medium_skin_tone":                                      "\U0001f469\U0001f3fd\u200d\U0001f9b1",
+	"curly_haired_woman_medium_dark_skin_tone":                                 "\U0001f469\U0001f3fe\u200d\U0001f9b1",
+	"curly_haired_woman_dark_skin_tone":                                        "\U0001f469\U0001f3ff\u200d\U0001f9b1",
+	"bald_woman_light_skin_tone":                                               "\U0001f469\U0001f3fb\u200d\U0001f9b2",
+	"bald_woman_medium_light_skin_tone":                                        "\U0001f469\U0001f3fc\u200d\U0001f9b2",
+	"bald_woman_medium_skin_tone":                                              "\U0001f469\U0001f3fd\u200d\U0001f9b2",
+	"bald_woman_medium_dark_skin_tone":                                         "\U0001f469\U0001f3fe\u200d\U0001f9b2",
+	"bald_woman_dark_skin_tone":                                                "\U0001f469\U0001f3ff\u200d\U0001f9b2",
+	"white_haired_woman_light_skin_tone":                                       "\U0001f469\U0001f3fb\u200d\U0001f9b3",
+	"white_haired_woman_medium_light_skin_tone":                                "\U0001f469\U0001f3fc\u200d\U0001f9b3",
+	"white_haired_woman_medium_skin_tone":                                      "\U0001f469\U0001f3fd\u200d\U0001f9b3",
+	"white_haired_woman_medium_dark_skin_tone":                                 "\U0001f469\U0001f3fe\u200d\U0001f9b3",
+	"white_haired_woman_dark_skin_tone":                                        "\U0001f469\U0001f3ff\u200d\U0001f9b3",
+	"woman_in_motorized_wheelchair_light_skin_tone":                            "\U0001f469\U0001f3fb\u200d\U0001f9bc",
+	"woman_in_motorized_wheelchair_medium_light_skin_tone":                     "\U0001f469\U0001f3fc\u200d\U0001f9bc",
+	"woman_in_motorized_wheelchair_medium_skin_tone":                           "\U0001f469\U0001f3fd\u200d\U0001f9bc",
+	"woman_in_motorized_wheelchair_medium_dark_skin_tone":                      "\U0001f469\U0001f3fe\u200d\U0001f9bc",
+	"woman_in_motorized_wheelchair_dark_skin_tone":                             "\U0001f469\U0001f3ff\u200d\U0001f9bc",
+	"woman_in_manual_wheelchair_light_skin_tone":                               "\U0001f469\U0001f3fb\u200d\U0001f9bd",
+	"woman_in_manual_wheelchair_medium_light_skin_tone":                        "\U0001f469\U0001f3fc\u200d\U0001f9bd",
+	"woman_in_manual_wheelchair_medium_skin_tone":                              "\U0001f469\U0001f3fd\u200d\U0001f9bd",
+	"woman_in_manual_wheelchair_medium_dark_skin_tone":                         "\U0001f469\U0001f3fe\u200d\U0001f9bd",
+	"woman_in_manual_wheelchair_dark_skin_tone":                                "\U0001f469\U0001f3ff\u200d\U0001f9bd",
+	"female-doctor_light_skin_tone":                                            "\U0001f469\U0001f3fb\u200d\u2695\ufe0f",
+	"woman_health_worker_light_skin_tone":                                      "\U0001f469\U0001f3fb\u200d\u2695\ufe0f",
+	"female-doctor_medium_light_skin_tone":                                     "\U0001f469\U0001f3fc\u200d\u2695\ufe0f",
+	"woman_health_worker_medium_light_skin_tone":                               "\U0001f469\U0001f3fc\u200d\u2695\ufe0f",
+	"female-doctor_medium_skin_tone":                                           "\U0001f469\U0001f3fd\u200d\u2695\ufe0f",
+	"woman_health_worker_medium_skin_tone":                                     "\U0001f469\U0001f3fd\u200d\u2695\ufe0f",
+	"female-doctor_medium_dark_skin_tone":                                      "\U0001f469\U0001f3fe\u200d\u2695\ufe0f",
+	"woman_health_worker_medium_dark_skin_tone":                                "\U0001f469\U0001f3fe\u200d\u2695\ufe0f",
+	"female-doctor_dark_skin_tone":                                             "\U0001f469\U0001f3ff\u200d\u2695\ufe0f",
+	"woman_health_worker_dark_skin_tone":                                       "\U0001f469\U0001f3ff\u200d\u2695\ufe0f",
+	"female-judge_light_skin_tone":                                             "\U0001f469\U0001f3fb\u200d\u2696\ufe0f",
+	"woman_judge_light_skin_tone":                                              "\U0001f469\U0001f3fb\u200d\u2696\ufe0f",
+	"female-judge_medium_light_skin_tone":                                      "\U0001f469\U0001f3fc\u200d\u2696\ufe0f",
+	"woman_judge_medium_light_skin_tone":                                       "\U0001f469\U0001f3fc\u200d\u2696\ufe0f",
+	"female-judge_medium_skin_tone":                                            "\U0001f469\U0001f3fd\u200d\u2696\ufe0f",
+	"woman_judge_medium_skin_tone":                                             "\U0001f469\U0001f3fd\u200d\u2696\ufe0f",
+	"female-judge_medium_dark_skin_tone":                                       "\U0001f469\U0001f3fe\u200d\u2696\ufe0f",
+	"woman_judge_medium_dark_skin_tone":                                        "\U0001f469\U0001f3fe\u200d\u2696\ufe0f",
+	"female-judge_dark_skin_tone":                                              "\U0001f469\U0001f3ff\u200d\u2696\ufe0f",
+	"woman_judge_dark_skin_tone":                                               "\U0001f469\U0001f3ff\u200d\u2696\ufe0f",
+	"female-pilot_light_skin_tone":                                             "\U0001f469\U0001f3fb\u200d\u2708\ufe0f",
+	"woman_pilot_light_skin_tone":                                              "\U0001f469\U0001f3fb\u200d\u2708\ufe0f",
+	"female-pilot_medium_light_skin_tone":                                      "\U0001f469\U0001f3fc\u200d\u2708\ufe0f",
+	"woman_pilot_medium_light_skin_tone":                                       "\U0001f469\U0001f3fc\u200d\u2708\ufe0f",
+	"female-pilot_medium_skin_tone":                                            "\U0001f469\U0001f3fd\u200d\u2708\ufe0f",
+	"woman_pilot_medium_skin_tone":                                             "\U0001f469\U0001f3fd\u200d\u2708\ufe0f",
+	"female-pilot_medium_dark_skin_tone":                                       "\U0001f469\U0001f3fe\u200d\u2708\ufe0f",
+	"woman_pilot_medium_dark_skin_tone":                                        "\U0001f469\U0001f3fe\u200d\u2708\ufe0f",
+	"female-pilot_dark_skin_tone":                                              "\U0001f469\U0001f3ff\u200d\u2708\ufe0f",
+	"woman_pilot_dark_skin_tone":                                               "\U0001f469\U0001f3ff\u200d\u2708\ufe0f",
+	"woman_light_skin_tone":                                                    "\U0001f469\U0001f3fb",
+	"woman_medium_light_skin_tone":                                             "\U0001f469\U0001f3fc",
+	"woman_medium_skin_tone":                                                   "\U0001f469\U0001f3fd",
+	"woman_medium_dark_skin_tone":                                              "\U0001f469\U0001f3fe",
+	"woman_dark_skin_tone":                                                     "\U0001f469\U0001f3ff",
+	"man_and_woman_holding_hands_light_skin_tone":                              "\U0001f46b\U0001f3fb",
+	"woman_and_man_holding_hands_light_skin_tone":                              "\U0001f46b\U0001f3fb",
+	"couple_light_skin_tone":                                                   "\U0001f46b\U0001f3fb",
+	"man_and_woman_holding_hands_medium_light_skin_tone":                       "\U0001f46b\U0001f3fc",
+	"woman_and_man_holding_hands_medium_light_skin_tone":                       "\U0001f46b\U0001f3fc",
+	"couple_medium_light_skin_tone":                                            "\U0001f46b\U0001f3fc",
+	"man_and_woman_holding_hands_medium_skin_tone":                             "\U0001f46b\U0001f3fd",
+	"woman_and_man_holding_hands_medium_skin_tone":                             "\U0001f46b\U0001f3fd",
+	"couple_medium_skin_tone":                                                  "\U0001f46b\U0001f3fd",
+	"man_and_woman_holding_hands_medium_dark_skin_tone":                        "\U0001f46b\U0001f3fe",
+	"woman_and_man_holding_hands_medium_dark_skin_tone":                        "\U0001f46b\U0001f3fe",
+	"couple_medium_dark_skin_tone":                                             "\U0001f46b\U0001f3fe",
+	"man_and_woman_holding_hands_dark_skin_tone":                               "\U0001f46b\U0001f3ff",
+	"woman_and_man_holding_hands_dark_skin_tone":                               "\U0001f46b\U0001f3ff",
+	"couple_dark_skin_tone":                                                    "\U0001f46b\U0001f3ff",
+	"man_and_woman_holding_hands_light_skin_tone_medium_light_skin_tone":       "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"woman_and_man_holding_hands_light_skin_tone_medium_light_skin_tone":       "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"couple_light_skin_tone_medium_light_skin_tone":                            "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"man_and_woman_holding_hands_light_skin_tone_medium_skin_tone":             "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"woman_and_man_holding_hands_light_skin_tone_medium_skin_tone":             "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"couple_light_skin_tone_medium_skin_tone":                                  "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"man_and_woman_holding_hands_light_skin_tone_medium_dark_skin_tone":        "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"woman_and_man_holding_hands_light_skin_tone_medium_dark_skin_tone":        "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"couple_light_skin_tone_medium_dark_skin_tone":                             "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"man_and_woman_holding_hands_light_skin_tone_dark_skin_tone":               "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"woman_and_man_holding_hands_light_skin_tone_dark_skin_tone":               "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"couple_light_skin_tone_dark_skin_tone":                                    "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"man_and_woman_holding_hands_medium_light_skin_tone_light_skin_tone":       "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"woman_and_man_holding_hands_medium_light_skin_tone_light_skin_tone":       "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"couple_medium_light_skin_tone_light_skin_tone":                            "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"man_and_woman_holding_hands_medium_light_skin_tone_medium_skin_tone":      "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"woman_and_man_holding_hands_medium_light_skin_tone_medium_skin_tone":      "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"couple_medium_light_skin_tone_medium_skin_tone":                           "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"man_and_woman_holding_hands_medium_light_skin_tone_medium_dark_skin_tone": "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"woman_and_man_holding_hands_medium_light_skin_tone_medium_dark_skin_tone": "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"couple_medium_light_skin_tone_medium_dark_skin_tone":                      "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"man_and_woman_holding_hands_medium_light_skin_tone_dark_skin_tone":        "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"woman_and_man_holding_hands_medium_light_skin_tone_dark_skin_tone":        "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"couple_medium_light_skin_tone_dark_skin_tone":                             "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"man_and_woman_holding_hands_medium_skin_tone_light_skin_tone":             "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"woman_and_man_holding_hands_medium_skin_tone_light_skin_tone":             "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"couple_medium_skin_tone_light_skin_tone":                                  "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"man_and_woman_holding_hands_medium_skin_tone_medium_light_skin_tone":      "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"woman_and_man_holding_hands_medium_skin_tone_medium_light_skin_tone":      "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"couple_medium_skin_tone_medium_light_skin_tone":                           "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"man_and_woman_holding_hands_medium_skin_tone_medium_dark_skin_tone":       "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"woman_and_man_holding_hands_medium_skin_tone_medium_dark_skin_tone":       "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"couple_medium_skin_tone_medium_dark_skin_tone":                            "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"man_and_woman_holding_hands_medium_skin_tone_dark_skin_tone":              "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"woman_and_man_holding_hands_medium_skin_tone_dark_skin_tone":              "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"couple_medium_skin_tone_dark_skin_tone":                                   "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"man_and_woman_holding_hands_medium_dark_skin_tone_light_skin_tone":        "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"woman_and_man_holding_hands_medium_dark_skin_tone_light_skin_tone":        "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"couple_medium_dark_skin_tone_light_skin_tone":                             "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"man_and_woman_holding_hands_medium_dark_skin_tone_medium_light_skin_tone": "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"woman_and_man_holding_hands_medium_dark_skin_tone_medium_light_skin_tone": "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"couple_medium_dark_skin_tone_medium_light_skin_tone":                      "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"man_and_woman_holding_hands_medium_dark_skin_tone_medium_skin_tone":       "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"woman_and_man_holding_hands_medium_dark_skin_tone_medium_skin_tone":       "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"couple_medium_dark_skin_tone_medium_skin_tone":                            "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"man_and_woman_holding_hands_medium_dark_skin_tone_dark_skin_tone":         "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"woman_and_man_holding_hands_medium_dark_skin_tone_dark_skin_tone":         "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"couple_medium_dark_skin_tone_dark_skin_tone":                              "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"man_and_woman_holding_hands_dark_skin_tone_light_skin_tone":               "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"woman_and_man_holding_hands_dark_skin_tone_light_skin_tone":               "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"couple_dark_skin_tone_light_skin_tone":                                    "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"man_and_woman_holding_hands_dark_skin_tone_medium_light_skin_tone":        "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"woman_and_man_holding_hands_dark_skin_tone_medium_light_skin_tone":        "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"couple_dark_skin_tone_medium_light_skin_tone":                             "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"man_and_woman_holding_hands_dark_skin_tone_medium_skin_tone":              "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"woman_and_man_holding_hands_dark_skin_tone_medium_skin_tone":              "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"couple_dark_skin_tone_medium_skin_tone":                                   "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"man_and_woman_holding_hands_dark_skin_tone_medium_dark_skin_tone":         "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"woman_and_man_holding_hands_dark_skin_tone_medium_dark_skin_tone":         "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"couple_dark_skin_tone_medium_dark_skin_tone":                              "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"two_men_holding_hands_light_skin_tone":                                    "\U0001f46c\U0001f3fb",
+	"men_holding_hands_light_skin_tone":                                        "\U0001f46c\U0001f3fb",
+	"two_men_holding_hands_medium_light_skin_tone":                             "\U0001f46c\U0001f3fc",
+	"men_holding_hands_medium_light_skin_tone":                                 "\U0001f46c\U0001f3fc",
+	"two_men_holding_hands_medium_skin_tone":                                   "\U0001f46c\U0001f3fd",
+	"men_holding_hands_medium_skin_tone":                                       "\U0001f46c\U0001f3fd",
+	"two_men_holding_hands_medium_dark_skin_tone":                              "\U0001f46c\U0001f3fe",
+	"men_holding_hands_medium_dark_skin_tone":                                  "\U0001f46c\U0001f3fe",
+	"two_men_holding_hands_dark_skin_tone":                                     "\U0001f46c\U0001f3ff",
+	"men_holding_hands_dark_skin_tone":                                         "\U0001f46c\U0001f3ff",
+	"two_men_holding_hands_light_skin_tone_medium_light_skin_tone":             "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"men_holding_hands_light_skin_tone_medium_light_skin_tone":                 "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"two_men_holding_hands_light_skin_tone_medium_skin_tone":                   "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"men_holding_hands_light_skin_tone_medium_skin_tone":                       "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"two_men_holding_hands_light_skin_tone_medium_dark_skin_tone":              "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"men_holding_hands_light_skin_tone_medium_dark_skin_tone":                  "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"two_men_holding_hands_light_skin_tone_dark_skin_tone":                     "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"men_holding_hands_light_skin_tone_dark_skin_tone":                         "\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"two_men_holding_hands_medium_light_skin_tone_light_skin_tone":             "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"men_holding_hands_medium_light_skin_tone_light_skin_tone":                 "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"two_men_holding_hands_medium_light_skin_tone_medium_skin_tone":            "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"men_holding_hands_medium_light_skin_tone_medium_skin_tone":                "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"two_men_holding_hands_medium_light_skin_tone_medium_dark_skin_tone":       "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"men_holding_hands_medium_light_skin_tone_medium_dark_skin_tone":           "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"two_men_holding_hands_medium_light_skin_tone_dark_skin_tone":              "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"men_holding_hands_medium_light_skin_tone_dark_skin_tone":                  "\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"two_men_holding_hands_medium_skin_tone_light_skin_tone":                   "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"men_holding_hands_medium_skin_tone_light_skin_tone":                       "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"two_men_holding_hands_medium_skin_tone_medium_light_skin_tone":            "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"men_holding_hands_medium_skin_tone_medium_light_skin_tone":                "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"two_men_holding_hands_medium_skin_tone_medium_dark_skin_tone":             "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"men_holding_hands_medium_skin_tone_medium_dark_skin_tone":                 "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"two_men_holding_hands_medium_skin_tone_dark_skin_tone":                    "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"men_holding_hands_medium_skin_tone_dark_skin_tone":                        "\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"two_men_holding_hands_medium_dark_skin_tone_light_skin_tone":              "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"men_holding_hands_medium_dark_skin_tone_light_skin_tone":                  "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"two_men_holding_hands_medium_dark_skin_tone_medium_light_skin_tone":       "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"men_holding_hands_medium_dark_skin_tone_medium_light_skin_tone":           "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"two_men_holding_hands_medium_dark_skin_tone_medium_skin_tone":             "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"men_holding_hands_medium_dark_skin_tone_medium_skin_tone":                 "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"two_men_holding_hands_medium_dark_skin_tone_dark_skin_tone":               "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"men_holding_hands_medium_dark_skin_tone_dark_skin_tone":                   "\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3ff",
+	"two_men_holding_hands_dark_skin_tone_light_skin_tone":                     "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"men_holding_hands_dark_skin_tone_light_skin_tone":                         "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fb",
+	"two_men_holding_hands_dark_skin_tone_medium_light_skin_tone":              "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"men_holding_hands_dark_skin_tone_medium_light_skin_tone":                  "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fc",
+	"two_men_holding_hands_dark_skin_tone_medium_skin_tone":                    "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"men_holding_hands_dark_skin_tone_medium_skin_tone":                        "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fd",
+	"two_men_holding_hands_dark_skin_tone_medium_dark_skin_tone":               "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"men_holding_hands_dark_skin_tone_medium_dark_skin_tone":                   "\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fe",
+	"two_women_holding_hands_light_skin_tone":                                  "\U0001f46d\U0001f3fb",
+	"women_holding_hands_light_skin_tone":                                      "\U0001f46d\U0001f3fb",
+	"two_women_holding_hands_medium_light_skin_tone":                           "\U0001f46d\U0001f3fc",
+	"women_holding_hands_medium_light_skin_tone":                               "\U0001f46d\U0001f3fc",
+	"two_women_holding_hands_medium_skin_tone":                                 "\U0001f46d\U0001f3fd",
+	"women_holding_hands_medium_skin_tone":                                     "\U0001f46d\U0001f3fd",
+	"two_women_holding_hands_medium_dark_skin_tone":                            "\U0001f46d\U0001f3fe",
+	"women_holding_hands_medium_dark_skin_tone":                                "\U0001f46d\U0001f3fe",
+	"two_women_holding_hands_dark_skin_tone":                                   "\U0001f46d\U0001f3ff",
+	"women_holding_hands_dark_skin_tone":                                       "\U0001f46d\U0001f3ff",
+	"two_women_holding_hands_light_skin_tone_medium_light_skin_tone":           "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"women_holding_hands_light_skin_tone_medium_light_skin_tone":               "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"two_women_holding_hands_light_skin_tone_medium_skin_tone":                 "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"women_holding_hands_light_skin_tone_medium_skin_tone":                     "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"two_women_holding_hands_light_skin_tone_medium_dark_skin_tone":            "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"women_holding_hands_light_skin_tone_medium_dark_skin_tone":                "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"two_women_holding_hands_light_skin_tone_dark_skin_tone":                   "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"women_holding_hands_light_skin_tone_dark_skin_tone":                       "\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"two_women_holding_hands_medium_light_skin_tone_light_skin_tone":           "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"women_holding_hands_medium_light_skin_tone_light_skin_tone":               "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"two_women_holding_hands_medium_light_skin_tone_medium_skin_tone":          "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"women_holding_hands_medium_light_skin_tone_medium_skin_tone":              "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"two_women_holding_hands_medium_light_skin_tone_medium_dark_skin_tone":     "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"women_holding_hands_medium_light_skin_tone_medium_dark_skin_tone":         "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"two_women_holding_hands_medium_light_skin_tone_dark_skin_tone":            "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"women_holding_hands_medium_light_skin_tone_dark_skin_tone":                "\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"two_women_holding_hands_medium_skin_tone_light_skin_tone":                 "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"women_holding_hands_medium_skin_tone_light_skin_tone":                     "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"two_women_holding_hands_medium_skin_tone_medium_light_skin_tone":          "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"women_holding_hands_medium_skin_tone_medium_light_skin_tone":              "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"two_women_holding_hands_medium_skin_tone_medium_dark_skin_tone":           "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"women_holding_hands_medium_skin_tone_medium_dark_skin_tone":               "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"two_women_holding_hands_medium_skin_tone_dark_skin_tone":                  "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"women_holding_hands_medium_skin_tone_dark_skin_tone":                      "\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"two_women_holding_hands_medium_dark_skin_tone_light_skin_tone":            "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"women_holding_hands_medium_dark_skin_tone_light_skin_tone":                "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"two_women_holding_hands_medium_dark_skin_tone_medium_light_skin_tone":     "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"women_holding_hands_medium_dark_skin_tone_medium_light_skin_tone":         "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"two_women_holding_hands_medium_dark_skin_tone_medium_skin_tone":           "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"women_holding_hands_medium_dark_skin_tone_medium_skin_tone":               "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"two_women_holding_hands_medium_dark_skin_tone_dark_skin_tone":             "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"women_holding_hands_medium_dark_skin_tone_dark_skin_tone":                 "\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3ff",
+	"two_women_holding_hands_dark_skin_tone_light_skin_tone":                   "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"women_holding_hands_dark_skin_tone_light_skin_tone":                       "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fb",
+	"two_women_holding_hands_dark_skin_tone_medium_light_skin_tone":            "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"women_holding_hands_dark_skin_tone_medium_light_skin_tone":                "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fc",
+	"two_women_holding_hands_dark_skin_tone_medium_skin_tone":                  "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"women_holding_hands_dark_skin_tone_medium_skin_tone":                      "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fd",
+	"two_women_holding_hands_dark_skin_tone_medium_dark_skin_tone":             "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"women_holding_hands_dark_skin_tone_medium_dark_skin_tone":                 "\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fe",
+	"female-police-officer_light_skin_tone":                                    "\U0001f46e\U0001f3fb\u200d\u2640\ufe0f",
+	"policewoman_light_skin_tone":                                              "\U0001f46e\U0001f3fb\u200d\u2640\ufe0f",
+	"female-police-officer_medium_light_skin_tone":                             "\U0001f46e\U0001f3fc\u200d\u2640\ufe0f",
+	"policewoman_medium_light_skin_tone":                                       "\U0001f46e\U0001f3fc\u200d\u2640\ufe0f",
+	"female-police-officer_medium_skin_tone":                                   "\U0001f46e\U0001f3fd\u200d\u2640\ufe0f",
+	"policewoman_medium_skin_tone":                                             "\U0001f46e\U0001f3fd\u200d\u2640\ufe0f",
+	"female-police-officer_medium_dark_skin_tone":                              "\U0001f46e\U0001f3fe\u200d\u2640\ufe0f",
+	"policewoman_medium_dark_skin_tone":                                        "\U0001f46e\U0001f3fe\u200d\u2640\ufe0f",
+	"female-police-officer_dark_skin_tone":                                     "\U0001f46e\U0001f3ff\u200d\u2640\ufe0f",
+	"policewoman_dark_skin_tone":                                               "\U0001f46e\U0001f3ff\u200d\u2640\ufe0f",
+	"male-police-officer_light_skin_tone":                                      "\U0001f46e\U0001f3fb\u200d\u2642\ufe0f",
+	"policeman_light_skin_tone":                                                "\U0001f46e\U0001f3fb\u200d\u2642\ufe0f",
+	"male-police-officer_medium_light_skin_tone":                               "\U0001f46e\U0001f3fc\u200d\u2642\ufe0f",
+	"policeman_medium_light_skin_tone":                                         "\U0001f46e\U0001f3fc\u200d\u2642\ufe0f",
+	"male-police-officer_medium_skin_tone":                                     "\U0001f46e\U0001f3fd\u200d\u2642\ufe0f",
+	"policeman_medium_skin_tone":                                               "\U0001f46e\U0001f3fd\u200d\u2642\ufe0f",
+	"male-police-officer_medium_dark_skin_tone":                                "\U0001f46e\U0001f3fe\u200d\u2642\ufe0f",
+	"policeman_medium_dark_skin_tone":                                          "\U0001f46e\U0001f3fe\u200d\u2642\ufe0f",
+	"male-police-officer_dark_skin_tone":                                       "\U0001f46e\U0001f3ff\u200d\u2642\ufe0f",
+	"policeman_dark_skin_tone":                                                 "\U0001f46e\U0001f3ff\u200d\u2642\ufe0f",
+	"cop_light_skin_tone":                                                      "\U0001f46e\U0001f3fb",
+	"cop_medium_light_skin_tone":                                               "\U0001f46e\U0001f3fc",
+	"cop_medium_skin_tone":                                                     "\U0001f46e\U0001f3fd",
+	"cop_medium_dark_skin_tone":                                                "\U0001f46e\U0001f3fe",
+	"cop_dark_skin_tone":                                                       "\U0001f46e\U0001f3ff",
+	"woman_with_veil_light_skin_tone":                                          "\U0001f470\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_with_veil_medium_light_skin_tone":                                   "\U0001f470\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_with_veil_medium_skin_tone":                                         "\U0001f470\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_with_veil_medium_dark_skin_tone":                                    "\U0001f470\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_with_veil_dark_skin_tone":                                           "\U0001f470\U0001f3ff\u200d\u2640\ufe0f",
+	"man_with_veil_light_skin_tone":                                            "\U0001f470\U0001f3fb\u200d\u2642\ufe0f",
+	"man_with_veil_medium_light_skin_tone":                                     "\U0001f470\U0001f3fc\u200d\u2642\ufe0f",
+	"man_with_veil_medium_skin_tone":                                           "\U0001f470\U0001f3fd\u200d\u2642\ufe0f",
+	"man_with_veil_medium_dark_skin_tone":                                      "\U0001f470\U0001f3fe\u200d\u2642\ufe0f",
+	"man_with_veil_dark_skin_tone":                                             "\U0001f470\U0001f3ff\u200d\u2642\ufe0f",
+	"bride_with_veil_light_skin_tone":                                          "\U0001f470\U0001f3fb",
+	"bride_with_veil_medium_light_skin_tone":                                   "\U0001f470\U0001f3fc",
+	"bride_with_veil_medium_skin_tone":                                         "\U0001f470\U0001f3fd",
+	"bride_with_veil_medium_dark_skin_tone":                                    "\U0001f470\U0001f3fe",
+	"bride_with_veil_dark_skin_tone":                                           "\U0001f470\U0001f3ff",
+	"blond-haired-woman_light_skin_tone":                                       "\U0001f471\U0001f3fb\u200d\u2640\ufe0f",
+	"blonde_woman_light_skin_tone":                                             "\U0001f471\U0001f3fb\u200d\u2640\ufe0f",
+	"blond-haired-woman_medium_light_skin_tone":                                "\U0001f471\U0001f3fc\u200d\u2640\ufe0f",
+	"blonde_woman_medium_light_skin_tone":                                      "\U0001f471\U0001f3fc\u200d\u2640\ufe0f",
+	"blond-haired-woman_medium_skin_tone":                                      "\U0001f471\U0001f3fd\u200d\u2640\ufe0f",
+	"blonde_woman_medium_skin_tone":                                            "\U0001f471\U0001f3fd\u200d\u2640\ufe0f",
+	"blond-haired-woman_medium_dark_skin_tone":                                 "\U0001f471\U0001f3fe\u200d\u2640\ufe0f",
+	"blonde_woman_medium_dark_skin_tone":                                       "\U0001f471\U0001f3fe\u200d\u2640\ufe0f",
+	"blond-haired-woman_dark_skin_tone":                                        "\U0001f471\U0001f3ff\u200d\u2640\ufe0f",
+	"blonde_woman_dark_skin_tone":                                              "\U0001f471\U0001f3ff\u200d\u2640\ufe0f",
+	"blond-haired-man_light_skin_tone":                                         "\U0001f471\U0001f3fb\u200d\u2642\ufe0f",
+	"blonde_man_light_skin_tone":                                               "\U0001f471\U0001f3fb\u200d\u2642\ufe0f",
+	"blond-haired-man_medium_light_skin_tone":                                  "\U0001f471\U0001f3fc\u200d\u2642\ufe0f",
+	"blonde_man_medium_light_skin_tone":                                        "\U0001f471\U0001f3fc\u200d\u2642\ufe0f",
+	"blond-haired-man_medium_skin_tone":                                        "\U0001f471\U0001f3fd\u200d\u2642\ufe0f",
+	"blonde_man_medium_skin_tone":                                              "\U0001f471\U0001f3fd\u200d\u2642\ufe0f",
+	"blond-haired-man_medium_dark_skin_tone":                                   "\U0001f471\U0001f3fe\u200d\u2642\ufe0f",
+	"blonde_man_medium_dark_skin_tone":                                         "\U0001f471\U0001f3fe\u200d\u2642\ufe0f",
+	"blond-haired-man_dark_skin_tone":                                          "\U0001f471\U0001f3ff\u200d\u2642\ufe0f",
+	"blonde_man_dark_skin_tone":                                                "\U0001f471\U0001f3ff\u200d\u2642\ufe0f",
+	"person_with_blond_hair_light_skin_tone":                                   "\U0001f471\U0001f3fb",
+	"person_with_blond_hair_medium_light_skin_tone":                            "\U0001f471\U0001f3fc",
+	"person_with_blond_hair_medium_skin_tone":                                  "\U0001f471\U0001f3fd",
+	"person_with_blond_hair_medium_dark_skin_tone":                             "\U0001f471\U0001f3fe",
+	"person_with_blond_hair_dark_skin_tone":                                    "\U0001f471\U0001f3ff",
+	"man_with_gua_pi_mao_light_skin_tone":                                      "\U0001f472\U0001f3fb",
+	"man_with_gua_pi_mao_medium_light_skin_tone":                               "\U0001f472\U0001f3fc",
+	"man_with_gua_pi_mao_medium_skin_tone":                                     "\U0001f472\U0001f3fd",
+	"man_with_gua_pi_mao_medium_dark_skin_tone":                                "\U0001f472\U0001f3fe",
+	"man_with_gua_pi_mao_dark_skin_tone":                                       "\U0001f472\U0001f3ff",
+	"woman-wearing-turban_light_skin_tone":                                     "\U0001f473\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_with_turban_light_skin_tone":                                        "\U0001f473\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-wearing-turban_medium_light_skin_tone":                              "\U0001f473\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_with_turban_medium_light_skin_tone":                                 "\U0001f473\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-wearing-turban_medium_skin_tone":                                    "\U0001f473\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_with_turban_medium_skin_tone":                                       "\U0001f473\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-wearing-turban_medium_dark_skin_tone":                               "\U0001f473\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_with_turban_medium_dark_skin_tone":                                  "\U0001f473\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-wearing-turban_dark_skin_tone":                                      "\U0001f473\U0001f3ff\u200d\u2640\ufe0f",
+	"woman_with_turban_dark_skin_tone":                                         "\U0001f473\U0001f3ff\u200d\u2640\ufe0f",
+	"man-wearing-turban_light_skin_tone":                                       "\U0001f473\U0001f3fb\u200d\u2642\ufe0f",
+	"man-wearing-turban_medium_light_skin_tone":                                "\U0001f473\U0001f3fc\u200d\u2642\ufe0f",
+	"man-wearing-turban_medium_skin_tone":                                      "\U0001f473\U0001f3fd\u200d\u2642\ufe0f",
+	"man-wearing-turban_medium_dark_skin_tone":                                 "\U0001f473\U0001f3fe\u200d\u2642\ufe0f",
+	"man-wearing-turban_dark_skin_tone":                                        "\U0001f473\U0001f3ff\u200d\u2642\ufe0f",
+	"man_with_turban_light_skin_tone":                                          "\U0001f473\U0001f3fb",
+	"man_with_turban_medium_light_skin_tone":                                   "\U0001f473\U0001f3fc",
+	"man_with_turban_medium_skin_tone":                                         "\U0001f473\U0001f3fd",
+	"man_with_turban_medium_dark_skin_tone":                                    "\U0001f473\U0001f3fe",
+	"man_with_turban_dark_skin_tone":                                           "\U0001f473\U0001f3ff",
+	"older_man_light_skin_tone":                                                "\U0001f474\U0001f3fb",
+	"older_man_medium_light_skin_tone":                                         "\U0001f474\U0001f3fc",
+	"older_man_medium_skin_tone":                                               "\U0001f474\U0001f3fd",
+	"older_man_medium_dark_skin_tone":                                          "\U0001f474\U0001f3fe",
+	"older_man_dark_skin_tone":                                                 "\U0001f474\U0001f3ff",
+	"older_woman_light_skin_tone":                                              "\U0001f475\U0001f3fb",
+	"older_woman_medium_light_skin_tone":                                       "\U0001f475\U0001f3fc",
+	"older_woman_medium_skin_tone":                                             "\U0001f475\U0001f3fd",
+	"older_woman_medium_dark_skin_tone":                                        "\U0001f475\U0001f3fe",
+	"older_woman_dark_skin_tone":                                               "\U0001f475\U0001f3ff",
+	"baby_light_skin_tone":                                                     "\U0001f476\U0001f3fb",
+	"baby_medium_light_skin_tone":                                              "\U0001f476\U0001f3fc",
+	"baby_medium_skin_tone":                                                    "\U0001f476\U0001f3fd",
+	"baby_medium_dark_skin_tone":                                               "\U0001f476\U0001f3fe",
+	"baby_dark_skin_tone":                                                      "\U0001f476\U0001f3ff",
+	"female-construction-worker_light_skin_tone":                               "\U0001f477\U0001f3fb\u200d\u2640\ufe0f",
+	"construction_worker_woman_light_skin_tone":                                "\U0001f477\U0001f3fb\u200d\u2640\ufe0f",
+	"female-construction-worker_medium_light_skin_tone":                        "\U0001f477\U0001f3fc\u200d\u2640\ufe0f",
+	"construction_worker_woman_medium_light_skin_tone":                         "\U0001f477\U0001f3fc\u200d\u2640\ufe0f",
+	"female-construction-worker_medium_skin_tone":                              "\U0001f477\U0001f3fd\u200d\u2640\ufe0f",
+	"construction_worker_woman_medium_skin_tone":                               "\U0001f477\U0001f3fd\u200d\u2640\ufe0f",
+	"female-construction-worker_medium_dark_skin_tone":                         "\U0001f477\U0001f3fe\u200d\u2640\ufe0f",
+	"construction_worker_woman_medium_dark_skin_tone":                          "\U0001f477\U0001f3fe\u200d\u2640\ufe0f",
+	"female-construction-worker_dark_skin_tone":                                "\U0001f477\U0001f3ff\u200d\u2640\ufe0f",
+	"construction_worker_woman_dark_skin_tone":                                 "\U0001f477\U0001f3ff\u200d\u2640\ufe0f",
+	"male-construction-worker_light_skin_tone":                                 "\U0001f477\U0001f3fb\u200d\u2642\ufe0f",
+	"construction_worker_man_light_skin_tone":                                  "\U0001f477\U0001f3fb\u200d\u2642\ufe0f",
+	"male-construction-worker_medium_light_skin_tone":                          "\U0001f477\U0001f3fc\u200d\u2642\ufe0f",
+	"construction_worker_man_medium_light_skin_tone":                           "\U0001f477\U0001f3fc\u200d\u2642\ufe0f",
+	"male-construction-worker_medium_skin_tone":                                "\U0001f477\U0001f3fd\u200d\u2642\ufe0f",
+	"construction_worker_man_medium_skin_tone":                                 "\U0001f477\U0001f3fd\u200d\u2642\ufe0f",
+	"male-construction-worker_medium_dark_skin_tone":                           "\U0001f477\U0001f3fe\u200d\u2642\ufe0f",
+	"construction_worker_man_medium_dark_skin_tone":                            "\U0001f477\U0001f3fe\u200d\u2642\ufe0f",
+	"male-construction-worker_dark_skin_tone":                                  "\U0001f477\U0001f3ff\u200d\u2642\ufe0f",
+	"construction_worker_man_dark_skin_tone":                                   "\U0001f477\U0001f3ff\u200d\u2642\ufe0f",
+	"construction_worker_light_skin_tone":                                      "\U0001f477\U0001f3fb",
+	"construction_worker_medium_light_skin_tone":                               "\U0001f477\U0001f3fc",
+	"construction_worker_medium_skin_tone":                                     "\U0001f477\U0001f3fd",
+	"construction_worker_medium_dark_skin_tone":                                "\U0001f477\U0001f3fe",
+	"construction_worker_dark_skin_tone":                                       "\U0001f477\U0001f3ff",
+	"princess_light_skin_tone":                                                 "\U0001f478\U0001f3fb",
+	"princess_medium_light_skin_tone":                                          "\U0001f478\U0001f3fc",
+	"princess_medium_skin_tone":                                                "\U0001f478\U0001f3fd",
+	"princess_medium_dark_skin_tone":                                           "\U0001f478\U0001f3fe",
+	"princess_dark_skin_tone":                                                  "\U0001f478\U0001f3ff",
+	"angel_light_skin_tone":                                                    "\U0001f47c\U0001f3fb",
+	"angel_medium_light_skin_tone":                                             "\U0001f47c\U0001f3fc",
+	"angel_medium_skin_tone":                                                   "\U0001f47c\U0001f3fd",
+	"angel_medium_dark_skin_tone":                                              "\U0001f47c\U0001f3fe",
+	"angel_dark_skin_tone":                                                     "\U0001f47c\U0001f3ff",
+	"woman-tipping-hand_light_skin_tone":                                       "\U0001f481\U0001f3fb\u200d\u2640\ufe0f",
+	"tipping_hand_woman_light_skin_tone":                                       "\U0001f481\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-tipping-hand_medium_light_skin_tone":                                "\U0001f481\U0001f3fc\u200d\u2640\ufe0f",
+	"tipping_hand_woman_medium_light_skin_tone":                                "\U0001f481\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-tipping-hand_medium_skin_tone":                                      "\U0001f481\U0001f3fd\u200d\u2640\ufe0f",
+	"tipping_hand_woman_medium_skin_tone":                                      "\U0001f481\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-tipping-hand_medium_dark_skin_tone":                                 "\U0001f481\U0001f3fe\u200d\u2640\ufe0f",
+	"tipping_hand_woman_medium_dark_skin_tone":                                 "\U0001f481\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-tipping-hand_dark_skin_tone":                                        "\U0001f481\U0001f3ff\u200d\u2640\ufe0f",
+	"tipping_hand_woman_dark_skin_tone":                                        "\U0001f481\U0001f3ff\u200d\u2640\ufe0f",
+	"man-tipping-hand_light_skin_tone":                                         "\U0001f481\U0001f3fb\u200d\u2642\ufe0f",
+	"tipping_hand_man_light_skin_tone":                                         "\U0001f481\U0001f3fb\u200d\u2642\ufe0f",
+	"man-tipping-hand_medium_light_skin_tone":                                  "\U0001f481\U0001f3fc\u200d\u2642\ufe0f",
+	"tipping_hand_man_medium_light_skin_tone":                                  "\U0001f481\U0001f3fc\u200d\u2642\ufe0f",
+	"man-tipping-hand_medium_skin_tone":                                        "\U0001f481\U0001f3fd\u200d\u2642\ufe0f",
+	"tipping_hand_man_medium_skin_tone":                                        "\U0001f481\U0001f3fd\u200d\u2642\ufe0f",
+	"man-tipping-hand_medium_dark_skin_tone":                                   "\U0001f481\U0001f3fe\u200d\u2642\ufe0f",
+	"tipping_hand_man_medium_dark_skin_tone":                                   "\U0001f481\U0001f3fe\u200d\u2642\ufe0f",
+	"man-tipping-hand_dark_skin_tone":                                          "\U0001f481\U0001f3ff\u200d\u2642\ufe0f",
+	"tipping_hand_man_dark_skin_tone":                                          "\U0001f481\U0001f3ff\u200d\u2642\ufe0f",
+	"information_desk_person_light_skin_tone":                                  "\U0001f481\U0001f3fb",
+	"information_desk_person_medium_light_skin_tone":                           "\U0001f481\U0001f3fc",
+	"information_desk_person_medium_skin_tone":                                 "\U0001f481\U0001f3fd",
+	"information_desk_person_medium_dark_skin_tone":                            "\U0001f481\U0001f3fe",
+	"information_desk_person_dark_skin_tone":                                   "\U0001f481\U0001f3ff",
+	"female-guard_light_skin_tone":                                             "\U0001f482\U0001f3fb\u200d\u2640\ufe0f",
+	"guardswoman_light_skin_tone":                                              "\U0001f482\U0001f3fb\u200d\u2640\ufe0f",
+	"female-guard_medium_light_skin_tone":                                      "\U0001f482\U0001f3fc\u200d\u2640\ufe0f",
+	"guardswoman_medium_light_skin_tone":                                       "\U0001f482\U0001f3fc\u200d\u2640\ufe0f",
+	"female-guard_medium_skin_tone":                                            "\U0001f482\U0001f3fd\u200d\u2640\ufe0f",
+	"guardswoman_medium_skin_tone":                                             "\U0001f482\U0001f3fd\u200d\u2640\ufe0f",
+	"female-guard_medium_dark_skin_tone":                                       "\U0001f482\U0001f3fe\u200d\u2640\ufe0f",
+	"guardswoman_medium_dark_skin_tone":                                        "\U0001f482\U0001f3fe\u200d\u2640\ufe0f",
+	"female-guard_dark_skin_tone":                                              "\U0001f482\U0001f3ff\u200d\u2640\ufe0f",
+	"guardswoman_dark_skin_tone":                                               "\U0001f482\U0001f3ff\u200d\u2640\ufe0f",
+	"male-guard_light_skin_tone":                                               "\U0001f482\U0001f3fb\u200d\u2642\ufe0f",
+	"male-guard_medium_light_skin_tone":                                        "\U0001f482\U0001f3fc\u200d\u2642\ufe0f",
+	"male-guard_medium_skin_tone":                                              "\U0001f482\U0001f3fd\u200d\u2642\ufe0f",
+	"male-guard_medium_dark_skin_tone":                                         "\U0001f482\U0001f3fe\u200d\u2642\ufe0f",
+	"male-guard_dark_skin_tone":                                                "\U0001f482\U0001f3ff\u200d\u2642\ufe0f",
+	"guardsman_light_skin_tone":                                                "\U0001f482\U0001f3fb",
+	"guardsman_medium_light_skin_tone":                                         "\U0001f482\U0001f3fc",
+	"guardsman_medium_skin_tone":                                               "\U0001f482\U0001f3fd",
+	"guardsman_medium_dark_skin_tone":                                          "\U0001f482\U0001f3fe",
+	"guardsman_dark_skin_tone":                                                 "\U0001f482\U0001f3ff",
+	"dancer_light_skin_tone":                                                   "\U0001f483\U0001f3fb",
+	"dancer_medium_light_skin_tone":                                            "\U0001f483\U0001f3fc",
+	"dancer_medium_skin_tone":                                                  "\U0001f483\U0001f3fd",
+	"dancer_medium_dark_skin_tone":                                             "\U0001f483\U0001f3fe",
+	"dancer_dark_skin_tone":                                                    "\U0001f483\U0001f3ff",
+	"nail_care_light_skin_tone":                                                "\U0001f485\U0001f3fb",
+	"nail_care_medium_light_skin_tone":                                         "\U0001f485\U0001f3fc",
+	"nail_care_medium_skin_tone":                                               "\U0001f485\U0001f3fd",
+	"nail_care_medium_dark_skin_tone":                                          "\U0001f485\U0001f3fe",
+	"nail_care_dark_skin_tone":                                                 "\U0001f485\U0001f3ff",
+	"woman-getting-massage_light_skin_tone":                                    "\U0001f486\U0001f3fb\u200d\u2640\ufe0f",
+	"massage_woman_light_skin_tone":                                            "\U0001f486\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-getting-massage_medium_light_skin_tone":                             "\U0001f486\U0001f3fc\u200d\u2640\ufe0f",
+	"massage_woman_medium_light_skin_tone":                                     "\U0001f486\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-getting-massage_medium_skin_tone":                                   "\U0001f486\U0001f3fd\u200d\u2640\ufe0f",
+	"massage_woman_medium_skin_tone":                                           "\U0001f486\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-getting-massage_medium_dark_skin_tone":                              "\U0001f486\U0001f3fe\u200d\u2640\ufe0f",
+	"massage_woman_medium_dark_skin_tone":                                      "\U0001f486\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-getting-massage_dark_skin_tone":                                     "\U0001f486\U0001f3ff\u200d\u2640\ufe0f",
+	"massage_woman_dark_skin_tone":                                             "\U0001f486\U0001f3ff\u200d\u2640\ufe0f",
+	"man-getting-massage_light_skin_tone":                                      "\U0001f486\U0001f3fb\u200d\u2642\ufe0f",
+	"massage_man_light_skin_tone":                                              "\U0001f486\U0001f3fb\u200d\u2642\ufe0f",
+	"man-getting-massage_medium_light_skin_tone":                               "\U0001f486\U0001f3fc\u200d\u2642\ufe0f",
+	"massage_man_medium_light_skin_tone":                                       "\U0001f486\U0001f3fc\u200d\u2642\ufe0f",
+	"man-getting-massage_medium_skin_tone":                                     "\U0001f486\U0001f3fd\u200d\u2642\ufe0f",
+	"massage_man_medium_skin_tone":                                             "\U0001f486\U0001f3fd\u200d\u2642\ufe0f",
+	"man-getting-massage_medium_dark_skin_tone":                                "\U0001f486\U0001f3fe\u200d\u2642\ufe0f",
+	"massage_man_medium_dark_skin_tone":                                        "\U0001f486\U0001f3fe\u200d\u2642\ufe0f",
+	"man-getting-massage_dark_skin_tone":                                       "\U0001f486\U0001f3ff\u200d\u2642\ufe0f",
+	"massage_man_dark_skin_tone":                                               "\U0001f486\U0001f3ff\u200d\u2642\ufe0f",
+	"massage_light_skin_tone":                                                  "\U0001f486\U0001f3fb",
+	"massage_medium_light_skin_tone":                                           "\U0001f486\U0001f3fc",
+	"massage_medium_skin_tone":                                                 "\U0001f486\U0001f3fd",
+	"massage_medium_dark_skin_tone":                                            "\U0001f486\U0001f3fe",
+	"massage_dark_skin_tone":                                                   "\U0001f486\U0001f3ff",
+	"woman-getting-haircut_light_skin_tone":                                    "\U0001f487\U0001f3fb\u200d\u2640\ufe0f",
+	"haircut_woman_light_skin_tone":                                            "\U0001f487\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-getting-haircut_medium_light_skin_tone":                             "\U0001f487\U0001f3fc\u200d\u2640\ufe0f",
+	"haircut_woman_medium_light_skin_tone":                                     "\U0001f487\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-getting-haircut_medium_skin_tone":                                   "\U0001f487\U0001f3fd\u200d\u2640\ufe0f",
+	"haircut_woman_medium_skin_tone":                                           "\U0001f487\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-getting-haircut_medium_dark_skin_tone":                              "\U0001f487\U0001f3fe\u200d\u2640\ufe0f",
+	"haircut_woman_medium_dark_skin_tone":                                      "\U0001f487\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-getting-haircut_dark_skin_tone":                                     "\U0001f487\U0001f3ff\u200d\u2640\ufe0f",
+	"haircut_woman_dark_skin_tone":                                             "\U0001f487\U0001f3ff\u200d\u2640\ufe0f",
+	"man-getting-haircut_light_skin_tone":                                      "\U0001f487\U0001f3fb\u200d\u2642\ufe0f",
+	"haircut_man_light_skin_tone":                                              "\U0001f487\U0001f3fb\u200d\u2642\ufe0f",
+	"man-getting-haircut_medium_light_skin_tone":                               "\U0001f487\U0001f3fc\u200d\u2642\ufe0f",
+	"haircut_man_medium_light_skin_tone":                                       "\U0001f487\U0001f3fc\u200d\u2642\ufe0f",
+	"man-getting-haircut_medium_skin_tone":                                     "\U0001f487\U0001f3fd\u200d\u2642\ufe0f",
+	"haircut_man_medium_skin_tone":                                             "\U0001f487\U0001f3fd\u200d\u2642\ufe0f",
+	"man-getting-haircut_medium_dark_skin_tone":                                "\U0001f487\U0001f3fe\u200d\u2642\ufe0f",
+	"haircut_man_medium_dark_skin_tone":                                        "\U0001f487\U0001f3fe\u200d\u2642\ufe0f",
+	"man-getting-haircut_dark_skin_tone":                                       "\U0001f487\U0001f3ff\u200d\u2642\ufe0f",
+	"haircut_man_dark_skin_tone":                                               "\U0001f487\U0001f3ff\u200d\u2642\ufe0f",
+	"haircut_light_skin_tone":                                                  "\U0001f487\U0001f3fb",
+	"haircut_medium_light_skin_tone":                                           "\U0001f487\U0001f3fc",
+	"haircut_medium_skin_tone":                                                 "\U0001f487\U0001f3fd",
+	"haircut_medium_dark_skin_tone":                                            "\U0001f487\U0001f3fe",
+	"haircut_dark_skin_tone":                                                   "\U0001f487\U0001f3ff",
+	"muscle_light_skin_tone":                                                   "\U0001f4aa\U0001f3fb",
+	"muscle_medium_light_skin_tone":                                            "\U0001f4aa\U0001f3fc",
+	"muscle_medium_skin_tone":                                                  "\U0001f4aa\U0001f3fd",
+	"muscle_medium_dark_skin_tone":                                             "\U0001f4aa\U0001f3fe",
+	"muscle_dark_skin_tone":                                                    "\U0001f4aa\U0001f3ff",
+	"man_in_business_suit_levitating_light_skin_tone":                          "\U0001f574\U0001f3fb",
+	"business_suit_levitating_light_skin_tone":                                 "\U0001f574\U0001f3fb",
+	"man_in_business_suit_levitating_medium_light_skin_tone":                   "\U0001f574\U0001f3fc",
+	"business_suit_levitating_medium_light_skin_tone":                          "\U0001f574\U0001f3fc",
+	"man_in_business_suit_levitating_medium_skin_tone":                         "\U0001f574\U0001f3fd",
+	"business_suit_levitating_medium_skin_tone":                                "\U0001f574\U0001f3fd",
+	"man_in_business_suit_levitating_medium_dark_skin_tone":                    "\U0001f574\U0001f3fe",
+	"business_suit_levitating_medium_dark_skin_tone":                           "\U0001f574\U0001f3fe",
+	"man_in_business_suit_levitating_dark_skin_tone":                           "\U0001f574\U0001f3ff",
+	"business_suit_levitating_dark_skin_tone":                                  "\U0001f574\U0001f3ff",
+	"female-detective_light_skin_tone":                                         "\U0001f575\U0001f3fb\u200d\u2640\ufe0f",
+	"female_detective_light_skin_tone":                                         "\U0001f575\U0001f3fb\u200d\u2640\ufe0f",
+	"female-detective_medium_light_skin_tone":                                  "\U0001f575\U0001f3fc\u200d\u2640\ufe0f",
+	"female_detective_medium_light_skin_tone":                                  "\U0001f575\U0001f3fc\u200d\u2640\ufe0f",
+	"female-detective_medium_skin_tone":                                        "\U0001f575\U0001f3fd\u200d\u2640\ufe0f",
+	"female_detective_medium_skin_tone":                                        "\U0001f575\U0001f3fd\u200d\u2640\ufe0f",
+	"female-detective_medium_dark_skin_tone":                                   "\U0001f575\U0001f3fe\u200d\u2640\ufe0f",
+	"female_detective_medium_dark_skin_tone":                                   "\U0001f575\U0001f3fe\u200d\u2640\ufe0f",
+	"female-detective_dark_skin_tone":                                          "\U0001f575\U0001f3ff\u200d\u2640\ufe0f",
+	"female_detective_dark_skin_tone":                                          "\U0001f575\U0001f3ff\u200d\u2640\ufe0f",
+	"male-detective_light_skin_tone":                                           "\U0001f575\U0001f3fb\u200d\u2642\ufe0f",
+	"male_detective_light_skin_tone":                                           "\U0001f575\U0001f3fb\u200d\u2642\ufe0f",
+	"male-detective_medium_light_skin_tone":                                    "\U0001f575\U0001f3fc\u200d\u2642\ufe0f",
+	"male_detective_medium_light_skin_tone":                                    "\U0001f575\U0001f3fc\u200d\u2642\ufe0f",
+	"male-detective_medium_skin_tone":                                          "\U0001f575\U0001f3fd\u200d\u2642\ufe0f",
+	"male_detective_medium_skin_tone":                                          "\U0001f575\U0001f3fd\u200d\u2642\ufe0f",
+	"male-detective_medium_dark_skin_tone":                                     "\U0001f575\U0001f3fe\u200d\u2642\ufe0f",
+	"male_detective_medium_dark_skin_tone":                                     "\U0001f575\U0001f3fe\u200d\u2642\ufe0f",
+	"male-detective_dark_skin_tone":                                            "\U0001f575\U0001f3ff\u200d\u2642\ufe0f",
+	"male_detective_dark_skin_tone":                                            "\U0001f575\U0001f3ff\u200d\u2642\ufe0f",
+	"sleuth_or_spy_light_skin_tone":                                            "\U0001f575\U0001f3fb",
+	"sleuth_or_spy_medium_light_skin_tone":                                     "\U0001f575\U0001f3fc",
+	"sleuth_or_spy_medium_skin_tone":                                           "\U0001f575\U0001f3fd",
+	"sleuth_or_spy_medium_dark_skin_tone":                                      "\U0001f575\U0001f3fe",
+	"sleuth_or_spy_dark_skin_tone":                                             "\U0001f575\U0001f3ff",
+	"man_dancing_light_skin_tone":                                              "\U0001f57a\U0001f3fb",
+	"man_dancing_medium_light_skin_tone":                                       "\U0001f57a\U0001f3fc",
+	"man_dancing_medium_skin_tone":                                             "\U0001f57a\U0001f3fd",
+	"man_dancing_medium_dark_skin_tone":                                        "\U0001f57a\U0001f3fe",
+	"man_dancing_dark_skin_tone":                                               "\U0001f57a\U0001f3ff",
+	"raised_hand_with_fingers_splayed_light_skin_tone":                         "\U0001f590\U0001f3fb",
+	"raised_hand_with_fingers_splayed_medium_light_skin_tone":                  "\U0001f590\U0001f3fc",
+	"raised_hand_with_fingers_splayed_medium_skin_tone":                        "\U0001f590\U0001f3fd",
+	"raised_hand_with_fingers_splayed_medium_dark_skin_tone":                   "\U0001f590\U0001f3fe",
+	"raised_hand_with_fingers_splayed_dark_skin_tone":                          "\U0001f590\U0001f3ff",
+	"middle_finger_light_skin_tone":                                            "\U0001f595\U0001f3fb",
+	"reversed_hand_with_middle_finger_extended_light_skin_tone":                "\U0001f595\U0001f3fb",
+	"middle_finger_medium_light_skin_tone":                                     "\U0001f595\U0001f3fc",
+	"reversed_hand_with_middle_finger_extended_medium_light_skin_tone":         "\U0001f595\U0001f3fc",
+	"middle_finger_medium_skin_tone":                                           "\U0001f595\U0001f3fd",
+	"reversed_hand_with_middle_finger_extended_medium_skin_tone":               "\U0001f595\U0001f3fd",
+	"middle_finger_medium_dark_skin_tone":                                      "\U0001f595\U0001f3fe",
+	"reversed_hand_with_middle_finger_extended_medium_dark_skin_tone":          "\U0001f595\U0001f3fe",
+	"middle_finger_dark_skin_tone":                                             "\U0001f595\U0001f3ff",
+	"reversed_hand_with_middle_finger_extended_dark_skin_tone":                 "\U0001f595\U0001f3ff",
+	"spock-hand_light_skin_tone":                                               "\U0001f596\U0001f3fb",
+	"vulcan_salute_light_skin_tone":                                            "\U0001f596\U0001f3fb",
+	"spock-hand_medium_light_skin_tone":                                        "\U0001f596\U0001f3fc",
+	"vulcan_salute_medium_light_skin_tone":                                     "\U0001f596\U0001f3fc",
+	"spock-hand_medium_skin_tone":                                              "\U0001f596\U0001f3fd",
+	"vulcan_salute_medium_skin_tone":                                           "\U0001f596\U0001f3fd",
+	"spock-hand_medium_dark_skin_tone":                                         "\U0001f596\U0001f3fe",
+	"vulcan_salute_medium_dark_skin_tone":                                      "\U0001f596\U0001f3fe",
+	"spock-hand_dark_skin_tone":                                                "\U0001f596\U0001f3ff",
+	"vulcan_salute_dark_skin_tone":                                             "\U0001f596\U0001f3ff",
+	"woman-gesturing-no_light_skin_tone":                                       "\U0001f645\U0001f3fb\u200d\u2640\ufe0f",
+	"no_good_woman_light_skin_tone":                                            "\U0001f645\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-gesturing-no_medium_light_skin_tone":                                "\U0001f645\U0001f3fc\u200d\u2640\ufe0f",
+	"no_good_woman_medium_light_skin_tone":                                     "\U0001f645\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-gesturing-no_medium_skin_tone":                                      "\U0001f645\U0001f3fd\u200d\u2640\ufe0f",
+	"no_good_woman_medium_skin_tone":                                           "\U0001f645\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-gesturing-no_medium_dark_skin_tone":                                 "\U0001f645\U0001f3fe\u200d\u2640\ufe0f",
+	"no_good_woman_medium_dark_skin_tone":                                      "\U0001f645\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-gesturing-no_dark_skin_tone":                                        "\U0001f645\U0001f3ff\u200d\u2640\ufe0f",
+	"no_good_woman_dark_skin_tone":                                             "\U0001f645\U0001f3ff\u200d\u2640\ufe0f",
+	"man-gesturing-no_light_skin_tone":                                         "\U0001f645\U0001f3fb\u200d\u2642\ufe0f",
+	"no_good_man_light_skin_tone":                                              "\U0001f645\U0001f3fb\u200d\u2642\ufe0f",
+	"man-gesturing-no_medium_light_skin_tone":                                  "\U0001f645\U0001f3fc\u200d\u2642\ufe0f",
+	"no_good_man_medium_light_skin_tone":                                       "\U0001f645\U0001f3fc\u200d\u2642\ufe0f",
+	"man-gesturing-no_medium_skin_tone":                                        "\U0001f645\U0001f3fd\u200d\u2642\ufe0f",
+	"no_good_man_medium_skin_tone":                                             "\U0001f645\U0001f3fd\u200d\u2642\ufe0f",
+	"man-gesturing-no_medium_dark_skin_tone":                                   "\U0001f645\U0001f3fe\u200d\u2642\ufe0f",
+	"no_good_man_medium_dark_skin_tone":                                        "\U0001f645\U0001f3fe\u200d\u2642\ufe0f",
+	"man-gesturing-no_dark_skin_tone":                                          "\U0001f645\U0001f3ff\u200d\u2642\ufe0f",
+	"no_good_man_dark_skin_tone":                                               "\U0001f645\U0001f3ff\u200d\u2642\ufe0f",
+	"no_good_light_skin_tone":                                                  "\U0001f645\U0001f3fb",
+	"no_good_medium_light_skin_tone":                                           "\U0001f645\U0001f3fc",
+	"no_good_medium_skin_tone":                                                 "\U0001f645\U0001f3fd",
+	"no_good_medium_dark_skin_tone":                                            "\U0001f645\U0001f3fe",
+	"no_good_dark_skin_tone":                                                   "\U0001f645\U0001f3ff",
+	"woman-gesturing-ok_light_skin_tone":                                       "\U0001f646\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-gesturing-ok_medium_light_skin_tone":                                "\U0001f646\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-gesturing-ok_medium_skin_tone":                                      "\U0001f646\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-gesturing-ok_medium_dark_skin_tone":                                 "\U0001f646\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-gesturing-ok_dark_skin_tone":                                        "\U0001f646\U0001f3ff\u200d\u2640\ufe0f",
+	"man-gesturing-ok_light_skin_tone":                                         "\U0001f646\U0001f3fb\u200d\u2642\ufe0f",
+	"ok_man_light_skin_tone":                                                   "\U0001f646\U0001f3fb\u200d\u2642\ufe0f",
+	"man-gesturing-ok_medium_light_skin_tone":                                  "\U0001f646\U0001f3fc\u200d\u2642\ufe0f",
+	"ok_man_medium_light_skin_tone":                                            "\U0001f646\U0001f3fc\u200d\u2642\ufe0f",
+	"man-gesturing-ok_medium_skin_tone":                                        "\U0001f646\U0001f3fd\u200d\u2642\ufe0f",
+	"ok_man_medium_skin_tone":                                                  "\U0001f646\U0001f3fd\u200d\u2642\ufe0f",
+	"man-gesturing-ok_medium_dark_skin_tone":                                   "\U0001f646\U0001f3fe\u200d\u2642\ufe0f",
+	"ok_man_medium_dark_skin_tone":                                             "\U0001f646\U0001f3fe\u200d\u2642\ufe0f",
+	"man-gesturing-ok_dark_skin_tone":                                          "\U0001f646\U0001f3ff\u200d\u2642\ufe0f",
+	"ok_man_dark_skin_tone":                                                    "\U0001f646\U0001f3ff\u200d\u2642\ufe0f",
+	"ok_woman_light_skin_tone":                                                 "\U0001f646\U0001f3fb",
+	"ok_woman_medium_light_skin_tone":                                          "\U0001f646\U0001f3fc",
+	"ok_woman_medium_skin_tone":                                                "\U0001f646\U0001f3fd",
+	"ok_woman_medium_dark_skin_tone":                                           "\U0001f646\U0001f3fe",
+	"ok_woman_dark_skin_tone":                                                  "\U0001f646\U0001f3ff",
+	"woman-bowing_light_skin_tone":                                             "\U0001f647\U0001f3fb\u200d\u2640\ufe0f",
+	"bowing_woman_light_skin_tone":                                             "\U0001f647\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-bowing_medium_light_skin_tone":                                      "\U0001f647\U0001f3fc\u200d\u2640\ufe0f",
+	"bowing_woman_medium_light_skin_tone":                                      "\U0001f647\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-bowing_medium_skin_tone":                                            "\U0001f647\U0001f3fd\u200d\u2640\ufe0f",
+	"bowing_woman_medium_skin_tone":                                            "\U0001f647\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-bowing_medium_dark_skin_tone":                                       "\U0001f647\U0001f3fe\u200d\u2640\ufe0f",
+	"bowing_woman_medium_dark_skin_tone":                                       "\U0001f647\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-bowing_dark_skin_tone":                                              "\U0001f647\U0001f3ff\u200d\u2640\ufe0f",
+	"bowing_woman_dark_skin_tone":                                              "\U0001f647\U0001f3ff\u200d\u2640\ufe0f",
+	"man-bowing_light_skin_tone":                                               "\U0001f647\U0001f3fb\u200d\u2642\ufe0f",
+	"bowing_man_light_skin_tone":                                               "\U0001f647\U0001f3fb\u200d\u2642\ufe0f",
+	"man-bowing_medium_light_skin_tone":                                        "\U0001f647\U0001f3fc\u200d\u2642\ufe0f",
+	"bowing_man_medium_light_skin_tone":                                        "\U0001f647\U0001f3fc\u200d\u2642\ufe0f",
+	"man-bowing_medium_skin_tone":                                              "\U0001f647\U0001f3fd\u200d\u2642\ufe0f",
+	"bowing_man_medium_skin_tone":                                              "\U0001f647\U0001f3fd\u200d\u2642\ufe0f",
+	"man-bowing_medium_dark_skin_tone":                                         "\U0001f647\U0001f3fe\u200d\u2642\ufe0f",
+	"bowing_man_medium_dark_skin_tone":                                         "\U0001f647\U0001f3fe\u200d\u2642\ufe0f",
+	"man-bowing_dark_skin_tone":                                                "\U0001f647\U0001f3ff\u200d\u2642\ufe0f",
+	"bowing_man_dark_skin_tone":                                                "\U0001f647\U0001f3ff\u200d\u2642\ufe0f",
+	"bow_light_skin_tone":                                                      "\U0001f647\U0001f3fb",
+	"bow_medium_light_skin_tone":                                               "\U0001f647\U0001f3fc",
+	"bow_medium_skin_tone":                                                     "\U0001f647\U0001f3fd",
+	"bow_medium_dark_skin_tone":                                                "\U0001f647\U0001f3fe",
+	"bow_dark_skin_tone":                                                       "\U0001f647\U0001f3ff",
+	"woman-raising-hand_light_skin_tone":                                       "\U0001f64b\U0001f3fb\u200d\u2640\ufe0f",
+	"raising_hand_woman_light_skin_tone":                                       "\U0001f64b\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-raising-hand_medium_light_skin_tone":                                "\U0001f64b\U0001f3fc\u200d\u2640\ufe0f",
+	"raising_hand_woman_medium_light_skin_tone":                                "\U0001f64b\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-raising-hand_medium_skin_tone":                                      "\U0001f64b\U0001f3fd\u200d\u2640\ufe0f",
+	"raising_hand_woman_medium_skin_tone":                                      "\U0001f64b\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-raising-hand_medium_dark_skin_tone":                                 "\U0001f64b\U0001f3fe\u200d\u2640\ufe0f",
+	"raising_hand_woman_medium_dark_skin_tone":                                 "\U0001f64b\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-raising-hand_dark_skin_tone":                                        "\U0001f64b\U0001f3ff\u200d\u2640\ufe0f",
+	"raising_hand_woman_dark_skin_tone":                                        "\U0001f64b\U0001f3ff\u200d\u2640\ufe0f",
+	"man-raising-hand_light_skin_tone":                                         "\U0001f64b\U0001f3fb\u200d\u2642\ufe0f",
+	"raising_hand_man_light_skin_tone":                                         "\U0001f64b\U0001f3fb\u200d\u2642\ufe0f",
+	"man-raising-hand_medium_light_skin_tone":                                  "\U0001f64b\U0001f3fc\u200d\u2642\ufe0f",
+	"raising_hand_man_medium_light_skin_tone":                                  "\U0001f64b\U0001f3fc\u200d\u2642\ufe0f",
+	"man-raising-hand_medium_skin_tone":                                        "\U0001f64b\U0001f3fd\u200d\u2642\ufe0f",
+	"raising_hand_man_medium_skin_tone":                                        "\U0001f64b\U0001f3fd\u200d\u2642\ufe0f",
+	"man-raising-hand_medium_dark_skin_tone":                                   "\U0001f64b\U0001f3fe\u200d\u2642\ufe0f",
+	"raising_hand_man_medium_dark_skin_tone":                                   "\U0001f64b\U0001f3fe\u200d\u2642\ufe0f",
+	"man-raising-hand_dark_skin_tone":                                          "\U0001f64b\U0001f3ff\u200d\u2642\ufe0f",
+	"raising_hand_man_dark_skin_tone":                                          "\U0001f64b\U0001f3ff\u200d\u2642\ufe0f",
+	"raising_hand_light_skin_tone":                                             "\U0001f64b\U0001f3fb",
+	"raising_hand_medium_light_skin_tone":                                      "\U0001f64b\U0001f3fc",
+	"raising_hand_medium_skin_tone":                                            "\U0001f64b\U0001f3fd",
+	"raising_hand_medium_dark_skin_tone":                                       "\U0001f64b\U0001f3fe",
+	"raising_hand_dark_skin_tone":                                              "\U0001f64b\U0001f3ff",
+	"raised_hands_light_skin_tone":                                             "\U0001f64c\U0001f3fb",
+	"raised_hands_medium_light_skin_tone":                                      "\U0001f64c\U0001f3fc",
+	"raised_hands_medium_skin_tone":                                            "\U0001f64c\U0001f3fd",
+	"raised_hands_medium_dark_skin_tone":                                       "\U0001f64c\U0001f3fe",
+	"raised_hands_dark_skin_tone":                                              "\U0001f64c\U0001f3ff",
+	"woman-frowning_light_skin_tone":                                           "\U0001f64d\U0001f3fb\u200d\u2640\ufe0f",
+	"frowning_woman_light_skin_tone":                                           "\U0001f64d\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-frowning_medium_light_skin_tone":                                    "\U0001f64d\U0001f3fc\u200d\u2640\ufe0f",
+	"frowning_woman_medium_light_skin_tone":                                    "\U0001f64d\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-frowning_medium_skin_tone":                                          "\U0001f64d\U0001f3fd\u200d\u2640\ufe0f",
+	"frowning_woman_medium_skin_tone":                                          "\U0001f64d\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-frowning_medium_dark_skin_tone":                                     "\U0001f64d\U0001f3fe\u200d\u2640\ufe0f",
+	"frowning_woman_medium_dark_skin_tone":                                     "\U0001f64d\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-frowning_dark_skin_tone":                                            "\U0001f64d\U0001f3ff\u200d\u2640\ufe0f",
+	"frowning_woman_dark_skin_tone":                                            "\U0001f64d\U0001f3ff\u200d\u2640\ufe0f",
+	"man-frowning_light_skin_tone":                                             "\U0001f64d\U0001f3fb\u200d\u2642\ufe0f",
+	"frowning_man_light_skin_tone":                                             "\U0001f64d\U0001f3fb\u200d\u2642\ufe0f",
+	"man-frowning_medium_light_skin_tone":                                      "\U0001f64d\U0001f3fc\u200d\u2642\ufe0f",
+	"frowning_man_medium_light_skin_tone":                                      "\U0001f64d\U0001f3fc\u200d\u2642\ufe0f",
+	"man-frowning_medium_skin_tone":                                            "\U0001f64d\U0001f3fd\u200d\u2642\ufe0f",
+	"frowning_man_medium_skin_tone":                                            "\U0001f64d\U0001f3fd\u200d\u2642\ufe0f",
+	"man-frowning_medium_dark_skin_tone":                                       "\U0001f64d\U0001f3fe\u200d\u2642\ufe0f",
+	"frowning_man_medium_dark_skin_tone":                                       "\U0001f64d\U0001f3fe\u200d\u2642\ufe0f",
+	"man-frowning_dark_skin_tone":                                              "\U0001f64d\U0001f3ff\u200d\u2642\ufe0f",
+	"frowning_man_dark_skin_tone":                                              "\U0001f64d\U0001f3ff\u200d\u2642\ufe0f",
+	"person_frowning_light_skin_tone":                                          "\U0001f64d\U0001f3fb",
+	"person_frowning_medium_light_skin_tone":                                   "\U0001f64d\U0001f3fc",
+	"person_frowning_medium_skin_tone":                                         "\U0001f64d\U0001f3fd",
+	"person_frowning_medium_dark_skin_tone":                                    "\U0001f64d\U0001f3fe",
+	"person_frowning_dark_skin_tone":                                           "\U0001f64d\U0001f3ff",
+	"woman-pouting_light_skin_tone":                                            "\U0001f64e\U0001f3fb\u200d\u2640\ufe0f",
+	"pouting_woman_light_skin_tone":                                            "\U0001f64e\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-pouting_medium_light_skin_tone":                                     "\U0001f64e\U0001f3fc\u200d\u2640\ufe0f",
+	"pouting_woman_medium_light_skin_tone":                                     "\U0001f64e\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-pouting_medium_skin_tone":                                           "\U0001f64e\U0001f3fd\u200d\u2640\ufe0f",
+	"pouting_woman_medium_skin_tone":                                           "\U0001f64e\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-pouting_medium_dark_skin_tone":                                      "\U0001f64e\U0001f3fe\u200d\u2640\ufe0f",
+	"pouting_woman_medium_dark_skin_tone":                                      "\U0001f64e\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-pouting_dark_skin_tone":                                             "\U0001f64e\U0001f3ff\u200d\u2640\ufe0f",
+	"pouting_woman_dark_skin_tone":                                             "\U0001f64e\U0001f3ff\u200d\u2640\ufe0f",
+	"man-pouting_light_skin_tone":                                              "\U0001f64e\U0001f3fb\u200d\u2642\ufe0f",
+	"pouting_man_light_skin_tone":                                              "\U0001f64e\U0001f3fb\u200d\u2642\ufe0f",
+	"man-pouting_medium_light_skin_tone":                                       "\U0001f64e\U0001f3fc\u200d\u2642\ufe0f",
+	"pouting_man_medium_light_skin_tone":                                       "\U0001f64e\U0001f3fc\u200d\u2642\ufe0f",
+	"man-pouting_medium_skin_tone":                                             "\U0001f64e\U0001f3fd\u200d\u2642\ufe0f",
+	"pouting_man_medium_skin_tone":                                             "\U0001f64e\U0001f3fd\u200d\u2642\ufe0f",
+	"man-pouting_medium_dark_skin_tone":                                        "\U0001f64e\U0001f3fe\u200d\u2642\ufe0f",
+	"pouting_man_medium_dark_skin_tone":                                        "\U0001f64e\U0001f3fe\u200d\u2642\ufe0f",
+	"man-pouting_dark_skin_tone":                                               "\U0001f64e\U0001f3ff\u200d\u2642\ufe0f",
+	"pouting_man_dark_skin_tone":                                               "\U0001f64e\U0001f3ff\u200d\u2642\ufe0f",
+	"person_with_pouting_face_light_skin_tone":                                 "\U0001f64e\U0001f3fb",
+	"person_with_pouting_face_medium_light_skin_tone":                          "\U0001f64e\U0001f3fc",
+	"person_with_pouting_face_medium_skin_tone":                                "\U0001f64e\U0001f3fd",
+	"person_with_pouting_face_medium_dark_skin_tone":                           "\U0001f64e\U0001f3fe",
+	"person_with_pouting_face_dark_skin_tone":                                  "\U0001f64e\U0001f3ff",
+	"pray_light_skin_tone":                                                     "\U0001f64f\U0001f3fb",
+	"pray_medium_light_skin_tone":                                              "\U0001f64f\U0001f3fc",
+	"pray_medium_skin_tone":                                                    "\U0001f64f\U0001f3fd",
+	"pray_medium_dark_skin_tone":                                               "\U0001f64f\U0001f3fe",
+	"pray_dark_skin_tone":                                                      "\U0001f64f\U0001f3ff",
+	"woman-rowing-boat_light_skin_tone":                                        "\U0001f6a3\U0001f3fb\u200d\u2640\ufe0f",
+	"rowing_woman_light_skin_tone":                                             "\U0001f6a3\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-rowing-boat_medium_light_skin_tone":                                 "\U0001f6a3\U0001f3fc\u200d\u2640\ufe0f",
+	"rowing_woman_medium_light_skin_tone":                                      "\U0001f6a3\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-rowing-boat_medium_skin_tone":                                       "\U0001f6a3\U0001f3fd\u200d\u2640\ufe0f",
+	"rowing_woman_medium_skin_tone":                                            "\U0001f6a3\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-rowing-boat_medium_dark_skin_tone":                                  "\U0001f6a3\U0001f3fe\u200d\u2640\ufe0f",
+	"rowing_woman_medium_dark_skin_tone":                                       "\U0001f6a3\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-rowing-boat_dark_skin_tone":                                         "\U0001f6a3\U0001f3ff\u200d\u2640\ufe0f",
+	"rowing_woman_dark_skin_tone":                                              "\U0001f6a3\U0001f3ff\u200d\u2640\ufe0f",
+	"man-rowing-boat_light_skin_tone":                                          "\U0001f6a3\U0001f3fb\u200d\u2642\ufe0f",
+	"rowing_man_light_skin_tone":                                               "\U0001f6a3\U0001f3fb\u200d\u2642\ufe0f",
+	"man-rowing-boat_medium_light_skin_tone":                                   "\U0001f6a3\U0001f3fc\u200d\u2642\ufe0f",
+	"rowing_man_medium_light_skin_tone":                                        "\U0001f6a3\U0001f3fc\u200d\u2642\ufe0f",
+	"man-rowing-boat_medium_skin_tone":                                         "\U0001f6a3\U0001f3fd\u200d\u2642\ufe0f",
+	"rowing_man_medium_skin_tone":                                              "\U0001f6a3\U0001f3fd\u200d\u2642\ufe0f",
+	"man-rowing-boat_medium_dark_skin_tone":                                    "\U0001f6a3\U0001f3fe\u200d\u2642\ufe0f",
+	"rowing_man_medium_dark_skin_tone":                                         "\U0001f6a3\U0001f3fe\u200d\u2642\ufe0f",
+	"man-rowing-boat_dark_skin_tone":                                           "\U0001f6a3\U0001f3ff\u200d\u2642\ufe0f",
+	"rowing_man_dark_skin_tone":                                                "\U0001f6a3\U0001f3ff\u200d\u2642\ufe0f",
+	"rowboat_light_skin_tone":                                                  "\U0001f6a3\U0001f3fb",
+	"rowboat_medium_light_skin_tone":                                           "\U0001f6a3\U0001f3fc",
+	"rowboat_medium_skin_tone":                                                 "\U0001f6a3\U0001f3fd",
+	"rowboat_medium_dark_skin_tone":                                            "\U0001f6a3\U0001f3fe",
+	"rowboat_dark_skin_tone":                                                   "\U0001f6a3\U0001f3ff",
+	"woman-biking_light_skin_tone":                                             "\U0001f6b4\U0001f3fb\u200d\u2640\ufe0f",
+	"biking_woman_light_skin_tone":                                             "\U0001f6b4\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-biking_medium_light_skin_tone":                                      "\U0001f6b4\U0001f3fc\u200d\u2640\ufe0f",
+	"biking_woman_medium_light_skin_tone":                                      "\U0001f6b4\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-biking_medium_skin_tone":                                            "\U0001f6b4\U0001f3fd\u200d\u2640\ufe0f",
+	"biking_woman_medium_skin_tone":                                            "\U0001f6b4\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-biking_medium_dark_skin_tone":                                       "\U0001f6b4\U0001f3fe\u200d\u2640\ufe0f",
+	"biking_woman_medium_dark_skin_tone":                                       "\U0001f6b4\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-biking_dark_skin_tone":                                              "\U0001f6b4\U0001f3ff\u200d\u2640\ufe0f",
+	"biking_woman_dark_skin_tone":                                              "\U0001f6b4\U0001f3ff\u200d\u2640\ufe0f",
+	"man-biking_light_skin_tone":                                               "\U0001f6b4\U0001f3fb\u200d\u2642\ufe0f",
+	"biking_man_light_skin_tone":                                               "\U0001f6b4\U0001f3fb\u200d\u2642\ufe0f",
+	"man-biking_medium_light_skin_tone":                                        "\U0001f6b4\U0001f3fc\u200d\u2642\ufe0f",
+	"biking_man_medium_light_skin_tone":                                        "\U0001f6b4\U0001f3fc\u200d\u2642\ufe0f",
+	"man-biking_medium_skin_tone":                                              "\U0001f6b4\U0001f3fd\u200d\u2642\ufe0f",
+	"biking_man_medium_skin_tone":                                              "\U0001f6b4\U0001f3fd\u200d\u2642\ufe0f",
+	"man-biking_medium_dark_skin_tone":                                         "\U0001f6b4\U0001f3fe\u200d\u2642\ufe0f",
+	"biking_man_medium_dark_skin_tone":                                         "\U0001f6b4\U0001f3fe\u200d\u2642\ufe0f",
+	"man-biking_dark_skin_tone":                                                "\U0001f6b4\U0001f3ff\u200d\u2642\ufe0f",
+	"biking_man_dark_skin_tone":                                                "\U0001f6b4\U0001f3ff\u200d\u2642\ufe0f",
+	"bicyclist_light_skin_tone":                                                "\U0001f6b4\U0001f3fb",
+	"bicyclist_medium_light_skin_tone":                                         "\U0001f6b4\U0001f3fc",
+	"bicyclist_medium_skin_tone":                                               "\U0001f6b4\U0001f3fd",
+	"bicyclist_medium_dark_skin_tone":                                          "\U0001f6b4\U0001f3fe",
+	"bicyclist_dark_skin_tone":                                                 "\U0001f6b4\U0001f3ff",
+	"woman-mountain-biking_light_skin_tone":                                    "\U0001f6b5\U0001f3fb\u200d\u2640\ufe0f",
+	"mountain_biking_woman_light_skin_tone":                                    "\U0001f6b5\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-mountain-biking_medium_light_skin_tone":                             "\U0001f6b5\U0001f3fc\u200d\u2640\ufe0f",
+	"mountain_biking_woman_medium_light_skin_tone":                             "\U0001f6b5\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-mountain-biking_medium_skin_tone":                                   "\U0001f6b5\U0001f3fd\u200d\u2640\ufe0f",
+	"mountain_biking_woman_medium_skin_tone":                                   "\U0001f6b5\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-mountain-biking_medium_dark_skin_tone":                              "\U0001f6b5\U0001f3fe\u200d\u2640\ufe0f",
+	"mountain_biking_woman_medium_dark_skin_tone":                              "\U0001f6b5\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-mountain-biking_dark_skin_tone":                                     "\U0001f6b5\U0001f3ff\u200d\u2640\ufe0f",
+	"mountain_biking_woman_dark_skin_tone":                                     "\U0001f6b5\U0001f3ff\u200d\u2640\ufe0f",
+	"man-mountain-biking_light_skin_tone":                                      "\U0001f6b5\U0001f3fb\u200d\u2642\ufe0f",
+	"mountain_biking_man_light_skin_tone":                                      "\U0001f6b5\U0001f3fb\u200d\u2642\ufe0f",
+	"man-mountain-biking_medium_light_skin_tone":                               "\U0001f6b5\U0001f3fc\u200d\u2642\ufe0f",
+	"mountain_biking_man_medium_light_skin_tone":                               "\U0001f6b5\U0001f3fc\u200d\u2642\ufe0f",
+	"man-mountain-biking_medium_skin_tone":                                     "\U0001f6b5\U0001f3fd\u200d\u2642\ufe0f",
+	"mountain_biking_man_medium_skin_tone":                                     "\U0001f6b5\U0001f3fd\u200d\u2642\ufe0f",
+	"man-mountain-biking_medium_dark_skin_tone":                                "\U0001f6b5\U0001f3fe\u200d\u2642\ufe0f",
+	"mountain_biking_man_medium_dark_skin_tone":                                "\U0001f6b5\U0001f3fe\u200d\u2642\ufe0f",
+	"man-mountain-biking_dark_skin_tone":                                       "\U0001f6b5\U0001f3ff\u200d\u2642\ufe0f",
+	"mountain_biking_man_dark_skin_tone":                                       "\U0001f6b5\U0001f3ff\u200d\u2642\ufe0f",
+	"mountain_bicyclist_light_skin_tone":                                       "\U0001f6b5\U0001f3fb",
+	"mountain_bicyclist_medium_light_skin_tone":                                "\U0001f6b5\U0001f3fc",
+	"mountain_bicyclist_medium_skin_tone":                                      "\U0001f6b5\U0001f3fd",
+	"mountain_bicyclist_medium_dark_skin_tone":                                 "\U0001f6b5\U0001f3fe",
+	"mountain_bicyclist_dark_skin_tone":                                        "\U0001f6b5\U0001f3ff",
+	"woman-walking_light_skin_tone":                                            "\U0001f6b6\U0001f3fb\u200d\u2640\ufe0f",
+	"walking_woman_light_skin_tone":                                            "\U0001f6b6\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-walking_medium_light_skin_tone":                                     "\U0001f6b6\U0001f3fc\u200d\u2640\ufe0f",
+	"walking_woman_medium_light_skin_tone":                                     "\U0001f6b6\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-walking_medium_skin_tone":                                           "\U0001f6b6\U0001f3fd\u200d\u2640\ufe0f",
+	"walking_woman_medium_skin_tone":                                           "\U0001f6b6\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-walking_medium_dark_skin_tone":                                      "\U0001f6b6\U0001f3fe\u200d\u2640\ufe0f",
+	"walking_woman_medium_dark_skin_tone":                                      "\U0001f6b6\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-walking_dark_skin_tone":                                             "\U0001f6b6\U0001f3ff\u200d\u2640\ufe0f",
+	"walking_woman_dark_skin_tone":                                             "\U0001f6b6\U0001f3ff\u200d\u2640\ufe0f",
+	"man-walking_light_skin_tone":                                              "\U0001f6b6\U0001f3fb\u200d\u2642\ufe0f",
+	"walking_man_light_skin_tone":                                              "\U0001f6b6\U0001f3fb\u200d\u2642\ufe0f",
+	"man-walking_medium_light_skin_tone":                                       "\U0001f6b6\U0001f3fc\u200d\u2642\ufe0f",
+	"walking_man_medium_light_skin_tone":                                       "\U0001f6b6\U0001f3fc\u200d\u2642\ufe0f",
+	"man-walking_medium_skin_tone":                                             "\U0001f6b6\U0001f3fd\u200d\u2642\ufe0f",
+	"walking_man_medium_skin_tone":                                             "\U0001f6b6\U0001f3fd\u200d\u2642\ufe0f",
+	"man-walking_medium_dark_skin_tone":                                        "\U0001f6b6\U0001f3fe\u200d\u2642\ufe0f",
+	"walking_man_medium_dark_skin_tone":                                        "\U0001f6b6\U0001f3fe\u200d\u2642\ufe0f",
+	"man-walking_dark_skin_tone":                                               "\U0001f6b6\U0001f3ff\u200d\u2642\ufe0f",
+	"walking_man_dark_skin_tone":                                               "\U0001f6b6\U0001f3ff\u200d\u2642\ufe0f",
+	"walking_light_skin_tone":                                                  "\U0001f6b6\U0001f3fb",
+	"walking_medium_light_skin_tone":                                           "\U0001f6b6\U0001f3fc",
+	"walking_medium_skin_tone":                                                 "\U0001f6b6\U0001f3fd",
+	"walking_medium_dark_skin_tone":                                            "\U0001f6b6\U0001f3fe",
+	"walking_dark_skin_tone":                                                   "\U0001f6b6\U0001f3ff",
+	"bath_light_skin_tone":                                                     "\U0001f6c0\U0001f3fb",
+	"bath_medium_light_skin_tone":                                              "\U0001f6c0\U0001f3fc",
+	"bath_medium_skin_tone":                                                    "\U0001f6c0\U0001f3fd",
+	"bath_medium_dark_skin_tone":                                               "\U0001f6c0\U0001f3fe",
+	"bath_dark_skin_tone":                                                      "\U0001f6c0\U0001f3ff",
+	"sleeping_accommodation_light_skin_tone":                                   "\U0001f6cc\U0001f3fb",
+	"sleeping_accommodation_medium_light_skin_tone":                            "\U0001f6cc\U0001f3fc",
+	"sleeping_accommodation_medium_skin_tone":                                  "\U0001f6cc\U0001f3fd",
+	"sleeping_accommodation_medium_dark_skin_tone":                             "\U0001f6cc\U0001f3fe",
+	"sleeping_accommodation_dark_skin_tone":                                    "\U0001f6cc\U0001f3ff",
+	"pinched_fingers_light_skin_tone":                                          "\U0001f90c\U0001f3fb",
+	"pinched_fingers_medium_light_skin_tone":                                   "\U0001f90c\U0001f3fc",
+	"pinched_fingers_medium_skin_tone":                                         "\U0001f90c\U0001f3fd",
+	"pinched_fingers_medium_dark_skin_tone":                                    "\U0001f90c\U0001f3fe",
+	"pinched_fingers_dark_skin_tone":                                           "\U0001f90c\U0001f3ff",
+	"pinching_hand_light_skin_tone":                                            "\U0001f90f\U0001f3fb",
+	"pinching_hand_medium_light_skin_tone":                                     "\U0001f90f\U0001f3fc",
+	"pinching_hand_medium_skin_tone":                                           "\U0001f90f\U0001f3fd",
+	"pinching_hand_medium_dark_skin_tone":                                      "\U0001f90f\U0001f3fe",
+	"pinching_hand_dark_skin_tone":                                             "\U0001f90f\U0001f3ff",
+	"the_horns_light_skin_tone":                                                "\U0001f918\U0001f3fb",
+	"sign_of_the_horns_light_skin_tone":                                        "\U0001f918\U0001f3fb",
+	"metal_light_skin_tone":                                                    "\U0001f918\U0001f3fb",
+	"the_horns_medium_light_skin_tone":                                         "\U0001f918\U0001f3fc",
+	"sign_of_the_horns_medium_light_skin_tone":                                 "\U0001f918\U0001f3fc",
+	"metal_medium_light_skin_tone":                                             "\U0001f918\U0001f3fc",
+	"the_horns_medium_skin_tone":                                               "\U0001f918\U0001f3fd",
+	"sign_of_the_horns_medium_skin_tone":                                       "\U0001f918\U0001f3fd",
+	"metal_medium_skin_tone":                                                   "\U0001f918\U0001f3fd",
+	"the_horns_medium_dark_skin_tone":                                          "\U0001f918\U0001f3fe",
+	"sign_of_the_horns_medium_dark_skin_tone":                                  "\U0001f918\U0001f3fe",
+	"metal_medium_dark_skin_tone":                                              "\U0001f918\U0001f3fe",
+	"the_horns_dark_skin_tone":                                                 "\U0001f918\U0001f3ff",
+	"sign_of_the_horns_dark_skin_tone":                                         "\U0001f918\U0001f3ff",
+	"metal_dark_skin_tone":                                                     "\U0001f918\U0001f3ff",
+	"call_me_hand_light_skin_tone":                                             "\U0001f919\U0001f3fb",
+	"call_me_hand_medium_light_skin_tone":                                      "\U0001f919\U0001f3fc",
+	"call_me_hand_medium_skin_tone":                                            "\U0001f919\U0001f3fd",
+	"call_me_hand_medium_dark_skin_tone":                                       "\U0001f919\U0001f3fe",
+	"call_me_hand_dark_skin_tone":                                              "\U0001f919\U0001f3ff",
+	"raised_back_of_hand_light_skin_tone":                                      "\U0001f91a\U0001f3fb",
+	"raised_back_of_hand_medium_light_skin_tone":                               "\U0001f91a\U0001f3fc",
+	"raised_back_of_hand_medium_skin_tone":                                     "\U0001f91a\U0001f3fd",
+	"raised_back_of_hand_medium_dark_skin_tone":                                "\U0001f91a\U0001f3fe",
+	"raised_back_of_hand_dark_skin_tone":                                       "\U0001f91a\U0001f3ff",
+	"left-facing_fist_light_skin_tone":                                         "\U0001f91b\U0001f3fb",
+	"fist_left_light_skin_tone":                                                "\U0001f91b\U0001f3fb",
+	"left-facing_fist_medium_light_skin_tone":                                  "\U0001f91b\U0001f3fc",
+	"fist_left_medium_light_skin_tone":                                         "\U0001f91b\U0001f3fc",
+	"left-facing_fist_medium_skin_tone":                                        "\U0001f91b\U0001f3fd",
+	"fist_left_medium_skin_tone":                                               "\U0001f91b\U0001f3fd",
+	"left-facing_fist_medium_dark_skin_tone":                                   "\U0001f91b\U0001f3fe",
+	"fist_left_medium_dark_skin_tone":                                          "\U0001f91b\U0001f3fe",
+	"left-facing_fist_dark_skin_tone":                                          "\U0001f91b\U0001f3ff",
+	"fist_left_dark_skin_tone":                                                 "\U0001f91b\U0001f3ff",
+	"right-facing_fist_light_skin_tone":                                        "\U0001f91c\U0001f3fb",
+	"fist_right_light_skin_tone":                                               "\U0001f91c\U0001f3fb",
+	"right-facing_fist_medium_light_skin_tone":                                 "\U0001f91c\U0001f3fc",
+	"fist_right_medium_light_skin_tone":                                        "\U0001f91c\U0001f3fc",
+	"right-facing_fist_medium_skin_tone":                                       "\U0001f91c\U0001f3fd",
+	"fist_right_medium_skin_tone":                                              "\U0001f91c\U0001f3fd",
+	"right-facing_fist_medium_dark_skin_tone":                                  "\U0001f91c\U0001f3fe",
+	"fist_right_medium_dark_skin_tone":                                         "\U0001f91c\U0001f3fe",
+	"right-facing_fist_dark_skin_tone":                                         "\U0001f91c\U0001f3ff",
+	"fist_right_dark_skin_tone":                                                "\U0001f91c\U0001f3ff",
+	"crossed_fingers_light_skin_tone":                                          "\U0001f91e\U0001f3fb",
+	"hand_with_index_and_middle_fingers_crossed_light_skin_tone":               "\U0001f91e\U0001f3fb",
+	"crossed_fingers_medium_light_skin_tone":                                   "\U0001f91e\U0001f3fc",
+	"hand_with_index_and_middle_fingers_crossed_medium_light_skin_tone":        "\U0001f91e\U0001f3fc",
+	"crossed_fingers_medium_skin_tone":                                         "\U0001f91e\U0001f3fd",
+	"hand_with_index_and_middle_fingers_crossed_medium_skin_tone":              "\U0001f91e\U0001f3fd",
+	"crossed_fingers_medium_dark_skin_tone":                                    "\U0001f91e\U0001f3fe",
+	"hand_with_index_and_middle_fingers_crossed_medium_dark_skin_tone":         "\U0001f91e\U0001f3fe",
+	"crossed_fingers_dark_skin_tone":                                           "\U0001f91e\U0001f3ff",
+	"hand_with_index_and_middle_fingers_crossed_dark_skin_tone":                "\U0001f91e\U0001f3ff",
+	"i_love_you_hand_sign_light_skin_tone":                                     "\U0001f91f\U0001f3fb",
+	"i_love_you_hand_sign_medium_light_skin_tone":                              "\U0001f91f\U0001f3fc",
+	"i_love_you_hand_sign_medium_skin_tone":                                    "\U0001f91f\U0001f3fd",
+	"i_love_you_hand_sign_medium_dark_skin_tone":                               "\U0001f91f\U0001f3fe",
+	"i_love_you_hand_sign_dark_skin_tone":                                      "\U0001f91f\U0001f3ff",
+	"woman-facepalming_light_skin_tone":                                        "\U0001f926\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_facepalming_light_skin_tone":                                        "\U0001f926\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-facepalming_medium_light_skin_tone":                                 "\U0001f926\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_facepalming_medium_light_skin_tone":                                 "\U0001f926\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-facepalming_medium_skin_tone":                                       "\U0001f926\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_facepalming_medium_skin_tone":                                       "\U0001f926\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-facepalming_medium_dark_skin_tone":                                  "\U0001f926\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_facepalming_medium_dark_skin_tone":                                  "\U0001f926\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-facepalming_dark_skin_tone":                                         "\U0001f926\U0001f3ff\u200d\u2640\ufe0f",
+	"woman_facepalming_dark_skin_tone":                                         "\U0001f926\U0001f3ff\u200d\u2640\ufe0f",
+	"man-facepalming_light_skin_tone":                                          "\U0001f926\U0001f3fb\u200d\u2642\ufe0f",
+	"man_facepalming_light_skin_tone":                                          "\U0001f926\U0001f3fb\u200d\u2642\ufe0f",
+	"man-facepalming_medium_light_skin_tone":                                   "\U0001f926\U0001f3fc\u200d\u2642\ufe0f",
+	"man_facepalming_medium_light_skin_tone":                                   "\U0001f926\U0001f3fc\u200d\u2642\ufe0f",
+	"man-facepalming_medium_skin_tone":                                         "\U0001f926\U0001f3fd\u200d\u2642\ufe0f",
+	"man_facepalming_medium_skin_tone":                                         "\U0001f926\U0001f3fd\u200d\u2642\ufe0f",
+	"man-facepalming_medium_dark_skin_tone":                                    "\U0001f926\U0001f3fe\u200d\u2642\ufe0f",
+	"man_facepalming_medium_dark_skin_tone":                                    "\U0001f926\U0001f3fe\u200d\u2642\ufe0f",
+	"man-facepalming_dark_skin_tone":                                           "\U0001f926\U0001f3ff\u200d\u2642\ufe0f",
+	"man_facepalming_dark_skin_tone":                                           "\U0001f926\U0001f3ff\u200d\u2642\ufe0f",
+	"face_palm_light_skin_tone":                                                "\U0001f926\U0001f3fb",
+	"face_palm_medium_light_skin_tone":                                         "\U0001f926\U0001f3fc",
+	"face_palm_medium_skin_tone":                                               "\U0001f926\U0001f3fd",
+	"face_palm_medium_dark_skin_tone":                                          "\U0001f926\U0001f3fe",
+	"face_palm_dark_skin_tone":                                                 "\U0001f926\U0001f3ff",
+	"pregnant_woman_light_skin_tone":                                           "\U0001f930\U0001f3fb",
+	"pregnant_woman_medium_light_skin_tone":                                    "\U0001f930\U0001f3fc",
+	"pregnant_woman_medium_skin_tone":                                          "\U0001f930\U0001f3fd",
+	"pregnant_woman_medium_dark_skin_tone":                                     "\U0001f930\U0001f3fe",
+	"pregnant_woman_dark_skin_tone":                                            "\U0001f930\U0001f3ff",
+	"breast-feeding_light_skin_tone":                                           "\U0001f931\U0001f3fb",
+	"breast-feeding_medium_light_skin_tone":                                    "\U0001f931\U0001f3fc",
+	"breast-feeding_medium_skin_tone":                                          "\U0001f931\U0001f3fd",
+	"breast-feeding_medium_dark_skin_tone":                                     "\U0001f931\U0001f3fe",
+	"breast-feeding_dark_skin_tone":                                            "\U0001f931\U0001f3ff",
+	"palms_up_together_light_skin_tone":                                        "\U0001f932\U0001f3fb",
+	"palms_up_together_medium_light_skin_tone":                                 "\U0001f932\U0001f3fc",
+	"palms_up_together_medium_skin_tone":                                       "\U0001f932\U0001f3fd",
+	"palms_up_together_medium_dark_skin_tone":                                  "\U0001f932\U0001f3fe",
+	"palms_up_together_dark_skin_tone":                                         "\U0001f932\U0001f3ff",
+	"selfie_light_skin_tone":                                                   "\U0001f933\U0001f3fb",
+	"selfie_medium_light_skin_tone":                                            "\U0001f933\U0001f3fc",
+	"selfie_medium_skin_tone":                                                  "\U0001f933\U0001f3fd",
+	"selfie_medium_dark_skin_tone":                                             "\U0001f933\U0001f3fe",
+	"selfie_dark_skin_tone":                                                    "\U0001f933\U0001f3ff",
+	"prince_light_skin_tone":                                                   "\U0001f934\U0001f3fb",
+	"prince_medium_light_skin_tone":                                            "\U0001f934\U0001f3fc",
+	"prince_medium_skin_tone":                                                  "\U0001f934\U0001f3fd",
+	"prince_medium_dark_skin_tone":                                             "\U0001f934\U0001f3fe",
+	"prince_dark_skin_tone":                                                    "\U0001f934\U0001f3ff",
+	"woman_in_tuxedo_light_skin_tone":                                          "\U0001f935\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_in_tuxedo_medium_light_skin_tone":                                   "\U0001f935\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_in_tuxedo_medium_skin_tone":                                         "\U0001f935\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_in_tuxedo_medium_dark_skin_tone":                                    "\U0001f935\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_in_tuxedo_dark_skin_tone":                                           "\U0001f935\U0001f3ff\u200d\u2640\ufe0f",
+	"man_in_tuxedo_light_skin_tone":                                            "\U0001f935\U0001f3fb\u200d\u2642\ufe0f",
+	"man_in_tuxedo_medium_light_skin_tone":                                     "\U0001f935\U0001f3fc\u200d\u2642\ufe0f",
+	"man_in_tuxedo_medium_skin_tone":                                           "\U0001f935\U0001f3fd\u200d\u2642\ufe0f",
+	"man_in_tuxedo_medium_dark_skin_tone":                                      "\U0001f935\U0001f3fe\u200d\u2642\ufe0f",
+	"man_in_tuxedo_dark_skin_tone":                                             "\U0001f935\U0001f3ff\u200d\u2642\ufe0f",
+	"person_in_tuxedo_light_skin_tone":                                         "\U0001f935\U0001f3fb",
+	"person_in_tuxedo_medium_light_skin_tone":                                  "\U0001f935\U0001f3fc",
+	"person_in_tuxedo_medium_skin_tone":                                        "\U0001f935\U0001f3fd",
+	"person_in_tuxedo_medium_dark_skin_tone":                                   "\U0001f935\U0001f3fe",
+	"person_in_tuxedo_dark_skin_tone":                                          "\U0001f935\U0001f3ff",
+	"mrs_claus_light_skin_tone":                                                "\U0001f936\U0001f3fb",
+	"mother_christmas_light_skin_tone":                                         "\U0001f936\U0001f3fb",
+	"mrs_claus_medium_light_skin_tone":                                         "\U0001f936\U0001f3fc",
+	"mother_christmas_medium_light_skin_tone":                                  "\U0001f936\U0001f3fc",
+	"mrs_claus_medium_skin_tone":                                               "\U0001f936\U0001f3fd",
+	"mother_christmas_medium_skin_tone":                                        "\U0001f936\U0001f3fd",
+	"mrs_claus_medium_dark_skin_tone":                                          "\U0001f936\U0001f3fe",
+	"mother_christmas_medium_dark_skin_tone":                                   "\U0001f936\U0001f3fe",
+	"mrs_claus_dark_skin_tone":                                                 "\U0001f936\U0001f3ff",
+	"mother_christmas_dark_skin_tone":                                          "\U0001f936\U0001f3ff",
+	"woman-shrugging_light_skin_tone":                                          "\U0001f937\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_shrugging_light_skin_tone":                                          "\U0001f937\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-shrugging_medium_light_skin_tone":                                   "\U0001f937\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_shrugging_medium_light_skin_tone":                                   "\U0001f937\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-shrugging_medium_skin_tone":                                         "\U0001f937\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_shrugging_medium_skin_tone":                                         "\U0001f937\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-shrugging_medium_dark_skin_tone":                                    "\U0001f937\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_shrugging_medium_dark_skin_tone":                                    "\U0001f937\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-shrugging_dark_skin_tone":                                           "\U0001f937\U0001f3ff\u200d\u2640\ufe0f",
+	"woman_shrugging_dark_skin_tone":                                           "\U0001f937\U0001f3ff\u200d\u2640\ufe0f",
+	"man-shrugging_light_skin_tone":                                            "\U0001f937\U0001f3fb\u200d\u2642\ufe0f",
+	"man_shrugging_light_skin_tone":                                            "\U0001f937\U0001f3fb\u200d\u2642\ufe0f",
+	"man-shrugging_medium_light_skin_tone":                                     "\U0001f937\U0001f3fc\u200d\u2642\ufe0f",
+	"man_shrugging_medium_light_skin_tone":                                     "\U0001f937\U0001f3fc\u200d\u2642\ufe0f",
+	"man-shrugging_medium_skin_tone":                                           "\U0001f937\U0001f3fd\u200d\u2642\ufe0f",
+	"man_shrugging_medium_skin_tone":                                           "\U0001f937\U0001f3fd\u200d\u2642\ufe0f",
+	"man-shrugging_medium_dark_skin_tone":                                      "\U0001f937\U0001f3fe\u200d\u2642\ufe0f",
+	"man_shrugging_medium_dark_skin_tone":                                      "\U0001f937\U0001f3fe\u200d\u2642\ufe0f",
+	"man-shrugging_dark_skin_tone":                                             "\U0001f937\U0001f3ff\u200d\u2642\ufe0f",
+	"man_shrugging_dark_skin_tone":                                             "\U0001f937\U0001f3ff\u200d\u2642\ufe0f",
+	"shrug_light_skin_tone":                                                    "\U0001f937\U0001f3fb",
+	"shrug_medium_light_skin_tone":                                             "\U0001f937\U0001f3fc",
+	"shrug_medium_skin_tone":                                                   "\U0001f937\U0001f3fd",
+	"shrug_medium_dark_skin_tone":                                              "\U0001f937\U0001f3fe",
+	"shrug_dark_skin_tone":                                                     "\U0001f937\U0001f3ff",
+	"woman-cartwheeling_light_skin_tone":                                       "\U0001f938\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_cartwheeling_light_skin_tone":                                       "\U0001f938\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-cartwheeling_medium_light_skin_tone":                                "\U0001f938\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_cartwheeling_medium_light_skin_tone":                                "\U0001f938\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-cartwheeling_medium_skin_tone":                                      "\U0001f938\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_cartwheeling_medium_skin_tone":                                      "\U0001f938\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-cartwheeling_medium_dark_skin_tone":                                 "\U0001f938\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_cartwheeling_medium_dark_skin_tone":                                 "\U0001f938\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-cartwheeling_dark_skin_tone":                                        "\U0001f938\U0001f3ff\u200d\u2640\ufe0f",
+	"woman_cartwheeling_dark_skin_tone":                                        "\U0001f938\U0001f3ff\u200d\u2640\ufe0f",
+	"man-cartwheeling_light_skin_tone":                                         "\U0001f938\U0001f3fb\u200d\u2642\ufe0f",
+	"man_cartwheeling_light_skin_tone":                                         "\U0001f938\U0001f3fb\u200d\u2642\ufe0f",
+	"man-cartwheeling_medium_light_skin_tone":                                  "\U0001f938\U0001f3fc\u200d\u2642\ufe0f",
+	"man_cartwheeling_medium_light_skin_tone":                                  "\U0001f938\U0001f3fc\u200d\u2642\ufe0f",
+	"man-cartwheeling_medium_skin_tone":                                        "\U0001f938\U0001f3fd\u200d\u2642\ufe0f",
+	"man_cartwheeling_medium_skin_tone":                                        "\U0001f938\U0001f3fd\u200d\u2642\ufe0f",
+	"man-cartwheeling_medium_dark_skin_tone":                                   "\U0001f938\U0001f3fe\u200d\u2642\ufe0f",
+	"man_cartwheeling_medium_dark_skin_tone":                                   "\U0001f938\U0001f3fe\u200d\u2642\ufe0f",
+	"man-cartwheeling_dark_skin_tone":                                          "\U0001f938\U0001f3ff\u200d\u2642\ufe0f",
+	"man_cartwheeling_dark_skin_tone":                                          "\U0001f938\U0001f3ff\u200d\u2642\ufe0f",
+	"person_doing_cartwheel_light_skin_tone":                                   "\U0001f938\U0001f3fb",
+	"person_doing_cartwheel_medium_light_skin_tone":                            "\U0001f938\U0001f3fc",
+	"person_doing_cartwheel_medium_skin_tone":                                  "\U0001f938\U0001f3fd",
+	"person_doing_cartwheel_medium_dark_skin_tone":                             "\U0001f938\U0001f3fe",
+	"person_doing_cartwheel_dark_skin_tone":                                    "\U0001f938\U0001f3ff",
+	"woman-juggling_light_skin_tone":                                           "\U0001f939\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_juggling_light_skin_tone":                                           "\U0001f939\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-juggling_medium_light_skin_tone":                                    "\U0001f939\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_juggling_medium_light_skin_tone":                                    "\U0001f939\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-juggling_medium_skin_tone":                                          "\U0001f939\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_juggling_medium_skin_tone":                                          "\U0001f939\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-juggling_medium_dark_skin_tone":                                     "\U0001f939\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_juggling_medium_dark_skin_tone":                                     "\U0001f939\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-juggling_dark_skin_tone":                                            "\U0001f939\U0001f3ff\u200d\u2640\ufe0f",
+	"woman_juggling_dark_skin_tone":                                            "\U0001f939\U0001f3ff\u200d\u2640\ufe0f",
+	"man-juggling_light_skin_tone":                                             "\U0001f939\U0001f3fb\u200d\u2642\ufe0f",
+	"man_juggling_light_skin_tone":                                             "\U0001f939\U0001f3fb\u200d\u2642\ufe0f",
+	"man-juggling_medium_light_skin_tone":                                      "\U0001f939\U0001f3fc\u200d\u2642\ufe0f",
+	"man_juggling_medium_light_skin_tone":                                      "\U0001f939\U0001f3fc\u200d\u2642\ufe0f",
+	"man-juggling_medium_skin_tone":                                            "\U0001f939\U0001f3fd\u200d\u2642\ufe0f",
+	"man_juggling_medium_skin_tone":                                            "\U0001f939\U0001f3fd\u200d\u2642\ufe0f",
+	"man-juggling_medium_dark_skin_tone":                                       "\U0001f939\U0001f3fe\u200d\u2642\ufe0f",
+	"man_juggling_medium_dark_skin_tone":                                       "\U0001f939\U0001f3fe\u200d\u2642\ufe0f",
+	"man-juggling_dark_skin_tone":                                              "\U0001f939\U0001f3ff\u200d\u2642\ufe0f",
+	"man_juggling_dark_skin_tone":                                              "\U0001f939\U0001f3ff\u200d\u2642\ufe0f",
+	"juggling_light_skin_tone":                                                 "\U0001f939\U0001f3fb",
+	"juggling_medium_light_skin_tone":                                          "\U0001f939\U0001f3fc",
+	"juggling_medium_skin_tone":                                                "\U0001f939\U0001f3fd",
+	"juggling_medium_dark_skin_tone":                                           "\U0001f939\U0001f3fe",
+	"juggling_dark_skin_tone":                                                  "\U0001f939\U0001f3ff",
+	"woman-playing-water-polo_light_skin_tone":                                 "\U0001f93d\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_playing_water_polo_light_skin_tone":                                 "\U0001f93d\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-playing-water-polo_medium_light_skin_tone":                          "\U0001f93d\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_playing_water_polo_medium_light_skin_tone":                          "\U0001f93d\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-playing-water-polo_medium_skin_tone":                                "\U0001f93d\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_playing_water_polo_medium_skin_tone":                                "\U0001f93d\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-playing-water-polo_medium_dark_skin_tone":                           "\U0001f93d\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_playing_water_polo_medium_dark_skin_tone":                           "\U0001f93d\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-playing-water-polo_dark_skin_tone":                                  "\U0001f93d\U0001f3ff\u200d\u2640\ufe0f",
+	"woman_playing_water_polo_dark_skin_tone":                                  "\U0001f93d\U0001f3ff\u200d\u2640\ufe0f",
+	"man-playing-water-polo_light_skin_tone":                                   "\U0001f93d\U0001f3fb\u200d\u2642\ufe0f",
+	"man_playing_water_polo_light_skin_tone":                                   "\U0001f93d\U0001f3fb\u200d\u2642\ufe0f",
+	"man-playing-water-polo_medium_light_skin_tone":                            "\U0001f93d\U0001f3fc\u200d\u2642\ufe0f",
+	"man_playing_water_polo_medium_light_skin_tone":                            "\U0001f93d\U0001f3fc\u200d\u2642\ufe0f",
+	"man-playing-water-polo_medium_skin_tone":                                  "\U0001f93d\U0001f3fd\u200d\u2642\ufe0f",
+	"man_playing_water_polo_medium_skin_tone":                                  "\U0001f93d\U0001f3fd\u200d\u2642\ufe0f",
+	"man-playing-water-polo_medium_dark_skin_tone":                             "\U0001f93d\U0001f3fe\u200d\u2642\ufe0f",
+	"man_playing_water_polo_medium_dark_skin_tone":                             "\U0001f93d\U0001f3fe\u200d\u2642\ufe0f",
+	"man-playing-water-polo_dark_skin_tone":                                    "\U0001f93d\U0001f3ff\u200d\u2642\ufe0f",
+	"man_playing_water_polo_dark_skin_tone":                                    "\U0001f93d\U0001f3ff\u200d\u2642\ufe0f",
+	"water_polo_light_skin_tone":                                               "\U0001f93d\U0001f3fb",
+	"water_polo_medium_light_skin_tone":                                        "\U0001f93d\U0001f3fc",
+	"water_polo_medium_skin_tone":                                              "\U0001f93d\U0001f3fd",
+	"water_polo_medium_dark_skin_tone":                                         "\U0001f93d\U0001f3fe",
+	"water_polo_dark_skin_tone":                                                "\U0001f93d\U0001f3ff",
+	"woman-playing-handball_light_skin_tone":                                   "\U0001f93e\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_playing_handball_light_skin_tone":                                   "\U0001f93e\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-playing-handball_medium_light_skin_tone":                            "\U0001f93e\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_playing_handball_medium_light_skin_tone":                            "\U0001f93e\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-playing-handball_medium_skin_tone":                                  "\U0001f93e\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_playing_handball_medium_skin_tone":                                  "\U0001f93e\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-playing-handball_medium_dark_skin_tone":                             "\U0001f93e\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_playing_handball_medium_dark_skin_tone":                             "\U0001f93e\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-playing-handball_dark_skin_tone":                                    "\U0001f93e\U0001f3ff\u200d\u2640\ufe0f",
+	"woman_playing_handball_dark_skin_tone":                                    "\U0001f93e\U0001f3ff\u200d\u2640\ufe0f",
+	"man-playing-handball_light_skin_tone":                                     "\U0001f93e\U0001f3fb\u200d\u2642\ufe0f",
+	"man_playing_handball_light_skin_tone":                                     "\U0001f93e\U0001f3fb\u200d\u2642\ufe0f",
+	"man-playing-handball_medium_light_skin_tone":                              "\U0001f93e\U0001f3fc\u200d\u2642\ufe0f",
+	"man_playing_handball_medium_light_skin_tone":                              "\U0001f93e\U0001f3fc\u200d\u2642\ufe0f",
+	"man-playing-handball_medium_skin_tone":                                    "\U0001f93e\U0001f3fd\u200d\u2642\ufe0f",
+	"man_playing_handball_medium_skin_tone":                                    "\U0001f93e\U0001f3fd\u200d\u2642\ufe0f",
+	"man-playing-handball_medium_dark_skin_tone":                               "\U0001f93e\U0001f3fe\u200d\u2642\ufe0f",
+	"man_playing_handball_medium_dark_skin_tone":                               "\U0001f93e\U0001f3fe\u200d\u2642\ufe0f",
+	"man-playing-handball_dark_skin_tone":                                      "\U0001f93e\U0001f3ff\u200d\u2642\ufe0f",
+	"man_playing_handball_dark_skin_tone":                                      "\U0001f93e\U0001f3ff\u200d\u2642\ufe0f",
+	"handball_light_skin_tone":                                                 "\U0001f93e\U0001f3fb",
+	"handball_medium_light_skin_tone":                                          "\U0001f93e\U0001f3fc",
+	"handball_medium_skin_tone":                                                "\U0001f93e\U0001f3fd",
+	"handball_medium_dark_skin_tone":                                           "\U0001f93e\U0001f3fe",
+	"handball_dark_skin_tone":                                                  "\U0001f93e\U0001f3ff",
+	"ninja_light_skin_tone":                                                    "\U0001f977\U0001f3fb",
+	"ninja_medium_light_skin_tone":                                             "\U0001f977\U0001f3fc",
+	"ninja_medium_skin_tone":                                                   "\U0001f977\U0001f3fd",
+	"ninja_medium_dark_skin_tone":                                              "\U0001f977\U0001f3fe",
+	"ninja_dark_skin_tone":                                                     "\U0001f977\U0001f3ff",
+	"leg_light_skin_tone":                                                      "\U0001f9b5\U0001f3fb",
+	"leg_medium_light_skin_tone":                                               "\U0001f9b5\U0001f3fc",
+	"leg_medium_skin_tone":                                                     "\U0001f9b5\U0001f3fd",
+	"leg_medium_dark_skin_tone":                                                "\U0001f9b5\U0001f3fe",
+	"leg_dark_skin_tone":                                                       "\U0001f9b5\U0001f3ff",
+	"foot_light_skin_tone":                                                     "\U0001f9b6\U0001f3fb",
+	"foot_medium_light_skin_tone":                                              "\U0001f9b6\U0001f3fc",
+	"foot_medium_skin_tone":                                                    "\U0001f9b6\U0001f3fd",
+	"foot_medium_dark_skin_tone":                                               "\U0001f9b6\U0001f3fe",
+	"foot_dark_skin_tone":                                                      "\U0001f9b6\U0001f3ff",
+	"female_superhero_light_skin_tone":                                         "\U0001f9b8\U0001f3fb\u200d\u2640\ufe0f",
+	"female_superhero_medium_light_skin_tone":                                  "\U0001f9b8\U0001f3fc\u200d\u2640\ufe0f",
+	"female_superhero_medium_skin_tone":                                        "\U0001f9b8\U0001f3fd\u200d\u2640\ufe0f",
+	"female_superhero_medium_dark_skin_tone":                                   "\U0001f9b8\U0001f3fe\u200d\u2640\ufe0f",
+	"female_superhero_dark_skin_tone":                                          "\U0001f9b8\U0001f3ff\u200d\u2640\ufe0f",
+	"male_superhero_light_skin_tone":                                           "\U0001f9b8\U0001f3fb\u200d\u2642\ufe0f",
+	"male_superhero_medium_light_skin_tone":                                    "\U0001f9b8\U0001f3fc\u200d\u2642\ufe0f",
+	"male_superhero_medium_skin_tone":                                          "\U0001f9b8\U0001f3fd\u200d\u2642\ufe0f",
+	"male_superhero_medium_dark_skin_tone":                                     "\U0001f9b8\U0001f3fe\u200d\u2642\ufe0f",
+	"male_superhero_dark_skin_tone":                                            "\U0001f9b8\U0001f3ff\u200d\u2642\ufe0f",
+	"superhero_light_skin_tone":                                                "\U0001f9b8\U0001f3fb",
+	"superhero_medium_light_skin_tone":                                         "\U0001f9b8\U0001f3fc",
+	"superhero_medium_skin_tone":                                               "\U0001f9b8\U0001f3fd",
+	"superhero_medium_dark_skin_tone":                                          "\U0001f9b8\U0001f3fe",
+	"superhero_dark_skin_tone":                                                 "\U0001f9b8\U0001f3ff",
+	"female_supervillain_light_skin_tone":                                      "\U0001f9b9\U0001f3fb\u200d\u2640\ufe0f",
+	"female_supervillain_medium_light_skin_tone":                               "\U0001f9b9\U0001f3fc\u200d\u2640\ufe0f",
+	"female_supervillain_medium_skin_tone":                                     "\U0001f9b9\U0001f3fd\u200d\u2640\ufe0f",
+	"female_supervillain_medium_dark_skin_tone":                                "\U0001f9b9\U0001f3fe\u200d\u2640\ufe0f",
+	"female_supervillain_dark_skin_tone":                                       "\U0001f9b9\U0001f3ff\u200d\u2640\ufe0f",
+	"male_supervillain_light_skin_tone":                                        "\U0001f9b9\U0001f3fb\u200d\u2642\ufe0f",
+	"male_supervillain_medium_light_skin_tone":                                 "\U0001f9b9\U0001f3fc\u200d\u2642\ufe0f",
+	"male_supervillain_medium_skin_tone":                                       "\U0001f9b9\U0001f3fd\u200d\u2642\ufe0f",
+	"male_supervillain_medium_dark_skin_tone":                                  "\U0001f9b9\U0001f3fe\u200d\u2642\ufe0f",
+	"male_supervillain_dark_skin_tone":                                         "\U0001f9b9\U0001f3ff\u200d\u2642\ufe0f",
+	"supervillain_light_skin_tone":                                             "\U0001f9b9\U0001f3fb",
+	"supervillain_medium_light_skin_tone":                                      "\U0001f9b9\U0001f3fc",
+	"supervillain_medium_skin_tone":                                            "\U0001f9b9\U0001f3fd",
+	"supervillain_medium_dark_skin_tone":                                       "\U0001f9b9\U0001f3fe",
+	"supervillain_dark_skin_tone":                                              "\U0001f9b9\U0001f3ff",
+	"ear_with_hearing_aid_light_skin_tone":                                     "\U0001f9bb\U0001f3fb",
+	"ear_with_hearing_aid_medium_light_skin_tone":                              "\U0001f9bb\U0001f3fc",
+	"ear_with_hearing_aid_medium_skin_tone":                                    "\U0001f9bb\U0001f3fd",
+	"ear_with_hearing_aid_medium_dark_skin_tone":                               "\U0001f9bb\U0001f3fe",
+	"ear_with_hearing_aid_dark_skin_tone":                                      "\U0001f9bb\U0001f3ff",
+	"woman_standing_light_skin_tone":                                           "\U0001f9cd\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_standing_medium_light_skin_tone":                                    "\U0001f9cd\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_standing_medium_skin_tone":                                          "\U0001f9cd\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_standing_medium_dark_skin_tone":                                     "\U0001f9cd\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_standing_dark_skin_tone":                                            "\U0001f9cd\U0001f3ff\u200d\u2640\ufe0f",
+	"man_standing_light_skin_tone":                                             "\U0001f9cd\U0001f3fb\u200d\u2642\ufe0f",
+	"man_standing_medium_light_skin_tone":                                      "\U0001f9cd\U0001f3fc\u200d\u2642\ufe0f",
+	"man_standing_medium_skin_tone":                                            "\U0001f9cd\U0001f3fd\u200d\u2642\ufe0f",
+	"man_standing_medium_dark_skin_tone":                                       "\U0001f9cd\U0001f3fe\u200d\u2642\ufe0f",
+	"man_standing_dark_skin_tone":                                              "\U0001f9cd\U0001f3ff\u200d\u2642\ufe0f",
+	"standing_person_light_skin_tone":                                          "\U0001f9cd\U0001f3fb",
+	"standing_person_medium_light_skin_tone":                                   "\U0001f9cd\U0001f3fc",
+	"standing_person_medium_skin_tone":                                         "\U0001f9cd\U0001f3fd",
+	"standing_person_medium_dark_skin_tone":                                    "\U0001f9cd\U0001f3fe",
+	"standing_person_dark_skin_tone":                                           "\U0001f9cd\U0001f3ff",
+	"woman_kneeling_light_skin_tone":                                           "\U0001f9ce\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_kneeling_medium_light_skin_tone":                                    "\U0001f9ce\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_kneeling_medium_skin_tone":                                          "\U0001f9ce\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_kneeling_medium_dark_skin_tone":                                     "\U0001f9ce\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_kneeling_dark_skin_tone":                                            "\U0001f9ce\U0001f3ff\u200d\u2640\ufe0f",
+	"man_kneeling_light_skin_tone":                                             "\U0001f9ce\U0001f3fb\u200d\u2642\ufe0f",
+	"man_kneeling_medium_light_skin_tone":                                      "\U0001f9ce\U0001f3fc\u200d\u2642\ufe0f",
+	"man_kneeling_medium_skin_tone":                                            "\U0001f9ce\U0001f3fd\u200d\u2642\ufe0f",
+	"man_kneeling_medium_dark_skin_tone":                                       "\U0001f9ce\U0001f3fe\u200d\u2642\ufe0f",
+	"man_kneeling_dark_skin_tone":                                              "\U0001f9ce\U0001f3ff\u200d\u2642\ufe0f",
+	"kneeling_person_light_skin_tone":                                          "\U0001f9ce\U0001f3fb",
+	"kneeling_person_medium_light_skin_tone":                                   "\U0001f9ce\U0001f3fc",
+	"kneeling_person_medium_skin_tone":                                         "\U0001f9ce\U0001f3fd",
+	"kneeling_person_medium_dark_skin_tone":                                    "\U0001f9ce\U0001f3fe",
+	"kneeling_person_dark_skin_tone":                                           "\U0001f9ce\U0001f3ff",
+	"deaf_woman_light_skin_tone":                                               "\U0001f9cf\U0001f3fb\u200d\u2640\ufe0f",
+	"deaf_woman_medium_light_skin_tone":                                        "\U0001f9cf\U0001f3fc\u200d\u2640\ufe0f",
+	"deaf_woman_medium_skin_tone":                                              "\U0001f9cf\U0001f3fd\u200d\u2640\ufe0f",
+	"deaf_woman_medium_dark_skin_tone":                                         "\U0001f9cf\U0001f3fe\u200d\u2640\ufe0f",
+	"deaf_woman_dark_skin_tone":                                                "\U0001f9cf\U0001f3ff\u200d\u2640\ufe0f",
+	"deaf_man_light_skin_tone":                                                 "\U0001f9cf\U0001f3fb\u200d\u2642\ufe0f",
+	"deaf_man_medium_light_skin_tone":                                          "\U0001f9cf\U0001f3fc\u200d\u2642\ufe0f",
+	"deaf_man_medium_skin_tone":                                                "\U0001f9cf\U0001f3fd\u200d\u2642\ufe0f",
+	"deaf_man_medium_dark_skin_tone":                                           "\U0001f9cf\U0001f3fe\u200d\u2642\ufe0f",
+	"deaf_man_dark_skin_tone":                                                  "\U0001f9cf\U0001f3ff\u200d\u2642\ufe0f",
+	"deaf_person_light_skin_tone":                                              "\U0001f9cf\U0001f3fb",
+	"deaf_person_medium_light_skin_tone":                                       "\U0001f9cf\U0001f3fc",
+	"deaf_person_medium_skin_tone":                                             "\U0001f9cf\U0001f3fd",
+	"deaf_person_medium_dark_skin_tone":                                        "\U0001f9cf\U0001f3fe",
+	"deaf_person_dark_skin_tone":                                               "\U0001f9cf\U0001f3ff",
+	"farmer_light_skin_tone":                                                   "\U0001f9d1\U0001f3fb\u200d\U0001f33e",
+	"farmer_medium_light_skin_tone":                                            "\U0001f9d1\U0001f3fc\u200d\U0001f33e",
+	"farmer_medium_skin_tone":                                                  "\U0001f9d1\U0001f3fd\u200d\U0001f33e",
+	"farmer_medium_dark_skin_tone":                                             "\U0001f9d1\U0001f3fe\u200d\U0001f33e",
+	"farmer_dark_skin_tone":                                                    "\U0001f9d1\U0001f3ff\u200d\U0001f33e",
+	"cook_light_skin_tone":                                                     "\U0001f9d1\U0001f3fb\u200d\U0001f373",
+	"cook_medium_light_skin_tone":                                              "\U0001f9d1\U0001f3fc\u200d\U0001f373",
+	"cook_medium_skin_tone":                                                    "\U0001f9d1\U0001f3fd\u200d\U0001f373",
+	"cook_medium_dark_skin_tone":                                               "\U0001f9d1\U0001f3fe\u200d\U0001f373",
+	"cook_dark_skin_tone":                                                      "\U0001f9d1\U0001f3ff\u200d\U0001f373",
+	"person_feeding_baby_light_skin_tone":                                      "\U0001f9d1\U0001f3fb\u200d\U0001f37c",
+	"person_feeding_baby_medium_light_skin_tone":                               "\U0001f9d1\U0001f3fc\u200d\U0001f37c",
+	"person_feeding_baby_medium_skin_tone":                                     "\U0001f9d1\U0001f3fd\u200d\U0001f37c",
+	"person_feeding_baby_medium_dark_skin_tone":                                "\U0001f9d1\U0001f3fe\u200d\U0001f37c",
+	"person_feeding_baby_dark_skin_tone":                                       "\U0001f9d1\U0001f3ff\u200d\U0001f37c",
+	"mx_claus_light_skin_tone":                                                 "\U0001f9d1\U0001f3fb\u200d\U0001f384",
+	"mx_claus_medium_light_skin_tone":                                          "\U0001f9d1\U0001f3fc\u200d\U0001f384",
+	"mx_claus_medium_skin_tone":                                                "\U0001f9d1\U0001f3fd\u200d\U0001f384",
+	"mx_claus_medium_dark_skin_tone":                                           "\U0001f9d1\U0001f3fe\u200d\U0001f384",
+	"mx_claus_dark_skin_tone":                                                  "\U0001f9d1\U0001f3ff\u200d\U0001f384",
+	"student_light_skin_tone":                                                  "\U0001f9d1\U0001f3fb\u200d\U0001f393",
+	"student_medium_light_skin_tone":                                           "\U0001f9d1\U0001f3fc\u200d\U0001f393",
+	"student_medium_skin_tone":                                                 "\U0001f9d1\U0001f3fd\u200d\U0001f393",
+	"student_medium_dark_skin_tone":                                            "\U0001f9d1\U0001f3fe\u200d\U0001f393",
+	"student_dark_skin_tone":                                                   "\U0001f9d1\U0001f3ff\u200d\U0001f393",
+	"singer_light_skin_tone":                                                   "\U0001f9d1\U0001f3fb\u200d\U0001f3a4",
+	"singer_medium_light_skin_tone":                                            "\U0001f9d1\U0001f3fc\u200d\U0001f3a4",
+	"singer_medium_skin_tone":                                                  "\U0001f9d1\U0001f3fd\u200d\U0001f3a4",
+	"singer_medium_dark_skin_tone":                                             "\U0001f9d1\U0001f3fe\u200d\U0001f3a4",
+	"singer_dark_skin_tone":                                                    "\U0001f9d1\U0001f3ff\u200d\U0001f3a4",
+	"artist_light_skin_tone":                                                   "\U0001f9d1\U0001f3fb\u200d\U0001f3a8",
+	"artist_medium_light_skin_tone":                                            "\U0001f9d1\U0001f3fc\u200d\U0001f3a8",
+	"artist_medium_skin_tone":                                                  "\U0001f9d1\U0001f3fd\u200d\U0001f3a8",
+	"artist_medium_dark_skin_tone":                                             "\U0001f9d1\U0001f3fe\u200d\U0001f3a8",
+	"artist_dark_skin_tone":                                                    "\U0001f9d1\U0001f3ff\u200d\U0001f3a8",
+	"teacher_light_skin_tone":                                                  "\U0001f9d1\U0001f3fb\u200d\U0001f3eb",
+	"teacher_medium_light_skin_tone":                                           "\U0001f9d1\U0001f3fc\u200d\U0001f3eb",
+	"teacher_medium_skin_tone":                                                 "\U0001f9d1\U0001f3fd\u200d\U0001f3eb",
+	"teacher_medium_dark_skin_tone":                                            "\U0001f9d1\U0001f3fe\u200d\U0001f3eb",
+	"teacher_dark_skin_tone":                                                   "\U0001f9d1\U0001f3ff\u200d\U0001f3eb",
+	"factory_worker_light_skin_tone":                                           "\U0001f9d1\U0001f3fb\u200d\U0001f3ed",
+	"factory_worker_medium_light_skin_tone":                                    "\U0001f9d1\U0001f3fc\u200d\U0001f3ed",
+	"factory_worker_medium_skin_tone":                                          "\U0001f9d1\U0001f3fd\u200d\U0001f3ed",
+	"factory_worker_medium_dark_skin_tone":                                     "\U0001f9d1\U0001f3fe\u200d\U0001f3ed",
+	"factory_worker_dark_skin_tone":                                            "\U0001f9d1\U0001f3ff\u200d\U0001f3ed",
+	"technologist_light_skin_tone":                                             "\U0001f9d1\U0001f3fb\u200d\U0001f4bb",
+	"technologist_medium_light_skin_tone":                                      "\U0001f9d1\U0001f3fc\u200d\U0001f4bb",
+	"technologist_medium_skin_tone":                                            "\U0001f9d1\U0001f3fd\u200d\U0001f4bb",
+	"technologist_medium_dark_skin_tone":                                       "\U0001f9d1\U0001f3fe\u200d\U0001f4bb",
+	"technologist_dark_skin_tone":                                              "\U0001f9d1\U0001f3ff\u200d\U0001f4bb",
+	"office_worker_light_skin_tone":                                            "\U0001f9d1\U0001f3fb\u200d\U0001f4bc",
+	"office_worker_medium_light_skin_tone":                                     "\U0001f9d1\U0001f3fc\u200d\U0001f4bc",
+	"office_worker_medium_skin_tone":                                           "\U0001f9d1\U0001f3fd\u200d\U0001f4bc",
+	"office_worker_medium_dark_skin_tone":                                      "\U0001f9d1\U0001f3fe\u200d\U0001f4bc",
+	"office_worker_dark_skin_tone":                                             "\U0001f9d1\U0001f3ff\u200d\U0001f4bc",
+	"mechanic_light_skin_tone":                                                 "\U0001f9d1\U0001f3fb\u200d\U0001f527",
+	"mechanic_medium_light_skin_tone":                                          "\U0001f9d1\U0001f3fc\u200d\U0001f527",
+	"mechanic_medium_skin_tone":                                                "\U0001f9d1\U0001f3fd\u200d\U0001f527",
+	"mechanic_medium_dark_skin_tone":                                           "\U0001f9d1\U0001f3fe\u200d\U0001f527",
+	"mechanic_dark_skin_tone":                                                  "\U0001f9d1\U0001f3ff\u200d\U0001f527",
+	"scientist_light_skin_tone":                                                "\U0001f9d1\U0001f3fb\u200d\U0001f52c",
+	"scientist_medium_light_skin_tone":                                         "\U0001f9d1\U0001f3fc\u200d\U0001f52c",
+	"scientist_medium_skin_tone":                                               "\U0001f9d1\U0001f3fd\u200d\U0001f52c",
+	"scientist_medium_dark_skin_tone":                                          "\U0001f9d1\U0001f3fe\u200d\U0001f52c",
+	"scientist_dark_skin_tone":                                                 "\U0001f9d1\U0001f3ff\u200d\U0001f52c",
+	"astronaut_light_skin_tone":                                                "\U0001f9d1\U0001f3fb\u200d\U0001f680",
+	"astronaut_medium_light_skin_tone":                                         "\U0001f9d1\U0001f3fc\u200d\U0001f680",
+	"astronaut_medium_skin_tone":                                               "\U0001f9d1\U0001f3fd\u200d\U0001f680",
+	"astronaut_medium_dark_skin_tone":                                          "\U0001f9d1\U0001f3fe\u200d\U0001f680",
+	"astronaut_dark_skin_tone":                                                 "\U0001f9d1\U0001f3ff\u200d\U0001f680",
+	"firefighter_light_skin_tone":                                              "\U0001f9d1\U0001f3fb\u200d\U0001f692",
+	"firefighter_medium_light_skin_tone":                                       "\U0001f9d1\U0001f3fc\u200d\U0001f692",
+	"firefighter_medium_skin_tone":                                             "\U0001f9d1\U0001f3fd\u200d\U0001f692",
+	"firefighter_medium_dark_skin_tone":                                        "\U0001f9d1\U0001f3fe\u200d\U0001f692",
+	"firefighter_dark_skin_tone":                                               "\U0001f9d1\U0001f3ff\u200d\U0001f692",
+	"people_holding_hands_light_skin_tone_light_skin_tone":                     "\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb",
+	"people_holding_hands_light_skin_tone_medium_light_skin_tone":              "\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc",
+	"people_holding_hands_light_skin_tone_medium_skin_tone":                    "\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd",
+	"people_holding_hands_light_skin_tone_medium_dark_skin_tone":               "\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe",
+	"people_holding_hands_light_skin_tone_dark_skin_tone":                      "\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff",
+	"people_holding_hands_medium_light_skin_tone_light_skin_tone":              "\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb",
+	"people_holding_hands_medium_light_skin_tone_medium_light_skin_tone":       "\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc",
+	"people_holding_hands_medium_light_skin_tone_medium_skin_tone":             "\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd",
+	"people_holding_hands_medium_light_skin_tone_medium_dark_skin_tone":        "\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe",
+	"people_holding_hands_medium_light_skin_tone_dark_skin_tone":               "\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff",
+	"people_holding_hands_medium_skin_tone_light_skin_tone":                    "\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb",
+	"people_holding_hands_medium_skin_tone_medium_light_skin_tone":             "\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc",
+	"people_holding_hands_medium_skin_tone_medium_skin_tone":                   "\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd",
+	"people_holding_hands_medium_skin_tone_medium_dark_skin_tone":              "\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe",
+	"people_holding_hands_medium_skin_tone_dark_skin_tone":                     "\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff",
+	"people_holding_hands_medium_dark_skin_tone_light_skin_tone":               "\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb",
+	"people_holding_hands_medium_dark_skin_tone_medium_light_skin_tone":        "\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc",
+	"people_holding_hands_medium_dark_skin_tone_medium_skin_tone":              "\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd",
+	"people_holding_hands_medium_dark_skin_tone_medium_dark_skin_tone":         "\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe",
+	"people_holding_hands_medium_dark_skin_tone_dark_skin_tone":                "\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff",
+	"people_holding_hands_dark_skin_tone_light_skin_tone":                      "\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb",
+	"people_holding_hands_dark_skin_tone_medium_light_skin_tone":               "\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc",
+	"people_holding_hands_dark_skin_tone_medium_skin_tone":                     "\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd",
+	"people_holding_hands_dark_skin_tone_medium_dark_skin_tone":                "\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe",
+	"people_holding_hands_dark_skin_tone_dark_skin_tone":                       "\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff",
+	"person_with_probing_cane_light_skin_tone":                                 "\U0001f9d1\U0001f3fb\u200d\U0001f9af",
+	"person_with_probing_cane_medium_light_skin_tone":                          "\U0001f9d1\U0001f3fc\u200d\U0001f9af",
+	"person_with_probing_cane_medium_skin_tone":                                "\U0001f9d1\U0001f3fd\u200d\U0001f9af",
+	"person_with_probing_cane_medium_dark_skin_tone":                           "\U0001f9d1\U0001f3fe\u200d\U0001f9af",
+	"person_with_probing_cane_dark_skin_tone":                                  "\U0001f9d1\U0001f3ff\u200d\U0001f9af",
+	"red_haired_person_light_skin_tone":                                        "\U0001f9d1\U0001f3fb\u200d\U0001f9b0",
+	"red_haired_person_medium_light_skin_tone":                                 "\U0001f9d1\U0001f3fc\u200d\U0001f9b0",
+	"red_haired_person_medium_skin_tone":                                       "\U0001f9d1\U0001f3fd\u200d\U0001f9b0",
+	"red_haired_person_medium_dark_skin_tone":                                  "\U0001f9d1\U0001f3fe\u200d\U0001f9b0",
+	"red_haired_person_dark_skin_tone":                                         "\U0001f9d1\U0001f3ff\u200d\U0001f9b0",
+	"curly_haired_person_light_skin_tone":                                      "\U0001f9d1\U0001f3fb\u200d\U0001f9b1",
+	"curly_haired_person_medium_light_skin_tone":                               "\U0001f9d1\U0001f3fc\u200d\U0001f9b1",
+	"curly_haired_person_medium_skin_tone":                                     "\U0001f9d1\U0001f3fd\u200d\U0001f9b1",
+	"curly_haired_person_medium_dark_skin_tone":                                "\U0001f9d1\U0001f3fe\u200d\U0001f9b1",
+	"curly_haired_person_dark_skin_tone":                                       "\U0001f9d1\U0001f3ff\u200d\U0001f9b1",
+	"bald_person_light_skin_tone":                                              "\U0001f9d1\U0001f3fb\u200d\U0001f9b2",
+	"bald_person_medium_light_skin_tone":                                       "\U0001f9d1\U0001f3fc\u200d\U0001f9b2",
+	"bald_person_medium_skin_tone":                                             "\U0001f9d1\U0001f3fd\u200d\U0001f9b2",
+	"bald_person_medium_dark_skin_tone":                                        "\U0001f9d1\U0001f3fe\u200d\U0001f9b2",
+	"bald_person_dark_skin_tone":                                               "\U0001f9d1\U0001f3ff\u200d\U0001f9b2",
+	"white_haired_person_light_skin_tone":                                      "\U0001f9d1\U0001f3fb\u200d\U0001f9b3",
+	"white_haired_person_medium_light_skin_tone":                               "\U0001f9d1\U0001f3fc\u200d\U0001f9b3",
+	"white_haired_person_medium_skin_tone":                                     "\U0001f9d1\U0001f3fd\u200d\U0001f9b3",
+	"white_haired_person_medium_dark_skin_tone":                                "\U0001f9d1\U0001f3fe\u200d\U0001f9b3",
+	"white_haired_person_dark_skin_tone":                                       "\U0001f9d1\U0001f3ff\u200d\U0001f9b3",
+	"person_in_motorized_wheelchair_light_skin_tone":                           "\U0001f9d1\U0001f3fb\u200d\U0001f9bc",
+	"person_in_motorized_wheelchair_medium_light_skin_tone":                    "\U0001f9d1\U0001f3fc\u200d\U0001f9bc",
+	"person_in_motorized_wheelchair_medium_skin_tone":                          "\U0001f9d1\U0001f3fd\u200d\U0001f9bc",
+	"person_in_motorized_wheelchair_medium_dark_skin_tone":                     "\U0001f9d1\U0001f3fe\u200d\U0001f9bc",
+	"person_in_motorized_wheelchair_dark_skin_tone":                            "\U0001f9d1\U0001f3ff\u200d\U0001f9bc",
+	"person_in_manual_wheelchair_light_skin_tone":                              "\U0001f9d1\U0001f3fb\u200d\U0001f9bd",
+	"person_in_manual_wheelchair_medium_light_skin_tone":                       "\U0001f9d1\U0001f3fc\u200d\U0001f9bd",
+	"person_in_manual_wheelchair_medium_skin_tone":                             "\U0001f9d1\U0001f3fd\u200d\U0001f9bd",
+	"person_in_manual_wheelchair_medium_dark_skin_tone":                        "\U0001f9d1\U0001f3fe\u200d\U0001f9bd",
+	"person_in_manual_wheelchair_dark_skin_tone":                               "\U0001f9d1\U0001f3ff\u200d\U0001f9bd",
+	"health_worker_light_skin_tone":                                            "\U0001f9d1\U0001f3fb\u200d\u2695\ufe0f",
+	"doctor_light_skin_tone":                                                   "\U0001f9d1\U0001f3fb\u200d\u2695\ufe0f",
+	"health_worker_medium_light_skin_tone":                                     "\U0001f9d1\U0001f3fc\u200d\u2695\ufe0f",
+	"doctor_medium_light_skin_tone":                                            "\U0001f9d1\U0001f3fc\u200d\u2695\ufe0f",
+	"health_worker_medium_skin_tone":                                           "\U0001f9d1\U0001f3fd\u200d\u2695\ufe0f",
+	"doctor_medium_skin_tone":                                                  "\U0001f9d1\U0001f3fd\u200d\u2695\ufe0f",
+	"health_worker_medium_dark_skin_tone":                                      "\U0001f9d1\U0001f3fe\u200d\u2695\ufe0f",
+	"doctor_medium_dark_skin_tone":                                             "\U0001f9d1\U0001f3fe\u200d\u2695\ufe0f",
+	"health_worker_dark_skin_tone":                                             "\U0001f9d1\U0001f3ff\u200d\u2695\ufe0f",
+	"doctor_dark_skin_tone":                                                    "\U0001f9d1\U0001f3ff\u200d\u2695\ufe0f",
+	"judge_light_skin_tone":                                                    "\U0001f9d1\U0001f3fb\u200d\u2696\ufe0f",
+	"judge_medium_light_skin_tone":                                             "\U0001f9d1\U0001f3fc\u200d\u2696\ufe0f",
+	"judge_medium_skin_tone":                                                   "\U0001f9d1\U0001f3fd\u200d\u2696\ufe0f",
+	"judge_medium_dark_skin_tone":                                              "\U0001f9d1\U0001f3fe\u200d\u2696\ufe0f",
+	"judge_dark_skin_tone":                                                     "\U0001f9d1\U0001f3ff\u200d\u2696\ufe0f",
+	"pilot_light_skin_tone":                                                    "\U0001f9d1\U0001f3fb\u200d\u2708\ufe0f",
+	"pilot_medium_light_skin_tone":                                             "\U0001f9d1\U0001f3fc\u200d\u2708\ufe0f",
+	"pilot_medium_skin_tone":                                                   "\U0001f9d1\U0001f3fd\u200d\u2708\ufe0f",
+	"pilot_medium_dark_skin_tone":                                              "\U0001f9d1\U0001f3fe\u200d\u2708\ufe0f",
+	"pilot_dark_skin_tone":                                                     "\U0001f9d1\U0001f3ff\u200d\u2708\ufe0f",
+	"adult_light_skin_tone":                                                    "\U0001f9d1\U0001f3fb",
+	"adult_medium_light_skin_tone":                                             "\U0001f9d1\U0001f3fc",
+	"adult_medium_skin_tone":                                                   "\U0001f9d1\U0001f3fd",
+	"adult_medium_dark_skin_tone":                                              "\U0001f9d1\U0001f3fe",
+	"adult_dark_skin_tone":                                                     "\U0001f9d1\U0001f3ff",
+	"child_light_skin_tone":                                                    "\U0001f9d2\U0001f3fb",
+	"child_medium_light_skin_tone":                                             "\U0001f9d2\U0001f3fc",
+	"child_medium_skin_tone":                                                   "\U0001f9d2\U0001f3fd",
+	"child_medium_dark_skin_tone":                                              "\U0001f9d2\U0001f3fe",
+	"child_dark_skin_tone":                                                     "\U0001f9d2\U0001f3ff",
+	"older_adult_light_skin_tone":                                              "\U0001f9d3\U0001f3fb",
+	"older_adult_medium_light_skin_tone":                                       "\U0001f9d3\U0001f3fc",
+	"older_adult_medium_skin_tone":                                             "\U0001f9d3\U0001f3fd",
+	"older_adult_medium_dark_skin_tone":                                        "\U0001f9d3\U0001f3fe",
+	"older_adult_dark_skin_tone":                                               "\U0001f9d3\U0001f3ff",
+	"bearded_person_light_skin_tone":                                           "\U0001f9d4\U0001f3fb",
+	"bearded_person_medium_light_skin_tone":                                    "\U0001f9d4\U0001f3fc",
+	"bearded_person_medium_skin_tone":                                          "\U0001f9d4\U0001f3fd",
+	"bearded_person_medium_dark_skin_tone":                                     "\U0001f9d4\U0001f3fe",
+	"bearded_person_dark_skin_tone":                                            "\U0001f9d4\U0001f3ff",
+	"person_with_headscarf_light_skin_tone":                                    "\U0001f9d5\U0001f3fb",
+	"person_with_headscarf_medium_light_skin_tone":                             "\U0001f9d5\U0001f3fc",
+	"person_with_headscarf_medium_skin_tone":                                   "\U0001f9d5\U0001f3fd",
+	"person_with_headscarf_medium_dark_skin_tone":                              "\U0001f9d5\U0001f3fe",
+	"person_with_headscarf_dark_skin_tone":                                     "\U0001f9d5\U0001f3ff",
+	"woman_in_steamy_room_light_skin_tone":                                     "\U0001f9d6\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_in_steamy_room_medium_light_skin_tone":                              "\U0001f9d6\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_in_steamy_room_medium_skin_tone":                                    "\U0001f9d6\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_in_steamy_room_medium_dark_skin_tone":                               "\U0001f9d6\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_in_steamy_room_dark_skin_tone":                                      "\U0001f9d6\U0001f3ff\u200d\u2640\ufe0f",
+	"man_in_steamy_room_light_skin_tone":                                       "\U0001f9d6\U0001f3fb\u200d\u2642\ufe0f",
+	"man_in_steamy_room_medium_light_skin_tone":                                "\U0001f9d6\U0001f3fc\u200d\u2642\ufe0f",
+	"man_in_steamy_room_medium_skin_tone":                                      "\U0001f9d6\U0001f3fd\u200d\u2642\ufe0f",
+	"man_in_steamy_room_medium_dark_skin_tone":                                 "\U0001f9d6\U0001f3fe\u200d\u2642\ufe0f",
+	"man_in_steamy_room_dark_skin_tone":                                        "\U0001f9d6\U0001f3ff\u200d\u2642\ufe0f",
+	"person_in_steamy_room_light_skin_tone":                                    "\U0001f9d6\U0001f3fb",
+	"person_in_steamy_room_medium_light_skin_tone":                             "\U0001f9d6\U0001f3fc",
+	"person_in_steamy_room_medium_skin_tone":                                   "\U0001f9d6\U0001f3fd",
+	"person_in_steamy_room_medium_dark_skin_tone":                              "\U0001f9d6\U0001f3fe",
+	"person_in_steamy_room_dark_skin_tone":                                     "\U0001f9d6\U0001f3ff",
+	"woman_climbing_light_skin_tone":                                           "\U0001f9d7\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_climbing_medium_light_skin_tone":                                    "\U0001f9d7\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_climbing_medium_skin_tone":                                          "\U0001f9d7\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_climbing_medium_dark_skin_tone":                                     "\U0001f9d7\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_climbing_dark_skin_tone":                                            "\U0001f9d7\U0001f3ff\u200d\u2640\ufe0f",
+	"man_climbing_light_skin_tone":                                             "\U0001f9d7\U0001f3fb\u200d\u2642\ufe0f",
+	"man_climbing_medium_light_skin_tone":                                      "\U0001f9d7\U0001f3fc\u200d\u2642\ufe0f",
+	"man_climbing_medium_skin_tone":                                            "\U0001f9d7\U0001f3fd\u200d\u2642\ufe0f",
+	"man_climbing_medium_dark_skin_tone":                                       "\U0001f9d7\U0001f3fe\u200d\u2642\ufe0f",
+	"man_climbing_dark_skin_tone":                                              "\U0001f9d7\U0001f3ff\u200d\u2642\ufe0f",
+	"person_climbing_light_skin_tone":                                          "\U0001f9d7\U0001f3fb",
+	"person_climbing_medium_light_skin_tone":                                   "\U0001f9d7\U0001f3fc",
+	"person_climbing_medium_skin_tone":                                         "\U0001f9d7\U0001f3fd",
+	"person_climbing_medium_dark_skin_tone":                                    "\U0001f9d7\U0001f3fe",
+	"person_climbing_dark_skin_tone":                                           "\U0001f9d7\U0001f3ff",
+	"woman_in_lotus_position_light_skin_tone":                                  "\U0001f9d8\U0001f3fb\u200d\u2640\ufe0f",
+	"woman_in_lotus_position_medium_light_skin_tone":                           "\U0001f9d8\U0001f3fc\u200d\u2640\ufe0f",
+	"woman_in_lotus_position_medium_skin_tone":                                 "\U0001f9d8\U0001f3fd\u200d\u2640\ufe0f",
+	"woman_in_lotus_position_medium_dark_skin_tone":                            "\U0001f9d8\U0001f3fe\u200d\u2640\ufe0f",
+	"woman_in_lotus_position_dark_skin_tone":                                   "\U0001f9d8\U0001f3ff\u200d\u2640\ufe0f",
+	"man_in_lotus_position_light_skin_tone":                                    "\U0001f9d8\U0001f3fb\u200d\u2642\ufe0f",
+	"man_in_lotus_position_medium_light_skin_tone":                             "\U0001f9d8\U0001f3fc\u200d\u2642\ufe0f",
+	"man_in_lotus_position_medium_skin_tone":                                   "\U0001f9d8\U0001f3fd\u200d\u2642\ufe0f",
+	"man_in_lotus_position_medium_dark_skin_tone":                              "\U0001f9d8\U0001f3fe\u200d\u2642\ufe0f",
+	"man_in_lotus_position_dark_skin_tone":                                     "\U0001f9d8\U0001f3ff\u200d\u2642\ufe0f",
+	"person_in_lotus_position_light_skin_tone":                                 "\U0001f9d8\U0001f3fb",
+	"person_in_lotus_position_medium_light_skin_tone":                          "\U0001f9d8\U0001f3fc",
+	"person_in_lotus_position_medium_skin_tone":                                "\U0001f9d8\U0001f3fd",
+	"person_in_lotus_position_medium_dark_skin_tone":                           "\U0001f9d8\U0001f3fe",
+	"person_in_lotus_position_dark_skin_tone":                                  "\U0001f9d8\U0001f3ff",
+	"female_mage_light_skin_tone":                                              "\U0001f9d9\U0001f3fb\u200d\u2640\ufe0f",
+	"female_mage_medium_light_skin_tone":                                       "\U0001f9d9\U0001f3fc\u200d\u2640\ufe0f",
+	"female_mage_medium_skin_tone":                                             "\U0001f9d9\U0001f3fd\u200d\u2640\ufe0f",
+	"female_mage_medium_dark_skin_tone":                                        "\U0001f9d9\U0001f3fe\u200d\u2640\ufe0f",
+	"female_mage_dark_skin_tone":                                               "\U0001f9d9\U0001f3ff\u200d\u2640\ufe0f",
+	"male_mage_light_skin_tone":                                                "\U0001f9d9\U0001f3fb\u200d\u2642\ufe0f",
+	"male_mage_medium_light_skin_tone":                                         "\U0001f9d9\U0001f3fc\u200d\u2642\ufe0f",
+	"male_mage_medium_skin_tone":                                               "\U0001f9d9\U0001f3fd\u200d\u2642\ufe0f",
+	"male_mage_medium_dark_skin_tone":                                          "\U0001f9d9\U0001f3fe\u200d\u2642\ufe0f",
+	"male_mage_dark_skin_tone":                                                 "\U0001f9d9\U0001f3ff\u200d\u2642\ufe0f",
+	"mage_light_skin_tone":                                                     "\U0001f9d9\U0001f3fb",
+	"mage_medium_light_skin_tone":                                              "\U0001f9d9\U0001f3fc",
+	"mage_medium_skin_tone":                                                    "\U0001f9d9\U0001f3fd",
+	"mage_medium_dark_skin_tone":                                               "\U0001f9d9\U0001f3fe",
+	"mage_dark_skin_tone":                                                      "\U0001f9d9\U0001f3ff",
+	"female_fairy_light_skin_tone":                                             "\U0001f9da\U0001f3fb\u200d\u2640\ufe0f",
+	"female_fairy_medium_light_skin_tone":                                      "\U0001f9da\U0001f3fc\u200d\u2640\ufe0f",
+	"female_fairy_medium_skin_tone":                                            "\U0001f9da\U0001f3fd\u200d\u2640\ufe0f",
+	"female_fairy_medium_dark_skin_tone":                                       "\U0001f9da\U0001f3fe\u200d\u2640\ufe0f",
+	"female_fairy_dark_skin_tone":                                              "\U0001f9da\U0001f3ff\u200d\u2640\ufe0f",
+	"male_fairy_light_skin_tone":                                               "\U0001f9da\U0001f3fb\u200d\u2642\ufe0f",
+	"male_fairy_medium_light_skin_tone":                                        "\U0001f9da\U0001f3fc\u200d\u2642\ufe0f",
+	"male_fairy_medium_skin_tone":                                              "\U0001f9da\U0001f3fd\u200d\u2642\ufe0f",
+	"male_fairy_medium_dark_skin_tone":                                         "\U0001f9da\U0001f3fe\u200d\u2642\ufe0f",
+	"male_fairy_dark_skin_tone":                                                "\U0001f9da\U0001f3ff\u200d\u2642\ufe0f",
+	"fairy_light_skin_tone":                                                    "\U0001f9da\U0001f3fb",
+	"fairy_medium_light_skin_tone":                                             "\U0001f9da\U0001f3fc",
+	"fairy_medium_skin_tone":                                                   "\U0001f9da\U0001f3fd",
+	"fairy_medium_dark_skin_tone":                                              "\U0001f9da\U0001f3fe",
+	"fairy_dark_skin_tone":                                                     "\U0001f9da\U0001f3ff",
+	"female_vampire_light_skin_tone":                                           "\U0001f9db\U0001f3fb\u200d\u2640\ufe0f",
+	"female_vampire_medium_light_skin_tone":                                    "\U0001f9db\U0001f3fc\u200d\u2640\ufe0f",
+	"female_vampire_medium_skin_tone":                                          "\U0001f9db\U0001f3fd\u200d\u2640\ufe0f",
+	"female_vampire_medium_dark_skin_tone":                                     "\U0001f9db\U0001f3fe\u200d\u2640\ufe0f",
+	"female_vampire_dark_skin_tone":                                            "\U0001f9db\U0001f3ff\u200d\u2640\ufe0f",
+	"male_vampire_light_skin_tone":                                             "\U0001f9db\U0001f3fb\u200d\u2642\ufe0f",
+	"male_vampire_medium_light_skin_tone":                                      "\U0001f9db\U0001f3fc\u200d\u2642\ufe0f",
+	"male_vampire_medium_skin_tone":                                            "\U0001f9db\U0001f3fd\u200d\u2642\ufe0f",
+	"male_vampire_medium_dark_skin_tone":                                       "\U0001f9db\U0001f3fe\u200d\u2642\ufe0f",
+	"male_vampire_dark_skin_tone":                                              "\U0001f9db\U0001f3ff\u200d\u2642\ufe0f",
+	"vampire_light_skin_tone":                                                  "\U0001f9db\U0001f3fb",
+	"vampire_medium_light_skin_tone":                                           "\U0001f9db\U0001f3fc",
+	"vampire_medium_skin_tone":                                                 "\U0001f9db\U0001f3fd",
+	"vampire_medium_dark_skin_tone":                                            "\U0001f9db\U0001f3fe",
+	"vampire_dark_skin_tone":                                                   "\U0001f9db\U0001f3ff",
+	"mermaid_light_skin_tone":                                                  "\U0001f9dc\U0001f3fb\u200d\u2640\ufe0f",
+	"mermaid_medium_light_skin_tone":                                           "\U0001f9dc\U0001f3fc\u200d\u2640\ufe0f",
+	"mermaid_medium_skin_tone":                                                 "\U0001f9dc\U0001f3fd\u200d\u2640\ufe0f",
+	"mermaid_medium_dark_skin_tone":                                            "\U0001f9dc\U0001f3fe\u200d\u2640\ufe0f",
+	"mermaid_dark_skin_tone":                                                   "\U0001f9dc\U0001f3ff\u200d\u2640\ufe0f",
+	"merman_light_skin_tone":                                                   "\U0001f9dc\U0001f3fb\u200d\u2642\ufe0f",
+	"merman_medium_light_skin_tone":                                            "\U0001f9dc\U0001f3fc\u200d\u2642\ufe0f",
+	"merman_medium_skin_tone":                                                  "\U0001f9dc\U0001f3fd\u200d\u2642\ufe0f",
+	"merman_medium_dark_skin_tone":                                             "\U0001f9dc\U0001f3fe\u200d\u2642\ufe0f",
+	"merman_dark_skin_tone":                                                    "\U0001f9dc\U0001f3ff\u200d\u2642\ufe0f",
+	"merperson_light_skin_tone":                                                "\U0001f9dc\U0001f3fb",
+	"merperson_medium_light_skin_tone":                                         "\U0001f9dc\U0001f3fc",
+	"merperson_medium_skin_tone":                                               "\U0001f9dc\U0001f3fd",
+	"merperson_medium_dark_skin_tone":                                          "\U0001f9dc\U0001f3fe",
+	"merperson_dark_skin_tone":                                                 "\U0001f9dc\U0001f3ff",
+	"female_elf_light_skin_tone":                                               "\U0001f9dd\U0001f3fb\u200d\u2640\ufe0f",
+	"female_elf_medium_light_skin_tone":                                        "\U0001f9dd\U0001f3fc\u200d\u2640\ufe0f",
+	"female_elf_medium_skin_tone":                                              "\U0001f9dd\U0001f3fd\u200d\u2640\ufe0f",
+	"female_elf_medium_dark_skin_tone":                                         "\U0001f9dd\U0001f3fe\u200d\u2640\ufe0f",
+	"female_elf_dark_skin_tone":                                                "\U0001f9dd\U0001f3ff\u200d\u2640\ufe0f",
+	"male_elf_light_skin_tone":                                                 "\U0001f9dd\U0001f3fb\u200d\u2642\ufe0f",
+	"male_elf_medium_light_skin_tone":                                          "\U0001f9dd\U0001f3fc\u200d\u2642\ufe0f",
+	"male_elf_medium_skin_tone":                                                "\U0001f9dd\U0001f3fd\u200d\u2642\ufe0f",
+	"male_elf_medium_dark_skin_tone":                                           "\U0001f9dd\U0001f3fe\u200d\u2642\ufe0f",
+	"male_elf_dark_skin_tone":                                                  "\U0001f9dd\U0001f3ff\u200d\u2642\ufe0f",
+	"elf_light_skin_tone":                                                      "\U0001f9dd\U0001f3fb",
+	"elf_medium_light_skin_tone":                                               "\U0001f9dd\U0001f3fc",
+	"elf_medium_skin_tone":                                                     "\U0001f9dd\U0001f3fd",
+	"elf_medium_dark_skin_tone":                                                "\U0001f9dd\U0001f3fe",
+	"elf_dark_skin_tone":                                                       "\U0001f9dd\U0001f3ff",
+	"point_up_light_skin_tone":                                                 "\u261d\U0001f3fb",
+	"point_up_medium_light_skin_tone":                                          "\u261d\U0001f3fc",
+	"point_up_medium_skin_tone":                                                "\u261d\U0001f3fd",
+	"point_up_medium_dark_skin_tone":                                           "\u261d\U0001f3fe",
+	"point_up_dark_skin_tone":                                                  "\u261d\U0001f3ff",
+	"woman-bouncing-ball_light_skin_tone":                                      "\u26f9\U0001f3fb\u200d\u2640\ufe0f",
+	"basketball_woman_light_skin_tone":                                         "\u26f9\U0001f3fb\u200d\u2640\ufe0f",
+	"woman-bouncing-ball_medium_light_skin_tone":                               "\u26f9\U0001f3fc\u200d\u2640\ufe0f",
+	"basketball_woman_medium_light_skin_tone":                                  "\u26f9\U0001f3fc\u200d\u2640\ufe0f",
+	"woman-bouncing-ball_medium_skin_tone":                                     "\u26f9\U0001f3fd\u200d\u2640\ufe0f",
+	"basketball_woman_medium_skin_tone":                                        "\u26f9\U0001f3fd\u200d\u2640\ufe0f",
+	"woman-bouncing-ball_medium_dark_skin_tone":                                "\u26f9\U0001f3fe\u200d\u2640\ufe0f",
+	"basketball_woman_medium_dark_skin_tone":                                   "\u26f9\U0001f3fe\u200d\u2640\ufe0f",
+	"woman-bouncing-ball_dark_skin_tone":                                       "\u26f9\U0001f3ff\u200d\u2640\ufe0f",
+	"basketball_woman_dark_skin_tone":                                          "\u26f9\U0001f3ff\u200d\u2640\ufe0f",
+	"man-bouncing-ball_light_skin_tone":                                        "\u26f9\U0001f3fb\u200d\u2642\ufe0f",
+	"basketball_man_light_skin_tone":                                           "\u26f9\U0001f3fb\u200d\u2642\ufe0f",
+	"man-bouncing-ball_medium_light_skin_tone":                                 "\u26f9\U0001f3fc\u200d\u2642\ufe0f",
+	"basketball_man_medium_light_skin_tone":                                    "\u26f9\U0001f3fc\u200d\u2642\ufe0f",
+	"man-bouncing-ball_medium_skin_tone":                                       "\u26f9\U0001f3fd\u200d\u2642\ufe0f",
+	"basketball_man_medium_skin_tone":                                          "\u26f9\U0001f3fd\u200d\u2642\ufe0f",
+	"man-bouncing-ball_medium_dark_skin_tone":                                  "\u26f9\U0001f3fe\u200d\u2642\ufe0f",
+	"basketball_man_medium_dark_skin_tone":                                     "\u26f9\U0001f3fe\u200d\u2642\ufe0f",
+	"man-bouncing-ball_dark_skin_tone":                                         "\u26f9\U0001f3ff\u200d\u2642\ufe0f",
+	"basketball_man_dark_skin_tone":                                            "\u26f9\U0001f3ff\u200d\u2642\ufe0f",
+	"person_with_ball_light_skin_tone":                                         "\u26f9\U0001f3fb",
+	"person_with_ball_medium_light_skin_tone":                                  "\u26f9\U0001f3fc",
+	"person_with_ball_medium_skin_tone":                                        "\u26f9\U0001f3fd",
+	"person_with_ball_medium_dark_skin_tone":                                   "\u26f9\U0001f3fe",
+	"person_with_ball_dark_skin_tone":                                          "\u26f9\U0001f3ff",
+	"fist_light_skin_tone":                                                     "\u270a\U0001f3fb",
+	"fist_raised_light_skin_tone":                                              "\u270a\U0001f3fb",
+	"fist_medium_light_skin_tone":                                              "\u270a\U0001f3fc",
+	"fist_raised_medium_light_skin_tone":                                       "\u270a\U0001f3fc",
+	"fist_medium_skin_tone":                                                    "\u270a\U0001f3fd",
+	"fist_raised_medium_skin_tone":                                             "\u270a\U0001f3fd",
+	"fist_medium_dark_skin_tone":                                               "\u270a\U0001f3fe",
+	"fist_raised_medium_dark_skin_tone":                                        "\u270a\U0001f3fe",
+	"fist_dark_skin_tone":                                                      "\u270a\U0001f3ff",
+	"fist_raised_dark_skin_tone":                                               "\u270a\U0001f3ff",
+	"hand_light_skin_tone":                                                     "\u270b\U0001f3fb",
+	"raised_hand_light_skin_tone":                                              "\u270b\U0001f3fb",
+	"hand_medium_light_skin_tone":                                              "\u270b\U0001f3fc",
+	"raised_hand_medium_light_skin_tone":                                       "\u270b\U0001f3fc",
+	"hand_medium_skin_tone":                                                    "\u270b\U0001f3fd",
+	"raised_hand_medium_skin_tone":                                             "\u270b\U0001f3fd",
+	"hand_medium_dark_skin_tone":                                               "\u270b\U0001f3fe",
+	"raised_hand_medium_dark_skin_tone":                                        "\u270b\U0001f3fe",
+	"hand_dark_skin_tone":                                                      "\u270b\U0001f3ff",
+	"raised_hand_dark_skin_tone":                                               "\u270b\U0001f3ff",
+	"v_light_skin_tone":                                                        "\u270c\U0001f3fb",
+	"v_medium_light_skin_tone":                                                 "\u270c\U0001f3fc",
+	"v_medium_skin_tone":                                                       "\u270c\U0001f3fd",
+	"v_medium_dark_skin_tone":                                                  "\u270c\U0001f3fe",
+	"v_dark_skin_tone":                                                         "\u270c\U0001f3ff",
+	"writing_hand_light_skin_tone":                                             "\u270d\U0001f3fb",
+	"writing_hand_medium_light_skin_tone":                                      "\u270d\U0001f3fc",
+	"writing_hand_medium_skin_tone":                                            "\u270d\U0001f3fd",
+	"writing_hand_medium_dark_skin_tone":                                       "\u270d\U0001f3fe",
+	"writing_hand_dark_skin_tone":                                              "\u270d\U0001f3ff",
+}
+
+// emojiNames maps Unicode emoji, without variation selectors, to the
+// Mattermost name used for reactions with them.
+var emojiNames = map[string]string{
+	"#\u20e3":                          "hash",
+	"*\u20e3":                          "asterisk",
+	"0\u20e3":                          "zero",
+	"1\u20e3":                          "one",
+	"2\u20e3":                          "two",
+	"3\u20e3":                          "three",
+	"4\u20e3":                          "four",
+	"5\u20e3":                          "five",
+	"6\u20e3":                          "six",
+	"7\u20e3":                          "seven",
+	"8\u20e3":                          "eight",
+	"9\u20e3":                          "nine",
+	"\u00a9":                           "copyright",
+	"\u00ae":                           "registered",
+	"\u203c":                           "bangbang",
+	"\u2049":                           "interrobang",
+	"\u2122":                           "tm",
+	"\u2139":                           "information_source",
+	"\u2194":                           "left_right_arrow",
+	"\u2195":                           "arrow_up_down",
+	"\u2196":                           "arrow_upper_left",
+	"\u2197":                           "arrow_upper_right",
+	"\u2198":                           "arrow_lower_right",
+	"\u2199":                           "arrow_lower_left",
+	"\u21a9":                           "leftwards_arrow_with_hook",
+	"\u21aa":                           "arrow_right_hook",
+	"\u231a":                           "watch",
+	"\u231b":                           "hourglass",
+	"\u2328":                           "keyboard",
+	"\u23cf":                           "eject",
+	"\u23e9":                           "fast_forward",
+	"\u23ea":                           "rewind",
+	"\u23eb":                           "arrow_double_up",
+	"\u23ec":                           "arrow_double_down",
+	"\u23ed":                           "next_track_button",
+	"\u23ee":                           "previous_track_button",
+	"\u23ef":                           "play_or_pause_button",
+	"\u23f0":                           "alarm_clock",
+	"\u23f1":                           "stopwatch",
+	"\u23f2":                           "timer_clock",
+	"\u23f3":                           "hourglass_flowing_sand",
+	"\u23f8":                           "pause_button",
+	"\u23f9":                           "stop_button",
+	"\u23fa":                           "record_button",
+	"\u24c2":                           "m",
+	"\u25aa":                           "black_small_square",
+	"\u25ab":                           "white_small_square",
+	"\u25b6":                           "arrow_forward",
+	"\u25c0":                           "arrow_backward",
+	"\u25fb":                           "white_medium_square",
+	"\u25fc":                           "black_medium_square",
+	"\u25fd":                           "white_medium_small_square",
+	"\u25fe":                           "black_medium_small_square",
+	"\u2600":                           "sunny",
+	"\u2601":                           "cloud",
+	"\u2602":                           "umbrella",
+	"\u2603":                           "snowman",
+	"\u2604":                           "comet",
+	"\u260e":                           "phone",
+	"\u2611":                           "ballot_box_with_check",
+	"\u2614":                           "umbrella_with_rain_drops",
+	"\u2615":                           "coffee",
+	"\u2618":                           "shamrock",
+	"\u261d":                           "point_up",
+	"\u261d\U0001f3fb":                 "point_up_light_skin_tone",
+	"\u261d\U0001f3fc":                 "point_up_medium_light_skin_tone",
+	"\u261d\U0001f3fd":                 "point_up_medium_skin_tone",
+	"\u261d\U0001f3fe":                 "point_up_medium_dark_skin_tone",
+	"\u261d\U0001f3ff":                 "point_up_dark_skin_tone",
+	"\u2620":                           "skull_and_crossbones",
+	"\u2622":                           "radioactive",
+	"\u2623":                           "biohazard",
+	"\u2626":                           "orthodox_cross",
+	"\u262a":                           "star_and_crescent",
+	"\u262e":                           "peace_symbol",
+	"\u262f":                           "yin_yang",
+	"\u2638":                           "wheel_of_dharma",
+	"\u2639":                           "frowning_face",
+	"\u263a":                           "relaxed",
+	"\u2640":                           "female_sign",
+	"\u2642":                           "male_sign",
+	"\u2648":                           "aries",
+	"\u2649":                           "taurus",
+	"\u264a":                           "gemini",
+	"\u264b":                           "cancer",
+	"\u264c":                           "leo",
+	"\u264d":                           "virgo",
+	"\u264e":                           "libra",
+	"\u264f":                           "scorpius",
+	"\u2650":                           "sagittarius",
+	"\u2651":                           "capricorn",
+	"\u2652":                           "aquarius",
+	"\u2653":                           "pisces",
+	"\u265f":                           "chess_pawn",
+	"\u2660":                           "spades",
+	"\u2663":                           "clubs",
+	"\u2665":                           "hearts",
+	"\u2666":                           "diamonds",
+	"\u2668":                           "hotsprings",
+	"\u267b":                           "recycle",
+	"\u267e":                           "infinity",
+	"\u267f":                           "wheelchair",
+	"\u2692":                           "hammer_and_pick",
+	"\u2693":                           "anchor",
+	"\u2694":                           "crossed_swords",
+	"\u2695":                           "medical_symbol",
+	"\u2696":                           "scales",
+	"\u2697":                           "alembic",
+	"\u2699":                           "gear",
+	"\u269b":                           "atom_symbol",
+	"\u269c":                           "fleur_de_lis",
+	"\u26a0":                           "warning",
+	"\u26a1":                           "zap",
+	"\u26a7":                           "transgender_symbol",
+	"\u26aa":                           "white_circle",
+	"\u26ab":                           "black_circle",
+	"\u26b0":                           "coffin",
+	"\u26b1":                           "funeral_urn",
+	"\u26bd":                           "soccer",
+	"\u26be":                           "baseball",
+	"\u26c4":                           "snowman_without_snow",
+	"\u26c5":                           "partly_sunny",
+	"\u26c8":                           "thunder_cloud_and_rain",
+	"\u26ce":                           "ophiuchus",
+	"\u26cf":                           "pick",
+	"\u26d1":                           "rescue_worker_helmet",
+	"\u26d3":                           "chains",
+	"\u26d4":                           "no_entry",
+	"\u26e9":                           "shinto_shrine",
+	"\u26ea":                           "church",
+	"\u26f0":                           "mountain",
+	"\u26f1":                           "parasol_on_ground",
+	"\u26f2":                           "fountain",
+	"\u26f3":                           "golf",
+	"\u26f4":                           "ferry",
+	"\u26f5":                           "boat",
+	"\u26f7":                           "skier",
+	"\u26f8":                           "ice_skate",
+	"\u26f9":                           "person_with_ball",
+	"\u26f9\u200d\u2640":               "basketball_woman",
+	"\u26f9\u200d\u2642":               "basketball_man",
+	"\u26f9\U0001f3fb":                 "person_with_ball_light_skin_tone",
+	"\u26f9\U0001f3fb\u200d\u2640":     "basketball_woman_light_skin_tone",
+	"\u26f9\U0001f3fb\u200d\u2642":     "basketball_man_light_skin_tone",
+	"\u26f9\U0001f3fc":                 "person_with_ball_medium_light_skin_tone",
+	"\u26f9\U0001f3fc\u200d\u2640":     "basketball_woman_medium_light_skin_tone",
+	"\u26f9\U0001f3fc\u200d\u2642":     "basketball_man_medium_light_skin_tone",
+	"\u26f9\U0001f3fd":                 "person_with_ball_medium_skin_tone",
+	"\u26f9\U0001f3fd\u200d\u2640":     "basketball_woman_medium_skin_tone",
+	"\u26f9\U0001f3fd\u200d\u2642":     "basketball_man_medium_skin_tone",
+	"\u26f9\U0001f3fe":                 "person_with_ball_medium_dark_skin_tone",
+	"\u26f9\U0001f3fe\u200d\u2640":     "basketball_woman_medium_dark_skin_tone",
+	"\u26f9\U0001f3fe\u200d\u2642":     "basketball_man_medium_dark_skin_tone",
+	"\u26f9\U0001f3ff":                 "person_with_ball_dark_skin_tone",
+	"\u26f9\U0001f3ff\u200d\u2640":     "basketball_woman_dark_skin_tone",
+	"\u26f9\U0001f3ff\u200d\u2642":     "basketball_man_dark_skin_tone",
+	"\u26fa":                           "tent",
+	"\u26fd":                           "fuelpump",
+	"\u2702":                           "scissors",
+	"\u2705":                           "white_check_mark",
+	"\u2708":                           "airplane",
+	"\u2709":                           "email",
+	"\u270a":                           "fist",
+	"\u270a\U0001f3fb":                 "fist_light_skin_tone",
+	"\u270a\U0001f3fc":                 "fist_medium_light_skin_tone",
+	"\u270a\U0001f3fd":                 "fist_medium_skin_tone",
+	"\u270a\U0001f3fe":                 "fist_medium_dark_skin_tone",
+	"\u270a\U0001f3ff":                 "fist_dark_skin_tone",
+	"\u270b":                           "hand",
+	"\u270b\U0001f3fb":                 "hand_light_skin_tone",
+	"\u270b\U0001f3fc":                 "hand_medium_light_skin_tone",
+	"\u270b\U0001f3fd":                 "hand_medium_skin_tone",
+	"\u270b\U0001f3fe":                 "hand_medium_dark_skin_tone",
+	"\u270b\U0001f3ff":                 "hand_dark_skin_tone",
+	"\u270c":                           "v",
+	"\u270c\U0001f3fb":                 "v_light_skin_tone",
+	"\u270c\U0001f3fc":                 "v_medium_light_skin_tone",
+	"\u270c\U0001f3fd":                 "v_medium_skin_tone",
+	"\u270c\U0001f3fe":                 "v_medium_dark_skin_tone",
+	"\u270c\U0001f3ff":                 "v_dark_skin_tone",
+	"\u270d":                           "writing_hand",
+	"\u270d\U0001f3fb":                 "writing_hand_light_skin_tone",
+	"\u270d\U0001f3fc":                 "writing_hand_medium_light_skin_tone",
+	"\u270d\U0001f3fd":                 "writing_hand_medium_skin_tone",
+	"\u270d\U0001f3fe":                 "writing_hand_medium_dark_skin_tone",
+	"\u270d\U0001f3ff":                 "writing_hand_dark_skin_tone",
+	"\u270f":                           "pencil2",
+	"\u2712":                           "black_nib",
+	"\u2714":                           "heavy_check_mark",
+	"\u2716":                           "heavy_multiplication_x",
+	"\u271d":                           "latin_cross",
+	"\u2721":                           "star_of_david",
+	"\u2728":                           "sparkles",
+	"\u2733":                           "eight_spoked_asterisk",
+	"\u2734":                           "eight_pointed_black_star",
+	"\u2744":                           "snowflake",
+	"\u2747":                           "sparkle",
+	"\u274c":                           "x",
+	"\u274e":                           "negative_squared_cross_mark",
+	"\u2753":                           "question",
+	"\u2754":                           "grey_question",
+	"\u2755":                           "grey_exclamation",
+	"\u2757":                           "exclamation",
+	"\u2763":                           "heavy_heart_exclamation",
+	"\u2764":                           "heart",
+	"\u2795":                           "heavy_plus_sign",
+	"\u2796":                           "heavy_minus_sign",
+	"\u2797":                           "heavy_division_sign",
+	"\u27a1":                           "arrow_right",
+	"\u27b0":                           "curly_loop",
+	"\u27bf":                           "loop",
+	"\u2934":                           "arrow_heading_up",
+	"\u2935":                           "arrow_heading_down",
+	"\u2b05":                           "arrow_left",
+	"\u2b06":                           "arrow_up",
+	"\u2b07":                           "arrow_down",
+	"\u2b1b":                           "black_large_square",
+	"\u2b1c":                           "white_large_square",
+	"\u2b50":                           "star",
+	"\u2b55":                           "o",
+	"\u3030":                           "wavy_dash",
+	"\u303d":                           "part_alternation_mark",
+	"\u3297":                           "congratulations",
+	"\u3299":                           "secret",
+	"\U0001f004":                       "mahjong",
+	"\U0001f0cf":                       "black_joker",
+	"\U0001f170":                       "a",
+	"\U0001f171":                       "b",
+	"\U0001f17e":                       "o2",
+	"\U0001f17f":                       "parking",
+	"\U0001f18e":                       "ab",
+	"\U0001f191":                       "cl",
+	"\U0001f192":                       "cool",
+	"\U0001f193":                       "free",
+	"\U0001f194":                       "id",
+	"\U0001f195":                       "new",
+	"\U0001f196":                       "ng",
+	"\U0001f197":                       "ok",
+	"\U0001f198":                       "sos",
+	"\U0001f199":                       "up",
+	"\U0001f19a":                       "vs",
+	"\U0001f1e6\U0001f1e8":             "flag-ac",
+	"\U0001f1e6\U0001f1e9":             "flag-ad",
+	"\U0001f1e6\U0001f1ea":             "flag-ae",
+	"\U0001f1e6\U0001f1eb":             "flag-af",
+	"\U0001f1e6\U0001f1ec":             "flag-ag",
+	"\U0001f1e6\U0001f1ee":             "flag-ai",
+	"\U0001f1e6\U0001f1f1":             "flag-al",
+	"\U0001f1e6\U0001f1f2":             "flag-am",
+	"\U0001f1e6\U0001f1f4":             "angola",
+	"\U0001f1e6\U0001f1f6":             "flag-aq",
+	"\U0001f1e6\U0001f1f7":             "flag-ar",
+	"\U0001f1e6\U0001f1f8":             "flag-as",
+	"\U0001f1e6\U0001f1f9":             "flag-at",
+	"\U0001f1e6\U0001f1fa":             "flag-au",
+	"\U0001f1e6\U0001f1fc":             "aruba",
+	"\U0001f1e6\U0001f1fd":             "flag-ax",
+	"\U0001f1e6\U0001f1ff":             "flag-az",
+	"\U0001f1e7\U0001f1e6":             "flag-ba",
+	"\U0001f1e7\U0001f1e7":             "flag-bb",
+	"\U0001f1e7\U0001f1e9":             "flag-bd",
+	"\U0001f1e7\U0001f1ea":             "flag-be",
+	"\U0001f1e7\U0001f1eb":             "flag-bf",
+	"\U0001f1e7\U0001f1ec":             "flag-bg",
+	"\U0001f1e7\U0001f1ed":             "flag-bh",
+	"\U0001f1e7\U0001f1ee":             "flag-bi",
+	"\U0001f1e7\U0001f1ef":             "benin",
+	"\U0001f1e7\U0001f1f1":             "flag-bl",
+	"\U0001f1e7\U0001f1f2":             "flag-bm",
+	"\U0001f1e7\U0001f1f3":             "brunei",
+	"\U0001f1e7\U0001f1f4":             "flag-bo",
+	"\U0001f1e7\U0001f1f6":             "flag-bq",
+	"\U0001f1e7\U0001f1f7":             "brazil",
+	"\U0001f1e7\U0001f1f8":             "flag-bs",
+	"\U0001f1e7\U0001f1f9":             "bhutan",
+	"\U0001f1e7\U0001f1fb":             "flag-bv",
+	"\U0001f1e7\U0001f1fc":             "flag-bw",
+	"\U0001f1e7\U0001f1fe":             "flag-by",
+	"\U0001f1e7\U0001f1ff":             "belize",
+	"\U0001f1e8\U0001f1e6":             "ca",
+	"\U0001f1e8\U0001f1e8":             "flag-cc",
+	"\U0001f1e8\U0001f1e9":             "flag-cd",
+	"\U0001f1e8\U0001f1eb":             "flag-cf",
+	"\U0001f1e8\U0001f1ec":             "flag-cg",
+	"\U0001f1e8\U0001f1ed":             "flag-ch",
+	"\U0001f1e8\U0001f1ee":             "flag-ci",
+	"\U0001f1e8\U0001f1f0":             "flag-ck",
+	"\U0001f1e8\U0001f1f1":             "chile",
+	"\U0001f1e8\U0001f1f2":             "flag-cm",
+	"\U0001f1e8\U0001f1f3":             "cn",
+	"\U0001f1e8\U0001f1f4":             "flag-co",
+	"\U0001f1e8\U0001f1f5":             "flag-cp",
+	"\U0001f1e8\U0001f1f7":             "flag-cr",
+	"\U0001f1e8\U0001f1fa":             "cuba",
+	"\U0001f1e8\U0001f1fb":             "flag-cv",
+	"\U0001f1e8\U0001f1fc":             "flag-cw",
+	"\U0001f1e8\U0001f1fd":             "flag-cx",
+	"\U0001f1e8\U0001f1fe":             "cyprus",
+	"\U0001f1e8\U0001f1ff":             "flag-cz",
+	"\U0001f1e9\U0001f1ea":             "de",
+	"\U0001f1e9\U0001f1ec":             "flag-dg",
+	"\U0001f1e9\U0001f1ef":             "flag-dj",
+	"\U0001f1e9\U0001f1f0":             "flag-dk",
+	"\U0001f1e9\U0001f1f2":             "flag-dm",
+	"\U0001f1e9\U0001f1f4":             "flag-do",
+	"\U0001f1e9\U0001f1ff":             "flag-dz",
+	"\U0001f1ea\U0001f1e6":             "flag-ea",
+	"\U0001f1ea\U0001f1e8":             "flag-ec",
+	"\U0001f1ea\U0001f1ea":             "flag-ee",
+	"\U0001f1ea\U0001f1ec":             "egypt",
+	"\U0001f1ea\U0001f1ed":             "flag-eh",
+	"\U0001f1ea\U0001f1f7":             "flag-er",
+	"\U0001f1ea\U0001f1f8":             "es",
+	"\U0001f1ea\U0001f1f9":             "flag-et",
+	"\U0001f1ea\U0001f1fa":             "eu",
+	"\U0001f1eb\U0001f1ee":             "flag-fi",
+	"\U0001f1eb\U0001f1ef":             "fiji",
+	"\U0001f1eb\U0001f1f0":             "flag-fk",
+	"\U0001f1eb\U0001f1f2":             "flag-fm",
+	"\U0001f1eb\U0001f1f4":             "flag-fo",
+	"\U0001f1eb\U0001f1f7":             "fr",
+	"\U0001f1ec\U0001f1e6":             "gabon",
+	"\U0001f1ec\U0001f1e7":             "gb",
+	"\U0001f1ec\U0001f1e9":             "flag-gd",
+	"\U0001f1ec\U0001f1ea":             "flag-ge",
+	"\U0001f1ec\U0001f1eb":             "flag-gf",
+	"\U0001f1ec\U0001f1ec":             "flag-gg",
+	"\U0001f1ec\U0001f1ed":             "ghana",
+	"\U0001f1ec\U0001f1ee":             "flag-gi",
+	"\U0001f1ec\U0001f1f1":             "flag-gl",
+	"\U0001f1ec\U0001f1f2":             "gambia",
+	"\U0001f1ec\U0001f1f3":             "guinea",
+	"\U0001f1ec\U0001f1f5":             "flag-gp",
+	"\U0001f1ec\U0001f1f6":             "flag-gq",
+	"\U0001f1ec\U0001f1f7":             "greece",
+	"\U0001f1ec\U0001f1f8":             "flag-gs",
+	"\U0001f1ec\U0001f1f9":             "flag-gt",
+	"\U0001f1ec\U0001f1fa":             "guam",
+	"\U0001f1ec\U0001f1fc":             "flag-gw",
+	"\U0001f1ec\U0001f1fe":             "guyana",
+	"\U0001f1ed\U0001f1f0":             "flag-hk",
+	"\U0001f1ed\U0001f1f2":             "flag-hm",
+	"\U0001f1ed\U0001f1f3":             "flag-hn",
+	"\U0001f1ed\U0001f1f7":             "flag-hr",
+	"\U0001f1ed\U0001f1f9":             "haiti",
+	"\U0001f1ed\U0001f1fa":             "flag-hu",
+	"\U0001f1ee\U0001f1e8":             "flag-ic",
+	"\U0001f1ee\U0001f1e9":             "flag-id",
+	"\U0001f1ee\U0001f1ea":             "flag-ie",
+	"\U0001f1ee\U0001f1f1":             "israel",
+	"\U0001f1ee\U0001f1f2":             "flag-im",
+	"\U0001f1ee\U0001f1f3":             "india",
+	"\U0001f1ee\U0001f1f4":             "flag-io",
+	"\U0001f1ee\U0001f1f6":             "iraq",
+	"\U0001f1ee\U0001f1f7":             "iran",
+	"\U0001f1ee\U0001f1f8":             "flag-is",
+	"\U0001f1ee\U0001f1f9":             "it",
+	"\U0001f1ef\U0001f1ea":             "jersey",
+	"\U0001f1ef\U0001f1f2":             "flag-jm",
+	"\U0001f1ef\U0001f1f4":             "jordan",
+	"\U0001f1ef\U0001f1f5":             "jp",
+	"\U0001f1f0\U0001f1ea":             "kenya",
+	"\U0001f1f0\U0001f1ec":             "flag-kg",
+	"\U0001f1f0\U0001f1ed":             "flag-kh",
+	"\U0001f1f0\U0001f1ee":             "flag-ki",
+	"\U0001f1f0\U0001f1f2":             "flag-km",
+	"\U0001f1f0\U0001f1f3":             "flag-kn",
+	"\U0001f1f0\U0001f1f5":             "flag-kp",
+	"\U0001f1f0\U0001f1f7":             "kr",
+	"\U0001f1f0\U0001f1fc":             "kuwait",
+	"\U0001f1f0\U0001f1fe":             "flag-ky",
+	"\U0001f1f0\U0001f1ff":             "flag-kz",
+	"\U0001f1f1\U0001f1e6":             "laos",
+	"\U0001f1f1\U0001f1e7":             "flag-lb",
+	"\U0001f1f1\U0001f1e8":             "flag-lc",
+	"\U0001f1f1\U0001f1ee":             "flag-li",
+	"\U0001f1f1\U0001f1f0":             "flag-lk",
+	"\U0001f1f1\U0001f1f7":             "flag-lr",
+	"\U0001f1f1\U0001f1f8":             "flag-ls",
+	"\U0001f1f1\U0001f1f9":             "flag-lt",
+	"\U0001f1f1\U0001f1fa":             "flag-lu",
+	"\U0001f1f1\U0001f1fb":             "latvia",
+	"\U0001f1f1\U0001f1fe":             "libya",
+	"\U0001f1f2\U0001f1e6":             "flag-ma",
+	"\U0001f1f2\U0001f1e8":             "monaco",
+	"\U0001f1f2\U0001f1e9":             "flag-md",
+	"\U0001f1f2\U0001f1ea":             "flag-me",
+	"\U0001f1f2\U0001f1eb":             "flag-mf",
+	"\U0001f1f2\U0001f1ec":             "flag-mg",
+	"\U0001f1f2\U0001f1ed":             "flag-mh",
+	"\U0001f1f2\U0001f1f0":             "flag-mk",
+	"\U0001f1f2\U0001f1f1":             "mali",
+	"\U0001f1f2\U0001f1f2":             "flag-mm",
+	"\U0001f1f2\U0001f1f3":             "flag-mn",
+	"\U0001f1f2\U0001f1f4":             "macau",
+	"\U0001f1f2\U0001f1f5":             "flag-mp",
+	"\U0001f1f2\U0001f1f6":             "flag-mq",
+	"\U0001f1f2\U0001f1f7":             "flag-mr",
+	"\U0001f1f2\U0001f1f8":             "flag-ms",
+	"\U0001f1f2\U0001f1f9":             "malta",
+	"\U0001f1f2\U0001f1fa":             "flag-mu",
+	"\U0001f1f2\U0001f1fb":             "flag-mv",
+	"\U0001f1f2\U0001f1fc":             "malawi",
+	"\U0001f1f2\U0001f1fd":             "mexico",
+	"\U0001f1f2\U0001f1fe":             "flag-my",
+	"\U0001f1f2\U0001f1ff":             "flag-mz",
+	"\U0001f1f3\U0001f1e6":             "flag-na",
+	"\U0001f1f3\U0001f1e8":             "flag-nc",
+	"\U0001f1f3\U0001f1ea":             "niger",
+	"\U0001f1f3\U0001f1eb":             "flag-nf",
+	"\U0001f1f3\U0001f1ec":             "flag-ng",
+	"\U0001f1f3\U0001f1ee":             "flag-ni",
+	"\U0001f1f3\U0001f1f1":             "flag-nl",
+	"\U0001f1f3\U0001f1f4":             "norway",
+	"\U0001f1f3\U0001f1f5":             "nepal",
+	"\U0001f1f3\U0001f1f7":             "nauru",
+	"\U0001f1f3\U0001f1fa":             "niue",
+	"\U0001f1f3\U0001f1ff":             "flag-nz",
+	"\U0001f1f4\U0001f1f2":             "oman",
+	"\U0001f1f5\U0001f1e6":             "panama",
+	"\U0001f1f5\U0001f1ea":             "peru",
+	"\U0001f1f5\U0001f1eb":             "flag-pf",
+	"\U0001f1f5\U0001f1ec":             "flag-pg",
+	"\U0001f1f5\U0001f1ed":             "flag-ph",
+	"\U0001f1f5\U0001f1f0":             "pk",
+	"\U0001f1f5\U0001f1f1":             "poland",
+	"\U0001f1f5\U0001f1f2":             "flag-pm",
+	"\U0001f1f5\U0001f1f3":             "flag-pn",
+	"\U0001f1f5\U0001f1f7":             "flag-pr",
+	"\U0001f1f5\U0001f1f8":             "flag-ps",
+	"\U0001f1f5\U0001f1f9":             "flag-pt",
+	"\U0001f1f5\U0001f1fc":             "palau",
+	"\U0001f1f5\U0001f1fe":             "flag-py",
+	"\U0001f1f6\U0001f1e6":             "qatar",
+	"\U0001f1f7\U0001f1ea":             "flag-re",
+	"\U0001f1f7\U0001f1f4":             "flag-ro",
+	"\U0001f1f7\U0001f1f8":             "serbia",
+	"\U0001f1f7\U0001f1fa":             "ru",
+	"\U0001f1f7\U0001f1fc":             "rwanda",
+	"\U0001f1f8\U0001f1e6":             "flag-sa",
+	"\U0001f1f8\U0001f1e7":             "flag-sb",
+	"\U0001f1f8\U0001f1e8":             "flag-sc",
+	"\U0001f1f8\U0001f1e9":             "sudan",
+	"\U0001f1f8\U0001f1ea":             "sweden",
+	"\U0001f1f8\U0001f1ec":             "flag-sg",
+	"\U0001f1f8\U0001f1ed":             "flag-sh",
+	"\U0001f1f8\U0001f1ee":             "flag-si",
+	"\U0001f1f8\U0001f1ef":             "flag-sj",
+	"\U0001f1f8\U0001f1f0":             "flag-sk",
+	"\U0001f1f8\U0001f1f1":             "flag-sl",
+	"\U0001f1f8\U0001f1f2":             "flag-sm",
+	"\U0001f1f8\U0001f1f3":             "flag-sn",
+	"\U0001f1f8\U0001f1f4":             "flag-so",
+	"\U0001f1f8\U0001f1f7":             "flag-sr",
+	"\U0001f1f8\U0001f1f8":             "flag-ss",
+	"\U0001f1f8\U0001f1f9":             "flag-st",
+	"\U0001f1f8\U0001f1fb":             "flag-sv",
+	"\U0001f1f8\U0001f1fd":             "flag-sx",
+	"\U0001f1f8\U0001f1fe":             "syria",
+	"\U0001f1f8\U0001f1ff":             "flag-sz",
+	"\U0001f1f9\U0001f1e6":             "flag-ta",
+	"\U0001f1f9\U0001f1e8":             "flag-tc",
+	"\U0001f1f9\U0001f1e9":             "chad",
+	"\U0001f1f9\U0001f1eb":             "flag-tf",
+	"\U0001f1f9\U0001f1ec":             "togo",
+	"\U0001f1f9\U0001f1ed":             "flag-th",
+	"\U0001f1f9\U0001f1ef":             "flag-tj",
+	"\U0001f1f9\U0001f1f0":             "flag-tk",
+	"\U0001f1f9\U0001f1f1":             "flag-tl",
+	"\U0001f1f9\U0001f1f2":             "flag-tm",
+	"\U0001f1f9\U0001f1f3":             "flag-tn",
+	"\U0001f1f9\U0001f1f4":             "tonga",
+	"\U0001f1f9\U0001f1f7":             "tr",
+	"\U0001f1f9\U0001f1f9":             "flag-tt",
+	"\U0001f1f9\U0001f1fb":             "tuvalu",
+	"\U0001f1f9\U0001f1fc":             "taiwan",
+	"\U0001f1f9\U0001f1ff":             "flag-tz",
+	"\U0001f1fa\U0001f1e6":             "flag-ua",
+	"\U0001f1fa\U0001f1ec":             "uganda",
+	"\U0001f1fa\U0001f1f2":             "flag-um",
+	"\U0001f1fa\U0001f1f3":             "flag-un",
+	"\U0001f1fa\U0001f1f8":             "us",
+	"\U0001f1fa\U0001f1fe":             "flag-uy",
+	"\U0001f1fa\U0001f1ff":             "flag-uz",
+	"\U0001f1fb\U0001f1e6":             "flag-va",
+	"\U0001f1fb\U0001f1e8":             "flag-vc",
+	"\U0001f1fb\U0001f1ea":             "flag-ve",
+	"\U0001f1fb\U0001f1ec":             "flag-vg",
+	"\U0001f1fb\U0001f1ee":             "flag-vi",
+	"\U0001f1fb\U0001f1f3":             "flag-vn",
+	"\U0001f1fb\U0001f1fa":             "flag-vu",
+	"\U0001f1fc\U0001f1eb":             "flag-wf",
+	"\U0001f1fc\U0001f1f8":             "samoa",
+	"\U0001f1fd\U0001f1f0":             "kosovo",
+	"\U0001f1fe\U0001f1ea":             "yemen",
+	"\U0001f1fe\U0001f1f9":             "flag-yt",
+	"\U0001f1ff\U0001f1e6":             "za",
+	"\U0001f1ff\U0001f1f2":             "zambia",
+	"\U0001f1ff\U0001f1fc":             "flag-zw",
+	"\U0001f201":                       "koko",
+	"\U0001f202":                       "sa",
+	"\U0001f21a":                       "u7121",
+	"\U0001f22f":                       "u6307",
+	"\U0001f232":                       "u7981",
+	"\U0001f233":                       "u7a7a",
+	"\U0001f234":                       "u5408",
+	"\U0001f235":                       "u6e80",
+	"\U0001f236":                       "u6709",
+	"\U0001f237":                       "u6708",
+	"\U0001f238":                       "u7533",
+	"\U0001f239":                       "u5272",
+	"\U0001f23a":                       "u55b6",
+	"\U0001f250":                       "ideograph_advantage",
+	"\U0001f251":                       "accept",
+	"\U0001f300":                       "cyclone",
+	"\U0001f301":                       "foggy",
+	"\U0001f302":                       "closed_umbrella",
+	"\U0001f303":                       "night_with_stars",
+	"\U0001f304":                       "sunrise_over_mountains",
+	"\U0001f305":                       "sunrise",
+	"\U0001f306":                       "city_sunset",
+	"\U0001f307":                       "city_sunrise",
+	"\U0001f308":                       "rainbow",
+	"\U0001f309":                       "bridge_at_night",
+	"\U0001f30a":                       "ocean",
+	"\U0001f30b":                       "volcano",
+	"\U0001f30c":                       "milky_way",
+	"\U0001f30d":                       "earth_africa",
+	"\U0001f30e":                       "earth_americas",
+	"\U0001f30f":                       "earth_asia",
+	"\U0001f310":                       "globe_with_meridians",
+	"\U0001f311":                       "new_moon",
+	"\U0001f312":                       "waxing_crescent_moon",
+	"\U0001f313":                       "first_quarter_moon",
+	"\U0001f314":                       "moon",
+	"\U0001f315":                       "full_moon",
+	"\U0001f316":                       "waning_gibbous_moon",
+	"\U0001f317":                       "last_quarter_moon",
+	"\U0001f318":                       "waning_crescent_moon",
+	"\U0001f319":                       "crescent_moon",
+	"\U0001f31a":                       "new_moon_with_face",
+	"\U0001f31b":                       "first_quarter_moon_with_face",
+	"\U0001f31c":                       "last_quarter_moon_with_face",
+	"\U0001f31d":                       "full_moon_with_face",
+	"\U0001f31e":                       "sun_with_face",
+	"\U0001f31f":                       "star2",
+	"\U0001f320":                       "stars",
+	"\U0001f321":                       "thermometer",
+	"\U0001f324":                       "mostly_sunny",
+	"\U0001f325":                       "barely_sunny",
+	"\U0001f326":                       "partly_sunny_rain",
+	"\U0001f327":                       "rain_cloud",
+	"\U0001f328":                       "snow_cloud",
+	"\U0001f329":                       "lightning",
+	"\U0001f32a":                       "tornado",
+	"\U0001f32b":                       "fog",
+	"\U0001f32c":                       "wind_face",
+	"\U0001f32d":                       "hotdog",
+	"\U0001f32e":                       "taco",
+	"\U0001f32f":                       "burrito",
+	"\U0001f330":                       "chestnut",
+	"\U0001f331":                       "seedling",
+	"\U0001f332":                       "evergreen_tree",
+	"\U0001f333":                       "deciduous_tree",
+	"\U0001f334":                       "palm_tree",
+	"\U0001f335":                       "cactus",
+	"\U0001f336":                       "hot_pepper",
+	"\U0001f337":                       "tulip",
+	"\U0001f338":                       "cherry_blossom",
+	"\U0001f339":                       "rose",
+	"\U0001f33a":                       "hibiscus",
+	"\U0001f33b":                       "sunflower",
+	"\U0001f33c":                       "blossom",
+	"\U0001f33d":                       "corn",
+	"\U0001f33e":                       "ear_of_rice",
+	"\U0001f33f":                       "herb",
+	"\U0001f340":                       "four_leaf_clover",
+	"\U0001f341":                       "maple_leaf",
+	"\U0001f342":                       "fallen_leaf",
+	"\U0001f343":                       "leaves",
+	"\U0001f344":                       "mushroom",
+	"\U0001f345":                       "tomato",
+	"\U0001f346":                       "eggplant",
+	"\U0001f347":                       "grapes",
+	"\U0001f348":                       "melon",
+	"\U0001f349":                       "watermelon",
+	"\U0001f34a":                       "orange",
+	"\U0001f34b":                       "lemon",
+	"\U0001f34c":                       "banana",
+	"\U0001f34d":                       "pineapple",
+	"\U0001f34e":                       "apple",
+	"\U0001f34f":                       "green_apple",
+	"\U0001f350":                       "pear",
+	"\U0001f351":                       "peach",
+	"\U0001f352":                       "cherries",
+	"\U0001f353":                       "strawberry",
+	"\U0001f354":                       "hamburger",
+	"\U0001f355":                       "pizza",
+	"\U0001f356":                       "meat_on_bone",
+	"\U0001f357":                       "poultry_leg",
+	"\U0001f358":                       "rice_cracker",
+	"\U0001f359":                       "rice_ball",
+	"\U0001f35a":                       "rice",
+	"\U0001f35b":                       "curry",
+	"\U0001f35c":                       "ramen",
+	"\U0001f35d":                       "spaghetti",
+	"\U0001f35e":                       "bread",
+	"\U0001f35f":                       "fries",
+	"\U0001f360":                       "sweet_potato",
+	"\U0001f361":                       "dango",
+	"\U0001f362":                       "oden",
+	"\U0001f363":                       "sushi",
+	"\U0001f364":                       "fried_shrimp",
+	"\U0001f365":                       "fish_cake",
+	"\U0001f366":                       "icecream",
+	"\U0001f367":                       "shaved_ice",
+	"\U0001f368":                       "ice_cream",
+	"\U0001f369":                       "doughnut",
+	"\U0001f36a":                       "cookie",
+	"\U0001f36b":                       "chocolate_bar",
+	"\U0001f36c":                       "candy",
+	"\U0001f36d":                       "lollipop",
+	"\U0001f36e":                       "custard",
+	"\U0001f36f":                       "honey_pot",
+	"\U0001f370":                       "cake",
+	"\U0001f371":                       "bento",
+	"\U0001f372":                       "stew",
+	"\U0001f373":                       "cooking",
+	"\U0001f374":                       "fork_and_knife",
+	"\U0001f375":                       "tea",
+	"\U0001f376":                       "sake",
+	"\U0001f377":                       "wine_glass",
+	"\U0001f378":                       "cocktail",
+	"\U0001f379":                       "tropical_drink",
+	"\U0001f37a":                       "beer",
+	"\U0001f37b":                       "beers",
+	"\U0001f37c":                       "baby_bottle",
+	"\U0001f37d":                       "knife_fork_plate",
+	"\U0001f37e":                       "champagne",
+	"\U0001f37f":                       "popcorn",
+	"\U0001f380":                       "ribbon",
+	"\U0001f381":                       "gift",
+	"\U0001f382":                       "birthday",
+	"\U0001f383":                       "jack_o_lantern",
+	"\U0001f384":                       "christmas_tree",
+	"\U0001f385":                       "santa",
+	"\U0001f385\U0001f3fb":             "santa_light_skin_tone",
+	"\U0001f385\U0001f3fc":             "santa_medium_light_skin_tone",
+	"\U0001f385\U0001f3fd":             "santa_medium_skin_tone",
+	"\U0001f385\U0001f3fe":             "santa_medium_dark_skin_tone",
+	"\U0001f385\U0001f3ff":             "santa_dark_skin_tone",
+	"\U0001f386":                       "fireworks",
+	"\U0001f387":                       "sparkler",
+	"\U0001f388":                       "balloon",
+	"\U0001f389":                       "tada",
+	"\U0001f38a":                       "confetti_ball",
+	"\U0001f38b":                       "tanabata_tree",
+	"\U0001f38c":                       "crossed_flags",
+	"\U0001f38d":                       "bamboo",
+	"\U0001f38e":                       "dolls",
+	"\U0001f38f":                       "flags",
+	"\U0001f390":                       "wind_chime",
+	"\U0001f391":                       "rice_scene",
+	"\U0001f392":                       "school_satchel",
+	"\U0001f393":                       "mortar_board",
+	"\U0001f396":                       "medal",
+	"\U0001f397":                       "reminder_ribbon",
+	"\U0001f399":                       "studio_microphone",
+	"\U0001f39a":                       "level_slider",
+	"\U0001f39b":                       "control_knobs",
+	"\U0001f39e":                       "film_strip",
+	"\U0001f39f":                       "tickets",
+	"\U0001f3a0":                       "carousel_horse",
+	"\U0001f3a1":                       "ferris_wheel",
+	"\U0001f3a2":                       "roller_coaster",
+	"\U0001f3a3":                       "fishing_pole_and_fish",
+	"\U0001f3a4":                       "microphone",
+	"\U0001f3a5":                       "movie_camera",
+	"\U0001f3a6":                       "cinema",
+	"\U0001f3a7":                       "headphones",
+	"\U0001f3a8":                       "art",
+	"\U0001f3a9":                       "tophat",
+	"\U0001f3aa":                       "circus_tent",
+	"\U0001f3ab":                       "ticket",
+	"\U0001f3ac":                       "clapper",
+	"\U0001f3ad":                       "performing_arts",
+	"\U0001f3ae":                       "video_game",
+	"\U0001f3af":                       "dart",
+	"\U0001f3b0":                       "slot_machine",
+	"\U0001f3b1":                       "8ball",
+	"\U0001f3b2":                       "game_die",
+	"\U0001f3b3":                       "bowling",
+	"\U0001f3b4":                       "flower_playing_cards",
+	"\U0001f3b5":                       "musical_note",
+	"\U0001f3b6":                       "notes",
+	"\U0001f3b7":                       "saxophone",
+	"\U0001f3b8":                       "guitar",
+	"\U0001f3b9":                       "musical_keyboard",
+	"\U0001f3ba":                       "trumpet",
+	"\U0001f3bb":                       "violin",
+	"\U0001f3bc":                       "musical_score",
+	"\U0001f3bd":                       "running_shirt_with_sash",
+	"\U0001f3be":                       "tennis",
+	"\U0001f3bf":                       "ski",
+	"\U0001f3c0":                       "basketball",
+	"\U0001f3c1":                       "checkered_flag",
+	"\U0001f3c2":                       "snowboarder",
+	"\U0001f3c2\U0001f3fb":             "snowboarder_light_skin_tone",
+	"\U0001f3c2\U0001f3fc":             "snowboarder_medium_light_skin_tone",
+	"\U0001f3c2\U0001f3fd":             "snowboarder_medium_skin_tone",
+	"\U0001f3c2\U0001f3fe":             "snowboarder_medium_dark_skin_tone",
+	"\U0001f3c2\U0001f3ff":             "snowboarder_dark_skin_tone",
+	"\U0001f3c3":                       "runner",
+	"\U0001f3c3\u200d\u2640":           "woman-running",
+	"\U0001f3c3\u200d\u2642":           "man-running",
+	"\U0001f3c3\U0001f3fb":             "runner_light_skin_tone",
+	"\U0001f3c3\U0001f3fb\u200d\u2640": "woman-running_light_skin_tone",
+	"\U0001f3c3\U0001f3fb\u200d\u2642": "man-running_light_skin_tone",
+	"\U0001f3c3\U0001f3fc":             "runner_medium_light_skin_tone",
+	"\U0001f3c3\U0001f3fc\u200d\u2640": "woman-running_medium_light_skin_tone",
+	"\U0001f3c3\U0001f3fc\u200d\u2642": "man-running_medium_light_skin_tone",
+	"\U0001f3c3\U0001f3fd":             "runner_medium_skin_tone",
+	"\U0001f3c3\U0001f3fd\u200d\u2640": "woman-running_medium_skin_tone",
+	"\U0001f3c3\U0001f3fd\u200d\u2642": "man-running_medium_skin_tone",
+	"\U0001f3c3\U0001f3fe":             "runner_medium_dark_skin_tone",
+	"\U0001f3c3\U0001f3fe\u200d\u2640": "woman-running_medium_dark_skin_tone",
+	"\U0001f3c3\U0001f3fe\u200d\u2642": "man-running_medium_dark_skin_tone",
+	"\U0001f3c3\U0001f3ff":             "runner_dark_skin_tone",
+	"\U0001f3c3\U0001f3ff\u200d\u2640": "woman-running_dark_skin_tone",
+	"\U0001f3c3\U0001f3ff\u200d\u2642": "man-running_dark_skin_tone",
+	"\U0001f3c4":                       "surfer",
+	"\U0001f3c4\u200d\u2640":           "woman-surfing",
+	"\U0001f3c4\u200d\u2642":           "man-surfing",
+	"\U0001f3c4\U0001f3fb":             "surfer_light_skin_tone",
+	"\U0001f3c4\U0001f3fb\u200d\u2640": "woman-surfing_light_skin_tone",
+	"\U0001f3c4\U0001f3fb\u200d\u2642": "man-surfing_light_skin_tone",
+	"\U0001f3c4\U0001f3fc":             "surfer_medium_light_skin_tone",
+	"\U0001f3c4\U0001f3fc\u200d\u2640": "woman-surfing_medium_light_skin_tone",
+	"\U0001f3c4\U0001f3fc\u200d\u2642": "man-surfing_medium_light_skin_tone",
+	"\U0001f3c4\U0001f3fd":             "surfer_medium_skin_tone",
+	"\U0001f3c4\U0001f3fd\u200d\u2640": "woman-surfing_medium_skin_tone",
+	"\U0001f3c4\U0001f3fd\u200d\u2642": "man-surfing_medium_skin_tone",
+	"\U0001f3c4\U0001f3fe":             "surfer_medium_dark_skin_tone",
+	"\U0001f3c4\U0001f3fe\u200d\u2640": "woman-surfing_medium_dark_skin_tone",
+	"\U0001f3c4\U0001f3fe\u200d\u2642": "man-surfing_medium_dark_skin_tone",
+	"\U0001f3c4\U0001f3ff":             "surfer_dark_skin_tone",
+	"\U0001f3c4\U0001f3ff\u200d\u2640": "woman-surfing_dark_skin_tone",
+	"\U0001f3c4\U0001f3ff\u200d\u2642": "man-surfing_dark_skin_tone",
+	"\U0001f3c5":                       "sports_medal",
+	"\U0001f3c6":                       "trophy",
+	"\U0001f3c7":                       "horse_racing",
+	"\U0001f3c7\U0001f3fb":             "horse_racing_light_skin_tone",
+	"\U0001f3c7\U0001f3fc":             "horse_racing_medium_light_skin_tone",
+	"\U0001f3c7\U0001f3fd":             "horse_racing_medium_skin_tone",
+	"\U0001f3c7\U0001f3fe":             "horse_racing_medium_dark_skin_tone",
+	"\U0001f3c7\U0001f3ff":             "horse_racing_dark_skin_tone",
+	"\U0001f3c8":                       "football",
+	"\U0001f3c9":                       "rugby_football",
+	"\U0001f3ca":                       "swimmer",
+	"\U0001f3ca\u200d\u2640":           "woman-swimming",
+	"\U0001f3ca\u200d\u2642":           "man-swimming",
+	"\U0001f3ca\U0001f3fb":             "swimmer_light_skin_tone",
+	"\U0001f3ca\U0001f3fb\u200d\u2640": "woman-swimming_light_skin_tone",
+	"\U0001f3ca\U0001f3fb\u200d\u2642": "man-swimming_light_skin_tone",
+	"\U0001f3ca\U0001f3fc":             "swimmer_medium_light_skin_tone",
+	"\U0001f3ca\U0001f3fc\u200d\u2640": "woman-swimming_medium_light_skin_tone",
+	"\U0001f3ca\U0001f3fc\u200d\u2642": "man-swimming_medium_light_skin_tone",
+	"\U0001f3ca\U0001f3fd":             "swimmer_medium_skin_tone",
+	"\U0001f3ca\U0001f3fd\u200d\u2640": "woman-swimming_medium_skin_tone",
+	"\U0001f3ca\U0001f3fd\u200d\u2642": "man-swimming_medium_skin_tone",
+	"\U0001f3ca\U0001f3fe":             "swimmer_medium_dark_skin_tone",
+	"\U0001f3ca\U0001f3fe\u200d\u2640": "woman-swimming_medium_dark_skin_tone",
+	"\U0001f3ca\U0001f3fe\u200d\u2642": "man-swimming_medium_dark_skin_tone",
+	"\U0001f3ca\U0001f3ff":             "swimmer_dark_skin_tone",
+	"\U0001f3ca\U0001f3ff\u200d\u2640": "woman-swimming_dark_skin_tone",
+	"\U0001f3ca\U0001f3ff\u200d\u2642": "man-swimming_dark_skin_tone",
+	"\U0001f3cb":                       "weight_lifter",
+	"\U0001f3cb\u200d\u2640":           "weight_lifting_woman",
+	"\U0001f3cb\u200d\u2642":           "weight_lifting_man",
+	"\U0001f3cb\U0001f3fb":             "weight_lifter_light_skin_tone",
+	"\U0001f3cb\U0001f3fb\u200d\u2640": "weight_lifting_woman_light_skin_tone",
+	"\U0001f3cb\U0001f3fb\u200d\u2642": "weight_lifting_man_light_skin_tone",
+	"\U0001f3cb\U0001f3fc":             "weight_lifter_medium_light_skin_tone",
+	"\U0001f3cb\U0001f3fc\u200d\u2640": "weight_lifting_woman_medium_light_skin_tone",
+	"\U0001f3cb\U0001f3fc\u200d\u2642": "weight_lifting_man_medium_light_skin_tone",
+	"\U0001f3cb\U0001f3fd":             "weight_lifter_medium_skin_tone",
+	"\U0001f3cb\U0001f3fd\u200d\u2640": "weight_lifting_woman_medium_skin_tone",
+	"\U0001f3cb\U0001f3fd\u200d\u2642": "weight_lifting_man_medium_skin_tone",
+	"\U0001f3cb\U0001f3fe":             "weight_lifter_medium_dark_skin_tone",
+	"\U0001f3cb\U0001f3fe\u200d\u2640": "weight_lifting_woman_medium_dark_skin_tone",
+	"\U0001f3cb\U0001f3fe\u200d\u2642": "weight_lifting_man_medium_dark_skin_tone",
+	"\U0001f3cb\U0001f3ff":             "weight_lifter_dark_skin_tone",
+	"\U0001f3cb\U0001f3ff\u200d\u2640": "weight_lifting_woman_dark_skin_tone",
+	"\U0001f3cb\U0001f3ff\u200d\u2642": "weight_lifting_man_dark_skin_tone",
+	"\U0001f3cc":                       "golfer",
+	"\U0001f3cc\u200d\u2640":           "woman-golfing",
+	"\U0001f3cc\u200d\u2642":           "man-golfing",
+	"\U0001f3cc\U0001f3fb":             "golfer_light_skin_tone",
+	"\U0001f3cc\U0001f3fb\u200d\u2640": "woman-golfing_light_skin_tone",
+	"\U0001f3cc\U0001f3fb\u200d\u2642": "man-golfing_light_skin_tone",
+	"\U0001f3cc\U0001f3fc":             "golfer_medium_light_skin_tone",
+	"\U0001f3cc\U0001f3fc\u200d\u2640": "woman-golfing_medium_light_skin_tone",
+	"\U0001f3cc\U0001f3fc\u200d\u2642": "man-golfing_medium_light_skin_tone",
+	"\U0001f3cc\U0001f3fd":             "golfer_medium_skin_tone",
+	"\U0001f3cc\U0001f3fd\u200d\u2640": "woman-golfing_medium_skin_tone",
+	"\U0001f3cc\U0001f3fd\u200d\u2642": "man-golfing_medium_skin_tone",
+	"\U0001f3cc\U0001f3fe":             "golfer_medium_dark_skin_tone",
+	"\U0001f3cc\U0001f3fe\u200d\u2640": "woman-golfing_medium_dark_skin_tone",
+	"\U0001f3cc\U0001f3fe\u200d\u2642": "man-golfing_medium_dark_skin_tone",
+	"\U0001f3cc\U0001f3ff":             "golfer_dark_skin_tone",
+	"\U0001f3cc\U0001f3ff\u200d\u2640": "woman-golfing_dark_skin_tone",
+	"\U0001f3cc\U0001f3ff\u200d\u2642": "man-golfing_dark_skin_tone",
+	"\U0001f3cd":                       "motorcycle",
+	"\U0001f3ce":                       "racing_car",
+	"\U0001f3cf":                       "cricket_bat_and_ball",
+	"\U0001f3d0":                       "volleyball",
+	"\U0001f3d1":                       "field_hockey",
+	"\U0001f3d2":                       "ice_hockey",
+	"\U0001f3d3":                       "ping_pong",
+	"\U0001f3d4":                       "mountain_snow",
+	"\U0001f3d5":                       "camping",
+	"\U0001f3d6":                       "beach_umbrella",
+	"\U0001f3d7":                       "building_construction",
+	"\U0001f3d8":                       "houses",
+	"\U0001f3d9":                       "cityscape",
+	"\U0001f3da":                       "derelict_house",
+	"\U0001f3db":                       "classical_building",
+	"\U0001f3dc":                       "desert",
+	"\U0001f3dd":                       "desert_island",
+	"\U0001f3de":                       "national_park",
+	"\U0001f3df":                       "stadium",
+	"\U0001f3e0":                       "house",
+	"\U0001f3e1":                       "house_with_garden",
+	"\U0001f3e2":                       "office",
+	"\U0001f3e3":                       "post_office",
+	"\U0001f3e4":                       "european_post_office",
+	"\U0001f3e5":                       "hospital",
+	"\U0001f3e6":                       "bank",
+	"\U0001f3e7":                       "atm",
+	"\U0001f3e8":                       "hotel",
+	"\U0001f3e9":                       "love_hotel",
+	"\U0001f3ea":                       "convenience_store",
+	"\U0001f3eb":                       "school",
+	"\U0001f3ec":                       "department_store",
+	"\U0001f3ed":                       "factory",
+	"\U0001f3ee":                       "lantern",
+	"\U0001f3ef":                       "japanese_castle",
+	"\U0001f3f0":                       "european_castle",
+	"\U0001f3f3":                       "white_flag",
+	"\U0001f3f3\u200d\u26a7":           "transgender_flag",
+	"\U0001f3f3\u200d\U0001f308":       "rainbow-flag",
+	"\U0001f3f4":                       "black_flag",
+	"\U0001f3f4\u200d\u2620":           "pirate_flag",
+	"\U0001f3f4\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f": "flag-england",
+	"\U0001f3f4\U000e0067\U000e0062\U000e0073\U000e0063\U000e0074\U000e007f": "flag-scotland",
+	"\U0001f3f4\U000e0067\U000e0062\U000e0077\U000e006c\U000e0073\U000e007f": "flag-wales",
+	"\U0001f3f5":                             "rosette",
+	"\U0001f3f7":                             "label",
+	"\U0001f3f8":                             "badminton",
+	"\U0001f3f9":                             "bow_and_arrow",
+	"\U0001f3fa":                             "amphora",
+	"\U0001f3fb":                             "skin-tone-2",
+	"\U0001f3fc":                             "skin-tone-3",
+	"\U0001f3fd":                             "skin-tone-4",
+	"\U0001f3fe":                             "skin-tone-5",
+	"\U0001f3ff":                             "skin-tone-6",
+	"\U0001f400":                             "rat",
+	"\U0001f401":                             "mouse2",
+	"\U0001f402":                             "ox",
+	"\U0001f403":                             "water_buffalo",
+	"\U0001f404":                             "cow2",
+	"\U0001f405":                             "tiger2",
+	"\U0001f406":                             "leopard",
+	"\U0001f407":                             "rabbit2",
+	"\U0001f408":                             "cat2",
+	"\U0001f408\u200d\u2b1b":                 "black_cat",
+	"\U0001f409":                             "dragon",
+	"\U0001f40a":                             "crocodile",
+	"\U0001f40b":                             "whale2",
+	"\U0001f40c":                             "snail",
+	"\U0001f40d":                             "snake",
+	"\U0001f40e":                             "racehorse",
+	"\U0001f40f":                             "ram",
+	"\U0001f410":                             "goat",
+	"\U0001f411":                             "sheep",
+	"\U0001f412":                             "monkey",
+	"\U0001f413":                             "rooster",
+	"\U0001f414":                             "chicken",
+	"\U0001f415":                             "dog2",
+	"\U0001f415\u200d\U0001f9ba":             "service_dog",
+	"\U0001f416":                             "pig2",
+	"\U0001f417":                             "boar",
+	"\U0001f418":                             "elephant",
+	"\U0001f419":                             "octopus",
+	"\U0001f41a":                             "shell",
+	"\U0001f41b":                             "bug",
+	"\U0001f41c":                             "ant",
+	"\U0001f41d":                             "bee",
+	"\U0001f41e":                             "ladybug",
+	"\U0001f41f":                             "fish",
+	"\U0001f420":                             "tropical_fish",
+	"\U0001f421":                             "blowfish",
+	"\U0001f422":                             "turtle",
+	"\U0001f423":                             "hatching_chick",
+	"\U0001f424":                             "baby_chick",
+	"\U0001f425":                             "hatched_chick",
+	"\U0001f426":                             "bird",
+	"\U0001f427":                             "penguin",
+	"\U0001f428":                             "koala",
+	"\U0001f429":                             "poodle",
+	"\U0001f42a":                             "dromedary_camel",
+	"\U0001f42b":                             "camel",
+	"\U0001f42c":                             "dolphin",
+	"\U0001f42d":                             "mouse",
+	"\U0001f42e":                             "cow",
+	"\U0001f42f":                             "tiger",
+	"\U0001f430":                             "rabbit",
+	"\U0001f431":                             "cat",
+	"\U0001f432":                             "dragon_face",
+	"\U0001f433":                             "whale",
+	"\U0001f434":                             "horse",
+	"\U0001f435":                             "monkey_face",
+	"\U0001f436":                             "dog",
+	"\U0001f437":                             "pig",
+	"\U0001f438":                             "frog",
+	"\U0001f439":                             "hamster",
+	"\U0001f43a":                             "wolf",
+	"\U0001f43b":                             "bear",
+	"\U0001f43b\u200d\u2744":                 "polar_bear",
+	"\U0001f43c":                             "panda_face",
+	"\U0001f43d":                             "pig_nose",
+	"\U0001f43e":                             "feet",
+	"\U0001f43f":                             "chipmunk",
+	"\U0001f440":                             "eyes",
+	"\U0001f441":                             "eye",
+	"\U0001f441\u200d\U0001f5e8":             "eye-in-speech-bubble",
+	"\U0001f442":                             "ear",
+	"\U0001f442\U0001f3fb":                   "ear_light_skin_tone",
+	"\U0001f442\U0001f3fc":                   "ear_medium_light_skin_tone",
+	"\U0001f442\U0001f3fd":                   "ear_medium_skin_tone",
+	"\U0001f442\U0001f3fe":                   "ear_medium_dark_skin_tone",
+	"\U0001f442\U0001f3ff":                   "ear_dark_skin_tone",
+	"\U0001f443":                             "nose",
+	"\U0001f443\U0001f3fb":                   "nose_light_skin_tone",
+	"\U0001f443\U0001f3fc":                   "nose_medium_light_skin_tone",
+	"\U0001f443\U0001f3fd":                   "nose_medium_skin_tone",
+	"\U0001f443\U0001f3fe":                   "nose_medium_dark_skin_tone",
+	"\U0001f443\U0001f3ff":                   "nose_dark_skin_tone",
+	"\U0001f444":                             "lips",
+	"\U0001f445":                             "tongue",
+	"\U0001f446":                             "point_up_2",
+	"\U0001f446\U0001f3fb":                   "point_up_2_light_skin_tone",
+	"\U0001f446\U0001f3fc":                   "point_up_2_medium_light_skin_tone",
+	"\U0001f446\U0001f3fd":                   "point_up_2_medium_skin_tone",
+	"\U0001f446\U0001f3fe":                   "point_up_2_medium_dark_skin_tone",
+	"\U0001f446\U0001f3ff":                   "point_up_2_dark_skin_tone",
+	"\U0001f447":                             "point_down",
+	"\U0001f447\U0001f3fb":                   "point_down_light_skin_tone",
+	"\U0001f447\U0001f3fc":                   "point_down_medium_light_skin_tone",
+	"\U0001f447\U0001f3fd":                   "point_down_medium_skin_tone",
+	"\U0001f447\U0001f3fe":                   "point_down_medium_dark_skin_tone",
+	"\U0001f447\U0001f3ff":                   "point_down_dark_skin_tone",
+	"\U0001f448":                             "point_left",
+	"\U0001f448\U0001f3fb":                   "point_left_light_skin_tone",
+	"\U0001f448\U0001f3fc":                   "point_left_medium_light_skin_tone",
+	"\U0001f448\U0001f3fd":                   "point_left_medium_skin_tone",
+	"\U0001f448\U0001f3fe":                   "point_left_medium_dark_skin_tone",
+	"\U0001f448\U0001f3ff":                   "point_left_dark_skin_tone",
+	"\U0001f449":                             "point_right",
+	"\U0001f449\U0001f3fb":                   "point_right_light_skin_tone",
+	"\U0001f449\U0001f3fc":                   "point_right_medium_light_skin_tone",
+	"\U0001f449\U0001f3fd":                   "point_right_medium_skin_tone",
+	"\U0001f449\U0001f3fe":                   "point_right_medium_dark_skin_tone",
+	"\U0001f449\U0001f3ff":                   "point_right_dark_skin_tone",
+	"\U0001f44a":                             "punch",
+	"\U0001f44a\U0001f3fb":                   "punch_light_skin_tone",
+	"\U0001f44a\U0001f3fc":                   "punch_medium_light_skin_tone",
+	"\U0001f44a\U0001f3fd":                   "punch_medium_skin_tone",
+	"\U0001f44a\U0001f3fe":                   "punch_medium_dark_skin_tone",
+	"\U0001f44a\U0001f3ff":                   "punch_dark_skin_tone",
+	"\U0001f44b":                             "wave",
+	"\U0001f44b\U0001f3fb":                   "wave_light_skin_tone",
+	"\U0001f44b\U0001f3fc":                   "wave_medium_light_skin_tone",
+	"\U0001f44b\U0001f3fd":                   "wave_medium_skin_tone",
+	"\U0001f44b\U0001f3fe":                   "wave_medium_dark_skin_tone",
+	"\U0001f44b\U0001f3ff":                   "wave_dark_skin_tone",
+	"\U0001f44c":                             "ok_hand",
+	"\U0001f44c\U0001f3fb":                   "ok_hand_light_skin_tone",
+	"\U0001f44c\U0001f3fc":                   "ok_hand_medium_light_skin_tone",
+	"\U0001f44c\U0001f3fd":                   "ok_hand_medium_skin_tone",
+	"\U0001f44c\U0001f3fe":                   "ok_hand_medium_dark_skin_tone",
+	"\U0001f44c\U0001f3ff":                   "ok_hand_dark_skin_tone",
+	"\U0001f44d":                             "+1",
+	"\U0001f44d\U0001f3fb":                   "+1_light_skin_tone",
+	"\U0001f44d\U0001f3fc":                   "+1_medium_light_skin_tone",
+	"\U0001f44d\U0001f3fd":                   "+1_medium_skin_tone",
+	"\U0001f44d\U0001f3fe":                   "+1_medium_dark_skin_tone",
+	"\U0001f44d\U0001f3ff":                   "+1_dark_skin_tone",
+	"\U0001f44e":                             "-1",
+	"\U0001f44e\U0001f3fb":                   "-1_light_skin_tone",
+	"\U0001f44e\U0001f3fc":                   "-1_medium_light_skin_tone",
+	"\U0001f44e\U0001f3fd":                   "-1_medium_skin_tone",
+	"\U0001f44e\U0001f3fe":                   "-1_medium_dark_skin_tone",
+	"\U0001f44e\U0001f3ff":                   "-1_dark_skin_tone",
+	"\U0001f44f":                             "clap",
+	"\U0001f44f\U0001f3fb":                   "clap_light_skin_tone",
+	"\U0001f44f\U0001f3fc":                   "clap_medium_light_skin_tone",
+	"\U0001f44f\U0001f3fd":                   "clap_medium_skin_tone",
+	"\U0001f44f\U0001f3fe":                   "clap_medium_dark_skin_tone",
+	"\U0001f44f\U0001f3ff":                   "clap_dark_skin_tone",
+	"\U0001f450":                             "open_hands",
+	"\U0001f450\U0001f3fb":                   "open_hands_light_skin_tone",
+	"\U0001f450\U0001f3fc":                   "open_hands_medium_light_skin_tone",
+	"\U0001f450\U0001f3fd":                   "open_hands_medium_skin_tone",
+	"\U0001f450\U0001f3fe":                   "open_hands_medium_dark_skin_tone",
+	"\U0001f450\U0001f3ff":                   "open_hands_dark_skin_tone",
+	"\U0001f451":                             "crown",
+	"\U0001f452":                             "womans_hat",
+	"\U0001f453":                             "eyeglasses",
+	"\U0001f454":                             "necktie",
+	"\U0001f455":                             "shirt",
+	"\U0001f456":                             "jeans",
+	"\U0001f457":                             "dress",
+	"\U0001f458":                             "kimono",
+	"\U0001f459":                             "bikini",
+	"\U0001f45a":                             "womans_clothes",
+	"\U0001f45b":                             "purse",
+	"\U0001f45c":                             "handbag",
+	"\U0001f45d":                             "pouch",
+	"\U0001f45e":                             "shoe",
+	"\U0001f45f":                             "athletic_shoe",
+	"\U0001f460":                             "high_heel",
+	"\U0001f461":                             "sandal",
+	"\U0001f462":                             "boot",
+	"\U0001f463":                             "footprints",
+	"\U0001f464":                             "bust_in_silhouette",
+	"\U0001f465":                             "busts_in_silhouette",
+	"\U0001f466":                             "boy",
+	"\U0001f466\U0001f3fb":                   "boy_light_skin_tone",
+	"\U0001f466\U0001f3fc":                   "boy_medium_light_skin_tone",
+	"\U0001f466\U0001f3fd":                   "boy_medium_skin_tone",
+	"\U0001f466\U0001f3fe":                   "boy_medium_dark_skin_tone",
+	"\U0001f466\U0001f3ff":                   "boy_dark_skin_tone",
+	"\U0001f467":                             "girl",
+	"\U0001f467\U0001f3fb":                   "girl_light_skin_tone",
+	"\U0001f467\U0001f3fc":                   "girl_medium_light_skin_tone",
+	"\U0001f467\U0001f3fd":                   "girl_medium_skin_tone",
+	"\U0001f467\U0001f3fe":                   "girl_medium_dark_skin_tone",
+	"\U0001f467\U0001f3ff":                   "girl_dark_skin_tone",
+	"\U0001f468":                             "man",
+	"\U0001f468\u200d\u2695":                 "male-doctor",
+	"\U0001f468\u200d\u2696":                 "man_judge",
+	"\U0001f468\u200d\u2708":                 "man_pilot",
+	"\U0001f468\u200d\u2764\u200d\U0001f468": "man-heart-man",
+	"\U0001f468\u200d\u2764\u200d\U0001f48b\u200d\U0001f468":         "man-kiss-man",
+	"\U0001f468\u200d\U0001f33e":                                     "man_farmer",
+	"\U0001f468\u200d\U0001f373":                                     "man_cook",
+	"\U0001f468\u200d\U0001f37c":                                     "man_feeding_baby",
+	"\U0001f468\u200d\U0001f393":                                     "man_student",
+	"\U0001f468\u200d\U0001f3a4":                                     "man_singer",
+	"\U0001f468\u200d\U0001f3a8":                                     "man_artist",
+	"\U0001f468\u200d\U0001f3eb":                                     "man_teacher",
+	"\U0001f468\u200d\U0001f3ed":                                     "man_factory_worker",
+	"\U0001f468\u200d\U0001f466":                                     "man-boy",
+	"\U0001f468\u200d\U0001f466\u200d\U0001f466":                     "man-boy-boy",
+	"\U0001f468\u200d\U0001f467":                                     "man-girl",
+	"\U0001f468\u200d\U0001f467\u200d\U0001f466":                     "man-girl-boy",
+	"\U0001f468\u200d\U0001f467\u200d\U0001f467":                     "man-girl-girl",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f466":                     "man-man-boy",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f466\u200d\U0001f466":     "man-man-boy-boy",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f467":                     "man-man-girl",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f466":     "man-man-girl-boy",
+	"\U0001f468\u200d\U0001f468\u200d\U0001f467\u200d\U0001f467":     "man-man-girl-girl",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f466":                     "man-woman-boy",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466":     "man-woman-boy-boy",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f467":                     "man-woman-girl",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466":     "man-woman-girl-boy",
+	"\U0001f468\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467":     "man-woman-girl-girl",
+	"\U0001f468\u200d\U0001f4bb":                                     "man_technologist",
+	"\U0001f468\u200d\U0001f4bc":                                     "man_office_worker",
+	"\U0001f468\u200d\U0001f527":                                     "man_mechanic",
+	"\U0001f468\u200d\U0001f52c":                                     "man_scientist",
+	"\U0001f468\u200d\U0001f680":                                     "man_astronaut",
+	"\U0001f468\u200d\U0001f692":                                     "man_firefighter",
+	"\U0001f468\u200d\U0001f9af":                                     "man_with_probing_cane",
+	"\U0001f468\u200d\U0001f9b0":                                     "red_haired_man",
+	"\U0001f468\u200d\U0001f9b1":                                     "curly_haired_man",
+	"\U0001f468\u200d\U0001f9b2":                                     "bald_man",
+	"\U0001f468\u200d\U0001f9b3":                                     "white_haired_man",
+	"\U0001f468\u200d\U0001f9bc":                                     "man_in_motorized_wheelchair",
+	"\U0001f468\u200d\U0001f9bd":                                     "man_in_manual_wheelchair",
+	"\U0001f468\U0001f3fb":                                           "man_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\u2695":                               "male-doctor_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\u2696":                               "man_judge_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\u2708":                               "man_pilot_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f33e":                           "man_farmer_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f373":                           "man_cook_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f37c":                           "man_feeding_baby_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f393":                           "man_student_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f3a4":                           "man_singer_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f3a8":                           "man_artist_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f3eb":                           "man_teacher_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f3ed":                           "man_factory_worker_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f4bb":                           "man_technologist_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f4bc":                           "man_office_worker_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f527":                           "man_mechanic_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f52c":                           "man_scientist_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f680":                           "man_astronaut_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f692":                           "man_firefighter_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "men_holding_hands_light_skin_tone_medium_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "men_holding_hands_light_skin_tone_medium_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "men_holding_hands_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "men_holding_hands_light_skin_tone_dark_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f9af":                           "man_with_probing_cane_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f9b0":                           "red_haired_man_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f9b1":                           "curly_haired_man_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f9b2":                           "bald_man_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f9b3":                           "white_haired_man_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f9bc":                           "man_in_motorized_wheelchair_light_skin_tone",
+	"\U0001f468\U0001f3fb\u200d\U0001f9bd":                           "man_in_manual_wheelchair_light_skin_tone",
+	"\U0001f468\U0001f3fc":                                           "man_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\u2695":                               "male-doctor_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\u2696":                               "man_judge_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\u2708":                               "man_pilot_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f33e":                           "man_farmer_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f373":                           "man_cook_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f37c":                           "man_feeding_baby_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f393":                           "man_student_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f3a4":                           "man_singer_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f3a8":                           "man_artist_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f3eb":                           "man_teacher_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f3ed":                           "man_factory_worker_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f4bb":                           "man_technologist_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f4bc":                           "man_office_worker_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f527":                           "man_mechanic_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f52c":                           "man_scientist_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f680":                           "man_astronaut_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f692":                           "man_firefighter_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "men_holding_hands_medium_light_skin_tone_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "men_holding_hands_medium_light_skin_tone_medium_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "men_holding_hands_medium_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "men_holding_hands_medium_light_skin_tone_dark_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f9af":                           "man_with_probing_cane_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f9b0":                           "red_haired_man_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f9b1":                           "curly_haired_man_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f9b2":                           "bald_man_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f9b3":                           "white_haired_man_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f9bc":                           "man_in_motorized_wheelchair_medium_light_skin_tone",
+	"\U0001f468\U0001f3fc\u200d\U0001f9bd":                           "man_in_manual_wheelchair_medium_light_skin_tone",
+	"\U0001f468\U0001f3fd":                                           "man_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\u2695":                               "male-doctor_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\u2696":                               "man_judge_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\u2708":                               "man_pilot_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f33e":                           "man_farmer_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f373":                           "man_cook_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f37c":                           "man_feeding_baby_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f393":                           "man_student_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f3a4":                           "man_singer_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f3a8":                           "man_artist_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f3eb":                           "man_teacher_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f3ed":                           "man_factory_worker_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f4bb":                           "man_technologist_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f4bc":                           "man_office_worker_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f527":                           "man_mechanic_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f52c":                           "man_scientist_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f680":                           "man_astronaut_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f692":                           "man_firefighter_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "men_holding_hands_medium_skin_tone_light_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "men_holding_hands_medium_skin_tone_medium_light_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "men_holding_hands_medium_skin_tone_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "men_holding_hands_medium_skin_tone_dark_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f9af":                           "man_with_probing_cane_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f9b0":                           "red_haired_man_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f9b1":                           "curly_haired_man_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f9b2":                           "bald_man_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f9b3":                           "white_haired_man_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f9bc":                           "man_in_motorized_wheelchair_medium_skin_tone",
+	"\U0001f468\U0001f3fd\u200d\U0001f9bd":                           "man_in_manual_wheelchair_medium_skin_tone",
+	"\U0001f468\U0001f3fe":                                           "man_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\u2695":                               "male-doctor_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\u2696":                               "man_judge_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\u2708":                               "man_pilot_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f33e":                           "man_farmer_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f373":                           "man_cook_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f37c":                           "man_feeding_baby_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f393":                           "man_student_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f3a4":                           "man_singer_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f3a8":                           "man_artist_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f3eb":                           "man_teacher_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f3ed":                           "man_factory_worker_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f4bb":                           "man_technologist_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f4bc":                           "man_office_worker_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f527":                           "man_mechanic_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f52c":                           "man_scientist_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f680":                           "man_astronaut_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f692":                           "man_firefighter_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "men_holding_hands_medium_dark_skin_tone_light_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "men_holding_hands_medium_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "men_holding_hands_medium_dark_skin_tone_medium_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "men_holding_hands_medium_dark_skin_tone_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f9af":                           "man_with_probing_cane_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f9b0":                           "red_haired_man_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f9b1":                           "curly_haired_man_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f9b2":                           "bald_man_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f9b3":                           "white_haired_man_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f9bc":                           "man_in_motorized_wheelchair_medium_dark_skin_tone",
+	"\U0001f468\U0001f3fe\u200d\U0001f9bd":                           "man_in_manual_wheelchair_medium_dark_skin_tone",
+	"\U0001f468\U0001f3ff":                                           "man_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\u2695":                               "male-doctor_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\u2696":                               "man_judge_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\u2708":                               "man_pilot_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f33e":                           "man_farmer_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f373":                           "man_cook_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f37c":                           "man_feeding_baby_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f393":                           "man_student_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f3a4":                           "man_singer_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f3a8":                           "man_artist_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f3eb":                           "man_teacher_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f3ed":                           "man_factory_worker_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f4bb":                           "man_technologist_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f4bc":                           "man_office_worker_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f527":                           "man_mechanic_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f52c":                           "man_scientist_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f680":                           "man_astronaut_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f692":                           "man_firefighter_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "men_holding_hands_dark_skin_tone_light_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "men_holding_hands_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "men_holding_hands_dark_skin_tone_medium_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "men_holding_hands_dark_skin_tone_medium_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f9af":                           "man_with_probing_cane_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f9b0":                           "red_haired_man_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f9b1":                           "curly_haired_man_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f9b2":                           "bald_man_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f9b3":                           "white_haired_man_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f9bc":                           "man_in_motorized_wheelchair_dark_skin_tone",
+	"\U0001f468\U0001f3ff\u200d\U0001f9bd":                           "man_in_manual_wheelchair_dark_skin_tone",
+	"\U0001f469":                                                     "woman",
+	"\U0001f469\u200d\u2695":                                         "female-doctor",
+	"\U0001f469\u200d\u2696":                                         "woman_judge",
+	"\U0001f469\u200d\u2708":                                         "woman_pilot",
+	"\U0001f469\u200d\u2764\u200d\U0001f468":                         "woman-heart-man",
+	"\U0001f469\u200d\u2764\u200d\U0001f469":                         "woman-heart-woman",
+	"\U0001f469\u200d\u2764\u200d\U0001f48b\u200d\U0001f468":         "woman-kiss-man",
+	"\U0001f469\u200d\u2764\u200d\U0001f48b\u200d\U0001f469":         "woman-kiss-woman",
+	"\U0001f469\u200d\U0001f33e":                                     "woman_farmer",
+	"\U0001f469\u200d\U0001f373":                                     "woman_cook",
+	"\U0001f469\u200d\U0001f37c":                                     "woman_feeding_baby",
+	"\U0001f469\u200d\U0001f393":                                     "woman_student",
+	"\U0001f469\u200d\U0001f3a4":                                     "woman_singer",
+	"\U0001f469\u200d\U0001f3a8":                                     "woman_artist",
+	"\U0001f469\u200d\U0001f3eb":                                     "woman_teacher",
+	"\U0001f469\u200d\U0001f3ed":                                     "woman_factory_worker",
+	"\U0001f469\u200d\U0001f466":                                     "woman-boy",
+	"\U0001f469\u200d\U0001f466\u200d\U0001f466":                     "woman-boy-boy",
+	"\U0001f469\u200d\U0001f467":                                     "woman-girl",
+	"\U0001f469\u200d\U0001f467\u200d\U0001f466":                     "woman-girl-boy",
+	"\U0001f469\u200d\U0001f467\u200d\U0001f467":                     "woman-girl-girl",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f466":                     "woman-woman-boy",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f466\u200d\U0001f466":     "woman-woman-boy-boy",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f467":                     "woman-woman-girl",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f466":     "woman-woman-girl-boy",
+	"\U0001f469\u200d\U0001f469\u200d\U0001f467\u200d\U0001f467":     "woman-woman-girl-girl",
+	"\U0001f469\u200d\U0001f4bb":                                     "woman_technologist",
+	"\U0001f469\u200d\U0001f4bc":                                     "woman_office_worker",
+	"\U0001f469\u200d\U0001f527":                                     "woman_mechanic",
+	"\U0001f469\u200d\U0001f52c":                                     "woman_scientist",
+	"\U0001f469\u200d\U0001f680":                                     "woman_astronaut",
+	"\U0001f469\u200d\U0001f692":                                     "woman_firefighter",
+	"\U0001f469\u200d\U0001f9af":                                     "woman_with_probing_cane",
+	"\U0001f469\u200d\U0001f9b0":                                     "red_haired_woman",
+	"\U0001f469\u200d\U0001f9b1":                                     "curly_haired_woman",
+	"\U0001f469\u200d\U0001f9b2":                                     "bald_woman",
+	"\U0001f469\u200d\U0001f9b3":                                     "white_haired_woman",
+	"\U0001f469\u200d\U0001f9bc":                                     "woman_in_motorized_wheelchair",
+	"\U0001f469\u200d\U0001f9bd":                                     "woman_in_manual_wheelchair",
+	"\U0001f469\U0001f3fb":                                           "woman_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\u2695":                               "female-doctor_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\u2696":                               "woman_judge_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\u2708":                               "woman_pilot_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f33e":                           "woman_farmer_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f373":                           "woman_cook_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f37c":                           "woman_feeding_baby_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f393":                           "woman_student_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f3a4":                           "woman_singer_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f3a8":                           "woman_artist_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f3eb":                           "woman_teacher_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f3ed":                           "woman_factory_worker_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f4bb":                           "woman_technologist_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f4bc":                           "woman_office_worker_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f527":                           "woman_mechanic_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f52c":                           "woman_scientist_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f680":                           "woman_astronaut_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f692":                           "woman_firefighter_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "couple_light_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "couple_light_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "couple_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "couple_light_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fc": "women_holding_hands_light_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fd": "women_holding_hands_light_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3fe": "women_holding_hands_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f91d\u200d\U0001f469\U0001f3ff": "women_holding_hands_light_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f9af":                           "woman_with_probing_cane_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f9b0":                           "red_haired_woman_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f9b1":                           "curly_haired_woman_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f9b2":                           "bald_woman_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f9b3":                           "white_haired_woman_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f9bc":                           "woman_in_motorized_wheelchair_light_skin_tone",
+	"\U0001f469\U0001f3fb\u200d\U0001f9bd":                           "woman_in_manual_wheelchair_light_skin_tone",
+	"\U0001f469\U0001f3fc":                                           "woman_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\u2695":                               "female-doctor_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\u2696":                               "woman_judge_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\u2708":                               "woman_pilot_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f33e":                           "woman_farmer_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f373":                           "woman_cook_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f37c":                           "woman_feeding_baby_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f393":                           "woman_student_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f3a4":                           "woman_singer_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f3a8":                           "woman_artist_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f3eb":                           "woman_teacher_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f3ed":                           "woman_factory_worker_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f4bb":                           "woman_technologist_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f4bc":                           "woman_office_worker_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f527":                           "woman_mechanic_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f52c":                           "woman_scientist_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f680":                           "woman_astronaut_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f692":                           "woman_firefighter_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "couple_medium_light_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "couple_medium_light_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "couple_medium_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "couple_medium_light_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fb": "women_holding_hands_medium_light_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fd": "women_holding_hands_medium_light_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3fe": "women_holding_hands_medium_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f91d\u200d\U0001f469\U0001f3ff": "women_holding_hands_medium_light_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f9af":                           "woman_with_probing_cane_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f9b0":                           "red_haired_woman_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f9b1":                           "curly_haired_woman_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f9b2":                           "bald_woman_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f9b3":                           "white_haired_woman_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f9bc":                           "woman_in_motorized_wheelchair_medium_light_skin_tone",
+	"\U0001f469\U0001f3fc\u200d\U0001f9bd":                           "woman_in_manual_wheelchair_medium_light_skin_tone",
+	"\U0001f469\U0001f3fd":                                           "woman_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\u2695":                               "female-doctor_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\u2696":                               "woman_judge_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\u2708":                               "woman_pilot_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f33e":                           "woman_farmer_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f373":                           "woman_cook_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f37c":                           "woman_feeding_baby_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f393":                           "woman_student_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f3a4":                           "woman_singer_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f3a8":                           "woman_artist_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f3eb":                           "woman_teacher_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f3ed":                           "woman_factory_worker_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f4bb":                           "woman_technologist_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f4bc":                           "woman_office_worker_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f527":                           "woman_mechanic_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f52c":                           "woman_scientist_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f680":                           "woman_astronaut_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f692":                           "woman_firefighter_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "couple_medium_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "couple_medium_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "couple_medium_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "couple_medium_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fb": "women_holding_hands_medium_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fc": "women_holding_hands_medium_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3fe": "women_holding_hands_medium_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f91d\u200d\U0001f469\U0001f3ff": "women_holding_hands_medium_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f9af":                           "woman_with_probing_cane_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f9b0":                           "red_haired_woman_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f9b1":                           "curly_haired_woman_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f9b2":                           "bald_woman_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f9b3":                           "white_haired_woman_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f9bc":                           "woman_in_motorized_wheelchair_medium_skin_tone",
+	"\U0001f469\U0001f3fd\u200d\U0001f9bd":                           "woman_in_manual_wheelchair_medium_skin_tone",
+	"\U0001f469\U0001f3fe":                                           "woman_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\u2695":                               "female-doctor_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\u2696":                               "woman_judge_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\u2708":                               "woman_pilot_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f33e":                           "woman_farmer_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f373":                           "woman_cook_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f37c":                           "woman_feeding_baby_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f393":                           "woman_student_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f3a4":                           "woman_singer_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f3a8":                           "woman_artist_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f3eb":                           "woman_teacher_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f3ed":                           "woman_factory_worker_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f4bb":                           "woman_technologist_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f4bc":                           "woman_office_worker_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f527":                           "woman_mechanic_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f52c":                           "woman_scientist_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f680":                           "woman_astronaut_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f692":                           "woman_firefighter_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "couple_medium_dark_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "couple_medium_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "couple_medium_dark_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f468\U0001f3ff": "couple_medium_dark_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fb": "women_holding_hands_medium_dark_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fc": "women_holding_hands_medium_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3fd": "women_holding_hands_medium_dark_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f91d\u200d\U0001f469\U0001f3ff": "women_holding_hands_medium_dark_skin_tone_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f9af":                           "woman_with_probing_cane_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f9b0":                           "red_haired_woman_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f9b1":                           "curly_haired_woman_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f9b2":                           "bald_woman_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f9b3":                           "white_haired_woman_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f9bc":                           "woman_in_motorized_wheelchair_medium_dark_skin_tone",
+	"\U0001f469\U0001f3fe\u200d\U0001f9bd":                           "woman_in_manual_wheelchair_medium_dark_skin_tone",
+	"\U0001f469\U0001f3ff":                                           "woman_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\u2695":                               "female-doctor_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\u2696":                               "woman_judge_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\u2708":                               "woman_pilot_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f33e":                           "woman_farmer_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f373":                           "woman_cook_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f37c":                           "woman_feeding_baby_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f393":                           "woman_student_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f3a4":                           "woman_singer_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f3a8":                           "woman_artist_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f3eb":                           "woman_teacher_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f3ed":                           "woman_factory_worker_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f4bb":                           "woman_technologist_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f4bc":                           "woman_office_worker_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f527":                           "woman_mechanic_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f52c":                           "woman_scientist_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f680":                           "woman_astronaut_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f692":                           "woman_firefighter_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fb": "couple_dark_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fc": "couple_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fd": "couple_dark_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f468\U0001f3fe": "couple_dark_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fb": "women_holding_hands_dark_skin_tone_light_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fc": "women_holding_hands_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fd": "women_holding_hands_dark_skin_tone_medium_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f91d\u200d\U0001f469\U0001f3fe": "women_holding_hands_dark_skin_tone_medium_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f9af":                           "woman_with_probing_cane_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f9b0":                           "red_haired_woman_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f9b1":                           "curly_haired_woman_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f9b2":                           "bald_woman_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f9b3":                           "white_haired_woman_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f9bc":                           "woman_in_motorized_wheelchair_dark_skin_tone",
+	"\U0001f469\U0001f3ff\u200d\U0001f9bd":                           "woman_in_manual_wheelchair_dark_skin_tone",
+	"\U0001f46a":                                                     "family",
+	"\U0001f46b":                                                     "couple",
+	"\U0001f46b\U0001f3fb":                                           "couple_light_skin_tone",
+	"\U0001f46b\U0001f3fc":                                           "couple_medium_light_skin_tone",
+	"\U0001f46b\U0001f3fd":                                           "couple_medium_skin_tone",
+	"\U0001f46b\U0001f3fe":                                           "couple_medium_dark_skin_tone",
+	"\U0001f46b\U0001f3ff":                                           "couple_dark_skin_tone",
+	"\U0001f46c":                                                     "men_holding_hands",
+	"\U0001f46c\U0001f3fb":                                           "men_holding_hands_light_skin_tone",
+	"\U0001f46c\U0001f3fc":                                           "men_holding_hands_medium_light_skin_tone",
+	"\U0001f46c\U0001f3fd":                                           "men_holding_hands_medium_skin_tone",
+	"\U0001f46c\U0001f3fe":                                           "men_holding_hands_medium_dark_skin_tone",
+	"\U0001f46c\U0001f3ff":                                           "men_holding_hands_dark_skin_tone",
+	"\U0001f46d":                                                     "women_holding_hands",
+	"\U0001f46d\U0001f3fb":                                           "women_holding_hands_light_skin_tone",
+	"\U0001f46d\U0001f3fc":                                           "women_holding_hands_medium_light_skin_tone",
+	"\U0001f46d\U0001f3fd":                                           "women_holding_hands_medium_skin_tone",
+	"\U0001f46d\U0001f3fe":                                           "women_holding_hands_medium_dark_skin_tone",
+	"\U0001f46d\U0001f3ff":                                           "women_holding_hands_dark_skin_tone",
+	"\U0001f46e":                                                     "cop",
+	"\U0001f46e\u200d\u2640":                                         "policewoman",
+	"\U0001f46e\u200d\u2642":                                         "policeman",
+	"\U0001f46e\U0001f3fb":                                           "cop_light_skin_tone",
+	"\U0001f46e\U0001f3fb\u200d\u2640":                               "policewoman_light_skin_tone",
+	"\U0001f46e\U0001f3fb\u200d\u2642":                               "policeman_light_skin_tone",
+	"\U0001f46e\U0001f3fc":                                           "cop_medium_light_skin_tone",
+	"\U0001f46e\U0001f3fc\u200d\u2640":                               "policewoman_medium_light_skin_tone",
+	"\U0001f46e\U0001f3fc\u200d\u2642":                               "policeman_medium_light_skin_tone",
+	"\U0001f46e\U0001f3fd":                                           "cop_medium_skin_tone",
+	"\U0001f46e\U0001f3fd\u200d\u2640":                               "policewoman_medium_skin_tone",
+	"\U0001f46e\U0001f3fd\u200d\u2642":                               "policeman_medium_skin_tone",
+	"\U0001f46e\U0001f3fe":                                           "cop_medium_dark_skin_tone",
+	"\U0001f46e\U0001f3fe\u200d\u2640":                               "policewoman_medium_dark_skin_tone",
+	"\U0001f46e\U0001f3fe\u200d\u2642":                               "policeman_medium_dark_skin_tone",
+	"\U0001f46e\U0001f3ff":                                           "cop_dark_skin_tone",
+	"\U0001f46e\U0001f3ff\u200d\u2640":                               "policewoman_dark_skin_tone",
+	"\U0001f46e\U0001f3ff\u200d\u2642":                               "policeman_dark_skin_tone",
+	"\U0001f46f":                                                     "dancers",
+	"\U0001f46f\u200d\u2640":                                         "dancing_women",
+	"\U0001f46f\u200d\u2642":                                         "dancing_men",
+	"\U0001f470":                                                     "bride_with_veil",
+	"\U0001f470\u200d\u2640":                                         "woman_with_veil",
+	"\U0001f470\u200d\u2642":                                         "man_with_veil",
+	"\U0001f470\U0001f3fb":                                           "bride_with_veil_light_skin_tone",
+	"\U0001f470\U0001f3fb\u200d\u2640":                               "woman_with_veil_light_skin_tone",
+	"\U0001f470\U0001f3fb\u200d\u2642":                               "man_with_veil_light_skin_tone",
+	"\U0001f470\U0001f3fc":                                           "bride_with_veil_medium_light_skin_tone",
+	"\U0001f470\U0001f3fc\u200d\u2640":                               "woman_with_veil_medium_light_skin_tone",
+	"\U0001f470\U0001f3fc\u200d\u2642":                               "man_with_veil_medium_light_skin_tone",
+	"\U0001f470\U0001f3fd":                                           "bride_with_veil_medium_skin_tone",
+	"\U0001f470\U0001f3fd\u200d\u2640":                               "woman_with_veil_medium_skin_tone",
+	"\U0001f470\U0001f3fd\u200d\u2642":                               "man_with_veil_medium_skin_tone",
+	"\U0001f470\U0001f3fe":                                           "bride_with_veil_medium_dark_skin_tone",
+	"\U0001f470\U0001f3fe\u200d\u2640":                               "woman_with_veil_medium_dark_skin_tone",
+	"\U0001f470\U0001f3fe\u200d\u2642":                               "man_with_veil_medium_dark_skin_tone",
+	"\U0001f470\U0001f3ff":                                           "bride_with_veil_dark_skin_tone",
+	"\U0001f470\U0001f3ff\u200d\u2640":                               "woman_with_veil_dark_skin_tone",
+	"\U0001f470\U0001f3ff\u200d\u2642":                               "man_with_veil_dark_skin_tone",
+	"\U0001f471":                                                     "person_with_blond_hair",
+	"\U0001f471\u200d\u2640":                                         "blonde_woman",
+	"\U0001f471\u200d\u2642":                                         "blonde_man",
+	"\U0001f471\U0001f3fb":                                           "person_with_blond_hair_light_skin_tone",
+	"\U0001f471\U0001f3fb\u200d\u2640":                               "blonde_woman_light_skin_tone",
+	"\U0001f471\U0001f3fb\u200d\u2642":                               "blonde_man_light_skin_tone",
+	"\U0001f471\U0001f3fc":                                           "person_with_blond_hair_medium_light_skin_tone",
+	"\U0001f471\U0001f3fc\u200d\u2640":                               "blonde_woman_medium_light_skin_tone",
+	"\U0001f471\U0001f3fc\u200d\u2642":                               "blonde_man_medium_light_skin_tone",
+	"\U0001f471\U0001f3fd":                                           "person_with_blond_hair_medium_skin_tone",
+	"\U0001f471\U0001f3fd\u200d\u2640":                               "blonde_woman_medium_skin_tone",
+	"\U0001f471\U0001f3fd\u200d\u2642":                               "blonde_man_medium_skin_tone",
+	"\U0001f471\U0001f3fe":                                           "person_with_blond_hair_medium_dark_skin_tone",
+	"\U0001f471\U0001f3fe\u200d\u2640":                               "blonde_woman_medium_dark_skin_tone",
+	"\U0001f471\U0001f3fe\u200d\u2642":                               "blonde_man_medium_dark_skin_tone",
+	"\U0001f471\U0001f3ff":                                           "person_with_blond_hair_dark_skin_tone",
+	"\U0001f471\U0001f3ff\u200d\u2640":                               "blonde_woman_dark_skin_tone",
+	"\U0001f471\U0001f3ff\u200d\u2642":                               "blonde_man_dark_skin_tone",
+	"\U0001f472":                                                     "man_with_gua_pi_mao",
+	"\U0001f472\U0001f3fb":                                           "man_with_gua_pi_mao_light_skin_tone",
+	"\U0001f472\U0001f3fc":                                           "man_with_gua_pi_mao_medium_light_skin_tone",
+	"\U0001f472\U0001f3fd":                                           "man_with_gua_pi_mao_medium_skin_tone",
+	"\U0001f472\U0001f3fe":                                           "man_with_gua_pi_mao_medium_dark_skin_tone",
+	"\U0001f472\U0001f3ff":                                           "man_with_gua_pi_mao_dark_skin_tone",
+	"\U0001f473":                                                     "man_with_turban",
+	"\U0001f473\u200d\u2640":                                         "woman_with_turban",
+	"\U0001f473\u200d\u2642":                                         "man-wearing-turban",
+	"\U0001f473\U0001f3fb":                                           "man_with_turban_light_skin_tone",
+	"\U0001f473\U0001f3fb\u200d\u2640":                               "woman_with_turban_light_skin_tone",
+	"\U0001f473\U0001f3fb\u200d\u2642":                               "man-wearing-turban_light_skin_tone",
+	"\U0001f473\U0001f3fc":                                           "man_with_turban_medium_light_skin_tone",
+	"\U0001f473\U0001f3fc\u200d\u2640":                               "woman_with_turban_medium_light_skin_tone",
+	"\U0001f473\U0001f3fc\u200d\u2642":                               "man-wearing-turban_medium_light_skin_tone",
+	"\U0001f473\U0001f3fd":                                           "man_with_turban_medium_skin_tone",
+	"\U0001f473\U0001f3fd\u200d\u2640":                               "woman_with_turban_medium_skin_tone",
+	"\U0001f473\U0001f3fd\u200d\u2642":                               "man-wearing-turban_medium_skin_tone",
+	"\U0001f473\U0001f3fe":                                           "man_with_turban_medium_dark_skin_tone",
+	"\U0001f473\U0001f3fe\u200d\u2640":                               "woman_with_turban_medium_dark_skin_tone",
+	"\U0001f473\U0001f3fe\u200d\u2642":                               "man-wearing-turban_medium_dark_skin_tone",
+	"\U0001f473\U0001f3ff":                                           "man_with_turban_dark_skin_tone",
+	"\U0001f473\U0001f3ff\u200d\u2640":                               "woman_with_turban_dark_skin_tone",
+	"\U0001f473\U0001f3ff\u200d\u2642":                               "man-wearing-turban_dark_skin_tone",
+	"\U0001f474":                                                     "older_man",
+	"\U0001f474\U0001f3fb":                                           "older_man_light_skin_tone",
+	"\U0001f474\U0001f3fc":                                           "older_man_medium_light_skin_tone",
+	"\U0001f474\U0001f3fd":                                           "older_man_medium_skin_tone",
+	"\U0001f474\U0001f3fe":                                           "older_man_medium_dark_skin_tone",
+	"\U0001f474\U0001f3ff":                                           "older_man_dark_skin_tone",
+	"\U0001f475":                                                     "older_woman",
+	"\U0001f475\U0001f3fb":                                           "older_woman_light_skin_tone",
+	"\U0001f475\U0001f3fc":                                           "older_woman_medium_light_skin_tone",
+	"\U0001f475\U0001f3fd":                                           "older_woman_medium_skin_tone",
+	"\U0001f475\U0001f3fe":                                           "older_woman_medium_dark_skin_tone",
+	"\U0001f475\U0001f3ff":                                           "older_woman_dark_skin_tone",
+	"\U0001f476":                                                     "baby",
+	"\U0001f476\U0001f3fb":                                           "baby_light_skin_tone",
+	"\U0001f476\U0001f3fc":                                           "baby_medium_light_skin_tone",
+	"\U0001f476\U0001f3fd":                                           "baby_medium_skin_tone",
+	"\U0001f476\U0001f3fe":                                           "baby_medium_dark_skin_tone",
+	"\U0001f476\U0001f3ff":                                           "baby_dark_skin_tone",
+	"\U0001f477":                                                     "construction_worker",
+	"\U0001f477\u200d\u2640":                                         "construction_worker_woman",
+	"\U0001f477\u200d\u2642":                                         "construction_worker_man",
+	"\U0001f477\U0001f3fb":                                           "construction_worker_light_skin_tone",
+	"\U0001f477\U0001f3fb\u200d\u2640":                               "construction_worker_woman_light_skin_tone",
+	"\U0001f477\U0001f3fb\u200d\u2642":                               "construction_worker_man_light_skin_tone",
+	"\U0001f477\U0001f3fc":                                           "construction_worker_medium_light_skin_tone",
+	"\U0001f477\U0001f3fc\u200d\u2640":                               "construction_worker_woman_medium_light_skin_tone",
+	"\U0001f477\U0001f3fc\u200d\u2642":                               "construction_worker_man_medium_light_skin_tone",
+	"\U0001f477\U0001f3fd":                                           "construction_worker_medium_skin_tone",
+	"\U0001f477\U0001f3fd\u200d\u2640":                               "construction_worker_woman_medium_skin_tone",
+	"\U0001f477\U0001f3fd\u200d\u2642":                               "construction_worker_man_medium_skin_tone",
+	"\U0001f477\U0001f3fe":                                           "construction_worker_medium_dark_skin_tone",
+	"\U0001f477\U0001f3fe\u200d\u2640":                               "construction_worker_woman_medium_dark_skin_tone",
+	"\U0001f477\U0001f3fe\u200d\u2642":                               "construction_worker_man_medium_dark_skin_tone",
+	"\U0001f477\U0001f3ff":                                           "construction_worker_dark_skin_tone",
+	"\U0001f477\U0001f3ff\u200d\u2640":                               "construction_worker_woman_dark_skin_tone",
+	"\U0001f477\U0001f3ff\u200d\u2642":                               "construction_worker_man_dark_skin_tone",
+	"\U0001f478":                                                     "princess",
+	"\U0001f478\U0001f3fb":                                           "princess_light_skin_tone",
+	"\U0001f478\U0001f3fc":                                           "princess_medium_light_skin_tone",
+	"\U0001f478\U0001f3fd":                                           "princess_medium_skin_tone",
+	"\U0001f478\U0001f3fe":                                           "princess_medium_dark_skin_tone",
+	"\U0001f478\U0001f3ff":                                           "princess_dark_skin_tone",
+	"\U0001f479":                                                     "japanese_ogre",
+	"\U0001f47a":                                                     "japanese_goblin",
+	"\U0001f47b":                                                     "ghost",
+	"\U0001f47c":                                                     "angel",
+	"\U0001f47c\U0001f3fb":                                           "angel_light_skin_tone",
+	"\U0001f47c\U0001f3fc":                                           "angel_medium_light_skin_tone",
+	"\U0001f47c\U0001f3fd":                                           "angel_medium_skin_tone",
+	"\U0001f47c\U0001f3fe":                                           "angel_medium_dark_skin_tone",
+	"\U0001f47c\U0001f3ff":                                           "angel_dark_skin_tone",
+	"\U0001f47d":                                                     "alien",
+	"\U0001f47e":                                                     "space_invader",
+	"\U0001f47f":                                                     "imp",
+	"\U0001f480":                                                     "skull",
+	"\U0001f481":                                                     "information_desk_person",
+	"\U0001f481\u200d\u2640":                                         "woman-tipping-hand",
+	"\U0001f481\u200d\u2642":                                         "man-tipping-hand",
+	"\U0001f481\U0001f3fb":                                           "information_desk_person_light_skin_tone",
+	"\U0001f481\U0001f3fb\u200d\u2640":                               "woman-tipping-hand_light_skin_tone",
+	"\U0001f481\U0001f3fb\u200d\u2642":                               "man-tipping-hand_light_skin_tone",
+	"\U0001f481\U0001f3fc":                                           "information_desk_person_medium_light_skin_tone",
+	"\U0001f481\U0001f3fc\u200d\u2640":                               "woman-tipping-hand_medium_light_skin_tone",
+	"\U0001f481\U0001f3fc\u200d\u2642":                               "man-tipping-hand_medium_light_skin_tone",
+	"\U0001f481\U0001f3fd":                                           "information_desk_person_medium_skin_tone",
+	"\U0001f481\U0001f3fd\u200d\u2640":                               "woman-tipping-hand_medium_skin_tone",
+	"\U0001f481\U0001f3fd\u200d\u2642":                               "man-tipping-hand_medium_skin_tone",
+	"\U0001f481\U0001f3fe":                                           "information_desk_person_medium_dark_skin_tone",
+	"\U0001f481\U0001f3fe\u200d\u2640":                               "woman-tipping-hand_medium_dark_skin_tone",
+	"\U0001f481\U0001f3fe\u200d\u2642":                               "man-tipping-hand_medium_dark_skin_tone",
+	"\U0001f481\U0001f3ff":                                           "information_desk_person_dark_skin_tone",
+	"\U0001f481\U0001f3ff\u200d\u2640":                               "woman-tipping-hand_dark_skin_tone",
+	"\U0001f481\U0001f3ff\u200d\u2642":                               "man-tipping-hand_dark_skin_tone",
+	"\U0001f482":                                                     "guardsman",
+	"\U0001f482\u200d\u2640":                                         "guardswoman",
+	"\U0001f482\u200d\u2642":                                         "male-guard",
+	"\U0001f482\U0001f3fb":                                           "guardsman_light_skin_tone",
+	"\U0001f482\U0001f3fb\u200d\u2640":                               "guardswoman_light_skin_tone",
+	"\U0001f482\U0001f3fb\u200d\u2642":                               "male-guard_light_skin_tone",
+	"\U0001f482\U0001f3fc":                                           "guardsman_medium_light_skin_tone",
+	"\U0001f482\U0001f3fc\u200d\u2640":                               "guardswoman_medium_light_skin_tone",
+	"\U0001f482\U0001f3fc\u200d\u2642":                               "male-guard_medium_light_skin_tone",
+	"\U0001f482\U0001f3fd":                                           "guardsman_medium_skin_tone",
+	"\U0001f482\U0001f3fd\u200d\u2640":                               "guardswoman_medium_skin_tone",
+	"\U0001f482\U0001f3fd\u200d\u2642":                               "male-guard_medium_skin_tone",
+	"\U0001f482\U0001f3fe":                                           "guardsman_medium_dark_skin_tone",
+	"\U0001f482\U0001f3fe\u200d\u2640":                               "guardswoman_medium_dark_skin_tone",
+	"\U0001f482\U0001f3fe\u200d\u2642":                               "male-guard_medium_dark_skin_tone",
+	"\U0001f482\U0001f3ff":                                           "guardsman_dark_skin_tone",
+	"\U0001f482\U0001f3ff\u200d\u2640":                               "guardswoman_dark_skin_tone",
+	"\U0001f482\U0001f3ff\u200d\u2642":                               "male-guard_dark_skin_tone",
+	"\U0001f483":                                                     "dancer",
+	"\U0001f483\U0001f3fb":                                           "dancer_light_skin_tone",
+	"\U0001f483\U0001f3fc":                                           "dancer_medium_light_skin_tone",
+	"\U0001f483\U0001f3fd":                                           "dancer_medium_skin_tone",
+	"\U0001f483\U0001f3fe":                                           "dancer_medium_dark_skin_tone",
+	"\U0001f483\U0001f3ff":                                           "dancer_dark_skin_tone",
+	"\U0001f484":                                                     "lipstick",
+	"\U0001f485":                                                     "nail_care",
+	"\U0001f485\U0001f3fb":                                           "nail_care_light_skin_tone",
+	"\U0001f485\U0001f3fc":                                           "nail_care_medium_light_skin_tone",
+	"\U0001f485\U0001f3fd":                                           "nail_care_medium_skin_tone",
+	"\U0001f485\U0001f3fe":                                           "nail_care_medium_dark_skin_tone",
+	"\U0001f485\U0001f3ff":                                           "nail_care_dark_skin_tone",
+	"\U0001f486":                                                     "massage",
+	"\U0001f486\u200d\u2640":                                         "massage_woman",
+	"\U0001f486\u200d\u2642":                                         "massage_man",
+	"\U0001f486\U0001f3fb":                                           "massage_light_skin_tone",
+	"\U0001f486\U0001f3fb\u200d\u2640":                               "massage_woman_light_skin_tone",
+	"\U0001f486\U0001f3fb\u200d\u2642":                               "massage_man_light_skin_tone",
+	"\U0001f486\U0001f3fc":                                           "massage_medium_light_skin_tone",
+	"\U0001f486\U0001f3fc\u200d\u2640":                               "massage_woman_medium_light_skin_tone",
+	"\U0001f486\U0001f3fc\u200d\u2642":                               "massage_man_medium_light_skin_tone",
+	"\U0001f486\U0001f3fd":                                           "massage_medium_skin_tone",
+	"\U0001f486\U0001f3fd\u200d\u2640":                               "massage_woman_medium_skin_tone",
+	"\U0001f486\U0001f3fd\u200d\u2642":                               "massage_man_medium_skin_tone",
+	"\U0001f486\U0001f3fe":                                           "massage_medium_dark_skin_tone",
+	"\U0001f486\U0001f3fe\u200d\u2640":                               "massage_woman_medium_dark_skin_tone",
+	"\U0001f486\U0001f3fe\u200d\u2642":                               "massage_man_medium_dark_skin_tone",
+	"\U0001f486\U0001f3ff":                                           "massage_dark_skin_tone",
+	"\U0001f486\U0001f3ff\u200d\u2640":                               "massage_woman_dark_skin_tone",
+	"\U0001f486\U0001f3ff\u200d\u2642":                               "massage_man_dark_skin_tone",
+	"\U0001f487":                                                     "haircut",
+	"\U0001f487\u200d\u2640":                                         "haircut_woman",
+	"\U0001f487\u200d\u2642":                                         "haircut_man",
+	"\U0001f487\U0001f3fb":                                           "haircut_light_skin_tone",
+	"\U0001f487\U0001f3fb\u200d\u2640":                               "haircut_woman_light_skin_tone",
+	"\U0001f487\U0001f3fb\u200d\u2642":                               "haircut_man_light_skin_tone",
+	"\U0001f487\U0001f3fc":                                           "haircut_medium_light_skin_tone",
+	"\U0001f487\U0001f3fc\u200d\u2640":                               "haircut_woman_medium_light_skin_tone",
+	"\U0001f487\U0001f3fc\u200d\u2642":                               "haircut_man_medium_light_skin_tone",
+	"\U0001f487\U0001f3fd":                                           "haircut_medium_skin_tone",
+	"\U0001f487\U0001f3fd\u200d\u2640":                               "haircut_woman_medium_skin_tone",
+	"\U0001f487\U0001f3fd\u200d\u2642":                               "haircut_man_medium_skin_tone",
+	"\U0001f487\U0001f3fe":                                           "haircut_medium_dark_skin_tone",
+	"\U0001f487\U0001f3fe\u200d\u2640":                               "haircut_woman_medium_dark_skin_tone",
+	"\U0001f487\U0001f3fe\u200d\u2642":                               "haircut_man_medium_dark_skin_tone",
+	"\U0001f487\U0001f3ff":                                           "haircut_dark_skin_tone",
+	"\U0001f487\U0001f3ff\u200d\u2640":                               "haircut_woman_dark_skin_tone",
+	"\U0001f487\U0001f3ff\u200d\u2642":                               "haircut_man_dark_skin_tone",
+	"\U0001f488":                                                     "barber",
+	"\U0001f489":                                                     "syringe",
+	"\U0001f48a":                                                     "pill",
+	"\U0001f48b":                                                     "kiss",
+	"\U0001f48c":                                                     "love_letter",
+	"\U0001f48d":                                                     "ring",
+	"\U0001f48e":                                                     "gem",
+	"\U0001f48f":                                                     "couplekiss",
+	"\U0001f490":                                                     "bouquet",
+	"\U0001f491":                                                     "couple_with_heart",
+	"\U0001f492":                                                     "wedding",
+	"\U0001f493":                                                     "heartbeat",
+	"\U0001f494":                                                     "broken_heart",
+	"\U0001f495":                                                     "two_hearts",
+	"\U0001f496":                                                     "sparkling_heart",
+	"\U0001f497":                                                     "heartpulse",
+	"\U0001f498":                                                     "cupid",
+	"\U0001f499":                                                     "blue_heart",
+	"\U0001f49a":                                                     "green_heart",
+	"\U0001f49b":                                                     "yellow_heart",
+	"\U0001f49c":                                                     "purple_heart",
+	"\U0001f49d":                                                     "gift_heart",
+	"\U0001f49e":                                                     "revolving_hearts",
+	"\U0001f49f":                                                     "heart_decoration",
+	"\U0001f4a0":                                                     "diamond_shape_with_a_dot_inside",
+	"\U0001f4a1":                                                     "bulb",
+	"\U0001f4a2":                                                     "anger",
+	"\U0001f4a3":                                                     "bomb",
+	"\U0001f4a4":                                                     "zzz",
+	"\U0001f4a5":                                                     "boom",
+	"\U0001f4a6":                                                     "sweat_drops",
+	"\U0001f4a7":                                                     "droplet",
+	"\U0001f4a8":                                                     "dash",
+	"\U0001f4a9":                                                     "poop",
+	"\U0001f4aa":                                                     "muscle",
+	"\U0001f4aa\U0001f3fb":                                           "muscle_light_skin_tone",
+	"\U0001f4aa\U0001f3fc":                                           "muscle_medium_light_skin_tone",
+	"\U0001f4aa\U0001f3fd":                                           "muscle_medium_skin_tone",
+	"\U0001f4aa\U0001f3fe":                                           "muscle_medium_dark_skin_tone",
+	"\U0001f4aa\U0001f3ff":                                           "muscle_dark_skin_tone",
+	"\U0001f4ab":                                                     "dizzy",
+	"\U0001f4ac":                                                     "speech_balloon",
+	"\U0001f4ad":                                                     "thought_balloon",
+	"\U0001f4ae":                                                     "white_flower",
+	"\U0001f4af":                                                     "100",
+	"\U0001f4b0":                                                     "moneybag",
+	"\U0001f4b1":                                                     "currency_exchange",
+	"\U0001f4b2":                                                     "heavy_dollar_sign",
+	"\U0001f4b3":                                                     "credit_card",
+	"\U0001f4b4":                                                     "yen",
+	"\U0001f4b5":                                                     "dollar",
+	"\U0001f4b6":                                                     "euro",
+	"\U0001f4b7":                                                     "pound",
+	"\U0001f4b8":                                                     "money_with_wings",
+	"\U0001f4b9":                                                     "chart",
+	"\U0001f4ba":                                                     "seat",
+	"\U0001f4bb":                                                     "computer",
+	"\U0001f4bc":                                                     "briefcase",
+	"\U0001f4bd":                                                     "minidisc",
+	"\U0001f4be":                                                     "floppy_disk",
+	"\U0001f4bf":                                                     "cd",
+	"\U0001f4c0":                                                     "dvd",
+	"\U0001f4c1":                                                     "file_folder",
+	"\U0001f4c2":                                                     "open_file_folder",
+	"\U0001f4c3":                                                     "page_with_curl",
+	"\U0001f4c4":                                                     "page_facing_up",
+	"\U0001f4c5":                                                     "date",
+	"\U0001f4c6":                                                     "calendar",
+	"\U0001f4c7":                                                     "card_index",
+	"\U0001f4c8":                                                     "chart_with_upwards_trend",
+	"\U0001f4c9":                                                     "chart_with_downwards_trend",
+	"\U0001f4ca":                                                     "bar_chart",
+	"\U0001f4cb":                                                     "clipboard",
+	"\U0001f4cc":                                                     "pushpin",
+	"\U0001f4cd":                                                     "round_pushpin",
+	"\U0001f4ce":                                                     "paperclip",
+	"\U0001f4cf":                                                     "straight_ruler",
+	"\U0001f4d0":                                                     "triangular_ruler",
+	"\U0001f4d1":                                                     "bookmark_tabs",
+	"\U0001f4d2":                                                     "ledger",
+	"\U0001f4d3":                                                     "notebook",
+	"\U0001f4d4":                                                     "notebook_with_decorative_cover",
+	"\U0001f4d5":                                                     "closed_book",
+	"\U0001f4d6":                                                     "book",
+	"\U0001f4d7":                                                     "green_book",
+	"\U0001f4d8":                                                     "blue_book",
+	"\U0001f4d9":                                                     "orange_book",
+	"\U0001f4da":                                                     "books",
+	"\U0001f4db":                                                     "name_badge",
+	"\U0001f4dc":                                                     "scroll",
+	"\U0001f4dd":                                                     "memo",
+	"\U0001f4de":                                                     "telephone_receiver",
+	"\U0001f4df":                                                     "pager",
+	"\U0001f4e0":                                                     "fax",
+	"\U0001f4e1":                                                     "satellite_antenna",
+	"\U0001f4e2":                                                     "loudspeaker",
+	"\U0001f4e3":                                                     "mega",
+	"\U0001f4e4":                                                     "outbox_tray",
+	"\U0001f4e5":                                                     "inbox_tray",
+	"\U0001f4e6":                                                     "package",
+	"\U0001f4e7":                                                     "e-mail",
+	"\U0001f4e8":                                                     "incoming_envelope",
+	"\U0001f4e9":                                                     "envelope_with_arrow",
+	"\U0001f4ea":                                                     "mailbox_closed",
+	"\U0001f4eb":                                                     "mailbox",
+	"\U0001f4ec":                                                     "mailbox_with_mail",
+	"\U0001f4ed":                                                     "mailbox_with_no_mail",
+	"\U0001f4ee":                                                     "postbox",
+	"\U0001f4ef":                                                     "postal_horn",
+	"\U0001f4f0":                                                     "newspaper",
+	"\U0001f4f1":                                                     "iphone",
+	"\U0001f4f2":                                                     "calling",
+	"\U0001f4f3":                                                     "vibration_mode",
+	"\U0001f4f4":                                                     "mobile_phone_off",
+	"\U0001f4f5":                                                     "no_mobile_phones",
+	"\U0001f4f6":                                                     "signal_strength",
+	"\U0001f4f7":                                                     "camera",
+	"\U0001f4f8":                                                     "camera_flash",
+	"\U0001f4f9":                                                     "video_camera",
+	"\U0001f4fa":                                                     "tv",
+	"\U0001f4fb":                                                     "radio",
+	"\U0001f4fc":                                                     "vhs",
+	"\U0001f4fd":                                                     "film_projector",
+	"\U0001f4ff":                                                     "prayer_beads",
+	"\U0001f500":                                                     "twisted_rightwards_arrows",
+	"\U0001f501":                                                     "repeat",
+	"\U0001f502":                                                     "repeat_one",
+	"\U0001f503":                                                     "arrows_clockwise",
+	"\U0001f504":                                                     "arrows_counterclockwise",
+	"\U0001f505":                                                     "low_brightness",
+	"\U0001f506":                                                     "high_brightness",
+	"\U0001f507":                                                     "mute",
+	"\U0001f508":                                                     "speaker",
+	"\U0001f509":                                                     "sound",
+	"\U0001f50a":                                                     "loud_sound",
+	"\U0001f50b":                                                     "battery",
+	"\U0001f50c":                                                     "electric_plug",
+	"\U0001f50d":                                                     "mag",
+	"\U0001f50e":                                                     "mag_right",
+	"\U0001f50f":                                                     "lock_with_ink_pen",
+	"\U0001f510":                                                     "closed_lock_with_key",
+	"\U0001f511":                                                     "key",
+	"\U0001f512":                                                     "lock",
+	"\U0001f513":                                                     "unlock",
+	"\U0001f514":                                                     "bell",
+	"\U0001f515":                                                     "no_bell",
+	"\U0001f516":                                                     "bookmark",
+	"\U0001f517":                                                     "link",
+	"\U0001f518":                                                     "radio_button",
+	"\U0001f519":                                                     "back",
+	"\U0001f51a":                                                     "end",
+	"\U0001f51b":                                                     "on",
+	"\U0001f51c":                                                     "soon",
+	"\U0001f51d":                                                     "top",
+	"\U0001f51e":                                                     "underage",
+	"\U0001f51f":                                                     "keycap_ten",
+	"\U0001f520":                                                     "capital_abcd",
+	"\U0001f521":                                                     "abcd",
+	"\U0001f522":                                                     "1234",
+	"\U0001f523":                                                     "symbols",
+	"\U0001f524":                                                     "abc",
+	"\U0001f525":                                                     "fire",
+	"\U0001f526":                                                     "flashlight",
+	"\U0001f527":                                                     "wrench",
+	"\U0001f528":                                                     "hammer",
+	"\U0001f529":                                                     "nut_and_bolt",
+	"\U0001f52a":                                                     "hocho",
+	"\U0001f52b":                                                     "gun",
+	"\U0001f52c":                                                     "microscope",
+	"\U0001f52d":                                                     "telescope",
+	"\U0001f52e":                                                     "crystal_ball",
+	"\U0001f52f":                                                     "six_pointed_star",
+	"\U0001f530":                                                     "beginner",
+	"\U0001f531":                                                     "trident",
+	"\U0001f532":                                                     "black_square_button",
+	"\U0001f533":                                                     "white_square_button",
+	"\U0001f534":                                                     "red_circle",
+	"\U0001f535":                                                     "large_blue_circle",
+	"\U0001f536":                                                     "large_orange_diamond",
+	"\U0001f537":                                                     "large_blue_diamond",
+	"\U0001f538":                                                     "small_orange_diamond",
+	"\U0001f539":                                                     "small_blue_diamond",
+	"\U0001f53a":                                                     "small_red_triangle",
+	"\U0001f53b":                                                     "small_red_triangle_down",
+	"\U0001f53c":                                                     "arrow_up_small",
+	"\U0001f53d":                                                     "arrow_down_small",
+	"\U0001f549":                                                     "om",
+	"\U0001f54a":                                                     "dove",
+	"\U0001f54b":                                                     "kaaba",
+	"\U0001f54c":                                                     "mosque",
+	"\U0001f54d":                                                     "synagogue",
+	"\U0001f54e":                                                     "menorah",
+	"\U0001f550":                                                     "clock1",
+	"\U0001f551":                                                     "clock2",
+	"\U0001f552":                                                     "clock3",
+	"\U0001f553":                                                     "clock4",
+	"\U0001f554":                                                     "clock5",
+	"\U0001f555":                                                     "clock6",
+	"\U0001f556":                                                     "clock7",
+	"\U0001f557":                                                     "clock8",
+	"\U0001f558":                                                     "clock9",
+	"\U0001f559":                                                     "clock10",
+	"\U0001f55a":                                                     "clock11",
+	"\U0001f55b":                                                     "clock12",
+	"\U0001f55c":                                                     "clock130",
+	"\U0001f55d":                                                     "clock230",
+	"\U0001f55e":                                                     "clock330",
+	"\U0001f55f":                                                     "clock430",
+	"\U0001f560":                                                     "clock530",
+	"\U0001f561":                                                     "clock630",
+	"\U0001f562":                                                     "clock730",
+	"\U0001f563":                                                     "clock830",
+	"\U0001f564":                                                     "clock930",
+	"\U0001f565":                                                     "clock1030",
+	"\U0001f566":                                                     "clock1130",
+	"\U0001f567":                                                     "clock1230",
+	"\U0001f56f":                                                     "candle",
+	"\U0001f570":                                                     "mantelpiece_clock",
+	"\U0001f573":                                                     "hole",
+	"\U0001f574":                                                     "business_suit_levitating",
+	"\U0001f574\U0001f3fb":                                           "business_suit_levitating_light_skin_tone",
+	"\U0001f574\U0001f3fc":                                           "business_suit_levitating_medium_light_skin_tone",
+	"\U0001f574\U0001f3fd":                                           "business_suit_levitating_medium_skin_tone",
+	"\U0001f574\U0001f3fe":                                           "business_suit_levitating_medium_dark_skin_tone",
+	"\U0001f574\U0001f3ff":                                           "business_suit_levitating_dark_skin_tone",
+	"\U0001f575":                                                     "detective",
+	"\U0001f575\u200d\u2640":                                         "female-detective",
+	"\U0001f575\u200d\u2642":                                         "male-detective",
+	"\U0001f575\U0001f3fb":                                           "sleuth_or_spy_light_skin_tone",
+	"\U0001f575\U0001f3fb\u200d\u2640":                               "female-detective_light_skin_tone",
+	"\U0001f575\U0001f3fb\u200d\u2642":                               "male-detective_light_skin_tone",
+	"\U0001f575\U0001f3fc":                                           "sleuth_or_spy_medium_light_skin_tone",
+	"\U0001f575\U0001f3fc\u200d\u2640":                               "female-detective_medium_light_skin_tone",
+	"\U0001f575\U0001f3fc\u200d\u2642":                               "male-detective_medium_light_skin_tone",
+	"\U0001f575\U0001f3fd":                                           "sleuth_or_spy_medium_skin_tone",
+	"\U0001f575\U0001f3fd\u200d\u2640":                               "female-detective_medium_skin_tone",
+	"\U0001f575\U0001f3fd\u200d\u2642":                               "male-detective_medium_skin_tone",
+	"\U0001f575\U0001f3fe":                                           "sleuth_or_spy_medium_dark_skin_tone",
+	"\U0001f575\U0001f3fe\u200d\u2640":                               "female-detective_medium_dark_skin_tone",
+	"\U0001f575\U0001f3fe\u200d\u2642":                               "male-detective_medium_dark_skin_tone",
+	"\U0001f575\U0001f3ff":                                           "sleuth_or_spy_dark_skin_tone",
+	"\U0001f575\U0001f3ff\u200d\u2640":                               "female-detective_dark_skin_tone",
+	"\U0001f575\U0001f3ff\u200d\u2642":                               "male-detective_dark_skin_tone",
+	"\U0001f576":                                                     "dark_sunglasses",
+	"\U0001f577":                                                     "spider",
+	"\U0001f578":                                                     "spider_web",
+	"\U0001f579":                                                     "joystick",
+	"\U0001f57a":                                                     "man_dancing",
+	"\U0001f57a\U0001f3fb":                                           "man_dancing_light_skin_tone",
+	"\U0001f57a\U0001f3fc":                                           "man_dancing_medium_light_skin_tone",
+	"\U0001f57a\U0001f3fd":                                           "man_dancing_medium_skin_tone",
+	"\U0001f57a\U0001f3fe":                                           "man_dancing_medium_dark_skin_tone",
+	"\U0001f57a\U0001f3ff":                                           "man_dancing_dark_skin_tone",
+	"\U0001f587":                                                     "paperclips",
+	"\U0001f58a":                                                     "pen",
+	"\U0001f58b":                                                     "fountain_pen",
+	"\U0001f58c":                                                     "paintbrush",
+	"\U0001f58d":                                                     "crayon",
+	"\U0001f590":                                                     "raised_hand_with_fingers_splayed",
+	"\U0001f590\U0001f3fb":                                           "raised_hand_with_fingers_splayed_light_skin_tone",
+	"\U0001f590\U0001f3fc":                                           "raised_hand_with_fingers_splayed_medium_light_skin_tone",
+	"\U0001f590\U0001f3fd":                                           "raised_hand_with_fingers_splayed_medium_skin_tone",
+	"\U0001f590\U0001f3fe":                                           "raised_hand_with_fingers_splayed_medium_dark_skin_tone",
+	"\U0001f590\U0001f3ff":                                           "raised_hand_with_fingers_splayed_dark_skin_tone",
+	"\U0001f595":                                                     "fu",
+	"\U0001f595\U0001f3fb":                                           "middle_finger_light_skin_tone",
+	"\U0001f595\U0001f3fc":                                           "middle_finger_medium_light_skin_tone",
+	"\U0001f595\U0001f3fd":                                           "middle_finger_medium_skin_tone",
+	"\U0001f595\U0001f3fe":                                           "middle_finger_medium_dark_skin_tone",
+	"\U0001f595\U0001f3ff":                                           "middle_finger_dark_skin_tone",
+	"\U0001f596":                                                     "spock-hand",
+	"\U0001f596\U0001f3fb":                                           "spock-hand_light_skin_tone",
+	"\U0001f596\U0001f3fc":                                           "spock-hand_medium_light_skin_tone",
+	"\U0001f596\U0001f3fd":                                           "spock-hand_medium_skin_tone",
+	"\U0001f596\U0001f3fe":                                           "spock-hand_medium_dark_skin_tone",
+	"\U0001f596\U0001f3ff":                                           "spock-hand_dark_skin_tone",
+	"\U0001f5a4":                                                     "black_heart",
+	"\U0001f5a5":                                                     "desktop_computer",
+	"\U0001f5a8":                                                     "printer",
+	"\U0001f5b1":                                                     "computer_mouse",
+	"\U0001f5b2":                                                     "trackball",
+	"\U0001f5bc":                                                     "framed_picture",
+	"\U0001f5c2":                                                     "card_index_dividers",
+	"\U0001f5c3":                                                     "card_file_box",
+	"\U0001f5c4":                                                     "file_cabinet",
+	"\U0001f5d1":                                                     "wastebasket",
+	"\U0001f5d2":                                                     "spiral_notepad",
+	"\U0001f5d3":                                                     "spiral_calendar",
+	"\U0001f5dc":                                                     "clamp",
+	"\U0001f5dd":                                                     "old_key",
+	"\U0001f5de":                                                     "newspaper_roll",
+	"\U0001f5e1":                                                     "dagger",
+	"\U0001f5e3":                                                     "speaking_head",
+	"\U0001f5e8":                                                     "left_speech_bubble",
+	"\U0001f5ef":                                                     "right_anger_bubble",
+	"\U0001f5f3":                                                     "ballot_box",
+	"\U0001f5fa":                                                     "world_map",
+	"\U0001f5fb":                                                     "mount_fuji",
+	"\U0001f5fc":                                                     "tokyo_tower",
+	"\U0001f5fd":                                                     "statue_of_liberty",
+	"\U0001f5fe":                                                     "japan",
+	"\U0001f5ff":                                                     "moyai",
+	"\U0001f600":                                                     "grinning",
+	"\U0001f601":                                                     "grin",
+	"\U0001f602":                                                     "joy",
+	"\U0001f603":                                                     "smiley",
+	"\U0001f604":                                                     "smile",
+	"\U0001f605":                                                     "sweat_smile",
+	"\U0001f606":                                                     "laughing",
+	"\U0001f607":                                                     "innocent",
+	"\U0001f608":                                                     "smiling_imp",
+	"\U0001f609":                                                     "wink",
+	"\U0001f60a":                                                     "blush",
+	"\U0001f60b":                                                     "yum",
+	"\U0001f60c":                                                     "relieved",
+	"\U0001f60d":                                                     "heart_eyes",
+	"\U0001f60e":                                                     "sunglasses",
+	"\U0001f60f":                                                     "smirk",
+	"\U0001f610":                                                     "neutral_face",
+	"\U0001f611":                                                     "expressionless",
+	"\U0001f612":                                                     "unamused",
+	"\U0001f613":                                                     "sweat",
+	"\U0001f614":                                                     "pensive",
+	"\U0001f615":                                                     "confused",
+	"\U0001f616":                                                     "confounded",
+	"\U0001f617":                                                     "kissing",
+	"\U0001f618":                                                     "kissing_heart",
+	"\U0001f619":                                                     "kissing_smiling_eyes",
+	"\U0001f61a":                                                     "kissing_closed_eyes",
+	"\U0001f61b":                                                     "stuck_out_tongue",
+	"\U0001f61c":                                                     "stuck_out_tongue_winking_eye",
+	"\U0001f61d":                                                     "stuck_out_tongue_closed_eyes",
+	"\U0001f61e":                                                     "disappointed",
+	"\U0001f61f":                                                     "worried",
+	"\U0001f620":                                                     "angry",
+	"\U0001f621":                                                     "rage",
+	"\U0001f622":                                                     "cry",
+	"\U0001f623":                                                     "persevere",
+	"\U0001f624":                                                     "triumph",
+	"\U0001f625":                                                     "disappointed_relieved",
+	"\U0001f626":                                                     "frowning",
+	"\U0001f627":                                                     "anguished",
+	"\U0001f628":                                                     "fearful",
+	"\U0001f629":                                                     "weary",
+	"\U0001f62a":                                                     "sleepy",
+	"\U0001f62b":                                                     "tired_face",
+	"\U0001f62c":                                                     "grimacing",
+	"\U0001f62d":                                                     "sob",
+	"\U0001f62e":                                                     "open_mouth",
+	"\U0001f62f":                                                     "hushed",
+	"\U0001f630":                                                     "cold_sweat",
+	"\U0001f631":                                                     "scream",
+	"\U0001f632":                                                     "astonished",
+	"\U0001f633":                                                     "flushed",
+	"\U0001f634":                                                     "sleeping",
+	"\U0001f635":                                                     "dizzy_face",
+	"\U0001f636":                                                     "no_mouth",
+	"\U0001f637":                                                     "mask",
+	"\U0001f638":                                                     "smile_cat",
+	"\U0001f639":                                                     "joy_cat",
+	"\U0001f63a":                                                     "smiley_cat",
+	"\U0001f63b":                                                     "heart_eyes_cat",
+	"\U0001f63c":                                                     "smirk_cat",
+	"\U0001f63d":                                                     "kissing_cat",
+	"\U0001f63e":                                                     "pouting_cat",
+	"\U0001f63f":                                                     "crying_cat_face",
+	"\U0001f640":                                                     "scream_cat",
+	"\U0001f641":                                                     "slightly_frowning_face",
+	"\U0001f642":                                                     "slightly_smiling_face",
+	"\U0001f643":                                                     "upside_down_face",
+	"\U0001f644":                                                     "roll_eyes",
+	"\U0001f645":                                                     "no_good",
+	"\U0001f645\u200d\u2640":                                         "ng_woman",
+	"\U0001f645\u200d\u2642":                                         "ng_man",
+	"\U0001f645\U0001f3fb":                                           "no_good_light_skin_tone",
+	"\U0001f645\U0001f3fb\u200d\u2640":                               "no_good_woman_light_skin_tone",
+	"\U0001f645\U0001f3fb\u200d\u2642":                               "no_good_man_light_skin_tone",
+	"\U0001f645\U0001f3fc":                                           "no_good_medium_light_skin_tone",
+	"\U0001f645\U0001f3fc\u200d\u2640":                               "no_good_woman_medium_light_skin_tone",
+	"\U0001f645\U0001f3fc\u200d\u2642":                               "no_good_man_medium_light_skin_tone",
+	"\U0001f645\U0001f3fd":                                           "no_good_medium_skin_tone",
+	"\U0001f645\U0001f3fd\u200d\u2640":                               "no_good_woman_medium_skin_tone",
+	"\U0001f645\U0001f3fd\u200d\u2642":                               "no_good_man_medium_skin_tone",
+	"\U0001f645\U0001f3fe":                                           "no_good_medium_dark_skin_tone",
+	"\U0001f645\U0001f3fe\u200d\u2640":                               "no_good_woman_medium_dark_skin_tone",
+	"\U0001f645\U0001f3fe\u200d\u2642":                               "no_good_man_medium_dark_skin_tone",
+	"\U0001f645\U0001f3ff":                                           "no_good_dark_skin_tone",
+	"\U0001f645\U0001f3ff\u200d\u2640":                               "no_good_woman_dark_skin_tone",
+	"\U0001f645\U0001f3ff\u200d\u2642":                               "no_good_man_dark_skin_tone",
+	"\U0001f646":                                                     "ok_woman",
+	"\U0001f646\u200d\u2640":                                         "woman-gesturing-ok",
+	"\U0001f646\u200d\u2642":                                         "ok_man",
+	"\U0001f646\U0001f3fb":                                           "ok_woman_light_skin_tone",
+	"\U0001f646\U0001f3fb\u200d\u2640":                               "woman-gesturing-ok_light_skin_tone",
+	"\U0001f646\U0001f3fb\u200d\u2642":                               "ok_man_light_skin_tone",
+	"\U0001f646\U0001f3fc":                                           "ok_woman_medium_light_skin_tone",
+	"\U0001f646\U0001f3fc\u200d\u2640":                               "woman-gesturing-ok_medium_light_skin_tone",
+	"\U0001f646\U0001f3fc\u200d\u2642":                               "ok_man_medium_light_skin_tone",
+	"\U0001f646\U0001f3fd":                                           "ok_woman_medium_skin_tone",
+	"\U0001f646\U0001f3fd\u200d\u2640":                               "woman-gesturing-ok_medium_skin_tone",
+	"\U0001f646\U0001f3fd\u200d\u2642":                               "ok_man_medium_skin_tone",
+	"\U0001f646\U0001f3fe":                                           "ok_woman_medium_dark_skin_tone",
+	"\U0001f646\U0001f3fe\u200d\u2640":                               "woman-gesturing-ok_medium_dark_skin_tone",
+	"\U0001f646\U0001f3fe\u200d\u2642":                               "ok_man_medium_dark_skin_tone",
+	"\U0001f646\U0001f3ff":                                           "ok_woman_dark_skin_tone",
+	"\U0001f646\U0001f3ff\u200d\u2640":                               "woman-gesturing-ok_dark_skin_tone",
+	"\U0001f646\U0001f3ff\u200d\u2642":                               "ok_man_dark_skin_tone",
+	"\U0001f647":                                                     "bow",
+	"\U0001f647\u200d\u2640":                                         "woman-bowing",
+	"\U0001f647\u200d\u2642":                                         "man-bowing",
+	"\U0001f647\U0001f3fb":                                           "bow_light_skin_tone",
+	"\U0001f647\U0001f3fb\u200d\u2640":                               "woman-bowing_light_skin_tone",
+	"\U0001f647\U0001f3fb\u200d\u2642":                               "man-bowing_light_skin_tone",
+	"\U0001f647\U0001f3fc":                                           "bow_medium_light_skin_tone",
+	"\U0001f647\U0001f3fc\u200d\u2640":                               "woman-bowing_medium_light_skin_tone",
+	"\U0001f647\U0001f3fc\u200d\u2642":                               "man-bowing_medium_light_skin_tone",
+	"\U0001f647\U0001f3fd":                                           "bow_medium_skin_tone",
+	"\U0001f647\U0001f3fd\u200d\u2640":                               "woman-bowing_medium_skin_tone",
+	"\U0001f647\U0001f3fd\u200d\u2642":                               "man-bowing_medium_skin_tone",
+	"\U0001f647\U0001f3fe":                                           "bow_medium_dark_skin_tone",
+	"\U0001f647\U0001f3fe\u200d\u2640":                               "woman-bowing_medium_dark_skin_tone",
+	"\U0001f647\U0001f3fe\u200d\u2642":                               "man-bowing_medium_dark_skin_tone",
+	"\U0001f647\U0001f3ff":                                           "bow_dark_skin_tone",
+	"\U0001f647\U0001f3ff\u200d\u2640":                               "woman-bowing_dark_skin_tone",
+	"\U0001f647\U0001f3ff\u200d\u2642":                               "man-bowing_dark_skin_tone",
+	"\U0001f648":                                                     "see_no_evil",
+	"\U0001f649":                                                     "hear_no_evil",
+	"\U0001f64a":                                                     "speak_no_evil",
+	"\U0001f64b":                                                     "raising_hand",
+	"\U0001f64b\u200d\u2640":                                         "woman-raising-hand",
+	"\U0001f64b\u200d\u2642":                                         "man-raising-hand",
+	"\U0001f64b\U0001f3fb":                                           "raising_hand_light_skin_tone",
+	"\U0001f64b\U0001f3fb\u200d\u2640":                               "woman-raising-hand_light_skin_tone",
+	"\U0001f64b\U0001f3fb\u200d\u2642":                               "man-raising-hand_light_skin_tone",
+	"\U0001f64b\U0001f3fc":                                           "raising_hand_medium_light_skin_tone",
+	"\U0001f64b\U0001f3fc\u200d\u2640":                               "woman-raising-hand_medium_light_skin_tone",
+	"\U0001f64b\U0001f3fc\u200d\u2642":                               "man-raising-hand_medium_light_skin_tone",
+	"\U0001f64b\U0001f3fd":                                           "raising_hand_medium_skin_tone",
+	"\U0001f64b\U0001f3fd\u200d\u2640":                               "woman-raising-hand_medium_skin_tone",
+	"\U0001f64b\U0001f3fd\u200d\u2642":                               "man-raising-hand_medium_skin_tone",
+	"\U0001f64b\U0001f3fe":                                           "raising_hand_medium_dark_skin_tone",
+	"\U0001f64b\U0001f3fe\u200d\u2640":                               "woman-raising-hand_medium_dark_skin_tone",
+	"\U0001f64b\U0001f3fe\u200d\u2642":                               "man-raising-hand_medium_dark_skin_tone",
+	"\U0001f64b\U0001f3ff":                                           "raising_hand_dark_skin_tone",
+	"\U0001f64b\U0001f3ff\u200d\u2640":                               "woman-raising-hand_dark_skin_tone",
+	"\U0001f64b\U0001f3ff\u200d\u2642":                               "man-raising-hand_dark_skin_tone",
+	"\U0001f64c":                                                     "raised_hands",
+	"\U0001f64c\U0001f3fb":                                           "raised_hands_light_skin_tone",
+	"\U0001f64c\U0001f3fc":                                           "raised_hands_medium_light_skin_tone",
+	"\U0001f64c\U0001f3fd":                                           "raised_hands_medium_skin_tone",
+	"\U0001f64c\U0001f3fe":                                           "raised_hands_medium_dark_skin_tone",
+	"\U0001f64c\U0001f3ff":                                           "raised_hands_dark_skin_tone",
+	"\U0001f64d":                                                     "person_frowning",
+	"\U0001f64d\u200d\u2640":                                         "woman-frowning",
+	"\U0001f64d\u200d\u2642":                                         "man-frowning",
+	"\U0001f64d\U0001f3fb":                                           "person_frowning_light_skin_tone",
+	"\U0001f64d\U0001f3fb\u200d\u2640":                               "woman-frowning_light_skin_tone",
+	"\U0001f64d\U0001f3fb\u200d\u2642":                               "man-frowning_light_skin_tone",
+	"\U0001f64d\U0001f3fc":                                           "person_frowning_medium_light_skin_tone",
+	"\U0001f64d\U0001f3fc\u200d\u2640":                               "woman-frowning_medium_light_skin_tone",
+	"\U0001f64d\U0001f3fc\u200d\u2642":                               "man-frowning_medium_light_skin_tone",
+	"\U0001f64d\U0001f3fd":                                           "person_frowning_medium_skin_tone",
+	"\U0001f64d\U0001f3fd\u200d\u2640":                               "woman-frowning_medium_skin_tone",
+	"\U0001f64d\U0001f3fd\u200d\u2642":                               "man-frowning_medium_skin_tone",
+	"\U0001f64d\U0001f3fe":                                           "person_frowning_medium_dark_skin_tone",
+	"\U0001f64d\U0001f3fe\u200d\u2640":                               "woman-frowning_medium_dark_skin_tone",
+	"\U0001f64d\U0001f3fe\u200d\u2642":                               "man-frowning_medium_dark_skin_tone",
+	"\U0001f64d\U0001f3ff":                                           "person_frowning_dark_skin_tone",
+	"\U0001f64d\U0001f3ff\u200d\u2640":                               "woman-frowning_dark_skin_tone",
+	"\U0001f64d\U0001f3ff\u200d\u2642":                               "man-frowning_dark_skin_tone",
+	"\U0001f64e":                                                     "person_with_pouting_face",
+	"\U0001f64e\u200d\u2640":                                         "woman-pouting",
+	"\U0001f64e\u200d\u2642":                                         "man-pouting",
+	"\U0001f64e\U0001f3fb":                                           "person_with_pouting_face_light_skin_tone",
+	"\U0001f64e\U0001f3fb\u200d\u2640":                               "woman-pouting_light_skin_tone",
+	"\U0001f64e\U0001f3fb\u200d\u2642":                               "man-pouting_light_skin_tone",
+	"\U0001f64e\U0001f3fc":                                           "person_with_pouting_face_medium_light_skin_tone",
+	"\U0001f64e\U0001f3fc\u200d\u2640":                               "woman-pouting_medium_light_skin_tone",
+	"\U0001f64e\U0001f3fc\u200d\u2642":                               "man-pouting_medium_light_skin_tone",
+	"\U0001f64e\U0001f3fd":                                           "person_with_pouting_face_medium_skin_tone",
+	"\U0001f64e\U0001f3fd\u200d\u2640":                               "woman-pouting_medium_skin_tone",
+	"\U0001f64e\U0001f3fd\u200d\u2642":                               "man-pouting_medium_skin_tone",
+	"\U0001f64e\U0001f3fe":                                           "person_with_pouting_face_medium_dark_skin_tone",
+	"\U0001f64e\U0001f3fe\u200d\u2640":                               "woman-pouting_medium_dark_skin_tone",
+	"\U0001f64e\U0001f3fe\u200d\u2642":                               "man-pouting_medium_dark_skin_tone",
+	"\U0001f64e\U0001f3ff":                                           "person_with_pouting_face_dark_skin_tone",
+	"\U0001f64e\U0001f3ff\u200d\u2640":                               "woman-pouting_dark_skin_tone",
+	"\U0001f64e\U0001f3ff\u200d\u2642":                               "man-pouting_dark_skin_tone",
+	"\U0001f64f":                                                     "pray",
+	"\U0001f64f\U0001f3fb":                                           "pray_light_skin_tone",
+	"\U0001f64f\U0001f3fc":                                           "pray_medium_light_skin_tone",
+	"\U0001f64f\U0001f3fd":                                           "pray_medium_skin_tone",
+	"\U0001f64f\U0001f3fe":                                           "pray_medium_dark_skin_tone",
+	"\U0001f64f\U0001f3ff":                                           "pray_dark_skin_tone",
+	"\U0001f680":                                                     "rocket",
+	"\U0001f681":                                                     "helicopter",
+	"\U0001f682":                                                     "steam_locomotive",
+	"\U0001f683":                                                     "railway_car",
+	"\U0001f684":                                                     "bullettrain_side",
+	"\U0001f685":                                                     "bullettrain_front",
+	"\U0001f686":                                                     "train2",
+	"\U0001f687":                                                     "metro",
+	"\U0001f688":                                                     "light_rail",
+	"\U0001f689":                                                     "station",
+	"\U0001f68a":                                                     "tram",
+	"\U0001f68b":                                                     "train",
+	"\U0001f68c":                                                     "bus",
+	"\U0001f68d":                                                     "oncoming_bus",
+	"\U0001f68e":                                                     "trolleybus",
+	"\U0001f68f":                                                     "busstop",
+	"\U0001f690":                                                     "minibus",
+	"\U0001f691":                                                     "ambulance",
+	"\U0001f692":                                                     "fire_engine",
+	"\U0001f693":                                                     "police_car",
+	"\U0001f694":                                                     "oncoming_police_car",
+	"\U0001f695":                                                     "taxi",
+	"\U0001f696":                                                     "oncoming_taxi",
+	"\U0001f697":                                                     "car",
+	"\U0001f698":                                                     "oncoming_automobile",
+	"\U0001f699":                                                     "blue_car",
+	"\U0001f69a":                                                     "truck",
+	"\U0001f69b":                                                     "articulated_lorry",
+	"\U0001f69c":                                                     "tractor",
+	"\U0001f69d":                                                     "monorail",
+	"\U0001f69e":                                                     "mountain_railway",
+	"\U0001f69f":                                                     "suspension_railway",
+	"\U0001f6a0":                                                     "mountain_cableway",
+	"\U0001f6a1":                                                     "aerial_tramway",
+	"\U0001f6a2":                                                     "ship",
+	"\U0001f6a3":                                                     "rowboat",
+	"\U0001f6a3\u200d\u2640":                                         "rowing_woman",
+	"\U0001f6a3\u200d\u2642":                                         "rowing_man",
+	"\U0001f6a3\U0001f3fb":                                           "rowboat_light_skin_tone",
+	"\U0001f6a3\U0001f3fb\u200d\u2640":                               "rowing_woman_light_skin_tone",
+	"\U0001f6a3\U0001f3fb\u200d\u2642":                               "rowing_man_light_skin_tone",
+	"\U0001f6a3\U0001f3fc":                                           "rowboat_medium_light_skin_tone",
+	"\U0001f6a3\U0001f3fc\u200d\u2640":                               "rowing_woman_medium_light_skin_tone",
+	"\U0001f6a3\U0001f3fc\u200d\u2642":                               "rowing_man_medium_light_skin_tone",
+	"\U0001f6a3\U0001f3fd":                                           "rowboat_medium_skin_tone",
+	"\U0001f6a3\U0001f3fd\u200d\u2640":                               "rowing_woman_medium_skin_tone",
+	"\U0001f6a3\U0001f3fd\u200d\u2642":                               "rowing_man_medium_skin_tone",
+	"\U0001f6a3\U0001f3fe":                                           "rowboat_medium_dark_skin_tone",
+	"\U0001f6a3\U0001f3fe\u200d\u2640":                               "rowing_woman_medium_dark_skin_tone",
+	"\U0001f6a3\U0001f3fe\u200d\u2642":                               "rowing_man_medium_dark_skin_tone",
+	"\U0001f6a3\U0001f3ff":                                           "rowboat_dark_skin_tone",
+	"\U0001f6a3\U0001f3ff\u200d\u2640":                               "rowing_woman_dark_skin_tone",
+	"\U0001f6a3\U0001f3ff\u200d\u2642":                               "rowing_man_dark_skin_tone",
+	"\U0001f6a4":                                                     "speedboat",
+	"\U0001f6a5":                                                     "traffic_light",
+	"\U0001f6a6":                                                     "vertical_traffic_light",
+	"\U0001f6a7":                                                     "construction",
+	"\U0001f6a8":                                                     "rotating_light",
+	"\U0001f6a9":                                                     "triangular_flag_on_post",
+	"\U0001f6aa":                                                     "door",
+	"\U0001f6ab":                                                     "no_entry_sign",
+	"\U0001f6ac":                                                     "smoking",
+	"\U0001f6ad":                                                     "no_smoking",
+	"\U0001f6ae":                                                     "put_litter_in_its_place",
+	"\U0001f6af":                                                     "do_not_litter",
+	"\U0001f6b0":                                                     "potable_water",
+	"\U0001f6b1":                                                     "non-potable_water",
+	"\U0001f6b2":                                                     "bike",
+	"\U0001f6b3":                                                     "no_bicycles",
+	"\U0001f6b4":                                                     "bicyclist",
+	"\U0001f6b4\u200d\u2640":                                         "woman-biking",
+	"\U0001f6b4\u200d\u2642":                                         "man-biking",
+	"\U0001f6b4\U0001f3fb":                                           "bicyclist_light_skin_tone",
+	"\U0001f6b4\U0001f3fb\u200d\u2640":                               "woman-biking_light_skin_tone",
+	"\U0001f6b4\U0001f3fb\u200d\u2642":                               "man-biking_light_skin_tone",
+	"\U0001f6b4\U0001f3fc":                                           "bicyclist_medium_light_skin_tone",
+	"\U0001f6b4\U0001f3fc\u200d\u2640":                               "woman-biking_medium_light_skin_tone",
+	"\U0001f6b4\U0001f3fc\u200d\u2642":                               "man-biking_medium_light_skin_tone",
+	"\U0001f6b4\U0001f3fd":                                           "bicyclist_medium_skin_tone",
+	"\U0001f6b4\U0001f3fd\u200d\u2640":                               "woman-biking_medium_skin_tone",
+	"\U0001f6b4\U0001f3fd\u200d\u2642":                               "man-biking_medium_skin_tone",
+	"\U0001f6b4\U0001f3fe":                                           "bicyclist_medium_dark_skin_tone",
+	"\U0001f6b4\U0001f3fe\u200d\u2640":                               "woman-biking_medium_dark_skin_tone",
+	"\U0001f6b4\U0001f3fe\u200d\u2642":                               "man-biking_medium_dark_skin_tone",
+	"\U0001f6b4\U0001f3ff":                                           "bicyclist_dark_skin_tone",
+	"\U0001f6b4\U0001f3ff\u200d\u2640":                               "woman-biking_dark_skin_tone",
+	"\U0001f6b4\U0001f3ff\u200d\u2642":                               "man-biking_dark_skin_tone",
+	"\U0001f6b5":                                                     "mountain_bicyclist",
+	"\U0001f6b5\u200d\u2640":                                         "woman-mountain-biking",
+	"\U0001f6b5\u200d\u2642":                                         "man-mountain-biking",
+	"\U0001f6b5\U0001f3fb":                                           "mountain_bicyclist_light_skin_tone",
+	"\U0001f6b5\U0001f3fb\u200d\u2640":                               "woman-mountain-biking_light_skin_tone",
+	"\U0001f6b5\U0001f3fb\u200d\u2642":                               "man-mountain-biking_light_skin_tone",
+	"\U0001f6b5\U0001f3fc":                                           "mountain_bicyclist_medium_light_skin_tone",
+	"\U0001f6b5\U0001f3fc\u200d\u2640":                               "woman-mountain-biking_medium_light_skin_tone",
+	"\U0001f6b5\U0001f3fc\u200d\u2642":                               "man-mountain-biking_medium_light_skin_tone",
+	"\U0001f6b5\U0001f3fd":                                           "mountain_bicyclist_medium_skin_tone",
+	"\U0001f6b5\U0001f3fd\u200d\u2640":                               "woman-mountain-biking_medium_skin_tone",
+	"\U0001f6b5\U0001f3fd\u200d\u2642":                               "man-mountain-biking_medium_skin_tone",
+	"\U0001f6b5\U0001f3fe":                                           "mountain_bicyclist_medium_dark_skin_tone",
+	"\U0001f6b5\U0001f3fe\u200d\u2640":                               "woman-mountain-biking_medium_dark_skin_tone",
+	"\U0001f6b5\U0001f3fe\u200d\u2642":                               "man-mountain-biking_medium_dark_skin_tone",
+	"\U0001f6b5\U0001f3ff":                                           "mountain_bicyclist_dark_skin_tone",
+	"\U0001f6b5\U0001f3ff\u200d\u2640":                               "woman-mountain-biking_dark_skin_tone",
+	"\U0001f6b5\U0001f3ff\u200d\u2642":                               "man-mountain-biking_dark_skin_tone",
+	"\U0001f6b6":                                                     "walking",
+	"\U0001f6b6\u200d\u2640":                                         "woman-walking",
+	"\U0001f6b6\u200d\u2642":                                         "man-walking",
+	"\U0001f6b6\U0001f3fb":                                           "walking_light_skin_tone",
+	"\U0001f6b6\U0001f3fb\u200d\u2640":                               "woman-walking_light_skin_tone",
+	"\U0001f6b6\U0001f3fb\u200d\u2642":                               "man-walking_light_skin_tone",
+	"\U0001f6b6\U0001f3fc":                                           "walking_medium_light_skin_tone",
+	"\U0001f6b6\U0001f3fc\u200d\u2640":                               "woman-walking_medium_light_skin_tone",
+	"\U0001f6b6\U0001f3fc\u200d\u2642":                               "man-walking_medium_light_skin_tone",
+	"\U0001f6b6\U0001f3fd":                                           "walking_medium_skin_tone",
+	"\U0001f6b6\U0001f3fd\u200d\u2640":                               "woman-walking_medium_skin_tone",
+	"\U0001f6b6\U0001f3fd\u200d\u2642":                               "man-walking_medium_skin_tone",
+	"\U0001f6b6\U0001f3fe":                                           "walking_medium_dark_skin_tone",
+	"\U0001f6b6\U0001f3fe\u200d\u2640":                               "woman-walking_medium_dark_skin_tone",
+	"\U0001f6b6\U0001f3fe\u200d\u2642":                               "man-walking_medium_dark_skin_tone",
+	"\U0001f6b6\U0001f3ff":                                           "walking_dark_skin_tone",
+	"\U0001f6b6\U0001f3ff\u200d\u2640":                               "woman-walking_dark_skin_tone",
+	"\U0001f6b6\U0001f3ff\u200d\u2642":                               "man-walking_dark_skin_tone",
+	"\U0001f6b7":                                                     "no_pedestrians",
+	"\U0001f6b8":                                                     "children_crossing",
+	"\U0001f6b9":                                                     "mens",
+	"\U0001f6ba":                                                     "womens",
+	"\U0001f6bb":                                                     "restroom",
+	"\U0001f6bc":                                                     "baby_symbol",
+	"\U0001f6bd":                                                     "toilet",
+	"\U0001f6be":                                                     "wc",
+	"\U0001f6bf":                                                     "shower",
+	"\U0001f6c0":                                                     "bath",
+	"\U0001f6c0\U0001f3fb":                                           "bath_light_skin_tone",
+	"\U0001f6c0\U0001f3fc":                                           "bath_medium_light_skin_tone",
+	"\U0001f6c0\U0001f3fd":                                           "bath_medium_skin_tone",
+	"\U0001f6c0\U0001f3fe":                                           "bath_medium_dark_skin_tone",
+	"\U0001f6c0\U0001f3ff":                                           "bath_dark_skin_tone",
+	"\U0001f6c1":                                                     "bathtub",
+	"\U0001f6c2":                                                     "passport_control",
+	"\U0001f6c3":                                                     "customs",
+	"\U0001f6c4":                                                     "baggage_claim",
+	"\U0001f6c5":                                                     "left_luggage",
+	"\U0001f6cb":                                                     "couch_and_lamp",
+	"\U0001f6cc":                                                     "sleeping_bed",
+	"\U0001f6cc\U0001f3fb":                                           "sleeping_accommodation_light_skin_tone",
+	"\U0001f6cc\U0001f3fc":                                           "sleeping_accommodation_medium_light_skin_tone",
+	"\U0001f6cc\U0001f3fd":                                           "sleeping_accommodation_medium_skin_tone",
+	"\U0001f6cc\U0001f3fe":                                           "sleeping_accommodation_medium_dark_skin_tone",
+	"\U0001f6cc\U0001f3ff":                                           "sleeping_accommodation_dark_skin_tone",
+	"\U0001f6cd":                                                     "shopping",
+	"\U0001f6ce":                                                     "bellhop_bell",
+	"\U0001f6cf":                                                     "bed",
+	"\U0001f6d0":                                                     "place_of_worship",
+	"\U0001f6d1":                                                     "stop_sign",
+	"\U0001f6d2":                                                     "shopping_cart",
+	"\U0001f6d5":                                                     "hindu_temple",
+	"\U0001f6d6":                                                     "hut",
+	"\U0001f6d7":                                                     "elevator",
+	"\U0001f6e0":                                                     "hammer_and_wrench",
+	"\U0001f6e1":                                                     "shield",
+	"\U0001f6e2":                                                     "oil_drum",
+	"\U0001f6e3":                                                     "motorway",
+	"\U0001f6e4":                                                     "railway_track",
+	"\U0001f6e5":                                                     "motor_boat",
+	"\U0001f6e9":                                                     "small_airplane",
+	"\U0001f6eb":                                                     "flight_departure",
+	"\U0001f6ec":                                                     "flight_arrival",
+	"\U0001f6f0":                                                     "satellite",
+	"\U0001f6f3":                                                     "passenger_ship",
+	"\U0001f6f4":                                                     "scooter",
+	"\U0001f6f5":                                                     "motor_scooter",
+	"\U0001f6f6":                                                     "canoe",
+	"\U0001f6f7":                                                     "sled",
+	"\U0001f6f8":                                                     "flying_saucer",
+	"\U0001f6f9":                                                     "skateboard",
+	"\U0001f6fa":                                                     "auto_rickshaw",
+	"\U0001f6fb":                                                     "pickup_truck",
+	"\U0001f6fc":                                                     "roller_skate",
+	"\U0001f7e0":                                                     "large_orange_circle",
+	"\U0001f7e1":                                                     "large_yellow_circle",
+	"\U0001f7e2":                                                     "large_green_circle",
+	"\U0001f7e3":                                                     "large_purple_circle",
+	"\U0001f7e4":                                                     "large_brown_circle",
+	"\U0001f7e5":                                                     "large_red_square",
+	"\U0001f7e6":                                                     "large_blue_square",
+	"\U0001f7e7":                                                     "large_orange_square",
+	"\U0001f7e8":                                                     "large_yellow_square",
+	"\U0001f7e9":                                                     "large_green_square",
+	"\U0001f7ea":                                                     "large_purple_square",
+	"\U0001f7eb":                                                     "large_brown_square",
+	"\U0001f90c":                                                     "pinched_fingers",
+	"\U0001f90c\U0001f3fb":                                           "pinched_fingers_light_skin_tone",
+	"\U0001f90c\U0001f3fc":                                           "pinched_fingers_medium_light_skin_tone",
+	"\U0001f90c\U0001f3fd":                                           "pinched_fingers_medium_skin_tone",
+	"\U0001f90c\U0001f3fe":                                           "pinched_fingers_medium_dark_skin_tone",
+	"\U0001f90c\U0001f3ff":                                           "pinched_fingers_dark_skin_tone",
+	"\U0001f90d":                                                     "white_heart",
+	"\U0001f90e":                                                     "brown_heart",
+	"\U0001f90f":                                                     "pinching_hand",
+	"\U0001f90f\U0001f3fb":                                           "pinching_hand_light_skin_tone",
+	"\U0001f90f\U0001f3fc":                                           "pinching_hand_medium_light_skin_tone",
+	"\U0001f90f\U0001f3fd":                                           "pinching_hand_medium_skin_tone",
+	"\U0001f90f\U0001f3fe":                                           "pinching_hand_medium_dark_skin_tone",
+	"\U0001f90f\U0001f3ff":                                           "pinching_hand_dark_skin_tone",
+	"\U0001f910":                                                     "zipper_mouth_face",
+	"\U0001f911":                                                     "money_mouth_face",
+	"\U0001f912":                                                     "face_with_thermometer",
+	"\U0001f913":                                                     "nerd_face",
+	"\U0001f914":                                                     "thinking",
+	"\U0001f915":                                                     "face_with_head_bandage",
+	"\U0001f916":                                                     "robot",
+	"\U0001f917":                                                     "hugs",
+	"\U0001f918":                                                     "metal",
+	"\U0001f918\U0001f3fb":                                           "metal_light_skin_tone",
+	"\U0001f918\U0001f3fc":                                           "metal_medium_light_skin_tone",
+	"\U0001f918\U0001f3fd":                                           "metal_medium_skin_tone",
+	"\U0001f918\U0001f3fe":                                           "metal_medium_dark_skin_tone",
+	"\U0001f918\U0001f3ff":                                           "metal_dark_skin_tone",
+	"\U0001f919":                                                     "call_me_hand",
+	"\U0001f919\U0001f3fb":                                           "call_me_hand_light_skin_tone",
+	"\U0001f919\U0001f3fc":                                           "call_me_hand_medium_light_skin_tone",
+	"\U0001f919\U0001f3fd":                                           "call_me_hand_medium_skin_tone",
+	"\U0001f919\U0001f3fe":                                           "call_me_hand_medium_dark_skin_tone",
+	"\U0001f919\U0001f3ff":                                           "call_me_hand_dark_skin_tone",
+	"\U0001f91a":                                                     "raised_back_of_hand",
+	"\U0001f91a\U0001f3fb":                                           "raised_back_of_hand_light_skin_tone",
+	"\U0001f91a\U0001f3fc":                                           "raised_back_of_hand_medium_light_skin_tone",
+	"\U0001f91a\U0001f3fd":                                           "raised_back_of_hand_medium_skin_tone",
+	"\U0001f91a\U0001f3fe":                                           "raised_back_of_hand_medium_dark_skin_tone",
+	"\U0001f91a\U0001f3ff":                                           "raised_back_of_hand_dark_skin_tone",
+	"\U0001f91b":                                                     "fist_left",
+	"\U0001f91b\U0001f3fb":                                           "fist_left_light_skin_tone",
+	"\U0001f91b\U0001f3fc":                                           "fist_left_medium_light_skin_tone",
+	"\U0001f91b\U0001f3fd":                                           "fist_left_medium_skin_tone",
+	"\U0001f91b\U0001f3fe":                                           "fist_left_medium_dark_skin_tone",
+	"\U0001f91b\U0001f3ff":                                           "fist_left_dark_skin_tone",
+	"\U0001f91c":                                                     "fist_right",
+	"\U0001f91c\U0001f3fb":                                           "fist_right_light_skin_tone",
+	"\U0001f91c\U0001f3fc":                                           "fist_right_medium_light_skin_tone",
+	"\U0001f91c\U0001f3fd":                                           "fist_right_medium_skin_tone",
+	"\U0001f91c\U0001f3fe":                                           "fist_right_medium_dark_skin_tone",
+	"\U0001f91c\U0001f3ff":                                           "fist_right_dark_skin_tone",
+	"\U0001f91d":                                                     "handshake",
+	"\U0001f91e":                                                     "crossed_fingers",
+	"\U0001f91e\U0001f3fb":                                           "crossed_fingers_light_skin_tone",
+	"\U0001f91e\U0001f3fc":                                           "crossed_fingers_medium_light_skin_tone",
+	"\U0001f91e\U0001f3fd":                                           "crossed_fingers_medium_skin_tone",
+	"\U0001f91e\U0001f3fe":                                           "crossed_fingers_medium_dark_skin_tone",
+	"\U0001f91e\U0001f3ff":                                           "crossed_fingers_dark_skin_tone",
+	"\U0001f91f":                                                     "i_love_you_hand_sign",
+	"\U0001f91f\U0001f3fb":                                           "i_love_you_hand_sign_light_skin_tone",
+	"\U0001f91f\U0001f3fc":                                           "i_love_you_hand_sign_medium_light_skin_tone",
+	"\U0001f91f\U0001f3fd":                                           "i_love_you_hand_sign_medium_skin_tone",
+	"\U0001f91f\U0001f3fe":                                           "i_love_you_hand_sign_medium_dark_skin_tone",
+	"\U0001f91f\U0001f3ff":                                           "i_love_you_hand_sign_dark_skin_tone",
+	"\U0001f920":                                                     "cowboy_hat_face",
+	"\U0001f921":                                                     "clown_face",
+	"\U0001f922":                                                     "nauseated_face",
+	"\U0001f923":                                                     "rofl",
+	"\U0001f924":                                                     "drooling_face",
+	"\U0001f925":                                                     "lying_face",
+	"\U0001f926":                                                     "face_palm",
+	"\U0001f926\u200d\u2640":                                         "woman-facepalming",
+	"\U0001f926\u200d\u2642":                                         "man-facepalming",
+	"\U0001f926\U0001f3fb":                                           "face_palm_light_skin_tone",
+	"\U0001f926\U0001f3fb\u200d\u2640":                               "woman-facepalming_light_skin_tone",
+	"\U0001f926\U0001f3fb\u200d\u2642":                               "man-facepalming_light_skin_tone",
+	"\U0001f926\U0001f3fc":                                           "face_palm_medium_light_skin_tone",
+	"\U0001f926\U0001f3fc\u200d\u2640":                               "woman-facepalming_medium_light_skin_tone",
+	"\U0001f926\U0001f3fc\u200d\u2642":                               "man-facepalming_medium_light_skin_tone",
+	"\U0001f926\U0001f3fd":                                           "face_palm_medium_skin_tone",
+	"\U0001f926\U0001f3fd\u200d\u2640":                               "woman-facepalming_medium_skin_tone",
+	"\U0001f926\U0001f3fd\u200d\u2642":                               "man-facepalming_medium_skin_tone",
+	"\U0001f926\U0001f3fe":                                           "face_palm_medium_dark_skin_tone",
+	"\U0001f926\U0001f3fe\u200d\u2640":                               "woman-facepalming_medium_dark_skin_tone",
+	"\U0001f926\U0001f3fe\u200d\u2642":                               "man-facepalming_medium_dark_skin_tone",
+	"\U0001f926\U0001f3ff":                                           "face_palm_dark_skin_tone",
+	"\U0001f926\U0001f3ff\u200d\u2640":                               "woman-facepalming_dark_skin_tone",
+	"\U0001f926\U0001f3ff\u200d\u2642":                               "man-facepalming_dark_skin_tone",
+	"\U0001f927":                                                     "sneezing_face",
+	"\U0001f928":                                                     "face_with_raised_eyebrow",
+	"\U0001f929":                                                     "star-struck",
+	"\U0001f92a":                                                     "zany_face",
+	"\U0001f92b":                                                     "shushing_face",
+	"\U0001f92c":                                                     "face_with_symbols_on_mouth",
+	"\U0001f92d":                                                     "face_with_hand_over_mouth",
+	"\U0001f92e":                                                     "face_vomiting",
+	"\U0001f92f":                                                     "exploding_head",
+	"\U0001f930":                                                     "pregnant_woman",
+	"\U0001f930\U0001f3fb":                                           "pregnant_woman_light_skin_tone",
+	"\U0001f930\U0001f3fc":                                           "pregnant_woman_medium_light_skin_tone",
+	"\U0001f930\U0001f3fd":                                           "pregnant_woman_medium_skin_tone",
+	"\U0001f930\U0001f3fe":                                           "pregnant_woman_medium_dark_skin_tone",
+	"\U0001f930\U0001f3ff":                                           "pregnant_woman_dark_skin_tone",
+	"\U0001f931":                                                     "breast-feeding",
+	"\U0001f931\U0001f3fb":                                           "breast-feeding_light_skin_tone",
+	"\U0001f931\U0001f3fc":                                           "breast-feeding_medium_light_skin_tone",
+	"\U0001f931\U0001f3fd":                                           "breast-feeding_medium_skin_tone",
+	"\U0001f931\U0001f3fe":                                           "breast-feeding_medium_dark_skin_tone",
+	"\U0001f931\U0001f3ff":                                           "breast-feeding_dark_skin_tone",
+	"\U0001f932":                                                     "palms_up_together",
+	"\U0001f932\U0001f3fb":                                           "palms_up_together_light_skin_tone",
+	"\U0001f932\U0001f3fc":                                           "palms_up_together_medium_light_skin_tone",
+	"\U0001f932\U0001f3fd":                                           "palms_up_together_medium_skin_tone",
+	"\U0001f932\U0001f3fe":                                           "palms_up_together_medium_dark_skin_tone",
+	"\U0001f932\U0001f3ff":                                           "palms_up_together_dark_skin_tone",
+	"\U0001f933":                                                     "selfie",
+	"\U0001f933\U0001f3fb":                                           "selfie_light_skin_tone",
+	"\U0001f933\U0001f3fc":                                           "selfie_medium_light_skin_tone",
+	"\U0001f933\U0001f3fd":                                           "selfie_medium_skin_tone",
+	"\U0001f933\U0001f3fe":                                           "selfie_medium_dark_skin_tone",
+	"\U0001f933\U0001f3ff":                                           "selfie_dark_skin_tone",
+	"\U0001f934":                                                     "prince",
+	"\U0001f934\U0001f3fb":                                           "prince_light_skin_tone",
+	"\U0001f934\U0001f3fc":                                           "prince_medium_light_skin_tone",
+	"\U0001f934\U0001f3fd":                                           "prince_medium_skin_tone",
+	"\U0001f934\U0001f3fe":                                           "prince_medium_dark_skin_tone",
+	"\U0001f934\U0001f3ff":                                           "prince_dark_skin_tone",
+	"\U0001f935":                                                     "person_in_tuxedo",
+	"\U0001f935\u200d\u2640":                                         "woman_in_tuxedo",
+	"\U0001f935\u200d\u2642":                                         "man_in_tuxedo",
+	"\U0001f935\U0001f3fb":                                           "person_in_tuxedo_light_skin_tone",
+	"\U0001f935\U0001f3fb\u200d\u2640":                               "woman_in_tuxedo_light_skin_tone",
+	"\U0001f935\U0001f3fb\u200d\u2642":                               "man_in_tuxedo_light_skin_tone",
+	"\U0001f935\U0001f3fc":                                           "person_in_tuxedo_medium_light_skin_tone",
+	"\U0001f935\U0001f3fc\u200d\u2640":                               "woman_in_tuxedo_medium_light_skin_tone",
+	"\U0001f935\U0001f3fc\u200d\u2642":                               "man_in_tuxedo_medium_light_skin_tone",
+	"\U0001f935\U0001f3fd":                                           "person_in_tuxedo_medium_skin_tone",
+	"\U0001f935\U0001f3fd\u200d\u2640":                               "woman_in_tuxedo_medium_skin_tone",
+	"\U0001f935\U0001f3fd\u200d\u2642":                               "man_in_tuxedo_medium_skin_tone",
+	"\U0001f935\U0001f3fe":                                           "person_in_tuxedo_medium_dark_skin_tone",
+	"\U0001f935\U0001f3fe\u200d\u2640":                               "woman_in_tuxedo_medium_dark_skin_tone",
+	"\U0001f935\U0001f3fe\u200d\u2642":                               "man_in_tuxedo_medium_dark_skin_tone",
+	"\U0001f935\U0001f3ff":                                           "person_in_tuxedo_dark_skin_tone",
+	"\U0001f935\U0001f3ff\u200d\u2640":                               "woman_in_tuxedo_dark_skin_tone",
+	"\U0001f935\U0001f3ff\u200d\u2642":                               "man_in_tuxedo_dark_skin_tone",
+	"\U0001f936":                                                     "mrs_claus",
+	"\U0001f936\U0001f3fb":                                           "mrs_claus_light_skin_tone",
+	"\U0001f936\U0001f3fc":                                           "mrs_claus_medium_light_skin_tone",
+	"\U0001f936\U0001f3fd":                                           "mrs_claus_medium_skin_tone",
+	"\U0001f936\U0001f3fe":                                           "mrs_claus_medium_dark_skin_tone",
+	"\U0001f936\U0001f3ff":                                           "mrs_claus_dark_skin_tone",
+	"\U0001f937":                                                     "shrug",
+	"\U0001f937\u200d\u2640":                                         "woman-shrugging",
+	"\U0001f937\u200d\u2642":                                         "man-shrugging",
+	"\U0001f937\U0001f3fb":                                           "shrug_light_skin_tone",
+	"\U0001f937\U0001f3fb\u200d\u2640":                               "woman-shrugging_light_skin_tone",
+	"\U0001f937\U0001f3fb\u200d\u2642":                               "man-shrugging_light_skin_tone",
+	"\U0001f937\U0001f3fc":                                           "shrug_medium_light_skin_tone",
+	"\U0001f937\U0001f3fc\u200d\u2640":                               "woman-shrugging_medium_light_skin_tone",
+	"\U0001f937\U0001f3fc\u200d\u2642":                               "man-shrugging_medium_light_skin_tone",
+	"\U0001f937\U0001f3fd":                                           "shrug_medium_skin_tone",
+	"\U0001f937\U0001f3fd\u200d\u2640":                               "woman-shrugging_medium_skin_tone",
+	"\U0001f937\U0001f3fd\u200d\u2642":                               "man-shrugging_medium_skin_tone",
+	"\U0001f937\U0001f3fe":                                           "shrug_medium_dark_skin_tone",
+	"\U0001f937\U0001f3fe\u200d\u2640":                               "woman-shrugging_medium_dark_skin_tone",
+	"\U0001f937\U0001f3fe\u200d\u2642":                               "man-shrugging_medium_dark_skin_tone",
+	"\U0001f937\U0001f3ff":                                           "shrug_dark_skin_tone",
+	"\U0001f937\U0001f3ff\u200d\u2640":                               "woman-shrugging_dark_skin_tone",
+	"\U0001f937\U0001f3ff\u200d\u2642":                               "man-shrugging_dark_skin_tone",
+	"\U0001f938":                                                     "person_doing_cartwheel",
+	"\U0001f938\u200d\u2640":                                         "woman-cartwheeling",
+	"\U0001f938\u200d\u2642":                                         "man-cartwheeling",
+	"\U0001f938\U0001f3fb":                                           "person_doing_cartwheel_light_skin_tone",
+	"\U0001f938\U0001f3fb\u200d\u2640":                               "woman-cartwheeling_light_skin_tone",
+	"\U0001f938\U0001f3fb\u200d\u2642":                               "man-cartwheeling_light_skin_tone",
+	"\U0001f938\U0001f3fc":                                           "person_doing_cartwheel_medium_light_skin_tone",
+	"\U0001f938\U0001f3fc\u200d\u2640":                               "woman-cartwheeling_medium_light_skin_tone",
+	"\U0001f938\U0001f3fc\u200d\u2642":                               "man-cartwheeling_medium_light_skin_tone",
+	"\U0001f938\U0001f3fd":                                           "person_doing_cartwheel_medium_skin_tone",
+	"\U0001f938\U0001f3fd\u200d\u2640":                               "woman-cartwheeling_medium_skin_tone",
+	"\U0001f938\U0001f3fd\u200d\u2642":                               "man-cartwheeling_medium_skin_tone",
+	"\U0001f938\U0001f3fe":                                           "person_doing_cartwheel_medium_dark_skin_tone",
+	"\U0001f938\U0001f3fe\u200d\u2640":                               "woman-cartwheeling_medium_dark_skin_tone",
+	"\U0001f938\U0001f3fe\u200d\u2642":                               "man-cartwheeling_medium_dark_skin_tone",
+	"\U0001f938\U0001f3ff":                                           "person_doing_cartwheel_dark_skin_tone",
+	"\U0001f938\U0001f3ff\u200d\u2640":                               "woman-cartwheeling_dark_skin_tone",
+	"\U0001f938\U0001f3ff\u200d\u2642":                               "man-cartwheeling_dark_skin_tone",
+	"\U0001f939":                                                     "juggling",
+	"\U0001f939\u200d\u2640":                                         "woman-juggling",
+	"\U0001f939\u200d\u2642":                                         "man-juggling",
+	"\U0001f939\U0001f3fb":                                           "juggling_light_skin_tone",
+	"\U0001f939\U0001f3fb\u200d\u2640":                               "woman-juggling_light_skin_tone",
+	"\U0001f939\U0001f3fb\u200d\u2642":                               "man-juggling_light_skin_tone",
+	"\U0001f939\U0001f3fc":                                           "juggling_medium_light_skin_tone",
+	"\U0001f939\U0001f3fc\u200d\u2640":                               "woman-juggling_medium_light_skin_tone",
+	"\U0001f939\U0001f3fc\u200d\u2642":                               "man-juggling_medium_light_skin_tone",
+	"\U0001f939\U0001f3fd":                                           "juggling_medium_skin_tone",
+	"\U0001f939\U0001f3fd\u200d\u2640":                               "woman-juggling_medium_skin_tone",
+	"\U0001f939\U0001f3fd\u200d\u2642":                               "man-juggling_medium_skin_tone",
+	"\U0001f939\U0001f3fe":                                           "juggling_medium_dark_skin_tone",
+	"\U0001f939\U0001f3fe\u200d\u2640":                               "woman-juggling_medium_dark_skin_tone",
+	"\U0001f939\U0001f3fe\u200d\u2642":                               "man-juggling_medium_dark_skin_tone",
+	"\U0001f939\U0001f3ff":                                           "juggling_dark_skin_tone",
+	"\U0001f939\U0001f3ff\u200d\u2640":                               "woman-juggling_dark_skin_tone",
+	"\U0001f939\U0001f3ff\u200d\u2642":                               "man-juggling_dark_skin_tone",
+	"\U0001f93a":                                                     "fencer",
+	"\U0001f93c":                                                     "wrestlers",
+	"\U0001f93c\u200d\u2640":                                         "woman-wrestling",
+	"\U0001f93c\u200d\u2642":                                         "man-wrestling",
+	"\U0001f93d":                                                     "water_polo",
+	"\U0001f93d\u200d\u2640":                                         "woman-playing-water-polo",
+	"\U0001f93d\u200d\u2642":                                         "man-playing-water-polo",
+	"\U0001f93d\U0001f3fb":                                           "water_polo_light_skin_tone",
+	"\U0001f93d\U0001f3fb\u200d\u2640":                               "woman-playing-water-polo_light_skin_tone",
+	"\U0001f93d\U0001f3fb\u200d\u2642":                               "man-playing-water-polo_light_skin_tone",
+	"\U0001f93d\U0001f3fc":                                           "water_polo_medium_light_skin_tone",
+	"\U0001f93d\U0001f3fc\u200d\u2640":                               "woman-playing-water-polo_medium_light_skin_tone",
+	"\U0001f93d\U0001f3fc\u200d\u2642":                               "man-playing-water-polo_medium_light_skin_tone",
+	"\U0001f93d\U0001f3fd":                                           "water_polo_medium_skin_tone",
+	"\U0001f93d\U0001f3fd\u200d\u2640":                               "woman-playing-water-polo_medium_skin_tone",
+	"\U0001f93d\U0001f3fd\u200d\u2642":                               "man-playing-water-polo_medium_skin_tone",
+	"\U0001f93d\U0001f3fe":                                           "water_polo_medium_dark_skin_tone",
+	"\U0001f93d\U0001f3fe\u200d\u2640":                               "woman-playing-water-polo_medium_dark_skin_tone",
+	"\U0001f93d\U0001f3fe\u200d\u2642":                               "man-playing-water-polo_medium_dark_skin_tone",
+	"\U0001f93d\U0001f3ff":                                           "water_polo_dark_skin_tone",
+	"\U0001f93d\U0001f3ff\u200d\u2640":                               "woman-playing-water-polo_dark_skin_tone",
+	"\U0001f93d\U0001f3ff\u200d\u2642":                               "man-playing-water-polo_dark_skin_tone",
+	"\U0001f93e":                                                     "handball",
+	"\U0001f93e\u200d\u2640":                                         "woman-playing-handball",
+	"\U0001f93e\u200d\u2642":                                         "man-playing-handball",
+	"\U0001f93e\U0001f3fb":                                           "handball_light_skin_tone",
+	"\U0001f93e\U0001f3fb\u200d\u2640":                               "woman-playing-handball_light_skin_tone",
+	"\U0001f93e\U0001f3fb\u200d\u2642":                               "man-playing-handball_light_skin_tone",
+	"\U0001f93e\U0001f3fc":                                           "handball_medium_light_skin_tone",
+	"\U0001f93e\U0001f3fc\u200d\u2640":                               "woman-playing-handball_medium_light_skin_tone",
+	"\U0001f93e\U0001f3fc\u200d\u2642":                               "man-playing-handball_medium_light_skin_tone",
+	"\U0001f93e\U0001f3fd":                                           "handball_medium_skin_tone",
+	"\U0001f93e\U0001f3fd\u200d\u2640":                               "woman-playing-handball_medium_skin_tone",
+	"\U0001f93e\U0001f3fd\u200d\u2642":                               "man-playing-handball_medium_skin_tone",
+	"\U0001f93e\U0001f3fe":                                           "handball_medium_dark_skin_tone",
+	"\U0001f93e\U0001f3fe\u200d\u2640":                               "woman-playing-handball_medium_dark_skin_tone",
+	"\U0001f93e\U0001f3fe\u200d\u2642":                               "man-playing-handball_medium_dark_skin_tone",
+	"\U0001f93e\U0001f3ff":                                           "handball_dark_skin_tone",
+	"\U0001f93e\U0001f3ff\u200d\u2640":                               "woman-playing-handball_dark_skin_tone",
+	"\U0001f93e\U0001f3ff\u200d\u2642":                               "man-playing-handball_dark_skin_tone",
+	"\U0001f93f":                                                     "diving_mask",
+	"\U0001f940":                                                     "wilted_flower",
+	"\U0001f941":                                                     "drum",
+	"\U0001f942":                                                     "clinking_glasses",
+	"\U0001f943":                                                     "tumbler_glass",
+	"\U0001f944":                                                     "spoon",
+	"\U0001f945":                                                     "goal_net",
+	"\U0001f947":                                                     "1st_place_medal",
+	"\U0001f948":                                                     "2nd_place_medal",
+	"\U0001f949":                                                     "3rd_place_medal",
+	"\U0001f94a":                                                     "boxing_glove",
+	"\U0001f94b":                                                     "martial_arts_uniform",
+	"\U0001f94c":                                                     "curling_stone",
+	"\U0001f94d":                                                     "lacrosse",
+	"\U0001f94e":                                                     "softball",
+	"\U0001f94f":                                                     "flying_disc",
+	"\U0001f950":                                                     "croissant",
+	"\U0001f951":                                                     "avocado",
+	"\U0001f952":                                                     "cucumber",
+	"\U0001f953":                                                     "bacon",
+	"\U0001f954":                                                     "potato",
+	"\U0001f955":                                                     "carrot",
+	"\U0001f956":                                                     "baguette_bread",
+	"\U0001f957":                                                     "green_salad",
+	"\U0001f958":                                                     "shallow_pan_of_food",
+	"\U0001f959":                                                     "stuffed_flatbread",
+	"\U0001f95a":                                                     "egg",
+	"\U0001f95b":                                                     "milk_glass",
+	"\U0001f95c":                                                     "peanuts",
+	"\U0001f95d":                                                     "kiwifruit",
+	"\U0001f95e":                                                     "pancakes",
+	"\U0001f95f":                                                     "dumpling",
+	"\U0001f960":                                                     "fortune_cookie",
+	"\U0001f961":                                                     "takeout_box",
+	"\U0001f962":                                                     "chopsticks",
+	"\U0001f963":                                                     "bowl_with_spoon",
+	"\U0001f964":                                                     "cup_with_straw",
+	"\U0001f965":                                                     "coconut",
+	"\U0001f966":                                                     "broccoli",
+	"\U0001f967":                                                     "pie",
+	"\U0001f968":                                                     "pretzel",
+	"\U0001f969":                                                     "cut_of_meat",
+	"\U0001f96a":                                                     "sandwich",
+	"\U0001f96b":                                                     "canned_food",
+	"\U0001f96c":                                                     "leafy_green",
+	"\U0001f96d":                                                     "mango",
+	"\U0001f96e":                                                     "moon_cake",
+	"\U0001f96f":                                                     "bagel",
+	"\U0001f970":                                                     "smiling_face_with_3_hearts",
+	"\U0001f971":                                                     "yawning_face",
+	"\U0001f972":                                                     "smiling_face_with_tear",
+	"\U0001f973":                                                     "partying_face",
+	"\U0001f974":                                                     "woozy_face",
+	"\U0001f975":                                                     "hot_face",
+	"\U0001f976":                                                     "cold_face",
+	"\U0001f977":                                                     "ninja",
+	"\U0001f977\U0001f3fb":                                           "ninja_light_skin_tone",
+	"\U0001f977\U0001f3fc":                                           "ninja_medium_light_skin_tone",
+	"\U0001f977\U0001f3fd":                                           "ninja_medium_skin_tone",
+	"\U0001f977\U0001f3fe":                                           "ninja_medium_dark_skin_tone",
+	"\U0001f977\U0001f3ff":                                           "ninja_dark_skin_tone",
+	"\U0001f978":                                                     "disguised_face",
+	"\U0001f97a":                                                     "pleading_face",
+	"\U0001f97b":                                                     "sari",
+	"\U0001f97c":                                                     "lab_coat",
+	"\U0001f97d":                                                     "goggles",
+	"\U0001f97e":                                                     "hiking_boot",
+	"\U0001f97f":                                                     "womans_flat_shoe",
+	"\U0001f980":                                                     "crab",
+	"\U0001f981":                                                     "lion",
+	"\U0001f982":                                                     "scorpion",
+	"\U0001f983":                                                     "turkey",
+	"\U0001f984":                                                     "unicorn",
+	"\U0001f985":                                                     "eagle",
+	"\U0001f986":                                                     "duck",
+	"\U0001f987":                                                     "bat",
+	"\U0001f988":                                                     "shark",
+	"\U0001f989":                                                     "owl",
+	"\U0001f98a":                                                     "fox_face",
+	"\U0001f98b":                                                     "butterfly",
+	"\U0001f98c":                                                     "deer",
+	"\U0001f98d":                                                     "gorilla",
+	"\U0001f98e":                                                     "lizard",
+	"\U0001f98f":                                                     "rhinoceros",
+	"\U0001f990":                                                     "shrimp",
+	"\U0001f991":                                                     "squid",
+	"\U0001f992":                                                     "giraffe_face",
+	"\U0001f993":                                                     "zebra_face",
+	"\U0001f994":                                                     "hedgehog",
+	"\U0001f995":                                                     "sauropod",
+	"\U0001f996":                                                     "t-rex",
+	"\U0001f997":                                                     "cricket",
+	"\U0001f998":                                                     "kangaroo",
+	"\U0001f999":                                                     "llama",
+	"\U0001f99a":                                                     "peacock",
+	"\U0001f99b":                                                     "hippopotamus",
+	"\U0001f99c":                                                     "parrot",
+	"\U0001f99d":                                                     "raccoon",
+	"\U0001f99e":                                                     "lobster",
+	"\U0001f99f":                                                     "mosquito",
+	"\U0001f9a0":                                                     "microbe",
+	"\U0001f9a1":                                                     "badger",
+	"\U0001f9a2":                                                     "swan",
+	"\U0001f9a3":                                                     "mammoth",
+	"\U0001f9a4":                                                     "dodo",
+	"\U0001f9a5":                                                     "sloth",
+	"\U0001f9a6":                                                     "otter",
+	"\U0001f9a7":                                                     "orangutan",
+	"\U0001f9a8":                                                     "skunk",
+	"\U0001f9a9":                                                     "flamingo",
+	"\U0001f9aa":                                                     "oyster",
+	"\U0001f9ab":                                                     "beaver",
+	"\U0001f9ac":                                                     "bison",
+	"\U0001f9ad":                                                     "seal",
+	"\U0001f9ae":                                                     "guide_dog",
+	"\U0001f9af":                                                     "probing_cane",
+	"\U0001f9b4":                                                     "bone",
+	"\U0001f9b5":                                                     "leg",
+	"\U0001f9b5\U0001f3fb":                                           "leg_light_skin_tone",
+	"\U0001f9b5\U0001f3fc":                                           "leg_medium_light_skin_tone",
+	"\U0001f9b5\U0001f3fd":                                           "leg_medium_skin_tone",
+	"\U0001f9b5\U0001f3fe":                                           "leg_medium_dark_skin_tone",
+	"\U0001f9b5\U0001f3ff":                                           "leg_dark_skin_tone",
+	"\U0001f9b6":                                                     "foot",
+	"\U0001f9b6\U0001f3fb":                                           "foot_light_skin_tone",
+	"\U0001f9b6\U0001f3fc":                                           "foot_medium_light_skin_tone",
+	"\U0001f9b6\U0001f3fd":                                           "foot_medium_skin_tone",
+	"\U0001f9b6\U0001f3fe":                                           "foot_medium_dark_skin_tone",
+	"\U0001f9b6\U0001f3ff":                                           "foot_dark_skin_tone",
+	"\U0001f9b7":                                                     "tooth",
+	"\U0001f9b8":                                                     "superhero",
+	"\U0001f9b8\u200d\u2640":                                         "female_superhero",
+	"\U0001f9b8\u200d\u2642":                                         "male_superhero",
+	"\U0001f9b8\U0001f3fb":                                           "superhero_light_skin_tone",
+	"\U0001f9b8\U0001f3fb\u200d\u2640":                               "female_superhero_light_skin_tone",
+	"\U0001f9b8\U0001f3fb\u200d\u2642":                               "male_superhero_light_skin_tone",
+	"\U0001f9b8\U0001f3fc":                                           "superhero_medium_light_skin_tone",
+	"\U0001f9b8\U0001f3fc\u200d\u2640":                               "female_superhero_medium_light_skin_tone",
+	"\U0001f9b8\U0001f3fc\u200d\u2642":                               "male_superhero_medium_light_skin_tone",
+	"\U0001f9b8\U0001f3fd":                                           "superhero_medium_skin_tone",
+	"\U0001f9b8\U0001f3fd\u200d\u2640":                               "female_superhero_medium_skin_tone",
+	"\U0001f9b8\U0001f3fd\u200d\u2642":                               "male_superhero_medium_skin_tone",
+	"\U0001f9b8\U0001f3fe":                                           "superhero_medium_dark_skin_tone",
+	"\U0001f9b8\U0001f3fe\u200d\u2640":                               "female_superhero_medium_dark_skin_tone",
+	"\U0001f9b8\U0001f3fe\u200d\u2642":                               "male_superhero_medium_dark_skin_tone",
+	"\U0001f9b8\U0001f3ff":                                           "superhero_dark_skin_tone",
+	"\U0001f9b8\U0001f3ff\u200d\u2640":                               "female_superhero_dark_skin_tone",
+	"\U0001f9b8\U0001f3ff\u200d\u2642":                               "male_superhero_dark_skin_tone",
+	"\U0001f9b9":                                                     "supervillain",
+	"\U0001f9b9\u200d\u2640":                                         "female_supervillain",
+	"\U0001f9b9\u200d\u2642":                                         "male_supervillain",
+	"\U0001f9b9\U0001f3fb":                                           "supervillain_light_skin_tone",
+	"\U0001f9b9\U0001f3fb\u200d\u2640":                               "female_supervillain_light_skin_tone",
+	"\U0001f9b9\U0001f3fb\u200d\u2642":                               "male_supervillain_light_skin_tone",
+	"\U0001f9b9\U0001f3fc":                                           "supervillain_medium_light_skin_tone",
+	"\U0001f9b9\U0001f3fc\u200d\u2640":                               "female_supervillain_medium_light_skin_tone",
+	"\U0001f9b9\U0001f3fc\u200d\u2642":                               "male_supervillain_medium_light_skin_tone",
+	"\U0001f9b9\U0001f3fd":                                           "supervillain_medium_skin_tone",
+	"\U0001f9b9\U0001f3fd\u200d\u2640":                               "female_supervillain_medium_skin_tone",
+	"\U0001f9b9\U0001f3fd\u200d\u2642":                               "male_supervillain_medium_skin_tone",
+	"\U0001f9b9\U0001f3fe":                                           "supervillain_medium_dark_skin_tone",
+	"\U0001f9b9\U0001f3fe\u200d\u2640":                               "female_supervillain_medium_dark_skin_tone",
+	"\U0001f9b9\U0001f3fe\u200d\u2642":                               "male_supervillain_medium_dark_skin_tone",
+	"\U0001f9b9\U0001f3ff":                                           "supervillain_dark_skin_tone",
+	"\U0001f9b9\U0001f3ff\u200d\u2640":                               "female_supervillain_dark_skin_tone",
+	"\U0001f9b9\U0001f3ff\u200d\u2642":                               "male_supervillain_dark_skin_tone",
+	"\U0001f9ba":                                                     "safety_vest",
+	"\U0001f9bb":                                                     "ear_with_hearing_aid",
+	"\U0001f9bb\U0001f3fb":                                           "ear_with_hearing_aid_light_skin_tone",
+	"\U0001f9bb\U0001f3fc":                                           "ear_with_hearing_aid_medium_light_skin_tone",
+	"\U0001f9bb\U0001f3fd":                                           "ear_with_hearing_aid_medium_skin_tone",
+	"\U0001f9bb\U0001f3fe":                                           "ear_with_hearing_aid_medium_dark_skin_tone",
+	"\U0001f9bb\U0001f3ff":                                           "ear_with_hearing_aid_dark_skin_tone",
+	"\U0001f9bc":                                                     "motorized_wheelchair",
+	"\U0001f9bd":                                                     "manual_wheelchair",
+	"\U0001f9be":                                                     "mechanical_arm",
+	"\U0001f9bf":                                                     "mechanical_leg",
+	"\U0001f9c0":                                                     "cheese",
+	"\U0001f9c1":                                                     "cupcake",
+	"\U0001f9c2":                                                     "salt",
+	"\U0001f9c3":                                                     "beverage_box",
+	"\U0001f9c4":                                                     "garlic",
+	"\U0001f9c5":                                                     "onion",
+	"\U0001f9c6":                                                     "falafel",
+	"\U0001f9c7":                                                     "waffle",
+	"\U0001f9c8":                                                     "butter",
+	"\U0001f9c9":                                                     "mate_drink",
+	"\U0001f9ca":                                                     "ice_cube",
+	"\U0001f9cb":                                                     "bubble_tea",
+	"\U0001f9cd":                                                     "standing_person",
+	"\U0001f9cd\u200d\u2640":                                         "woman_standing",
+	"\U0001f9cd\u200d\u2642":                                         "man_standing",
+	"\U0001f9cd\U0001f3fb":                                           "standing_person_light_skin_tone",
+	"\U0001f9cd\U0001f3fb\u200d\u2640":                               "woman_standing_light_skin_tone",
+	"\U0001f9cd\U0001f3fb\u200d\u2642":                               "man_standing_light_skin_tone",
+	"\U0001f9cd\U0001f3fc":                                           "standing_person_medium_light_skin_tone",
+	"\U0001f9cd\U0001f3fc\u200d\u2640":                               "woman_standing_medium_light_skin_tone",
+	"\U0001f9cd\U0001f3fc\u200d\u2642":                               "man_standing_medium_light_skin_tone",
+	"\U0001f9cd\U0001f3fd":                                           "standing_person_medium_skin_tone",
+	"\U0001f9cd\U0001f3fd\u200d\u2640":                               "woman_standing_medium_skin_tone",
+	"\U0001f9cd\U0001f3fd\u200d\u2642":                               "man_standing_medium_skin_tone",
+	"\U0001f9cd\U0001f3fe":                                           "standing_person_medium_dark_skin_tone",
+	"\U0001f9cd\U0001f3fe\u200d\u2640":                               "woman_standing_medium_dark_skin_tone",
+	"\U0001f9cd\U0001f3fe\u200d\u2642":                               "man_standing_medium_dark_skin_tone",
+	"\U0001f9cd\U0001f3ff":                                           "standing_person_dark_skin_tone",
+	"\U0001f9cd\U0001f3ff\u200d\u2640":                               "woman_standing_dark_skin_tone",
+	"\U0001f9cd\U0001f3ff\u200d\u2642":                               "man_standing_dark_skin_tone",
+	"\U0001f9ce":                                                     "kneeling_person",
+	"\U0001f9ce\u200d\u2640":                                         "woman_kneeling",
+	"\U0001f9ce\u200d\u2642":                                         "man_kneeling",
+	"\U0001f9ce\U0001f3fb":                                           "kneeling_person_light_skin_tone",
+	"\U0001f9ce\U0001f3fb\u200d\u2640":                               "woman_kneeling_light_skin_tone",
+	"\U0001f9ce\U0001f3fb\u200d\u2642":                               "man_kneeling_light_skin_tone",
+	"\U0001f9ce\U0001f3fc":                                           "kneeling_person_medium_light_skin_tone",
+	"\U0001f9ce\U0001f3fc\u200d\u2640":                               "woman_kneeling_medium_light_skin_tone",
+	"\U0001f9ce\U0001f3fc\u200d\u2642":                               "man_kneeling_medium_light_skin_tone",
+	"\U0001f9ce\U0001f3fd":                                           "kneeling_person_medium_skin_tone",
+	"\U0001f9ce\U0001f3fd\u200d\u2640":                               "woman_kneeling_medium_skin_tone",
+	"\U0001f9ce\U0001f3fd\u200d\u2642":                               "man_kneeling_medium_skin_tone",
+	"\U0001f9ce\U0001f3fe":                                           "kneeling_person_medium_dark_skin_tone",
+	"\U0001f9ce\U0001f3fe\u200d\u2640":                               "woman_kneeling_medium_dark_skin_tone",
+	"\U0001f9ce\U0001f3fe\u200d\u2642":                               "man_kneeling_medium_dark_skin_tone",
+	"\U0001f9ce\U0001f3ff":                                           "kneeling_person_dark_skin_tone",
+	"\U0001f9ce\U0001f3ff\u200d\u2640":                               "woman_kneeling_dark_skin_tone",
+	"\U0001f9ce\U0001f3ff\u200d\u2642":                               "man_kneeling_dark_skin_tone",
+	"\U0001f9cf":                                                     "deaf_person",
+	"\U0001f9cf\u200d\u2640":                                         "deaf_woman",
+	"\U0001f9cf\u200d\u2642":                                         "deaf_man",
+	"\U0001f9cf\U0001f3fb":                                           "deaf_person_light_skin_tone",
+	"\U0001f9cf\U0001f3fb\u200d\u2640":                               "deaf_woman_light_skin_tone",
+	"\U0001f9cf\U0001f3fb\u200d\u2642":                               "deaf_man_light_skin_tone",
+	"\U0001f9cf\U0001f3fc":                                           "deaf_person_medium_light_skin_tone",
+	"\U0001f9cf\U0001f3fc\u200d\u2640":                               "deaf_woman_medium_light_skin_tone",
+	"\U0001f9cf\U0001f3fc\u200d\u2642":                               "deaf_man_medium_light_skin_tone",
+	"\U0001f9cf\U0001f3fd":                                           "deaf_person_medium_skin_tone",
+	"\U0001f9cf\U0001f3fd\u200d\u2640":                               "deaf_woman_medium_skin_tone",
+	"\U0001f9cf\U0001f3fd\u200d\u2642":                               "deaf_man_medium_skin_tone",
+	"\U0001f9cf\U0001f3fe":                                           "deaf_person_medium_dark_skin_tone",
+	"\U0001f9cf\U0001f3fe\u200d\u2640":                               "deaf_woman_medium_dark_skin_tone",
+	"\U0001f9cf\U0001f3fe\u200d\u2642":                               "deaf_man_medium_dark_skin_tone",
+	"\U0001f9cf\U0001f3ff":                                           "deaf_person_dark_skin_tone",
+	"\U0001f9cf\U0001f3ff\u200d\u2640":                               "deaf_woman_dark_skin_tone",
+	"\U0001f9cf\U0001f3ff\u200d\u2642":                               "deaf_man_dark_skin_tone",
+	"\U0001f9d0":                                                     "face_with_monocle",
+	"\U0001f9d1":                                                     "adult",
+	"\U0001f9d1\u200d\u2695":                                         "doctor",
+	"\U0001f9d1\u200d\u2696":                                         "judge",
+	"\U0001f9d1\u200d\u2708":                                         "pilot",
+	"\U0001f9d1\u200d\U0001f33e":                                     "farmer",
+	"\U0001f9d1\u200d\U0001f373":                                     "cook",
+	"\U0001f9d1\u200d\U0001f37c":                                     "person_feeding_baby",
+	"\U0001f9d1\u200d\U0001f384":                                     "mx_claus",
+	"\U0001f9d1\u200d\U0001f393":                                     "student",
+	"\U0001f9d1\u200d\U0001f3a4":                                     "singer",
+	"\U0001f9d1\u200d\U0001f3a8":                                     "artist",
+	"\U0001f9d1\u200d\U0001f3eb":                                     "teacher",
+	"\U0001f9d1\u200d\U0001f3ed":                                     "factory_worker",
+	"\U0001f9d1\u200d\U0001f4bb":                                     "technologist",
+	"\U0001f9d1\u200d\U0001f4bc":                                     "office_worker",
+	"\U0001f9d1\u200d\U0001f527":                                     "mechanic",
+	"\U0001f9d1\u200d\U0001f52c":                                     "scientist",
+	"\U0001f9d1\u200d\U0001f680":                                     "astronaut",
+	"\U0001f9d1\u200d\U0001f692":                                     "firefighter",
+	"\U0001f9d1\u200d\U0001f91d\u200d\U0001f9d1":                     "people_holding_hands",
+	"\U0001f9d1\u200d\U0001f9af":                                     "person_with_probing_cane",
+	"\U0001f9d1\u200d\U0001f9b0":                                     "red_haired_person",
+	"\U0001f9d1\u200d\U0001f9b1":                                     "curly_haired_person",
+	"\U0001f9d1\u200d\U0001f9b2":                                     "bald_person",
+	"\U0001f9d1\u200d\U0001f9b3":                                     "white_haired_person",
+	"\U0001f9d1\u200d\U0001f9bc":                                     "person_in_motorized_wheelchair",
+	"\U0001f9d1\u200d\U0001f9bd":                                     "person_in_manual_wheelchair",
+	"\U0001f9d1\U0001f3fb":                                           "adult_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\u2695":                               "doctor_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\u2696":                               "judge_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\u2708":                               "pilot_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f33e":                           "farmer_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f373":                           "cook_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f37c":                           "person_feeding_baby_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f384":                           "mx_claus_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f393":                           "student_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f3a4":                           "singer_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f3a8":                           "artist_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f3eb":                           "teacher_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f3ed":                           "factory_worker_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f4bb":                           "technologist_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f4bc":                           "office_worker_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f527":                           "mechanic_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f52c":                           "scientist_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f680":                           "astronaut_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f692":                           "firefighter_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb": "people_holding_hands_light_skin_tone_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc": "people_holding_hands_light_skin_tone_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd": "people_holding_hands_light_skin_tone_medium_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe": "people_holding_hands_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff": "people_holding_hands_light_skin_tone_dark_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f9af":                           "person_with_probing_cane_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f9b0":                           "red_haired_person_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f9b1":                           "curly_haired_person_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f9b2":                           "bald_person_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f9b3":                           "white_haired_person_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f9bc":                           "person_in_motorized_wheelchair_light_skin_tone",
+	"\U0001f9d1\U0001f3fb\u200d\U0001f9bd":                           "person_in_manual_wheelchair_light_skin_tone",
+	"\U0001f9d1\U0001f3fc":                                           "adult_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\u2695":                               "doctor_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\u2696":                               "judge_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\u2708":                               "pilot_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f33e":                           "farmer_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f373":                           "cook_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f37c":                           "person_feeding_baby_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f384":                           "mx_claus_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f393":                           "student_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f3a4":                           "singer_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f3a8":                           "artist_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f3eb":                           "teacher_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f3ed":                           "factory_worker_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f4bb":                           "technologist_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f4bc":                           "office_worker_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f527":                           "mechanic_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f52c":                           "scientist_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f680":                           "astronaut_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f692":                           "firefighter_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb": "people_holding_hands_medium_light_skin_tone_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc": "people_holding_hands_medium_light_skin_tone_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd": "people_holding_hands_medium_light_skin_tone_medium_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe": "people_holding_hands_medium_light_skin_tone_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff": "people_holding_hands_medium_light_skin_tone_dark_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f9af":                           "person_with_probing_cane_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f9b0":                           "red_haired_person_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f9b1":                           "curly_haired_person_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f9b2":                           "bald_person_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f9b3":                           "white_haired_person_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f9bc":                           "person_in_motorized_wheelchair_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fc\u200d\U0001f9bd":                           "person_in_manual_wheelchair_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fd":                                           "adult_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\u2695":                               "doctor_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\u2696":                               "judge_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\u2708":                               "pilot_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f33e":                           "farmer_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f373":                           "cook_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f37c":                           "person_feeding_baby_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f384":                           "mx_claus_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f393":                           "student_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f3a4":                           "singer_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f3a8":                           "artist_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f3eb":                           "teacher_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f3ed":                           "factory_worker_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f4bb":                           "technologist_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f4bc":                           "office_worker_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f527":                           "mechanic_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f52c":                           "scientist_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f680":                           "astronaut_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f692":                           "firefighter_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb": "people_holding_hands_medium_skin_tone_light_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc": "people_holding_hands_medium_skin_tone_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd": "people_holding_hands_medium_skin_tone_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe": "people_holding_hands_medium_skin_tone_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff": "people_holding_hands_medium_skin_tone_dark_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f9af":                           "person_with_probing_cane_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f9b0":                           "red_haired_person_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f9b1":                           "curly_haired_person_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f9b2":                           "bald_person_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f9b3":                           "white_haired_person_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f9bc":                           "person_in_motorized_wheelchair_medium_skin_tone",
+	"\U0001f9d1\U0001f3fd\u200d\U0001f9bd":                           "person_in_manual_wheelchair_medium_skin_tone",
+	"\U0001f9d1\U0001f3fe":                                           "adult_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\u2695":                               "doctor_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\u2696":                               "judge_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\u2708":                               "pilot_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f33e":                           "farmer_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f373":                           "cook_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f37c":                           "person_feeding_baby_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f384":                           "mx_claus_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f393":                           "student_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f3a4":                           "singer_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f3a8":                           "artist_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f3eb":                           "teacher_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f3ed":                           "factory_worker_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f4bb":                           "technologist_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f4bc":                           "office_worker_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f527":                           "mechanic_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f52c":                           "scientist_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f680":                           "astronaut_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f692":                           "firefighter_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb": "people_holding_hands_medium_dark_skin_tone_light_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc": "people_holding_hands_medium_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd": "people_holding_hands_medium_dark_skin_tone_medium_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe": "people_holding_hands_medium_dark_skin_tone_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff": "people_holding_hands_medium_dark_skin_tone_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f9af":                           "person_with_probing_cane_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f9b0":                           "red_haired_person_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f9b1":                           "curly_haired_person_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f9b2":                           "bald_person_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f9b3":                           "white_haired_person_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f9bc":                           "person_in_motorized_wheelchair_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3fe\u200d\U0001f9bd":                           "person_in_manual_wheelchair_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff":                                           "adult_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\u2695":                               "doctor_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\u2696":                               "judge_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\u2708":                               "pilot_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f33e":                           "farmer_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f373":                           "cook_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f37c":                           "person_feeding_baby_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f384":                           "mx_claus_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f393":                           "student_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f3a4":                           "singer_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f3a8":                           "artist_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f3eb":                           "teacher_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f3ed":                           "factory_worker_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f4bb":                           "technologist_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f4bc":                           "office_worker_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f527":                           "mechanic_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f52c":                           "scientist_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f680":                           "astronaut_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f692":                           "firefighter_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fb": "people_holding_hands_dark_skin_tone_light_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fc": "people_holding_hands_dark_skin_tone_medium_light_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fd": "people_holding_hands_dark_skin_tone_medium_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3fe": "people_holding_hands_dark_skin_tone_medium_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f91d\u200d\U0001f9d1\U0001f3ff": "people_holding_hands_dark_skin_tone_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f9af":                           "person_with_probing_cane_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f9b0":                           "red_haired_person_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f9b1":                           "curly_haired_person_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f9b2":                           "bald_person_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f9b3":                           "white_haired_person_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f9bc":                           "person_in_motorized_wheelchair_dark_skin_tone",
+	"\U0001f9d1\U0001f3ff\u200d\U0001f9bd":                           "person_in_manual_wheelchair_dark_skin_tone",
+	"\U0001f9d2":                                                     "child",
+	"\U0001f9d2\U0001f3fb":                                           "child_light_skin_tone",
+	"\U0001f9d2\U0001f3fc":                                           "child_medium_light_skin_tone",
+	"\U0001f9d2\U0001f3fd":                                           "child_medium_skin_tone",
+	"\U0001f9d2\U0001f3fe":                                           "child_medium_dark_skin_tone",
+	"\U0001f9d2\U0001f3ff":                                           "child_dark_skin_tone",
+	"\U0001f9d3":                                                     "older_adult",
+	"\U0001f9d3\U0001f3fb":                                           "older_adult_light_skin_tone",
+	"\U0001f9d3\U0001f3fc":                                           "older_adult_medium_light_skin_tone",
+	"\U0001f9d3\U0001f3fd":                                           "older_adult_medium_skin_tone",
+	"\U0001f9d3\U0001f3fe":                                           "older_adult_medium_dark_skin_tone",
+	"\U0001f9d3\U0001f3ff":                                           "older_adult_dark_skin_tone",
+	"\U0001f9d4":                                                     "bearded_person",
+	"\U0001f9d4\U0001f3fb":                                           "bearded_person_light_skin_tone",
+	"\U0001f9d4\U0001f3fc":                                           "bearded_person_medium_light_skin_tone",
+	"\U0001f9d4\U0001f3fd":                                           "bearded_person_medium_skin_tone",
+	"\U0001f9d4\U0001f3fe":                                           "bearded_person_medium_dark_skin_tone",
+	"\U0001f9d4\U0001f3ff":                                           "bearded_person_dark_skin_tone",
+	"\U0001f9d5":                                                     "person_with_headscarf",
+	"\U0001f9d5\U0001f3fb":                                           "person_with_headscarf_light_skin_tone",
+	"\U0001f9d5\U0001f3fc":                                           "person_with_headscarf_medium_light_skin_tone",
+	"\U0001f9d5\U0001f3fd":                                           "person_with_headscarf_medium_skin_tone",
+	"\U0001f9d5\U0001f3fe":                                           "person_with_headscarf_medium_dark_skin_tone",
+	"\U0001f9d5\U0001f3ff":                                           "person_with_headscarf_dark_skin_tone",
+	"\U0001f9d6":                                                     "person_in_steamy_room",
+	"\U0001f9d6\u200d\u2640":                                         "woman_in_steamy_room",
+	"\U0001f9d6\u200d\u2642":                                         "man_in_steamy_room",
+	"\U0001f9d6\U0001f3fb":                                           "person_in_steamy_room_light_skin_tone",
+	"\U0001f9d6\U0001f3fb\u200d\u2640":                               "woman_in_steamy_room_light_skin_tone",
+	"\U0001f9d6\U0001f3fb\u200d\u2642":                               "man_in_steamy_room_light_skin_tone",
+	"\U0001f9d6\U0001f3fc":                                           "person_in_steamy_room_medium_light_skin_tone",
+	"\U0001f9d6\U0001f3fc\u200d\u2640":                               "woman_in_steamy_room_medium_light_skin_tone",
+	"\U0001f9d6\U0001f3fc\u200d\u2642":                               "man_in_steamy_room_medium_light_skin_tone",
+	"\U0001f9d6\U0001f3fd":                                           "person_in_steamy_room_medium_skin_tone",
+	"\U0001f9d6\U0001f3fd\u200d\u2640":                               "woman_in_steamy_room_medium_skin_tone",
+	"\U0001f9d6\U0001f3fd\u200d\u2642":                               "man_in_steamy_room_medium_skin_tone",
+	"\U0001f9d6\U0001f3fe":                                           "person_in_steamy_room_medium_dark_skin_tone",
+	"\U0001f9d6\U0001f3fe\u200d\u2640":                               "woman_in_steamy_room_medium_dark_skin_tone",
+	"\U0001f9d6\U0001f3fe\u200d\u2642":                               "man_in_steamy_room_medium_dark_skin_tone",
+	"\U0001f9d6\U0001f3ff":                                           "person_in_steamy_room_dark_skin_tone",
+	"\U0001f9d6\U0001f3ff\u200d\u2640":                               "woman_in_steamy_room_dark_skin_tone",
+	"\U0001f9d6\U0001f3ff\u200d\u2642":                               "man_in_steamy_room_dark_skin_tone",
+	"\U0001f9d7":                                                     "person_climbing",
+	"\U0001f9d7\u200d\u2640":                                         "woman_climbing",
+	"\U0001f9d7\u200d\u2642":                                         "man_climbing",
+	"\U0001f9d7\U0001f3fb":                                           "person_climbing_light_skin_tone",
+	"\U0001f9d7\U0001f3fb\u200d\u2640":                               "woman_climbing_light_skin_tone",
+	"\U0001f9d7\U0001f3fb\u200d\u2642":                               "man_climbing_light_skin_tone",
+	"\U0001f9d7\U0001f3fc":                                           "person_climbing_medium_light_skin_tone",
+	"\U0001f9d7\U0001f3fc\u200d\u2640":                               "woman_climbing_medium_light_skin_tone",
+	"\U0001f9d7\U0001f3fc\u200d\u2642":                               "man_climbing_medium_light_skin_tone",
+	"\U0001f9d7\U0001f3fd":                                           "person_climbing_medium_skin_tone",
+	"\U0001f9d7\U0001f3fd\u200d\u2640":                               "woman_climbing_medium_skin_tone",
+	"\U0001f9d7\U0001f3fd\u200d\u2642":                               "man_climbing_medium_skin_tone",
+	"\U0001f9d7\U0001f3fe":                                           "person_climbing_medium_dark_skin_tone",
+	"\U0001f9d7\U0001f3fe\u200d\u2640":                               "woman_climbing_medium_dark_skin_tone",
+	"\U0001f9d7\U0001f3fe\u200d\u2642":                               "man_climbing_medium_dark_skin_tone",
+	"\U0001f9d7\U0001f3ff":                                           "person_climbing_dark_skin_tone",
+	"\U0001f9d7\U0001f3ff\u200d\u2640":                               "woman_climbing_dark_skin_tone",
+	"\U0001f9d7\U0001f3ff\u200d\u2642":                               "man_climbing_dark_skin_tone",
+	"\U0001f9d8":                                                     "person_in_lotus_position",
+	"\U0001f9d8\u200d\u2640":                                         "woman_in_lotus_position",
+	"\U0001f9d8\u200d\u2642":                                         "man_in_lotus_position",
+	"\U0001f9d8\U0001f3fb":                                           "person_in_lotus_position_light_skin_tone",
+	"\U0001f9d8\U0001f3fb\u200d\u2640":                               "woman_in_lotus_position_light_skin_tone",
+	"\U0001f9d8\U0001f3fb\u200d\u2642":                               "man_in_lotus_position_light_skin_tone",
+	"\U0001f9d8\U0001f3fc":                                           "person_in_lotus_position_medium_light_skin_tone",
+	"\U0001f9d8\U0001f3fc\u200d\u2640":                               "woman_in_lotus_position_medium_light_skin_tone",
+	"\U0001f9d8\U0001f3fc\u200d\u2642":                               "man_in_lotus_position_medium_light_skin_tone",
+	"\U0001f9d8\U0001f3fd":                                           "person_in_lotus_position_medium_skin_tone",
+	"\U0001f9d8\U0001f3fd\u200d\u2640":                               "woman_in_lotus_position_medium_skin_tone",
+	"\U0001f9d8\U0001f3fd\u200d\u2642":                               "man_in_lotus_position_medium_skin_tone",
+	"\U0001f9d8\U0001f3fe":                                           "person_in_lotus_position_medium_dark_skin_tone",
+	"\U0001f9d8\U0001f3fe\u200d\u2640":                               "woman_in_lotus_position_medium_dark_skin_tone",
+	"\U0001f9d8\U0001f3fe\u200d\u2642":                               "man_in_lotus_position_medium_dark_skin_tone",
+	"\U0001f9d8\U0001f3ff":                                           "person_in_lotus_position_dark_skin_tone",
+	"\U0001f9d8\U0001f3ff\u200d\u2640":                               "woman_in_lotus_position_dark_skin_tone",
+	"\U0001f9d8\U0001f3ff\u200d\u2642":                               "man_in_lotus_position_dark_skin_tone",
+	"\U0001f9d9":                                                     "mage",
+	"\U0001f9d9\u200d\u2640":                                         "female_mage",
+	"\U0001f9d9\u200d\u2642":                                         "male_mage",
+	"\U0001f9d9\U0001f3fb":                                           "mage_light_skin_tone",
+	"\U0001f9d9\U0001f3fb\u200d\u2640":                               "female_mage_light_skin_tone",
+	"\U0001f9d9\U0001f3fb\u200d\u2642":                               "male_mage_light_skin_tone",
+	"\U0001f9d9\U0001f3fc":                                           "mage_medium_light_skin_tone",
+	"\U0001f9d9\U0001f3fc\u200d\u2640":                               "female_mage_medium_light_skin_tone",
+	"\U0001f9d9\U0001f3fc\u200d\u2642":                               "male_mage_medium_light_skin_tone",
+	"\U0001f9d9\U0001f3fd":                                           "mage_medium_skin_tone",
+	"\U0001f9d9\U0001f3fd\u200d\u2640":                               "female_mage_medium_skin_tone",
+	"\U0001f9d9\U0001f3fd\u200d\u2642":                               "male_mage_medium_skin_tone",
+	"\U0001f9d9\U0001f3fe":                                           "mage_medium_dark_skin_tone",
+	"\U0001f9d9\U0001f3fe\u200d\u2640":                               "female_mage_medium_dark_skin_tone",
+	"\U0001f9d9\U0001f3fe\u200d\u2642":                               "male_mage_medium_dark_skin_tone",
+	"\U0001f9d9\U0001f3ff":                                           "mage_dark_skin_tone",
+	"\U0001f9d9\U0001f3ff\u200d\u2640":                               "female_mage_dark_skin_tone",
+	"\U0001f9d9\U0001f3ff\u200d\u2642":                               "male_mage_dark_skin_tone",
+	"\U0001f9da":                                                     "fairy",
+	"\U0001f9da\u200d\u2640":                                         "female_fairy",
+	"\U0001f9da\u200d\u2642":                                         "male_fairy",
+	"\U0001f9da\U0001f3fb":                                           "fairy_light_skin_tone",
+	"\U0001f9da\U0001f3fb\u200d\u2640":                               "female_fairy_light_skin_tone",
+	"\U0001f9da\U0001f3fb\u200d\u2642":                               "male_fairy_light_skin_tone",
+	"\U0001f9da\U0001f3fc":                                           "fairy_medium_light_skin_tone",
+	"\U0001f9da\U0001f3fc\u200d\u2640":                               "female_fairy_medium_light_skin_tone",
+	"\U0001f9da\U0001f3fc\u200d\u2642":                               "male_fairy_medium_light_skin_tone",
+	"\U0001f9da\U0001f3fd":                                           "fairy_medium_skin_tone",
+	"\U0001f9da\U0001f3fd\u200d\u2640":                               "female_fairy_medium_skin_tone",
+	"\U0001f9da\U0001f3fd\u200d\u2642":                               "male_fairy_medium_skin_tone",
+	"\U0001f9da\U0001f3fe":                                           "fairy_medium_dark_skin_tone",
+	"\U0001f9da\U0001f3fe\u200d\u2640":                               "female_fairy_medium_dark_skin_tone",
+	"\U0001f9da\U0001f3fe\u200d\u2642":                               "male_fairy_medium_dark_skin_tone",
+	"\U0001f9da\U0001f3ff":                                           "fairy_dark_skin_tone",
+	"\U0001f9da\U0001f3ff\u200d\u2640":                               "female_fairy_dark_skin_tone",
+	"\U0001f9da\U0001f3ff\u200d\u2642":                               "male_fairy_dark_skin_tone",
+	"\U0001f9db":                                                     "vampire",
+	"\U0001f9db\u200d\u2640":                                         "female_vampire",
+	"\U0001f9db\u200d\u2642":                                         "male_vampire",
+	"\U0001f9db\U0001f3fb":                                           "vampire_light_skin_tone",
+	"\U0001f9db\U0001f3fb\u200d\u2640":                               "female_vampire_light_skin_tone",
+	"\U0001f9db\U0001f3fb\u200d\u2642":                               "male_vampire_light_skin_tone",
+	"\U0001f9db\U0001f3fc":                                           "vampire_medium_light_skin_tone",
+	"\U0001f9db\U0001f3fc\u200d\u2640":                               "female_vampire_medium_light_skin_tone",
+	"\U0001f9db\U0001f3fc\u200d\u2642":                               "male_vampire_medium_light_skin_tone",
+	"\U0001f9db\U0001f3fd":                                           "vampire_medium_skin_tone",
+	"\U0001f9db\U0001f3fd\u200d\u2640":                               "female_vampire_medium_skin_tone",
+	"\U0001f9db\U0001f3fd\u200d\u2642":                               "male_vampire_medium_skin_tone",
+	"\U0001f9db\U0001f3fe":                                           "vampire_medium_dark_skin_tone",
+	"\U0001f9db\U0001f3fe\u200d\u2640":                               "female_vampire_medium_dark_skin_tone",
+	"\U0001f9db\U0001f3fe\u200d\u2642":                               "male_vampire_medium_dark_skin_tone",
+	"\U0001f9db\U0001f3ff":                                           "vampire_dark_skin_tone",
+	"\U0001f9db\U0001f3ff\u200d\u2640":                               "female_vampire_dark_skin_tone",
+	"\U0001f9db\U0001f3ff\u200d\u2642":                               "male_vampire_dark_skin_tone",
+	"\U0001f9dc":                                                     "merperson",
+	"\U0001f9dc\u200d\u2640":                                         "mermaid",
+	"\U0001f9dc\u200d\u2642":                                         "merman",
+	"\U0001f9dc\U0001f3fb":                                           "merperson_light_skin_tone",
+	"\U0001f9dc\U0001f3fb\u200d\u2640":                               "mermaid_light_skin_tone",
+	"\U0001f9dc\U0001f3fb\u200d\u2642":                               "merman_light_skin_tone",
+	"\U0001f9dc\U0001f3fc":                                           "merperson_medium_light_skin_tone",
+	"\U0001f9dc\U0001f3fc\u200d\u2640":                               "mermaid_medium_light_skin_tone",
+	"\U0001f9dc\U0001f3fc\u200d\u2642":                               "merman_medium_light_skin_tone",
+	"\U0001f9dc\U0001f3fd":                                           "merperson_medium_skin_tone",
+	"\U0001f9dc\U0001f3fd\u200d\u2640":                               "mermaid_medium_skin_tone",
+	"\U0001f9dc\U0001f3fd\u200d\u2642":                               "merman_medium_skin_tone",
+	"\U0001f9dc\U0001f3fe":                                           "merperson_medium_dark_skin_tone",
+	"\U0001f9dc\U0001f3fe\u200d\u2640":                               "mermaid_medium_dark_skin_tone",
+	"\U0001f9dc\U0001f3fe\u200d\u2642":                               "merman_medium_dark_skin_tone",
+	"\U0001f9dc\U0001f3ff":                                           "merperson_dark_skin_tone",
+	"\U0001f9dc\U0001f3ff\u200d\u2640":                               "mermaid_dark_skin_tone",
+	"\U0001f9dc\U0001f3ff\u200d\u2642":                               "merman_dark_skin_tone",
+	"\U0001f9dd":                                                     "elf",
+	"\U0001f9dd\u200d\u2640":                                         "female_elf",
+	"\U0001f9dd\u200d\u2642":                                         "male_elf",
+	"\U0001f9dd\U0001f3fb":                                           "elf_light_skin_tone",
+	"\U0001f9dd\U0001f3fb\u200d\u2640":                               "female_elf_light_skin_tone",
+	"\U0001f9dd\U0001f3fb\u200d\u2642":                               "male_elf_light_skin_tone",
+	"\U0001f9dd\U0001f3fc":                                           "elf_medium_light_skin_tone",
+	"\U0001f9dd\U0001f3fc\u200d\u2640":                               "female_elf_medium_light_skin_tone",
+	"\U0001f9dd\U0001f3fc\u200d\u2642":                               "male_elf_medium_light_skin_tone",
+	"\U0001f9dd\U0001f3fd":                                           "elf_medium_skin_tone",
+	"\U0001f9dd\U0001f3fd\u200d\u2640":                               "female_elf_medium_skin_tone",
+	"\U0001f9dd\U0001f3fd\u200d\u2642":                               "male_elf_medium_skin_tone",
+	"\U0001f9dd\U0001f3fe":                                           "elf_medium_dark_skin_tone",
+	"\U0001f9dd\U0001f3fe\u200d\u2640":                               "female_elf_medium_dark_skin_tone",
+	"\U0001f9dd\U0001f3fe\u200d\u2642":                               "male_elf_medium_dark_skin_tone",
+	"\U0001f9dd\U0001f3ff":                                           "elf_dark_skin_tone",
+	"\U0001f9dd\U0001f3ff\u200d\u2640":                               "female_elf_dark_skin_tone",
+	"\U0001f9dd\U0001f3ff\u200d\u2642":                               "male_elf_dark_skin_tone",
+	"\U0001f9de":                                                     "genie",
+	"\U0001f9de\u200d\u2640":                                         "female_genie",
+	"\U0001f9de\u200d\u2642":                                         "male_genie",
+	"\U0001f9df":                                                     "zombie",
+	"\U0001f9df\u200d\u2640":                                         "female_zombie",
+	"\U0001f9df\u200d\u2642":                                         "male_zombie",
+	"\U0001f9e0":                                                     "brain",
+	"\U0001f9e1":                                                     "orange_heart",
+	"\U0001f9e2":                                                     "billed_cap",
+	"\U0001f9e3":                                                     "scarf",
+	"\U0001f9e4":                                                     "gloves",
+	"\U0001f9e5":                                                     "coat",
+	"\U0001f9e6":                                                     "socks",
+	"\U0001f9e7":                                                     "red_envelope",
+	"\U0001f9e8":                                                     "firecracker",
+	"\U0001f9e9":                                                     "jigsaw",
+	"\U0001f9ea":                                                     "test_tube",
+	"\U0001f9eb":                                                     "petri_dish",
+	"\U0001f9ec":                                                     "dna",
+	"\U0001f9ed":                                                     "compass",
+	"\U0001f9ee":                                                     "abacus",
+	"\U0001f9ef":                                                     "fire_extinguisher",
+	"\U0001f9f0":                                                     "toolbox",
+	"\U0001f9f1":                                                     "bricks",
+	"\U0001f9f2":                                                     "magnet",
+	"\U0001f9f3":                                                     "luggage",
+	"\U0001f9f4":                                                     "lotion_bottle",
+	"\U0001f9f5":                                                     "thread",
+	"\U0001f9f6":                                                     "yarn",
+	"\U0001f9f7":                                                     "safety_pin",
+	"\U0001f9f8":                                                     "teddy_bear",
+	"\U0001f9f9":                                                     "broom",
+	"\U0001f9fa":                                                     "basket",
+	"\U0001f9fb":                                                     "roll_of_paper",
+	"\U0001f9fc":                                                     "soap",
+	"\U0001f9fd":                                                     "sponge",
+	"\U0001f9fe":                                                     "receipt",
+	"\U0001f9ff":                                                     "nazar_amulet",
+	"\U0001fa70":                                                     "ballet_shoes",
+	"\U0001fa71":                                                     "one-piece_swimsuit",
+	"\U0001fa72":                                                     "briefs",
+	"\U0001fa73":                                                     "shorts",
+	"\U0001fa74":                                                     "thong_sandal",
+	"\U0001fa78":                                                     "drop_of_blood",
+	"\U0001fa79":                                                     "adhesive_bandage",
+	"\U0001fa7a":                                                     "stethoscope",
+	"\U0001fa80":                                                     "yo-yo",
+	"\U0001fa81":                                                     "kite",
+	"\U0001fa82":                                                     "parachute",
+	"\U0001fa83":                                                     "boomerang",
+	"\U0001fa84":                                                     "magic_wand",
+	"\U0001fa85":                                                     "pinata",
+	"\U0001fa86":                                                     "nesting_dolls",
+	"\U0001fa90":                                                     "ringed_planet",
+	"\U0001fa91":                                                     "chair",
+	"\U0001fa92":                                                     "razor",
+	"\U0001fa93":                                                     "axe",
+	"\U0001fa94":                                                     "diya_lamp",
+	"\U0001fa95":                                                     "banjo",
+	"\U0001fa96":                                                     "military_helmet",
+	"\U0001fa97":                                                     "accordion",
+	"\U0001fa98":                                                     "long_drum",
+	"\U0001fa99":                                                     "coin",
+	"\U0001fa9a":                                                     "carpentry_saw",
+	"\U0001fa9b":                                                     "screwdriver",
+	"\U0001fa9c":                                                     "ladder",
+	"\U0001fa9d":                                                     "hook",
+	"\U0001fa9e":                                                     "mirror",
+	"\U0001fa9f":                                                     "window",
+	"\U0001faa0":                                                     "plunger",
+	"\U0001faa1":                                                     "sewing_needle",
+	"\U0001faa2":                                                     "knot",
+	"\U0001faa3":                                                     "bucket",
+	"\U0001faa4":                                                     "mouse_trap",
+	"\U0001faa5":                                                     "toothbrush",
+	"\U0001faa6":                                                     "headstone",
+	"\U0001faa7":                                                     "placard",
+	"\U0001faa8":                                                     "rock",
+	"\U0001fab0":                                                     "fly",
+	"\U0001fab1":                                                     "worm",
+	"\U0001fab2":                                                     "beetle",
+	"\U0001fab3":                                                     "cockroach",
+	"\U0001fab4":                                                     "potted_plant",
+	"\U0001fab5":                                                     "wood",
+	"\U0001fab6":                                                     "feather",
+	"\U0001fac0":                                                     "anatomical_heart",
+	"\U0001fac1":                                                     "lungs",
+	"\U0001fac2":                                                     "people_hugging",
+	"\U0001fad0":                                                     "blueberries",
+	"\U0001fad1":                                                     "bell_pepper",
+	"\U0001fad2":                                                     "olive",
+	"\U0001fad3":                                                     "flatbread",
+	"\U0001fad4":                                                     "tamale",
+	"\U0001fad5":                                                     "fondue",
+	"\U0001fad6":                                                     "teapot",
+}
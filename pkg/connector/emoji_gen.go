@@ -0,0 +1,134 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build ignore
+
+// emoji_gen.go generates emoji_data.go from the system emoji of the
+// Mattermost server module in go.mod. Run it with go generate after
+// upgrading the module.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const mattermostModule = "github.com/mattermost/mattermost/server/public"
+
+type emoji struct {
+	name    string
+	unicode string
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "emoji_gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", mattermostModule).Output()
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %w", mattermostModule, err)
+	}
+	emojis, err := readSystemEmojis(filepath.Join(strings.TrimSpace(string(out)), "model", "emoji_data.go"))
+	if err != nil {
+		return err
+	}
+
+	// Each emoji is named by its shortest name, the first in Mattermost's
+	// order on ties, e.g. "+1" rather than "thumbsup".
+	names := make(map[string]string)
+	for _, e := range emojis {
+		key := strings.ReplaceAll(e.unicode, "\ufe0f", "")
+		if prev, ok := names[key]; !ok || len(e.name) < len(prev) {
+			names[key] = e.name
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by emoji_gen.go from the Mattermost system emoji; DO NOT EDIT.\n\n")
+	buf.WriteString("package connector\n\n")
+	buf.WriteString("// emojiByName maps Mattermost system emoji names to Unicode emoji.\n")
+	buf.WriteString("var emojiByName = map[string]string{\n")
+	for _, e := range emojis {
+		fmt.Fprintf(&buf, "\t%s: %s,\n", strconv.Quote(e.name), strconv.QuoteToASCII(e.unicode))
+	}
+	buf.WriteString("}\n\n")
+	buf.WriteString("// emojiNames maps Unicode emoji, without variation selectors, to the\n")
+	buf.WriteString("// Mattermost name used for reactions with them.\n")
+	buf.WriteString("var emojiNames = map[string]string{\n")
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%s: %s,\n", strconv.QuoteToASCII(key), strconv.Quote(names[key]))
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return os.WriteFile("emoji_data.go", src, 0o644)
+}
+
+// readSystemEmojis returns the entries of model.SystemEmojis in source
+// order. Entries whose value isn't a code point sequence, like the
+// Mattermost logo, are skipped.
+func readSystemEmojis(path string) ([]emoji, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var emojis []emoji
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "SystemEmojis" {
+			return true
+		}
+		lit := spec.Values[0].(*ast.CompositeLit)
+		for _, elt := range lit.Elts {
+			kv := elt.(*ast.KeyValueExpr)
+			name, _ := strconv.Unquote(kv.Key.(*ast.BasicLit).Value)
+			hex, _ := strconv.Unquote(kv.Value.(*ast.BasicLit).Value)
+			if unicode, ok := codePoints(hex); ok {
+				emojis = append(emojis, emoji{name: name, unicode: unicode})
+			}
+		}
+		return false
+	})
+	if len(emojis) == 0 {
+		return nil, fmt.Errorf("no SystemEmojis found in %s", path)
+	}
+	return emojis, nil
+}
+
+// codePoints converts a dash-separated code point sequence like
+// "2764-fe0f" to its string.
+func codePoints(hex string) (string, bool) {
+	var sb strings.Builder
+	for _, part := range strings.Split(hex, "-") {
+		cp, err := strconv.ParseUint(part, 16, 32)
+		if err != nil {
+			return "", false
+		}
+		sb.WriteRune(rune(cp))
+	}
+	return sb.String(), true
+}
@@ -0,0 +1,146 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeEmojiOverrides(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "emoji.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write overrides: %v", err)
+	}
+	return path
+}
+
+func TestSystemEmojiDataset(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		emoji string
+	}{
+		{"grinning", "\U0001f600"},
+		{"thumbsup", "\U0001f44d"},
+		{"+1", "\U0001f44d"},
+		{"+1_medium_skin_tone", "\U0001f44d\U0001f3fd"},
+		{"flag-fr", "\U0001f1eb\U0001f1f7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := reactionToEmoji(tt.name); got != tt.emoji {
+				t.Errorf("reactionToEmoji(%q) = %q, want %q", tt.name, got, tt.emoji)
+			}
+		})
+	}
+}
+
+func TestEmojiToReaction_VariationSelector(t *testing.T) {
+	t.Parallel()
+	for _, emoji := range []string{"\u2764", "\u2764\ufe0f"} {
+		if got := emojiToReaction(emoji); got != "heart" {
+			t.Errorf("emojiToReaction(%q) = %q, want heart", emoji, got)
+		}
+	}
+	if got := emojiToReaction("\U0001f44d\U0001f3fd"); got != "+1_medium_skin_tone" {
+		t.Errorf("emojiToReaction(skin tone) = %q, want +1_medium_skin_tone", got)
+	}
+}
+
+func TestLoadEmojiOverrides(t *testing.T) {
+	t.Parallel()
+	path := writeEmojiOverrides(t, "party_parrot: \"\U0001f99c\"\nparrot_alias: \"\U0001f99c\"\nshipit: \"\U0001f43f\ufe0f\"\n")
+	o, err := loadEmojiOverrides(path)
+	if err != nil {
+		t.Fatalf("loadEmojiOverrides: %v", err)
+	}
+	if got := o.reactionToEmoji("party_parrot"); got != "\U0001f99c" {
+		t.Errorf("reactionToEmoji(party_parrot) = %q", got)
+	}
+	if got := o.reactionToEmoji("parrot_alias"); got != "\U0001f99c" {
+		t.Errorf("reactionToEmoji(parrot_alias) = %q", got)
+	}
+	// The first name of an emoji wins.
+	if got := o.emojiToReaction("\U0001f99c"); got != "party_parrot" {
+		t.Errorf("emojiToReaction(parrot) = %q, want party_parrot", got)
+	}
+	// Variation selectors are ignored, and overrides beat the system emoji.
+	if got := o.emojiToReaction("\U0001f43f"); got != "shipit" {
+		t.Errorf("emojiToReaction(chipmunk) = %q, want shipit", got)
+	}
+	if !o.reactionMatches("\U0001f43f\ufe0f", "shipit") {
+		t.Error("reactionMatches(chipmunk, shipit) = false")
+	}
+	// Names without an override still use the system emoji.
+	if got := o.emojiToReaction("\U0001f44d"); got != "+1" {
+		t.Errorf("emojiToReaction(thumbs up) = %q, want +1", got)
+	}
+}
+
+func TestLoadEmojiOverrides_Empty(t *testing.T) {
+	t.Parallel()
+	o, err := loadEmojiOverrides(writeEmojiOverrides(t, ""))
+	if err != nil {
+		t.Fatalf("loadEmojiOverrides: %v", err)
+	}
+	if got := o.reactionToEmoji("smile"); got != "\U0001f604" {
+		t.Errorf("reactionToEmoji(smile) = %q", got)
+	}
+}
+
+func TestLoadEmojiOverrides_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"not a mapping", "- smile\n", "must be a mapping"},
+		{"colon in name", "\":smile:\": \"\U0001f604\"\n", "invalid emoji name"},
+		{"space in name", "\"big smile\": \"\U0001f604\"\n", "invalid emoji name"},
+		{"empty emoji", "smile: \"\"\n", "has no Unicode emoji"},
+		{"nested emoji", "smile:\n  a: b\n", "has no Unicode emoji"},
+		{"invalid yaml", "smile: [\n", "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := loadEmojiOverrides(writeEmojiOverrides(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadEmojiOverrides_MissingFile(t *testing.T) {
+	t.Parallel()
+	if _, err := loadEmojiOverrides(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestConfigPostProcessEmojiOverrides(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{EmojiOverridesFile: writeEmojiOverrides(t, "party_parrot: \"\U0001f99c\"\n")}
+	if err := cfg.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	mc := &MattermostConnector{Config: *cfg}
+	if got := mc.emojiOverrides().reactionToEmoji("party_parrot"); got != "\U0001f99c" {
+		t.Errorf("reactionToEmoji(party_parrot) = %q", got)
+	}
+
+	bad := &Config{EmojiOverridesFile: filepath.Join(t.TempDir(), "missing.yaml")}
+	if err := bad.PostProcess(); err == nil || !strings.Contains(err.Error(), "emoji_overrides_file") {
+		t.Errorf("PostProcess err = %v, want emoji_overrides_file error", err)
+	}
+}
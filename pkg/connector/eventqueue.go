@@ -0,0 +1,275 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+// queuedEvent is a remote event waiting to be handed to the bridge.
+type queuedEvent struct {
+	login *bridgev2.UserLogin
+	evt   bridgev2.RemoteEvent
+}
+
+// missedChannels records the channels of one login that had events dropped
+// by the event queue. The value is true once a resync has been started.
+type missedChannels struct {
+	login    *bridgev2.UserLogin
+	channels map[string]bool
+}
+
+// eventQueue is a bounded queue between the Mattermost event handlers and
+// the bridge. When the homeserver is slow, events wait here instead of
+// piling up without bound; once the queue is full, further events are
+// dropped. With catch-up backfill enabled, channels that lost events are
+// resynced once the queue has drained to half, and the catch-up backfill of
+// the resync refetches their posts from Mattermost.
+type eventQueue struct {
+	next    remoteEventSender
+	events  chan queuedEvent
+	catchUp bool
+	log     zerolog.Logger
+
+	// missed maps user login IDs to the channels that had events dropped
+	// since their last catch-up resync. Guarded by missedMu.
+	missed   map[networkid.UserLoginID]*missedChannels
+	missedMu sync.Mutex
+
+	dispatched atomic.Uint64
+	dropped    atomic.Uint64
+	refetched  atomic.Uint64
+}
+
+func newEventQueue(next remoteEventSender, size int, catchUp bool, log zerolog.Logger) *eventQueue {
+	return &eventQueue{
+		next:    next,
+		events:  make(chan queuedEvent, size),
+		catchUp: catchUp,
+		log:     log,
+		missed:  make(map[networkid.UserLoginID]*missedChannels),
+	}
+}
+
+// remoteEventSender returns the sender new clients queue remote events with:
+// the event queue if it's enabled, the bridge otherwise.
+func (mc *MattermostConnector) remoteEventSender() remoteEventSender {
+	if mc.eventQueue != nil {
+		return mc.eventQueue
+	}
+	return &bridgeEventSender{bridge: mc.Bridge}
+}
+
+// startEventQueue starts handing queued events to the bridge, if the queue is
+// enabled.
+func (mc *MattermostConnector) startEventQueue(ctx context.Context) {
+	size := mc.Config.EventQueue.Size
+	if size <= 0 || mc.Bridge == nil {
+		return
+	}
+	log := mc.Bridge.Log.With().Str("component", "event_queue").Logger()
+	catchUp := mc.Config.backfillMissed()
+	if !catchUp {
+		log.Warn().Msg("Catch-up backfill is disabled, posts dropped by a full event queue won't be refetched")
+	}
+	mc.eventQueue = newEventQueue(&bridgeEventSender{bridge: mc.Bridge}, size, catchUp, log)
+	mc.background.Add(1)
+	go func() {
+		defer mc.background.Done()
+		mc.eventQueue.run(ctx)
+	}()
+}
+
+// refetchable reports whether posts lost with a dropped event of this type
+// are worth a resync. Typing notifications and receipts are only useful
+// live.
+func refetchable(evtType bridgev2.RemoteEventType) bool {
+	switch evtType {
+	case bridgev2.RemoteEventTyping, bridgev2.RemoteEventReadReceipt,
+		bridgev2.RemoteEventDeliveryReceipt, bridgev2.RemoteEventMarkUnread:
+		return false
+	}
+	return true
+}
+
+// isCatchUpResync reports whether evt is a resync that backfills posts the
+// portal is missing.
+func isCatchUpResync(evt bridgev2.RemoteEvent) bool {
+	resync, ok := evt.(*simplevent.ChatResync)
+	return ok && resync.CheckNeedsBackfillFunc != nil
+}
+
+// QueueRemoteEvent implements remoteEventSender. It never blocks. Events of a
+// channel that already lost events are dropped too until a catch-up resync
+// of the channel is queued: catch-up backfill starts after the newest bridged
+// post, so bridging a later post would leave a gap.
+func (q *eventQueue) QueueRemoteEvent(login *bridgev2.UserLogin, evt bridgev2.RemoteEvent) {
+	portalID := evt.GetPortalKey().ID
+	channelID := ParsePortalID(portalID)
+	_, isTeam := ParseTeamPortalID(portalID)
+	refetch := q.catchUp && refetchable(evt.GetType()) && channelID != "" && !isTeam
+	if refetch && q.isMissed(login.ID, channelID) {
+		if isCatchUpResync(evt) && q.tryQueue(login, evt) {
+			q.unmark(login.ID, channelID)
+			return
+		}
+		q.dropped.Add(1)
+		if isCatchUpResync(evt) {
+			// Try again once the queue drains.
+			q.mark(login, channelID)
+		}
+		return
+	}
+	if q.tryQueue(login, evt) {
+		return
+	}
+	q.dropped.Add(1)
+	if !refetch {
+		q.log.Debug().
+			Stringer("event_type", evt.GetType()).
+			Str("channel_id", channelID).
+			Msg("Event queue full, dropping event")
+		return
+	}
+	q.mark(login, channelID)
+	q.log.Warn().
+		Stringer("event_type", evt.GetType()).
+		Str("channel_id", channelID).
+		Str("login_id", string(login.ID)).
+		Int("queue_size", cap(q.events)).
+		Msg("Event queue full, channel will be resynced once it drains")
+}
+
+// tryQueue adds an event to the queue if there's room.
+func (q *eventQueue) tryQueue(login *bridgev2.UserLogin, evt bridgev2.RemoteEvent) bool {
+	select {
+	case q.events <- queuedEvent{login: login, evt: evt}:
+		return true
+	default:
+		return false
+	}
+}
+
+// mark records that a channel lost events and needs a resync.
+func (q *eventQueue) mark(login *bridgev2.UserLogin, channelID string) {
+	q.missedMu.Lock()
+	defer q.missedMu.Unlock()
+	missed, ok := q.missed[login.ID]
+	if !ok {
+		missed = &missedChannels{login: login, channels: make(map[string]bool)}
+		q.missed[login.ID] = missed
+	}
+	missed.channels[channelID] = false
+}
+
+// unmark forgets a channel's dropped events.
+func (q *eventQueue) unmark(loginID networkid.UserLoginID, channelID string) {
+	q.missedMu.Lock()
+	defer q.missedMu.Unlock()
+	missed, ok := q.missed[loginID]
+	if !ok {
+		return
+	}
+	delete(missed.channels, channelID)
+	if len(missed.channels) == 0 {
+		delete(q.missed, loginID)
+	}
+}
+
+// isMissed reports whether the channel has dropped events waiting for a
+// resync.
+func (q *eventQueue) isMissed(loginID networkid.UserLoginID, channelID string) bool {
+	q.missedMu.Lock()
+	defer q.missedMu.Unlock()
+	missed, ok := q.missed[loginID]
+	if !ok {
+		return false
+	}
+	_, ok = missed.channels[channelID]
+	return ok
+}
+
+// run hands queued events to the bridge in order until ctx is done.
+func (q *eventQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queued := <-q.events:
+			q.next.QueueRemoteEvent(queued.login, queued.evt)
+			q.dispatched.Add(1)
+			if len(q.events) <= cap(q.events)/2 {
+				q.refetchMissed(ctx)
+			}
+		}
+	}
+}
+
+// refetchMissed starts a resync of every channel that had events dropped and
+// isn't being resynced yet.
+func (q *eventQueue) refetchMissed(ctx context.Context) {
+	q.missedMu.Lock()
+	defer q.missedMu.Unlock()
+	for loginID, missed := range q.missed {
+		client, ok := missed.login.Client.(*MattermostClient)
+		if !ok || client.client == nil {
+			delete(q.missed, loginID)
+			continue
+		}
+		var channelIDs []string
+		for channelID, started := range missed.channels {
+			if !started {
+				missed.channels[channelID] = true
+				channelIDs = append(channelIDs, channelID)
+			}
+		}
+		if len(channelIDs) == 0 {
+			continue
+		}
+		q.refetched.Add(uint64(len(channelIDs)))
+		q.log.Info().
+			Str("login_id", string(loginID)).
+			Int("channel_count", len(channelIDs)).
+			Msg("Resyncing channels with dropped events")
+		go q.refetchChannels(ctx, client, loginID, channelIDs)
+	}
+}
+
+// missedCount returns the number of channels waiting for a resync.
+func (q *eventQueue) missedCount() int {
+	q.missedMu.Lock()
+	defer q.missedMu.Unlock()
+	n := 0
+	for _, missed := range q.missed {
+		n += len(missed.channels)
+	}
+	return n
+}
+
+// refetchChannels queues a catch-up resync for channels that had events
+// dropped. The resync clears the channel's mark once it's queued. Channels
+// that can't be fetched, or have no posts to catch up on, are given up on.
+func (q *eventQueue) refetchChannels(ctx context.Context, client *MattermostClient, loginID networkid.UserLoginID, channelIDs []string) {
+	for _, channelID := range channelIDs {
+		ch, _, err := client.client.GetChannel(ctx, channelID, "")
+		if err != nil {
+			q.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to get channel to refetch dropped events")
+			q.unmark(loginID, channelID)
+			continue
+		}
+		if ch.LastPostAt == 0 {
+			q.unmark(loginID, channelID)
+		}
+		client.queueChannelSync(ctx, ch, client.allowCreatePortal(ctx, ch.Id, ch.Name, ch.TeamId, false))
+	}
+}
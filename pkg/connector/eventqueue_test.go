@@ -0,0 +1,309 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+// queueTestEvent returns a remote event of the given type in a channel.
+func queueTestEvent(evtType bridgev2.RemoteEventType, channelID string) bridgev2.RemoteEvent {
+	return &simplevent.Typing{
+		EventMeta: simplevent.EventMeta{
+			Type:      evtType,
+			PortalKey: makePortalKey(channelID),
+		},
+	}
+}
+
+// newQueueTestLogin returns a user login whose client sends through q.
+func newQueueTestLogin(mc *MattermostClient, q *eventQueue) *bridgev2.UserLogin {
+	login := &bridgev2.UserLogin{
+		UserLogin: &database.UserLogin{ID: networkid.UserLoginID("login1")},
+		Client:    mc,
+	}
+	mc.userLogin = login
+	mc.eventSender = q
+	return login
+}
+
+func TestRefetchable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		evtType bridgev2.RemoteEventType
+		want    bool
+	}{
+		{bridgev2.RemoteEventMessage, true},
+		{bridgev2.RemoteEventEdit, true},
+		{bridgev2.RemoteEventReaction, true},
+		{bridgev2.RemoteEventChatResync, true},
+		{bridgev2.RemoteEventChatInfoChange, true},
+		{bridgev2.RemoteEventTyping, false},
+		{bridgev2.RemoteEventReadReceipt, false},
+		{bridgev2.RemoteEventDeliveryReceipt, false},
+		{bridgev2.RemoteEventMarkUnread, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.evtType.String(), func(t *testing.T) {
+			t.Parallel()
+			if got := refetchable(tt.evtType); got != tt.want {
+				t.Errorf("refetchable(%v) = %v, want %v", tt.evtType, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEventQueue_Overflow verifies that a full queue drops events, marks
+// their channel, and keeps dropping the channel's events until it's resynced.
+func TestEventQueue_Overflow(t *testing.T) {
+	t.Parallel()
+	next := &mockEventSender{}
+	q := newEventQueue(next, 2, true, zerolog.Nop())
+	login := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch2"))
+	// Full: the typing event is lost, the message marks ch2.
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventTyping, "ch3"))
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch2"))
+
+	if got := len(q.events); got != 2 {
+		t.Fatalf("depth: got %d, want 2", got)
+	}
+	if got := q.dropped.Load(); got != 2 {
+		t.Errorf("dropped: got %d, want 2", got)
+	}
+	if q.isMissed("login1", "ch3") {
+		t.Error("typing shouldn't mark its channel")
+	}
+	if !q.isMissed("login1", "ch2") {
+		t.Fatal("ch2 should be marked for resync")
+	}
+
+	// Room again, but ch2 stays blocked until its resync; other channels
+	// and non-refetchable events go through.
+	<-q.events
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventEdit, "ch2"))
+	if got := len(q.events); got != 1 {
+		t.Errorf("event of a marked channel was queued, depth %d", got)
+	}
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventTyping, "ch2"))
+	if got := len(q.events); got != 2 {
+		t.Errorf("typing in a marked channel should be queued, depth %d", got)
+	}
+	if got := q.missedCount(); got != 1 {
+		t.Errorf("missed channels: got %d, want 1", got)
+	}
+}
+
+// TestEventQueue_OverflowWithoutCatchUp verifies that without catch-up
+// backfill, dropped events are lost but their channel isn't blocked.
+func TestEventQueue_OverflowWithoutCatchUp(t *testing.T) {
+	t.Parallel()
+	q := newEventQueue(&mockEventSender{}, 1, false, zerolog.Nop())
+	login := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	if q.isMissed("login1", "ch1") {
+		t.Error("channel shouldn't be marked without catch-up backfill")
+	}
+	<-q.events
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	if got := len(q.events); got != 1 {
+		t.Errorf("depth: got %d, want 1", got)
+	}
+}
+
+// TestEventQueue_CatchUpResyncClearsMark verifies that a channel's mark is
+// only cleared by a resync that backfills, and only once it's queued.
+func TestEventQueue_CatchUpResyncClearsMark(t *testing.T) {
+	t.Parallel()
+	q := newEventQueue(&mockEventSender{}, 1, true, zerolog.Nop())
+	login := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+	catchUp := &simplevent.ChatResync{
+		EventMeta: simplevent.EventMeta{Type: bridgev2.RemoteEventChatResync, PortalKey: makePortalKey("ch1")},
+		CheckNeedsBackfillFunc: func(context.Context, *database.Message) (bool, error) {
+			return true, nil
+		},
+	}
+
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch2"))
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	// Still full: the resync is dropped and the channel stays marked.
+	q.QueueRemoteEvent(login, catchUp)
+	if !q.isMissed("login1", "ch1") {
+		t.Fatal("ch1 should stay marked while its resync can't be queued")
+	}
+
+	<-q.events
+	q.QueueRemoteEvent(login, &simplevent.ChatResync{
+		EventMeta: simplevent.EventMeta{Type: bridgev2.RemoteEventChatResync, PortalKey: makePortalKey("ch1")},
+	})
+	if !q.isMissed("login1", "ch1") || len(q.events) != 0 {
+		t.Fatal("a resync without backfill shouldn't be queued or clear the mark")
+	}
+	q.QueueRemoteEvent(login, catchUp)
+	if q.isMissed("login1", "ch1") {
+		t.Error("queued catch-up resync should clear the mark")
+	}
+	if got := len(q.events); got != 1 {
+		t.Errorf("depth: got %d, want 1", got)
+	}
+}
+
+// TestEventQueue_RunRefetches verifies that queued events reach the bridge in
+// order and that channels with dropped events are resynced once the queue
+// drains.
+func TestEventQueue_RunRefetches(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Channels["ch2"] = &model.Channel{Id: "ch2", Name: "town-square", Type: model.ChannelTypeOpen, TeamId: "team1", LastPostAt: 5000}
+	fake.ChannelMembers["ch2"] = model.ChannelMembers{{ChannelId: "ch2", UserId: "my-user-id"}}
+
+	next := &mockEventSender{}
+	q := newEventQueue(next, 1, true, zerolog.Nop())
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.Backfill.MissedLimit = 50
+	login := newQueueTestLogin(mc, q)
+
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	q.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch2"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go q.run(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(next.Events()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	events := next.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].GetPortalKey() != makePortalKey("ch1") || events[0].GetType() != bridgev2.RemoteEventMessage {
+		t.Errorf("first event: got %v in %v", events[0].GetType(), events[0].GetPortalKey())
+	}
+	resync, ok := events[1].(*simplevent.ChatResync)
+	if !ok || resync.PortalKey != makePortalKey("ch2") {
+		t.Fatalf("second event should be a ChatResync of ch2, got %T", events[1])
+	}
+	if resync.CheckNeedsBackfillFunc == nil {
+		t.Error("refetch should catch up on missed posts")
+	}
+	if !resync.CreatePortal {
+		t.Error("refetch should create the portal under the default policy")
+	}
+	if q.isMissed("login1", "ch2") {
+		t.Error("ch2 should no longer be marked")
+	}
+	if got := q.refetched.Load(); got != 1 {
+		t.Errorf("refetched: got %d, want 1", got)
+	}
+}
+
+// TestMattermostConnector_RemoteEventSender verifies that clients send
+// through the queue only when it's enabled.
+func TestMattermostConnector_RemoteEventSender(t *testing.T) {
+	t.Parallel()
+	mc := &MattermostConnector{Bridge: &bridgev2.Bridge{}}
+	if _, ok := mc.remoteEventSender().(*bridgeEventSender); !ok {
+		t.Error("expected the bridge sender without a queue")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	mc.Config.EventQueue.Size = 10
+	mc.startEventQueue(ctx)
+	if mc.remoteEventSender() != remoteEventSender(mc.eventQueue) || mc.eventQueue == nil {
+		t.Error("expected the event queue")
+	}
+	if got := cap(mc.eventQueue.events); got != 10 {
+		t.Errorf("capacity: got %d, want 10", got)
+	}
+}
+
+func TestHandleMetrics(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		method  string
+		queue   bool
+		status  int
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "queue metrics",
+			method: http.MethodGet,
+			queue:  true,
+			status: http.StatusOK,
+			want: []string{
+				"# TYPE mautrix_mattermost_event_queue_depth gauge\nmautrix_mattermost_event_queue_depth 1\n",
+				"mautrix_mattermost_event_queue_capacity 1\n",
+				"mautrix_mattermost_event_queue_dropped_total 1\n",
+				"# TYPE mautrix_mattermost_event_queue_dispatched_total counter\n",
+				"mautrix_mattermost_event_queue_missed_channels 1\n",
+			},
+		},
+		{
+			name:    "queue disabled",
+			method:  http.MethodGet,
+			status:  http.StatusOK,
+			notWant: []string{"event_queue"},
+		},
+		{
+			name:   "wrong method",
+			method: http.MethodPost,
+			queue:  true,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := &MattermostConnector{Bridge: &bridgev2.Bridge{}}
+			if tt.queue {
+				mc.eventQueue = newEventQueue(&mockEventSender{}, 1, true, zerolog.Nop())
+				login := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+				mc.eventQueue.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+				mc.eventQueue.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch2"))
+			}
+
+			rec := httptest.NewRecorder()
+			mc.HandleMetrics(rec, httptest.NewRequest(tt.method, "/metrics", nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status: got %d, want %d", rec.Code, tt.status)
+			}
+			body := rec.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body missing %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("body should not contain %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
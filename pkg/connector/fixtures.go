@@ -0,0 +1,299 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+// Test fixture endpoints let integration tests create ghosts, portals and
+// messages directly instead of waiting for channel discovery. They are only
+// served when admin_api_fixtures is enabled and an admin token is set.
+
+// maxFixtureBodySize is the maximum allowed request body for fixture endpoints (64 KB).
+const maxFixtureBodySize = 64 << 10
+
+// errNoClientLogin is returned when no login is available for the admin API
+// to act through, e.g. as the source of fixture events.
+var errNoClientLogin = errors.New("no Mattermost login available")
+
+// FixtureGhostRequest is the body of POST /debug/fixtures/ghost.
+type FixtureGhostRequest struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	IsBot  bool   `json:"is_bot"`
+}
+
+// FixturePortalRequest is the body of POST /debug/fixtures/portal.
+type FixturePortalRequest struct {
+	ChannelID string   `json:"channel_id"`
+	LoginID   string   `json:"login_id,omitempty"`
+	Name      string   `json:"name"`
+	Topic     string   `json:"topic,omitempty"`
+	Type      string   `json:"type"` // Mattermost channel type: O, P, D or G. Defaults to O.
+	Members   []string `json:"members"`
+}
+
+// FixtureMessageRequest is the body of POST /debug/fixtures/message.
+type FixtureMessageRequest struct {
+	ChannelID string `json:"channel_id"`
+	LoginID   string `json:"login_id,omitempty"`
+	PostID    string `json:"post_id"`
+	UserID    string `json:"user_id"`
+	Message   string `json:"message"`
+	RootID    string `json:"root_id,omitempty"`
+	// CreateAt is the post timestamp in milliseconds. Defaults to now.
+	CreateAt int64 `json:"create_at,omitempty"`
+}
+
+// registerFixtureRoutes adds the fixture endpoints to the admin mux.
+func (mc *MattermostConnector) registerFixtureRoutes(mux *http.ServeMux) {
+	mux.Handle("/debug/fixtures/ghost", mc.debugHandler(http.HandlerFunc(mc.HandleFixtureGhost)))
+	mux.Handle("/debug/fixtures/portal", mc.debugHandler(http.HandlerFunc(mc.HandleFixturePortal)))
+	mux.Handle("/debug/fixtures/message", mc.debugHandler(http.HandlerFunc(mc.HandleFixtureMessage)))
+}
+
+// decodeFixtureRequest enforces POST, bounds the body and decodes it into v.
+// It writes the error response and returns false on failure.
+func decodeFixtureRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxFixtureBodySize)
+	defer func() { _ = r.Body.Close() }()
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeFixtureResponse(w http.ResponseWriter, status int, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// clientLogin returns the login admin API requests act through, such as the
+// one fixture events are attributed to: the given login ID, or the first full
+// (non double-puppet-only) login.
+func (mc *MattermostConnector) clientLogin(ctx context.Context, loginID string) (*bridgev2.UserLogin, *MattermostClient, error) {
+	var login *bridgev2.UserLogin
+	if loginID != "" {
+		var err error
+		login, err = mc.Bridge.GetExistingUserLoginByID(ctx, networkid.UserLoginID(loginID))
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		userIDs, err := mc.Bridge.DB.UserLogin.GetAllUserIDsWithLogins(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	Outer:
+		for _, uid := range userIDs {
+			user, err := mc.Bridge.GetExistingUserByMXID(ctx, uid)
+			if err != nil || user == nil {
+				continue
+			}
+			for _, l := range user.GetUserLogins() {
+				if meta, ok := l.Metadata.(*UserLoginMetadata); ok && meta != nil && !meta.DoublePuppetOnly {
+					login = l
+					break Outer
+				}
+			}
+		}
+	}
+	if login == nil {
+		return nil, nil, errNoClientLogin
+	}
+	client, ok := login.Client.(*MattermostClient)
+	if !ok {
+		return nil, nil, fmt.Errorf("login %s has no Mattermost client", login.ID)
+	}
+	return login, client, nil
+}
+
+// HandleFixtureGhost is an HTTP handler for POST /debug/fixtures/ghost. It
+// creates (or updates) the ghost for a Mattermost user ID.
+func (mc *MattermostConnector) HandleFixtureGhost(w http.ResponseWriter, r *http.Request) {
+	var req FixtureGhostRequest
+	if !decodeFixtureRequest(w, r, &req) {
+		return
+	}
+	if req.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	mc.ctxLog(r.Context()).Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("user_id", req.UserID).
+		Msg("Fixture ghost requested")
+
+	ctx := r.Context()
+	ghost, err := mc.Bridge.GetGhostByID(ctx, MakeUserID(req.UserID))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get ghost: %v", err), http.StatusInternalServerError)
+		return
+	}
+	info := &bridgev2.UserInfo{IsBot: &req.IsBot}
+	if req.Name != "" {
+		info.Name = &req.Name
+	}
+	ghost.UpdateInfo(ctx, info)
+
+	writeFixtureResponse(w, http.StatusOK, map[string]string{
+		"user_id": req.UserID,
+		"mxid":    string(ghost.Intent.GetMXID()),
+	})
+}
+
+// HandleFixturePortal is an HTTP handler for POST /debug/fixtures/portal. It
+// creates the Matrix room for a channel synchronously, or updates its info if
+// the room already exists.
+func (mc *MattermostConnector) HandleFixturePortal(w http.ResponseWriter, r *http.Request) {
+	var req FixturePortalRequest
+	if !decodeFixtureRequest(w, r, &req) {
+		return
+	}
+	if req.ChannelID == "" {
+		http.Error(w, "channel_id is required", http.StatusBadRequest)
+		return
+	}
+	chType := model.ChannelType(req.Type)
+	switch chType {
+	case "":
+		chType = model.ChannelTypeOpen
+	case model.ChannelTypeOpen, model.ChannelTypePrivate, model.ChannelTypeDirect, model.ChannelTypeGroup:
+	default:
+		http.Error(w, "type must be one of O, P, D, G", http.StatusBadRequest)
+		return
+	}
+
+	mc.ctxLog(r.Context()).Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("channel_id", req.ChannelID).
+		Str("channel_type", string(chType)).
+		Int("members", len(req.Members)).
+		Msg("Fixture portal requested")
+
+	ctx := r.Context()
+	login, client, err := mc.clientLogin(ctx, req.LoginID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to find login: %v", err), http.StatusConflict)
+		return
+	}
+
+	channel := &model.Channel{
+		Id:          req.ChannelID,
+		Type:        chType,
+		Name:        req.Name,
+		DisplayName: req.Name,
+		Header:      req.Topic,
+	}
+	members := make(model.ChannelMembers, 0, len(req.Members))
+	for _, uid := range req.Members {
+		members = append(members, model.ChannelMember{ChannelId: req.ChannelID, UserId: uid})
+	}
+	info := client.channelToChatInfo(channel, members)
+
+	portal, err := mc.Bridge.GetPortalByKey(ctx, makePortalKey(req.ChannelID))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get portal: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if portal.MXID == "" {
+		err = portal.CreateMatrixRoom(ctx, login, info)
+	} else {
+		portal.UpdateInfo(ctx, info, login, nil, time.Time{})
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to create portal room: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeFixtureResponse(w, http.StatusOK, map[string]string{
+		"channel_id": req.ChannelID,
+		"room_id":    string(portal.MXID),
+	})
+}
+
+// HandleFixtureMessage is an HTTP handler for POST /debug/fixtures/message.
+// It queues a post through the normal remote event pipeline, so it is
+// converted and sent exactly like a post received over the WebSocket.
+// Events for one portal are processed in order.
+func (mc *MattermostConnector) HandleFixtureMessage(w http.ResponseWriter, r *http.Request) {
+	var req FixtureMessageRequest
+	if !decodeFixtureRequest(w, r, &req) {
+		return
+	}
+	if req.ChannelID == "" || req.PostID == "" || req.UserID == "" {
+		http.Error(w, "channel_id, post_id and user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	mc.ctxLog(r.Context()).Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("channel_id", req.ChannelID).
+		Str("post_id", req.PostID).
+		Str("user_id", req.UserID).
+		Msg("Fixture message requested")
+
+	login, client, err := mc.clientLogin(r.Context(), req.LoginID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to find login: %v", err), http.StatusConflict)
+		return
+	}
+
+	createAt := req.CreateAt
+	if createAt == 0 {
+		createAt = time.Now().UnixMilli()
+	}
+	post := &model.Post{
+		Id:        req.PostID,
+		ChannelId: req.ChannelID,
+		UserId:    req.UserID,
+		Message:   req.Message,
+		RootId:    req.RootID,
+		CreateAt:  createAt,
+	}
+
+	mc.Bridge.QueueRemoteEvent(login, &simplevent.Message[*model.Post]{
+		EventMeta: simplevent.EventMeta{
+			Type: bridgev2.RemoteEventMessage,
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("post_id", post.Id).Str("channel_id", post.ChannelId).Bool("fixture", true)
+			},
+			PortalKey:    makePortalKey(post.ChannelId),
+			Sender:       client.senderFor(post.UserId),
+			Timestamp:    time.UnixMilli(post.CreateAt),
+			CreatePortal: true,
+		},
+		ID:   MakeMessageID(post.Id),
+		Data: post,
+		ConvertMessageFunc: func(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, data *model.Post) (*bridgev2.ConvertedMessage, error) {
+			return client.convertPostToMatrix(ctx, portal, intent, data), nil
+		},
+	})
+
+	writeFixtureResponse(w, http.StatusAccepted, map[string]string{
+		"channel_id": req.ChannelID,
+		"post_id":    req.PostID,
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminAPIHandler_FixturesGating(t *testing.T) {
+	t.Setenv("BRIDGE_API_TOKEN", "")
+	tests := []struct {
+		name     string
+		token    string
+		fixtures bool
+		auth     string
+		want     int
+	}{
+		{"disabled without token", "", true, "", http.StatusNotFound},
+		{"disabled without flag", "s3cret", false, "Bearer s3cret", http.StatusNotFound},
+		{"requires token", "s3cret", true, "", http.StatusUnauthorized},
+		{"enabled", "s3cret", true, "Bearer s3cret", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := newTestBridgeConnector()
+			mc.Config.AdminAPIToken = tt.token
+			mc.Config.AdminAPIFixtures = tt.fixtures
+			h := mc.adminAPIHandler()
+			for _, path := range []string{"/debug/fixtures/ghost", "/debug/fixtures/portal", "/debug/fixtures/message"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				if tt.auth != "" {
+					req.Header.Set("Authorization", tt.auth)
+				}
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, req)
+				if w.Code != tt.want {
+					t.Errorf("%s: got %d, want %d", path, w.Code, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFixtureHandlers_Validation(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+		want    int
+	}{
+		{"ghost invalid JSON", mc.HandleFixtureGhost, "{", http.StatusBadRequest},
+		{"ghost missing user_id", mc.HandleFixtureGhost, `{"name":"Alice"}`, http.StatusBadRequest},
+		{"portal missing channel_id", mc.HandleFixturePortal, `{"name":"town-square"}`, http.StatusBadRequest},
+		{"portal invalid type", mc.HandleFixturePortal, `{"channel_id":"ch1","type":"X"}`, http.StatusBadRequest},
+		{"message missing post_id", mc.HandleFixtureMessage, `{"channel_id":"ch1","user_id":"u1"}`, http.StatusBadRequest},
+		{"message missing user_id", mc.HandleFixtureMessage, `{"channel_id":"ch1","post_id":"p1"}`, http.StatusBadRequest},
+		{"oversized body", mc.HandleFixtureMessage, `{"message":"` + strings.Repeat("a", maxFixtureBodySize) + `"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodPost, "/debug/fixtures/", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			tt.handler(w, req)
+			if w.Code != tt.want {
+				t.Errorf("got %d, want %d (body %q)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestFixtureHandlers_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	for _, h := range []http.HandlerFunc{mc.HandleFixtureGhost, mc.HandleFixturePortal, mc.HandleFixtureMessage} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/fixtures/", nil)
+		w := httptest.NewRecorder()
+		h(w, req)
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("got %d, want 405", w.Code)
+		}
+	}
+}
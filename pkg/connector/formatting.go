@@ -6,9 +6,16 @@
 package connector
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
 	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"maunium.net/go/mautrix/bridgev2"
 	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/format"
+	"maunium.net/go/mautrix/id"
 )
 
 // mattermostfmtParse converts Mattermost markdown to Matrix HTML message content.
@@ -16,7 +23,113 @@ func mattermostfmtParse(text string) *mattermostfmt.ParsedMessage {
 	return mattermostfmt.Parse(text)
 }
 
+// mattermostfmtParseWithOptions converts Mattermost markdown to Matrix HTML
+// message content, rendering timestamps according to opts.
+func mattermostfmtParseWithOptions(text string, opts mattermostfmt.Options) *mattermostfmt.ParsedMessage {
+	return mattermostfmt.ParseWithOptions(text, opts)
+}
+
+// formatOptions returns the timestamp rendering options from the config.
+func (c *Config) formatOptions() mattermostfmt.Options {
+	return mattermostfmt.Options{
+		Location:   c.location,
+		TimeFormat: c.TimeFormat,
+	}
+}
+
+// plainText returns its text unchanged, dropping the markup that
+// format.HTMLToText would otherwise render as markdown.
+func plainText(s string, _ format.Context) string { return s }
+
+// topicTextParser flattens rendered Mattermost markdown to plain text.
+var topicTextParser = &format.HTMLParser{
+	TabsToSpaces:           4,
+	Newline:                "\n",
+	HorizontalLine:         "\n---\n",
+	PillConverter:          format.DefaultPillConverter,
+	BoldConverter:          plainText,
+	ItalicConverter:        plainText,
+	StrikethroughConverter: plainText,
+	MonospaceConverter:     plainText,
+	MonospaceBlockConverter: func(code, _ string, _ format.Context) string {
+		return code
+	},
+}
+
+// topicText converts a Mattermost channel header, or a topic rendered from
+// one, to a Matrix room topic. Room topics are plain text, so the markdown is
+// rendered like a message and then flattened, e.g. links become
+// "text (url)".
+func (c *Config) topicText(markdown string) string {
+	parsed := mattermostfmtParseWithOptions(markdown, c.formatOptions())
+	if parsed.Format != event.FormatHTML {
+		return parsed.Body
+	}
+	return strings.TrimSpace(topicTextParser.Parse(parsed.FormattedBody, format.NewContext(context.Background())))
+}
+
+// topicMarkdown converts a Matrix room topic to a Mattermost channel header,
+// from its HTML representation (MSC3765) if it has one.
+func topicMarkdown(content *event.TopicEventContent) string {
+	if content.ExtensibleTopic != nil {
+		for _, text := range content.ExtensibleTopic.Text {
+			if text.MimeType == "text/html" {
+				return strings.TrimSpace(matrixfmtParse(&event.MessageEventContent{
+					Body:          content.Topic,
+					Format:        event.FormatHTML,
+					FormattedBody: text.Body,
+				}))
+			}
+		}
+	}
+	return content.Topic
+}
+
 // matrixfmtParse converts Matrix message content to Mattermost markdown.
 func matrixfmtParse(content *event.MessageEventContent) string {
 	return matrixfmt.Parse(content)
 }
+
+// matrixfmtParseWithOptions converts Matrix message content to Mattermost
+// markdown, converting user pills according to opts.
+func matrixfmtParseWithOptions(content *event.MessageEventContent, opts matrixfmt.Options) string {
+	return matrixfmt.ParseWithOptions(content, opts)
+}
+
+// matrixFormatOptions returns the options for converting a Matrix message
+// sent to a portal through the login. Spoilers link to eventID, the event
+// the message is shown in. Inline images are only reuploaded with
+// inlineImageResolver.
+func (m *MattermostClient) matrixFormatOptions(ctx context.Context, portal *bridgev2.Portal, eventID id.EventID) matrixfmt.Options {
+	return matrixfmt.Options{
+		Mentions:   m.matrixMentionResolver(ctx),
+		Channels:   m.matrixChannelResolver(ctx, portal),
+		Permalinks: m.matrixPermalinkResolver(ctx),
+		Spoilers:   m.connector.Config.spoilerConverter(portal, eventID),
+	}
+}
+
+// formatOptionsFor returns the timestamp rendering options for a portal:
+// the config defaults, overridden by the portal's timezone and locale
+// settings when set. A nil portal yields the config defaults.
+func (c *Config) formatOptionsFor(portal *bridgev2.Portal) mattermostfmt.Options {
+	opts := c.formatOptions()
+	if portal == nil {
+		return opts
+	}
+	meta, ok := portal.Metadata.(*PortalMetadata)
+	if !ok || meta == nil {
+		return opts
+	}
+	if meta.Timezone != "" {
+		if loc, err := time.LoadLocation(meta.Timezone); err == nil {
+			opts.Location = loc
+		}
+	}
+	if meta.Locale != "" {
+		if layout, ok := mattermostfmt.LocaleTimeFormat(meta.Locale); ok {
+			opts.TimeFormat = layout
+		}
+	}
+	return opts
+}
@@ -47,3 +47,57 @@ func TestMattermostfmtParse(t *testing.T) {
 		t.Error("parsed Body should not be empty")
 	}
 }
+
+func TestTopicText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"plain", "Release planning", "Release planning"},
+		{"empty", "", ""},
+		{"bold", "**Welcome** to the team", "Welcome to the team"},
+		{"link", "See [the docs](https://example.com/docs)", "See the docs (https://example.com/docs)"},
+		{"code", "Run `make build` first", "Run make build first"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{}
+			if got := cfg.topicText(tt.markdown); got != tt.want {
+				t.Errorf("topicText(%q) = %q, want %q", tt.markdown, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopicMarkdown(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content *event.TopicEventContent
+		want    string
+	}{
+		{"plain", &event.TopicEventContent{Topic: "Release planning"}, "Release planning"},
+		{"html", &event.TopicEventContent{
+			Topic: "Welcome to the team",
+			ExtensibleTopic: &event.ExtensibleTopic{Text: []event.ExtensibleText{
+				{MimeType: "text/plain", Body: "Welcome to the team"},
+				{MimeType: "text/html", Body: "<strong>Welcome</strong> to the team"},
+			}},
+		}, "**Welcome** to the team"},
+		{"plain only extensible", &event.TopicEventContent{
+			Topic:           "Plain",
+			ExtensibleTopic: &event.ExtensibleTopic{Text: []event.ExtensibleText{{Body: "Plain"}}},
+		}, "Plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := topicMarkdown(tt.content); got != tt.want {
+				t.Errorf("topicMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
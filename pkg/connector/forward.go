@@ -0,0 +1,133 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// forwardedKey is the content key of MSC2723 forwarded message metadata,
+// which Matrix clients add to messages they forward.
+const forwardedKey = "m.forwarded"
+
+// maxPermalinkQuotes is the number of permalinked posts quoted below one
+// Mattermost post.
+const maxPermalinkQuotes = 3
+
+// forwardedSender returns the original sender of a forwarded Matrix message,
+// and whether the message was forwarded at all. The sender is empty if the
+// client didn't say.
+func forwardedSender(evt *event.Event) (id.UserID, bool) {
+	if evt == nil {
+		return "", false
+	}
+	forwarded, ok := evt.Content.Raw[forwardedKey].(map[string]any)
+	if !ok {
+		return "", false
+	}
+	sender, _ := forwarded["sender"].(string)
+	return id.UserID(sender), true
+}
+
+// forwardQuote renders a forwarded Matrix message on Mattermost, which has no
+// forwards, as a quote led by the original author. Mattermost users are named
+// by username without "@" so the quote doesn't notify them; other Matrix users
+// by their user ID.
+func (m *MattermostClient) forwardQuote(ctx context.Context, sender id.UserID, text string) string {
+	attribution := "*Forwarded message*"
+	if sender != "" {
+		author := "`" + string(sender) + "`"
+		if resolve := m.matrixMentionResolver(ctx); resolve != nil {
+			if username, ok := resolve(sender); ok {
+				author = "**" + username + "**"
+			}
+		}
+		attribution = "*Forwarded from* " + author
+	}
+	if text == "" {
+		return "> " + attribution
+	}
+	return "> " + attribution + "\n> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
+
+// permalinkPostIDs returns the IDs of the posts a Mattermost message links to
+// with permalinks (<server>/<team>/pl/<post ID>) to the login's server, in
+// order and without duplicates.
+func permalinkPostIDs(serverURL, message string) []string {
+	re := permalinkRe(serverURL)
+	if re == nil || !strings.Contains(message, "/pl/") {
+		return nil
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, match := range re.FindAllStringSubmatch(message, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
+
+// appendPermalinkQuotes quotes the posts that post links to with permalinks
+// after content, like the previews Mattermost shows below them. Only posts in
+// post's own channel are quoted: the login may read channels the room's
+// members can't, so posts elsewhere are left as links, like deleted ones and
+// ones the login can't read. Mentions in quotes aren't resolved, as in
+// attachments.
+func (m *MattermostClient) appendPermalinkQuotes(ctx context.Context, content *event.MessageEventContent, post *model.Post, opts mattermostfmt.Options) {
+	if m.client == nil {
+		return
+	}
+	opts.Mentions = nil
+	var body, formatted []string
+	for _, postID := range permalinkPostIDs(m.serverURL, post.Message) {
+		if len(body) == maxPermalinkQuotes {
+			break
+		}
+		if postID == post.Id {
+			continue
+		}
+		linked, _, err := m.client.GetPost(ctx, postID, "")
+		if err != nil {
+			m.log.Debug().Err(err).Str("linked_post_id", postID).Msg("Failed to get permalinked post")
+			continue
+		}
+		if linked.DeleteAt != 0 || linked.ChannelId != post.ChannelId {
+			continue
+		}
+		author := "Someone"
+		if username, ok := m.mentionUsername(ctx, linked.UserId); ok {
+			author = "@" + username
+		}
+		plain := "> " + author + " wrote:"
+		rich := "<blockquote><p><strong>" + html.EscapeString(author) + "</strong> wrote:</p>"
+		if linked.Message != "" {
+			plainText, htmlText := attachmentMarkdown(linked.Message, opts)
+			plain += "\n> " + strings.ReplaceAll(plainText, "\n", "\n> ")
+			rich += "<p>" + htmlText + "</p>"
+		}
+		body = append(body, plain)
+		formatted = append(formatted, rich+"</blockquote>")
+	}
+	if len(body) == 0 {
+		return
+	}
+
+	if content.Format != event.FormatHTML {
+		content.FormattedBody = strings.ReplaceAll(html.EscapeString(content.Body), "\n", "<br/>")
+	}
+	content.Body = strings.Join(append([]string{content.Body}, body...), "\n\n")
+	content.Format = event.FormatHTML
+	content.FormattedBody += strings.Join(formatted, "")
+}
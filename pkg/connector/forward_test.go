@@ -0,0 +1,196 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestForwardedSender(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		evt        *event.Event
+		wantSender id.UserID
+		wantOK     bool
+	}{
+		{"no event", nil, "", false},
+		{"not forwarded", &event.Event{Content: event.Content{Raw: map[string]any{"body": "hi"}}}, "", false},
+		{"forwarded", &event.Event{Content: event.Content{Raw: map[string]any{
+			"m.forwarded": map[string]any{"sender": "@alice:example.com", "event_id": "$e"},
+		}}}, "@alice:example.com", true},
+		{"no sender", &event.Event{Content: event.Content{Raw: map[string]any{"m.forwarded": map[string]any{}}}}, "", true},
+		{"invalid metadata", &event.Event{Content: event.Content{Raw: map[string]any{"m.forwarded": true}}}, "", false},
+	}
+	for _, tt := range tests {
+		sender, ok := forwardedSender(tt.evt)
+		if sender != tt.wantSender || ok != tt.wantOK {
+			t.Errorf("%s: got %q, %v, want %q, %v", tt.name, sender, ok, tt.wantSender, tt.wantOK)
+		}
+	}
+}
+
+func TestForwardQuote(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	mc.connector.Puppets["@agent:example.com"] = &PuppetClient{MXID: "@agent:example.com", UserID: "agent-id", Username: "agent"}
+	tests := []struct {
+		name   string
+		sender id.UserID
+		text   string
+		want   string
+	}{
+		{"puppet", "@agent:example.com", "hello", "> *Forwarded from* **agent**\n> hello"},
+		{"matrix user", "@bob:example.com", "line 1\nline 2", "> *Forwarded from* `@bob:example.com`\n> line 1\n> line 2"},
+		{"unknown sender", "", "hello", "> *Forwarded message*\n> hello"},
+		{"no text", "@bob:example.com", "", "> *Forwarded from* `@bob:example.com`"},
+	}
+	for _, tt := range tests {
+		if got := mc.forwardQuote(context.Background(), tt.sender, tt.text); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleMatrixMessage_Forwarded(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Event: &event.Event{Content: event.Content{Raw: map[string]any{
+				"m.forwarded": map[string]any{"sender": "@bob:example.com"},
+			}}},
+			Portal:  makeTestPortal("test-channel"),
+			Content: &event.MessageEventContent{MsgType: event.MsgText, Body: "Hello"},
+		},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var post model.Post
+	for _, call := range fm.Calls() {
+		if call.Method == "POST" && call.Path == "/api/v4/posts" {
+			_ = json.Unmarshal([]byte(call.Body), &post)
+		}
+	}
+	if want := "> *Forwarded from* `@bob:example.com`\n> Hello"; post.Message != want {
+		t.Errorf("message: got %q, want %q", post.Message, want)
+	}
+}
+
+const (
+	testLinkedPostID  = "aaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testDeletedPostID = "bbbbbbbbbbbbbbbbbbbbbbbbbb"
+	testMissingPostID = "cccccccccccccccccccccccccc"
+)
+
+func TestPermalinkPostIDs(t *testing.T) {
+	t.Parallel()
+	const server = "https://mm.example.com"
+	tests := []struct {
+		name    string
+		server  string
+		message string
+		want    []string
+	}{
+		{"permalink", server + "/", "see " + server + "/eng/pl/" + testLinkedPostID + " please", []string{testLinkedPostID}},
+		{
+			"duplicates", server,
+			server + "/eng/pl/" + testLinkedPostID + " and " + server + "/ops/pl/" + testLinkedPostID + ", " + server + "/eng/pl/" + testDeletedPostID,
+			[]string{testLinkedPostID, testDeletedPostID},
+		},
+		{"other server", server, "https://other.example.com/eng/pl/" + testLinkedPostID, nil},
+		{"short id", server, server + "/eng/pl/abc", nil},
+		{"no server", "", server + "/eng/pl/" + testLinkedPostID, nil},
+	}
+	for _, tt := range tests {
+		if got := permalinkPostIDs(tt.server, tt.message); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// newPermalinkTestClient returns a client whose server has a linked post by
+// alice, a deleted post and no post testMissingPostID.
+func newPermalinkTestClient(t *testing.T) *MattermostClient {
+	t.Helper()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	fm.Users["alice-id"] = &model.User{Id: "alice-id", Username: "alice"}
+	list := model.NewPostList()
+	for _, post := range []*model.Post{
+		{Id: testLinkedPostID, ChannelId: "ch2", UserId: "alice-id", Message: "the **plan**\nstep 2"},
+		{Id: testDeletedPostID, ChannelId: "ch2", UserId: "alice-id", Message: "gone", DeleteAt: 1},
+	} {
+		list.AddPost(post)
+		list.AddOrder(post.Id)
+	}
+	fm.Posts["ch2"] = list
+	return newFullTestClient(fm.Server.URL)
+}
+
+func TestConvertPostToMatrix_PermalinkQuotes(t *testing.T) {
+	t.Parallel()
+	const quoteHTML = "<blockquote><p><strong>@alice</strong> wrote:</p><p>the <strong>plan</strong><br/>step 2</p></blockquote>"
+	tests := []struct {
+		name      string
+		channelID string
+		postIDs   []string
+		wantBody  string
+		wantHTML  string
+	}{
+		{"linked post", "ch2", []string{testLinkedPostID}, "> @alice wrote:\n> the **plan**\n> step 2", quoteHTML},
+		{"deleted and missing posts", "ch2", []string{testDeletedPostID, testMissingPostID}, "", ""},
+		{"same post twice", "ch2", []string{testLinkedPostID, testLinkedPostID}, "> @alice wrote:\n> the **plan**\n> step 2", quoteHTML},
+		{"post in another channel", "ch1", []string{testLinkedPostID}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newPermalinkTestClient(t)
+			message := "see"
+			for _, postID := range tt.postIDs {
+				message += " " + client.serverURL + "/eng/pl/" + postID
+			}
+			post := &model.Post{Id: "p1", ChannelId: tt.channelID, Message: message}
+
+			msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
+			if len(msg.Parts) != 1 {
+				t.Fatalf("expected 1 part, got %d", len(msg.Parts))
+			}
+			content := msg.Parts[0].Content
+			wantBody := message
+			if tt.wantBody != "" {
+				wantBody += "\n\n" + tt.wantBody
+			}
+			if content.Body != wantBody {
+				t.Errorf("body:\ngot  %q\nwant %q", content.Body, wantBody)
+			}
+			if tt.wantHTML == "" {
+				if content.Format == event.FormatHTML && strings.Contains(content.FormattedBody, "<blockquote>") {
+					t.Errorf("no quote expected, got %q", content.FormattedBody)
+				}
+				return
+			}
+			if content.Format != event.FormatHTML || !strings.HasSuffix(content.FormattedBody, tt.wantHTML) || strings.Count(content.FormattedBody, "<blockquote>") != 1 {
+				t.Errorf("formatted body: got %q, want it to end with %q", content.FormattedBody, tt.wantHTML)
+			}
+		})
+	}
+}
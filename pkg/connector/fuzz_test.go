@@ -12,6 +12,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
 )
 
 // ---------------------------------------------------------------------------
@@ -514,3 +515,94 @@ func FuzzMattermostFmtParse(f *testing.F) {
 		}
 	})
 }
+
+// ---------------------------------------------------------------------------
+// FuzzValidatePuppetMXID — fuzz the validation of puppet MXIDs from the admin
+// API and config. Must never panic. Accepted IDs must parse as Matrix user
+// IDs, with a server name serverNameRe accepts and no leading or trailing dot.
+// ---------------------------------------------------------------------------
+
+func FuzzValidatePuppetMXID(f *testing.F) {
+	f.Add("@alice:example.com")
+	f.Add("@alice:example.com:8448")
+	f.Add("@alice:[::1]:8448")
+	f.Add("@alice:127.0.0.1")
+	f.Add("@alice")
+	f.Add("alice:example.com")
+	f.Add("@alice:")
+	f.Add("@alice:.example.com")
+	f.Add("@alice:example.com.")
+	f.Add("@alice:exa mple.com")
+	f.Add("@alice:[::1")
+	f.Add("@:example.com")
+	f.Add("@alice:example.com:99999999")
+	f.Add("@al\x00ice:example.com")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, mxid string) {
+		userID, err := validatePuppetMXID(mxid)
+		if err != nil {
+			if userID != "" {
+				t.Errorf("rejected %q but returned %q", mxid, userID)
+			}
+			return
+		}
+		if string(userID) != mxid {
+			t.Errorf("accepted %q but returned %q", mxid, userID)
+		}
+		_, server, err := id.UserID(mxid).ParseAndValidate()
+		if err != nil {
+			t.Fatalf("accepted %q, which doesn't parse: %v", mxid, err)
+		}
+		if !serverNameRe.MatchString(server) || strings.HasPrefix(server, ".") || strings.HasSuffix(server, ".") {
+			t.Errorf("accepted %q with invalid server name %q", mxid, server)
+		}
+	})
+}
+
+// ---------------------------------------------------------------------------
+// FuzzParseMatterpollPost — fuzz the parsing of Matterpoll posts, whose props
+// come from any integration that sets the post type. Must never panic. A
+// parsed poll always has answers, all of them vote buttons.
+// ---------------------------------------------------------------------------
+
+func FuzzParseMatterpollPost(f *testing.F) {
+	f.Add("Lunch?", `{"attachments":[{"title":"Lunch?","actions":[{"id":"vote0","name":"Pizza","cookie":"c0"},{"id":"vote1","name":"Sushi"},{"id":"endPoll","name":"End"}]}]}`)
+	f.Add("Ended", `{"attachments":[{"title":"Ended","text":"results"}]}`)
+	f.Add("", `{"attachments":[]}`)
+	f.Add("", `{"attachments":"not a list"}`)
+	f.Add("", `{"attachments":[null,{"actions":[null,{"id":"vote"}]}]}`)
+	f.Add("", `{"attachments":[{"actions":[{"id":7,"name":["x"]}]}]}`)
+	f.Add("", `{}`)
+	f.Add("", `null`)
+
+	f.Fuzz(func(t *testing.T, message, propsJSON string) {
+		var props model.StringInterface
+		if err := json.Unmarshal([]byte(propsJSON), &props); err != nil {
+			return
+		}
+		post := &model.Post{Type: matterpollPostType, Message: message}
+		post.SetProps(props)
+
+		poll, ok := parseMatterpollPost(post)
+		if !ok {
+			if poll != nil {
+				t.Errorf("not a poll but returned %+v", poll)
+			}
+			return
+		}
+		if poll == nil || len(poll.Answers) == 0 {
+			t.Fatalf("parsed a poll without answers from %s", propsJSON)
+		}
+		for _, answer := range poll.Answers {
+			if !strings.HasPrefix(answer.ActionID, matterpollVoteAction) {
+				t.Errorf("answer with non-vote action %q", answer.ActionID)
+			}
+		}
+
+		post.Type = model.PostTypeDefault
+		if _, ok := parseMatterpollPost(post); ok {
+			t.Error("parsed a poll from a post of another type")
+		}
+	})
+}
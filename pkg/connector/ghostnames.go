@@ -0,0 +1,154 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+)
+
+// ghostRenameBatchSize is how many users are fetched per request when
+// ghosts are renamed.
+const ghostRenameBatchSize = 100
+
+const ghostGetAllIDs = `
+	SELECT id FROM ghost WHERE bridge_id=$1
+`
+
+// ghostDisplayname renders the display name of a Mattermost user's ghost
+// with the live displayname template of teamID, marking guests. teamID
+// comes from ghostTeams, so every login renders the same name.
+func (mc *MattermostConnector) ghostDisplayname(user *model.User, teamID string) string {
+	name := mc.liveConfig().FormatTeamDisplayname(teamID, DisplaynameParams{
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+	})
+	if user.IsGuest() {
+		name += mc.Config.Guests.DisplaynameSuffix
+	}
+	return name
+}
+
+// renameGhosts re-renders the display name of every ghost through the first
+// full login, after a displayname template was reloaded. The names don't
+// depend on the login's team, see ghostTeams. Failures are logged.
+func (mc *MattermostConnector) renameGhosts(ctx context.Context) {
+	log := zerolog.Ctx(ctx)
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return
+	}
+	_, client, err := mc.clientLogin(ctx, "")
+	if err != nil {
+		log.Warn().Err(err).Msg("Can't rename ghosts without a Mattermost login")
+		return
+	}
+	renamed, err := client.renameGhosts(ctx)
+	if err != nil {
+		log.Error().Err(err).Int("renamed", renamed).Msg("Failed to rename ghosts")
+		return
+	}
+	log.Info().Int("renamed", renamed).Msg("Renamed ghosts after displayname template change")
+}
+
+// renameGhosts re-renders the display name of every ghost in the database,
+// fetching the users and their teams in batches. Puppet bots
+// are skipped, like in handleUserUpdated. It returns how many ghosts were
+// renamed.
+func (m *MattermostClient) renameGhosts(ctx context.Context) (int, error) {
+	if m.client == nil {
+		return 0, errors.New("login has no Mattermost client")
+	}
+	userIDs, err := m.ghostUserIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	renamed := 0
+	for start := 0; start < len(userIDs); start += ghostRenameBatchSize {
+		users, _, err := m.client.GetUsersByIds(ctx, userIDs[start:min(start+ghostRenameBatchSize, len(userIDs))])
+		if err != nil {
+			return renamed, fmt.Errorf("failed to get users: %w", err)
+		}
+		teams := m.ghostTeams(ctx, userIDs[start:min(start+ghostRenameBatchSize, len(userIDs))])
+		for _, user := range users {
+			ghost, err := m.connector.Bridge.GetGhostByID(ctx, MakeUserID(user.Id))
+			if err != nil {
+				return renamed, fmt.Errorf("failed to get ghost: %w", err)
+			}
+			name := m.connector.ghostDisplayname(user, teams[user.Id])
+			if ghost.Name == name {
+				continue
+			}
+			ghost.UpdateInfo(ctx, &bridgev2.UserInfo{Name: &name})
+			renamed++
+		}
+	}
+	return renamed, nil
+}
+
+// ghostTeams picks the team whose displayname template applies to each of
+// userIDs: the lowest team ID in team_displayname_templates the user is an
+// active member of. Ghosts are shared by every login, so the choice can't
+// depend on the login's own team. Users without such a team are left out,
+// and get the default template. Teams that can't be looked up are skipped.
+func (m *MattermostClient) ghostTeams(ctx context.Context, userIDs []string) map[string]string {
+	teamIDs := slices.Sorted(maps.Keys(m.connector.liveConfig().TeamDisplaynameTemplates))
+	teams := make(map[string]string, len(userIDs))
+	if m.client == nil {
+		return teams
+	}
+	for _, teamID := range teamIDs {
+		remaining := slices.DeleteFunc(slices.Clone(userIDs), func(userID string) bool {
+			_, ok := teams[userID]
+			return ok
+		})
+		if len(remaining) == 0 {
+			break
+		}
+		members, _, err := m.client.GetTeamMembersByIds(ctx, teamID, remaining)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("team_id", teamID).
+				Msg("Failed to get team members for ghost displaynames")
+			continue
+		}
+		for _, member := range members {
+			if member.DeleteAt == 0 {
+				teams[member.UserId] = teamID
+			}
+		}
+	}
+	return teams
+}
+
+// ghostUserIDs returns the Mattermost user IDs of the bridge's ghosts,
+// except puppet bots.
+func (m *MattermostClient) ghostUserIDs(ctx context.Context) ([]string, error) {
+	rows, err := m.connector.Bridge.DB.Query(ctx, ghostGetAllIDs, m.connector.Bridge.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ghosts: %w", err)
+	}
+	defer rows.Close()
+	var userIDs []string
+	for rows.Next() {
+		var ghostID networkid.UserID
+		if err := rows.Scan(&ghostID); err != nil {
+			return nil, fmt.Errorf("failed to read ghost: %w", err)
+		}
+		if userID := ParseUserID(ghostID); !m.connector.IsPuppetUserID(userID) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, rows.Err()
+}
@@ -0,0 +1,130 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestGhostDisplayname(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Config.DisplaynameTemplate = "{{.DisplayName}}"
+	mc.Config.TeamDisplaynameTemplates = map[string]string{"team1": "{{.Username}} (team1)"}
+	mc.Config.Guests.DisplaynameSuffix = " (guest)"
+	if err := mc.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		user   *model.User
+		teamID string
+		want   string
+	}{
+		{"nickname", &model.User{Username: "alice", Nickname: "Al", FirstName: "Alice"}, "", "Al"},
+		{"full name", &model.User{Username: "alice", FirstName: "Alice", LastName: "Liddell"}, "", "Alice Liddell"},
+		{"username", &model.User{Username: "alice"}, "", "alice"},
+		{"team template", &model.User{Username: "alice", Nickname: "Al"}, "team1", "alice (team1)"},
+		{"guest", &model.User{Username: "bob", Roles: model.SystemGuestRoleId}, "", "bob (guest)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := mc.ghostDisplayname(tt.user, tt.teamID); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenameGhosts(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Users["alice-id"] = &model.User{Id: "alice-id", Username: "alice", FirstName: "Alice", LastName: "Liddell", Nickname: "Al"}
+	fake.Users["puppet-id"] = &model.User{Id: "puppet-id", Username: "puppet", IsBot: true}
+	mc, intent := newUserSyncTestClient(t, fake)
+	mc.connector.Puppets[id.UserID("@puppet:example.com")] = &PuppetClient{UserID: "puppet-id"}
+
+	ctx := context.Background()
+	for _, userID := range []string{"puppet-id", "alice-id"} {
+		ghost, err := mc.connector.Bridge.GetGhostByID(ctx, MakeUserID(userID))
+		if err != nil {
+			t.Fatalf("GetGhostByID: %v", err)
+		}
+		ghost.UpdateInfo(ctx, mc.mmUserToUserInfo(ctx, fake.Users[userID]))
+	}
+	if intent.displayName != "Alice Liddell (Al)" {
+		t.Fatalf("initial name = %q", intent.displayName)
+	}
+
+	userIDs, err := mc.ghostUserIDs(ctx)
+	if err != nil || len(userIDs) != 1 || userIDs[0] != "alice-id" {
+		t.Fatalf("ghost user IDs: got %v, %v; puppets should be skipped", userIDs, err)
+	}
+
+	mc.connector.Config.DisplaynameTemplate = "{{.Username}} (MM)"
+	if err := mc.connector.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	renamed, err := mc.renameGhosts(ctx)
+	if err != nil || renamed != 1 {
+		t.Fatalf("renameGhosts: got %d, %v", renamed, err)
+	}
+	if intent.displayName != "alice (MM)" {
+		t.Errorf("name after rename = %q", intent.displayName)
+	}
+
+	// Ghosts already up to date aren't touched.
+	if renamed, err := mc.renameGhosts(ctx); err != nil || renamed != 0 {
+		t.Errorf("second renameGhosts: got %d, %v", renamed, err)
+	}
+}
+
+func TestGhostTeams(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.TeamMembers["t1"] = []*model.TeamMember{
+		{TeamId: "t1", UserId: "alice-id"},
+		{TeamId: "t1", UserId: "carol-id", DeleteAt: 1},
+	}
+	fake.TeamMembers["t2"] = []*model.TeamMember{
+		{TeamId: "t2", UserId: "alice-id"},
+		{TeamId: "t2", UserId: "bob-id"},
+	}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.teamID = "t2"
+	mc.connector.Config.TeamDisplaynameTemplates = map[string]string{
+		"t2": "{{.Username}} (t2)",
+		"t1": "{{.Username}} (t1)",
+	}
+	if err := mc.connector.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+
+	teams := mc.ghostTeams(context.Background(), []string{"alice-id", "bob-id", "carol-id", "dave-id"})
+	want := map[string]string{"alice-id": "t1", "bob-id": "t2"}
+	if len(teams) != len(want) {
+		t.Fatalf("got %v, want %v", teams, want)
+	}
+	for userID, teamID := range want {
+		if teams[userID] != teamID {
+			t.Errorf("%s: got team %q, want %q", userID, teams[userID], teamID)
+		}
+	}
+
+	// The login's own team doesn't matter.
+	info := mc.mmUserToUserInfo(context.Background(), &model.User{Id: "alice-id", Username: "alice"})
+	if *info.Name != "alice (t1)" {
+		t.Errorf("name = %q, want %q", *info.Name, "alice (t1)")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// GroupMentionConfig controls the bridging of the @channel, @all and @here
+// group mentions as Matrix @room mentions.
+type GroupMentionConfig struct {
+	// ToMatrix bridges group mentions in Mattermost posts as @room
+	// mentions.
+	ToMatrix bool `yaml:"to_matrix"`
+	// ToMattermost bridges @room mentions of Matrix users allowed to notify
+	// the room as @channel, and strips the group mentions of the others.
+	ToMattermost bool `yaml:"to_mattermost"`
+}
+
+// groupMentionsToMatrix reports whether the group mentions of a post become
+// @room mentions: when enabled and they notified the channel. Mattermost
+// disables them for posters without the permission, and posters may disable
+// them when mentioning a large channel.
+func (c *Config) groupMentionsToMatrix(post *model.Post) bool {
+	if !c.GroupMentions.ToMatrix {
+		return false
+	}
+	for _, prop := range []string{model.PostPropsMentionHighlightDisabled, model.PostPropsGroupHighlightDisabled} {
+		if disabled, _ := post.GetProp(prop).(bool); disabled {
+			return false
+		}
+	}
+	return true
+}
+
+// convertGroupMentions converts the group mentions of a Matrix message's
+// Mattermost text. When the sender may notify the portal room, an @room
+// mention becomes @channel. Otherwise the @ of @room and of any Mattermost
+// group mention is stripped, so the post notifies no one. The sender is the
+// relayed user when there is one, as for choosing a puppet.
+func (m *MattermostClient) convertGroupMentions(ctx context.Context, portal *bridgev2.Portal, origSender *bridgev2.OrigSender, evt *event.Event, content *event.MessageEventContent, text string) string {
+	if !m.connector.Config.GroupMentions.ToMattermost || !strings.Contains(text, "@") {
+		return text
+	}
+	var sender id.UserID
+	if origSender != nil {
+		sender = origSender.UserID
+	} else if evt != nil {
+		sender = evt.Sender
+	}
+	// Without intentional mentions, @room in the body mentions the room.
+	mentionsRoom := content.Mentions == nil || content.Mentions.Room
+	var allowed, checked bool
+	return mattermostfmt.ReplaceGroupMentions(text, func(name string) string {
+		if !checked {
+			allowed, checked = m.canNotifyRoom(ctx, portal, sender), true
+		}
+		switch {
+		case !allowed:
+			return name
+		case name == "room" && mentionsRoom:
+			return "@channel"
+		}
+		return "@" + name
+	})
+}
+
+// canNotifyRoom reports whether a Matrix user's power level in the portal
+// room allows notifying the whole room. It fails closed when the power
+// levels can't be read.
+func (m *MattermostClient) canNotifyRoom(ctx context.Context, portal *bridgev2.Portal, userID id.UserID) bool {
+	if userID == "" || portal == nil || portal.MXID == "" || portal.Bridge == nil {
+		return false
+	}
+	pl, err := portal.Bridge.Matrix.GetPowerLevels(ctx, portal.MXID)
+	if err != nil {
+		m.log.Warn().Err(err).Str("room_id", portal.MXID.String()).Msg("Failed to get power levels for group mention")
+		return false
+	}
+	return pl.GetUserLevel(userID) >= pl.Notifications.Room()
+}
@@ -0,0 +1,121 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// roomNotifyMatrixConnector returns power levels where @mod may notify the
+// room and everyone else may not, or err.
+type roomNotifyMatrixConnector struct {
+	nopMatrixConnector
+	err error
+}
+
+func (c roomNotifyMatrixConnector) GetPowerLevels(context.Context, id.RoomID) (*event.PowerLevelsEventContent, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &event.PowerLevelsEventContent{Users: map[id.UserID]int{"@mod:example.com": 50}}, nil
+}
+
+func TestConvertPostToMatrix_GroupMentions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		enabled  bool
+		props    model.StringInterface
+		wantBody string
+		wantRoom bool
+	}{
+		{"enabled", true, nil, "@room standup now", true},
+		{"disabled", false, nil, "@channel standup now", false},
+		{"no permission", true, model.StringInterface{model.PostPropsMentionHighlightDisabled: true}, "@channel standup now", false},
+		{"disabled by poster", true, model.StringInterface{model.PostPropsGroupHighlightDisabled: true}, "@channel standup now", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient()
+			client.connector.Config.GroupMentions.ToMatrix = tt.enabled
+			post := &model.Post{Id: "p1", Message: "@channel standup now"}
+			post.SetProps(tt.props)
+
+			content := client.convertPostToMatrix(context.Background(), nil, nil, post).Parts[0].Content
+			if content.Body != tt.wantBody {
+				t.Errorf("body = %q, want %q", content.Body, tt.wantBody)
+			}
+			if room := content.Mentions != nil && content.Mentions.Room; room != tt.wantRoom {
+				t.Errorf("room mention = %v, want %v", room, tt.wantRoom)
+			}
+		})
+	}
+}
+
+func TestConvertGroupMentions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		sender   id.UserID
+		text     string
+		mentions *event.Mentions
+		plErr    error
+		want     string
+	}{
+		{name: "room mention", sender: "@mod:example.com", text: "@room standup", mentions: &event.Mentions{Room: true}, want: "@channel standup"},
+		{name: "legacy room mention", sender: "@mod:example.com", text: "@room standup", want: "@channel standup"},
+		{name: "not a room mention", sender: "@mod:example.com", text: "the @room alias", mentions: &event.Mentions{}, want: "the @room alias"},
+		{name: "group mention", sender: "@mod:example.com", text: "@here standup", mentions: &event.Mentions{}, want: "@here standup"},
+		{name: "no permission", sender: "@user:example.com", text: "@room and @all, @here", mentions: &event.Mentions{Room: true}, want: "room and all, here"},
+		{name: "power levels unavailable", sender: "@mod:example.com", text: "@room standup", plErr: errors.New("boom"), want: "room standup"},
+		{name: "code", sender: "@user:example.com", text: "`@channel`", want: "`@channel`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient()
+			client.connector.Config.GroupMentions.ToMattermost = true
+			portal := makeTestPortalWithBot("ch1", nil)
+			portal.MXID = "!ch1:example.com"
+			portal.Bridge.Matrix = roomNotifyMatrixConnector{err: tt.plErr}
+			content := &event.MessageEventContent{Body: tt.text, Mentions: tt.mentions}
+
+			got := client.convertGroupMentions(context.Background(), portal, nil, &event.Event{Sender: tt.sender}, content, tt.text)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertGroupMentions_Relayed(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.Config.GroupMentions.ToMattermost = true
+	portal := makeTestPortalWithBot("ch1", nil)
+	portal.MXID = "!ch1:example.com"
+	portal.Bridge.Matrix = roomNotifyMatrixConnector{}
+	content := &event.MessageEventContent{Body: "@room hi"}
+
+	// The relay user may notify the room, the relayed user may not.
+	got := client.convertGroupMentions(context.Background(), portal, &bridgev2.OrigSender{UserID: "@user:example.com"}, &event.Event{Sender: "@mod:example.com"}, content, "@room hi")
+	if got != "room hi" {
+		t.Errorf("got %q, want the relayed user's mention stripped", got)
+	}
+
+	client.connector.Config.GroupMentions.ToMattermost = false
+	if got := client.convertGroupMentions(context.Background(), portal, nil, &event.Event{Sender: "@user:example.com"}, content, "@all hi"); got != "@all hi" {
+		t.Errorf("disabled: got %q, want the text unchanged", got)
+	}
+}
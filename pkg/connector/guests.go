@@ -0,0 +1,87 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// guestLookupTimeout bounds the lookup of a user not known to be a guest or
+// not, which runs on the WebSocket goroutine.
+const guestLookupTimeout = 5 * time.Second
+
+// rememberGuest records whether a Mattermost user is a guest.
+func (m *MattermostClient) rememberGuest(userID string, guest bool) {
+	m.guestsMu.Lock()
+	defer m.guestsMu.Unlock()
+	if m.guests == nil {
+		m.guests = newClientCache[string, bool](m, "guests", true)
+	}
+	m.guests.set(userID, guest)
+}
+
+// resetGuests forgets which users are guests, so users promoted or demoted
+// since are looked up again.
+func (m *MattermostClient) resetGuests() {
+	m.guestsMu.Lock()
+	m.guests.clear()
+	m.guestsMu.Unlock()
+}
+
+// isExcludedGuestMember reports whether a channel member is a guest left out
+// by guests.exclude, and records the member's guest status.
+func (m *MattermostClient) isExcludedGuestMember(member model.ChannelMember) bool {
+	m.rememberGuest(member.UserId, member.SchemeGuest)
+	return m.connector.Config.Guests.Exclude && member.SchemeGuest
+}
+
+// isExcludedGuest reports whether a user is a guest left out by
+// guests.exclude. Users not seen in a channel member list, user info or
+// mention yet are fetched once, within guestLookupTimeout, and cached for
+// mentions too; if that fails, the user is treated as a regular user so
+// their messages aren't lost, and looked up again next time.
+func (m *MattermostClient) isExcludedGuest(userID string) bool {
+	if !m.connector.Config.Guests.Exclude || userID == m.userID {
+		return false
+	}
+	m.guestsMu.Lock()
+	guest, ok := m.guests.get(userID)
+	m.guestsMu.Unlock()
+	if ok {
+		return guest
+	}
+	if user := m.cachedMentionUser(userID); user != nil {
+		m.rememberGuest(userID, user.IsGuest())
+		return user.IsGuest()
+	}
+	if m.client == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(m.log.WithContext(context.Background()), guestLookupTimeout)
+	defer cancel()
+	user, _, err := m.client.GetUser(ctx, userID, "")
+	if err != nil {
+		m.log.Warn().Err(err).Str("user_id", userID).Msg("Failed to check if user is a guest")
+		return false
+	}
+	m.rememberGuest(userID, user.IsGuest())
+	m.rememberMentionUser(user.Username, user)
+	return user.IsGuest()
+}
+
+// hasExcludedGuest reports whether a DM's other member is a guest left out by
+// guests.exclude, in which case the DM isn't bridged.
+func (m *MattermostClient) hasExcludedGuest(members model.ChannelMembers) bool {
+	for _, member := range members {
+		if member.UserId != m.userID && m.isExcludedGuestMember(member) {
+			return true
+		}
+	}
+	return false
+}
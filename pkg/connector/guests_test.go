@@ -0,0 +1,170 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestMmUserToUserInfo_Guest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		roles  string
+		suffix string
+		want   string
+	}{
+		{"guest with suffix", model.SystemGuestRoleId, " (guest)", "alice (MM) (guest)"},
+		{"guest without suffix", model.SystemGuestRoleId, "", "alice (MM)"},
+		{"regular user", model.SystemUserRoleId, " (guest)", "alice (MM)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient()
+			client.connector.Config.Guests.DisplaynameSuffix = tt.suffix
+			info := client.mmUserToUserInfo(context.Background(), &model.User{Id: "alice-id", Username: "alice", Roles: tt.roles})
+			if info.Name == nil || *info.Name != tt.want {
+				t.Errorf("name: got %v, want %q", info.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelMembersToChatMembers_ExcludeGuests(t *testing.T) {
+	t.Parallel()
+	members := model.ChannelMembers{
+		{UserId: "user1", ChannelId: "ch1"},
+		{UserId: "guest1", ChannelId: "ch1", SchemeGuest: true},
+	}
+	tests := []struct {
+		name    string
+		exclude bool
+		want    int
+	}{
+		{"guests included", false, 2},
+		{"guests excluded", true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient()
+			client.connector.Config.Guests.Exclude = tt.exclude
+			result := client.channelMembersToChatMembers(members)
+			if len(result.MemberMap) != tt.want || result.TotalMemberCount != tt.want {
+				t.Errorf("members: got %d (total %d), want %d", len(result.MemberMap), result.TotalMemberCount, tt.want)
+			}
+			if _, ok := result.MemberMap[MakeUserID("guest1")]; ok == tt.exclude {
+				t.Errorf("guest in member list: got %v, want %v", ok, !tt.exclude)
+			}
+		})
+	}
+}
+
+func TestIsExcludedGuest(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Users["guest-id"] = &model.User{Id: "guest-id", Username: "guest", Roles: model.SystemGuestRoleId}
+	fake.Users["user-id"] = &model.User{Id: "user-id", Username: "user", Roles: model.SystemUserRoleId}
+
+	mc := newFullTestClient(fake.Server.URL)
+	if mc.isExcludedGuest("guest-id") {
+		t.Error("guests should not be excluded unless guests.exclude is set")
+	}
+	if fake.CalledPath("/api/v4/users/guest-id") {
+		t.Error("users should not be looked up unless guests.exclude is set")
+	}
+
+	mc.connector.Config.Guests.Exclude = true
+	tests := []struct {
+		userID string
+		want   bool
+	}{
+		{"guest-id", true},
+		{"user-id", false},
+		{"my-user-id", false},
+		// Lookup failures don't drop messages.
+		{"unknown-id", false},
+	}
+	for _, tt := range tests {
+		if got := mc.isExcludedGuest(tt.userID); got != tt.want {
+			t.Errorf("isExcludedGuest(%q): got %v, want %v", tt.userID, got, tt.want)
+		}
+	}
+
+	// Channel members are known without a lookup.
+	mc.isExcludedGuestMember(model.ChannelMember{UserId: "member-guest-id", SchemeGuest: true})
+	if !mc.isExcludedGuest("member-guest-id") {
+		t.Error("guest channel member should be excluded")
+	}
+	if fake.CalledPath("/api/v4/users/member-guest-id") {
+		t.Error("known channel member should not be looked up")
+	}
+
+	// So are users looked up for mentions, and the users looked up here are
+	// known to mentions.
+	mc.rememberMentionUser("mentioned", &model.User{Id: "mentioned-id", Username: "mentioned", Roles: model.SystemGuestRoleId})
+	if !mc.isExcludedGuest("mentioned-id") || fake.CalledPath("/api/v4/users/mentioned-id") {
+		t.Error("mentioned guest should be excluded without a lookup")
+	}
+	if user := mc.cachedMentionUser("guest-id"); user == nil || user.Username != "guest" {
+		t.Errorf("cached mention user = %+v, want guest", user)
+	}
+}
+
+func TestHandlePosted_ExcludedGuest(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.Guests.Exclude = true
+	mc.rememberGuest("guest-id", true)
+
+	postJSON, err := json.Marshal(&model.Post{Id: "p1", UserId: "guest-id", ChannelId: "ch1", Message: "hello"})
+	if err != nil {
+		t.Fatalf("marshal post: %v", err)
+	}
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventPosted, "ch1", map[string]any{"post": string(postJSON)}))
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventUserAdded, "ch1", map[string]any{"user_id": "guest-id"}))
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventTyping, "ch1", map[string]any{"user_id": "guest-id"}))
+
+	if events := testMock(mc).Events(); len(events) != 0 {
+		t.Errorf("expected no events for an excluded guest, got %d", len(events))
+	}
+}
+
+func TestQueueChannelSync_DMWithExcludedGuest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		exclude bool
+		want    int
+	}{
+		{"guests included", false, 1},
+		{"guests excluded", true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.ChannelMembers["dm1"] = model.ChannelMembers{
+				{ChannelId: "dm1", UserId: "my-user-id"},
+				{ChannelId: "dm1", UserId: "guest-id", SchemeGuest: true},
+			}
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.Guests.Exclude = tt.exclude
+
+			mc.queueChannelSync(context.Background(), &model.Channel{Id: "dm1", Type: model.ChannelTypeDirect}, true)
+			if events := testMock(mc).Events(); len(events) != tt.want {
+				t.Errorf("expected %d events, got %d", tt.want, len(events))
+			}
+		})
+	}
+}
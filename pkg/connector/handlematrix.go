@@ -7,23 +7,68 @@ package connector
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
 	"github.com/mattermost/mattermost/server/public/model"
 	"maunium.net/go/mautrix/bridgev2"
 	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
 	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
 )
 
 // HandleMatrixMessage handles a message sent from Matrix to Mattermost.
+// With outbound_hold enabled, messages that fail because Mattermost is
+// unreachable, and later messages of the same portal, are held and sent once
+// it's back.
 func (m *MattermostClient) HandleMatrixMessage(ctx context.Context, msg *bridgev2.MatrixMessage) (*bridgev2.MatrixMessageResponse, error) {
 	if !m.IsLoggedIn() {
 		return nil, bridgev2.ErrNotLoggedIn
 	}
+	if msg.Portal.RoomType == database.RoomTypeSpace {
+		return nil, errTeamSpaceUnsupported
+	}
+	if portalMetadata(msg.Portal).Archived {
+		return nil, apiError("failed to send message", nil, ErrChannelArchived)
+	}
 
+	hold := m.connector.outboundHold
+	if hold == nil || m.userLogin == nil || msg.Event == nil {
+		return m.sendMatrixMessage(ctx, msg)
+	}
+	if hold.holding(m.userLogin.ID, ParsePortalID(msg.Portal.ID)) {
+		return m.holdMatrixMessage(ctx, msg, nil)
+	}
+	resp, err := m.sendMatrixMessage(ctx, msg)
+	if errors.Is(err, errMattermostUnreachable) {
+		return m.holdMatrixMessage(ctx, msg, err)
+	}
+	return resp, err
+}
+
+// sendMatrixMessage posts a Matrix message to Mattermost.
+func (m *MattermostClient) sendMatrixMessage(ctx context.Context, msg *bridgev2.MatrixMessage) (*bridgev2.MatrixMessageResponse, error) {
 	// Check if the real sender has a puppet Mattermost client.
 	// If so, post as that puppet instead of the relay account.
 	postClient, senderID := m.resolvePostClient(msg.OrigSender, msg.Event)
+	// Relayed users without a puppet may post through a webhook instead.
+	// Webhook posts can't reply in a thread room's thread.
+	_, threadRootID, isThread := ParseThreadPortalID(msg.Portal.ID)
+	if postClient == m.client && !isThread && m.connector.Config.RelayWebhooks.enabledFor(ParsePortalID(msg.Portal.ID), portalMetadata(msg.Portal).TeamID) {
+		if resp, err := m.sendWebhookMessage(ctx, msg); !errors.Is(err, errWebhookUnavailable) {
+			return resp, err
+		}
+	}
 
 	channelID := ParsePortalID(msg.Portal.ID)
 	content := msg.Content
@@ -31,58 +76,92 @@ func (m *MattermostClient) HandleMatrixMessage(ctx context.Context, msg *bridgev
 	post := &model.Post{
 		ChannelId: channelID,
 	}
+	var eventID id.EventID
+	if msg.Event != nil {
+		eventID = msg.Event.ID
+	}
 
 	switch content.MsgType {
 	case event.MsgText, event.MsgNotice, event.MsgEmote:
-		text := matrixfmtParse(content)
+		opts := m.matrixFormatOptions(ctx, msg.Portal, eventID)
+		opts.Images = m.inlineImageResolver(ctx, postClient, msg.Portal, &post.FileIds)
+		text := matrixfmtParseWithOptions(content, opts)
 		if content.MsgType == event.MsgEmote {
 			text = "/me " + text
 		}
 		post.Message = text
 
 	case event.MsgImage, event.MsgVideo, event.MsgAudio, event.MsgFile:
-		fileID, err := m.uploadMatrixMedia(ctx, msg)
+		// Mattermost only attaches files uploaded by the posting user, so
+		// upload with the same client that creates the post.
+		fileID, err := m.uploadMatrixMedia(ctx, postClient, msg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to upload media: %w", err)
+			if m.checkPuppetFailure(ctx, senderID, nil, err) {
+				return nil, puppetTokenError("failed to upload media", err)
+			}
+			return nil, apiError("failed to upload media", nil, err)
 		}
 		post.FileIds = []string{fileID}
-		if content.Body != "" && content.Body != content.GetFileName() {
-			post.Message = content.Body
-		}
+		post.Message = mediaCaption(content, m.matrixFormatOptions(ctx, msg.Portal, eventID))
 
 	default:
 		return nil, fmt.Errorf("unsupported message type: %s", content.MsgType)
 	}
+	post.Message = m.convertGroupMentions(ctx, msg.Portal, msg.OrigSender, msg.Event, content, post.Message)
+
+	if sender, ok := forwardedSender(msg.Event); ok {
+		post.Message = m.forwardQuote(ctx, sender, post.Message)
+	}
 
-	// Handle replies.
-	if msg.ReplyTo != nil {
+	// Handle replies. Everything sent in a thread room replies in its
+	// thread.
+	if isThread {
+		post.RootId = threadRootID
+	} else if msg.ReplyTo != nil {
 		post.RootId = ParseMessageID(msg.ReplyTo.ID)
 	}
+	m.connector.markBridgePost(post, eventID)
 
-	createdPost, _, err := postClient.CreatePost(ctx, post)
+	// Messages longer than a post continue in replies to it.
+	parts := m.splitPostMessage(ctx, post.Message)
+	post.Message = parts[0]
+	createdPost, resp, err := postClient.CreatePost(ctx, post)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create post: %w", err)
+		if m.checkPuppetFailure(ctx, senderID, resp, err) {
+			return nil, puppetTokenError("failed to create post", err)
+		}
+		if isUnreachable(resp, err) {
+			err = fmt.Errorf("%w: %w", errMattermostUnreachable, err)
+		}
+		return nil, apiError("failed to create post", resp, err)
 	}
+	m.recordPuppetSuccess(senderID)
+	m.connector.metrics().messages.inc(directionToMattermost)
 
-	return &bridgev2.MatrixMessageResponse{
-		DB: &database.Message{
-			ID:       MakeMessageID(createdPost.Id),
-			SenderID: MakeUserID(senderID),
-		},
-	}, nil
+	dbMsg := &database.Message{
+		ID:       MakeMessageID(createdPost.Id),
+		SenderID: MakeUserID(senderID),
+	}
+	if len(parts) > 1 {
+		if continuations := m.createContinuationPosts(ctx, postClient, createdPost, parts[1:]); len(continuations) > 0 {
+			dbMsg.Metadata = &MessageMetadata{ContinuationPosts: continuations}
+		}
+	}
+	return &bridgev2.MatrixMessageResponse{DB: dbMsg}, nil
 }
 
 // resolvePostClient returns the Mattermost API client and user ID to use for
 // posting a message. If the original Matrix sender has a puppet client
 // configured (i.e. a dedicated Mattermost bot account), that client is used.
-// Otherwise falls back to the default relay client.
+// Otherwise, or if the puppet is unhealthy, falls back to the default relay
+// client.
 func (m *MattermostClient) resolvePostClient(origSender *bridgev2.OrigSender, evt *event.Event) (*model.Client4, string) {
 	m.connector.puppetMu.RLock()
 	defer m.connector.puppetMu.RUnlock()
 
 	// Check OrigSender first (set by bridgev2 for relayed messages).
 	if origSender != nil {
-		if puppet, ok := m.connector.Puppets[origSender.UserID]; ok {
+		if puppet, ok := m.connector.Puppets[origSender.UserID]; ok && puppet.Healthy() {
 			m.log.Debug().
 				Str("mxid", string(origSender.UserID)).
 				Str("mm_username", puppet.Username).
@@ -93,7 +172,7 @@ func (m *MattermostClient) resolvePostClient(origSender *bridgev2.OrigSender, ev
 
 	// Also check raw event sender (covers non-relay cases).
 	if evt != nil && evt.Sender != "" {
-		if puppet, ok := m.connector.Puppets[evt.Sender]; ok {
+		if puppet, ok := m.connector.Puppets[evt.Sender]; ok && puppet.Healthy() {
 			m.log.Debug().
 				Str("mxid", string(evt.Sender)).
 				Str("mm_username", puppet.Username).
@@ -112,17 +191,42 @@ func (m *MattermostClient) HandleMatrixEdit(ctx context.Context, msg *bridgev2.M
 	}
 
 	postID := ParseMessageID(msg.EditTarget.ID)
-	text := matrixfmtParse(msg.Content)
+	// Edits can't attach files, so inline images become their alt text.
+	opts := m.matrixFormatOptions(ctx, msg.Portal, msg.EditTarget.MXID)
+	content := webhookEditContent(msg)
+	var text string
+	switch content.MsgType {
+	case event.MsgImage, event.MsgVideo, event.MsgAudio, event.MsgFile:
+		// Every part of a post edits the post's message, which is the
+		// caption of media sent from Matrix. Files can't be renamed, so an
+		// edit without a caption of a file part of a post leaves its text.
+		text = mediaCaption(content, opts)
+		if text == "" && msg.EditTarget.PartID != "" {
+			return nil
+		}
+	default:
+		text = matrixfmtParseWithOptions(content, opts)
+	}
+	text = m.convertGroupMentions(ctx, msg.Portal, msg.OrigSender, msg.Event, content, text)
 
+	// Like new messages, edits longer than a post continue in replies.
+	parts := m.splitPostMessage(ctx, text)
 	patch := &model.PostPatch{
-		Message: &text,
+		Message: &parts[0],
 	}
 
-	_, _, err := m.client.PatchPost(ctx, postID, patch)
+	patched, resp, err := m.client.PatchPost(ctx, postID, patch)
 	if err != nil {
-		return fmt.Errorf("failed to edit post: %w", err)
+		return apiError("failed to edit post", resp, err)
 	}
 
+	meta := messageMetadata(msg.EditTarget)
+	if len(parts) > 1 || len(meta.ContinuationPosts) > 0 {
+		postClient, _ := m.resolvePostClient(msg.OrigSender, msg.Event)
+		meta.ContinuationPosts = m.editContinuationPosts(ctx, postClient, patched, meta.ContinuationPosts, parts[1:])
+		// bridgev2 saves the edit target after the edit.
+		msg.EditTarget.Metadata = meta
+	}
 	return nil
 }
 
@@ -132,17 +236,28 @@ func (m *MattermostClient) HandleMatrixMessageRemove(ctx context.Context, msg *b
 		return bridgev2.ErrNotLoggedIn
 	}
 
+	if IsPollVoteID(msg.TargetMessage.ID) {
+		return errPollVoteRetracted
+	}
 	postID := ParseMessageID(msg.TargetMessage.ID)
-	_, err := m.client.DeletePost(ctx, postID)
+	resp, err := m.client.DeletePost(ctx, postID)
 	if err != nil {
-		return fmt.Errorf("failed to delete post: %w", err)
+		return apiError("failed to delete post", resp, err)
 	}
+	m.deleteContinuationPosts(ctx, messageMetadata(msg.TargetMessage).ContinuationPosts)
 	return nil
 }
 
 // PreHandleMatrixReaction validates a reaction before sending.
 func (m *MattermostClient) PreHandleMatrixReaction(_ context.Context, msg *bridgev2.MatrixReaction) (bridgev2.MatrixReactionPreResponse, error) {
-	emojiID := emojiToReaction(msg.Content.RelatesTo.Key)
+	if m.connector.Config.isSavedPostReaction(msg.Content.RelatesTo.Key) {
+		return bridgev2.MatrixReactionPreResponse{
+			SenderID: MakeUserID(m.userID),
+			EmojiID:  savedPostEmojiID,
+			Emoji:    msg.Content.RelatesTo.Key,
+		}, nil
+	}
+	emojiID := m.connector.emojiOverrides().emojiToReaction(msg.Content.RelatesTo.Key)
 	return bridgev2.MatrixReactionPreResponse{
 		SenderID: MakeUserID(m.userID),
 		EmojiID:  MakeEmojiID(emojiID),
@@ -156,7 +271,13 @@ func (m *MattermostClient) HandleMatrixReaction(ctx context.Context, msg *bridge
 		return nil, bridgev2.ErrNotLoggedIn
 	}
 
-	postID := ParseMessageID(msg.TargetMessage.ID)
+	postID := reactionTargetPost(msg.TargetMessage)
+	if msg.PreHandleResp.EmojiID == savedPostEmojiID {
+		if err := m.setPostSaved(ctx, postID, true); err != nil {
+			return nil, err
+		}
+		return &database.Reaction{EmojiID: savedPostEmojiID}, nil
+	}
 	emojiName := ParseEmojiID(msg.PreHandleResp.EmojiID)
 
 	mmReaction := &model.Reaction{
@@ -165,16 +286,46 @@ func (m *MattermostClient) HandleMatrixReaction(ctx context.Context, msg *bridge
 		EmojiName: emojiName,
 	}
 
-	_, _, err = m.client.SaveReaction(ctx, mmReaction)
+	_, resp, err := m.client.SaveReaction(ctx, mmReaction)
 	if err != nil {
-		return nil, fmt.Errorf("failed to save reaction: %w", err)
+		return nil, apiError("failed to save reaction", resp, err)
 	}
+	m.acknowledgeReaction(ctx, msg.TargetMessage)
 
 	return &database.Reaction{
 		EmojiID: MakeEmojiID(emojiName),
 	}, nil
 }
 
+// reactionTargetPost returns the Mattermost post a reaction to a message part
+// goes to. A post is bridged as its text followed by one part per file, all
+// with the post's message ID, and Mattermost only has reactions on whole
+// posts, so reactions to any part go to the post.
+func reactionTargetPost(part *database.Message) string {
+	return ParseMessageID(part.ID)
+}
+
+// sameReactionOnOtherPart reports whether the sender of a reaction has the
+// same reaction on another part of the post, and returns that part. Such a
+// reaction is one reaction in Mattermost, which must stay until it's removed
+// from every part.
+func (m *MattermostClient) sameReactionOnOtherPart(ctx context.Context, reaction *database.Reaction) (networkid.PartID, bool) {
+	if m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return "", false
+	}
+	reactions, err := m.connector.Bridge.DB.Reaction.GetAllToMessageBySender(ctx, reaction.Room.Receiver, reaction.MessageID, reaction.SenderID)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to get reactions on the other parts of the post")
+		return "", false
+	}
+	for _, other := range reactions {
+		if other.EmojiID == reaction.EmojiID && other.MessagePartID != reaction.MessagePartID {
+			return other.MessagePartID, true
+		}
+	}
+	return "", false
+}
+
 // HandleMatrixReactionRemove removes a reaction in Mattermost.
 func (m *MattermostClient) HandleMatrixReactionRemove(ctx context.Context, msg *bridgev2.MatrixReactionRemove) error {
 	if !m.IsLoggedIn() {
@@ -183,15 +334,27 @@ func (m *MattermostClient) HandleMatrixReactionRemove(ctx context.Context, msg *
 
 	postID := ParseMessageID(msg.TargetReaction.MessageID)
 	emojiName := ParseEmojiID(msg.TargetReaction.EmojiID)
+	if part, ok := m.sameReactionOnOtherPart(ctx, msg.TargetReaction); ok {
+		m.log.Debug().
+			Str("post_id", postID).
+			Str("emoji", emojiName).
+			Str("other_part_id", string(part)).
+			Msg("Keeping Mattermost reaction still on another part of the post")
+		return nil
+	}
+	if msg.TargetReaction.EmojiID == savedPostEmojiID {
+		return m.setPostSaved(ctx, postID, false)
+	}
 
-	_, err := m.client.DeleteReaction(ctx, &model.Reaction{
+	resp, err := m.client.DeleteReaction(ctx, &model.Reaction{
 		UserId:    m.userID,
 		PostId:    postID,
 		EmojiName: emojiName,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to remove reaction: %w", err)
+		return apiError("failed to remove reaction", resp, err)
 	}
+	m.unacknowledgeReaction(ctx, msg.TargetReaction)
 	return nil
 }
 
@@ -202,11 +365,11 @@ func (m *MattermostClient) HandleMatrixReadReceipt(ctx context.Context, msg *bri
 	}
 
 	channelID := ParsePortalID(msg.Portal.ID)
-	_, _, err := m.client.ViewChannel(ctx, m.userID, &model.ChannelView{
+	_, resp, err := m.client.ViewChannel(ctx, m.userID, &model.ChannelView{
 		ChannelId: channelID,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to mark channel as viewed: %w", err)
+		return apiError("failed to mark channel as viewed", resp, err)
 	}
 	return nil
 }
@@ -217,75 +380,130 @@ func (m *MattermostClient) HandleMatrixTyping(ctx context.Context, msg *bridgev2
 		return bridgev2.ErrNotLoggedIn
 	}
 
-	channelID := ParsePortalID(msg.Portal.ID)
-
-	_, err := m.client.PublishUserTyping(ctx, m.userID, model.TypingRequest{
-		ChannelId: channelID,
-	})
-	if err != nil {
-		m.log.Debug().Err(err).Msg("Failed to send typing indicator")
+	// bridgev2 only calls this for the login's own user, who may still be
+	// mapped to a puppet bot.
+	var sender id.UserID
+	if m.userLogin != nil {
+		sender = m.userLogin.UserMXID
 	}
+	m.publishTyping(ctx, ParsePortalID(msg.Portal.ID), sender, false)
 	return nil
 }
 
-// uploadMatrixMedia downloads media from Matrix and uploads it to Mattermost.
-func (m *MattermostClient) uploadMatrixMedia(ctx context.Context, msg *bridgev2.MatrixMessage) (string, error) {
+// errNoMediaURL is returned for media events without a plain or encrypted
+// file URL.
+var errNoMediaURL = errors.New("media event has no URL")
+
+// uploadMatrixMedia streams media from Matrix to Mattermost through a
+// temporary file, so large files aren't held in memory. Returns the
+// Mattermost file ID.
+func (m *MattermostClient) uploadMatrixMedia(ctx context.Context, client *model.Client4, msg *bridgev2.MatrixMessage) (string, error) {
 	content := msg.Content
+	// Media in encrypted rooms carries its URL and key in content.File
+	// instead of content.URL. DownloadMediaToFile decrypts it.
+	if content.URL == "" && (content.File == nil || content.File.URL == "") {
+		return "", errNoMediaURL
+	}
+	channelID := ParsePortalID(msg.Portal.ID)
+	filename := mediaFileName(content)
 
-	data, err := msg.Portal.Bridge.Bot.DownloadMedia(ctx, content.URL, content.File)
+	var fileInfo *model.FileInfo
+	err := msg.Portal.Bridge.Bot.DownloadMediaToFile(ctx, content.URL, content.File, false, func(f *os.File) error {
+		stat, err := f.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to stat downloaded media: %w", err)
+		}
+		var mimeType string
+		if content.Info != nil {
+			mimeType = content.Info.MimeType
+		}
+		fileInfo, err = uploadFileStream(ctx, client, channelID, filename, mimeType, f, stat.Size())
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to download Matrix media: %w", err)
+		return "", err
 	}
 
-	channelID := ParsePortalID(msg.Portal.ID)
-	filename := content.GetFileName()
-	if filename == "" {
-		filename = "upload"
-	}
+	m.log.Debug().
+		Str("channel_id", channelID).
+		Str("file_id", fileInfo.Id).
+		Str("filename", filename).
+		Int64("size", fileInfo.Size).
+		Bool("encrypted", content.File != nil).
+		Msg("Uploaded Matrix media to Mattermost")
+	return fileInfo.Id, nil
+}
 
-	fileUploadResp, _, err := m.client.UploadFile(ctx, data, channelID, filename)
+// uploadFileStream uploads a file to Mattermost using the raw request body
+// upload API, streaming body instead of buffering a multipart form.
+func uploadFileStream(ctx context.Context, client *model.Client4, channelID, filename, mimeType string, body io.Reader, size int64) (*model.FileInfo, error) {
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	query := url.Values{"channel_id": {channelID}, "filename": {filename}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.APIURL+"/files?"+query.Encode(), body)
 	if err != nil {
-		return "", fmt.Errorf("failed to upload to Mattermost: %w", err)
+		return nil, fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", mimeType)
+	if client.AuthToken != "" {
+		req.Header.Set(model.HeaderAuth, client.AuthType+" "+client.AuthToken)
 	}
 
-	if len(fileUploadResp.FileInfos) == 0 {
-		return "", fmt.Errorf("no file info returned from upload")
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to Mattermost: %w: %w", errMattermostUnreachable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if unreachableStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("failed to upload to Mattermost: %w: %s", errMattermostUnreachable, resp.Status)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Mattermost's rate limiter answers in plain text, and the streamed
+		// body can't be retried, so report it as an AppError apiError
+		// recognizes.
+		return nil, fmt.Errorf("failed to upload to Mattermost: %w",
+			model.NewAppError("uploadFileStream", "api.file.upload_file.rate_limited", nil, resp.Status, http.StatusTooManyRequests))
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to upload to Mattermost: %w", model.AppErrorFromJSON(resp.Body))
 	}
 
-	return fileUploadResp.FileInfos[0].Id, nil
+	var uploadResp model.FileUploadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&uploadResp); err != nil {
+		return nil, fmt.Errorf("failed to decode upload response: %w", err)
+	}
+	if len(uploadResp.FileInfos) == 0 {
+		return nil, fmt.Errorf("no file info returned from upload")
+	}
+	return uploadResp.FileInfos[0], nil
 }
 
-// emojiToReaction converts a Unicode emoji to a Mattermost emoji name.
-func emojiToReaction(emoji string) string {
-	reverseMap := map[string]string{
-		"\U0001f44d":   "+1",
-		"\U0001f44e":   "-1",
-		"\u2764\ufe0f": "heart",
-		"\U0001f604":   "smile",
-		"\U0001f606":   "laughing",
-		"\U0001f44b":   "wave",
-		"\U0001f44f":   "clap",
-		"\U0001f525":   "fire",
-		"\U0001f4af":   "100",
-		"\U0001f389":   "tada",
-		"\U0001f440":   "eyes",
-		"\U0001f914":   "thinking",
-		"\u2705":       "white_check_mark",
-		"\u274c":       "x",
-		"\u26a0\ufe0f": "warning",
-		"\U0001f680":   "rocket",
-		"\u2b50":       "star",
-		"\U0001f64f":   "pray",
-	}
-
-	if name, ok := reverseMap[emoji]; ok {
+// mediaFileName returns the file name to upload a Matrix media message
+// under, stripped of any directory components. Falls back to "upload" with an
+// extension matching the MIME type.
+func mediaFileName(content *event.MessageEventContent) string {
+	name := strings.TrimSpace(content.GetFileName())
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name != "" && name != "." && name != "/" {
 		return name
 	}
-
-	// Strip colons for custom emoji names.
-	if len(emoji) > 2 && emoji[0] == ':' && emoji[len(emoji)-1] == ':' {
-		return emoji[1 : len(emoji)-1]
+	name = "upload"
+	if content.Info != nil && content.Info.MimeType != "" {
+		if exts, _ := mime.ExtensionsByType(content.Info.MimeType); len(exts) > 0 {
+			name += exts[0]
+		}
 	}
+	return name
+}
 
-	return emoji
+// mediaCaption returns the Markdown caption of a Matrix media message. Per
+// the Matrix spec, the body is a caption only when a separate filename is
+// set; otherwise it's just the file name.
+func mediaCaption(content *event.MessageEventContent, opts matrixfmt.Options) string {
+	if content.FileName == "" || content.Body == content.FileName {
+		return ""
+	}
+	return matrixfmtParseWithOptions(content, opts)
 }
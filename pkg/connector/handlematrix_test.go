@@ -7,14 +7,20 @@ package connector
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/rs/zerolog"
 	"maunium.net/go/mautrix/bridgev2"
 	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/crypto/attachment"
 	"maunium.net/go/mautrix/event"
 	"maunium.net/go/mautrix/id"
 )
@@ -268,6 +274,28 @@ func TestHandleMatrixMessage_Text(t *testing.T) {
 	}
 }
 
+func TestHandleMatrixMessage_TeamSpace(t *testing.T) {
+	fm := newFakeMM()
+	defer fm.Close()
+	mc := newFullTestClient(fm.Server.URL)
+
+	portal := makeTestPortal("test-channel")
+	portal.RoomType = database.RoomTypeSpace
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  portal,
+			Content: &event.MessageEventContent{MsgType: event.MsgText, Body: "Hello"},
+		},
+	}
+
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); !errors.Is(err, errTeamSpaceUnsupported) {
+		t.Fatalf("expected errTeamSpaceUnsupported, got %v", err)
+	}
+	if fm.CalledPath("/api/v4/posts") {
+		t.Error("nothing should be posted from a team space")
+	}
+}
+
 func TestHandleMatrixMessage_Emote(t *testing.T) {
 	fm := newFakeMM()
 	defer fm.Close()
@@ -445,6 +473,60 @@ func TestHandleMatrixEdit_Success(t *testing.T) {
 	}
 }
 
+func TestHandleMatrixEdit_Media(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		partID    networkid.PartID
+		body      string
+		wantPatch bool
+		wantText  string
+	}{
+		{"caption", "", "new caption", true, "new caption"},
+		{"caption removed", "", "photo.jpg", true, ""},
+		{"file part caption", "1", "new caption", true, "new caption"},
+		{"file part without caption", "1", "photo.jpg", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := newFakeMM()
+			t.Cleanup(fm.Close)
+			mc := newFullTestClient(fm.Server.URL)
+
+			err := mc.HandleMatrixEdit(context.Background(), &bridgev2.MatrixEdit{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+					Portal:  makeTestPortal("test-channel"),
+					Content: &event.MessageEventContent{MsgType: event.MsgImage, Body: tt.body, FileName: "photo.jpg"},
+				},
+				EditTarget: &database.Message{ID: MakeMessageID("post-to-edit"), PartID: tt.partID},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var patches []endpointCall
+			for _, call := range fm.Calls() {
+				if strings.HasSuffix(call.Path, "/patch") {
+					patches = append(patches, call)
+				}
+			}
+			if !tt.wantPatch {
+				if len(patches) != 0 {
+					t.Errorf("expected no patch, got %d", len(patches))
+				}
+				return
+			}
+			if len(patches) != 1 {
+				t.Fatalf("expected 1 patch, got %d", len(patches))
+			}
+			var patch model.PostPatch
+			if err := json.Unmarshal([]byte(patches[0].Body), &patch); err != nil || patch.Message == nil || *patch.Message != tt.wantText {
+				t.Errorf("patch body %q, want message %q", patches[0].Body, tt.wantText)
+			}
+		})
+	}
+}
+
 func TestHandleMatrixEdit_APIError(t *testing.T) {
 	fm := newFakeMM()
 	defer fm.Close()
@@ -931,6 +1013,368 @@ func TestHandleMatrixMessage_EmptyBody(t *testing.T) {
 	}
 }
 
-// Note: Testing HandleMatrixMessage with file uploads (MsgImage/MsgVideo/MsgAudio/MsgFile)
-// requires a full bridge setup for DownloadMedia, which is impractical in unit tests.
-// The media upload path is covered by integration tests or manual testing.
+// ---------------------------------------------------------------------------
+// HandleMatrixMessage media tests
+// ---------------------------------------------------------------------------
+
+// lastCreatedPost returns the post body of the last POST /api/v4/posts call.
+func lastCreatedPost(t *testing.T, fm *fakeMM) *model.Post {
+	t.Helper()
+	var post *model.Post
+	for _, c := range fm.Calls() {
+		if c.Method == http.MethodPost && c.Path == "/api/v4/posts" {
+			post = &model.Post{}
+			if err := json.Unmarshal([]byte(c.Body), post); err != nil {
+				t.Fatalf("decode post: %v", err)
+			}
+		}
+	}
+	if post == nil {
+		t.Fatal("no post created")
+	}
+	return post
+}
+
+func TestHandleMatrixMessage_Media(t *testing.T) {
+	t.Parallel()
+	const mxc = id.ContentURIString("mxc://localhost/abc")
+	data := []byte("\x89PNG fake image data")
+	tests := []struct {
+		name        string
+		content     *event.MessageEventContent
+		wantName    string
+		wantMime    string
+		wantMessage string
+	}{
+		{
+			name:     "file name only",
+			content:  &event.MessageEventContent{MsgType: event.MsgImage, Body: "cat.png", URL: mxc, Info: &event.FileInfo{MimeType: "image/png"}},
+			wantName: "cat.png",
+			wantMime: "image/png",
+		},
+		{
+			name:        "caption",
+			content:     &event.MessageEventContent{MsgType: event.MsgFile, Body: "see attached", FileName: "report.pdf", URL: mxc},
+			wantName:    "report.pdf",
+			wantMime:    "application/octet-stream",
+			wantMessage: "see attached",
+		},
+		{
+			name: "formatted caption",
+			content: &event.MessageEventContent{
+				MsgType: event.MsgVideo, Body: "look", FileName: "clip.mp4", URL: mxc,
+				Format: event.FormatHTML, FormattedBody: "<strong>look</strong>",
+				Info: &event.FileInfo{MimeType: "video/mp4"},
+			},
+			wantName:    "clip.mp4",
+			wantMime:    "video/mp4",
+			wantMessage: "**look**",
+		},
+		{
+			name:     "body equal to file name",
+			content:  &event.MessageEventContent{MsgType: event.MsgAudio, Body: "voice.ogg", FileName: "voice.ogg", URL: mxc},
+			wantName: "voice.ogg",
+			wantMime: "application/octet-stream",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := newFakeMM()
+			t.Cleanup(fm.Close)
+			fm.TokenToUser["test-token"] = "my-user-id"
+			mc := newFullTestClient(fm.Server.URL)
+
+			msg := &bridgev2.MatrixMessage{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+					Portal:  makeTestPortalWithBot("test-channel", map[id.ContentURIString][]byte{mxc: data}),
+					Content: tt.content,
+				},
+			}
+			if _, err := mc.HandleMatrixMessage(context.Background(), msg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(fm.Uploads) != 1 {
+				t.Fatalf("expected 1 upload, got %d", len(fm.Uploads))
+			}
+			up := fm.Uploads[0]
+			if up.Name != tt.wantName || up.MimeType != tt.wantMime || up.ChannelId != "test-channel" {
+				t.Errorf("upload: name %q mime %q channel %q", up.Name, up.MimeType, up.ChannelId)
+			}
+			if up.Size != int64(len(data)) {
+				t.Errorf("upload size: got %d, want %d", up.Size, len(data))
+			}
+
+			post := lastCreatedPost(t, fm)
+			if len(post.FileIds) != 1 || post.FileIds[0] != "uploaded-file-id" {
+				t.Errorf("FileIds: got %v", post.FileIds)
+			}
+			if post.Message != tt.wantMessage {
+				t.Errorf("Message: got %q, want %q", post.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// TestHandleMatrixMessage_MediaUsesPuppetClient verifies that media is
+// uploaded by the same account that creates the post, since Mattermost only
+// attaches files uploaded by the poster.
+func TestHandleMatrixMessage_MediaUsesPuppetClient(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	fm.TokenToUser["puppet-token"] = "alice-mm-id"
+	mc := newFullTestClient(fm.Server.URL)
+
+	puppetClient := model.NewAPIv4Client(fm.Server.URL)
+	puppetClient.SetToken("puppet-token")
+	mc.connector.Puppets[id.UserID("@alice:localhost")] = &PuppetClient{
+		MXID:     id.UserID("@alice:localhost"),
+		Client:   puppetClient,
+		UserID:   "alice-mm-id",
+		Username: "alice",
+	}
+
+	const mxc = id.ContentURIString("mxc://localhost/abc")
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  makeTestPortalWithBot("test-channel", map[id.ContentURIString][]byte{mxc: []byte("data")}),
+			Content: &event.MessageEventContent{MsgType: event.MsgFile, Body: "a.txt", URL: mxc},
+			Event:   &event.Event{Sender: "@alice:localhost"},
+		},
+	}
+	resp, err := mc.HandleMatrixMessage(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fm.Uploads) != 1 || fm.Uploads[0].CreatorId != "alice-mm-id" {
+		t.Fatalf("expected upload by alice-mm-id, got %+v", fm.Uploads)
+	}
+	if string(resp.DB.SenderID) != "alice-mm-id" {
+		t.Errorf("SenderID: got %q", resp.DB.SenderID)
+	}
+}
+
+func TestHandleMatrixMessage_MediaDownloadError(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  makeTestPortalWithBot("test-channel", nil),
+			Content: &event.MessageEventContent{MsgType: event.MsgImage, Body: "x.png", URL: "mxc://localhost/missing"},
+		},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err == nil {
+		t.Fatal("expected error for failed download")
+	}
+	if fm.CalledPath("/api/v4/posts") {
+		t.Error("no post should be created when the download fails")
+	}
+}
+
+func TestHandleMatrixMessage_MediaUploadError(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	fm.FailEndpoints["/api/v4/files"] = true
+	mc := newFullTestClient(fm.Server.URL)
+
+	const mxc = id.ContentURIString("mxc://localhost/abc")
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  makeTestPortalWithBot("test-channel", map[id.ContentURIString][]byte{mxc: []byte("data")}),
+			Content: &event.MessageEventContent{MsgType: event.MsgImage, Body: "x.png", URL: mxc},
+		},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err == nil {
+		t.Fatal("expected error for failed upload")
+	}
+}
+
+func TestHandleMatrixMessage_EncryptedMedia(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+
+	const mxc = id.ContentURIString("mxc://localhost/encrypted")
+	plaintext := []byte("secret report contents")
+	file := &event.EncryptedFileInfo{EncryptedFile: *attachment.NewEncryptedFile(), URL: mxc}
+	ciphertext := file.Encrypt(plaintext)
+	file = roundTripFileInfo(t, file)
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal: makeTestPortalWithBot("test-channel", map[id.ContentURIString][]byte{mxc: ciphertext}),
+			Content: &event.MessageEventContent{
+				MsgType: event.MsgFile,
+				Body:    "report.txt",
+				Info:    &event.FileInfo{MimeType: "text/plain"},
+				File:    file,
+			},
+		},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err != nil {
+		t.Fatalf("HandleMatrixMessage: %v", err)
+	}
+
+	fm.mu.Lock()
+	uploads := append([]*model.FileInfo(nil), fm.Uploads...)
+	fm.mu.Unlock()
+	if len(uploads) != 1 {
+		t.Fatalf("expected 1 upload, got %d", len(uploads))
+	}
+	if uploads[0].Size != int64(len(plaintext)) {
+		t.Errorf("uploaded size: got %d, want decrypted size %d", uploads[0].Size, len(plaintext))
+	}
+	if uploads[0].Name != "report.txt" || uploads[0].MimeType != "text/plain" {
+		t.Errorf("upload metadata: got %q %q", uploads[0].Name, uploads[0].MimeType)
+	}
+	if post := lastCreatedPost(t, fm); len(post.FileIds) != 1 {
+		t.Errorf("post FileIds: got %v", post.FileIds)
+	}
+}
+
+func TestHandleMatrixMessage_EncryptedMediaWrongKey(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+
+	const mxc = id.ContentURIString("mxc://localhost/encrypted")
+	ciphertext := attachment.NewEncryptedFile().Encrypt([]byte("data"))
+	// The event's key doesn't match the one the file was encrypted with.
+	file := &event.EncryptedFileInfo{EncryptedFile: *attachment.NewEncryptedFile(), URL: mxc}
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  makeTestPortalWithBot("test-channel", map[id.ContentURIString][]byte{mxc: ciphertext}),
+			Content: &event.MessageEventContent{MsgType: event.MsgFile, Body: "x.bin", File: file},
+		},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err == nil {
+		t.Fatal("expected error for undecryptable media")
+	}
+	if fm.CalledPath("/api/v4/files") {
+		t.Error("undecryptable media should not be uploaded")
+	}
+}
+
+func TestHandleMatrixMessage_MediaNoURL(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  makeTestPortalWithBot("test-channel", nil),
+			Content: &event.MessageEventContent{MsgType: event.MsgImage, Body: "x.png"},
+		},
+	}
+	_, err := mc.HandleMatrixMessage(context.Background(), msg)
+	if !errors.Is(err, errNoMediaURL) {
+		t.Fatalf("expected errNoMediaURL, got %v", err)
+	}
+}
+
+func TestMediaFileName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content *event.MessageEventContent
+		want    string
+	}{
+		{"file name field", &event.MessageEventContent{Body: "caption", FileName: "a.png"}, "a.png"},
+		{"body as name", &event.MessageEventContent{Body: "b.txt"}, "b.txt"},
+		{"strips directories", &event.MessageEventContent{FileName: "../../etc/passwd"}, "passwd"},
+		{"strips windows directories", &event.MessageEventContent{FileName: "C:\\Users\\x\\c.doc"}, "c.doc"},
+		{"empty with mime", &event.MessageEventContent{Info: &event.FileInfo{MimeType: "image/png"}}, "upload.png"},
+		{"empty without mime", &event.MessageEventContent{}, "upload"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := mediaFileName(tt.content); got != tt.want {
+				t.Errorf("mediaFileName: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleMatrixReaction_FileParts(t *testing.T) {
+	t.Parallel()
+	// Reactions to the text and to each file of a post go to the post.
+	for _, index := range []int{0, 1, 2, 11} {
+		fm := newFakeMM()
+		mc := newFullTestClient(fm.Server.URL)
+		_, err := mc.HandleMatrixReaction(context.Background(), &bridgev2.MatrixReaction{
+			MatrixEventBase: bridgev2.MatrixEventBase[*event.ReactionEventContent]{
+				Portal:  makeTestPortal("test-channel"),
+				Content: &event.ReactionEventContent{RelatesTo: event.RelatesTo{Key: "\U0001f44d"}},
+			},
+			TargetMessage: &database.Message{ID: MakeMessageID("p1"), PartID: MakeMessagePartID(index)},
+			PreHandleResp: &bridgev2.MatrixReactionPreResponse{EmojiID: MakeEmojiID("+1")},
+		})
+		if err != nil {
+			t.Fatalf("part %d: %v", index, err)
+		}
+		calls := fm.Calls()
+		if len(calls) != 1 || !strings.Contains(calls[0].Body, `"post_id":"p1"`) {
+			t.Errorf("part %d: calls %+v, want one reaction on p1", index, calls)
+		}
+		fm.Close()
+	}
+}
+
+func TestHandleMatrixReactionRemove_FileParts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		otherParts []int
+		wantDelete bool
+	}{
+		{"only part", nil, true},
+		{"same reaction on another file", []int{2}, false},
+		{"same reaction on the text", []int{0}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, client, fake, portal := newReactionSyncTestConnector(t)
+			addFileParts(t, mc, portal)
+			ctx := context.Background()
+			reactionOn := func(index int) *database.Reaction {
+				return &database.Reaction{
+					Room:          portal.PortalKey,
+					MessageID:     MakeMessageID("p1"),
+					MessagePartID: MakeMessagePartID(index),
+					SenderID:      MakeUserID("relayuser"),
+					EmojiID:       MakeEmojiID("smile"),
+					MXID:          id.EventID("$smile-" + strconv.Itoa(index) + ":example.com"),
+					Timestamp:     time.UnixMilli(2000),
+				}
+			}
+			target := reactionOn(1)
+			for _, index := range append(tt.otherParts, 1) {
+				if err := mc.Bridge.DB.Reaction.Upsert(ctx, reactionOn(index)); err != nil {
+					t.Fatalf("insert reaction: %v", err)
+				}
+			}
+			err := client.HandleMatrixReactionRemove(ctx, &bridgev2.MatrixReactionRemove{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.RedactionEventContent]{Portal: portal},
+				TargetReaction:  target,
+			})
+			if err != nil {
+				t.Fatalf("remove: %v", err)
+			}
+			if deleted := len(reactionDeletes(fake)) > 0; deleted != tt.wantDelete {
+				t.Errorf("deleted in Mattermost: %v, want %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
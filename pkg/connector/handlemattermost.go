@@ -6,12 +6,17 @@
 package connector
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"html"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/rs/zerolog"
 	"maunium.net/go/mautrix/bridgev2"
@@ -21,12 +26,22 @@ import (
 	"maunium.net/go/mautrix/event"
 )
 
-// senderFor builds an EventSender for the given Mattermost user ID. If the
-// user has a double puppet UserLogin registered, SenderLogin is set so the
-// bridgev2 framework uses that user's double puppet intent instead of a ghost.
+// senderFor builds an EventSender for the given Mattermost user ID and
+// tracks the user's presence. See memberSender.
 func (m *MattermostClient) senderFor(mmUserID string) bridgev2.EventSender {
+	sender := m.memberSender(mmUserID)
+	m.trackPresence(mmUserID)
+	return sender
+}
+
+// memberSender builds an EventSender for the given Mattermost user ID. The
+// login's own user is marked IsFromMe, and if the user has a double puppet
+// UserLogin registered, SenderLogin is set so the bridgev2 framework uses
+// that user's double puppet intent instead of a ghost.
+func (m *MattermostClient) memberSender(mmUserID string) bridgev2.EventSender {
 	sender := bridgev2.EventSender{
-		Sender: MakeUserID(mmUserID),
+		IsFromMe: mmUserID == m.userID,
+		Sender:   MakeUserID(mmUserID),
 	}
 	if loginID, ok := m.connector.DoublePuppetLoginID(mmUserID); ok {
 		sender.SenderLogin = loginID
@@ -36,6 +51,15 @@ func (m *MattermostClient) senderFor(mmUserID string) bridgev2.EventSender {
 
 // handleEvent dispatches a Mattermost WebSocket event to the appropriate handler.
 func (m *MattermostClient) handleEvent(evt *model.WebSocketEvent) {
+	// Channels owned by another shard are bridged by a different process.
+	if chID := evt.GetBroadcast().ChannelId; chID != "" && !m.connector.OwnsChannel(chID) {
+		m.log.Trace().
+			Str("event_type", string(evt.EventType())).
+			Str("channel_id", chID).
+			Msg("Skipping event for channel owned by another shard")
+		return
+	}
+
 	switch evt.EventType() {
 	case model.WebsocketEventPosted:
 		m.handlePosted(evt)
@@ -51,6 +75,42 @@ func (m *MattermostClient) handleEvent(evt *model.WebSocketEvent) {
 		m.handleTyping(evt)
 	case model.WebsocketEventChannelViewed:
 		m.handleChannelViewed(evt)
+	case model.WebsocketEventChannelMemberUpdated:
+		m.handleChannelMemberUpdated(evt)
+	case model.WebsocketEventMemberroleUpdated:
+		m.handleMemberRoleUpdated(evt)
+	case model.WebsocketEventUserRoleUpdated:
+		m.handleUserRoleUpdated(evt)
+	case model.WebsocketEventDirectAdded, model.WebsocketEventGroupAdded:
+		m.handleDirectAdded(evt)
+	case model.WebsocketEventChannelUpdated:
+		m.handleChannelUpdated(evt)
+	case model.WebsocketEventChannelDeleted:
+		m.handleChannelDeleted(evt)
+	case model.WebsocketEventChannelRestored:
+		m.handleChannelRestored(evt)
+	case model.WebsocketEventStatusChange:
+		m.handleStatusChange(evt)
+	case model.WebsocketEventUserAdded:
+		m.handleUserAdded(evt)
+	case model.WebsocketEventUserRemoved:
+		m.handleUserRemoved(evt)
+	case model.WebsocketEventUserUpdated:
+		m.handleUserUpdated(evt)
+	case model.WebsocketEventAddedToTeam:
+		m.handleAddedToTeam(evt)
+	case model.WebsocketEventLeaveTeam:
+		m.handleLeaveTeam(evt)
+	case model.WebsocketEventUpdateTeam:
+		m.handleUpdateTeam(evt)
+	case model.WebsocketEventPreferencesChanged:
+		m.handlePreferencesChanged(evt, true)
+	case model.WebsocketEventPreferencesDeleted:
+		m.handlePreferencesChanged(evt, false)
+	case callsEventCallStart:
+		m.handleCallStart(evt)
+	case callsEventCallEnd:
+		m.handleCallEnd(evt)
 	default:
 		m.log.Trace().Str("event_type", string(evt.EventType())).Msg("Unhandled event type")
 	}
@@ -70,39 +130,65 @@ func (m *MattermostClient) parsePostedEvent(evt *model.WebSocketEvent) (*model.P
 		return nil, fmt.Errorf("failed to unmarshal post: %w", err)
 	}
 
-	// Echo prevention: skip own posts.
-	if post.UserId == m.userID {
-		return nil, nil
-	}
-
-	// Echo prevention: skip non-default post types (system messages).
-	if post.Type != "" && post.Type != model.PostTypeDefault {
-		return nil, nil
-	}
-
-	// Echo prevention: skip posts from puppet bot users.
-	if m.connector.IsPuppetUserID(post.UserId) {
-		m.log.Debug().
-			Str("post_id", post.Id).
-			Str("user_id", post.UserId).
-			Msg("Skipping puppet bot post (echo prevention)")
+	if m.isEchoPost(&post, string(evt.EventType())) || m.isExcludedGuest(post.UserId) {
 		return nil, nil
 	}
 
 	// Echo prevention: skip posts from usernames matching known bridge patterns.
 	senderName, _ := evt.GetData()["sender_name"].(string)
 	senderName = strings.TrimPrefix(senderName, "@")
-	if senderName != "" && isBridgeUsername(senderName, m.connector.Config.BotPrefix) {
+	if senderName != "" && isBridgeUsername(senderName, m.connector.liveConfig().BotPrefix) {
 		m.log.Debug().
 			Str("post_id", post.Id).
 			Str("username", senderName).
 			Msg("Skipping bridge username post (echo prevention)")
+		m.postEchoDropped(echoLayerBridgeUsername, string(evt.EventType()), &post, senderName)
+		return nil, nil
+	}
+	if m.isSystemUserPost(&post, string(evt.EventType()), senderName) {
 		return nil, nil
 	}
 
 	return &post, nil
 }
 
+// isEchoPost reports whether a new post must not be bridged to Matrix: posts
+// carrying the bridge marker, the logged-in user's own posts and puppet bot
+// posts are echoes of Matrix messages, and system messages other than
+// reminders aren't bridged. Drops are recorded under event, the Mattermost
+// event type.
+func (m *MattermostClient) isEchoPost(post *model.Post, event string) bool {
+	// Echo prevention: skip posts the bridge created from Matrix events.
+	if m.isMarkerEcho(post, event) {
+		return true
+	}
+
+	// Echo prevention: skip own posts.
+	if post.UserId == m.userID {
+		m.postEchoDropped(echoLayerOwnUser, event, post, "")
+		return true
+	}
+
+	// Echo prevention: skip non-default post types (system messages).
+	// Reminders, addressed to the logged-in user, and Matterpoll polls are
+	// the exceptions.
+	if post.Type != "" && post.Type != model.PostTypeDefault && post.Type != model.PostTypeReminder && post.Type != matterpollPostType {
+		m.postEchoDropped(echoLayerSystemMessage, event, post, "")
+		return true
+	}
+
+	// Echo prevention: skip posts from puppet bot users.
+	if m.connector.IsPuppetUserID(post.UserId) {
+		m.log.Debug().
+			Str("post_id", post.Id).
+			Str("user_id", post.UserId).
+			Msg("Skipping puppet bot post (echo prevention)")
+		m.postEchoDropped(echoLayerPuppet, event, post, "")
+		return true
+	}
+	return false
+}
+
 // parsePostEditedEvent extracts and validates an edited post from a WebSocket event,
 // applying echo prevention. Returns (nil, nil) to skip, (nil, err) for errors,
 // or (post, nil) to proceed.
@@ -117,7 +203,14 @@ func (m *MattermostClient) parsePostEditedEvent(evt *model.WebSocketEvent) (*mod
 		return nil, fmt.Errorf("failed to unmarshal edited post: %w", err)
 	}
 
+	if m.isMarkerEcho(&post, string(evt.EventType())) {
+		return nil, nil
+	}
 	if post.UserId == m.userID {
+		m.postEchoDropped(echoLayerOwnUser, string(evt.EventType()), &post, "")
+		return nil, nil
+	}
+	if m.isExcludedGuest(post.UserId) {
 		return nil, nil
 	}
 
@@ -127,17 +220,22 @@ func (m *MattermostClient) parsePostEditedEvent(evt *model.WebSocketEvent) (*mod
 			Str("post_id", post.Id).
 			Str("user_id", post.UserId).
 			Msg("Skipping puppet bot edit (echo prevention)")
+		m.postEchoDropped(echoLayerPuppet, string(evt.EventType()), &post, "")
 		return nil, nil
 	}
 
 	// Echo prevention: skip edits from usernames matching known bridge patterns.
 	senderName, _ := evt.GetData()["sender_name"].(string)
 	senderName = strings.TrimPrefix(senderName, "@")
-	if senderName != "" && isBridgeUsername(senderName, m.connector.Config.BotPrefix) {
+	if senderName != "" && isBridgeUsername(senderName, m.connector.liveConfig().BotPrefix) {
 		m.log.Debug().
 			Str("post_id", post.Id).
 			Str("username", senderName).
 			Msg("Skipping bridge username edit (echo prevention)")
+		m.postEchoDropped(echoLayerBridgeUsername, string(evt.EventType()), &post, senderName)
+		return nil, nil
+	}
+	if m.isSystemUserPost(&post, string(evt.EventType()), senderName) {
 		return nil, nil
 	}
 
@@ -158,7 +256,11 @@ func (m *MattermostClient) parsePostDeletedEvent(evt *model.WebSocketEvent) (*mo
 		return nil, fmt.Errorf("failed to unmarshal deleted post: %w", err)
 	}
 
+	if m.isMarkerEcho(&post, string(evt.EventType())) {
+		return nil, nil
+	}
 	if post.UserId == m.userID {
+		m.postEchoDropped(echoLayerOwnUser, string(evt.EventType()), &post, "")
 		return nil, nil
 	}
 
@@ -168,17 +270,22 @@ func (m *MattermostClient) parsePostDeletedEvent(evt *model.WebSocketEvent) (*mo
 			Str("post_id", post.Id).
 			Str("user_id", post.UserId).
 			Msg("Skipping puppet bot delete (echo prevention)")
+		m.postEchoDropped(echoLayerPuppet, string(evt.EventType()), &post, "")
 		return nil, nil
 	}
 
 	// Echo prevention: skip deletes from usernames matching known bridge patterns.
 	senderName, _ := evt.GetData()["sender_name"].(string)
 	senderName = strings.TrimPrefix(senderName, "@")
-	if senderName != "" && isBridgeUsername(senderName, m.connector.Config.BotPrefix) {
+	if senderName != "" && isBridgeUsername(senderName, m.connector.liveConfig().BotPrefix) {
 		m.log.Debug().
 			Str("post_id", post.Id).
 			Str("username", senderName).
 			Msg("Skipping bridge username delete (echo prevention)")
+		m.postEchoDropped(echoLayerBridgeUsername, string(evt.EventType()), &post, senderName)
+		return nil, nil
+	}
+	if m.isSystemUserPost(&post, string(evt.EventType()), senderName) {
 		return nil, nil
 	}
 
@@ -200,6 +307,11 @@ func (m *MattermostClient) parseReactionEvent(evt *model.WebSocketEvent) (*model
 
 	// Echo prevention: skip own reactions.
 	if reaction.UserId == m.userID {
+		m.reactionEchoDropped(echoLayerOwnUser, string(evt.EventType()), &reaction, "")
+		return nil, nil
+	}
+
+	if m.isExcludedGuest(reaction.UserId) {
 		return nil, nil
 	}
 
@@ -210,18 +322,34 @@ func (m *MattermostClient) parseReactionEvent(evt *model.WebSocketEvent) (*model
 			Str("user_id", reaction.UserId).
 			Str("emoji", reaction.EmojiName).
 			Msg("Skipping puppet bot reaction (echo prevention)")
+		m.reactionEchoDropped(echoLayerPuppet, string(evt.EventType()), &reaction, "")
 		return nil, nil
 	}
 
 	// Echo prevention: skip reactions from usernames matching known bridge patterns.
+	// Reaction events often lack sender_name, so the username is looked up
+	// (and cached) from the user ID instead.
 	senderName, _ := evt.GetData()["sender_name"].(string)
 	senderName = strings.TrimPrefix(senderName, "@")
-	if senderName != "" && isBridgeUsername(senderName, m.connector.Config.BotPrefix) {
+	if senderName == "" && m.client != nil {
+		senderName, _ = m.mentionUsername(m.log.WithContext(context.Background()), reaction.UserId)
+	}
+	if senderName != "" && isBridgeUsername(senderName, m.connector.liveConfig().BotPrefix) {
 		m.log.Debug().
 			Str("post_id", reaction.PostId).
 			Str("username", senderName).
 			Str("emoji", reaction.EmojiName).
 			Msg("Skipping bridge username reaction (echo prevention)")
+		m.reactionEchoDropped(echoLayerBridgeUsername, string(evt.EventType()), &reaction, senderName)
+		return nil, nil
+	}
+	if username, ok := m.systemUsername(reaction.UserId, senderName); ok {
+		m.log.Debug().
+			Str("post_id", reaction.PostId).
+			Str("username", username).
+			Str("emoji", reaction.EmojiName).
+			Msg("Skipping system user reaction")
+		m.reactionEchoDropped(echoLayerSystemUser, string(evt.EventType()), &reaction, username)
 		return nil, nil
 	}
 
@@ -231,7 +359,12 @@ func (m *MattermostClient) parseReactionEvent(evt *model.WebSocketEvent) (*model
 // parseTypingEvent extracts typing event data. Returns ("", "", false) to skip.
 func (m *MattermostClient) parseTypingEvent(evt *model.WebSocketEvent) (userID, channelID string, ok bool) {
 	uid, uidOk := evt.GetData()["user_id"].(string)
-	if !uidOk || uid == m.userID {
+	if !uidOk || uid == m.userID || m.isExcludedGuest(uid) {
+		return "", "", false
+	}
+	// Puppet bots type on behalf of Matrix users, so their typing would
+	// echo back.
+	if m.connector.IsPuppetUserID(uid) {
 		return "", "", false
 	}
 	return uid, evt.GetBroadcast().ChannelId, true
@@ -246,13 +379,33 @@ func (m *MattermostClient) parseChannelViewedEvent(evt *model.WebSocketEvent) (c
 	return chID, true
 }
 
+// isTooOld reports whether a live event created at createAt (Unix
+// milliseconds) is older than max_message_age_seconds, and logs the drop.
+func (m *MattermostClient) isTooOld(eventType, postID string, createAt int64) bool {
+	maxAge := m.connector.Config.maxMessageAge()
+	if maxAge == 0 || createAt <= 0 {
+		return false
+	}
+	age := time.Since(time.UnixMilli(createAt))
+	if age <= maxAge {
+		return false
+	}
+	m.log.Debug().
+		Str("event_type", eventType).
+		Str("post_id", postID).
+		Dur("age", age).
+		Msg("Dropping event older than max_message_age_seconds")
+	return true
+}
+
 func (m *MattermostClient) handlePosted(evt *model.WebSocketEvent) {
 	post, err := m.parsePostedEvent(evt)
 	if err != nil {
 		m.log.Warn().Err(err).Msg("Failed to parse posted event")
 		return
 	}
-	if post == nil {
+	if post == nil || m.isTooOld(string(evt.EventType()), post.Id, post.CreateAt) ||
+		m.isDuplicatePost(string(evt.EventType()), post) {
 		return
 	}
 
@@ -262,23 +415,51 @@ func (m *MattermostClient) handlePosted(evt *model.WebSocketEvent) {
 		Str("user_id", post.UserId).
 		Msg("Received new message")
 
-	ts := time.UnixMilli(post.CreateAt)
+	// team_id is empty for DMs and group DMs.
+	teamID, _ := evt.GetData()["team_id"].(string)
+	channelName, _ := evt.GetData()["channel_name"].(string)
+	ctx := m.log.WithContext(context.Background())
+	createPortal := m.allowCreatePortal(ctx, post.ChannelId, channelName, teamID, false)
+	if m.coalescePost(ctx, post, teamID, createPortal) {
+		return
+	}
+	m.queuePost(ctx, post, createPortal)
+}
 
+// queuePost queues a new post as a message in its channel's portal, or in
+// its thread's room when the thread is split into one. Posts merged into it
+// by coalesce_bot_posts follow it in the same message.
+func (m *MattermostClient) queuePost(ctx context.Context, post *model.Post, createPortal bool, merged ...*model.Post) {
+	m.trackReadReceipts(post.ChannelId)
+	portalKey := m.postPortalKey(ctx, post)
+	postHandleFunc := m.connector.watchIfRelayMissing
+	if isThreadPortalKey(portalKey) {
+		// The channel's portal has a room, so the thread's room is created
+		// whatever the portal creation policy.
+		createPortal = true
+		postHandleFunc = m.threadPostHandled
+	}
 	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.Message[*model.Post]{
 		EventMeta: simplevent.EventMeta{
 			Type: bridgev2.RemoteEventMessage,
 			LogContext: func(c zerolog.Context) zerolog.Context {
 				return c.Str("post_id", post.Id).Str("channel_id", post.ChannelId)
 			},
-			PortalKey:    makePortalKey(post.ChannelId),
-			Sender:       m.senderFor(post.UserId),
-			Timestamp:    ts,
-			CreatePortal: true,
+			PortalKey:      portalKey,
+			Sender:         m.senderFor(post.UserId),
+			Timestamp:      time.UnixMilli(post.CreateAt),
+			CreatePortal:   createPortal,
+			PostHandleFunc: postHandleFunc,
 		},
 		ID:   MakeMessageID(post.Id),
 		Data: post,
 		ConvertMessageFunc: func(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, data *model.Post) (*bridgev2.ConvertedMessage, error) {
-			return m.convertPostToMatrix(data), nil
+			m.connector.metrics().messages.inc(directionToMatrix)
+			msg := m.convertPostToMatrix(ctx, portal, intent, data)
+			if len(merged) > 0 {
+				m.mergeCoalescedPosts(ctx, portal, intent, msg, data.Id, merged)
+			}
+			return msg, nil
 		},
 	})
 }
@@ -289,11 +470,26 @@ func (m *MattermostClient) handlePostEdited(evt *model.WebSocketEvent) {
 		m.log.Error().Err(err).Msg("Failed to parse post edited event")
 		return
 	}
-	if post == nil {
+	if post == nil || m.isTooOld(string(evt.EventType()), post.Id, post.EditAt) ||
+		m.isDuplicatePost(string(evt.EventType()), post) || m.updateBurstPost(post, false) {
 		return
 	}
 
+	m.queuePostEdit(post)
+}
+
+// queuePostEdit queues the edit of a bridged post, live or found by a
+// backfill catching up.
+func (m *MattermostClient) queuePostEdit(post *model.Post) {
 	ts := time.UnixMilli(post.EditAt)
+	ctx := m.log.WithContext(context.Background())
+	// A post merged into another's message by coalesce_bot_posts edits
+	// that message.
+	messageID := m.connector.coalescedMessageID(ctx, post)
+	portalKey := makePortalKey(post.ChannelId)
+	if post.RootId != "" {
+		portalKey = m.bridgedPortalKey(ctx, post.ChannelId, messageID)
+	}
 
 	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.Message[*model.Post]{
 		EventMeta: simplevent.EventMeta{
@@ -301,14 +497,24 @@ func (m *MattermostClient) handlePostEdited(evt *model.WebSocketEvent) {
 			LogContext: func(c zerolog.Context) zerolog.Context {
 				return c.Str("post_id", post.Id).Str("channel_id", post.ChannelId)
 			},
-			PortalKey: makePortalKey(post.ChannelId),
+			PortalKey: portalKey,
 			Sender:    m.senderFor(post.UserId),
 			Timestamp: ts,
 		},
-		TargetMessage: MakeMessageID(post.Id),
+		TargetMessage: MakeMessageID(messageID),
 		Data:          post,
 		ConvertEditFunc: func(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, existing []*database.Message, data *model.Post) (*bridgev2.ConvertedEdit, error) {
-			return m.convertEditToMatrix(data, existing), nil
+			// Matterpoll edits its polls to update the vote counts, which
+			// Matrix clients count themselves.
+			if data.Type == matterpollPostType {
+				return nil, bridgev2.ErrIgnoringRemoteEvent
+			}
+			// The edit only has one of the posts merged into the message,
+			// which is rendered again with all of them.
+			if len(existing) > 0 && len(messageMetadata(existing[0]).CoalescedPosts) > 0 {
+				return m.convertCoalescedEdit(ctx, portal, intent, existing[0], data)
+			}
+			return m.convertEditToMatrix(ctx, portal, intent, data, existing), nil
 		},
 	})
 }
@@ -319,11 +525,20 @@ func (m *MattermostClient) handlePostDeleted(evt *model.WebSocketEvent) {
 		m.log.Error().Err(err).Msg("Failed to parse post deleted event")
 		return
 	}
-	if post == nil {
+	if post == nil || m.isDuplicatePost(string(evt.EventType()), post) || m.updateBurstPost(post, true) {
 		return
 	}
 
 	ts := time.UnixMilli(post.DeleteAt)
+	ctx := m.log.WithContext(context.Background())
+	messageID := m.connector.coalescedMessageID(ctx, post)
+	portalKey := makePortalKey(post.ChannelId)
+	if post.RootId != "" {
+		portalKey = m.bridgedPortalKey(ctx, post.ChannelId, messageID)
+	}
+	if m.removeCoalescedPost(ctx, post, messageID, portalKey) {
+		return
+	}
 
 	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.MessageRemove{
 		EventMeta: simplevent.EventMeta{
@@ -331,7 +546,7 @@ func (m *MattermostClient) handlePostDeleted(evt *model.WebSocketEvent) {
 			LogContext: func(c zerolog.Context) zerolog.Context {
 				return c.Str("post_id", post.Id).Str("channel_id", post.ChannelId)
 			},
-			PortalKey: makePortalKey(post.ChannelId),
+			PortalKey: portalKey,
 			Sender:    m.senderFor(post.UserId),
 			Timestamp: ts,
 		},
@@ -345,26 +560,29 @@ func (m *MattermostClient) handleReactionAdded(evt *model.WebSocketEvent) {
 		m.log.Error().Err(err).Msg("Failed to parse reaction added event")
 		return
 	}
-	if reaction == nil {
+	if reaction == nil || m.isTooOld(string(evt.EventType()), reaction.PostId, reaction.CreateAt) ||
+		m.isDuplicateReaction(string(evt.EventType()), reaction) {
 		return
 	}
+	m.queueReaction(evt.GetBroadcast().ChannelId, reaction)
+}
 
-	ts := time.UnixMilli(reaction.CreateAt)
-	emoji := reactionToEmoji(reaction.EmojiName)
-
+// queueReaction queues a reaction added on Mattermost as a remote event.
+func (m *MattermostClient) queueReaction(channelID string, reaction *model.Reaction) {
+	portalKey := m.bridgedPortalKey(m.log.WithContext(context.Background()), channelID, reaction.PostId)
 	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.Reaction{
 		EventMeta: simplevent.EventMeta{
 			Type: bridgev2.RemoteEventReaction,
 			LogContext: func(c zerolog.Context) zerolog.Context {
 				return c.Str("post_id", reaction.PostId).Str("emoji", reaction.EmojiName)
 			},
-			PortalKey: makePortalKey(evt.GetBroadcast().ChannelId),
+			PortalKey: portalKey,
 			Sender:    m.senderFor(reaction.UserId),
-			Timestamp: ts,
+			Timestamp: time.UnixMilli(reaction.CreateAt),
 		},
 		TargetMessage: MakeMessageID(reaction.PostId),
 		EmojiID:       MakeEmojiID(reaction.EmojiName),
-		Emoji:         emoji,
+		Emoji:         m.connector.emojiOverrides().reactionToEmoji(reaction.EmojiName),
 	})
 }
 
@@ -374,17 +592,23 @@ func (m *MattermostClient) handleReactionRemoved(evt *model.WebSocketEvent) {
 		m.log.Error().Err(err).Msg("Failed to parse reaction removed event")
 		return
 	}
-	if reaction == nil {
+	if reaction == nil || m.isDuplicateReaction(string(evt.EventType()), reaction) {
 		return
 	}
 
+	m.queueReactionRemove(evt.GetBroadcast().ChannelId, reaction)
+}
+
+// queueReactionRemove queues the removal of a bridged Mattermost reaction.
+func (m *MattermostClient) queueReactionRemove(channelID string, reaction *model.Reaction) {
+	portalKey := m.bridgedPortalKey(m.log.WithContext(context.Background()), channelID, reaction.PostId)
 	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.Reaction{
 		EventMeta: simplevent.EventMeta{
 			Type: bridgev2.RemoteEventReactionRemove,
 			LogContext: func(c zerolog.Context) zerolog.Context {
 				return c.Str("post_id", reaction.PostId).Str("emoji", reaction.EmojiName)
 			},
-			PortalKey: makePortalKey(evt.GetBroadcast().ChannelId),
+			PortalKey: portalKey,
 			Sender:    m.senderFor(reaction.UserId),
 		},
 		TargetMessage: MakeMessageID(reaction.PostId),
@@ -398,7 +622,7 @@ func (m *MattermostClient) handleTyping(evt *model.WebSocketEvent) {
 		return
 	}
 
-	timeout := m.connector.Config.TypingTimeout
+	timeout := m.connector.liveConfig().TypingTimeout
 	if timeout <= 0 {
 		timeout = 5
 	}
@@ -423,45 +647,81 @@ func (m *MattermostClient) handleChannelViewed(evt *model.WebSocketEvent) {
 		EventMeta: simplevent.EventMeta{
 			Type:      bridgev2.RemoteEventReadReceipt,
 			PortalKey: makePortalKey(channelID),
-			Sender: bridgev2.EventSender{
-				IsFromMe: true,
-				Sender:   MakeUserID(m.userID),
-			},
+			Sender:    m.senderFor(m.userID),
 		},
 	})
 }
 
 // convertPostToMatrix converts a Mattermost post to a bridgev2.ConvertedMessage.
-func (m *MattermostClient) convertPostToMatrix(post *model.Post) *bridgev2.ConvertedMessage {
-	var parts []*bridgev2.ConvertedMessagePart
+// Timestamps are rendered with the portal's timezone and locale settings.
+// Attachments are reuploaded through intent.
+func (m *MattermostClient) convertPostToMatrix(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, post *model.Post) *bridgev2.ConvertedMessage {
+	opts := m.connector.Config.formatOptionsFor(portal)
+	if post.Type == model.PostTypeReminder {
+		msg := m.convertReminderToMatrix(post, opts)
+		addPostIDToParts(msg.Parts, post.Id)
+		return msg
+	}
+	if poll, ok := parseMatterpollPost(post); ok {
+		msg := convertMatterpollToMatrix(poll)
+		if post.RootId != "" && !isThreadPortal(portal) {
+			msg.ReplyTo = &networkid.MessageOptionalPartID{MessageID: MakeMessageID(post.RootId)}
+		}
+		addPostIDToParts(msg.Parts, post.Id)
+		return msg
+	}
 
-	if post.Message != "" {
-		parsed := mattermostfmtParse(post.Message)
+	var parts []*bridgev2.ConvertedMessagePart
 
-		parts = append(parts, &bridgev2.ConvertedMessagePart{
-			ID:   MakeMessagePartID(0),
-			Type: event.EventMessage,
-			Content: &event.MessageEventContent{
-				MsgType:       event.MsgText,
-				Body:          parsed.Body,
-				Format:        parsed.Format,
-				FormattedBody: parsed.FormattedBody,
-			},
-		})
+	if attachments := post.Attachments(); post.Message != "" || len(attachments) > 0 {
+		opts.Mentions = m.mentionResolver(ctx, post.ChannelId)
+		opts.Channels = m.channelLinkResolver(ctx, portal)
+		opts.Permalinks = m.permalinkResolver(ctx)
+		opts.RoomMentions = m.connector.Config.groupMentionsToMatrix(post)
+		content := &event.MessageEventContent{MsgType: event.MsgText}
+		if post.Message != "" {
+			parsed := mattermostfmtParseWithOptions(post.Message, opts)
+			content.Body = parsed.Body
+			content.Format = parsed.Format
+			content.FormattedBody = parsed.FormattedBody
+			content.Mentions = parsed.Mentions
+		}
+		appendAttachments(content, attachments, opts, m.connector.commandPrefix())
+		m.appendPermalinkQuotes(ctx, content, post, opts)
+
+		if content.Body != "" {
+			part := &bridgev2.ConvertedMessagePart{
+				ID:      MakeMessagePartID(0),
+				Type:    event.EventMessage,
+				Content: content,
+			}
+			if info, ok := postPriority(post); ok {
+				part.Extra = make(map[string]any)
+				applyPostPriority(content, part.Extra, info, m.connector.Config.PostPriority.Labels)
+			}
+			parts = append(parts, part)
+		}
 	}
 
 	for i, fileID := range post.FileIds {
-		filePart := m.convertFileToMatrix(fileID, i+1)
+		filePart := m.convertFileToMatrix(ctx, portal, intent, fileID, i+1)
 		if filePart != nil {
 			parts = append(parts, filePart)
 		}
 	}
 
+	if info, ok := postPriority(post); ok && info.RequestedAck {
+		markRequestedAck(parts)
+	}
+	markEditAt(parts, post.EditAt)
+	addPostIDToParts(parts, post.Id)
+
 	msg := &bridgev2.ConvertedMessage{
 		Parts: parts,
 	}
 
-	if post.RootId != "" {
+	// The root of a thread room's posts is in the channel's room.
+	if post.RootId != "" && !isThreadPortal(portal) {
 		replyTo := MakeMessageID(post.RootId)
 		msg.ReplyTo = &networkid.MessageOptionalPartID{MessageID: replyTo}
 	}
@@ -469,38 +729,180 @@ func (m *MattermostClient) convertPostToMatrix(post *model.Post) *bridgev2.Conve
 	return msg
 }
 
-// convertEditToMatrix converts an edited Mattermost post to a bridgev2.ConvertedEdit.
-func (m *MattermostClient) convertEditToMatrix(post *model.Post, existing []*database.Message) *bridgev2.ConvertedEdit {
-	parsed := mattermostfmtParse(post.Message)
+// convertReminderToMatrix renders a Mattermost "Remind me" post as a notice.
+// Reminder posts carry no message text; the webapp builds the text from the
+// target_time, username, team_name and post_id props.
+func (m *MattermostClient) convertReminderToMatrix(post *model.Post, opts mattermostfmt.Options) *bridgev2.ConvertedMessage {
+	username, _ := post.GetProp("username").(string)
+	teamName, _ := post.GetProp("team_name").(string)
+	postID, _ := post.GetProp("post_id").(string)
 
-	var editParts []*bridgev2.ConvertedEditPart
-	var targetPart *database.Message
-	if len(existing) > 0 {
-		targetPart = existing[0]
+	var when string
+	if target, ok := post.GetProp("target_time").(float64); ok && target > 0 {
+		when = opts.FormatTime(time.Unix(int64(target), 0))
 	}
 
-	editParts = append(editParts, &bridgev2.ConvertedEditPart{
-		Part: targetPart,
-		Type: event.EventMessage,
-		Content: &event.MessageEventContent{
-			MsgType:       event.MsgText,
-			Body:          parsed.Body,
-			Format:        parsed.Format,
-			FormattedBody: parsed.FormattedBody,
-		},
+	body := "⏰ Reminder about a message"
+	formatted := html.EscapeString(body)
+	if username != "" {
+		body += " from @" + username
+		formatted += " from @" + html.EscapeString(username)
+	}
+	if when != "" {
+		body += " (set for " + when + ")"
+		formatted += " (set for " + html.EscapeString(when) + ")"
+	}
+	if teamName != "" && postID != "" && m.serverURL != "" {
+		link := strings.TrimRight(m.serverURL, "/") + "/" + url.PathEscape(teamName) + "/pl/" + url.PathEscape(postID)
+		body += ": " + link
+		formatted += `: <a href="` + html.EscapeString(link) + `">view message</a>`
+	}
+
+	return &bridgev2.ConvertedMessage{
+		Parts: []*bridgev2.ConvertedMessagePart{{
+			ID:   MakeMessagePartID(0),
+			Type: event.EventMessage,
+			Content: &event.MessageEventContent{
+				MsgType:       event.MsgNotice,
+				Body:          body,
+				Format:        event.FormatHTML,
+				FormattedBody: formatted,
+			},
+		}},
+	}
+}
+
+// convertEditToMatrix converts an edited Mattermost post to a
+// bridgev2.ConvertedEdit. The text part is edited, added or removed with the
+// post's message, files removed from the post are redacted and files added
+// to it are bridged as new parts through intent.
+func (m *MattermostClient) convertEditToMatrix(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, post *model.Post, existing []*database.Message) *bridgev2.ConvertedEdit {
+	opts := m.connector.Config.formatOptionsFor(portal)
+	opts.Mentions = m.mentionResolver(ctx, post.ChannelId)
+	opts.Channels = m.channelLinkResolver(ctx, portal)
+	opts.Permalinks = m.permalinkResolver(ctx)
+	opts.RoomMentions = m.connector.Config.groupMentionsToMatrix(post)
+	parsed := mattermostfmtParseWithOptions(post.Message, opts)
+
+	content := &event.MessageEventContent{
+		MsgType:       event.MsgText,
+		Body:          parsed.Body,
+		Format:        parsed.Format,
+		FormattedBody: parsed.FormattedBody,
+		Mentions:      parsed.Mentions,
+	}
+	appendAttachments(content, post.Attachments(), opts, m.connector.commandPrefix())
+	m.appendPermalinkQuotes(ctx, content, post, opts)
+
+	textPart, fileParts, stale, lastIndex := matchEditParts(post, existing)
+	edit := &bridgev2.ConvertedEdit{}
+	switch {
+	case content.Body == "" && textPart != nil && len(post.FileIds) > 0:
+		// The text of a post with files can be removed.
+		edit.DeletedParts = append(edit.DeletedParts, textPart)
+	case content.Body == "":
+	case textPart != nil || len(existing) == 0:
+		// The framework only converts edits of bridged messages, so an
+		// empty existing list only comes from direct calls.
+		part := &bridgev2.ConvertedEditPart{
+			Part:    textPart,
+			Type:    event.EventMessage,
+			Content: content,
+		}
+		if info, ok := postPriority(post); ok {
+			part.Extra = make(map[string]any)
+			applyPostPriority(content, part.Extra, info, m.connector.Config.PostPriority.Labels)
+		}
+		if textPart != nil && post.EditAt > 0 {
+			setEditAt(textPart, post.EditAt)
+		}
+		edit.ModifiedParts = append(edit.ModifiedParts, part)
+	default:
+		part := &bridgev2.ConvertedMessagePart{
+			ID:      MakeMessagePartID(0),
+			Type:    event.EventMessage,
+			Content: content,
+		}
+		if info, ok := postPriority(post); ok {
+			part.Extra = make(map[string]any)
+			applyPostPriority(content, part.Extra, info, m.connector.Config.PostPriority.Labels)
+			if info.RequestedAck {
+				markRequestedAck([]*bridgev2.ConvertedMessagePart{part})
+			}
+		}
+		markEditAt([]*bridgev2.ConvertedMessagePart{part}, post.EditAt)
+		edit.AddedParts = &bridgev2.ConvertedMessage{Parts: []*bridgev2.ConvertedMessagePart{part}}
+	}
+
+	edit.DeletedParts = append(edit.DeletedParts, stale...)
+	for fileID, part := range fileParts {
+		if !slices.Contains(post.FileIds, fileID) {
+			edit.DeletedParts = append(edit.DeletedParts, part)
+		}
+	}
+	slices.SortFunc(edit.DeletedParts, func(a, b *database.Message) int {
+		return cmp.Compare(a.PartID, b.PartID)
 	})
+	for _, fileID := range post.FileIds {
+		if _, ok := fileParts[fileID]; ok {
+			continue
+		}
+		lastIndex++
+		filePart := m.convertFileToMatrix(ctx, portal, intent, fileID, lastIndex)
+		if filePart == nil {
+			continue
+		}
+		if edit.AddedParts == nil {
+			edit.AddedParts = &bridgev2.ConvertedMessage{}
+		}
+		edit.AddedParts.Parts = append(edit.AddedParts.Parts, filePart)
+	}
+	if edit.AddedParts != nil {
+		addPostIDToParts(edit.AddedParts.Parts, post.Id)
+		if post.RootId != "" && !isThreadPortal(portal) {
+			edit.AddedParts.ReplyTo = &networkid.MessageOptionalPartID{MessageID: MakeMessageID(post.RootId)}
+		}
+	}
+	return edit
+}
 
-	return &bridgev2.ConvertedEdit{
-		ModifiedParts: editParts,
+// matchEditParts sorts the bridged parts of an edited post into its text
+// part, if any, its file parts by file ID, and stale parts, and returns the
+// highest part index in use. File parts bridged without a file ID in their
+// metadata are matched by position in the post's files, and are stale past
+// its last file.
+func matchEditParts(post *model.Post, existing []*database.Message) (textPart *database.Message, fileParts map[string]*database.Message, stale []*database.Message, lastIndex int) {
+	fileParts = make(map[string]*database.Message)
+	for _, part := range existing {
+		index, ok := ParseMessagePartID(part.PartID)
+		if !ok {
+			continue
+		}
+		lastIndex = max(lastIndex, index)
+		fileID := messageMetadata(part).FileID
+		switch {
+		case fileID != "":
+			fileParts[fileID] = part
+		case index == 0:
+			textPart = part
+		case index <= len(post.FileIds):
+			fileParts[post.FileIds[index-1]] = part
+		default:
+			stale = append(stale, part)
+		}
 	}
+	return textPart, fileParts, stale, lastIndex
 }
 
-// convertFileToMatrix converts a Mattermost file attachment to a Matrix message part.
-func (m *MattermostClient) convertFileToMatrix(fileID string, partIndex int) *bridgev2.ConvertedMessagePart {
-	ctx := context.Background()
+// convertFileToMatrix converts a Mattermost file attachment to a Matrix message
+// part. The file is streamed into the Matrix media repo through intent; files
+// that are too large or fail to reupload become a download link instead.
+// Without an intent or a portal room, only the file metadata is bridged.
+func (m *MattermostClient) convertFileToMatrix(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, fileID string, partIndex int) *bridgev2.ConvertedMessagePart {
+	log := m.log.With().Str("file_id", fileID).Logger()
 	fileInfo, _, err := m.client.GetFileInfo(ctx, fileID)
 	if err != nil {
-		m.log.Error().Err(err).Str("file_id", fileID).Msg("Failed to get file info")
+		log.Error().Err(err).Msg("Failed to get file info")
 		return nil
 	}
 
@@ -515,20 +917,44 @@ func (m *MattermostClient) convertFileToMatrix(fileID string, partIndex int) *br
 		msgType = event.MsgAudio
 	}
 
-	return &bridgev2.ConvertedMessagePart{
-		ID:   MakeMessagePartID(partIndex),
-		Type: event.EventMessage,
-		Content: &event.MessageEventContent{
-			MsgType: msgType,
-			Body:    fileInfo.Name,
-			Info: &event.FileInfo{
-				MimeType: mimeType,
-				Size:     int(fileInfo.Size),
-			},
+	content := &event.MessageEventContent{
+		MsgType: msgType,
+		Body:    fileInfo.Name,
+		Info: &event.FileInfo{
+			MimeType: mimeType,
+			Size:     int(fileInfo.Size),
+			Width:    fileInfo.Width,
+			Height:   fileInfo.Height,
 		},
+	}
+
+	if intent != nil && portal != nil && portal.MXID != "" {
+		mxc, file, err := m.reuploadFile(ctx, portal, intent, fileInfo)
+		if err != nil {
+			tooLarge := isFileTooLarge(err)
+			log.Warn().Err(err).
+				Int64("size", fileInfo.Size).
+				Bool("too_large", tooLarge).
+				Msg("Failed to reupload file, bridging as link")
+			return m.fileLinkPart(fileInfo, partIndex, tooLarge)
+		}
+		// In encrypted rooms the upload is encrypted and the URL is
+		// returned inside file instead.
+		if file != nil {
+			content.File = file
+		} else {
+			content.URL = mxc
+		}
+	}
+
+	return &bridgev2.ConvertedMessagePart{
+		ID:      MakeMessagePartID(partIndex),
+		Type:    event.EventMessage,
+		Content: content,
 		Extra: map[string]any{
 			"fi.mau.mattermost.file_id": fileID,
 		},
+		DBMetadata: &MessageMetadata{FileID: fileID},
 	}
 }
 
@@ -548,34 +974,3 @@ func isBridgeUsername(username, botPrefix string) bool {
 		return false
 	}
 }
-
-// reactionToEmoji converts a Mattermost emoji name to a Unicode emoji.
-func reactionToEmoji(name string) string {
-	emojiMap := map[string]string{
-		"+1":               "\U0001f44d",
-		"-1":               "\U0001f44e",
-		"heart":            "\u2764\ufe0f",
-		"smile":            "\U0001f604",
-		"laughing":         "\U0001f606",
-		"thumbsup":         "\U0001f44d",
-		"thumbsdown":       "\U0001f44e",
-		"wave":             "\U0001f44b",
-		"clap":             "\U0001f44f",
-		"fire":             "\U0001f525",
-		"100":              "\U0001f4af",
-		"tada":             "\U0001f389",
-		"eyes":             "\U0001f440",
-		"thinking":         "\U0001f914",
-		"white_check_mark": "\u2705",
-		"x":                "\u274c",
-		"warning":          "\u26a0\ufe0f",
-		"rocket":           "\U0001f680",
-		"star":             "\u2b50",
-		"pray":             "\U0001f64f",
-	}
-
-	if emoji, ok := emojiMap[name]; ok {
-		return emoji
-	}
-	return fmt.Sprintf(":%s:", name)
-}
@@ -8,12 +8,15 @@ package connector
 import (
 	"context"
 	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"maunium.net/go/mautrix/bridgev2"
 	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
 	"maunium.net/go/mautrix/bridgev2/simplevent"
 	"maunium.net/go/mautrix/event"
 	"maunium.net/go/mautrix/id"
@@ -29,7 +32,7 @@ func TestConvertPostToMatrix_TextOnly(t *testing.T) {
 		UserId:    "user1",
 	}
 
-	msg := client.convertPostToMatrix(post)
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	if len(msg.Parts) != 1 {
 		t.Fatalf("expected 1 part, got %d", len(msg.Parts))
@@ -60,7 +63,7 @@ func TestConvertPostToMatrix_WithReply(t *testing.T) {
 		RootId:    "parentpost",
 	}
 
-	msg := client.convertPostToMatrix(post)
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	if msg.ReplyTo == nil {
 		t.Fatal("ReplyTo should not be nil for reply")
@@ -80,7 +83,7 @@ func TestConvertPostToMatrix_EmptyMessage(t *testing.T) {
 		UserId:    "user1",
 	}
 
-	msg := client.convertPostToMatrix(post)
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	if len(msg.Parts) != 0 {
 		t.Errorf("expected 0 parts for empty message, got %d", len(msg.Parts))
@@ -97,7 +100,7 @@ func TestConvertPostToMatrix_WithFormatting(t *testing.T) {
 		UserId:    "user1",
 	}
 
-	msg := client.convertPostToMatrix(post)
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	if len(msg.Parts) != 1 {
 		t.Fatalf("expected 1 part, got %d", len(msg.Parts))
@@ -121,7 +124,7 @@ func TestConvertPostToMatrix_PartIDs(t *testing.T) {
 		UserId:    "user1",
 	}
 
-	msg := client.convertPostToMatrix(post)
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	if len(msg.Parts) < 1 {
 		t.Fatal("expected at least 1 part")
@@ -137,12 +140,13 @@ func TestConvertEditToMatrix(t *testing.T) {
 	post := &model.Post{
 		Id:      "post6",
 		Message: "edited content",
+		EditAt:  2000,
 	}
 	existing := []*database.Message{
 		{ID: "post6"},
 	}
 
-	edit := client.convertEditToMatrix(post, existing)
+	edit := client.convertEditToMatrix(context.Background(), nil, nil, post, existing)
 
 	if len(edit.ModifiedParts) != 1 {
 		t.Fatalf("expected 1 modified part, got %d", len(edit.ModifiedParts))
@@ -154,6 +158,9 @@ func TestConvertEditToMatrix(t *testing.T) {
 	if part.Content.Body != "edited content" {
 		t.Errorf("body: got %q, want %q", part.Content.Body, "edited content")
 	}
+	if got := messageMetadata(existing[0]).EditAt; got != 2000 {
+		t.Errorf("edit time: got %d, want 2000", got)
+	}
 }
 
 func TestConvertEditToMatrix_NoExisting(t *testing.T) {
@@ -164,7 +171,7 @@ func TestConvertEditToMatrix_NoExisting(t *testing.T) {
 		Message: "edited",
 	}
 
-	edit := client.convertEditToMatrix(post, nil)
+	edit := client.convertEditToMatrix(context.Background(), nil, nil, post, nil)
 
 	if len(edit.ModifiedParts) != 1 {
 		t.Fatalf("expected 1 modified part, got %d", len(edit.ModifiedParts))
@@ -174,6 +181,74 @@ func TestConvertEditToMatrix_NoExisting(t *testing.T) {
 	}
 }
 
+func TestConvertEditToMatrix_MultiPart(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	for _, fileID := range []string{"f2", "f3"} {
+		fake.Files[fileID] = &model.FileInfo{Id: fileID, Name: fileID + ".txt", MimeType: "text/plain", Size: 3}
+	}
+	client := newFullTestClient(fake.Server.URL)
+
+	// existingParts returns the parts of a post bridged as text, a file
+	// with its file ID and a file bridged before file IDs were recorded.
+	existingParts := func() []*database.Message {
+		return []*database.Message{
+			{ID: "p1", PartID: MakeMessagePartID(0), Metadata: &MessageMetadata{}},
+			{ID: "p1", PartID: MakeMessagePartID(1), Metadata: &MessageMetadata{FileID: "f1"}},
+			{ID: "p1", PartID: MakeMessagePartID(2)},
+		}
+	}
+	tests := []struct {
+		name        string
+		message     string
+		fileIDs     []string
+		existing    []*database.Message
+		wantText    networkid.PartID
+		wantDeleted []networkid.PartID
+		wantAdded   []networkid.PartID
+	}{
+		{"text edited", "edited", []string{"f1", "f2"}, existingParts(), "", nil, nil},
+		// The legacy part is past the post's last file, so f2 is bridged
+		// again.
+		{"file removed", "edited", []string{"f2"}, existingParts(), "", []networkid.PartID{"1", "2"}, []networkid.PartID{"3"}},
+		{"legacy file removed", "edited", []string{"f1"}, existingParts(), "", []networkid.PartID{"2"}, nil},
+		{"file added", "edited", []string{"f1", "f2", "f3"}, existingParts(), "", nil, []networkid.PartID{"3"}},
+		{"text removed", "", []string{"f1", "f2"}, existingParts(), "none", []networkid.PartID{""}, nil},
+		{"text added", "caption", []string{"f1"}, existingParts()[1:2], "none", nil, []networkid.PartID{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			post := &model.Post{Id: "p1", ChannelId: "ch1", Message: tt.message, FileIds: tt.fileIDs}
+
+			edit := client.convertEditToMatrix(context.Background(), nil, nil, post, tt.existing)
+
+			switch {
+			case tt.wantText == "none" && len(edit.ModifiedParts) != 0:
+				t.Errorf("expected no modified parts, got %d", len(edit.ModifiedParts))
+			case tt.wantText != "none" && (len(edit.ModifiedParts) != 1 || edit.ModifiedParts[0].Part.PartID != tt.wantText):
+				t.Errorf("expected the text part to be modified, got %+v", edit.ModifiedParts)
+			}
+			var deleted, added []networkid.PartID
+			for _, part := range edit.DeletedParts {
+				deleted = append(deleted, part.PartID)
+			}
+			if edit.AddedParts != nil {
+				for _, part := range edit.AddedParts.Parts {
+					added = append(added, part.ID)
+				}
+			}
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Errorf("deleted parts: got %q, want %q", deleted, tt.wantDeleted)
+			}
+			if !slices.Equal(added, tt.wantAdded) {
+				t.Errorf("added parts: got %q, want %q", added, tt.wantAdded)
+			}
+		})
+	}
+}
+
 func TestReactionToEmoji_KnownEmojis(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -339,7 +414,7 @@ func TestConvertedMessage_PartsAreConvertedMessagePart(t *testing.T) {
 		Message: "test",
 	}
 
-	msg := client.convertPostToMatrix(post)
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	for _, part := range msg.Parts {
 		// Verify the part has expected structure.
@@ -419,6 +494,45 @@ func TestHandlePosted_EchoPrevention_OwnPost(t *testing.T) {
 	}
 }
 
+func TestHandlePosted_PortalCreationPolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		cfg    PortalCreationConfig
+		teamID string
+		want   bool
+	}{
+		{"always", PortalCreationConfig{}, "t1", true},
+		{"only-synced", PortalCreationConfig{Policy: PortalCreationOnlySynced}, "t1", false},
+		{"never", PortalCreationConfig{Policy: PortalCreationNever}, "t1", false},
+		{"team override", PortalCreationConfig{Policy: PortalCreationNever, Teams: map[string]PortalCreationPolicy{"t1": PortalCreationAlways}}, "t1", true},
+		{"override for other team", PortalCreationConfig{Policy: PortalCreationNever, Teams: map[string]PortalCreationPolicy{"t2": PortalCreationAlways}}, "t1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mc.connector.Config.PortalCreation = tt.cfg
+			mock := testMock(mc)
+			postJSON, _ := json.Marshal(&model.Post{Id: "p1", UserId: "other-user", ChannelId: "ch1", Message: "hello"})
+
+			mc.handlePosted(newWebSocketEvent(model.WebsocketEventPosted, "ch1", map[string]any{
+				"post":        string(postJSON),
+				"sender_name": "@other",
+				"team_id":     tt.teamID,
+			}))
+
+			events := mock.Events()
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			if got := events[0].(*simplevent.Message[*model.Post]).CreatePortal; got != tt.want {
+				t.Errorf("CreatePortal: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestHandlePosted_EchoPrevention_SystemMsg(t *testing.T) {
 	t.Parallel()
 	mc := newFullTestClient("http://localhost")
@@ -1609,6 +1723,103 @@ func TestParseReactionEvent_BridgeUsername_Filtered(t *testing.T) {
 	}
 }
 
+func TestParseReactionEvent_NoSenderName_LooksUpUsername(t *testing.T) {
+	t.Parallel()
+	// Reaction events usually lack sender_name; the username layer looks
+	// the sender up instead.
+	tests := []struct {
+		name     string
+		username string
+		fail     bool
+		filtered bool
+	}{
+		{"bridge username", "mattermost_ghost", false, true},
+		{"configurable bot prefix", "relay_bot", false, true},
+		{"normal user", "alice", false, false},
+		// A failed lookup doesn't drop the reaction.
+		{"lookup failure", "mattermost_ghost", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.Users["reactor-id"] = &model.User{Id: "reactor-id", Username: tt.username}
+			if tt.fail {
+				fake.FailEndpoints["/users/reactor-id"] = true
+			}
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.BotPrefix = "relay_"
+
+			reactionJSON, _ := json.Marshal(&model.Reaction{
+				UserId: "reactor-id", PostId: "p1", EmojiName: "+1",
+			})
+			evt := newWebSocketEvent(model.WebsocketEventReactionAdded, "ch1", map[string]any{
+				"reaction": string(reactionJSON),
+			})
+
+			reaction, err := mc.parseReactionEvent(evt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if filtered := reaction == nil; filtered != tt.filtered {
+				t.Errorf("filtered: got %v, want %v", filtered, tt.filtered)
+			}
+		})
+	}
+}
+
+func TestParseReactionEvent_NoSenderName_CachesLookup(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Users["reactor-id"] = &model.User{Id: "reactor-id", Username: "alice"}
+	mc := newFullTestClient(fake.Server.URL)
+
+	reactionJSON, _ := json.Marshal(&model.Reaction{
+		UserId: "reactor-id", PostId: "p1", EmojiName: "+1",
+	})
+	for range 3 {
+		evt := newWebSocketEvent(model.WebsocketEventReactionAdded, "ch1", map[string]any{
+			"reaction": string(reactionJSON),
+		})
+		if reaction, err := mc.parseReactionEvent(evt); err != nil || reaction == nil {
+			t.Fatalf("reaction should pass, got %v, %v", reaction, err)
+		}
+	}
+
+	lookups := 0
+	for _, call := range fake.Calls() {
+		if call.Path == "/api/v4/users/reactor-id" {
+			lookups++
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("user lookups: got %d, want 1", lookups)
+	}
+}
+
+func TestParseReactionEvent_SenderNameSkipsLookup(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+
+	reactionJSON, _ := json.Marshal(&model.Reaction{
+		UserId: "reactor-id", PostId: "p1", EmojiName: "+1",
+	})
+	evt := newWebSocketEvent(model.WebsocketEventReactionAdded, "ch1", map[string]any{
+		"reaction":    string(reactionJSON),
+		"sender_name": "@alice",
+	})
+	if reaction, err := mc.parseReactionEvent(evt); err != nil || reaction == nil {
+		t.Fatalf("reaction should pass, got %v, %v", reaction, err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("sender_name should avoid the lookup, got calls %+v", fake.Calls())
+	}
+}
+
 func TestParseReactionEvent_PuppetUser_Filtered(t *testing.T) {
 	t.Parallel()
 	// Reactions from puppet bot users should be filtered (echo prevention layer 1).
@@ -1879,7 +2090,7 @@ func TestConvertPostToMatrix_WithFiles(t *testing.T) {
 		FileIds:   model.StringArray{"f1"},
 	}
 
-	msg := mc.convertPostToMatrix(post)
+	msg := mc.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	// Should have 2 parts: text + file.
 	if len(msg.Parts) != 2 {
@@ -1911,7 +2122,7 @@ func TestConvertPostToMatrix_OnlyFiles(t *testing.T) {
 		FileIds:   model.StringArray{"f2"},
 	}
 
-	msg := mc.convertPostToMatrix(post)
+	msg := mc.convertPostToMatrix(context.Background(), nil, nil, post)
 
 	// Should have 1 part: file only (no text since message is empty).
 	if len(msg.Parts) != 1 {
@@ -1932,7 +2143,7 @@ func TestConvertFileToMatrix_Image(t *testing.T) {
 	}
 
 	mc := newFullTestClient(fake.Server.URL)
-	result := mc.convertFileToMatrix("f1", 1)
+	result := mc.convertFileToMatrix(context.Background(), nil, nil, "f1", 1)
 
 	if result == nil {
 		t.Fatal("expected non-nil result")
@@ -1964,7 +2175,7 @@ func TestConvertFileToMatrix_Video(t *testing.T) {
 	}
 
 	mc := newFullTestClient(fake.Server.URL)
-	result := mc.convertFileToMatrix("f2", 1)
+	result := mc.convertFileToMatrix(context.Background(), nil, nil, "f2", 1)
 
 	if result == nil {
 		t.Fatal("expected non-nil result")
@@ -1984,7 +2195,7 @@ func TestConvertFileToMatrix_Audio(t *testing.T) {
 	}
 
 	mc := newFullTestClient(fake.Server.URL)
-	result := mc.convertFileToMatrix("f3", 1)
+	result := mc.convertFileToMatrix(context.Background(), nil, nil, "f3", 1)
 
 	if result == nil {
 		t.Fatal("expected non-nil result")
@@ -2004,7 +2215,7 @@ func TestConvertFileToMatrix_GenericFile(t *testing.T) {
 	}
 
 	mc := newFullTestClient(fake.Server.URL)
-	result := mc.convertFileToMatrix("f4", 1)
+	result := mc.convertFileToMatrix(context.Background(), nil, nil, "f4", 1)
 
 	if result == nil {
 		t.Fatal("expected non-nil result")
@@ -2022,7 +2233,7 @@ func TestConvertFileToMatrix_APIError(t *testing.T) {
 	fake.FailEndpoints["/files/"] = true
 
 	mc := newFullTestClient(fake.Server.URL)
-	result := mc.convertFileToMatrix("f1", 1)
+	result := mc.convertFileToMatrix(context.Background(), nil, nil, "f1", 1)
 
 	if result != nil {
 		t.Errorf("expected nil result on API error, got %+v", result)
@@ -2244,3 +2455,138 @@ func TestHandlePostDeleted_SenderLoginSet(t *testing.T) {
 		t.Errorf("SenderLogin: got %q, want %q", events[0].GetSender().SenderLogin, "dp-deleter")
 	}
 }
+
+func TestConvertPostToMatrix_Timestamps(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.Config.Timezone = "Asia/Tokyo"
+	client.connector.Config.TimeFormat = "2006-01-02 15:04 MST"
+	if err := client.connector.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, &model.Post{Id: "p1", Message: "standup at <t:1700000000>"})
+	if len(msg.Parts) != 1 {
+		t.Fatalf("expected 1 part, got %d", len(msg.Parts))
+	}
+	if got := msg.Parts[0].Content.Body; got != "standup at 2023-11-15 07:13 JST" {
+		t.Errorf("body: got %q", got)
+	}
+}
+
+func TestConvertEditToMatrix_Timestamps(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+
+	edit := client.convertEditToMatrix(context.Background(), nil, nil, &model.Post{Id: "p1", Message: "moved to <t:1700000000:d>"}, nil)
+	if got := edit.ModifiedParts[0].Content.Body; got != "moved to 2023-11-14" {
+		t.Errorf("body: got %q", got)
+	}
+}
+
+func TestConvertPostToMatrix_Reminder(t *testing.T) {
+	t.Parallel()
+	client := newFullTestClient("https://mm.example.com/")
+	if err := client.connector.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+
+	post := &model.Post{Id: "r1", Type: model.PostTypeReminder, ChannelId: "dm1", UserId: "system-bot"}
+	post.AddProp("target_time", float64(1700000000))
+	post.AddProp("username", "alice")
+	post.AddProp("team_name", "eng")
+	post.AddProp("post_id", "orig123")
+
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
+	if len(msg.Parts) != 1 {
+		t.Fatalf("expected 1 part, got %d", len(msg.Parts))
+	}
+	content := msg.Parts[0].Content
+	if content.MsgType != event.MsgNotice {
+		t.Errorf("MsgType: got %v, want m.notice", content.MsgType)
+	}
+	for _, want := range []string{"@alice", "2023-11-14 22:13 UTC", "https://mm.example.com/eng/pl/orig123"} {
+		if !strings.Contains(content.Body, want) {
+			t.Errorf("body %q missing %q", content.Body, want)
+		}
+	}
+	if !strings.Contains(content.FormattedBody, `<a href="https://mm.example.com/eng/pl/orig123">`) {
+		t.Errorf("formatted body missing permalink: %q", content.FormattedBody)
+	}
+}
+
+func TestConvertPostToMatrix_ReminderMissingProps(t *testing.T) {
+	t.Parallel()
+	client := newFullTestClient("")
+	post := &model.Post{Id: "r1", Type: model.PostTypeReminder}
+	post.AddProp("username", "<b>x</b>")
+
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, post)
+	content := msg.Parts[0].Content
+	if strings.Contains(content.Body, "/pl/") {
+		t.Errorf("no permalink expected without team/post props: %q", content.Body)
+	}
+	if strings.Contains(content.FormattedBody, "<b>") {
+		t.Errorf("username must be HTML-escaped: %q", content.FormattedBody)
+	}
+}
+
+func TestHandlePosted_ReminderNotFiltered(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	mock := testMock(mc)
+
+	post := &model.Post{Id: "r1", Type: model.PostTypeReminder, ChannelId: "dm1", UserId: "system-bot"}
+	post.AddProp("target_time", float64(1700000000))
+	postJSON, _ := json.Marshal(post)
+	mc.handlePosted(newWebSocketEvent(model.WebsocketEventPosted, "dm1", map[string]any{
+		"post": string(postJSON),
+	}))
+
+	if len(mock.Events()) != 1 {
+		t.Fatalf("expected reminder post to be queued, got %d events", len(mock.Events()))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// max_message_age_seconds tests
+// ---------------------------------------------------------------------------
+
+func TestHandleEvent_MaxMessageAge(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UnixMilli()
+	old := time.Now().Add(-2 * time.Hour).UnixMilli()
+	postEvent := func(eventType model.WebsocketEventType, createAt, editAt int64) *model.WebSocketEvent {
+		postJSON, _ := json.Marshal(&model.Post{Id: "p1", UserId: "other-user", ChannelId: "ch1", Message: "hi", CreateAt: createAt, EditAt: editAt})
+		return newWebSocketEvent(eventType, "ch1", map[string]any{"post": string(postJSON)})
+	}
+	reactionEvent := func(createAt int64) *model.WebSocketEvent {
+		reactionJSON, _ := json.Marshal(&model.Reaction{UserId: "other-user", PostId: "p1", EmojiName: "+1", CreateAt: createAt})
+		return newWebSocketEvent(model.WebsocketEventReactionAdded, "ch1", map[string]any{"reaction": string(reactionJSON), "sender_name": "other"})
+	}
+	tests := []struct {
+		name       string
+		maxAge     int
+		evt        *model.WebSocketEvent
+		wantQueued bool
+	}{
+		{"recent post", 3600, postEvent(model.WebsocketEventPosted, now, 0), true},
+		{"old post", 3600, postEvent(model.WebsocketEventPosted, old, 0), false},
+		{"old post without limit", 0, postEvent(model.WebsocketEventPosted, old, 0), true},
+		{"recent edit of an old post", 3600, postEvent(model.WebsocketEventPostEdited, old, now), true},
+		{"old edit", 3600, postEvent(model.WebsocketEventPostEdited, old, old), false},
+		{"recent reaction", 3600, reactionEvent(now), true},
+		{"old reaction", 3600, reactionEvent(old), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mc.connector.Config.MaxMessageAgeSeconds = tt.maxAge
+			mc.handleEvent(tt.evt)
+			if queued := len(testMock(mc).Events()) > 0; queued != tt.wantQueued {
+				t.Errorf("queued: got %v, want %v", queued, tt.wantQueued)
+			}
+		})
+	}
+}
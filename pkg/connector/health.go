@@ -0,0 +1,255 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Connection states of a login, as reported by /api/health.
+const (
+	connStateDisconnected     = "disconnected"
+	connStateConnected        = "connected"
+	connStateReconnecting     = "reconnecting"
+	connStateBadCredentials   = "bad_credentials"
+	connStateDoublePuppetOnly = "double_puppet_only"
+)
+
+// Overall statuses of /api/health.
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthDown     = "down"
+)
+
+// healthPingTimeout bounds the Mattermost pings of one /api/health request,
+// so a hanging server doesn't hang the probe.
+const healthPingTimeout = 2 * time.Second
+
+// setConnState records the login's connection state for /api/health.
+func (m *MattermostClient) setConnState(state string) {
+	m.connState.Store(state)
+}
+
+// connectionState returns the login's connection state, disconnected until
+// Connect sets one.
+func (m *MattermostClient) connectionState() string {
+	if state, ok := m.connState.Load().(string); ok {
+		return state
+	}
+	return connStateDisconnected
+}
+
+// LoginHealth is the state of one login in the /api/health response.
+type LoginHealth struct {
+	LoginID string `json:"login_id"`
+	// State is connected, reconnecting, disconnected, bad_credentials or
+	// double_puppet_only. Double-puppet-only logins have no connection.
+	State string `json:"state"`
+	// LastEventAt is when the login last received a WebSocket event.
+	LastEventAt *time.Time `json:"last_event_at,omitempty"`
+	// PingMS is the round trip of a ping to the login's Mattermost server,
+	// left out when the ping failed or was skipped.
+	PingMS    *int64 `json:"ping_ms,omitempty"`
+	PingError string `json:"ping_error,omitempty"`
+	// HeldMessages is the number of Matrix messages held for the login by
+	// the outbound hold.
+	HeldMessages int `json:"held_messages"`
+}
+
+// PuppetTokenHealth counts the puppets by whether their token is believed
+// to work.
+type PuppetTokenHealth struct {
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+}
+
+// QueueHealth reports the sizes of the bridge's queues.
+type QueueHealth struct {
+	// Events waiting in the event queue, and its capacity; both 0 when
+	// it's disabled.
+	Events        int `json:"events"`
+	EventCapacity int `json:"event_capacity"`
+	// MissedChannels is the number of channels with dropped events waiting
+	// for a resync.
+	MissedChannels int `json:"missed_channels"`
+	// HeldMessages is the number of Matrix messages held by the outbound
+	// hold, across logins.
+	HeldMessages int `json:"held_messages"`
+}
+
+// HealthResponse is the body of GET /api/health.
+type HealthResponse struct {
+	// Status is "ok", "degraded" if something needs attention but
+	// messages are bridged, or "down" if no login with a Mattermost
+	// connection is connected.
+	Status string `json:"status"`
+	// Problems explains a status other than ok.
+	Problems      []string            `json:"problems,omitempty"`
+	Puppets       int                 `json:"puppets"`
+	PuppetTokens  PuppetTokenHealth   `json:"puppet_tokens"`
+	Logins        []LoginHealth       `json:"logins"`
+	Queues        QueueHealth         `json:"queues"`
+	PortalWatcher PortalWatcherStatus `json:"portal_watcher"`
+}
+
+// HandleHealth is an HTTP handler for GET /api/health. It reports the state
+// of each login with a ping of its Mattermost server, the puppet tokens,
+// the queue sizes and the result of the last portal watcher pass. A down
+// bridge gets 503 Service Unavailable, so the endpoint can serve as a
+// Kubernetes readiness probe. With ?probe=liveness the pings are skipped
+// and the response is always 200 OK, as restarting the bridge doesn't
+// bring Mattermost back.
+func (mc *MattermostConnector) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	liveness := r.URL.Query().Get("probe") == "liveness"
+
+	interval := mc.Config.PortalWatcher.interval()
+	if interval == 0 {
+		interval = defaultPortalWatchInterval
+	}
+	resp := HealthResponse{
+		Puppets:      mc.PuppetCount(),
+		PuppetTokens: mc.puppetTokenHealth(),
+		Logins:       mc.loginHealth(r.Context(), !liveness),
+		Queues:       mc.queueHealth(),
+		PortalWatcher: PortalWatcherStatus{
+			IntervalSeconds: max(int(interval/time.Second), 0),
+			LastRun:         mc.lastPortalWatch.Load(),
+		},
+	}
+	resp.Status, resp.Problems = resp.evaluate()
+
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Status == healthDown && !liveness {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		mc.ctxLog(r.Context()).Warn().Err(err).Msg("Failed to write health response")
+	}
+}
+
+// evaluate returns the overall status of a health response and the
+// problems behind it.
+func (resp *HealthResponse) evaluate() (string, []string) {
+	var problems []string
+	connections, connected := 0, 0
+	for _, login := range resp.Logins {
+		if login.State == connStateDoublePuppetOnly {
+			continue
+		}
+		connections++
+		if login.State == connStateConnected {
+			connected++
+		} else {
+			problems = append(problems, "login "+login.LoginID+" is "+login.State)
+		}
+		if login.PingError != "" {
+			problems = append(problems, "login "+login.LoginID+" can't reach Mattermost")
+		}
+	}
+	if resp.PuppetTokens.Invalid > 0 {
+		problems = append(problems, "some puppet tokens are invalid")
+	}
+	if q := resp.Queues; q.EventCapacity > 0 && q.Events >= q.EventCapacity {
+		problems = append(problems, "the event queue is full")
+	}
+	if last := resp.PortalWatcher.LastRun; last != nil && last.Error != "" {
+		problems = append(problems, "the last portal watcher pass failed")
+	}
+
+	switch {
+	case connections > 0 && connected == 0:
+		return healthDown, problems
+	case len(problems) > 0:
+		return healthDegraded, problems
+	}
+	return healthOK, nil
+}
+
+// puppetTokenHealth counts the puppets by token health.
+func (mc *MattermostConnector) puppetTokenHealth() PuppetTokenHealth {
+	mc.puppetMu.RLock()
+	defer mc.puppetMu.RUnlock()
+	var health PuppetTokenHealth
+	for _, puppet := range mc.Puppets {
+		if puppet.Healthy() {
+			health.Valid++
+		} else {
+			health.Invalid++
+		}
+	}
+	return health
+}
+
+// loginHealth returns the health of the loaded logins, by login ID. With
+// ping, the Mattermost server of each login with a client is pinged, in
+// parallel and bounded by healthPingTimeout.
+func (mc *MattermostConnector) loginHealth(ctx context.Context, ping bool) []LoginHealth {
+	clients := mc.loadedClients()
+	logins := make([]LoginHealth, len(clients))
+	ctx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		login := &logins[i]
+		login.LoginID = string(client.userLogin.ID)
+		login.State = client.connectionState()
+		if at := client.lastEventAt.Load(); at > 0 {
+			t := time.UnixMilli(at).UTC()
+			login.LastEventAt = &t
+		}
+		if mc.outboundHold != nil {
+			login.HeldMessages = mc.outboundHold.count(client.userLogin.ID)
+		}
+		if !ping || client.client == nil || login.State == connStateDoublePuppetOnly {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			if _, _, err := client.client.GetPing(ctx); err != nil {
+				login.PingError = err.Error()
+				return
+			}
+			ms := time.Since(start).Milliseconds()
+			login.PingMS = &ms
+		}()
+	}
+	wg.Wait()
+	slices.SortFunc(logins, func(a, b LoginHealth) int {
+		return cmp.Compare(a.LoginID, b.LoginID)
+	})
+	return logins
+}
+
+// queueHealth returns the sizes of the event queue and the outbound hold.
+func (mc *MattermostConnector) queueHealth() QueueHealth {
+	var health QueueHealth
+	if q := mc.eventQueue; q != nil {
+		health.Events = len(q.events)
+		health.EventCapacity = cap(q.events)
+		health.MissedChannels = q.missedCount()
+	}
+	if h := mc.outboundHold; h != nil {
+		h.mu.Lock()
+		for _, count := range h.counts {
+			health.HeldMessages += count
+		}
+		h.mu.Unlock()
+	}
+	return health
+}
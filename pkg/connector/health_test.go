@@ -0,0 +1,138 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+)
+
+// trackHealthClient loads a client of loginID talking to serverURL in the
+// given connection state.
+func trackHealthClient(mc *MattermostConnector, loginID, serverURL, state string) *MattermostClient {
+	client := newFullTestClient(serverURL)
+	client.connector = mc
+	client.userLogin = &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: networkid.UserLoginID(loginID)}}
+	client.setConnState(state)
+	mc.trackClient(client)
+	return client
+}
+
+// getHealth requests /api/health with query and returns the status code and
+// response.
+func getHealth(t *testing.T, mc *MattermostConnector, query string) (int, HealthResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	mc.HandleHealth(w, httptest.NewRequest(http.MethodGet, "/api/health"+query, nil))
+	var resp HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return w.Code, resp
+}
+
+func TestHandleHealth_Logins(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newTestBridgeConnector()
+	connected := trackHealthClient(mc, "a-login", fake.Server.URL, connStateConnected)
+	connected.lastEventAt.Store(time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC).UnixMilli())
+	trackHealthClient(mc, "b-login", fake.Server.URL, connStateReconnecting)
+	dp := trackHealthClient(mc, "c-login", fake.Server.URL, connStateDoublePuppetOnly)
+	dp.client = nil
+
+	code, resp := getHealth(t, mc, "")
+	if code != http.StatusOK {
+		t.Fatalf("status code = %d, want 200", code)
+	}
+	if resp.Status != healthDegraded || len(resp.Problems) != 1 || resp.Problems[0] != "login b-login is reconnecting" {
+		t.Errorf("status = %q, problems = %q", resp.Status, resp.Problems)
+	}
+	if len(resp.Logins) != 3 {
+		t.Fatalf("logins = %+v", resp.Logins)
+	}
+	a, b, c := resp.Logins[0], resp.Logins[1], resp.Logins[2]
+	if a.LoginID != "a-login" || a.State != connStateConnected || a.PingMS == nil || a.LastEventAt == nil || !a.LastEventAt.Equal(time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("connected login = %+v", a)
+	}
+	if b.State != connStateReconnecting || b.PingMS == nil || b.LastEventAt != nil {
+		t.Errorf("reconnecting login = %+v", b)
+	}
+	if c.State != connStateDoublePuppetOnly || c.PingMS != nil || c.PingError != "" {
+		t.Errorf("double puppet login = %+v", c)
+	}
+}
+
+func TestHandleHealth_Down(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newTestBridgeConnector()
+	trackHealthClient(mc, "login", fake.Server.URL, connStateBadCredentials)
+
+	code, resp := getHealth(t, mc, "")
+	if code != http.StatusServiceUnavailable || resp.Status != healthDown {
+		t.Errorf("readiness: status code %d, status %q", code, resp.Status)
+	}
+
+	pings := len(fake.Calls())
+	code, resp = getHealth(t, mc, "?probe=liveness")
+	if code != http.StatusOK || resp.Status != healthDown {
+		t.Errorf("liveness: status code %d, status %q", code, resp.Status)
+	}
+	if resp.Logins[0].PingMS != nil || len(fake.Calls()) != pings {
+		t.Error("liveness probe pinged Mattermost")
+	}
+}
+
+func TestHandleHealth_PingFailure(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.FailEndpoints["/system/ping"] = true
+	mc := newTestBridgeConnector()
+	trackHealthClient(mc, "login", fake.Server.URL, connStateConnected)
+
+	code, resp := getHealth(t, mc, "")
+	if code != http.StatusOK || resp.Status != healthDegraded {
+		t.Errorf("status code %d, status %q", code, resp.Status)
+	}
+	if login := resp.Logins[0]; login.PingError == "" || login.PingMS != nil {
+		t.Errorf("login = %+v", login)
+	}
+}
+
+func TestHandleHealth_PuppetsAndQueues(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Puppets["@a:example.com"] = &PuppetClient{MXID: "@a:example.com"}
+	bad := &PuppetClient{MXID: "@b:example.com"}
+	bad.markUnhealthy(PuppetReasonAuthFailed, "401")
+	mc.Puppets["@b:example.com"] = bad
+	mc.eventQueue = newEventQueue(&mockEventSender{}, 4, false, zerolog.Nop())
+	login := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+	mc.eventQueue.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+
+	_, resp := getHealth(t, mc, "")
+	if resp.Puppets != 2 || resp.PuppetTokens != (PuppetTokenHealth{Valid: 1, Invalid: 1}) {
+		t.Errorf("puppets = %d, tokens = %+v", resp.Puppets, resp.PuppetTokens)
+	}
+	if resp.Queues.Events != 1 || resp.Queues.EventCapacity != 4 {
+		t.Errorf("queues = %+v", resp.Queues)
+	}
+	if resp.Status != healthDegraded {
+		t.Errorf("status = %q, want degraded for an invalid puppet token", resp.Status)
+	}
+}
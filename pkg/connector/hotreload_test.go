@@ -135,6 +135,52 @@ func TestReloadPuppetsFromEntries_AddsPuppets(t *testing.T) {
 	}
 }
 
+func TestReloadPuppetsFromEntries_PerEntryURL(t *testing.T) {
+	defaultMM := fakeMattermostAPI(map[string]struct{ id, username string }{})
+	defer defaultMM.Close()
+	otherMM := fakeMattermostAPI(map[string]struct{ id, username string }{
+		"tok-carol": {"uid-carol", "puppet-carol"},
+	})
+	defer otherMM.Close()
+	movedMM := fakeMattermostAPI(map[string]struct{ id, username string }{
+		"tok-carol": {"uid-carol", "puppet-carol"},
+	})
+	defer movedMM.Close()
+
+	mc := newTestBridgeConnector()
+	mc.Config.ServerURL = defaultMM.URL
+
+	added, _ := mc.ReloadPuppetsFromEntries(context.Background(), []PuppetEntry{
+		{Slug: "CAROL", MXID: "@puppet-carol:example.com", Token: "tok-carol", URL: otherMM.URL + "/"},
+	})
+	if added != 1 {
+		t.Fatalf("expected 1 added on the entry's server, got %d", added)
+	}
+	puppet := mc.Puppets[id.UserID("@puppet-carol:example.com")]
+	if puppet.Client.URL != otherMM.URL {
+		t.Errorf("puppet client URL = %q, want %q", puppet.Client.URL, otherMM.URL)
+	}
+
+	// Same token, same server: kept as-is.
+	added, _ = mc.ReloadPuppetsFromEntries(context.Background(), []PuppetEntry{
+		{Slug: "CAROL", MXID: "@puppet-carol:example.com", Token: "tok-carol", URL: otherMM.URL},
+	})
+	if added != 0 {
+		t.Errorf("expected unchanged puppet to be kept, got %d added", added)
+	}
+
+	// Same token, another server: reloaded.
+	added, _ = mc.ReloadPuppetsFromEntries(context.Background(), []PuppetEntry{
+		{Slug: "CAROL", MXID: "@puppet-carol:example.com", Token: "tok-carol", URL: movedMM.URL},
+	})
+	if added != 1 {
+		t.Errorf("expected puppet moved to another server to be reloaded, got %d added", added)
+	}
+	if got := mc.Puppets[id.UserID("@puppet-carol:example.com")].Client.URL; got != movedMM.URL {
+		t.Errorf("puppet client URL = %q, want %q", got, movedMM.URL)
+	}
+}
+
 func TestReloadPuppetsFromEntries_RemovesPuppets(t *testing.T) {
 	mm := fakeMattermostAPI(map[string]struct{ id, username string }{
 		"tok-alice": {"uid-alice", "puppet-alice"},
@@ -250,6 +296,34 @@ func TestHandleReloadPuppets_WithBody(t *testing.T) {
 	}
 }
 
+func TestHandleReloadPuppets_InvalidURL(t *testing.T) {
+	for _, rawURL := range []string{"ftp://mattermost.example.com", "mattermost.example.com", "https://", "http://%zz"} {
+		t.Run(rawURL, func(t *testing.T) {
+			mc := newTestBridgeConnector()
+			body, _ := json.Marshal([]PuppetEntry{
+				{Slug: "BAD", MXID: "@puppet-bad:example.com", Token: "secret-tok-bad", URL: rawURL},
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/reload-puppets", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			mc.HandleReloadPuppets(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), "BAD") {
+				t.Errorf("error should name the puppet: %s", w.Body.String())
+			}
+			if strings.Contains(w.Body.String(), "secret-tok-bad") {
+				t.Error("error must not contain the token")
+			}
+			if mc.PuppetCount() != 0 {
+				t.Error("no puppet should be loaded from a rejected request")
+			}
+		})
+	}
+}
+
 func TestHandleReloadPuppets_MethodNotAllowed(t *testing.T) {
 	mc := newTestBridgeConnector()
 
@@ -398,6 +472,7 @@ func TestPuppetEntry_JSON(t *testing.T) {
 		Slug:  "ALICE",
 		MXID:  "@puppet-alice:example.com",
 		Token: "secret-token",
+		URL:   "https://mm2.example.com",
 	}
 
 	data, err := json.Marshal(entry)
@@ -419,6 +494,9 @@ func TestPuppetEntry_JSON(t *testing.T) {
 	if decoded.Token != "secret-token" {
 		t.Errorf("Token: got %q", decoded.Token)
 	}
+	if decoded.URL != "https://mm2.example.com" {
+		t.Errorf("URL: got %q", decoded.URL)
+	}
 }
 
 // ---------------------------------------------------------------------------
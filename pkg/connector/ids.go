@@ -7,8 +7,10 @@ package connector
 
 import (
 	"strconv"
+	"strings"
 
 	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/id"
 )
 
 // MakePortalID creates a networkid.PortalID from a Mattermost channel ID.
@@ -16,11 +18,55 @@ func MakePortalID(channelID string) networkid.PortalID {
 	return networkid.PortalID(channelID)
 }
 
-// ParsePortalID extracts the Mattermost channel ID from a PortalID.
+// ParsePortalID extracts the Mattermost channel ID from a PortalID. For the
+// portal of a thread room, it's the channel of the thread.
 func ParsePortalID(portalID networkid.PortalID) string {
+	if channelID, _, ok := ParseThreadPortalID(portalID); ok {
+		return channelID
+	}
 	return string(portalID)
 }
 
+// teamPortalPrefix marks the portal IDs of team spaces. Channel IDs never
+// contain a colon.
+const teamPortalPrefix = "team:"
+
+// MakeTeamPortalID creates the networkid.PortalID of a Mattermost team's
+// space.
+func MakeTeamPortalID(teamID string) networkid.PortalID {
+	return networkid.PortalID(teamPortalPrefix + teamID)
+}
+
+// ParseTeamPortalID extracts the Mattermost team ID from the PortalID of a
+// team space. ok is false for channel portals.
+func ParseTeamPortalID(portalID networkid.PortalID) (teamID string, ok bool) {
+	return strings.CutPrefix(string(portalID), teamPortalPrefix)
+}
+
+// threadPortalPrefix marks the portal IDs of thread rooms, followed by the
+// channel ID and the thread's root post ID.
+const threadPortalPrefix = "thread:"
+
+// MakeThreadPortalID creates the networkid.PortalID of the room a Mattermost
+// thread is split into.
+func MakeThreadPortalID(channelID, rootID string) networkid.PortalID {
+	return networkid.PortalID(threadPortalPrefix + channelID + ":" + rootID)
+}
+
+// ParseThreadPortalID extracts the Mattermost channel ID and root post ID
+// from the PortalID of a thread room. ok is false for other portals.
+func ParseThreadPortalID(portalID networkid.PortalID) (channelID, rootID string, ok bool) {
+	rest, ok := strings.CutPrefix(string(portalID), threadPortalPrefix)
+	if !ok {
+		return "", "", false
+	}
+	channelID, rootID, ok = strings.Cut(rest, ":")
+	if !ok || channelID == "" || rootID == "" {
+		return "", "", false
+	}
+	return channelID, rootID, true
+}
+
 // MakeUserID creates a networkid.UserID from a Mattermost user ID.
 func MakeUserID(userID string) networkid.UserID {
 	return networkid.UserID(userID)
@@ -41,6 +87,22 @@ func ParseMessageID(messageID networkid.MessageID) string {
 	return string(messageID)
 }
 
+// pollVotePrefix marks the message IDs of Matrix poll votes sent as
+// Matterpoll votes, which have no post of their own. Post IDs never contain
+// a colon.
+const pollVotePrefix = "vote:"
+
+// MakePollVoteID creates the networkid.MessageID of a Matrix poll vote on a
+// Mattermost post.
+func MakePollVoteID(postID string, eventID id.EventID) networkid.MessageID {
+	return networkid.MessageID(pollVotePrefix + postID + ":" + string(eventID))
+}
+
+// IsPollVoteID reports whether a MessageID is that of a poll vote.
+func IsPollVoteID(messageID networkid.MessageID) bool {
+	return strings.HasPrefix(string(messageID), pollVotePrefix)
+}
+
 // MakeMessagePartID creates a networkid.PartID for message parts (e.g., file attachments).
 func MakeMessagePartID(index int) networkid.PartID {
 	if index == 0 {
@@ -49,6 +111,20 @@ func MakeMessagePartID(index int) networkid.PartID {
 	return networkid.PartID(strconv.Itoa(index))
 }
 
+// ParseMessagePartID returns the index of a message part: 0 for the post's
+// text, i for its i-th file. Reports false for part IDs the bridge doesn't
+// create.
+func ParseMessagePartID(partID networkid.PartID) (int, bool) {
+	if partID == "" {
+		return 0, true
+	}
+	index, err := strconv.Atoi(string(partID))
+	if err != nil || index < 1 || MakeMessagePartID(index) != partID {
+		return 0, false
+	}
+	return index, true
+}
+
 // MakeEmojiID creates a networkid.EmojiID from a Mattermost emoji name.
 func MakeEmojiID(emojiName string) networkid.EmojiID {
 	return networkid.EmojiID(emojiName)
@@ -65,3 +141,19 @@ func makePortalKey(channelID string) networkid.PortalKey {
 		ID: MakePortalID(channelID),
 	}
 }
+
+// makeTeamPortalKey creates the networkid.PortalKey of a Mattermost team's
+// space.
+func makeTeamPortalKey(teamID string) networkid.PortalKey {
+	return networkid.PortalKey{
+		ID: MakeTeamPortalID(teamID),
+	}
+}
+
+// makeThreadPortalKey creates the networkid.PortalKey of a Mattermost
+// thread's room.
+func makeThreadPortalKey(channelID, rootID string) networkid.PortalKey {
+	return networkid.PortalKey{
+		ID: MakeThreadPortalID(channelID, rootID),
+	}
+}
@@ -36,6 +36,41 @@ func TestPortalIDRoundTrip(t *testing.T) {
 	}
 }
 
+func TestTeamPortalIDRoundTrip(t *testing.T) {
+	t.Parallel()
+	portalID := MakeTeamPortalID("team1")
+	if portalID != networkid.PortalID("team:team1") {
+		t.Errorf("MakeTeamPortalID: got %q, want %q", portalID, "team:team1")
+	}
+	got, ok := ParseTeamPortalID(portalID)
+	if !ok || got != "team1" {
+		t.Errorf("ParseTeamPortalID: got (%q, %v), want (team1, true)", got, ok)
+	}
+	if _, ok := ParseTeamPortalID(MakePortalID("ch1")); ok {
+		t.Error("channel portal ID should not parse as a team")
+	}
+}
+
+func TestThreadPortalIDRoundTrip(t *testing.T) {
+	t.Parallel()
+	portalID := MakeThreadPortalID("ch1", "root1")
+	if portalID != networkid.PortalID("thread:ch1:root1") {
+		t.Errorf("MakeThreadPortalID: got %q, want %q", portalID, "thread:ch1:root1")
+	}
+	channelID, rootID, ok := ParseThreadPortalID(portalID)
+	if !ok || channelID != "ch1" || rootID != "root1" {
+		t.Errorf("ParseThreadPortalID: got (%q, %q, %v), want (ch1, root1, true)", channelID, rootID, ok)
+	}
+	if got := ParsePortalID(portalID); got != "ch1" {
+		t.Errorf("ParsePortalID: got %q, want the thread's channel", got)
+	}
+	for _, other := range []networkid.PortalID{MakePortalID("ch1"), MakeTeamPortalID("team1"), "thread:ch1"} {
+		if _, _, ok := ParseThreadPortalID(other); ok {
+			t.Errorf("%q should not parse as a thread", other)
+		}
+	}
+}
+
 func TestMakeUserID(t *testing.T) {
 	t.Parallel()
 	id := MakeUserID("user42")
@@ -185,3 +220,18 @@ func TestUserLoginIDRoundTrip(t *testing.T) {
 		t.Errorf("UserLoginID round trip: got %q, want %q", got, original)
 	}
 }
+
+func TestParseMessagePartID(t *testing.T) {
+	t.Parallel()
+	for index := 0; index <= 12; index++ {
+		got, ok := ParseMessagePartID(MakeMessagePartID(index))
+		if !ok || got != index {
+			t.Errorf("ParseMessagePartID(MakeMessagePartID(%d)) = %d, %v", index, got, ok)
+		}
+	}
+	for _, partID := range []networkid.PartID{"0", "-1", "01", "file", "1.5"} {
+		if _, ok := ParseMessagePartID(partID); ok {
+			t.Errorf("ParseMessagePartID(%q) should fail", partID)
+		}
+	}
+}
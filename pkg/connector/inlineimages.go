@@ -0,0 +1,91 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/id"
+)
+
+// maxPostFiles is how many files Mattermost attaches to a post. Inline
+// images beyond it keep only their alt text.
+const maxPostFiles = 10
+
+// inlineImageResolver returns the resolver that reuploads the inline images
+// of a Matrix message to the portal's channel with client, which must be
+// the client that creates the post. The uploaded files are appended to
+// fileIDs, to be attached to the post: Mattermost only serves files attached
+// to a post to the channel's members. An image used twice is uploaded once.
+func (m *MattermostClient) inlineImageResolver(ctx context.Context, client *model.Client4, portal *bridgev2.Portal, fileIDs *model.StringArray) matrixfmt.ImageResolver {
+	if m.serverURL == "" || portal == nil || portal.Bridge == nil || portal.Bridge.Bot == nil {
+		return nil
+	}
+	channelID := ParsePortalID(portal.ID)
+	uploaded := make(map[id.ContentURIString]string)
+	return func(uri id.ContentURIString) (string, bool) {
+		fileID, ok := uploaded[uri]
+		if !ok {
+			if len(*fileIDs) >= maxPostFiles {
+				return "", false
+			}
+			var err error
+			fileID, err = m.uploadInlineImage(ctx, client, portal, channelID, uri)
+			if err != nil {
+				m.log.Warn().Err(err).
+					Str("channel_id", channelID).
+					Str("mxc", string(uri)).
+					Msg("Failed to reupload inline image, sending its alt text")
+				return "", false
+			}
+			uploaded[uri] = fileID
+			*fileIDs = append(*fileIDs, fileID)
+		}
+		return strings.TrimRight(m.serverURL, "/") + "/api/v4/files/" + fileID, true
+	}
+}
+
+// uploadInlineImage streams an inline Matrix image to the channel through a
+// temporary file and returns its Mattermost file ID. Inline images carry no
+// file info, so the MIME type is sniffed from the content.
+func (m *MattermostClient) uploadInlineImage(ctx context.Context, client *model.Client4, portal *bridgev2.Portal, channelID string, uri id.ContentURIString) (string, error) {
+	var fileInfo *model.FileInfo
+	err := portal.Bridge.Bot.DownloadMediaToFile(ctx, uri, nil, false, func(f *os.File) error {
+		stat, err := f.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to stat downloaded image: %w", err)
+		}
+		head := make([]byte, 512)
+		n, err := io.ReadFull(f, head)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("failed to read downloaded image: %w", err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind downloaded image: %w", err)
+		}
+		mimeType := http.DetectContentType(head[:n])
+		filename := "image"
+		if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
+			filename += exts[0]
+		}
+		fileInfo, err = uploadFileStream(ctx, client, channelID, filename, mimeType, f, stat.Size())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fileInfo.Id, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestHandleMatrixMessage_InlineImages(t *testing.T) {
+	t.Parallel()
+	const cat = id.ContentURIString("mxc://example.com/cat")
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	fm.TokenToUser["test-token"] = "my-user-id"
+	mc := newFullTestClient(fm.Server.URL)
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal: makeTestPortalWithBot("test-channel", map[id.ContentURIString][]byte{cat: []byte("\x89PNG\r\n\x1a\nfake image data")}),
+			Content: &event.MessageEventContent{
+				MsgType: event.MsgText,
+				Body:    "cat cat dog",
+				Format:  event.FormatHTML,
+				FormattedBody: `<img src="mxc://example.com/cat" alt="cat"> <img src="mxc://example.com/cat" alt="again"> ` +
+					`<img src="mxc://example.com/dog" alt="dog"> <img data-mx-emoticon src="mxc://example.com/cat" alt=":cat:">`,
+			},
+		},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The image used twice is uploaded once; the missing one and the custom
+	// emoji aren't uploaded.
+	if len(fm.Uploads) != 1 {
+		t.Fatalf("expected 1 upload, got %d", len(fm.Uploads))
+	}
+	if up := fm.Uploads[0]; up.Name != "image.png" || up.MimeType != "image/png" || up.ChannelId != "test-channel" {
+		t.Errorf("upload: name %q mime %q channel %q", up.Name, up.MimeType, up.ChannelId)
+	}
+	post := lastCreatedPost(t, fm)
+	if len(post.FileIds) != 1 || post.FileIds[0] != "uploaded-file-id" {
+		t.Errorf("FileIds = %v, want the uploaded image", post.FileIds)
+	}
+	url := fm.Server.URL + "/api/v4/files/uploaded-file-id"
+	if want := "![cat](" + url + ") ![again](" + url + ") dog :cat:"; post.Message != want {
+		t.Errorf("Message = %q, want %q", post.Message, want)
+	}
+}
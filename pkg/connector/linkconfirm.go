@@ -0,0 +1,265 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/commands"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// Where double puppet confirmation codes are sent.
+const (
+	LinkDeliverMatrix     = "matrix"
+	LinkDeliverMattermost = "mattermost"
+)
+
+const (
+	// defaultLinkExpiry is how long a confirmation code is valid when
+	// double_puppet_confirmation.expiry_minutes is 0.
+	defaultLinkExpiry = 15 * time.Minute
+	// maxLinkAttempts is how many wrong codes cancel a pending link.
+	maxLinkAttempts = 5
+	// linkCodeDigits is the length of confirmation codes.
+	linkCodeDigits = 8
+)
+
+var (
+	errNoPendingLink = errors.New("no account link is waiting for confirmation")
+	errLinkExpired   = errors.New("the confirmation code has expired")
+	errWrongLinkCode = errors.New("wrong confirmation code")
+)
+
+// DoublePuppetConfirmationConfig makes POST /api/double-puppet wait for the
+// Matrix user to approve the link with a one-time code, so operators can't
+// silently make Mattermost messages appear as sent by someone else.
+type DoublePuppetConfirmationConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Deliver is where the code is sent: "matrix" (default) sends it to the
+	// Matrix user's management room, "mattermost" as a Mattermost DM from
+	// the bridge's login to the Mattermost user. Either way, the Matrix user
+	// confirms with the confirm-link command.
+	Deliver string `yaml:"deliver"`
+	// ExpiryMinutes is how long a code is valid. 0 uses 15.
+	ExpiryMinutes int `yaml:"expiry_minutes"`
+}
+
+// expiry returns how long a confirmation code is valid.
+func (c *DoublePuppetConfirmationConfig) expiry() time.Duration {
+	if c.ExpiryMinutes <= 0 {
+		return defaultLinkExpiry
+	}
+	return time.Duration(c.ExpiryMinutes) * time.Minute
+}
+
+// validate checks the delivery target.
+func (c *DoublePuppetConfirmationConfig) validate() error {
+	switch c.Deliver {
+	case "", LinkDeliverMatrix, LinkDeliverMattermost:
+		return nil
+	default:
+		return fmt.Errorf("invalid double_puppet_confirmation.deliver %q", c.Deliver)
+	}
+}
+
+// pendingLink is a double puppet link waiting for its Matrix user to confirm.
+type pendingLink struct {
+	mmUserID string
+	code     string
+	expires  time.Time
+	attempts int
+}
+
+// newLinkCode returns a random numeric confirmation code.
+func newLinkCode() (string, error) {
+	var sb strings.Builder
+	for range linkCodeDigits {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate confirmation code: %w", err)
+		}
+		sb.WriteByte(byte('0' + n.Int64()))
+	}
+	return sb.String(), nil
+}
+
+// requestLinkConfirmation sends a confirmation code for linking a Mattermost
+// user to a Matrix user, and keeps the link pending until the Matrix user
+// confirms it. A new request replaces the pending one of the same Matrix
+// user. The code is never logged or returned.
+func (mc *MattermostConnector) requestLinkConfirmation(ctx context.Context, mmUserID string, mxid id.UserID) (time.Time, error) {
+	cfg := &mc.Config.DoublePuppetConfirmation
+	code, err := newLinkCode()
+	if err != nil {
+		return time.Time{}, err
+	}
+	expires := time.Now().Add(cfg.expiry())
+	minutes := int(cfg.expiry().Minutes())
+
+	if cfg.Deliver == LinkDeliverMattermost {
+		err = mc.sendLinkCodeToMattermost(ctx, mmUserID, fmt.Sprintf(
+			"A bridge operator asked to link your Mattermost account to the Matrix account %s. "+
+				"Your Mattermost messages would then appear in Matrix as sent by that account.\n\n"+
+				"To approve, send `confirm-link %s` as %s in a direct chat with the bridge bot (%s) within %d minutes. "+
+				"If you didn't expect this, ignore this message.",
+			mxid, code, mxid, mc.Bridge.Bot.GetMXID(), minutes))
+	} else {
+		err = mc.sendLinkCodeToMatrix(ctx, mxid, fmt.Sprintf(
+			"A bridge operator asked to link your Matrix account to the Mattermost account %s. "+
+				"Messages that account sends on Mattermost would then appear as sent by you.\n\n"+
+				"To approve, send `confirm-link %s` here within %d minutes. "+
+				"If you didn't expect this, ignore this message.",
+			mc.linkAccountName(ctx, mmUserID), code, minutes))
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	mc.pendingLinksMu.Lock()
+	defer mc.pendingLinksMu.Unlock()
+	if mc.pendingLinks == nil {
+		mc.pendingLinks = make(map[id.UserID]*pendingLink)
+	}
+	now := time.Now()
+	for pendingMXID, link := range mc.pendingLinks {
+		if now.After(link.expires) {
+			delete(mc.pendingLinks, pendingMXID)
+		}
+	}
+	mc.pendingLinks[mxid] = &pendingLink{mmUserID: mmUserID, code: code, expires: expires}
+	return expires, nil
+}
+
+// linkAccountName returns "@username (ID)" for a Mattermost user, or just
+// the ID when no login can look the user up.
+func (mc *MattermostConnector) linkAccountName(ctx context.Context, mmUserID string) string {
+	client := mc.primaryClient.Load()
+	if client == nil {
+		return mmUserID
+	}
+	user, _, err := client.GetUser(ctx, mmUserID, "")
+	if err != nil {
+		mc.ctxLog(ctx).Debug().Err(err).Str("mm_user_id", mmUserID).Msg("Failed to look up user for link confirmation")
+		return mmUserID
+	}
+	return fmt.Sprintf("@%s (%s)", user.Username, mmUserID)
+}
+
+// sendLinkCodeToMatrix sends a confirmation message to the Matrix user's
+// management room as a notice from the bridge bot.
+func (mc *MattermostConnector) sendLinkCodeToMatrix(ctx context.Context, mxid id.UserID, text string) error {
+	user, err := mc.Bridge.GetUserByMXID(ctx, mxid)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := mc.sendManagementNotice(ctx, user, text); err != nil {
+		return fmt.Errorf("failed to send confirmation code: %w", err)
+	}
+	return nil
+}
+
+// sendManagementNotice sends text, rendered as Markdown, to the user's
+// management room as a notice from the bridge bot.
+func (mc *MattermostConnector) sendManagementNotice(ctx context.Context, user *bridgev2.User, text string) error {
+	roomID, err := user.GetManagementRoom(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get management room: %w", err)
+	}
+	parsed := mattermostfmtParse(text)
+	content := &event.MessageEventContent{
+		MsgType:       event.MsgNotice,
+		Body:          parsed.Body,
+		Format:        parsed.Format,
+		FormattedBody: parsed.FormattedBody,
+	}
+	_, err = mc.Bridge.Bot.SendMessage(ctx, roomID, event.EventMessage, &event.Content{Parsed: content}, nil)
+	return err
+}
+
+// sendLinkCodeToMattermost sends a confirmation message to the Mattermost
+// user as a DM from the bridge's primary login. The login's own posts aren't
+// bridged, so the code doesn't show up in Matrix.
+func (mc *MattermostConnector) sendLinkCodeToMattermost(ctx context.Context, mmUserID, text string) error {
+	client := mc.primaryClient.Load()
+	if client == nil {
+		return fmt.Errorf("no Mattermost login to send the confirmation code from")
+	}
+	me, _, err := client.GetMe(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to get bridge user: %w", err)
+	}
+	channel, _, err := client.CreateDirectChannel(ctx, me.Id, mmUserID)
+	if err != nil {
+		return fmt.Errorf("failed to create direct channel: %w", err)
+	}
+	if _, _, err := client.CreatePost(ctx, &model.Post{ChannelId: channel.Id, Message: text}); err != nil {
+		return fmt.Errorf("failed to send confirmation code: %w", err)
+	}
+	return nil
+}
+
+// confirmLink checks a confirmation code sent by a Matrix user and returns
+// the Mattermost user of their pending link. Wrong codes count against
+// maxLinkAttempts; the link is dropped once used, expired or out of attempts.
+func (mc *MattermostConnector) confirmLink(mxid id.UserID, code string) (string, error) {
+	mc.pendingLinksMu.Lock()
+	defer mc.pendingLinksMu.Unlock()
+	link, ok := mc.pendingLinks[mxid]
+	if !ok {
+		return "", errNoPendingLink
+	}
+	if time.Now().After(link.expires) {
+		delete(mc.pendingLinks, mxid)
+		return "", errLinkExpired
+	}
+	if subtle.ConstantTimeCompare([]byte(code), []byte(link.code)) != 1 {
+		link.attempts++
+		if link.attempts >= maxLinkAttempts {
+			delete(mc.pendingLinks, mxid)
+		}
+		return "", errWrongLinkCode
+	}
+	delete(mc.pendingLinks, mxid)
+	return link.mmUserID, nil
+}
+
+func (mc *MattermostConnector) fnConfirmLink(ce *commands.Event) {
+	if len(ce.Args) != 1 {
+		ce.Reply("Usage: `confirm-link <code>`")
+		return
+	}
+	mmUserID, err := mc.confirmLink(ce.User.MXID, strings.TrimSpace(ce.Args[0]))
+	if err != nil {
+		ce.Log.Info().Err(err).Msg("Account link not confirmed")
+		switch {
+		case errors.Is(err, errWrongLinkCode):
+			ce.Reply("Wrong confirmation code.")
+		case errors.Is(err, errLinkExpired):
+			ce.Reply("The confirmation code has expired. Ask the bridge operator to request the link again.")
+		default:
+			ce.Reply("No account link is waiting for your confirmation.")
+		}
+		return
+	}
+	if err := mc.setupUserDoublePuppet(ce.Ctx, mmUserID, string(ce.User.MXID)); err != nil {
+		ce.Log.Err(err).Str("mm_user_id", mmUserID).Msg("Double puppet setup failed after confirmation")
+		ce.Reply("Failed to link your account: %v", err)
+		return
+	}
+	ce.Log.Info().Str("mm_user_id", mmUserID).Msg("Account link confirmed")
+	ce.Reply("Linked. Messages of Mattermost user `%s` now appear as sent by you.", mmUserID)
+}
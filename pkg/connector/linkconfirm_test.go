@@ -0,0 +1,287 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/commands"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/id"
+)
+
+// linkMatrixConnector is a nopMatrixConnector whose double puppeting always
+// succeeds.
+type linkMatrixConnector struct {
+	nopMatrixConnector
+}
+
+func (linkMatrixConnector) NewUserIntent(_ context.Context, userID id.UserID, token string) (bridgev2.MatrixAPI, string, error) {
+	return ghostIntent{mxid: userID}, token, nil
+}
+
+func (linkMatrixConnector) ParseGhostMXID(id.UserID) (networkid.UserID, bool) {
+	return "", false
+}
+
+var linkCodePattern = regexp.MustCompile(`confirm-link (\d+)`)
+
+// newLinkTestConnector returns a connector with confirmation enabled whose
+// bridge bot records its messages. @alice:example.com has a management room.
+func newLinkTestConnector(t *testing.T) (*MattermostConnector, *fakeReplyBot) {
+	t.Helper()
+	mc := newRelayTestConnector(t, nil)
+	mc.Bridge.Matrix = linkMatrixConnector{}
+	bot := &fakeReplyBot{}
+	mc.Bridge.Bot = bot
+	mc.dpLogins = make(map[string]networkid.UserLoginID)
+	mc.Config.DoublePuppetConfirmation.Enabled = true
+	if err := mc.Bridge.DB.User.Insert(context.Background(), &database.User{
+		MXID:           "@alice:example.com",
+		ManagementRoom: "!mgmt:example.com",
+	}); err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	return mc, bot
+}
+
+func postDoublePuppet(mc *MattermostConnector, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/double-puppet", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mc.HandleDoublePuppet(w, req)
+	return w
+}
+
+// confirmLinkCommand runs confirm-link as a Matrix user and returns the reply.
+func confirmLinkCommand(t *testing.T, mc *MattermostConnector, mxid id.UserID, args ...string) string {
+	t.Helper()
+	user, err := mc.Bridge.GetUserByMXID(context.Background(), mxid)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	bot := &fakeReplyBot{}
+	log := zerolog.Nop()
+	mc.fnConfirmLink(&commands.Event{Bot: bot, Bridge: mc.Bridge, User: user, Args: args, Ctx: context.Background(), Log: &log})
+	return bot.lastReply()
+}
+
+func TestDoublePuppetConfirmation_Matrix(t *testing.T) {
+	t.Parallel()
+	mc, bot := newLinkTestConnector(t)
+	mmUserID := model.NewId()
+
+	w := postDoublePuppet(mc, `{"mm_user_id":"`+mmUserID+`","matrix_mxid":"@alice:example.com"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp["status"] != "pending" || resp["expires_at"] == "" {
+		t.Errorf("response = %v, want pending with expiry", resp)
+	}
+	if _, ok := mc.DoublePuppetLoginID(mmUserID); ok {
+		t.Fatal("link registered before confirmation")
+	}
+
+	match := linkCodePattern.FindStringSubmatch(bot.lastReply())
+	if match == nil || len(match[1]) != linkCodeDigits {
+		t.Fatalf("notice %q has no confirmation code", bot.lastReply())
+	}
+	if strings.Contains(w.Body.String(), match[1]) {
+		t.Error("response must not contain the confirmation code")
+	}
+
+	if reply := confirmLinkCommand(t, mc, "@bob:example.com", match[1]); !strings.Contains(reply, "No account link") {
+		t.Errorf("other user's reply = %q", reply)
+	}
+	if reply := confirmLinkCommand(t, mc, "@alice:example.com", match[1]); !strings.Contains(reply, "Linked") {
+		t.Fatalf("reply = %q, want linked", reply)
+	}
+	if _, ok := mc.DoublePuppetLoginID(mmUserID); !ok {
+		t.Error("link not registered after confirmation")
+	}
+	if reply := confirmLinkCommand(t, mc, "@alice:example.com", match[1]); !strings.Contains(reply, "No account link") {
+		t.Errorf("reused code reply = %q", reply)
+	}
+}
+
+func TestDoublePuppetConfirmation_Mattermost(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Users["bridge-id"] = &model.User{Id: "bridge-id", Username: "bridge"}
+	fake.TokenToUser["bridge-token"] = "bridge-id"
+	mc, bot := newLinkTestConnector(t)
+	mc.Config.DoublePuppetConfirmation.Deliver = LinkDeliverMattermost
+	client := model.NewAPIv4Client(fake.Server.URL)
+	client.SetToken("bridge-token")
+	mc.primaryClient.Store(client)
+	mmUserID := model.NewId()
+
+	w := postDoublePuppet(mc, `{"mm_user_id":"`+mmUserID+`","matrix_mxid":"@alice:example.com"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202: %s", w.Code, w.Body.String())
+	}
+	if len(bot.replies) != 0 {
+		t.Errorf("code also sent to Matrix: %v", bot.replies)
+	}
+	var post model.Post
+	for _, call := range fake.Calls() {
+		if call.Method == http.MethodPost && call.Path == "/api/v4/posts" {
+			_ = json.Unmarshal([]byte(call.Body), &post)
+		}
+	}
+	match := linkCodePattern.FindStringSubmatch(post.Message)
+	if match == nil {
+		t.Fatalf("DM %q has no confirmation code", post.Message)
+	}
+	if reply := confirmLinkCommand(t, mc, "@alice:example.com", match[1]); !strings.Contains(reply, "Linked") {
+		t.Errorf("reply = %q, want linked", reply)
+	}
+}
+
+func TestDoublePuppetConfirmation_Rejects(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		body       string
+		deliver    string
+		wantStatus int
+	}{
+		{"invalid mxid", `{"mm_user_id":"` + model.NewId() + `","matrix_mxid":"alice"}`, "", http.StatusBadRequest},
+		{"invalid user ID", `{"mm_user_id":"nope","matrix_mxid":"@alice:example.com"}`, "", http.StatusBadRequest},
+		{"no Mattermost login", `{"mm_user_id":"` + model.NewId() + `","matrix_mxid":"@alice:example.com"}`, LinkDeliverMattermost, http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, _ := newLinkTestConnector(t)
+			mc.Config.DoublePuppetConfirmation.Deliver = tt.deliver
+			if w := postDoublePuppet(mc, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if len(mc.pendingLinks) != 0 {
+				t.Errorf("pending links = %v, want none", mc.pendingLinks)
+			}
+		})
+	}
+}
+
+func TestConfirmLink(t *testing.T) {
+	t.Parallel()
+	const mxid = id.UserID("@alice:example.com")
+	tests := []struct {
+		name     string
+		link     *pendingLink
+		codes    []string
+		wantErr  error
+		wantKept bool
+	}{
+		{"correct", &pendingLink{mmUserID: "u1", code: "12345678", expires: time.Now().Add(time.Minute)}, []string{"12345678"}, nil, false},
+		{"no link", nil, []string{"12345678"}, errNoPendingLink, false},
+		{"expired", &pendingLink{mmUserID: "u1", code: "12345678", expires: time.Now().Add(-time.Second)}, []string{"12345678"}, errLinkExpired, false},
+		{"wrong code", &pendingLink{mmUserID: "u1", code: "12345678", expires: time.Now().Add(time.Minute)}, []string{"87654321"}, errWrongLinkCode, true},
+		{"out of attempts", &pendingLink{mmUserID: "u1", code: "12345678", expires: time.Now().Add(time.Minute)},
+			[]string{"0", "1", "2", "3", "4"}, errWrongLinkCode, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := &MattermostConnector{pendingLinks: make(map[id.UserID]*pendingLink)}
+			if tt.link != nil {
+				mc.pendingLinks[mxid] = tt.link
+			}
+			var mmUserID string
+			var err error
+			for _, code := range tt.codes {
+				mmUserID, err = mc.confirmLink(mxid, code)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && mmUserID != "u1" {
+				t.Errorf("user = %q, want u1", mmUserID)
+			}
+			if _, kept := mc.pendingLinks[mxid]; kept != tt.wantKept {
+				t.Errorf("link kept = %v, want %v", kept, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestNewLinkCode(t *testing.T) {
+	t.Parallel()
+	code, err := newLinkCode()
+	if err != nil {
+		t.Fatalf("newLinkCode: %v", err)
+	}
+	if !regexp.MustCompile(`^\d{8}$`).MatchString(code) {
+		t.Errorf("code = %q, want %d digits", code, linkCodeDigits)
+	}
+}
+
+func TestDoublePuppetConfirmationConfig_Validate(t *testing.T) {
+	t.Parallel()
+	for deliver, wantErr := range map[string]bool{"": false, LinkDeliverMatrix: false, LinkDeliverMattermost: false, "email": true} {
+		cfg := DoublePuppetConfirmationConfig{Deliver: deliver}
+		if err := cfg.validate(); (err != nil) != wantErr {
+			t.Errorf("deliver %q: err = %v, wantErr %v", deliver, err, wantErr)
+		}
+	}
+}
+
+func TestDoublePuppetConfirmation_ReloadedPuppet(t *testing.T) {
+	t.Parallel()
+	mm := fakeMattermostAPI(map[string]struct{ id, username string }{
+		"tok-alice": {"uid-alice", "alice-bot"},
+	})
+	t.Cleanup(mm.Close)
+	mc, bot := newLinkTestConnector(t)
+	mc.Config.ServerURL = mm.URL
+	mc.Puppets = make(map[id.UserID]*PuppetClient)
+
+	body := `[{"slug":"ALICE","mxid":"@alice:example.com","token":"tok-alice"}]`
+	w := httptest.NewRecorder()
+	mc.HandleReloadPuppets(w, httptest.NewRequest(http.MethodPost, "/api/reload-puppets", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	if !mc.hasPuppet("@alice:example.com") {
+		t.Fatal("puppet not loaded")
+	}
+	if _, ok := mc.DoublePuppetLoginID("uid-alice"); ok {
+		t.Fatal("double puppet set up before confirmation")
+	}
+	match := linkCodePattern.FindStringSubmatch(bot.lastReply())
+	if match == nil {
+		t.Fatalf("notice %q has no confirmation code", bot.lastReply())
+	}
+	if reply := confirmLinkCommand(t, mc, "@alice:example.com", match[1]); !strings.Contains(reply, "Linked") {
+		t.Fatalf("reply = %q, want linked", reply)
+	}
+	if _, ok := mc.DoublePuppetLoginID("uid-alice"); !ok {
+		t.Error("double puppet not set up after confirmation")
+	}
+
+	// Once confirmed, the link isn't asked for again.
+	if !mc.puppetLinkApproved(context.Background(), mc.Puppets["@alice:example.com"]) {
+		t.Error("confirmed link should be approved")
+	}
+}
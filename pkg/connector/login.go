@@ -7,7 +7,9 @@ package connector
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"maunium.net/go/mautrix/bridgev2"
@@ -17,7 +19,7 @@ import (
 
 // GetLoginFlows returns the available login methods for the bridge.
 func (mc *MattermostConnector) GetLoginFlows() []bridgev2.LoginFlow {
-	return []bridgev2.LoginFlow{
+	flows := []bridgev2.LoginFlow{
 		{
 			Name:        "Personal Access Token",
 			Description: "Log in with a Mattermost personal access token",
@@ -28,7 +30,20 @@ func (mc *MattermostConnector) GetLoginFlows() []bridgev2.LoginFlow {
 			Description: "Log in with username and password",
 			ID:          "password",
 		},
+		{
+			Name:        "Single Sign-On",
+			Description: "Log in through GitLab, SAML or another SSO provider in the browser",
+			ID:          "sso",
+		},
+	}
+	if mc.Config.OAuth2.enabled() {
+		flows = append(flows, bridgev2.LoginFlow{
+			Name:        "OAuth 2.0",
+			Description: "Authorize the bridge to access your account in the browser",
+			ID:          "oauth2",
+		})
 	}
+	return flows
 }
 
 // CreateLogin starts a new login process for the given flow.
@@ -44,6 +59,23 @@ func (mc *MattermostConnector) CreateLogin(_ context.Context, user *bridgev2.Use
 			connector: mc,
 			user:      user,
 		}, nil
+	case "sso":
+		return &SSOLoginProcess{
+			TokenLoginProcess: TokenLoginProcess{
+				connector: mc,
+				user:      user,
+			},
+		}, nil
+	case "oauth2":
+		if !mc.Config.OAuth2.enabled() {
+			return nil, errOAuth2Disabled
+		}
+		return &OAuth2LoginProcess{
+			TokenLoginProcess: TokenLoginProcess{
+				connector: mc,
+				user:      user,
+			},
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown login flow: %s", flowID)
 	}
@@ -95,12 +127,14 @@ func (t *TokenLoginProcess) SubmitUserInput(ctx context.Context, input map[strin
 	}
 
 	token := input["token"]
-	return t.finishLogin(ctx, t.serverURL, token)
+	return t.finishLogin(ctx, t.serverURL, token, nil)
 }
 
 func (t *TokenLoginProcess) Cancel() {}
 
-func (t *TokenLoginProcess) finishLogin(ctx context.Context, serverURL, token string) (*bridgev2.LoginStep, error) {
+// finishLogin verifies token and creates the login. grant is the OAuth 2.0
+// grant the token came from, if any, whose refresh token is saved with it.
+func (t *TokenLoginProcess) finishLogin(ctx context.Context, serverURL, token string, grant *oauth2Grant) (*bridgev2.LoginStep, error) {
 	result, err := validateTokenLogin(ctx, serverURL, token)
 	if err != nil {
 		return nil, err
@@ -123,6 +157,12 @@ func (t *TokenLoginProcess) finishLogin(ctx context.Context, serverURL, token st
 	meta.Token = token
 	meta.UserID = result.User.Id
 	meta.TeamID = result.TeamID
+	if grant != nil {
+		meta.RefreshToken = grant.RefreshToken
+		if !grant.ExpiresAt.IsZero() {
+			meta.TokenExpiresAt = grant.ExpiresAt.UnixMilli()
+		}
+	}
 	if err := ul.Save(ctx); err != nil {
 		return nil, fmt.Errorf("failed to save login: %w", err)
 	}
@@ -146,11 +186,20 @@ func (t *TokenLoginProcess) finishLogin(ctx context.Context, serverURL, token st
 	}, nil
 }
 
-// PasswordLoginProcess implements username/password login.
+// mfaRequiredErrorID is the error Mattermost returns for a password login
+// that lacks a valid MFA code.
+const mfaRequiredErrorID = "mfa.validate_token.authenticate.app_error"
+
+// PasswordLoginProcess implements username/password login, asking for an
+// MFA code when the account has MFA enabled.
 type PasswordLoginProcess struct {
 	connector *MattermostConnector
 	user      *bridgev2.User
 	serverURL string
+	// username and password are kept between the credentials and the MFA
+	// steps.
+	username string
+	password string
 }
 
 var _ bridgev2.LoginProcessUserInput = (*PasswordLoginProcess)(nil)
@@ -196,11 +245,36 @@ func (p *PasswordLoginProcess) SubmitUserInput(ctx context.Context, input map[st
 		}, nil
 	}
 
-	username := input["username"]
-	password := input["password"]
+	mfaCode, mfaStep := input["mfa_code"]
+	if !mfaStep {
+		p.username = input["username"]
+		p.password = input["password"]
+	}
 
 	client := model.NewAPIv4Client(p.serverURL)
-	user, _, err := client.Login(ctx, username, password)
+	user, _, err := client.LoginWithMFA(ctx, p.username, p.password, mfaCode)
+	var appErr *model.AppError
+	if errors.As(err, &appErr) && appErr.Id == mfaRequiredErrorID {
+		instructions := "Enter the code from your authenticator app"
+		if mfaStep {
+			instructions = "The code was not accepted. Enter a new code from your authenticator app"
+		}
+		return &bridgev2.LoginStep{
+			Type:         bridgev2.LoginStepTypeUserInput,
+			StepID:       "fi.mau.mattermost.login.mfa",
+			Instructions: instructions,
+			UserInputParams: &bridgev2.LoginUserInputParams{
+				Fields: []bridgev2.LoginInputDataField{
+					{
+						Type: bridgev2.LoginInputFieldType2FACode,
+						ID:   "mfa_code",
+						Name: "MFA Code",
+					},
+				},
+			},
+		}, nil
+	}
+	p.username, p.password = "", ""
 	if err != nil {
 		return nil, fmt.Errorf("login failed: %w", err)
 	}
@@ -209,7 +283,9 @@ func (p *PasswordLoginProcess) SubmitUserInput(ctx context.Context, input map[st
 	return p.finishLogin(ctx, p.serverURL, client.AuthToken, user)
 }
 
-func (p *PasswordLoginProcess) Cancel() {}
+func (p *PasswordLoginProcess) Cancel() {
+	p.username, p.password = "", ""
+}
 
 func (p *PasswordLoginProcess) finishLogin(ctx context.Context, serverURL, token string, me *model.User) (*bridgev2.LoginStep, error) {
 	client := model.NewAPIv4Client(serverURL)
@@ -259,6 +335,62 @@ func (p *PasswordLoginProcess) finishLogin(ctx context.Context, serverURL, token
 	}, nil
 }
 
+// ssoCookieName is the cookie holding the Mattermost session token after a
+// login in the browser.
+const ssoCookieName = "MMAUTHTOKEN"
+
+// SSOLoginProcess implements login through the Mattermost web login page,
+// for accounts that sign in with GitLab, SAML, OpenID or another SSO
+// provider. The session token is taken from the MMAUTHTOKEN cookie and used
+// like a personal access token.
+type SSOLoginProcess struct {
+	TokenLoginProcess
+}
+
+var (
+	_ bridgev2.LoginProcessUserInput = (*SSOLoginProcess)(nil)
+	_ bridgev2.LoginProcessCookies   = (*SSOLoginProcess)(nil)
+)
+
+func (s *SSOLoginProcess) SubmitUserInput(_ context.Context, input map[string]string) (*bridgev2.LoginStep, error) {
+	serverURL, err := parseServerURL(input["server_url"])
+	if err != nil {
+		return nil, err
+	}
+	parsed, _ := url.Parse(serverURL)
+	s.serverURL = serverURL
+	return &bridgev2.LoginStep{
+		Type:   bridgev2.LoginStepTypeCookies,
+		StepID: "fi.mau.mattermost.login.sso",
+		Instructions: fmt.Sprintf("Log in at %s/login with your SSO provider, then submit the %s cookie of %s",
+			serverURL, ssoCookieName, parsed.Hostname()),
+		CookiesParams: &bridgev2.LoginCookiesParams{
+			URL: serverURL + "/login",
+			Fields: []bridgev2.LoginCookieField{
+				{
+					ID:       "token",
+					Required: true,
+					Sources: []bridgev2.LoginCookieFieldSource{
+						{
+							Type:         bridgev2.LoginCookieTypeCookie,
+							Name:         ssoCookieName,
+							CookieDomain: parsed.Hostname(),
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *SSOLoginProcess) SubmitCookies(ctx context.Context, cookies map[string]string) (*bridgev2.LoginStep, error) {
+	token := cookies["token"]
+	if token == "" {
+		return nil, fmt.Errorf("missing %s cookie", ssoCookieName)
+	}
+	return s.finishLogin(ctx, s.serverURL, token, nil)
+}
+
 // getLoginMeta is a helper to extract metadata from a UserLogin.
 func getLoginMeta(login *bridgev2.UserLogin) *UserLoginMetadata {
 	return login.Metadata.(*UserLoginMetadata)
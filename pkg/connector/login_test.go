@@ -7,6 +7,7 @@ package connector
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -19,8 +20,8 @@ func TestGetLoginFlows(t *testing.T) {
 	mc := &MattermostConnector{}
 	flows := mc.GetLoginFlows()
 
-	if len(flows) != 2 {
-		t.Fatalf("GetLoginFlows: got %d flows, want 2", len(flows))
+	if len(flows) != 3 {
+		t.Fatalf("GetLoginFlows: got %d flows, want 3", len(flows))
 	}
 
 	if flows[0].ID != "token" {
@@ -29,6 +30,9 @@ func TestGetLoginFlows(t *testing.T) {
 	if flows[1].ID != "password" {
 		t.Errorf("flows[1].ID: got %q, want %q", flows[1].ID, "password")
 	}
+	if flows[2].ID != "sso" {
+		t.Errorf("flows[2].ID: got %q, want %q", flows[2].ID, "sso")
+	}
 
 	for i, flow := range flows {
 		if flow.Name == "" {
@@ -76,16 +80,34 @@ func TestCreateLogin_Password(t *testing.T) {
 	}
 }
 
-func TestCreateLogin_UnknownFlow(t *testing.T) {
+func TestCreateLogin_SSO(t *testing.T) {
 	mc := &MattermostConnector{}
 	ctx := context.Background()
 
 	proc, err := mc.CreateLogin(ctx, nil, "sso")
+	if err != nil {
+		t.Fatalf("CreateLogin(sso): unexpected error: %v", err)
+	}
+
+	sp, ok := proc.(*SSOLoginProcess)
+	if !ok {
+		t.Fatalf("CreateLogin(sso): got %T, want *SSOLoginProcess", proc)
+	}
+	if sp.connector != mc {
+		t.Error("SSOLoginProcess.connector should be the connector")
+	}
+}
+
+func TestCreateLogin_UnknownFlow(t *testing.T) {
+	mc := &MattermostConnector{}
+	ctx := context.Background()
+
+	proc, err := mc.CreateLogin(ctx, nil, "oauth")
 	if err == nil {
-		t.Fatal("CreateLogin(sso): expected error, got nil")
+		t.Fatal("CreateLogin(oauth): expected error, got nil")
 	}
 	if proc != nil {
-		t.Errorf("CreateLogin(sso): expected nil process, got %T", proc)
+		t.Errorf("CreateLogin(oauth): expected nil process, got %T", proc)
 	}
 }
 
@@ -438,18 +460,134 @@ func TestPasswordSubmitUserInput_Credentials(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	// Submit credentials step. The Mattermost client will call /api/v4/users/login
-	// which our fake doesn't handle, so it will return an error from the Login call.
+	// Submit credentials step. The fake server knows no accounts, so the
+	// Login call fails.
 	_, err := pp.SubmitUserInput(ctx, map[string]string{
 		"username": "alice",
 		"password": "secret",
 	})
-	// Login will fail because the fake server doesn't handle /api/v4/users/login.
 	if err == nil {
 		t.Fatal("expected login failure error, got nil")
 	}
 }
 
+func TestPasswordSubmitUserInput_MFA(t *testing.T) {
+	fake := newFakeMM()
+	defer fake.Close()
+
+	fake.Users["uid1"] = &model.User{Id: "uid1", Username: "alice"}
+	fake.Logins["alice"] = fakeLogin{UserID: "uid1", Password: "secret", MFACode: "123456", Token: "session-tok"}
+	fake.TokenToUser["session-tok"] = "uid1"
+	// Fail after authentication so the test stops before creating the login.
+	fake.FailEndpoints["/teams"] = true
+
+	pp := &PasswordLoginProcess{connector: &MattermostConnector{}, serverURL: fake.Server.URL}
+	ctx := context.Background()
+
+	step, err := pp.SubmitUserInput(ctx, map[string]string{"username": "alice", "password": "secret"})
+	if err != nil {
+		t.Fatalf("SubmitUserInput(credentials): unexpected error: %v", err)
+	}
+	if step.StepID != "fi.mau.mattermost.login.mfa" {
+		t.Fatalf("step.StepID: got %q, want %q", step.StepID, "fi.mau.mattermost.login.mfa")
+	}
+	if len(step.UserInputParams.Fields) != 1 || step.UserInputParams.Fields[0].Type != bridgev2.LoginInputFieldType2FACode {
+		t.Errorf("MFA fields: got %+v", step.UserInputParams.Fields)
+	}
+
+	step, err = pp.SubmitUserInput(ctx, map[string]string{"mfa_code": "000000"})
+	if err != nil {
+		t.Fatalf("SubmitUserInput(wrong code): unexpected error: %v", err)
+	}
+	if step.StepID != "fi.mau.mattermost.login.mfa" || !strings.Contains(step.Instructions, "not accepted") {
+		t.Errorf("wrong code should ask again, got %q: %q", step.StepID, step.Instructions)
+	}
+
+	_, err = pp.SubmitUserInput(ctx, map[string]string{"mfa_code": "123456"})
+	if err == nil || !strings.Contains(err.Error(), "failed to get teams") {
+		t.Fatalf("expected the login to pass MFA and fail on teams, got %v", err)
+	}
+	if pp.username != "" || pp.password != "" {
+		t.Error("credentials should be cleared after the login attempt")
+	}
+}
+
+func TestPasswordCancel_ClearsCredentials(t *testing.T) {
+	pp := &PasswordLoginProcess{username: "alice", password: "secret"}
+	pp.Cancel()
+	if pp.username != "" || pp.password != "" {
+		t.Error("Cancel should clear the stored credentials")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SSO login tests
+// ---------------------------------------------------------------------------
+
+func TestSSOSubmitUserInput(t *testing.T) {
+	sp := &SSOLoginProcess{}
+	ctx := context.Background()
+
+	step, err := sp.SubmitUserInput(ctx, map[string]string{"server_url": "https://mm.example.com/"})
+	if err != nil {
+		t.Fatalf("SubmitUserInput(server_url): unexpected error: %v", err)
+	}
+	if sp.serverURL != "https://mm.example.com" {
+		t.Errorf("serverURL: got %q, want %q", sp.serverURL, "https://mm.example.com")
+	}
+	if step.Type != bridgev2.LoginStepTypeCookies || step.CookiesParams == nil {
+		t.Fatalf("step: got %q with %+v, want a cookies step", step.Type, step.CookiesParams)
+	}
+	if step.CookiesParams.URL != "https://mm.example.com/login" {
+		t.Errorf("cookies URL: got %q", step.CookiesParams.URL)
+	}
+	fields := step.CookiesParams.Fields
+	if len(fields) != 1 || fields[0].ID != "token" || len(fields[0].Sources) != 1 {
+		t.Fatalf("cookie fields: got %+v", fields)
+	}
+	if src := fields[0].Sources[0]; src.Name != "MMAUTHTOKEN" || src.CookieDomain != "mm.example.com" {
+		t.Errorf("cookie source: got %+v", src)
+	}
+}
+
+func TestSSOSubmitUserInput_InvalidURL(t *testing.T) {
+	for _, serverURL := range []string{"", "mm.example.com", "ftp://mm.example.com", "https://"} {
+		sp := &SSOLoginProcess{}
+		if _, err := sp.SubmitUserInput(context.Background(), map[string]string{"server_url": serverURL}); err == nil {
+			t.Errorf("SubmitUserInput(%q): expected error, got nil", serverURL)
+		}
+	}
+}
+
+func TestSSOSubmitCookies(t *testing.T) {
+	fake := newFakeMM()
+	defer fake.Close()
+
+	fake.Users["uid1"] = &model.User{Id: "uid1", Username: "alice"}
+	fake.TokenToUser["session-tok"] = "uid1"
+	fake.FailEndpoints["/teams"] = true
+
+	tests := []struct {
+		name    string
+		cookies map[string]string
+		wantErr string
+	}{
+		{"missing cookie", map[string]string{}, "missing MMAUTHTOKEN cookie"},
+		{"invalid session", map[string]string{"token": "expired-tok"}, "authentication failed"},
+		// Fails after authentication, before creating the login.
+		{"valid session", map[string]string{"token": "session-tok"}, "failed to get teams"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := &SSOLoginProcess{TokenLoginProcess{connector: &MattermostConnector{}, serverURL: fake.Server.URL}}
+			_, err := sp.SubmitCookies(context.Background(), tt.cookies)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SubmitCookies: got %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestPasswordFinishLogin_TeamsFailed(t *testing.T) {
 	fake := newFakeMM()
 	defer fake.Close()
@@ -0,0 +1,50 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// testChannels resolves the town-square room by ID and alias.
+func testChannels(uri *id.MatrixURI) (string, bool) {
+	if uri.RoomID() == "!town:example.com" || uri.RoomAlias() == "#town:example.com" {
+		return "town-square", true
+	}
+	return "", false
+}
+
+func TestParseChannelLinks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"room id", `see <a href="https://matrix.to/#/!town:example.com?via=example.com">Town Square</a>`, "see ~town-square"},
+		{"alias", `see <a href="https://matrix.to/#/%23town:example.com">#town:example.com</a>`, "see ~town-square"},
+		{"matrix uri", `<a href="matrix:roomid/town:example.com">Town Square</a>`, "~town-square"},
+		{"event permalink", `<a href="https://matrix.to/#/!town:example.com/$event">message</a>`, "[message](https://matrix.to/#/!town:example.com/$event)"},
+		{"unknown room", `<a href="https://matrix.to/#/!other:example.com">Other</a>`, "[Other](https://matrix.to/#/!other:example.com)"},
+		{"user pill without mention resolver", `<a href="https://matrix.to/#/@alice:example.com">Alice</a>`, "[Alice](https://matrix.to/#/@alice:example.com)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content := &event.MessageEventContent{
+				MsgType:       event.MsgText,
+				Format:        event.FormatHTML,
+				FormattedBody: tt.input,
+			}
+			if got := ParseWithOptions(content, Options{Channels: testChannels}); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// codePlaceholder stands for the markdown of a code block, inline code or
+// spoiler until the other conversions are done, so they leave it alone.
+func codePlaceholder(idx int) string {
+	return "\x00CODE" + strconv.Itoa(idx) + "\x00"
+}
+
+// codeLanguage returns the language of a code block from its class
+// attribute, e.g. "go" for class="language-go hljs".
+func codeLanguage(class string) string {
+	for _, name := range strings.Fields(class) {
+		if lang, ok := strings.CutPrefix(name, "language-"); ok {
+			return lang
+		}
+	}
+	return ""
+}
+
+// longestRun returns the length of the longest run of c in s.
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// fencedCode returns a Mattermost fenced code block of the HTML-escaped code
+// of a <pre> element, with the language of its class. The fence is longer
+// than any backtick run in the code.
+func fencedCode(class, code string) string {
+	code = strings.TrimSuffix(html.UnescapeString(code), "\n")
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	return fence + codeLanguage(class) + "\n" + code + "\n" + fence
+}
+
+// inlineCode returns Mattermost inline code of the HTML-escaped code of a
+// <code> element.
+func inlineCode(code string) string {
+	code = html.UnescapeString(code)
+	fence := strings.Repeat("`", longestRun(code, '`')+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		return fence + " " + code + " " + fence
+	}
+	return fence + code + fence
+}
+
+// restoreCode replaces the code placeholders with their markdown. The last
+// ones are restored first, since a spoiler set aside after the code in it
+// holds the code's placeholders.
+func restoreCode(text string, code []string) string {
+	for i := len(code) - 1; i >= 0; i-- {
+		text = strings.Replace(text, codePlaceholder(i), code[i], 1)
+	}
+	return text
+}
@@ -0,0 +1,71 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+)
+
+func TestParseCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"language",
+			`<pre><code class="language-go">fmt.Println("hi")` + "\n</code></pre>",
+			"```go\nfmt.Println(\"hi\")\n```",
+		},
+		{
+			"language among other classes",
+			`<pre><code class="hljs language-python">print(1)</code></pre>`,
+			"```python\nprint(1)\n```",
+		},
+		{
+			"no language",
+			`<pre><code>make build</code></pre>`,
+			"```\nmake build\n```",
+		},
+		{
+			"escaped and formatting-like content",
+			`<pre><code class="language-html">&lt;strong&gt;x&lt;/strong&gt; **y** &amp; &lt;br&gt;` + "\n</code></pre>",
+			"```html\n<strong>x</strong> **y** & <br>\n```",
+		},
+		{
+			"multiple lines",
+			"<pre><code>a := 1\n\nb := 2\n</code></pre>",
+			"```\na := 1\n\nb := 2\n```",
+		},
+		{
+			"backticks in block",
+			"<pre><code>```nested```</code></pre>",
+			"````\n```nested```\n````",
+		},
+		{
+			"inline",
+			`run <code>a &lt; b</code> now`,
+			"run `a < b` now",
+		},
+		{
+			"inline with backtick",
+			"<code>x`y</code>",
+			"``x`y``",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Parse(&event.MessageEventContent{Body: "code", Format: event.FormatHTML, FormattedBody: tt.html})
+			if got != tt.want {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"maps"
+
+	"maunium.net/go/mautrix/event"
+)
+
+// features is how well ParseWithOptions converts each Matrix formatting
+// feature to Mattermost markdown. It's declared as the room capabilities of
+// portals, so it must change with the conversion: every fully supported
+// feature has a sample in the tests. Partially supported features are
+// converted only with the matching Options. Dropped features lose their
+// formatting but keep their text; features not listed are unsupported.
+var features = event.FormattingFeatureMap{
+	event.FmtBold:               event.CapLevelFullySupported,
+	event.FmtItalic:             event.CapLevelFullySupported,
+	event.FmtStrikethrough:      event.CapLevelFullySupported,
+	event.FmtInlineCode:         event.CapLevelFullySupported,
+	event.FmtCodeBlock:          event.CapLevelFullySupported,
+	event.FmtSyntaxHighlighting: event.CapLevelFullySupported,
+	event.FmtBlockquote:         event.CapLevelFullySupported,
+	event.FmtInlineLink:         event.CapLevelFullySupported,
+	event.FmtUserLink:           event.CapLevelFullySupported,
+	event.FmtRoomLink:           event.CapLevelFullySupported,
+	event.FmtEventLink:          event.CapLevelFullySupported,
+	event.FmtUnorderedList:      event.CapLevelFullySupported,
+	event.FmtOrderedList:        event.CapLevelFullySupported,
+	event.FmtHeaders:            event.CapLevelFullySupported,
+	event.FmtTable:              event.CapLevelFullySupported,
+
+	// Mattermost markdown has no spoilers, so Options.Spoilers hides them
+	// behind a link or in a plugin's syntax.
+	event.FmtSpoiler:       event.CapLevelPartialSupport,
+	event.FmtSpoilerReason: event.CapLevelPartialSupport,
+
+	// Mattermost markdown has no underline, colors or math, and custom
+	// emojis keep only their shortcode. Horizontal lines exist in
+	// Mattermost but aren't converted yet.
+	event.FmtCustomEmoji:         event.CapLevelDropped,
+	event.FmtUnderline:           event.CapLevelDropped,
+	event.FmtTextForegroundColor: event.CapLevelDropped,
+	event.FmtTextBackgroundColor: event.CapLevelDropped,
+	event.FmtMath:                event.CapLevelDropped,
+	event.FmtHorizontalLine:      event.CapLevelDropped,
+}
+
+// Features returns the Matrix formatting features the conversion supports,
+// for room capabilities.
+func Features() event.FormattingFeatureMap {
+	return maps.Clone(features)
+}
@@ -0,0 +1,83 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+)
+
+// featureSamples converts one sample of each fully supported feature.
+var featureSamples = map[event.FormattingFeature]struct {
+	html string
+	want string
+}{
+	event.FmtBold:               {"<strong>bold</strong>", "**bold**"},
+	event.FmtItalic:             {"<em>italic</em>", "_italic_"},
+	event.FmtStrikethrough:      {"<del>gone</del>", "~~gone~~"},
+	event.FmtInlineCode:         {"<code>x := 1</code>", "`x := 1`"},
+	event.FmtCodeBlock:          {"<pre><code>x := 1</code></pre>", "```\nx := 1\n```"},
+	event.FmtSyntaxHighlighting: {`<pre><code class="language-go">x := 1</code></pre>`, "```go\nx := 1\n```"},
+	event.FmtBlockquote:         {"<blockquote>quoted</blockquote>", "> quoted"},
+	event.FmtInlineLink:         {`<a href="https://example.com">site</a>`, "[site](https://example.com)"},
+	event.FmtUserLink:           {`<a href="https://matrix.to/#/@alice:example.com">Alice</a>`, "[Alice](https://matrix.to/#/@alice:example.com)"},
+	event.FmtRoomLink:           {`<a href="https://matrix.to/#/#town:example.com">town</a>`, "[town](https://matrix.to/#/#town:example.com)"},
+	event.FmtEventLink: {`<a href="https://matrix.to/#/!room:example.com/$event">msg</a>`,
+		"[msg](https://matrix.to/#/!room:example.com/$event)"},
+	event.FmtUnorderedList: {"<ul><li>one</li><li>two</li></ul>", "- one\n- two"},
+	event.FmtOrderedList:   {"<ol><li>one</li><li>two</li></ol>", "1. one\n2. two"},
+	event.FmtHeaders:       {"<h2>Title</h2>", "## Title"},
+	event.FmtTable: {"<table><tr><th>Name</th></tr><tr><td>alice</td></tr></table>",
+		"| Name |\n| --- |\n| alice |"},
+}
+
+func TestFeatures_FullySupportedAreConverted(t *testing.T) {
+	t.Parallel()
+	for feature, level := range Features() {
+		if level != event.CapLevelFullySupported {
+			continue
+		}
+		t.Run(string(feature), func(t *testing.T) {
+			t.Parallel()
+			sample, ok := featureSamples[feature]
+			if !ok {
+				t.Fatalf("fully supported feature %s has no sample", feature)
+			}
+			got := Parse(&event.MessageEventContent{Format: event.FormatHTML, FormattedBody: sample.html})
+			if got != sample.want {
+				t.Errorf("Parse(%q) = %q, want %q", sample.html, got, sample.want)
+			}
+		})
+	}
+}
+
+func TestFeatures_DroppedKeepText(t *testing.T) {
+	t.Parallel()
+	tests := map[event.FormattingFeature]string{
+		event.FmtUnderline:   "<u>secret</u>",
+		event.FmtCustomEmoji: `<img data-mx-emoticon src="mxc://example.com/party" alt="secret">`,
+	}
+	for feature, html := range tests {
+		t.Run(string(feature), func(t *testing.T) {
+			t.Parallel()
+			if level := Features()[feature]; level != event.CapLevelDropped {
+				t.Errorf("level = %d, want dropped", level)
+			}
+			if got := Parse(&event.MessageEventContent{Format: event.FormatHTML, FormattedBody: html}); got != "secret" {
+				t.Errorf("Parse(%q) = %q, want the text kept", html, got)
+			}
+		})
+	}
+}
+
+func TestFeatures_ReturnsCopy(t *testing.T) {
+	t.Parallel()
+	Features()[event.FmtBold] = event.CapLevelRejected
+	if Features()[event.FmtBold] != event.CapLevelFullySupported {
+		t.Error("changing the returned map changed the feature table")
+	}
+}
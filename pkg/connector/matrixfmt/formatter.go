@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
 )
 
 var (
@@ -18,7 +19,7 @@ var (
 	emRe         = regexp.MustCompile(`<em>(.*?)</em>`)
 	delRe        = regexp.MustCompile(`<del>(.*?)</del>`)
 	codeRe       = regexp.MustCompile(`<code>(.*?)</code>`)
-	preRe        = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	preRe        = regexp.MustCompile(`(?s)<pre><code(?: class="([^"]*)")?>(.*?)</code></pre>`)
 	linkRe       = regexp.MustCompile(`<a href="([^"]+)"[^>]*>(.*?)</a>`)
 	brRe         = regexp.MustCompile(`<br\s*/?>`)
 	blockquoteRe = regexp.MustCompile(`(?s)<blockquote>(.*?)</blockquote>`)
@@ -28,33 +29,103 @@ var (
 	liRe         = regexp.MustCompile(`<li>(.*?)</li>`)
 	pRe          = regexp.MustCompile(`(?s)<p>(.*?)</p>`)
 	tagRe        = regexp.MustCompile(`<[^>]+>`)
+	blankLinesRe = regexp.MustCompile(`\n{3,}`)
 )
 
+// MentionResolver returns the Mattermost username of a mentioned Matrix
+// user, or false if the user has no Mattermost account to mention.
+type MentionResolver func(userID id.UserID) (string, bool)
+
+// ChannelResolver returns the name of the Mattermost channel bridged to a
+// Matrix room, given the room's ID or alias URI, or false if the room isn't
+// a channel that can be referenced.
+type ChannelResolver func(uri *id.MatrixURI) (string, bool)
+
+// Options controls the optional parts of the conversion.
+type Options struct {
+	// Mentions resolves Matrix user pills to Mattermost @username mentions.
+	// Without it, pills are converted like any other link.
+	Mentions MentionResolver
+	// Channels resolves links to portal rooms to Mattermost ~channel
+	// references. Without it, room links are converted like any other link.
+	Channels ChannelResolver
+	// Permalinks resolves links to bridged events, bare or not, to
+	// Mattermost permalinks of their posts. Without it, event links are
+	// converted like any other link.
+	Permalinks PermalinkResolver
+	// Spoilers converts spoilers. Without it, spoilers keep their text
+	// unhidden.
+	Spoilers SpoilerConverter
+	// Images reuploads inline images to Mattermost. Without it, images are
+	// replaced with their alt text.
+	Images ImageResolver
+}
+
 // Parse converts Matrix message content to Mattermost markdown.
 func Parse(content *event.MessageEventContent) string {
+	return ParseWithOptions(content, Options{})
+}
+
+// ParseWithOptions converts Matrix message content to Mattermost markdown,
+// converting user pills to @username mentions with opts.Mentions, room links
+// with opts.Channels, event links with opts.Permalinks, spoilers with
+// opts.Spoilers and inline images with opts.Images.
+func ParseWithOptions(content *event.MessageEventContent, opts Options) string {
 	if content == nil {
 		return ""
 	}
 
 	// If no HTML format, return plain text body.
 	if content.Format != event.FormatHTML || content.FormattedBody == "" {
-		return content.Body
+		return replacePermalinks(content.Body, opts.Permalinks)
 	}
 
 	text := content.FormattedBody
 
-	// Code blocks first (preserve content inside).
-	text = preRe.ReplaceAllString(text, "```\n$1\n```")
-	text = codeRe.ReplaceAllString(text, "`$1`")
+	// Code blocks first, keeping the language. The code is set aside until
+	// the end, so the other conversions leave it alone.
+	var code []string
+	text = preRe.ReplaceAllStringFunc(text, func(match string) string {
+		parts := preRe.FindStringSubmatch(match)
+		code = append(code, fencedCode(parts[1], parts[2]))
+		return codePlaceholder(len(code) - 1)
+	})
+	text = codeRe.ReplaceAllStringFunc(text, func(match string) string {
+		code = append(code, inlineCode(codeRe.FindStringSubmatch(match)[1]))
+		return codePlaceholder(len(code) - 1)
+	})
+
+	// Inline images, before links so linked images stay linked.
+	text = imgRe.ReplaceAllStringFunc(text, func(tag string) string {
+		return convertImage(tag, opts.Images)
+	})
 
 	// Inline formatting.
 	text = strongRe.ReplaceAllString(text, "**$1**")
 	text = emRe.ReplaceAllString(text, "_${1}_")
 	text = delRe.ReplaceAllString(text, "~~$1~~")
 
-	// Links.
+	// User pills and room links, then other links.
+	if opts.Mentions != nil || opts.Channels != nil {
+		text = linkRe.ReplaceAllStringFunc(text, func(match string) string {
+			parts := linkRe.FindStringSubmatch(match)
+			if ref, ok := matrixReference(parts[1], opts); ok {
+				return ref
+			}
+			return match
+		})
+	}
 	text = linkRe.ReplaceAllString(text, "[$2]($1)")
 
+	// Spoilers, once their text is converted. The markdown is set aside
+	// like code, so a link revealing the spoiler isn't made a permalink.
+	if opts.Spoilers != nil {
+		text = spoilerRe.ReplaceAllStringFunc(text, func(match string) string {
+			code = append(code, convertSpoiler(match, opts.Spoilers))
+			return codePlaceholder(len(code) - 1)
+		})
+	}
+
 	// Headings.
 	text = headingRe.ReplaceAllStringFunc(text, func(match string) string {
 		parts := headingRe.FindStringSubmatch(match)
@@ -92,6 +163,9 @@ func Parse(content *event.MessageEventContent) string {
 		return strings.Join(result, "\n")
 	})
 
+	// Tables, once their cells' formatting is converted.
+	text = tableRe.ReplaceAllStringFunc(text, convertTable)
+
 	// Paragraphs.
 	text = pRe.ReplaceAllString(text, "$1\n\n")
 
@@ -102,7 +176,32 @@ func Parse(content *event.MessageEventContent) string {
 	text = tagRe.ReplaceAllString(text, "")
 
 	// Clean up extra whitespace.
+	text = blankLinesRe.ReplaceAllString(text, "\n\n")
 	text = strings.TrimSpace(text)
 
-	return text
+	return restoreCode(replacePermalinks(text, opts.Permalinks), code)
+}
+
+// matrixReference converts a Matrix user or room link to a Mattermost
+// @username mention or ~channel reference. Links to events are left alone.
+func matrixReference(href string, opts Options) (string, bool) {
+	uri, err := id.ParseMatrixURIOrMatrixToURL(href)
+	if err != nil || uri.Sigil2 != 0 {
+		return "", false
+	}
+	switch uri.Sigil1 {
+	case '@':
+		if opts.Mentions != nil {
+			if username, ok := opts.Mentions(uri.UserID()); ok {
+				return "@" + username, true
+			}
+		}
+	case '!', '#':
+		if opts.Channels != nil {
+			if name, ok := opts.Channels(uri); ok {
+				return "~" + name, true
+			}
+		}
+	}
+	return "", false
 }
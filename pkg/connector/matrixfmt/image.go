@@ -0,0 +1,53 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"regexp"
+	"strings"
+
+	"maunium.net/go/mautrix/id"
+)
+
+// ImageResolver reuploads an inline Matrix image to Mattermost, given its
+// mxc:// URI, and returns the URL of the uploaded file, or false if it
+// couldn't be reuploaded.
+type ImageResolver func(uri id.ContentURIString) (string, bool)
+
+var (
+	imgRe     = regexp.MustCompile(`<img\s[^>]*>`)
+	imgAttrRe = regexp.MustCompile(`([\w-]+)(?:="([^"]*)")?`)
+	// imageAltEscaper escapes the characters that would end the alt text of a
+	// markdown image.
+	imageAltEscaper = strings.NewReplacer(`[`, `\[`, `]`, `\]`)
+)
+
+// convertImage converts an <img> element to a Mattermost markdown image of
+// the file reuploaded with resolve. Custom emojis (data-mx-emoticon) become
+// their shortcode, and images that can't be reuploaded their alt text, so
+// nothing is lost when resolve is nil or fails.
+func convertImage(tag string, resolve ImageResolver) string {
+	attrs := make(map[string]string)
+	for _, attr := range imgAttrRe.FindAllStringSubmatch(tag, -1) {
+		attrs[attr[1]] = attr[2]
+	}
+	alt := strings.TrimSpace(attrs["alt"])
+	if alt == "" {
+		alt = strings.TrimSpace(attrs["title"])
+	}
+	if _, ok := attrs["data-mx-emoticon"]; ok {
+		return alt
+	}
+	src := id.ContentURIString(attrs["src"])
+	if resolve == nil || !strings.HasPrefix(string(src), "mxc://") {
+		return alt
+	}
+	url, ok := resolve(src)
+	if !ok {
+		return alt
+	}
+	return "![" + imageAltEscaper.Replace(alt) + "](" + url + ")"
+}
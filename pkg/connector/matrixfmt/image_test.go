@@ -0,0 +1,57 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// testImages reuploads mxc://example.com/cat and fails for other media.
+func testImages(uri id.ContentURIString) (string, bool) {
+	if uri == "mxc://example.com/cat" {
+		return "https://mm.example.com/api/v4/files/file1", true
+	}
+	return "", false
+}
+
+func TestParseImages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"image", `look <img src="mxc://example.com/cat" alt="a cat"> here`, "look ![a cat](https://mm.example.com/api/v4/files/file1) here"},
+		{"self-closing", `<img alt="cat" src="mxc://example.com/cat" />`, "![cat](https://mm.example.com/api/v4/files/file1)"},
+		{"title as alt", `<img src="mxc://example.com/cat" title="kitty">`, "![kitty](https://mm.example.com/api/v4/files/file1)"},
+		{"brackets in alt", `<img src="mxc://example.com/cat" alt="[cat]">`, `![\[cat\]](https://mm.example.com/api/v4/files/file1)`},
+		{"linked", `<a href="https://example.com"><img src="mxc://example.com/cat" alt="cat"></a>`,
+			"[![cat](https://mm.example.com/api/v4/files/file1)](https://example.com)"},
+		{"upload fails", `<img src="mxc://example.com/dog" alt="a dog">`, "a dog"},
+		{"not mxc", `<img src="https://example.com/cat.png" alt="cat">`, "cat"},
+		{"custom emoji", `party <img data-mx-emoticon src="mxc://example.com/cat" alt=":party:" height="32">`, "party :party:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content := &event.MessageEventContent{Format: event.FormatHTML, FormattedBody: tt.html}
+			if got := ParseWithOptions(content, Options{Images: testImages}); got != tt.want {
+				t.Errorf("ParseWithOptions(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImages_NoResolver(t *testing.T) {
+	t.Parallel()
+	content := &event.MessageEventContent{Format: event.FormatHTML, FormattedBody: `<img src="mxc://example.com/cat" alt="a cat">`}
+	if got := Parse(content); got != "a cat" {
+		t.Errorf("Parse() = %q, want the alt text", got)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// testMentions resolves the ghost of alice.
+func testMentions(userID id.UserID) (string, bool) {
+	if userID == "@mm_alice:example.com" {
+		return "alice", true
+	}
+	return "", false
+}
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"pill", `hi <a href="https://matrix.to/#/@mm_alice:example.com">Alice (MM)</a>!`, "hi @alice!"},
+		{"escaped pill", `<a href="https://matrix.to/#/%40mm_alice%3Aexample.com">Alice</a> hi`, "@alice hi"},
+		{"matrix uri", `<a href="matrix:u/mm_alice:example.com">Alice</a>`, "@alice"},
+		{"unknown user", `<a href="https://matrix.to/#/@carol:example.com">Carol</a>`, "[Carol](https://matrix.to/#/@carol:example.com)"},
+		{"room link", `<a href="https://matrix.to/#/#room:example.com">room</a>`, "[room](https://matrix.to/#/#room:example.com)"},
+		{"web link", `<a href="https://example.com">site</a>`, "[site](https://example.com)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content := &event.MessageEventContent{
+				MsgType:       event.MsgText,
+				Format:        event.FormatHTML,
+				FormattedBody: tt.input,
+			}
+			if got := ParseWithOptions(content, Options{Mentions: testMentions}); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMentions_NoResolver(t *testing.T) {
+	t.Parallel()
+	content := &event.MessageEventContent{
+		MsgType:       event.MsgText,
+		Format:        event.FormatHTML,
+		FormattedBody: `<a href="https://matrix.to/#/@mm_alice:example.com">Alice</a>`,
+	}
+	want := "[Alice](https://matrix.to/#/@mm_alice:example.com)"
+	if got := Parse(content); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"regexp"
+	"strings"
+
+	"maunium.net/go/mautrix/id"
+)
+
+// PermalinkResolver returns the Mattermost permalink of the post bridged to
+// a Matrix event, given the event's URI, or false if the event isn't a
+// bridged post.
+type PermalinkResolver func(uri *id.MatrixURI) (string, bool)
+
+var (
+	// matrixLinkRe matches matrix.to URLs and matrix: URIs in converted
+	// markdown, whether bare or the target of a link.
+	matrixLinkRe = regexp.MustCompile(`(?:https://matrix\.to/#/|matrix:)[^\s<>()\[\]"]+`)
+	// markdownCodeRe matches code blocks and inline code in converted
+	// markdown.
+	markdownCodeRe = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+)
+
+// replacePermalinks replaces links to events in markdown, outside code, with
+// the permalinks of the bridged posts. Links to other events, users and rooms
+// are left alone.
+func replacePermalinks(text string, resolve PermalinkResolver) string {
+	if resolve == nil || (!strings.Contains(text, "matrix.to/") && !strings.Contains(text, "matrix:")) {
+		return text
+	}
+	replace := func(segment string) string {
+		return matrixLinkRe.ReplaceAllStringFunc(segment, func(link string) string {
+			uri, err := id.ParseMatrixURIOrMatrixToURL(link)
+			if err != nil || uri.Sigil2 != '$' {
+				return link
+			}
+			if permalink, ok := resolve(uri); ok {
+				return permalink
+			}
+			return link
+		})
+	}
+	var sb strings.Builder
+	last := 0
+	for _, loc := range markdownCodeRe.FindAllStringIndex(text, -1) {
+		sb.WriteString(replace(text[last:loc[0]]))
+		sb.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(replace(text[last:]))
+	return sb.String()
+}
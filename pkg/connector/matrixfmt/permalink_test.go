@@ -0,0 +1,71 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+const testPermalink = "https://mm.example.com/eng/pl/abcdefghijklmnopqrstuvwxyz"
+
+// testPermalinks resolves the event $post to testPermalink.
+func testPermalinks(uri *id.MatrixURI) (string, bool) {
+	if uri.EventID() == "$post" {
+		return testPermalink, true
+	}
+	return "", false
+}
+
+func TestParsePermalinks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content event.MessageEventContent
+		want    string
+	}{
+		{
+			"plain body",
+			event.MessageEventContent{Body: "see https://matrix.to/#/!town:example.com/$post?via=example.com"},
+			"see " + testPermalink,
+		},
+		{
+			"matrix uri",
+			event.MessageEventContent{Body: "see matrix:roomid/town:example.com/e/post"},
+			"see " + testPermalink,
+		},
+		{
+			"html link",
+			event.MessageEventContent{Format: event.FormatHTML, FormattedBody: `<a href="https://matrix.to/#/!town:example.com/$post">this</a> <strong>x</strong>`},
+			"[this](" + testPermalink + ") **x**",
+		},
+		{
+			"unknown event",
+			event.MessageEventContent{Body: "see https://matrix.to/#/!town:example.com/$other"},
+			"see https://matrix.to/#/!town:example.com/$other",
+		},
+		{
+			"room link",
+			event.MessageEventContent{Body: "see https://matrix.to/#/!town:example.com"},
+			"see https://matrix.to/#/!town:example.com",
+		},
+		{
+			"code",
+			event.MessageEventContent{Format: event.FormatHTML, FormattedBody: `<code>https://matrix.to/#/!town:example.com/$post</code>`},
+			"`https://matrix.to/#/!town:example.com/$post`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ParseWithOptions(&tt.content, Options{Permalinks: testPermalinks}); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
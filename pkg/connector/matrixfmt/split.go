@@ -0,0 +1,162 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitMessage splits Mattermost markdown into parts of at most limit runes,
+// for messages longer than a post may be. Parts end at line breaks when
+// possible; longer lines are cut at a space. A fenced code block cut in two
+// is closed at the end of one part and opened again, with its language, at
+// the start of the next. Text within the limit is returned as is.
+func SplitMessage(text string, limit int) []string {
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+	s := &splitter{limit: limit}
+	for _, line := range strings.Split(text, "\n") {
+		s.addLine(line)
+	}
+	s.flush(true)
+	return s.parts
+}
+
+// splitter collects the lines of the part being built.
+type splitter struct {
+	limit int
+	parts []string
+	lines []string
+	// size is the length in runes of lines joined by line breaks.
+	size int
+	// fence is the opening line of the code block the last line is in, and
+	// marker the backticks or tildes that close it. Both are empty outside
+	// code blocks.
+	fence  string
+	marker string
+}
+
+// addLine adds a line to the current part, starting a new part first when
+// it doesn't fit.
+func (s *splitter) addLine(line string) {
+	n := utf8.RuneCountInString(line)
+	// A line opening a code block needs room to close it too, and a line
+	// closing one doesn't.
+	reserved := s.reserved()
+	if s.closesFence(line) {
+		reserved = 0
+	} else if marker := fenceMarker(strings.TrimLeft(line, " ")); s.marker == "" && marker != "" {
+		reserved = 1 + len(marker)
+	}
+	if len(s.lines) > 0 && s.size+1+n+reserved > s.limit {
+		s.flush(false)
+	}
+	for room := s.room(reserved); n > room; room = s.room(reserved) {
+		head, tail := cutLine(line, room)
+		s.append(head)
+		s.flush(false)
+		line, n = tail, utf8.RuneCountInString(tail)
+	}
+	s.append(line)
+	s.updateFence(line)
+}
+
+// append adds a line that fits to the current part.
+func (s *splitter) append(line string) {
+	if len(s.lines) > 0 {
+		s.size++
+	}
+	s.lines = append(s.lines, line)
+	s.size += utf8.RuneCountInString(line)
+}
+
+// room returns the number of runes a line added to the current part may
+// have, leaving reserved runes free. It's at least 1, so parts always make
+// progress.
+func (s *splitter) room(reserved int) int {
+	room := s.limit - s.size - reserved
+	if len(s.lines) > 0 {
+		room--
+	}
+	return max(room, 1)
+}
+
+// reserved returns the runes needed to close the current code block.
+func (s *splitter) reserved() int {
+	if s.marker == "" {
+		return 0
+	}
+	return 1 + len(s.marker)
+}
+
+// flush ends the current part. Unless it's the last one, an open code block
+// is closed and opened again in the next part.
+func (s *splitter) flush(last bool) {
+	if len(s.lines) == 0 {
+		return
+	}
+	part := strings.Join(s.lines, "\n")
+	if !last && s.marker != "" {
+		part += "\n" + s.marker
+	}
+	s.parts = append(s.parts, part)
+	s.lines, s.size = nil, 0
+	if !last && s.fence != "" {
+		s.append(s.fence)
+	}
+}
+
+// updateFence tracks whether line opens or closes a fenced code block.
+func (s *splitter) updateFence(line string) {
+	if s.marker == "" {
+		if marker := fenceMarker(strings.TrimLeft(line, " ")); marker != "" {
+			s.fence, s.marker = line, marker
+		}
+		return
+	}
+	if s.closesFence(line) {
+		s.fence, s.marker = "", ""
+	}
+}
+
+// closesFence reports whether line closes the current code block.
+func (s *splitter) closesFence(line string) bool {
+	if s.marker == "" {
+		return false
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	return strings.HasPrefix(trimmed, s.marker) && strings.TrimSpace(strings.TrimLeft(trimmed, s.marker[:1])) == ""
+}
+
+// fenceMarker returns the backticks or tildes opening a fenced code block
+// on a line, or "" if the line doesn't open one.
+func fenceMarker(line string) string {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return ""
+	}
+	n := len(line) - len(strings.TrimLeft(line, line[:1]))
+	if n < 3 {
+		return ""
+	}
+	return line[:n]
+}
+
+// cutLine cuts a line after at most n runes, after the last space if it's in
+// the second half.
+func cutLine(line string, n int) (head, tail string) {
+	runes := []rune(line)
+	cut := n
+	for i := n; i > n/2; i-- {
+		if unicode.IsSpace(runes[i-1]) {
+			cut = i
+			break
+		}
+	}
+	return string(runes[:cut]), string(runes[cut:])
+}
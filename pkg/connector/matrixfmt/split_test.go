@@ -0,0 +1,64 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{"fits", "hello\nworld", 11, []string{"hello\nworld"}},
+		{"no limit", "hello", 0, []string{"hello"}},
+		{"lines", "aaaa\nbbbb\ncccc", 9, []string{"aaaa\nbbbb", "cccc"}},
+		{"long line at space", "aaaa bbbb cccc", 10, []string{"aaaa bbbb ", "cccc"}},
+		{"long word", "aaaaaaaaaaaa", 5, []string{"aaaaa", "aaaaa", "aa"}},
+		{"multibyte", "ééé\nééé", 4, []string{"ééé", "ééé"}},
+		{
+			"code block",
+			"intro\n```go\nline1\nline2\nline3\n```\nafter",
+			24,
+			[]string{"intro\n```go\nline1\n```", "```go\nline2\nline3\n```", "after"},
+		},
+		{
+			"tilde fence",
+			"~~~~\naaaa\nbbbb\n~~~~",
+			15,
+			[]string{"~~~~\naaaa\n~~~~", "~~~~\nbbbb\n~~~~"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SplitMessage(tt.text, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitMessage(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitMessage_Limit(t *testing.T) {
+	t.Parallel()
+	text := strings.Repeat("word ", 200) + "\n```\n" + strings.Repeat("code line\n", 100) + "```\n" + strings.Repeat("x", 700)
+	for _, part := range SplitMessage(text, 100) {
+		if n := utf8.RuneCountInString(part); n > 100 {
+			t.Fatalf("part of %d runes: %q", n, part)
+		}
+		if strings.Count(part, "```")%2 != 0 {
+			t.Errorf("part with an unclosed code block: %q", part)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SpoilerConverter returns the markdown of a Matrix spoiler, given the
+// converted markdown of its text and its reason, which may be empty.
+type SpoilerConverter func(text, reason string) string
+
+var (
+	spoilerRe       = regexp.MustCompile(`(?s)<span([^>]*\sdata-mx-spoiler\b[^>]*)>(.*?)</span>`)
+	spoilerReasonRe = regexp.MustCompile(`data-mx-spoiler="([^"]*)"`)
+)
+
+// convertSpoiler converts a <span data-mx-spoiler> element, whose content is
+// already converted, with convert. Spoilers are inline, so line breaks and
+// leftover tags in their text are dropped.
+func convertSpoiler(match string, convert SpoilerConverter) string {
+	parts := spoilerRe.FindStringSubmatch(match)
+	var reason string
+	if m := spoilerReasonRe.FindStringSubmatch(parts[1]); m != nil {
+		reason = strings.TrimSpace(m[1])
+	}
+	text := tagRe.ReplaceAllString(brRe.ReplaceAllString(parts[2], " "), "")
+	return convert(strings.TrimSpace(text), reason)
+}
@@ -0,0 +1,56 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+)
+
+// testSpoilers renders spoilers as [reason|text], showing what the
+// converter was given.
+func testSpoilers(text, reason string) string {
+	return "[" + reason + "|" + text + "]"
+}
+
+func TestParseSpoilers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"no reason", `the killer is <span data-mx-spoiler>the butler</span>!`, "the killer is [|the butler]!"},
+		{"empty reason", `<span data-mx-spoiler="">x</span>`, "[|x]"},
+		{"reason", `<span data-mx-spoiler="movie plot">the butler</span>`, "[movie plot|the butler]"},
+		{"other attributes", `<span class="x" data-mx-spoiler="plot">y</span>`, "[plot|y]"},
+		{"formatted text", `<span data-mx-spoiler><strong>big</strong> reveal</span>`, "[|**big** reveal]"},
+		{"line break", `<span data-mx-spoiler>a<br>b</span>`, "[|a b]"},
+		{"code", `<span data-mx-spoiler><code>x</code></span>`, "[|`x`]"},
+		{"link kept from permalinks", `<span data-mx-spoiler>https://matrix.to/#/!town:example.com/$post</span>`,
+			"[|https://matrix.to/#/!town:example.com/$post]"},
+		{"not a spoiler", `<span data-mx-color="#ff0000">red</span>`, "red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content := &event.MessageEventContent{Format: event.FormatHTML, FormattedBody: tt.html}
+			got := ParseWithOptions(content, Options{Spoilers: testSpoilers, Permalinks: testPermalinks})
+			if got != tt.want {
+				t.Errorf("ParseWithOptions(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpoilers_NoConverter(t *testing.T) {
+	t.Parallel()
+	content := &event.MessageEventContent{Format: event.FormatHTML, FormattedBody: `<span data-mx-spoiler="plot">secret</span>`}
+	if got := Parse(content); got != "secret" {
+		t.Errorf("Parse() = %q, want the text kept", got)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	tableRe     = regexp.MustCompile(`(?s)<table[^>]*>(.*?)</table>`)
+	tableRowRe  = regexp.MustCompile(`(?s)<tr[^>]*>(.*?)</tr>`)
+	tableCellRe = regexp.MustCompile(`(?s)<t[hd]([^>]*)>(.*?)</t[hd]>`)
+	alignRe     = regexp.MustCompile(`(?:align="|text-align:\s*)(left|center|right)`)
+)
+
+// tableCell converts the already converted content of a table cell to the
+// text of a pipe table cell, which has to fit on one line.
+func tableCell(content string) string {
+	content = brRe.ReplaceAllString(content, " ")
+	content = tagRe.ReplaceAllString(content, "")
+	content = strings.Join(strings.Fields(content), " ")
+	return strings.ReplaceAll(content, "|", `\|`)
+}
+
+// convertTable converts an HTML table to a Mattermost pipe table. The first
+// row is the header, whether its cells are <th> or <td>, and the alignment
+// of its cells sets the columns' alignment. Rows are padded to the widest
+// row. Tables without rows are dropped.
+func convertTable(match string) string {
+	var rows [][]string
+	var align []string
+	columns := 0
+	for _, row := range tableRowRe.FindAllStringSubmatch(tableRe.FindStringSubmatch(match)[1], -1) {
+		var cells []string
+		for _, cell := range tableCellRe.FindAllStringSubmatch(row[1], -1) {
+			if len(rows) == 0 {
+				a := ""
+				if m := alignRe.FindStringSubmatch(cell[1]); m != nil {
+					a = m[1]
+				}
+				align = append(align, a)
+			}
+			cells = append(cells, tableCell(cell[2]))
+		}
+		rows = append(rows, cells)
+		columns = max(columns, len(cells))
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	line := func(cells []string) string {
+		padded := make([]string, columns)
+		copy(padded, cells)
+		return "| " + strings.Join(padded, " | ") + " |"
+	}
+	lines := []string{line(rows[0])}
+	delimiters := make([]string, columns)
+	for i := range delimiters {
+		a := ""
+		if i < len(align) {
+			a = align[i]
+		}
+		switch a {
+		case "left":
+			delimiters[i] = ":---"
+		case "center":
+			delimiters[i] = ":---:"
+		case "right":
+			delimiters[i] = "---:"
+		default:
+			delimiters[i] = "---"
+		}
+	}
+	lines = append(lines, "| "+strings.Join(delimiters, " | ")+" |")
+	for _, cells := range rows[1:] {
+		lines = append(lines, line(cells))
+	}
+	// Blank lines around the table keep it apart from the text around it.
+	return "\n\n" + strings.Join(lines, "\n") + "\n\n"
+}
@@ -0,0 +1,99 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrixfmt
+
+import (
+	"strings"
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+)
+
+func TestParseTable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"header and body",
+			"<table><thead><tr><th>Name</th><th>Role</th></tr></thead>" +
+				"<tbody><tr><td>alice</td><td><strong>admin</strong></td></tr><tr><td>bob</td><td>user</td></tr></tbody></table>",
+			"| Name | Role |\n| --- | --- |\n| alice | **admin** |\n| bob | user |",
+		},
+		{
+			"alignment",
+			`<table><tr><th align="left">a</th><th style="text-align: center">b</th><th align="right">c</th></tr><tr><td>1</td><td>2</td><td>3</td></tr></table>`,
+			"| a | b | c |\n| :--- | :---: | ---: |\n| 1 | 2 | 3 |",
+		},
+		{
+			"pipes, line breaks and short rows",
+			"<table><tr><th>cmd</th><th>note</th></tr><tr><td>a | b</td><td>line<br>two</td></tr><tr><td>only</td></tr></table>",
+			"| cmd | note |\n| --- | --- |\n| a \\| b | line two |\n| only |  |",
+		},
+		{
+			"around text",
+			"<p>Results:</p><table><tr><th>x</th></tr><tr><td>1</td></tr></table><p>Done</p>",
+			"Results:\n\n| x |\n| --- |\n| 1 |\n\nDone",
+		},
+		{
+			"empty",
+			"before<table></table>after",
+			"beforeafter",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Parse(&event.MessageEventContent{Body: "table", Format: event.FormatHTML, FormattedBody: tt.html})
+			if got != tt.want {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// FuzzConvertTable verifies that table conversion never panics and that
+// every line of a converted table has as many cell separators, so no cell
+// content breaks the table apart. This is a required fuzz test for a parsing
+// function.
+func FuzzConvertTable(f *testing.F) {
+	f.Add("<tr><th>a</th><th>b</th></tr><tr><td>1</td></tr>")
+	f.Add(`<tr><th align="right">a|b</th></tr><tr><td>x\|<br>y</td><td>z</td></tr>`)
+	f.Add("<tr><td>\n</td></tr><tr></tr>")
+	f.Add("<tr><th style=\"text-align: center\"></th></tr>")
+	f.Add("<tr><td><table><tr><td>nested</td></tr></table></td></tr>")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, rows string) {
+		got := convertTable("<table>" + rows + "</table>")
+		if got == "" {
+			return
+		}
+		lines := strings.Split(strings.TrimSpace(got), "\n")
+		if len(lines) < 2 {
+			t.Fatalf("table without delimiter row: %q", got)
+		}
+		want := separators(lines[0])
+		for _, line := range lines {
+			if n := separators(line); n != want || n < 2 {
+				t.Errorf("line %q has %d separators, want %d", line, n, want)
+			}
+		}
+	})
+}
+
+// separators counts the unescaped pipes in a pipe table line.
+func separators(line string) int {
+	n := 0
+	for i := range len(line) {
+		if line[i] == '|' && (i == 0 || line[i-1] != '\\') {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"maunium.net/go/mautrix/id"
+)
+
+// ChannelResolver returns the Matrix URI of the room bridging the Mattermost
+// channel with a name, or false if the channel has no room.
+type ChannelResolver func(name string) (*id.MatrixURI, bool)
+
+// channelRe matches ~channel references. Mattermost channel names contain
+// lowercase letters, digits, '-' and '_'. The leading group keeps URL paths,
+// link texts and strikethrough markers from matching.
+var channelRe = regexp.MustCompile(`(^|[^\w~/:.\-\[])~([a-zA-Z0-9][a-zA-Z0-9_\-]*)`)
+
+// replaceChannelLinks replaces the resolvable ~channel references in text,
+// outside inline code, with link placeholders. It returns the new text and
+// links with the room links appended.
+func replaceChannelLinks(text string, resolve ChannelResolver, links []string) (string, []string) {
+	if resolve == nil || !strings.Contains(text, "~") {
+		return text, links
+	}
+	text = replaceOutsideCode(text, func(segment string) string {
+		return channelRe.ReplaceAllStringFunc(segment, func(match string) string {
+			parts := channelRe.FindStringSubmatch(match)
+			prefix, name := parts[1], parts[2]
+			// Trailing '-' or '_' is punctuation unless a channel by
+			// that name exists.
+			trimmed := name
+			uri, ok := resolve(strings.ToLower(name))
+			if !ok {
+				trimmed = strings.TrimRight(name, "-_")
+				if trimmed == "" || trimmed == name {
+					return match
+				}
+				if uri, ok = resolve(strings.ToLower(trimmed)); !ok {
+					return match
+				}
+			}
+			links = append(links, `<a href="`+html.EscapeString(uri.MatrixToURL())+`">~`+html.EscapeString(trimmed)+`</a>`)
+			return prefix + linkPlaceholder(len(links)-1) + name[len(trimmed):]
+		})
+	})
+	return text, links
+}
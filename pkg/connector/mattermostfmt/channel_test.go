@@ -0,0 +1,77 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/id"
+)
+
+// testChannels resolves town-square and dev_ops to portal rooms.
+func testChannels(name string) (*id.MatrixURI, bool) {
+	switch name {
+	case "town-square":
+		return id.RoomID("!town:example.com").URI("example.com"), true
+	case "dev_ops":
+		return id.RoomID("!devops:example.com").URI(), true
+	}
+	return nil, false
+}
+
+const (
+	townLink   = `<a href="https://matrix.to/#/%21town:example.com?via=example.com">~town-square</a>`
+	devopsLink = `<a href="https://matrix.to/#/%21devops:example.com">~dev_ops</a>`
+)
+
+func TestParseChannelLinks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		input         string
+		wantFormatted string
+	}{
+		{"single", "see ~town-square", "see " + townLink},
+		{"start of message", "~town-square is busy", townLink + " is busy"},
+		{"underscore", "ask in ~dev_ops", "ask in " + devopsLink},
+		{"trailing punctuation", "moved to ~town-square-", "moved to " + townLink + "-"},
+		{"case insensitive", "see ~Town-Square", "see " + `<a href="https://matrix.to/#/%21town:example.com?via=example.com">~Town-Square</a>`},
+		{"with formatting", "**see** ~town-square", "<strong>see</strong> " + townLink},
+		{"inline code", "`~town-square` or ~town-square", "<code>~town-square</code> or " + townLink},
+		{"strikethrough", "~~town-square~~", "<del>town-square</del>"},
+		{"unknown channel", "see ~off-topic", ""},
+		{"home directory", "in ~/town-square", ""},
+		{"url path", "https://example.com/~town-square", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := ParseWithOptions(tt.input, Options{Channels: testChannels})
+			if result.Body != tt.input {
+				t.Errorf("Body: got %q, want %q", result.Body, tt.input)
+			}
+			if result.FormattedBody != tt.wantFormatted {
+				t.Errorf("FormattedBody: got %q, want %q", result.FormattedBody, tt.wantFormatted)
+			}
+		})
+	}
+}
+
+func TestParseChannelLinks_WithMentions(t *testing.T) {
+	t.Parallel()
+	result := ParseWithOptions("@alice see ~town-square", Options{Mentions: testMentions, Channels: testChannels})
+	want := alicePill + " see " + townLink
+	if result.FormattedBody != want {
+		t.Errorf("FormattedBody: got %q, want %q", result.FormattedBody, want)
+	}
+}
+
+func TestParseChannelLinks_NoResolver(t *testing.T) {
+	t.Parallel()
+	if result := Parse("see ~town-square"); result.FormattedBody != "" {
+		t.Errorf("FormattedBody: got %q, want none", result.FormattedBody)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"strings"
+)
+
+// indentedCodeLine strips the indentation of a line of an indented code
+// block: four spaces or a tab. It returns false for other lines.
+func indentedCodeLine(line string) (string, bool) {
+	if rest, ok := strings.CutPrefix(line, "    "); ok {
+		return rest, true
+	}
+	return strings.CutPrefix(line, "\t")
+}
+
+// replaceIndentedCode replaces the code blocks indented by four spaces or a
+// tab in text with the placeholders returned by add. Like in Mattermost, an
+// indented block must start the text or follow a blank line, so it doesn't
+// take over the continuation lines of a paragraph or list item. Blank lines
+// inside a block are kept; trailing ones are not.
+func replaceIndentedCode(text string, add func(content string) string) string {
+	if !strings.Contains(text, "    ") && !strings.Contains(text, "\t") {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		first, ok := indentedCodeLine(lines[i])
+		if !ok || strings.TrimSpace(first) == "" || (i > 0 && strings.TrimSpace(lines[i-1]) != "") {
+			result = append(result, lines[i])
+			continue
+		}
+		code := []string{first}
+		end := i
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "" {
+				code = append(code, "")
+				continue
+			}
+			line, ok := indentedCodeLine(lines[j])
+			if !ok {
+				break
+			}
+			code = append(code, line)
+			end = j
+		}
+		code = code[:end-i+1]
+		result = append(result, add(strings.Join(code, "\n")))
+		i = end
+	}
+	return strings.Join(result, "\n")
+}
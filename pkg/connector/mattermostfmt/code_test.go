@@ -0,0 +1,76 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCodeBlocks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			"fenced with language",
+			"```go\nfmt.Println(\"hi\")\n```",
+			`<pre><code class="language-go">fmt.Println(&#34;hi&#34;)` + "\n</code></pre>",
+		},
+		{
+			"language with symbols",
+			"```c++\nint x;\n```",
+			`<pre><code class="language-c++">int x;` + "\n</code></pre>",
+		},
+		{
+			"indented by spaces",
+			"    x := 1\n    y := 2",
+			"<pre><code>x := 1\ny := 2\n</code></pre>",
+		},
+		{
+			"indented by a tab",
+			"\tmake build",
+			"<pre><code>make build\n</code></pre>",
+		},
+		{
+			"indented after a paragraph",
+			"Run this:\n\n    make build\n\n    make test\n\nthen deploy",
+			"<p>Run this:</p><p><pre><code>make build\n\nmake test\n</code></pre></p><p>then deploy</p>",
+		},
+		{
+			"indented code is literal",
+			"    **not bold** @alice",
+			"<pre><code>**not bold** @alice\n</code></pre>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Parse(tt.text)
+			if got.FormattedBody != tt.want {
+				t.Errorf("FormattedBody = %q, want %q", got.FormattedBody, tt.want)
+			}
+			if got.Body != tt.text {
+				t.Errorf("Body = %q, want the original text", got.Body)
+			}
+		})
+	}
+}
+
+func TestParseIndentedContinuation(t *testing.T) {
+	t.Parallel()
+	// Indented lines continuing a paragraph or list item aren't code.
+	for _, text := range []string{
+		"first line\n    continued",
+		"- item\n    more of the item",
+	} {
+		if got := Parse(text); strings.Contains(got.FormattedBody, "<pre>") {
+			t.Errorf("Parse(%q) = %q, want no code block", text, got.FormattedBody)
+		}
+	}
+}
@@ -21,14 +21,18 @@ type ParsedMessage struct {
 	Format        event.Format
 	FormattedBody string
 	RelatesTo     *event.RelatesTo
+	// Mentions lists the users mentioned in the message, and whether the room
+	// is. It is only set when a MentionResolver is given or RoomMentions is
+	// enabled.
+	Mentions *event.Mentions
 }
 
 var (
 	boldRe       = regexp.MustCompile(`\*\*(.+?)\*\*`)
-	italicRe     = regexp.MustCompile(`(?:^|[^*])_(.+?)_(?:[^*]|$)`)
+	italicRe     = regexp.MustCompile(`(^|[^*])_(.+?)_([^*]|$)`)
 	strikeRe     = regexp.MustCompile(`~~(.+?)~~`)
 	codeRe       = regexp.MustCompile("`([^`]+)`")
-	codeBlockRe  = regexp.MustCompile("(?s)```(\\w+)?\\n?(.*?)```")
+	codeBlockRe  = regexp.MustCompile("(?s)```([\\w#+.-]+)?\\n?(.*?)```")
 	linkRe       = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
 	headingRe    = regexp.MustCompile(`(?m)^(#{1,6})\s+(.+)$`)
 	ulRe         = regexp.MustCompile(`(?m)^[-*]\s+(.+)$`)
@@ -42,10 +46,32 @@ type codeBlock struct {
 	content string
 }
 
-// Parse converts a Mattermost markdown message to Matrix event content.
+// Parse converts a Mattermost markdown message to Matrix event content,
+// rendering timestamps in UTC with DefaultTimeFormat.
 func Parse(text string) *ParsedMessage {
+	return ParseWithOptions(text, Options{})
+}
+
+// ParseWithOptions converts a Mattermost markdown message to Matrix event
+// content, rendering timestamps according to opts. With opts.Mentions,
+// @username mentions become Matrix pills, with opts.Channels, ~channel
+// references become links to the channel's room, and with opts.Permalinks,
+// permalinks to bridged posts become links to their events, in the body too.
+// With opts.RoomMentions, @channel, @all and @here become @room, in the body
+// too.
+func ParseWithOptions(text string, opts Options) *ParsedMessage {
+	var mentions *event.Mentions
+	if opts.Mentions != nil || opts.RoomMentions {
+		mentions = &event.Mentions{}
+	}
 	if text == "" {
-		return &ParsedMessage{}
+		return &ParsedMessage{Mentions: mentions}
+	}
+
+	text = ConvertTimestamps(text, opts)
+	text = replacePermalinks(text, opts.Permalinks)
+	if opts.RoomMentions {
+		text = replaceRoomMentions(text, mentions)
 	}
 
 	hasFormatting := boldRe.MatchString(text) ||
@@ -57,13 +83,10 @@ func Parse(text string) *ParsedMessage {
 		headingRe.MatchString(text) ||
 		blockquoteRe.MatchString(text) ||
 		ulRe.MatchString(text) ||
-		olRe.MatchString(text)
+		olRe.MatchString(text) ||
+		hasTable(text)
 
-	if !hasFormatting {
-		return &ParsedMessage{Body: text}
-	}
-
-	// Step 1: Extract code blocks into placeholders.
+	// Step 1: Extract fenced and indented code blocks into placeholders.
 	var codeBlocks []codeBlock
 	processed := codeBlockRe.ReplaceAllStringFunc(text, func(match string) string {
 		parts := codeBlockRe.FindStringSubmatch(match)
@@ -79,6 +102,20 @@ func Parse(text string) *ParsedMessage {
 		codeBlocks = append(codeBlocks, codeBlock{lang: lang, content: content})
 		return "\x00CODEBLOCK" + strconv.Itoa(idx) + "\x00"
 	})
+	processed = replaceIndentedCode(processed, func(content string) string {
+		idx := len(codeBlocks)
+		codeBlocks = append(codeBlocks, codeBlock{content: content + "\n"})
+		return "\x00CODEBLOCK" + strconv.Itoa(idx) + "\x00"
+	})
+
+	// Mentions and channel links outside code become placeholders for their
+	// links.
+	processed, links := replaceMentions(processed, userMentionResolver(opts), mentions, nil)
+	processed, links = replaceChannelLinks(processed, opts.Channels, links)
+
+	if !hasFormatting && len(codeBlocks) == 0 && len(links) == 0 {
+		return &ParsedMessage{Body: text, Mentions: mentions}
+	}
 
 	// Step 2: Process line-by-line for structural elements on raw text.
 	lines := strings.Split(processed, "\n")
@@ -96,7 +133,16 @@ func Parse(text string) *ParsedMessage {
 		listType = ""
 	}
 
-	for _, line := range lines {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		// Check table.
+		if t, n, ok := parseTable(lines, i); ok {
+			flushList()
+			result = append(result, t.html())
+			i += n - 1
+			continue
+		}
+
 		// Check blockquote.
 		if m := blockquoteRe.FindStringSubmatch(line); len(m) >= 2 {
 			flushList()
@@ -144,7 +190,9 @@ func Parse(text string) *ParsedMessage {
 	// Step 3: Inline formatting.
 	formatted = codeRe.ReplaceAllString(formatted, "<code>$1</code>")
 	formatted = boldRe.ReplaceAllString(formatted, "<strong>$1</strong>")
-	formatted = italicRe.ReplaceAllString(formatted, "<em>$1</em>")
+	// The characters around the underscores are kept, so neither the spaces
+	// around an italic nor the placeholders next to it are lost.
+	formatted = italicRe.ReplaceAllString(formatted, "$1<em>$2</em>$3")
 	formatted = strikeRe.ReplaceAllString(formatted, "<del>$1</del>")
 
 	// Links — only allow safe URL schemes.
@@ -162,7 +210,21 @@ func Parse(text string) *ParsedMessage {
 		return text
 	})
 
-	// Step 4: Restore code blocks with language hints.
+	formatted = restoreLinks(formatted, links)
+
+	// Step 4: Paragraphs (double newlines).
+	formatted = strings.ReplaceAll(formatted, "\n\n", "</p><p>")
+
+	// Step 5: Line breaks (remaining single newlines).
+	formatted = strings.ReplaceAll(formatted, "\n", "<br/>")
+
+	// Wrap in paragraph tags if we have paragraph breaks.
+	if strings.Contains(formatted, "</p><p>") {
+		formatted = "<p>" + formatted + "</p>"
+	}
+
+	// Step 6: Restore code blocks with language hints, after the line breaks
+	// so their newlines are kept.
 	for i, cb := range codeBlocks {
 		placeholder := "\x00CODEBLOCK" + strconv.Itoa(i) + "\x00"
 		escapedContent := html.EscapeString(cb.content)
@@ -175,20 +237,10 @@ func Parse(text string) *ParsedMessage {
 		formatted = strings.Replace(formatted, placeholder, replacement, 1)
 	}
 
-	// Step 5: Paragraphs (double newlines).
-	formatted = strings.ReplaceAll(formatted, "\n\n", "</p><p>")
-
-	// Step 6: Line breaks (remaining single newlines).
-	formatted = strings.ReplaceAll(formatted, "\n", "<br/>")
-
-	// Wrap in paragraph tags if we have paragraph breaks.
-	if strings.Contains(formatted, "</p><p>") {
-		formatted = "<p>" + formatted + "</p>"
-	}
-
 	return &ParsedMessage{
-		Body:          text,
+		Body:          alignTables(text),
 		Format:        event.FormatHTML,
 		FormattedBody: formatted,
+		Mentions:      mentions,
 	}
 }
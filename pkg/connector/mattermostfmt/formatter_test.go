@@ -329,14 +329,17 @@ func FuzzParse(f *testing.F) {
 	f.Add("hello\x00world\x01\x02")
 	f.Add(strings.Repeat("**bold**", 100))
 	f.Add("```\n" + strings.Repeat("x", 1000) + "\n```")
+	f.Add("meet at <t:1700000000:t> or <t:0>")
+	f.Add("a|b\n-|-\n1|2")
 
 	f.Fuzz(func(t *testing.T, input string) {
 		// Should never panic for any input.
 		result := Parse(input)
 
-		// Body must always equal the original input.
-		if result.Body != input && input != "" {
-			t.Errorf("Body should equal input for non-empty strings")
+		// Body equals the original input unless it has timestamp tokens or
+		// a table, which are rendered in the body too.
+		if !strings.Contains(input, "<t:") && !hasTable(input) && result.Body != input && input != "" {
+			t.Errorf("Body should equal input for non-empty strings without timestamps or tables")
 		}
 
 		// FormattedBody should not contain raw <script> tags.
@@ -0,0 +1,166 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// MentionResolver returns the Matrix user a Mattermost username refers to,
+// or false if the username isn't a user that can be mentioned.
+type MentionResolver func(username string) (id.UserID, bool)
+
+// mentionRe matches @username mentions. Mattermost usernames start with a
+// letter and contain letters, digits, '.', '-' and '_'. The leading group
+// keeps e-mail addresses, URL paths and link texts from matching.
+var mentionRe = regexp.MustCompile(`(^|[^\w@/:.\-\[])@([a-zA-Z][a-zA-Z0-9._\-]*)`)
+
+// groupMentionRe matches the @channel, @all and @here group mentions, and
+// @room, with the same leading group as mentionRe. Mattermost matches group
+// mentions ignoring case.
+var groupMentionRe = regexp.MustCompile(`(?i)(^|[^\w@/:.\-\[])@(channel|all|here|room)`)
+
+// isGroupMention reports whether a username is one of Mattermost's group
+// mentions, which no user can have.
+func isGroupMention(username string) bool {
+	switch strings.ToLower(username) {
+	case "channel", "all", "here":
+		return true
+	}
+	return false
+}
+
+// userMentionResolver returns the resolver for the @username mentions of a
+// message, which skips group mentions, and @room when it stands for them.
+func userMentionResolver(opts Options) MentionResolver {
+	if opts.Mentions == nil {
+		return nil
+	}
+	return func(username string) (id.UserID, bool) {
+		if isGroupMention(username) || (opts.RoomMentions && username == "room") {
+			return "", false
+		}
+		return opts.Mentions(username)
+	}
+}
+
+// ReplaceGroupMentions replaces the @channel, @all, @here and @room mentions
+// in Mattermost markdown, outside code, with what replace returns for their
+// lower-cased name without the @. A mention followed by a username
+// character, like @channels or @here_now, is left alone, but sentence
+// punctuation may follow it.
+func ReplaceGroupMentions(text string, replace func(name string) string) string {
+	if !strings.Contains(text, "@") {
+		return text
+	}
+	replaceSegment := func(segment string) string {
+		var sb strings.Builder
+		last := 0
+		for _, loc := range groupMentionRe.FindAllStringSubmatchIndex(segment, -1) {
+			if end := loc[1]; end < len(segment) && isMentionSuffix(segment[end]) {
+				continue
+			}
+			sb.WriteString(segment[last:loc[3]])
+			sb.WriteString(replace(strings.ToLower(segment[loc[4]:loc[5]])))
+			last = loc[1]
+		}
+		sb.WriteString(segment[last:])
+		return sb.String()
+	}
+	var sb strings.Builder
+	last := 0
+	for _, loc := range codeBlockRe.FindAllStringIndex(text, -1) {
+		sb.WriteString(replaceOutsideCode(text[last:loc[0]], replaceSegment))
+		sb.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(replaceOutsideCode(text[last:], replaceSegment))
+	return sb.String()
+}
+
+// isMentionSuffix reports whether c continues a mention into a longer word.
+func isMentionSuffix(c byte) bool {
+	return c == '_' || c == '-' || c == '@' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// replaceRoomMentions replaces the group mentions in text with @room and
+// sets mentions.Room if there were any. An @room typed in Mattermost doesn't
+// mention the room, since it doesn't notify the channel.
+func replaceRoomMentions(text string, mentions *event.Mentions) string {
+	return ReplaceGroupMentions(text, func(name string) string {
+		if name != "room" {
+			mentions.Room = true
+		}
+		return "@room"
+	})
+}
+
+// replaceMentions replaces the resolvable @username mentions in text, outside
+// inline code, with link placeholders. It returns the new text and links
+// with the pills appended, and adds the mentioned users to mentions.
+func replaceMentions(text string, resolve MentionResolver, mentions *event.Mentions, links []string) (string, []string) {
+	if resolve == nil || !strings.Contains(text, "@") {
+		return text, links
+	}
+	text = replaceOutsideCode(text, func(segment string) string {
+		return mentionRe.ReplaceAllStringFunc(segment, func(match string) string {
+			parts := mentionRe.FindStringSubmatch(match)
+			prefix, username := parts[1], parts[2]
+			// Sentence punctuation after a mention isn't part of the
+			// username, unless a user by that name exists.
+			trimmed := username
+			userID, ok := resolve(strings.ToLower(username))
+			if !ok {
+				trimmed = strings.TrimRight(username, ".-_")
+				if trimmed == "" || trimmed == username {
+					return match
+				}
+				if userID, ok = resolve(strings.ToLower(trimmed)); !ok {
+					return match
+				}
+			}
+			mentions.Add(userID)
+			links = append(links, `<a href="`+html.EscapeString(userID.URI().MatrixToURL())+`">@`+html.EscapeString(trimmed)+`</a>`)
+			return prefix + linkPlaceholder(len(links)-1) + username[len(trimmed):]
+		})
+	})
+	return text, links
+}
+
+// linkPlaceholder returns the placeholder standing in for the idx-th mention
+// pill or channel link until the formatting passes are done.
+func linkPlaceholder(idx int) string {
+	return "\x00LINK" + strconv.Itoa(idx) + "\x00"
+}
+
+// replaceOutsideCode applies replace to the parts of text outside inline
+// code, where mentions and channel links are literal text.
+func replaceOutsideCode(text string, replace func(string) string) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range codeRe.FindAllStringIndex(text, -1) {
+		sb.WriteString(replace(text[last:loc[0]]))
+		sb.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(replace(text[last:]))
+	return sb.String()
+}
+
+// restoreLinks replaces the link placeholders with their HTML.
+func restoreLinks(formatted string, links []string) string {
+	for i, link := range links {
+		formatted = strings.Replace(formatted, linkPlaceholder(i), link, 1)
+	}
+	return formatted
+}
@@ -0,0 +1,182 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"maunium.net/go/mautrix/id"
+)
+
+// testMentions resolves alice and bob.smith to Matrix users.
+func testMentions(username string) (id.UserID, bool) {
+	switch username {
+	case "alice":
+		return "@mm_alice:example.com", true
+	case "bob.smith":
+		return "@mm_bob:example.com", true
+	}
+	return "", false
+}
+
+const (
+	alicePill = `<a href="https://matrix.to/#/@mm_alice:example.com">@alice</a>`
+	bobPill   = `<a href="https://matrix.to/#/@mm_bob:example.com">@bob.smith</a>`
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		input         string
+		wantFormatted string
+		wantUsers     []id.UserID
+	}{
+		{"single", "hi @alice", "hi " + alicePill, []id.UserID{"@mm_alice:example.com"}},
+		{"start of message", "@alice hi", alicePill + " hi", []id.UserID{"@mm_alice:example.com"}},
+		{"dotted username", "ping @bob.smith", "ping " + bobPill, []id.UserID{"@mm_bob:example.com"}},
+		{"trailing period", "thanks @alice.", "thanks " + alicePill + ".", []id.UserID{"@mm_alice:example.com"}},
+		{"case insensitive", "hi @Alice", "hi " + `<a href="https://matrix.to/#/@mm_alice:example.com">@Alice</a>`, []id.UserID{"@mm_alice:example.com"}},
+		{"repeated", "@alice and @bob.smith, @alice", alicePill + " and " + bobPill + ", " + alicePill, []id.UserID{"@mm_alice:example.com", "@mm_bob:example.com"}},
+		{"with formatting", "**hi** @alice", "<strong>hi</strong> " + alicePill, []id.UserID{"@mm_alice:example.com"}},
+		{"bold mention", "**@alice**", "<strong>" + alicePill + "</strong>", []id.UserID{"@mm_alice:example.com"}},
+		{"underscores after mention", "@alice_ _hi", alicePill + "<em> </em>hi", []id.UserID{"@mm_alice:example.com"}},
+		{"inline code", "`@alice` and @alice", "<code>@alice</code> and " + alicePill, []id.UserID{"@mm_alice:example.com"}},
+		{"code block", "```@alice```", "<pre><code>@alice</code></pre>", nil},
+		{"unknown user", "hi @carol", "", nil},
+		{"e-mail address", "mail alice@alice.com", "", nil},
+		{"url path", "see https://example.com/@alice", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := ParseWithOptions(tt.input, Options{Mentions: testMentions})
+			if result.Body != tt.input {
+				t.Errorf("Body: got %q, want %q", result.Body, tt.input)
+			}
+			if result.FormattedBody != tt.wantFormatted {
+				t.Errorf("FormattedBody: got %q, want %q", result.FormattedBody, tt.wantFormatted)
+			}
+			if result.Mentions == nil {
+				t.Fatal("Mentions should be set with a resolver")
+			}
+			if !slices.Equal(result.Mentions.UserIDs, tt.wantUsers) {
+				t.Errorf("Mentions: got %v, want %v", result.Mentions.UserIDs, tt.wantUsers)
+			}
+		})
+	}
+}
+
+func TestParseMentions_NoResolver(t *testing.T) {
+	t.Parallel()
+	result := Parse("hi @alice")
+	if result.FormattedBody != "" {
+		t.Errorf("FormattedBody: got %q, want none", result.FormattedBody)
+	}
+	if result.Mentions != nil {
+		t.Errorf("Mentions: got %v, want nil", result.Mentions)
+	}
+}
+
+func TestParseRoomMentions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		wantBody string
+		wantRoom bool
+	}{
+		{"channel", "@channel deploy is done", "@room deploy is done", true},
+		{"all", "hi @all", "hi @room", true},
+		{"here", "lunch? @here", "lunch? @room", true},
+		{"upper case", "hi @Channel", "hi @room", true},
+		{"trailing punctuation", "look @here.", "look @room.", true},
+		{"longer word", "hi @channels and @here_now", "hi @channels and @here_now", false},
+		{"e-mail address", "mail all@here.com", "mail all@here.com", false},
+		{"inline code", "`@channel`", "`@channel`", false},
+		{"code block", "```\n@all\n```", "```\n@all\n```", false},
+		{"no mention", "hello", "hello", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := ParseWithOptions(tt.input, Options{RoomMentions: true})
+			if result.Body != tt.wantBody {
+				t.Errorf("Body: got %q, want %q", result.Body, tt.wantBody)
+			}
+			if result.Mentions == nil || result.Mentions.Room != tt.wantRoom {
+				t.Errorf("Mentions: got %+v, want room %v", result.Mentions, tt.wantRoom)
+			}
+		})
+	}
+}
+
+func TestParseRoomMentions_WithResolver(t *testing.T) {
+	t.Parallel()
+	var resolved []string
+	resolve := func(username string) (id.UserID, bool) {
+		resolved = append(resolved, username)
+		return testMentions(username)
+	}
+	result := ParseWithOptions("@all and @room, ask @alice", Options{Mentions: resolve, RoomMentions: true})
+	if want := "@room and @room, ask " + alicePill; result.FormattedBody != want {
+		t.Errorf("FormattedBody: got %q, want %q", result.FormattedBody, want)
+	}
+	if !result.Mentions.Room || !slices.Equal(result.Mentions.UserIDs, []id.UserID{"@mm_alice:example.com"}) {
+		t.Errorf("Mentions: got %+v", result.Mentions)
+	}
+	// @room and group mentions are never looked up as usernames.
+	if !slices.Equal(resolved, []string{"alice"}) {
+		t.Errorf("resolved %v, want only alice", resolved)
+	}
+	if result := ParseWithOptions("hi @channel", Options{}); result.Body != "hi @channel" || result.Mentions != nil {
+		t.Errorf("without RoomMentions: got %+v", result)
+	}
+}
+
+func TestReplaceGroupMentions(t *testing.T) {
+	t.Parallel()
+	var names []string
+	got := ReplaceGroupMentions("@Room, @here and `@all` or @channel.\n```\n@channel\n```", func(name string) string {
+		names = append(names, name)
+		return "@" + strings.ToUpper(name)
+	})
+	if want := "@ROOM, @HERE and `@all` or @CHANNEL.\n```\n@channel\n```"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if !slices.Equal(names, []string{"room", "here", "channel"}) {
+		t.Errorf("names: got %v", names)
+	}
+}
+
+// FuzzParseWithResolvers verifies that the link placeholders of resolved
+// mentions and channel references never survive into the formatted body,
+// whatever formatting they end up in, including links made from resolved
+// permalinks. This is a required fuzz test for a parsing function.
+func FuzzParseWithResolvers(f *testing.F) {
+	f.Add("hi @alice and @bob.smith.")
+	f.Add("**@alice** `@alice` ```\n@alice\n```")
+	f.Add("# @alice\n> @bob.smith\n- @alice")
+	f.Add("[@alice](https://example.com) @alice_")
+	f.Add("| @alice | @bob.smith |\n| --- | --- |\n| @alice | x |")
+	f.Add("~~@alice~~ _@alice_")
+	f.Add("0 @AliCe_ _")
+	f.Add("@alice in ~town-square and ~dev_ops, not ~nowhere")
+	f.Add("**~town-square** _~dev_ops_ `~town-square`")
+	f.Add("@alice see " + testPermalink + " and [this](" + testPermalink + ")")
+	f.Add("`" + testPermalink + "` ```\n" + testPermalink + "\n``` " + testPermalink + "/pl/")
+	f.Add(strings.Repeat("@alice ", 50))
+
+	f.Fuzz(func(t *testing.T, text string) {
+		opts := Options{Mentions: testMentions, Channels: testChannels, Permalinks: testPermalinks}
+		result := ParseWithOptions(text, opts)
+		if strings.Contains(result.FormattedBody, "\x00LINK") {
+			t.Errorf("link placeholder left in formatted body for %q: %q", text, result.FormattedBody)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"regexp"
+	"strings"
+
+	"maunium.net/go/mautrix/id"
+)
+
+// PermalinkResolver returns the Matrix URI of the event bridging the
+// Mattermost post a permalink links to, or false if the link isn't to a
+// bridged post.
+type PermalinkResolver func(link string) (*id.MatrixURI, bool)
+
+// permalinkRe matches Mattermost permalinks (<server>/<team>/pl/<post ID>),
+// bare or the target of a link. Post IDs are 26 lowercase letters and digits.
+var permalinkRe = regexp.MustCompile(`https?://[^\s<>()\[\]"']+/pl/[a-z0-9]{26}\b`)
+
+// replacePermalinks replaces the resolvable permalinks in text, outside code
+// blocks and inline code, with matrix.to links to the bridged events.
+func replacePermalinks(text string, resolve PermalinkResolver) string {
+	if resolve == nil || !strings.Contains(text, "/pl/") {
+		return text
+	}
+	replace := func(segment string) string {
+		return replaceOutsideCode(segment, func(s string) string {
+			return permalinkRe.ReplaceAllStringFunc(s, func(link string) string {
+				if uri, ok := resolve(link); ok {
+					return uri.MatrixToURL()
+				}
+				return link
+			})
+		})
+	}
+	var sb strings.Builder
+	last := 0
+	for _, loc := range codeBlockRe.FindAllStringIndex(text, -1) {
+		sb.WriteString(replace(text[last:loc[0]]))
+		sb.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(replace(text[last:]))
+	return sb.String()
+}
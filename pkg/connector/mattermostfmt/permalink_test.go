@@ -0,0 +1,61 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"testing"
+
+	"maunium.net/go/mautrix/id"
+)
+
+const (
+	testPermalink   = "https://mm.example.com/eng/pl/abcdefghijklmnopqrstuvwxyz"
+	testEventLink   = "https://matrix.to/#/%21town:example.com/$post?via=example.com"
+	testUnknownLink = "https://mm.example.com/eng/pl/zyxwvutsrqponmlkjihgfedcba"
+)
+
+// testPermalinks resolves testPermalink to an event in the town-square room.
+func testPermalinks(link string) (*id.MatrixURI, bool) {
+	if link == testPermalink {
+		return id.RoomID("!town:example.com").EventURI("$post", "example.com"), true
+	}
+	return nil, false
+}
+
+func TestParsePermalinks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		input         string
+		wantBody      string
+		wantFormatted string
+	}{
+		{"bare", "see " + testPermalink, "see " + testEventLink, ""},
+		{"link target", "see [this](" + testPermalink + ")", "see [this](" + testEventLink + ")", `see <a href="` + testEventLink + `">this</a>`},
+		{"unknown post", "see " + testUnknownLink, "see " + testUnknownLink, ""},
+		{"inline code", "`" + testPermalink + "` **x**", "`" + testPermalink + "` **x**", "<code>" + testPermalink + "</code> <strong>x</strong>"},
+		{"code block", "```text\n" + testPermalink + "```", "```text\n" + testPermalink + "```", `<pre><code class="language-text">` + testPermalink + "</code></pre>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := ParseWithOptions(tt.input, Options{Permalinks: testPermalinks})
+			if result.Body != tt.wantBody {
+				t.Errorf("Body: got %q, want %q", result.Body, tt.wantBody)
+			}
+			if result.FormattedBody != tt.wantFormatted {
+				t.Errorf("FormattedBody: got %q, want %q", result.FormattedBody, tt.wantFormatted)
+			}
+		})
+	}
+}
+
+func TestParsePermalinks_NoResolver(t *testing.T) {
+	t.Parallel()
+	if result := Parse("see " + testPermalink); result.Body != "see "+testPermalink {
+		t.Errorf("Body: got %q", result.Body)
+	}
+}
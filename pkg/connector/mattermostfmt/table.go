@@ -0,0 +1,196 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// tableDelimiterRe matches the delimiter row under the header of a pipe
+// table, e.g. "| --- | :---: |".
+var tableDelimiterRe = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+// table is a pipe table: its header cells, column alignments ("left",
+// "center", "right" or "") and body rows, all with as many cells as the
+// header.
+type table struct {
+	header []string
+	align  []string
+	rows   [][]string
+}
+
+// splitTableRow splits a table row into its trimmed cells. Escaped pipes
+// (\|) are part of a cell.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseTable parses the pipe table starting at lines[start]. It returns the
+// table and the number of lines it spans, or false if no table starts there.
+// Like in Mattermost, the header and delimiter rows must have pipes and the
+// same number of cells; the table ends at a blank line or a line without a
+// pipe.
+func parseTable(lines []string, start int) (*table, int, bool) {
+	if start+1 >= len(lines) || !strings.Contains(lines[start], "|") || !strings.Contains(lines[start+1], "|") ||
+		!tableDelimiterRe.MatchString(lines[start+1]) {
+		return nil, 0, false
+	}
+	t := &table{header: splitTableRow(lines[start])}
+	delimiters := splitTableRow(lines[start+1])
+	if len(delimiters) != len(t.header) {
+		return nil, 0, false
+	}
+	for _, d := range delimiters {
+		switch left, right := strings.HasPrefix(d, ":"), strings.HasSuffix(d, ":"); {
+		case left && right:
+			t.align = append(t.align, "center")
+		case right:
+			t.align = append(t.align, "right")
+		case left:
+			t.align = append(t.align, "left")
+		default:
+			t.align = append(t.align, "")
+		}
+	}
+	end := start + 2
+	for ; end < len(lines) && strings.TrimSpace(lines[end]) != "" && strings.Contains(lines[end], "|"); end++ {
+		row := splitTableRow(lines[end])
+		// Rows get as many cells as the header, dropping or adding cells.
+		cells := make([]string, len(t.header))
+		copy(cells, row)
+		t.rows = append(t.rows, cells)
+	}
+	return t, end - start, true
+}
+
+// html renders the table as Matrix HTML on one line, so the line break pass
+// leaves it alone. The cells are HTML-escaped; inline formatting is
+// converted afterwards like in the rest of the message. Matrix HTML has no
+// column alignment, so it only applies to the plain text.
+func (t *table) html() string {
+	var sb strings.Builder
+	row := func(cells []string, tag string) {
+		sb.WriteString("<tr>")
+		for _, cell := range cells {
+			sb.WriteString("<" + tag + ">" + html.EscapeString(cell) + "</" + tag + ">")
+		}
+		sb.WriteString("</tr>")
+	}
+	sb.WriteString("<table><thead>")
+	row(t.header, "th")
+	sb.WriteString("</thead>")
+	if len(t.rows) > 0 {
+		sb.WriteString("<tbody>")
+		for _, cells := range t.rows {
+			row(cells, "td")
+		}
+		sb.WriteString("</tbody>")
+	}
+	sb.WriteString("</table>")
+	return sb.String()
+}
+
+// text renders the table as plain text with its columns padded to the same
+// width, for the plain text body.
+func (t *table) text() string {
+	widths := make([]int, len(t.header))
+	for _, cells := range append([][]string{t.header}, t.rows...) {
+		for i, cell := range cells {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	pad := func(cell string, i int) string {
+		space := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		switch t.align[i] {
+		case "right":
+			return space + cell
+		case "center":
+			half := len(space) / 2
+			return space[:half] + cell + space[half:]
+		}
+		return cell + space
+	}
+	line := func(cells []string) string {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = pad(cell, i)
+		}
+		return strings.TrimRight(strings.Join(padded, " | "), " ")
+	}
+	lines := []string{line(t.header)}
+	rule := make([]string, len(widths))
+	for i, w := range widths {
+		rule[i] = strings.Repeat("-", max(w, 1))
+	}
+	lines = append(lines, strings.Join(rule, "-|-"))
+	for _, cells := range t.rows {
+		lines = append(lines, line(cells))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hasTable reports whether text has a pipe table.
+func hasTable(text string) bool {
+	if !strings.Contains(text, "|") || !strings.Contains(text, "-") {
+		return false
+	}
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		if _, _, ok := parseTable(lines, i); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// alignTables replaces the pipe tables in text, outside code blocks, with
+// their plain text rendering.
+func alignTables(text string) string {
+	replace := func(segment string) string {
+		lines := strings.Split(segment, "\n")
+		result := make([]string, 0, len(lines))
+		for i := 0; i < len(lines); i++ {
+			if t, n, ok := parseTable(lines, i); ok {
+				result = append(result, t.text())
+				i += n - 1
+				continue
+			}
+			result = append(result, lines[i])
+		}
+		return strings.Join(result, "\n")
+	}
+	var sb strings.Builder
+	last := 0
+	for _, loc := range codeBlockRe.FindAllStringIndex(text, -1) {
+		sb.WriteString(replace(text[last:loc[0]]))
+		sb.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(replace(text[last:]))
+	return sb.String()
+}
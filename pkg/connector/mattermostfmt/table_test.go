@@ -0,0 +1,122 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"strings"
+	"testing"
+
+	"maunium.net/go/mautrix/event"
+)
+
+func TestParseTable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		text     string
+		wantHTML string
+		wantBody string
+	}{
+		{
+			"header and body",
+			"| Name | Role |\n|---|---|\n| alice | **admin** |\n| bob | user |",
+			"<table><thead><tr><th>Name</th><th>Role</th></tr></thead><tbody>" +
+				"<tr><td>alice</td><td><strong>admin</strong></td></tr><tr><td>bob</td><td>user</td></tr></tbody></table>",
+			"Name  | Role\n------|----------\nalice | **admin**\nbob   | user",
+		},
+		{
+			"alignment and no outer pipes",
+			"a | b | c\n:-- | :-: | --:\nlong | x | 1",
+			"<table><thead><tr><th>a</th><th>b</th><th>c</th></tr></thead><tbody>" +
+				"<tr><td>long</td><td>x</td><td>1</td></tr></tbody></table>",
+			"a    | b | c\n-----|---|--\nlong | x | 1",
+		},
+		{
+			"escaped pipes, escaping and short rows",
+			"| cmd | note |\n| --- | --- |\n| a \\| b | <tag> |\n| only |",
+			"<table><thead><tr><th>cmd</th><th>note</th></tr></thead><tbody>" +
+				"<tr><td>a | b</td><td>&lt;tag&gt;</td></tr><tr><td>only</td><td></td></tr></tbody></table>",
+			"cmd   | note\n------|------\na | b | <tag>\nonly  |",
+		},
+		{
+			"around text",
+			"Results:\n| x |\n| - |\n| 1 |\n\nDone",
+			"<p>Results:<br/><table><thead><tr><th>x</th></tr></thead><tbody><tr><td>1</td></tr></tbody></table></p><p>Done</p>",
+			"Results:\nx\n-\n1\n\nDone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Parse(tt.text)
+			if got.Format != event.FormatHTML || got.FormattedBody != tt.wantHTML {
+				t.Errorf("FormattedBody = %q, want %q", got.FormattedBody, tt.wantHTML)
+			}
+			if got.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", got.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestParseNotTable(t *testing.T) {
+	t.Parallel()
+	for _, text := range []string{
+		"a | b",
+		"a | b\nc | d",
+		"Title\n---",
+		"| a | b |\n| --- |",
+		"```\n| a |\n| - |\n```",
+	} {
+		got := Parse(text)
+		if got.Body != text {
+			t.Errorf("Parse(%q).Body = %q, want the text unchanged", text, got.Body)
+		}
+		if strings.Contains(got.FormattedBody, "<table>") {
+			t.Errorf("Parse(%q) = %q, want no table", text, got.FormattedBody)
+		}
+	}
+}
+
+// FuzzParseTable verifies that table parsing never panics, that parsed tables
+// have as many cells in every row as in the header, and that their plain
+// text rendering spans as many lines as the table. This is a required fuzz
+// test for a parsing function.
+func FuzzParseTable(f *testing.F) {
+	f.Add("| a | b |\n|---|---|\n| 1 | 2 |")
+	f.Add("a|b\n-|-\n1|2|3\n4")
+	f.Add("|:-:|\n|:-:|\n||")
+	f.Add("| a \\| b |\n| --- |\n| c \\|")
+	f.Add("| é | ü |\n| ---: | :--- |\n| ñññ |")
+	f.Add("|\n|")
+	f.Add("x\n| a |\n| - |\n\ny")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		lines := strings.Split(text, "\n")
+		for i := range lines {
+			tbl, n, ok := parseTable(lines, i)
+			if !ok {
+				continue
+			}
+			if n < 2 || i+n > len(lines) {
+				t.Fatalf("table at line %d spans %d of %d lines", i, n, len(lines))
+			}
+			if len(tbl.align) != len(tbl.header) {
+				t.Errorf("%d alignments for %d header cells", len(tbl.align), len(tbl.header))
+			}
+			for _, row := range tbl.rows {
+				if len(row) != len(tbl.header) {
+					t.Errorf("row has %d cells, header has %d", len(row), len(tbl.header))
+				}
+			}
+			if got := strings.Count(tbl.text(), "\n") + 1; got != n {
+				t.Errorf("plain text has %d lines, table spans %d", got, n)
+			}
+			_ = tbl.html()
+		}
+		_ = alignTables(text)
+	})
+}
@@ -0,0 +1,162 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeFormat is the Go time layout used for rendered timestamps when
+// no layout is configured.
+const DefaultTimeFormat = "2006-01-02 15:04 MST"
+
+// Options controls locale-sensitive parts of the conversion and the
+// resolution of mentions and channel references.
+type Options struct {
+	// Location is the timezone timestamps are rendered in. Defaults to UTC.
+	Location *time.Location
+	// TimeFormat is the Go time layout for full timestamps. Defaults to
+	// DefaultTimeFormat.
+	TimeFormat string
+	// Mentions resolves @username mentions to Matrix users. Without it,
+	// mentions are left as text.
+	Mentions MentionResolver
+	// Channels resolves ~channel references to Matrix rooms. Without it,
+	// references are left as text.
+	Channels ChannelResolver
+	// Permalinks resolves permalinks to bridged posts to matrix.to links to
+	// their events. Without it, permalinks are left as they are.
+	Permalinks PermalinkResolver
+	// RoomMentions converts @channel, @all and @here to @room and sets
+	// Mentions.Room. Without it, they are left as text.
+	RoomMentions bool
+}
+
+func (o Options) location() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+func (o Options) timeFormat() string {
+	if o.TimeFormat == "" {
+		return DefaultTimeFormat
+	}
+	return o.TimeFormat
+}
+
+// FormatTime renders t as an absolute time using the configured timezone and
+// layout.
+func (o Options) FormatTime(t time.Time) string {
+	return t.In(o.location()).Format(o.timeFormat())
+}
+
+// timestampRe matches timestamp tokens of the form <t:UNIX> or
+// <t:UNIX:STYLE>, as posted by integrations and clients that render them as
+// localized times. Matrix clients have no equivalent, so they are rewritten
+// to absolute times.
+var timestampRe = regexp.MustCompile(`<t:(-?\d{1,12})(?::([tTdDfFR]))?>`)
+
+// timestampStyleLayouts maps the single-letter timestamp styles to Go
+// layouts. Styles not listed here (f, R) use the configured full layout;
+// relative times are rendered absolutely since they'd go stale in Matrix.
+var timestampStyleLayouts = map[string]string{
+	"t": "15:04",
+	"T": "15:04:05",
+	"d": "2006-01-02",
+	"D": "January 2, 2006",
+	"F": "Monday, January 2, 2006 15:04 MST",
+}
+
+// ConvertTimestamps rewrites timestamp tokens in text to absolute times.
+// Tokens inside inline code or code blocks are left untouched.
+func ConvertTimestamps(text string, opts Options) string {
+	if !strings.Contains(text, "<t:") {
+		return text
+	}
+
+	var skip [][]int
+	skip = append(skip, codeBlockRe.FindAllStringIndex(text, -1)...)
+	skip = append(skip, codeRe.FindAllStringIndex(text, -1)...)
+	inCode := func(start int) bool {
+		for _, r := range skip {
+			if start >= r[0] && start < r[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range timestampRe.FindAllStringSubmatchIndex(text, -1) {
+		if inCode(m[0]) {
+			continue
+		}
+		unix, err := strconv.ParseInt(text[m[2]:m[3]], 10, 64)
+		if err != nil {
+			continue
+		}
+		var style string
+		if m[4] >= 0 {
+			style = text[m[4]:m[5]]
+		}
+		t := time.Unix(unix, 0)
+		b.WriteString(text[last:m[0]])
+		if layout, ok := timestampStyleLayouts[style]; ok {
+			b.WriteString(t.In(opts.location()).Format(layout))
+		} else {
+			b.WriteString(opts.FormatTime(t))
+		}
+		last = m[1]
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// localeTimeFormats maps locale tags to Go layouts that follow the locale's
+// customary date order and clock. Month and weekday names stay in English,
+// since Go's time package doesn't localize them.
+var localeTimeFormats = map[string]string{
+	"en":    "Jan 2, 2006 3:04 PM MST",
+	"en-us": "Jan 2, 2006 3:04 PM MST",
+	"en-gb": "2 Jan 2006 15:04 MST",
+	"de":    "02.01.2006 15:04 MST",
+	"fr":    "02/01/2006 15:04 MST",
+	"es":    "02/01/2006 15:04 MST",
+	"it":    "02/01/2006 15:04 MST",
+	"nl":    "02-01-2006 15:04 MST",
+	"pt":    "02/01/2006 15:04 MST",
+	"pt-br": "02/01/2006 15:04 MST",
+	"ru":    "02.01.2006 15:04 MST",
+	"pl":    "02.01.2006 15:04 MST",
+	"sv":    "2006-01-02 15:04 MST",
+	"ja":    "2006/01/02 15:04 MST",
+	"zh":    "2006/01/02 15:04 MST",
+	"ko":    "2006. 01. 02. 15:04 MST",
+}
+
+// LocaleTimeFormat returns the time layout for a locale tag such as "de" or
+// "en-GB". Tags are matched case-insensitively, with "_" accepted in place of
+// "-", falling back to the base language.
+func LocaleTimeFormat(locale string) (string, bool) {
+	tag := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if layout, ok := localeTimeFormats[tag]; ok {
+		return layout, true
+	}
+	if base, _, found := strings.Cut(tag, "-"); found {
+		layout, ok := localeTimeFormats[base]
+		return layout, ok
+	}
+	return "", false
+}
@@ -0,0 +1,145 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mattermostfmt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// 1700000000 is 2023-11-14 22:13:20 UTC.
+const testUnix = "1700000000"
+
+func TestConvertTimestamps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"no tokens", "hello world", "hello world"},
+		{"default style", "at <t:" + testUnix + ">", "at 2023-11-14 22:13 UTC"},
+		{"full style", "<t:" + testUnix + ":f>", "2023-11-14 22:13 UTC"},
+		{"relative rendered absolute", "<t:" + testUnix + ":R>", "2023-11-14 22:13 UTC"},
+		{"short time", "<t:" + testUnix + ":t>", "22:13"},
+		{"long time", "<t:" + testUnix + ":T>", "22:13:20"},
+		{"short date", "<t:" + testUnix + ":d>", "2023-11-14"},
+		{"long date", "<t:" + testUnix + ":D>", "November 14, 2023"},
+		{"long date time", "<t:" + testUnix + ":F>", "Tuesday, November 14, 2023 22:13 UTC"},
+		{"multiple", "<t:0:d> to <t:" + testUnix + ":d>", "1970-01-01 to 2023-11-14"},
+		{"unknown style untouched", "<t:" + testUnix + ":x>", "<t:" + testUnix + ":x>"},
+		{"not a number untouched", "<t:abc>", "<t:abc>"},
+		{"inline code untouched", "`<t:" + testUnix + ">`", "`<t:" + testUnix + ">`"},
+		{"code block untouched", "```\n<t:" + testUnix + ">\n```", "```\n<t:" + testUnix + ">\n```"},
+		{"mixed code and text", "`<t:0>` <t:0:d>", "`<t:0>` 1970-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ConvertTimestamps(tt.input, Options{}); got != tt.want {
+				t.Errorf("ConvertTimestamps(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertTimestamps_Timezone(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("CET", 3600)
+	got := ConvertTimestamps("<t:"+testUnix+">", Options{Location: loc, TimeFormat: "15:04 MST"})
+	if got != "23:13 CET" {
+		t.Errorf("got %q, want %q", got, "23:13 CET")
+	}
+}
+
+func TestOptionsFormatTime_Defaults(t *testing.T) {
+	t.Parallel()
+	got := Options{}.FormatTime(time.Unix(1700000000, 0))
+	if got != "2023-11-14 22:13 UTC" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestParseWithOptions_Timestamp(t *testing.T) {
+	t.Parallel()
+	result := ParseWithOptions("**due** <t:"+testUnix+":d>", Options{})
+	if !strings.Contains(result.Body, "2023-11-14") {
+		t.Errorf("Body should contain converted date, got %q", result.Body)
+	}
+	if strings.Contains(result.FormattedBody, "&lt;t:") {
+		t.Errorf("FormattedBody should not contain escaped token, got %q", result.FormattedBody)
+	}
+	if !strings.Contains(result.FormattedBody, "<strong>due</strong> 2023-11-14") {
+		t.Errorf("FormattedBody: got %q", result.FormattedBody)
+	}
+}
+
+func TestParse_PlainTimestampUsesUTC(t *testing.T) {
+	t.Parallel()
+	result := Parse("<t:" + testUnix + ">")
+	if result.Body != "2023-11-14 22:13 UTC" {
+		t.Errorf("Body: got %q", result.Body)
+	}
+}
+
+func TestLocaleTimeFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		locale string
+		want   string
+		ok     bool
+	}{
+		{"de", "02.01.2006 15:04 MST", true},
+		{"en-GB", "2 Jan 2006 15:04 MST", true},
+		{"en_gb", "2 Jan 2006 15:04 MST", true},
+		{"de-AT", "02.01.2006 15:04 MST", true},
+		{"EN", "Jan 2, 2006 3:04 PM MST", true},
+		{"xx", "", false},
+		{"xx-YY", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			t.Parallel()
+			got, ok := LocaleTimeFormat(tt.locale)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("LocaleTimeFormat(%q) = (%q, %v), want (%q, %v)", tt.locale, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+// FuzzConvertTimestamps verifies that timestamp conversion never panics,
+// leaves text without tokens alone and keeps the text before the first
+// token. This is a required fuzz test for a parsing function.
+func FuzzConvertTimestamps(f *testing.F) {
+	f.Add("<t:" + testUnix + ">")
+	f.Add("at <t:" + testUnix + ":t> and <t:-1:R>")
+	f.Add("`<t:" + testUnix + ">` <t:0:F>")
+	f.Add("```\n<t:" + testUnix + ">\n```<t:0>")
+	f.Add("<t:999999999999:D>")
+	f.Add("<t:<t:0>>")
+	f.Add("<t:")
+	f.Add("no tokens")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		got := ConvertTimestamps(text, Options{})
+		if got != ConvertTimestamps(text, Options{}) {
+			t.Errorf("non-deterministic for %q", text)
+		}
+		first := strings.Index(text, "<t:")
+		if first < 0 {
+			if got != text {
+				t.Errorf("text without tokens changed: got %q, want %q", got, text)
+			}
+			return
+		}
+		if !strings.HasPrefix(got, text[:first]) {
+			t.Errorf("text before the first token changed: got %q for %q", got, text)
+		}
+	})
+}
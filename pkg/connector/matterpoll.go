@@ -0,0 +1,281 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// Matterpoll (https://github.com/matterpoll/matterpoll) posts polls as custom
+// posts with a message attachment holding the question and one button per
+// answer. Votes are button clicks, which the server forwards to the plugin.
+const (
+	matterpollPluginID = "com.github.matterpoll.matterpoll"
+	matterpollPostType = "custom_matterpoll"
+	// matterpollVoteAction prefixes the IDs of the answer buttons, followed
+	// by the answer index. The other buttons manage the poll.
+	matterpollVoteAction = "vote"
+	// matterpollCheckInterval is how long the plugin check is reused.
+	matterpollCheckInterval = 10 * time.Minute
+)
+
+// errPollVoteRetracted is returned for Matrix poll responses without
+// answers, which Matterpoll can't express.
+var errPollVoteRetracted = errors.New("votes can't be retracted in Mattermost")
+
+// errPollEnded is returned for votes on Matterpoll polls that ended.
+var errPollEnded = errors.New("the poll has ended")
+
+// matterpollPoll is a poll parsed from a Matterpoll post.
+type matterpollPoll struct {
+	Question string
+	Answers  []matterpollAnswer
+}
+
+// matterpollAnswer is an answer of a Matterpoll poll and the button that
+// votes for it.
+type matterpollAnswer struct {
+	ActionID string
+	Cookie   string
+	Text     string
+}
+
+// parseMatterpollPost returns the poll of a Matterpoll post. ok is false for
+// other posts and for polls without answer buttons, such as ended polls.
+func parseMatterpollPost(post *model.Post) (poll *matterpollPoll, ok bool) {
+	if post.Type != matterpollPostType {
+		return nil, false
+	}
+	attachments := post.Attachments()
+	poll = &matterpollPoll{Question: post.Message}
+	if len(attachments) > 0 {
+		poll.Question = cmp.Or(attachments[0].Title, poll.Question)
+	}
+	for _, action := range postActions(attachments) {
+		if strings.HasPrefix(action.Id, matterpollVoteAction) {
+			poll.Answers = append(poll.Answers, matterpollAnswer{ActionID: action.Id, Cookie: action.Cookie, Text: action.Name})
+		}
+	}
+	if len(poll.Answers) == 0 {
+		return nil, false
+	}
+	return poll, true
+}
+
+// answer returns the answer with the given button ID.
+func (p *matterpollPoll) answer(actionID string) (matterpollAnswer, bool) {
+	i := slices.IndexFunc(p.Answers, func(a matterpollAnswer) bool { return a.ActionID == actionID })
+	if i < 0 {
+		return matterpollAnswer{}, false
+	}
+	return p.Answers[i], true
+}
+
+// pollFallback renders a poll as text, for clients without poll support and
+// for Mattermost servers without Matterpoll.
+func pollFallback(question string, answers []string) (body, formatted string) {
+	var sb, fb strings.Builder
+	sb.WriteString("📊 " + question)
+	fb.WriteString("<p>📊 <strong>" + html.EscapeString(question) + "</strong></p><ol>")
+	for i, answer := range answers {
+		fmt.Fprintf(&sb, "\n%d. %s", i+1, answer)
+		fb.WriteString("<li>" + html.EscapeString(answer) + "</li>")
+	}
+	fb.WriteString("</ol>")
+	return sb.String(), fb.String()
+}
+
+// convertMatterpollToMatrix converts a Matterpoll post to an MSC3381 poll
+// whose answer IDs are the IDs of the answer buttons. Matterpoll allows one
+// vote per user unless the poll was created with more, which the post
+// doesn't tell, so the poll allows one answer.
+func convertMatterpollToMatrix(poll *matterpollPoll) *bridgev2.ConvertedMessage {
+	texts := make([]string, len(poll.Answers))
+	answers := make([]map[string]any, len(poll.Answers))
+	for i, answer := range poll.Answers {
+		texts[i] = answer.Text
+		answers[i] = map[string]any{
+			"id":                      answer.ActionID,
+			"org.matrix.msc1767.text": answer.Text,
+		}
+	}
+	body, formatted := pollFallback(poll.Question, texts)
+	return &bridgev2.ConvertedMessage{
+		Parts: []*bridgev2.ConvertedMessagePart{{
+			ID:   MakeMessagePartID(0),
+			Type: event.EventUnstablePollStart,
+			Content: &event.MessageEventContent{
+				Body:          body,
+				Format:        event.FormatHTML,
+				FormattedBody: formatted,
+			},
+			Extra: map[string]any{
+				"org.matrix.msc1767.text": body,
+				"org.matrix.msc3381.poll.start": map[string]any{
+					"kind":           "org.matrix.msc3381.poll.disclosed",
+					"max_selections": 1,
+					"question":       map[string]any{"org.matrix.msc1767.text": poll.Question},
+					"answers":        answers,
+				},
+			},
+		}},
+	}
+}
+
+// matterpollAvailable reports whether the Matterpoll plugin is enabled on
+// the server. The answer is reused for matterpollCheckInterval. When the
+// plugin list can't be fetched, it's assumed to be, and fetched again on the
+// next vote.
+func (m *MattermostClient) matterpollAvailable(ctx context.Context, client *model.Client4) bool {
+	m.matterpollMu.Lock()
+	defer m.matterpollMu.Unlock()
+	if !m.matterpollCheckedAt.IsZero() && time.Since(m.matterpollCheckedAt) < matterpollCheckInterval {
+		return m.matterpollEnabled
+	}
+	manifests, _, err := client.GetWebappPlugins(ctx)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to get plugins, assuming Matterpoll is enabled")
+		return true
+	}
+	m.matterpollEnabled = slices.ContainsFunc(manifests, func(manifest *model.Manifest) bool {
+		return manifest.Id == matterpollPluginID
+	})
+	m.matterpollCheckedAt = time.Now()
+	return m.matterpollEnabled
+}
+
+// HandleMatrixPollStart implements bridgev2.PollHandlingNetworkAPI. A
+// Matterpoll poll can only be created by a slash command whose post can't be
+// tied to the Matrix event, so Matrix polls are posted as text. The answers
+// are kept in the message metadata to name them in votes.
+func (m *MattermostClient) HandleMatrixPollStart(ctx context.Context, msg *bridgev2.MatrixPollStart) (*bridgev2.MatrixMessageResponse, error) {
+	start := msg.Content.PollStart
+	texts := make([]string, len(start.Answers))
+	answers := make(map[string]string, len(start.Answers))
+	for i, answer := range start.Answers {
+		texts[i] = answer.Text
+		answers[answer.ID] = answer.Text
+	}
+	body, formatted := pollFallback(start.Question.Text, texts)
+	text := msg.MatrixMessage
+	text.Content = &event.MessageEventContent{
+		MsgType:       event.MsgText,
+		Body:          body,
+		Format:        event.FormatHTML,
+		FormattedBody: formatted,
+	}
+	resp, err := m.HandleMatrixMessage(ctx, &text)
+	if err == nil && resp.DB != nil {
+		resp.DB.Metadata = &MessageMetadata{PollAnswers: answers}
+	}
+	return resp, err
+}
+
+// HandleMatrixPollVote implements bridgev2.PollHandlingNetworkAPI. Votes on
+// Matterpoll polls click the answer buttons as the sender; votes on other
+// posts, or when Matterpoll is disabled, are posted as a thread reply.
+// Relayed votes would be counted for the relay account, so they're posted
+// as a reply naming the voter too.
+func (m *MattermostClient) HandleMatrixPollVote(ctx context.Context, msg *bridgev2.MatrixPollVote) (*bridgev2.MatrixMessageResponse, error) {
+	if !m.IsLoggedIn() {
+		return nil, bridgev2.ErrNotLoggedIn
+	}
+	if portalMetadata(msg.Portal).Archived {
+		return nil, apiError("failed to vote", nil, ErrChannelArchived)
+	}
+	answerIDs := msg.Content.Response.Answers
+	if len(answerIDs) == 0 {
+		return nil, errPollVoteRetracted
+	}
+	postClient, senderID := m.resolvePostClient(msg.OrigSender, msg.Event)
+	var voter string
+	if msg.OrigSender != nil && senderID == m.userID {
+		voter = cmp.Or(msg.OrigSender.DisambiguatedName, msg.OrigSender.UserID.String())
+	}
+	postID := ParseMessageID(msg.VoteTo.ID)
+	post, resp, err := postClient.GetPost(ctx, postID, "")
+	if err != nil {
+		return nil, apiError("failed to get poll", resp, err)
+	}
+
+	poll, ok := parseMatterpollPost(post)
+	if !ok && post.Type == matterpollPostType {
+		return nil, errPollEnded
+	}
+	if !ok || voter != "" || !m.matterpollAvailable(ctx, postClient) {
+		answers := messageMetadata(msg.VoteTo).PollAnswers
+		if poll != nil {
+			answers = make(map[string]string, len(poll.Answers))
+			for _, answer := range poll.Answers {
+				answers[answer.ActionID] = answer.Text
+			}
+		}
+		var eventID id.EventID
+		if msg.Event != nil {
+			eventID = msg.Event.ID
+		}
+		return m.sendPollVoteFallback(ctx, postClient, senderID, voter, post, answers, answerIDs, eventID)
+	}
+	for _, answerID := range answerIDs {
+		answer, ok := poll.answer(answerID)
+		if !ok {
+			m.log.Warn().Str("post_id", postID).Str("answer_id", answerID).Msg("Ignoring vote for unknown poll answer")
+			continue
+		}
+		if resp, err := postClient.DoPostActionWithCookie(ctx, postID, answer.ActionID, "", answer.Cookie); err != nil {
+			return nil, apiError("failed to vote", resp, err)
+		}
+	}
+	return &bridgev2.MatrixMessageResponse{
+		DB: &database.Message{
+			ID:       MakePollVoteID(postID, msg.Event.ID),
+			SenderID: MakeUserID(senderID),
+		},
+	}, nil
+}
+
+// sendPollVoteFallback posts a vote as a reply in the poll's thread, naming
+// the answers by their text in answers, or by their ID if it's unknown.
+// voter names the Matrix user of a relayed vote. eventID is the vote's
+// Matrix event, if known.
+func (m *MattermostClient) sendPollVoteFallback(ctx context.Context, postClient *model.Client4, senderID, voter string, post *model.Post, answers map[string]string, answerIDs []string, eventID id.EventID) (*bridgev2.MatrixMessageResponse, error) {
+	texts := make([]string, len(answerIDs))
+	for i, answerID := range answerIDs {
+		texts[i] = cmp.Or(answers[answerID], answerID)
+	}
+	vote := &model.Post{
+		ChannelId: post.ChannelId,
+		RootId:    cmp.Or(post.RootId, post.Id),
+		Message:   "🗳️ Voted for " + strings.Join(texts, ", "),
+	}
+	if voter != "" {
+		vote.Message = "🗳️ " + voter + " voted for " + strings.Join(texts, ", ")
+	}
+	m.connector.markBridgePost(vote, eventID)
+	created, resp, err := postClient.CreatePost(ctx, vote)
+	if err != nil {
+		return nil, apiError("failed to post vote", resp, err)
+	}
+	return &bridgev2.MatrixMessageResponse{
+		DB: &database.Message{
+			ID:       MakeMessageID(created.Id),
+			SenderID: MakeUserID(senderID),
+		},
+	}, nil
+}
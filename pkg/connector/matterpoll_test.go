@@ -0,0 +1,303 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+)
+
+// matterpollPost returns a Matterpoll post in ch1 asking which day with the
+// answers Monday and Tuesday, and the buttons to manage the poll.
+func matterpollPost(id string) *model.Post {
+	post := &model.Post{Id: id, ChannelId: "ch1", UserId: "matterpoll-bot", Type: matterpollPostType}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{{
+		Title: "Which day?",
+		Actions: []*model.PostAction{
+			{Id: "vote0", Name: "Monday", Type: model.PostActionTypeButton, Cookie: "cookie-0"},
+			{Id: "vote1", Name: "Tuesday", Type: model.PostActionTypeButton, Cookie: "cookie-1"},
+			{Id: "addOption", Name: "Add Option", Type: model.PostActionTypeButton},
+			{Id: "endPoll", Name: "End Poll", Type: model.PostActionTypeButton},
+		},
+	}})
+	return post
+}
+
+func TestParseMatterpollPost(t *testing.T) {
+	t.Parallel()
+	ended := matterpollPost("p2")
+	ended.Attachments()[0].Actions = nil
+	model.ParseSlackAttachment(ended, []*model.SlackAttachment{{Title: "Which day?"}})
+
+	tests := []struct {
+		name        string
+		post        *model.Post
+		wantOK      bool
+		wantAnswers []string
+	}{
+		{"poll", matterpollPost("p1"), true, []string{"vote0", "vote1"}},
+		{"ended", ended, false, nil},
+		{"other post", &model.Post{Id: "p3", Message: "hello"}, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			poll, ok := parseMatterpollPost(tt.post)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if poll.Question != "Which day?" {
+				t.Errorf("question = %q", poll.Question)
+			}
+			var ids []string
+			for _, answer := range poll.Answers {
+				ids = append(ids, answer.ActionID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantAnswers, ",") {
+				t.Errorf("answers = %v, want %v", ids, tt.wantAnswers)
+			}
+		})
+	}
+}
+
+func TestConvertPostToMatrix_Matterpoll(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, matterpollPost("p1"))
+
+	if len(msg.Parts) != 1 || msg.Parts[0].Type != event.EventUnstablePollStart {
+		t.Fatalf("expected one poll start part, got %+v", msg.Parts)
+	}
+	part := msg.Parts[0]
+	if part.Content.Body != "📊 Which day?\n1. Monday\n2. Tuesday" {
+		t.Errorf("fallback body = %q", part.Content.Body)
+	}
+	raw, _ := json.Marshal(part.Extra)
+	var content event.PollStartEventContent
+	if err := json.Unmarshal(raw, &content); err != nil {
+		t.Fatalf("poll content doesn't parse: %v", err)
+	}
+	start := content.PollStart
+	if start.Question.Text != "Which day?" || start.MaxSelections != 1 || len(start.Answers) != 2 {
+		t.Fatalf("poll = %+v", start)
+	}
+	if start.Answers[1].ID != "vote1" || start.Answers[1].Text != "Tuesday" {
+		t.Errorf("second answer = %+v", start.Answers[1])
+	}
+}
+
+func TestIsEchoPost_Matterpoll(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	if mc.isEchoPost(matterpollPost("p1"), "posted") {
+		t.Error("Matterpoll posts should be bridged")
+	}
+}
+
+// pollVote returns a Matrix vote for answerIDs on the message of post p1.
+func pollVote(answerIDs ...string) *bridgev2.MatrixPollVote {
+	content := &event.PollResponseEventContent{}
+	content.Response.Answers = answerIDs
+	return &bridgev2.MatrixPollVote{
+		MatrixMessage: bridgev2.MatrixMessage{
+			MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+				Event:  &event.Event{ID: "$vote:example.com"},
+				Portal: makeTestPortal("ch1"),
+			},
+		},
+		VoteTo:  &database.Message{ID: MakeMessageID("p1")},
+		Content: content,
+	}
+}
+
+func TestHandleMatrixPollVote(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Posts["ch1"] = makePostList([]*model.Post{matterpollPost("p1")})
+	fake.WebappPlugins = []*model.Manifest{{Id: matterpollPluginID}}
+	mc := newFullTestClient(fake.Server.URL)
+
+	resp, err := mc.HandleMatrixPollVote(context.Background(), pollVote("vote1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !IsPollVoteID(resp.DB.ID) {
+		t.Errorf("message ID = %q, want a poll vote ID", resp.DB.ID)
+	}
+	var votes []endpointCall
+	for _, call := range fake.Calls() {
+		if strings.Contains(call.Path, "/actions/") {
+			votes = append(votes, call)
+		}
+	}
+	if len(votes) != 1 || votes[0].Path != "/api/v4/posts/p1/actions/vote1" || !strings.Contains(votes[0].Body, "cookie-1") {
+		t.Errorf("vote calls = %+v, want one click of vote1 with its cookie", votes)
+	}
+	if fake.CalledPath("/api/v4/posts") && !strings.Contains(fake.Calls()[len(fake.Calls())-1].Path, "/actions/") {
+		t.Error("no fallback post expected")
+	}
+}
+
+func TestHandleMatrixPollVote_Fallback(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		post     *model.Post
+		meta     *MessageMetadata
+		relayed  bool
+		wantText string
+	}{
+		{"plugin disabled", matterpollPost("p1"), nil, false, "🗳️ Voted for Tuesday"},
+		{"poll from matrix", &model.Post{Id: "p1", ChannelId: "ch1", Message: "📊 Lunch?"}, &MessageMetadata{PollAnswers: map[string]string{"vote1": "Pizza"}}, false, "🗳️ Voted for Pizza"},
+		{"unknown answer", &model.Post{Id: "p1", ChannelId: "ch1", Message: "📊 Lunch?"}, nil, false, "🗳️ Voted for vote1"},
+		{"relayed", matterpollPost("p1"), nil, true, "🗳️ Alice voted for Tuesday"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.Posts["ch1"] = makePostList([]*model.Post{tt.post})
+			mc := newFullTestClient(fake.Server.URL)
+			vote := pollVote("vote1")
+			if tt.meta != nil {
+				vote.VoteTo.Metadata = tt.meta
+			}
+			if tt.relayed {
+				fake.WebappPlugins = []*model.Manifest{{Id: matterpollPluginID}}
+				vote.OrigSender = &bridgev2.OrigSender{UserID: "@alice:example.com", DisambiguatedName: "Alice"}
+			}
+
+			resp, err := mc.HandleMatrixPollVote(context.Background(), vote)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.DB.ID != MakeMessageID("created-post-id") {
+				t.Errorf("message ID = %q, want the fallback post", resp.DB.ID)
+			}
+			var created *model.Post
+			for _, call := range fake.Calls() {
+				if call.Method == "POST" && call.Path == "/api/v4/posts" {
+					_ = json.Unmarshal([]byte(call.Body), &created)
+				}
+				if strings.Contains(call.Path, "/actions/") {
+					t.Error("fallback votes must not click buttons")
+				}
+			}
+			if created == nil || created.Message != tt.wantText || created.RootId != "p1" {
+				t.Errorf("fallback post = %+v, want %q in the poll's thread", created, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestMatterpollAvailable_Cached(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.WebappPlugins = []*model.Manifest{{Id: matterpollPluginID}}
+	mc := newFullTestClient(fake.Server.URL)
+
+	for range 2 {
+		if !mc.matterpollAvailable(context.Background(), mc.client) {
+			t.Error("Matterpoll should be available")
+		}
+	}
+	checks := 0
+	for _, call := range fake.Calls() {
+		if call.Path == "/api/v4/plugins/webapp" {
+			checks++
+		}
+	}
+	if checks != 1 {
+		t.Errorf("plugins fetched %d times, want once", checks)
+	}
+
+	// The check is repeated once it's old.
+	mc.matterpollCheckedAt = time.Now().Add(-matterpollCheckInterval)
+	fake.mu.Lock()
+	fake.WebappPlugins = nil
+	fake.mu.Unlock()
+	if mc.matterpollAvailable(context.Background(), mc.client) {
+		t.Error("disabled Matterpoll should be picked up after the check interval")
+	}
+}
+
+func TestHandleMatrixPollVote_Errors(t *testing.T) {
+	t.Parallel()
+	ended := matterpollPost("p1")
+	model.ParseSlackAttachment(ended, []*model.SlackAttachment{{Title: "Which day?"}})
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Posts["ch1"] = makePostList([]*model.Post{ended})
+	mc := newFullTestClient(fake.Server.URL)
+
+	if _, err := mc.HandleMatrixPollVote(context.Background(), pollVote()); !errors.Is(err, errPollVoteRetracted) {
+		t.Errorf("retracted vote: err = %v", err)
+	}
+	if _, err := mc.HandleMatrixPollVote(context.Background(), pollVote("vote0")); !errors.Is(err, errPollEnded) {
+		t.Errorf("ended poll: err = %v", err)
+	}
+	err := mc.HandleMatrixMessageRemove(context.Background(), &bridgev2.MatrixMessageRemove{
+		TargetMessage: &database.Message{ID: MakePollVoteID("p1", "$vote:example.com")},
+	})
+	if !errors.Is(err, errPollVoteRetracted) {
+		t.Errorf("vote redaction: err = %v", err)
+	}
+}
+
+func TestHandleMatrixPollStart(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+	var content event.PollStartEventContent
+	err := json.Unmarshal([]byte(`{"org.matrix.msc3381.poll.start":{
+		"kind":"org.matrix.msc3381.poll.disclosed","max_selections":1,
+		"question":{"org.matrix.msc1767.text":"Lunch?"},
+		"answers":[{"id":"a1","org.matrix.msc1767.text":"Pizza"},{"id":"a2","org.matrix.msc1767.text":"Sushi"}]}}`), &content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := mc.HandleMatrixPollStart(context.Background(), &bridgev2.MatrixPollStart{
+		MatrixMessage: bridgev2.MatrixMessage{
+			MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{Portal: makeTestPortal("ch1")},
+		},
+		Content: &content,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := messageMetadata(resp.DB).PollAnswers; got["a2"] != "Sushi" {
+		t.Errorf("poll answers metadata = %v", got)
+	}
+	var created model.Post
+	for _, call := range fake.Calls() {
+		if call.Path == "/api/v4/posts" {
+			_ = json.Unmarshal([]byte(call.Body), &created)
+		}
+	}
+	for _, want := range []string{"Lunch?", "1. Pizza", "2. Sushi"} {
+		if !strings.Contains(created.Message, want) {
+			t.Errorf("post %q missing %q", created.Message, want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// errFileTooLarge is returned when an attachment exceeds the media size limit.
+var errFileTooLarge = errors.New("file exceeds media size limit")
+
+// SetMaxFileSize implements bridgev2.MaxFileSizeingNetwork. The Matrix
+// connector calls it with the homeserver's upload limit at startup.
+func (mc *MattermostConnector) SetMaxFileSize(maxSize int64) {
+	mc.maxFileSize.Store(maxSize)
+}
+
+// mediaSizeLimit returns the largest attachment, in bytes, that is
+// reuploaded to Matrix: the smaller of media.max_size_mb and the
+// homeserver's upload limit. 0 means unlimited.
+func (mc *MattermostConnector) mediaSizeLimit() int64 {
+	limit := mc.maxFileSize.Load()
+	if configured := int64(mc.Config.Media.MaxSizeMB) << 20; configured > 0 && (limit <= 0 || configured < limit) {
+		limit = configured
+	}
+	return limit
+}
+
+// portalBot returns the bridge bot's Matrix API for a portal, or nil when
+// the portal isn't attached to a bridge.
+func portalBot(portal *bridgev2.Portal) bridgev2.MatrixAPI {
+	if portal == nil || portal.Bridge == nil {
+		return nil
+	}
+	return portal.Bridge.Bot
+}
+
+// reuploadFile streams a Mattermost attachment into the Matrix media repo.
+// The download response body is copied straight into the upload writer, so
+// the file is never held in memory. The whole transfer is bounded by the
+// configured media timeout.
+func (m *MattermostClient) reuploadFile(ctx context.Context, portal *bridgev2.Portal, intent bridgev2.MatrixAPI, fileInfo *model.FileInfo) (id.ContentURIString, *event.EncryptedFileInfo, error) {
+	limit := m.connector.mediaSizeLimit()
+	if limit > 0 && fileInfo.Size > limit {
+		return "", nil, errFileTooLarge
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.connector.Config.mediaTimeout())
+	defer cancel()
+
+	resp, err := m.client.DoAPIGet(ctx, "/files/"+url.PathEscape(fileInfo.Id), "")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	size := fileInfo.Size
+	if resp.ContentLength > 0 {
+		size = resp.ContentLength
+	}
+	if limit > 0 && size > limit {
+		return "", nil, errFileTooLarge
+	}
+
+	return intent.UploadMediaStream(ctx, portal.MXID, size, false, func(w io.Writer) (*bridgev2.FileStreamResult, error) {
+		body := io.Reader(resp.Body)
+		if limit > 0 {
+			// Guard against files growing past the reported size.
+			body = io.LimitReader(resp.Body, limit+1)
+		}
+		n, err := io.Copy(w, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stream file: %w", err)
+		}
+		if limit > 0 && n > limit {
+			return nil, errFileTooLarge
+		}
+		return &bridgev2.FileStreamResult{
+			FileName: fileInfo.Name,
+			MimeType: fileInfo.MimeType,
+		}, nil
+	})
+}
+
+// isFileTooLarge reports whether a reupload failed because the file is over
+// the bridge or homeserver size limit. The appservice intent reports its
+// own pre-upload size check as a plain error, hence the string match.
+func isFileTooLarge(err error) bool {
+	return errors.Is(err, errFileTooLarge) || errors.Is(err, mautrix.MTooLarge) ||
+		strings.Contains(err.Error(), "file too large")
+}
+
+// fileLinkPart builds a notice linking to an attachment on the Mattermost
+// server, sent in place of files that can't be reuploaded.
+func (m *MattermostClient) fileLinkPart(fileInfo *model.FileInfo, partIndex int, tooLarge bool) *bridgev2.ConvertedMessagePart {
+	reason := "could not be bridged"
+	if tooLarge {
+		reason = "is too large to bridge"
+	}
+	body := fmt.Sprintf("📎 %s (%s) %s", fileInfo.Name, formatFileSize(fileInfo.Size), reason)
+	formatted := html.EscapeString(body)
+	if m.serverURL != "" {
+		link := strings.TrimRight(m.serverURL, "/") + "/api/v4/files/" + url.PathEscape(fileInfo.Id) + "?download=1"
+		body += ": " + link
+		formatted += `: <a href="` + html.EscapeString(link) + `">download</a>`
+	}
+
+	return &bridgev2.ConvertedMessagePart{
+		ID:   MakeMessagePartID(partIndex),
+		Type: event.EventMessage,
+		Content: &event.MessageEventContent{
+			MsgType:       event.MsgNotice,
+			Body:          body,
+			Format:        event.FormatHTML,
+			FormattedBody: formatted,
+		},
+		Extra: map[string]any{
+			"fi.mau.mattermost.file_id": fileInfo.Id,
+		},
+		DBMetadata: &MessageMetadata{FileID: fileInfo.Id},
+	}
+}
+
+// formatFileSize renders a byte count for display, e.g. "12.5 MB".
+func formatFileSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
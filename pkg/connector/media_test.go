@@ -0,0 +1,302 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+)
+
+// newMediaTestPortal returns a portal with a Matrix room and a bridge bot
+// that records uploads.
+func newMediaTestPortal(uploadLimit int64) (*bridgev2.Portal, *fakeMatrixBot) {
+	bot := &fakeMatrixBot{uploadLimit: uploadLimit}
+	portal := makeTestPortal("ch1")
+	portal.MXID = "!room:test"
+	portal.Bridge = &bridgev2.Bridge{Bot: bot}
+	return portal, bot
+}
+
+func TestConvertFileToMatrix_Reupload(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	data := []byte("\x89PNG fake image data")
+	fake.Files["f1"] = &model.FileInfo{Id: "f1", Name: "photo.png", MimeType: "image/png", Size: int64(len(data))}
+	fake.FileData["f1"] = data
+
+	mc := newFullTestClient(fake.Server.URL)
+	portal, bot := newMediaTestPortal(0)
+
+	part := mc.convertFileToMatrix(context.Background(), portal, bot, "f1", 1)
+	if part == nil {
+		t.Fatal("expected non-nil part")
+	}
+	if part.Content.MsgType != event.MsgImage {
+		t.Errorf("MsgType: got %v, want MsgImage", part.Content.MsgType)
+	}
+	if part.Content.URL != "mxc://test/upload1" {
+		t.Errorf("URL: got %q, want mxc://test/upload1", part.Content.URL)
+	}
+
+	uploads := bot.Uploads()
+	if len(uploads) != 1 {
+		t.Fatalf("expected 1 upload, got %d", len(uploads))
+	}
+	up := uploads[0]
+	if !bytes.Equal(up.Data, data) {
+		t.Errorf("uploaded data: got %q, want %q", up.Data, data)
+	}
+	if up.FileName != "photo.png" || up.MimeType != "image/png" {
+		t.Errorf("upload metadata: got %q %q", up.FileName, up.MimeType)
+	}
+	if up.RoomID != portal.MXID {
+		t.Errorf("upload room: got %q, want %q", up.RoomID, portal.MXID)
+	}
+}
+
+func TestConvertFileToMatrix_EncryptedRoom(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	data := []byte("confidential video bytes")
+	fake.Files["f1"] = &model.FileInfo{Id: "f1", Name: "clip.mp4", MimeType: "video/mp4", Size: int64(len(data))}
+	fake.FileData["f1"] = data
+
+	mc := newFullTestClient(fake.Server.URL)
+	portal, bot := newMediaTestPortal(0)
+	bot.encrypted = true
+
+	part := mc.convertFileToMatrix(context.Background(), portal, bot, "f1", 1)
+	if part == nil {
+		t.Fatal("expected non-nil part")
+	}
+	content := part.Content
+	if content.URL != "" {
+		t.Errorf("URL should be empty for encrypted media, got %q", content.URL)
+	}
+	if content.File == nil || content.File.URL != "mxc://test/upload1" {
+		t.Fatalf("File: got %+v, want URL mxc://test/upload1", content.File)
+	}
+	if content.MsgType != event.MsgVideo || content.Info.MimeType != "video/mp4" {
+		t.Errorf("content type: got %v %q", content.MsgType, content.Info.MimeType)
+	}
+
+	uploads := bot.Uploads()
+	if len(uploads) != 1 {
+		t.Fatalf("expected 1 upload, got %d", len(uploads))
+	}
+	if bytes.Equal(uploads[0].Data, data) {
+		t.Error("uploaded data should be encrypted")
+	}
+	decrypted, err := roundTripFileInfo(t, content.File).Decrypt(uploads[0].Data)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(decrypted, data) {
+		t.Errorf("decrypted data: got %q, want %q", decrypted, data)
+	}
+}
+
+func TestConvertFileToMatrix_Fallback(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		maxSizeMB   int
+		hsLimit     int64
+		uploadLimit int64
+		size        int
+		noData      bool
+		wantReason  string
+	}{
+		{name: "over config limit", maxSizeMB: 1, size: 2 << 20, wantReason: "is too large to bridge"},
+		{name: "over homeserver limit", hsLimit: 1024, size: 2048, wantReason: "is too large to bridge"},
+		{name: "rejected by homeserver", uploadLimit: 10, size: 20, wantReason: "is too large to bridge"},
+		{name: "download fails", size: 20, noData: true, wantReason: "could not be bridged"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+
+			fake.Files["f1"] = &model.FileInfo{Id: "f1", Name: "big.bin", MimeType: "application/octet-stream", Size: int64(tt.size)}
+			if !tt.noData {
+				fake.FileData["f1"] = make([]byte, tt.size)
+			}
+
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.Media.MaxSizeMB = tt.maxSizeMB
+			mc.connector.SetMaxFileSize(tt.hsLimit)
+			portal, bot := newMediaTestPortal(tt.uploadLimit)
+
+			part := mc.convertFileToMatrix(context.Background(), portal, bot, "f1", 2)
+			if part == nil {
+				t.Fatal("expected non-nil part")
+			}
+			if part.Content.MsgType != event.MsgNotice {
+				t.Errorf("MsgType: got %v, want MsgNotice", part.Content.MsgType)
+			}
+			if part.ID != MakeMessagePartID(2) {
+				t.Errorf("part ID: got %q", part.ID)
+			}
+			if !strings.Contains(part.Content.Body, tt.wantReason) {
+				t.Errorf("body %q should contain %q", part.Content.Body, tt.wantReason)
+			}
+			link := fake.Server.URL + "/api/v4/files/f1?download=1"
+			if !strings.Contains(part.Content.Body, link) {
+				t.Errorf("body %q should contain link %q", part.Content.Body, link)
+			}
+			if len(bot.Uploads()) != 0 {
+				t.Errorf("expected no uploads, got %d", len(bot.Uploads()))
+			}
+		})
+	}
+}
+
+func TestConvertFileToMatrix_Timeout(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	fake.Files["f1"] = &model.FileInfo{Id: "f1", Name: "a.txt", MimeType: "text/plain", Size: 4}
+	fake.FileData["f1"] = []byte("data")
+	fake.FileDelay = time.Minute
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.Media.TimeoutSeconds = 1
+	portal, bot := newMediaTestPortal(0)
+
+	start := time.Now()
+	part := mc.convertFileToMatrix(context.Background(), portal, bot, "f1", 1)
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("reupload took %v, expected the 1s timeout to apply", elapsed)
+	}
+	if part == nil || part.Content.MsgType != event.MsgNotice {
+		t.Fatalf("expected link notice after timeout, got %+v", part)
+	}
+	if !strings.Contains(part.Content.Body, "could not be bridged") {
+		t.Errorf("body %q should explain the failure", part.Content.Body)
+	}
+	if len(bot.Uploads()) != 0 {
+		t.Errorf("expected no uploads, got %d", len(bot.Uploads()))
+	}
+}
+
+func TestConvertPostToMatrix_ReuploadsFiles(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	for i, name := range []string{"a.txt", "b.txt"} {
+		fileID := fmt.Sprintf("f%d", i+1)
+		fake.Files[fileID] = &model.FileInfo{Id: fileID, Name: name, MimeType: "text/plain", Size: 1}
+		fake.FileData[fileID] = []byte{byte('a' + i)}
+	}
+
+	mc := newFullTestClient(fake.Server.URL)
+	portal, bot := newMediaTestPortal(0)
+	post := &model.Post{Id: "p1", Message: "files", FileIds: model.StringArray{"f1", "f2"}}
+
+	msg := mc.convertPostToMatrix(context.Background(), portal, bot, post)
+	if len(msg.Parts) != 3 {
+		t.Fatalf("expected 3 parts, got %d", len(msg.Parts))
+	}
+	for i, part := range msg.Parts[1:] {
+		if part.Content.URL == "" {
+			t.Errorf("file part %d has no URL", i+1)
+		}
+	}
+	if len(bot.Uploads()) != 2 {
+		t.Errorf("expected 2 uploads, got %d", len(bot.Uploads()))
+	}
+}
+
+func TestMediaSizeLimit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		maxSizeMB int
+		hsLimit   int64
+		want      int64
+	}{
+		{name: "unlimited", want: 0},
+		{name: "homeserver only", hsLimit: 50 << 20, want: 50 << 20},
+		{name: "config only", maxSizeMB: 10, want: 10 << 20},
+		{name: "config smaller", maxSizeMB: 10, hsLimit: 50 << 20, want: 10 << 20},
+		{name: "homeserver smaller", maxSizeMB: 100, hsLimit: 50 << 20, want: 50 << 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := &MattermostConnector{Config: Config{Media: MediaConfig{MaxSizeMB: tt.maxSizeMB}}}
+			mc.SetMaxFileSize(tt.hsLimit)
+			if got := mc.mediaSizeLimit(); got != tt.want {
+				t.Errorf("mediaSizeLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMediaTimeout(t *testing.T) {
+	t.Parallel()
+	if got := (&Config{}).mediaTimeout(); got != defaultMediaTimeout {
+		t.Errorf("default: got %v, want %v", got, defaultMediaTimeout)
+	}
+	cfg := &Config{Media: MediaConfig{TimeoutSeconds: 5}}
+	if got := cfg.mediaTimeout(); got != 5*time.Second {
+		t.Errorf("configured: got %v, want 5s", got)
+	}
+}
+
+func TestIsFileTooLarge(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errFileTooLarge, true},
+		{fmt.Errorf("wrapped: %w", errFileTooLarge), true},
+		{mautrix.MTooLarge, true},
+		{errors.New("file too large (60.00 MB > 50.00 MB)"), true},
+		{errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		if got := isFileTooLarge(tt.err); got != tt.want {
+			t.Errorf("isFileTooLarge(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestFormatFileSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{512, "512 B"},
+		{2048, "2.0 KB"},
+		{5 << 20, "5.0 MB"},
+		{3 << 30, "3.0 GB"},
+	}
+	for _, tt := range tests {
+		if got := formatFileSize(tt.size); got != tt.want {
+			t.Errorf("formatFileSize(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
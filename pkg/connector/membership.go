@@ -0,0 +1,251 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// parseUserAddedEvent extracts user_added data. Returns ok=false to skip.
+func (m *MattermostClient) parseUserAddedEvent(evt *model.WebSocketEvent) (channelID, userID string, ok bool) {
+	channelID = evt.GetBroadcast().ChannelId
+	userID, _ = evt.GetData()["user_id"].(string)
+	if channelID == "" || userID == "" {
+		return "", "", false
+	}
+	return channelID, userID, true
+}
+
+// parseUserRemovedEvent extracts user_removed data. Mattermost sends the event
+// to the channel with the removed user in the data, and to the removed user
+// with the channel in the data, so both shapes are accepted. Returns ok=false
+// to skip.
+func (m *MattermostClient) parseUserRemovedEvent(evt *model.WebSocketEvent) (channelID, userID, removerID string, ok bool) {
+	data := evt.GetData()
+	channelID = evt.GetBroadcast().ChannelId
+	if channelID == "" {
+		channelID, _ = data["channel_id"].(string)
+	}
+	userID, _ = data["user_id"].(string)
+	if userID == "" {
+		userID = evt.GetBroadcast().UserId
+	}
+	removerID, _ = data["remover_id"].(string)
+	if channelID == "" || userID == "" {
+		return "", "", "", false
+	}
+	return channelID, userID, removerID, true
+}
+
+// handleUserAdded joins a user's ghost to the portal when they're added to a
+// channel. When the logged-in user is added, the channel is resynced instead,
+// creating the portal if portal_creation allows it.
+func (m *MattermostClient) handleUserAdded(evt *model.WebSocketEvent) {
+	channelID, userID, ok := m.parseUserAddedEvent(evt)
+	if !ok {
+		m.log.Warn().Msg("User added event missing channel or user ID")
+		return
+	}
+	m.log.Debug().
+		Str("channel_id", channelID).
+		Str("user_id", userID).
+		Msg("User added to channel")
+	m.forgetMentionMember(channelID, userID)
+
+	if userID == m.userID {
+		teamID, _ := evt.GetData()["team_id"].(string)
+		m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatResync{
+			EventMeta: simplevent.EventMeta{
+				Type:      bridgev2.RemoteEventChatResync,
+				PortalKey: makePortalKey(channelID),
+				LogContext: func(c zerolog.Context) zerolog.Context {
+					return c.Str("channel_id", channelID)
+				},
+				CreatePortal:   m.allowCreatePortal(m.log.WithContext(context.Background()), channelID, "", teamID, false),
+				PostHandleFunc: m.connector.watchIfRelayMissing,
+			},
+			GetChatInfoFunc: m.GetChatInfo,
+		})
+		return
+	}
+	if m.isExcludedGuest(userID) {
+		return
+	}
+	// Mattermost doesn't say who added the user, so the bridge bot invites.
+	m.queueMemberChange(channelID, bridgev2.EventSender{}, userID, event.MembershipJoin)
+}
+
+// handleUserRemoved removes a user's ghost from the portal when they leave or
+// are removed from a channel. Removals by someone else are sent as kicks by
+// the remover.
+func (m *MattermostClient) handleUserRemoved(evt *model.WebSocketEvent) {
+	channelID, userID, removerID, ok := m.parseUserRemovedEvent(evt)
+	if !ok {
+		m.log.Warn().Msg("User removed event missing channel or user ID")
+		return
+	}
+	// The copy sent to the removed user has no channel broadcast, so the
+	// shard check in handleEvent didn't apply.
+	if !m.connector.OwnsChannel(channelID) {
+		return
+	}
+	m.log.Debug().
+		Str("channel_id", channelID).
+		Str("user_id", userID).
+		Str("remover_id", removerID).
+		Msg("User removed from channel")
+	m.forgetMentionMember(channelID, userID)
+
+	if removerID == "" {
+		removerID = userID
+	}
+	m.queueMemberChange(channelID, m.senderFor(removerID), userID, event.MembershipLeave)
+}
+
+// queueMemberChange queues a membership change for one user of a portal.
+// Once the channel's portal handled it, the change is queued for the
+// channel's thread rooms too, so users who leave the channel stop seeing
+// its threads.
+func (m *MattermostClient) queueMemberChange(channelID string, sender bridgev2.EventSender, userID string, membership event.Membership) {
+	evt := m.memberChangeEvent(makePortalKey(channelID), sender, userID, membership)
+	evt.PostHandleFunc = func(ctx context.Context, _ *bridgev2.Portal) {
+		keys, err := m.connector.threadPortalKeys(ctx, channelID)
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to get thread rooms for member change")
+			return
+		}
+		for _, key := range keys {
+			m.eventSender.QueueRemoteEvent(m.userLogin, m.memberChangeEvent(key, sender, userID, membership))
+		}
+	}
+	m.eventSender.QueueRemoteEvent(m.userLogin, evt)
+}
+
+// memberChangeEvent builds the remote event of a membership change for one
+// user of a portal.
+func (m *MattermostClient) memberChangeEvent(portalKey networkid.PortalKey, sender bridgev2.EventSender, userID string, membership event.Membership) *simplevent.ChatInfoChange {
+	channelID := ParsePortalID(portalKey.ID)
+	return &simplevent.ChatInfoChange{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatInfoChange,
+			PortalKey: portalKey,
+			Sender:    sender,
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("channel_id", channelID).Str("member_id", userID)
+			},
+		},
+		ChatInfoChange: &bridgev2.ChatInfoChange{
+			MemberChanges: &bridgev2.ChatMemberList{
+				MemberMap: map[networkid.UserID]bridgev2.ChatMember{
+					MakeUserID(userID): {
+						EventSender: m.senderFor(userID),
+						Membership:  membership,
+					},
+				},
+			},
+		},
+	}
+}
+
+// memberTargetUserID returns the Mattermost user behind the target of a Matrix
+// membership change: a ghost, a Matrix user with a login, or a Matrix user
+// mapped to a puppet bot. It returns "" for other Matrix users.
+func (m *MattermostClient) memberTargetUserID(msg *bridgev2.MatrixMembershipChange) string {
+	if userID := targetUserID(msg.Target); userID != "" {
+		return userID
+	}
+	if msg.Event == nil || msg.Event.StateKey == nil {
+		return ""
+	}
+	return m.puppetTargetUserID(id.UserID(*msg.Event.StateKey))
+}
+
+// targetUserID returns the Mattermost user behind a ghost or a Matrix user
+// with a login, or "" for other targets.
+func targetUserID(target bridgev2.GhostOrUserLogin) string {
+	switch target := target.(type) {
+	case *bridgev2.Ghost:
+		return ParseUserID(target.ID)
+	case *bridgev2.UserLogin:
+		if meta, ok := target.Metadata.(*UserLoginMetadata); ok {
+			return meta.UserID
+		}
+	}
+	return ""
+}
+
+// puppetTargetUserID returns the puppet bot a Matrix user is mapped to, or
+// "" if they have none.
+func (m *MattermostClient) puppetTargetUserID(mxid id.UserID) string {
+	m.connector.puppetMu.RLock()
+	defer m.connector.puppetMu.RUnlock()
+	if puppet, ok := m.connector.Puppets[mxid]; ok {
+		return puppet.UserID
+	}
+	return ""
+}
+
+// HandleMatrixMembership adds users to the channel when they're invited or
+// join the room, and removes them when they're kicked, banned or leave.
+// Changes are made with the same client as room name and topic changes, or
+// the relay account for relayed users without a puppet, and changes to
+// Matrix users with no Mattermost account are ignored.
+func (m *MattermostClient) HandleMatrixMembership(ctx context.Context, msg *bridgev2.MatrixMembershipChange) (bool, error) {
+	var add bool
+	switch {
+	case msg.Type.To == event.MembershipInvite,
+		msg.Type.To == event.MembershipJoin && msg.Type.From != event.MembershipJoin:
+		add = true
+	case (msg.Type.To == event.MembershipLeave || msg.Type.To == event.MembershipBan) &&
+		(msg.Type.From == event.MembershipJoin || msg.Type.From == event.MembershipInvite):
+		add = false
+	default:
+		return false, nil
+	}
+	mmUserID := m.memberTargetUserID(msg)
+	if mmUserID == "" {
+		return false, nil
+	}
+	client, senderID, err := m.channelEditClient(msg.OrigSender, msg.Portal, true)
+	if err != nil {
+		return false, err
+	}
+
+	channelID := ParsePortalID(msg.Portal.ID)
+	var resp *model.Response
+	if add {
+		_, resp, err = client.AddChannelMember(ctx, channelID, mmUserID)
+	} else {
+		resp, err = client.RemoveUserFromChannel(ctx, channelID, mmUserID)
+	}
+	if err != nil {
+		m.checkPuppetFailure(ctx, senderID, resp, err)
+		if add {
+			return false, fmt.Errorf("failed to add channel member: %w", err)
+		}
+		return false, fmt.Errorf("failed to remove channel member: %w", err)
+	}
+	m.recordPuppetSuccess(senderID)
+	log := zerolog.Ctx(ctx).Info().
+		Str("channel_id", channelID).
+		Str("mm_user_id", mmUserID).
+		Str("actor_id", senderID).
+		Bool("added", add)
+	if msg.OrigSender != nil && senderID == m.userID {
+		log = log.Stringer("relayed_for", msg.OrigSender.UserID)
+	}
+	log.Msg("Updated Mattermost channel membership from Matrix")
+	return true, nil
+}
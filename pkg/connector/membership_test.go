@@ -0,0 +1,332 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+)
+
+// userRemovedForUser builds the user_removed copy Mattermost sends to the
+// removed user, which carries the channel in its data.
+func userRemovedForUser(userID, channelID, removerID string) *model.WebSocketEvent {
+	evt := model.NewWebSocketEvent(model.WebsocketEventUserRemoved, "", "", userID, nil, "")
+	return evt.SetData(map[string]any{"channel_id": channelID, "remover_id": removerID})
+}
+
+func TestParseUserAddedEvent(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	tests := []struct {
+		name      string
+		channelID string
+		data      map[string]any
+		wantOk    bool
+	}{
+		{"valid", "ch1", map[string]any{"user_id": "u1", "team_id": "t1"}, true},
+		{"missing user", "ch1", map[string]any{"team_id": "t1"}, false},
+		{"missing channel", "", map[string]any{"user_id": "u1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			channelID, userID, ok := client.parseUserAddedEvent(newWebSocketEvent(model.WebsocketEventUserAdded, tt.channelID, tt.data))
+			if ok != tt.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && (channelID != "ch1" || userID != "u1") {
+				t.Errorf("got %q, %q; want ch1, u1", channelID, userID)
+			}
+		})
+	}
+}
+
+func TestParseUserRemovedEvent(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	tests := []struct {
+		name        string
+		evt         *model.WebSocketEvent
+		wantChannel string
+		wantUser    string
+		wantRemover string
+		wantOk      bool
+	}{
+		{
+			name:        "channel broadcast",
+			evt:         newWebSocketEvent(model.WebsocketEventUserRemoved, "ch1", map[string]any{"user_id": "u1", "remover_id": "admin"}),
+			wantChannel: "ch1", wantUser: "u1", wantRemover: "admin", wantOk: true,
+		},
+		{
+			name:        "user broadcast",
+			evt:         userRemovedForUser("u1", "ch1", "u1"),
+			wantChannel: "ch1", wantUser: "u1", wantRemover: "u1", wantOk: true,
+		},
+		{
+			name: "missing user",
+			evt:  newWebSocketEvent(model.WebsocketEventUserRemoved, "ch1", map[string]any{}),
+		},
+		{
+			name: "missing channel",
+			evt:  newWebSocketEvent(model.WebsocketEventUserRemoved, "", map[string]any{"user_id": "u1"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			channelID, userID, removerID, ok := client.parseUserRemovedEvent(tt.evt)
+			if channelID != tt.wantChannel || userID != tt.wantUser || removerID != tt.wantRemover || ok != tt.wantOk {
+				t.Errorf("got %q, %q, %q, %v; want %q, %q, %q, %v",
+					channelID, userID, removerID, ok, tt.wantChannel, tt.wantUser, tt.wantRemover, tt.wantOk)
+			}
+		})
+	}
+}
+
+// memberChange returns the single membership change queued by a handler.
+func memberChange(t *testing.T, mc *MattermostClient) (*simplevent.ChatInfoChange, bridgev2.ChatMember) {
+	t.Helper()
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	change, ok := events[0].(*simplevent.ChatInfoChange)
+	if !ok {
+		t.Fatalf("expected ChatInfoChange, got %T", events[0])
+	}
+	members := change.ChatInfoChange.MemberChanges
+	if members == nil || len(members.MemberMap) != 1 {
+		t.Fatalf("expected 1 member change, got %+v", members)
+	}
+	for _, member := range members.MemberMap {
+		return change, member
+	}
+	return nil, bridgev2.ChatMember{}
+}
+
+func TestHandleUserAdded(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventUserAdded, "ch1", map[string]any{"user_id": "bob-id"}))
+
+	change, member := memberChange(t, mc)
+	if change.PortalKey != makePortalKey("ch1") {
+		t.Errorf("portal key: got %+v", change.PortalKey)
+	}
+	if change.Sender.Sender != "" {
+		t.Errorf("sender: got %q, want the bridge bot", change.Sender.Sender)
+	}
+	if member.Sender != MakeUserID("bob-id") || member.Membership != event.MembershipJoin {
+		t.Errorf("member: got %+v, want bob-id joined", member)
+	}
+}
+
+func TestHandleUserAdded_Self(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventUserAdded, "ch1", map[string]any{"user_id": mc.userID}))
+
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	resync, ok := events[0].(*simplevent.ChatResync)
+	if !ok {
+		t.Fatalf("expected ChatResync, got %T", events[0])
+	}
+	if !resync.CreatePortal || resync.PortalKey != makePortalKey("ch1") {
+		t.Errorf("resync: got %+v, want portal ch1 created", resync.EventMeta)
+	}
+}
+
+func TestHandleUserRemoved(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		evt        *model.WebSocketEvent
+		wantSender string
+	}{
+		{
+			name:       "kicked",
+			evt:        newWebSocketEvent(model.WebsocketEventUserRemoved, "ch1", map[string]any{"user_id": "bob-id", "remover_id": "admin-id"}),
+			wantSender: "admin-id",
+		},
+		{
+			name:       "left",
+			evt:        newWebSocketEvent(model.WebsocketEventUserRemoved, "ch1", map[string]any{"user_id": "bob-id", "remover_id": "bob-id"}),
+			wantSender: "bob-id",
+		},
+		{
+			name:       "no remover",
+			evt:        newWebSocketEvent(model.WebsocketEventUserRemoved, "ch1", map[string]any{"user_id": "bob-id"}),
+			wantSender: "bob-id",
+		},
+		{
+			name:       "sent to removed user",
+			evt:        userRemovedForUser("bob-id", "ch1", "admin-id"),
+			wantSender: "admin-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://unused")
+			mc.handleEvent(tt.evt)
+
+			change, member := memberChange(t, mc)
+			if change.Sender.Sender != MakeUserID(tt.wantSender) {
+				t.Errorf("sender: got %q, want %q", change.Sender.Sender, tt.wantSender)
+			}
+			if member.Sender != MakeUserID("bob-id") || member.Membership != event.MembershipLeave {
+				t.Errorf("member: got %+v, want bob-id left", member)
+			}
+		})
+	}
+}
+
+func membershipChange(portal *bridgev2.Portal, target bridgev2.GhostOrUserLogin, stateKey string, origSender *bridgev2.OrigSender, changeType bridgev2.MembershipChangeType) *bridgev2.MatrixMembershipChange {
+	return &bridgev2.MatrixMembershipChange{
+		MatrixRoomMeta: bridgev2.MatrixRoomMeta[*event.MemberEventContent]{
+			MatrixEventBase: bridgev2.MatrixEventBase[*event.MemberEventContent]{
+				Event:      &event.Event{Sender: "@me:localhost", StateKey: &stateKey},
+				Content:    &event.MemberEventContent{Membership: changeType.To},
+				Portal:     portal,
+				OrigSender: origSender,
+			},
+		},
+		Target: target,
+		Type:   changeType,
+	}
+}
+
+func ghostTarget(mmUserID string) *bridgev2.Ghost {
+	return &bridgev2.Ghost{Ghost: &database.Ghost{ID: MakeUserID(mmUserID)}}
+}
+
+func TestHandleMatrixMembership(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		target     bridgev2.GhostOrUserLogin
+		stateKey   string
+		changeType bridgev2.MembershipChangeType
+		wantMember string
+		wantCalled bool
+	}{
+		{name: "invite ghost", target: ghostTarget("bob-id"), stateKey: "@mattermost_bob-id:localhost", changeType: bridgev2.Invite, wantMember: "bob-id", wantCalled: true},
+		{name: "kick ghost", target: ghostTarget("carol-id"), stateKey: "@mattermost_carol-id:localhost", changeType: bridgev2.Kick, wantCalled: true},
+		{name: "ban ghost", target: ghostTarget("carol-id"), stateKey: "@mattermost_carol-id:localhost", changeType: bridgev2.BanJoined, wantCalled: true},
+		{name: "revoke invite", target: ghostTarget("carol-id"), stateKey: "@mattermost_carol-id:localhost", changeType: bridgev2.RevokeInvite, wantCalled: true},
+		{name: "puppet user joins", stateKey: "@alice:localhost", changeType: bridgev2.MembershipChangeType{From: event.MembershipInvite, To: event.MembershipJoin, IsSelf: true}, wantMember: "bot-alice", wantCalled: true},
+		{name: "plain Matrix user", stateKey: "@stranger:localhost", changeType: bridgev2.Invite},
+		{name: "profile change", target: ghostTarget("carol-id"), stateKey: "@mattermost_carol-id:localhost", changeType: bridgev2.ProfileChange},
+		{name: "unban", target: ghostTarget("carol-id"), stateKey: "@mattermost_carol-id:localhost", changeType: bridgev2.Unban},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.ChannelMembers["ch1"] = model.ChannelMembers{{ChannelId: "ch1", UserId: "carol-id"}}
+			mc, _ := newHealthTestClient(fake)
+
+			changed, err := mc.HandleMatrixMembership(context.Background(), membershipChange(makeTestPortal("ch1"), tt.target, tt.stateKey, nil, tt.changeType))
+			if err != nil {
+				t.Fatalf("HandleMatrixMembership: %v", err)
+			}
+			if changed != tt.wantCalled {
+				t.Errorf("changed = %v, want %v", changed, tt.wantCalled)
+			}
+			if got := len(fake.Calls()) != 0; got != tt.wantCalled {
+				t.Fatalf("API called = %v, want %v (%+v)", got, tt.wantCalled, fake.Calls())
+			}
+			if !tt.wantCalled {
+				return
+			}
+			members := map[string]bool{}
+			for _, member := range fake.ChannelMembers["ch1"] {
+				members[member.UserId] = true
+			}
+			if tt.wantMember != "" && !members[tt.wantMember] {
+				t.Errorf("%s should be added, members: %v", tt.wantMember, members)
+			}
+			if tt.wantMember == "" && members["carol-id"] {
+				t.Errorf("carol-id should be removed, members: %v", members)
+			}
+		})
+	}
+}
+
+func TestHandleMatrixMembership_Rejected(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		roomType   database.RoomType
+		origSender *bridgev2.OrigSender
+		wantErr    error
+	}{
+		{name: "direct message", roomType: database.RoomTypeDM, wantErr: errChannelEditUnsupported},
+		{name: "team space", roomType: database.RoomTypeSpace, wantErr: errTeamSpaceUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			mc := newFullTestClient(fake.Server.URL)
+			portal := makeTestPortal("ch1")
+			portal.RoomType = tt.roomType
+
+			msg := membershipChange(portal, ghostTarget("bob-id"), "@mattermost_bob-id:localhost", tt.origSender, bridgev2.Invite)
+			if _, err := mc.HandleMatrixMembership(context.Background(), msg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(fake.Calls()) != 0 {
+				t.Errorf("expected no API calls, got %+v", fake.Calls())
+			}
+		})
+	}
+}
+
+func TestHandleMatrixMembership_Relayed(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+
+	// A relayed user without a puppet invites through the relay account.
+	origSender := &bridgev2.OrigSender{UserID: "@stranger:localhost"}
+	msg := membershipChange(makeTestPortal("ch1"), ghostTarget("bob-id"), "@mattermost_bob-id:localhost", origSender, bridgev2.Invite)
+	if _, err := mc.HandleMatrixMembership(context.Background(), msg); err != nil {
+		t.Fatalf("HandleMatrixMembership: %v", err)
+	}
+	if members := fake.ChannelMembers["ch1"]; len(members) != 1 || members[0].UserId != "bob-id" {
+		t.Errorf("members: got %+v, want bob-id", members)
+	}
+}
+
+func TestHandleMatrixMembership_RelayedPuppet(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, _ := newHealthTestClient(fake)
+
+	origSender := &bridgev2.OrigSender{UserID: "@alice:localhost"}
+	msg := membershipChange(makeTestPortal("ch1"), ghostTarget("bob-id"), "@mattermost_bob-id:localhost", origSender, bridgev2.Invite)
+	if _, err := mc.HandleMatrixMembership(context.Background(), msg); err != nil {
+		t.Fatalf("HandleMatrixMembership: %v", err)
+	}
+	if members := fake.ChannelMembers["ch1"]; len(members) != 1 || members[0].UserId != "bob-id" {
+		t.Errorf("members: got %+v, want bob-id", members)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/id"
+)
+
+// resetMentionUsers forgets the users looked up for mentions, so renamed
+// users are looked up again.
+func (m *MattermostClient) resetMentionUsers() {
+	m.mentionMu.Lock()
+	m.mentionUsers.clear()
+	m.mentionUsernames.clear()
+	m.mentionMembers.clear()
+	m.mentionMu.Unlock()
+}
+
+// forgetMentionMember drops the cached channel membership of a user, after
+// they joined or left the channel.
+func (m *MattermostClient) forgetMentionMember(channelID, userID string) {
+	m.mentionMu.Lock()
+	m.mentionMembers.delete(channelMemberKey{channel: channelID, user: userID})
+	m.mentionMu.Unlock()
+}
+
+// isMentionMember reports whether a mentioned user is a member of a
+// channel, caching the answer. Failed lookups other than a missing member
+// count as not a member and are retried on the next mention.
+func (m *MattermostClient) isMentionMember(ctx context.Context, channelID, userID string) bool {
+	key := channelMemberKey{channel: channelID, user: userID}
+	m.mentionMu.Lock()
+	member, ok := m.mentionMembers.get(key)
+	m.mentionMu.Unlock()
+	if ok {
+		return member
+	}
+	_, resp, err := m.client.GetChannelMember(ctx, channelID, userID, "")
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		m.log.Warn().Err(err).Str("channel_id", channelID).Str("user_id", userID).Msg("Failed to look up mentioned channel member")
+		return false
+	}
+	m.mentionMu.Lock()
+	defer m.mentionMu.Unlock()
+	if m.mentionMembers == nil {
+		m.mentionMembers = newClientCache[channelMemberKey, bool](m, "mention_members", true)
+	}
+	m.mentionMembers.set(key, err == nil)
+	return err == nil
+}
+
+// rememberMentionUser caches a user looked up for a mention. A nil user marks
+// a username that doesn't exist.
+func (m *MattermostClient) rememberMentionUser(username string, user *model.User) {
+	m.mentionMu.Lock()
+	defer m.mentionMu.Unlock()
+	if m.mentionUsers == nil {
+		m.mentionUsers = newClientCache[string, *model.User](m, "mention_users", true)
+		m.mentionUsernames = newClientCache[string, string](m, "mention_usernames", true)
+	}
+	m.mentionUsers.set(username, user)
+	if user != nil {
+		m.mentionUsernames.set(user.Id, user.Username)
+	}
+}
+
+// mentionUserByUsername returns the Mattermost user with a username, or nil
+// if there is none or the lookup fails.
+func (m *MattermostClient) mentionUserByUsername(ctx context.Context, username string) *model.User {
+	m.mentionMu.Lock()
+	user, ok := m.mentionUsers.get(username)
+	m.mentionMu.Unlock()
+	if ok {
+		return user
+	}
+	user, resp, err := m.client.GetUserByUsername(ctx, username, "")
+	if err != nil {
+		// Unknown usernames are cached so "@" in ordinary text isn't looked
+		// up again; other failures are retried on the next mention.
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			m.rememberMentionUser(username, nil)
+		} else {
+			m.log.Warn().Err(err).Str("username", username).Msg("Failed to look up mentioned user")
+		}
+		return nil
+	}
+	m.rememberMentionUser(username, user)
+	return user
+}
+
+// cachedMentionUser returns the cached user of a Mattermost user ID, or nil
+// if it wasn't looked up for a mention.
+func (m *MattermostClient) cachedMentionUser(userID string) *model.User {
+	m.mentionMu.Lock()
+	defer m.mentionMu.Unlock()
+	username, ok := m.mentionUsernames.get(userID)
+	if !ok {
+		return nil
+	}
+	user, _ := m.mentionUsers.get(username)
+	return user
+}
+
+// mentionUsername returns the username of a Mattermost user ID. Lookups
+// share the cache of the users looked up for mentions.
+func (m *MattermostClient) mentionUsername(ctx context.Context, userID string) (string, bool) {
+	m.mentionMu.Lock()
+	username, ok := m.mentionUsernames.get(userID)
+	m.mentionMu.Unlock()
+	if ok {
+		return username, true
+	}
+	user, _, err := m.client.GetUser(ctx, userID, "")
+	if err != nil {
+		m.log.Warn().Err(err).Str("user_id", userID).Msg("Failed to look up username")
+		return "", false
+	}
+	m.rememberMentionUser(user.Username, user)
+	return user.Username, true
+}
+
+// matrixUserFor returns the Matrix user that stands for a Mattermost user:
+// the logged-in Matrix user for the login's own account, the Matrix user of
+// a puppet bot, or otherwise the user's ghost.
+func (m *MattermostClient) matrixUserFor(userID string) (id.UserID, bool) {
+	if userID == m.userID && m.userLogin != nil {
+		return m.userLogin.UserMXID, true
+	}
+	m.connector.puppetMu.RLock()
+	for mxid, puppet := range m.connector.Puppets {
+		if puppet.UserID == userID {
+			m.connector.puppetMu.RUnlock()
+			return mxid, true
+		}
+	}
+	m.connector.puppetMu.RUnlock()
+	if m.connector.Bridge == nil || m.connector.Bridge.Matrix == nil {
+		return "", false
+	}
+	return m.connector.Bridge.Matrix.GhostIntent(MakeUserID(userID)).GetMXID(), true
+}
+
+// mentionResolver returns the resolver that turns @username mentions in a
+// channel's posts into Matrix pills. Only members of the channel who aren't
+// excluded guests are resolved, as Mattermost only notifies members;
+// memberships are cached by isMentionMember. Without
+// a client, mentions are left as text.
+func (m *MattermostClient) mentionResolver(ctx context.Context, channelID string) mattermostfmt.MentionResolver {
+	if m.client == nil {
+		return nil
+	}
+	return func(username string) (id.UserID, bool) {
+		user := m.mentionUserByUsername(ctx, username)
+		if user == nil || m.isExcludedGuest(user.Id) {
+			return "", false
+		}
+		if !m.isMentionMember(ctx, channelID, user.Id) {
+			return "", false
+		}
+		return m.matrixUserFor(user.Id)
+	}
+}
+
+// matrixMentionResolver returns the resolver that turns Matrix user pills
+// into @username mentions: pills of the logged-in Matrix user, puppeted
+// Matrix users and Mattermost ghosts. Other users are left as links.
+func (m *MattermostClient) matrixMentionResolver(ctx context.Context) matrixfmt.MentionResolver {
+	if m.client == nil {
+		return nil
+	}
+	return func(mxid id.UserID) (string, bool) {
+		if m.userLogin != nil && mxid == m.userLogin.UserMXID {
+			return m.mentionUsername(ctx, m.userID)
+		}
+		m.connector.puppetMu.RLock()
+		puppet, ok := m.connector.Puppets[mxid]
+		m.connector.puppetMu.RUnlock()
+		if ok && puppet.Username != "" {
+			return puppet.Username, true
+		}
+		if m.connector.Bridge == nil || m.connector.Bridge.Matrix == nil {
+			return "", false
+		}
+		ghostID, ok := m.connector.Bridge.Matrix.ParseGhostMXID(mxid)
+		if !ok {
+			return "", false
+		}
+		return m.mentionUsername(ctx, ParseUserID(ghostID))
+	}
+}
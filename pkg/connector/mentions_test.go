@@ -0,0 +1,189 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// ghostMatrixConnector names the ghost of Mattermost user X @mm_X:example.com.
+type ghostMatrixConnector struct {
+	nopMatrixConnector
+}
+
+func (ghostMatrixConnector) GhostIntent(userID networkid.UserID) bridgev2.MatrixAPI {
+	return ghostIntent{mxid: id.UserID("@mm_" + ParseUserID(userID) + ":example.com")}
+}
+
+func (ghostMatrixConnector) ParseGhostMXID(userID id.UserID) (networkid.UserID, bool) {
+	localpart, ok := strings.CutPrefix(strings.TrimSuffix(string(userID), ":example.com"), "@mm_")
+	if !ok {
+		return "", false
+	}
+	return MakeUserID(localpart), true
+}
+
+// ghostIntent is a ghost's bridgev2.MatrixAPI that only knows its MXID.
+type ghostIntent struct {
+	bridgev2.MatrixAPI
+	mxid id.UserID
+}
+
+func (g ghostIntent) GetMXID() id.UserID { return g.mxid }
+
+// newMentionTestClient returns a client whose fake server knows alice and bob
+// (not a member of ch1), the logged-in user, and a puppet bot, all but bob in
+// ch1. The puppet bot stands for @puppet:example.com.
+func newMentionTestClient(t *testing.T) (*MattermostClient, *fakeMM) {
+	t.Helper()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	for _, user := range []*model.User{
+		{Id: "alice-id", Username: "alice"},
+		{Id: "bob-id", Username: "bob"},
+		{Id: "my-user-id", Username: "me"},
+		{Id: "puppet-id", Username: "puppetbot"},
+	} {
+		fake.Users[user.Id] = user
+	}
+	fake.ChannelMembers["ch1"] = model.ChannelMembers{
+		{ChannelId: "ch1", UserId: "alice-id"},
+		{ChannelId: "ch1", UserId: "my-user-id"},
+		{ChannelId: "ch1", UserId: "puppet-id"},
+	}
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Bridge.Matrix = ghostMatrixConnector{}
+	mc.connector.Puppets["@puppet:example.com"] = &PuppetClient{MXID: "@puppet:example.com", UserID: "puppet-id", Username: "puppetbot"}
+	mc.userLogin = &bridgev2.UserLogin{UserLogin: &database.UserLogin{UserMXID: "@me:example.com"}}
+	return mc, fake
+}
+
+func TestMentionResolver(t *testing.T) {
+	t.Parallel()
+	mc, fake := newMentionTestClient(t)
+	resolve := mc.mentionResolver(context.Background(), "ch1")
+	tests := []struct {
+		username string
+		want     id.UserID
+		wantOK   bool
+	}{
+		{"alice", "@mm_alice-id:example.com", true},
+		{"me", "@me:example.com", true},
+		{"puppetbot", "@puppet:example.com", true},
+		// Not a member of the channel.
+		{"bob", "", false},
+		{"carol", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := resolve(tt.username)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("resolve(%q): got %q, %v, want %q, %v", tt.username, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	// Users, including unknown ones, and memberships are looked up once,
+	// also by the resolvers of later messages.
+	before := len(fake.Calls())
+	resolve = mc.mentionResolver(context.Background(), "ch1")
+	for _, username := range []string{"alice", "bob", "carol"} {
+		resolve(username)
+	}
+	if lookups := len(fake.Calls()) - before; lookups != 0 {
+		t.Errorf("expected no requests, got %d", lookups)
+	}
+
+	// Joining the channel drops the cached membership.
+	fake.mu.Lock()
+	fake.ChannelMembers["ch1"] = append(fake.ChannelMembers["ch1"], model.ChannelMember{ChannelId: "ch1", UserId: "bob-id"})
+	fake.mu.Unlock()
+	mc.handleUserAdded(newWebSocketEvent(model.WebsocketEventUserAdded, "ch1", map[string]any{"user_id": "bob-id"}))
+	if got, ok := resolve("bob"); !ok || got != "@mm_bob-id:example.com" {
+		t.Errorf("resolve(bob) after joining: got %q, %v", got, ok)
+	}
+}
+
+func TestMentionResolver_ExcludedGuest(t *testing.T) {
+	t.Parallel()
+	mc, fake := newMentionTestClient(t)
+	fake.Users["alice-id"].Roles = model.SystemGuestRoleId
+	mc.connector.Config.Guests.Exclude = true
+
+	if _, ok := mc.mentionResolver(context.Background(), "ch1")("alice"); ok {
+		t.Error("excluded guests should not be mentioned")
+	}
+}
+
+func TestConvertPostToMatrix_Mentions(t *testing.T) {
+	t.Parallel()
+	mc, _ := newMentionTestClient(t)
+	post := &model.Post{Id: "p1", ChannelId: "ch1", Message: "@alice, @bob: lunch?"}
+
+	msg := mc.convertPostToMatrix(context.Background(), nil, nil, post)
+	content := msg.Parts[0].Content
+	if content.Body != post.Message {
+		t.Errorf("Body: got %q, want %q", content.Body, post.Message)
+	}
+	wantHTML := `<a href="https://matrix.to/#/@mm_alice-id:example.com">@alice</a>, @bob: lunch?`
+	if content.Format != event.FormatHTML || content.FormattedBody != wantHTML {
+		t.Errorf("FormattedBody: got %q, want %q", content.FormattedBody, wantHTML)
+	}
+	if content.Mentions == nil || !slices.Equal(content.Mentions.UserIDs, []id.UserID{"@mm_alice-id:example.com"}) {
+		t.Errorf("Mentions: got %+v", content.Mentions)
+	}
+
+	edit := mc.convertEditToMatrix(context.Background(), nil, nil, post, nil)
+	if got := edit.ModifiedParts[0].Content.Mentions; got == nil || len(got.UserIDs) != 1 {
+		t.Errorf("edit Mentions: got %+v", got)
+	}
+}
+
+func TestMatrixMentionResolver(t *testing.T) {
+	t.Parallel()
+	mc, _ := newMentionTestClient(t)
+	resolve := mc.matrixMentionResolver(context.Background())
+	tests := []struct {
+		mxid   id.UserID
+		want   string
+		wantOK bool
+	}{
+		{"@mm_alice-id:example.com", "alice", true},
+		{"@me:example.com", "me", true},
+		{"@puppet:example.com", "puppetbot", true},
+		{"@mm_unknown-id:example.com", "", false},
+		{"@stranger:example.com", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := resolve(tt.mxid)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("resolve(%q): got %q, %v, want %q, %v", tt.mxid, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestMatrixfmtParse_Mentions(t *testing.T) {
+	t.Parallel()
+	mc, _ := newMentionTestClient(t)
+	content := &event.MessageEventContent{
+		MsgType:       event.MsgText,
+		Body:          "Alice: ping",
+		Format:        event.FormatHTML,
+		FormattedBody: `<a href="https://matrix.to/#/@mm_alice-id:example.com">Alice</a>: ping`,
+	}
+	if got := matrixfmtParseWithOptions(content, mc.matrixFormatOptions(context.Background(), nil, "")); got != "@alice: ping" {
+		t.Errorf("got %q, want %q", got, "@alice: ping")
+	}
+}
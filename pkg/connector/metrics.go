@@ -0,0 +1,223 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Directions of bridged messages, the direction label of
+// mautrix_mattermost_messages_bridged_total.
+const (
+	directionToMattermost = "matrix_to_mattermost"
+	directionToMatrix     = "mattermost_to_matrix"
+)
+
+// Echo prevention layers, the layer label of
+// mautrix_mattermost_echo_dropped_total. See doc/echo-prevention.md.
+const (
+	echoLayerBridgeMarker   = "bridge_marker"
+	echoLayerOwnUser        = "own_user"
+	echoLayerSystemMessage  = "system_message"
+	echoLayerPuppet         = "puppet"
+	echoLayerBridgeUsername = "bridge_username"
+	echoLayerSystemUser     = "system_user"
+)
+
+// Outcomes of Mattermost 429 responses, the result label of
+// mautrix_mattermost_api_rate_limited_total.
+const (
+	rateLimitRetried = "retried"
+	rateLimitGaveUp  = "gave_up"
+)
+
+// Histogram bucket upper bounds, in seconds.
+var (
+	apiLatencyBuckets       = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	backfillDurationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+)
+
+// counterVec is a counter with one label.
+type counterVec struct {
+	mu     sync.Mutex
+	values map[string]uint64
+}
+
+// inc adds one to the counter for a label value.
+func (c *counterVec) inc(value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values == nil {
+		c.values = make(map[string]uint64)
+	}
+	c.values[value]++
+}
+
+// get returns the counter for a label value.
+func (c *counterVec) get(value string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[value]
+}
+
+// write writes the counter in the Prometheus text exposition format, one
+// sample per label value seen, sorted by value.
+func (c *counterVec) write(w io.Writer, name, label, help string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(w, "%s{%s=%q} %d\n", name, label, key, c.values[key])
+	}
+}
+
+// histogram counts observed durations in buckets.
+type histogram struct {
+	buckets []float64
+
+	mu     sync.Mutex
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+// observe records a duration.
+func (h *histogram) observe(d time.Duration) {
+	seconds := d.Seconds()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, bound := range h.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// write writes the histogram in the Prometheus text exposition format.
+func (h *histogram) write(w io.Writer, name, help string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	for i, bound := range h.buckets {
+		_, _ = fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", name, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+	}
+	_, _ = fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n%s_sum %g\n%s_count %d\n", name, h.count, name, h.sum, name, h.count)
+}
+
+// bridgeMetrics counts what the bridge does, for GET /metrics.
+type bridgeMetrics struct {
+	// messages counts messages bridged, by direction.
+	messages counterVec
+	// echoDrops counts Mattermost events dropped by echo prevention, by
+	// layer.
+	echoDrops counterVec
+	// duplicateDrops counts Mattermost events dropped because they were
+	// already queued, by event type.
+	duplicateDrops counterVec
+	// wsReconnects counts WebSocket reconnects, by result.
+	wsReconnects counterVec
+	// puppetAuthFailures counts rejected puppet tokens, by reason.
+	puppetAuthFailures counterVec
+	// apiLatency is the duration of Mattermost API requests, without the
+	// pacing wait and per 429 retry.
+	apiLatency *histogram
+	// apiWaiting is how many Mattermost API requests are waiting for their
+	// client's rate limit.
+	apiWaiting atomic.Int64
+	// apiRateLimited counts 429 responses from Mattermost, by result.
+	apiRateLimited counterVec
+	// backfillDuration is the duration of backfill fetches.
+	backfillDuration *histogram
+	// caches counts the entries and evictions of the login caches.
+	caches cacheMetrics
+}
+
+// metrics returns the connector's metrics, created on first use.
+func (mc *MattermostConnector) metrics() *bridgeMetrics {
+	mc.metricsOnce.Do(func() {
+		mc.bridgeMetrics = &bridgeMetrics{
+			apiLatency:       newHistogram(apiLatencyBuckets),
+			backfillDuration: newHistogram(backfillDurationBuckets),
+		}
+	})
+	return mc.bridgeMetrics
+}
+
+// writeMetric writes one metric in the Prometheus text exposition format.
+func writeMetric(w io.Writer, name, kind, help string, value any) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+}
+
+// HandleMetrics is an HTTP handler for GET /metrics. It reports bridge
+// activity and the event queue in the Prometheus text format; the queue
+// metrics are omitted when the queue is disabled.
+func (mc *MattermostConnector) HandleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mc.ctxLog(r.Context()).Debug().
+		Str("remote_addr", r.RemoteAddr).
+		Msg("Metrics requested")
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics := mc.metrics()
+	metrics.messages.write(w, "mautrix_mattermost_messages_bridged_total", "direction",
+		"Messages bridged, by direction.")
+	metrics.echoDrops.write(w, "mautrix_mattermost_echo_dropped_total", "layer",
+		"Mattermost events dropped by echo prevention, by layer.")
+	metrics.duplicateDrops.write(w, "mautrix_mattermost_duplicate_events_dropped_total", "event",
+		"Mattermost events dropped because they were already queued, by event type.")
+	metrics.wsReconnects.write(w, "mautrix_mattermost_websocket_reconnects_total", "result",
+		"WebSocket reconnects, by result.")
+	metrics.puppetAuthFailures.write(w, "mautrix_mattermost_puppet_auth_failures_total", "reason",
+		"Puppet tokens rejected by Mattermost, by reason.")
+	metrics.apiLatency.write(w, "mautrix_mattermost_api_request_duration_seconds",
+		"Duration of Mattermost API requests.")
+	writeMetric(w, "mautrix_mattermost_api_requests_waiting", "gauge",
+		"Mattermost API requests waiting for their client's rate limit.", metrics.apiWaiting.Load())
+	metrics.apiRateLimited.write(w, "mautrix_mattermost_api_rate_limited_total", "result",
+		"Mattermost API requests rejected with 429 Too Many Requests, by result.")
+	metrics.backfillDuration.write(w, "mautrix_mattermost_backfill_duration_seconds",
+		"Duration of backfill fetches from Mattermost.")
+	mc.writeCacheMetrics(w)
+
+	q := mc.eventQueue
+	if q == nil {
+		return
+	}
+	writeMetric(w, "mautrix_mattermost_event_queue_depth", "gauge",
+		"Mattermost events waiting to be handed to the bridge.", len(q.events))
+	writeMetric(w, "mautrix_mattermost_event_queue_capacity", "gauge",
+		"Maximum number of events the queue holds.", cap(q.events))
+	writeMetric(w, "mautrix_mattermost_event_queue_dispatched_total", "counter",
+		"Events handed to the bridge.", q.dispatched.Load())
+	writeMetric(w, "mautrix_mattermost_event_queue_dropped_total", "counter",
+		"Events dropped because the queue was full or their channel was waiting for a resync.", q.dropped.Load())
+	writeMetric(w, "mautrix_mattermost_event_queue_refetched_channels_total", "counter",
+		"Channels resynced to refetch dropped events.", q.refetched.Load())
+	writeMetric(w, "mautrix_mattermost_event_queue_missed_channels", "gauge",
+		"Channels with dropped events waiting for a resync.", q.missedCount())
+}
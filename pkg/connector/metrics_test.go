@@ -0,0 +1,139 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+)
+
+func TestCounterVec_Write(t *testing.T) {
+	t.Parallel()
+	var c counterVec
+	c.inc("b")
+	c.inc("a")
+	c.inc("b")
+	var buf strings.Builder
+	c.write(&buf, "test_total", "kind", "Test counter.")
+	want := "# HELP test_total Test counter.\n# TYPE test_total counter\n" +
+		"test_total{kind=\"a\"} 1\ntest_total{kind=\"b\"} 2\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestHistogram_Write(t *testing.T) {
+	t.Parallel()
+	h := newHistogram([]float64{0.1, 1})
+	h.observe(50 * time.Millisecond)
+	h.observe(500 * time.Millisecond)
+	h.observe(2 * time.Second)
+	var buf strings.Builder
+	h.write(&buf, "test_seconds", "Test histogram.")
+	want := "# HELP test_seconds Test histogram.\n# TYPE test_seconds histogram\n" +
+		"test_seconds_bucket{le=\"0.1\"} 1\n" +
+		"test_seconds_bucket{le=\"1\"} 2\n" +
+		"test_seconds_bucket{le=\"+Inf\"} 3\n" +
+		"test_seconds_sum 2.55\n" +
+		"test_seconds_count 3\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestEchoDropMetrics(t *testing.T) {
+	t.Parallel()
+	postEvent := func(post *model.Post, senderName string) *model.WebSocketEvent {
+		postJSON, _ := json.Marshal(post)
+		return newWebSocketEvent(model.WebsocketEventPosted, "ch1", map[string]any{"post": string(postJSON), "sender_name": senderName})
+	}
+	tests := []struct {
+		name  string
+		evt   *model.WebSocketEvent
+		layer string
+	}{
+		{"own post", postEvent(&model.Post{Id: "p1", UserId: "my-user-id"}, ""), echoLayerOwnUser},
+		{"system message", postEvent(&model.Post{Id: "p1", UserId: "alice-id", Type: model.PostTypeJoinChannel}, ""), echoLayerSystemMessage},
+		{"puppet post", postEvent(&model.Post{Id: "p1", UserId: "puppet-id"}, ""), echoLayerPuppet},
+		{"bridge username", postEvent(&model.Post{Id: "p1", UserId: "bob-id"}, "@mattermost_bob"), echoLayerBridgeUsername},
+		{"system user", postEvent(&model.Post{Id: "p1", UserId: "system-bot-id"}, "@system-bot"), echoLayerSystemUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mc.connector.Puppets["@bot:test"] = &PuppetClient{MXID: "@bot:test", UserID: "puppet-id"}
+			post, err := mc.parsePostedEvent(tt.evt)
+			if post != nil || err != nil {
+				t.Fatalf("parsePostedEvent = %v, %v; want dropped", post, err)
+			}
+			if got := mc.connector.metrics().echoDrops.get(tt.layer); got != 1 {
+				t.Errorf("echo drops for %s = %d, want 1", tt.layer, got)
+			}
+		})
+	}
+}
+
+func TestHandleMetrics_BridgeMetrics(t *testing.T) {
+	t.Parallel()
+	mc := &MattermostConnector{Bridge: &bridgev2.Bridge{}}
+	metrics := mc.metrics()
+	metrics.messages.inc(directionToMattermost)
+	metrics.messages.inc(directionToMatrix)
+	metrics.messages.inc(directionToMatrix)
+	metrics.echoDrops.inc(echoLayerPuppet)
+	metrics.wsReconnects.inc("failure")
+	metrics.puppetAuthFailures.inc(PuppetReasonAuthFailed)
+	metrics.apiLatency.observe(30 * time.Millisecond)
+	metrics.backfillDuration.observe(3 * time.Second)
+
+	rec := httptest.NewRecorder()
+	mc.HandleMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"mautrix_mattermost_messages_bridged_total{direction=\"matrix_to_mattermost\"} 1\n",
+		"mautrix_mattermost_messages_bridged_total{direction=\"mattermost_to_matrix\"} 2\n",
+		"mautrix_mattermost_echo_dropped_total{layer=\"puppet\"} 1\n",
+		"mautrix_mattermost_websocket_reconnects_total{result=\"failure\"} 1\n",
+		"mautrix_mattermost_puppet_auth_failures_total{reason=\"" + PuppetReasonAuthFailed + "\"} 1\n",
+		"mautrix_mattermost_api_request_duration_seconds_bucket{le=\"0.05\"} 1\n",
+		"mautrix_mattermost_api_request_duration_seconds_count 1\n",
+		"mautrix_mattermost_backfill_duration_seconds_bucket{le=\"2.5\"} 0\n",
+		"mautrix_mattermost_backfill_duration_seconds_bucket{le=\"5\"} 1\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q", want)
+		}
+	}
+}
+
+func TestAPITransport_RecordsLatency(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	mc := &MattermostConnector{}
+	client := mc.newAPIClient(server.URL)
+	resp, err := client.HTTPClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if got := mc.metrics().apiLatency.count; got != 1 {
+		t.Errorf("latency observations = %d, want 1", got)
+	}
+}
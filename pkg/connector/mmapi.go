@@ -0,0 +1,273 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+)
+
+// APIProfile selects defaults for the Mattermost deployment the bridge talks
+// to.
+type APIProfile string
+
+const (
+	// APIProfileSelfHosted suits self-hosted servers, whose rate limits are
+	// off by default.
+	APIProfileSelfHosted APIProfile = "self-hosted"
+	// APIProfileCloud suits Mattermost Cloud, which enforces per-user rate
+	// limits and doesn't let the bridge's login look up other users' bots.
+	APIProfileCloud APIProfile = "cloud"
+)
+
+const (
+	// cloudRequestsPerSecond is the default request rate of each client with
+	// the cloud profile.
+	cloudRequestsPerSecond = 10
+	// defaultSyncConcurrency is how many channels are synced at once with
+	// the self-hosted profile. The cloud profile syncs one at a time.
+	defaultSyncConcurrency = 4
+	// mattermostRateLimitRetries is how many times a Mattermost request
+	// rejected with 429 Too Many Requests is retried.
+	mattermostRateLimitRetries = 3
+	// defaultRateLimitWait is the wait before a retry when a 429 response
+	// doesn't say how long to wait.
+	defaultRateLimitWait = time.Second
+	// maxRateLimitWait caps the wait before one retry.
+	maxRateLimitWait = 30 * time.Second
+)
+
+// MattermostAPIConfig tunes the Mattermost API clients for the server's
+// deployment.
+type MattermostAPIConfig struct {
+	// Profile is "self-hosted" (default) or "cloud".
+	Profile APIProfile `yaml:"profile"`
+	// RequestsPerSecond caps the API requests of each Mattermost client,
+	// including puppets. 0 uses the profile default: unlimited when
+	// self-hosted, cloudRequestsPerSecond on Cloud.
+	RequestsPerSecond int `yaml:"requests_per_second"`
+	// Burst is how many requests a client may send at once before pacing
+	// at RequestsPerSecond kicks in. 0 or 1 paces every request.
+	Burst int `yaml:"burst"`
+	// SyncConcurrency is how many channels a login syncs at once. 0 uses the
+	// profile default.
+	SyncConcurrency int `yaml:"sync_concurrency"`
+}
+
+// cloud reports whether the cloud profile is selected.
+func (c *MattermostAPIConfig) cloud() bool {
+	return c.Profile == APIProfileCloud
+}
+
+// requestInterval returns the minimum time between two requests of one
+// client, or 0 for no limit.
+func (c *MattermostAPIConfig) requestInterval() time.Duration {
+	rps := c.RequestsPerSecond
+	if rps == 0 && c.cloud() {
+		rps = cloudRequestsPerSecond
+	}
+	if rps <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(rps)
+}
+
+// burst returns how many requests a client may send back to back.
+func (c *MattermostAPIConfig) burst() int {
+	return max(c.Burst, 1)
+}
+
+// syncConcurrency returns how many channels a login syncs at once.
+func (c *MattermostAPIConfig) syncConcurrency() int {
+	switch {
+	case c.SyncConcurrency > 0:
+		return c.SyncConcurrency
+	case c.cloud():
+		return 1
+	default:
+		return defaultSyncConcurrency
+	}
+}
+
+// botLookups reports whether puppet failures may be diagnosed by looking up
+// the puppet's bot with the login's client, which Cloud doesn't allow.
+func (c *MattermostAPIConfig) botLookups() bool {
+	return !c.cloud()
+}
+
+// validate checks the profile and limits.
+func (c *MattermostAPIConfig) validate() error {
+	switch c.Profile {
+	case "", APIProfileSelfHosted, APIProfileCloud:
+	default:
+		return fmt.Errorf("invalid mattermost_api.profile %q", c.Profile)
+	}
+	if c.RequestsPerSecond < 0 {
+		return fmt.Errorf("mattermost_api.requests_per_second can't be negative")
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("mattermost_api.burst can't be negative")
+	}
+	if c.SyncConcurrency < 0 {
+		return fmt.Errorf("mattermost_api.sync_concurrency can't be negative")
+	}
+	return nil
+}
+
+// newAPIClient returns a Mattermost API client for serverURL that paces its
+// requests and retries rate-limited ones as configured in mattermost_api.
+// Each client gets its own bucket, as Mattermost limits each user apart.
+func (mc *MattermostConnector) newAPIClient(serverURL string) *model.Client4 {
+	client := model.NewAPIv4Client(serverURL)
+	client.HTTPClient = &http.Client{Transport: &apiTransport{
+		next:     http.DefaultTransport,
+		interval: mc.Config.MattermostAPI.requestInterval(),
+		burst:    mc.Config.MattermostAPI.burst(),
+		metrics:  mc.metrics(),
+	}}
+	return client
+}
+
+// apiTransport is a token bucket for the requests of one Mattermost client:
+// up to burst requests start at once, then one per interval. It retries
+// requests rejected with 429 Too Many Requests after the wait the server
+// asks for.
+type apiTransport struct {
+	next     http.RoundTripper
+	interval time.Duration
+	// burst is the bucket size. Values below 1 count as 1.
+	burst int
+	// metrics records latency, waiting requests and 429s. Optional.
+	metrics *bridgeMetrics
+
+	// slot is when the bucket is full again: each request moves it one
+	// interval later. Guarded by mu.
+	slot time.Time
+	mu   sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		resp, err := t.next.RoundTrip(req)
+		if t.metrics != nil {
+			t.metrics.apiLatency.observe(time.Since(start))
+		}
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+		// A request out of retries, or whose body can't be replayed, is
+		// returned as is.
+		if attempt >= mattermostRateLimitRetries ||
+			(req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			t.countRateLimited(rateLimitGaveUp)
+			return resp, nil
+		}
+		t.countRateLimited(rateLimitRetried)
+		wait := withJitter(mattermostRetryAfter(resp))
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		zerolog.Ctx(ctx).Debug().
+			Str("path", req.URL.Path).
+			Int("attempt", attempt+1).
+			Dur("retry_in", wait).
+			Msg("Mattermost request rate limited, retrying")
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			t.countRateLimited(rateLimitGaveUp)
+			return nil, fmt.Errorf("gave up waiting to retry rate-limited request: %w", ctx.Err())
+		case <-timer.C:
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay request body: %w", err)
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// countRateLimited counts a 429 response by what was done about it.
+func (t *apiTransport) countRateLimited(result string) {
+	if t.metrics != nil {
+		t.metrics.apiRateLimited.inc(result)
+	}
+}
+
+// wait blocks until the bucket has a token for the request.
+func (t *apiTransport) wait(req *http.Request) error {
+	if t.interval <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	now := time.Now()
+	if t.slot.Before(now) {
+		t.slot = now
+	}
+	// The bucket holds burst tokens, so a request may start up to burst-1
+	// intervals before the bucket is full again.
+	start := t.slot.Add(-time.Duration(max(t.burst, 1)-1) * t.interval)
+	t.slot = t.slot.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	if t.metrics != nil {
+		t.metrics.apiWaiting.Add(1)
+		defer t.metrics.apiWaiting.Add(-1)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// mattermostRetryAfter returns how long a 429 response asks to wait: the
+// Retry-After header, or X-Ratelimit-Reset, in seconds. It falls back to
+// defaultRateLimitWait and is capped at maxRateLimitWait.
+func mattermostRetryAfter(resp *http.Response) time.Duration {
+	for _, header := range []string{"Retry-After", "X-Ratelimit-Reset"} {
+		if secs, err := strconv.Atoi(resp.Header.Get(header)); err == nil && secs > 0 {
+			return min(time.Duration(secs)*time.Second, maxRateLimitWait)
+		}
+	}
+	return defaultRateLimitWait
+}
+
+// withJitter adds up to 20% to d, so requests limited together don't all
+// retry at the same moment.
+func withJitter(d time.Duration) time.Duration {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(d/5)+1))
+	if err != nil {
+		return d
+	}
+	return d + time.Duration(n.Int64())
+}
@@ -0,0 +1,279 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMattermostAPIConfig_Profiles(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		cfg             MattermostAPIConfig
+		wantInterval    time.Duration
+		wantConcurrency int
+		wantBotLookups  bool
+	}{
+		{"default", MattermostAPIConfig{}, 0, defaultSyncConcurrency, true},
+		{"self-hosted", MattermostAPIConfig{Profile: APIProfileSelfHosted}, 0, defaultSyncConcurrency, true},
+		{"cloud", MattermostAPIConfig{Profile: APIProfileCloud}, 100 * time.Millisecond, 1, false},
+		{"cloud overrides", MattermostAPIConfig{Profile: APIProfileCloud, RequestsPerSecond: 4, SyncConcurrency: 2}, 250 * time.Millisecond, 2, false},
+		{"self-hosted limit", MattermostAPIConfig{RequestsPerSecond: 50}, 20 * time.Millisecond, defaultSyncConcurrency, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.requestInterval(); got != tt.wantInterval {
+				t.Errorf("requestInterval: got %v, want %v", got, tt.wantInterval)
+			}
+			if got := tt.cfg.syncConcurrency(); got != tt.wantConcurrency {
+				t.Errorf("syncConcurrency: got %d, want %d", got, tt.wantConcurrency)
+			}
+			if got := tt.cfg.botLookups(); got != tt.wantBotLookups {
+				t.Errorf("botLookups: got %v, want %v", got, tt.wantBotLookups)
+			}
+		})
+	}
+}
+
+func TestMattermostAPIConfig_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     MattermostAPIConfig
+		wantErr bool
+	}{
+		{"empty", MattermostAPIConfig{}, false},
+		{"cloud", MattermostAPIConfig{Profile: APIProfileCloud, RequestsPerSecond: 5}, false},
+		{"unknown profile", MattermostAPIConfig{Profile: "saas"}, true},
+		{"negative rate", MattermostAPIConfig{RequestsPerSecond: -1}, true},
+		{"negative concurrency", MattermostAPIConfig{SyncConcurrency: -1}, true},
+		{"negative burst", MattermostAPIConfig{Burst: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate: got %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMattermostRetryAfter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{"none", nil, defaultRateLimitWait},
+		{"retry-after", map[string]string{"Retry-After": "3"}, 3 * time.Second},
+		{"ratelimit reset", map[string]string{"X-Ratelimit-Reset": "2"}, 2 * time.Second},
+		{"retry-after wins", map[string]string{"Retry-After": "4", "X-Ratelimit-Reset": "2"}, 4 * time.Second},
+		{"capped", map[string]string{"Retry-After": "3600"}, maxRateLimitWait},
+		{"http date", map[string]string{"Retry-After": "Wed, 21 Oct 2015 07:28:00 GMT"}, defaultRateLimitWait},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := &http.Response{Header: http.Header{}}
+			for k, v := range tt.headers {
+				resp.Header.Set(k, v)
+			}
+			if got := mattermostRetryAfter(resp); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPITransport_RetriesRateLimited(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"p1"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	metrics := &bridgeMetrics{apiLatency: newHistogram(apiLatencyBuckets)}
+	client := &http.Client{Transport: &apiTransport{next: http.DefaultTransport, metrics: metrics}}
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"message":"hi"}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status: got %d, want 200", resp.StatusCode)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls: got %d, want 2", calls.Load())
+	}
+	if len(bodies) != 2 || bodies[1] != `{"message":"hi"}` {
+		t.Errorf("retried body: got %q", bodies)
+	}
+	if got := metrics.apiRateLimited.get(rateLimitRetried); got != 1 {
+		t.Errorf("retried count: got %d, want 1", got)
+	}
+}
+
+func TestAPITransport_GivesUp(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	client := &http.Client{Transport: &apiTransport{next: http.DefaultTransport}}
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error once the context is done")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls: got %d, want 1", calls.Load())
+	}
+}
+
+func TestAPITransport_Paces(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: &apiTransport{next: http.DefaultTransport, interval: 20 * time.Millisecond}}
+	start := time.Now()
+	for range 4 {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("4 requests 20ms apart took %v, want at least 60ms", elapsed)
+	}
+}
+
+func TestAPITransport_Burst(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	metrics := &bridgeMetrics{apiLatency: newHistogram(apiLatencyBuckets)}
+	client := &http.Client{Transport: &apiTransport{
+		next:     http.DefaultTransport,
+		interval: 100 * time.Millisecond,
+		burst:    3,
+		metrics:  metrics,
+	}}
+	get := func() {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+	start := time.Now()
+	for range 3 {
+		get()
+	}
+	if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+		t.Errorf("a burst of 3 took %v, want no wait", elapsed)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		get()
+	}()
+	deadline := time.Now().Add(time.Second)
+	for metrics.apiWaiting.Load() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := metrics.apiWaiting.Load(); got != 1 {
+		t.Errorf("waiting requests: got %d, want 1", got)
+	}
+	<-done
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("a 4th request after a burst of 3 took %v, want it paced", elapsed)
+	}
+	if got := metrics.apiWaiting.Load(); got != 0 {
+		t.Errorf("waiting requests after the wait: got %d, want 0", got)
+	}
+}
+
+func TestUploadFileStream_RateLimited(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "limit exceeded", http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+
+	metrics := &bridgeMetrics{apiLatency: newHistogram(apiLatencyBuckets)}
+	mc := &MattermostConnector{bridgeMetrics: metrics}
+	mc.metricsOnce.Do(func() {})
+	client := mc.newAPIClient(srv.URL)
+
+	// A streamed body can't be replayed, so the upload gives up on the
+	// first 429.
+	body := io.MultiReader(strings.NewReader("data"))
+	_, err := uploadFileStream(context.Background(), client, "ch1", "a.txt", "text/plain", body, 4)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !errors.Is(apiError("failed to upload media", nil, err), ErrRateLimited) {
+		t.Errorf("upload error %v doesn't classify as rate limited", err)
+	}
+	if got := metrics.apiRateLimited.get(rateLimitGaveUp); got != 1 {
+		t.Errorf("gave_up: got %d, want 1", got)
+	}
+}
+
+func TestNewAPIClient(t *testing.T) {
+	t.Parallel()
+	mc := &MattermostConnector{Config: Config{MattermostAPI: MattermostAPIConfig{Profile: APIProfileCloud}}}
+	client := mc.newAPIClient("https://example.cloud.mattermost.com/")
+	transport, ok := client.HTTPClient.Transport.(*apiTransport)
+	if !ok {
+		t.Fatalf("transport: got %T", client.HTTPClient.Transport)
+	}
+	if transport.interval != 100*time.Millisecond {
+		t.Errorf("interval: got %v, want 100ms", transport.interval)
+	}
+	if client.URL != "https://example.cloud.mattermost.com" {
+		t.Errorf("URL: got %q", client.URL)
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	t.Parallel()
+	for range 100 {
+		got := withJitter(100 * time.Millisecond)
+		if got < 100*time.Millisecond || got > 120*time.Millisecond {
+			t.Fatalf("withJitter(100ms) = %v, want within [100ms, 120ms]", got)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/status"
+)
+
+// oauth2CallbackPath is the admin API path Mattermost redirects users'
+// browsers to after they authorize the bridge.
+const oauth2CallbackPath = "/api/oauth2/callback"
+
+const (
+	// oauth2LoginTimeout is how long an OAuth 2.0 login waits for the user
+	// to authorize the bridge.
+	oauth2LoginTimeout = 10 * time.Minute
+	// defaultOAuth2RefreshMargin is how long before expiry access tokens
+	// are refreshed when oauth2.refresh_margin_minutes is 0.
+	defaultOAuth2RefreshMargin = 10 * time.Minute
+	// oauth2RefreshRetry is the pause before retrying a failed refresh.
+	oauth2RefreshRetry = time.Minute
+	// oauth2StateBytes is the size of the random state of a login.
+	oauth2StateBytes = 32
+)
+
+var (
+	errOAuth2Disabled       = errors.New("OAuth 2.0 login is not configured")
+	errOAuth2NoClientSecret = errors.New("oauth2.client_secret is not set")
+	errOAuth2GrantRejected  = errors.New("grant was rejected")
+)
+
+// OAuth2Config enables logging in through an OAuth 2.0 application
+// registered in Mattermost, for servers where users sign in with OAuth or
+// OpenID and have no password.
+type OAuth2Config struct {
+	// ClientID and ClientSecret identify the Mattermost OAuth 2.0
+	// application. ClientSecret falls back to the
+	// MATTERMOST_OAUTH2_CLIENT_SECRET environment variable.
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	// RedirectURL is the application's callback URL: the admin API's
+	// /api/oauth2/callback, as reached by users' browsers.
+	RedirectURL string `yaml:"redirect_url"`
+	// RefreshMarginMinutes is how long before expiry access tokens are
+	// refreshed. 0 uses 10.
+	RefreshMarginMinutes int `yaml:"refresh_margin_minutes"`
+}
+
+// enabled reports whether the oauth2 login flow is offered.
+func (c *OAuth2Config) enabled() bool {
+	return c.ClientID != "" && c.RedirectURL != ""
+}
+
+// refreshMargin returns how long before expiry access tokens are refreshed.
+func (c *OAuth2Config) refreshMargin() time.Duration {
+	if c.RefreshMarginMinutes <= 0 {
+		return defaultOAuth2RefreshMargin
+	}
+	return time.Duration(c.RefreshMarginMinutes) * time.Minute
+}
+
+// validate checks the redirect URL.
+func (c *OAuth2Config) validate() error {
+	if c.RedirectURL == "" {
+		return nil
+	}
+	if _, err := parseServerURL(c.RedirectURL); err != nil {
+		return fmt.Errorf("invalid oauth2.redirect_url %q", c.RedirectURL)
+	}
+	return nil
+}
+
+// oauth2ClientSecret resolves the OAuth 2.0 client secret: config first,
+// then the MATTERMOST_OAUTH2_CLIENT_SECRET environment variable.
+func (mc *MattermostConnector) oauth2ClientSecret() string {
+	if secret := mc.Config.OAuth2.ClientSecret; secret != "" {
+		return secret
+	}
+	return os.Getenv("MATTERMOST_OAUTH2_CLIENT_SECRET")
+}
+
+// oauth2Grant is the result of an OAuth 2.0 token request.
+type oauth2Grant struct {
+	AccessToken  string
+	RefreshToken string
+	// ExpiresAt is when the access token expires, zero if it doesn't.
+	ExpiresAt time.Time
+}
+
+// requestOAuth2Token requests an access token from serverURL's OAuth 2.0
+// token endpoint with the given grant parameters. A grant rejected by the
+// server, such as an expired code or refresh token, is reported as
+// errOAuth2GrantRejected.
+func (mc *MattermostConnector) requestOAuth2Token(ctx context.Context, serverURL string, params url.Values) (*oauth2Grant, error) {
+	secret := mc.oauth2ClientSecret()
+	if secret == "" {
+		return nil, errOAuth2NoClientSecret
+	}
+	cfg := &mc.Config.OAuth2
+	params.Set("client_id", cfg.ClientID)
+	params.Set("client_secret", secret)
+	params.Set("redirect_uri", cfg.RedirectURL)
+
+	resp, r, err := mc.newAPIClient(serverURL).GetOAuthAccessToken(ctx, params)
+	if err != nil {
+		if r != nil && (r.StatusCode == http.StatusBadRequest || r.StatusCode == http.StatusUnauthorized) {
+			return nil, fmt.Errorf("%w: %w", errOAuth2GrantRejected, err)
+		}
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	if resp == nil || resp.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+	grant := &oauth2Grant{AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken}
+	if resp.ExpiresInSeconds > 0 {
+		grant.ExpiresAt = time.Now().Add(time.Duration(resp.ExpiresInSeconds) * time.Second)
+	}
+	return grant, nil
+}
+
+// OAuth2LoginProcess implements login through a Mattermost OAuth 2.0
+// application: the user opens the authorize URL, Mattermost redirects their
+// browser to the admin API's callback with a code, and the code is
+// exchanged for an access token and a refresh token.
+type OAuth2LoginProcess struct {
+	TokenLoginProcess
+	// state identifies the login in the callback. It is only known to the
+	// user's browser and Mattermost.
+	state   string
+	expires time.Time
+	// callback receives the callback's code or error.
+	callback chan oauth2Callback
+}
+
+// oauth2Callback is what Mattermost redirected back with.
+type oauth2Callback struct {
+	code string
+	err  string
+}
+
+var (
+	_ bridgev2.LoginProcessUserInput      = (*OAuth2LoginProcess)(nil)
+	_ bridgev2.LoginProcessDisplayAndWait = (*OAuth2LoginProcess)(nil)
+)
+
+func (o *OAuth2LoginProcess) SubmitUserInput(_ context.Context, input map[string]string) (*bridgev2.LoginStep, error) {
+	serverURL, err := parseServerURL(input["server_url"])
+	if err != nil {
+		return nil, err
+	}
+	state := make([]byte, oauth2StateBytes)
+	if _, err := rand.Read(state); err != nil {
+		return nil, fmt.Errorf("failed to generate login state: %w", err)
+	}
+	o.serverURL = serverURL
+	o.state = hex.EncodeToString(state)
+	o.expires = time.Now().Add(oauth2LoginTimeout)
+	o.callback = make(chan oauth2Callback, 1)
+	o.connector.addOAuth2Login(o)
+
+	authorizeURL := serverURL + "/oauth/authorize?" + url.Values{
+		"response_type": {model.AuthCodeResponseType},
+		"client_id":     {o.connector.Config.OAuth2.ClientID},
+		"redirect_uri":  {o.connector.Config.OAuth2.RedirectURL},
+		"state":         {o.state},
+	}.Encode()
+	return &bridgev2.LoginStep{
+		Type:   bridgev2.LoginStepTypeDisplayAndWait,
+		StepID: "fi.mau.mattermost.login.oauth2",
+		Instructions: fmt.Sprintf("Open this URL in your browser and allow the bridge to access your account within %d minutes: %s",
+			int(oauth2LoginTimeout.Minutes()), authorizeURL),
+		DisplayAndWaitParams: &bridgev2.LoginDisplayAndWaitParams{
+			Type: bridgev2.LoginDisplayTypeCode,
+			Data: authorizeURL,
+		},
+	}, nil
+}
+
+// Wait waits for the callback, then exchanges its code for tokens and
+// creates the login.
+func (o *OAuth2LoginProcess) Wait(ctx context.Context) (*bridgev2.LoginStep, error) {
+	defer o.connector.removeOAuth2Login(o)
+	timer := time.NewTimer(time.Until(o.expires))
+	defer timer.Stop()
+
+	var cb oauth2Callback
+	select {
+	case cb = <-o.callback:
+	case <-timer.C:
+		return nil, errors.New("timed out waiting for authorization")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if cb.err != "" {
+		return nil, fmt.Errorf("authorization failed: %s", cb.err)
+	}
+
+	grant, err := o.connector.requestOAuth2Token(ctx, o.serverURL, url.Values{
+		"grant_type": {model.AccessTokenGrantType},
+		"code":       {cb.code},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	return o.finishLogin(ctx, o.serverURL, grant.AccessToken, grant)
+}
+
+func (o *OAuth2LoginProcess) Cancel() {
+	o.connector.removeOAuth2Login(o)
+}
+
+// addOAuth2Login registers a login waiting for its callback, dropping
+// expired ones.
+func (mc *MattermostConnector) addOAuth2Login(login *OAuth2LoginProcess) {
+	mc.oauth2LoginsMu.Lock()
+	defer mc.oauth2LoginsMu.Unlock()
+	if mc.oauth2Logins == nil {
+		mc.oauth2Logins = make(map[*OAuth2LoginProcess]struct{})
+	}
+	now := time.Now()
+	for pending := range mc.oauth2Logins {
+		if now.After(pending.expires) {
+			delete(mc.oauth2Logins, pending)
+		}
+	}
+	mc.oauth2Logins[login] = struct{}{}
+}
+
+// removeOAuth2Login forgets a login, so its callback can't be used.
+func (mc *MattermostConnector) removeOAuth2Login(login *OAuth2LoginProcess) {
+	mc.oauth2LoginsMu.Lock()
+	defer mc.oauth2LoginsMu.Unlock()
+	delete(mc.oauth2Logins, login)
+}
+
+// takeOAuth2Login returns and forgets the unexpired login with the given
+// state, or nil. States are compared in constant time.
+func (mc *MattermostConnector) takeOAuth2Login(state string) *OAuth2LoginProcess {
+	mc.oauth2LoginsMu.Lock()
+	defer mc.oauth2LoginsMu.Unlock()
+	for login := range mc.oauth2Logins {
+		if subtle.ConstantTimeCompare([]byte(login.state), []byte(state)) == 1 {
+			delete(mc.oauth2Logins, login)
+			if time.Now().After(login.expires) {
+				return nil
+			}
+			return login
+		}
+	}
+	return nil
+}
+
+// HandleOAuth2Callback is an HTTP handler for GET /api/oauth2/callback,
+// the redirect URL of the Mattermost OAuth 2.0 application. It hands the
+// authorization code to the waiting login. It is called by users'
+// browsers, so it doesn't require the admin token; the login's one-time
+// state authenticates it.
+func (mc *MattermostConnector) HandleOAuth2Callback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	state := query.Get("state")
+	if len(state) != hex.EncodedLen(oauth2StateBytes) {
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		return
+	}
+	code, authErr := query.Get("code"), query.Get("error")
+	if code == "" && authErr == "" {
+		http.Error(w, "missing code", http.StatusBadRequest)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	login := mc.takeOAuth2Login(state)
+	if login == nil {
+		log.Warn().Str("remote_addr", r.RemoteAddr).Msg("OAuth 2.0 callback for an unknown or expired login")
+		http.Error(w, "unknown or expired login, start the login again", http.StatusBadRequest)
+		return
+	}
+	login.callback <- oauth2Callback{code: code, err: authErr}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if authErr != "" {
+		log.Info().Str("error", authErr).Msg("OAuth 2.0 authorization was denied")
+		_, _ = fmt.Fprintln(w, "The bridge was not authorized. You can close this window.")
+		return
+	}
+	log.Info().Msg("Received OAuth 2.0 authorization")
+	_, _ = fmt.Fprintln(w, "The bridge was authorized. You can close this window and return to your Matrix client.")
+}
+
+// needsOAuth2Refresh reports whether the login's access token is an OAuth
+// 2.0 token that expires within the refresh margin.
+func (m *MattermostClient) needsOAuth2Refresh(meta *UserLoginMetadata) bool {
+	if meta.RefreshToken == "" || meta.TokenExpiresAt == 0 {
+		return false
+	}
+	expires := time.UnixMilli(meta.TokenExpiresAt)
+	return time.Until(expires) < m.connector.Config.OAuth2.refreshMargin()
+}
+
+// refreshOAuth2Token exchanges the login's refresh token for a new access
+// token, switches the client to it and saves both tokens.
+func (m *MattermostClient) refreshOAuth2Token(ctx context.Context) error {
+	meta := getLoginMeta(m.userLogin)
+	grant, err := m.connector.requestOAuth2Token(ctx, m.serverURL, url.Values{
+		"grant_type":    {model.RefreshTokenGrantType},
+		"refresh_token": {meta.RefreshToken},
+	})
+	if err != nil {
+		return err
+	}
+	meta.Token = grant.AccessToken
+	if grant.RefreshToken != "" {
+		meta.RefreshToken = grant.RefreshToken
+	}
+	meta.TokenExpiresAt = 0
+	if !grant.ExpiresAt.IsZero() {
+		meta.TokenExpiresAt = grant.ExpiresAt.UnixMilli()
+	}
+	m.client.SetToken(grant.AccessToken)
+	if err := m.userLogin.Save(ctx); err != nil {
+		return fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+	m.log.Info().Time("expires_at", grant.ExpiresAt).Msg("Refreshed OAuth 2.0 access token")
+	return nil
+}
+
+// refreshOAuth2Periodically refreshes the login's access token before it
+// expires until the client disconnects. Failed refreshes are retried every
+// minute; a rejected refresh token logs the login out.
+func (m *MattermostClient) refreshOAuth2Periodically(ctx context.Context) {
+	meta := getLoginMeta(m.userLogin)
+	margin := m.connector.Config.OAuth2.refreshMargin()
+	next := time.UnixMilli(meta.TokenExpiresAt).Add(-margin)
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-m.stopChan:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		err := m.refreshOAuth2Token(ctx)
+		switch {
+		case errors.Is(err, errOAuth2GrantRejected):
+			m.oauth2RefreshRejected(err)
+			return
+		case err != nil:
+			m.log.Warn().Err(err).Msg("Failed to refresh OAuth 2.0 access token")
+			next = time.Now().Add(oauth2RefreshRetry)
+		case meta.TokenExpiresAt == 0:
+			return
+		default:
+			next = time.UnixMilli(meta.TokenExpiresAt).Add(-margin)
+		}
+	}
+}
+
+// oauth2RefreshRejected reports a login whose refresh token no longer
+// works, so the user logs in again.
+func (m *MattermostClient) oauth2RefreshRejected(err error) {
+	m.log.Error().Err(err).Msg("OAuth 2.0 refresh token was rejected")
+	m.setConnState(connStateBadCredentials)
+	m.userLogin.BridgeState.Send(status.BridgeState{
+		StateEvent: status.StateBadCredentials,
+		Error:      "mm-oauth2-refresh-rejected",
+		Message:    "Mattermost OAuth 2.0 session expired, log in again",
+	})
+}
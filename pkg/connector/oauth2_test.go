@@ -0,0 +1,333 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+)
+
+// newOAuth2TestConnector returns a connector with an OAuth 2.0 application
+// configured.
+func newOAuth2TestConnector() *MattermostConnector {
+	mc := newTestBridgeConnector()
+	mc.Config.OAuth2 = OAuth2Config{
+		ClientID:     "app-id",
+		ClientSecret: "app-secret",
+		RedirectURL:  "https://bridge.example.com/api/oauth2/callback",
+	}
+	return mc
+}
+
+// startOAuth2Login starts an OAuth 2.0 login against serverURL and returns
+// it with its authorize URL.
+func startOAuth2Login(t *testing.T, mc *MattermostConnector, serverURL string) (*OAuth2LoginProcess, *url.URL) {
+	t.Helper()
+	proc, err := mc.CreateLogin(context.Background(), nil, "oauth2")
+	if err != nil {
+		t.Fatalf("CreateLogin(oauth2): %v", err)
+	}
+	login := proc.(*OAuth2LoginProcess)
+	step, err := login.SubmitUserInput(context.Background(), map[string]string{"server_url": serverURL})
+	if err != nil {
+		t.Fatalf("SubmitUserInput: %v", err)
+	}
+	if step.Type != bridgev2.LoginStepTypeDisplayAndWait || step.DisplayAndWaitParams == nil {
+		t.Fatalf("step: got %q with %+v, want a display and wait step", step.Type, step.DisplayAndWaitParams)
+	}
+	authorizeURL, err := url.Parse(step.DisplayAndWaitParams.Data)
+	if err != nil {
+		t.Fatalf("parse authorize URL: %v", err)
+	}
+	return login, authorizeURL
+}
+
+// callOAuth2Callback requests the OAuth 2.0 callback with query.
+func callOAuth2Callback(mc *MattermostConnector, method, query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	mc.HandleOAuth2Callback(w, httptest.NewRequest(method, oauth2CallbackPath+"?"+query, nil))
+	return w
+}
+
+func TestOAuth2Config(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		cfg        OAuth2Config
+		wantOn     bool
+		wantMargin time.Duration
+		wantErr    bool
+	}{
+		{"unset", OAuth2Config{}, false, 10 * time.Minute, false},
+		{"no redirect URL", OAuth2Config{ClientID: "app"}, false, 10 * time.Minute, false},
+		{"enabled", OAuth2Config{ClientID: "app", RedirectURL: "https://bridge.example.com/api/oauth2/callback", RefreshMarginMinutes: 30}, true, 30 * time.Minute, false},
+		{"invalid redirect URL", OAuth2Config{ClientID: "app", RedirectURL: "bridge.example.com"}, true, 10 * time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.enabled(); got != tt.wantOn {
+				t.Errorf("enabled() = %v, want %v", got, tt.wantOn)
+			}
+			if got := tt.cfg.refreshMargin(); got != tt.wantMargin {
+				t.Errorf("refreshMargin() = %v, want %v", got, tt.wantMargin)
+			}
+			if err := tt.cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOAuth2ClientSecret_EnvFallback(t *testing.T) {
+	t.Setenv("MATTERMOST_OAUTH2_CLIENT_SECRET", "env-secret")
+	mc := &MattermostConnector{}
+	if got := mc.oauth2ClientSecret(); got != "env-secret" {
+		t.Errorf("oauth2ClientSecret: got %q, want env-secret", got)
+	}
+	mc.Config.OAuth2.ClientSecret = "cfg-secret"
+	if got := mc.oauth2ClientSecret(); got != "cfg-secret" {
+		t.Errorf("oauth2ClientSecret: got %q, want cfg-secret", got)
+	}
+}
+
+func TestOAuth2LoginFlow(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	if _, err := mc.CreateLogin(context.Background(), nil, "oauth2"); !errors.Is(err, errOAuth2Disabled) {
+		t.Errorf("CreateLogin(oauth2) without config: got %v, want errOAuth2Disabled", err)
+	}
+
+	mc = newOAuth2TestConnector()
+	flows := mc.GetLoginFlows()
+	if len(flows) != 4 || flows[3].ID != "oauth2" {
+		t.Fatalf("GetLoginFlows: got %+v", flows)
+	}
+	login, authorizeURL := startOAuth2Login(t, mc, "https://mm.example.com/")
+	if authorizeURL.Scheme != "https" || authorizeURL.Host != "mm.example.com" || authorizeURL.Path != "/oauth/authorize" {
+		t.Errorf("authorize URL: got %s", authorizeURL)
+	}
+	query := authorizeURL.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != "app-id" ||
+		query.Get("redirect_uri") != mc.Config.OAuth2.RedirectURL || query.Get("state") != login.state {
+		t.Errorf("authorize URL query: got %v", query)
+	}
+	if len(login.state) != 64 {
+		t.Errorf("state: got %d characters, want 64", len(login.state))
+	}
+	if strings.Contains(authorizeURL.String(), "app-secret") {
+		t.Error("authorize URL contains the client secret")
+	}
+
+	login.Cancel()
+	if w := callOAuth2Callback(mc, http.MethodGet, "state="+login.state+"&code=abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("callback after cancel: got %d, want 400", w.Code)
+	}
+}
+
+func TestHandleOAuth2Callback(t *testing.T) {
+	t.Parallel()
+	mc := newOAuth2TestConnector()
+	login, _ := startOAuth2Login(t, mc, "https://mm.example.com")
+	unknown := strings.Repeat("0", 64)
+
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		want   int
+	}{
+		{"wrong method", http.MethodPost, "state=" + login.state + "&code=abc", http.StatusMethodNotAllowed},
+		{"missing state", http.MethodGet, "code=abc", http.StatusBadRequest},
+		{"short state", http.MethodGet, "state=abc&code=abc", http.StatusBadRequest},
+		{"missing code", http.MethodGet, "state=" + login.state, http.StatusBadRequest},
+		{"unknown state", http.MethodGet, "state=" + unknown + "&code=abc", http.StatusBadRequest},
+		{"valid", http.MethodGet, "state=" + login.state + "&code=abc", http.StatusOK},
+		{"reused state", http.MethodGet, "state=" + login.state + "&code=abc", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if w := callOAuth2Callback(mc, tt.method, tt.query); w.Code != tt.want {
+			t.Errorf("%s: got %d, want %d: %s", tt.name, w.Code, tt.want, w.Body.String())
+		}
+	}
+	select {
+	case cb := <-login.callback:
+		if cb.code != "abc" || cb.err != "" {
+			t.Errorf("callback: got %+v", cb)
+		}
+	default:
+		t.Error("the login didn't receive the code")
+	}
+}
+
+func TestAdminAPIHandler_OAuth2CallbackWithoutToken(t *testing.T) {
+	t.Parallel()
+	mc := newOAuth2TestConnector()
+	mc.Config.AdminAPIToken = "s3cret"
+	login, _ := startOAuth2Login(t, mc, "https://mm.example.com")
+
+	w := httptest.NewRecorder()
+	mc.adminAPIHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, oauth2CallbackPath+"?state="+login.state+"&code=abc", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("callback without admin token: got %d, want 200", w.Code)
+	}
+	w = httptest.NewRecorder()
+	mc.adminAPIHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("health without admin token: got %d, want 401", w.Code)
+	}
+}
+
+func TestOAuth2Wait(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.OAuth2Grants["good-code"] = &model.AccessResponse{AccessToken: "oauth-tok", RefreshToken: "refresh-tok", ExpiresInSeconds: 3600}
+	fake.Users["uid1"] = &model.User{Id: "uid1", Username: "alice"}
+	fake.TokenToUser["oauth-tok"] = "uid1"
+	fake.FailEndpoints["/teams"] = true
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{"denied", "error=access_denied", "authorization failed: access_denied"},
+		{"expired code", "code=old-code", "grant was rejected"},
+		// Fails after the exchange and authentication, before creating the
+		// login.
+		{"valid code", "code=good-code", "failed to get teams"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newOAuth2TestConnector()
+			login, _ := startOAuth2Login(t, mc, fake.Server.URL)
+			if w := callOAuth2Callback(mc, http.MethodGet, "state="+login.state+"&"+tt.query); w.Code != http.StatusOK {
+				t.Fatalf("callback: got %d", w.Code)
+			}
+			_, err := login.Wait(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Wait: got %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOAuth2Wait_Timeout(t *testing.T) {
+	t.Parallel()
+	mc := newOAuth2TestConnector()
+	login, _ := startOAuth2Login(t, mc, "https://mm.example.com")
+	login.expires = time.Now()
+	if _, err := login.Wait(context.Background()); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Wait: got %v, want a timeout", err)
+	}
+	if w := callOAuth2Callback(mc, http.MethodGet, "state="+login.state+"&code=abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("callback after timeout: got %d, want 400", w.Code)
+	}
+}
+
+func TestRequestOAuth2Token(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.OAuth2Grants["code"] = &model.AccessResponse{AccessToken: "tok", RefreshToken: "refresh", ExpiresInSeconds: 60}
+	fake.OAuth2Grants["forever"] = &model.AccessResponse{AccessToken: "tok"}
+	mc := newOAuth2TestConnector()
+
+	grant, err := mc.requestOAuth2Token(context.Background(), fake.Server.URL, url.Values{"grant_type": {"authorization_code"}, "code": {"code"}})
+	if err != nil {
+		t.Fatalf("requestOAuth2Token: %v", err)
+	}
+	if grant.AccessToken != "tok" || grant.RefreshToken != "refresh" || time.Until(grant.ExpiresAt) > time.Minute || time.Until(grant.ExpiresAt) < 50*time.Second {
+		t.Errorf("grant: got %+v", grant)
+	}
+	form, _ := url.ParseQuery(fake.Calls()[0].Body)
+	if form.Get("client_id") != "app-id" || form.Get("client_secret") != "app-secret" || form.Get("redirect_uri") != mc.Config.OAuth2.RedirectURL {
+		t.Errorf("token request form: got %v", form)
+	}
+
+	grant, err = mc.requestOAuth2Token(context.Background(), fake.Server.URL, url.Values{"code": {"forever"}})
+	if err != nil || !grant.ExpiresAt.IsZero() {
+		t.Errorf("token without expiry: got %+v, %v", grant, err)
+	}
+
+	mc.Config.OAuth2.ClientSecret = ""
+	if _, err := mc.requestOAuth2Token(context.Background(), fake.Server.URL, url.Values{"code": {"code"}}); !errors.Is(err, errOAuth2NoClientSecret) {
+		t.Errorf("without client secret: got %v", err)
+	}
+}
+
+func TestNeedsOAuth2Refresh(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	now := time.Now()
+	tests := []struct {
+		name string
+		meta UserLoginMetadata
+		want bool
+	}{
+		{"not OAuth", UserLoginMetadata{Token: "tok"}, false},
+		{"no expiry", UserLoginMetadata{RefreshToken: "r"}, false},
+		{"expires later", UserLoginMetadata{RefreshToken: "r", TokenExpiresAt: now.Add(time.Hour).UnixMilli()}, false},
+		{"expires soon", UserLoginMetadata{RefreshToken: "r", TokenExpiresAt: now.Add(5 * time.Minute).UnixMilli()}, true},
+		{"expired", UserLoginMetadata{RefreshToken: "r", TokenExpiresAt: now.Add(-time.Hour).UnixMilli()}, true},
+	}
+	for _, tt := range tests {
+		if got := client.needsOAuth2Refresh(&tt.meta); got != tt.want {
+			t.Errorf("%s: needsOAuth2Refresh() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRefreshOAuth2Token(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.OAuth2Grants["refresh-1"] = &model.AccessResponse{AccessToken: "tok-2", RefreshToken: "refresh-2", ExpiresInSeconds: 3600}
+
+	mc := newRelayTestConnector(t, nil)
+	mc.Config.OAuth2 = newOAuth2TestConnector().Config.OAuth2
+	login, err := mc.Bridge.GetExistingUserLoginByID(ctx, MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	meta := getLoginMeta(login)
+	meta.Token, meta.RefreshToken = "tok-1", "refresh-1"
+	client := login.Client.(*MattermostClient)
+	client.serverURL = fake.Server.URL
+	client.client = mc.newAPIClient(fake.Server.URL)
+	client.client.SetToken("tok-1")
+
+	if err := client.refreshOAuth2Token(ctx); err != nil {
+		t.Fatalf("refreshOAuth2Token: %v", err)
+	}
+	if meta.Token != "tok-2" || meta.RefreshToken != "refresh-2" || client.client.AuthToken != "tok-2" {
+		t.Errorf("after refresh: token %q, refresh token %q, client token %q", meta.Token, meta.RefreshToken, client.client.AuthToken)
+	}
+	if expires := time.UnixMilli(meta.TokenExpiresAt); time.Until(expires) < 59*time.Minute {
+		t.Errorf("expiry: got %v", expires)
+	}
+	saved, err := mc.Bridge.DB.UserLogin.GetByID(ctx, login.ID)
+	if err != nil || saved.Metadata.(*UserLoginMetadata).RefreshToken != "refresh-2" {
+		t.Errorf("saved login: got %+v, %v", saved, err)
+	}
+
+	meta.RefreshToken = "revoked"
+	if err := client.refreshOAuth2Token(ctx); !errors.Is(err, errOAuth2GrantRejected) {
+		t.Errorf("rejected refresh: got %v, want errOAuth2GrantRejected", err)
+	}
+}
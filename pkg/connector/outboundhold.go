@@ -0,0 +1,478 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"go.mau.fi/util/dbutil"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// OutboundHoldConfig holds Matrix messages that couldn't be sent because
+// Mattermost was unreachable, and sends them once it's back.
+type OutboundHoldConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxPerPortal is the number of messages held for one portal. Further
+	// messages fail until the held ones are sent. 0 uses 100.
+	MaxPerPortal int `yaml:"max_per_portal"`
+	// MaxAgeMinutes is how long a message is held before it's given up.
+	// 0 uses 60.
+	MaxAgeMinutes int `yaml:"max_age_minutes"`
+	// RetryIntervalSeconds is the time between attempts to send held
+	// messages while the login stays connected. 0 uses 30.
+	RetryIntervalSeconds int `yaml:"retry_interval_seconds"`
+}
+
+const (
+	defaultHoldPerPortal     = 100
+	defaultHoldMaxAge        = time.Hour
+	defaultHoldRetryInterval = 30 * time.Second
+)
+
+func (c *OutboundHoldConfig) maxPerPortal() int {
+	if c.MaxPerPortal <= 0 {
+		return defaultHoldPerPortal
+	}
+	return c.MaxPerPortal
+}
+
+func (c *OutboundHoldConfig) maxAge() time.Duration {
+	if c.MaxAgeMinutes <= 0 {
+		return defaultHoldMaxAge
+	}
+	return time.Duration(c.MaxAgeMinutes) * time.Minute
+}
+
+func (c *OutboundHoldConfig) retryInterval() time.Duration {
+	if c.RetryIntervalSeconds <= 0 {
+		return defaultHoldRetryInterval
+	}
+	return time.Duration(c.RetryIntervalSeconds) * time.Second
+}
+
+// errMattermostUnreachable wraps the errors of Mattermost requests that
+// failed without reaching Mattermost, or that a proxy answered for it.
+var errMattermostUnreachable = errors.New("mattermost is unreachable")
+
+// errHoldFull is returned when a portal already has the maximum number of
+// held messages.
+var errHoldFull = errors.New("too many messages held for the portal")
+
+// errBrokenHeldMessage is returned for held messages that can't be decoded.
+var errBrokenHeldMessage = errors.New("invalid held message")
+
+// isUnreachable reports whether a failed Mattermost request never got an
+// answer from Mattermost: the connection failed, or a proxy in front of it
+// answered 502, 503 or 504.
+func isUnreachable(resp *model.Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	var appErr *model.AppError
+	if statusCode == 0 && errors.As(err, &appErr) {
+		statusCode = appErr.StatusCode
+	}
+	return unreachableStatus(statusCode)
+}
+
+// unreachableStatus reports whether a response status means Mattermost
+// didn't answer the request. 0 is the status of requests without response.
+func unreachableStatus(statusCode int) bool {
+	switch statusCode {
+	case 0, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+const (
+	outboundHoldCreateTable = `
+		CREATE TABLE IF NOT EXISTS mattermost_outbound_hold (
+			bridge_id  TEXT   NOT NULL,
+			login_id   TEXT   NOT NULL,
+			channel_id TEXT   NOT NULL,
+			event_id   TEXT   NOT NULL,
+			queued_at  BIGINT NOT NULL,
+			message    TEXT   NOT NULL,
+			PRIMARY KEY (bridge_id, event_id)
+		)
+	`
+	outboundHoldInsert = `
+		INSERT INTO mattermost_outbound_hold (bridge_id, login_id, channel_id, event_id, queued_at, message)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	outboundHoldGetOldest = `
+		SELECT channel_id, event_id, queued_at, message FROM mattermost_outbound_hold
+		WHERE bridge_id=$1 AND login_id=$2
+		ORDER BY queued_at, event_id
+		LIMIT 1
+	`
+	outboundHoldDelete = `
+		DELETE FROM mattermost_outbound_hold WHERE bridge_id=$1 AND event_id=$2
+	`
+	outboundHoldCount = `
+		SELECT login_id, channel_id, COUNT(*) FROM mattermost_outbound_hold
+		WHERE bridge_id=$1
+		GROUP BY login_id, channel_id
+	`
+)
+
+// heldMessage is a Matrix message waiting for Mattermost, as stored in the
+// hold table. Content is the content the bridge handed over, which has the
+// relay format applied for relayed messages. PortalID is the room's portal,
+// which is a thread room's portal for messages sent in one; messages held
+// before it was recorded are sent to their channel's portal.
+type heldMessage struct {
+	PortalID   networkid.PortalID         `json:"portal_id,omitempty"`
+	Event      *event.Event               `json:"event"`
+	Content    *event.MessageEventContent `json:"content"`
+	OrigSender id.UserID                  `json:"orig_sender,omitempty"`
+	ReplyTo    networkid.MessageID        `json:"reply_to,omitempty"`
+
+	channelID string
+	queuedAt  time.Time
+}
+
+// holdKey identifies the held messages of one login in one channel.
+type holdKey struct {
+	login   networkid.UserLoginID
+	channel string
+}
+
+// outboundHold stores held Matrix messages in the bridge database. Messages
+// are held per login and channel and sent oldest first, so a channel's
+// messages keep their order.
+type outboundHold struct {
+	db       *dbutil.Database
+	bridgeID string
+
+	// counts is the number of held messages by login and channel. Guarded
+	// by mu.
+	counts map[holdKey]int
+	mu     sync.Mutex
+}
+
+// startOutboundHold creates the hold table and counts the messages held
+// before a restart, if the hold is enabled.
+func (mc *MattermostConnector) startOutboundHold(ctx context.Context) error {
+	if !mc.Config.OutboundHold.Enabled || mc.Bridge == nil || mc.Bridge.DB == nil {
+		return nil
+	}
+	hold := &outboundHold{
+		db:       mc.Bridge.DB.Database,
+		bridgeID: string(mc.Bridge.ID),
+		counts:   make(map[holdKey]int),
+	}
+	if _, err := hold.db.Exec(ctx, outboundHoldCreateTable); err != nil {
+		return fmt.Errorf("failed to create outbound hold table: %w", err)
+	}
+	rows, err := hold.db.Query(ctx, outboundHoldCount, hold.bridgeID)
+	if err != nil {
+		return fmt.Errorf("failed to count held messages: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key holdKey
+		var count int
+		if err := rows.Scan(&key.login, &key.channel, &count); err != nil {
+			return fmt.Errorf("failed to count held messages: %w", err)
+		}
+		hold.counts[key] = count
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to count held messages: %w", err)
+	}
+	mc.outboundHold = hold
+	return nil
+}
+
+// holding reports whether messages of a login are held for a channel.
+func (h *outboundHold) holding(login networkid.UserLoginID, channelID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.counts[holdKey{login, channelID}] > 0
+}
+
+// pending reports whether a login has held messages.
+func (h *outboundHold) pending(login networkid.UserLoginID) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for key, count := range h.counts {
+		if key.login == login && count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// count returns the number of messages held for a login.
+func (h *outboundHold) count(login networkid.UserLoginID) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for key, count := range h.counts {
+		if key.login == login {
+			n += count
+		}
+	}
+	return n
+}
+
+// add stores a message, unless max messages are already held for its
+// channel.
+func (h *outboundHold) add(ctx context.Context, login networkid.UserLoginID, msg *heldMessage, max int) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode held message: %w", err)
+	}
+	key := holdKey{login, msg.channelID}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.counts[key] >= max {
+		return errHoldFull
+	}
+	if _, err := h.db.Exec(ctx, outboundHoldInsert,
+		h.bridgeID, string(login), msg.channelID, string(msg.Event.ID), msg.queuedAt.UnixNano(), string(data)); err != nil {
+		return fmt.Errorf("failed to store held message: %w", err)
+	}
+	h.counts[key]++
+	return nil
+}
+
+// oldest returns the login's message held the longest, or nil if there are
+// none.
+func (h *outboundHold) oldest(ctx context.Context, login networkid.UserLoginID) (*heldMessage, error) {
+	var msg heldMessage
+	var eventID, data string
+	var queuedAt int64
+	err := h.db.QueryRow(ctx, outboundHoldGetOldest, h.bridgeID, string(login)).
+		Scan(&msg.channelID, &eventID, &queuedAt, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get held message: %w", err)
+	}
+	if err := json.Unmarshal([]byte(data), &msg); err != nil || msg.Event == nil || msg.Content == nil {
+		// Keep the ID so the broken row can be removed.
+		msg.Event = &event.Event{ID: id.EventID(eventID)}
+		return &msg, fmt.Errorf("failed to decode held message %s: %w", eventID, errBrokenHeldMessage)
+	}
+	msg.Event.Content.Parsed = msg.Content
+	msg.queuedAt = time.Unix(0, queuedAt)
+	return &msg, nil
+}
+
+// remove deletes a held message once it has been sent or given up.
+func (h *outboundHold) remove(ctx context.Context, login networkid.UserLoginID, msg *heldMessage) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, err := h.db.Exec(ctx, outboundHoldDelete, h.bridgeID, string(msg.Event.ID)); err != nil {
+		return fmt.Errorf("failed to delete held message: %w", err)
+	}
+	key := holdKey{login, msg.channelID}
+	if h.counts[key] <= 1 {
+		delete(h.counts, key)
+	} else {
+		h.counts[key]--
+	}
+	return nil
+}
+
+// holdMatrixMessage holds a Matrix message until Mattermost is reachable
+// again. cause is the error that sending it failed with, nil if it's held
+// behind earlier messages of its portal.
+func (m *MattermostClient) holdMatrixMessage(ctx context.Context, msg *bridgev2.MatrixMessage, cause error) (*bridgev2.MatrixMessageResponse, error) {
+	held := &heldMessage{
+		PortalID:  msg.Portal.ID,
+		Event:     msg.Event,
+		Content:   msg.Content,
+		channelID: ParsePortalID(msg.Portal.ID),
+		queuedAt:  time.Now(),
+	}
+	if msg.OrigSender != nil {
+		held.OrigSender = msg.OrigSender.UserID
+	}
+	if msg.ReplyTo != nil {
+		held.ReplyTo = msg.ReplyTo.ID
+	}
+	log := zerolog.Ctx(ctx)
+	if err := m.connector.outboundHold.add(ctx, m.userLogin.ID, held, m.connector.Config.OutboundHold.maxPerPortal()); err != nil {
+		log.Warn().Err(err).Str("channel_id", held.channelID).Msg("Failed to hold Matrix message")
+		if cause == nil {
+			cause = err
+		}
+		return nil, bridgev2.WrapErrorInStatus(fmt.Errorf("failed to hold message: %w", cause)).
+			WithStatus(event.MessageStatusRetriable).
+			WithErrorReason(event.MessageStatusNetworkError).
+			WithMessage("Mattermost is unreachable and too many messages are waiting for it, try again later").
+			WithIsCertain(true).
+			WithSendNotice(true)
+	}
+	log.Info().Err(cause).Str("channel_id", held.channelID).Msg("Holding Matrix message until Mattermost is reachable")
+	m.connector.Bridge.Matrix.SendMessageStatus(ctx, &bridgev2.MessageStatus{
+		Status:      event.MessageStatusPending,
+		ErrorReason: event.MessageStatusNetworkError,
+		Message:     "Mattermost is unreachable, the message will be sent once it's back",
+	}, bridgev2.StatusEventInfoFromEvent(msg.Event))
+	// The message is saved to the database when it's sent.
+	return &bridgev2.MatrixMessageResponse{Pending: true}, nil
+}
+
+// retryHeldMessages periodically sends the login's held messages, for
+// outages the WebSocket didn't notice.
+func (m *MattermostClient) retryHeldMessages(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+			if m.connector.outboundHold.pending(m.userLogin.ID) {
+				m.flushHeldMessages(ctx)
+			}
+		}
+	}
+}
+
+// flushHeldMessages sends the login's held messages, oldest first. It stops
+// at the first message Mattermost is still unreachable for, so the rest
+// keep their order.
+func (m *MattermostClient) flushHeldMessages(ctx context.Context) {
+	hold := m.connector.outboundHold
+	if hold == nil || m.userLogin == nil || !m.IsLoggedIn() {
+		return
+	}
+	if !m.holdFlushMu.TryLock() {
+		return
+	}
+	defer m.holdFlushMu.Unlock()
+	log := zerolog.Ctx(ctx)
+	sent := 0
+	for {
+		held, err := hold.oldest(ctx, m.userLogin.ID)
+		if errors.Is(err, errBrokenHeldMessage) {
+			log.Warn().Err(err).Msg("Dropping held message")
+		} else if err != nil {
+			log.Err(err).Msg("Failed to load held messages")
+			return
+		} else if held == nil {
+			if sent > 0 {
+				log.Info().Int("count", sent).Msg("Sent held Matrix messages")
+			}
+			return
+		} else if err = m.sendHeldMessage(ctx, held); errors.Is(err, errMattermostUnreachable) {
+			log.Debug().Err(err).Msg("Mattermost still unreachable, keeping held messages")
+			return
+		} else {
+			sent++
+		}
+		if err := hold.remove(ctx, m.userLogin.ID, held); err != nil {
+			log.Err(err).Msg("Failed to remove held message")
+			return
+		}
+	}
+}
+
+// sendHeldMessage sends a held message to Mattermost, saves it and reports
+// its status to Matrix. Messages that fail for other reasons than
+// Mattermost being unreachable, or that were held too long, are reported as
+// failed. Only errMattermostUnreachable is returned.
+func (m *MattermostClient) sendHeldMessage(ctx context.Context, held *heldMessage) error {
+	log := zerolog.Ctx(ctx).With().
+		Str("channel_id", held.channelID).
+		Stringer("event_id", held.Event.ID).
+		Logger()
+	portalKey := makePortalKey(held.channelID)
+	if held.PortalID != "" {
+		portalKey.ID = held.PortalID
+	}
+	portal, err := m.connector.Bridge.GetExistingPortalByKey(ctx, portalKey)
+	if err != nil || portal == nil {
+		log.Warn().Err(err).Msg("Portal of held message not found, dropping it")
+		return nil
+	}
+	info := bridgev2.StatusEventInfoFromEvent(held.Event)
+	if time.Since(held.queuedAt) > m.connector.Config.OutboundHold.maxAge() {
+		log.Warn().Time("queued_at", held.queuedAt).Msg("Giving up held message")
+		m.connector.Bridge.Matrix.SendMessageStatus(ctx, &bridgev2.MessageStatus{
+			Status:      event.MessageStatusFail,
+			ErrorReason: event.MessageStatusNetworkError,
+			Message:     "Mattermost was unreachable for too long",
+			IsCertain:   true,
+			SendNotice:  true,
+		}, info)
+		return nil
+	}
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Event:   held.Event,
+			Content: held.Content,
+			Portal:  portal,
+		},
+	}
+	if held.OrigSender != "" {
+		msg.OrigSender = &bridgev2.OrigSender{UserID: held.OrigSender}
+	}
+	if held.ReplyTo != "" {
+		msg.ReplyTo, err = m.connector.Bridge.DB.Message.GetFirstPartByID(ctx, portal.Receiver, held.ReplyTo)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to get reply target of held message")
+		}
+	}
+
+	resp, err := m.sendMatrixMessage(ctx, msg)
+	if errors.Is(err, errMattermostUnreachable) {
+		return err
+	} else if err != nil {
+		log.Warn().Err(err).Msg("Failed to send held message")
+		status := bridgev2.WrapErrorInStatus(err).WithSendNotice(true)
+		if status.Status == "" {
+			status.Status = event.MessageStatusRetriable
+		}
+		if status.ErrorReason == "" {
+			status.ErrorReason = event.MessageStatusGenericError
+		}
+		m.connector.Bridge.Matrix.SendMessageStatus(ctx, &status, info)
+		return nil
+	}
+
+	dbMsg := resp.DB
+	dbMsg.MXID = held.Event.ID
+	dbMsg.Room = portal.PortalKey
+	dbMsg.SenderMXID = held.Event.Sender
+	dbMsg.Timestamp = time.UnixMilli(held.Event.Timestamp)
+	if msg.ReplyTo != nil {
+		dbMsg.ReplyTo = networkid.MessageOptionalPartID{MessageID: msg.ReplyTo.ID, PartID: &msg.ReplyTo.PartID}
+	}
+	// Like bridgev2, make sure the sender's ghost row exists first.
+	_, _ = m.connector.Bridge.GetGhostByID(ctx, dbMsg.SenderID)
+	if err := m.connector.Bridge.DB.Message.Insert(ctx, dbMsg); err != nil {
+		log.Err(err).Msg("Failed to save held message to database")
+	}
+	m.connector.Bridge.Matrix.SendMessageStatus(ctx, &bridgev2.MessageStatus{Status: event.MessageStatusSuccess}, info)
+	return nil
+}
@@ -0,0 +1,339 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// statusMatrixConnector is a ghostMatrixConnector that records the message
+// statuses sent for each event.
+type statusMatrixConnector struct {
+	ghostMatrixConnector
+	mu       sync.Mutex
+	statuses map[id.EventID][]event.MessageStatus
+}
+
+func (c *statusMatrixConnector) SendMessageStatus(_ context.Context, status *bridgev2.MessageStatus, evt *bridgev2.MessageStatusEventInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses[evt.SourceEventID] = append(c.statuses[evt.SourceEventID], status.Status)
+}
+
+func (c *statusMatrixConnector) lastStatus(eventID id.EventID) event.MessageStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	statuses := c.statuses[eventID]
+	if len(statuses) == 0 {
+		return ""
+	}
+	return statuses[len(statuses)-1]
+}
+
+// holdTestSetup is a relay test connector with outbound_hold enabled whose
+// relay login talks to a fake Mattermost behind a proxy that answers 503
+// while down is set.
+type holdTestSetup struct {
+	mc     *MattermostConnector
+	client *MattermostClient
+	portal *bridgev2.Portal
+	fake   *fakeMM
+	matrix *statusMatrixConnector
+	down   *atomic.Bool
+}
+
+func newHoldTestSetup(t *testing.T, cfg OutboundHoldConfig) *holdTestSetup {
+	t.Helper()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	matrix := &statusMatrixConnector{statuses: make(map[id.EventID][]event.MessageStatus)}
+	mc.Bridge.Matrix = matrix
+	mc.Puppets = make(map[id.UserID]*PuppetClient)
+	cfg.Enabled = true
+	mc.Config.OutboundHold = cfg
+	if err := mc.startOutboundHold(ctx); err != nil {
+		t.Fatalf("start outbound hold: %v", err)
+	}
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	down := &atomic.Bool{}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fake.handler(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	login, err := mc.Bridge.GetExistingUserLoginByID(ctx, MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	client := login.Client.(*MattermostClient)
+	client.client = model.NewAPIv4Client(proxy.URL)
+	client.client.SetToken("relay-token")
+	client.userID = "relay-id"
+	return &holdTestSetup{
+		mc:     mc,
+		client: client,
+		portal: getRelayTestPortal(t, mc, relayTestChannel),
+		fake:   fake,
+		matrix: matrix,
+		down:   down,
+	}
+}
+
+func (s *holdTestSetup) send(eventID id.EventID, body string) (*bridgev2.MatrixMessageResponse, error) {
+	content := &event.MessageEventContent{MsgType: event.MsgText, Body: body}
+	return s.client.HandleMatrixMessage(context.Background(), &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Event: &event.Event{
+				ID:        eventID,
+				Type:      event.EventMessage,
+				RoomID:    s.portal.MXID,
+				Sender:    "@relay:example.com",
+				Timestamp: time.Now().UnixMilli(),
+				Content:   event.Content{Parsed: content},
+			},
+			Content: content,
+			Portal:  s.portal,
+		},
+	})
+}
+
+// postedMessages returns the messages of the posts created on the fake.
+func (s *holdTestSetup) postedMessages() []string {
+	var messages []string
+	for _, call := range s.fake.Calls() {
+		if call.Method == http.MethodPost && call.Path == "/api/v4/posts" {
+			var post model.Post
+			_ = json.Unmarshal([]byte(call.Body), &post)
+			messages = append(messages, post.Message)
+		}
+	}
+	return messages
+}
+
+func TestOutboundHold_HoldsAndFlushesInOrder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newHoldTestSetup(t, OutboundHoldConfig{})
+
+	s.down.Store(true)
+	resp, err := s.send("$one:example.com", "one")
+	if err != nil || resp == nil || !resp.Pending {
+		t.Fatalf("unreachable send: resp %+v, err %v, want pending", resp, err)
+	}
+	if got := s.matrix.lastStatus("$one:example.com"); got != event.MessageStatusPending {
+		t.Errorf("status of held message = %q, want pending", got)
+	}
+
+	// Mattermost is back, but the portal still has a held message, so the
+	// next one waits behind it.
+	s.down.Store(false)
+	resp, err = s.send("$two:example.com", "two")
+	if err != nil || resp == nil || !resp.Pending {
+		t.Fatalf("send behind held message: resp %+v, err %v, want pending", resp, err)
+	}
+	if posted := s.postedMessages(); len(posted) != 0 {
+		t.Fatalf("posted %v before the flush", posted)
+	}
+
+	s.client.flushHeldMessages(ctx)
+	if posted := strings.Join(s.postedMessages(), ","); posted != "one,two" {
+		t.Errorf("posted %q, want one,two", posted)
+	}
+	for _, eventID := range []id.EventID{"$one:example.com", "$two:example.com"} {
+		if got := s.matrix.lastStatus(eventID); got != event.MessageStatusSuccess {
+			t.Errorf("status of %s = %q, want success", eventID, got)
+		}
+	}
+	if msg, err := s.mc.Bridge.DB.Message.GetPartByMXID(ctx, "$one:example.com"); err != nil || msg == nil {
+		t.Errorf("sent held message not saved: %v", err)
+	}
+	if s.mc.outboundHold.holding(s.client.userLogin.ID, relayTestChannel) {
+		t.Error("portal still holding after the flush")
+	}
+
+	resp, err = s.send("$three:example.com", "three")
+	if err != nil || resp == nil || resp.Pending {
+		t.Errorf("send after flush: resp %+v, err %v, want sent", resp, err)
+	}
+}
+
+func TestOutboundHold_ThreadRoom(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newHoldTestSetup(t, OutboundHoldConfig{})
+	if err := s.mc.Bridge.DB.Portal.Insert(ctx, &database.Portal{
+		BridgeID:  "mattermost",
+		PortalKey: makeThreadPortalKey(relayTestChannel, "r1"),
+		MXID:      "!thread-r1:example.com",
+		Metadata:  &PortalMetadata{},
+	}); err != nil {
+		t.Fatalf("insert thread portal: %v", err)
+	}
+	portal, err := s.mc.Bridge.GetExistingPortalByKey(ctx, makeThreadPortalKey(relayTestChannel, "r1"))
+	if err != nil || portal == nil {
+		t.Fatalf("get thread portal: %v", err)
+	}
+	s.portal = portal
+
+	s.down.Store(true)
+	if resp, err := s.send("$one:example.com", "one"); err != nil || resp == nil || !resp.Pending {
+		t.Fatalf("unreachable send: resp %+v, err %v, want pending", resp, err)
+	}
+	s.down.Store(false)
+	s.client.flushHeldMessages(ctx)
+
+	var rootIDs []string
+	for _, call := range s.fake.Calls() {
+		if call.Method == http.MethodPost && call.Path == "/api/v4/posts" {
+			var post model.Post
+			_ = json.Unmarshal([]byte(call.Body), &post)
+			rootIDs = append(rootIDs, post.RootId)
+		}
+	}
+	if len(rootIDs) != 1 || rootIDs[0] != "r1" {
+		t.Errorf("posted with root IDs %q, want the thread's root", rootIDs)
+	}
+	msg, err := s.mc.Bridge.DB.Message.GetPartByMXID(ctx, "$one:example.com")
+	if err != nil || msg == nil || msg.Room != s.portal.PortalKey {
+		t.Errorf("held message saved as %+v (%v), want in the thread room", msg, err)
+	}
+}
+
+func TestOutboundHold_StaysHeldWhileUnreachable(t *testing.T) {
+	t.Parallel()
+	s := newHoldTestSetup(t, OutboundHoldConfig{})
+	s.down.Store(true)
+	if _, err := s.send("$one:example.com", "one"); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	s.client.flushHeldMessages(context.Background())
+	if !s.mc.outboundHold.holding(s.client.userLogin.ID, relayTestChannel) {
+		t.Error("message dropped while Mattermost is unreachable")
+	}
+}
+
+func TestOutboundHold_Full(t *testing.T) {
+	t.Parallel()
+	s := newHoldTestSetup(t, OutboundHoldConfig{MaxPerPortal: 1})
+	s.down.Store(true)
+	if _, err := s.send("$one:example.com", "one"); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	_, err := s.send("$two:example.com", "two")
+	var status bridgev2.MessageStatus
+	if !errors.As(err, &status) || status.Status != event.MessageStatusRetriable {
+		t.Errorf("send to full hold: err %v, want a retriable status", err)
+	}
+}
+
+func TestOutboundHold_GivesUpOldMessages(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newHoldTestSetup(t, OutboundHoldConfig{MaxAgeMinutes: 1})
+	content := &event.MessageEventContent{MsgType: event.MsgText, Body: "old"}
+	if err := s.mc.outboundHold.add(ctx, s.client.userLogin.ID, &heldMessage{
+		Event:     &event.Event{ID: "$old:example.com", Type: event.EventMessage, RoomID: s.portal.MXID, Content: event.Content{Parsed: content}},
+		Content:   content,
+		channelID: relayTestChannel,
+		queuedAt:  time.Now().Add(-time.Hour),
+	}, 10); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	s.client.flushHeldMessages(ctx)
+	if posted := s.postedMessages(); len(posted) != 0 {
+		t.Errorf("posted %v, want the old message given up", posted)
+	}
+	if got := s.matrix.lastStatus("$old:example.com"); got != event.MessageStatusFail {
+		t.Errorf("status = %q, want fail", got)
+	}
+	if s.mc.outboundHold.pending(s.client.userLogin.ID) {
+		t.Error("old message still held")
+	}
+}
+
+func TestOutboundHold_SurvivesRestart(t *testing.T) {
+	t.Parallel()
+	s := newHoldTestSetup(t, OutboundHoldConfig{})
+	s.down.Store(true)
+	if _, err := s.send("$one:example.com", "one"); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	s.mc.outboundHold = nil
+	if err := s.mc.startOutboundHold(context.Background()); err != nil {
+		t.Fatalf("restart outbound hold: %v", err)
+	}
+	if !s.mc.outboundHold.holding(s.client.userLogin.ID, relayTestChannel) {
+		t.Fatal("held message lost on restart")
+	}
+	s.down.Store(false)
+	s.client.flushHeldMessages(context.Background())
+	if posted := strings.Join(s.postedMessages(), ","); posted != "one" {
+		t.Errorf("posted %q, want one", posted)
+	}
+}
+
+func TestOutboundHold_Disabled(t *testing.T) {
+	t.Parallel()
+	s := newHoldTestSetup(t, OutboundHoldConfig{})
+	s.mc.outboundHold = nil
+	s.down.Store(true)
+	_, err := s.send("$one:example.com", "one")
+	if !errors.Is(err, errMattermostUnreachable) {
+		t.Errorf("err = %v, want errMattermostUnreachable", err)
+	}
+	var status bridgev2.MessageStatus
+	if !errors.As(err, &status) || status.Status != event.MessageStatusRetriable || status.ErrorReason != event.MessageStatusNetworkError {
+		t.Errorf("status = %+v, want retriable network error", status)
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		resp *model.Response
+		err  error
+		want bool
+	}{
+		{"no error", &model.Response{StatusCode: http.StatusOK}, nil, false},
+		{"connection failed", nil, model.NewAppError("DoAPIRequest", "model.client.connecting.app_error", nil, "", 0), true},
+		{"bad gateway", &model.Response{StatusCode: http.StatusBadGateway}, errors.New("bad gateway"), true},
+		{"unavailable", &model.Response{StatusCode: http.StatusServiceUnavailable}, errors.New("unavailable"), true},
+		{"gateway timeout", &model.Response{StatusCode: http.StatusGatewayTimeout}, errors.New("timeout"), true},
+		{"server error", &model.Response{StatusCode: http.StatusInternalServerError}, errors.New("internal"), false},
+		{"forbidden", nil, model.NewAppError("CreatePost", "api.context.permissions.app_error", nil, "", http.StatusForbidden), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isUnreachable(tt.resp, tt.err); got != tt.want {
+				t.Errorf("isUnreachable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
+	"github.com/aiku/mautrix-mattermost/pkg/connector/mattermostfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/id"
+)
+
+// permalinkRe returns the pattern of permalinks to posts on a server,
+// capturing the post ID, or nil without a server URL.
+func permalinkRe(serverURL string) *regexp.Regexp {
+	serverURL = strings.TrimRight(serverURL, "/")
+	if serverURL == "" {
+		return nil
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(serverURL) + `/[A-Za-z0-9_-]+/pl/([a-z0-9]{26})\b`)
+}
+
+// permalinkPostID returns the ID of the post a permalink links to, if it's a
+// permalink to a post on the server.
+func permalinkPostID(serverURL, link string) (string, bool) {
+	re := permalinkRe(serverURL)
+	if re == nil {
+		return "", false
+	}
+	match := re.FindStringSubmatch(link)
+	if match == nil || match[0] != link {
+		return "", false
+	}
+	return match[1], true
+}
+
+// teamName returns the name of a team, as used in permalinks.
+func (m *MattermostClient) teamName(ctx context.Context, teamID string) (string, bool) {
+	team, ok := m.lookupTeam(ctx, teamID)
+	if !ok {
+		return "", false
+	}
+	return team.Name, true
+}
+
+// lookupTeam returns a team, cached until the next channel sync or channel
+// update.
+func (m *MattermostClient) lookupTeam(ctx context.Context, teamID string) (*model.Team, bool) {
+	m.channelLinksMu.Lock()
+	team, ok := m.teams.get(teamID)
+	m.channelLinksMu.Unlock()
+	if ok {
+		return team, true
+	}
+	team, _, err := m.client.GetTeam(ctx, teamID, "")
+	if err != nil {
+		m.log.Warn().Err(err).Str("team_id", teamID).Msg("Failed to look up team")
+		return nil, false
+	}
+	m.channelLinksMu.Lock()
+	if m.teams == nil {
+		m.teams = newClientCache[string, *model.Team](m, "teams", true)
+	}
+	m.teams.set(teamID, team)
+	m.channelLinksMu.Unlock()
+	return team, true
+}
+
+// permalinkResolver returns the resolver that turns permalinks to bridged
+// posts into links to their Matrix events. Permalinks to other servers and
+// to posts that were never bridged are left as they are.
+func (m *MattermostClient) permalinkResolver(ctx context.Context) mattermostfmt.PermalinkResolver {
+	if m.serverURL == "" || m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return nil
+	}
+	var via []string
+	if m.connector.Bridge.Bot != nil {
+		via = []string{m.connector.Bridge.Bot.GetMXID().Homeserver()}
+	}
+	return func(link string) (*id.MatrixURI, bool) {
+		postID, ok := permalinkPostID(m.serverURL, link)
+		if !ok {
+			return nil, false
+		}
+		msg, err := m.connector.Bridge.DB.Message.GetFirstPartByID(ctx, "", MakeMessageID(postID))
+		if err != nil || msg == nil || msg.MXID == "" {
+			return nil, false
+		}
+		linked, err := m.connector.Bridge.GetExistingPortalByKey(ctx, msg.Room)
+		if err != nil || linked == nil || linked.MXID == "" {
+			return nil, false
+		}
+		return linked.MXID.EventURI(msg.MXID, via...), true
+	}
+}
+
+// matrixPermalinkResolver returns the resolver that turns links to Matrix
+// events bridged from or to Mattermost posts into permalinks to the posts.
+// Event IDs are unique across rooms, so the room of a link isn't checked.
+func (m *MattermostClient) matrixPermalinkResolver(ctx context.Context) matrixfmt.PermalinkResolver {
+	if m.client == nil || m.serverURL == "" || m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return nil
+	}
+	return func(uri *id.MatrixURI) (string, bool) {
+		part, err := m.connector.Bridge.DB.Message.GetPartByMXID(ctx, uri.EventID())
+		if err != nil || part == nil {
+			return "", false
+		}
+		linked, err := m.connector.Bridge.GetExistingPortalByKey(ctx, part.Room)
+		if err != nil || linked == nil || linked.RoomType == database.RoomTypeSpace {
+			return "", false
+		}
+		teamName, ok := m.teamName(ctx, m.portalTeam(linked))
+		if !ok {
+			return "", false
+		}
+		return strings.TrimRight(m.serverURL, "/") + "/" + teamName + "/pl/" + ParseMessageID(part.ID), true
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestPermalinkPostID(t *testing.T) {
+	t.Parallel()
+	const server = "https://mm.example.com"
+	tests := []struct {
+		name   string
+		link   string
+		wantID string
+	}{
+		{"permalink", server + "/eng/pl/" + testLinkedPostID, testLinkedPostID},
+		{"other server", "https://other.example.com/eng/pl/" + testLinkedPostID, ""},
+		{"trailing path", server + "/eng/pl/" + testLinkedPostID + "/more", ""},
+		{"channel link", server + "/eng/channels/town-square", ""},
+	}
+	for _, tt := range tests {
+		postID, ok := permalinkPostID(server+"/", tt.link)
+		if postID != tt.wantID || ok != (tt.wantID != "") {
+			t.Errorf("%s: got %q, %v, want %q", tt.name, postID, ok, tt.wantID)
+		}
+	}
+}
+
+// newPermalinkResolverTestClient returns a client whose bridge has the
+// relayTestChannel portal, in team eng, with post testLinkedPostID bridged as
+// $linked:example.com. The server has no posts, so permalinks aren't quoted.
+func newPermalinkResolverTestClient(t *testing.T) (*MattermostClient, *fakeMM) {
+	t.Helper()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Teams["my-user-id"] = []*model.Team{{Id: "team1", Name: "eng"}}
+
+	client := newFullTestClient(fake.Server.URL)
+	client.connector = newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {TeamID: "team1"}})
+	if err := client.connector.Bridge.DB.Message.Insert(context.Background(), &database.Message{
+		ID:        MakeMessageID(testLinkedPostID),
+		MXID:      "$linked:example.com",
+		Room:      makePortalKey(relayTestChannel),
+		SenderID:  MakeUserID("alice-id"),
+		Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	return client, fake
+}
+
+func TestConvertPostToMatrix_Permalinks(t *testing.T) {
+	t.Parallel()
+	client, _ := newPermalinkResolverTestClient(t)
+	bridged := client.serverURL + "/eng/pl/" + testLinkedPostID
+	unbridged := client.serverURL + "/eng/pl/" + testMissingPostID
+	eventLink := id.RoomID("!" + relayTestChannel + ":example.com").EventURI("$linked:example.com").MatrixToURL()
+
+	tests := []struct {
+		name     string
+		message  string
+		wantBody string
+	}{
+		{"bridged post", "see " + bridged, "see " + eventLink},
+		{"markdown link", "see [this](" + bridged + ")", "see [this](" + eventLink + ")"},
+		{"unbridged post", "see " + unbridged, "see " + unbridged},
+		{"inline code", "`" + bridged + "`", "`" + bridged + "`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := client.convertPostToMatrix(context.Background(), nil, nil, &model.Post{Id: "p1", ChannelId: "ch1", Message: tt.message})
+			if len(msg.Parts) != 1 {
+				t.Fatalf("expected 1 part, got %d", len(msg.Parts))
+			}
+			if body := msg.Parts[0].Content.Body; body != tt.wantBody {
+				t.Errorf("body:\ngot  %q\nwant %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestMatrixPermalinkResolver(t *testing.T) {
+	t.Parallel()
+	client, fake := newPermalinkResolverTestClient(t)
+	roomID := id.RoomID("!" + relayTestChannel + ":example.com")
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"bridged event", "see " + roomID.EventURI("$linked:example.com").MatrixToURL(), "see " + client.serverURL + "/eng/pl/" + testLinkedPostID},
+		{"matrix uri", roomID.EventURI("$linked:example.com").String(), client.serverURL + "/eng/pl/" + testLinkedPostID},
+		{"unbridged event", "see https://matrix.to/#/!other:example.com/$other", "see https://matrix.to/#/!other:example.com/$other"},
+		{"room link", "see https://matrix.to/#/!other:example.com", "see https://matrix.to/#/!other:example.com"},
+	}
+	opts := client.matrixFormatOptions(context.Background(), nil, "")
+	for _, tt := range tests {
+		content := &event.MessageEventContent{MsgType: event.MsgText, Body: tt.body}
+		if got := matrixfmtParseWithOptions(content, opts); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// The team name is looked up once.
+	teamCalls := 0
+	for _, call := range fake.Calls() {
+		if call.Path == "/api/v4/teams/team1" {
+			teamCalls++
+		}
+	}
+	if teamCalls != 1 {
+		t.Errorf("team lookups: got %d, want 1", teamCalls)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+)
+
+// defaultPortalWatchInterval is the time between portal watcher passes when
+// portal_watcher.interval_seconds is 0.
+const defaultPortalWatchInterval = 60 * time.Second
+
+// Reasons for a portal watcher pass, as reported by /api/health.
+const (
+	portalWatchInterval  = "interval"
+	portalWatchTriggered = "trigger"
+)
+
+// PortalWatcherConfig controls WatchNewPortals, the background pass that sets
+// relays and sends auto-invites on portal rooms.
+type PortalWatcherConfig struct {
+	// IntervalSeconds is the time between passes. 0 uses 60 seconds. A
+	// negative value turns periodic passes off, leaving only the passes
+	// triggered by new rooms and the admin API.
+	IntervalSeconds int `yaml:"interval_seconds"`
+}
+
+// interval returns the time between passes for WatchNewPortals: 0 for the
+// default, negative for none.
+func (c *PortalWatcherConfig) interval() time.Duration {
+	if c.IntervalSeconds < 0 {
+		return -1
+	}
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// PortalWatchResult reports one portal watcher pass.
+type PortalWatchResult struct {
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+	// Reason is "interval" for periodic passes and "trigger" for requested
+	// ones.
+	Reason string `json:"reason"`
+	// Portals is the number of portal rooms checked.
+	Portals   int    `json:"portals"`
+	RelaysSet int    `json:"relays_set"`
+	Invites   int    `json:"invites"`
+	Error     string `json:"error,omitempty"`
+}
+
+// portalWatchTrigger returns the channel that requests an immediate portal
+// watcher pass. It holds at most one request, so requests made during a pass
+// coalesce into one more pass.
+func (mc *MattermostConnector) portalWatchTrigger() chan struct{} {
+	mc.watchTriggerOnce.Do(func() {
+		mc.watchTrigger = make(chan struct{}, 1)
+	})
+	return mc.watchTrigger
+}
+
+// TriggerPortalWatch requests a portal watcher pass without waiting for the
+// next interval. It doesn't block; the pass runs in the watcher goroutine.
+func (mc *MattermostConnector) TriggerPortalWatch() {
+	select {
+	case mc.portalWatchTrigger() <- struct{}{}:
+	default:
+	}
+}
+
+// runPortalWatch runs one portal watcher pass and records its result for
+// /api/health.
+func (mc *MattermostConnector) runPortalWatch(ctx context.Context, reason string) {
+	result := &PortalWatchResult{StartedAt: time.Now(), Reason: reason}
+	var relayErr, inviteErr error
+	result.Portals, result.RelaysSet, relayErr = mc.checkAndSetRelay(ctx)
+	result.Invites, inviteErr = mc.checkAutoInvites(ctx)
+	if err := errors.Join(relayErr, inviteErr); err != nil {
+		result.Error = err.Error()
+	}
+	result.DurationMS = time.Since(result.StartedAt).Milliseconds()
+	mc.lastPortalWatch.Store(result)
+}
+
+// watchIfRelayMissing is a remote event PostHandleFunc that requests a portal
+// watcher pass when the portal has a room but not the relay it's allowed.
+// That's the case right after an event created the room, so new rooms don't
+// wait for the next interval. Each portal requests one pass while its relay
+// is missing; if that pass can't set it, the periodic passes retry.
+func (mc *MattermostConnector) watchIfRelayMissing(_ context.Context, portal *bridgev2.Portal) {
+	missing := portal.MXID != "" && portal.Relay == nil && mc.relayAllowed(portal)
+	mc.relayWatchedMu.Lock()
+	_, watched := mc.relayWatched[portal.PortalKey]
+	switch {
+	case missing && !watched:
+		if mc.relayWatched == nil {
+			mc.relayWatched = make(map[networkid.PortalKey]struct{})
+		}
+		mc.relayWatched[portal.PortalKey] = struct{}{}
+	case !missing && watched:
+		delete(mc.relayWatched, portal.PortalKey)
+	}
+	mc.relayWatchedMu.Unlock()
+	if missing && !watched {
+		mc.TriggerPortalWatch()
+	}
+}
+
+// PortalWatcherStatus is the portal watcher part of the /api/health response.
+type PortalWatcherStatus struct {
+	// IntervalSeconds is the time between periodic passes, or 0 if they're
+	// turned off.
+	IntervalSeconds int                `json:"interval_seconds"`
+	LastRun         *PortalWatchResult `json:"last_run,omitempty"`
+}
+
+// HandlePortalWatch is an HTTP handler for POST /api/portals/watch. It
+// requests an immediate portal watcher pass, for example right after rooms
+// were created by hand, and returns without waiting for it. The result shows
+// up in GET /api/health.
+func (mc *MattermostConnector) HandlePortalWatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	mc.ctxLog(r.Context()).Info().
+		Str("remote_addr", r.RemoteAddr).
+		Msg("Portal watcher pass requested")
+	mc.TriggerPortalWatch()
+	w.WriteHeader(http.StatusAccepted)
+}
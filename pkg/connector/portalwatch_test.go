@@ -0,0 +1,200 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestPortalWatcherConfig_Interval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		seconds int
+		want    time.Duration
+	}{
+		{0, 0},
+		{30, 30 * time.Second},
+		{-5, -1},
+	}
+	for _, tt := range tests {
+		cfg := PortalWatcherConfig{IntervalSeconds: tt.seconds}
+		if got := cfg.interval(); got != tt.want {
+			t.Errorf("interval(%d) = %v, want %v", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestTriggerPortalWatch_Coalesces(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.TriggerPortalWatch()
+	mc.TriggerPortalWatch()
+	if n := len(mc.portalWatchTrigger()); n != 1 {
+		t.Errorf("pending triggers: got %d, want 1", n)
+	}
+}
+
+func TestWatchNewPortals_Triggered(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	bot := &fakeInviteBot{}
+	mc.Bridge.Bot = bot
+	mc.Config.AutoInvite = AutoInviteConfig{Users: []id.UserID{"@ops:example.com"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		// Periodic passes are off, so only the trigger runs one.
+		mc.WatchNewPortals(ctx, -1)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	mc.TriggerPortalWatch()
+	deadline := time.Now().Add(2 * time.Second)
+	for mc.lastPortalWatch.Load() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("triggered pass did not run")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	result := mc.lastPortalWatch.Load()
+	if result.Reason != portalWatchTriggered || result.Portals != 1 || result.RelaysSet != 1 || result.Invites != 1 || result.Error != "" {
+		t.Errorf("result: %+v", result)
+	}
+	if portal := getRelayTestPortal(t, mc, relayTestChannel); portal.RelayLoginID != "relayuser" {
+		t.Errorf("portal relay: got %q, want relayuser", portal.RelayLoginID)
+	}
+}
+
+func TestWatchIfRelayMissing(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		meta *PortalMetadata
+		want int
+	}{
+		{"missing relay", &PortalMetadata{}, 1},
+		{"relay disabled", &PortalMetadata{RelayDisabled: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: tt.meta})
+			mc.watchIfRelayMissing(context.Background(), getRelayTestPortal(t, mc, relayTestChannel))
+			if n := len(mc.portalWatchTrigger()); n != tt.want {
+				t.Errorf("pending triggers: got %d, want %d", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchIfRelayMissing_OncePerPortal(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}, relayTestChannel2: {}})
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	trigger := mc.portalWatchTrigger()
+
+	mc.watchIfRelayMissing(ctx, portal)
+	<-trigger
+	mc.watchIfRelayMissing(ctx, portal)
+	if n := len(trigger); n != 0 {
+		t.Errorf("pending triggers after a repeated event: got %d, want 0", n)
+	}
+	mc.watchIfRelayMissing(ctx, getRelayTestPortal(t, mc, relayTestChannel2))
+	if n := len(trigger); n != 1 {
+		t.Errorf("pending triggers after another portal's event: got %d, want 1", n)
+	}
+	<-trigger
+
+	// Once the relay was set, losing it again requests another pass.
+	portal.Relay = &bridgev2.UserLogin{}
+	mc.watchIfRelayMissing(ctx, portal)
+	portal.Relay = nil
+	mc.watchIfRelayMissing(ctx, portal)
+	if n := len(trigger); n != 1 {
+		t.Errorf("pending triggers after the relay was lost: got %d, want 1", n)
+	}
+}
+
+// health requests GET /api/health and decodes the response.
+func health(t *testing.T, mc *MattermostConnector) HealthResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	mc.HandleHealth(w, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want 200", w.Code)
+	}
+	var resp HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp
+}
+
+func TestHandleHealth(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+
+	resp := health(t, mc)
+	if resp.Status != "ok" || resp.PortalWatcher.IntervalSeconds != 60 || resp.PortalWatcher.LastRun != nil {
+		t.Errorf("before the first pass: %+v", resp)
+	}
+
+	mc.Config.PortalWatcher.IntervalSeconds = -1
+	mc.runPortalWatch(context.Background(), portalWatchInterval)
+	resp = health(t, mc)
+	if resp.Status != "ok" || resp.PortalWatcher.IntervalSeconds != 0 {
+		t.Errorf("after a pass: %+v", resp)
+	}
+	if last := resp.PortalWatcher.LastRun; last == nil || last.Reason != portalWatchInterval || last.StartedAt.IsZero() {
+		t.Errorf("last run: %+v", last)
+	}
+
+	mc.lastPortalWatch.Store(&PortalWatchResult{Error: "failed to get portals"})
+	if resp := health(t, mc); resp.Status != "degraded" {
+		t.Errorf("status after a failed pass: got %q, want degraded", resp.Status)
+	}
+
+	w := httptest.NewRecorder()
+	mc.HandleHealth(w, httptest.NewRequest(http.MethodPost, "/api/health", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status: got %d, want 405", w.Code)
+	}
+}
+
+func TestHandlePortalWatch(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+
+	w := httptest.NewRecorder()
+	mc.HandlePortalWatch(w, httptest.NewRequest(http.MethodGet, "/api/portals/watch", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status: got %d, want 405", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	mc.HandlePortalWatch(w, httptest.NewRequest(http.MethodPost, "/api/portals/watch", nil))
+	if w.Code != http.StatusAccepted {
+		t.Errorf("status: got %d, want 202", w.Code)
+	}
+	if n := len(mc.portalWatchTrigger()); n != 1 {
+		t.Errorf("pending triggers: got %d, want 1", n)
+	}
+}
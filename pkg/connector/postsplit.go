@@ -0,0 +1,117 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// splitPostMessage splits the message of a post from Matrix into parts that
+// fit the server's post size limit. Mattermost never allows less than
+// PostMessageMaxRunesV1, so the limit is only looked up for longer messages.
+func (m *MattermostClient) splitPostMessage(ctx context.Context, text string) []string {
+	if utf8.RuneCountInString(text) <= model.PostMessageMaxRunesV1 {
+		return []string{text}
+	}
+	return matrixfmt.SplitMessage(text, m.maxPostRunes(ctx))
+}
+
+// maxPostRunes returns the most runes a post may have on the login's
+// server: the MaxPostSize of its client config, or Mattermost's default
+// when the server doesn't report it. The limit is fetched once per login;
+// after a failed request, the default is used and the next long message
+// tries again.
+func (m *MattermostClient) maxPostRunes(ctx context.Context) int {
+	if size := m.maxPostSize.Load(); size > 0 {
+		return int(size)
+	}
+	size := model.PostMessageMaxRunesV2
+	if m.client == nil {
+		return size
+	}
+	cfg, _, err := m.client.GetOldClientConfig(ctx, "")
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to get client config for the post size limit")
+		return size
+	}
+	if n, err := strconv.Atoi(cfg["MaxPostSize"]); err == nil && n > 0 {
+		size = n
+	}
+	m.maxPostSize.Store(int64(size))
+	m.log.Debug().Int("max_post_size", size).Msg("Got post size limit")
+	return size
+}
+
+// createContinuationPosts posts the parts of a split Matrix message after
+// the first one, as replies in the first post's thread, or in the thread it
+// replies to. It returns the IDs of the posts created; a failed part ends
+// the message there, since the first post already exists.
+func (m *MattermostClient) createContinuationPosts(ctx context.Context, client *model.Client4, first *model.Post, parts []string) []string {
+	rootID := first.RootId
+	if rootID == "" {
+		rootID = first.Id
+	}
+	ids := make([]string, 0, len(parts))
+	for i, part := range parts {
+		post := &model.Post{
+			ChannelId: first.ChannelId,
+			RootId:    rootID,
+			Message:   part,
+		}
+		m.connector.markBridgePost(post, "")
+		created, _, err := client.CreatePost(ctx, post)
+		if err != nil {
+			m.log.Warn().Err(err).
+				Str("post_id", first.Id).
+				Int("part", i+2).
+				Int("part_count", len(parts)+1).
+				Msg("Failed to post part of a split message")
+			break
+		}
+		ids = append(ids, created.Id)
+	}
+	return ids
+}
+
+// editContinuationPosts updates the posts a split Matrix message continued
+// in after an edit of its first post. Existing parts are patched, parts the
+// edit added are posted after them, and parts it no longer needs are
+// deleted. It returns the IDs of the message's continuation posts.
+func (m *MattermostClient) editContinuationPosts(ctx context.Context, client *model.Client4, first *model.Post, postIDs []string, parts []string) []string {
+	kept := postIDs[:min(len(postIDs), len(parts))]
+	for i, postID := range kept {
+		if _, _, err := m.client.PatchPost(ctx, postID, &model.PostPatch{Message: &parts[i]}); err != nil {
+			m.log.Warn().Err(err).
+				Str("post_id", postID).
+				Int("part", i+2).
+				Msg("Failed to edit part of a split message")
+		}
+	}
+	m.deleteContinuationPosts(ctx, postIDs[len(kept):])
+	ids := slices.Clip(kept)
+	if len(parts) > len(kept) {
+		ids = append(ids, m.createContinuationPosts(ctx, client, first, parts[len(kept):])...)
+	}
+	return ids
+}
+
+// deleteContinuationPosts deletes the posts a split Matrix message
+// continued in. Deleting a thread's root deletes its replies too, but the
+// first post may itself be a reply.
+func (m *MattermostClient) deleteContinuationPosts(ctx context.Context, postIDs []string) {
+	for _, postID := range postIDs {
+		if resp, err := m.client.DeletePost(ctx, postID); err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			m.log.Warn().Err(err).Str("post_id", postID).Msg("Failed to delete part of a split message")
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+)
+
+func TestMaxPostRunes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   int
+	}{
+		{"reported", map[string]string{"MaxPostSize": "4000"}, 4000},
+		{"not reported", nil, model.PostMessageMaxRunesV2},
+		{"invalid", map[string]string{"MaxPostSize": "lots"}, model.PostMessageMaxRunesV2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := newFakeMM()
+			t.Cleanup(fm.Close)
+			fm.ClientConfig = tt.config
+			mc := newFullTestClient(fm.Server.URL)
+
+			if got := mc.maxPostRunes(context.Background()); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+			mc.maxPostRunes(context.Background())
+			fetched := 0
+			for _, c := range fm.Calls() {
+				if c.Path == "/api/v4/config/client" {
+					fetched++
+				}
+			}
+			if fetched != 1 {
+				t.Errorf("client config fetched %d times, want once", fetched)
+			}
+		})
+	}
+}
+
+func TestHandleMatrixMessage_Split(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	fm.ClientConfig = map[string]string{"MaxPostSize": "4000"}
+	mc := newFullTestClient(fm.Server.URL)
+	body := strings.Repeat(strings.Repeat("a", 99)+"\n", 100)
+
+	resp, err := mc.HandleMatrixMessage(context.Background(), &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  makeTestPortal("test-channel"),
+			Content: &event.MessageEventContent{MsgType: event.MsgText, Body: body},
+			Event:   &event.Event{ID: "$long"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var posts []*model.Post
+	for _, c := range fm.Calls() {
+		if c.Method == "POST" && c.Path == "/api/v4/posts" {
+			post := &model.Post{}
+			if err := json.Unmarshal([]byte(c.Body), post); err != nil {
+				t.Fatalf("decode post: %v", err)
+			}
+			posts = append(posts, post)
+		}
+	}
+	if len(posts) != 3 {
+		t.Fatalf("got %d posts, want 3", len(posts))
+	}
+	var joined []string
+	for i, post := range posts {
+		if n := len([]rune(post.Message)); n > 4000 {
+			t.Errorf("post %d has %d runes", i, n)
+		}
+		if !mc.connector.isBridgeMarkedPost(post) {
+			t.Errorf("post %d isn't marked as posted by the bridge", i)
+		}
+		wantRoot := "created-post-id"
+		if i == 0 {
+			wantRoot = ""
+		}
+		if post.RootId != wantRoot {
+			t.Errorf("post %d root = %q, want %q", i, post.RootId, wantRoot)
+		}
+		joined = append(joined, post.Message)
+	}
+	if strings.Join(joined, "\n") != body {
+		t.Error("the posts don't add up to the message")
+	}
+	if got := messageMetadata(resp.DB).ContinuationPosts; len(got) != 2 {
+		t.Errorf("continuation posts = %v, want 2", got)
+	}
+}
+
+func TestHandleMatrixEdit_Split(t *testing.T) {
+	t.Parallel()
+	line := strings.Repeat("a", 99) + "\n"
+	tests := []struct {
+		name        string
+		lines       int
+		old         []string
+		wantPatched []string
+		wantCreated int
+		wantDeleted []string
+		wantParts   int
+	}{
+		{"grows", 100, nil, []string{"first"}, 2, nil, 2},
+		{"same parts", 100, []string{"second", "third"}, []string{"first", "second", "third"}, 0, nil, 2},
+		{"adds a part", 100, []string{"second"}, []string{"first", "second"}, 1, nil, 2},
+		{"shrinks", 50, []string{"second", "third"}, []string{"first", "second"}, 0, []string{"third"}, 1},
+		{"fits again", 1, []string{"second", "third"}, []string{"first"}, 0, []string{"second", "third"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := newFakeMM()
+			t.Cleanup(fm.Close)
+			fm.ClientConfig = map[string]string{"MaxPostSize": "4000"}
+			mc := newFullTestClient(fm.Server.URL)
+			target := &database.Message{ID: MakeMessageID("first")}
+			if tt.old != nil {
+				target.Metadata = &MessageMetadata{ContinuationPosts: tt.old}
+			}
+
+			err := mc.HandleMatrixEdit(context.Background(), &bridgev2.MatrixEdit{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+					Portal:  makeTestPortal("test-channel"),
+					Content: &event.MessageEventContent{MsgType: event.MsgText, Body: strings.Repeat(line, tt.lines)},
+					Event:   &event.Event{ID: "$edit"},
+				},
+				EditTarget: target,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var patched, deleted []string
+			created := 0
+			for _, c := range fm.Calls() {
+				switch {
+				case c.Method == "PUT" && strings.HasSuffix(c.Path, "/patch"):
+					var patch model.PostPatch
+					_ = json.Unmarshal([]byte(c.Body), &patch)
+					if n := len([]rune(*patch.Message)); n > 4000 {
+						t.Errorf("patch of %s has %d runes", c.Path, n)
+					}
+					patched = append(patched, strings.TrimSuffix(strings.TrimPrefix(c.Path, "/api/v4/posts/"), "/patch"))
+				case c.Method == "POST" && c.Path == "/api/v4/posts":
+					var post model.Post
+					_ = json.Unmarshal([]byte(c.Body), &post)
+					if post.RootId != "first" {
+						t.Errorf("new part root = %q, want the first post", post.RootId)
+					}
+					created++
+				case c.Method == "DELETE":
+					deleted = append(deleted, strings.TrimPrefix(c.Path, "/api/v4/posts/"))
+				}
+			}
+			if strings.Join(patched, ",") != strings.Join(tt.wantPatched, ",") {
+				t.Errorf("patched %v, want %v", patched, tt.wantPatched)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("created %d posts, want %d", created, tt.wantCreated)
+			}
+			if strings.Join(deleted, ",") != strings.Join(tt.wantDeleted, ",") {
+				t.Errorf("deleted %v, want %v", deleted, tt.wantDeleted)
+			}
+			if got := messageMetadata(target).ContinuationPosts; len(got) != tt.wantParts {
+				t.Errorf("continuation posts = %v, want %d", got, tt.wantParts)
+			}
+		})
+	}
+}
+
+func TestHandleMatrixMessageRemove_Split(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+
+	err := mc.HandleMatrixMessageRemove(context.Background(), &bridgev2.MatrixMessageRemove{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.RedactionEventContent]{
+			Portal: makeTestPortal("test-channel"),
+		},
+		TargetMessage: &database.Message{
+			ID:       MakeMessageID("first"),
+			Metadata: &MessageMetadata{ContinuationPosts: []string{"second", "third"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, postID := range []string{"first", "second", "third"} {
+		if !fm.CalledPath("/api/v4/posts/" + postID) {
+			t.Errorf("post %s wasn't deleted", postID)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+)
+
+var _ bridgev2.PowerLevelHandlingNetworkAPI = (*MattermostClient)(nil)
+
+// maxRolePowerLevel caps the power levels roles map to, so ghosts always
+// stay below the bridge bot and can be demoted again.
+const maxRolePowerLevel = 99
+
+// PowerLevelConfig maps Mattermost roles to Matrix power levels in portal
+// rooms. A member gets the highest level of the roles they hold; 0 leaves a
+// role unmapped.
+type PowerLevelConfig struct {
+	// Enabled sets the level of every member from their roles, demoting
+	// members who lost one. When off, channel admins get level 50 and other
+	// levels are left alone.
+	Enabled bool `yaml:"enabled"`
+	// ChannelAdmin is the level of channel admins.
+	ChannelAdmin int `yaml:"channel_admin"`
+	// TeamAdmin is the level of admins of the channel's team.
+	TeamAdmin int `yaml:"team_admin"`
+	// SystemAdmin is the level of Mattermost system admins.
+	SystemAdmin int `yaml:"system_admin"`
+	// SyncToMattermost makes the channel admin role of a ghost follow its
+	// level when a Matrix user with a login or puppet changes it.
+	SyncToMattermost bool `yaml:"sync_to_mattermost"`
+}
+
+// validate checks that the levels are in range.
+func (c *PowerLevelConfig) validate() error {
+	for name, level := range map[string]int{
+		"channel_admin": c.ChannelAdmin,
+		"team_admin":    c.TeamAdmin,
+		"system_admin":  c.SystemAdmin,
+	} {
+		if level < 0 || level > maxRolePowerLevel {
+			return fmt.Errorf("power_levels.%s must be between 0 and %d, got %d", name, maxRolePowerLevel, level)
+		}
+	}
+	return nil
+}
+
+// memberRoles are the Mattermost roles of a channel member that map to
+// power levels.
+type memberRoles struct {
+	channelAdmin bool
+	teamAdmin    bool
+	systemAdmin  bool
+}
+
+// levelFor returns the power level of a member with the given roles.
+func (c *PowerLevelConfig) levelFor(roles memberRoles) int {
+	level := 0
+	if roles.channelAdmin {
+		level = max(level, c.ChannelAdmin)
+	}
+	if roles.teamAdmin {
+		level = max(level, c.TeamAdmin)
+	}
+	if roles.systemAdmin {
+		level = max(level, c.SystemAdmin)
+	}
+	return level
+}
+
+// lookupRoles returns the roles of the given channel members. Team and
+// system admins are looked up in batches, only for the roles that map to a
+// level. A failed lookup is logged and leaves that role unset.
+func (m *MattermostClient) lookupRoles(ctx context.Context, teamID string, members model.ChannelMembers) map[string]memberRoles {
+	cfg := &m.connector.Config.PowerLevels
+	roles := make(map[string]memberRoles, len(members))
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		roles[member.UserId] = memberRoles{channelAdmin: member.SchemeAdmin}
+		userIDs = append(userIDs, member.UserId)
+	}
+	if len(userIDs) == 0 || m.client == nil {
+		return roles
+	}
+	log := zerolog.Ctx(ctx)
+	if cfg.TeamAdmin > 0 && teamID != "" {
+		teamMembers, _, err := m.client.GetTeamMembersByIds(ctx, teamID, userIDs)
+		if err != nil {
+			log.Warn().Err(err).Str("team_id", teamID).Msg("Failed to get team roles for power levels")
+		}
+		for _, teamMember := range teamMembers {
+			if r, ok := roles[teamMember.UserId]; ok {
+				r.teamAdmin = teamMember.SchemeAdmin
+				roles[teamMember.UserId] = r
+			}
+		}
+	}
+	if cfg.SystemAdmin > 0 {
+		users, _, err := m.client.GetUsersByIds(ctx, userIDs)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to get system roles for power levels")
+		}
+		for _, user := range users {
+			if r, ok := roles[user.Id]; ok {
+				r.systemAdmin = user.IsSystemAdmin()
+				roles[user.Id] = r
+			}
+		}
+	}
+	return roles
+}
+
+// applyRolePowerLevels sets the power level of every member of a synced
+// channel from their Mattermost roles, when power_levels is enabled. DMs and
+// group DMs have no roles and are left alone.
+func (m *MattermostClient) applyRolePowerLevels(ctx context.Context, channel *model.Channel, members model.ChannelMembers, list *bridgev2.ChatMemberList) {
+	cfg := &m.connector.Config.PowerLevels
+	if !cfg.Enabled || list == nil || channel.Type == model.ChannelTypeDirect || channel.Type == model.ChannelTypeGroup {
+		return
+	}
+	for i := range members {
+		m.swapMemberRoles(&members[i])
+	}
+	roles := m.lookupRoles(ctx, channel.TeamId, members)
+	for userID, chatMember := range list.MemberMap {
+		level := cfg.levelFor(roles[ParseUserID(userID)])
+		chatMember.PowerLevel = &level
+		list.MemberMap[userID] = chatMember
+	}
+}
+
+// parseMemberRoleUpdatedEvent extracts the team member from a
+// memberrole_updated event.
+func parseMemberRoleUpdatedEvent(evt *model.WebSocketEvent) (*model.TeamMember, error) {
+	memberJSON, ok := evt.GetData()["member"].(string)
+	if !ok {
+		return nil, fmt.Errorf("member role updated event missing member data")
+	}
+	var member model.TeamMember
+	if err := json.Unmarshal([]byte(memberJSON), &member); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team member: %w", err)
+	}
+	if member.TeamId == "" || member.UserId == "" {
+		return nil, fmt.Errorf("member role updated event has no team or user ID")
+	}
+	return &member, nil
+}
+
+// channelMemberKey identifies a user in a channel.
+type channelMemberKey struct {
+	channel string
+	user    string
+}
+
+// channelRoles are the channel roles of a member as Mattermost sent them.
+type channelRoles struct {
+	schemeAdmin bool
+	roles       string
+}
+
+// swapMemberRoles records the channel roles of a member and reports whether
+// they changed since they were last recorded. Members seen for the first
+// time count as changed.
+func (m *MattermostClient) swapMemberRoles(member *model.ChannelMember) bool {
+	key := channelMemberKey{member.ChannelId, member.UserId}
+	roles := channelRoles{member.SchemeAdmin, member.Roles}
+	m.memberRolesMu.Lock()
+	defer m.memberRolesMu.Unlock()
+	if m.memberRoles == nil {
+		m.memberRoles = newClientCache[channelMemberKey, channelRoles](m, "member_roles", false)
+	}
+	if prev, ok := m.memberRoles.get(key); ok && prev == roles {
+		return false
+	}
+	m.memberRoles.set(key, roles)
+	return true
+}
+
+// updateMemberPowerLevel queues the power level of one member of a channel
+// and its thread rooms, after their channel roles changed. Mattermost sends
+// channel_member_updated for read position changes too, which are skipped.
+func (m *MattermostClient) updateMemberPowerLevel(member *model.ChannelMember) {
+	if !m.swapMemberRoles(member) {
+		return
+	}
+	ctx := m.log.WithContext(context.Background())
+	channel, _, err := m.client.GetChannel(ctx, member.ChannelId, "")
+	if err != nil {
+		m.log.Warn().Err(err).Str("channel_id", member.ChannelId).Msg("Failed to get channel for power level update")
+		return
+	}
+	if channel.Type == model.ChannelTypeDirect || channel.Type == model.ChannelTypeGroup {
+		return
+	}
+	roles := m.lookupRoles(ctx, channel.TeamId, model.ChannelMembers{*member})
+	level := m.connector.Config.PowerLevels.levelFor(roles[member.UserId])
+	evt := m.powerLevelChangeEvent(makePortalKey(member.ChannelId), member, level)
+	evt.PostHandleFunc = func(ctx context.Context, _ *bridgev2.Portal) {
+		keys, err := m.connector.threadPortalKeys(ctx, member.ChannelId)
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", member.ChannelId).Msg("Failed to get thread rooms for power level update")
+			return
+		}
+		for _, key := range keys {
+			m.eventSender.QueueRemoteEvent(m.userLogin, m.powerLevelChangeEvent(key, member, level))
+		}
+	}
+	m.eventSender.QueueRemoteEvent(m.userLogin, evt)
+}
+
+// powerLevelChangeEvent builds the remote event setting the power level of
+// one member of a portal.
+func (m *MattermostClient) powerLevelChangeEvent(portalKey networkid.PortalKey, member *model.ChannelMember, level int) *simplevent.ChatInfoChange {
+	return &simplevent.ChatInfoChange{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatInfoChange,
+			PortalKey: portalKey,
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("channel_id", member.ChannelId).Str("member_id", member.UserId)
+			},
+		},
+		ChatInfoChange: &bridgev2.ChatInfoChange{
+			MemberChanges: &bridgev2.ChatMemberList{
+				MemberMap: map[networkid.UserID]bridgev2.ChatMember{
+					MakeUserID(member.UserId): {
+						EventSender: m.memberSender(member.UserId),
+						Membership:  event.MembershipJoin,
+						PowerLevel:  &level,
+					},
+				},
+			},
+		},
+	}
+}
+
+// handleMemberRoleUpdated resyncs the channels of a team when the login's
+// user became or stopped being a team admin. Mattermost only sends the
+// event to the member's sessions; the roles of other users are picked up
+// by the next channel resync.
+func (m *MattermostClient) handleMemberRoleUpdated(evt *model.WebSocketEvent) {
+	cfg := &m.connector.Config.PowerLevels
+	if !cfg.Enabled || cfg.TeamAdmin == 0 {
+		return
+	}
+	member, err := parseMemberRoleUpdatedEvent(evt)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to parse member role updated event")
+		return
+	}
+	if member.UserId != m.userID {
+		return
+	}
+	ctx := m.log.WithContext(context.Background())
+	channels, _, err := m.client.GetChannelsForTeamForUser(ctx, member.TeamId, m.userID, false, "")
+	if err != nil {
+		m.log.Warn().Err(err).Str("team_id", member.TeamId).Msg("Failed to get team channels for power level update")
+		return
+	}
+	m.resyncPowerLevels(channels)
+}
+
+// handleUserRoleUpdated resyncs the channels of the login's user when they
+// became or stopped being a system admin.
+func (m *MattermostClient) handleUserRoleUpdated(evt *model.WebSocketEvent) {
+	cfg := &m.connector.Config.PowerLevels
+	if !cfg.Enabled || cfg.SystemAdmin == 0 {
+		return
+	}
+	if userID, _ := evt.GetData()["user_id"].(string); userID != m.userID {
+		return
+	}
+	ctx := m.log.WithContext(context.Background())
+	channels, _, err := m.client.GetChannelsForUserWithLastDeleteAt(ctx, m.userID, 0)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to get channels for power level update")
+		return
+	}
+	m.resyncPowerLevels(channels)
+}
+
+// resyncPowerLevels queues a resync of the existing portals of the given
+// team channels, which sets the power levels of their members again.
+func (m *MattermostClient) resyncPowerLevels(channels []*model.Channel) {
+	for _, channel := range channels {
+		if channel.Type == model.ChannelTypeDirect || channel.Type == model.ChannelTypeGroup || !m.connector.OwnsChannel(channel.Id) {
+			continue
+		}
+		channelID := channel.Id
+		m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatResync{
+			EventMeta: simplevent.EventMeta{
+				Type:      bridgev2.RemoteEventChatResync,
+				PortalKey: makePortalKey(channelID),
+				LogContext: func(c zerolog.Context) zerolog.Context {
+					return c.Str("channel_id", channelID)
+				},
+			},
+			GetChatInfoFunc: m.GetChatInfo,
+		})
+	}
+}
+
+// HandleMatrixPowerLevels gives or takes the channel admin role of the
+// Mattermost users whose level crossed power_levels.channel_admin, when
+// sync_to_mattermost is on. Ghosts, users with a login and users mapped to a
+// puppet are covered. Like other room changes, it's made as the Matrix
+// user's puppet or login, so Mattermost checks that they may manage the
+// channel's roles, and relayed users can't make it.
+func (m *MattermostClient) HandleMatrixPowerLevels(ctx context.Context, msg *bridgev2.MatrixPowerLevelChange) (bool, error) {
+	cfg := &m.connector.Config.PowerLevels
+	if !cfg.Enabled || !cfg.SyncToMattermost || cfg.ChannelAdmin == 0 {
+		return false, bridgev2.ErrPowerLevelsNotSupported
+	}
+	client, senderID, err := m.channelEditClient(msg.OrigSender, msg.Portal, false)
+	if err != nil {
+		return false, err
+	}
+	channelID := ParsePortalID(msg.Portal.ID)
+	log := zerolog.Ctx(ctx)
+	changed := false
+	for mxid, change := range msg.Users {
+		userID := targetUserID(change.Target)
+		if userID == "" {
+			userID = m.puppetTargetUserID(mxid)
+		}
+		isAdmin := change.NewLevel >= cfg.ChannelAdmin
+		if userID == "" || (change.OrigLevel >= cfg.ChannelAdmin) == isAdmin {
+			continue
+		}
+		member, resp, err := client.GetChannelMember(ctx, channelID, userID, "")
+		if err != nil {
+			m.checkPuppetFailure(ctx, senderID, resp, err)
+			return changed, apiError("failed to get channel member", resp, err)
+		}
+		if member.SchemeAdmin == isAdmin {
+			continue
+		}
+		resp, err = client.UpdateChannelMemberSchemeRoles(ctx, channelID, userID, &model.SchemeRoles{
+			SchemeAdmin: isAdmin,
+			SchemeUser:  member.SchemeUser,
+			SchemeGuest: member.SchemeGuest,
+		})
+		if err != nil {
+			m.checkPuppetFailure(ctx, senderID, resp, err)
+			return changed, apiError("failed to update channel member roles", resp, err)
+		}
+		m.recordPuppetSuccess(senderID)
+		changed = true
+		log.Info().
+			Str("channel_id", channelID).
+			Str("member_id", userID).
+			Bool("channel_admin", isAdmin).
+			Str("mm_user_id", senderID).
+			Msg("Updated Mattermost channel roles from Matrix power levels")
+	}
+	return changed, nil
+}
@@ -0,0 +1,302 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/id"
+)
+
+// testPowerLevels is a power_levels config with a distinct level per role.
+var testPowerLevels = PowerLevelConfig{Enabled: true, ChannelAdmin: 50, TeamAdmin: 60, SystemAdmin: 75}
+
+func TestPowerLevelConfig_LevelFor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		cfg   PowerLevelConfig
+		roles memberRoles
+		want  int
+	}{
+		{"member", testPowerLevels, memberRoles{}, 0},
+		{"channel admin", testPowerLevels, memberRoles{channelAdmin: true}, 50},
+		{"team admin", testPowerLevels, memberRoles{teamAdmin: true}, 60},
+		{"highest role", testPowerLevels, memberRoles{channelAdmin: true, systemAdmin: true}, 75},
+		{"unmapped role", PowerLevelConfig{ChannelAdmin: 50}, memberRoles{systemAdmin: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.levelFor(tt.roles); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPowerLevelConfig_Validate(t *testing.T) {
+	t.Parallel()
+	if err := testPowerLevels.validate(); err != nil {
+		t.Errorf("valid config: %v", err)
+	}
+	for _, cfg := range []PowerLevelConfig{{ChannelAdmin: -1}, {TeamAdmin: 100}, {SystemAdmin: 9001}} {
+		if err := cfg.validate(); err == nil {
+			t.Errorf("%+v: expected an error", cfg)
+		}
+	}
+}
+
+// newPowerLevelTestClient returns a client whose fake server has alice as
+// channel admin, bob as team admin and carol as system admin of channel ch1
+// in team t1, with dave as a plain member.
+func newPowerLevelTestClient(t *testing.T) (*MattermostClient, *fakeMM) {
+	t.Helper()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Channels["ch1"] = &model.Channel{Id: "ch1", TeamId: "t1", Type: model.ChannelTypeOpen}
+	fake.ChannelMembers["ch1"] = model.ChannelMembers{
+		{ChannelId: "ch1", UserId: "alice-id", SchemeAdmin: true, SchemeUser: true},
+		{ChannelId: "ch1", UserId: "bob-id", SchemeUser: true},
+		{ChannelId: "ch1", UserId: "carol-id", SchemeUser: true},
+		{ChannelId: "ch1", UserId: "dave-id", SchemeUser: true},
+	}
+	fake.TeamMembers["t1"] = []*model.TeamMember{
+		{TeamId: "t1", UserId: "alice-id"},
+		{TeamId: "t1", UserId: "bob-id", SchemeAdmin: true},
+	}
+	fake.Users["carol-id"] = &model.User{Id: "carol-id", Roles: model.SystemAdminRoleId + " " + model.SystemUserRoleId}
+	fake.Users["dave-id"] = &model.User{Id: "dave-id", Roles: model.SystemUserRoleId}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.PowerLevels = testPowerLevels
+	return mc, fake
+}
+
+func TestApplyRolePowerLevels(t *testing.T) {
+	t.Parallel()
+	mc, fake := newPowerLevelTestClient(t)
+	members := fake.ChannelMembers["ch1"]
+
+	list := mc.channelMembersToChatMembers(members)
+	mc.applyRolePowerLevels(context.Background(), fake.Channels["ch1"], members, list)
+	want := map[string]int{"alice-id": 50, "bob-id": 60, "carol-id": 75, "dave-id": 0}
+	for userID, level := range want {
+		member := list.MemberMap[MakeUserID(userID)]
+		if member.PowerLevel == nil || *member.PowerLevel != level {
+			t.Errorf("%s: got %v, want %d", userID, member.PowerLevel, level)
+		}
+	}
+
+	// Without power_levels, channel admins keep level 50 and others none.
+	mc.connector.Config.PowerLevels.Enabled = false
+	list = mc.channelMembersToChatMembers(members)
+	mc.applyRolePowerLevels(context.Background(), fake.Channels["ch1"], members, list)
+	if level := list.MemberMap[MakeUserID("alice-id")].PowerLevel; level == nil || *level != 50 {
+		t.Errorf("disabled: alice level = %v, want 50", level)
+	}
+	if level := list.MemberMap[MakeUserID("carol-id")].PowerLevel; level != nil {
+		t.Errorf("disabled: carol level = %d, want none", *level)
+	}
+}
+
+func TestApplyRolePowerLevels_DM(t *testing.T) {
+	t.Parallel()
+	mc, fake := newPowerLevelTestClient(t)
+	members := model.ChannelMembers{{ChannelId: "dm", UserId: "carol-id"}, {ChannelId: "dm", UserId: "my-user-id"}}
+
+	list := mc.channelMembersToChatMembers(members)
+	mc.applyRolePowerLevels(context.Background(), &model.Channel{Id: "dm", Type: model.ChannelTypeDirect}, members, list)
+	for userID, member := range list.MemberMap {
+		if member.PowerLevel != nil {
+			t.Errorf("%s: got level %d in a DM", userID, *member.PowerLevel)
+		}
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("expected no role lookups for a DM, got %+v", fake.Calls())
+	}
+}
+
+func TestHandleChannelMemberUpdated_PowerLevel(t *testing.T) {
+	t.Parallel()
+	mc, _ := newPowerLevelTestClient(t)
+	memberJSON, _ := json.Marshal(&model.ChannelMember{ChannelId: "ch1", UserId: "carol-id", SchemeAdmin: true})
+
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventChannelMemberUpdated, "", map[string]any{"channelMember": string(memberJSON)}))
+
+	change, member := memberChange(t, mc)
+	if change.PortalKey != makePortalKey("ch1") {
+		t.Errorf("portal key: got %+v", change.PortalKey)
+	}
+	if member.PowerLevel == nil || *member.PowerLevel != 75 {
+		t.Errorf("level: got %v, want 75", member.PowerLevel)
+	}
+}
+
+func TestHandleChannelMemberUpdated_UnchangedRoles(t *testing.T) {
+	t.Parallel()
+	mc, _ := newPowerLevelTestClient(t)
+	memberEvent := func(member *model.ChannelMember) *model.WebSocketEvent {
+		memberJSON, _ := json.Marshal(member)
+		return newWebSocketEvent(model.WebsocketEventChannelMemberUpdated, "", map[string]any{"channelMember": string(memberJSON)})
+	}
+
+	mc.handleEvent(memberEvent(&model.ChannelMember{ChannelId: "ch1", UserId: "carol-id", SchemeAdmin: true, LastViewedAt: 1000}))
+	// A read position update with the same roles.
+	mc.handleEvent(memberEvent(&model.ChannelMember{ChannelId: "ch1", UserId: "carol-id", SchemeAdmin: true, LastViewedAt: 2000}))
+	if events := testMock(mc).Events(); len(events) != 1 {
+		t.Fatalf("got %d events, want one for the first update", len(events))
+	}
+	mc.handleEvent(memberEvent(&model.ChannelMember{ChannelId: "ch1", UserId: "carol-id", LastViewedAt: 3000}))
+	if events := testMock(mc).Events(); len(events) != 2 {
+		t.Errorf("got %d events, want one more for the role change", len(events))
+	}
+}
+
+func TestUpdateMemberPowerLevel_ThreadRooms(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc, fake := newPowerLevelTestClient(t)
+	fake.Channels[relayTestChannel] = &model.Channel{Id: relayTestChannel, TeamId: "t1", Type: model.ChannelTypeOpen}
+	conn := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	conn.Config.PowerLevels = testPowerLevels
+	mc.connector = conn
+	thread := insertThreadPortal(t, mc, "r1")
+
+	mc.updateMemberPowerLevel(&model.ChannelMember{ChannelId: relayTestChannel, UserId: "bob-id", SchemeAdmin: true})
+	events := testMock(mc).Events()
+	if len(events) != 1 || events[0].GetPortalKey() != makePortalKey(relayTestChannel) {
+		t.Fatalf("events = %+v, want the channel's", events)
+	}
+	events[0].(*simplevent.ChatInfoChange).PostHandleFunc(ctx, getRelayTestPortal(t, conn, relayTestChannel))
+
+	events = testMock(mc).Events()
+	if len(events) != 2 || events[1].GetPortalKey() != thread.PortalKey {
+		t.Fatalf("events = %+v, want the change queued for the thread room", events)
+	}
+	member := events[1].(*simplevent.ChatInfoChange).ChatInfoChange.MemberChanges.MemberMap[MakeUserID("bob-id")]
+	if member.PowerLevel == nil || *member.PowerLevel != 60 {
+		t.Errorf("thread room level: got %v, want 60", member.PowerLevel)
+	}
+}
+
+func TestHandleRoleUpdated_Resync(t *testing.T) {
+	t.Parallel()
+	teamMemberJSON := func(userID string) string {
+		data, _ := json.Marshal(&model.TeamMember{TeamId: "t1", UserId: userID, SchemeAdmin: true})
+		return string(data)
+	}
+	tests := []struct {
+		name string
+		evt  *model.WebSocketEvent
+		want int
+	}{
+		{"team role", newWebSocketEvent(model.WebsocketEventMemberroleUpdated, "", map[string]any{"member": teamMemberJSON("my-user-id")}), 1},
+		{"team role of other user", newWebSocketEvent(model.WebsocketEventMemberroleUpdated, "", map[string]any{"member": teamMemberJSON("bob-id")}), 0},
+		{"system role", newWebSocketEvent(model.WebsocketEventUserRoleUpdated, "", map[string]any{"user_id": "my-user-id", "roles": "system_admin system_user"}), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, fake := newPowerLevelTestClient(t)
+			channels := []*model.Channel{fake.Channels["ch1"], {Id: "dm", Type: model.ChannelTypeDirect}}
+			fake.ChannelsForTeamUser["t1:my-user-id"] = channels
+			fake.ChannelsForUser["my-user-id"] = channels
+
+			mc.handleEvent(tt.evt)
+
+			events := testMock(mc).Events()
+			if len(events) != tt.want {
+				t.Fatalf("expected %d events, got %d", tt.want, len(events))
+			}
+			for _, evt := range events {
+				if resync, ok := evt.(*simplevent.ChatResync); !ok || resync.PortalKey != makePortalKey("ch1") || resync.CreatePortal {
+					t.Errorf("expected a resync of the ch1 portal, got %+v", evt)
+				}
+			}
+		})
+	}
+}
+
+// powerLevelChange returns a Matrix power level change of the given users in
+// the ch1 portal.
+func powerLevelChange(origSender *bridgev2.OrigSender, users map[id.UserID]*bridgev2.UserPowerLevelChange) *bridgev2.MatrixPowerLevelChange {
+	msg := &bridgev2.MatrixPowerLevelChange{Users: users}
+	msg.Portal = makeTestPortal("ch1")
+	msg.OrigSender = origSender
+	return msg
+}
+
+func TestHandleMatrixPowerLevels(t *testing.T) {
+	t.Parallel()
+	mc, fake := newPowerLevelTestClient(t)
+	mc.connector.Config.PowerLevels.SyncToMattermost = true
+	change := func(userID string, from, to int) *bridgev2.UserPowerLevelChange {
+		return &bridgev2.UserPowerLevelChange{
+			Target:                 ghostTarget(userID),
+			SinglePowerLevelChange: bridgev2.SinglePowerLevelChange{OrigLevel: from, NewLevel: to, NewIsSet: true},
+		}
+	}
+
+	changed, err := mc.HandleMatrixPowerLevels(context.Background(), powerLevelChange(nil, map[id.UserID]*bridgev2.UserPowerLevelChange{
+		"@mattermost_dave-id:localhost":  change("dave-id", 0, 50),
+		"@mattermost_alice-id:localhost": change("alice-id", 50, 0),
+		"@mattermost_bob-id:localhost":   change("bob-id", 0, 10),
+	}))
+	if err != nil || !changed {
+		t.Fatalf("HandleMatrixPowerLevels: got %v, %v", changed, err)
+	}
+	admins := map[string]bool{}
+	for _, member := range fake.ChannelMembers["ch1"] {
+		admins[member.UserId] = member.SchemeAdmin
+	}
+	if !admins["dave-id"] || admins["alice-id"] || admins["bob-id"] {
+		t.Errorf("channel admins: got %v, want only dave-id", admins)
+	}
+	for _, member := range fake.ChannelMembers["ch1"] {
+		if !member.SchemeUser {
+			t.Errorf("%s lost the member role", member.UserId)
+		}
+	}
+}
+
+func TestHandleMatrixPowerLevels_Rejected(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		sync       bool
+		origSender *bridgev2.OrigSender
+		wantErr    error
+	}{
+		{name: "sync disabled", wantErr: bridgev2.ErrPowerLevelsNotSupported},
+		{name: "relayed user", sync: true, origSender: &bridgev2.OrigSender{UserID: "@stranger:localhost"}, wantErr: errRelayedChannelEdit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, fake := newPowerLevelTestClient(t)
+			mc.connector.Config.PowerLevels.SyncToMattermost = tt.sync
+
+			_, err := mc.HandleMatrixPowerLevels(context.Background(), powerLevelChange(tt.origSender, map[id.UserID]*bridgev2.UserPowerLevelChange{
+				"@mattermost_dave-id:localhost": {
+					Target:                 ghostTarget("dave-id"),
+					SinglePowerLevelChange: bridgev2.SinglePowerLevelChange{NewLevel: 100, NewIsSet: true},
+				},
+			}))
+			// bridgev2's status errors don't support errors.Is.
+			if err == nil || err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(fake.Calls()) != 0 {
+				t.Errorf("expected no API calls, got %+v", fake.Calls())
+			}
+		})
+	}
+}
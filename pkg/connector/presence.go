@@ -0,0 +1,289 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// presencePollInterval is how often the statuses of known Mattermost users
+// are polled. Mattermost only sends status_change events to the sessions of
+// the user whose status changed, so other users' statuses have to be polled,
+// like the Mattermost webapp does.
+const presencePollInterval = time.Minute
+
+// mmStatusToPresence maps a Mattermost status to a Matrix presence and status
+// message. ok is false for unknown statuses.
+func mmStatusToPresence(status string) (presence event.Presence, statusMsg string, ok bool) {
+	switch status {
+	case model.StatusOnline:
+		return event.PresenceOnline, "", true
+	case model.StatusAway:
+		return event.PresenceUnavailable, "", true
+	case model.StatusDnd:
+		return event.PresenceUnavailable, "Do not disturb", true
+	case model.StatusOutOfOffice:
+		return event.PresenceUnavailable, "Out of office", true
+	case model.StatusOffline:
+		return event.PresenceOffline, "", true
+	default:
+		return "", "", false
+	}
+}
+
+// matrixPresenceToMMStatus maps a Matrix presence to a Mattermost status.
+// ok is false for unknown presences.
+func matrixPresenceToMMStatus(presence event.Presence) (status string, ok bool) {
+	switch presence {
+	case event.PresenceOnline:
+		return model.StatusOnline, true
+	case event.PresenceUnavailable:
+		return model.StatusAway, true
+	case event.PresenceOffline:
+		return model.StatusOffline, true
+	default:
+		return "", false
+	}
+}
+
+// presenceAPI is the part of a Matrix client needed to set presence.
+// *appservice.IntentAPI implements it.
+type presenceAPI interface {
+	EnsureRegistered(ctx context.Context) error
+	SetPresence(ctx context.Context, presence mautrix.ReqPresence) error
+}
+
+// presenceAPIFor returns the presence API behind a ghost intent, or nil if
+// the intent doesn't support presence.
+func presenceAPIFor(intent bridgev2.MatrixAPI) presenceAPI {
+	switch typed := intent.(type) {
+	case *matrix.ASIntent:
+		if typed.Matrix != nil {
+			return typed.Matrix
+		}
+	case presenceAPI:
+		return typed
+	}
+	return nil
+}
+
+// setMatrixPresence sets the presence of the Matrix user behind intent from a
+// Mattermost status.
+func setMatrixPresence(ctx context.Context, intent bridgev2.MatrixAPI, status string) error {
+	presence, statusMsg, ok := mmStatusToPresence(status)
+	if !ok {
+		return fmt.Errorf("unknown status %q", status)
+	}
+	api := presenceAPIFor(intent)
+	if api == nil {
+		return fmt.Errorf("intent does not support presence")
+	}
+	if err := api.EnsureRegistered(ctx); err != nil {
+		return fmt.Errorf("failed to register ghost: %w", err)
+	}
+	if err := api.SetPresence(ctx, mautrix.ReqPresence{Presence: presence, StatusMsg: statusMsg}); err != nil {
+		return fmt.Errorf("failed to set presence: %w", err)
+	}
+	return nil
+}
+
+// parseStatusChangeEvent extracts status_change data. Returns ok=false to skip.
+func (m *MattermostClient) parseStatusChangeEvent(evt *model.WebSocketEvent) (userID, status string, ok bool) {
+	uid, uidOk := evt.GetData()["user_id"].(string)
+	st, stOk := evt.GetData()["status"].(string)
+	if !uidOk || !stOk || uid == "" {
+		return "", "", false
+	}
+	return uid, st, true
+}
+
+func (m *MattermostClient) handleStatusChange(evt *model.WebSocketEvent) {
+	if !m.connector.Config.BridgePresence {
+		return
+	}
+	userID, status, ok := m.parseStatusChangeEvent(evt)
+	if !ok {
+		return
+	}
+	m.updateGhostPresence(m.log.WithContext(context.Background()), userID, status)
+}
+
+// shouldBridgePresence reports whether a Mattermost user's status is bridged
+// to their ghost. Puppet bots are skipped since their Matrix user is real,
+// and so are double-puppeted users, whose Matrix presence is pushed the other
+// way.
+func (m *MattermostClient) shouldBridgePresence(mmUserID string) bool {
+	if m.connector.IsPuppetUserID(mmUserID) {
+		return false
+	}
+	if _, ok := m.connector.DoublePuppetLoginID(mmUserID); ok {
+		return false
+	}
+	return true
+}
+
+// trackPresence adds a Mattermost user to the set whose status is polled.
+func (m *MattermostClient) trackPresence(mmUserID string) {
+	if !m.connector.Config.BridgePresence || mmUserID == "" {
+		return
+	}
+	m.presenceMu.Lock()
+	defer m.presenceMu.Unlock()
+	if m.presenceStatus == nil {
+		m.presenceStatus = newClientCache[string, string](m, "presence", false)
+	}
+	if _, ok := m.presenceStatus.get(mmUserID); !ok {
+		m.presenceStatus.set(mmUserID, "")
+	}
+}
+
+// swapPresence records status as the last bridged status of a user and
+// reports whether it changed.
+func (m *MattermostClient) swapPresence(mmUserID, status string) bool {
+	m.presenceMu.Lock()
+	defer m.presenceMu.Unlock()
+	if m.presenceStatus == nil {
+		m.presenceStatus = newClientCache[string, string](m, "presence", false)
+	}
+	if last, _ := m.presenceStatus.get(mmUserID); last == status {
+		return false
+	}
+	m.presenceStatus.set(mmUserID, status)
+	return true
+}
+
+// trackedPresenceUsers returns the users whose status is polled.
+func (m *MattermostClient) trackedPresenceUsers() []string {
+	m.presenceMu.Lock()
+	defer m.presenceMu.Unlock()
+	return m.presenceStatus.keys()
+}
+
+// updateGhostPresence sets the presence of a Mattermost user's ghost, unless
+// the status is unchanged since it was last bridged.
+func (m *MattermostClient) updateGhostPresence(ctx context.Context, mmUserID, status string) {
+	if !m.shouldBridgePresence(mmUserID) || !m.swapPresence(mmUserID, status) {
+		return
+	}
+	log := m.log.With().Str("user_id", mmUserID).Str("status", status).Logger()
+	ghost, err := m.connector.Bridge.GetGhostByID(ctx, MakeUserID(mmUserID))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get ghost for presence update")
+		return
+	}
+	if err := setMatrixPresence(ctx, ghost.Intent, status); err != nil {
+		log.Warn().Err(err).Msg("Failed to bridge presence")
+		// Forget the status so the next poll retries.
+		m.swapPresence(mmUserID, "")
+		return
+	}
+	log.Debug().Msg("Bridged presence")
+}
+
+// pollPresence periodically fetches the statuses of tracked users and bridges
+// any that changed. It runs until the client disconnects.
+func (m *MattermostClient) pollPresence(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+			m.syncPresence(ctx)
+		}
+	}
+}
+
+// syncPresence fetches the statuses of tracked users once.
+func (m *MattermostClient) syncPresence(ctx context.Context) {
+	userIDs := m.trackedPresenceUsers()
+	if len(userIDs) == 0 || m.client == nil {
+		return
+	}
+	statuses, _, err := m.client.GetUsersStatusesByIds(ctx, userIDs)
+	if err != nil {
+		m.log.Warn().Err(err).Int("users", len(userIDs)).Msg("Failed to poll user statuses")
+		return
+	}
+	for _, st := range statuses {
+		m.updateGhostPresence(ctx, st.UserId, st.Status)
+	}
+}
+
+// registerPresenceHandler subscribes to Matrix presence events, so the
+// presence of double-puppeted users is pushed to Mattermost. The homeserver
+// must send ephemeral events to the appservice.
+func (mc *MattermostConnector) registerPresenceHandler() {
+	if !mc.Config.BridgePresence || mc.Bridge == nil {
+		return
+	}
+	conn, ok := mc.Bridge.Matrix.(*matrix.Connector)
+	if !ok || conn.EventProcessor == nil {
+		mc.Bridge.Log.Warn().Msg("Matrix connector doesn't expose events, presence won't be pushed to Mattermost")
+		return
+	}
+	conn.EventProcessor.On(event.EphemeralEventPresence, mc.handleMatrixPresence)
+}
+
+// handleMatrixPresence pushes the presence of a double-puppeted Matrix user
+// to their Mattermost account.
+func (mc *MattermostConnector) handleMatrixPresence(ctx context.Context, evt *event.Event) {
+	content, ok := evt.Content.Parsed.(*event.PresenceEventContent)
+	if !ok {
+		return
+	}
+	mmUserID, client := mc.doublePuppetForMXID(evt.Sender)
+	if mmUserID == "" {
+		return
+	}
+	mc.pushPresence(ctx, client, mmUserID, content.Presence)
+}
+
+// doublePuppetForMXID returns the Mattermost user double-puppeted by a Matrix
+// user and the client to update their status with: their own login's client
+// when they have a full login, otherwise the primary client.
+func (mc *MattermostConnector) doublePuppetForMXID(mxid id.UserID) (string, *model.Client4) {
+	mmUserID, login := mc.doublePuppetLogin(mxid)
+	if mmUserID == "" {
+		return "", nil
+	}
+	if client, ok := login.Client.(*MattermostClient); ok && client.client != nil {
+		return mmUserID, client.client
+	}
+	return mmUserID, mc.primaryClient.Load()
+}
+
+// pushPresence sets a Mattermost user's status from a Matrix presence.
+// Updating another user's status needs the edit_other_users permission.
+func (mc *MattermostConnector) pushPresence(ctx context.Context, client *model.Client4, mmUserID string, presence event.Presence) {
+	log := mc.Bridge.Log.With().Str("mm_user_id", mmUserID).Str("presence", string(presence)).Logger()
+	status, ok := matrixPresenceToMMStatus(presence)
+	if !ok {
+		log.Debug().Msg("Ignoring unknown Matrix presence")
+		return
+	}
+	if client == nil {
+		log.Warn().Msg("No Mattermost client to push presence with")
+		return
+	}
+	if _, _, err := client.UpdateUserStatus(ctx, mmUserID, &model.Status{UserId: mmUserID, Status: status}); err != nil {
+		log.Warn().Err(err).Msg("Failed to push presence to Mattermost")
+		return
+	}
+	log.Debug().Str("status", status).Msg("Pushed presence to Mattermost")
+}
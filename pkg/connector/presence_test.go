@@ -0,0 +1,302 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+)
+
+// fakePresenceIntent is a ghost intent that records presence updates.
+type fakePresenceIntent struct {
+	bridgev2.MatrixAPI
+	registerErr error
+	presences   []mautrix.ReqPresence
+}
+
+func (f *fakePresenceIntent) EnsureRegistered(context.Context) error {
+	return f.registerErr
+}
+
+func (f *fakePresenceIntent) SetPresence(_ context.Context, presence mautrix.ReqPresence) error {
+	f.presences = append(f.presences, presence)
+	return nil
+}
+
+func TestMMStatusToPresence(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		status   string
+		want     event.Presence
+		wantMsg  string
+		wantOkay bool
+	}{
+		{model.StatusOnline, event.PresenceOnline, "", true},
+		{model.StatusAway, event.PresenceUnavailable, "", true},
+		{model.StatusDnd, event.PresenceUnavailable, "Do not disturb", true},
+		{model.StatusOutOfOffice, event.PresenceUnavailable, "Out of office", true},
+		{model.StatusOffline, event.PresenceOffline, "", true},
+		{"bogus", "", "", false},
+	}
+	for _, tt := range tests {
+		presence, msg, ok := mmStatusToPresence(tt.status)
+		if presence != tt.want || msg != tt.wantMsg || ok != tt.wantOkay {
+			t.Errorf("mmStatusToPresence(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.status, presence, msg, ok, tt.want, tt.wantMsg, tt.wantOkay)
+		}
+	}
+}
+
+func TestMatrixPresenceToMMStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		presence event.Presence
+		want     string
+		wantOkay bool
+	}{
+		{event.PresenceOnline, model.StatusOnline, true},
+		{event.PresenceUnavailable, model.StatusAway, true},
+		{event.PresenceOffline, model.StatusOffline, true},
+		{"busy", "", false},
+	}
+	for _, tt := range tests {
+		status, ok := matrixPresenceToMMStatus(tt.presence)
+		if status != tt.want || ok != tt.wantOkay {
+			t.Errorf("matrixPresenceToMMStatus(%q) = %q, %v; want %q, %v", tt.presence, status, ok, tt.want, tt.wantOkay)
+		}
+	}
+}
+
+func TestSetMatrixPresence(t *testing.T) {
+	t.Parallel()
+	intent := &fakePresenceIntent{}
+	if err := setMatrixPresence(context.Background(), intent, model.StatusDnd); err != nil {
+		t.Fatalf("setMatrixPresence: %v", err)
+	}
+	want := mautrix.ReqPresence{Presence: event.PresenceUnavailable, StatusMsg: "Do not disturb"}
+	if len(intent.presences) != 1 || intent.presences[0] != want {
+		t.Errorf("presences: got %+v, want [%+v]", intent.presences, want)
+	}
+}
+
+func TestSetMatrixPresence_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		intent bridgev2.MatrixAPI
+		status string
+	}{
+		{name: "unknown status", intent: &fakePresenceIntent{}, status: "bogus"},
+		{name: "intent without presence", intent: &fakeMatrixBot{}, status: model.StatusOnline},
+		{name: "register fails", intent: &fakePresenceIntent{registerErr: errors.New("nope")}, status: model.StatusOnline},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := setMatrixPresence(context.Background(), tt.intent, tt.status); err == nil {
+				t.Error("expected an error")
+			}
+			if fake, ok := tt.intent.(*fakePresenceIntent); ok && len(fake.presences) != 0 {
+				t.Errorf("presence should not be set, got %+v", fake.presences)
+			}
+		})
+	}
+}
+
+func TestParseStatusChangeEvent(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	tests := []struct {
+		name       string
+		data       map[string]any
+		wantUserID string
+		wantStatus string
+		wantOk     bool
+	}{
+		{"valid", map[string]any{"user_id": "u1", "status": "away"}, "u1", "away", true},
+		{"missing status", map[string]any{"user_id": "u1"}, "", "", false},
+		{"missing user", map[string]any{"status": "away"}, "", "", false},
+		{"empty user", map[string]any{"user_id": "", "status": "away"}, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			evt := newWebSocketEvent(model.WebsocketEventStatusChange, "", tt.data)
+			userID, status, ok := client.parseStatusChangeEvent(evt)
+			if userID != tt.wantUserID || status != tt.wantStatus || ok != tt.wantOk {
+				t.Errorf("got %q, %q, %v; want %q, %q, %v", userID, status, ok, tt.wantUserID, tt.wantStatus, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestShouldBridgePresence(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Puppets["@bot:test"] = &PuppetClient{MXID: "@bot:test", UserID: "puppet-id"}
+	mc.connector.dpLogins["dp-id"] = MakeUserLoginID("dp-id")
+
+	tests := []struct {
+		userID string
+		want   bool
+	}{
+		{"regular-id", true},
+		{"puppet-id", false},
+		{"dp-id", false},
+	}
+	for _, tt := range tests {
+		if got := mc.shouldBridgePresence(tt.userID); got != tt.want {
+			t.Errorf("shouldBridgePresence(%q) = %v, want %v", tt.userID, got, tt.want)
+		}
+	}
+}
+
+func TestTrackPresence(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.senderFor("u1")
+	if users := mc.trackedPresenceUsers(); len(users) != 0 {
+		t.Errorf("users tracked with presence disabled: %v", users)
+	}
+
+	mc.connector.Config.BridgePresence = true
+	mc.senderFor("u1")
+	mc.senderFor("u1")
+	mc.senderFor("u2")
+	if users := mc.trackedPresenceUsers(); len(users) != 2 {
+		t.Errorf("tracked users: got %v, want u1 and u2", users)
+	}
+}
+
+func TestSwapPresence(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	if !mc.swapPresence("u1", model.StatusOnline) {
+		t.Error("first status should be a change")
+	}
+	if mc.swapPresence("u1", model.StatusOnline) {
+		t.Error("same status should not be a change")
+	}
+	if !mc.swapPresence("u1", model.StatusAway) {
+		t.Error("new status should be a change")
+	}
+}
+
+func TestHandleStatusChange_Disabled(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventStatusChange, "", map[string]any{"user_id": "u1", "status": "away"}))
+	if users := mc.trackedPresenceUsers(); len(users) != 0 {
+		t.Errorf("status change should be ignored when disabled, tracked %v", users)
+	}
+}
+
+func TestSyncPresence_SkipsDoublePuppets(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Statuses["dp-id"] = &model.Status{UserId: "dp-id", Status: model.StatusAway}
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.BridgePresence = true
+	mc.connector.dpLogins["dp-id"] = MakeUserLoginID("dp-id")
+	mc.trackPresence("dp-id")
+
+	mc.syncPresence(context.Background())
+
+	if !fake.CalledPath("/api/v4/users/status/ids") {
+		t.Error("expected statuses to be polled")
+	}
+	// The double puppet's status is never recorded as bridged.
+	if !mc.swapPresence("dp-id", model.StatusAway) {
+		t.Error("double-puppeted user's status should not be bridged")
+	}
+}
+
+func TestSyncPresence_NoTrackedUsers(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.syncPresence(context.Background())
+	if len(fake.Calls()) != 0 {
+		t.Errorf("expected no API calls, got %+v", fake.Calls())
+	}
+}
+
+func TestPushPresence(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		presence   event.Presence
+		wantStatus string
+	}{
+		{event.PresenceOnline, model.StatusOnline},
+		{event.PresenceUnavailable, model.StatusAway},
+		{event.PresenceOffline, model.StatusOffline},
+		{"busy", ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.presence), func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			mc := newFullTestClient(fake.Server.URL)
+
+			mc.connector.pushPresence(context.Background(), mc.client, "alice-id", tt.presence)
+
+			st := fake.Statuses["alice-id"]
+			if tt.wantStatus == "" {
+				if st != nil {
+					t.Errorf("status should not be pushed, got %+v", st)
+				}
+				return
+			}
+			if st == nil || st.Status != tt.wantStatus {
+				t.Errorf("status: got %+v, want %q", st, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPushPresence_NoClient(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	// Must not panic without a primary client.
+	mc.connector.pushPresence(context.Background(), nil, "alice-id", event.PresenceOnline)
+}
+
+func TestHandleMatrixPresence_NotDoublePuppeted(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.primaryClient.Store(mc.client)
+
+	evt := &event.Event{
+		Sender:  "@stranger:test",
+		Type:    event.EphemeralEventPresence,
+		Content: event.Content{Parsed: &event.PresenceEventContent{Presence: event.PresenceOnline}},
+	}
+	mc.connector.handleMatrixPresence(context.Background(), evt)
+	if len(fake.Calls()) != 0 {
+		t.Errorf("expected no API calls, got %+v", fake.Calls())
+	}
+}
+
+func TestRegisterPresenceHandler_NoMatrixConnector(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.BridgePresence = true
+	// Must not panic when the Matrix connector isn't the appservice one.
+	mc.connector.registerPresenceHandler()
+}
@@ -0,0 +1,157 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"html"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+)
+
+// postPriorityField is the content field of Matrix messages bridged from
+// posts with a priority or a requested acknowledgement, holding
+// postPriorityInfo.
+const postPriorityField = "fi.mau.mattermost.priority"
+
+// postPriorityImportant is the priority of Important posts. The model only
+// has a constant for Urgent.
+const postPriorityImportant = "important"
+
+// PostPriorityConfig controls the bridging of message priority: the
+// Important and Urgent labels and requested acknowledgements of Mattermost
+// posts.
+type PostPriorityConfig struct {
+	// Labels prefixes the Matrix messages of Important and Urgent posts
+	// with the label, and those requesting an acknowledgement with a hint.
+	Labels bool `yaml:"labels"`
+	// AcknowledgeWithReactions acknowledges a post requesting it when a
+	// Matrix user reacts to it, and removes the acknowledgement with their
+	// last reaction.
+	AcknowledgeWithReactions bool `yaml:"acknowledge_with_reactions"`
+}
+
+// postPriorityInfo is the postPriorityField of a message.
+type postPriorityInfo struct {
+	// Priority is "important" or "urgent", empty for standard posts that
+	// request an acknowledgement.
+	Priority     string `json:"priority,omitempty"`
+	RequestedAck bool   `json:"requested_ack,omitempty"`
+}
+
+// postPriority returns the priority of a post. ok is false for standard
+// posts without a requested acknowledgement.
+func postPriority(post *model.Post) (info postPriorityInfo, ok bool) {
+	priority := post.GetPriority()
+	if priority == nil {
+		return info, false
+	}
+	if priority.Priority != nil {
+		switch *priority.Priority {
+		case model.PostPriorityUrgent, postPriorityImportant:
+			info.Priority = *priority.Priority
+		}
+	}
+	info.RequestedAck = priority.RequestedAck != nil && *priority.RequestedAck
+	return info, info.Priority != "" || info.RequestedAck
+}
+
+// priorityLabel returns the label of a priority, empty for standard posts.
+func priorityLabel(priority string) string {
+	switch priority {
+	case model.PostPriorityUrgent:
+		return "🚨 Urgent"
+	case postPriorityImportant:
+		return "❗ Important"
+	}
+	return ""
+}
+
+// applyPostPriority adds the priority of a post to the content of its text
+// part: the postPriorityField in extra and, with labels, the label before
+// the text and the acknowledgement hint after it.
+func applyPostPriority(content *event.MessageEventContent, extra map[string]any, info postPriorityInfo, labels bool) {
+	extra[postPriorityField] = info
+	if !labels {
+		return
+	}
+	if content.FormattedBody == "" {
+		content.Format = event.FormatHTML
+		content.FormattedBody = html.EscapeString(content.Body)
+	}
+	if label := priorityLabel(info.Priority); label != "" {
+		content.Body = label + ": " + content.Body
+		content.FormattedBody = "<strong>" + label + ":</strong> " + content.FormattedBody
+	}
+	if info.RequestedAck {
+		const hint = "Acknowledgement requested: react to acknowledge."
+		content.Body += "\n\n✅ " + hint
+		content.FormattedBody += "<p><em>✅ " + hint + "</em></p>"
+	}
+}
+
+// markRequestedAck records in the metadata of every part of a converted
+// post that the post requests an acknowledgement, so that reactions to any
+// part acknowledge it.
+func markRequestedAck(parts []*bridgev2.ConvertedMessagePart) {
+	for _, part := range parts {
+		meta, ok := part.DBMetadata.(*MessageMetadata)
+		if !ok || meta == nil {
+			meta = &MessageMetadata{}
+			part.DBMetadata = meta
+		}
+		meta.RequestedAck = true
+	}
+}
+
+// acknowledgeReaction acknowledges the post of a message part reacted to
+// from Matrix, if the post requests an acknowledgement. A failure only
+// loses the acknowledgement, so it is logged rather than failing the
+// reaction.
+func (m *MattermostClient) acknowledgeReaction(ctx context.Context, part *database.Message) {
+	if !m.connector.Config.PostPriority.AcknowledgeWithReactions || part == nil || !messageMetadata(part).RequestedAck {
+		return
+	}
+	postID := reactionTargetPost(part)
+	if _, _, err := m.client.AcknowledgePost(ctx, postID, m.userID); err != nil {
+		m.log.Warn().Err(err).Str("post_id", postID).Msg("Failed to acknowledge post")
+		return
+	}
+	m.log.Debug().Str("post_id", postID).Msg("Acknowledged post from reaction")
+}
+
+// unacknowledgeReaction removes the acknowledgement of the post of a removed
+// Matrix reaction when it was the sender's last reaction to a post
+// requesting an acknowledgement. Mattermost only allows this shortly after
+// acknowledging, so a failure is logged at debug level.
+func (m *MattermostClient) unacknowledgeReaction(ctx context.Context, reaction *database.Reaction) {
+	if !m.connector.Config.PostPriority.AcknowledgeWithReactions || m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return
+	}
+	part, err := m.connector.Bridge.DB.Message.GetPartByID(ctx, reaction.Room.Receiver, reaction.MessageID, reaction.MessagePartID)
+	if err != nil || part == nil || !messageMetadata(part).RequestedAck {
+		return
+	}
+	reactions, err := m.connector.Bridge.DB.Reaction.GetAllToMessageBySender(ctx, reaction.Room.Receiver, reaction.MessageID, reaction.SenderID)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to get the other reactions to the post")
+		return
+	}
+	for _, other := range reactions {
+		if other.EmojiID != reaction.EmojiID || other.MessagePartID != reaction.MessagePartID {
+			return
+		}
+	}
+	postID := ParseMessageID(reaction.MessageID)
+	if _, err := m.client.UnacknowledgePost(ctx, postID, m.userID); err != nil {
+		m.log.Debug().Err(err).Str("post_id", postID).Msg("Failed to remove acknowledgement")
+		return
+	}
+	m.log.Debug().Str("post_id", postID).Msg("Removed acknowledgement with last reaction")
+}
@@ -0,0 +1,217 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+)
+
+// priorityPost returns a post in ch1 with the given priority and requested
+// acknowledgement.
+func priorityPost(priority string, requestedAck bool) *model.Post {
+	return &model.Post{
+		Id:        "p1",
+		ChannelId: "ch1",
+		Message:   "Deploy **now**",
+		Metadata: &model.PostMetadata{Priority: &model.PostPriority{
+			Priority:     model.NewPointer(priority),
+			RequestedAck: model.NewPointer(requestedAck),
+		}},
+	}
+}
+
+func TestPostPriority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		post   *model.Post
+		want   postPriorityInfo
+		wantOK bool
+	}{
+		{"no metadata", &model.Post{Message: "hi"}, postPriorityInfo{}, false},
+		{"standard", priorityPost("", false), postPriorityInfo{}, false},
+		{"important", priorityPost("important", false), postPriorityInfo{Priority: "important"}, true},
+		{"urgent with ack", priorityPost("urgent", true), postPriorityInfo{Priority: "urgent", RequestedAck: true}, true},
+		{"ack only", priorityPost("", true), postPriorityInfo{RequestedAck: true}, true},
+		{"unknown priority", priorityPost("critical", false), postPriorityInfo{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := postPriority(tt.post)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("postPriority() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConvertPostToMatrix_Priority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		post          *model.Post
+		labels        bool
+		wantBody      string
+		wantFormatted string
+		wantAck       bool
+	}{
+		{
+			name:          "urgent",
+			post:          priorityPost("urgent", false),
+			labels:        true,
+			wantBody:      "🚨 Urgent: Deploy **now**",
+			wantFormatted: "<strong>🚨 Urgent:</strong> Deploy <strong>now</strong>",
+		},
+		{
+			name:          "important with ack",
+			post:          priorityPost("important", true),
+			labels:        true,
+			wantBody:      "❗ Important: Deploy **now**\n\n✅ Acknowledgement requested: react to acknowledge.",
+			wantFormatted: "<strong>❗ Important:</strong> Deploy <strong>now</strong><p><em>✅ Acknowledgement requested: react to acknowledge.</em></p>",
+			wantAck:       true,
+		},
+		{
+			name:          "labels off",
+			post:          priorityPost("urgent", true),
+			wantBody:      "Deploy **now**",
+			wantFormatted: "Deploy <strong>now</strong>",
+			wantAck:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient()
+			client.connector.Config.PostPriority.Labels = tt.labels
+
+			msg := client.convertPostToMatrix(context.Background(), nil, nil, tt.post)
+
+			if len(msg.Parts) != 1 {
+				t.Fatalf("expected one part, got %d", len(msg.Parts))
+			}
+			part := msg.Parts[0]
+			if part.Content.Body != tt.wantBody {
+				t.Errorf("body = %q, want %q", part.Content.Body, tt.wantBody)
+			}
+			if part.Content.FormattedBody != tt.wantFormatted {
+				t.Errorf("formatted body = %q, want %q", part.Content.FormattedBody, tt.wantFormatted)
+			}
+			info, ok := part.Extra[postPriorityField].(postPriorityInfo)
+			if !ok || info.RequestedAck != tt.wantAck {
+				t.Errorf("%s = %+v", postPriorityField, part.Extra[postPriorityField])
+			}
+			meta, _ := part.DBMetadata.(*MessageMetadata)
+			if got := meta != nil && meta.RequestedAck; got != tt.wantAck {
+				t.Errorf("RequestedAck metadata = %v, want %v", got, tt.wantAck)
+			}
+		})
+	}
+}
+
+func TestConvertPostToMatrix_StandardPostHasNoPriority(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.Config.PostPriority.Labels = true
+
+	msg := client.convertPostToMatrix(context.Background(), nil, nil, &model.Post{Id: "p1", Message: "hello"})
+
+	if len(msg.Parts) != 1 {
+		t.Fatalf("expected one part, got %d", len(msg.Parts))
+	}
+	if _, ok := msg.Parts[0].Extra[postPriorityField]; msg.Parts[0].Content.Body != "hello" || ok || msg.Parts[0].DBMetadata != nil {
+		t.Errorf("standard post part = %+v", msg.Parts[0])
+	}
+}
+
+func TestConvertEditToMatrix_KeepsPriorityLabel(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.Config.PostPriority.Labels = true
+	existing := []*database.Message{{ID: MakeMessageID("p1"), PartID: MakeMessagePartID(0)}}
+
+	edit := client.convertEditToMatrix(context.Background(), nil, nil, priorityPost("urgent", false), existing)
+
+	if len(edit.ModifiedParts) != 1 {
+		t.Fatalf("expected one modified part, got %d", len(edit.ModifiedParts))
+	}
+	part := edit.ModifiedParts[0]
+	if !strings.HasPrefix(part.Content.Body, "🚨 Urgent: ") {
+		t.Errorf("edited body = %q", part.Content.Body)
+	}
+	if _, ok := part.Extra[postPriorityField]; !ok {
+		t.Errorf("edit is missing %s", postPriorityField)
+	}
+}
+
+func reactionToPart(meta *MessageMetadata) *bridgev2.MatrixReaction {
+	return &bridgev2.MatrixReaction{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.ReactionEventContent]{
+			Portal:  makeTestPortal("test-channel"),
+			Content: &event.ReactionEventContent{RelatesTo: event.RelatesTo{Key: "\U0001f44d"}},
+		},
+		TargetMessage: &database.Message{ID: MakeMessageID("target-post"), Metadata: meta},
+		PreHandleResp: &bridgev2.MatrixReactionPreResponse{EmojiID: MakeEmojiID("+1")},
+	}
+}
+
+func TestHandleMatrixReaction_Acknowledges(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+
+	tests := []struct {
+		name    string
+		meta    *MessageMetadata
+		enabled bool
+		wantAck bool
+	}{
+		{"requested", &MessageMetadata{RequestedAck: true}, true, true},
+		{"not requested", &MessageMetadata{}, true, false},
+		{"no metadata", nil, true, false},
+		{"disabled", &MessageMetadata{RequestedAck: true}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient(fm.Server.URL)
+			mc.userID = "user-" + strings.ReplaceAll(tt.name, " ", "-")
+			mc.connector.Config.PostPriority.AcknowledgeWithReactions = tt.enabled
+
+			if _, err := mc.HandleMatrixReaction(context.Background(), reactionToPart(tt.meta)); err != nil {
+				t.Fatalf("HandleMatrixReaction: %v", err)
+			}
+			ackPath := "/api/v4/users/" + mc.userID + "/posts/target-post/ack"
+			if got := fm.CalledPath(ackPath); got != tt.wantAck {
+				t.Errorf("acknowledged = %v, want %v", got, tt.wantAck)
+			}
+		})
+	}
+}
+
+func TestHandleMatrixReaction_AcknowledgeFailureKeepsReaction(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	fm.FailEndpoints["/ack"] = true
+	mc := newFullTestClient(fm.Server.URL)
+	mc.connector.Config.PostPriority.AcknowledgeWithReactions = true
+
+	reaction, err := mc.HandleMatrixReaction(context.Background(), reactionToPart(&MessageMetadata{RequestedAck: true}))
+	if err != nil {
+		t.Fatalf("HandleMatrixReaction: %v", err)
+	}
+	if reaction == nil || string(reaction.EmojiID) != "+1" {
+		t.Errorf("reaction = %+v", reaction)
+	}
+}
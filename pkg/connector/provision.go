@@ -0,0 +1,225 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/id"
+)
+
+// maxProvisionBodySize is the maximum size of a /api/portals/provision
+// request body.
+const maxProvisionBodySize = 16 << 10
+
+// maxProvisionChannels bounds how many channels one provisioning request may
+// list.
+const maxProvisionChannels = 100
+
+// provisionWriteTimeout bounds how long a provisioning response may take to
+// write. Creating rooms takes a few requests to the homeserver each, so a
+// full batch can outlast the admin server's WriteTimeout.
+const provisionWriteTimeout = 5 * time.Minute
+
+// ProvisionRequest is the body of POST /api/portals/provision.
+type ProvisionRequest struct {
+	ChannelIDs []string `json:"channel_ids"`
+	// LoginID is the login whose Mattermost account creates the rooms.
+	// Defaults to the first full login.
+	LoginID string `json:"login_id,omitempty"`
+}
+
+// ProvisionResult reports what was done for one channel of a provisioning
+// request.
+type ProvisionResult struct {
+	ChannelID string `json:"channel_id"`
+	RoomID    string `json:"room_id,omitempty"`
+	// Created is true when the room was created by this request, and false
+	// when the channel already had one.
+	Created bool `json:"created"`
+	// Relay is true when the portal has a relay.
+	Relay bool `json:"relay"`
+	// InvitedPuppets lists the puppets invited because their bot is a
+	// member of the channel.
+	InvitedPuppets []id.UserID `json:"invited_puppets,omitempty"`
+	Error          string      `json:"error,omitempty"`
+}
+
+// errChannelNotOwned is reported for channels of another shard.
+var errChannelNotOwned = errors.New("channel is bridged by another shard")
+
+// HandleProvisionPortals is an HTTP handler for POST /api/portals/provision.
+// It creates the portal rooms of the listed channels ahead of their first
+// message, sets their relay where the relay config allows, and invites the
+// puppets whose bots are channel members and the auto_invite users, so new
+// workspaces are ready before anyone posts. Channels that already have a
+// room get the relay and invites only. Each channel is reported separately;
+// one failing doesn't stop the others.
+func (mc *MattermostConnector) HandleProvisionPortals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	r.Body = http.MaxBytesReader(w, r.Body, maxProvisionBodySize)
+	defer func() { _ = r.Body.Close() }()
+
+	var req ProvisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if len(req.ChannelIDs) == 0 {
+		http.Error(w, "channel_ids is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.ChannelIDs) > maxProvisionChannels {
+		http.Error(w, fmt.Sprintf("at most %d channels can be provisioned at once", maxProvisionChannels), http.StatusBadRequest)
+		return
+	}
+	channelIDs := make([]string, 0, len(req.ChannelIDs))
+	seen := make(map[string]bool, len(req.ChannelIDs))
+	for _, channelID := range req.ChannelIDs {
+		if !model.IsValidId(channelID) {
+			http.Error(w, "channel_ids must be valid Mattermost IDs", http.StatusBadRequest)
+			return
+		}
+		if !seen[channelID] {
+			seen[channelID] = true
+			channelIDs = append(channelIDs, channelID)
+		}
+	}
+
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Int("channels", len(channelIDs)).
+		Msg("Portal provisioning requested")
+
+	ctx := r.Context()
+	login, client, err := mc.clientLogin(ctx, req.LoginID)
+	if err != nil || client.client == nil {
+		http.Error(w, "no Mattermost login available to create rooms with", http.StatusConflict)
+		return
+	}
+	relay, err := mc.relayLogin(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to find relay login, provisioning without relay")
+	}
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(provisionWriteTimeout))
+	results := make([]ProvisionResult, 0, len(channelIDs))
+	failed := 0
+	for _, channelID := range channelIDs {
+		result := mc.provisionPortal(ctx, login, client, relay, channelID)
+		if result.Error != "" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	log.Info().
+		Int("channels", len(results)).
+		Int("failed", failed).
+		Msg("Portal provisioning finished")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"portals": results}); err != nil {
+		log.Warn().Err(err).Msg("Failed to write provisioning response")
+	}
+}
+
+// provisionPortal creates the room of one channel if it has none, sets the
+// relay and invites the channel's puppets and auto_invite users. Failures are reported in the
+// result's Error.
+func (mc *MattermostConnector) provisionPortal(ctx context.Context, login *bridgev2.UserLogin, client *MattermostClient, relay *bridgev2.UserLogin, channelID string) ProvisionResult {
+	log := mc.ctxLog(ctx).With().Str("channel_id", channelID).Logger()
+	result := ProvisionResult{ChannelID: channelID}
+	fail := func(msg string, err error) ProvisionResult {
+		log.Warn().Err(err).Msg(msg)
+		result.Error = fmt.Sprintf("%s: %v", msg, err)
+		return result
+	}
+
+	if !mc.OwnsChannel(channelID) {
+		return fail("Channel not provisioned", errChannelNotOwned)
+	}
+	// Provisioning a channel unbridged through the admin API bridges it
+	// again.
+	if rebridged, err := mc.rebridgeChannel(ctx, channelID); err != nil {
+		return fail("Failed to bridge unbridged channel again", err)
+	} else if rebridged {
+		log.Info().Msg("Unbridged channel provisioned, bridging it again")
+	}
+	portal, err := mc.Bridge.GetPortalByKey(ctx, makePortalKey(channelID))
+	if err != nil {
+		return fail("Failed to get portal", err)
+	}
+	info, err := client.GetChatInfo(ctx, portal)
+	if err != nil {
+		return fail("Failed to get channel info", err)
+	}
+	if portal.MXID == "" {
+		if err := portal.CreateMatrixRoom(ctx, login, info); err != nil {
+			return fail("Failed to create portal room", err)
+		}
+		result.Created = true
+	}
+	result.RoomID = string(portal.MXID)
+
+	if relay != nil && portal.Relay == nil && mc.relayAllowed(portal) {
+		if err := portal.SetRelay(ctx, relay); err != nil {
+			log.Warn().Err(err).Msg("Failed to set relay on provisioned portal")
+		}
+	}
+	result.Relay = portal.Relay != nil
+
+	for _, mxid := range mc.channelPuppets(info) {
+		if err := mc.Bridge.Bot.EnsureInvited(ctx, portal.MXID, mxid); err != nil {
+			log.Warn().Err(err).Stringer("mxid", mxid).Msg("Failed to invite puppet to provisioned portal")
+			continue
+		}
+		result.InvitedPuppets = append(result.InvitedPuppets, mxid)
+	}
+	if mc.autoInvite(ctx, portal, portalMetadata(portal).TeamID) > 0 {
+		if err := portal.Save(ctx); err != nil {
+			log.Warn().Err(err).Msg("Failed to save auto-invites of provisioned portal")
+		}
+	}
+
+	log.Info().
+		Stringer("room_id", portal.MXID).
+		Bool("created", result.Created).
+		Bool("relay", result.Relay).
+		Int("invited_puppets", len(result.InvitedPuppets)).
+		Msg("Portal provisioned")
+	return result
+}
+
+// channelPuppets returns the Matrix users of the puppets whose bots are
+// members of a channel.
+func (mc *MattermostConnector) channelPuppets(info *bridgev2.ChatInfo) []id.UserID {
+	if info.Members == nil {
+		return nil
+	}
+	mc.puppetMu.RLock()
+	defer mc.puppetMu.RUnlock()
+	var mxids []id.UserID
+	for mxid, puppet := range mc.Puppets {
+		if _, ok := info.Members.MemberMap[MakeUserID(puppet.UserID)]; ok {
+			mxids = append(mxids, mxid)
+		}
+	}
+	slices.Sort(mxids)
+	return mxids
+}
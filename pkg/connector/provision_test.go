@@ -0,0 +1,222 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/id"
+)
+
+// fakeInviteBot is a bridge bot that records invites. Other methods are
+// unimplemented and panic if called.
+type fakeInviteBot struct {
+	bridgev2.MatrixAPI
+	mu      sync.Mutex
+	invited map[id.RoomID][]id.UserID
+}
+
+func (b *fakeInviteBot) EnsureInvited(_ context.Context, roomID id.RoomID, userID id.UserID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.invited == nil {
+		b.invited = make(map[id.RoomID][]id.UserID)
+	}
+	b.invited[roomID] = append(b.invited[roomID], userID)
+	return nil
+}
+
+// newProvisionTestConnector returns a connector with a portal room for
+// relayTestChannel, a login whose client talks to a fake server knowing that
+// channel and relayTestChannel2, and two puppets, of which only
+// @agent:example.com has its bot in relayTestChannel.
+func newProvisionTestConnector(t *testing.T) (*MattermostConnector, *fakeInviteBot) {
+	t.Helper()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	bot := &fakeInviteBot{}
+	mc.Bridge.Bot = bot
+	mc.Puppets = map[id.UserID]*PuppetClient{
+		"@agent:example.com": {MXID: "@agent:example.com", UserID: "agent-bot-id"},
+		"@other:example.com": {MXID: "@other:example.com", UserID: "other-bot-id"},
+	}
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	for _, channelID := range []string{relayTestChannel, relayTestChannel2} {
+		fake.Channels[channelID] = &model.Channel{Id: channelID, Type: model.ChannelTypeOpen, Name: "town-square"}
+	}
+	fake.ChannelMembers[relayTestChannel] = model.ChannelMembers{
+		{ChannelId: relayTestChannel, UserId: "my-user-id"},
+		{ChannelId: relayTestChannel, UserId: "agent-bot-id"},
+	}
+
+	login, err := mc.Bridge.GetExistingUserLoginByID(context.Background(), MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	client := login.Client.(*MattermostClient)
+	client.client = model.NewAPIv4Client(fake.Server.URL)
+	client.userID = "my-user-id"
+	return mc, bot
+}
+
+// provision posts body to the provisioning endpoint.
+func provision(mc *MattermostConnector, method, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/portals/provision", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mc.HandleProvisionPortals(w, req)
+	return w
+}
+
+func TestHandleProvisionPortals_ExistingPortal(t *testing.T) {
+	t.Parallel()
+	mc, bot := newProvisionTestConnector(t)
+
+	w := provision(mc, http.MethodPost, `{"channel_ids":["`+relayTestChannel+`","`+relayTestChannel+`"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, body %q", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Portals []ProvisionResult `json:"portals"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Portals) != 1 {
+		t.Fatalf("duplicate channels should be provisioned once, got %+v", resp.Portals)
+	}
+	result := resp.Portals[0]
+	if result.Error != "" || result.Created || !result.Relay || result.RoomID != "!"+relayTestChannel+":example.com" {
+		t.Errorf("result: %+v", result)
+	}
+	if len(result.InvitedPuppets) != 1 || result.InvitedPuppets[0] != "@agent:example.com" {
+		t.Errorf("invited puppets: got %v, want [@agent:example.com]", result.InvitedPuppets)
+	}
+	if invited := bot.invited[id.RoomID(result.RoomID)]; len(invited) != 1 || invited[0] != "@agent:example.com" {
+		t.Errorf("bot invites: got %v", invited)
+	}
+	if portal := getRelayTestPortal(t, mc, relayTestChannel); portal.RelayLoginID != "relayuser" {
+		t.Errorf("portal relay: got %q, want relayuser", portal.RelayLoginID)
+	}
+}
+
+func TestHandleProvisionPortals_RelayDisabled(t *testing.T) {
+	t.Parallel()
+	mc, _ := newProvisionTestConnector(t)
+	mc.Config.Relay = RelayConfig{ChannelDenylist: []string{relayTestChannel}}
+
+	w := provision(mc, http.MethodPost, `{"channel_ids":["`+relayTestChannel+`"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, body %q", w.Code, w.Body.String())
+	}
+	if portal := getRelayTestPortal(t, mc, relayTestChannel); portal.RelayLoginID != "" {
+		t.Errorf("denied channel should not get a relay, got %q", portal.RelayLoginID)
+	}
+}
+
+func TestHandleProvisionPortals_Unbridged(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc, _ := newProvisionTestConnector(t)
+	if _, err := mc.unbridgeChannel(ctx, relayTestChannel); err != nil {
+		t.Fatalf("unbridge: %v", err)
+	}
+
+	w := provision(mc, http.MethodPost, `{"channel_ids":["`+relayTestChannel+`"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, body %q", w.Code, w.Body.String())
+	}
+	if mc.isUnbridged(ctx, relayTestChannel) {
+		t.Error("provisioned channel is still unbridged")
+	}
+}
+
+func TestHandleProvisionPortals_ChannelErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		channelID string
+		shard     bool
+	}{
+		{"unknown channel", "cccccccccccccccccccccccccc", false},
+		{"other shard", relayTestChannel, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, bot := newProvisionTestConnector(t)
+			if tt.shard {
+				mc.Config.Sharding = ShardingConfig{Count: 2, ID: (shardForChannel(tt.channelID, 2) + 1) % 2}
+			}
+
+			w := provision(mc, http.MethodPost, `{"channel_ids":["`+tt.channelID+`"]}`)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status: got %d, body %q", w.Code, w.Body.String())
+			}
+			var resp struct {
+				Portals []ProvisionResult `json:"portals"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if len(resp.Portals) != 1 || resp.Portals[0].Error == "" || resp.Portals[0].RoomID != "" {
+				t.Errorf("expected a failed result, got %+v", resp.Portals)
+			}
+			if len(bot.invited) != 0 {
+				t.Errorf("no puppets should be invited, got %v", bot.invited)
+			}
+		})
+	}
+}
+
+func TestHandleProvisionPortals_Validation(t *testing.T) {
+	t.Parallel()
+	mc, _ := newProvisionTestConnector(t)
+	tooMany := make([]string, maxProvisionChannels+1)
+	for i := range tooMany {
+		tooMany[i] = `"` + relayTestChannel + `"`
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"invalid json", http.MethodPost, "not json", http.StatusBadRequest},
+		{"no channels", http.MethodPost, `{"channel_ids":[]}`, http.StatusBadRequest},
+		{"invalid channel", http.MethodPost, `{"channel_ids":["../x"]}`, http.StatusBadRequest},
+		{"too many channels", http.MethodPost, `{"channel_ids":[` + strings.Join(tooMany, ",") + `]}`, http.StatusBadRequest},
+		{"too large", http.MethodPost, `{"channel_ids":["` + strings.Repeat("a", maxProvisionBodySize) + `"]}`, http.StatusBadRequest},
+		{"unknown login", http.MethodPost, `{"channel_ids":["` + relayTestChannel + `"],"login_id":"nobody"}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if w := provision(mc, tt.method, tt.body); w.Code != tt.want {
+				t.Errorf("status: got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleProvisionPortals_NoClient(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+
+	if w := provision(mc, http.MethodPost, `{"channel_ids":["`+relayTestChannel+`"]}`); w.Code != http.StatusConflict {
+		t.Errorf("status: got %d, want %d", w.Code, http.StatusConflict)
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/id"
+)
+
+// Reasons a puppet is marked unhealthy, as reported by /api/puppets.
+const (
+	// PuppetReasonOwnerDeactivated means Mattermost disabled the puppet's
+	// bot account because its owner was deactivated
+	// (ServiceSettings.DisableBotsWhenOwnerIsDeactivated).
+	PuppetReasonOwnerDeactivated = "owner_deactivated"
+	// PuppetReasonBotDisabled means the puppet's bot or user account is
+	// deactivated.
+	PuppetReasonBotDisabled = "bot_disabled"
+	// PuppetReasonAuthFailed means the puppet's token was rejected for
+	// another or unknown reason, e.g. it was revoked.
+	PuppetReasonAuthFailed = "auth_failed"
+)
+
+// puppetHealth records why a puppet's token stopped working.
+type puppetHealth struct {
+	Reason string
+	Detail string
+	Since  time.Time
+}
+
+// Healthy reports whether the puppet's token is believed to work. Unhealthy
+// puppets are skipped when routing messages, so they go through the relay.
+func (p *PuppetClient) Healthy() bool {
+	return p.health.Load() == nil
+}
+
+// markUnhealthy records a failure. It returns false if the puppet was
+// already unhealthy for the same reason.
+func (p *PuppetClient) markUnhealthy(reason, detail string) bool {
+	prev := p.health.Load()
+	if prev != nil && prev.Reason == reason {
+		return false
+	}
+	p.health.Store(&puppetHealth{Reason: reason, Detail: detail, Since: time.Now()})
+	return true
+}
+
+// markHealthy clears a recorded failure.
+func (p *PuppetClient) markHealthy() {
+	p.health.Store(nil)
+}
+
+// markSuccess records that an API call with the puppet's token succeeded.
+func (p *PuppetClient) markSuccess() {
+	p.lastSuccess.Store(time.Now().UnixMilli())
+}
+
+// LastSuccess returns the time of the last API call that succeeded with the
+// puppet's token, or the zero time if there was none.
+func (p *PuppetClient) LastSuccess() time.Time {
+	ms := p.lastSuccess.Load()
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// isAuthFailure reports whether a Mattermost API error means the token was
+// rejected. 403s are not counted, since they usually mean the puppet lacks
+// access to one channel rather than that its account is unusable.
+func isAuthFailure(resp *model.Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	if resp != nil && resp.StatusCode != 0 {
+		return resp.StatusCode == http.StatusUnauthorized
+	}
+	var appErr *model.AppError
+	return errors.As(err, &appErr) && appErr.StatusCode == http.StatusUnauthorized
+}
+
+// diagnosePuppetAuthError works out why a puppet's token was rejected. When
+// admin is set (a client allowed to read other users' bots), it looks up the
+// bot and its owner to tell a bot disabled by its owner's deactivation apart
+// from other failures. Otherwise it relies on the error details.
+func diagnosePuppetAuthError(ctx context.Context, admin *model.Client4, puppetUserID string, err error) (reason, detail string) {
+	if admin != nil && puppetUserID != "" {
+		bot, _, botErr := admin.GetBotIncludeDeleted(ctx, puppetUserID, "")
+		if botErr == nil && bot.DeleteAt != 0 {
+			if bot.OwnerId != "" {
+				owner, _, ownerErr := admin.GetUser(ctx, bot.OwnerId, "")
+				if ownerErr == nil && owner.DeleteAt != 0 {
+					return PuppetReasonOwnerDeactivated, fmt.Sprintf("bot @%s was disabled because its owner @%s was deactivated", bot.Username, owner.Username)
+				}
+			}
+			return PuppetReasonBotDisabled, fmt.Sprintf("bot @%s is disabled", bot.Username)
+		}
+	}
+
+	var appErr *model.AppError
+	if errors.As(err, &appErr) {
+		// Token sessions of deactivated accounts are rejected with
+		// "inactive_user_id=<id>" in the details.
+		if strings.Contains(appErr.DetailedError, "inactive_user_id") {
+			return PuppetReasonBotDisabled, "token belongs to a deactivated account"
+		}
+		if appErr.Message != "" {
+			return PuppetReasonAuthFailed, appErr.Message
+		}
+		return PuppetReasonAuthFailed, appErr.Id
+	}
+	return PuppetReasonAuthFailed, "token rejected"
+}
+
+// puppetByUserID returns the puppet for a Mattermost user ID, or nil.
+func (mc *MattermostConnector) puppetByUserID(mmUserID string) *PuppetClient {
+	mc.puppetMu.RLock()
+	defer mc.puppetMu.RUnlock()
+	for _, puppet := range mc.Puppets {
+		if puppet.UserID == mmUserID {
+			return puppet
+		}
+	}
+	return nil
+}
+
+// checkPuppetFailure marks the puppet posting as senderID unhealthy if err
+// shows its token was rejected. Failures of the login's own client are
+// ignored; they surface through the bridge state instead. It reports whether
+// a puppet's token was rejected.
+func (m *MattermostClient) checkPuppetFailure(ctx context.Context, senderID string, resp *model.Response, err error) bool {
+	if senderID == m.userID || !isAuthFailure(resp, err) {
+		return false
+	}
+	puppet := m.connector.puppetByUserID(senderID)
+	if puppet == nil {
+		return false
+	}
+	admin := m.client
+	if !m.connector.Config.MattermostAPI.botLookups() {
+		admin = nil
+	}
+	reason, detail := diagnosePuppetAuthError(ctx, admin, senderID, err)
+	m.connector.metrics().puppetAuthFailures.inc(reason)
+	if puppet.markUnhealthy(reason, detail) {
+		m.log.Warn().
+			Str("mxid", string(puppet.MXID)).
+			Str("mm_user_id", puppet.UserID).
+			Str("mm_username", puppet.Username).
+			Str("reason", reason).
+			Str("detail", detail).
+			Msg("Puppet marked unhealthy, falling back to relay")
+	}
+	return true
+}
+
+// recordPuppetSuccess records a successful API call by the puppet posting as
+// senderID. Calls made with the login's own client are ignored.
+func (m *MattermostClient) recordPuppetSuccess(senderID string) {
+	if senderID == m.userID {
+		return
+	}
+	if puppet := m.connector.puppetByUserID(senderID); puppet != nil {
+		puppet.markSuccess()
+	}
+}
+
+// PuppetStatus is one entry of the GET /api/puppets response.
+type PuppetStatus struct {
+	MXID       string `json:"mxid"`
+	MMUserID   string `json:"mm_user_id"`
+	MMUsername string `json:"mm_username"`
+	// Healthy is false once Mattermost rejected the puppet's token.
+	Healthy bool       `json:"healthy"`
+	Reason  string     `json:"reason,omitempty"`
+	Detail  string     `json:"detail,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+	// LastSuccess is the last API call that succeeded with the token.
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	// DoublePuppet reports whether the puppet's Mattermost user is double
+	// puppeted, so its posts appear as the Matrix user.
+	DoublePuppet bool `json:"double_puppet"`
+	// DoublePuppetSkipped explains why a puppet isn't double puppeted, e.g.
+	// because its homeserver is remote and the bridge has no secret for it.
+	DoublePuppetSkipped string `json:"double_puppet_skipped,omitempty"`
+	// Homeserver is the server name of the puppet's MXID, and Remote is true
+	// when it isn't the bridge's homeserver.
+	Homeserver string `json:"homeserver"`
+	Remote     bool   `json:"remote,omitempty"`
+	// Provisioned is true for puppets created by POST /api/provision-puppet.
+	Provisioned bool `json:"provisioned,omitempty"`
+}
+
+// puppetStatus returns the status of one puppet.
+func (mc *MattermostConnector) puppetStatus(puppet *PuppetClient) PuppetStatus {
+	status := PuppetStatus{
+		MXID:        string(puppet.MXID),
+		MMUserID:    puppet.UserID,
+		MMUsername:  puppet.Username,
+		Healthy:     true,
+		Provisioned: puppet.provisioned,
+	}
+	if health := puppet.health.Load(); health != nil {
+		since := health.Since
+		status.Healthy = false
+		status.Reason = health.Reason
+		status.Detail = health.Detail
+		status.Since = &since
+	}
+	if last := puppet.LastSuccess(); !last.IsZero() {
+		status.LastSuccess = &last
+	}
+	_, status.DoublePuppet = mc.DoublePuppetLoginID(puppet.UserID)
+	status.Homeserver = puppet.MXID.Homeserver()
+	status.Remote = mc.isRemoteMXID(puppet.MXID)
+	if err := mc.checkRemoteDoublePuppet(puppet.MXID); err != nil && !status.DoublePuppet {
+		status.DoublePuppetSkipped = err.Error()
+	}
+	return status
+}
+
+// PuppetStatuses returns the status of every loaded puppet, sorted by MXID.
+// Thread-safe.
+func (mc *MattermostConnector) PuppetStatuses() []PuppetStatus {
+	mc.puppetMu.RLock()
+	statuses := make([]PuppetStatus, 0, len(mc.Puppets))
+	for _, puppet := range mc.Puppets {
+		statuses = append(statuses, mc.puppetStatus(puppet))
+	}
+	mc.puppetMu.RUnlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].MXID < statuses[j].MXID
+	})
+	return statuses
+}
+
+// PuppetStatusFor returns the status of the puppet of a Matrix user.
+// Thread-safe.
+func (mc *MattermostConnector) PuppetStatusFor(mxid id.UserID) (PuppetStatus, bool) {
+	mc.puppetMu.RLock()
+	defer mc.puppetMu.RUnlock()
+	puppet, ok := mc.Puppets[mxid]
+	if !ok {
+		return PuppetStatus{}, false
+	}
+	return mc.puppetStatus(puppet), true
+}
+
+// HandleListPuppets is an HTTP handler for GET /api/puppets. It lists the
+// loaded puppets and their health, and the misconfigured puppet entries that
+// were skipped. Tokens are never included.
+func (mc *MattermostConnector) HandleListPuppets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	statuses := mc.PuppetStatuses()
+	unhealthy := 0
+	for _, status := range statuses {
+		if !status.Healthy {
+			unhealthy++
+		}
+	}
+	issues := mc.PuppetIssues()
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Int("puppets", len(statuses)).
+		Int("unhealthy", unhealthy).
+		Int("misconfigured", len(issues)).
+		Msg("Puppet list requested")
+
+	if issues == nil {
+		issues = []PuppetIssue{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"puppets": statuses, "misconfigured": issues}); err != nil {
+		log.Warn().Err(err).Msg("Failed to write puppet list response")
+	}
+}
+
+// HandleGetPuppet is an HTTP handler for GET /api/puppets/{mxid}. It returns
+// the status of one puppet. Tokens are never included.
+func (mc *MattermostConnector) HandleGetPuppet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	mxid := id.UserID(r.PathValue("mxid"))
+	if _, _, err := mxid.Parse(); err != nil {
+		http.Error(w, "invalid Matrix user ID", http.StatusBadRequest)
+		return
+	}
+	status, ok := mc.PuppetStatusFor(mxid)
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("mxid", string(mxid)).
+		Bool("found", ok).
+		Msg("Puppet status requested")
+	if !ok {
+		http.Error(w, "puppet not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.Warn().Err(err).Msg("Failed to write puppet status response")
+	}
+}
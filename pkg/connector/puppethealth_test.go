@@ -0,0 +1,375 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// newHealthTestClient returns a client whose relay token is "test-token" and
+// a puppet for @alice:localhost using token "puppet-token", both against fake.
+func newHealthTestClient(fake *fakeMM) (*MattermostClient, *PuppetClient) {
+	mc := newFullTestClient(fake.Server.URL)
+	puppetClient := model.NewAPIv4Client(fake.Server.URL)
+	puppetClient.SetToken("puppet-token")
+	puppet := &PuppetClient{
+		MXID:     "@alice:localhost",
+		Client:   puppetClient,
+		UserID:   "bot-alice",
+		Username: "alice-bot",
+	}
+	mc.connector.Puppets[puppet.MXID] = puppet
+	return mc, puppet
+}
+
+func puppetTextMessage(sender id.UserID) *bridgev2.MatrixMessage {
+	return &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Event:   &event.Event{Sender: sender},
+			Portal:  makeTestPortal("ch1"),
+			Content: &event.MessageEventContent{MsgType: event.MsgText, Body: "hello"},
+		},
+	}
+}
+
+func TestIsAuthFailure(t *testing.T) {
+	t.Parallel()
+	unauthorized := &model.AppError{Id: "api.context.session_expired.app_error", StatusCode: http.StatusUnauthorized}
+	tests := []struct {
+		name string
+		resp *model.Response
+		err  error
+		want bool
+	}{
+		{"no error", &model.Response{StatusCode: http.StatusUnauthorized}, nil, false},
+		{"401 response", &model.Response{StatusCode: http.StatusUnauthorized}, errors.New("x"), true},
+		{"403 response", &model.Response{StatusCode: http.StatusForbidden}, errors.New("x"), false},
+		{"500 response", &model.Response{StatusCode: http.StatusInternalServerError}, unauthorized, false},
+		{"401 app error", nil, unauthorized, true},
+		{"plain error", nil, errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isAuthFailure(tt.resp, tt.err); got != tt.want {
+				t.Errorf("isAuthFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnosePuppetAuthError(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	fake.Bots["bot-owner-gone"] = &model.Bot{UserId: "bot-owner-gone", Username: "ci-bot", OwnerId: "owner1", DeleteAt: 1}
+	fake.Users["owner1"] = &model.User{Id: "owner1", Username: "carol", DeleteAt: 1}
+	fake.Bots["bot-disabled"] = &model.Bot{UserId: "bot-disabled", Username: "old-bot", OwnerId: "owner2", DeleteAt: 1}
+	fake.Users["owner2"] = &model.User{Id: "owner2", Username: "dave"}
+	fake.Bots["bot-active"] = &model.Bot{UserId: "bot-active", Username: "ok-bot", OwnerId: "owner2"}
+
+	admin := model.NewAPIv4Client(fake.Server.URL)
+	admin.SetToken("test-token")
+
+	inactive := &model.AppError{Id: "app.user_access_token.invalid_or_missing", DetailedError: "inactive_user_id=bot-x", StatusCode: 401}
+	expired := &model.AppError{Id: "api.context.session_expired.app_error", Message: "Invalid or expired session", StatusCode: 401}
+
+	tests := []struct {
+		name       string
+		admin      *model.Client4
+		userID     string
+		err        error
+		wantReason string
+	}{
+		{"owner deactivated", admin, "bot-owner-gone", expired, PuppetReasonOwnerDeactivated},
+		{"bot disabled", admin, "bot-disabled", expired, PuppetReasonBotDisabled},
+		{"active bot, revoked token", admin, "bot-active", expired, PuppetReasonAuthFailed},
+		{"unknown bot, inactive detail", admin, "bot-unknown", inactive, PuppetReasonBotDisabled},
+		{"no admin, inactive detail", nil, "bot-owner-gone", inactive, PuppetReasonBotDisabled},
+		{"no admin, generic", nil, "bot-owner-gone", expired, PuppetReasonAuthFailed},
+		{"plain error", nil, "", errors.New("boom"), PuppetReasonAuthFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reason, detail := diagnosePuppetAuthError(context.Background(), tt.admin, tt.userID, tt.err)
+			if reason != tt.wantReason {
+				t.Errorf("reason: got %q (%s), want %q", reason, detail, tt.wantReason)
+			}
+			if detail == "" {
+				t.Error("detail should not be empty")
+			}
+		})
+	}
+}
+
+func TestHandleMatrixMessage_PuppetOwnerDeactivated(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.RejectTokens["puppet-token"] = &model.AppError{Id: "api.context.session_expired.app_error", StatusCode: 401}
+	fake.Bots["bot-alice"] = &model.Bot{UserId: "bot-alice", Username: "alice-bot", OwnerId: "owner1", DeleteAt: 1}
+	fake.Users["owner1"] = &model.User{Id: "owner1", Username: "alice", DeleteAt: 1}
+
+	mc, puppet := newHealthTestClient(fake)
+
+	_, err := mc.HandleMatrixMessage(context.Background(), puppetTextMessage("@alice:localhost"))
+	if err == nil {
+		t.Fatal("expected error when the puppet token is rejected")
+	}
+	if puppet.Healthy() {
+		t.Fatal("puppet should be marked unhealthy")
+	}
+	var status bridgev2.MessageStatus
+	if !errors.Is(err, ErrPuppetTokenRejected) || !errors.As(err, &status) ||
+		status.Status != event.MessageStatusRetriable || status.ErrorReason != event.MessageStatusNoPermission || !status.SendNotice {
+		t.Errorf("error: got %v (status %+v), want a retriable puppet token status", err, status)
+	}
+	if strings.Contains(status.Message, "puppet-token") || strings.Contains(err.Error(), "puppet-token") {
+		t.Error("the puppet token must not appear in the status")
+	}
+	if health := puppet.health.Load(); health.Reason != PuppetReasonOwnerDeactivated {
+		t.Errorf("reason: got %q, want %q", health.Reason, PuppetReasonOwnerDeactivated)
+	}
+
+	// The next message falls back to the relay client.
+	if _, err = mc.HandleMatrixMessage(context.Background(), puppetTextMessage("@alice:localhost")); err != nil {
+		t.Fatalf("relay fallback: %v", err)
+	}
+	client, userID := mc.resolvePostClient(nil, &event.Event{Sender: "@alice:localhost"})
+	if client != mc.client || userID != mc.userID {
+		t.Error("unhealthy puppet should resolve to the relay client")
+	}
+}
+
+func TestHandleMatrixMessage_PuppetFailureOnCloud(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.RejectTokens["puppet-token"] = &model.AppError{Id: "api.context.session_expired.app_error", StatusCode: 401}
+	fake.Bots["bot-alice"] = &model.Bot{UserId: "bot-alice", Username: "alice-bot", OwnerId: "owner1", DeleteAt: 1}
+
+	mc, puppet := newHealthTestClient(fake)
+	mc.connector.Config.MattermostAPI.Profile = APIProfileCloud
+
+	if _, err := mc.HandleMatrixMessage(context.Background(), puppetTextMessage("@alice:localhost")); err == nil {
+		t.Fatal("expected error when the puppet token is rejected")
+	}
+	if health := puppet.health.Load(); health == nil || health.Reason != PuppetReasonAuthFailed {
+		t.Errorf("health: got %+v, want %q", health, PuppetReasonAuthFailed)
+	}
+	if fake.CalledPath("/api/v4/bots/bot-alice") {
+		t.Error("the bot should not be looked up on Cloud")
+	}
+}
+
+func TestHandleMatrixMessage_PuppetServerErrorStaysHealthy(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.FailEndpoints["/api/v4/posts"] = true
+
+	mc, puppet := newHealthTestClient(fake)
+
+	if _, err := mc.HandleMatrixMessage(context.Background(), puppetTextMessage("@alice:localhost")); err == nil {
+		t.Fatal("expected error")
+	}
+	if !puppet.Healthy() {
+		t.Error("non-auth failures should not mark the puppet unhealthy")
+	}
+}
+
+func TestHandleMatrixMessage_PuppetRecordsSuccess(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	mc, puppet := newHealthTestClient(fake)
+	if !puppet.LastSuccess().IsZero() {
+		t.Fatal("new puppet should have no successful call")
+	}
+
+	if _, err := mc.HandleMatrixMessage(context.Background(), puppetTextMessage("@alice:localhost")); err != nil {
+		t.Fatalf("HandleMatrixMessage: %v", err)
+	}
+	if puppet.LastSuccess().IsZero() {
+		t.Error("successful post should be recorded")
+	}
+}
+
+func TestReloadPuppetsFromEntries_RecoversUnhealthy(t *testing.T) {
+	t.Parallel()
+	mm := fakeMattermostAPI(map[string]struct{ id, username string }{
+		"tok-alice": {"uid-alice", "puppet-alice"},
+	})
+	t.Cleanup(mm.Close)
+
+	mc := newTestBridgeConnector()
+	mc.Config.ServerURL = mm.URL
+	entries := []PuppetEntry{{Slug: "ALICE", MXID: "@puppet-alice:example.com", Token: "tok-alice"}}
+	mc.ReloadPuppetsFromEntries(context.Background(), entries)
+
+	puppet := mc.puppetByUserID("uid-alice")
+	if puppet == nil {
+		t.Fatal("puppet not loaded")
+	}
+	puppet.markUnhealthy(PuppetReasonBotDisabled, "bot @puppet-alice is disabled")
+
+	added, removed := mc.ReloadPuppetsFromEntries(context.Background(), entries)
+	if added != 0 || removed != 0 {
+		t.Errorf("added/removed: got %d/%d, want 0/0", added, removed)
+	}
+	if !puppet.Healthy() {
+		t.Error("puppet should recover once its token works again")
+	}
+}
+
+func TestReloadPuppetsFromEntries_StillUnhealthy(t *testing.T) {
+	t.Parallel()
+	mm := fakeMattermostAPI(nil)
+	t.Cleanup(mm.Close)
+
+	mc := newTestBridgeConnector()
+	client := model.NewAPIv4Client(mm.URL)
+	client.SetToken("tok-alice")
+	puppet := &PuppetClient{MXID: "@puppet-alice:example.com", Client: client, UserID: "uid-alice"}
+	puppet.markUnhealthy(PuppetReasonOwnerDeactivated, "owner gone")
+	mc.Puppets[puppet.MXID] = puppet
+
+	mc.ReloadPuppetsFromEntries(context.Background(), []PuppetEntry{{Slug: "ALICE", MXID: "@puppet-alice:example.com", Token: "tok-alice"}})
+
+	if mc.PuppetCount() != 1 {
+		t.Errorf("unhealthy puppet should stay listed, got %d puppets", mc.PuppetCount())
+	}
+	if health := puppet.health.Load(); health == nil || health.Reason != PuppetReasonOwnerDeactivated {
+		t.Errorf("health: got %+v, want reason preserved", health)
+	}
+}
+
+func TestMarkUnhealthy(t *testing.T) {
+	t.Parallel()
+	puppet := &PuppetClient{}
+	if !puppet.markUnhealthy(PuppetReasonAuthFailed, "a") {
+		t.Error("first failure should report a change")
+	}
+	if puppet.markUnhealthy(PuppetReasonAuthFailed, "b") {
+		t.Error("same reason should not report a change")
+	}
+	if !puppet.markUnhealthy(PuppetReasonOwnerDeactivated, "c") {
+		t.Error("new reason should report a change")
+	}
+	puppet.markHealthy()
+	if !puppet.Healthy() {
+		t.Error("markHealthy should clear the failure")
+	}
+}
+
+func TestHandleListPuppets(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Puppets["@b:localhost"] = &PuppetClient{MXID: "@b:localhost", UserID: "mm-b", Username: "bot-b", Client: model.NewAPIv4Client("http://mm")}
+	mc.Puppets["@a:localhost"] = &PuppetClient{MXID: "@a:localhost", UserID: "mm-a", Username: "bot-a", Client: model.NewAPIv4Client("http://mm")}
+	mc.Puppets["@b:localhost"].Client.SetToken("secret-token")
+	mc.Puppets["@b:localhost"].markUnhealthy(PuppetReasonOwnerDeactivated, "bot @bot-b was disabled because its owner @bob was deactivated")
+
+	rec := httptest.NewRecorder()
+	mc.HandleListPuppets(rec, httptest.NewRequest(http.MethodGet, "/api/puppets", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "secret-token") {
+		t.Error("response must not include tokens")
+	}
+
+	var resp struct {
+		Puppets []PuppetStatus `json:"puppets"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Puppets) != 2 {
+		t.Fatalf("expected 2 puppets, got %d", len(resp.Puppets))
+	}
+	a, b := resp.Puppets[0], resp.Puppets[1]
+	if a.MXID != "@a:localhost" || !a.Healthy || a.Reason != "" || a.Since != nil {
+		t.Errorf("healthy puppet: got %+v", a)
+	}
+	if b.MXID != "@b:localhost" || b.Healthy || b.Reason != PuppetReasonOwnerDeactivated || b.Since == nil {
+		t.Errorf("unhealthy puppet: got %+v", b)
+	}
+}
+
+func TestHandleListPuppets_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	rec := httptest.NewRecorder()
+	mc.HandleListPuppets(rec, httptest.NewRequest(http.MethodPost, "/api/puppets", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status: got %d, want 405", rec.Code)
+	}
+}
+
+func TestHandleGetPuppet(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.dpLogins = map[string]networkid.UserLoginID{"mm-a": "login-a"}
+	mc.Puppets["@a:localhost"] = &PuppetClient{MXID: "@a:localhost", UserID: "mm-a", Username: "bot-a", Client: model.NewAPIv4Client("http://mm")}
+	mc.Puppets["@a:localhost"].Client.SetToken("secret-token")
+	mc.Puppets["@a:localhost"].markSuccess()
+	mc.Puppets["@b:localhost"] = &PuppetClient{MXID: "@b:localhost", UserID: "mm-b", Username: "bot-b", Client: model.NewAPIv4Client("http://mm")}
+	handler := mc.adminAPIHandler()
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		want             int
+		wantDoublePuppet bool
+		wantLastSuccess  bool
+	}{
+		{"double puppeted", http.MethodGet, "/api/puppets/@a:localhost", http.StatusOK, true, true},
+		{"escaped", http.MethodGet, "/api/puppets/%40b%3Alocalhost", http.StatusOK, false, false},
+		{"unknown", http.MethodGet, "/api/puppets/@c:localhost", http.StatusNotFound, false, false},
+		{"invalid mxid", http.MethodGet, "/api/puppets/alice", http.StatusBadRequest, false, false},
+		{"method", http.MethodPost, "/api/puppets/@a:localhost", http.StatusMethodNotAllowed, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.want {
+				t.Fatalf("status: got %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			if strings.Contains(rec.Body.String(), "secret-token") {
+				t.Error("response must not include tokens")
+			}
+			var status PuppetStatus
+			if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !status.Healthy || status.DoublePuppet != tt.wantDoublePuppet || (status.LastSuccess != nil) != tt.wantLastSuccess {
+				t.Errorf("status: got %+v", status)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/id"
+)
+
+// Reasons a puppet entry is skipped, as reported in the misconfigured list
+// of /api/puppets. Rejected tokens use PuppetReasonAuthFailed.
+const (
+	// PuppetIssueInvalidMXID means the entry's MXID isn't a valid Matrix
+	// user ID, e.g. it lacks a server name.
+	PuppetIssueInvalidMXID = "invalid_mxid"
+	// PuppetIssueGhostMXID means the entry's MXID is in the bridge's ghost
+	// namespace instead of being a real Matrix user.
+	PuppetIssueGhostMXID = "ghost_mxid"
+	// PuppetIssueDuplicateMXID means an earlier entry has the same MXID.
+	PuppetIssueDuplicateMXID = "duplicate_mxid"
+)
+
+// serverNameRe matches a Matrix server name: a DNS name, an IPv4 address or
+// a bracketed IPv6 address, with an optional port.
+var serverNameRe = regexp.MustCompile(`^(?:\[[0-9A-Fa-f:.]{2,45}\]|[0-9A-Za-z.-]{1,255})(?::[0-9]{1,5})?$`)
+
+// validatePuppetMXID checks that a puppet's MXID is a valid Matrix user ID,
+// including its server name, and returns it.
+func validatePuppetMXID(mxid string) (id.UserID, error) {
+	userID := id.UserID(mxid)
+	_, server, err := userID.ParseAndValidate()
+	if err != nil {
+		return "", fmt.Errorf("mxid must be a valid Matrix user ID")
+	}
+	if !serverNameRe.MatchString(server) || strings.HasPrefix(server, ".") || strings.HasSuffix(server, ".") {
+		return "", fmt.Errorf("mxid has an invalid server name")
+	}
+	return userID, nil
+}
+
+// PuppetIssue is a puppet entry that was skipped when loading puppets.
+type PuppetIssue struct {
+	Slug   string `json:"slug,omitempty"`
+	MXID   string `json:"mxid"`
+	Reason string `json:"reason"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// checkPuppetEntries splits puppet entries into the ones that can be loaded
+// and the issues of the others: invalid MXIDs, ghost MXIDs and repeated
+// MXIDs, of which the first entry wins.
+func (mc *MattermostConnector) checkPuppetEntries(entries []PuppetEntry) (valid []PuppetEntry, issues []PuppetIssue) {
+	seen := make(map[id.UserID]string, len(entries))
+	for _, entry := range entries {
+		mxid, err := validatePuppetMXID(entry.MXID)
+		prev, dup := seen[mxid]
+		switch {
+		case err != nil:
+			issues = append(issues, PuppetIssue{Slug: entry.Slug, MXID: entry.MXID, Reason: PuppetIssueInvalidMXID, Detail: err.Error()})
+		case mc.isGhostMXID(mxid):
+			issues = append(issues, PuppetIssue{Slug: entry.Slug, MXID: entry.MXID, Reason: PuppetIssueGhostMXID, Detail: "puppets must map real Matrix users"})
+		case dup:
+			issues = append(issues, PuppetIssue{Slug: entry.Slug, MXID: entry.MXID, Reason: PuppetIssueDuplicateMXID, Detail: fmt.Sprintf("already configured by puppet %q", prev)})
+		default:
+			seen[mxid] = entry.Slug
+			valid = append(valid, entry)
+		}
+	}
+	return valid, issues
+}
+
+// isGhostMXID reports whether mxid is one of the bridge's ghosts.
+func (mc *MattermostConnector) isGhostMXID(mxid id.UserID) bool {
+	if mc.Bridge == nil || mc.Bridge.Matrix == nil {
+		return false
+	}
+	_, isGhost := mc.Bridge.Matrix.ParseGhostMXID(mxid)
+	return isGhost
+}
+
+// isRemoteMXID reports whether mxid lives on another homeserver than the
+// bridge's. It's false when the bridge's server isn't known.
+func (mc *MattermostConnector) isRemoteMXID(mxid id.UserID) bool {
+	if mc.Bridge == nil || mc.Bridge.Matrix == nil {
+		return false
+	}
+	local := mc.Bridge.Matrix.ServerName()
+	return local != "" && !strings.EqualFold(mxid.Homeserver(), local)
+}
+
+// errNoRemoteDoublePuppet is returned when a puppet on a remote homeserver
+// can't be double puppeted.
+var errNoRemoteDoublePuppet = errors.New("no as_token secret in double_puppet.secrets for the puppet's homeserver")
+
+// checkRemoteDoublePuppet returns errNoRemoteDoublePuppet if mxid is on a
+// remote homeserver for which the bridge can't assert identities. Double
+// puppeting with the appservice's token needs an as_token secret for the
+// user's server, which federated homeservers rarely grant.
+func (mc *MattermostConnector) checkRemoteDoublePuppet(mxid id.UserID) error {
+	if !mc.isRemoteMXID(mxid) {
+		return nil
+	}
+	conn, ok := mc.Bridge.Matrix.(*matrix.Connector)
+	if !ok || conn.Config == nil {
+		return errNoRemoteDoublePuppet
+	}
+	if !strings.HasPrefix(conn.Config.DoublePuppet.Secrets[mxid.Homeserver()], "as_token:") {
+		return errNoRemoteDoublePuppet
+	}
+	return nil
+}
+
+// setupPuppetDoublePuppet sets up double puppeting for a newly loaded
+// puppet, so its Mattermost user's posts appear as the Matrix user. Puppets
+// on remote homeservers the bridge can't assert identities on still post to
+// Mattermost; their Mattermost posts just come from a ghost. With confirm,
+// for puppets that don't come from the bridge's own environment, the link
+// goes through double_puppet_confirmation like POST /api/double-puppet.
+func (mc *MattermostConnector) setupPuppetDoublePuppet(ctx context.Context, puppet *PuppetClient, slug string, confirm bool) {
+	log := mc.ctxLog(ctx)
+	if confirm && !mc.puppetLinkApproved(ctx, puppet) {
+		mc.requestPuppetLinkConfirmation(ctx, puppet, slug)
+		return
+	}
+	err := mc.setupUserDoublePuppet(ctx, puppet.UserID, string(puppet.MXID))
+	switch {
+	case errors.Is(err, errNoRemoteDoublePuppet):
+		log.Info().
+			Str("slug", slug).
+			Stringer("mxid", puppet.MXID).
+			Str("homeserver", puppet.MXID.Homeserver()).
+			Msg("Puppet is on a remote homeserver without a double puppet secret, skipping double puppet")
+	case err != nil:
+		log.Warn().Err(err).
+			Str("slug", slug).
+			Stringer("mxid", puppet.MXID).
+			Msg("Failed to setup double puppet for puppet user")
+	}
+}
+
+// puppetLinkApproved reports whether a puppet's Matrix user may be double
+// puppeted without asking: confirmation is disabled, or the Matrix user
+// already has the login of the puppet's Mattermost user, from an earlier
+// confirmation.
+func (mc *MattermostConnector) puppetLinkApproved(ctx context.Context, puppet *PuppetClient) bool {
+	if !mc.Config.DoublePuppetConfirmation.Enabled {
+		return true
+	}
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return false
+	}
+	login, err := mc.Bridge.DB.UserLogin.GetByID(ctx, MakeUserLoginID(puppet.UserID))
+	if err != nil {
+		mc.ctxLog(ctx).Warn().Err(err).Stringer("mxid", puppet.MXID).Msg("Failed to check existing double puppet login")
+		return false
+	}
+	return login != nil && login.UserMXID == puppet.MXID
+}
+
+// requestPuppetLinkConfirmation asks a puppet's Matrix user to approve
+// double puppeting; the link is set up once they send confirm-link.
+// Failures are logged and leave the puppet without a double puppet.
+func (mc *MattermostConnector) requestPuppetLinkConfirmation(ctx context.Context, puppet *PuppetClient, slug string) {
+	log := mc.ctxLog(ctx)
+	if err := mc.checkRemoteDoublePuppet(puppet.MXID); err != nil {
+		log.Info().
+			Str("slug", slug).
+			Stringer("mxid", puppet.MXID).
+			Str("homeserver", puppet.MXID.Homeserver()).
+			Msg("Puppet is on a remote homeserver without a double puppet secret, skipping double puppet")
+		return
+	}
+	expires, err := mc.requestLinkConfirmation(ctx, puppet.UserID, puppet.MXID)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("slug", slug).
+			Stringer("mxid", puppet.MXID).
+			Msg("Failed to send double puppet confirmation for puppet user")
+		return
+	}
+	log.Info().
+		Str("slug", slug).
+		Stringer("mxid", puppet.MXID).
+		Str("mm_user_id", puppet.UserID).
+		Time("expires_at", expires).
+		Msg("Double puppet confirmation sent for puppet user")
+}
+
+// PuppetIssues returns the puppet entries skipped by the last puppet load or
+// reload. Thread-safe.
+func (mc *MattermostConnector) PuppetIssues() []PuppetIssue {
+	mc.puppetMu.RLock()
+	defer mc.puppetMu.RUnlock()
+	return append([]PuppetIssue(nil), mc.puppetIssues...)
+}
@@ -0,0 +1,238 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidatePuppetMXID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		mxid string
+		ok   bool
+	}{
+		{"@alice:example.com", true},
+		{"@alice:matrix.other.org", true},
+		{"@alice:example.com:8448", true},
+		{"@alice:127.0.0.1", true},
+		{"@alice:[::1]:8448", true},
+		{"alice", false},
+		{"@alice", false},
+		{"@:example.com", false},
+		{"@Alice:example.com", false},
+		{"@alice:", false},
+		{"@alice:exa mple.com", false},
+		{"@alice:example.com:port", false},
+		{"@alice:.example.com", false},
+		{"@alice:example.com/path", false},
+		{"<script>alert(1)</script>", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mxid, func(t *testing.T) {
+			t.Parallel()
+			_, err := validatePuppetMXID(tt.mxid)
+			if (err == nil) != tt.ok {
+				t.Errorf("validatePuppetMXID(%q) error = %v, want ok=%v", tt.mxid, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCheckPuppetEntries(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Bridge.Matrix = doctorMatrixConnector{}
+
+	valid, issues := mc.checkPuppetEntries([]PuppetEntry{
+		{Slug: "ALICE", MXID: "@alice:example.com", Token: "tok-a"},
+		{Slug: "BAD", MXID: "alice", Token: "tok-bad"},
+		{Slug: "GHOST", MXID: "@mattermost_abc:example.com", Token: "tok-g"},
+		{Slug: "ALICE2", MXID: "@alice:example.com", Token: "tok-a2"},
+		{Slug: "REMOTE", MXID: "@bob:other.org", Token: "tok-b"},
+	})
+
+	if len(valid) != 2 || valid[0].Slug != "ALICE" || valid[1].Slug != "REMOTE" {
+		t.Errorf("valid entries: got %+v", valid)
+	}
+	want := map[string]string{
+		"BAD":    PuppetIssueInvalidMXID,
+		"GHOST":  PuppetIssueGhostMXID,
+		"ALICE2": PuppetIssueDuplicateMXID,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for _, issue := range issues {
+		if want[issue.Slug] != issue.Reason {
+			t.Errorf("issue of %s: got reason %q, want %q", issue.Slug, issue.Reason, want[issue.Slug])
+		}
+		if strings.Contains(issue.Detail, "tok-") {
+			t.Errorf("issue detail must not contain the token: %q", issue.Detail)
+		}
+	}
+}
+
+func TestReloadPuppetsFromEntries_RemoteHomeserver(t *testing.T) {
+	t.Parallel()
+	mm := fakeMattermostAPI(map[string]struct{ id, username string }{
+		"tok-local":  {"uid-local", "puppet-local"},
+		"tok-remote": {"uid-remote", "puppet-remote"},
+	})
+	t.Cleanup(mm.Close)
+
+	mc := newTestBridgeConnector()
+	mc.Bridge.Matrix = doctorMatrixConnector{}
+	mc.Config.ServerURL = mm.URL
+
+	added, _ := mc.ReloadPuppetsFromEntries(context.Background(), []PuppetEntry{
+		{Slug: "LOCAL", MXID: "@local:example.com", Token: "tok-local"},
+		{Slug: "REMOTE", MXID: "@remote:other.org", Token: "tok-remote"},
+	})
+	if added != 2 {
+		t.Fatalf("remote puppets should be loaded: got %d added", added)
+	}
+
+	local, ok := mc.PuppetStatusFor("@local:example.com")
+	if !ok || local.Remote || local.Homeserver != "example.com" || local.DoublePuppetSkipped != "" {
+		t.Errorf("local puppet status: got %+v", local)
+	}
+	remote, ok := mc.PuppetStatusFor("@remote:other.org")
+	if !ok || !remote.Remote || remote.Homeserver != "other.org" {
+		t.Errorf("remote puppet status: got %+v", remote)
+	}
+	if remote.DoublePuppet || remote.DoublePuppetSkipped == "" {
+		t.Errorf("remote puppet should report why double puppeting was skipped: got %+v", remote)
+	}
+	if err := mc.setupUserDoublePuppet(context.Background(), "uid-remote", "@remote:other.org"); !errors.Is(err, errNoRemoteDoublePuppet) {
+		t.Errorf("setupUserDoublePuppet for a remote user: got %v, want errNoRemoteDoublePuppet", err)
+	}
+}
+
+func TestReloadPuppetsFromEntries_RecordsIssues(t *testing.T) {
+	t.Parallel()
+	mm := fakeMattermostAPI(map[string]struct{ id, username string }{
+		"tok-good": {"uid-good", "puppet-good"},
+	})
+	t.Cleanup(mm.Close)
+
+	mc := newTestBridgeConnector()
+	mc.Config.ServerURL = mm.URL
+
+	added, _ := mc.ReloadPuppetsFromEntries(context.Background(), []PuppetEntry{
+		{Slug: "GOOD", MXID: "@good:example.com", Token: "tok-good"},
+		{Slug: "NOSERVER", MXID: "@noserver", Token: "tok-noserver"},
+		{Slug: "REVOKED", MXID: "@revoked:example.com", Token: "tok-revoked"},
+	})
+	if added != 1 {
+		t.Errorf("expected 1 added, got %d", added)
+	}
+
+	issues := mc.PuppetIssues()
+	reasons := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		reasons[issue.Slug] = issue.Reason
+	}
+	if reasons["NOSERVER"] != PuppetIssueInvalidMXID || reasons["REVOKED"] != PuppetReasonAuthFailed || len(reasons) != 2 {
+		t.Errorf("issues: got %+v", issues)
+	}
+
+	// A reload without the bad entries clears them.
+	mc.ReloadPuppetsFromEntries(context.Background(), []PuppetEntry{
+		{Slug: "GOOD", MXID: "@good:example.com", Token: "tok-good"},
+	})
+	if issues := mc.PuppetIssues(); len(issues) != 0 {
+		t.Errorf("expected issues to be cleared, got %+v", issues)
+	}
+}
+
+func TestHandleReloadPuppets_InvalidMXID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		entries []PuppetEntry
+	}{
+		{"no server name", []PuppetEntry{{Slug: "BAD", MXID: "@bad", Token: "secret-tok-bad"}}},
+		{"invalid server name", []PuppetEntry{{Slug: "BAD", MXID: "@bad:exa mple.com", Token: "secret-tok-bad"}}},
+		{"duplicate", []PuppetEntry{
+			{Slug: "FIRST", MXID: "@bad:example.com", Token: "secret-tok-first"},
+			{Slug: "BAD", MXID: "@bad:example.com", Token: "secret-tok-bad"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newTestBridgeConnector()
+			body, _ := json.Marshal(tt.entries)
+			w := httptest.NewRecorder()
+
+			mc.HandleReloadPuppets(w, httptest.NewRequest(http.MethodPost, "/api/reload-puppets", bytes.NewReader(body)))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), `"BAD"`) {
+				t.Errorf("error should name the puppet: %s", w.Body.String())
+			}
+			if strings.Contains(w.Body.String(), "secret-tok") {
+				t.Error("error must not contain the token")
+			}
+			if mc.PuppetCount() != 0 {
+				t.Error("no puppet should be loaded from a rejected request")
+			}
+		})
+	}
+}
+
+func TestHandleListPuppets_Misconfigured(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.puppetIssues = []PuppetIssue{{Slug: "BAD", MXID: "@bad", Reason: PuppetIssueInvalidMXID, Detail: "mxid must be a valid Matrix user ID"}}
+
+	rec := httptest.NewRecorder()
+	mc.HandleListPuppets(rec, httptest.NewRequest(http.MethodGet, "/api/puppets", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d", rec.Code)
+	}
+	var resp struct {
+		Puppets       []PuppetStatus `json:"puppets"`
+		Misconfigured []PuppetIssue  `json:"misconfigured"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Misconfigured) != 1 || resp.Misconfigured[0].Slug != "BAD" || resp.Misconfigured[0].Reason != PuppetIssueInvalidMXID {
+		t.Errorf("misconfigured: got %+v", resp.Misconfigured)
+	}
+}
+
+func TestLoadPuppets_RecordsIssues(t *testing.T) {
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+
+	mc := newTestBridgeConnector()
+	mc.Config.ServerURL = fake.Server.URL
+
+	t.Setenv("MATTERMOST_PUPPET_NOSERVER_MXID", "@noserver")
+	t.Setenv("MATTERMOST_PUPPET_NOSERVER_TOKEN", "tok-noserver")
+
+	mc.loadPuppets(context.Background())
+
+	if len(mc.Puppets) != 0 {
+		t.Errorf("expected 0 puppets, got %d", len(mc.Puppets))
+	}
+	issues := mc.PuppetIssues()
+	if len(issues) != 1 || issues[0].Slug != "NOSERVER" || issues[0].Reason != PuppetIssueInvalidMXID {
+		t.Errorf("issues: got %+v", issues)
+	}
+}
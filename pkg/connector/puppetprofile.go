@@ -0,0 +1,350 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+var errNoProfileAPI = errors.New("matrix connector can't fetch profiles")
+
+// matrixProfile is a Matrix display name and avatar pushed to Mattermost.
+type matrixProfile struct {
+	DisplayName string
+	AvatarURL   id.ContentURIString
+}
+
+// syncedProfile returns the profile last pushed to the puppet's bot.
+func (p *PuppetClient) syncedProfile() matrixProfile {
+	p.profileMu.Lock()
+	defer p.profileMu.Unlock()
+	return p.profile
+}
+
+// profileAPI fetches global Matrix profiles. *appservice.IntentAPI
+// implements it.
+type profileAPI interface {
+	GetProfile(ctx context.Context, mxid id.UserID) (*mautrix.RespUserProfile, error)
+}
+
+// matrixProfileAPI returns the bridge bot's profile API, or nil.
+func (mc *MattermostConnector) matrixProfileAPI() profileAPI {
+	if conn, ok := mc.Bridge.Matrix.(*matrix.Connector); ok && conn.Bot != nil {
+		return conn.Bot
+	}
+	if api, ok := mc.Bridge.Bot.(profileAPI); ok {
+		return api
+	}
+	return nil
+}
+
+// startPuppetProfileSync subscribes to Matrix member events, so profile
+// changes of puppet and double-puppeted users are pushed as they happen, and
+// starts the periodic resync if configured.
+func (mc *MattermostConnector) startPuppetProfileSync(ctx context.Context) {
+	cfg := mc.Config.PuppetProfileSync
+	if (!cfg.Enabled && !cfg.DoublePuppets) || mc.Bridge == nil {
+		return
+	}
+	if conn, ok := mc.Bridge.Matrix.(*matrix.Connector); ok && conn.EventProcessor != nil {
+		conn.EventProcessor.On(event.StateMember, mc.handleMatrixMemberProfile)
+	} else {
+		mc.Bridge.Log.Warn().Msg("Matrix connector doesn't expose events, profiles will only sync on schedule")
+	}
+	if cfg.IntervalMinutes > 0 {
+		go mc.runPuppetProfileSync(ctx, time.Duration(cfg.IntervalMinutes)*time.Minute)
+	}
+}
+
+// runPuppetProfileSync pushes every puppet's profile on each tick until ctx
+// is done.
+func (mc *MattermostConnector) runPuppetProfileSync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			mc.syncPuppetProfiles(ctx)
+		}
+	}
+}
+
+// syncPuppetProfiles pushes the Matrix profile of every healthy puppet and,
+// if enabled, every double-puppeted user with their own login.
+func (mc *MattermostConnector) syncPuppetProfiles(ctx context.Context) {
+	cfg := mc.Config.PuppetProfileSync
+	if cfg.Enabled {
+		mc.puppetMu.RLock()
+		puppets := make([]*PuppetClient, 0, len(mc.Puppets))
+		for _, puppet := range mc.Puppets {
+			if puppet.Healthy() {
+				puppets = append(puppets, puppet)
+			}
+		}
+		mc.puppetMu.RUnlock()
+
+		for _, puppet := range puppets {
+			if err := mc.syncPuppetProfile(ctx, puppet); err != nil {
+				mc.Bridge.Log.Warn().Err(err).
+					Str("mxid", string(puppet.MXID)).
+					Str("mm_username", puppet.Username).
+					Msg("Failed to sync puppet profile")
+			}
+		}
+	}
+	if cfg.DoublePuppets {
+		for _, client := range mc.doublePuppetClients() {
+			if err := client.syncUserProfile(ctx); err != nil {
+				client.log.Warn().Err(err).Msg("Failed to sync double puppet profile")
+			}
+		}
+	}
+}
+
+// syncLoadedPuppetProfiles pushes the Matrix profiles of newly loaded
+// puppets in the background, so a bot shows its user's current profile from
+// the first message on. It does nothing unless puppet_profile_sync is
+// enabled.
+func (mc *MattermostConnector) syncLoadedPuppetProfiles(ctx context.Context, puppets ...*PuppetClient) {
+	if !mc.Config.PuppetProfileSync.Enabled || len(puppets) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		for _, puppet := range puppets {
+			if err := mc.syncPuppetProfile(ctx, puppet); err != nil {
+				mc.ctxLog(ctx).Warn().Err(err).
+					Str("mxid", string(puppet.MXID)).
+					Str("mm_username", puppet.Username).
+					Msg("Failed to sync profile of loaded puppet")
+			}
+		}
+	}()
+}
+
+// handleMatrixMemberProfile pushes a puppet or double-puppeted user's profile
+// when a member event shows it differs from what was last pushed. Member
+// events carry per-room profiles, so the global profile is fetched before
+// pushing.
+func (mc *MattermostConnector) handleMatrixMemberProfile(ctx context.Context, evt *event.Event) {
+	if evt.StateKey == nil || id.UserID(*evt.StateKey) != evt.Sender {
+		return
+	}
+	content, ok := evt.Content.Parsed.(*event.MemberEventContent)
+	if !ok || content.Membership != event.MembershipJoin {
+		return
+	}
+	seen := matrixProfile{DisplayName: content.Displayname, AvatarURL: content.AvatarURL}
+	cfg := mc.Config.PuppetProfileSync
+
+	mc.puppetMu.RLock()
+	puppet := mc.Puppets[evt.Sender]
+	mc.puppetMu.RUnlock()
+	if puppet != nil {
+		if !cfg.Enabled || !puppet.Healthy() || seen == puppet.syncedProfile() {
+			return
+		}
+		if err := mc.syncPuppetProfile(ctx, puppet); err != nil {
+			mc.Bridge.Log.Warn().Err(err).
+				Str("mxid", string(puppet.MXID)).
+				Str("mm_username", puppet.Username).
+				Msg("Failed to sync puppet profile")
+		}
+		return
+	}
+
+	if !cfg.DoublePuppets {
+		return
+	}
+	mmUserID, login := mc.doublePuppetLogin(evt.Sender)
+	if mmUserID == "" {
+		return
+	}
+	client, ok := login.Client.(*MattermostClient)
+	if !ok || client.client == nil {
+		mc.Bridge.Log.Debug().
+			Str("mxid", string(evt.Sender)).
+			Str("mm_user_id", mmUserID).
+			Msg("Double puppet has no Mattermost token, not pushing profile")
+		return
+	}
+	if seen == client.syncedProfile() {
+		return
+	}
+	if err := client.syncUserProfile(ctx); err != nil {
+		client.log.Warn().Err(err).Msg("Failed to sync double puppet profile")
+	}
+}
+
+// fetchMatrixProfile returns a Matrix user's global profile.
+func (mc *MattermostConnector) fetchMatrixProfile(ctx context.Context, mxid id.UserID) (matrixProfile, error) {
+	api := mc.matrixProfileAPI()
+	if api == nil {
+		return matrixProfile{}, errNoProfileAPI
+	}
+	profile, err := api.GetProfile(ctx, mxid)
+	if err != nil {
+		return matrixProfile{}, fmt.Errorf("failed to get Matrix profile: %w", err)
+	}
+	return matrixProfile{
+		DisplayName: profile.DisplayName,
+		AvatarURL:   profile.AvatarURL.CUString(),
+	}, nil
+}
+
+// syncPuppetProfile fetches a puppet user's global Matrix profile and pushes
+// it to their bot.
+func (mc *MattermostConnector) syncPuppetProfile(ctx context.Context, puppet *PuppetClient) error {
+	profile, err := mc.fetchMatrixProfile(ctx, puppet.MXID)
+	if err != nil {
+		return err
+	}
+	return mc.pushPuppetProfile(ctx, puppet, profile)
+}
+
+// pushPuppetProfile updates the puppet's bot display name and profile image
+// where they differ from what was last pushed. Bots are edited with the
+// primary client, since a bot's own token usually can't edit the bot; the
+// puppet's client is used if no login is connected yet. An empty display
+// name is ignored, as Mattermost bots must have one.
+func (mc *MattermostConnector) pushPuppetProfile(ctx context.Context, puppet *PuppetClient, profile matrixProfile) error {
+	client := mc.primaryClient.Load()
+	if client == nil {
+		client = puppet.Client
+	}
+	synced := puppet.syncedProfile()
+	log := mc.Bridge.Log.With().
+		Str("mxid", string(puppet.MXID)).
+		Str("mm_user_id", puppet.UserID).
+		Logger()
+
+	if profile.DisplayName != "" && profile.DisplayName != synced.DisplayName {
+		name := truncateRunes(profile.DisplayName, model.BotDisplayNameMaxRunes)
+		if _, _, err := client.PatchBot(ctx, puppet.UserID, &model.BotPatch{DisplayName: &name}); err != nil {
+			return fmt.Errorf("failed to update bot display name: %w", err)
+		}
+		puppet.profileMu.Lock()
+		puppet.profile.DisplayName = profile.DisplayName
+		puppet.profileMu.Unlock()
+		log.Info().Str("display_name", name).Msg("Updated puppet bot display name")
+	}
+
+	if profile.AvatarURL != synced.AvatarURL {
+		if err := mc.setProfileImage(ctx, client, puppet.UserID, profile.AvatarURL); err != nil {
+			return err
+		}
+		puppet.profileMu.Lock()
+		puppet.profile.AvatarURL = profile.AvatarURL
+		puppet.profileMu.Unlock()
+		log.Info().Str("avatar_url", string(profile.AvatarURL)).Msg("Updated puppet bot profile image")
+	}
+	return nil
+}
+
+// setProfileImage sets a Mattermost user's profile image to a Matrix avatar,
+// or resets it to the default if avatarURL is empty.
+func (mc *MattermostConnector) setProfileImage(ctx context.Context, client *model.Client4, mmUserID string, avatarURL id.ContentURIString) error {
+	if avatarURL == "" {
+		if _, err := client.SetDefaultProfileImage(ctx, mmUserID); err != nil {
+			return fmt.Errorf("failed to reset profile image: %w", err)
+		}
+		return nil
+	}
+	data, err := mc.Bridge.Bot.DownloadMedia(ctx, avatarURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to download avatar: %w", err)
+	}
+	if _, err := client.SetProfileImage(ctx, mmUserID, data); err != nil {
+		return fmt.Errorf("failed to update profile image: %w", err)
+	}
+	return nil
+}
+
+// doublePuppetClients returns the clients of double-puppeted users who have
+// their own Mattermost login, and so can edit their own profile.
+func (mc *MattermostConnector) doublePuppetClients() []*MattermostClient {
+	mc.dpLoginsMu.RLock()
+	defer mc.dpLoginsMu.RUnlock()
+	var clients []*MattermostClient
+	for _, loginID := range mc.dpLogins {
+		login := mc.Bridge.GetCachedUserLoginByID(loginID)
+		if login == nil {
+			continue
+		}
+		if client, ok := login.Client.(*MattermostClient); ok && client.client != nil {
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
+// syncedProfile returns the Matrix profile last pushed to the user's
+// Mattermost account.
+func (m *MattermostClient) syncedProfile() matrixProfile {
+	m.profileMu.Lock()
+	defer m.profileMu.Unlock()
+	return m.profile
+}
+
+// syncUserProfile fetches the global Matrix profile of the login's user and
+// pushes it to their Mattermost account.
+func (m *MattermostClient) syncUserProfile(ctx context.Context) error {
+	profile, err := m.connector.fetchMatrixProfile(ctx, m.userLogin.UserMXID)
+	if err != nil {
+		return err
+	}
+	return m.pushUserProfile(ctx, profile)
+}
+
+// pushUserProfile updates the logged-in user's Mattermost nickname and
+// profile image from their Matrix profile, where they differ from what was
+// last pushed. The nickname is used rather than the first and last name,
+// since Matrix display names aren't split and the default displayname
+// template prefers it. The user's own token is used, so no extra permissions
+// are needed.
+func (m *MattermostClient) pushUserProfile(ctx context.Context, profile matrixProfile) error {
+	synced := m.syncedProfile()
+	if profile.DisplayName != synced.DisplayName {
+		nickname := truncateRunes(profile.DisplayName, model.UserNicknameMaxRunes)
+		if _, _, err := m.client.PatchUser(ctx, m.userID, &model.UserPatch{Nickname: &nickname}); err != nil {
+			return fmt.Errorf("failed to update nickname: %w", err)
+		}
+		m.profileMu.Lock()
+		m.profile.DisplayName = profile.DisplayName
+		m.profileMu.Unlock()
+		m.log.Info().Str("nickname", nickname).Msg("Updated Mattermost nickname from Matrix")
+	}
+	if profile.AvatarURL != synced.AvatarURL {
+		if err := m.connector.setProfileImage(ctx, m.client, m.userID, profile.AvatarURL); err != nil {
+			return err
+		}
+		m.profileMu.Lock()
+		m.profile.AvatarURL = profile.AvatarURL
+		m.profileMu.Unlock()
+		m.log.Info().Str("avatar_url", string(profile.AvatarURL)).Msg("Updated Mattermost profile image from Matrix")
+	}
+	return nil
+}
+
+// truncateRunes shortens s to at most n runes.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,371 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// newProfileTestClient returns a client whose connector has a puppet for
+// @alice:test and a bridge bot serving the given profiles and media.
+func newProfileTestClient(serverURL string, profiles map[id.UserID]*mautrix.RespUserProfile, media map[id.ContentURIString][]byte) (*MattermostClient, *PuppetClient) {
+	mc := newFullTestClient(serverURL)
+	mc.connector.Bridge.Bot = &fakeMatrixBot{profiles: profiles, media: media}
+	mc.connector.primaryClient.Store(mc.client)
+	puppet := &PuppetClient{MXID: "@alice:test", Client: mc.client, UserID: "alice-bot-id", Username: "alice"}
+	mc.connector.Puppets[puppet.MXID] = puppet
+	return mc, puppet
+}
+
+func TestTruncateRunes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exact", 5, "exact"},
+		{"too long", 3, "too"},
+		{"héllo", 2, "hé"},
+	}
+	for _, tt := range tests {
+		if got := truncateRunes(tt.in, tt.n); got != tt.want {
+			t.Errorf("truncateRunes(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPushPuppetProfile(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	media := map[id.ContentURIString][]byte{"mxc://test/alice": []byte("avatar-data")}
+	mc, puppet := newProfileTestClient(fake.Server.URL, nil, media)
+	ctx := context.Background()
+
+	profile := matrixProfile{DisplayName: "Alice", AvatarURL: "mxc://test/alice"}
+	if err := mc.connector.pushPuppetProfile(ctx, puppet, profile); err != nil {
+		t.Fatalf("pushPuppetProfile: %v", err)
+	}
+	if bot := fake.Bots["alice-bot-id"]; bot == nil || bot.DisplayName != "Alice" {
+		t.Errorf("bot: got %+v, want display name Alice", bot)
+	}
+	if got := string(fake.ProfileImages["alice-bot-id"]); got != "avatar-data" {
+		t.Errorf("profile image: got %q, want avatar-data", got)
+	}
+	if got := puppet.syncedProfile(); got != profile {
+		t.Errorf("synced profile: got %+v, want %+v", got, profile)
+	}
+
+	// Pushing the same profile again is a no-op.
+	calls := len(fake.Calls())
+	if err := mc.connector.pushPuppetProfile(ctx, puppet, profile); err != nil {
+		t.Fatalf("pushPuppetProfile: %v", err)
+	}
+	if len(fake.Calls()) != calls {
+		t.Errorf("unchanged profile should not be pushed, got %+v", fake.Calls()[calls:])
+	}
+
+	// Removing the avatar resets the profile image.
+	if err := mc.connector.pushPuppetProfile(ctx, puppet, matrixProfile{DisplayName: "Alice"}); err != nil {
+		t.Fatalf("pushPuppetProfile: %v", err)
+	}
+	if _, ok := fake.ProfileImages["alice-bot-id"]; ok {
+		t.Error("profile image should be reset")
+	}
+}
+
+func TestPushPuppetProfile_TruncatesDisplayName(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, puppet := newProfileTestClient(fake.Server.URL, nil, nil)
+
+	long := strings.Repeat("a", model.BotDisplayNameMaxRunes+10)
+	if err := mc.connector.pushPuppetProfile(context.Background(), puppet, matrixProfile{DisplayName: long}); err != nil {
+		t.Fatalf("pushPuppetProfile: %v", err)
+	}
+	if bot := fake.Bots["alice-bot-id"]; bot == nil || len(bot.DisplayName) != model.BotDisplayNameMaxRunes {
+		t.Errorf("bot: got %+v, want display name truncated to %d runes", bot, model.BotDisplayNameMaxRunes)
+	}
+}
+
+func TestPushPuppetProfile_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		profile matrixProfile
+		fail    string
+	}{
+		{name: "patch fails", profile: matrixProfile{DisplayName: "Alice"}, fail: "/api/v4/bots/"},
+		{name: "avatar missing", profile: matrixProfile{AvatarURL: "mxc://test/missing"}},
+		{name: "upload fails", profile: matrixProfile{AvatarURL: "mxc://test/alice"}, fail: "/image"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			if tt.fail != "" {
+				fake.FailEndpoints[tt.fail] = true
+			}
+			media := map[id.ContentURIString][]byte{"mxc://test/alice": []byte("avatar-data")}
+			mc, puppet := newProfileTestClient(fake.Server.URL, nil, media)
+
+			if err := mc.connector.pushPuppetProfile(context.Background(), puppet, tt.profile); err == nil {
+				t.Error("expected an error")
+			}
+			if got := puppet.syncedProfile(); got != (matrixProfile{}) {
+				t.Errorf("failed push should not be recorded, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestSyncPuppetProfile(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	profiles := map[id.UserID]*mautrix.RespUserProfile{"@alice:test": {DisplayName: "Alice"}}
+	mc, puppet := newProfileTestClient(fake.Server.URL, profiles, nil)
+
+	if err := mc.connector.syncPuppetProfile(context.Background(), puppet); err != nil {
+		t.Fatalf("syncPuppetProfile: %v", err)
+	}
+	if bot := fake.Bots["alice-bot-id"]; bot == nil || bot.DisplayName != "Alice" {
+		t.Errorf("bot: got %+v, want display name Alice", bot)
+	}
+
+	delete(profiles, "@alice:test")
+	if err := mc.connector.syncPuppetProfile(context.Background(), puppet); err == nil {
+		t.Error("expected an error for a missing profile")
+	}
+}
+
+func TestSyncPuppetProfiles_SkipsUnhealthy(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	profiles := map[id.UserID]*mautrix.RespUserProfile{"@alice:test": {DisplayName: "Alice"}}
+	mc, puppet := newProfileTestClient(fake.Server.URL, profiles, nil)
+	puppet.markUnhealthy("token_rejected", "test")
+
+	mc.connector.syncPuppetProfiles(context.Background())
+	if len(fake.Calls()) != 0 {
+		t.Errorf("unhealthy puppet should not be synced, got %+v", fake.Calls())
+	}
+}
+
+func TestHandleMatrixMemberProfile(t *testing.T) {
+	t.Parallel()
+	member := func(sender, stateKey string, membership event.Membership, name string) *event.Event {
+		return &event.Event{
+			Sender:   id.UserID(sender),
+			StateKey: &stateKey,
+			Type:     event.StateMember,
+			Content: event.Content{Parsed: &event.MemberEventContent{
+				Membership:  membership,
+				Displayname: name,
+			}},
+		}
+	}
+	tests := []struct {
+		name      string
+		evt       *event.Event
+		wantPatch bool
+	}{
+		{"puppet profile change", member("@alice:test", "@alice:test", event.MembershipJoin, "Alice"), true},
+		{"unchanged profile", member("@alice:test", "@alice:test", event.MembershipJoin, "Old"), false},
+		{"member changed by someone else", member("@bob:test", "@alice:test", event.MembershipJoin, "Alice"), false},
+		{"leave", member("@alice:test", "@alice:test", event.MembershipLeave, "Alice"), false},
+		{"not a puppet", member("@carol:test", "@carol:test", event.MembershipJoin, "Carol"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			profiles := map[id.UserID]*mautrix.RespUserProfile{
+				"@alice:test": {DisplayName: "Alice"},
+				"@carol:test": {DisplayName: "Carol"},
+			}
+			mc, puppet := newProfileTestClient(fake.Server.URL, profiles, nil)
+			mc.connector.Config.PuppetProfileSync.Enabled = true
+			puppet.profile = matrixProfile{DisplayName: "Old"}
+
+			mc.connector.handleMatrixMemberProfile(context.Background(), tt.evt)
+			if got := fake.CalledPath("/api/v4/bots/alice-bot-id"); got != tt.wantPatch {
+				t.Errorf("bot patched: got %v, want %v", got, tt.wantPatch)
+			}
+		})
+	}
+}
+
+// newDoublePuppetTestClient returns a logged-in client for @dana:test whose
+// bridge bot serves the given profiles and media.
+func newDoublePuppetTestClient(serverURL string, profiles map[id.UserID]*mautrix.RespUserProfile, media map[id.ContentURIString][]byte) *MattermostClient {
+	mc := newFullTestClient(serverURL)
+	mc.connector.Bridge.Bot = &fakeMatrixBot{profiles: profiles, media: media}
+	mc.userLogin = &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: MakeUserLoginID(mc.userID), UserMXID: "@dana:test"}}
+	return mc
+}
+
+func TestPushUserProfile(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	media := map[id.ContentURIString][]byte{"mxc://test/dana": []byte("dana-avatar")}
+	mc := newDoublePuppetTestClient(fake.Server.URL, nil, media)
+	ctx := context.Background()
+
+	profile := matrixProfile{DisplayName: "Dana", AvatarURL: "mxc://test/dana"}
+	if err := mc.pushUserProfile(ctx, profile); err != nil {
+		t.Fatalf("pushUserProfile: %v", err)
+	}
+	if user := fake.Users[mc.userID]; user == nil || user.Nickname != "Dana" {
+		t.Errorf("user: got %+v, want nickname Dana", user)
+	}
+	if got := string(fake.ProfileImages[mc.userID]); got != "dana-avatar" {
+		t.Errorf("profile image: got %q, want dana-avatar", got)
+	}
+
+	calls := len(fake.Calls())
+	if err := mc.pushUserProfile(ctx, profile); err != nil {
+		t.Fatalf("pushUserProfile: %v", err)
+	}
+	if len(fake.Calls()) != calls {
+		t.Errorf("unchanged profile should not be pushed, got %+v", fake.Calls()[calls:])
+	}
+
+	// Unlike bots, users may clear their nickname.
+	if err := mc.pushUserProfile(ctx, matrixProfile{AvatarURL: "mxc://test/dana"}); err != nil {
+		t.Fatalf("pushUserProfile: %v", err)
+	}
+	if user := fake.Users[mc.userID]; user.Nickname != "" {
+		t.Errorf("nickname: got %q, want it cleared", user.Nickname)
+	}
+}
+
+func TestPushUserProfile_PatchFails(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.FailEndpoints["/patch"] = true
+	mc := newDoublePuppetTestClient(fake.Server.URL, nil, nil)
+
+	if err := mc.pushUserProfile(context.Background(), matrixProfile{DisplayName: "Dana"}); err == nil {
+		t.Error("expected an error")
+	}
+	if got := mc.syncedProfile(); got != (matrixProfile{}) {
+		t.Errorf("failed push should not be recorded, got %+v", got)
+	}
+}
+
+func TestSyncUserProfile(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	profiles := map[id.UserID]*mautrix.RespUserProfile{"@dana:test": {DisplayName: "Dana"}}
+	mc := newDoublePuppetTestClient(fake.Server.URL, profiles, nil)
+
+	if err := mc.syncUserProfile(context.Background()); err != nil {
+		t.Fatalf("syncUserProfile: %v", err)
+	}
+	if user := fake.Users[mc.userID]; user == nil || user.Nickname != "Dana" {
+		t.Errorf("user: got %+v, want nickname Dana", user)
+	}
+}
+
+func TestHandleMatrixMemberProfile_DoublePuppetWithoutLogin(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.PuppetProfileSync.DoublePuppets = true
+	// The mapping exists, but the login isn't loaded.
+	mc.connector.dpLogins["dana-id"] = MakeUserLoginID("dana-id")
+
+	stateKey := "@dana:test"
+	mc.connector.handleMatrixMemberProfile(context.Background(), &event.Event{
+		Sender:   "@dana:test",
+		StateKey: &stateKey,
+		Type:     event.StateMember,
+		Content: event.Content{Parsed: &event.MemberEventContent{
+			Membership:  event.MembershipJoin,
+			Displayname: "Dana",
+		}},
+	})
+	if len(fake.Calls()) != 0 {
+		t.Errorf("expected no API calls, got %+v", fake.Calls())
+	}
+}
+
+func TestStartPuppetProfileSync_NoMatrixConnector(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.PuppetProfileSync.Enabled = true
+	// Must not panic when the Matrix connector isn't the appservice one.
+	mc.connector.startPuppetProfileSync(context.Background())
+}
+
+func TestSyncLoadedPuppetProfiles_OnReload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+	}{
+		{"enabled", true, "Bob"},
+		{"disabled", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			fake.Users["bob-bot-id"] = &model.User{Id: "bob-bot-id", Username: "bob-bot"}
+			fake.TokenToUser["bob-token"] = "bob-bot-id"
+			profiles := map[id.UserID]*mautrix.RespUserProfile{"@bob:test": {DisplayName: "Bob"}}
+			mc, _ := newProfileTestClient(fake.Server.URL, profiles, nil)
+			mc.connector.Config.ServerURL = fake.Server.URL
+			mc.connector.Config.PuppetProfileSync.Enabled = tt.enabled
+
+			entries := []PuppetEntry{{Slug: "BOB", MXID: "@bob:test", Token: "bob-token"}}
+			if added, _ := mc.connector.ReloadPuppetsFromEntries(context.Background(), entries); added != 1 {
+				t.Fatalf("added = %d, want 1", added)
+			}
+			botName := func() string {
+				fake.mu.Lock()
+				defer fake.mu.Unlock()
+				if bot := fake.Bots["bob-bot-id"]; bot != nil {
+					return bot.DisplayName
+				}
+				return ""
+			}
+			deadline := time.Now().Add(2 * time.Second)
+			for tt.want != "" && botName() != tt.want && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			if !tt.enabled {
+				time.Sleep(50 * time.Millisecond)
+			}
+			if got := botName(); got != tt.want {
+				t.Errorf("bot display name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"go.mau.fi/util/dbutil"
+	"maunium.net/go/mautrix/id"
+)
+
+// maxPuppetProvisionBodySize is the maximum size of a
+// /api/provision-puppet request body.
+const maxPuppetProvisionBodySize = 16 << 10
+
+// maxPuppetProvisionTargets bounds how many teams and channels one puppet
+// provisioning request may list.
+const maxPuppetProvisionTargets = 100
+
+// PuppetProvisioningConfig lets the admin API create Mattermost bot accounts
+// for new puppets.
+type PuppetProvisioningConfig struct {
+	// AdminToken is a Mattermost system admin access token, used to create
+	// bots and their tokens. Falls back to the MATTERMOST_ADMIN_TOKEN
+	// environment variable. Empty disables POST /api/provision-puppet.
+	AdminToken string `yaml:"admin_token"`
+	// TeamIDs are the teams every provisioned bot is added to, on top of the
+	// ones in the request.
+	TeamIDs []string `yaml:"team_ids"`
+}
+
+// PuppetProvisionRequest is the body of POST /api/provision-puppet.
+type PuppetProvisionRequest struct {
+	MXID string `json:"mxid"`
+	// Username is the Mattermost username of the bot.
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// TeamIDs and ChannelIDs are the teams and channels the bot is added
+	// to. Teams come before channels, so channels of the listed teams can
+	// be joined.
+	TeamIDs    []string `json:"team_ids,omitempty"`
+	ChannelIDs []string `json:"channel_ids,omitempty"`
+}
+
+// PuppetProvisionResult is the response of POST /api/provision-puppet. The
+// bot's token is never included.
+type PuppetProvisionResult struct {
+	MXID       string `json:"mxid"`
+	MMUserID   string `json:"mm_user_id"`
+	MMUsername string `json:"mm_username"`
+	// Teams and Channels report adding the bot to each team and channel.
+	Teams    []MembershipResult `json:"teams"`
+	Channels []MembershipResult `json:"channels"`
+}
+
+// MembershipResult reports adding a provisioned bot to one team or channel.
+type MembershipResult struct {
+	ID    string `json:"id"`
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	provisionedPuppetCreateTable = `
+		CREATE TABLE IF NOT EXISTS mattermost_provisioned_puppet (
+			bridge_id  TEXT   NOT NULL,
+			mxid       TEXT   NOT NULL,
+			mm_user_id TEXT   NOT NULL,
+			token_id   TEXT   NOT NULL,
+			token      TEXT   NOT NULL,
+			created_at BIGINT NOT NULL,
+			PRIMARY KEY (bridge_id, mxid)
+		)
+	`
+	provisionedPuppetInsert = `
+		INSERT INTO mattermost_provisioned_puppet (bridge_id, mxid, mm_user_id, token_id, token, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	provisionedPuppetGetAll = `
+		SELECT mxid, token FROM mattermost_provisioned_puppet WHERE bridge_id=$1
+	`
+)
+
+// errPuppetExists is returned when provisioning a puppet for a Matrix user
+// that already has one.
+var errPuppetExists = errors.New("puppet already exists")
+
+// puppetAdminToken resolves the Mattermost admin token used to provision
+// puppets: config first, then the MATTERMOST_ADMIN_TOKEN environment
+// variable.
+func (mc *MattermostConnector) puppetAdminToken() string {
+	if token := mc.Config.PuppetProvisioning.AdminToken; token != "" {
+		return token
+	}
+	return os.Getenv("MATTERMOST_ADMIN_TOKEN")
+}
+
+// validate checks a provisioning request.
+func (req *PuppetProvisionRequest) validate() error {
+	if _, err := validatePuppetMXID(req.MXID); err != nil {
+		return err
+	}
+	if !model.IsValidUsername(req.Username) {
+		return fmt.Errorf("username must be a valid Mattermost username")
+	}
+	if len(req.TeamIDs)+len(req.ChannelIDs) > maxPuppetProvisionTargets {
+		return fmt.Errorf("at most %d teams and channels can be listed", maxPuppetProvisionTargets)
+	}
+	for _, ids := range [][]string{req.TeamIDs, req.ChannelIDs} {
+		for _, targetID := range ids {
+			if !model.IsValidId(targetID) {
+				return fmt.Errorf("team_ids and channel_ids must be valid Mattermost IDs")
+			}
+		}
+	}
+	return nil
+}
+
+// HandleProvisionPuppet is an HTTP handler for POST /api/provision-puppet.
+// It creates a Mattermost bot for a Matrix user with the admin token,
+// creates an access token for it, adds it to the requested teams and
+// channels and registers it as the user's puppet. Provisioned puppets are
+// stored in the bridge database and loaded again on startup.
+func (mc *MattermostConnector) HandleProvisionPuppet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	adminToken := mc.puppetAdminToken()
+	if adminToken == "" {
+		http.Error(w, "puppet provisioning is disabled", http.StatusNotFound)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxPuppetProvisionBodySize)
+	defer func() { _ = r.Body.Close() }()
+
+	var req PuppetProvisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if err := req.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if mc.hasPuppet(id.UserID(req.MXID)) {
+		http.Error(w, errPuppetExists.Error(), http.StatusConflict)
+		return
+	}
+
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("mxid", req.MXID).
+		Str("username", req.Username).
+		Msg("Puppet provisioning requested")
+
+	result, status, err := mc.provisionPuppet(r.Context(), adminToken, &req)
+	if err != nil {
+		log.Warn().Err(err).Str("mxid", req.MXID).Msg("Failed to provision puppet")
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Warn().Err(err).Msg("Failed to write puppet provisioning response")
+	}
+}
+
+// provisionPuppet creates the bot and token of a puppet, adds the bot to its
+// teams and channels, stores it and registers it. If the bot can't be
+// finished, it's disabled again so no half-provisioned bot is left active.
+// The returned status is the HTTP status for err.
+func (mc *MattermostConnector) provisionPuppet(ctx context.Context, adminToken string, req *PuppetProvisionRequest) (*PuppetProvisionResult, int, error) {
+	log := mc.ctxLog(ctx).With().Str("mxid", req.MXID).Logger()
+	admin := mc.newAPIClient(mc.Config.ServerURL)
+	admin.SetToken(adminToken)
+
+	description := req.Description
+	if description == "" {
+		description = "Matrix puppet for " + req.MXID
+	}
+	bot, resp, err := admin.CreateBot(ctx, &model.Bot{
+		Username:    req.Username,
+		DisplayName: req.DisplayName,
+		Description: description,
+	})
+	if err != nil {
+		status := http.StatusBadGateway
+		if resp != nil && resp.StatusCode == http.StatusBadRequest {
+			status = http.StatusConflict
+		}
+		return nil, status, fmt.Errorf("failed to create bot: %w", err)
+	}
+	disableBot := func() {
+		if _, _, err := admin.DisableBot(ctx, bot.UserId); err != nil {
+			log.Warn().Err(err).Str("mm_user_id", bot.UserId).Msg("Failed to disable bot of failed puppet provisioning")
+		}
+	}
+
+	token, _, err := admin.CreateUserAccessToken(ctx, bot.UserId, "mautrix-mattermost puppet for "+req.MXID)
+	if err != nil {
+		disableBot()
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to create bot access token: %w", err)
+	}
+
+	result := &PuppetProvisionResult{MXID: req.MXID, MMUserID: bot.UserId, MMUsername: bot.Username}
+	for _, teamID := range uniqueIDs(mc.Config.PuppetProvisioning.TeamIDs, req.TeamIDs) {
+		membership := MembershipResult{ID: teamID}
+		if _, _, err := admin.AddTeamMember(ctx, teamID, bot.UserId); err != nil {
+			log.Warn().Err(err).Str("team_id", teamID).Msg("Failed to add provisioned bot to team")
+			membership.Error = err.Error()
+		}
+		result.Teams = append(result.Teams, membership)
+	}
+	for _, channelID := range uniqueIDs(req.ChannelIDs) {
+		membership := MembershipResult{ID: channelID}
+		if _, _, err := admin.AddChannelMember(ctx, channelID, bot.UserId); err != nil {
+			log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to add provisioned bot to channel")
+			membership.Error = err.Error()
+		}
+		result.Channels = append(result.Channels, membership)
+	}
+
+	if err := mc.saveProvisionedPuppet(ctx, id.UserID(req.MXID), bot.UserId, token); err != nil {
+		if _, err := admin.RevokeUserAccessToken(ctx, token.Id); err != nil {
+			log.Warn().Err(err).Msg("Failed to revoke token of failed puppet provisioning")
+		}
+		disableBot()
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to store puppet: %w", err)
+	}
+	if err := mc.registerProvisionedPuppet(ctx, id.UserID(req.MXID), token.Token); err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+
+	log.Info().
+		Str("mm_user_id", bot.UserId).
+		Str("mm_username", bot.Username).
+		Int("teams", len(result.Teams)).
+		Int("channels", len(result.Channels)).
+		Msg("Provisioned puppet")
+	return result, http.StatusCreated, nil
+}
+
+// hasPuppet reports whether a Matrix user has a puppet.
+func (mc *MattermostConnector) hasPuppet(mxid id.UserID) bool {
+	mc.puppetMu.RLock()
+	defer mc.puppetMu.RUnlock()
+	_, ok := mc.Puppets[mxid]
+	return ok
+}
+
+// uniqueIDs concatenates lists of IDs, dropping repeated ones.
+func uniqueIDs(lists ...[]string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, listID := range list {
+			if !seen[listID] {
+				seen[listID] = true
+				ids = append(ids, listID)
+			}
+		}
+	}
+	return ids
+}
+
+// provisionedPuppetDB returns the database provisioned puppets are stored
+// in, creating their table if needed.
+func (mc *MattermostConnector) provisionedPuppetDB(ctx context.Context) (*dbutil.Database, error) {
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return nil, errors.New("puppet provisioning requires a bridge database")
+	}
+	db := mc.Bridge.DB.Database
+	if _, err := db.Exec(ctx, provisionedPuppetCreateTable); err != nil {
+		return nil, fmt.Errorf("failed to create provisioned puppet table: %w", err)
+	}
+	return db, nil
+}
+
+// saveProvisionedPuppet stores a provisioned puppet's token so the puppet is
+// loaded again on startup.
+func (mc *MattermostConnector) saveProvisionedPuppet(ctx context.Context, mxid id.UserID, mmUserID string, token *model.UserAccessToken) error {
+	db, err := mc.provisionedPuppetDB(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(ctx, provisionedPuppetInsert,
+		string(mc.Bridge.ID), string(mxid), mmUserID, token.Id, token.Token, time.Now().UnixMilli())
+	return err
+}
+
+// loadProvisionedPuppets registers the puppets created by
+// POST /api/provision-puppet. Puppets also configured through the
+// environment keep that configuration.
+func (mc *MattermostConnector) loadProvisionedPuppets(ctx context.Context) {
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return
+	}
+	log := mc.Bridge.Log.With().Str("component", "puppet_provisioning").Logger()
+	db, err := mc.provisionedPuppetDB(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load provisioned puppets")
+		return
+	}
+	rows, err := db.Query(ctx, provisionedPuppetGetAll, string(mc.Bridge.ID))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load provisioned puppets")
+		return
+	}
+	type storedPuppet struct {
+		mxid  id.UserID
+		token string
+	}
+	var stored []storedPuppet
+	for rows.Next() {
+		var puppet storedPuppet
+		if err := rows.Scan(&puppet.mxid, &puppet.token); err != nil {
+			log.Error().Err(err).Msg("Failed to read provisioned puppet")
+			continue
+		}
+		stored = append(stored, puppet)
+	}
+	if err := rows.Close(); err != nil {
+		log.Warn().Err(err).Msg("Failed to close provisioned puppet rows")
+	}
+	for _, puppet := range stored {
+		if mc.hasPuppet(puppet.mxid) {
+			continue
+		}
+		if err := mc.registerProvisionedPuppet(ctx, puppet.mxid, puppet.token); err != nil {
+			log.Error().Err(err).Stringer("mxid", puppet.mxid).Msg("Failed to load provisioned puppet")
+		}
+	}
+}
+
+// registerProvisionedPuppet verifies a provisioned puppet's token and adds
+// it to the puppet map, with double puppeting once its Matrix user approved
+// it, if double_puppet_confirmation is enabled.
+func (mc *MattermostConnector) registerProvisionedPuppet(ctx context.Context, mxid id.UserID, token string) error {
+	client := mc.newAPIClient(mc.Config.ServerURL)
+	client.SetToken(token)
+	me, _, err := client.GetMe(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to verify provisioned puppet token: %w", err)
+	}
+	puppet := &PuppetClient{
+		MXID:        mxid,
+		Client:      client,
+		UserID:      me.Id,
+		Username:    me.Username,
+		provisioned: true,
+	}
+	puppet.markSuccess()
+	mc.watchPuppetToken(puppet)
+	mc.puppetMu.Lock()
+	if _, ok := mc.Puppets[mxid]; ok {
+		mc.puppetMu.Unlock()
+		return errPuppetExists
+	}
+	mc.Puppets[mxid] = puppet
+	mc.puppetMu.Unlock()
+
+	mc.ctxLog(ctx).Info().
+		Stringer("mxid", mxid).
+		Str("mm_user_id", me.Id).
+		Str("mm_username", me.Username).
+		Msg("Loaded provisioned puppet")
+	mc.syncLoadedPuppetProfiles(ctx, puppet)
+	mc.setupPuppetDoublePuppet(ctx, puppet, "", true)
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/id"
+)
+
+// noDoublePuppetMatrixConnector is a nopMatrixConnector that refuses double
+// puppeting, so provisioned puppets are registered without it.
+type noDoublePuppetMatrixConnector struct {
+	nopMatrixConnector
+}
+
+func (noDoublePuppetMatrixConnector) NewUserIntent(context.Context, id.UserID, string) (bridgev2.MatrixAPI, string, error) {
+	return nil, "", errors.New("double puppeting is not available in tests")
+}
+
+// newPuppetProvisionTestConnector returns a connector with a bridge database that
+// provisions puppets against fake.
+func newPuppetProvisionTestConnector(t *testing.T, fake *fakeMM) *MattermostConnector {
+	t.Helper()
+	mc := newRelayTestConnector(t, nil)
+	mc.Bridge.Matrix = noDoublePuppetMatrixConnector{}
+	mc.Puppets = make(map[id.UserID]*PuppetClient)
+	mc.dpLogins = make(map[string]networkid.UserLoginID)
+	mc.Config.ServerURL = fake.Server.URL
+	mc.Config.PuppetProvisioning.AdminToken = "admin-token"
+	return mc
+}
+
+func postProvisionPuppet(mc *MattermostConnector, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/provision-puppet", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mc.HandleProvisionPuppet(w, req)
+	return w
+}
+
+func TestHandleProvisionPuppet_Rejects(t *testing.T) {
+	t.Parallel()
+	teamID := model.NewId()
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		disabled   bool
+		wantStatus int
+	}{
+		{"wrong method", http.MethodGet, "", false, http.StatusMethodNotAllowed},
+		{"disabled", http.MethodPost, `{"mxid":"@alice:example.com","username":"alice-bot"}`, true, http.StatusNotFound},
+		{"invalid JSON", http.MethodPost, `{`, false, http.StatusBadRequest},
+		{"invalid mxid", http.MethodPost, `{"mxid":"alice","username":"alice-bot"}`, false, http.StatusBadRequest},
+		{"invalid username", http.MethodPost, `{"mxid":"@alice:example.com","username":"Alice Bot"}`, false, http.StatusBadRequest},
+		{"invalid team ID", http.MethodPost, `{"mxid":"@alice:example.com","username":"alice-bot","team_ids":["nope"]}`, false, http.StatusBadRequest},
+		{"existing puppet", http.MethodPost, `{"mxid":"@bob:example.com","username":"bob-bot","team_ids":["` + teamID + `"]}`, false, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			defer fake.Close()
+			mc := newPuppetProvisionTestConnector(t, fake)
+			mc.Puppets["@bob:example.com"] = &PuppetClient{MXID: "@bob:example.com"}
+			if tt.disabled {
+				mc.Config.PuppetProvisioning.AdminToken = ""
+			}
+			req := httptest.NewRequest(tt.method, "/api/provision-puppet", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mc.HandleProvisionPuppet(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if fake.CalledPath("/api/v4/bots") {
+				t.Error("rejected request should not create a bot")
+			}
+		})
+	}
+}
+
+func TestHandleProvisionPuppet_Success(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	defer fake.Close()
+	mc := newPuppetProvisionTestConnector(t, fake)
+	configTeam, requestTeam, channelID := model.NewId(), model.NewId(), model.NewId()
+	mc.Config.PuppetProvisioning.TeamIDs = []string{configTeam}
+
+	w := postProvisionPuppet(mc, `{"mxid":"@alice:example.com","username":"alice-bot","display_name":"Alice",`+
+		`"team_ids":["`+requestTeam+`","`+configTeam+`"],"channel_ids":["`+channelID+`"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201: %s", w.Code, w.Body.String())
+	}
+	var result PuppetProvisionResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result.MMUsername != "alice-bot" || result.MMUserID == "" {
+		t.Errorf("result = %+v, want bot alice-bot", result)
+	}
+	if len(result.Teams) != 2 || result.Teams[0].ID != configTeam || result.Teams[1].ID != requestTeam {
+		t.Errorf("teams = %+v, want config team then request team, once each", result.Teams)
+	}
+	if len(result.Channels) != 1 || result.Channels[0].ID != channelID || result.Channels[0].Error != "" {
+		t.Errorf("channels = %+v, want %s", result.Channels, channelID)
+	}
+
+	puppet, ok := mc.Puppets["@alice:example.com"]
+	if !ok || !puppet.provisioned || puppet.UserID != result.MMUserID {
+		t.Fatalf("puppet = %+v, want provisioned puppet of %s", puppet, result.MMUserID)
+	}
+	if token := puppet.Client.AuthToken; strings.Contains(w.Body.String(), token) {
+		t.Error("response must not contain the bot's token")
+	}
+
+	if added, removed := mc.ReloadPuppetsFromEntries(context.Background(), nil); added != 0 || removed != 0 {
+		t.Errorf("reload = +%d -%d, want provisioned puppet kept", added, removed)
+	}
+	if !mc.hasPuppet("@alice:example.com") {
+		t.Error("reload removed the provisioned puppet")
+	}
+
+	// A restarted bridge loads the puppet from the database.
+	restarted := &MattermostConnector{Bridge: mc.Bridge, Config: mc.Config}
+	restarted.Puppets = make(map[id.UserID]*PuppetClient)
+	restarted.dpLogins = make(map[string]networkid.UserLoginID)
+	restarted.loadProvisionedPuppets(context.Background())
+	if loaded, ok := restarted.Puppets["@alice:example.com"]; !ok || loaded.UserID != result.MMUserID {
+		t.Errorf("loaded puppets = %v, want alice's", restarted.Puppets)
+	}
+
+	if w := postProvisionPuppet(mc, `{"mxid":"@alice:example.com","username":"alice-bot2"}`); w.Code != http.StatusConflict {
+		t.Errorf("second provisioning status = %d, want 409", w.Code)
+	}
+}
+
+func TestHandleProvisionPuppet_Failures(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		fail        string
+		wantStatus  int
+		wantDisable bool
+	}{
+		{"bot creation fails", "/api/v4/bots", http.StatusBadGateway, false},
+		{"token creation fails", "/tokens", http.StatusBadGateway, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			defer fake.Close()
+			fake.FailEndpoints[tt.fail] = true
+			mc := newPuppetProvisionTestConnector(t, fake)
+			w := postProvisionPuppet(mc, `{"mxid":"@alice:example.com","username":"alice-bot"}`)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := fake.CalledPath("/disable"); got != tt.wantDisable {
+				t.Errorf("bot disabled = %v, want %v", got, tt.wantDisable)
+			}
+			if mc.hasPuppet("@alice:example.com") {
+				t.Error("failed provisioning should not register a puppet")
+			}
+		})
+	}
+}
+
+func TestHandleProvisionPuppet_UsernameTaken(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	defer fake.Close()
+	fake.Bots["taken"] = &model.Bot{UserId: "taken", Username: "alice-bot"}
+	mc := newPuppetProvisionTestConnector(t, fake)
+	if w := postProvisionPuppet(mc, `{"mxid":"@alice:example.com","username":"alice-bot"}`); w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleProvisionPuppet_MembershipErrorsReported(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	defer fake.Close()
+	teamID := model.NewId()
+	fake.FailEndpoints["/api/v4/teams/"+teamID] = true
+	mc := newPuppetProvisionTestConnector(t, fake)
+	w := postProvisionPuppet(mc, `{"mxid":"@alice:example.com","username":"alice-bot","team_ids":["`+teamID+`"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201: %s", w.Code, w.Body.String())
+	}
+	var result PuppetProvisionResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(result.Teams) != 1 || result.Teams[0].Error == "" {
+		t.Errorf("teams = %+v, want the failed team reported", result.Teams)
+	}
+}
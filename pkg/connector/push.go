@@ -0,0 +1,81 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"maunium.net/go/mautrix/bridgev2"
+)
+
+// Mattermost device ID prefixes, which tell the push proxy which platform a
+// token is for. These are the ones the current Mattermost mobile app uses.
+const (
+	apnsDevicePrefix = "apple_rn-v2"
+	fcmDevicePrefix  = "android_rn-v2"
+)
+
+// PushConfig lets wrapper apps register push tokens with Mattermost, so
+// Mattermost's push proxy wakes the app up for new messages.
+type PushConfig struct {
+	// APNsBundleID is the iOS bundle ID the push proxy sends APNs pushes
+	// for. Empty disables APNs registration.
+	APNsBundleID string `yaml:"apns_bundle_id"`
+	// FCMSenderID is the Firebase sender ID the push proxy sends FCM pushes
+	// as. Empty disables FCM registration.
+	FCMSenderID string `yaml:"fcm_sender_id"`
+}
+
+var errPushTypeUnsupported = errors.New("push type not configured in push")
+
+var _ bridgev2.PushableNetworkAPI = (*MattermostClient)(nil)
+
+// GetPushConfigs returns the push types Mattermost's push proxy is
+// configured for, or nil if none are.
+func (m *MattermostClient) GetPushConfigs() *bridgev2.PushConfig {
+	cfg := m.connector.Config.Push
+	if cfg.APNsBundleID == "" && cfg.FCMSenderID == "" {
+		return nil
+	}
+	push := &bridgev2.PushConfig{}
+	if cfg.APNsBundleID != "" {
+		push.APNs = &bridgev2.APNsPushConfig{BundleID: cfg.APNsBundleID}
+	}
+	if cfg.FCMSenderID != "" {
+		push.FCM = &bridgev2.FCMPushConfig{SenderID: cfg.FCMSenderID}
+	}
+	return push
+}
+
+// RegisterPushNotifications attaches a push token to the login's Mattermost
+// session, as the Mattermost mobile app does. Mattermost then sends pushes
+// for the login's notifications through its push proxy. The token is never
+// logged.
+func (m *MattermostClient) RegisterPushNotifications(ctx context.Context, pushType bridgev2.PushType, token string) error {
+	if !m.IsLoggedIn() {
+		return bridgev2.ErrNotLoggedIn
+	}
+	if token == "" {
+		return errors.New("push token is empty")
+	}
+	cfg := m.connector.Config.Push
+	var prefix string
+	switch {
+	case pushType == bridgev2.PushTypeAPNs && cfg.APNsBundleID != "":
+		prefix = apnsDevicePrefix
+	case pushType == bridgev2.PushTypeFCM && cfg.FCMSenderID != "":
+		prefix = fcmDevicePrefix
+	default:
+		return fmt.Errorf("%w: %s", errPushTypeUnsupported, pushType)
+	}
+	if _, err := m.client.AttachDeviceProps(ctx, map[string]string{"device_id": prefix + ":" + token}); err != nil {
+		return fmt.Errorf("failed to attach push token to session: %w", err)
+	}
+	m.log.Info().Stringer("push_type", pushType).Msg("Registered push notifications")
+	return nil
+}
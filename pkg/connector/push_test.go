@@ -0,0 +1,114 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"maunium.net/go/mautrix/bridgev2"
+)
+
+func TestGetPushConfigs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		cfg      PushConfig
+		wantNil  bool
+		wantAPNs string
+		wantFCM  string
+	}{
+		{"unconfigured", PushConfig{}, true, "", ""},
+		{"apns", PushConfig{APNsBundleID: "com.example.app"}, false, "com.example.app", ""},
+		{"both", PushConfig{APNsBundleID: "com.example.app", FCMSenderID: "123"}, false, "com.example.app", "123"},
+	}
+	for _, tt := range tests {
+		mc := newFullTestClient("http://unused")
+		mc.connector.Config.Push = tt.cfg
+		got := mc.GetPushConfigs()
+		if tt.wantNil {
+			if got != nil {
+				t.Errorf("%s: got %+v, want nil", tt.name, got)
+			}
+			continue
+		}
+		if (got.APNs == nil) != (tt.wantAPNs == "") || got.APNs != nil && got.APNs.BundleID != tt.wantAPNs {
+			t.Errorf("%s: APNs = %+v, want %q", tt.name, got.APNs, tt.wantAPNs)
+		}
+		if (got.FCM == nil) != (tt.wantFCM == "") || got.FCM != nil && got.FCM.SenderID != tt.wantFCM {
+			t.Errorf("%s: FCM = %+v, want %q", tt.name, got.FCM, tt.wantFCM)
+		}
+	}
+}
+
+func TestRegisterPushNotifications(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		pushType bridgev2.PushType
+		token    string
+		wantErr  error
+		wantBody string
+	}{
+		{"apns", bridgev2.PushTypeAPNs, "tok", nil, `{"device_id":"apple_rn-v2:tok"}`},
+		{"fcm", bridgev2.PushTypeFCM, "tok", nil, `{"device_id":"android_rn-v2:tok"}`},
+		{"web unsupported", bridgev2.PushTypeWeb, "tok", errPushTypeUnsupported, ""},
+		{"empty token", bridgev2.PushTypeAPNs, "", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			defer fake.Close()
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.Push = PushConfig{APNsBundleID: "com.example.app", FCMSenderID: "123"}
+
+			err := mc.RegisterPushNotifications(context.Background(), tt.pushType, tt.token)
+			if tt.wantBody == "" {
+				if err == nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if fake.CalledPath("/sessions/device") {
+					t.Error("device attached despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RegisterPushNotifications: %v", err)
+			}
+			var body string
+			for _, call := range fake.Calls() {
+				if call.Method == "PUT" && call.Path == "/api/v4/users/sessions/device" {
+					body = call.Body
+				}
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRegisterPushNotifications_NotLoggedIn(t *testing.T) {
+	t.Parallel()
+	mc := newNotLoggedInClient()
+	mc.connector.Config.Push = PushConfig{APNsBundleID: "com.example.app"}
+	if err := mc.RegisterPushNotifications(context.Background(), bridgev2.PushTypeAPNs, "tok"); !errors.Is(err, bridgev2.ErrNotLoggedIn) {
+		t.Errorf("err = %v, want ErrNotLoggedIn", err)
+	}
+}
+
+func TestSenderFor_IsFromMe(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	if sender := mc.senderFor(mc.userID); !sender.IsFromMe || sender.Sender != MakeUserID(mc.userID) {
+		t.Errorf("own sender = %+v, want IsFromMe", sender)
+	}
+	if sender := mc.senderFor("alice-id"); sender.IsFromMe {
+		t.Errorf("other sender = %+v, want not IsFromMe", sender)
+	}
+}
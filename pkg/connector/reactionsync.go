@@ -0,0 +1,423 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// ReactionSyncConfig controls the repair of reactions that drifted apart
+// between Matrix and Mattermost, e.g. because the bridge lost its records of
+// them after a re-login.
+type ReactionSyncConfig struct {
+	// Redactions compares the reactions on a room's recent posts when a
+	// Matrix user redacts an event the bridge has no record of, so removing
+	// a reaction the bridge lost still removes it in Mattermost.
+	Redactions bool `yaml:"redactions"`
+	// IntervalMinutes is the time between comparisons of the reactions on
+	// recent posts in recently active rooms. 0 disables them.
+	IntervalMinutes int `yaml:"interval_minutes"`
+	// RecentPosts is the number of each room's latest posts compared. 0
+	// uses 20.
+	RecentPosts int `yaml:"recent_posts"`
+}
+
+// defaultReactionSyncPosts is used when reaction_sync.recent_posts is unset.
+const defaultReactionSyncPosts = 20
+
+// reactionSyncWindow is how long after its last bridged post a room's
+// reactions are compared by the periodic job.
+const reactionSyncWindow = 7 * 24 * time.Hour
+
+// maxMatrixReactions is the number of reactions to one event fetched from
+// the homeserver.
+const maxMatrixReactions = 100
+
+// interval returns the time between periodic comparisons, or 0 for none.
+func (c *ReactionSyncConfig) interval() time.Duration {
+	if c.IntervalMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(c.IntervalMinutes) * time.Minute
+}
+
+// recentPosts returns the number of posts compared per room.
+func (c *ReactionSyncConfig) recentPosts() int {
+	if c.RecentPosts <= 0 {
+		return defaultReactionSyncPosts
+	}
+	return c.RecentPosts
+}
+
+// matrixRequestAPI is the part of a Matrix client used for requests mautrix
+// has no method for.
+type matrixRequestAPI interface {
+	BuildURLWithQuery(urlPath mautrix.PrefixableURLPath, urlQuery map[string]string) string
+	MakeRequest(ctx context.Context, method, httpURL string, reqBody, resBody any) ([]byte, error)
+}
+
+// matrixRequestAPI returns the bridge bot's Matrix client, or nil.
+func (mc *MattermostConnector) matrixRequestAPI() matrixRequestAPI {
+	if conn, ok := mc.Bridge.Matrix.(*matrix.Connector); ok && conn.Bot != nil {
+		return conn.Bot
+	}
+	if api, ok := mc.Bridge.Bot.(matrixRequestAPI); ok {
+		return api
+	}
+	return nil
+}
+
+// matrixReaction is a reaction to a Matrix event, as returned by the
+// relations API. The key is outside the encrypted payload in encrypted rooms.
+type matrixReaction struct {
+	ID        id.EventID `json:"event_id"`
+	Sender    id.UserID  `json:"sender"`
+	Timestamp int64      `json:"origin_server_ts"`
+	Content   struct {
+		RelatesTo struct {
+			Key string `json:"key"`
+		} `json:"m.relates_to"`
+	} `json:"content"`
+}
+
+// matrixReactions returns the reactions to a Matrix event that haven't been
+// redacted.
+func (mc *MattermostConnector) matrixReactions(ctx context.Context, roomID id.RoomID, eventID id.EventID) ([]*matrixReaction, error) {
+	api := mc.matrixRequestAPI()
+	if api == nil {
+		return nil, fmt.Errorf("no Matrix client to get reactions with")
+	}
+	var resp struct {
+		Chunk []*matrixReaction `json:"chunk"`
+	}
+	url := api.BuildURLWithQuery(
+		mautrix.ClientURLPath{"v1", "rooms", roomID, "relations", eventID, event.RelAnnotation},
+		map[string]string{"limit": strconv.Itoa(maxMatrixReactions)},
+	)
+	if _, err := api.MakeRequest(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get reactions to %s: %w", eventID, err)
+	}
+	return resp.Chunk, nil
+}
+
+// recentMessages returns the first part of each of the latest bridged posts
+// in a portal, newest first.
+func (m *MattermostClient) recentMessages(ctx context.Context, portal *bridgev2.Portal) ([]*database.Message, error) {
+	parts, err := m.connector.Bridge.DB.Message.GetLastNInPortal(ctx, portal.PortalKey, m.connector.Config.ReactionSync.recentPosts())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent messages: %w", err)
+	}
+	seen := make(map[string]bool, len(parts))
+	messages := make([]*database.Message, 0, len(parts))
+	for _, part := range parts {
+		if seen[string(part.ID)] {
+			continue
+		}
+		seen[string(part.ID)] = true
+		messages = append(messages, part)
+	}
+	return messages, nil
+}
+
+// reconcileReactions compares the reactions on a bridged post in Mattermost
+// with the reaction table. Other users' reactions missing from the table are
+// bridged, and bridged ones no longer in Mattermost are removed. The
+// login's own reactions, which come from Matrix, are looked up on the Matrix
+// event: lost rows are restored, and when removeOwn is set, reactions no
+// longer on Matrix are removed from Mattermost. Without removeOwn they're
+// left alone, as they may have been added in Mattermost.
+func (m *MattermostClient) reconcileReactions(ctx context.Context, portal *bridgev2.Portal, msg *database.Message, removeOwn bool) error {
+	postID := ParseMessageID(msg.ID)
+	post, _, err := m.client.GetPost(ctx, postID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post.DeleteAt != 0 {
+		return nil
+	}
+	existing, err := m.connector.Bridge.DB.Reaction.GetAllToMessage(ctx, portal.Receiver, msg.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get bridged reactions: %w", err)
+	}
+	bridged := make(map[reactionKey]bool, len(existing))
+	for _, reaction := range existing {
+		bridged[reactionKey{reaction.SenderID, reaction.EmojiID}] = true
+	}
+
+	channelID := ParsePortalID(portal.ID)
+	current := make(map[reactionKey]bool)
+	var own []*model.Reaction
+	if post.Metadata != nil {
+		for _, reaction := range post.Metadata.Reactions {
+			key := reactionKey{MakeUserID(reaction.UserId), MakeEmojiID(reaction.EmojiName)}
+			if current[key] {
+				continue
+			}
+			current[key] = true
+			reaction.PostId = postID
+			switch {
+			case bridged[key], m.connector.IsPuppetUserID(reaction.UserId), m.isExcludedGuest(reaction.UserId):
+			case reaction.UserId == m.userID:
+				own = append(own, reaction)
+			default:
+				m.log.Debug().
+					Str("post_id", postID).
+					Str("user_id", reaction.UserId).
+					Str("emoji", reaction.EmojiName).
+					Msg("Bridging reaction missing from the database")
+				m.queueReaction(channelID, reaction)
+			}
+		}
+	}
+	for _, reaction := range existing {
+		// Reactions saving the post have no Mattermost reaction.
+		if current[reactionKey{reaction.SenderID, reaction.EmojiID}] || reaction.EmojiID == savedPostEmojiID {
+			continue
+		}
+		removed := &model.Reaction{
+			UserId:    ParseUserID(reaction.SenderID),
+			PostId:    postID,
+			EmojiName: ParseEmojiID(reaction.EmojiID),
+		}
+		m.log.Debug().
+			Str("post_id", postID).
+			Str("user_id", removed.UserId).
+			Str("emoji", removed.EmojiName).
+			Msg("Removing reaction no longer in Mattermost")
+		m.queueReactionRemove(channelID, removed)
+	}
+	if len(own) == 0 || m.userLogin == nil {
+		return nil
+	}
+	return m.reconcileOwnReactions(ctx, portal, msg, own, removeOwn)
+}
+
+// partReactions holds the Matrix reactions to one part of a bridged post.
+type partReactions struct {
+	part      *database.Message
+	reactions []*matrixReaction
+}
+
+// reconcileOwnReactions restores the reaction rows of the login's reactions
+// that are still on the Matrix events of the post, and removes the others
+// from Mattermost if removeOwn is set. A Mattermost reaction can be on any
+// part on Matrix, the text or a file, or on several.
+func (m *MattermostClient) reconcileOwnReactions(ctx context.Context, portal *bridgev2.Portal, msg *database.Message, own []*model.Reaction, removeOwn bool) error {
+	parts, err := m.connector.Bridge.DB.Message.GetAllPartsByID(ctx, portal.Receiver, msg.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get message parts: %w", err)
+	}
+	onMatrix := make([]partReactions, 0, len(parts))
+	for _, part := range parts {
+		reactions, err := m.connector.matrixReactions(ctx, portal.MXID, part.MXID)
+		if err != nil {
+			return err
+		}
+		onMatrix = append(onMatrix, partReactions{part, reactions})
+	}
+	for _, reaction := range own {
+		log := m.log.With().Str("post_id", reaction.PostId).Str("emoji", reaction.EmojiName).Logger()
+		found := false
+		for _, pr := range onMatrix {
+			for _, mxReaction := range pr.reactions {
+				if mxReaction.Sender != m.userLogin.UserMXID || !m.connector.emojiOverrides().reactionMatches(mxReaction.Content.RelatesTo.Key, reaction.EmojiName) {
+					continue
+				}
+				found = true
+				if err := m.connector.Bridge.DB.Reaction.Upsert(ctx, &database.Reaction{
+					Room:          portal.PortalKey,
+					MessageID:     pr.part.ID,
+					MessagePartID: pr.part.PartID,
+					SenderID:      MakeUserID(m.userID),
+					SenderMXID:    m.userLogin.UserMXID,
+					EmojiID:       MakeEmojiID(reaction.EmojiName),
+					MXID:          mxReaction.ID,
+					Timestamp:     time.UnixMilli(mxReaction.Timestamp),
+					Emoji:         mxReaction.Content.RelatesTo.Key,
+				}); err != nil {
+					return fmt.Errorf("failed to restore reaction: %w", err)
+				}
+				log.Debug().
+					Stringer("reaction_event_id", mxReaction.ID).
+					Str("part_id", string(pr.part.PartID)).
+					Msg("Restored reaction missing from the database")
+				break
+			}
+		}
+		if found || !removeOwn {
+			continue
+		}
+		if _, err := m.client.DeleteReaction(ctx, reaction); err != nil {
+			log.Warn().Err(err).Msg("Failed to remove reaction redacted on Matrix")
+			continue
+		}
+		log.Debug().Msg("Removed reaction redacted on Matrix")
+	}
+	return nil
+}
+
+// reconcilePortalReactions reconciles the reactions on a portal's recent
+// posts, if its latest post was bridged after since.
+func (m *MattermostClient) reconcilePortalReactions(ctx context.Context, portal *bridgev2.Portal, since time.Time, removeOwn bool) {
+	log := m.log.With().Str("channel_id", ParsePortalID(portal.ID)).Logger()
+	messages, err := m.recentMessages(ctx, portal)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to reconcile reactions")
+		return
+	}
+	if len(messages) == 0 || messages[0].Timestamp.Before(since) {
+		return
+	}
+	for _, msg := range messages {
+		if err := m.reconcileReactions(ctx, portal, msg, removeOwn); err != nil {
+			log.Debug().Err(err).Str("post_id", ParseMessageID(msg.ID)).Msg("Failed to reconcile reactions on post")
+		}
+	}
+}
+
+// syncReactionsPeriodically reconciles the reactions on recent posts in
+// recently active rooms at each interval. It runs until the client
+// disconnects.
+func (m *MattermostClient) syncReactionsPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+			m.syncReactions(ctx)
+		}
+	}
+}
+
+// syncReactions reconciles the reactions on recent posts once, in the
+// rooms of this login with a post in reactionSyncWindow.
+func (m *MattermostClient) syncReactions(ctx context.Context) {
+	if m.client == nil {
+		return
+	}
+	portals, err := m.connector.Bridge.GetAllPortalsWithMXID(ctx)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to get portals to reconcile reactions in")
+		return
+	}
+	since := time.Now().Add(-reactionSyncWindow)
+	for _, portal := range portals {
+		if portal.RoomType == database.RoomTypeSpace || isThreadPortal(portal) || !m.connector.OwnsChannel(ParsePortalID(portal.ID)) {
+			continue
+		}
+		if portal.Receiver != "" && (m.userLogin == nil || portal.Receiver != m.userLogin.ID) {
+			continue
+		}
+		m.reconcilePortalReactions(ctx, portal, since, false)
+	}
+}
+
+// registerRedactionHandler subscribes to Matrix redactions, so the
+// redaction of a reaction missing from the database, which bridgev2 drops,
+// still removes it in Mattermost.
+func (mc *MattermostConnector) registerRedactionHandler() {
+	if !mc.Config.ReactionSync.Redactions || mc.Bridge == nil {
+		return
+	}
+	conn, ok := mc.Bridge.Matrix.(*matrix.Connector)
+	if !ok || conn.EventProcessor == nil {
+		mc.Bridge.Log.Warn().Msg("Matrix connector doesn't expose events, redactions of unknown reactions won't be reconciled")
+		return
+	}
+	conn.EventProcessor.On(event.EventRedaction, mc.handleMatrixRedaction)
+}
+
+// handleMatrixRedaction reconciles the reactions of a Matrix user's logins
+// on a portal's recent posts when they redact an event the bridge has no
+// record of, in case it was a reaction whose row was lost. The
+// reconciliation runs in the background, so it doesn't hold up the
+// processing of Matrix events.
+func (mc *MattermostConnector) handleMatrixRedaction(ctx context.Context, evt *event.Event) {
+	target := evt.Redacts
+	if content, ok := evt.Content.Parsed.(*event.RedactionEventContent); ok && content.Redacts != "" {
+		target = content.Redacts
+	}
+	if target == "" {
+		return
+	}
+	// Ghosts and the bridge bot aren't users, so their redactions stop here.
+	user, err := mc.Bridge.GetExistingUserByMXID(ctx, evt.Sender)
+	if err != nil || user == nil || len(user.GetUserLogins()) == 0 {
+		return
+	}
+	portal, err := mc.Bridge.GetPortalByMXID(ctx, evt.RoomID)
+	if err != nil || portal == nil || portal.RoomType == database.RoomTypeSpace {
+		return
+	}
+	if msg, err := mc.Bridge.DB.Message.GetPartByMXID(ctx, target); err != nil || msg != nil {
+		return
+	}
+	if reaction, err := mc.Bridge.DB.Reaction.GetByMXID(ctx, target); err != nil || reaction != nil {
+		return
+	}
+	for _, login := range user.GetUserLogins() {
+		client, ok := login.Client.(*MattermostClient)
+		if !ok || !client.IsLoggedIn() {
+			continue
+		}
+		client.log.Debug().
+			Stringer("redaction_target_mxid", target).
+			Stringer("room_id", evt.RoomID).
+			Msg("Reconciling reactions after redaction of unknown event")
+		client.reconcileAfterRedaction(portal)
+	}
+}
+
+// reconcileAfterRedaction starts a reconciliation of a portal's reactions
+// after a redaction. If one is already running for the portal, it runs once
+// more when done, to catch reactions redacted meanwhile.
+func (m *MattermostClient) reconcileAfterRedaction(portal *bridgev2.Portal) {
+	key := portal.PortalKey
+	m.reconcilingMu.Lock()
+	defer m.reconcilingMu.Unlock()
+	if _, ok := m.reconcilingPortals[key]; ok {
+		m.reconcilingPortals[key] = true
+		return
+	}
+	if m.reconcilingPortals == nil {
+		m.reconcilingPortals = make(map[networkid.PortalKey]bool)
+	}
+	m.reconcilingPortals[key] = false
+	go func() {
+		ctx := m.log.WithContext(context.Background())
+		for {
+			m.reconcilePortalReactions(ctx, portal, time.Time{}, true)
+			m.reconcilingMu.Lock()
+			again := m.reconcilingPortals[key]
+			if again {
+				m.reconcilingPortals[key] = false
+			} else {
+				delete(m.reconcilingPortals, key)
+			}
+			m.reconcilingMu.Unlock()
+			if !again {
+				return
+			}
+		}
+	}()
+}
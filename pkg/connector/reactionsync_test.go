@@ -0,0 +1,349 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestReactionSyncConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		cfg          ReactionSyncConfig
+		wantInterval time.Duration
+		wantPosts    int
+	}{
+		{ReactionSyncConfig{}, 0, defaultReactionSyncPosts},
+		{ReactionSyncConfig{IntervalMinutes: 30, RecentPosts: 5}, 30 * time.Minute, 5},
+		{ReactionSyncConfig{IntervalMinutes: -1, RecentPosts: -1}, 0, defaultReactionSyncPosts},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.interval(); got != tt.wantInterval {
+			t.Errorf("interval(%+v) = %v, want %v", tt.cfg, got, tt.wantInterval)
+		}
+		if got := tt.cfg.recentPosts(); got != tt.wantPosts {
+			t.Errorf("recentPosts(%+v) = %d, want %d", tt.cfg, got, tt.wantPosts)
+		}
+	}
+}
+
+func TestReactionMatches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		key, name string
+		want      bool
+	}{
+		{"👍", "+1", true},
+		{"👍", "thumbsup", true},
+		{":custom:", "custom", true},
+		{"custom", "custom", true},
+		{"👍", "smile", false},
+	}
+	for _, tt := range tests {
+		if got := reactionMatches(tt.key, tt.name); got != tt.want {
+			t.Errorf("reactionMatches(%q, %q) = %v, want %v", tt.key, tt.name, got, tt.want)
+		}
+	}
+}
+
+// fakeRelationsBot is a bridge bot whose relations API returns reactions:
+// those in byEvent for the events it has, and reactions for the others.
+type fakeRelationsBot struct {
+	bridgev2.MatrixAPI
+	reactions []*matrixReaction
+	byEvent   map[id.EventID][]*matrixReaction
+}
+
+func (fakeRelationsBot) BuildURLWithQuery(urlPath mautrix.PrefixableURLPath, _ map[string]string) string {
+	eventID := urlPath.(mautrix.ClientURLPath)[4]
+	return fmt.Sprintf("https://matrix.example.com/relations/%s", eventID)
+}
+
+func (b fakeRelationsBot) MakeRequest(_ context.Context, _, url string, _, resBody any) ([]byte, error) {
+	reactions, ok := b.byEvent[id.EventID(url[strings.LastIndex(url, "/")+1:])]
+	if !ok {
+		reactions = b.reactions
+	}
+	data, _ := json.Marshal(map[string]any{"chunk": reactions})
+	return data, json.Unmarshal(data, resBody)
+}
+
+// newReactionSyncTestConnector returns a connector whose relay login is
+// connected to a fake server with post p1 in relayTestChannel, bridged as
+// $p1:example.com. The post has reactions from alice (+1, not bridged) and
+// the login (smile and tada, both without records), and a record of bob's
+// heart, which is no longer in Mattermost. On Matrix only smile is left.
+func newReactionSyncTestConnector(t *testing.T) (*MattermostConnector, *MattermostClient, *fakeMM, *bridgev2.Portal) {
+	t.Helper()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {TeamID: "team1"}})
+	mc.Bridge.Bot = fakeRelationsBot{reactions: []*matrixReaction{
+		testMatrixReaction("$smile:example.com", "@relay:example.com", "😄"),
+		testMatrixReaction("$other:example.com", "@other:example.com", "🎉"),
+	}}
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	post := &model.Post{Id: "p1", ChannelId: relayTestChannel, Metadata: &model.PostMetadata{Reactions: []*model.Reaction{
+		{UserId: "alice-id", EmojiName: "+1", CreateAt: 2000},
+		{UserId: "relayuser", EmojiName: "smile"},
+		{UserId: "relayuser", EmojiName: "tada"},
+	}}}
+	list := model.NewPostList()
+	list.AddPost(post)
+	list.AddOrder(post.Id)
+	fake.Posts[relayTestChannel] = list
+
+	login, err := mc.Bridge.GetExistingUserLoginByID(ctx, MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	client := login.Client.(*MattermostClient)
+	client.client = model.NewAPIv4Client(fake.Server.URL)
+	client.client.SetToken("relay-token")
+	client.userID = "relayuser"
+	client.eventSender = &mockEventSender{}
+
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	if err := mc.Bridge.DB.Message.Insert(ctx, &database.Message{
+		ID:        MakeMessageID("p1"),
+		MXID:      "$p1:example.com",
+		Room:      portal.PortalKey,
+		SenderID:  MakeUserID("alice-id"),
+		Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	if err := mc.Bridge.DB.Reaction.Upsert(ctx, &database.Reaction{
+		Room:      portal.PortalKey,
+		MessageID: MakeMessageID("p1"),
+		SenderID:  MakeUserID("bob-id"),
+		EmojiID:   MakeEmojiID("heart"),
+		MXID:      "$heart:example.com",
+		Timestamp: time.UnixMilli(1500),
+	}); err != nil {
+		t.Fatalf("insert reaction: %v", err)
+	}
+	return mc, client, fake, portal
+}
+
+func testMatrixReaction(eventID id.EventID, sender id.UserID, key string) *matrixReaction {
+	reaction := &matrixReaction{ID: eventID, Sender: sender, Timestamp: 3000}
+	reaction.Content.RelatesTo.Key = key
+	return reaction
+}
+
+// queuedReactions returns the reactions queued on a test client as
+// "add|remove user emoji" strings, sorted.
+func queuedReactions(t *testing.T, client *MattermostClient) []string {
+	t.Helper()
+	var got []string
+	for _, evt := range testMock(client).Events() {
+		reaction, ok := evt.(*simplevent.Reaction)
+		if !ok {
+			t.Fatalf("unexpected event %T", evt)
+		}
+		action := "add"
+		if reaction.Type == bridgev2.RemoteEventReactionRemove {
+			action = "remove"
+		}
+		got = append(got, action+" "+ParseUserID(reaction.Sender.Sender)+" "+ParseEmojiID(reaction.EmojiID))
+	}
+	sort.Strings(got)
+	return got
+}
+
+// reactionDeletes returns the emoji of the reactions deleted on the fake
+// server.
+func reactionDeletes(fake *fakeMM) []string {
+	var deleted []string
+	for _, call := range fake.Calls() {
+		if call.Method == "DELETE" && strings.Contains(call.Path, "/reactions/") {
+			deleted = append(deleted, call.Path[strings.LastIndex(call.Path, "/")+1:])
+		}
+	}
+	return deleted
+}
+
+func TestReconcileReactions(t *testing.T) {
+	t.Parallel()
+	for _, removeOwn := range []bool{false, true} {
+		mc, client, fake, portal := newReactionSyncTestConnector(t)
+		ctx := context.Background()
+		msg, err := mc.Bridge.DB.Message.GetFirstPartByID(ctx, "", MakeMessageID("p1"))
+		if err != nil || msg == nil {
+			t.Fatalf("get message: %v", err)
+		}
+		if err := client.reconcileReactions(ctx, portal, msg, removeOwn); err != nil {
+			t.Fatalf("reconcile (removeOwn=%v): %v", removeOwn, err)
+		}
+
+		want := []string{"add alice-id +1", "remove bob-id heart"}
+		if got := queuedReactions(t, client); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("removeOwn=%v: queued %v, want %v", removeOwn, got, want)
+		}
+		restored, err := mc.Bridge.DB.Reaction.GetByMXID(ctx, "$smile:example.com")
+		if err != nil || restored == nil || restored.EmojiID != MakeEmojiID("smile") || restored.SenderID != MakeUserID("relayuser") {
+			t.Errorf("removeOwn=%v: restored reaction %+v, %v", removeOwn, restored, err)
+		}
+		wantDeleted := ""
+		if removeOwn {
+			wantDeleted = "tada"
+		}
+		if got := strings.Join(reactionDeletes(fake), ","); got != wantDeleted {
+			t.Errorf("removeOwn=%v: deleted %q, want %q", removeOwn, got, wantDeleted)
+		}
+	}
+}
+
+func TestHandleMatrixRedaction(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		sender      id.UserID
+		redacts     id.EventID
+		wantDeleted string
+	}{
+		{"unknown target", "@relay:example.com", "$lost:example.com", "tada"},
+		{"bridged message", "@relay:example.com", "$p1:example.com", ""},
+		{"bridged reaction", "@relay:example.com", "$heart:example.com", ""},
+		{"user without login", "@nobody:example.com", "$lost:example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, client, fake, _ := newReactionSyncTestConnector(t)
+			mc.handleMatrixRedaction(context.Background(), &event.Event{
+				Type:    event.EventRedaction,
+				Sender:  tt.sender,
+				RoomID:  id.RoomID("!" + relayTestChannel + ":example.com"),
+				Redacts: tt.redacts,
+				Content: event.Content{Parsed: &event.RedactionEventContent{Redacts: tt.redacts}},
+			})
+			waitForReconcile(t, client)
+			if got := strings.Join(reactionDeletes(fake), ","); got != tt.wantDeleted {
+				t.Errorf("deleted %q, want %q", got, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+// waitForReconcile waits until no reconciliation after a redaction is
+// running for client.
+func waitForReconcile(t *testing.T, client *MattermostClient) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		client.reconcilingMu.Lock()
+		running := len(client.reconcilingPortals)
+		client.reconcilingMu.Unlock()
+		if running == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("reconciliation didn't finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReconcileAfterRedaction_AlreadyRunning(t *testing.T) {
+	t.Parallel()
+	_, client, fake, portal := newReactionSyncTestConnector(t)
+	client.reconcilingPortals = map[networkid.PortalKey]bool{portal.PortalKey: false}
+
+	client.reconcileAfterRedaction(portal)
+	if again := client.reconcilingPortals[portal.PortalKey]; !again {
+		t.Error("redaction during a reconciliation should run it again")
+	}
+	if got := reactionDeletes(fake); len(got) != 0 {
+		t.Errorf("started a second reconciliation, deleted %v", got)
+	}
+}
+
+func TestSyncReactions_SkipsInactiveRooms(t *testing.T) {
+	t.Parallel()
+	mc, client, _, _ := newReactionSyncTestConnector(t)
+	client.syncReactions(context.Background())
+	if got := queuedReactions(t, client); len(got) != 0 {
+		t.Errorf("room without a post in the last week: queued %v", got)
+	}
+
+	if err := mc.Bridge.DB.Message.Insert(context.Background(), &database.Message{
+		ID:        MakeMessageID("p2"),
+		MXID:      "$p2:example.com",
+		Room:      makePortalKey(relayTestChannel),
+		SenderID:  MakeUserID("alice-id"),
+		Timestamp: time.Now(),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	client.syncReactions(context.Background())
+	if got := queuedReactions(t, client); len(got) != 2 {
+		t.Errorf("active room: queued %v, want 2 reactions", got)
+	}
+}
+
+// addFileParts bridges post p1 of newReactionSyncTestConnector as a text
+// and two files: parts "", "1" and "2", as $p1, $p1-1 and $p1-2.
+func addFileParts(t *testing.T, mc *MattermostConnector, portal *bridgev2.Portal) {
+	t.Helper()
+	for _, index := range []int{1, 2} {
+		if err := mc.Bridge.DB.Message.Insert(context.Background(), &database.Message{
+			ID:        MakeMessageID("p1"),
+			PartID:    MakeMessagePartID(index),
+			MXID:      id.EventID(fmt.Sprintf("$p1-%d:example.com", index)),
+			Room:      portal.PortalKey,
+			SenderID:  MakeUserID("alice-id"),
+			Timestamp: time.UnixMilli(1000),
+		}); err != nil {
+			t.Fatalf("insert part %d: %v", index, err)
+		}
+	}
+}
+
+func TestReconcileReactions_FileParts(t *testing.T) {
+	t.Parallel()
+	mc, client, fake, portal := newReactionSyncTestConnector(t)
+	addFileParts(t, mc, portal)
+	// The login's smile is on the second file, not on the text.
+	mc.Bridge.Bot = fakeRelationsBot{byEvent: map[id.EventID][]*matrixReaction{
+		"$p1:example.com":   nil,
+		"$p1-1:example.com": {testMatrixReaction("$other:example.com", "@other:example.com", "😄")},
+		"$p1-2:example.com": {testMatrixReaction("$smile:example.com", "@relay:example.com", "😄")},
+	}}
+	ctx := context.Background()
+	msg, err := mc.Bridge.DB.Message.GetFirstPartByID(ctx, "", MakeMessageID("p1"))
+	if err != nil || msg == nil {
+		t.Fatalf("get message: %v", err)
+	}
+	if err := client.reconcileReactions(ctx, portal, msg, true); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+
+	restored, err := mc.Bridge.DB.Reaction.GetByMXID(ctx, "$smile:example.com")
+	if err != nil || restored == nil || restored.MessagePartID != MakeMessagePartID(2) {
+		t.Errorf("restored reaction %+v, %v; want one on part 2", restored, err)
+	}
+	if got := strings.Join(reactionDeletes(fake), ","); got != "tada" {
+		t.Errorf("deleted %q, want only tada", got)
+	}
+}
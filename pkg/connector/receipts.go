@@ -0,0 +1,224 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+// ReadReceiptsConfig controls the bridging of other Mattermost users' read
+// positions. The logged-in user's own position is always bridged.
+type ReadReceiptsConfig struct {
+	// Enabled bridges other users' read positions as read receipts of their
+	// ghosts.
+	Enabled bool `yaml:"enabled"`
+	// PollIntervalSeconds is the time between polls of the read positions in
+	// recently active channels. 0 uses 60 seconds. A negative value turns
+	// polling off, leaving only channel_member_updated events.
+	PollIntervalSeconds int `yaml:"poll_interval_seconds"`
+}
+
+// defaultReceiptPollInterval is used when read_receipts.poll_interval_seconds
+// is unset.
+const defaultReceiptPollInterval = time.Minute
+
+// receiptChannelWindow is how long after its last post a channel's read
+// positions are polled. Most reads happen soon after a post.
+const receiptChannelWindow = 24 * time.Hour
+
+// receiptMembersPerPage is the page size of channel member polls. Only the
+// first page is polled, so the read positions of members of very large
+// channels may be missed.
+const receiptMembersPerPage = 200
+
+// pollInterval returns the time between polls: the default for 0, negative
+// for none.
+func (c *ReadReceiptsConfig) pollInterval() time.Duration {
+	switch {
+	case c.PollIntervalSeconds < 0:
+		return -1
+	case c.PollIntervalSeconds == 0:
+		return defaultReceiptPollInterval
+	}
+	return time.Duration(c.PollIntervalSeconds) * time.Second
+}
+
+// receiptChannel is a channel whose read positions are polled.
+type receiptChannel struct {
+	// lastPost is when the last post in the channel was received.
+	lastPost time.Time
+	// lastViewed maps users to the last_viewed_at last bridged for them.
+	lastViewed map[string]int64
+}
+
+// trackReadReceipts adds a channel that received a post to the set whose
+// read positions are polled.
+func (m *MattermostClient) trackReadReceipts(channelID string) {
+	if !m.connector.Config.ReadReceipts.Enabled || channelID == "" {
+		return
+	}
+	m.receiptsMu.Lock()
+	defer m.receiptsMu.Unlock()
+	if m.receiptChannels == nil {
+		m.receiptChannels = make(map[string]*receiptChannel)
+	}
+	channel, ok := m.receiptChannels[channelID]
+	if !ok {
+		channel = &receiptChannel{lastViewed: make(map[string]int64)}
+		m.receiptChannels[channelID] = channel
+	}
+	channel.lastPost = time.Now()
+}
+
+// trackedReceiptChannels returns the channels whose read positions are
+// polled, and forgets those without a post in receiptChannelWindow.
+func (m *MattermostClient) trackedReceiptChannels() []string {
+	m.receiptsMu.Lock()
+	defer m.receiptsMu.Unlock()
+	channelIDs := make([]string, 0, len(m.receiptChannels))
+	for channelID, channel := range m.receiptChannels {
+		if time.Since(channel.lastPost) > receiptChannelWindow {
+			delete(m.receiptChannels, channelID)
+			continue
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+	return channelIDs
+}
+
+// swapLastViewed records lastViewedAt as the last bridged read position of a
+// user in a channel and reports whether it moved forward.
+func (m *MattermostClient) swapLastViewed(channelID, userID string, lastViewedAt int64) bool {
+	m.receiptsMu.Lock()
+	defer m.receiptsMu.Unlock()
+	if m.receiptChannels == nil {
+		m.receiptChannels = make(map[string]*receiptChannel)
+	}
+	channel, ok := m.receiptChannels[channelID]
+	if !ok {
+		channel = &receiptChannel{lastViewed: make(map[string]int64)}
+		m.receiptChannels[channelID] = channel
+	}
+	if lastViewedAt <= channel.lastViewed[userID] {
+		return false
+	}
+	channel.lastViewed[userID] = lastViewedAt
+	return true
+}
+
+// shouldBridgeReceipt reports whether a Mattermost user's read position is
+// bridged to a receipt of their ghost. The logged-in user's position comes
+// from channel_viewed events, puppet bots' Matrix users send their own
+// receipts, and excluded guests aren't bridged.
+func (m *MattermostClient) shouldBridgeReceipt(userID string) bool {
+	return userID != "" && userID != m.userID && !m.connector.IsPuppetUserID(userID) && !m.isExcludedGuest(userID)
+}
+
+// bridgeReadPosition queues a read receipt for a user's read position in a
+// channel, unless it's not past the last one bridged.
+func (m *MattermostClient) bridgeReadPosition(channelID, userID string, lastViewedAt int64) {
+	if lastViewedAt <= 0 || !m.shouldBridgeReceipt(userID) || !m.swapLastViewed(channelID, userID, lastViewedAt) {
+		return
+	}
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.Receipt{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventReadReceipt,
+			PortalKey: makePortalKey(channelID),
+			Sender:    m.senderFor(userID),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("channel_id", channelID).Str("user_id", userID)
+			},
+		},
+		ReadUpTo: time.UnixMilli(lastViewedAt),
+	})
+}
+
+// parseChannelMemberUpdatedEvent extracts the channel member from a
+// channel_member_updated event.
+func parseChannelMemberUpdatedEvent(evt *model.WebSocketEvent) (*model.ChannelMember, error) {
+	memberJSON, ok := evt.GetData()["channelMember"].(string)
+	if !ok {
+		return nil, fmt.Errorf("channel member updated event missing member data")
+	}
+	var member model.ChannelMember
+	if err := json.Unmarshal([]byte(memberJSON), &member); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal channel member: %w", err)
+	}
+	if member.ChannelId == "" || member.UserId == "" {
+		return nil, fmt.Errorf("channel member updated event has no channel or user ID")
+	}
+	return &member, nil
+}
+
+// handleChannelMemberUpdated bridges the read position and, with
+// power_levels, the power level in a channel_member_updated event. Mattermost
+// sends these to the sessions of the member, so they mostly cover users with
+// their own login.
+func (m *MattermostClient) handleChannelMemberUpdated(evt *model.WebSocketEvent) {
+	readReceipts := m.connector.Config.ReadReceipts.Enabled
+	powerLevels := m.connector.Config.PowerLevels.Enabled
+	if !readReceipts && !powerLevels {
+		return
+	}
+	member, err := parseChannelMemberUpdatedEvent(evt)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to parse channel member updated event")
+		return
+	}
+	if !m.connector.OwnsChannel(member.ChannelId) {
+		return
+	}
+	if readReceipts {
+		m.bridgeReadPosition(member.ChannelId, member.UserId, member.LastViewedAt)
+	}
+	if powerLevels {
+		m.updateMemberPowerLevel(member)
+	}
+}
+
+// pollReadReceipts periodically polls the read positions of the members of
+// recently active channels and bridges those that moved. It runs until the
+// client disconnects.
+func (m *MattermostClient) pollReadReceipts(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+			m.syncReadReceipts(ctx)
+		}
+	}
+}
+
+// syncReadReceipts polls the read positions in recently active channels
+// once.
+func (m *MattermostClient) syncReadReceipts(ctx context.Context) {
+	if m.client == nil {
+		return
+	}
+	for _, channelID := range m.trackedReceiptChannels() {
+		members, _, err := m.client.GetChannelMembers(ctx, channelID, 0, receiptMembersPerPage, "")
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to poll channel read positions")
+			continue
+		}
+		for _, member := range members {
+			m.bridgeReadPosition(channelID, member.UserId, member.LastViewedAt)
+		}
+	}
+}
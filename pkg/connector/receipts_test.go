@@ -0,0 +1,142 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+func TestReadReceiptsConfig_PollInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		seconds int
+		want    time.Duration
+	}{
+		{0, time.Minute},
+		{15, 15 * time.Second},
+		{-1, -1},
+	}
+	for _, tt := range tests {
+		cfg := ReadReceiptsConfig{PollIntervalSeconds: tt.seconds}
+		if got := cfg.pollInterval(); got != tt.want {
+			t.Errorf("pollInterval(%d) = %v, want %v", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+// receipts returns the read receipts queued on a test client, by user ID.
+func receipts(t *testing.T, mc *MattermostClient) map[string]time.Time {
+	t.Helper()
+	got := make(map[string]time.Time)
+	for _, evt := range testMock(mc).Events() {
+		receipt, ok := evt.(*simplevent.Receipt)
+		if !ok {
+			t.Fatalf("unexpected event %T", evt)
+		}
+		got[ParseUserID(receipt.Sender.Sender)] = receipt.ReadUpTo
+	}
+	return got
+}
+
+func TestSyncReadReceipts(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	defer fake.Close()
+	fake.ChannelMembers["ch1"] = model.ChannelMembers{
+		{ChannelId: "ch1", UserId: "alice-id", LastViewedAt: 2000},
+		{ChannelId: "ch1", UserId: "my-user-id", LastViewedAt: 3000},
+		{ChannelId: "ch1", UserId: "puppet-id", LastViewedAt: 3000},
+		{ChannelId: "ch1", UserId: "bob-id", LastViewedAt: 0},
+	}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.ReadReceipts.Enabled = true
+	mc.connector.Puppets["@bot:test"] = &PuppetClient{MXID: "@bot:test", UserID: "puppet-id"}
+	ctx := context.Background()
+
+	mc.syncReadReceipts(ctx)
+	if got := receipts(t, mc); len(got) != 0 {
+		t.Fatalf("untracked channel: got receipts %v", got)
+	}
+
+	mc.trackReadReceipts("ch1")
+	mc.syncReadReceipts(ctx)
+	got := receipts(t, mc)
+	if len(got) != 1 || !got["alice-id"].Equal(time.UnixMilli(2000)) {
+		t.Fatalf("first poll: got %v, want only alice-id at 2000", got)
+	}
+
+	// Unchanged positions aren't bridged again.
+	testMock(mc).Reset()
+	mc.syncReadReceipts(ctx)
+	if got := receipts(t, mc); len(got) != 0 {
+		t.Errorf("second poll: got receipts %v", got)
+	}
+
+	fake.mu.Lock()
+	fake.ChannelMembers["ch1"][0].LastViewedAt = 4000
+	fake.mu.Unlock()
+	mc.syncReadReceipts(ctx)
+	if got := receipts(t, mc); len(got) != 1 || !got["alice-id"].Equal(time.UnixMilli(4000)) {
+		t.Errorf("third poll: got %v, want alice-id at 4000", got)
+	}
+}
+
+func TestTrackReadReceipts(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	mc.trackReadReceipts("ch1")
+	if channels := mc.trackedReceiptChannels(); len(channels) != 0 {
+		t.Errorf("disabled: got tracked channels %v", channels)
+	}
+
+	mc.connector.Config.ReadReceipts.Enabled = true
+	mc.trackReadReceipts("ch1")
+	mc.trackReadReceipts("ch2")
+	mc.receiptChannels["ch2"].lastPost = time.Now().Add(-receiptChannelWindow - time.Minute)
+	if channels := mc.trackedReceiptChannels(); len(channels) != 1 || channels[0] != "ch1" {
+		t.Errorf("got tracked channels %v, want [ch1]", channels)
+	}
+	if _, ok := mc.receiptChannels["ch2"]; ok {
+		t.Error("inactive channel should be forgotten")
+	}
+}
+
+func TestHandleChannelMemberUpdated(t *testing.T) {
+	t.Parallel()
+	memberEvent := func(member *model.ChannelMember) *model.WebSocketEvent {
+		memberJSON, _ := json.Marshal(member)
+		return newWebSocketEvent(model.WebsocketEventChannelMemberUpdated, "", map[string]any{"channelMember": string(memberJSON)})
+	}
+	tests := []struct {
+		name     string
+		disabled bool
+		evt      *model.WebSocketEvent
+		want     int
+	}{
+		{"other user", false, memberEvent(&model.ChannelMember{ChannelId: "ch1", UserId: "alice-id", LastViewedAt: 1000}), 1},
+		{"disabled", true, memberEvent(&model.ChannelMember{ChannelId: "ch1", UserId: "alice-id", LastViewedAt: 1000}), 0},
+		{"own user", false, memberEvent(&model.ChannelMember{ChannelId: "ch1", UserId: "my-user-id", LastViewedAt: 1000}), 0},
+		{"missing member", false, newWebSocketEvent(model.WebsocketEventChannelMemberUpdated, "", map[string]any{}), 0},
+		{"missing channel", false, memberEvent(&model.ChannelMember{UserId: "alice-id", LastViewedAt: 1000}), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://localhost")
+			mc.connector.Config.ReadReceipts.Enabled = !tt.disabled
+			mc.handleEvent(tt.evt)
+			if got := receipts(t, mc); len(got) != tt.want {
+				t.Errorf("got receipts %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+)
+
+// recoverMissedPosts bridges the posts sent while the bridge was down. For
+// every portal room, the posts created since its latest bridged message are
+// fetched and queued as new messages, oldest first and up to
+// backfill.missed_limit per portal.
+//
+// It only runs when the bridge's own backfill is disabled: otherwise the
+// catch-up backfill of the channel sync already fetches these posts.
+func (m *MattermostClient) recoverMissedPosts(ctx context.Context) {
+	cfg := &m.connector.Config
+	br := m.connector.Bridge
+	if !cfg.backfillMissed() || br == nil || br.DB == nil {
+		return
+	}
+	if br.Config != nil && br.Config.Backfill.Enabled {
+		return
+	}
+
+	portals, err := br.DB.Portal.GetAllWithMXID(ctx)
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to get portals to recover missed posts")
+		return
+	}
+	limit := m.connector.liveConfig().backfillLimit(true)
+	recovered := 0
+	for _, portal := range portals {
+		// Missed replies of thread rooms are recovered with their channel.
+		if portal.RoomType == database.RoomTypeSpace || isThreadPortalKey(portal.PortalKey) {
+			continue
+		}
+		channelID := ParsePortalID(portal.ID)
+		if !m.connector.OwnsChannel(channelID) {
+			continue
+		}
+		n, err := m.recoverChannelPosts(ctx, portal, limit)
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to recover missed posts")
+			continue
+		}
+		recovered += n
+	}
+	m.log.Info().
+		Int("portal_count", len(portals)).
+		Int("post_count", recovered).
+		Msg("Missed post recovery complete")
+}
+
+// recoverChannelPosts queues up to limit posts of one portal's channel that
+// are newer than its latest bridged message, and returns how many it queued.
+// Portals without bridged messages are skipped, since there's no point to
+// recover from.
+func (m *MattermostClient) recoverChannelPosts(ctx context.Context, portal *database.Portal, limit int) (int, error) {
+	latest, err := m.connector.Bridge.DB.Message.GetLastPartAtOrBeforeTime(ctx, portal.PortalKey, time.Now().Add(time.Minute))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest bridged message: %w", err)
+	}
+	if latest == nil {
+		return 0, nil
+	}
+	channelID := ParsePortalID(portal.ID)
+	since := latest.Timestamp.UnixMilli()
+	list, _, err := m.client.GetPostsSince(ctx, channelID, since, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get posts since latest bridged message: %w", err)
+	}
+
+	// Posts are returned when created, edited or deleted since; only new
+	// ones are missing. A post sharing the latest message's millisecond is
+	// kept and checked against the message table like the rest.
+	var posts []*model.Post
+	for _, post := range list.ToSlice() {
+		if post.CreateAt < since || post.DeleteAt != 0 || post.ChannelId != channelID {
+			continue
+		}
+		if m.isEchoPost(post, "recovered_post") {
+			continue
+		}
+		senderName, bridgeUser := m.isBridgeUserPost(ctx, post, "recovered_post")
+		if bridgeUser || m.isSystemUserPost(post, "recovered_post", senderName) || m.isExcludedGuest(post.UserId) ||
+			m.isTooOld("recovered_post", post.Id, post.CreateAt) || m.isMessageBridged(ctx, &bridgev2.Portal{Portal: portal}, post.Id) {
+			continue
+		}
+		posts = append(posts, post)
+	}
+	posts = orderThreads(posts)
+	if len(posts) > limit {
+		m.log.Warn().
+			Str("channel_id", channelID).
+			Int("missed_count", len(posts)).
+			Int("limit", limit).
+			Msg("More missed posts than backfill.missed_limit, recovering the oldest")
+		posts = posts[:limit]
+	}
+	if len(posts) > 0 {
+		m.flushBurst(ctx, channelID)
+	}
+	queued := 0
+	for _, post := range posts {
+		// The post may have arrived on the WebSocket since it was fetched.
+		if m.isDuplicatePost(string(model.WebsocketEventPosted), post) {
+			continue
+		}
+		m.queuePost(ctx, post, false)
+		queued++
+	}
+	if queued > 0 {
+		m.log.Debug().
+			Str("channel_id", channelID).
+			Int("post_count", queued).
+			Time("since", latest.Timestamp).
+			Msg("Recovered missed posts")
+	}
+	return queued, nil
+}
+
+// isBridgeUserPost applies the bridge username echo layer to a post fetched
+// from the API, which unlike a WebSocket event has no sender_name: the
+// username is looked up in the username cache. It returns the username, ""
+// when the lookup fails, which lets the post through like an event without
+// sender_name.
+func (m *MattermostClient) isBridgeUserPost(ctx context.Context, post *model.Post, event string) (string, bool) {
+	if m.client == nil {
+		return "", false
+	}
+	senderName, ok := m.mentionUsername(ctx, post.UserId)
+	if !ok || !isBridgeUsername(senderName, m.connector.liveConfig().BotPrefix) {
+		return senderName, false
+	}
+	m.log.Debug().
+		Str("post_id", post.Id).
+		Str("username", senderName).
+		Msg("Skipping bridge username post (echo prevention)")
+	m.postEchoDropped(echoLayerBridgeUsername, event, post, senderName)
+	return senderName, true
+}
@@ -0,0 +1,180 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+)
+
+// newRecoveryTestClient returns a client whose bridge has a portal for
+// relayTestChannel with one bridged message at 1000 ms, and whose fake
+// server has these posts in the channel:
+//
+//	old     1000 ms, already bridged
+//	edited   500 ms, edited at 2500 ms
+//	new1    2000 ms
+//	own     2100 ms, by the logged-in user
+//	join    2200 ms, system message
+//	removed 2300 ms, deleted at 2400 ms
+//	new2    3000 ms
+func newRecoveryTestClient(t *testing.T, missedLimit int) *MattermostClient {
+	t.Helper()
+	connector := newRelayTestConnector(t, map[string]*PortalMetadata{
+		relayTestChannel:  {},
+		relayTestChannel2: {},
+	})
+	connector.Config.Backfill.MissedLimit = missedLimit
+	if err := connector.Bridge.DB.Message.Insert(context.Background(), &database.Message{
+		ID:        MakeMessageID("old"),
+		MXID:      "$old:example.com",
+		Room:      makePortalKey(relayTestChannel),
+		SenderID:  MakeUserID("alice-id"),
+		Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	list := model.NewPostList()
+	for _, post := range []*model.Post{
+		{Id: "old", UserId: "alice-id", CreateAt: 1000},
+		{Id: "edited", UserId: "alice-id", CreateAt: 500, UpdateAt: 2500, EditAt: 2500},
+		{Id: "new1", UserId: "alice-id", CreateAt: 2000},
+		{Id: "own", UserId: "my-user-id", CreateAt: 2100},
+		{Id: "join", UserId: "bob-id", CreateAt: 2200, Type: model.PostTypeJoinChannel},
+		{Id: "removed", UserId: "alice-id", CreateAt: 2300, DeleteAt: 2400},
+		{Id: "new2", UserId: "bob-id", CreateAt: 3000},
+	} {
+		post.ChannelId = relayTestChannel
+		list.AddPost(post)
+		list.AddOrder(post.Id)
+	}
+	fake.Posts[relayTestChannel] = list
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector = connector
+	return mc
+}
+
+// recoveredPostIDs returns the post IDs of the queued messages, in order.
+func recoveredPostIDs(t *testing.T, mc *MattermostClient) []string {
+	t.Helper()
+	var ids []string
+	for _, evt := range testMock(mc).Events() {
+		msg, ok := evt.(*simplevent.Message[*model.Post])
+		if !ok {
+			t.Fatalf("expected Message, got %T", evt)
+		}
+		if msg.CreatePortal {
+			t.Errorf("recovered post %s should not create a portal", msg.Data.Id)
+		}
+		ids = append(ids, msg.Data.Id)
+	}
+	return ids
+}
+
+func TestRecoverMissedPosts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		missedLimit int
+		want        []string
+	}{
+		{"disabled", 0, nil},
+		{"all missed posts", 10, []string{"new1", "new2"}},
+		{"limited", 1, []string{"new1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newRecoveryTestClient(t, tt.missedLimit)
+			mc.recoverMissedPosts(context.Background())
+
+			got := recoveredPostIDs(t, mc)
+			if len(got) != len(tt.want) {
+				t.Fatalf("recovered posts: got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("recovered posts: got %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRecoverMissedPosts_BridgeBackfillEnabled(t *testing.T) {
+	t.Parallel()
+	mc := newRecoveryTestClient(t, 10)
+	mc.connector.Bridge.Config.Backfill.Enabled = true
+	mc.recoverMissedPosts(context.Background())
+
+	if got := recoveredPostIDs(t, mc); len(got) != 0 {
+		t.Errorf("catch-up is left to the bridge's backfill, got %v", got)
+	}
+}
+
+func TestRecoverMissedPosts_NotOwned(t *testing.T) {
+	t.Parallel()
+	mc := newRecoveryTestClient(t, 10)
+	mc.connector.Config.Sharding = ShardingConfig{Count: 2, ID: (shardForChannel(relayTestChannel, 2) + 1) % 2}
+	mc.recoverMissedPosts(context.Background())
+
+	if got := recoveredPostIDs(t, mc); len(got) != 0 {
+		t.Errorf("channels of other shards should be skipped, got %v", got)
+	}
+}
+
+func TestRecoverMissedPosts_MaxMessageAge(t *testing.T) {
+	t.Parallel()
+	// The test posts were created in 1970.
+	mc := newRecoveryTestClient(t, 10)
+	mc.connector.Config.MaxMessageAgeSeconds = 3600
+	mc.recoverMissedPosts(context.Background())
+	if got := recoveredPostIDs(t, mc); len(got) != 0 {
+		t.Errorf("recovered posts: got %v, want none", got)
+	}
+}
+
+func TestRecoverMissedPosts_FlushesBurst(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newRecoveryTestClient(t, 10)
+	mc.eventSender = &mockEventSender{}
+	mc.connector.Config.CoalesceBotPosts.WindowSeconds = 60
+	t.Cleanup(mc.flushBursts)
+	held := botPost("burst", "held")
+	held.ChannelId = relayTestChannel
+	if !mc.coalescePost(ctx, held, "", false) {
+		t.Fatal("bot post wasn't held")
+	}
+
+	mc.recoverMissedPosts(ctx)
+
+	if got := recoveredPostIDs(t, mc); !slices.Equal(got, []string{"burst", "new1", "new2"}) {
+		t.Errorf("queued posts = %v, want the burst before the recovered posts", got)
+	}
+}
+
+func TestRecoverMissedPosts_BridgeUsername(t *testing.T) {
+	t.Parallel()
+	mc := newRecoveryTestClient(t, 10)
+	mc.rememberMentionUser("mattermost_bob", &model.User{Id: "bob-id", Username: "mattermost_bob"})
+	mc.recoverMissedPosts(context.Background())
+
+	if got := recoveredPostIDs(t, mc); !slices.Equal(got, []string{"new1"}) {
+		t.Errorf("recovered posts = %v, want the bridge user's post skipped", got)
+	}
+}
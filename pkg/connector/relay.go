@@ -0,0 +1,142 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+)
+
+// maxRelayBodySize is the maximum size of a /api/relay request body.
+const maxRelayBodySize = 4 << 10
+
+// relayAllowed reports whether the auto-login user may be set as relay on a
+// portal: the portal must be a channel rather than a team space, the relay
+// config must allow the channel, and no operator may have cleared the
+// portal's relay through the admin API.
+func (mc *MattermostConnector) relayAllowed(portal *bridgev2.Portal) bool {
+	if portal.RoomType == database.RoomTypeSpace {
+		return false
+	}
+	meta := portalMetadata(portal)
+	if meta.RelayDisabled {
+		return false
+	}
+	return mc.Config.Relay.allows(ParsePortalID(portal.ID), meta.TeamID)
+}
+
+// relayLogin returns the login of the first user that has one, which is
+// used as the relay for portals.
+func (mc *MattermostConnector) relayLogin(ctx context.Context) (*bridgev2.UserLogin, error) {
+	userIDs, err := mc.Bridge.DB.UserLogin.GetAllUserIDsWithLogins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users with logins: %w", err)
+	}
+	for _, userID := range userIDs {
+		user, err := mc.Bridge.GetUserByMXID(ctx, userID)
+		if err != nil {
+			continue
+		}
+		if logins := user.GetUserLogins(); len(logins) > 0 {
+			return logins[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// RelayRequest is the body of POST /api/relay.
+type RelayRequest struct {
+	ChannelID string `json:"channel_id"`
+	// Relay sets the relay on the channel's portal when true. When false,
+	// the relay is cleared and the portal is skipped by the automatic relay
+	// setup until it's enabled again.
+	Relay bool `json:"relay"`
+}
+
+// HandleRelay is an HTTP handler for POST /api/relay. It sets or clears the
+// relay of one portal, overriding the relay config for that portal.
+func (mc *MattermostConnector) HandleRelay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	r.Body = http.MaxBytesReader(w, r.Body, maxRelayBodySize)
+	defer func() { _ = r.Body.Close() }()
+
+	var req RelayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if !model.IsValidId(req.ChannelID) {
+		http.Error(w, "channel_id must be a valid Mattermost ID", http.StatusBadRequest)
+		return
+	}
+
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("channel_id", req.ChannelID).
+		Bool("relay", req.Relay).
+		Msg("Relay change requested")
+
+	ctx := r.Context()
+	portal, err := mc.Bridge.GetExistingPortalByKey(ctx, makePortalKey(req.ChannelID))
+	if err != nil {
+		log.Error().Err(err).Str("channel_id", req.ChannelID).Msg("Failed to get portal for relay change")
+		http.Error(w, "failed to get portal", http.StatusInternalServerError)
+		return
+	}
+	if portal == nil {
+		http.Error(w, "no portal for channel", http.StatusNotFound)
+		return
+	}
+
+	var login *bridgev2.UserLogin
+	if req.Relay {
+		login, err = mc.relayLogin(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to find relay login")
+			http.Error(w, "failed to find relay login", http.StatusInternalServerError)
+			return
+		}
+		if login == nil {
+			http.Error(w, "no login available to relay with", http.StatusConflict)
+			return
+		}
+	}
+	portalMetadata(portal).RelayDisabled = !req.Relay
+	if err := portal.SetRelay(ctx, login); err != nil {
+		log.Error().Err(err).Str("channel_id", req.ChannelID).Msg("Failed to change portal relay")
+		http.Error(w, "failed to save portal", http.StatusInternalServerError)
+		return
+	}
+
+	resp := map[string]any{
+		"channel_id": req.ChannelID,
+		"room_id":    string(portal.MXID),
+		"relay":      req.Relay,
+	}
+	if login != nil {
+		resp["relay_login_id"] = string(login.ID)
+	}
+	log.Info().
+		Str("channel_id", req.ChannelID).
+		Stringer("room_id", portal.MXID).
+		Bool("relay", req.Relay).
+		Msg("Portal relay changed")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Warn().Err(err).Msg("Failed to write relay response")
+	}
+}
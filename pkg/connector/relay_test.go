@@ -0,0 +1,182 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/id"
+)
+
+const (
+	relayTestChannel  = "aaaaaaaaaaaaaaaaaaaaaaaaaa"
+	relayTestChannel2 = "bbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+// nopMatrixConnector is the minimal bridgev2.MatrixConnector needed by
+// bridgev2.NewBridge. Other methods are unimplemented and panic if called.
+type nopMatrixConnector struct {
+	bridgev2.MatrixConnector
+}
+
+func (nopMatrixConnector) Init(*bridgev2.Bridge)         {}
+func (nopMatrixConnector) BotIntent() bridgev2.MatrixAPI { return nil }
+func (nopMatrixConnector) ServerName() string            { return "example.com" }
+
+// newRelayTestConnector returns a connector with a bridge backed by an
+// in-memory database holding one user with a login and a portal room for
+// each of the given channels.
+func newRelayTestConnector(t *testing.T, channels map[string]*PortalMetadata) *MattermostConnector {
+	t.Helper()
+	ctx := context.Background()
+	mc := &MattermostConnector{}
+	mc.Bridge = bridgev2.NewBridge("mattermost", newTestDB(t), zerolog.Nop(), nil, nopMatrixConnector{}, mc,
+		func(*bridgev2.Bridge) bridgev2.CommandProcessor { return nil })
+	if err := mc.Bridge.DB.Upgrade(ctx); err != nil {
+		t.Fatalf("upgrade bridge db: %v", err)
+	}
+	if err := mc.Bridge.DB.User.Insert(ctx, &database.User{MXID: "@relay:example.com"}); err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	if err := mc.Bridge.DB.UserLogin.Insert(ctx, &database.UserLogin{
+		ID:       MakeUserLoginID("relayuser"),
+		UserMXID: "@relay:example.com",
+		Metadata: &UserLoginMetadata{},
+	}); err != nil {
+		t.Fatalf("insert login: %v", err)
+	}
+	for channelID, meta := range channels {
+		if err := mc.Bridge.DB.Portal.Insert(ctx, &database.Portal{
+			BridgeID:  "mattermost",
+			PortalKey: makePortalKey(channelID),
+			MXID:      id.RoomID("!" + channelID + ":example.com"),
+			Metadata:  meta,
+		}); err != nil {
+			t.Fatalf("insert portal: %v", err)
+		}
+	}
+	return mc
+}
+
+func getRelayTestPortal(t *testing.T, mc *MattermostConnector, channelID string) *bridgev2.Portal {
+	t.Helper()
+	portal, err := mc.Bridge.GetExistingPortalByKey(context.Background(), makePortalKey(channelID))
+	if err != nil || portal == nil {
+		t.Fatalf("get portal %s: %v", channelID, err)
+	}
+	return portal
+}
+
+func TestCheckAndSetRelay_Filters(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{
+		relayTestChannel:  {TeamID: "t1"},
+		relayTestChannel2: {TeamID: "t2"},
+	})
+	mc.Config.Relay = RelayConfig{Teams: []string{"t1"}}
+
+	mc.checkAndSetRelay(context.Background())
+
+	if portal := getRelayTestPortal(t, mc, relayTestChannel); portal.RelayLoginID != "relayuser" {
+		t.Errorf("team t1 portal relay: got %q, want relayuser", portal.RelayLoginID)
+	}
+	if portal := getRelayTestPortal(t, mc, relayTestChannel2); portal.RelayLoginID != "" {
+		t.Errorf("team t2 portal relay: got %q, want none", portal.RelayLoginID)
+	}
+}
+
+func TestCheckAndSetRelay_SkipsDisabled(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{
+		relayTestChannel: {RelayDisabled: true},
+	})
+
+	mc.checkAndSetRelay(context.Background())
+
+	if portal := getRelayTestPortal(t, mc, relayTestChannel); portal.RelayLoginID != "" {
+		t.Errorf("disabled portal relay: got %q, want none", portal.RelayLoginID)
+	}
+}
+
+func TestHandleRelay(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	mc.Config.Relay = RelayConfig{ChannelDenylist: []string{relayTestChannel}}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/relay", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		mc.HandleRelay(w, req)
+		return w
+	}
+
+	// Enabling overrides the denylist.
+	w := post(`{"channel_id":"` + relayTestChannel + `","relay":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("enable status: got %d, body %q", w.Code, w.Body.String())
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp["relay_login_id"] != "relayuser" || resp["relay"] != true {
+		t.Errorf("enable response: %v", resp)
+	}
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	if portal.RelayLoginID != "relayuser" || portalMetadata(portal).RelayDisabled {
+		t.Errorf("after enable: relay %q, disabled %v", portal.RelayLoginID, portalMetadata(portal).RelayDisabled)
+	}
+
+	// Clearing removes the relay and keeps the watcher from restoring it.
+	mc.Config.Relay = RelayConfig{}
+	w = post(`{"channel_id":"` + relayTestChannel + `","relay":false}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("clear status: got %d, body %q", w.Code, w.Body.String())
+	}
+	mc.checkAndSetRelay(context.Background())
+	portal = getRelayTestPortal(t, mc, relayTestChannel)
+	if portal.RelayLoginID != "" || !portalMetadata(portal).RelayDisabled {
+		t.Errorf("after clear: relay %q, disabled %v", portal.RelayLoginID, portalMetadata(portal).RelayDisabled)
+	}
+}
+
+func TestHandleRelay_Errors(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, nil)
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"invalid json", http.MethodPost, "not json", http.StatusBadRequest},
+		{"missing channel", http.MethodPost, `{"relay":true}`, http.StatusBadRequest},
+		{"invalid channel", http.MethodPost, `{"channel_id":"../x","relay":true}`, http.StatusBadRequest},
+		{"too large", http.MethodPost, `{"channel_id":"` + strings.Repeat("a", maxRelayBodySize) + `"}`, http.StatusBadRequest},
+		{"no portal", http.MethodPost, `{"channel_id":"` + relayTestChannel + `","relay":false}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(tt.method, "/api/relay", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mc.HandleRelay(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status: got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
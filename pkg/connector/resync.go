@@ -0,0 +1,249 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// maxResyncBodySize is the maximum size of a /api/resync request body.
+const maxResyncBodySize = 4 << 10
+
+// resyncWriteTimeout bounds how long a resync response may take to write.
+// Each channel takes a few Mattermost requests, so resyncing every portal
+// can outlast the admin server's WriteTimeout.
+const resyncWriteTimeout = 5 * time.Minute
+
+// ResyncRequest is the body of POST /api/resync. Exactly one of ChannelID
+// and All is set.
+type ResyncRequest struct {
+	ChannelID string `json:"channel_id,omitempty"`
+	// All resyncs every portal room of the login on this shard.
+	All bool `json:"all,omitempty"`
+	// LoginID is the login whose Mattermost account fetches the channels.
+	// Defaults to the first full login.
+	LoginID string `json:"login_id,omitempty"`
+}
+
+// ResyncResult reports one channel of a resync request.
+type ResyncResult struct {
+	ChannelID string `json:"channel_id"`
+	RoomID    string `json:"room_id,omitempty"`
+	// Pinned is the number of pinned posts of the channel.
+	Pinned int    `json:"pinned"`
+	Error  string `json:"error,omitempty"`
+}
+
+// errNoPortalRoom is reported for channels without a portal room, which a
+// resync doesn't create.
+var errNoPortalRoom = errors.New("channel has no portal room")
+
+// errExcludedGuestDM is reported for DMs with a guest excluded by the
+// guests config, which aren't bridged.
+var errExcludedGuestDM = errors.New("DM with an excluded guest")
+
+// HandleResync is an HTTP handler for POST /api/resync. It re-fetches the
+// info, members, pinned posts and missed posts of one channel's portal, or
+// of every portal, and queues a ChatResync for each, for use after bulk
+// changes in Mattermost or edits to the bridge database. The resyncs are
+// queued when it returns; the rooms update as the bridge handles them.
+func (mc *MattermostConnector) HandleResync(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	r.Body = http.MaxBytesReader(w, r.Body, maxResyncBodySize)
+	defer func() { _ = r.Body.Close() }()
+
+	var req ResyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if (req.ChannelID == "") == !req.All {
+		http.Error(w, "exactly one of channel_id and all is required", http.StatusBadRequest)
+		return
+	}
+	if req.ChannelID != "" && !model.IsValidId(req.ChannelID) {
+		http.Error(w, "channel_id must be a valid Mattermost ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	login, client, err := mc.clientLogin(ctx, req.LoginID)
+	if err != nil || client.client == nil {
+		http.Error(w, "no Mattermost login available to resync with", http.StatusConflict)
+		return
+	}
+
+	var portals []*bridgev2.Portal
+	if req.All {
+		portals, err = mc.resyncPortals(ctx, login)
+		if err != nil {
+			log.Err(err).Msg("Failed to get portals to resync")
+			http.Error(w, "failed to get portals", http.StatusInternalServerError)
+			return
+		}
+	} else {
+		portal, err := mc.Bridge.GetExistingPortalByKey(ctx, makePortalKey(req.ChannelID))
+		switch {
+		case err != nil:
+			log.Err(err).Str("channel_id", req.ChannelID).Msg("Failed to get portal to resync")
+			http.Error(w, "failed to get portal", http.StatusInternalServerError)
+			return
+		case portal == nil || portal.MXID == "":
+			http.Error(w, errNoPortalRoom.Error(), http.StatusNotFound)
+			return
+		case !mc.OwnsChannel(req.ChannelID):
+			http.Error(w, errChannelNotOwned.Error(), http.StatusConflict)
+			return
+		}
+		portals = []*bridgev2.Portal{portal}
+	}
+
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("login_id", string(login.ID)).
+		Bool("all", req.All).
+		Int("channels", len(portals)).
+		Msg("Channel resync requested")
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(resyncWriteTimeout))
+	results := make([]ResyncResult, 0, len(portals))
+	failed := 0
+	for _, portal := range portals {
+		result := client.resyncPortal(ctx, portal)
+		if result.Error != "" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	log.Info().
+		Int("channels", len(results)).
+		Int("failed", failed).
+		Msg("Channel resync queued")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"channels": results}); err != nil {
+		log.Warn().Err(err).Msg("Failed to write resync response")
+	}
+}
+
+// resyncPortals returns the portal rooms a resync of all channels covers:
+// those of this shard's channels shared by all logins or belonging to login.
+func (mc *MattermostConnector) resyncPortals(ctx context.Context, login *bridgev2.UserLogin) ([]*bridgev2.Portal, error) {
+	all, err := mc.Bridge.GetAllPortalsWithMXID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	portals := make([]*bridgev2.Portal, 0, len(all))
+	for _, portal := range all {
+		if portal.RoomType == database.RoomTypeSpace || isThreadPortal(portal) || !mc.OwnsChannel(ParsePortalID(portal.ID)) {
+			continue
+		}
+		if portal.Receiver != "" && portal.Receiver != login.ID {
+			continue
+		}
+		portals = append(portals, portal)
+	}
+	slices.SortFunc(portals, func(a, b *bridgev2.Portal) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return portals, nil
+}
+
+// resyncPortal fetches a portal's channel and pinned posts and queues a
+// ChatResync with them. Unlike the channel sync on connect, the resync
+// always checks for posts after the latest bridged message when catching up
+// on missed posts is enabled, and it sets the room's pinned events.
+func (m *MattermostClient) resyncPortal(ctx context.Context, portal *bridgev2.Portal) ResyncResult {
+	channelID := ParsePortalID(portal.ID)
+	log := m.log.With().Str("channel_id", channelID).Logger()
+	result := ResyncResult{ChannelID: channelID, RoomID: string(portal.MXID)}
+	fail := func(msg string, err error) ResyncResult {
+		log.Warn().Err(err).Msg(msg)
+		result.Error = fmt.Sprintf("%s: %v", msg, err)
+		return result
+	}
+
+	ch, _, err := m.client.GetChannel(ctx, channelID, "")
+	if err != nil {
+		return fail("Failed to get channel", err)
+	}
+	pinned, _, err := m.client.GetPinnedPosts(ctx, channelID, "")
+	if err != nil {
+		return fail("Failed to get pinned posts", err)
+	}
+	evt, err := m.channelSyncEvent(ctx, ch, false)
+	if err != nil {
+		return fail("Failed to get channel members", err)
+	}
+	if evt == nil {
+		return fail("Channel not resynced", errExcludedGuestDM)
+	}
+	result.Pinned = len(pinned.Order)
+
+	evt.ChatInfo.ExtraUpdates = bridgev2.MergeExtraUpdaters(evt.ChatInfo.ExtraUpdates, m.pinnedUpdater(channelID, pinned.Order))
+	if cfg := &m.connector.Config; cfg.backfillMissed() && ch.LastPostAt > 0 {
+		evt.LatestMessageTS = time.UnixMilli(ch.LastPostAt)
+		evt.CheckNeedsBackfillFunc = func(context.Context, *database.Message) (bool, error) {
+			return true, nil
+		}
+	}
+	m.eventSender.QueueRemoteEvent(m.userLogin, evt)
+	log.Debug().Int("pinned", result.Pinned).Msg("Queued channel resync")
+	return result
+}
+
+// pinnedUpdater returns a ChatInfo.ExtraUpdates hook that sets the room's
+// pinned events to the bridged messages of postIDs. Posts that weren't
+// bridged are left out. The events last set are tracked in the portal
+// metadata, so the state event is only sent when they change.
+func (m *MattermostClient) pinnedUpdater(channelID string, postIDs []string) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		if portal.MXID == "" {
+			return false
+		}
+		pinned := make([]id.EventID, 0, len(postIDs))
+		for _, postID := range postIDs {
+			msg, err := portal.Bridge.DB.Message.GetFirstPartByID(ctx, portal.Receiver, MakeMessageID(postID))
+			if err != nil {
+				m.log.Warn().Err(err).Str("post_id", postID).Msg("Failed to get pinned message")
+				continue
+			}
+			if msg != nil {
+				pinned = append(pinned, msg.MXID)
+			}
+		}
+		meta := portalMetadata(portal)
+		if slices.Equal(meta.PinnedEvents, pinned) {
+			return false
+		}
+		content := &event.PinnedEventsEventContent{Pinned: pinned}
+		_, err := portal.Bridge.Bot.SendState(ctx, portal.MXID, event.StatePinnedEvents, "", &event.Content{Parsed: content}, time.Time{})
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to set pinned events")
+			return false
+		}
+		meta.PinnedEvents = pinned
+		return true
+	}
+}
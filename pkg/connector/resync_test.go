@@ -0,0 +1,197 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// newResyncTestConnector returns a provisioning test connector whose login
+// queues events to a mockEventSender, and the fake server behind it.
+func newResyncTestConnector(t *testing.T) (*MattermostConnector, *fakeMM, *mockEventSender) {
+	t.Helper()
+	mc, _ := newProvisionTestConnector(t)
+	login, err := mc.Bridge.GetExistingUserLoginByID(context.Background(), MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	client := login.Client.(*MattermostClient)
+	sender := &mockEventSender{}
+	client.eventSender = sender
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	for _, channelID := range []string{relayTestChannel, relayTestChannel2} {
+		fake.Channels[channelID] = &model.Channel{Id: channelID, Type: model.ChannelTypeOpen, Name: "town-square", LastPostAt: 2000}
+	}
+	client.client = model.NewAPIv4Client(fake.Server.URL)
+	return mc, fake, sender
+}
+
+// resync posts body to the resync endpoint.
+func resync(mc *MattermostConnector, method, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/resync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mc.HandleResync(w, req)
+	return w
+}
+
+func TestHandleResync_Validation(t *testing.T) {
+	t.Parallel()
+	mc, _, _ := newResyncTestConnector(t)
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"invalid JSON", http.MethodPost, "{", http.StatusBadRequest},
+		{"neither", http.MethodPost, `{}`, http.StatusBadRequest},
+		{"both", http.MethodPost, `{"channel_id":"` + relayTestChannel + `","all":true}`, http.StatusBadRequest},
+		{"invalid channel ID", http.MethodPost, `{"channel_id":"nope"}`, http.StatusBadRequest},
+		{"no portal room", http.MethodPost, `{"channel_id":"` + relayTestChannel2 + `"}`, http.StatusNotFound},
+		{"unknown login", http.MethodPost, `{"all":true,"login_id":"nobody"}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if w := resync(mc, tt.method, tt.body); w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleResync_Channel(t *testing.T) {
+	t.Parallel()
+	mc, fake, sender := newResyncTestConnector(t)
+	mc.Config.Backfill = BackfillConfig{MissedLimit: 50}
+	fake.Pinned[relayTestChannel] = &model.PostList{Order: []string{"p1", "p2"}}
+
+	w := resync(mc, http.MethodPost, `{"channel_id":"`+relayTestChannel+`"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Channels []ResyncResult `json:"channels"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := ResyncResult{ChannelID: relayTestChannel, RoomID: "!" + relayTestChannel + ":example.com", Pinned: 2}
+	if len(resp.Channels) != 1 || resp.Channels[0] != want {
+		t.Fatalf("channels = %+v, want [%+v]", resp.Channels, want)
+	}
+
+	events := sender.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected one queued event, got %d", len(events))
+	}
+	evt, ok := events[0].(*simplevent.ChatResync)
+	if !ok {
+		t.Fatalf("queued %T, want ChatResync", events[0])
+	}
+	if evt.PortalKey != makePortalKey(relayTestChannel) || evt.CreatePortal {
+		t.Errorf("resync portal = %v, create = %v", evt.PortalKey, evt.CreatePortal)
+	}
+	if evt.ChatInfo == nil || evt.ChatInfo.Members == nil || evt.ChatInfo.ExtraUpdates == nil {
+		t.Fatalf("resync is missing chat info: %+v", evt.ChatInfo)
+	}
+	latest := &database.Message{Timestamp: time.UnixMilli(5000)}
+	if evt.CheckNeedsBackfillFunc == nil {
+		t.Fatal("resync doesn't check for missed posts")
+	}
+	if needs, _ := evt.CheckNeedsBackfillFunc(context.Background(), latest); !needs {
+		t.Error("resync skipped the missed post check")
+	}
+}
+
+func TestHandleResync_All(t *testing.T) {
+	t.Parallel()
+	mc, fake, sender := newResyncTestConnector(t)
+	fake.FailEndpoints["/channels/"+relayTestChannel+"/pinned"] = true
+
+	w := resync(mc, http.MethodPost, `{"all":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Channels []ResyncResult `json:"channels"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	// Only relayTestChannel has a portal room.
+	if len(resp.Channels) != 1 || resp.Channels[0].ChannelID != relayTestChannel {
+		t.Fatalf("channels = %+v", resp.Channels)
+	}
+	if !strings.Contains(resp.Channels[0].Error, "Failed to get pinned posts") {
+		t.Errorf("error = %q", resp.Channels[0].Error)
+	}
+	if n := len(sender.Events()); n != 0 {
+		t.Errorf("queued %d events for a failed channel", n)
+	}
+}
+
+func TestPinnedUpdater(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	bot := &fakeMatrixBot{}
+	mc.Bridge.Bot = bot
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	if err := mc.Bridge.DB.Message.Insert(ctx, &database.Message{
+		ID:        MakeMessageID("p1"),
+		MXID:      "$p1:example.com",
+		Room:      portal.PortalKey,
+		SenderID:  MakeUserID("u1"),
+		Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	client := newFullTestClient("http://localhost")
+	client.connector = mc
+
+	// p2 was never bridged, so only p1 is pinned.
+	update := client.pinnedUpdater(relayTestChannel, []string{"p1", "p2"})
+	if !update(ctx, portal) {
+		t.Fatal("first update changed nothing")
+	}
+	states := bot.States()
+	if len(states) != 1 || states[0].Type != event.StatePinnedEvents {
+		t.Fatalf("states = %+v", states)
+	}
+	content, _ := states[0].Content.Parsed.(*event.PinnedEventsEventContent)
+	if content == nil || len(content.Pinned) != 1 || content.Pinned[0] != "$p1:example.com" {
+		t.Errorf("pinned = %+v", states[0].Content.Parsed)
+	}
+	if got := portalMetadata(portal).PinnedEvents; len(got) != 1 || got[0] != id.EventID("$p1:example.com") {
+		t.Errorf("metadata pinned = %v", got)
+	}
+
+	if update(ctx, portal) {
+		t.Error("unchanged pins were set again")
+	}
+	if !client.pinnedUpdater(relayTestChannel, nil)(ctx, portal) {
+		t.Error("unpinning everything changed nothing")
+	}
+	if states := bot.States(); len(states) != 2 {
+		t.Errorf("expected 2 state events, got %d", len(states))
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// RoomNameParams holds the parameters for rendering the room name and alias
+// templates.
+type RoomNameParams struct {
+	ChannelID string
+	// ChannelName is the channel's URL name, unique in its team.
+	ChannelName string
+	// DisplayName is the channel's display name, or its URL name if it has
+	// none.
+	DisplayName string
+	// TeamName and TeamDisplayName are empty if the team can't be looked
+	// up.
+	TeamName        string
+	TeamDisplayName string
+}
+
+// FormatRoomName renders the room name template. Without a template, or if
+// rendering fails or gives nothing, it falls back to the display name.
+func (c *Config) FormatRoomName(params RoomNameParams) string {
+	if c.roomNameTemplate == nil {
+		return params.DisplayName
+	}
+	var buf []byte
+	if err := c.roomNameTemplate.Execute((*templateBuffer)(&buf), params); err != nil {
+		return params.DisplayName
+	}
+	if name := strings.TrimSpace(string(buf)); name != "" {
+		return name
+	}
+	return params.DisplayName
+}
+
+// FormatRoomAlias renders the room alias template into an alias localpart.
+// Returns "" if no template is configured or rendering fails or gives
+// nothing.
+func (c *Config) FormatRoomAlias(params RoomNameParams) string {
+	if c.roomAliasTemplate == nil {
+		return ""
+	}
+	var buf []byte
+	if err := c.roomAliasTemplate.Execute((*templateBuffer)(&buf), params); err != nil {
+		return ""
+	}
+	return aliasLocalpart(string(buf))
+}
+
+// aliasLocalpart lowercases s and replaces what isn't allowed in a room
+// alias localpart with underscores.
+func aliasLocalpart(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', strings.ContainsRune("._=-/+", r):
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// roomNameParams builds the room name template parameters for a channel.
+// The team is looked up, and cached, if there is a client.
+func (m *MattermostClient) roomNameParams(ctx context.Context, channel *model.Channel) RoomNameParams {
+	params := RoomNameParams{
+		ChannelID:   channel.Id,
+		ChannelName: channel.Name,
+		DisplayName: channel.DisplayName,
+	}
+	if params.DisplayName == "" {
+		params.DisplayName = channel.Name
+	}
+	if channel.TeamId != "" && m.client != nil {
+		if team, ok := m.lookupTeam(ctx, channel.TeamId); ok {
+			params.TeamName = team.Name
+			params.TeamDisplayName = team.DisplayName
+		}
+	}
+	return params
+}
+
+// roomNameClaim is a portal room holding a name. Claims on the same name
+// are ranked by channel creation time, then portal ID: the older channel
+// wins.
+type roomNameClaim struct {
+	portalID networkid.PortalID
+	// createAt is the channel's creation time, or 0 if unknown, for rooms
+	// loaded from the database whose channel wasn't seen since. Claims of
+	// unknown rank are never displaced and never displace others.
+	createAt int64
+}
+
+// before reports whether c keeps a name that other also wants.
+func (c roomNameClaim) before(other roomNameClaim) bool {
+	switch {
+	case c.createAt == 0 || other.createAt == 0:
+		return true
+	case c.createAt != other.createAt:
+		return c.createAt < other.createAt
+	}
+	return c.portalID < other.portalID
+}
+
+// roomNameCandidates returns the room name of a team channel, and the
+// suffixes that set it apart when another portal room holds it: the
+// channel's URL name, then the team and channel names, then the channel ID.
+func (m *MattermostClient) roomNameCandidates(ctx context.Context, channel *model.Channel) (string, []string) {
+	params := m.roomNameParams(ctx, channel)
+	name := m.connector.Config.FormatRoomName(params)
+	suffixes := []string{params.ChannelName}
+	if params.TeamName != "" {
+		suffixes = append(suffixes, params.TeamName+"/"+params.ChannelName)
+	}
+	suffixes = append(suffixes, channel.Id)
+	return name, suffixes
+}
+
+// roomName returns the room name of a team channel, without claiming it:
+// names are only claimed when the room is created or updated, by
+// roomNameClaimer.
+func (m *MattermostClient) roomName(ctx context.Context, channel *model.Channel) string {
+	name, suffixes := m.roomNameCandidates(ctx, channel)
+	m.connector.roomNamesMu.Lock()
+	defer m.connector.roomNamesMu.Unlock()
+	candidate, _ := m.connector.pickRoomName(ctx, roomNameClaim{portalID: MakePortalID(channel.Id), createAt: channel.CreateAt}, name, suffixes)
+	return candidate
+}
+
+// roomNameClaimer returns a ChatInfo.ExtraUpdates hook that claims the room
+// name of a team channel's portal, or nil for DMs and group DMs. It runs
+// when the room is created or its info updated, so channels without a room
+// hold no names. A room being created that lost its name to another room
+// in the meantime is created with the next free one. A newer channel's
+// room displaced by this one is resynced, to take a suffix.
+func (m *MattermostClient) roomNameClaimer(channel *model.Channel) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	if channel.Type == model.ChannelTypeDirect || channel.Type == model.ChannelTypeGroup {
+		return nil
+	}
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		name, suffixes := m.roomNameCandidates(ctx, channel)
+		claimed, displaced := m.connector.claimRoomName(ctx, roomNameClaim{portalID: portal.ID, createAt: channel.CreateAt}, name, suffixes)
+		if displaced != "" {
+			m.resyncRoomName(displaced)
+		}
+		if portal.MXID != "" || portal.Name == claimed {
+			return false
+		}
+		portal.Name = claimed
+		return true
+	}
+}
+
+// resyncRoomName queues a resync of a portal whose room name was claimed by
+// an older channel, so the room gets a new one.
+func (m *MattermostClient) resyncRoomName(portalID networkid.PortalID) {
+	channelID := ParsePortalID(portalID)
+	m.log.Debug().Str("channel_id", channelID).Msg("Room name claimed by an older channel, resyncing")
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatResync{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatResync,
+			PortalKey: makePortalKey(channelID),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("channel_id", channelID)
+			},
+		},
+		GetChatInfoFunc: m.GetChatInfo,
+	})
+}
+
+// claimRoomName picks a room name like pickRoomName and holds it for the
+// claim's portal, releasing the portal's other names. It returns the name,
+// and the portal that held it before, if any. Names are loaded from the
+// database on first use, so rooms keep their names across restarts.
+func (mc *MattermostConnector) claimRoomName(ctx context.Context, claim roomNameClaim, name string, suffixes []string) (string, networkid.PortalID) {
+	mc.roomNamesMu.Lock()
+	defer mc.roomNamesMu.Unlock()
+	candidate, displaced := mc.pickRoomName(ctx, claim, name, suffixes)
+	for held, holder := range mc.roomNames {
+		if holder.portalID == claim.portalID && held != candidate {
+			delete(mc.roomNames, held)
+		}
+	}
+	mc.roomNames[candidate] = claim
+	return candidate, displaced
+}
+
+// pickRoomName returns the first of name and name with each suffix that no
+// other portal room holds, or that is held by a portal the claim ranks
+// before, along with that portal. roomNamesMu must be held.
+func (mc *MattermostConnector) pickRoomName(ctx context.Context, claim roomNameClaim, name string, suffixes []string) (string, networkid.PortalID) {
+	if mc.roomNames == nil {
+		mc.roomNames = mc.loadRoomNames(ctx)
+	}
+	candidate := name
+	for i := 0; ; i++ {
+		holder, ok := mc.roomNames[candidate]
+		switch {
+		case !ok || holder.portalID == claim.portalID:
+			return candidate, ""
+		case !holder.before(claim):
+			return candidate, holder.portalID
+		case i == len(suffixes):
+			return candidate, ""
+		}
+		candidate = fmt.Sprintf("%s (%s)", name, suffixes[i])
+	}
+}
+
+// loadRoomNames returns the names of the existing channel portal rooms.
+func (mc *MattermostConnector) loadRoomNames(ctx context.Context) map[string]roomNameClaim {
+	names := make(map[string]roomNameClaim)
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return names
+	}
+	portals, err := mc.Bridge.GetAllPortalsWithMXID(ctx)
+	if err != nil {
+		mc.Bridge.Log.Warn().Err(err).Msg("Failed to load room names")
+		return names
+	}
+	for _, portal := range portals {
+		if portal.RoomType == database.RoomTypeDefault && portal.Name != "" {
+			names[portal.Name] = roomNameClaim{portalID: portal.ID}
+		}
+	}
+	return names
+}
+
+// roomAliasAPI creates and resolves Matrix room aliases.
+// *appservice.IntentAPI implements it.
+type roomAliasAPI interface {
+	aliasAPI
+	CreateAlias(ctx context.Context, alias id.RoomAlias, roomID id.RoomID) (*mautrix.RespAliasCreate, error)
+}
+
+// matrixRoomAliasAPI returns the bridge bot's alias API, or nil.
+func (mc *MattermostConnector) matrixRoomAliasAPI() roomAliasAPI {
+	if conn, ok := mc.Bridge.Matrix.(*matrix.Connector); ok && conn.Bot != nil {
+		return conn.Bot
+	}
+	if api, ok := mc.Bridge.Bot.(roomAliasAPI); ok {
+		return api
+	}
+	return nil
+}
+
+// setRoomAlias points alias at a room, unless it already does, and makes it
+// the room's canonical alias. An alias held by another room is left alone.
+func (mc *MattermostConnector) setRoomAlias(ctx context.Context, roomID id.RoomID, alias id.RoomAlias) error {
+	api := mc.matrixRoomAliasAPI()
+	if api == nil {
+		return errNoAliasAPI
+	}
+	resp, err := api.ResolveAlias(ctx, alias)
+	switch {
+	case errors.Is(err, mautrix.MNotFound):
+		if _, err = api.CreateAlias(ctx, alias, roomID); err != nil {
+			return fmt.Errorf("failed to create alias: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to resolve alias: %w", err)
+	case resp.RoomID != roomID:
+		return fmt.Errorf("alias already points to %s", resp.RoomID)
+	}
+	content := &event.CanonicalAliasEventContent{Alias: alias}
+	_, err = mc.Bridge.Bot.SendState(ctx, roomID, event.StateCanonicalAlias, "", &event.Content{Parsed: content}, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to set canonical alias: %w", err)
+	}
+	return nil
+}
+
+// roomAliasUpdater returns a ChatInfo.ExtraUpdates hook that gives a team
+// channel's portal room the alias from room_alias_template, or nil if none
+// is configured. The alias set is tracked in the portal metadata, so it's
+// only set again when the channel or team is renamed; old aliases are kept,
+// so links to them keep working.
+func (m *MattermostClient) roomAliasUpdater(channel *model.Channel) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	if m.connector.Config.roomAliasTemplate == nil || channel.Type == model.ChannelTypeDirect || channel.Type == model.ChannelTypeGroup {
+		return nil
+	}
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		if portal.MXID == "" {
+			return false
+		}
+		localpart := m.connector.Config.FormatRoomAlias(m.roomNameParams(ctx, channel))
+		if localpart == "" {
+			return false
+		}
+		alias := id.NewRoomAlias(localpart, portal.Bridge.Matrix.ServerName())
+		meta := portalMetadata(portal)
+		if meta.Alias == alias {
+			return false
+		}
+		if err := m.connector.setRoomAlias(ctx, portal.MXID, alias); err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channel.Id).Stringer("alias", alias).Msg("Failed to set room alias")
+			return false
+		}
+		m.log.Debug().Str("channel_id", channel.Id).Stringer("alias", alias).Msg("Set room alias")
+		meta.Alias = alias
+		return true
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestFormatRoomName(t *testing.T) {
+	t.Parallel()
+	params := RoomNameParams{ChannelName: "town-square", DisplayName: "Town Square", TeamName: "eng", TeamDisplayName: "Engineering"}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"", "Town Square"},
+		{"{{.TeamDisplayName}} / {{.DisplayName}}", "Engineering / Town Square"},
+		{"{{if .Missing}}x{{end}}", "Town Square"},
+		{"  ", "Town Square"},
+	}
+	for _, tt := range tests {
+		cfg := Config{RoomNameTemplate: tt.template}
+		if err := cfg.PostProcess(); err != nil {
+			t.Fatalf("PostProcess(%q): %v", tt.template, err)
+		}
+		if got := cfg.FormatRoomName(params); got != tt.want {
+			t.Errorf("FormatRoomName(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+
+	cfg := Config{RoomNameTemplate: "{{.Broken"}
+	if err := cfg.PostProcess(); err == nil {
+		t.Error("expected error for invalid room_name_template")
+	}
+}
+
+func TestFormatRoomAlias(t *testing.T) {
+	t.Parallel()
+	params := RoomNameParams{ChannelName: "town-square", DisplayName: "Town Square", TeamName: "eng"}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"", ""},
+		{"mattermost_{{.TeamName}}_{{.ChannelName}}", "mattermost_eng_town-square"},
+		{"mattermost {{.DisplayName}}", "mattermost_town_square"},
+	}
+	for _, tt := range tests {
+		cfg := Config{RoomAliasTemplate: tt.template}
+		if err := cfg.PostProcess(); err != nil {
+			t.Fatalf("PostProcess(%q): %v", tt.template, err)
+		}
+		if got := cfg.FormatRoomAlias(params); got != tt.want {
+			t.Errorf("FormatRoomAlias(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestClaimRoomName(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost").connector
+	ctx := context.Background()
+	suffixes := []string{"general", "eng/general", "ch2"}
+
+	claims := []struct {
+		portal        string
+		createAt      int64
+		name          string
+		want          string
+		wantDisplaced string
+	}{
+		{"ch1", 100, "General", "General", ""},
+		{"ch1", 100, "General", "General", ""},
+		{"ch2", 200, "General", "General (general)", ""},
+		{"ch3", 300, "General", "General (eng/general)", ""},
+		{"ch4", 400, "General", "General (ch2)", ""},
+		// ch1 is renamed, freeing its name for the next channel to claim it.
+		{"ch1", 100, "Town", "Town", ""},
+		{"ch2", 200, "General", "General", ""},
+		// An older channel takes the name from a newer one.
+		{"ch0", 50, "Town", "Town", "ch1"},
+		// The same creation time falls back to the portal ID.
+		{"ch5", 200, "General", "General (general)", ""},
+		{"ch11", 100, "General", "General", "ch2"},
+	}
+	for i, c := range claims {
+		got, displaced := mc.claimRoomName(ctx, roomNameClaim{portalID: MakePortalID(c.portal), createAt: c.createAt}, c.name, suffixes)
+		if got != c.want || displaced != MakePortalID(c.wantDisplaced) {
+			t.Errorf("claim %d (%s): got %q displacing %q, want %q displacing %q", i, c.portal, got, displaced, c.want, c.wantDisplaced)
+		}
+	}
+	if holder := mc.roomNames["General (general)"]; holder.portalID != MakePortalID("ch5") {
+		t.Errorf("General (general) is held by %q, want ch5", holder.portalID)
+	}
+}
+
+func TestClaimRoomName_LoadsExistingRooms(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	ctx := context.Background()
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	portal.Name = "General"
+	if err := portal.Save(ctx); err != nil {
+		t.Fatalf("save portal: %v", err)
+	}
+
+	// The existing room's channel wasn't seen yet, so it keeps its name even
+	// against an older channel.
+	got, displaced := mc.claimRoomName(ctx, roomNameClaim{portalID: MakePortalID(relayTestChannel2), createAt: 1}, "General", []string{"general"})
+	if got != "General (general)" || displaced != "" {
+		t.Errorf("got %q displacing %q, want the existing room to keep its name", got, displaced)
+	}
+}
+
+func TestRoomNameClaimer(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	ctx := context.Background()
+	older := &model.Channel{Id: "ch1", Name: "general", DisplayName: "General", Type: model.ChannelTypeOpen, CreateAt: 100}
+	newer := &model.Channel{Id: "ch2", Name: "general-2", DisplayName: "General", Type: model.ChannelTypeOpen, CreateAt: 200}
+
+	// Looking up a name doesn't hold it.
+	if got := mc.roomName(ctx, newer); got != "General" {
+		t.Fatalf("roomName = %q", got)
+	}
+	if len(mc.connector.roomNames) != 0 {
+		t.Fatalf("roomName claimed %v", mc.connector.roomNames)
+	}
+
+	// The newer channel's room is created first and claims the name.
+	newerPortal := makeTestPortal("ch2")
+	newerPortal.Name = "General"
+	if mc.roomNameClaimer(newer)(ctx, newerPortal) {
+		t.Fatal("claiming a free name shouldn't change the portal")
+	}
+	newerPortal.MXID = "!newer:example.com"
+	if got := mc.roomName(ctx, older); got != "General" {
+		t.Fatalf("older channel's roomName = %q, want it to win the name", got)
+	}
+
+	// The older channel's room, being created under a name picked before,
+	// takes it and gets the newer room resynced.
+	olderPortal := makeTestPortal("ch1")
+	olderPortal.Name = "General (general)"
+	if !mc.roomNameClaimer(older)(ctx, olderPortal) || olderPortal.Name != "General" {
+		t.Errorf("older portal name = %q, want General", olderPortal.Name)
+	}
+	events := testMock(mc).Events()
+	if len(events) != 1 || events[0].GetType() != bridgev2.RemoteEventChatResync || events[0].GetPortalKey().ID != MakePortalID("ch2") {
+		t.Errorf("events = %+v, want a resync of the newer room", events)
+	}
+	if got := mc.roomName(ctx, newer); got != "General (general-2)" {
+		t.Errorf("newer channel's roomName = %q", got)
+	}
+
+	if mc.roomNameClaimer(&model.Channel{Id: "dm", Type: model.ChannelTypeDirect}) != nil {
+		t.Error("DMs shouldn't claim room names")
+	}
+}
+
+// fakeRoomAliasBot is a bridge bot with an alias directory that records
+// canonical alias changes.
+type fakeRoomAliasBot struct {
+	bridgev2.MatrixAPI
+	mu        sync.Mutex
+	aliases   map[id.RoomAlias]id.RoomID
+	canonical map[id.RoomID]id.RoomAlias
+}
+
+func (b *fakeRoomAliasBot) ResolveAlias(_ context.Context, alias id.RoomAlias) (*mautrix.RespAliasResolve, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	roomID, ok := b.aliases[alias]
+	if !ok {
+		return nil, mautrix.MNotFound
+	}
+	return &mautrix.RespAliasResolve{RoomID: roomID}, nil
+}
+
+func (b *fakeRoomAliasBot) CreateAlias(_ context.Context, alias id.RoomAlias, roomID id.RoomID) (*mautrix.RespAliasCreate, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.aliases[alias] = roomID
+	return &mautrix.RespAliasCreate{}, nil
+}
+
+func (b *fakeRoomAliasBot) SendState(_ context.Context, roomID id.RoomID, evtType event.Type, _ string, content *event.Content, _ time.Time) (*mautrix.RespSendEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if evtType == event.StateCanonicalAlias {
+		b.canonical[roomID] = content.Parsed.(*event.CanonicalAliasEventContent).Alias
+	}
+	return &mautrix.RespSendEvent{}, nil
+}
+
+func TestRoomAliasUpdater(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}, relayTestChannel2: {}})
+	mc.Config.RoomAliasTemplate = "mattermost_{{.ChannelName}}"
+	if err := mc.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	bot := &fakeRoomAliasBot{
+		aliases:   map[id.RoomAlias]id.RoomID{"#mattermost_taken:example.com": "!other:example.com"},
+		canonical: make(map[id.RoomID]id.RoomAlias),
+	}
+	mc.Bridge.Bot = bot
+	client := newTestClient()
+	client.connector = mc
+
+	portal := getRelayTestPortal(t, mc, relayTestChannel)
+	update := client.roomAliasUpdater(&model.Channel{Id: relayTestChannel, Name: "general", Type: model.ChannelTypeOpen})
+	if !update(ctx, portal) {
+		t.Fatal("first update should set the alias")
+	}
+	want := id.RoomAlias("#mattermost_general:example.com")
+	if bot.aliases[want] != portal.MXID || bot.canonical[portal.MXID] != want || portalMetadata(portal).Alias != want {
+		t.Errorf("alias not set: aliases %v, canonical %v, meta %q", bot.aliases, bot.canonical, portalMetadata(portal).Alias)
+	}
+	if update(ctx, portal) {
+		t.Error("second update should change nothing")
+	}
+
+	taken := getRelayTestPortal(t, mc, relayTestChannel2)
+	update = client.roomAliasUpdater(&model.Channel{Id: relayTestChannel2, Name: "taken", Type: model.ChannelTypeOpen})
+	if update(ctx, taken) {
+		t.Error("an alias held by another room should be left alone")
+	}
+	if bot.aliases["#mattermost_taken:example.com"] != "!other:example.com" {
+		t.Error("the other room's alias should be kept")
+	}
+
+	if client.roomAliasUpdater(&model.Channel{Id: "dm", Type: model.ChannelTypeDirect}) != nil {
+		t.Error("DMs should get no alias")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// SavedPostsConfig controls the bridging of saved posts, which the
+// Mattermost API calls flagged posts.
+type SavedPostsConfig struct {
+	// Enabled marks the Matrix events of posts a double-puppeted user saves
+	// in Mattermost in their room account data, and saves the posts they
+	// react to with Reaction.
+	Enabled bool `yaml:"enabled"`
+	// Reaction is the Matrix reaction that saves a post; removing it
+	// unsaves the post. Empty uses 🔖.
+	Reaction string `yaml:"reaction"`
+}
+
+// defaultSavedPostReaction is used when saved_posts.reaction is unset.
+const defaultSavedPostReaction = "🔖"
+
+// reaction returns the Matrix reaction that saves a post.
+func (c *SavedPostsConfig) reaction() string {
+	if c.Reaction == "" {
+		return defaultSavedPostReaction
+	}
+	return c.Reaction
+}
+
+// savedPostEmojiID is the emoji ID of reactions that save a post. It isn't
+// a valid Mattermost emoji name, so it can't clash with a real reaction.
+const savedPostEmojiID networkid.EmojiID = "fi.mau.mattermost.saved_post"
+
+// AccountDataSavedPosts is the room account data listing the events of the
+// posts the user saved in Mattermost.
+var AccountDataSavedPosts = event.Type{Type: "fi.mau.mattermost.saved_posts", Class: event.AccountDataEventType}
+
+// SavedPostsEventContent is the content of AccountDataSavedPosts.
+type SavedPostsEventContent struct {
+	EventIDs []id.EventID `json:"event_ids"`
+}
+
+// isSavedPostReaction reports whether a Matrix reaction saves the post it
+// reacts to instead of being sent as a reaction.
+func (c *Config) isSavedPostReaction(key string) bool {
+	return c.SavedPosts.Enabled && key == c.SavedPosts.reaction()
+}
+
+// setPostSaved saves or unsaves a post for the login's user.
+func (m *MattermostClient) setPostSaved(ctx context.Context, postID string, saved bool) error {
+	prefs := model.Preferences{{
+		UserId:   m.userID,
+		Category: model.PreferenceCategoryFlaggedPost,
+		Name:     postID,
+		Value:    "true",
+	}}
+	if saved {
+		resp, err := m.client.UpdatePreferences(ctx, m.userID, prefs)
+		if err != nil {
+			return apiError("failed to save post", resp, err)
+		}
+	} else {
+		resp, err := m.client.DeletePreferences(ctx, m.userID, prefs)
+		if err != nil {
+			return apiError("failed to unsave post", resp, err)
+		}
+	}
+	m.log.Debug().Str("post_id", postID).Bool("saved", saved).Msg("Updated saved post from reaction")
+	return nil
+}
+
+// roomAccountDataAPI is the part of a Matrix client needed to edit room
+// account data. *appservice.IntentAPI implements it.
+type roomAccountDataAPI interface {
+	GetRoomAccountData(ctx context.Context, roomID id.RoomID, name string, output any) error
+	SetRoomAccountData(ctx context.Context, roomID id.RoomID, name string, data any) error
+}
+
+// roomAccountDataAPIFor returns the account data API behind a double puppet
+// intent, or nil if the intent doesn't support account data.
+func roomAccountDataAPIFor(intent bridgev2.MatrixAPI) roomAccountDataAPI {
+	switch typed := intent.(type) {
+	case *matrix.ASIntent:
+		if typed.Matrix != nil {
+			return typed.Matrix
+		}
+	case roomAccountDataAPI:
+		return typed
+	}
+	return nil
+}
+
+// handlePreferencesChanged bridges the posts the login's user saved or
+// unsaved in Mattermost to the saved posts account data of their double
+// puppet. Mattermost only sends preference events to their user's own
+// connections, and other preference categories are ignored.
+func (m *MattermostClient) handlePreferencesChanged(evt *model.WebSocketEvent, saved bool) {
+	if !m.connector.Config.SavedPosts.Enabled || m.userLogin == nil {
+		return
+	}
+	prefsJSON, ok := evt.GetData()["preferences"].(string)
+	if !ok {
+		return
+	}
+	var prefs model.Preferences
+	if err := json.Unmarshal([]byte(prefsJSON), &prefs); err != nil {
+		m.log.Warn().Err(err).Msg("Failed to parse preferences event")
+		return
+	}
+	ctx := m.log.WithContext(context.Background())
+	for _, pref := range prefs {
+		if pref.Category != model.PreferenceCategoryFlaggedPost || pref.UserId != m.userID {
+			continue
+		}
+		if err := m.markSavedPost(ctx, pref.Name, saved && pref.Value == "true"); err != nil {
+			m.log.Warn().Err(err).Str("post_id", pref.Name).Msg("Failed to bridge saved post")
+		}
+	}
+}
+
+// markSavedPost adds or removes the Matrix event of a post in the saved
+// posts account data of the login's user in the post's portal. Posts that
+// weren't bridged, and users without a double puppet, are skipped.
+func (m *MattermostClient) markSavedPost(ctx context.Context, postID string, saved bool) error {
+	api := roomAccountDataAPIFor(m.userLogin.User.DoublePuppet(ctx))
+	if api == nil {
+		return nil
+	}
+	msg, err := m.connector.Bridge.DB.Message.GetFirstPartByID(ctx, "", MakeMessageID(postID))
+	if err != nil {
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+	if msg == nil || !m.connector.OwnsChannel(ParsePortalID(msg.Room.ID)) {
+		return nil
+	}
+	portal, err := m.connector.Bridge.GetExistingPortalByKey(ctx, msg.Room)
+	if err != nil {
+		return fmt.Errorf("failed to get portal: %w", err)
+	}
+	if portal == nil || portal.MXID == "" {
+		return nil
+	}
+
+	m.connector.savedPostsMu.Lock()
+	defer m.connector.savedPostsMu.Unlock()
+	var content SavedPostsEventContent
+	err = api.GetRoomAccountData(ctx, portal.MXID, AccountDataSavedPosts.Type, &content)
+	if err != nil && !errors.Is(err, mautrix.MNotFound) {
+		return fmt.Errorf("failed to get saved posts: %w", err)
+	}
+	has := slices.Contains(content.EventIDs, msg.MXID)
+	if has == saved {
+		return nil
+	}
+	if saved {
+		content.EventIDs = append(content.EventIDs, msg.MXID)
+	} else {
+		content.EventIDs = slices.DeleteFunc(content.EventIDs, func(evtID id.EventID) bool { return evtID == msg.MXID })
+	}
+	if err := api.SetRoomAccountData(ctx, portal.MXID, AccountDataSavedPosts.Type, &content); err != nil {
+		return fmt.Errorf("failed to set saved posts: %w", err)
+	}
+	m.log.Debug().
+		Str("post_id", postID).
+		Stringer("event_id", msg.MXID).
+		Bool("saved", saved).
+		Msg("Bridged saved post")
+	return nil
+}
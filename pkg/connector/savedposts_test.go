@@ -0,0 +1,216 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// fakeAccountDataIntent is a double puppet intent that stores room account
+// data.
+type fakeAccountDataIntent struct {
+	bridgev2.MatrixAPI
+	mu   sync.Mutex
+	data map[id.RoomID]map[string]json.RawMessage
+}
+
+func (f *fakeAccountDataIntent) GetRoomAccountData(_ context.Context, roomID id.RoomID, name string, output any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	raw, ok := f.data[roomID][name]
+	if !ok {
+		return mautrix.MNotFound
+	}
+	return json.Unmarshal(raw, output)
+}
+
+func (f *fakeAccountDataIntent) SetRoomAccountData(_ context.Context, roomID id.RoomID, name string, data any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if f.data[roomID] == nil {
+		f.data[roomID] = make(map[string]json.RawMessage)
+	}
+	f.data[roomID][name] = raw
+	return nil
+}
+
+// savedPosts returns the saved posts account data of a room.
+func (f *fakeAccountDataIntent) savedPosts(t *testing.T, roomID id.RoomID) []id.EventID {
+	t.Helper()
+	var content SavedPostsEventContent
+	if err := f.GetRoomAccountData(context.Background(), roomID, AccountDataSavedPosts.Type, &content); err != nil {
+		return nil
+	}
+	return content.EventIDs
+}
+
+// accountDataMatrixConnector is a nopMatrixConnector whose double puppets
+// all use intent.
+type accountDataMatrixConnector struct {
+	nopMatrixConnector
+	intent bridgev2.MatrixAPI
+}
+
+func (c accountDataMatrixConnector) NewUserIntent(_ context.Context, _ id.UserID, token string) (bridgev2.MatrixAPI, string, error) {
+	return c.intent, token, nil
+}
+
+// preferencesEvent returns a preferences_changed or preferences_deleted
+// event with prefs.
+func preferencesEvent(t *testing.T, evtType model.WebsocketEventType, prefs model.Preferences) *model.WebSocketEvent {
+	t.Helper()
+	raw, err := json.Marshal(prefs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evt := model.NewWebSocketEvent(evtType, "", "", "me-id", nil, "")
+	evt.Add("preferences", string(raw))
+	return evt
+}
+
+func TestHandlePreferencesChanged_SavedPosts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{"ch1": {}})
+	intent := &fakeAccountDataIntent{data: make(map[id.RoomID]map[string]json.RawMessage)}
+	mc.Bridge.Matrix = accountDataMatrixConnector{intent: intent}
+	mc.Config.SavedPosts.Enabled = true
+	for _, postID := range []string{"p1", "p2"} {
+		if err := mc.Bridge.DB.Message.Insert(ctx, &database.Message{
+			ID:        MakeMessageID(postID),
+			MXID:      id.EventID("$" + postID + ":example.com"),
+			Room:      makePortalKey("ch1"),
+			SenderID:  MakeUserID("alice-id"),
+			Timestamp: time.UnixMilli(1000),
+		}); err != nil {
+			t.Fatalf("insert message: %v", err)
+		}
+	}
+	login, err := mc.Bridge.GetExistingUserLoginByID(ctx, MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	client := login.Client.(*MattermostClient)
+	client.userID = "me-id"
+	const room = id.RoomID("!ch1:example.com")
+	flag := func(userID, postID string) *model.Preference {
+		return &model.Preference{UserId: userID, Category: model.PreferenceCategoryFlaggedPost, Name: postID, Value: "true"}
+	}
+
+	client.handleEvent(preferencesEvent(t, model.WebsocketEventPreferencesChanged, model.Preferences{
+		*flag("me-id", "p1"),
+		*flag("me-id", "p2"),
+		*flag("me-id", "unbridged"),
+		*flag("other-id", "p2"),
+		{UserId: "me-id", Category: model.PreferenceCategoryDisplaySettings, Name: "p2", Value: "true"},
+	}))
+	if got := intent.savedPosts(t, room); len(got) != 2 || got[0] != "$p1:example.com" || got[1] != "$p2:example.com" {
+		t.Fatalf("saved posts = %v, want $p1 and $p2", got)
+	}
+
+	client.handleEvent(preferencesEvent(t, model.WebsocketEventPreferencesDeleted, model.Preferences{*flag("me-id", "p1")}))
+	if got := intent.savedPosts(t, room); len(got) != 1 || got[0] != "$p2:example.com" {
+		t.Errorf("saved posts = %v, want $p2", got)
+	}
+
+	mc.Config.SavedPosts.Enabled = false
+	client.handleEvent(preferencesEvent(t, model.WebsocketEventPreferencesChanged, model.Preferences{*flag("me-id", "p1")}))
+	if got := intent.savedPosts(t, room); len(got) != 1 {
+		t.Errorf("saved posts changed while disabled: %v", got)
+	}
+}
+
+func TestHandleMatrixReaction_SavedPost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		enabled   bool
+		reaction  string
+		key       string
+		wantSaved bool
+	}{
+		{"default reaction", true, "", "🔖", true},
+		{"configured reaction", true, "⭐", "⭐", true},
+		{"other reaction", true, "", "👍", false},
+		{"disabled", false, "", "🔖", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := newFakeMM()
+			t.Cleanup(fm.Close)
+			mc := newFullTestClient(fm.Server.URL)
+			mc.connector.Config.SavedPosts = SavedPostsConfig{Enabled: tt.enabled, Reaction: tt.reaction}
+			ctx := context.Background()
+
+			msg := &bridgev2.MatrixReaction{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.ReactionEventContent]{
+					Portal:  makeTestPortal("test-channel"),
+					Content: &event.ReactionEventContent{RelatesTo: event.RelatesTo{Key: tt.key}},
+				},
+				TargetMessage: &database.Message{ID: MakeMessageID("target-post")},
+			}
+			pre, err := mc.PreHandleMatrixReaction(ctx, msg)
+			if err != nil {
+				t.Fatalf("PreHandleMatrixReaction: %v", err)
+			}
+			msg.PreHandleResp = &pre
+			reaction, err := mc.HandleMatrixReaction(ctx, msg)
+			if err != nil {
+				t.Fatalf("HandleMatrixReaction: %v", err)
+			}
+			prefsPath := "/api/v4/users/my-user-id/preferences"
+			if got := fm.CalledPath(prefsPath); got != tt.wantSaved {
+				t.Errorf("saved = %v, want %v", got, tt.wantSaved)
+			}
+			if got := fm.CalledPath("/api/v4/reactions"); got == tt.wantSaved {
+				t.Errorf("reaction sent = %v, want %v", got, !tt.wantSaved)
+			}
+			if !tt.wantSaved {
+				return
+			}
+			if reaction.EmojiID != savedPostEmojiID {
+				t.Errorf("EmojiID = %q, want %q", reaction.EmojiID, savedPostEmojiID)
+			}
+			for _, call := range fm.Calls() {
+				if call.Path == prefsPath && (!strings.Contains(call.Body, `"category":"flagged_post"`) || !strings.Contains(call.Body, `"name":"target-post"`)) {
+					t.Errorf("preferences body = %s", call.Body)
+				}
+			}
+
+			// Removing the reaction unsaves the post.
+			err = mc.HandleMatrixReactionRemove(ctx, &bridgev2.MatrixReactionRemove{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.RedactionEventContent]{Portal: msg.Portal},
+				TargetReaction:  &database.Reaction{MessageID: MakeMessageID("target-post"), EmojiID: savedPostEmojiID},
+			})
+			if err != nil {
+				t.Fatalf("HandleMatrixReactionRemove: %v", err)
+			}
+			if !fm.CalledPath(prefsPath + "/delete") {
+				t.Error("post not unsaved")
+			}
+			if fm.CalledPath("/reactions/") {
+				t.Error("a Mattermost reaction was removed")
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/status"
+)
+
+// maxAuthErrorBodySize bounds how much of a 401 response is read to find
+// out why the token was rejected.
+const maxAuthErrorBodySize = 16 << 10
+
+// tokenCheckTimeout bounds the request that confirms a login's token was
+// rejected.
+const tokenCheckTimeout = 30 * time.Second
+
+// tokenWatchTransport reports responses that reject the request's token,
+// for any API call of a client, so revoked or expired tokens are noticed
+// wherever they surface.
+type tokenWatchTransport struct {
+	next http.RoundTripper
+	// rejected is called with the error of each 401 response to a request
+	// that carried a token. It must not block.
+	rejected func(appErr *model.AppError)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenWatchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || req.Header.Get(model.HeaderAuth) == "" {
+		return resp, err
+	}
+	// The body is put back for the caller once the error is read.
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxAuthErrorBodySize))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	appErr := &model.AppError{}
+	if json.Unmarshal(body, appErr) != nil || appErr.Id == "" {
+		appErr = model.NewAppError(req.URL.Path, "api.context.session_expired.app_error", nil, "", http.StatusUnauthorized)
+	}
+	appErr.StatusCode = http.StatusUnauthorized
+	t.rejected(appErr)
+	return resp, nil
+}
+
+// watchTokenRejections makes client call rejected when Mattermost rejects
+// its token. Clients already watched are left as they are.
+func watchTokenRejections(client *model.Client4, rejected func(appErr *model.AppError)) {
+	if client.HTTPClient == nil {
+		client.HTTPClient = &http.Client{}
+	}
+	next := client.HTTPClient.Transport
+	if _, ok := next.(*tokenWatchTransport); ok {
+		return
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	client.HTTPClient.Transport = &tokenWatchTransport{next: next, rejected: rejected}
+}
+
+// watchPuppetToken marks the puppet unhealthy as soon as any of its API
+// calls is rejected, not only the ones that post.
+func (mc *MattermostConnector) watchPuppetToken(puppet *PuppetClient) {
+	watchTokenRejections(puppet.Client, func(appErr *model.AppError) {
+		// Bot lookups would need another client; the posting path refines
+		// the reason of puppets already marked when it sees the failure.
+		if !puppet.Healthy() {
+			return
+		}
+		reason, detail := diagnosePuppetAuthError(context.Background(), nil, "", appErr)
+		if !puppet.markUnhealthy(reason, detail) {
+			return
+		}
+		mc.metrics().puppetAuthFailures.inc(reason)
+		mc.Bridge.Log.Warn().
+			Str("mxid", string(puppet.MXID)).
+			Str("mm_user_id", puppet.UserID).
+			Str("mm_username", puppet.Username).
+			Str("reason", reason).
+			Str("detail", detail).
+			Msg("Puppet token rejected, falling back to relay")
+	})
+}
+
+// watchToken checks the login's token whenever Mattermost rejects one of
+// its API calls.
+func (m *MattermostClient) watchToken() {
+	watchTokenRejections(m.client, func(*model.AppError) {
+		if m.tokenCheck.CompareAndSwap(false, true) {
+			go m.checkRejectedToken()
+		}
+	})
+}
+
+// checkRejectedToken confirms that the login's token no longer works, as a
+// single 401 may come from one endpoint, and asks the user to log in again
+// if so. OAuth 2.0 logins first try to refresh their token.
+func (m *MattermostClient) checkRejectedToken() {
+	ctx, cancel := context.WithTimeout(m.log.WithContext(context.Background()), tokenCheckTimeout)
+	defer cancel()
+
+	_, resp, err := m.client.GetMe(ctx, "")
+	if !isAuthFailure(resp, err) {
+		m.tokenCheck.Store(false)
+		return
+	}
+	if meta := getLoginMeta(m.userLogin); meta.RefreshToken != "" && m.connector.Config.OAuth2.enabled() {
+		if err := m.refreshOAuth2Token(ctx); err == nil {
+			m.tokenCheck.Store(false)
+			return
+		} else if !errors.Is(err, errOAuth2GrantRejected) {
+			// The server may be unreachable; the next rejection checks again.
+			m.log.Warn().Err(err).Msg("Failed to refresh rejected OAuth 2.0 access token")
+			m.tokenCheck.Store(false)
+			return
+		}
+	}
+	m.tokenRejected(ctx, err)
+}
+
+// tokenRejected stops the login, whose token Mattermost no longer accepts,
+// reports it as BAD_CREDENTIALS and tells the user to log in again. The
+// login stays stopped until it connects again.
+func (m *MattermostClient) tokenRejected(ctx context.Context, err error) {
+	m.log.Error().Err(err).Msg("Mattermost token was revoked or expired")
+	m.Disconnect()
+	m.setConnState(connStateBadCredentials)
+	m.userLogin.BridgeState.Send(status.BridgeState{
+		StateEvent: status.StateBadCredentials,
+		Error:      "mm-token-rejected",
+		Message:    "Mattermost session was revoked or expired, log in again",
+	})
+
+	if m.userLogin.User == nil {
+		return
+	}
+	text := fmt.Sprintf("Mattermost no longer accepts the session of your login **%s**, so your messages aren't bridged. "+
+		"It was probably revoked or expired. Log in again with `login` to resume bridging.", m.userLogin.RemoteName)
+	if err := m.connector.sendManagementNotice(ctx, m.userLogin.User, text); err != nil {
+		m.log.Warn().Err(err).Msg("Failed to notify user of rejected token")
+	}
+}
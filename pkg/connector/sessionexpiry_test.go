@@ -0,0 +1,167 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestWatchTokenRejections(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.RejectTokens["revoked"] = &model.AppError{Id: "api.context.session_expired.app_error", Message: "Invalid or expired session", StatusCode: 401}
+	fake.TokenToUser["test-token"] = "my-user-id"
+	fake.Users["my-user-id"] = &model.User{Id: "my-user-id", Username: "me"}
+
+	tests := []struct {
+		name   string
+		token  string
+		wantID string
+	}{
+		{"rejected token", "revoked", "api.context.session_expired.app_error"},
+		{"valid token", "test-token", ""},
+		{"no token", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newTestBridgeConnector()
+			client := mc.newAPIClient(fake.Server.URL)
+			client.SetToken(tt.token)
+			var mu sync.Mutex
+			var got []*model.AppError
+			watch := func(appErr *model.AppError) {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, appErr)
+			}
+			watchTokenRejections(client, watch)
+			watchTokenRejections(client, watch)
+
+			_, _, err := client.GetMe(context.Background(), "")
+			if tt.wantID == "" {
+				if len(got) != 0 {
+					t.Errorf("rejections = %+v, want none", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Id != tt.wantID || got[0].StatusCode != 401 {
+				t.Fatalf("rejections = %+v, want one %s", got, tt.wantID)
+			}
+			// The caller still sees the error.
+			if !isAuthFailure(nil, err) || !strings.Contains(err.Error(), "Invalid or expired session") {
+				t.Errorf("GetMe error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWatchPuppetToken(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.RejectTokens["puppet-token"] = &model.AppError{
+		Id:            "api.context.session_expired.app_error",
+		DetailedError: "token=abc inactive_user_id=bot-id",
+		StatusCode:    401,
+	}
+	mc := newTestBridgeConnector()
+	client := mc.newAPIClient(fake.Server.URL)
+	client.SetToken("puppet-token")
+	puppet := &PuppetClient{MXID: id.UserID("@bot:example.com"), Client: client, UserID: "bot-id"}
+	puppet.markSuccess()
+	mc.watchPuppetToken(puppet)
+
+	// Any API call reveals the rejection, not only posting.
+	_, _, _ = client.GetChannel(context.Background(), "ch1", "")
+	if puppet.Healthy() {
+		t.Fatal("puppet still healthy after its token was rejected")
+	}
+	if h := puppet.health.Load(); h.Reason != PuppetReasonBotDisabled {
+		t.Errorf("reason = %q, want %q", h.Reason, PuppetReasonBotDisabled)
+	}
+}
+
+func TestCheckRejectedToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.RejectTokens["expired-token"] = &model.AppError{Id: "api.context.session_expired.app_error", StatusCode: 401}
+	fake.TokenToUser["test-token"] = "my-user-id"
+	fake.Users["my-user-id"] = &model.User{Id: "my-user-id", Username: "me"}
+
+	mc := newRelayTestConnector(t, nil)
+	bot := &fakeReplyBot{}
+	mc.Bridge.Bot = bot
+	login, err := mc.Bridge.GetExistingUserLoginByID(ctx, MakeUserLoginID("relayuser"))
+	if err != nil || login == nil {
+		t.Fatalf("get login: %v", err)
+	}
+	login.RemoteName = "relay"
+	login.User.ManagementRoom = "!mgmt:example.com"
+	client := login.Client.(*MattermostClient)
+	client.serverURL = fake.Server.URL
+	client.client = mc.newAPIClient(fake.Server.URL)
+	client.client.SetToken("test-token")
+	client.watchToken()
+
+	// A 401 from one endpoint doesn't stop the login while the token works.
+	fake.EndpointErrors["/channels/forbidden"] = &model.AppError{Id: "api.context.permissions.app_error", StatusCode: 401}
+	_, _, _ = client.client.GetChannel(ctx, "forbidden", "")
+	waitForTokenCheck(t, client)
+	if state := client.connectionState(); state == connStateBadCredentials {
+		t.Fatal("login stopped although its token works")
+	}
+
+	client.client.SetToken("expired-token")
+	_, _, _ = client.client.GetChannel(ctx, "ch1", "")
+	deadline := time.Now().Add(5 * time.Second)
+	for client.connectionState() != connStateBadCredentials {
+		if time.Now().After(deadline) {
+			t.Fatalf("state = %q, want %q", client.connectionState(), connStateBadCredentials)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for bot.lastReply() == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("no management room notice")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	notice := bot.lastReply()
+	if !strings.Contains(notice, "relay") || !strings.Contains(notice, "login") {
+		t.Errorf("notice = %q", notice)
+	}
+	if strings.Contains(notice, "expired-token") {
+		t.Error("notice must not contain the token")
+	}
+	select {
+	case <-client.stopChan:
+	default:
+		t.Error("login not stopped")
+	}
+}
+
+// waitForTokenCheck waits until no token check of client is running.
+func waitForTokenCheck(t *testing.T, client *MattermostClient) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for client.tokenCheck.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("token check didn't finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
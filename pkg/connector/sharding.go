@@ -0,0 +1,268 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.mau.fi/util/dbutil"
+)
+
+// ShardingConfig controls how Mattermost channels are split across several
+// bridge processes sharing one database. Each process owns exactly one shard;
+// channels are assigned to shards by hashing their channel ID.
+type ShardingConfig struct {
+	// Count is the total number of shards. 0 or 1 disables sharding.
+	Count int `yaml:"count"`
+	// ID is the shard owned by this process, in the range [0, Count).
+	ID int `yaml:"id"`
+	// WorkerID identifies this process in the lease table. Defaults to
+	// "<hostname>-<pid>".
+	WorkerID string `yaml:"worker_id"`
+	// LeaseSeconds is how long a shard lease stays valid without a heartbeat.
+	// A standby process configured with the same shard ID takes over once
+	// the lease expires. Defaults to 60.
+	LeaseSeconds int `yaml:"lease_seconds"`
+}
+
+// Enabled reports whether channel sharding is active.
+func (sc ShardingConfig) Enabled() bool {
+	return sc.Count > 1
+}
+
+// shardForChannel maps a Mattermost channel ID onto a shard index using
+// FNV-1a, which is stable across processes and Go versions.
+func shardForChannel(channelID string, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(channelID))
+	return int(h.Sum32() % uint32(count))
+}
+
+const (
+	shardLeaseCreateTable = `
+		CREATE TABLE IF NOT EXISTS mattermost_shard_lease (
+			bridge_id    TEXT    NOT NULL,
+			shard_id     INTEGER NOT NULL,
+			owner        TEXT    NOT NULL,
+			heartbeat_at BIGINT  NOT NULL,
+			PRIMARY KEY (bridge_id, shard_id)
+		)
+	`
+	// shardLeaseAcquire claims the lease if it is free, already ours, or
+	// expired. When another live worker holds it, no row is affected.
+	shardLeaseAcquire = `
+		INSERT INTO mattermost_shard_lease (bridge_id, shard_id, owner, heartbeat_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (bridge_id, shard_id) DO UPDATE
+			SET owner=excluded.owner, heartbeat_at=excluded.heartbeat_at
+			WHERE mattermost_shard_lease.owner=excluded.owner
+			   OR mattermost_shard_lease.heartbeat_at < $5
+	`
+	shardLeaseRelease = `
+		DELETE FROM mattermost_shard_lease WHERE bridge_id=$1 AND shard_id=$2 AND owner=$3
+	`
+	shardLeaseGetOwner = `
+		SELECT owner, heartbeat_at FROM mattermost_shard_lease WHERE bridge_id=$1 AND shard_id=$2
+	`
+)
+
+// shardLease coordinates ownership of a single shard through a lease row in
+// the shared bridge database. Only the process holding the lease bridges
+// events for the shard's channels; a second process configured for the same
+// shard stays on standby until the lease expires or is released (handoff).
+type shardLease struct {
+	db       *dbutil.Database
+	bridgeID string
+	shardID  int
+	owner    string
+	ttl      time.Duration
+	log      zerolog.Logger
+
+	// onAcquired is called when a standby process takes over the lease.
+	onAcquired func()
+
+	held atomic.Bool
+}
+
+func newShardLease(db *dbutil.Database, bridgeID string, cfg ShardingConfig, log zerolog.Logger) *shardLease {
+	owner := cfg.WorkerID
+	if owner == "" {
+		host, _ := os.Hostname()
+		owner = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	ttl := time.Duration(cfg.LeaseSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = 60 * time.Second
+	}
+	return &shardLease{
+		db:       db,
+		bridgeID: bridgeID,
+		shardID:  cfg.ID,
+		owner:    owner,
+		ttl:      ttl,
+		log:      log,
+	}
+}
+
+// ensureTable creates the lease table if it doesn't exist yet.
+func (sl *shardLease) ensureTable(ctx context.Context) error {
+	_, err := sl.db.Exec(ctx, shardLeaseCreateTable)
+	return err
+}
+
+// tryAcquire claims or renews the lease and records the outcome in held.
+func (sl *shardLease) tryAcquire(ctx context.Context) (bool, error) {
+	now := time.Now()
+	res, err := sl.db.Exec(ctx, shardLeaseAcquire,
+		sl.bridgeID, sl.shardID, sl.owner, now.UnixMilli(), now.Add(-sl.ttl).UnixMilli())
+	if err != nil {
+		sl.held.Store(false)
+		return false, fmt.Errorf("acquire shard lease: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		sl.held.Store(false)
+		return false, fmt.Errorf("acquire shard lease: %w", err)
+	}
+	acquired := affected > 0
+	if was := sl.held.Swap(acquired); was != acquired {
+		if acquired {
+			sl.log.Info().Int("shard_id", sl.shardID).Str("owner", sl.owner).Msg("Acquired shard lease")
+			if sl.onAcquired != nil {
+				sl.onAcquired()
+			}
+		} else {
+			sl.log.Warn().Int("shard_id", sl.shardID).Str("owner", sl.owner).Msg("Lost shard lease")
+		}
+	}
+	return acquired, nil
+}
+
+// currentOwner returns the worker currently recorded as the shard owner.
+func (sl *shardLease) currentOwner(ctx context.Context) (string, time.Time, error) {
+	var owner string
+	var heartbeat int64
+	err := sl.db.QueryRow(ctx, shardLeaseGetOwner, sl.bridgeID, sl.shardID).Scan(&owner, &heartbeat)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return owner, time.UnixMilli(heartbeat), nil
+}
+
+// release gives up the lease so a standby worker can take over immediately.
+func (sl *shardLease) release(ctx context.Context) error {
+	sl.held.Store(false)
+	_, err := sl.db.Exec(ctx, shardLeaseRelease, sl.bridgeID, sl.shardID, sl.owner)
+	return err
+}
+
+// Held reports whether this process currently owns the shard.
+func (sl *shardLease) Held() bool {
+	return sl.held.Load()
+}
+
+// run heartbeats the lease every third of its TTL until ctx is cancelled,
+// then releases it for handoff. The first attempt is made by startSharding.
+func (sl *shardLease) run(ctx context.Context) {
+	interval := sl.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := sl.release(releaseCtx); err != nil {
+				sl.log.Warn().Err(err).Int("shard_id", sl.shardID).Msg("Failed to release shard lease")
+			}
+			cancel()
+			return
+		case <-ticker.C:
+		}
+		if _, err := sl.tryAcquire(ctx); err != nil && ctx.Err() == nil {
+			sl.log.Error().Err(err).Int("shard_id", sl.shardID).Msg("Shard lease heartbeat failed")
+		}
+	}
+}
+
+// startSharding validates the sharding config and starts the lease loop.
+// It is a no-op when sharding is disabled. The first lease attempt is made
+// before returning, so the logins' initial channel sync, which runs after
+// Start, already knows whether this process owns the shard.
+func (mc *MattermostConnector) startSharding(ctx context.Context) error {
+	cfg := mc.Config.Sharding
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.ID < 0 || cfg.ID >= cfg.Count {
+		return fmt.Errorf("sharding.id %d out of range for sharding.count %d", cfg.ID, cfg.Count)
+	}
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return fmt.Errorf("sharding requires a bridge database")
+	}
+
+	log := mc.Bridge.Log.With().Str("component", "sharding").Logger()
+	lease := newShardLease(mc.Bridge.DB.Database, string(mc.Bridge.ID), cfg, log)
+	if err := lease.ensureTable(ctx); err != nil {
+		return fmt.Errorf("failed to create shard lease table: %w", err)
+	}
+	if _, err := lease.tryAcquire(ctx); err != nil {
+		log.Error().Err(err).Int("shard_id", cfg.ID).Msg("Shard lease heartbeat failed")
+	}
+	lease.onAcquired = mc.catchUpShard
+	mc.shardLease = lease
+
+	log.Info().
+		Int("shard_id", cfg.ID).
+		Int("shard_count", cfg.Count).
+		Str("owner", lease.owner).
+		Bool("lease_held", lease.Held()).
+		Msg("Channel sharding enabled")
+	mc.background.Add(1)
+	go func() {
+		defer mc.background.Done()
+		lease.run(ctx)
+	}()
+	return nil
+}
+
+// catchUpShard syncs the channels of a shard this process just took over
+// and recovers the posts sent while it was on standby. The logins skipped
+// the shard's channels while another process held the lease.
+func (mc *MattermostConnector) catchUpShard() {
+	for _, client := range mc.loadedClients() {
+		if client.connectionState() != connStateConnected {
+			continue
+		}
+		ctx := client.log.WithContext(context.Background())
+		go client.syncChannels(ctx)
+		go client.recoverMissedPosts(ctx)
+	}
+}
+
+// OwnsChannel reports whether this process is responsible for bridging the
+// given Mattermost channel. Always true when sharding is disabled. When
+// sharding is enabled, the channel must hash to this process's shard and the
+// shard lease must currently be held.
+func (mc *MattermostConnector) OwnsChannel(channelID string) bool {
+	cfg := mc.Config.Sharding
+	if !cfg.Enabled() {
+		return true
+	}
+	if shardForChannel(channelID, cfg.Count) != cfg.ID {
+		return false
+	}
+	return mc.shardLease != nil && mc.shardLease.Held()
+}
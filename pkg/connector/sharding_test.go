@@ -0,0 +1,377 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+)
+
+func TestShardForChannel_Deterministic(t *testing.T) {
+	t.Parallel()
+	for _, chID := range []string{"", "ch1", "abcdefghijklmnopqrstuvwxyz"} {
+		first := shardForChannel(chID, 7)
+		for range 10 {
+			if got := shardForChannel(chID, 7); got != first {
+				t.Fatalf("shardForChannel(%q) not deterministic: %d vs %d", chID, got, first)
+			}
+		}
+	}
+}
+
+func TestShardForChannel_Range(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		count int
+	}{
+		{"disabled zero", 0},
+		{"disabled one", 1},
+		{"negative", -3},
+		{"two", 2},
+		{"many", 64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for i := range 200 {
+				got := shardForChannel(fmt.Sprintf("channel-%d", i), tt.count)
+				if tt.count <= 1 {
+					if got != 0 {
+						t.Fatalf("count %d: got shard %d, want 0", tt.count, got)
+					}
+					continue
+				}
+				if got < 0 || got >= tt.count {
+					t.Fatalf("count %d: shard %d out of range", tt.count, got)
+				}
+			}
+		})
+	}
+}
+
+func TestShardForChannel_Distribution(t *testing.T) {
+	t.Parallel()
+	const count = 4
+	seen := make(map[int]int)
+	for i := range 1000 {
+		seen[shardForChannel(fmt.Sprintf("%026d", i), count)]++
+	}
+	for shard := range count {
+		if seen[shard] < 100 {
+			t.Errorf("shard %d got only %d of 1000 channels, distribution is badly skewed", shard, seen[shard])
+		}
+	}
+}
+
+func TestOwnsChannel_DisabledOwnsEverything(t *testing.T) {
+	t.Parallel()
+	mc := &MattermostConnector{}
+	if !mc.OwnsChannel("anything") {
+		t.Error("with sharding disabled every channel should be owned")
+	}
+}
+
+func TestOwnsChannel_RequiresMatchingShardAndLease(t *testing.T) {
+	t.Parallel()
+	mc := &MattermostConnector{Config: Config{Sharding: ShardingConfig{Count: 2, ID: 0}}}
+
+	var mine, theirs string
+	for i := 0; mine == "" || theirs == ""; i++ {
+		chID := fmt.Sprintf("ch-%d", i)
+		if shardForChannel(chID, 2) == 0 {
+			mine = chID
+		} else {
+			theirs = chID
+		}
+	}
+
+	if mc.OwnsChannel(mine) {
+		t.Error("channel must not be owned before a lease exists")
+	}
+
+	mc.shardLease = &shardLease{}
+	if mc.OwnsChannel(mine) {
+		t.Error("channel must not be owned while the lease is not held")
+	}
+
+	mc.shardLease.held.Store(true)
+	if !mc.OwnsChannel(mine) {
+		t.Error("channel in own shard should be owned while lease is held")
+	}
+	if mc.OwnsChannel(theirs) {
+		t.Error("channel in another shard must never be owned")
+	}
+}
+
+func TestStartSharding_InvalidID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		cfg  ShardingConfig
+	}{
+		{"id equals count", ShardingConfig{Count: 2, ID: 2}},
+		{"negative id", ShardingConfig{Count: 3, ID: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newTestBridgeConnector()
+			mc.Config.Sharding = tt.cfg
+			if err := mc.startSharding(context.Background()); err == nil {
+				t.Error("expected error for out-of-range shard id")
+			}
+		})
+	}
+}
+
+func TestStartSharding_DisabledIsNoop(t *testing.T) {
+	t.Parallel()
+	mc := &MattermostConnector{}
+	if err := mc.startSharding(context.Background()); err != nil {
+		t.Fatalf("disabled sharding should not error: %v", err)
+	}
+	if mc.shardLease != nil {
+		t.Error("disabled sharding should not create a lease")
+	}
+}
+
+func TestStartSharding_AcquiresBeforeReturning(t *testing.T) {
+	t.Parallel()
+	mc := newRelayTestConnector(t, nil)
+	mc.Config.Sharding = ShardingConfig{Count: 2, ID: 1, WorkerID: "worker-a"}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		mc.background.Wait()
+	})
+
+	if err := mc.startSharding(ctx); err != nil {
+		t.Fatalf("startSharding: %v", err)
+	}
+	if !mc.shardLease.Held() {
+		t.Error("lease should be held when startSharding returns")
+	}
+}
+
+func newTestShardLease(t *testing.T, owner string, leaseSeconds int) *shardLease {
+	t.Helper()
+	db := newTestDB(t)
+	sl := newShardLease(db, "bridge", ShardingConfig{Count: 2, ID: 1, WorkerID: owner, LeaseSeconds: leaseSeconds}, zerolog.Nop())
+	if err := sl.ensureTable(context.Background()); err != nil {
+		t.Fatalf("ensureTable: %v", err)
+	}
+	return sl
+}
+
+func TestShardLease_AcquireAndRenew(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	sl := newTestShardLease(t, "worker-a", 60)
+
+	for i := range 2 {
+		ok, err := sl.tryAcquire(ctx)
+		if err != nil {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+		if !ok || !sl.Held() {
+			t.Fatalf("attempt %d: expected lease to be held", i)
+		}
+	}
+
+	owner, _, err := sl.currentOwner(ctx)
+	if err != nil {
+		t.Fatalf("currentOwner: %v", err)
+	}
+	if owner != "worker-a" {
+		t.Errorf("owner: got %q, want worker-a", owner)
+	}
+}
+
+func TestShardLease_StandbyWaitsForLiveOwner(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	active := newTestShardLease(t, "worker-a", 60)
+	standby := newShardLease(active.db, "bridge", ShardingConfig{Count: 2, ID: 1, WorkerID: "worker-b", LeaseSeconds: 60}, zerolog.Nop())
+
+	if ok, err := active.tryAcquire(ctx); err != nil || !ok {
+		t.Fatalf("active acquire: ok=%v err=%v", ok, err)
+	}
+	ok, err := standby.tryAcquire(ctx)
+	if err != nil {
+		t.Fatalf("standby acquire: %v", err)
+	}
+	if ok || standby.Held() {
+		t.Error("standby must not take a lease held by a live owner")
+	}
+}
+
+func TestShardLease_HandoffOnRelease(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	active := newTestShardLease(t, "worker-a", 60)
+	standby := newShardLease(active.db, "bridge", ShardingConfig{Count: 2, ID: 1, WorkerID: "worker-b", LeaseSeconds: 60}, zerolog.Nop())
+
+	if ok, _ := active.tryAcquire(ctx); !ok {
+		t.Fatal("active should acquire")
+	}
+	if err := active.release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if active.Held() {
+		t.Error("released lease should not be held")
+	}
+	if ok, err := standby.tryAcquire(ctx); err != nil || !ok {
+		t.Fatalf("standby should take over after release: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestShardLease_TakeoverAfterExpiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	active := newTestShardLease(t, "worker-a", 60)
+	if ok, _ := active.tryAcquire(ctx); !ok {
+		t.Fatal("active should acquire")
+	}
+
+	// Simulate a crashed owner by aging its heartbeat past the TTL.
+	if _, err := active.db.Exec(ctx, "UPDATE mattermost_shard_lease SET heartbeat_at=0"); err != nil {
+		t.Fatalf("age heartbeat: %v", err)
+	}
+
+	standby := newShardLease(active.db, "bridge", ShardingConfig{Count: 2, ID: 1, WorkerID: "worker-b", LeaseSeconds: 60}, zerolog.Nop())
+	if ok, err := standby.tryAcquire(ctx); err != nil || !ok {
+		t.Fatalf("standby should take over expired lease: ok=%v err=%v", ok, err)
+	}
+	// The old owner notices on its next heartbeat.
+	if ok, _ := active.tryAcquire(ctx); ok || active.Held() {
+		t.Error("previous owner must lose the lease after takeover")
+	}
+}
+
+func TestShardLease_OnAcquiredOnTakeover(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	active := newTestShardLease(t, "worker-a", 60)
+	standby := newShardLease(active.db, "bridge", ShardingConfig{Count: 2, ID: 1, WorkerID: "worker-b", LeaseSeconds: 60}, zerolog.Nop())
+	var acquired int
+	standby.onAcquired = func() { acquired++ }
+
+	if ok, _ := active.tryAcquire(ctx); !ok {
+		t.Fatal("active should acquire")
+	}
+	if ok, _ := standby.tryAcquire(ctx); ok || acquired != 0 {
+		t.Fatalf("standby acquired = %v, callbacks = %d", ok, acquired)
+	}
+	if err := active.release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	for range 2 {
+		if ok, err := standby.tryAcquire(ctx); err != nil || !ok {
+			t.Fatalf("standby should take over: ok=%v err=%v", ok, err)
+		}
+	}
+	if acquired != 1 {
+		t.Errorf("onAcquired called %d times, want once on takeover", acquired)
+	}
+}
+
+func TestShardLease_ReleaseDoesNotStealOthersLease(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	active := newTestShardLease(t, "worker-a", 60)
+	other := newShardLease(active.db, "bridge", ShardingConfig{Count: 2, ID: 1, WorkerID: "worker-b", LeaseSeconds: 60}, zerolog.Nop())
+
+	if ok, _ := active.tryAcquire(ctx); !ok {
+		t.Fatal("active should acquire")
+	}
+	if err := other.release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	owner, _, err := active.currentOwner(ctx)
+	if err != nil {
+		t.Fatalf("currentOwner: %v", err)
+	}
+	if owner != "worker-a" {
+		t.Errorf("non-owner release must not delete the lease, owner is now %q", owner)
+	}
+}
+
+func TestNewShardLease_Defaults(t *testing.T) {
+	t.Parallel()
+	sl := newShardLease(nil, "bridge", ShardingConfig{Count: 2}, zerolog.Nop())
+	if sl.owner == "" {
+		t.Error("owner should default to hostname-pid")
+	}
+	if sl.ttl <= 0 {
+		t.Errorf("ttl should have a positive default, got %v", sl.ttl)
+	}
+}
+
+func TestHandleEvent_SkipsChannelsOfOtherShards(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://localhost")
+	mc.connector.Config.Sharding = ShardingConfig{Count: 2, ID: 0}
+	mc.connector.shardLease = &shardLease{}
+	mc.connector.shardLease.held.Store(true)
+	mock := testMock(mc)
+
+	var mine, theirs string
+	for i := 0; mine == "" || theirs == ""; i++ {
+		chID := fmt.Sprintf("ch-%d", i)
+		if shardForChannel(chID, 2) == 0 {
+			mine = chID
+		} else {
+			theirs = chID
+		}
+	}
+
+	for _, chID := range []string{theirs, mine} {
+		postJSON, _ := json.Marshal(&model.Post{Id: "p-" + chID, UserId: "other-user", ChannelId: chID, Message: "hi"})
+		mc.handleEvent(newWebSocketEvent(model.WebsocketEventPosted, chID, map[string]any{"post": string(postJSON)}))
+	}
+
+	events := mock.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected only the owned channel's post to be queued, got %d events", len(events))
+	}
+	if got := ParsePortalID(events[0].GetPortalKey().ID); got != mine {
+		t.Errorf("queued event for channel %q, want %q", got, mine)
+	}
+}
+
+func TestSyncChannels_SkipsChannelsOfOtherShards(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	defer fake.Close()
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.Sharding = ShardingConfig{Count: 2, ID: 1}
+	mc.connector.shardLease = &shardLease{}
+	mc.connector.shardLease.held.Store(true)
+
+	var channels []*model.Channel
+	owned := 0
+	for i := range 10 {
+		chID := fmt.Sprintf("sync-ch-%d", i)
+		channels = append(channels, &model.Channel{Id: chID, Type: model.ChannelTypeOpen, Name: chID})
+		if shardForChannel(chID, 2) == 1 {
+			owned++
+		}
+	}
+	fake.ChannelsForUser["my-user-id"] = channels
+
+	mc.syncChannels(context.Background())
+
+	if got := len(testMock(mc).Events()); got != owned {
+		t.Errorf("expected %d ChatResync events for owned channels, got %d", owned, got)
+	}
+}
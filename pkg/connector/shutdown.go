@@ -0,0 +1,295 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.mau.fi/util/dbutil"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+)
+
+// shutdownTimeout bounds the graceful part of Stop. Whatever is left when
+// it runs out is abandoned.
+const shutdownTimeout = 10 * time.Second
+
+var _ bridgev2.StoppableNetwork = (*MattermostConnector)(nil)
+
+// Stop implements bridgev2.StoppableNetwork. The bridge calls it after
+// disconnecting every login and before closing the database. It stops the
+// admin API, disconnects the clients the bridge didn't, stops the
+// background loops (releasing the shard lease), records the channels of the
+// events still in the event queue for catch-up on the next start and waits
+// for held Matrix messages being sent. Safe to call more than once.
+func (mc *MattermostConnector) Stop() {
+	mc.stopOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		mc.shutdown(ctx)
+	})
+}
+
+// shutdown is Stop with a deadline.
+func (mc *MattermostConnector) shutdown(ctx context.Context) {
+	log := mc.Bridge.Log.With().Str("component", "shutdown").Logger()
+
+	if mc.adminServer != nil {
+		if err := mc.adminServer.Shutdown(ctx); err != nil {
+			log.Warn().Err(err).Msg("Admin API didn't shut down in time, closing it")
+			_ = mc.adminServer.Close()
+		}
+	}
+
+	clients := mc.loadedClients()
+	for _, client := range clients {
+		client.Disconnect()
+	}
+
+	if mc.cancelBackground != nil {
+		mc.cancelBackground()
+	}
+	if !waitDone(ctx, mc.background.Wait) {
+		log.Warn().Msg("Background loops didn't stop in time")
+	}
+
+	// The bridge stopped processing remote events before calling Stop, so
+	// queued events can't be delivered anymore; their channels are resynced
+	// on the next start instead.
+	if mc.eventQueue != nil {
+		missed, dropped := mc.eventQueue.drainMissed()
+		saved, err := mc.saveMissedChannels(ctx, missed)
+		evt := log.Info()
+		if err != nil {
+			evt = log.Warn().Err(err)
+		}
+		evt.Int("dropped", dropped).
+			Int("missed_channels", saved).
+			Msg("Recorded channels of undelivered events for catch-up")
+	}
+
+	for _, client := range clients {
+		if !waitDone(ctx, client.waitHeldMessages) {
+			log.Warn().Str("login_id", string(client.userLogin.ID)).Msg("Held messages still being sent at shutdown")
+		}
+	}
+	log.Info().Int("client_count", len(clients)).Msg("Mattermost connector stopped")
+}
+
+// trackClient records the client of a loaded login for Stop, replacing the
+// previous client of the login.
+func (mc *MattermostConnector) trackClient(client *MattermostClient) {
+	mc.clientsMu.Lock()
+	defer mc.clientsMu.Unlock()
+	if mc.clients == nil {
+		mc.clients = make(map[networkid.UserLoginID]*MattermostClient)
+	}
+	mc.clients[client.userLogin.ID] = client
+}
+
+// untrackClient forgets the client of a login that logged out.
+func (mc *MattermostConnector) untrackClient(client *MattermostClient) {
+	if client.userLogin == nil {
+		return
+	}
+	mc.clientsMu.Lock()
+	defer mc.clientsMu.Unlock()
+	if mc.clients[client.userLogin.ID] == client {
+		delete(mc.clients, client.userLogin.ID)
+	}
+}
+
+// loadedClients returns the clients of the loaded logins.
+func (mc *MattermostConnector) loadedClients() []*MattermostClient {
+	mc.clientsMu.Lock()
+	defer mc.clientsMu.Unlock()
+	clients := make([]*MattermostClient, 0, len(mc.clients))
+	for _, client := range mc.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// waitHeldMessages waits until the login's held messages aren't being sent.
+// Disconnect stops further retries.
+func (m *MattermostClient) waitHeldMessages() {
+	m.holdFlushMu.Lock()
+	defer m.holdFlushMu.Unlock()
+}
+
+// drainMissed empties the queue without handing its events to the bridge.
+// It returns the channels that lost events by login, including those still
+// waiting for a catch-up resync, and the number of events dropped. The
+// queue's run loop must have stopped.
+func (q *eventQueue) drainMissed() (map[networkid.UserLoginID][]string, int) {
+	dropped := 0
+	for len(q.events) > 0 {
+		queued := <-q.events
+		dropped++
+		q.dropped.Add(1)
+		portalID := queued.evt.GetPortalKey().ID
+		channelID := ParsePortalID(portalID)
+		if _, isTeam := ParseTeamPortalID(portalID); refetchable(queued.evt.GetType()) && channelID != "" && !isTeam {
+			q.mark(queued.login, channelID)
+		}
+	}
+	q.missedMu.Lock()
+	defer q.missedMu.Unlock()
+	missed := make(map[networkid.UserLoginID][]string, len(q.missed))
+	for loginID, channels := range q.missed {
+		missed[loginID] = slices.Sorted(maps.Keys(channels.channels))
+	}
+	clear(q.missed)
+	return missed, dropped
+}
+
+const (
+	missedChannelCreateTable = `
+		CREATE TABLE IF NOT EXISTS mattermost_missed_channel (
+			bridge_id  TEXT NOT NULL,
+			login_id   TEXT NOT NULL,
+			channel_id TEXT NOT NULL,
+			PRIMARY KEY (bridge_id, login_id, channel_id)
+		)
+	`
+	missedChannelInsert = `
+		INSERT INTO mattermost_missed_channel (bridge_id, login_id, channel_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (bridge_id, login_id, channel_id) DO NOTHING
+	`
+	missedChannelGet = `
+		SELECT channel_id FROM mattermost_missed_channel WHERE bridge_id=$1 AND login_id=$2
+	`
+	missedChannelDelete = `
+		DELETE FROM mattermost_missed_channel WHERE bridge_id=$1 AND login_id=$2
+	`
+)
+
+// missedChannelDB returns the database recording the channels of events
+// lost at shutdown, creating its table if needed, or nil without a bridge
+// database.
+func (mc *MattermostConnector) missedChannelDB(ctx context.Context) (*dbutil.Database, error) {
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return nil, nil
+	}
+	db := mc.Bridge.DB.Database
+	if !mc.missedChannelTable.Load() {
+		if _, err := db.Exec(ctx, missedChannelCreateTable); err != nil {
+			return nil, fmt.Errorf("failed to create missed channel table: %w", err)
+		}
+		mc.missedChannelTable.Store(true)
+	}
+	return db, nil
+}
+
+// saveMissedChannels records the channels that lost events by login, so
+// the logins resync them on the next start. It returns how many were
+// recorded.
+func (mc *MattermostConnector) saveMissedChannels(ctx context.Context, missed map[networkid.UserLoginID][]string) (int, error) {
+	if len(missed) == 0 {
+		return 0, nil
+	}
+	db, err := mc.missedChannelDB(ctx)
+	if db == nil {
+		return 0, err
+	}
+	saved := 0
+	err = db.DoTxn(ctx, nil, func(ctx context.Context) error {
+		for loginID, channelIDs := range missed {
+			for _, channelID := range channelIDs {
+				if _, err := db.Exec(ctx, missedChannelInsert, string(mc.Bridge.ID), string(loginID), channelID); err != nil {
+					return err
+				}
+				saved++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to save missed channels: %w", err)
+	}
+	return saved, nil
+}
+
+// takeMissedChannels returns and forgets the channels a login lost events
+// of at the last shutdown.
+func (mc *MattermostConnector) takeMissedChannels(ctx context.Context, loginID networkid.UserLoginID) ([]string, error) {
+	db, err := mc.missedChannelDB(ctx)
+	if db == nil {
+		return nil, err
+	}
+	var channelIDs []string
+	err = db.DoTxn(ctx, nil, func(ctx context.Context) error {
+		rows, err := db.Query(ctx, missedChannelGet, string(mc.Bridge.ID), string(loginID))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var channelID string
+			if err := rows.Scan(&channelID); err != nil {
+				return err
+			}
+			channelIDs = append(channelIDs, channelID)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		_, err = db.Exec(ctx, missedChannelDelete, string(mc.Bridge.ID), string(loginID))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missed channels: %w", err)
+	}
+	return channelIDs, nil
+}
+
+// resyncMissedChannels queues a catch-up resync of the channels the login
+// lost events of at the last shutdown.
+func (m *MattermostClient) resyncMissedChannels(ctx context.Context) {
+	if m.userLogin == nil {
+		return
+	}
+	channelIDs, err := m.connector.takeMissedChannels(ctx, m.userLogin.ID)
+	if err != nil {
+		m.log.Err(err).Msg("Failed to get channels with events lost at shutdown")
+		return
+	}
+	if len(channelIDs) == 0 {
+		return
+	}
+	m.log.Info().Int("channel_count", len(channelIDs)).Msg("Resyncing channels with events lost at shutdown")
+	for _, channelID := range channelIDs {
+		if !m.connector.OwnsChannel(channelID) {
+			continue
+		}
+		ch, _, err := m.client.GetChannel(ctx, channelID, "")
+		if err != nil {
+			m.log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to get channel with events lost at shutdown")
+			continue
+		}
+		m.queueChannelSync(ctx, ch, m.allowCreatePortal(ctx, ch.Id, ch.Name, ch.TeamId, false))
+	}
+}
+
+// waitDone calls wait and returns whether it returned before ctx was done.
+func waitDone(ctx context.Context, wait func()) bool {
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
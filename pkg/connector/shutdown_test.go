@@ -0,0 +1,174 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+)
+
+func TestStop(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+
+	// Admin API on a free port.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc.adminServer = &http.Server{Handler: mc.adminAPIHandler(), ReadHeaderTimeout: time.Second}
+	served := make(chan error, 1)
+	go func() { served <- mc.adminServer.Serve(listener) }()
+
+	// A background loop that must be stopped.
+	var ctx context.Context
+	ctx, mc.cancelBackground = context.WithCancel(context.Background())
+	mc.background.Add(1)
+	go func() {
+		defer mc.background.Done()
+		<-ctx.Done()
+	}()
+
+	// Queued events the bridge can't take anymore.
+	next := &mockEventSender{}
+	mc.eventQueue = newEventQueue(next, 4, false, zerolog.Nop())
+	login := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+	mc.eventQueue.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	mc.eventQueue.QueueRemoteEvent(login, queueTestEvent(bridgev2.RemoteEventMessage, "ch2"))
+
+	// A loaded client that must be disconnected.
+	client := newFullTestClient("http://localhost")
+	client.connector = mc
+	client.userLogin = login
+	mc.trackClient(client)
+
+	mc.Stop()
+
+	select {
+	case err := <-served:
+		if !errors.Is(err, http.ErrServerClosed) {
+			t.Errorf("admin API stopped with %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("admin API still running")
+	}
+	if ctx.Err() == nil {
+		t.Error("background context not cancelled")
+	}
+	if got := len(next.Events()); got != 0 {
+		t.Errorf("handed %d events to the stopped bridge", got)
+	}
+	if got := len(mc.eventQueue.events); got != 0 {
+		t.Errorf("%d events left in the queue", got)
+	}
+	select {
+	case <-client.stopChan:
+	default:
+		t.Error("client not disconnected")
+	}
+
+	// Stopping again is a no-op.
+	mc.Stop()
+}
+
+func TestStop_NothingStarted(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	mc.Stop()
+}
+
+func TestEventQueue_DrainMissed(t *testing.T) {
+	t.Parallel()
+	next := &mockEventSender{}
+	q := newEventQueue(next, 8, true, zerolog.Nop())
+	login1 := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+	login2 := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login2"}}
+	q.QueueRemoteEvent(login1, queueTestEvent(bridgev2.RemoteEventMessage, "ch2"))
+	q.QueueRemoteEvent(login1, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	q.QueueRemoteEvent(login1, queueTestEvent(bridgev2.RemoteEventTyping, "ch3"))
+	q.QueueRemoteEvent(login2, queueTestEvent(bridgev2.RemoteEventMessage, "ch1"))
+	// A channel that lost events earlier and wasn't resynced yet.
+	q.mark(login2, "ch4")
+
+	missed, dropped := q.drainMissed()
+	if dropped != 4 {
+		t.Errorf("dropped %d events, want 4", dropped)
+	}
+	if got := missed["login1"]; !slices.Equal(got, []string{"ch1", "ch2"}) {
+		t.Errorf("login1 missed %v, want ch1 and ch2", got)
+	}
+	if got := missed["login2"]; !slices.Equal(got, []string{"ch1", "ch4"}) {
+		t.Errorf("login2 missed %v, want ch1 and ch4", got)
+	}
+	if len(next.Events()) != 0 || len(q.events) != 0 || q.missedCount() != 0 {
+		t.Errorf("queue not emptied: handed %d, left %d, missed %d", len(next.Events()), len(q.events), q.missedCount())
+	}
+}
+
+func TestResyncMissedChannels(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, nil)
+	missed := map[networkid.UserLoginID][]string{"relayuser": {relayTestChannel}, "other": {relayTestChannel2}}
+	if saved, err := mc.saveMissedChannels(ctx, missed); err != nil || saved != 2 {
+		t.Fatalf("saved %d, %v, want 2", saved, err)
+	}
+
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Channels[relayTestChannel] = &model.Channel{Id: relayTestChannel, Name: "town-square", Type: model.ChannelTypeOpen}
+	client := newFullTestClient(fake.Server.URL)
+	client.connector = mc
+	client.userLogin = &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "relayuser"}}
+
+	client.resyncMissedChannels(ctx)
+	events := testMock(client).Events()
+	if len(events) != 1 || events[0].GetPortalKey() != makePortalKey(relayTestChannel) {
+		t.Fatalf("events = %+v, want a resync of the missed channel", events)
+	}
+
+	// The channels are only resynced once.
+	client.resyncMissedChannels(ctx)
+	if events := testMock(client).Events(); len(events) != 1 {
+		t.Errorf("resynced again: %+v", events)
+	}
+	if channelIDs, err := mc.takeMissedChannels(ctx, "other"); err != nil || !slices.Equal(channelIDs, []string{relayTestChannel2}) {
+		t.Errorf("other login's channels = %v, %v", channelIDs, err)
+	}
+}
+
+func TestTrackClient(t *testing.T) {
+	t.Parallel()
+	mc := newTestBridgeConnector()
+	login := &bridgev2.UserLogin{UserLogin: &database.UserLogin{ID: "login1"}}
+	first := &MattermostClient{connector: mc, userLogin: login}
+	second := &MattermostClient{connector: mc, userLogin: login}
+
+	mc.trackClient(first)
+	mc.trackClient(second)
+	if clients := mc.loadedClients(); len(clients) != 1 || clients[0] != second {
+		t.Fatalf("clients = %v, want the reloaded client only", clients)
+	}
+	mc.untrackClient(first)
+	if len(mc.loadedClients()) != 1 {
+		t.Error("untracking a replaced client removed its successor")
+	}
+	mc.untrackClient(second)
+	if len(mc.loadedClients()) != 0 {
+		t.Error("client still tracked after logout")
+	}
+}
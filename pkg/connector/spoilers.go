@@ -0,0 +1,48 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/id"
+)
+
+// How Matrix spoilers are sent to Mattermost.
+const (
+	SpoilerStyleLink  = "link"
+	SpoilerStylePipes = "pipes"
+)
+
+// spoilerConverter returns the converter of the spoilers of a Matrix event
+// in a portal, according to spoiler_style. With the link style, the text is
+// left out of Mattermost and replaced with a link to the event, where Matrix
+// clients reveal it.
+func (c *Config) spoilerConverter(portal *bridgev2.Portal, eventID id.EventID) matrixfmt.SpoilerConverter {
+	if c.SpoilerStyle == SpoilerStylePipes {
+		return func(text, _ string) string {
+			return "||" + text + "||"
+		}
+	}
+	var link string
+	if portal != nil && portal.MXID != "" && eventID != "" {
+		var via []string
+		if portal.Bridge != nil && portal.Bridge.Matrix != nil {
+			via = append(via, portal.Bridge.Matrix.ServerName())
+		}
+		link = portal.MXID.EventURI(eventID, via...).MatrixToURL()
+	}
+	return func(_, reason string) string {
+		label := "(spoiler)"
+		if reason != "" {
+			label = "(spoiler: " + reason + ")"
+		}
+		if link == "" {
+			return label
+		}
+		return label + " [reveal in Matrix](" + link + ")"
+	}
+}
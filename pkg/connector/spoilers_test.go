@@ -0,0 +1,56 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"testing"
+
+	"github.com/aiku/mautrix-mattermost/pkg/connector/matrixfmt"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestSpoilerConverter(t *testing.T) {
+	t.Parallel()
+	portal := makeTestPortal("ch1")
+	portal.MXID = "!room:example.com"
+	tests := []struct {
+		name    string
+		style   string
+		eventID id.EventID
+		html    string
+		want    string
+	}{
+		{"link", "", "$evt", `the killer is <span data-mx-spoiler>the butler</span>`,
+			"the killer is (spoiler) [reveal in Matrix](https://matrix.to/#/%21room:example.com/$evt)"},
+		{"link with reason", SpoilerStyleLink, "$evt", `<span data-mx-spoiler="plot">the butler</span>`,
+			"(spoiler: plot) [reveal in Matrix](https://matrix.to/#/%21room:example.com/$evt)"},
+		{"link without event", SpoilerStyleLink, "", `<span data-mx-spoiler>the butler</span>`, "(spoiler)"},
+		{"pipes", SpoilerStylePipes, "$evt", `the killer is <span data-mx-spoiler="plot"><em>the butler</em></span>`,
+			"the killer is ||_the butler_||"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{SpoilerStyle: tt.style}
+			content := &event.MessageEventContent{Body: "x", Format: event.FormatHTML, FormattedBody: tt.html}
+			got := matrixfmtParseWithOptions(content, matrixfmt.Options{Spoilers: cfg.spoilerConverter(portal, tt.eventID)})
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigPostProcessSpoilerStyle(t *testing.T) {
+	t.Parallel()
+	for style, wantErr := range map[string]bool{"": false, SpoilerStyleLink: false, SpoilerStylePipes: false, "blur": true} {
+		cfg := &Config{SpoilerStyle: style}
+		if err := cfg.PostProcess(); (err != nil) != wantErr {
+			t.Errorf("spoiler_style %q: err = %v, want error %v", style, err, wantErr)
+		}
+	}
+}
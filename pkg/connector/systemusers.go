@@ -0,0 +1,78 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// defaultSystemUsernames are the accounts Mattermost and its bundled plugins
+// post as on their own: system-bot sends test notifications and system
+// notices, mattermost-advisor sends admin advisories, and feedbackbot and
+// surveybot run the NPS and user surveys.
+var defaultSystemUsernames = []string{"system-bot", "mattermost-advisor", "feedbackbot", "surveybot"}
+
+// SystemUsersConfig lists the Mattermost system accounts whose traffic isn't
+// bridged.
+type SystemUsersConfig struct {
+	// Usernames are the accounts whose posts and reactions aren't bridged.
+	// Unset uses the built-in list; an empty list bridges every account.
+	Usernames []string `yaml:"usernames"`
+}
+
+// usernames returns the configured system usernames, or the built-in list.
+func (c *SystemUsersConfig) usernames() []string {
+	if c.Usernames == nil {
+		return defaultSystemUsernames
+	}
+	return c.Usernames
+}
+
+// isSystemUsername reports whether a Mattermost username is a system account
+// left out of the bridge.
+func (c *SystemUsersConfig) isSystemUsername(username string) bool {
+	return username != "" && slices.ContainsFunc(c.usernames(), func(name string) bool {
+		return strings.EqualFold(strings.TrimPrefix(name, "@"), username)
+	})
+}
+
+// systemUsername returns the username of a Mattermost user if it's a system
+// account left out of the bridge. senderName is the username the event came
+// with, if any; otherwise the username is looked up and cached. A failed
+// lookup treats the user as a regular user, so their messages aren't lost.
+func (m *MattermostClient) systemUsername(userID, senderName string) (string, bool) {
+	cfg := &m.connector.Config.SystemUsers
+	if len(cfg.usernames()) == 0 || userID == "" || userID == m.userID {
+		return "", false
+	}
+	if senderName == "" && m.client != nil {
+		senderName, _ = m.mentionUsername(m.log.WithContext(context.Background()), userID)
+	}
+	if !cfg.isSystemUsername(senderName) {
+		return "", false
+	}
+	return senderName, true
+}
+
+// isSystemUserPost reports whether a post is from a system account left out
+// of the bridge, and records the drop under event.
+func (m *MattermostClient) isSystemUserPost(post *model.Post, event, senderName string) bool {
+	username, ok := m.systemUsername(post.UserId, senderName)
+	if !ok {
+		return false
+	}
+	m.log.Debug().
+		Str("post_id", post.Id).
+		Str("username", username).
+		Str("event_type", event).
+		Msg("Skipping system user post")
+	m.postEchoDropped(echoLayerSystemUser, event, post, username)
+	return true
+}
@@ -0,0 +1,108 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSystemUsersConfig_IsSystemUsername(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		usernames []string
+		username  string
+		want      bool
+	}{
+		{"built-in system-bot", nil, "system-bot", true},
+		{"built-in feedbackbot", nil, "feedbackbot", true},
+		{"regular user", nil, "alice", false},
+		{"empty username", nil, "", false},
+		{"case-insensitive", []string{"NoisyBot"}, "noisybot", true},
+		{"configured with @", []string{"@noisybot"}, "noisybot", true},
+		{"configured list replaces built-in", []string{"noisybot"}, "system-bot", false},
+		{"empty list bridges everyone", []string{}, "system-bot", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := SystemUsersConfig{Usernames: tt.usernames}
+			if got := cfg.isSystemUsername(tt.username); got != tt.want {
+				t.Errorf("isSystemUsername(%q) = %v, want %v", tt.username, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEvents_DropsSystemUsers(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Users["system-bot-id"] = &model.User{Id: "system-bot-id", Username: "system-bot"}
+	fake.Users["alice-id"] = &model.User{Id: "alice-id", Username: "alice"}
+
+	postEvent := func(eventType model.WebsocketEventType, userID, senderName string) *model.WebSocketEvent {
+		postJSON, _ := json.Marshal(&model.Post{Id: "p1", ChannelId: "ch1", UserId: userID})
+		return newWebSocketEvent(eventType, "ch1", map[string]any{"post": string(postJSON), "sender_name": senderName})
+	}
+	reactionEvent := func(userID string) *model.WebSocketEvent {
+		reactionJSON, _ := json.Marshal(&model.Reaction{PostId: "p1", ChannelId: "ch1", UserId: userID, EmojiName: "+1"})
+		return newWebSocketEvent(model.WebsocketEventReactionAdded, "ch1", map[string]any{"reaction": string(reactionJSON)})
+	}
+	parse := func(mc *MattermostClient, evt *model.WebSocketEvent) bool {
+		var kept bool
+		switch evt.EventType() {
+		case model.WebsocketEventPosted:
+			post, _ := mc.parsePostedEvent(evt)
+			kept = post != nil
+		case model.WebsocketEventPostEdited:
+			post, _ := mc.parsePostEditedEvent(evt)
+			kept = post != nil
+		case model.WebsocketEventPostDeleted:
+			post, _ := mc.parsePostDeletedEvent(evt)
+			kept = post != nil
+		case model.WebsocketEventReactionAdded:
+			reaction, _ := mc.parseReactionEvent(evt)
+			kept = reaction != nil
+		}
+		return kept
+	}
+	tests := []struct {
+		name      string
+		evt       *model.WebSocketEvent
+		usernames []string
+		wantKept  bool
+	}{
+		{"test notification post", postEvent(model.WebsocketEventPosted, "system-bot-id", "@system-bot"), nil, false},
+		{"system user edit", postEvent(model.WebsocketEventPostEdited, "system-bot-id", "@system-bot"), nil, false},
+		{"system user delete", postEvent(model.WebsocketEventPostDeleted, "system-bot-id", "@system-bot"), nil, false},
+		{"system user reaction looked up", reactionEvent("system-bot-id"), nil, false},
+		{"regular post", postEvent(model.WebsocketEventPosted, "alice-id", "@alice"), nil, true},
+		{"regular reaction", reactionEvent("alice-id"), nil, true},
+		{"configured user", postEvent(model.WebsocketEventPosted, "alice-id", "@alice"), []string{"alice"}, false},
+		{"denylist disabled", postEvent(model.WebsocketEventPosted, "system-bot-id", "@system-bot"), []string{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.SystemUsers.Usernames = tt.usernames
+			if got := parse(mc, tt.evt); got != tt.wantKept {
+				t.Errorf("event kept = %v, want %v", got, tt.wantKept)
+			}
+			wantDrops := uint64(1)
+			if tt.wantKept {
+				wantDrops = 0
+			}
+			if got := mc.connector.metrics().echoDrops.get(echoLayerSystemUser); got != wantDrops {
+				t.Errorf("system user drops = %d, want %d", got, wantDrops)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+)
+
+// teamParentID returns the parent space of a channel's portal, which is its
+// team's space, or nil for DMs and when team spaces are disabled.
+func (m *MattermostClient) teamParentID(channel *model.Channel) *networkid.PortalID {
+	if !m.connector.Config.TeamSpaces || channel.TeamId == "" {
+		return nil
+	}
+	parentID := MakeTeamPortalID(channel.TeamId)
+	return &parentID
+}
+
+// teamToChatInfo converts a Mattermost team to the bridgev2.ChatInfo of its
+// space. The only member managed by the bridge is the logged-in user; other
+// users see the rooms of the channels they are in.
+func (m *MattermostClient) teamToChatInfo(team *model.Team) *bridgev2.ChatInfo {
+	spaceType := database.RoomTypeSpace
+	name := team.DisplayName
+	if name == "" {
+		name = team.Name
+	}
+	topic := team.Description
+	return &bridgev2.ChatInfo{
+		Name:   &name,
+		Topic:  &topic,
+		Avatar: m.teamIconAvatar(team.Id, team.LastTeamIconUpdate),
+		Type:   &spaceType,
+		Members: &bridgev2.ChatMemberList{
+			MemberMap: map[networkid.UserID]bridgev2.ChatMember{
+				MakeUserID(m.userID): {
+					EventSender: bridgev2.EventSender{IsFromMe: true, Sender: MakeUserID(m.userID)},
+					Membership:  event.MembershipJoin,
+				},
+			},
+		},
+	}
+}
+
+// getTeamChatInfo fetches a team and returns the ChatInfo of its space.
+func (m *MattermostClient) getTeamChatInfo(ctx context.Context, teamID string) (*bridgev2.ChatInfo, error) {
+	team, _, err := m.client.GetTeam(ctx, teamID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team info: %w", err)
+	}
+	return m.teamToChatInfo(team), nil
+}
+
+// parseTeamMemberEvent extracts added_to_team and leave_team data. Returns
+// ok=false to skip.
+func parseTeamMemberEvent(evt *model.WebSocketEvent) (teamID, userID string, ok bool) {
+	teamID, _ = evt.GetData()["team_id"].(string)
+	userID, _ = evt.GetData()["user_id"].(string)
+	if teamID == "" || userID == "" {
+		return "", "", false
+	}
+	return teamID, userID, true
+}
+
+// handleAddedToTeam syncs the channels of a team the logged-in user was
+// added to, which creates their rooms subject to portal_creation and adds
+// them to the team's space. Other users' team changes have no Matrix side:
+// their channel joins arrive as user_added events.
+func (m *MattermostClient) handleAddedToTeam(evt *model.WebSocketEvent) {
+	teamID, userID, ok := parseTeamMemberEvent(evt)
+	if !ok {
+		m.log.Warn().Msg("Added to team event missing team or user ID")
+		return
+	}
+	if userID != m.userID {
+		return
+	}
+	m.log.Info().Str("team_id", teamID).Msg("Added to team, syncing its channels")
+
+	ctx := m.log.WithContext(context.Background())
+	if m.connector.Config.TeamSpaces {
+		m.queueTeamResync(teamID)
+	}
+	channels, _, err := m.client.GetChannelsForTeamForUser(ctx, teamID, m.userID, false, "")
+	if err != nil {
+		m.log.Error().Err(err).Str("team_id", teamID).Msg("Failed to fetch team channels")
+		return
+	}
+	for _, ch := range channels {
+		if !m.connector.OwnsChannel(ch.Id) {
+			continue
+		}
+		m.queueChannelSync(ctx, ch, m.allowCreatePortal(ctx, ch.Id, ch.Name, ch.TeamId, false))
+	}
+}
+
+// handleLeaveTeam removes the logged-in user from a team's space and from
+// the rooms of its channels when they leave or are removed from the team.
+// Mattermost also sends user_removed for each channel, so the room removals
+// are only a fallback.
+func (m *MattermostClient) handleLeaveTeam(evt *model.WebSocketEvent) {
+	teamID, userID, ok := parseTeamMemberEvent(evt)
+	if !ok {
+		m.log.Warn().Msg("Leave team event missing team or user ID")
+		return
+	}
+	if userID != m.userID || !m.connector.Config.TeamSpaces {
+		return
+	}
+	m.log.Info().Str("team_id", teamID).Msg("Left team, leaving its space")
+
+	self := map[networkid.UserID]bridgev2.ChatMember{
+		MakeUserID(m.userID): {
+			EventSender: bridgev2.EventSender{IsFromMe: true, Sender: MakeUserID(m.userID)},
+			Membership:  event.MembershipLeave,
+		},
+	}
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatInfoChange{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatInfoChange,
+			PortalKey: makeTeamPortalKey(teamID),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("team_id", teamID)
+			},
+		},
+		ChatInfoChange: &bridgev2.ChatInfoChange{
+			MemberChanges: &bridgev2.ChatMemberList{MemberMap: self},
+		},
+	})
+
+	if m.connector.Bridge == nil || m.connector.Bridge.DB == nil {
+		return
+	}
+	ctx := m.log.WithContext(context.Background())
+	children, err := m.connector.Bridge.DB.Portal.GetChildren(ctx, makeTeamPortalKey(teamID))
+	if err != nil {
+		m.log.Warn().Err(err).Str("team_id", teamID).Msg("Failed to get rooms of left team")
+		return
+	}
+	for _, child := range children {
+		// Thread rooms follow their channel's members.
+		if _, _, ok := ParseThreadPortalID(child.ID); ok {
+			continue
+		}
+		channelID := ParsePortalID(child.ID)
+		if m.connector.OwnsChannel(channelID) {
+			m.queueMemberChange(channelID, m.senderFor(m.userID), m.userID, event.MembershipLeave)
+		}
+	}
+}
+
+// parseUpdateTeamEvent extracts the team from an update_team event.
+func parseUpdateTeamEvent(evt *model.WebSocketEvent) (*model.Team, error) {
+	teamJSON, ok := evt.GetData()["team"].(string)
+	if !ok {
+		return nil, fmt.Errorf("update team event missing team data")
+	}
+	var team model.Team
+	if err := json.Unmarshal([]byte(teamJSON), &team); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team: %w", err)
+	}
+	if team.Id == "" {
+		return nil, fmt.Errorf("update team event has no team ID")
+	}
+	return &team, nil
+}
+
+// handleUpdateTeam updates the team's space when the team is renamed or its
+// icon changes, and makes the next channel sync pick up the new icon for
+// channel avatars.
+func (m *MattermostClient) handleUpdateTeam(evt *model.WebSocketEvent) {
+	team, err := parseUpdateTeamEvent(evt)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to parse update team event")
+		return
+	}
+	m.forgetTeamIcon(team.Id)
+	if !m.connector.Config.TeamSpaces {
+		return
+	}
+	info := m.teamToChatInfo(team)
+	// Membership isn't part of team updates.
+	info.Members = nil
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatInfoChange{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatInfoChange,
+			PortalKey: makeTeamPortalKey(team.Id),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("team_id", team.Id)
+			},
+		},
+		ChatInfoChange: &bridgev2.ChatInfoChange{ChatInfo: info},
+	})
+}
+
+// queueTeamResync queues a resync of a team's space. The space itself is
+// created by the bridge once a room of the team needs it.
+func (m *MattermostClient) queueTeamResync(teamID string) {
+	m.eventSender.QueueRemoteEvent(m.userLogin, &simplevent.ChatResync{
+		EventMeta: simplevent.EventMeta{
+			Type:      bridgev2.RemoteEventChatResync,
+			PortalKey: makeTeamPortalKey(teamID),
+			LogContext: func(c zerolog.Context) zerolog.Context {
+				return c.Str("team_id", teamID)
+			},
+		},
+		GetChatInfoFunc: m.GetChatInfo,
+	})
+}
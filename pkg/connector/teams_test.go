@@ -0,0 +1,316 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+)
+
+func updateTeamEvent(t *testing.T, team *model.Team) *model.WebSocketEvent {
+	t.Helper()
+	data, err := json.Marshal(team)
+	if err != nil {
+		t.Fatalf("marshal team: %v", err)
+	}
+	return newWebSocketEvent(model.WebsocketEventUpdateTeam, "", map[string]any{"team": string(data)})
+}
+
+func TestTeamParentID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		teamSpaces bool
+		channel    *model.Channel
+		want       string
+	}{
+		{"disabled", false, &model.Channel{Id: "ch1", TeamId: "team1"}, ""},
+		{"team channel", true, &model.Channel{Id: "ch1", TeamId: "team1"}, "team:team1"},
+		{"direct message", true, &model.Channel{Id: "dm1", Type: model.ChannelTypeDirect}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient()
+			client.connector.Config.TeamSpaces = tt.teamSpaces
+			got := client.teamParentID(tt.channel)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("parent: got %q, want none", *got)
+				}
+				return
+			}
+			if got == nil || string(*got) != tt.want {
+				t.Errorf("parent: got %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelToChatInfo_TeamSpace(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	client.connector.Config.TeamSpaces = true
+	info := client.channelToChatInfo(&model.Channel{Id: "ch1", TeamId: "team1", Type: model.ChannelTypeOpen}, nil)
+	if info.ParentID == nil || *info.ParentID != MakeTeamPortalID("team1") {
+		t.Errorf("parent: got %v, want team1's space", info.ParentID)
+	}
+}
+
+func TestHandleChannelUpdated_MovedTeam(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.TeamSpaces = true
+	mc.handleEvent(channelUpdatedEvent(t, &model.Channel{Id: "ch1", TeamId: "team2", Type: model.ChannelTypeOpen, Name: "moved"}))
+
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	change, ok := events[0].(*simplevent.ChatInfoChange)
+	if !ok {
+		t.Fatalf("expected ChatInfoChange, got %T", events[0])
+	}
+	parent := change.ChatInfoChange.ChatInfo.ParentID
+	if parent == nil || *parent != MakeTeamPortalID("team2") {
+		t.Errorf("parent: got %v, want team2's space", parent)
+	}
+}
+
+func TestGetChatInfo_Team(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Teams["my-user-id"] = []*model.Team{{Id: "team1", Name: "eng", DisplayName: "Engineering", Description: "Builders"}}
+	mc := newFullTestClient(fake.Server.URL)
+
+	portal := makeTestPortal("")
+	portal.ID = MakeTeamPortalID("team1")
+	info, err := mc.GetChatInfo(context.Background(), portal)
+	if err != nil {
+		t.Fatalf("GetChatInfo: %v", err)
+	}
+	if info.Type == nil || *info.Type != database.RoomTypeSpace {
+		t.Errorf("type: got %v, want space", info.Type)
+	}
+	if info.Name == nil || *info.Name != "Engineering" {
+		t.Errorf("name: got %v", info.Name)
+	}
+	if info.Topic == nil || *info.Topic != "Builders" {
+		t.Errorf("topic: got %v", info.Topic)
+	}
+	if info.Avatar != nil {
+		t.Errorf("avatar: got %+v, want none for a team without an icon", info.Avatar)
+	}
+	if info.Members == nil || len(info.Members.MemberMap) != 1 || !info.Members.MemberMap[MakeUserID("my-user-id")].IsFromMe {
+		t.Errorf("members: got %+v, want only the logged-in user", info.Members)
+	}
+}
+
+func TestGetChatInfo_TeamNotFound(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+
+	portal := makeTestPortal("")
+	portal.ID = MakeTeamPortalID("missing")
+	if _, err := mc.GetChatInfo(context.Background(), portal); err == nil {
+		t.Fatal("expected error for a missing team")
+	}
+}
+
+func TestHandleAddedToTeam(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.ChannelsForTeamUser["team2:my-user-id"] = []*model.Channel{
+		{Id: "ch1", TeamId: "team2", Name: "town-square", Type: model.ChannelTypeOpen},
+	}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.TeamSpaces = true
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventAddedToTeam, "", map[string]any{"team_id": "team2", "user_id": mc.userID}))
+
+	events := testMock(mc).Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	space, ok := events[0].(*simplevent.ChatResync)
+	if !ok || space.PortalKey != makeTeamPortalKey("team2") || space.GetChatInfoFunc == nil {
+		t.Errorf("expected a resync of team2's space, got %+v", events[0])
+	}
+	room, ok := events[1].(*simplevent.ChatResync)
+	if !ok || room.PortalKey != makePortalKey("ch1") || !room.CreatePortal {
+		t.Fatalf("expected ch1 to be created, got %+v", events[1])
+	}
+	if room.ChatInfo.ParentID == nil || *room.ChatInfo.ParentID != MakeTeamPortalID("team2") {
+		t.Errorf("ch1 parent: got %v, want team2's space", room.ChatInfo.ParentID)
+	}
+}
+
+func TestHandleAddedToTeam_PortalCreation(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.ChannelsForTeamUser["team2:my-user-id"] = []*model.Channel{
+		{Id: "ch1", TeamId: "team2", Type: model.ChannelTypeOpen},
+	}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.PortalCreation = PortalCreationConfig{Policy: PortalCreationNever}
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventAddedToTeam, "", map[string]any{"team_id": "team2", "user_id": mc.userID}))
+
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if room, ok := events[0].(*simplevent.ChatResync); !ok || room.CreatePortal {
+		t.Errorf("expected a resync that doesn't create the room, got %+v", events[0])
+	}
+}
+
+func TestHandleAddedToTeam_Ignored(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		data map[string]any
+	}{
+		{"other user", map[string]any{"team_id": "team2", "user_id": "bob-id"}},
+		{"missing team", map[string]any{"user_id": "my-user-id"}},
+		{"missing user", map[string]any{"team_id": "team2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			mc := newFullTestClient(fake.Server.URL)
+			mc.connector.Config.TeamSpaces = true
+			mc.handleEvent(newWebSocketEvent(model.WebsocketEventAddedToTeam, "", tt.data))
+			if events := testMock(mc).Events(); len(events) != 0 {
+				t.Errorf("expected no events, got %d", len(events))
+			}
+			if fake.CalledPath("/api/v4/users/my-user-id/teams/team2/channels") {
+				t.Error("team channels should not be fetched")
+			}
+		})
+	}
+}
+
+func TestHandleLeaveTeam(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.TeamSpaces = true
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventLeaveTeam, "", map[string]any{"team_id": "team1", "user_id": mc.userID}))
+
+	change, member := memberChange(t, mc)
+	if change.PortalKey != makeTeamPortalKey("team1") {
+		t.Errorf("portal key: got %+v", change.PortalKey)
+	}
+	if !member.IsFromMe || member.Membership != event.MembershipLeave {
+		t.Errorf("member: got %+v, want the logged-in user to leave", member)
+	}
+}
+
+func TestHandleLeaveTeam_Ignored(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		teamSpaces bool
+		userID     string
+	}{
+		{"spaces disabled", false, "my-user-id"},
+		{"other user", true, "bob-id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newFullTestClient("http://unused")
+			mc.connector.Config.TeamSpaces = tt.teamSpaces
+			mc.handleEvent(newWebSocketEvent(model.WebsocketEventLeaveTeam, "", map[string]any{"team_id": "team1", "user_id": tt.userID}))
+			if events := testMock(mc).Events(); len(events) != 0 {
+				t.Errorf("expected no events, got %d", len(events))
+			}
+		})
+	}
+}
+
+func TestParseUpdateTeamEvent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr bool
+	}{
+		{"valid", map[string]any{"team": `{"id":"team1","display_name":"Eng"}`}, false},
+		{"missing", map[string]any{}, true},
+		{"invalid JSON", map[string]any{"team": "{"}, true},
+		{"no ID", map[string]any{"team": `{"display_name":"Eng"}`}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseUpdateTeamEvent(newWebSocketEvent(model.WebsocketEventUpdateTeam, "", tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err: got %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleUpdateTeam(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Config.TeamSpaces = true
+	mc.teamIcons = newBoundedCache[string, int64](10, 0, nil)
+	mc.teamIcons.set("team1", 1)
+	mc.handleEvent(updateTeamEvent(t, &model.Team{Id: "team1", Name: "eng", DisplayName: "Engineering", LastTeamIconUpdate: 2}))
+
+	events := testMock(mc).Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	change, ok := events[0].(*simplevent.ChatInfoChange)
+	if !ok {
+		t.Fatalf("expected ChatInfoChange, got %T", events[0])
+	}
+	if change.PortalKey != makeTeamPortalKey("team1") {
+		t.Errorf("portal key: got %+v", change.PortalKey)
+	}
+	info := change.ChatInfoChange.ChatInfo
+	if info.Name == nil || *info.Name != "Engineering" {
+		t.Errorf("name: got %v", info.Name)
+	}
+	if info.Avatar == nil || info.Avatar.ID != "team_team1_2" {
+		t.Errorf("avatar: got %+v", info.Avatar)
+	}
+	if info.Members != nil {
+		t.Error("members should not be touched by a team update")
+	}
+	if _, ok := mc.teamIcons.get("team1"); ok {
+		t.Error("cached team icon should be forgotten")
+	}
+}
+
+func TestHandleUpdateTeam_SpacesDisabled(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.teamIcons = newBoundedCache[string, int64](10, 0, nil)
+	mc.teamIcons.set("team1", 1)
+	mc.handleEvent(updateTeamEvent(t, &model.Team{Id: "team1", LastTeamIconUpdate: 2}))
+
+	if events := testMock(mc).Events(); len(events) != 0 {
+		t.Errorf("expected no events, got %d", len(events))
+	}
+	if _, ok := mc.teamIcons.get("team1"); ok {
+		t.Error("cached team icon should be forgotten so channel avatars update")
+	}
+}
@@ -6,18 +6,35 @@
 package connector
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog"
+	"go.mau.fi/util/dbutil"
+	"maunium.net/go/mautrix"
 	"maunium.net/go/mautrix/bridgev2"
 	"maunium.net/go/mautrix/bridgev2/database"
 	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/crypto/attachment"
+	"maunium.net/go/mautrix/event"
 	"maunium.net/go/mautrix/id"
 )
 
@@ -78,10 +95,110 @@ type fakeMM struct {
 	ChannelsForUser map[string][]*model.Channel
 	// Files maps file ID to model.FileInfo.
 	Files map[string]*model.FileInfo
+	// FileData maps file ID to file content for downloads.
+	FileData map[string][]byte
+	// FileDelay delays file downloads, unless the client gives up first.
+	FileDelay time.Duration
 	// Posts maps channel ID to PostList for backfill endpoints.
 	Posts map[string]*model.PostList
+	// Pinned maps channel IDs to their pinned posts.
+	Pinned map[string]*model.PostList
+	// PaginatePosts makes the channel posts endpoint honour the before,
+	// after and per_page parameters instead of returning the whole list.
+	PaginatePosts bool
 	// FailEndpoints causes specific path prefixes to return 500.
 	FailEndpoints map[string]bool
+	// EndpointErrors makes paths containing a key fail with the AppError's
+	// status code and body.
+	EndpointErrors map[string]*model.AppError
+	// RejectTokens makes requests with these bearer tokens fail with 401
+	// and the given error.
+	RejectTokens map[string]*model.AppError
+	// Bots maps bot user ID to model.Bot, including disabled bots.
+	Bots map[string]*model.Bot
+	// Statuses maps user ID to status, updated by UpdateUserStatus.
+	Statuses map[string]*model.Status
+	// ProfileImages maps user ID to the last uploaded profile image. Resetting
+	// the image to the default removes the entry.
+	ProfileImages map[string][]byte
+	// Uploads records uploaded files, guarded by mu.
+	Uploads []*model.FileInfo
+	// Logins maps login ID to the credentials accepted by POST /users/login.
+	Logins map[string]fakeLogin
+	// WebappPlugins lists the plugins returned by GET /plugins/webapp.
+	WebappPlugins []*model.Manifest
+	// ClientConfig is returned by GET /config/client.
+	ClientConfig map[string]string
+	// OAuth2Grants maps authorization codes and refresh tokens to the
+	// response of POST /oauth/access_token. Others are rejected with 400.
+	OAuth2Grants map[string]*model.AccessResponse
+	// Hooks maps incoming webhook ID to the webhooks created by POST
+	// /hooks/incoming. Posting to a hook adds the post to the channel's
+	// Posts.
+	Hooks map[string]*model.IncomingWebhook
+	// TeamMembers maps team ID to its members, for the team member lookups
+	// by user ID.
+	TeamMembers map[string][]*model.TeamMember
+}
+
+// fakeLogin is an account that can log in with a password, and an MFA code
+// if MFACode is set.
+type fakeLogin struct {
+	UserID   string
+	Password string
+	MFACode  string
+	// Token is the session token returned on success.
+	Token string
+}
+
+// postsSince returns the posts of pl created, edited or deleted at or after
+// since, like the since query parameter of the channel posts endpoint.
+func postsSince(pl *model.PostList, since string) *model.PostList {
+	ts, _ := strconv.ParseInt(since, 10, 64)
+	page := model.NewPostList()
+	for _, post := range pl.ToSlice() {
+		if post.CreateAt >= ts || post.UpdateAt >= ts || post.DeleteAt >= ts {
+			page.AddPost(post)
+			page.AddOrder(post.Id)
+		}
+	}
+	return page
+}
+
+// paginatePosts returns the page of pl selected by the before, after and
+// per_page query parameters, ordered newest first like Mattermost.
+func paginatePosts(pl *model.PostList, query url.Values) *model.PostList {
+	posts := pl.ToSlice()
+	sort.Slice(posts, func(i, j int) bool { return posts[i].CreateAt > posts[j].CreateAt })
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 60
+	}
+	index := func(id string) int {
+		for i, post := range posts {
+			if post.Id == id {
+				return i
+			}
+		}
+		return -1
+	}
+	if before := query.Get("before"); before != "" {
+		posts = posts[index(before)+1:]
+		posts = posts[:min(perPage, len(posts))]
+	} else if after := query.Get("after"); after != "" {
+		if i := index(after); i >= 0 {
+			posts = posts[:i]
+		}
+		posts = posts[max(len(posts)-perPage, 0):]
+	} else {
+		posts = posts[:min(perPage, len(posts))]
+	}
+	page := model.NewPostList()
+	for _, post := range posts {
+		page.AddPost(post)
+		page.AddOrder(post.Id)
+	}
+	return page
 }
 
 func newFakeMM() *fakeMM {
@@ -94,8 +211,19 @@ func newFakeMM() *fakeMM {
 		ChannelsForTeamUser: make(map[string][]*model.Channel),
 		ChannelsForUser:     make(map[string][]*model.Channel),
 		Files:               make(map[string]*model.FileInfo),
+		FileData:            make(map[string][]byte),
 		Posts:               make(map[string]*model.PostList),
+		Pinned:              make(map[string]*model.PostList),
 		FailEndpoints:       make(map[string]bool),
+		EndpointErrors:      make(map[string]*model.AppError),
+		RejectTokens:        make(map[string]*model.AppError),
+		Bots:                make(map[string]*model.Bot),
+		Statuses:            make(map[string]*model.Status),
+		ProfileImages:       make(map[string][]byte),
+		Logins:              make(map[string]fakeLogin),
+		OAuth2Grants:        make(map[string]*model.AccessResponse),
+		Hooks:               make(map[string]*model.IncomingWebhook),
+		TeamMembers:         make(map[string][]*model.TeamMember),
 	}
 	f.Server = httptest.NewServer(http.HandlerFunc(f.handler))
 	return f
@@ -151,9 +279,55 @@ func (f *fakeMM) handler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	for prefix, appErr := range f.EndpointErrors {
+		if strings.Contains(r.URL.Path, prefix) {
+			w.WriteHeader(appErr.StatusCode)
+			_ = json.NewEncoder(w).Encode(appErr)
+			return
+		}
+	}
+
+	auth := r.Header.Get("Authorization")
+	for tok, appErr := range f.RejectTokens {
+		if auth == "BEARER "+tok || auth == "Bearer "+tok {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(appErr)
+			return
+		}
+	}
+
 	path := r.URL.Path
 
 	switch {
+	// GET /api/v4/channels/{channel_id}/pinned
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/channels/") && strings.HasSuffix(path, "/pinned"):
+		channelID := strings.Split(path, "/")[4]
+		f.mu.Lock()
+		pinned := f.Pinned[channelID]
+		f.mu.Unlock()
+		if pinned == nil {
+			pinned = model.NewPostList()
+		}
+		_ = json.NewEncoder(w).Encode(pinned)
+
+	// POST|DELETE /api/v4/users/{user_id}/posts/{post_id}/ack
+	case strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/ack"):
+		if r.Method == "POST" {
+			parts := strings.Split(path, "/")
+			_ = json.NewEncoder(w).Encode(&model.PostAcknowledgement{UserId: parts[4], PostId: parts[6], AcknowledgedAt: model.GetMillis()})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	// GET /api/v4/bots/{bot_user_id}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/bots/"):
+		if bot, ok := f.Bots[path[len("/api/v4/bots/"):]]; ok {
+			_ = json.NewEncoder(w).Encode(bot)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+
 	// GET /api/v4/users/me
 	case r.Method == "GET" && path == "/api/v4/users/me":
 		uid := f.resolveToken(r)
@@ -168,6 +342,32 @@ func (f *fakeMM) handler(w http.ResponseWriter, r *http.Request) {
 		}
 		w.WriteHeader(http.StatusNotFound)
 
+	// POST /api/v4/users/ids
+	case r.Method == "POST" && path == "/api/v4/users/ids":
+		var ids []string
+		_ = json.Unmarshal(body, &ids)
+		users := []*model.User{}
+		for _, uid := range ids {
+			if u, ok := f.Users[uid]; ok {
+				users = append(users, u)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(users)
+
+	// POST /api/v4/users/search
+	case r.Method == "POST" && path == "/api/v4/users/search":
+		var search model.UserSearch
+		_ = json.Unmarshal(body, &search)
+		users := []*model.User{}
+		for _, u := range f.Users {
+			if !strings.Contains(u.Username, search.Term) || (search.TeamId != "" && !slices.ContainsFunc(f.Teams[u.Id], func(t *model.Team) bool { return t.Id == search.TeamId })) {
+				continue
+			}
+			users = append(users, u)
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+		_ = json.NewEncoder(w).Encode(users[:min(len(users), max(search.Limit, 1))])
+
 	// GET /api/v4/users/{user_id}/channels (GetChannelsForUserWithLastDeleteAt)
 	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/channels") && !strings.Contains(path, "/teams/"):
 		parts := strings.Split(path, "/")
@@ -210,6 +410,12 @@ func (f *fakeMM) handler(w http.ResponseWriter, r *http.Request) {
 		if len(parts) >= 6 {
 			chID := parts[4]
 			if pl, ok := f.Posts[chID]; ok {
+				if f.PaginatePosts {
+					pl = paginatePosts(pl, r.URL.Query())
+				}
+				if since := r.URL.Query().Get("since"); since != "" {
+					pl = postsSince(pl, since)
+				}
 				_ = json.NewEncoder(w).Encode(pl)
 				return
 			}
@@ -217,6 +423,149 @@ func (f *fakeMM) handler(w http.ResponseWriter, r *http.Request) {
 		// Return empty post list.
 		_ = json.NewEncoder(w).Encode(model.NewPostList())
 
+	// GET /api/v4/teams/{team_id}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/teams/") && !strings.Contains(path[len("/api/v4/teams/"):], "/"):
+		teamID := path[len("/api/v4/teams/"):]
+		for _, teams := range f.Teams {
+			for _, team := range teams {
+				if team.Id == teamID {
+					_ = json.NewEncoder(w).Encode(team)
+					return
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+
+	// GET /api/v4/posts/{post_id}/thread (GetPostThread)
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/posts/") && strings.HasSuffix(path, "/thread"):
+		rootID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/v4/posts/"), "/thread")
+		thread := model.NewPostList()
+		for _, pl := range f.Posts {
+			for id, post := range pl.Posts {
+				if id == rootID || post.RootId == rootID {
+					thread.AddPost(post)
+					thread.AddOrder(id)
+				}
+			}
+		}
+		if _, ok := thread.Posts[rootID]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "app.post.get.app_error"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(thread)
+
+	// POST /api/v4/posts/ids (GetPostsByIds)
+	case r.Method == "POST" && path == "/api/v4/posts/ids":
+		var ids []string
+		_ = json.Unmarshal(body, &ids)
+		posts := []*model.Post{}
+		for _, postID := range ids {
+			for _, pl := range f.Posts {
+				if post, ok := pl.Posts[postID]; ok {
+					posts = append(posts, post)
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(posts)
+
+	// GET /api/v4/posts/{post_id}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/posts/") && !strings.Contains(path[len("/api/v4/posts/"):], "/"):
+		postID := path[len("/api/v4/posts/"):]
+		for _, pl := range f.Posts {
+			if post, ok := pl.Posts[postID]; ok {
+				_ = json.NewEncoder(w).Encode(post)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "app.post.get.app_error"})
+
+	// GET /api/v4/config/client
+	case r.Method == "GET" && path == "/api/v4/config/client":
+		cfg := f.ClientConfig
+		if cfg == nil {
+			cfg = map[string]string{}
+		}
+		_ = json.NewEncoder(w).Encode(cfg)
+
+	// GET /api/v4/plugins/webapp
+	case r.Method == "GET" && path == "/api/v4/plugins/webapp":
+		plugins := f.WebappPlugins
+		if plugins == nil {
+			plugins = []*model.Manifest{}
+		}
+		_ = json.NewEncoder(w).Encode(plugins)
+
+	// POST /api/v4/posts/{post_id}/actions/{action_id}
+	case r.Method == "POST" && strings.HasPrefix(path, "/api/v4/posts/") && strings.Contains(path, "/actions/"):
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "OK"})
+
+	// POST /api/v4/teams/{team_id}/members/ids
+	case r.Method == "POST" && strings.HasPrefix(path, "/api/v4/teams/") && strings.HasSuffix(path, "/members/ids"):
+		var userIDs []string
+		_ = json.Unmarshal(body, &userIDs)
+		members := []*model.TeamMember{}
+		for _, member := range f.TeamMembers[strings.Split(path, "/")[4]] {
+			if slices.Contains(userIDs, member.UserId) {
+				members = append(members, member)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(members)
+
+	// PUT /api/v4/channels/{channel_id}/members/{user_id}/schemeRoles
+	case r.Method == "PUT" && strings.HasPrefix(path, "/api/v4/channels/") && strings.HasSuffix(path, "/schemeRoles"):
+		parts := strings.Split(path, "/")
+		var roles model.SchemeRoles
+		_ = json.Unmarshal(body, &roles)
+		f.mu.Lock()
+		members := f.ChannelMembers[parts[4]]
+		for i := range members {
+			if members[i].UserId == parts[6] {
+				members[i].SchemeAdmin = roles.SchemeAdmin
+				members[i].SchemeUser = roles.SchemeUser
+				members[i].SchemeGuest = roles.SchemeGuest
+			}
+		}
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "OK"})
+
+	// POST /api/v4/hooks/incoming
+	case r.Method == "POST" && path == "/api/v4/hooks/incoming":
+		var hook model.IncomingWebhook
+		_ = json.Unmarshal(body, &hook)
+		hook.Id = "hook-id-" + hook.ChannelId
+		f.mu.Lock()
+		f.Hooks[hook.Id] = &hook
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(&hook)
+
+	// POST /hooks/{hook_id}
+	case r.Method == "POST" && strings.HasPrefix(path, "/hooks/"):
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		hook, ok := f.Hooks[path[len("/hooks/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "invalid webhook"})
+			return
+		}
+		var req model.IncomingWebhookRequest
+		_ = json.Unmarshal(body, &req)
+		post := &model.Post{Id: "webhook-post-id", ChannelId: hook.ChannelId, UserId: "my-user-id", Message: req.Text, CreateAt: model.GetMillis()}
+		for key, value := range req.Props {
+			post.AddProp(key, value)
+		}
+		post.AddProp("override_username", req.Username)
+		if f.Posts[hook.ChannelId] == nil {
+			f.Posts[hook.ChannelId] = model.NewPostList()
+		}
+		f.Posts[hook.ChannelId].AddPost(post)
+		f.Posts[hook.ChannelId].AddOrder(post.Id)
+		_, _ = w.Write([]byte("ok"))
+
 	// POST /api/v4/posts
 	case r.Method == "POST" && path == "/api/v4/posts":
 		var post model.Post
@@ -224,9 +573,120 @@ func (f *fakeMM) handler(w http.ResponseWriter, r *http.Request) {
 		post.Id = "created-post-id"
 		_ = json.NewEncoder(w).Encode(&post)
 
+	// PUT /api/v4/channels/{channel_id}/patch
+	case r.Method == "PUT" && strings.HasPrefix(path, "/api/v4/channels/") && strings.HasSuffix(path, "/patch"):
+		chID := strings.TrimSuffix(path[len("/api/v4/channels/"):], "/patch")
+		var patch model.ChannelPatch
+		_ = json.Unmarshal(body, &patch)
+		f.mu.Lock()
+		ch, ok := f.Channels[chID]
+		if !ok {
+			ch = &model.Channel{Id: chID}
+			f.Channels[chID] = ch
+		}
+		ch.Patch(&patch)
+		_ = json.NewEncoder(w).Encode(ch)
+		f.mu.Unlock()
+
+	// PUT /api/v4/bots/{bot_user_id}
+	case r.Method == "PUT" && strings.HasPrefix(path, "/api/v4/bots/") && !strings.Contains(path[len("/api/v4/bots/"):], "/"):
+		botID := path[len("/api/v4/bots/"):]
+		var patch model.BotPatch
+		_ = json.Unmarshal(body, &patch)
+		f.mu.Lock()
+		bot, ok := f.Bots[botID]
+		if !ok {
+			bot = &model.Bot{UserId: botID}
+			f.Bots[botID] = bot
+		}
+		bot.Patch(&patch)
+		_ = json.NewEncoder(w).Encode(bot)
+		f.mu.Unlock()
+
+	// PUT /api/v4/users/{user_id}/patch
+	case r.Method == "PUT" && strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/patch"):
+		userID := strings.TrimSuffix(path[len("/api/v4/users/"):], "/patch")
+		var patch model.UserPatch
+		_ = json.Unmarshal(body, &patch)
+		f.mu.Lock()
+		user, ok := f.Users[userID]
+		if !ok {
+			user = &model.User{Id: userID}
+			f.Users[userID] = user
+		}
+		user.Patch(&patch)
+		_ = json.NewEncoder(w).Encode(user)
+		f.mu.Unlock()
+
+	// GET /api/v4/users/{user_id}/image
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/image"):
+		f.mu.Lock()
+		data, ok := f.ProfileImages[strings.TrimSuffix(path[len("/api/v4/users/"):], "/image")]
+		f.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+
+	// POST /api/v4/users/{user_id}/image
+	case r.Method == "POST" && strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/image"):
+		userID := strings.TrimSuffix(path[len("/api/v4/users/"):], "/image")
+		_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(1 << 20)
+		if err != nil || len(form.File["image"]) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		file, _ := form.File["image"][0].Open()
+		data, _ := io.ReadAll(file)
+		_ = file.Close()
+		f.mu.Lock()
+		f.ProfileImages[userID] = data
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	// DELETE /api/v4/users/{user_id}/image
+	case r.Method == "DELETE" && strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/image"):
+		f.mu.Lock()
+		delete(f.ProfileImages, strings.TrimSuffix(path[len("/api/v4/users/"):], "/image"))
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	// POST /api/v4/channels/{channel_id}/members
+	case r.Method == "POST" && strings.HasPrefix(path, "/api/v4/channels/") && strings.HasSuffix(path, "/members"):
+		chID := strings.TrimSuffix(path[len("/api/v4/channels/"):], "/members")
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		member := model.ChannelMember{ChannelId: chID, UserId: req["user_id"]}
+		f.mu.Lock()
+		f.ChannelMembers[chID] = append(f.ChannelMembers[chID], member)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(&member)
+
+	// DELETE /api/v4/channels/{channel_id}/members/{user_id}
+	case r.Method == "DELETE" && strings.HasPrefix(path, "/api/v4/channels/") && strings.Contains(path, "/members/"):
+		chID, userID, _ := strings.Cut(path[len("/api/v4/channels/"):], "/members/")
+		f.mu.Lock()
+		var kept model.ChannelMembers
+		for _, member := range f.ChannelMembers[chID] {
+			if member.UserId != userID {
+				kept = append(kept, member)
+			}
+		}
+		f.ChannelMembers[chID] = kept
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	// GET /api/v4/teams/{team_id}/image
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/teams/") && strings.HasSuffix(path, "/image"):
+		_, _ = w.Write([]byte("icon:" + strings.TrimSuffix(path[len("/api/v4/teams/"):], "/image")))
+
 	// PUT /api/v4/posts/{post_id}/patch
 	case r.Method == "PUT" && strings.HasSuffix(path, "/patch"):
-		_ = json.NewEncoder(w).Encode(&model.Post{Id: "patched"})
+		postID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/v4/posts/"), "/patch")
+		_ = json.NewEncoder(w).Encode(&model.Post{Id: postID, ChannelId: "test-channel"})
 
 	// DELETE /api/v4/posts/{post_id}
 	case r.Method == "DELETE" && strings.HasPrefix(path, "/api/v4/posts/"):
@@ -267,6 +727,20 @@ func (f *fakeMM) handler(w http.ResponseWriter, r *http.Request) {
 		}
 		w.WriteHeader(http.StatusNotFound)
 
+	// GET /api/v4/channels/{channel_id}/members/{user_id}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/channels/") && strings.Contains(path, "/members/"):
+		parts := strings.Split(path, "/")
+		if len(parts) == 7 {
+			for _, member := range f.ChannelMembers[parts[4]] {
+				if member.UserId == parts[6] {
+					_ = json.NewEncoder(w).Encode(member)
+					return
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+
 	// GET /api/v4/channels/{channel_id}/members
 	case r.Method == "GET" && strings.HasSuffix(path, "/members"):
 		parts := strings.Split(path, "/")
@@ -306,17 +780,216 @@ func (f *fakeMM) handler(w http.ResponseWriter, r *http.Request) {
 		}
 		w.WriteHeader(http.StatusNotFound)
 
+	// GET /api/v4/files/{file_id}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/files/"):
+		if data, ok := f.FileData[path[len("/api/v4/files/"):]]; ok {
+			select {
+			case <-time.After(f.FileDelay):
+			case <-r.Context().Done():
+				return
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			_, _ = w.Write(data)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+
 	// POST /api/v4/files (upload)
 	case r.Method == "POST" && path == "/api/v4/files":
+		info := &model.FileInfo{Id: "uploaded-file-id", Name: "upload"}
+		// Raw request body uploads pass metadata in the query string.
+		if name := r.URL.Query().Get("filename"); name != "" {
+			info.Name = name
+			info.ChannelId = r.URL.Query().Get("channel_id")
+			info.CreatorId = f.resolveToken(r)
+			info.MimeType = r.Header.Get("Content-Type")
+			info.Size = int64(len(body))
+		}
+		f.mu.Lock()
+		f.Uploads = append(f.Uploads, info)
+		f.mu.Unlock()
 		_ = json.NewEncoder(w).Encode(&model.FileUploadResponse{
-			FileInfos: []*model.FileInfo{{Id: "uploaded-file-id", Name: "upload"}},
+			FileInfos: []*model.FileInfo{info},
 		})
 
+	// GET /api/v4/teams/{team_id}/channels/name/{channel_name}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/teams/") && strings.Contains(path, "/channels/name/"):
+		parts := strings.Split(path, "/")
+		if len(parts) == 8 {
+			for _, ch := range f.Channels {
+				if ch.TeamId == parts[4] && ch.Name == parts[7] {
+					_ = json.NewEncoder(w).Encode(ch)
+					return
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+
+	// GET /api/v4/users/username/{username}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/users/username/"):
+		name := path[len("/api/v4/users/username/"):]
+		for _, u := range f.Users {
+			if u.Username == name {
+				_ = json.NewEncoder(w).Encode(u)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+
+	// GET /api/v4/users/email/{email}
+	case r.Method == "GET" && strings.HasPrefix(path, "/api/v4/users/email/"):
+		email := path[len("/api/v4/users/email/"):]
+		for _, u := range f.Users {
+			if u.Email == email {
+				_ = json.NewEncoder(w).Encode(u)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+
+	// POST /api/v4/channels/direct
+	case r.Method == "POST" && path == "/api/v4/channels/direct":
+		var ids []string
+		_ = json.Unmarshal(body, &ids)
+		ch := &model.Channel{Id: "dm-" + strings.Join(ids, "-"), Type: model.ChannelTypeDirect, Name: strings.Join(ids, "__")}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(ch)
+
+	// POST /api/v4/users/status/ids
+	case r.Method == "POST" && path == "/api/v4/users/status/ids":
+		var ids []string
+		_ = json.Unmarshal(body, &ids)
+		f.mu.Lock()
+		statuses := []*model.Status{}
+		for _, uid := range ids {
+			if st, ok := f.Statuses[uid]; ok {
+				statuses = append(statuses, st)
+			}
+		}
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(statuses)
+
+	// PUT /api/v4/users/{user_id}/status
+	case r.Method == "PUT" && strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/status"):
+		var st model.Status
+		_ = json.Unmarshal(body, &st)
+		f.mu.Lock()
+		f.Statuses[st.UserId] = &st
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(&st)
+
+	// POST /api/v4/users/login
+	case r.Method == "POST" && path == "/api/v4/users/login":
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		login, ok := f.Logins[req["login_id"]]
+		if !ok || login.Password != req["password"] {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "api.user.login.invalid_credentials_email_username", "status_code": http.StatusUnauthorized})
+			return
+		}
+		if login.MFACode != "" && login.MFACode != req["token"] {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "mfa.validate_token.authenticate.app_error", "status_code": http.StatusUnauthorized})
+			return
+		}
+		w.Header().Set(model.HeaderToken, login.Token)
+		_ = json.NewEncoder(w).Encode(f.Users[login.UserID])
+
 	// POST /api/v4/users/logout
 	case r.Method == "POST" && path == "/api/v4/users/logout":
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 
+	// PUT /api/v4/users/sessions/device
+	case r.Method == "PUT" && path == "/api/v4/users/sessions/device":
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	// POST /oauth/access_token
+	case r.Method == "POST" && path == "/oauth/access_token":
+		form, _ := url.ParseQuery(string(body))
+		key := form.Get("code")
+		if form.Get("grant_type") == model.RefreshTokenGrantType {
+			key = form.Get("refresh_token")
+		}
+		grant, ok := f.OAuth2Grants[key]
+		if !ok || form.Get("client_secret") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "api.oauth.get_access_token.expired_code.app_error", "status_code": http.StatusBadRequest})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(grant)
+
+	// GET /api/v4/system/ping
+	case r.Method == "GET" && path == "/api/v4/system/ping":
+		w.Header().Set(model.HeaderVersionId, "10.5.0.10.5.0.abcdef.false")
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": model.StatusOk})
+
+	// POST /api/v4/bots
+	case r.Method == "POST" && path == "/api/v4/bots":
+		var bot model.Bot
+		_ = json.Unmarshal(body, &bot)
+		f.mu.Lock()
+		for _, existing := range f.Bots {
+			if existing.Username == bot.Username {
+				f.mu.Unlock()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"id": "app.user.save.username_exists.app_error", "status_code": http.StatusBadRequest})
+				return
+			}
+		}
+		bot.UserId = model.NewId()
+		f.Bots[bot.UserId] = &bot
+		f.Users[bot.UserId] = &model.User{Id: bot.UserId, Username: bot.Username, IsBot: true}
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(&bot)
+
+	// POST /api/v4/bots/{bot_user_id}/disable
+	case r.Method == "POST" && strings.HasPrefix(path, "/api/v4/bots/") && strings.HasSuffix(path, "/disable"):
+		f.mu.Lock()
+		bot, ok := f.Bots[strings.TrimSuffix(path[len("/api/v4/bots/"):], "/disable")]
+		if ok {
+			bot.DeleteAt = model.GetMillis()
+		}
+		f.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(bot)
+
+	// POST /api/v4/users/{user_id}/tokens
+	case r.Method == "POST" && strings.HasPrefix(path, "/api/v4/users/") && strings.HasSuffix(path, "/tokens"):
+		userID := strings.TrimSuffix(path[len("/api/v4/users/"):], "/tokens")
+		token := &model.UserAccessToken{Id: model.NewId(), Token: model.NewId(), UserId: userID, IsActive: true}
+		f.mu.Lock()
+		f.TokenToUser[token.Token] = userID
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(token)
+
+	// POST /api/v4/users/tokens/revoke
+	case r.Method == "POST" && path == "/api/v4/users/tokens/revoke":
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+
+	// POST /api/v4/teams/{team_id}/members
+	case r.Method == "POST" && strings.HasPrefix(path, "/api/v4/teams/") && strings.HasSuffix(path, "/members"):
+		var member model.TeamMember
+		_ = json.Unmarshal(body, &member)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(&member)
+
+	// PUT /api/v4/users/{user_id}/preferences and
+	// POST /api/v4/users/{user_id}/preferences/delete
+	case (r.Method == "PUT" && strings.HasSuffix(path, "/preferences")) ||
+		(r.Method == "POST" && strings.HasSuffix(path, "/preferences/delete")):
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "OK"})
+
 	default:
 		w.WriteHeader(http.StatusNotFound)
 		_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found: " + path})
@@ -391,3 +1064,198 @@ func makeTestPortal(channelID string) *bridgev2.Portal {
 		},
 	}
 }
+
+// newTestDB opens an in-memory SQLite database for tests that need SQL
+// storage. The pool is limited to one connection so every query sees the
+// same in-memory database.
+func newTestDB(t *testing.T) *dbutil.Database {
+	t.Helper()
+	db, err := dbutil.NewWithDialect(":memory:", "sqlite3")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	db.RawDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// newTestBridgeDB returns a migrated bridgev2 database backed by an
+// in-memory SQLite database.
+func newTestBridgeDB(t *testing.T) *database.Database {
+	t.Helper()
+	mc := &MattermostConnector{}
+	db := database.New("mattermost", mc.GetDBMetaTypes(), newTestDB(t))
+	if err := db.Upgrade(context.Background()); err != nil {
+		t.Fatalf("upgrade bridge db: %v", err)
+	}
+	return db
+}
+
+// fakeMatrixBot is a bridgev2.MatrixAPI that serves media downloads from
+// memory and records sent messages, state events and uploads. Other methods are
+// unimplemented and panic if called.
+type fakeMatrixBot struct {
+	bridgev2.MatrixAPI
+	media map[id.ContentURIString][]byte
+	// profiles are the global profiles returned by GetProfile.
+	profiles map[id.UserID]*mautrix.RespUserProfile
+	// uploadLimit makes uploads larger than this many bytes fail with
+	// M_TOO_LARGE. 0 means unlimited.
+	uploadLimit int64
+	// encrypted makes uploads behave like uploads to an encrypted room:
+	// the data is encrypted and the URL is returned in the file info.
+	encrypted bool
+
+	mu       sync.Mutex
+	sent     []*event.Content
+	states   []fakeState
+	uploaded []fakeUpload
+}
+
+// fakeState is a state event recorded by fakeMatrixBot.
+type fakeState struct {
+	Type     event.Type
+	StateKey string
+	Content  *event.Content
+}
+
+func (b *fakeMatrixBot) SendState(_ context.Context, _ id.RoomID, eventType event.Type, stateKey string, content *event.Content, _ time.Time) (*mautrix.RespSendEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.states = append(b.states, fakeState{Type: eventType, StateKey: stateKey, Content: content})
+	return &mautrix.RespSendEvent{EventID: id.EventID(fmt.Sprintf("$state%d", len(b.states)))}, nil
+}
+
+func (b *fakeMatrixBot) States() []fakeState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]fakeState(nil), b.states...)
+}
+
+// roundTripFileInfo returns a copy of file as a Matrix client would receive
+// it, serialized into an event and parsed again.
+func roundTripFileInfo(t *testing.T, file *event.EncryptedFileInfo) *event.EncryptedFileInfo {
+	t.Helper()
+	data, err := json.Marshal(file)
+	if err != nil {
+		t.Fatalf("marshal file info: %v", err)
+	}
+	var parsed event.EncryptedFileInfo
+	if err = json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("unmarshal file info: %v", err)
+	}
+	return &parsed
+}
+
+// fakeUpload is a media upload recorded by fakeMatrixBot.
+type fakeUpload struct {
+	RoomID   id.RoomID
+	FileName string
+	MimeType string
+	Data     []byte
+}
+
+func (b *fakeMatrixBot) UploadMediaStream(_ context.Context, roomID id.RoomID, size int64, _ bool, cb bridgev2.FileStreamCallback) (id.ContentURIString, *event.EncryptedFileInfo, error) {
+	if b.uploadLimit > 0 && size > b.uploadLimit {
+		return "", nil, mautrix.MTooLarge
+	}
+	var buf bytes.Buffer
+	res, err := cb(&buf)
+	if err != nil {
+		return "", nil, err
+	}
+	data := buf.Bytes()
+	var file *event.EncryptedFileInfo
+	if b.encrypted {
+		file = &event.EncryptedFileInfo{EncryptedFile: *attachment.NewEncryptedFile()}
+		file.EncryptInPlace(data)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.uploaded = append(b.uploaded, fakeUpload{
+		RoomID:   roomID,
+		FileName: res.FileName,
+		MimeType: res.MimeType,
+		Data:     data,
+	})
+	mxc := id.ContentURIString(fmt.Sprintf("mxc://test/upload%d", len(b.uploaded)))
+	if file != nil {
+		file.URL = mxc
+		return "", file, nil
+	}
+	return mxc, nil, nil
+}
+
+func (b *fakeMatrixBot) Uploads() []fakeUpload {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]fakeUpload(nil), b.uploaded...)
+}
+
+func (b *fakeMatrixBot) SendMessage(_ context.Context, _ id.RoomID, _ event.Type, content *event.Content, _ *bridgev2.MatrixSendExtra) (*mautrix.RespSendEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, content)
+	return &mautrix.RespSendEvent{EventID: id.EventID(fmt.Sprintf("$sent%d", len(b.sent)))}, nil
+}
+
+func (b *fakeMatrixBot) Sent() []*event.Content {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*event.Content(nil), b.sent...)
+}
+
+func (b *fakeMatrixBot) DownloadMediaToFile(_ context.Context, uri id.ContentURIString, file *event.EncryptedFileInfo, _ bool, callback func(*os.File) error) error {
+	if file != nil {
+		uri = file.URL
+	}
+	data, ok := b.media[uri]
+	if !ok {
+		return fmt.Errorf("media %s not found", uri)
+	}
+	if file != nil {
+		data = bytes.Clone(data)
+		if err := file.DecryptInPlace(data); err != nil {
+			return err
+		}
+	}
+	f, err := os.CreateTemp("", "fake-media-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return callback(f)
+}
+
+func (b *fakeMatrixBot) DownloadMedia(_ context.Context, uri id.ContentURIString, _ *event.EncryptedFileInfo) ([]byte, error) {
+	data, ok := b.media[uri]
+	if !ok {
+		return nil, fmt.Errorf("media %s not found", uri)
+	}
+	return data, nil
+}
+
+func (b *fakeMatrixBot) GetProfile(_ context.Context, mxid id.UserID) (*mautrix.RespUserProfile, error) {
+	profile, ok := b.profiles[mxid]
+	if !ok {
+		return nil, mautrix.MNotFound
+	}
+	return profile, nil
+}
+
+// makeTestPortalWithBot returns a test portal whose bridge bot serves the
+// given media.
+func makeTestPortalWithBot(channelID string, media map[id.ContentURIString][]byte) *bridgev2.Portal {
+	portal := makeTestPortal(channelID)
+	portal.Bridge = &bridgev2.Bridge{Bot: &fakeMatrixBot{media: media}}
+	return portal
+}
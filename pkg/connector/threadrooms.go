@@ -0,0 +1,277 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+)
+
+// defaultThreadReplyThreshold is the number of replies at which a thread
+// gets its own room when reply_threshold isn't set.
+const defaultThreadReplyThreshold = 20
+
+// maxThreadRoomNameExcerpt is the length, in runes, of the excerpt of the
+// root post in a thread room's name.
+const maxThreadRoomNameExcerpt = 50
+
+// errThreadRoomEdit is returned for room metadata, membership and power
+// level changes in thread rooms, which have no Mattermost channel of their
+// own to change.
+var errThreadRoomEdit = errors.New("thread rooms don't change their Mattermost channel")
+
+// ThreadRoomConfig controls splitting long Mattermost threads into rooms of
+// their own.
+type ThreadRoomConfig struct {
+	// Enabled bridges the replies of threads that reached ReplyThreshold
+	// into a room of their own.
+	Enabled bool `yaml:"enabled"`
+	// ReplyThreshold is the number of replies at which a thread gets its
+	// room. 0 uses defaultThreadReplyThreshold.
+	ReplyThreshold int `yaml:"reply_threshold"`
+}
+
+// validate checks that the reply threshold isn't negative.
+func (c *ThreadRoomConfig) validate() error {
+	if c.ReplyThreshold < 0 {
+		return fmt.Errorf("thread_rooms.reply_threshold must not be negative, got %d", c.ReplyThreshold)
+	}
+	return nil
+}
+
+// replyThreshold returns the number of replies at which a thread gets its
+// room.
+func (c *ThreadRoomConfig) replyThreshold() int64 {
+	if c.ReplyThreshold > 0 {
+		return int64(c.ReplyThreshold)
+	}
+	return defaultThreadReplyThreshold
+}
+
+// isThreadPortal reports whether a portal is the room of a thread.
+func isThreadPortal(portal *bridgev2.Portal) bool {
+	if portal == nil {
+		return false
+	}
+	_, _, ok := ParseThreadPortalID(portal.ID)
+	return ok
+}
+
+// postPortalKey returns the portal a new post is bridged to: its thread's
+// room when the thread has one or just reached the reply threshold, and its
+// channel's portal otherwise. Only threads of team channels whose portal
+// has a room are split, so the thread's root is in the channel's room.
+func (m *MattermostClient) postPortalKey(ctx context.Context, post *model.Post) networkid.PortalKey {
+	key := makePortalKey(post.ChannelId)
+	cfg := &m.connector.Config.ThreadRooms
+	br := m.connector.Bridge
+	if !cfg.Enabled || post.RootId == "" || br == nil || br.DB == nil {
+		return key
+	}
+	log := m.log.With().Str("channel_id", post.ChannelId).Str("root_id", post.RootId).Logger()
+	threadKey := makeThreadPortalKey(post.ChannelId, post.RootId)
+	thread, err := br.GetExistingPortalByKey(ctx, threadKey)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get thread room portal")
+		return key
+	}
+	if thread != nil {
+		return threadKey
+	}
+	channel, err := br.GetExistingPortalByKey(ctx, key)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get channel portal for thread room")
+		return key
+	}
+	if channel == nil || channel.MXID == "" || channel.RoomType != database.RoomTypeDefault {
+		return key
+	}
+	if m.threadReplyCount(ctx, post) < cfg.replyThreshold() {
+		return key
+	}
+	log.Info().Msg("Thread reached the reply threshold, bridging it into its own room")
+	return threadKey
+}
+
+// threadReplyCount returns the number of replies in a post's thread.
+// Mattermost sets it on new replies; the root post is fetched otherwise.
+func (m *MattermostClient) threadReplyCount(ctx context.Context, post *model.Post) int64 {
+	if post.ReplyCount > 0 {
+		return post.ReplyCount
+	}
+	root, _, err := m.client.GetPost(ctx, post.RootId, "")
+	if err != nil {
+		m.log.Debug().Err(err).Str("root_id", post.RootId).Msg("Failed to get thread root to count replies")
+		return 0
+	}
+	return root.ReplyCount
+}
+
+// bridgedPortalKey returns the portal a post was bridged to, so its edits,
+// deletion and reactions follow it into a thread room. Posts that weren't
+// bridged belong to their channel's portal.
+func (m *MattermostClient) bridgedPortalKey(ctx context.Context, channelID, postID string) networkid.PortalKey {
+	key := makePortalKey(channelID)
+	br := m.connector.Bridge
+	if br == nil || br.DB == nil {
+		return key
+	}
+	msg, err := br.DB.Message.GetFirstPartByID(ctx, "", MakeMessageID(postID))
+	if err != nil {
+		m.log.Warn().Err(err).Str("post_id", postID).Msg("Failed to get bridged post to find its room")
+		return key
+	}
+	if msg != nil && ParsePortalID(msg.Room.ID) == channelID && isThreadPortalKey(msg.Room) {
+		return msg.Room
+	}
+	return key
+}
+
+// isThreadPortalKey reports whether a portal key is that of a thread room.
+func isThreadPortalKey(key networkid.PortalKey) bool {
+	_, _, ok := ParseThreadPortalID(key.ID)
+	return ok
+}
+
+// getThreadChatInfo returns the info of a thread room: the channel's
+// members and space, named after the channel and the thread's root post.
+func (m *MattermostClient) getThreadChatInfo(ctx context.Context, channelID, rootID string) (*bridgev2.ChatInfo, error) {
+	channel, _, err := m.client.GetChannel(ctx, channelID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel info: %w", err)
+	}
+	root, _, err := m.client.GetPost(ctx, rootID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread root post: %w", err)
+	}
+	members, _, err := m.client.GetChannelMembers(ctx, channelID, 0, 200, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel members: %w", err)
+	}
+
+	memberList := m.channelMembersToChatMembers(members)
+	m.applyRolePowerLevels(ctx, channel, members, memberList)
+	roomType := database.RoomTypeDefault
+	name := m.threadRoomName(ctx, channel, root)
+	return &bridgev2.ChatInfo{
+		Name:     &name,
+		Members:  memberList,
+		Type:     &roomType,
+		ParentID: m.teamParentID(channel),
+		ExtraUpdates: bridgev2.MergeExtraUpdaters(
+			m.teamUpdater(channel),
+			m.autoInviteUpdater(channel),
+		),
+	}, nil
+}
+
+// threadRoomName names a thread room after its channel's room and the first
+// line of the root post.
+func (m *MattermostClient) threadRoomName(ctx context.Context, channel *model.Channel, root *model.Post) string {
+	excerpt, _, _ := strings.Cut(strings.TrimSpace(m.connector.Config.topicText(root.Message)), "\n")
+	excerpt = strings.TrimSpace(excerpt)
+	if short := truncateRunes(excerpt, maxThreadRoomNameExcerpt); short != excerpt {
+		excerpt = short + "…"
+	}
+	channelName, _ := m.channelNameAndTopic(ctx, channel)
+	switch {
+	case channelName == nil || *channelName == "":
+		if excerpt == "" {
+			return "Thread"
+		}
+		return excerpt
+	case excerpt == "":
+		return *channelName + " – thread"
+	}
+	return *channelName + " – " + excerpt
+}
+
+// threadPostHandled is the PostHandleFunc of posts bridged to a thread
+// room. The first one links the new room from the thread in the channel's
+// room.
+func (m *MattermostClient) threadPostHandled(ctx context.Context, portal *bridgev2.Portal) {
+	m.connector.watchIfRelayMissing(ctx, portal)
+	meta := portalMetadata(portal)
+	if portal.MXID == "" || meta.ThreadAnnounced {
+		return
+	}
+	if !m.announceThreadRoom(ctx, portal) {
+		return
+	}
+	meta.ThreadAnnounced = true
+	if err := portal.Save(ctx); err != nil {
+		m.log.Warn().Err(err).Str("portal_id", string(portal.ID)).Msg("Failed to save thread room announcement")
+	}
+}
+
+// announceThreadRoom sends a notice linking a thread room to the thread in
+// its channel's room, as a reply in the thread when its root is bridged.
+// It reports whether the notice was sent.
+func (m *MattermostClient) announceThreadRoom(ctx context.Context, portal *bridgev2.Portal) bool {
+	channelID, rootID, ok := ParseThreadPortalID(portal.ID)
+	if !ok {
+		return false
+	}
+	log := m.log.With().Str("channel_id", channelID).Str("root_id", rootID).Logger()
+	channel, err := portal.Bridge.GetExistingPortalByKey(ctx, makePortalKey(channelID))
+	if err != nil || channel == nil || channel.MXID == "" {
+		log.Warn().Err(err).Msg("No channel room to announce thread room in")
+		return false
+	}
+
+	link := portal.MXID.URI(portal.Bridge.Matrix.ServerName()).MatrixToURL()
+	content := &event.MessageEventContent{
+		MsgType:       event.MsgNotice,
+		Body:          "This thread continues in its own room: " + link,
+		Format:        event.FormatHTML,
+		FormattedBody: `This thread continues in <a href="` + link + `">its own room</a>.`,
+	}
+	root, err := portal.Bridge.DB.Message.GetFirstPartByID(ctx, channel.Receiver, MakeMessageID(rootID))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get thread root to announce thread room")
+	} else if root != nil && root.Room == channel.PortalKey {
+		prev := root.MXID
+		last, err := portal.Bridge.DB.Message.GetLastThreadMessage(ctx, channel.PortalKey, root.ID)
+		if err == nil && last != nil {
+			prev = last.MXID
+		}
+		content.RelatesTo = (&event.RelatesTo{}).SetThread(root.MXID, prev)
+	}
+
+	_, err = portal.Bridge.Bot.SendMessage(ctx, channel.MXID, event.EventMessage, &event.Content{Parsed: content}, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to announce thread room")
+		return false
+	}
+	log.Debug().Stringer("room_id", portal.MXID).Msg("Announced thread room")
+	return true
+}
+
+// threadPortalKeys returns the keys of a channel's thread rooms.
+func (mc *MattermostConnector) threadPortalKeys(ctx context.Context, channelID string) ([]networkid.PortalKey, error) {
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		return nil, nil
+	}
+	portals, err := mc.Bridge.DB.Portal.GetAllWithMXID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portals: %w", err)
+	}
+	var keys []networkid.PortalKey
+	for _, portal := range portals {
+		if threadChannelID, _, ok := ParseThreadPortalID(portal.ID); ok && threadChannelID == channelID {
+			keys = append(keys, portal.PortalKey)
+		}
+	}
+	return keys, nil
+}
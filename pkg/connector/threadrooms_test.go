@@ -0,0 +1,279 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/simplevent"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// newThreadRoomTestClient returns a client with thread rooms enabled, a
+// channel portal with a room and the thread root r1 with rootReplies
+// replies on the fake server.
+func newThreadRoomTestClient(t *testing.T, rootReplies int64) *MattermostClient {
+	t.Helper()
+	conn := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	conn.Config.ThreadRooms.Enabled = true
+	conn.Config.ThreadRooms.ReplyThreshold = 3
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Posts[relayTestChannel] = makePostList([]*model.Post{
+		{Id: "r1", ChannelId: relayTestChannel, Message: "Release plan\nDetails", ReplyCount: rootReplies},
+	})
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector = conn
+	return mc
+}
+
+func insertThreadPortal(t *testing.T, mc *MattermostClient, rootID string) *bridgev2.Portal {
+	t.Helper()
+	ctx := context.Background()
+	key := makeThreadPortalKey(relayTestChannel, rootID)
+	if err := mc.connector.Bridge.DB.Portal.Insert(ctx, &database.Portal{
+		BridgeID:  "mattermost",
+		PortalKey: key,
+		MXID:      id.RoomID("!thread-" + rootID + ":example.com"),
+		Metadata:  &PortalMetadata{},
+	}); err != nil {
+		t.Fatalf("insert thread portal: %v", err)
+	}
+	portal, err := mc.connector.Bridge.GetExistingPortalByKey(ctx, key)
+	if err != nil || portal == nil {
+		t.Fatalf("get thread portal: %v", err)
+	}
+	return portal
+}
+
+func TestPostPortalKey(t *testing.T) {
+	t.Parallel()
+	threadKey := makeThreadPortalKey(relayTestChannel, "r1")
+	channelKey := makePortalKey(relayTestChannel)
+	tests := []struct {
+		name        string
+		disabled    bool
+		rootReplies int64
+		post        *model.Post
+		hasRoom     bool
+		want        bool
+	}{
+		{name: "root post", rootReplies: 5, post: &model.Post{Id: "p1"}},
+		{name: "below threshold", rootReplies: 2, post: &model.Post{Id: "p1", RootId: "r1"}},
+		{name: "reached threshold", rootReplies: 3, post: &model.Post{Id: "p1", RootId: "r1"}, want: true},
+		{name: "reply count on post", post: &model.Post{Id: "p1", RootId: "r1", ReplyCount: 4}, want: true},
+		{name: "disabled", disabled: true, rootReplies: 5, post: &model.Post{Id: "p1", RootId: "r1"}},
+		{name: "thread room exists", post: &model.Post{Id: "p1", RootId: "r1"}, hasRoom: true, want: true},
+		{name: "channel without portal", rootReplies: 5, post: &model.Post{Id: "p1", RootId: "r1", ChannelId: "other"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := newThreadRoomTestClient(t, tt.rootReplies)
+			mc.connector.Config.ThreadRooms.Enabled = !tt.disabled
+			if tt.hasRoom {
+				insertThreadPortal(t, mc, "r1")
+			}
+			if tt.post.ChannelId == "" {
+				tt.post.ChannelId = relayTestChannel
+			}
+
+			got := mc.postPortalKey(context.Background(), tt.post)
+			switch {
+			case tt.want && got != threadKey:
+				t.Errorf("got %v, want the thread room", got)
+			case !tt.want && got != makePortalKey(tt.post.ChannelId):
+				t.Errorf("got %v, want the channel portal %v", got, channelKey)
+			}
+		})
+	}
+}
+
+func TestBridgedPortalKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newThreadRoomTestClient(t, 0)
+	thread := insertThreadPortal(t, mc, "r1")
+	if err := mc.connector.Bridge.DB.Message.Insert(ctx, &database.Message{
+		ID: MakeMessageID("p1"), MXID: "$p1:example.com", Room: thread.PortalKey,
+		SenderID: MakeUserID("user1"), Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+
+	if got := mc.bridgedPortalKey(ctx, relayTestChannel, "p1"); got != thread.PortalKey {
+		t.Errorf("bridged reply: got %v, want the thread room", got)
+	}
+	if got := mc.bridgedPortalKey(ctx, relayTestChannel, "p2"); got != makePortalKey(relayTestChannel) {
+		t.Errorf("unknown post: got %v, want the channel portal", got)
+	}
+}
+
+func TestQueuePost_ThreadRoom(t *testing.T) {
+	t.Parallel()
+	mc := newThreadRoomTestClient(t, 3)
+	sender := &mockEventSender{}
+	mc.eventSender = sender
+
+	mc.queuePost(context.Background(), &model.Post{Id: "p1", ChannelId: relayTestChannel, RootId: "r1"}, false)
+
+	events := sender.Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	msg, ok := events[0].(*simplevent.Message[*model.Post])
+	if !ok {
+		t.Fatalf("got %T, want a message", events[0])
+	}
+	if msg.PortalKey != makeThreadPortalKey(relayTestChannel, "r1") {
+		t.Errorf("portal key = %v, want the thread room", msg.PortalKey)
+	}
+	if !msg.CreatePortal {
+		t.Error("the thread room should be created")
+	}
+}
+
+func TestConvertPostToMatrix_ThreadRoomReply(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	portal := makeTestPortal(relayTestChannel)
+	portal.ID = MakeThreadPortalID(relayTestChannel, "r1")
+	post := &model.Post{Id: "p1", RootId: "r1", Message: "reply"}
+
+	if msg := client.convertPostToMatrix(context.Background(), portal, nil, post); msg.ReplyTo != nil {
+		t.Errorf("reply in a thread room replies to %v, want no reply", msg.ReplyTo)
+	}
+	if msg := client.convertPostToMatrix(context.Background(), makeTestPortal(relayTestChannel), nil, post); msg.ReplyTo == nil {
+		t.Error("reply in the channel room should reply to its root")
+	}
+}
+
+func TestHandleMatrixMessage_ThreadRoom(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+	portal := makeTestPortal("test-channel")
+	portal.ID = MakeThreadPortalID("test-channel", "root-post")
+
+	msg := &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Portal:  portal,
+			Content: &event.MessageEventContent{MsgType: event.MsgText, Body: "in the thread"},
+		},
+		ReplyTo: &database.Message{ID: MakeMessageID("other-reply")},
+	}
+	if _, err := mc.HandleMatrixMessage(context.Background(), msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range fm.Calls() {
+		if c.Path != "/api/v4/posts" {
+			continue
+		}
+		if !strings.Contains(c.Body, `"channel_id":"test-channel"`) || !strings.Contains(c.Body, `"root_id":"root-post"`) {
+			t.Errorf("post = %s, want a reply to root-post in test-channel", c.Body)
+		}
+		return
+	}
+	t.Error("expected a post")
+}
+
+func TestChannelEditClient_ThreadRoom(t *testing.T) {
+	t.Parallel()
+	fm := newFakeMM()
+	t.Cleanup(fm.Close)
+	mc := newFullTestClient(fm.Server.URL)
+	portal := makeTestPortal("test-channel")
+	portal.ID = MakeThreadPortalID("test-channel", "root-post")
+
+	if _, _, err := mc.channelEditClient(nil, portal, false); !errors.Is(err, errThreadRoomEdit) {
+		t.Errorf("got %v, want errThreadRoomEdit", err)
+	}
+}
+
+func TestThreadRoomName(t *testing.T) {
+	t.Parallel()
+	client := newTestClient()
+	channel := &model.Channel{Id: "ch1", Type: model.ChannelTypeOpen, Name: "town-square", DisplayName: "Town Square"}
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"**Release** plan\nDetails", "Town Square – Release plan"},
+		{strings.Repeat("a", 60), "Town Square – " + strings.Repeat("a", 50) + "…"},
+		{"", "Town Square – thread"},
+	}
+	for _, tt := range tests {
+		if got := client.threadRoomName(context.Background(), channel, &model.Post{Message: tt.message}); got != tt.want {
+			t.Errorf("threadRoomName(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestThreadPostHandled_Announces(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newThreadRoomTestClient(t, 0)
+	bot := &fakeMatrixBot{}
+	mc.connector.Bridge.Bot = bot
+	channel := getRelayTestPortal(t, mc.connector, relayTestChannel)
+	if err := mc.connector.Bridge.DB.Message.Insert(ctx, &database.Message{
+		ID: MakeMessageID("r1"), MXID: "$r1:example.com", Room: channel.PortalKey,
+		SenderID: MakeUserID("user1"), Timestamp: time.UnixMilli(1000),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	thread := insertThreadPortal(t, mc, "r1")
+
+	mc.threadPostHandled(ctx, thread)
+	mc.threadPostHandled(ctx, thread)
+
+	sent := bot.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("sent %d notices, want 1", len(sent))
+	}
+	content := sent[0].AsMessage()
+	if !strings.Contains(content.Body, "https://matrix.to/#/%21thread-r1:example.com") {
+		t.Errorf("body = %q, want a link to the thread room", content.Body)
+	}
+	if content.RelatesTo == nil || content.RelatesTo.GetThreadParent() != "$r1:example.com" {
+		t.Errorf("relates to = %+v, want the thread of $r1", content.RelatesTo)
+	}
+	if !portalMetadata(thread).ThreadAnnounced {
+		t.Error("thread room should be marked announced")
+	}
+}
+
+func TestQueueMemberChange_ThreadRooms(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newThreadRoomTestClient(t, 0)
+	thread := insertThreadPortal(t, mc, "r1")
+	sender := &mockEventSender{}
+	mc.eventSender = sender
+
+	mc.queueMemberChange(relayTestChannel, bridgev2.EventSender{}, "user1", event.MembershipLeave)
+	events := sender.Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want the channel's", len(events))
+	}
+	change := events[0].(*simplevent.ChatInfoChange)
+	change.PostHandleFunc(ctx, getRelayTestPortal(t, mc.connector, relayTestChannel))
+
+	events = sender.Events()
+	if len(events) != 2 || events[1].GetPortalKey() != thread.PortalKey {
+		t.Fatalf("events = %+v, want the change queued for the thread room", events)
+	}
+}
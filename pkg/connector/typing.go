@@ -0,0 +1,97 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/matrix"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// publishTyping shows sender as typing in a Mattermost channel. The typing
+// event comes from sender's puppet bot when they have one, like their posts.
+// Otherwise it comes from this login's user, which for relayed typing is
+// only done with relay_typing, so the relay user doesn't appear to type for
+// everyone.
+func (m *MattermostClient) publishTyping(ctx context.Context, channelID string, sender id.UserID, relayed bool) {
+	client, userID := m.resolvePostClient(nil, &event.Event{Sender: sender})
+	if relayed && userID == m.userID && !m.connector.Config.RelayTyping {
+		m.log.Trace().Str("sender", string(sender)).Msg("Not relaying typing of user without puppet")
+		return
+	}
+	if _, err := client.PublishUserTyping(ctx, userID, model.TypingRequest{ChannelId: channelID}); err != nil {
+		m.log.Debug().Err(err).Str("sender", string(sender)).Msg("Failed to send typing indicator")
+	}
+}
+
+// registerTypingHandler subscribes to Matrix typing events, so users without
+// a Mattermost login of their own, which bridgev2 doesn't bridge typing for,
+// type through their puppet bot or the portal's relay.
+func (mc *MattermostConnector) registerTypingHandler() {
+	if mc.Bridge == nil {
+		return
+	}
+	conn, ok := mc.Bridge.Matrix.(*matrix.Connector)
+	if !ok || conn.EventProcessor == nil {
+		mc.Bridge.Log.Warn().Msg("Matrix connector doesn't expose events, typing of puppet users won't be bridged")
+		return
+	}
+	conn.EventProcessor.On(event.EphemeralEventTyping, mc.handleMatrixTypingEvent)
+}
+
+// handleMatrixTypingEvent bridges the typing of users in a portal room that
+// bridgev2 leaves out: everyone but ghosts, the bridge bot, users with a
+// logged-in Mattermost login (handled by HandleMatrixTyping), and users
+// double-puppeting a Mattermost account without a puppet bot, whose typing
+// may have come from Mattermost.
+func (mc *MattermostConnector) handleMatrixTypingEvent(ctx context.Context, evt *event.Event) {
+	content, ok := evt.Content.Parsed.(*event.TypingEventContent)
+	if !ok || len(content.UserIDs) == 0 {
+		return
+	}
+	portal, err := mc.Bridge.GetPortalByMXID(ctx, evt.RoomID)
+	if err != nil || portal == nil || portal.RoomType == database.RoomTypeSpace || portal.Relay == nil {
+		return
+	}
+	relay, ok := portal.Relay.Client.(*MattermostClient)
+	if !ok || !relay.IsLoggedIn() {
+		return
+	}
+	channelID := ParsePortalID(portal.ID)
+	for _, userID := range content.UserIDs {
+		if !mc.bridgesTypingOf(ctx, userID) {
+			continue
+		}
+		relay.publishTyping(ctx, channelID, userID, true)
+	}
+}
+
+// bridgesTypingOf reports whether the typing of a Matrix user is bridged by
+// handleMatrixTypingEvent.
+func (mc *MattermostConnector) bridgesTypingOf(ctx context.Context, userID id.UserID) bool {
+	if userID == mc.Bridge.Bot.GetMXID() {
+		return false
+	}
+	if _, isGhost := mc.Bridge.Matrix.ParseGhostMXID(userID); isGhost {
+		return false
+	}
+	if user, err := mc.Bridge.GetExistingUserByMXID(ctx, userID); err == nil && user != nil {
+		for _, login := range user.GetUserLogins() {
+			if login.Client != nil && login.Client.IsLoggedIn() {
+				return false
+			}
+		}
+	}
+	if mc.hasPuppet(userID) {
+		return true
+	}
+	mmUserID, _ := mc.doublePuppetLogin(userID)
+	return mmUserID == ""
+}
@@ -0,0 +1,155 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// typingPaths returns the typing endpoints called on fake.
+func typingPaths(fake *fakeMM) []string {
+	var paths []string
+	for _, call := range fake.Calls() {
+		if strings.HasSuffix(call.Path, "/typing") {
+			paths = append(paths, call.Path)
+		}
+	}
+	return paths
+}
+
+// addTypingPuppet maps @puppet:example.com to the puppet-id bot on fake.
+func addTypingPuppet(mc *MattermostConnector, fake *fakeMM) {
+	client := model.NewAPIv4Client(fake.Server.URL)
+	client.SetToken("puppet-token")
+	mc.Puppets["@puppet:example.com"] = &PuppetClient{MXID: "@puppet:example.com", UserID: "puppet-id", Client: client}
+}
+
+func TestPublishTyping(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		sender      id.UserID
+		relayed     bool
+		relayTyping bool
+		want        string
+	}{
+		{"puppet", "@puppet:example.com", true, false, "/api/v4/users/puppet-id/typing"},
+		{"own user with puppet", "@puppet:example.com", false, false, "/api/v4/users/puppet-id/typing"},
+		{"own user", "@me:example.com", false, false, "/api/v4/users/my-user-id/typing"},
+		{"relayed without puppet", "@carol:example.com", true, false, ""},
+		{"relayed with relay_typing", "@carol:example.com", true, true, "/api/v4/users/my-user-id/typing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			mc := newFullTestClient(fake.Server.URL)
+			addTypingPuppet(mc.connector, fake)
+			mc.connector.Config.RelayTyping = tt.relayTyping
+
+			mc.publishTyping(context.Background(), "ch1", tt.sender, tt.relayed)
+			paths := typingPaths(fake)
+			switch {
+			case tt.want == "" && len(paths) != 0:
+				t.Errorf("typing sent to %v, want none", paths)
+			case tt.want != "" && (len(paths) != 1 || paths[0] != tt.want):
+				t.Errorf("typing sent to %v, want %s", paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleMatrixTyping_LoginOwnerPuppet(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc := newFullTestClient(fake.Server.URL)
+	addTypingPuppet(mc.connector, fake)
+	mc.userLogin = &bridgev2.UserLogin{UserLogin: &database.UserLogin{UserMXID: "@puppet:example.com"}}
+
+	if err := mc.HandleMatrixTyping(context.Background(), &bridgev2.MatrixTyping{Portal: makeTestPortal("ch1"), IsTyping: true}); err != nil {
+		t.Fatalf("HandleMatrixTyping: %v", err)
+	}
+	if paths := typingPaths(fake); len(paths) != 1 || paths[0] != "/api/v4/users/puppet-id/typing" {
+		t.Errorf("typing sent to %v, want the puppet bot", paths)
+	}
+}
+
+func TestHandleMatrixTypingEvent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		userID      id.UserID
+		relayTyping bool
+		want        string
+	}{
+		{"puppet", "@puppet:example.com", false, "/api/v4/users/puppet-id/typing"},
+		{"ghost", "@mm_alice:example.com", true, ""},
+		{"bridge bot", "@bot:example.com", true, ""},
+		{"user with login", "@relay:example.com", true, ""},
+		{"no puppet", "@carol:example.com", false, ""},
+		{"no puppet with relay_typing", "@carol:example.com", true, "/api/v4/users/relay-id/typing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+			mc.Bridge.Matrix = ghostMatrixConnector{}
+			mc.Bridge.Bot = &fakeReplyBot{}
+			mc.Puppets = make(map[id.UserID]*PuppetClient)
+			mc.Config.RelayTyping = tt.relayTyping
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			addTypingPuppet(mc, fake)
+
+			login, err := mc.Bridge.GetExistingUserLoginByID(ctx, MakeUserLoginID("relayuser"))
+			if err != nil || login == nil {
+				t.Fatalf("get login: %v", err)
+			}
+			client := login.Client.(*MattermostClient)
+			client.client = model.NewAPIv4Client(fake.Server.URL)
+			client.client.SetToken("relay-token")
+			client.userID = "relay-id"
+			portal := getRelayTestPortal(t, mc, relayTestChannel)
+			if err := portal.SetRelay(ctx, login); err != nil {
+				t.Fatalf("set relay: %v", err)
+			}
+
+			mc.handleMatrixTypingEvent(ctx, &event.Event{
+				Type:    event.EphemeralEventTyping,
+				RoomID:  portal.MXID,
+				Content: event.Content{Parsed: &event.TypingEventContent{UserIDs: []id.UserID{tt.userID}}},
+			})
+			paths := typingPaths(fake)
+			switch {
+			case tt.want == "" && len(paths) != 0:
+				t.Errorf("typing sent to %v, want none", paths)
+			case tt.want != "" && (len(paths) != 1 || paths[0] != tt.want):
+				t.Errorf("typing sent to %v, want %s", paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTypingEvent_PuppetBot(t *testing.T) {
+	t.Parallel()
+	mc := newFullTestClient("http://unused")
+	mc.connector.Puppets["@puppet:example.com"] = &PuppetClient{MXID: "@puppet:example.com", UserID: "puppet-id"}
+	evt := newWebSocketEvent(model.WebsocketEventTyping, "ch1", map[string]any{"user_id": "puppet-id"})
+	if _, _, ok := mc.parseTypingEvent(evt); ok {
+		t.Error("typing of a puppet bot should be skipped as an echo")
+	}
+}
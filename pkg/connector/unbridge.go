@@ -0,0 +1,372 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// What DELETE /api/portals/{channel_id} does with the rooms of the portal.
+const (
+	// unbridgeRoomKeep leaves the room as it is, with the bridge bot in it.
+	unbridgeRoomKeep = "keep"
+	// unbridgeRoomArchive makes the room read-only before the bot leaves.
+	unbridgeRoomArchive = "archive"
+	// unbridgeRoomLeave makes the bot leave the room.
+	unbridgeRoomLeave = "leave"
+	// unbridgeRoomDelete kicks everyone out of the room, like the unbridge
+	// command.
+	unbridgeRoomDelete = "delete"
+)
+
+const (
+	unbridgedChannelCreateTable = `
+		CREATE TABLE IF NOT EXISTS mattermost_unbridged_channel (
+			bridge_id    TEXT   NOT NULL,
+			channel_id   TEXT   NOT NULL,
+			unbridged_at BIGINT NOT NULL,
+			PRIMARY KEY (bridge_id, channel_id)
+		)
+	`
+	unbridgedChannelInsert = `
+		INSERT INTO mattermost_unbridged_channel (bridge_id, channel_id, unbridged_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (bridge_id, channel_id) DO NOTHING
+	`
+	unbridgedChannelDelete = `
+		DELETE FROM mattermost_unbridged_channel WHERE bridge_id=$1 AND channel_id=$2
+	`
+	unbridgedChannelGetAll = `
+		SELECT channel_id FROM mattermost_unbridged_channel WHERE bridge_id=$1
+	`
+)
+
+// unbridgedNotice is posted in rooms that aren't deleted when their portal
+// is.
+const unbridgedNotice = "This room is no longer bridged to Mattermost."
+
+// unbridgeWriteTimeout bounds how long a portal deletion response may take
+// to write. Kicking the ghosts of a large channel takes a request each.
+const unbridgeWriteTimeout = 5 * time.Minute
+
+// unbridgeOptions selects what happens to the rooms of a deleted portal.
+type unbridgeOptions struct {
+	// Room is one of the unbridgeRoom* constants.
+	Room string
+	// KickGhosts removes the ghosts of Mattermost users from the rooms.
+	KickGhosts bool
+}
+
+// UnbridgeResult is the response of DELETE /api/portals/{channel_id}.
+type UnbridgeResult struct {
+	ChannelID string    `json:"channel_id"`
+	RoomID    id.RoomID `json:"room_id,omitempty"`
+	// ThreadRoomIDs are the rooms of the channel's thread rooms, deleted
+	// with it.
+	ThreadRoomIDs []id.RoomID `json:"thread_room_ids,omitempty"`
+	Room          string      `json:"room"`
+	// KickedGhosts counts the ghosts removed from the rooms.
+	KickedGhosts int `json:"kicked_ghosts"`
+	// Denied is true when this request added the channel to the unbridged
+	// channels, which get no portal until they're provisioned again, and
+	// false when it already was one.
+	Denied bool `json:"denied"`
+	// Error reports rooms that couldn't be cleaned up as requested. The
+	// portals are deleted regardless.
+	Error string `json:"error,omitempty"`
+}
+
+// parseUnbridgeOptions reads the room and kick_ghosts query parameters of
+// DELETE /api/portals/{channel_id}.
+func parseUnbridgeOptions(query url.Values) (unbridgeOptions, error) {
+	opts := unbridgeOptions{Room: unbridgeRoomKeep}
+	switch room := query.Get("room"); room {
+	case "":
+	case unbridgeRoomKeep, unbridgeRoomArchive, unbridgeRoomLeave, unbridgeRoomDelete:
+		opts.Room = room
+	default:
+		return opts, errors.New("room must be keep, archive, leave or delete")
+	}
+	if raw := query.Get("kick_ghosts"); raw != "" {
+		kick, err := strconv.ParseBool(raw)
+		if err != nil {
+			return opts, errors.New("kick_ghosts must be true or false")
+		}
+		opts.KickGhosts = kick
+	}
+	return opts, nil
+}
+
+// HandleDeletePortal is an HTTP handler for DELETE /api/portals/{channel_id}.
+// It deletes the portal of a channel and of its thread rooms, cleans up
+// their rooms as the room and kick_ghosts query parameters ask, and records
+// the channel as unbridged so it doesn't get a portal again, across
+// restarts, until it's provisioned.
+func (mc *MattermostConnector) HandleDeletePortal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := mc.ctxLog(r.Context())
+	channelID := r.PathValue("channel_id")
+	if !model.IsValidId(channelID) {
+		http.Error(w, "channel_id must be a valid Mattermost ID", http.StatusBadRequest)
+		return
+	}
+	opts, err := parseUnbridgeOptions(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	portal, err := mc.Bridge.GetExistingPortalByKey(ctx, makePortalKey(channelID))
+	if err != nil {
+		log.Err(err).Str("channel_id", channelID).Msg("Failed to get portal to delete")
+		http.Error(w, "failed to get portal", http.StatusInternalServerError)
+		return
+	}
+	if portal == nil {
+		http.Error(w, "channel isn't bridged", http.StatusNotFound)
+		return
+	}
+	log.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Str("channel_id", channelID).
+		Str("room", opts.Room).
+		Bool("kick_ghosts", opts.KickGhosts).
+		Msg("Portal deletion requested")
+
+	// The channel is denied first, so a post arriving meanwhile doesn't
+	// create the portal again.
+	result := UnbridgeResult{ChannelID: channelID, RoomID: portal.MXID, Room: opts.Room}
+	result.Denied, err = mc.unbridgeChannel(ctx, channelID)
+	if err != nil {
+		log.Err(err).Str("channel_id", channelID).Msg("Failed to record unbridged channel")
+		http.Error(w, "failed to record unbridged channel", http.StatusInternalServerError)
+		return
+	}
+	threadKeys, err := mc.threadPortalKeys(ctx, channelID)
+	if err != nil {
+		log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to get thread rooms of deleted portal")
+	}
+	portals := []*bridgev2.Portal{portal}
+	for _, key := range threadKeys {
+		thread, err := mc.Bridge.GetExistingPortalByKey(ctx, key)
+		if err != nil || thread == nil {
+			log.Warn().Err(err).Str("portal_id", string(key.ID)).Msg("Failed to get thread room portal to delete")
+			continue
+		}
+		portals = append(portals, thread)
+		result.ThreadRoomIDs = append(result.ThreadRoomIDs, thread.MXID)
+	}
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(unbridgeWriteTimeout))
+	var cleanupErrs []error
+	// Thread rooms go first: once the channel's portal is gone, nothing
+	// lists them.
+	for i := len(portals) - 1; i >= 0; i-- {
+		roomID := portals[i].MXID
+		if err := portals[i].Delete(ctx); err != nil {
+			log.Err(err).Str("portal_id", string(portals[i].ID)).Msg("Failed to delete portal")
+			http.Error(w, "failed to delete portal", http.StatusInternalServerError)
+			return
+		}
+		if roomID == "" {
+			continue
+		}
+		kicked, err := mc.cleanUpUnbridgedRoom(ctx, portals[i], roomID, opts)
+		result.KickedGhosts += kicked
+		if err != nil {
+			log.Warn().Err(err).Stringer("room_id", roomID).Msg("Failed to clean up room of deleted portal")
+			cleanupErrs = append(cleanupErrs, fmt.Errorf("%s: %w", roomID, err))
+		}
+	}
+	if err := errors.Join(cleanupErrs...); err != nil {
+		result.Error = err.Error()
+	}
+
+	log.Info().
+		Str("channel_id", channelID).
+		Stringer("room_id", result.RoomID).
+		Int("thread_rooms", len(result.ThreadRoomIDs)).
+		Int("kicked_ghosts", result.KickedGhosts).
+		Bool("denied", result.Denied).
+		Msg("Portal deleted through the admin API")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Warn().Err(err).Msg("Failed to write portal deletion response")
+	}
+}
+
+// loadUnbridgedChannelsLocked reads the unbridged channels from the
+// database, creating their table if needed. Without a bridge database, they
+// only last until the bridge restarts. unbridgedMu must be held.
+func (mc *MattermostConnector) loadUnbridgedChannelsLocked(ctx context.Context) error {
+	if mc.unbridgedChannels != nil {
+		return nil
+	}
+	channels := make(map[string]struct{})
+	if mc.Bridge == nil || mc.Bridge.DB == nil {
+		mc.unbridgedChannels = channels
+		return nil
+	}
+	db := mc.Bridge.DB.Database
+	if _, err := db.Exec(ctx, unbridgedChannelCreateTable); err != nil {
+		return fmt.Errorf("failed to create unbridged channel table: %w", err)
+	}
+	rows, err := db.Query(ctx, unbridgedChannelGetAll, string(mc.Bridge.ID))
+	if err != nil {
+		return fmt.Errorf("failed to load unbridged channels: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var channelID string
+		if err := rows.Scan(&channelID); err != nil {
+			return fmt.Errorf("failed to load unbridged channels: %w", err)
+		}
+		channels[channelID] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to load unbridged channels: %w", err)
+	}
+	mc.unbridgedChannels = channels
+	return nil
+}
+
+// isUnbridged reports whether a channel was unbridged through the admin
+// API. If the unbridged channels can't be loaded, channels are let through
+// and the load is retried on the next call.
+func (mc *MattermostConnector) isUnbridged(ctx context.Context, channelID string) bool {
+	mc.unbridgedMu.Lock()
+	defer mc.unbridgedMu.Unlock()
+	if err := mc.loadUnbridgedChannelsLocked(ctx); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Failed to load unbridged channels")
+		return false
+	}
+	_, ok := mc.unbridgedChannels[channelID]
+	return ok
+}
+
+// unbridgeChannel records a channel as unbridged, and reports whether it
+// wasn't yet.
+func (mc *MattermostConnector) unbridgeChannel(ctx context.Context, channelID string) (bool, error) {
+	mc.unbridgedMu.Lock()
+	defer mc.unbridgedMu.Unlock()
+	if err := mc.loadUnbridgedChannelsLocked(ctx); err != nil {
+		return false, err
+	}
+	if _, ok := mc.unbridgedChannels[channelID]; ok {
+		return false, nil
+	}
+	if mc.Bridge != nil && mc.Bridge.DB != nil {
+		_, err := mc.Bridge.DB.Exec(ctx, unbridgedChannelInsert, string(mc.Bridge.ID), channelID, time.Now().UnixMilli())
+		if err != nil {
+			return false, fmt.Errorf("failed to save unbridged channel: %w", err)
+		}
+	}
+	mc.unbridgedChannels[channelID] = struct{}{}
+	return true, nil
+}
+
+// rebridgeChannel lets an unbridged channel get a portal again, and reports
+// whether it was unbridged.
+func (mc *MattermostConnector) rebridgeChannel(ctx context.Context, channelID string) (bool, error) {
+	mc.unbridgedMu.Lock()
+	defer mc.unbridgedMu.Unlock()
+	if err := mc.loadUnbridgedChannelsLocked(ctx); err != nil {
+		return false, err
+	}
+	if _, ok := mc.unbridgedChannels[channelID]; !ok {
+		return false, nil
+	}
+	if mc.Bridge != nil && mc.Bridge.DB != nil {
+		if _, err := mc.Bridge.DB.Exec(ctx, unbridgedChannelDelete, string(mc.Bridge.ID), channelID); err != nil {
+			return false, fmt.Errorf("failed to remove unbridged channel: %w", err)
+		}
+	}
+	delete(mc.unbridgedChannels, channelID)
+	return true, nil
+}
+
+// cleanUpUnbridgedRoom does what opts asks with the room of a deleted
+// portal, and returns the number of ghosts kicked.
+func (mc *MattermostConnector) cleanUpUnbridgedRoom(ctx context.Context, portal *bridgev2.Portal, roomID id.RoomID, opts unbridgeOptions) (int, error) {
+	bot := mc.Bridge.Bot
+	if opts.Room == unbridgeRoomDelete {
+		return 0, bot.DeleteRoom(ctx, roomID, false)
+	}
+	var kicked int
+	var errs []error
+	if opts.KickGhosts {
+		n, err := mc.kickGhosts(ctx, roomID)
+		kicked = n
+		errs = append(errs, err)
+	}
+	if opts.Room == unbridgeRoomArchive {
+		if err := setRoomReadOnly(ctx, portal, true); err != nil {
+			errs = append(errs, fmt.Errorf("failed to make room read-only: %w", err))
+		}
+	}
+	content := &event.MessageEventContent{MsgType: event.MsgNotice, Body: unbridgedNotice}
+	_, err := bot.SendMessage(ctx, roomID, event.EventMessage, &event.Content{Parsed: content}, nil)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to send notice: %w", err))
+	}
+	if opts.Room == unbridgeRoomArchive || opts.Room == unbridgeRoomLeave {
+		if err := removeMember(ctx, bot, roomID, bot.GetMXID(), ""); err != nil {
+			errs = append(errs, fmt.Errorf("failed to leave room: %w", err))
+		}
+	}
+	return kicked, errors.Join(errs...)
+}
+
+// kickGhosts removes the ghosts of Mattermost users from a room, and
+// returns how many were removed.
+func (mc *MattermostConnector) kickGhosts(ctx context.Context, roomID id.RoomID) (int, error) {
+	members, err := mc.Bridge.Matrix.GetMembers(ctx, roomID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get room members: %w", err)
+	}
+	var kicked int
+	var errs []error
+	for userID, member := range members {
+		if member.Membership != event.MembershipJoin && member.Membership != event.MembershipInvite {
+			continue
+		}
+		if !mc.Bridge.IsGhostMXID(userID) {
+			continue
+		}
+		if err := removeMember(ctx, mc.Bridge.Bot, roomID, userID, "Channel unbridged"); err != nil {
+			errs = append(errs, fmt.Errorf("failed to kick %s: %w", userID, err))
+			continue
+		}
+		kicked++
+	}
+	return kicked, errors.Join(errs...)
+}
+
+// removeMember kicks userID from a room as the bridge bot, or makes the bot
+// leave when userID is its own.
+func removeMember(ctx context.Context, bot bridgev2.MatrixAPI, roomID id.RoomID, userID id.UserID, reason string) error {
+	content := &event.MemberEventContent{Membership: event.MembershipLeave, Reason: reason}
+	_, err := bot.SendState(ctx, roomID, event.StateMember, userID.String(), &event.Content{Parsed: content}, time.Time{})
+	return err
+}
@@ -0,0 +1,231 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+const unbridgeTestBotMXID = id.UserID("@mattermostbot:example.com")
+
+// unbridgeMatrixConnector returns a ghost, a Matrix user and a ghost that
+// left as the members of every room.
+type unbridgeMatrixConnector struct {
+	nopMatrixConnector
+}
+
+func (unbridgeMatrixConnector) GetMembers(context.Context, id.RoomID) (map[id.UserID]*event.MemberEventContent, error) {
+	return map[id.UserID]*event.MemberEventContent{
+		"@mattermost_user1:example.com": {Membership: event.MembershipJoin},
+		"@mattermost_user2:example.com": {Membership: event.MembershipLeave},
+		"@alice:example.com":            {Membership: event.MembershipJoin},
+		unbridgeTestBotMXID:             {Membership: event.MembershipJoin},
+	}, nil
+}
+
+func (unbridgeMatrixConnector) ParseGhostMXID(userID id.UserID) (networkid.UserID, bool) {
+	localpart, ok := strings.CutPrefix(userID.Localpart(), "mattermost_")
+	return networkid.UserID(localpart), ok
+}
+
+func (unbridgeMatrixConnector) GetPowerLevels(context.Context, id.RoomID) (*event.PowerLevelsEventContent, error) {
+	return &event.PowerLevelsEventContent{}, nil
+}
+
+// unbridgeTestBot is a fakeMatrixBot that records deleted rooms.
+type unbridgeTestBot struct {
+	fakeMatrixBot
+	deleteMu sync.Mutex
+	deleted  []id.RoomID
+}
+
+func (b *unbridgeTestBot) GetMXID() id.UserID { return unbridgeTestBotMXID }
+
+func (b *unbridgeTestBot) DeleteRoom(_ context.Context, roomID id.RoomID, _ bool) error {
+	b.deleteMu.Lock()
+	defer b.deleteMu.Unlock()
+	b.deleted = append(b.deleted, roomID)
+	return nil
+}
+
+// newUnbridgeTest returns a connector with a portal for relayTestChannel and
+// one of its thread rooms.
+func newUnbridgeTest(t *testing.T) (*MattermostConnector, *unbridgeTestBot) {
+	t.Helper()
+	mc := newRelayTestConnector(t, map[string]*PortalMetadata{relayTestChannel: {}})
+	mc.Bridge.Matrix = unbridgeMatrixConnector{}
+	bot := &unbridgeTestBot{}
+	mc.Bridge.Bot = bot
+	if err := mc.Bridge.DB.Portal.Insert(context.Background(), &database.Portal{
+		BridgeID:  "mattermost",
+		PortalKey: makeThreadPortalKey(relayTestChannel, "r1"),
+		MXID:      "!thread-r1:example.com",
+		Metadata:  &PortalMetadata{},
+	}); err != nil {
+		t.Fatalf("insert thread portal: %v", err)
+	}
+	return mc, bot
+}
+
+func deletePortalRequest(mc *MattermostConnector, method, channelID, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/portals/"+channelID+"?"+query, nil)
+	req.SetPathValue("channel_id", channelID)
+	w := httptest.NewRecorder()
+	mc.HandleDeletePortal(w, req)
+	return w
+}
+
+func TestHandleDeletePortal_Validation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		method    string
+		channelID string
+		query     string
+		want      int
+	}{
+		{"method", http.MethodGet, relayTestChannel, "", http.StatusMethodNotAllowed},
+		{"channel ID", http.MethodDelete, "town-square", "", http.StatusBadRequest},
+		{"room", http.MethodDelete, relayTestChannel, "room=burn", http.StatusBadRequest},
+		{"kick_ghosts", http.MethodDelete, relayTestChannel, "kick_ghosts=maybe", http.StatusBadRequest},
+		{"not bridged", http.MethodDelete, relayTestChannel2, "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, _ := newUnbridgeTest(t)
+			if w := deletePortalRequest(mc, tt.method, tt.channelID, tt.query); w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			getRelayTestPortal(t, mc, relayTestChannel)
+			if mc.isUnbridged(context.Background(), tt.channelID) {
+				t.Error("rejected request unbridged the channel")
+			}
+		})
+	}
+}
+
+func TestHandleDeletePortal(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	channelRoom := id.RoomID("!" + relayTestChannel + ":example.com")
+	tests := []struct {
+		name         string
+		query        string
+		wantDeleted  bool
+		wantReadOnly bool
+		wantLeave    bool
+		wantKicked   int
+	}{
+		{name: "keep", query: ""},
+		{name: "archive", query: "room=archive", wantReadOnly: true, wantLeave: true},
+		{name: "leave and kick", query: "room=leave&kick_ghosts=true", wantLeave: true, wantKicked: 2},
+		{name: "delete", query: "room=delete&kick_ghosts=true", wantDeleted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc, bot := newUnbridgeTest(t)
+
+			w := deletePortalRequest(mc, http.MethodDelete, relayTestChannel, tt.query)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+			}
+			var result UnbridgeResult
+			if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if result.RoomID != channelRoom || len(result.ThreadRoomIDs) != 1 || !result.Denied || result.Error != "" {
+				t.Errorf("result = %+v", result)
+			}
+			if result.KickedGhosts != tt.wantKicked {
+				t.Errorf("kicked ghosts = %d, want %d", result.KickedGhosts, tt.wantKicked)
+			}
+
+			for _, key := range []networkid.PortalKey{makePortalKey(relayTestChannel), makeThreadPortalKey(relayTestChannel, "r1")} {
+				if portal, err := mc.Bridge.GetExistingPortalByKey(ctx, key); err != nil || portal != nil {
+					t.Errorf("portal %s still exists: %v", key.ID, err)
+				}
+			}
+			if !mc.isUnbridged(ctx, relayTestChannel) {
+				t.Error("channel not recorded as unbridged")
+			}
+
+			if got := len(bot.deleted) > 0; got != tt.wantDeleted {
+				t.Errorf("rooms deleted = %v, want %v", bot.deleted, tt.wantDeleted)
+			}
+			var readOnly, left bool
+			var kicked int
+			for _, state := range bot.States() {
+				switch {
+				case state.Type == event.StatePowerLevels:
+					readOnly = state.Content.Parsed.(*event.PowerLevelsEventContent).EventsDefault == archivedEventsDefault
+				case state.StateKey == unbridgeTestBotMXID.String():
+					left = true
+				case state.Type == event.StateMember:
+					if state.StateKey != "@mattermost_user1:example.com" {
+						t.Errorf("kicked %s, want only the joined ghost", state.StateKey)
+					}
+					kicked++
+				}
+			}
+			if readOnly != tt.wantReadOnly || left != tt.wantLeave || kicked != tt.wantKicked {
+				t.Errorf("read-only = %v, left = %v, kicked = %d", readOnly, left, kicked)
+			}
+			if !tt.wantDeleted && len(bot.Sent()) != 2 {
+				t.Errorf("sent %d notices, want one per room", len(bot.Sent()))
+			}
+		})
+	}
+}
+
+func TestUnbridgeChannel(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mc := newRelayTestConnector(t, nil)
+	mc.Config.Channels = ChannelFilterConfig{Denylist: []string{"off-topic"}}
+	client := newTestClient()
+	client.connector = mc
+
+	if denied, err := mc.unbridgeChannel(ctx, relayTestChannel); err != nil || !denied {
+		t.Fatalf("first unbridge = %v, %v, want the channel added", denied, err)
+	}
+	if denied, _ := mc.unbridgeChannel(ctx, relayTestChannel); denied {
+		t.Error("second unbridge should find the channel unbridged")
+	}
+	if client.channelAllowed(ctx, relayTestChannel, "town-square", "") {
+		t.Error("unbridged channel allowed")
+	}
+	if !slices.Equal(mc.channelFilter().Denylist, []string{"off-topic"}) || mc.channelFilterOverride.Load() != nil {
+		t.Errorf("channel filter changed: %+v", mc.channelFilter())
+	}
+
+	// The unbridged channels are loaded from the database after a restart.
+	mc.unbridgedChannels = nil
+	if !mc.isUnbridged(ctx, relayTestChannel) {
+		t.Error("unbridged channel forgotten on restart")
+	}
+
+	if rebridged, err := mc.rebridgeChannel(ctx, relayTestChannel); err != nil || !rebridged {
+		t.Fatalf("rebridge = %v, %v", rebridged, err)
+	}
+	mc.unbridgedChannels = nil
+	if mc.isUnbridged(ctx, relayTestChannel) {
+		t.Error("rebridged channel still unbridged after a restart")
+	}
+}
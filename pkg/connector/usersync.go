@@ -0,0 +1,82 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// parseUserUpdatedEvent extracts the user of a user_updated event. The server
+// sends the user as a JSON object, but a JSON string is accepted as well, as
+// for the other events.
+func parseUserUpdatedEvent(evt *model.WebSocketEvent) (*model.User, error) {
+	var userJSON []byte
+	switch raw := evt.GetData()["user"].(type) {
+	case string:
+		userJSON = []byte(raw)
+	case map[string]any:
+		var err error
+		if userJSON, err = json.Marshal(raw); err != nil {
+			return nil, fmt.Errorf("failed to marshal user: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("user updated event missing user data")
+	}
+	var user model.User
+	if err := json.Unmarshal(userJSON, &user); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user updated event has no user ID")
+	}
+	return &user, nil
+}
+
+// handleUserUpdated refreshes a Mattermost user's ghost when their name,
+// nickname or profile image changes. Ghosts are otherwise only synced the
+// first time the bridge sees the user. Puppet bots are skipped, since their
+// Matrix user is real and its profile is pushed the other way. Users without
+// a ghost are skipped too: Mattermost sends the event for every user of the
+// server, and their ghost gets the current profile when it's created.
+func (m *MattermostClient) handleUserUpdated(evt *model.WebSocketEvent) {
+	user, err := parseUserUpdatedEvent(evt)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("Failed to parse user updated event")
+		return
+	}
+	m.refreshMentionUser(user)
+	if m.connector.IsPuppetUserID(user.Id) {
+		return
+	}
+	log := m.log.With().Str("user_id", user.Id).Logger()
+	ctx := log.WithContext(context.Background())
+	ghost, err := m.connector.Bridge.GetExistingGhostByID(ctx, MakeUserID(user.Id))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get ghost for profile update")
+		return
+	} else if ghost == nil {
+		return
+	}
+	ghost.UpdateInfo(ctx, m.mmUserToUserInfo(ctx, user))
+	log.Debug().Msg("Synced ghost profile")
+}
+
+// refreshMentionUser replaces the cached user of a mention lookup, dropping
+// the entry under the old username after a rename.
+func (m *MattermostClient) refreshMentionUser(user *model.User) {
+	m.mentionMu.Lock()
+	if m.mentionUsernames != nil {
+		if old, ok := m.mentionUsernames.get(user.Id); ok && old != user.Username {
+			m.mentionUsers.delete(old)
+		}
+	}
+	m.mentionMu.Unlock()
+	m.rememberMentionUser(user.Username, user)
+}
@@ -0,0 +1,196 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/networkid"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// profileIntent is a ghost's bridgev2.MatrixAPI that records its profile.
+type profileIntent struct {
+	bridgev2.MatrixAPI
+	mu          sync.Mutex
+	displayName string
+	avatarURL   id.ContentURIString
+	uploads     int
+}
+
+func (p *profileIntent) GetMXID() id.UserID { return "@mm_ghost:example.com" }
+
+func (p *profileIntent) SetDisplayName(_ context.Context, name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.displayName = name
+	return nil
+}
+
+func (p *profileIntent) UploadMedia(context.Context, id.RoomID, []byte, string, string) (id.ContentURIString, *event.EncryptedFileInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.uploads++
+	return id.ContentURIString("mxc://example.com/avatar" + strconv.Itoa(p.uploads)), nil, nil
+}
+
+func (p *profileIntent) SetAvatarURL(_ context.Context, avatarURL id.ContentURIString) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.avatarURL = avatarURL
+	return nil
+}
+
+func (p *profileIntent) SetExtraProfileMeta(context.Context, any) error { return nil }
+
+// profileMatrixConnector hands out a single profileIntent for every ghost.
+type profileMatrixConnector struct {
+	nopMatrixConnector
+	intent *profileIntent
+}
+
+func (c profileMatrixConnector) GhostIntent(networkid.UserID) bridgev2.MatrixAPI { return c.intent }
+
+// newUserSyncTestClient returns a client on a bridge with a database whose
+// ghosts record their profile in the returned intent.
+func newUserSyncTestClient(t *testing.T, fake *fakeMM) (*MattermostClient, *profileIntent) {
+	t.Helper()
+	intent := &profileIntent{}
+	conn := newRelayTestConnector(t, nil)
+	conn.Bridge.Matrix = profileMatrixConnector{intent: intent}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Bridge = conn.Bridge
+	mc.connector.Config.DisplaynameTemplate = "{{.FirstName}} {{.LastName}}{{if .Nickname}} ({{.Nickname}}){{end}}"
+	if err := mc.connector.Config.PostProcess(); err != nil {
+		t.Fatalf("post-process config: %v", err)
+	}
+	return mc, intent
+}
+
+func TestParseUserUpdatedEvent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr bool
+	}{
+		{"object", map[string]any{"user": map[string]any{"id": "alice-id", "first_name": "Alice"}}, false},
+		{"string", map[string]any{"user": `{"id":"alice-id","first_name":"Alice"}`}, false},
+		{"missing", map[string]any{}, true},
+		{"invalid JSON", map[string]any{"user": "{"}, true},
+		{"no ID", map[string]any{"user": map[string]any{"first_name": "Alice"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			user, err := parseUserUpdatedEvent(newWebSocketEvent(model.WebsocketEventUserUpdated, "", tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err: got %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (user.Id != "alice-id" || user.FirstName != "Alice") {
+				t.Errorf("user = %+v", user)
+			}
+		})
+	}
+}
+
+func TestHandleUserUpdated_SyncsGhost(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.ProfileImages["alice-id"] = []byte("\x89PNG\r\n\x1a\nfirst")
+	mc, intent := newUserSyncTestClient(t, fake)
+	if _, err := mc.connector.Bridge.GetGhostByID(context.Background(), MakeUserID("alice-id")); err != nil {
+		t.Fatalf("create ghost: %v", err)
+	}
+
+	update := func(user map[string]any) {
+		mc.handleEvent(newWebSocketEvent(model.WebsocketEventUserUpdated, "", map[string]any{"user": user}))
+	}
+	update(map[string]any{"id": "alice-id", "username": "alice", "first_name": "Alice", "last_name": "Liddell", "last_picture_update": 1})
+	if intent.displayName != "Alice Liddell" || intent.avatarURL != "mxc://example.com/avatar1" {
+		t.Fatalf("profile = %q %q, want name and avatar set", intent.displayName, intent.avatarURL)
+	}
+
+	// A nickname change renames the ghost without reuploading the avatar.
+	update(map[string]any{"id": "alice-id", "username": "alice", "first_name": "Alice", "last_name": "Liddell", "nickname": "Al", "last_picture_update": 1})
+	if intent.displayName != "Alice Liddell (Al)" || intent.uploads != 1 {
+		t.Errorf("profile = %q after %d uploads, want renamed with 1 upload", intent.displayName, intent.uploads)
+	}
+
+	// A new picture is reuploaded.
+	fake.mu.Lock()
+	fake.ProfileImages["alice-id"] = []byte("\x89PNG\r\n\x1a\nsecond")
+	fake.mu.Unlock()
+	update(map[string]any{"id": "alice-id", "username": "alice", "first_name": "Alice", "last_name": "Liddell", "nickname": "Al", "last_picture_update": 2})
+	if intent.avatarURL != "mxc://example.com/avatar2" {
+		t.Errorf("avatar = %q, want the new picture", intent.avatarURL)
+	}
+
+	ghost, err := mc.connector.Bridge.GetGhostByID(context.Background(), MakeUserID("alice-id"))
+	if err != nil {
+		t.Fatalf("get ghost: %v", err)
+	}
+	if ghost.Name != "Alice Liddell (Al)" || ghost.AvatarID != "alice-id_2" {
+		t.Errorf("stored ghost = %q %q", ghost.Name, ghost.AvatarID)
+	}
+}
+
+func TestHandleUserUpdated_SkipsUsersWithoutGhost(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, intent := newUserSyncTestClient(t, fake)
+
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventUserUpdated, "", map[string]any{
+		"user": map[string]any{"id": "bob-id", "username": "bob", "first_name": "Bob"},
+	}))
+	if intent.displayName != "" {
+		t.Errorf("ghost renamed to %q", intent.displayName)
+	}
+	if ghost, err := mc.connector.Bridge.GetExistingGhostByID(context.Background(), MakeUserID("bob-id")); err != nil || ghost != nil {
+		t.Errorf("ghost created for a user the bridge never saw: %+v, %v", ghost, err)
+	}
+}
+
+func TestHandleUserUpdated_SkipsPuppets(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, intent := newUserSyncTestClient(t, fake)
+	mc.connector.Puppets["@puppet:example.com"] = &PuppetClient{MXID: "@puppet:example.com", UserID: "puppet-id"}
+
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventUserUpdated, "", map[string]any{
+		"user": map[string]any{"id": "puppet-id", "username": "puppetbot", "first_name": "Puppet"},
+	}))
+	if intent.displayName != "" {
+		t.Errorf("puppet ghost renamed to %q", intent.displayName)
+	}
+}
+
+func TestHandleUserUpdated_RefreshesMentionCache(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, _ := newUserSyncTestClient(t, fake)
+	mc.rememberMentionUser("alice", &model.User{Id: "alice-id", Username: "alice"})
+
+	mc.handleEvent(newWebSocketEvent(model.WebsocketEventUserUpdated, "", map[string]any{
+		"user": map[string]any{"id": "alice-id", "username": "alice2"},
+	}))
+	if _, ok := mc.mentionUsers.get("alice"); ok {
+		t.Error("old username still cached")
+	}
+	if username, _ := mc.mentionUsernames.get("alice-id"); username != "alice2" {
+		t.Errorf("cached username = %q, want alice2", username)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+const (
+	// webhookDisplayName names the incoming webhooks the bridge creates.
+	webhookDisplayName = "Matrix bridge"
+	// webhookPostLookupSkew is how far before posting to a webhook the
+	// channel's posts are searched for the post it made, whose ID the
+	// webhook doesn't return. It covers clock skew with the server.
+	webhookPostLookupSkew = time.Minute
+)
+
+var (
+	// errWebhookUnavailable is returned by sendWebhookMessage when a message
+	// can't go through a webhook and should be posted by the relay account.
+	errWebhookUnavailable = errors.New("message can't be posted through a webhook")
+	// errWebhookPostNotFound is returned when a webhook accepted a message
+	// but its post wasn't found among the channel's posts since.
+	errWebhookPostNotFound = errors.New("webhook post not found")
+)
+
+// RelayWebhookConfig selects the channels where messages of relayed Matrix
+// users, who have no puppet, are posted through an incoming webhook with the
+// user's Matrix name and avatar instead of by the relay account.
+type RelayWebhookConfig struct {
+	// Enabled applies to all channels.
+	Enabled bool `yaml:"enabled"`
+	// Teams overrides Enabled for the channels of a team, keyed by team ID.
+	Teams map[string]bool `yaml:"teams"`
+	// Channels overrides Enabled and Teams for a channel, keyed by channel
+	// ID.
+	Channels map[string]bool `yaml:"channels"`
+}
+
+// enabledFor reports whether a channel in the given team posts relayed
+// messages through a webhook. teamID is empty for DMs and group DMs.
+func (c *RelayWebhookConfig) enabledFor(channelID, teamID string) bool {
+	if enabled, ok := c.Channels[channelID]; ok {
+		return enabled
+	}
+	if enabled, ok := c.Teams[teamID]; ok && teamID != "" {
+		return enabled
+	}
+	return c.Enabled
+}
+
+// webhookEligible reports whether a relayed message can be posted through a
+// webhook: a text message that isn't a reply, since webhooks can't attach
+// files or post in threads.
+func webhookEligible(msg *bridgev2.MatrixMessage) bool {
+	if msg.OrigSender == nil || msg.Event == nil || msg.ReplyTo != nil {
+		return false
+	}
+	switch msg.Content.MsgType {
+	case event.MsgText, event.MsgNotice:
+		return true
+	}
+	return false
+}
+
+// sendWebhookMessage posts a relayed message through the channel's incoming
+// webhook, named and pictured after the Matrix sender. Webhooks override the
+// relay's message format, so the text is converted from the event's original
+// content. It returns errWebhookUnavailable when the message should be
+// posted by the relay account instead.
+func (m *MattermostClient) sendWebhookMessage(ctx context.Context, msg *bridgev2.MatrixMessage) (*bridgev2.MatrixMessageResponse, error) {
+	if !webhookEligible(msg) {
+		return nil, errWebhookUnavailable
+	}
+	original, ok := msg.Event.Content.Parsed.(*event.MessageEventContent)
+	if !ok {
+		original = msg.Content
+	}
+	log := m.log.With().Str("channel_id", ParsePortalID(msg.Portal.ID)).Logger()
+	hookID, err := m.channelWebhook(ctx, msg.Portal)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get channel webhook, posting as relay")
+		return nil, errWebhookUnavailable
+	}
+
+	opts := m.matrixFormatOptions(ctx, msg.Portal, msg.Event.ID)
+	text := matrixfmtParseWithOptions(original, opts)
+	text = m.convertGroupMentions(ctx, msg.Portal, msg.OrigSender, msg.Event, original, text)
+	if sender, ok := forwardedSender(msg.Event); ok {
+		text = m.forwardQuote(ctx, sender, text)
+	}
+	req := &model.IncomingWebhookRequest{
+		Text:     text,
+		Username: msg.OrigSender.DisambiguatedName,
+		IconURL:  m.connector.publicMediaURL(msg.OrigSender.AvatarURL),
+		Props: model.StringInterface{
+			postPropFromBridge:    m.connector.postMarker(),
+			postPropMatrixEventID: msg.Event.ID.String(),
+		},
+	}
+	posted := time.Now()
+	if err := m.postToWebhook(ctx, hookID, req); errors.Is(err, errMattermostUnreachable) {
+		return nil, err
+	} else if err != nil {
+		// The webhook may have been deleted in Mattermost. A new one is
+		// created for the next message.
+		portalMetadata(msg.Portal).WebhookID = ""
+		if saveErr := msg.Portal.Save(ctx); saveErr != nil {
+			log.Warn().Err(saveErr).Msg("Failed to clear channel webhook")
+		}
+		log.Warn().Err(err).Msg("Failed to post through channel webhook, posting as relay")
+		return nil, errWebhookUnavailable
+	}
+
+	m.connector.metrics().messages.inc(directionToMattermost)
+	post, err := m.findWebhookPost(ctx, ParsePortalID(msg.Portal.ID), msg.Event.ID, posted)
+	if err != nil {
+		// The message was posted, and failing it would have it sent again.
+		// Without its post ID, its edits, redactions and replies aren't
+		// bridged.
+		log.Warn().Err(err).Stringer("event_id", msg.Event.ID).Msg("Failed to find webhook post, saving message without it")
+		return &bridgev2.MatrixMessageResponse{}, nil
+	}
+	return &bridgev2.MatrixMessageResponse{
+		DB: &database.Message{
+			ID:       MakeMessageID(post.Id),
+			SenderID: MakeUserID(post.UserId),
+			Metadata: &MessageMetadata{Webhook: true},
+		},
+	}, nil
+}
+
+// webhookEditContent returns the content to edit a post with. Edits of
+// relayed users get the relay's message format like their messages, which
+// posts made through a webhook don't have, so those are edited with the
+// event's original content.
+func webhookEditContent(msg *bridgev2.MatrixEdit) *event.MessageEventContent {
+	if msg.OrigSender == nil || msg.Event == nil || !messageMetadata(msg.EditTarget).Webhook {
+		return msg.Content
+	}
+	if original, ok := msg.Event.Content.Parsed.(*event.MessageEventContent); ok && original.NewContent != nil {
+		return original.NewContent
+	}
+	return msg.Content
+}
+
+// channelWebhook returns the ID of the incoming webhook the bridge posts to
+// in a portal's channel, creating it with the login's account on first use.
+func (m *MattermostClient) channelWebhook(ctx context.Context, portal *bridgev2.Portal) (string, error) {
+	meta := portalMetadata(portal)
+	if meta.WebhookID != "" {
+		return meta.WebhookID, nil
+	}
+	hook, resp, err := m.client.CreateIncomingWebhook(ctx, &model.IncomingWebhook{
+		ChannelId:     ParsePortalID(portal.ID),
+		DisplayName:   webhookDisplayName,
+		Description:   "Posts the messages of Matrix users without a Mattermost account",
+		ChannelLocked: true,
+	})
+	if err != nil {
+		return "", apiError("failed to create incoming webhook", resp, err)
+	}
+	meta.WebhookID = hook.Id
+	if err := portal.Save(ctx); err != nil {
+		return "", fmt.Errorf("failed to save portal: %w", err)
+	}
+	m.log.Info().Str("channel_id", hook.ChannelId).Msg("Created incoming webhook for relayed messages")
+	return hook.Id, nil
+}
+
+// postToWebhook sends a message to an incoming webhook. The webhook URL
+// authorizes the post by itself, so it's never logged.
+func (m *MattermostClient) postToWebhook(ctx context.Context, hookID string, req *model.IncomingWebhookRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook request: %w", err)
+	}
+	hookURL := strings.TrimRight(m.serverURL, "/") + "/hooks/" + hookID
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, hookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpClient := m.client.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		// The URL of the error would leak the webhook ID.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to post to webhook: %w: %w", errMattermostUnreachable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post to webhook: %s", resp.Status)
+	}
+	return nil
+}
+
+// findWebhookPost finds the post a webhook made for a Matrix event among
+// the channel's posts since it was posted to, by its matrix_event_id prop.
+// Searching by time rather than the latest posts keeps a busy channel from
+// burying it.
+func (m *MattermostClient) findWebhookPost(ctx context.Context, channelID string, eventID id.EventID, posted time.Time) (*model.Post, error) {
+	since := posted.Add(-webhookPostLookupSkew).UnixMilli()
+	posts, resp, err := m.client.GetPostsSince(ctx, channelID, since, false)
+	if err != nil {
+		return nil, apiError("failed to find webhook post", resp, err)
+	}
+	for _, postID := range posts.Order {
+		post := posts.Posts[postID]
+		if post != nil && post.GetProp(postPropMatrixEventID) == eventID.String() {
+			return post, nil
+		}
+	}
+	return nil, errWebhookPostNotFound
+}
+
+// publicMediaURL returns the public HTTP URL of Matrix media, or "" when the
+// bridge doesn't serve public media.
+func (mc *MattermostConnector) publicMediaURL(uri id.ContentURIString) string {
+	if uri == "" || mc.Bridge == nil {
+		return ""
+	}
+	if pm, ok := mc.Bridge.Matrix.(bridgev2.MatrixConnectorWithPublicMedia); ok {
+		return pm.GetPublicMediaAddress(uri)
+	}
+	return ""
+}
@@ -0,0 +1,324 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/bridgev2/database"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// publicMediaMatrixConnector is a nopMatrixConnector serving public media.
+type publicMediaMatrixConnector struct {
+	nopMatrixConnector
+}
+
+func (publicMediaMatrixConnector) GetPublicMediaAddress(uri id.ContentURIString) string {
+	return "https://bridge.example.com/media/" + strings.TrimPrefix(string(uri), "mxc://")
+}
+
+func TestRelayWebhookConfig_EnabledFor(t *testing.T) {
+	t.Parallel()
+	cfg := RelayWebhookConfig{
+		Enabled:  true,
+		Teams:    map[string]bool{"t1": false},
+		Channels: map[string]bool{"ch1": true, "ch2": false},
+	}
+	tests := []struct {
+		name      string
+		channelID string
+		teamID    string
+		want      bool
+	}{
+		{"default", "ch3", "t2", true},
+		{"team override", "ch3", "t1", false},
+		{"channel over team", "ch1", "t1", true},
+		{"channel override", "ch2", "t2", false},
+		{"DM", "dm", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfg.enabledFor(tt.channelID, tt.teamID); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookEligible(t *testing.T) {
+	t.Parallel()
+	orig := &bridgev2.OrigSender{UserID: "@stranger:example.com"}
+	text := &event.MessageEventContent{MsgType: event.MsgText, Body: "hi"}
+	tests := []struct {
+		name    string
+		orig    *bridgev2.OrigSender
+		content *event.MessageEventContent
+		replyTo *database.Message
+		want    bool
+	}{
+		{"relayed text", orig, text, nil, true},
+		{"relayed notice", orig, &event.MessageEventContent{MsgType: event.MsgNotice, Body: "hi"}, nil, true},
+		{"not relayed", nil, text, nil, false},
+		{"reply", orig, text, &database.Message{}, false},
+		{"media", orig, &event.MessageEventContent{MsgType: event.MsgImage, Body: "cat.png"}, nil, false},
+		{"emote", orig, &event.MessageEventContent{MsgType: event.MsgEmote, Body: "waves"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			msg := &bridgev2.MatrixMessage{
+				MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+					Event:      &event.Event{ID: "$evt:example.com"},
+					Content:    tt.content,
+					OrigSender: tt.orig,
+				},
+				ReplyTo: tt.replyTo,
+			}
+			if got := webhookEligible(msg); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newWebhookTestClient returns a client whose bridge database holds the
+// portal of ch1, with relay webhooks enabled.
+func newWebhookTestClient(t *testing.T, fake *fakeMM) (*MattermostClient, *bridgev2.Portal) {
+	t.Helper()
+	conn := newRelayTestConnector(t, map[string]*PortalMetadata{"ch1": {TeamID: "t1"}})
+	conn.Bridge.Matrix = publicMediaMatrixConnector{}
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Bridge = conn.Bridge
+	mc.connector.Config.RelayWebhooks = RelayWebhookConfig{Enabled: true}
+	return mc, getRelayTestPortal(t, conn, "ch1")
+}
+
+// relayedMessage returns a message of a relayed user, whose content was
+// relay-formatted by bridgev2 while the event keeps the original.
+func relayedMessage(portal *bridgev2.Portal, body string) *bridgev2.MatrixMessage {
+	original := &event.MessageEventContent{MsgType: event.MsgText, Body: body}
+	return &bridgev2.MatrixMessage{
+		MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+			Event: &event.Event{
+				ID:      "$evt:example.com",
+				Sender:  "@stranger:example.com",
+				Content: event.Content{Parsed: original},
+			},
+			Portal:  portal,
+			Content: &event.MessageEventContent{MsgType: event.MsgText, Body: "Stranger: " + body},
+			OrigSender: &bridgev2.OrigSender{
+				UserID:            "@stranger:example.com",
+				DisambiguatedName: "Stranger",
+				MemberEventContent: event.MemberEventContent{
+					AvatarURL: "mxc://example.com/avatar",
+				},
+			},
+		},
+	}
+}
+
+func TestHandleMatrixMessage_Webhook(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, portal := newWebhookTestClient(t, fake)
+
+	resp, err := mc.HandleMatrixMessage(context.Background(), relayedMessage(portal, "hello"))
+	if err != nil {
+		t.Fatalf("HandleMatrixMessage: %v", err)
+	}
+	if resp.DB.ID != MakeMessageID("webhook-post-id") || !messageMetadata(resp.DB).Webhook {
+		t.Errorf("response = %+v", resp.DB)
+	}
+	if fake.CalledPath("/api/v4/posts") {
+		t.Error("relayed message was also posted by the relay account")
+	}
+	if portalMetadata(portal).WebhookID != "hook-id-ch1" {
+		t.Errorf("portal webhook ID = %q", portalMetadata(portal).WebhookID)
+	}
+
+	var req model.IncomingWebhookRequest
+	for _, c := range fake.Calls() {
+		if c.Method == http.MethodPost && c.Path == "/hooks/hook-id-ch1" {
+			if err := json.Unmarshal([]byte(c.Body), &req); err != nil {
+				t.Fatalf("decode webhook request: %v", err)
+			}
+		}
+	}
+	if req.Text != "hello" || req.Username != "Stranger" || req.IconURL != "https://bridge.example.com/media/example.com/avatar" {
+		t.Errorf("webhook request = %+v", req)
+	}
+	if req.Props[postPropFromBridge] != mc.connector.postMarker() || req.Props[postPropMatrixEventID] != "$evt:example.com" {
+		t.Errorf("webhook props = %v", req.Props)
+	}
+
+	// The webhook is reused for the next message.
+	if _, err := mc.HandleMatrixMessage(context.Background(), relayedMessage(portal, "again")); err != nil {
+		t.Fatalf("second HandleMatrixMessage: %v", err)
+	}
+	created := 0
+	for _, c := range fake.Calls() {
+		if c.Path == "/api/v4/hooks/incoming" {
+			created++
+		}
+	}
+	if created != 1 {
+		t.Errorf("created %d webhooks, want 1", created)
+	}
+}
+
+func TestHandleMatrixMessage_WebhookFallback(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		setup func(*MattermostClient, *fakeMM, *bridgev2.MatrixMessage)
+	}{
+		{
+			name: "disabled for team",
+			setup: func(mc *MattermostClient, _ *fakeMM, _ *bridgev2.MatrixMessage) {
+				mc.connector.Config.RelayWebhooks.Teams = map[string]bool{"t1": false}
+			},
+		},
+		{
+			name: "reply",
+			setup: func(_ *MattermostClient, _ *fakeMM, msg *bridgev2.MatrixMessage) {
+				msg.ReplyTo = &database.Message{ID: MakeMessageID("root")}
+			},
+		},
+		{
+			name: "webhook creation fails",
+			setup: func(_ *MattermostClient, fake *fakeMM, _ *bridgev2.MatrixMessage) {
+				fake.FailEndpoints["/hooks/incoming"] = true
+			},
+		},
+		{
+			name: "webhook deleted",
+			setup: func(_ *MattermostClient, _ *fakeMM, msg *bridgev2.MatrixMessage) {
+				portalMetadata(msg.Portal).WebhookID = "deleted-hook"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := newFakeMM()
+			t.Cleanup(fake.Close)
+			mc, portal := newWebhookTestClient(t, fake)
+			msg := relayedMessage(portal, "hello")
+			tt.setup(mc, fake, msg)
+
+			if _, err := mc.HandleMatrixMessage(context.Background(), msg); err != nil {
+				t.Fatalf("HandleMatrixMessage: %v", err)
+			}
+			if post := lastCreatedPost(t, fake); post.Message != "Stranger: hello" {
+				t.Errorf("relay post message = %q", post.Message)
+			}
+			// A webhook that failed is forgotten, to be recreated.
+			if got := portalMetadata(portal).WebhookID; got != "" {
+				t.Errorf("portal webhook ID = %q, want none", got)
+			}
+		})
+	}
+}
+
+func TestWebhookEditContent(t *testing.T) {
+	t.Parallel()
+	original := &event.MessageEventContent{MsgType: event.MsgText, Body: "* fixed", NewContent: &event.MessageEventContent{MsgType: event.MsgText, Body: "fixed"}}
+	relayFormatted := &event.MessageEventContent{MsgType: event.MsgText, Body: "Stranger: fixed"}
+	edit := func(webhook bool, orig *bridgev2.OrigSender) *bridgev2.MatrixEdit {
+		return &bridgev2.MatrixEdit{
+			MatrixEventBase: bridgev2.MatrixEventBase[*event.MessageEventContent]{
+				Event:      &event.Event{Content: event.Content{Parsed: original}},
+				Content:    relayFormatted,
+				OrigSender: orig,
+			},
+			EditTarget: &database.Message{Metadata: &MessageMetadata{Webhook: webhook}},
+		}
+	}
+	orig := &bridgev2.OrigSender{UserID: "@stranger:example.com"}
+
+	if got := webhookEditContent(edit(true, orig)); got.Body != "fixed" {
+		t.Errorf("webhook post edit body = %q, want the original", got.Body)
+	}
+	if got := webhookEditContent(edit(false, orig)); got.Body != "Stranger: fixed" {
+		t.Errorf("relay post edit body = %q, want the relay format", got.Body)
+	}
+	if got := webhookEditContent(edit(true, nil)); got != relayFormatted {
+		t.Errorf("edit without relay should keep its content, got %q", got.Body)
+	}
+}
+
+func TestPostToWebhook_UnreachableHidesURL(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	mc := newFullTestClient(fake.Server.URL)
+	fake.Close()
+
+	err := mc.postToWebhook(context.Background(), "secret-hook-id", &model.IncomingWebhookRequest{Text: "hi"})
+	if !errors.Is(err, errMattermostUnreachable) {
+		t.Fatalf("got %v, want errMattermostUnreachable", err)
+	}
+	if strings.Contains(err.Error(), "secret-hook-id") || strings.Contains(err.Error(), "/hooks/") {
+		t.Errorf("error leaks the webhook URL: %v", err)
+	}
+}
+
+func TestFindWebhookPost_BusyChannel(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.PaginatePosts = true
+	mc := newFullTestClient(fake.Server.URL)
+	posted := time.Now()
+	posts := model.NewPostList()
+	webhookPost := &model.Post{Id: "webhook-post-id", ChannelId: "ch1", CreateAt: posted.UnixMilli()}
+	webhookPost.AddProp(postPropMatrixEventID, "$evt:example.com")
+	posts.AddPost(webhookPost)
+	posts.AddOrder(webhookPost.Id)
+	// Posts made between the webhook post and the lookup.
+	for i := range 30 {
+		post := &model.Post{Id: fmt.Sprintf("later-%d", i), ChannelId: "ch1", CreateAt: posted.UnixMilli() + int64(i) + 1}
+		posts.AddPost(post)
+		posts.AddOrder(post.Id)
+	}
+	fake.Posts["ch1"] = posts
+
+	post, err := mc.findWebhookPost(context.Background(), "ch1", "$evt:example.com", posted)
+	if err != nil || post.Id != "webhook-post-id" {
+		t.Errorf("got %v, %v, want the webhook post", post, err)
+	}
+}
+
+func TestHandleMatrixMessage_WebhookPostNotFound(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	mc, portal := newWebhookTestClient(t, fake)
+	fake.FailEndpoints["/channels/ch1/posts"] = true
+
+	resp, err := mc.HandleMatrixMessage(context.Background(), relayedMessage(portal, "hello"))
+	if err != nil {
+		t.Fatalf("a message posted through the webhook shouldn't fail: %v", err)
+	}
+	if resp.DB != nil {
+		t.Errorf("response = %+v, want no message to save", resp.DB)
+	}
+	if fake.CalledPath("/api/v4/posts") {
+		t.Error("message was posted again by the relay account")
+	}
+}
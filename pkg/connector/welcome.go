@@ -0,0 +1,126 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/bridgev2"
+	"maunium.net/go/mautrix/event"
+)
+
+// WelcomeParams holds the parameters for rendering the welcome notice
+// template.
+type WelcomeParams struct {
+	ChannelID   string
+	ChannelName string
+	DisplayName string
+	Purpose     string
+	Header      string
+	// Type is the Mattermost channel type: O, P, D or G.
+	Type       string
+	TeamName   string
+	ServerURL  string
+	ChannelURL string
+}
+
+// FormatWelcomeNotice renders the welcome notice template. Returns "" if no
+// template is configured or rendering fails.
+func (c *Config) FormatWelcomeNotice(params WelcomeParams) string {
+	if c.welcomeTemplate == nil {
+		return ""
+	}
+	var buf []byte
+	if err := c.welcomeTemplate.Execute((*templateBuffer)(&buf), params); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(buf))
+}
+
+// welcomeUpdater returns a ChatInfo.ExtraUpdates hook that posts the welcome
+// notice into a newly created portal room, or nil if no notice is configured.
+//
+// bridgev2 has no room-created hook, so this relies on ordering: the hook
+// first runs from CreateMatrixRoom before the room exists, where it marks the
+// welcome as pending in the portal metadata. The next info update with a
+// room (the ChatResync that triggered creation, or a later sync) sends it.
+// Portals that existed before the notice was configured never get one.
+func (m *MattermostClient) welcomeUpdater(channel *model.Channel) bridgev2.ExtraUpdater[*bridgev2.Portal] {
+	if m.connector.Config.welcomeTemplate == nil {
+		return nil
+	}
+	return func(ctx context.Context, portal *bridgev2.Portal) bool {
+		meta := portalMetadata(portal)
+		if portal.MXID == "" {
+			if meta.WelcomePending {
+				return false
+			}
+			meta.WelcomePending = true
+			return true
+		}
+		if !meta.WelcomePending {
+			return false
+		}
+		meta.WelcomePending = false
+		m.sendWelcomeNotice(ctx, portal, channel)
+		return true
+	}
+}
+
+// welcomeParams builds the template parameters for a channel. The team name
+// is looked up for the channel link; DMs use the login's team.
+func (m *MattermostClient) welcomeParams(ctx context.Context, channel *model.Channel) WelcomeParams {
+	params := WelcomeParams{
+		ChannelID:   channel.Id,
+		ChannelName: channel.Name,
+		DisplayName: channel.DisplayName,
+		Purpose:     channel.Purpose,
+		Header:      channel.Header,
+		Type:        string(channel.Type),
+		ServerURL:   strings.TrimRight(m.serverURL, "/"),
+	}
+	teamID := channel.TeamId
+	if teamID == "" {
+		teamID = m.teamID
+	}
+	if teamID != "" && m.client != nil {
+		team, _, err := m.client.GetTeam(ctx, teamID, "")
+		if err != nil {
+			m.log.Debug().Err(err).Str("team_id", teamID).Msg("Failed to get team for welcome notice")
+		} else {
+			params.TeamName = team.Name
+		}
+	}
+	if params.TeamName != "" && params.ServerURL != "" {
+		params.ChannelURL = params.ServerURL + "/" + url.PathEscape(params.TeamName) + "/channels/" + url.PathEscape(channel.Name)
+	}
+	return params
+}
+
+// sendWelcomeNotice renders the welcome template for a channel and sends it
+// to the portal room as a notice from the bridge bot.
+func (m *MattermostClient) sendWelcomeNotice(ctx context.Context, portal *bridgev2.Portal, channel *model.Channel) {
+	text := m.connector.Config.FormatWelcomeNotice(m.welcomeParams(ctx, channel))
+	if text == "" {
+		return
+	}
+	parsed := mattermostfmtParse(text)
+	content := &event.MessageEventContent{
+		MsgType:       event.MsgNotice,
+		Body:          parsed.Body,
+		Format:        parsed.Format,
+		FormattedBody: parsed.FormattedBody,
+	}
+	_, err := portal.Bridge.Bot.SendMessage(ctx, portal.MXID, event.EventMessage, &event.Content{Parsed: content}, nil)
+	if err != nil {
+		m.log.Warn().Err(err).Str("channel_id", channel.Id).Msg("Failed to send welcome notice")
+		return
+	}
+	m.log.Debug().Str("channel_id", channel.Id).Msg("Sent welcome notice")
+}
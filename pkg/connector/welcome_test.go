@@ -0,0 +1,138 @@
+// Copyright 2024-2026 Remi Philippe
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"maunium.net/go/mautrix/event"
+)
+
+func TestConfigPostProcessWelcomeNotice(t *testing.T) {
+	t.Parallel()
+	cfg := Config{WelcomeNotice: "{{.Broken"}
+	if err := cfg.PostProcess(); err == nil {
+		t.Fatal("expected error for invalid welcome_notice template")
+	}
+
+	cfg = Config{WelcomeNotice: "Bridged from **{{.DisplayName}}**{{if .Purpose}}: {{.Purpose}}{{end}}\n"}
+	if err := cfg.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	got := cfg.FormatWelcomeNotice(WelcomeParams{DisplayName: "Town Square", Purpose: "General chat"})
+	if got != "Bridged from **Town Square**: General chat" {
+		t.Errorf("FormatWelcomeNotice: got %q", got)
+	}
+}
+
+func TestFormatWelcomeNotice_Disabled(t *testing.T) {
+	t.Parallel()
+	cfg := Config{}
+	if err := cfg.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	if got := cfg.FormatWelcomeNotice(WelcomeParams{DisplayName: "x"}); got != "" {
+		t.Errorf("expected empty notice without template, got %q", got)
+	}
+}
+
+func TestWelcomeUpdater_Disabled(t *testing.T) {
+	t.Parallel()
+	mc := newTestClient()
+	if mc.welcomeUpdater(&model.Channel{Id: "ch1"}) != nil {
+		t.Error("welcomeUpdater should be nil without a template")
+	}
+	portal := makeTestPortalWithBot("ch1", nil)
+	portal.MXID = "!room:example.com"
+	info := mc.channelToChatInfo(&model.Channel{Id: "ch1", Type: model.ChannelTypeOpen}, nil)
+	info.ExtraUpdates(context.Background(), portal)
+	if sent := portal.Bridge.Bot.(*fakeMatrixBot).Sent(); len(sent) != 0 {
+		t.Errorf("ChatInfo.ExtraUpdates sent %d messages without a template", len(sent))
+	}
+}
+
+func TestWelcomeUpdater_SendsOnceAfterCreation(t *testing.T) {
+	t.Parallel()
+	fake := newFakeMM()
+	t.Cleanup(fake.Close)
+	fake.Teams["my-user-id"] = []*model.Team{{Id: "team1", Name: "eng"}}
+
+	mc := newFullTestClient(fake.Server.URL)
+	mc.connector.Config.WelcomeNotice = "Bridged from [~{{.ChannelName}}]({{.ChannelURL}}). {{.Header}}"
+	if err := mc.connector.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+
+	channel := &model.Channel{Id: "ch1", TeamId: "team1", Name: "town-square", Header: "Welcome!", Type: model.ChannelTypeOpen}
+	update := mc.channelToChatInfo(channel, nil).ExtraUpdates
+	if update == nil {
+		t.Fatal("expected ExtraUpdates hook")
+	}
+
+	portal := makeTestPortalWithBot("ch1", nil)
+	bot := portal.Bridge.Bot.(*fakeMatrixBot)
+	ctx := context.Background()
+
+	// Before the room exists: mark pending, don't send.
+	if !update(ctx, portal) {
+		t.Error("first call should report a metadata change")
+	}
+	if !portalMetadata(portal).WelcomePending {
+		t.Error("welcome should be pending before the room exists")
+	}
+	if update(ctx, portal) {
+		t.Error("repeated call before room creation should not report a change")
+	}
+	if len(bot.Sent()) != 0 {
+		t.Fatal("no notice should be sent before the room exists")
+	}
+
+	// After room creation: send once.
+	portal.MXID = "!room:localhost"
+	if !update(ctx, portal) {
+		t.Error("sending the welcome should report a metadata change")
+	}
+	if update(ctx, portal) {
+		t.Error("welcome should only be sent once")
+	}
+	sent := bot.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 notice, got %d", len(sent))
+	}
+	content := sent[0].Parsed.(*event.MessageEventContent)
+	if content.MsgType != event.MsgNotice {
+		t.Errorf("MsgType: got %q, want m.notice", content.MsgType)
+	}
+	wantURL := fake.Server.URL + "/eng/channels/town-square"
+	if !strings.Contains(content.FormattedBody, `href="`+wantURL+`"`) {
+		t.Errorf("FormattedBody should link to %s, got %q", wantURL, content.FormattedBody)
+	}
+	if !strings.Contains(content.Body, "Welcome!") {
+		t.Errorf("Body should include the header, got %q", content.Body)
+	}
+}
+
+func TestWelcomeUpdater_ExistingPortalNotWelcomed(t *testing.T) {
+	t.Parallel()
+	mc := newTestClient()
+	mc.connector.Config.WelcomeNotice = "hi"
+	if err := mc.connector.Config.PostProcess(); err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+
+	portal := makeTestPortalWithBot("ch1", nil)
+	portal.MXID = "!existing:localhost"
+	update := mc.welcomeUpdater(&model.Channel{Id: "ch1"})
+	if update(context.Background(), portal) {
+		t.Error("existing portal without pending welcome should not change")
+	}
+	if n := len(portal.Bridge.Bot.(*fakeMatrixBot).Sent()); n != 0 {
+		t.Errorf("expected no notice for existing portal, got %d", n)
+	}
+}
@@ -365,33 +365,55 @@ func getMatrixMessages(t *testing.T, roomID string, limit int) []map[string]any
 	return msgs
 }
 
-func joinUserToPortalRoom(t *testing.T, roomID, userMXID string) {
+// portalMembership returns a user's membership in a room ("invite", "join",
+// ...), or "" if the user has none.
+func portalMembership(t *testing.T, roomID, userMXID string) string {
 	t.Helper()
-	bridgeBotMXID := "@mattermostbot:" + domain
-
-	// Bridge bot invites the user
-	inviteBody := map[string]string{"user_id": userMXID}
-	code, resp := doJSON(t, "POST",
-		fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/invite?user_id=%s",
-			synapseURL, roomID, bridgeBotMXID),
-		inviteBody, bridgeASToken)
+	code, resp := doJSON(t, "GET",
+		fmt.Sprintf("%s/_synapse/admin/v1/rooms/%s/state", synapseURL, roomID),
+		nil, synapseAdminToken)
 	if code != 200 {
-		errMsg, _ := resp["error"].(string)
-		if !strings.Contains(errMsg, "already in the room") {
-			t.Logf("invite %s to %s: %d %v", userMXID, roomID, code, resp)
+		t.Fatalf("state %s: %d %v", roomID, code, resp)
+	}
+	state, _ := resp["state"].([]any)
+	for _, raw := range state {
+		evt, _ := raw.(map[string]any)
+		if evt["type"] != "m.room.member" || evt["state_key"] != userMXID {
+			continue
+		}
+		content, _ := evt["content"].(map[string]any)
+		membership, _ := content["membership"].(string)
+		return membership
+	}
+	return ""
+}
+
+// acceptPortalInvite waits for the bridge to invite a user listed in its
+// auto_invite config to a portal room, and joins the room as the user.
+func acceptPortalInvite(t *testing.T, roomID, userMXID string) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		membership := portalMembership(t, roomID, userMXID)
+		if membership == "join" {
+			return
 		}
+		if membership == "invite" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s not invited to %s by auto_invite (membership %q)", userMXID, roomID, membership)
+		}
+		time.Sleep(time.Second)
 	}
 
 	// User joins using bridge AS token (all test users are in bridge namespace)
-	code, resp = doJSON(t, "POST",
+	code, resp := doJSON(t, "POST",
 		fmt.Sprintf("%s/_matrix/client/v3/join/%s?user_id=%s",
 			synapseURL, roomID, userMXID),
 		map[string]string{}, bridgeASToken)
 	if code != 200 {
-		errMsg, _ := resp["error"].(string)
-		if !strings.Contains(errMsg, "already in the room") {
-			t.Fatalf("join %s to %s: %d %v", userMXID, roomID, code, resp)
-		}
+		t.Fatalf("join %s to %s: %d %v", userMXID, roomID, code, resp)
 	}
 }
 
@@ -502,7 +524,7 @@ func requirePortalRoom(t *testing.T, channel string) string {
 func joinAgentToRoom(t *testing.T, slug, roomID string) string {
 	t.Helper()
 	agentMXID := "@" + slug + ":" + domain
-	joinUserToPortalRoom(t, roomID, agentMXID)
+	acceptPortalInvite(t, roomID, agentMXID)
 	return agentMXID
 }
 